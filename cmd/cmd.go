@@ -11,8 +11,10 @@ import (
 	"github.com/charmbracelet/soft-serve/pkg/config"
 	"github.com/charmbracelet/soft-serve/pkg/db"
 	"github.com/charmbracelet/soft-serve/pkg/hooks"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
 	"github.com/charmbracelet/soft-serve/pkg/store"
 	"github.com/charmbracelet/soft-serve/pkg/store/database"
+	"github.com/charmbracelet/soft-serve/pkg/webhook"
 	"github.com/spf13/cobra"
 )
 
@@ -25,6 +27,9 @@ func InitBackendContext(cmd *cobra.Command, _ []string) error {
 			return fmt.Errorf("create data directory: %w", err)
 		}
 	}
+	if err := webhook.Configure(cfg.Outbound); err != nil {
+		return fmt.Errorf("configure outbound connections: %w", err)
+	}
 	dbx, err := db.Open(ctx, cfg.DB.Driver, cfg.DB.DataSource)
 	if err != nil {
 		return fmt.Errorf("open database: %w", err)
@@ -62,7 +67,14 @@ func InitializeHooks(ctx context.Context, cfg *config.Config, be *backend.Backen
 	}
 
 	for _, repo := range repos {
-		if err := hooks.GenerateHooks(ctx, cfg, repo.Name()); err != nil {
+		rp, err := be.RepositoryPath(ctx, repo.Name())
+		if errors.Is(err, proto.ErrRepoNotFound) {
+			// The repository is missing from its volume.
+			continue
+		} else if err != nil {
+			return err
+		}
+		if err := hooks.GenerateHooks(ctx, cfg, rp); err != nil {
 			return err
 		}
 	}
@@ -264,6 +264,11 @@ func (repository) IsPrivate() bool {
 	return false
 }
 
+// IsInternal implements proto.Repository.
+func (repository) IsInternal() bool {
+	return false
+}
+
 // Name implements proto.Repository.
 func (r repository) Name() string {
 	return filepath.Base(r.r.Path)
@@ -97,7 +97,9 @@ var (
 
 			switch cmdName {
 			case hooks.PreReceiveHook:
-				hks.PreReceive(ctx, stdout, stderr, repoName, opts)
+				if err := hks.PreReceive(ctx, stdout, stderr, repoName, opts); err != nil {
+					return err
+				}
 			case hooks.PostReceiveHook:
 				hks.PostReceive(ctx, stdout, stderr, repoName, opts)
 			}
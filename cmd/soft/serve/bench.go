@@ -0,0 +1,231 @@
+package serve
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	benchConcurrency int
+	benchRequests    int
+	benchDuration    time.Duration
+
+	benchCommand = &cobra.Command{
+		Use:   "bench",
+		Short: "Benchmark git transport against a running server",
+		Long: `Benchmark git transport against a running server.
+
+Each subcommand runs git operations concurrently against the given remote URL
+and reports throughput and latency percentiles. The remote can be any URL git
+understands (ssh://, http://, git://). Authentication uses your regular git
+and SSH configuration.`,
+		// The benchmark talks to a running server, it doesn't need the
+		// local database.
+		PersistentPreRunE: func(*cobra.Command, []string) error {
+			if benchConcurrency < 1 {
+				return fmt.Errorf("invalid concurrency %d: must be at least 1", benchConcurrency)
+			}
+			return nil
+		},
+		PersistentPostRunE: func(*cobra.Command, []string) error { return nil },
+	}
+
+	benchCloneCommand = &cobra.Command{
+		Use:   "clone REMOTE",
+		Short: "Benchmark concurrent clones (upload-pack)",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			remote := args[0]
+			tmp, err := os.MkdirTemp("", "soft-serve-bench")
+			if err != nil {
+				return err
+			}
+			defer os.RemoveAll(tmp) //nolint:errcheck
+
+			res := runBench(c.Context(), func(ctx context.Context, worker, n int) error {
+				dir := filepath.Join(tmp, fmt.Sprintf("%d-%d", worker, n))
+				defer os.RemoveAll(dir) //nolint:errcheck
+				return runGit(ctx, "", "clone", "--quiet", "--bare", remote, dir)
+			})
+			res.print(c, "clone")
+			return nil
+		},
+	}
+
+	benchPushCommand = &cobra.Command{
+		Use:   "push REMOTE",
+		Short: "Benchmark concurrent pushes (receive-pack)",
+		Long: `Benchmark concurrent pushes (receive-pack).
+
+Each worker clones the remote once, then repeatedly commits and force pushes
+to its own bench/<worker> branch. The branches are deleted when the benchmark
+is done.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			ctx := c.Context()
+			remote := args[0]
+			tmp, err := os.MkdirTemp("", "soft-serve-bench")
+			if err != nil {
+				return err
+			}
+			defer os.RemoveAll(tmp) //nolint:errcheck
+
+			// Prepare a working copy for each worker.
+			for w := 0; w < benchConcurrency; w++ {
+				dir := filepath.Join(tmp, fmt.Sprintf("%d", w))
+				if err := runGit(ctx, "", "clone", "--quiet", remote, dir); err != nil {
+					return fmt.Errorf("clone %s: %w", remote, err)
+				}
+			}
+
+			res := runBench(ctx, func(ctx context.Context, worker, n int) error {
+				dir := filepath.Join(tmp, fmt.Sprintf("%d", worker))
+				if err := runGit(ctx, dir,
+					"-c", "user.name=soft-serve-bench",
+					"-c", "user.email=bench@soft-serve",
+					"commit", "--quiet", "--allow-empty", "-m", fmt.Sprintf("bench %d-%d", worker, n)); err != nil {
+					return err
+				}
+				return runGit(ctx, dir, "push", "--quiet", "--force", "origin", fmt.Sprintf("HEAD:refs/heads/bench/%d", worker))
+			})
+			res.print(c, "push")
+
+			// Clean up the bench branches.
+			dir := filepath.Join(tmp, "0")
+			refs := make([]string, 0, benchConcurrency)
+			for w := 0; w < benchConcurrency; w++ {
+				refs = append(refs, fmt.Sprintf("refs/heads/bench/%d", w))
+			}
+			if err := runGit(context.Background(), dir, append([]string{"push", "--quiet", "--delete", "origin"}, refs...)...); err != nil {
+				c.PrintErrf("failed to delete bench branches: %v\n", err)
+			}
+
+			return nil
+		},
+	}
+)
+
+func init() {
+	benchCommand.PersistentFlags().IntVarP(&benchConcurrency, "concurrency", "c", 4, "number of concurrent workers")
+	benchCommand.PersistentFlags().IntVarP(&benchRequests, "requests", "n", 100, "total number of operations to run")
+	benchCommand.PersistentFlags().DurationVarP(&benchDuration, "duration", "d", 0, "stop after this duration (0 means no limit)")
+	benchCommand.AddCommand(benchCloneCommand, benchPushCommand)
+	Command.AddCommand(benchCommand)
+}
+
+// benchResult holds the outcome of a benchmark run.
+type benchResult struct {
+	elapsed   time.Duration
+	latencies []time.Duration
+	errors    int
+	lastErr   error
+}
+
+// runBench runs op with benchConcurrency workers until benchRequests
+// operations have run or benchDuration has elapsed.
+func runBench(ctx context.Context, op func(ctx context.Context, worker, n int) error) *benchResult {
+	if benchDuration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, benchDuration)
+		defer cancel()
+	}
+
+	jobs := make(chan int)
+	go func() {
+		defer close(jobs)
+		for n := 0; n < benchRequests; n++ {
+			select {
+			case jobs <- n:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	res := &benchResult{}
+	start := time.Now()
+	for w := 0; w < benchConcurrency; w++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for n := range jobs {
+				t := time.Now()
+				err := op(ctx, worker, n)
+				d := time.Since(t)
+
+				mu.Lock()
+				if err != nil {
+					if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+						res.errors++
+						res.lastErr = err
+					}
+				} else {
+					res.latencies = append(res.latencies, d)
+				}
+				mu.Unlock()
+			}
+		}(w)
+	}
+	wg.Wait()
+	res.elapsed = time.Since(start)
+
+	return res
+}
+
+// percentile returns the p-th percentile of the sorted latencies.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	i := int(float64(len(sorted)-1) * p / 100)
+	return sorted[i]
+}
+
+func (r *benchResult) print(c *cobra.Command, name string) {
+	sort.Slice(r.latencies, func(i, j int) bool { return r.latencies[i] < r.latencies[j] })
+	ok := len(r.latencies)
+
+	c.Printf("Operation:   %s\n", name)
+	c.Printf("Concurrency: %d\n", benchConcurrency)
+	c.Printf("Completed:   %d\n", ok)
+	c.Printf("Failed:      %d\n", r.errors)
+	c.Printf("Elapsed:     %s\n", r.elapsed.Round(time.Millisecond))
+	if r.elapsed > 0 {
+		c.Printf("Throughput:  %.2f ops/s\n", float64(ok)/r.elapsed.Seconds())
+	}
+	if ok > 0 {
+		c.Printf("Latency:     p50=%s p90=%s p99=%s max=%s\n",
+			percentile(r.latencies, 50).Round(time.Millisecond),
+			percentile(r.latencies, 90).Round(time.Millisecond),
+			percentile(r.latencies, 99).Round(time.Millisecond),
+			r.latencies[ok-1].Round(time.Millisecond),
+		)
+	}
+	if r.lastErr != nil {
+		c.Printf("Last error:  %v\n", r.lastErr)
+	}
+}
+
+// runGit runs a git command in dir. On failure, the command output is
+// included in the error.
+func runGit(ctx context.Context, dir string, args ...string) error {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("git: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package serve
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestPercentile(t *testing.T) {
+	sorted := make([]time.Duration, 100)
+	for i := range sorted {
+		sorted[i] = time.Duration(i+1) * time.Millisecond
+	}
+
+	cases := []struct {
+		in   []time.Duration
+		p    float64
+		want time.Duration
+	}{
+		{nil, 50, 0},
+		{[]time.Duration{time.Second}, 99, time.Second},
+		{sorted, 0, time.Millisecond},
+		{sorted, 50, 50 * time.Millisecond},
+		{sorted, 90, 90 * time.Millisecond},
+		{sorted, 99, 99 * time.Millisecond},
+		{sorted, 100, 100 * time.Millisecond},
+	}
+
+	for _, c := range cases {
+		if got := percentile(c.in, c.p); got != c.want {
+			t.Errorf("percentile(%d latencies, %v) => %s, want %s", len(c.in), c.p, got, c.want)
+		}
+	}
+}
+
+func setBenchFlags(t *testing.T, concurrency, requests int, duration time.Duration) {
+	t.Helper()
+	c, n, d := benchConcurrency, benchRequests, benchDuration
+	t.Cleanup(func() { benchConcurrency, benchRequests, benchDuration = c, n, d })
+	benchConcurrency, benchRequests, benchDuration = concurrency, requests, duration
+}
+
+func TestBenchConcurrency(t *testing.T) {
+	for _, c := range []int{0, -1} {
+		setBenchFlags(t, c, 10, 0)
+		if err := benchCommand.PersistentPreRunE(benchCommand, nil); err == nil {
+			t.Errorf("concurrency %d: expected an error", c)
+		}
+	}
+
+	setBenchFlags(t, 1, 10, 0)
+	if err := benchCommand.PersistentPreRunE(benchCommand, nil); err != nil {
+		t.Errorf("concurrency 1: unexpected error: %v", err)
+	}
+}
+
+func TestRunBench(t *testing.T) {
+	setBenchFlags(t, 3, 10, 0)
+
+	errFail := errors.New("fail")
+	var mu sync.Mutex
+	workers := map[int]bool{}
+	ops := map[int]bool{}
+	res := runBench(context.Background(), func(_ context.Context, worker, n int) error {
+		mu.Lock()
+		workers[worker] = true
+		ops[n] = true
+		mu.Unlock()
+		if n%5 == 0 {
+			return errFail
+		}
+		return nil
+	})
+
+	if len(ops) != 10 {
+		t.Errorf("ran %d operations, want 10", len(ops))
+	}
+	for w := range workers {
+		if w < 0 || w >= 3 {
+			t.Errorf("unexpected worker %d", w)
+		}
+	}
+	if len(res.latencies) != 8 {
+		t.Errorf("completed %d operations, want 8", len(res.latencies))
+	}
+	if res.errors != 2 {
+		t.Errorf("failed %d operations, want 2", res.errors)
+	}
+	if !errors.Is(res.lastErr, errFail) {
+		t.Errorf("last error %v, want %v", res.lastErr, errFail)
+	}
+}
+
+func TestRunBenchDuration(t *testing.T) {
+	setBenchFlags(t, 2, 1000000, 50*time.Millisecond)
+
+	res := runBench(context.Background(), func(ctx context.Context, _, _ int) error {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Millisecond):
+			return nil
+		}
+	})
+
+	if res.errors != 0 {
+		t.Errorf("operations cut short by the deadline counted as failures: %d", res.errors)
+	}
+	if len(res.latencies) == 0 || len(res.latencies) >= benchRequests {
+		t.Errorf("completed %d operations, want the deadline to stop the run", len(res.latencies))
+	}
+}
@@ -0,0 +1,305 @@
+package serve
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/git"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/migrate"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/spf13/cobra"
+)
+
+var (
+	seedRepos   int
+	seedIssues  int
+	seedMRs     int
+	seedCommits int
+	seedPrefix  string
+	seedSeed    int64
+
+	seedCommand = &cobra.Command{
+		Use:   "seed",
+		Short: "Generate demo data",
+		Long: `Generate demo repositories with history, issues, and merge requests.
+
+Issues and merge requests are spread across the generated repositories. The
+generated data is deterministic for a given --seed.`,
+		Args: cobra.NoArgs,
+		RunE: func(c *cobra.Command, _ []string) error {
+			ctx := c.Context()
+			if err := migrate.Migrate(ctx, db.FromContext(ctx)); err != nil {
+				return fmt.Errorf("migration error: %w", err)
+			}
+
+			s := &seeder{
+				be:  backend.FromContext(ctx),
+				rnd: rand.New(rand.NewSource(seedSeed)), //nolint:gosec
+				now: time.Now(),
+			}
+
+			return s.run(ctx, c)
+		},
+	}
+)
+
+func init() {
+	seedCommand.Flags().IntVar(&seedRepos, "repos", 5, "number of repositories to create")
+	seedCommand.Flags().IntVar(&seedIssues, "issues", 200, "total number of issues to create")
+	seedCommand.Flags().IntVar(&seedMRs, "mrs", 50, "total number of merge requests to create")
+	seedCommand.Flags().IntVar(&seedCommits, "commits", 20, "number of commits on each repository default branch")
+	seedCommand.Flags().StringVar(&seedPrefix, "prefix", "demo", "prefix of the generated repository names")
+	seedCommand.Flags().Int64Var(&seedSeed, "seed", 1, "random seed")
+	Command.AddCommand(seedCommand)
+}
+
+var (
+	seedUsernames = []string{"alex", "sam", "robin", "jordan", "casey", "morgan"}
+	seedVerbs     = []string{"Fix", "Add", "Improve", "Refactor", "Document", "Remove", "Speed up", "Handle errors in"}
+	seedSubjects  = []string{
+		"login flow", "config parser", "repository listing", "webhook delivery",
+		"README", "SSH key handling", "LFS uploads", "branch protection",
+		"search results", "markdown rendering", "access tokens", "mirror sync",
+	}
+	seedParagraphs = []string{
+		"This happens consistently when the repository has more than a handful of branches.",
+		"Steps to reproduce are in the linked discussion; it only shows up on a fresh install.",
+		"We should add a test covering this once the fix lands.",
+		"The current behavior is confusing for new users and the docs don't mention it.",
+		"Happy to pick this up if nobody else is working on it.",
+		"This would also simplify the follow-up work on caching.",
+	}
+	seedCloseReasons = []models.IssueCloseReason{
+		models.IssueCloseReasonCompleted, models.IssueCloseReasonCompleted,
+		models.IssueCloseReasonNotPlanned, models.IssueCloseReasonDuplicate,
+	}
+)
+
+// seeder generates demo data.
+type seeder struct {
+	be    *backend.Backend
+	rnd   *rand.Rand
+	now   time.Time
+	users []proto.User
+}
+
+func (s *seeder) run(ctx context.Context, c *cobra.Command) error {
+	if seedRepos <= 0 {
+		return errors.New("--repos must be positive")
+	}
+
+	for _, username := range seedUsernames {
+		user, err := s.be.User(ctx, username)
+		if errors.Is(err, proto.ErrUserNotFound) {
+			user, err = s.be.CreateUser(ctx, username, proto.UserOptions{})
+		}
+		if err != nil {
+			return fmt.Errorf("create user %q: %w", username, err)
+		}
+		s.users = append(s.users, user)
+	}
+
+	for i := 0; i < seedRepos; i++ {
+		name := fmt.Sprintf("%s-%d", seedPrefix, i+1)
+		issues := share(seedIssues, seedRepos, i)
+		mrs := share(seedMRs, seedRepos, i)
+		if err := s.seedRepo(ctx, name, issues, mrs); err != nil {
+			return fmt.Errorf("seed %q: %w", name, err)
+		}
+		c.Printf("Created %s with %d issues and %d merge requests\n", name, issues, mrs)
+	}
+
+	return nil
+}
+
+// share returns the part of total assigned to the i-th of n buckets.
+func share(total, n, i int) int {
+	v := total / n
+	if i < total%n {
+		v++
+	}
+	return v
+}
+
+func (s *seeder) user() proto.User {
+	return s.users[s.rnd.Intn(len(s.users))]
+}
+
+func (s *seeder) title() string {
+	return seedVerbs[s.rnd.Intn(len(seedVerbs))] + " " + seedSubjects[s.rnd.Intn(len(seedSubjects))]
+}
+
+func (s *seeder) description() string {
+	n := 1 + s.rnd.Intn(3)
+	paras := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		paras = append(paras, seedParagraphs[s.rnd.Intn(len(seedParagraphs))])
+	}
+	return strings.Join(paras, "\n\n")
+}
+
+func (s *seeder) seedRepo(ctx context.Context, name string, issues, mrs int) error {
+	owner := s.user()
+	r, err := s.be.CreateRepository(ctx, name, owner, proto.RepositoryOptions{
+		ProjectName: strings.ToUpper(name[:1]) + name[1:],
+		Description: "Demo repository generated by soft serve seed",
+	})
+	if err != nil {
+		return err
+	}
+
+	gr, err := r.Open()
+	if err != nil {
+		return err
+	}
+
+	out, err := git.NewCommand("symbolic-ref", "--short", "HEAD").RunInDir(gr.Path)
+	if err != nil {
+		return err
+	}
+	defaultBranch := strings.TrimSpace(string(out))
+
+	// Default branch history.
+	when := s.now.Add(-time.Duration(seedCommits+mrs) * time.Hour)
+	if err := s.commit(gr.Path, defaultBranch, "", "README.md",
+		fmt.Sprintf("# %s\n\nA demo repository.\n", name), "Initial commit", when); err != nil {
+		return err
+	}
+	for i := 1; i < seedCommits; i++ {
+		when = when.Add(time.Hour)
+		file := fmt.Sprintf("src/file%d.go", s.rnd.Intn(5))
+		content := fmt.Sprintf("package src\n\n// Revision %d.\n", i)
+		if err := s.commit(gr.Path, defaultBranch, "", file, content, s.title(), when); err != nil {
+			return err
+		}
+	}
+
+	// Issues, some closed and some depending on earlier issues.
+	var issueIDs []int64
+	for i := 0; i < issues; i++ {
+		uctx := proto.WithUserContext(ctx, s.user())
+		id, err := s.be.CreateIssue(uctx, name, s.title(), s.description())
+		if err != nil {
+			return err
+		}
+		if len(issueIDs) > 0 && s.rnd.Intn(5) == 0 {
+			dep := issueIDs[s.rnd.Intn(len(issueIDs))]
+			if err := s.be.AddIssueDependency(uctx, name, id, dep); err != nil {
+				return err
+			}
+		}
+		if s.rnd.Intn(3) == 0 {
+			reason := seedCloseReasons[s.rnd.Intn(len(seedCloseReasons))]
+			if err := s.be.CloseIssue(uctx, name, id, reason); err != nil {
+				return err
+			}
+		}
+		issueIDs = append(issueIDs, id)
+	}
+
+	// Merge requests, each from its own branch with a commit.
+	for i := 0; i < mrs; i++ {
+		when = when.Add(time.Hour)
+		branch := fmt.Sprintf("feature/%d", i+1)
+		title := s.title()
+		content := fmt.Sprintf("package src\n\n// %s.\n", title)
+		if err := s.commit(gr.Path, branch, defaultBranch, fmt.Sprintf("src/feature%d.go", i+1), content, title, when); err != nil {
+			return err
+		}
+
+		uctx := proto.WithUserContext(ctx, s.user())
+		id, err := s.be.CreateMergeRequest(uctx, name, title, s.description(), branch, defaultBranch)
+		if err != nil {
+			return err
+		}
+		if s.rnd.Intn(4) == 0 {
+			if err := s.be.CloseMergeRequest(uctx, name, id); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// commit writes file to branch in the bare repository at path and commits it
+// without touching the repository hooks. If the branch does not exist, it
+// starts from base, or from an empty tree when base is empty.
+func (s *seeder) commit(path, branch, base, file, content, message string, when time.Time) error {
+	tmp, err := os.MkdirTemp("", "soft-serve-seed")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp) //nolint:errcheck
+
+	fp := filepath.Join(tmp, filepath.FromSlash(file))
+	if err := os.MkdirAll(filepath.Dir(fp), os.ModePerm); err != nil {
+		return err
+	}
+	if err := os.WriteFile(fp, []byte(content), 0o600); err != nil {
+		return err
+	}
+
+	author := s.user()
+	date := when.Format(time.RFC3339)
+	envs := []string{
+		"GIT_INDEX_FILE=" + filepath.Join(tmp, ".index"),
+		"GIT_AUTHOR_NAME=" + author.Username(),
+		"GIT_AUTHOR_EMAIL=" + author.Username() + "@example.com",
+		"GIT_AUTHOR_DATE=" + date,
+		"GIT_COMMITTER_NAME=" + author.Username(),
+		"GIT_COMMITTER_EMAIL=" + author.Username() + "@example.com",
+		"GIT_COMMITTER_DATE=" + date,
+	}
+	run := func(args ...string) (string, error) {
+		out, err := git.NewCommand(args...).AddEnvs(envs...).RunInDir(path)
+		return strings.TrimSpace(string(out)), err
+	}
+
+	parent, err := run("rev-parse", "--verify", "--quiet", "refs/heads/"+branch)
+	if err != nil && base != "" {
+		parent, err = run("rev-parse", "--verify", "--quiet", "refs/heads/"+base)
+	}
+	if err != nil {
+		parent = ""
+	}
+
+	if parent != "" {
+		_, err = run("read-tree", parent)
+	} else {
+		_, err = run("read-tree", "--empty")
+	}
+	if err != nil {
+		return err
+	}
+
+	if _, err := run("--work-tree="+tmp, "add", file); err != nil {
+		return err
+	}
+
+	tree, err := run("write-tree")
+	if err != nil {
+		return err
+	}
+
+	args := []string{"commit-tree", tree, "-m", message}
+	if parent != "" {
+		args = append(args, "-p", parent)
+	}
+	sha, err := run(args...)
+	if err != nil {
+		return err
+	}
+
+	_, err = run("update-ref", "refs/heads/"+branch, sha)
+	return err
+}
@@ -231,6 +231,24 @@ func printStats(stats FileStats) string {
 type Diff struct {
 	*git.Diff
 	Files []*DiffFile
+
+	// repo is used to read the files rendered with a DiffRenderer.
+	repo *Repository
+}
+
+// Size returns the number of bytes of the changed lines of the diff, an
+// estimate of the memory it takes.
+func (d *Diff) Size() int {
+	var n int
+	for _, f := range d.Files {
+		n += len(f.Name)
+		for _, s := range f.Sections {
+			for _, l := range s.Lines {
+				n += len(l.Content)
+			}
+		}
+	}
+	return n
 }
 
 // FileStats returns the diff file stats.
@@ -323,6 +341,10 @@ func (d *Diff) Patch() string {
 	var p strings.Builder
 	for _, f := range d.Files {
 		writeFilePatchHeader(&p, f)
+		if out, ok := d.renderFileDiff(f); ok {
+			p.WriteString(out)
+			continue
+		}
 		for _, s := range f.Sections {
 			for _, l := range s.Lines {
 				p.WriteString(s.diffFor(l))
@@ -333,7 +355,7 @@ func (d *Diff) Patch() string {
 	return p.String()
 }
 
-func toDiff(ddiff *git.Diff) *Diff {
+func toDiff(r *Repository, ddiff *git.Diff) *Diff {
 	files := make([]*DiffFile, 0, len(ddiff.Files))
 	for _, df := range ddiff.Files {
 		sections := make([]*DiffSection, 0, len(df.Sections))
@@ -350,6 +372,7 @@ func toDiff(ddiff *git.Diff) *Diff {
 	diff := &Diff{
 		Diff:  ddiff,
 		Files: files,
+		repo:  r,
 	}
 	return diff
 }
@@ -0,0 +1,225 @@
+package git
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"image"
+	_ "image/gif"  // register GIF images
+	_ "image/jpeg" // register JPEG images
+	_ "image/png"  // register PNG images
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/dustin/go-humanize"
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+// DiffRenderer renders the changes of a file in place of its raw patch. from
+// and to are the contents of the file before and after the change, nil when
+// the file is added or deleted.
+type DiffRenderer interface {
+	RenderDiff(from, to []byte) (string, error)
+}
+
+// DiffRendererFunc is a function that implements DiffRenderer.
+type DiffRendererFunc func(from, to []byte) (string, error)
+
+// RenderDiff implements DiffRenderer.
+func (f DiffRendererFunc) RenderDiff(from, to []byte) (string, error) {
+	return f(from, to)
+}
+
+var (
+	diffRenderersMu sync.RWMutex
+	diffRenderers   = map[string]DiffRenderer{
+		".gif":   DiffRendererFunc(renderImageDiff),
+		".jpeg":  DiffRendererFunc(renderImageDiff),
+		".jpg":   DiffRendererFunc(renderImageDiff),
+		".png":   DiffRendererFunc(renderImageDiff),
+		".ipynb": DiffRendererFunc(renderNotebookDiff),
+	}
+)
+
+// RegisterDiffRenderer registers a diff renderer for the files with the given
+// extension, e.g. ".png". It replaces any renderer registered for ext, and a
+// nil renderer falls back to the raw patch.
+func RegisterDiffRenderer(ext string, r DiffRenderer) {
+	diffRenderersMu.Lock()
+	defer diffRenderersMu.Unlock()
+	ext = strings.ToLower(ext)
+	if r == nil {
+		delete(diffRenderers, ext)
+		return
+	}
+	diffRenderers[ext] = r
+}
+
+// diffRendererFor returns the diff renderer of the given file, if any.
+func diffRendererFor(name string) DiffRenderer {
+	diffRenderersMu.RLock()
+	defer diffRenderersMu.RUnlock()
+	return diffRenderers[strings.ToLower(filepath.Ext(name))]
+}
+
+// renderFileDiff renders the changes of a file with the renderer of its file
+// type. It returns false if there is none or it fails.
+func (d *Diff) renderFileDiff(f *DiffFile) (string, bool) {
+	if d.repo == nil {
+		return "", false
+	}
+	from, to := f.Files()
+	name := f.Name
+	if to == nil && from != nil {
+		name = from.Name()
+	}
+	r := diffRendererFor(name)
+	if r == nil {
+		return "", false
+	}
+
+	var err error
+	var fromData, toData []byte
+	if from != nil {
+		if fromData, err = d.repo.blob(from.Hash()); err != nil {
+			return "", false
+		}
+	}
+	if to != nil {
+		if toData, err = d.repo.blob(to.Hash()); err != nil {
+			return "", false
+		}
+	}
+
+	out, err := r.RenderDiff(fromData, toData)
+	if err != nil {
+		return "", false
+	}
+	return out, true
+}
+
+// blob returns the contents of the blob with the given hash.
+func (r *Repository) blob(hash string) ([]byte, error) {
+	b, err := r.CatFileBlob(hash)
+	if err != nil {
+		return nil, err
+	}
+	return b.Bytes()
+}
+
+// renderImageDiff summarizes the dimensions and size changes of an image.
+func renderImageDiff(from, to []byte) (string, error) {
+	describe := func(data []byte) (string, error) {
+		cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%dx%d, %s", cfg.Width, cfg.Height, humanize.Bytes(uint64(len(data)))), nil
+	}
+
+	switch {
+	case from == nil && to == nil:
+		return "", nil
+	case from == nil:
+		desc, err := describe(to)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Image added: %s\n", desc), nil
+	case to == nil:
+		desc, err := describe(from)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Image deleted: %s\n", desc), nil
+	}
+
+	fromDesc, err := describe(from)
+	if err != nil {
+		return "", err
+	}
+	toDesc, err := describe(to)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("Image changed: %s → %s\n", fromDesc, toDesc), nil
+}
+
+// notebook is the part of a Jupyter notebook that's worth diffing.
+type notebook struct {
+	Cells []struct {
+		CellType string          `json:"cell_type"`
+		Source   json.RawMessage `json:"source"`
+	} `json:"cells"`
+}
+
+// notebookText returns the cells of a notebook as text, without their outputs
+// and execution counts.
+func notebookText(data []byte) (string, error) {
+	if data == nil {
+		return "", nil
+	}
+	var nb notebook
+	if err := json.Unmarshal(data, &nb); err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	for i, cell := range nb.Cells {
+		// The source is either a string or a list of lines.
+		var source string
+		var lines []string
+		if err := json.Unmarshal(cell.Source, &lines); err == nil {
+			source = strings.Join(lines, "")
+		} else if err := json.Unmarshal(cell.Source, &source); err != nil {
+			return "", err
+		}
+		fmt.Fprintf(&sb, "# In [%d] (%s)\n", i+1, cell.CellType)
+		sb.WriteString(source)
+		if !strings.HasSuffix(source, "\n") {
+			sb.WriteString("\n")
+		}
+	}
+	return sb.String(), nil
+}
+
+// renderNotebookDiff diffs the cells of a Jupyter notebook with their outputs
+// stripped.
+func renderNotebookDiff(from, to []byte) (string, error) {
+	fromText, err := notebookText(from)
+	if err != nil {
+		return "", err
+	}
+	toText, err := notebookText(to)
+	if err != nil {
+		return "", err
+	}
+	if fromText == toText {
+		return "Notebook outputs changed\n", nil
+	}
+
+	dmp := diffmatchpatch.New()
+	a, b, lines := dmp.DiffLinesToChars(fromText, toText)
+	diffs := dmp.DiffCharsToLines(dmp.DiffMain(a, b, false), lines)
+
+	var sb strings.Builder
+	for _, d := range diffs {
+		prefix := " "
+		switch d.Type {
+		case diffmatchpatch.DiffInsert:
+			prefix = "+"
+		case diffmatchpatch.DiffDelete:
+			prefix = "-"
+		}
+		for _, line := range strings.SplitAfter(d.Text, "\n") {
+			if line == "" {
+				continue
+			}
+			sb.WriteString(prefix)
+			sb.WriteString(strings.TrimSuffix(line, "\n"))
+			sb.WriteString("\n")
+		}
+	}
+	return sb.String(), nil
+}
@@ -0,0 +1,81 @@
+package git
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func encodePNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewGray(image.Rect(0, 0, width, height))); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestRenderImageDiff(t *testing.T) {
+	is := is.New(t)
+	small := encodePNG(t, 2, 3)
+	large := encodePNG(t, 40, 30)
+
+	out, err := renderImageDiff(small, large)
+	is.NoErr(err)
+	is.True(strings.Contains(out, "Image changed: 2x3, "))
+	is.True(strings.Contains(out, "→ 40x30, "))
+
+	out, err = renderImageDiff(nil, small)
+	is.NoErr(err)
+	is.True(strings.HasPrefix(out, "Image added: 2x3, "))
+
+	_, err = renderImageDiff([]byte("not an image"), small)
+	is.True(err != nil)
+}
+
+func TestRenderNotebookDiff(t *testing.T) {
+	cases := []struct {
+		name string
+		from string
+		to   string
+		want string
+	}{
+		{
+			name: "source change",
+			from: `{"cells":[{"cell_type":"code","source":["x = 1\n","print(x)"],"outputs":[{"text":"1"}]}]}`,
+			to:   `{"cells":[{"cell_type":"code","source":["x = 2\n","print(x)"],"outputs":[{"text":"2"}]}]}`,
+			want: " # In [1] (code)\n-x = 1\n+x = 2\n print(x)\n",
+		},
+		{
+			name: "outputs only",
+			from: `{"cells":[{"cell_type":"code","source":"x = 1","execution_count":1,"outputs":[]}]}`,
+			to:   `{"cells":[{"cell_type":"code","source":"x = 1","execution_count":2,"outputs":[{"text":"1"}]}]}`,
+			want: "Notebook outputs changed\n",
+		},
+		{
+			name: "added",
+			to:   `{"cells":[{"cell_type":"markdown","source":"# Title"}]}`,
+			want: "+# In [1] (markdown)\n+# Title\n",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			is := is.New(t)
+			var from, to []byte
+			if c.from != "" {
+				from = []byte(c.from)
+			}
+			if c.to != "" {
+				to = []byte(c.to)
+			}
+			out, err := renderNotebookDiff(from, to)
+			is.NoErr(err)
+			is.Equal(out, c.want)
+		})
+	}
+}
@@ -138,17 +138,39 @@ func (r *Repository) TreePath(ref *Reference, path string) (*Tree, error) {
 	return t.SubTree(path)
 }
 
-// Diff returns the diff for the given commit.
-func (r *Repository) Diff(commit *Commit) (*Diff, error) {
-	diff, err := r.Repository.Diff(commit.ID.String(), DiffMaxFiles, DiffMaxFileLines, DiffMaxLineChars, git.DiffOptions{
+// DiffOptions are options for computing diffs.
+type DiffOptions struct {
+	// Base is the revision to diff against. Diffs are against the first
+	// parent of the commit when empty.
+	Base string
+	// IgnoreWhitespace ignores whitespace changes, like git diff -w.
+	IgnoreWhitespace bool
+}
+
+// diffOptions returns the git-module options matching opts.
+func diffOptions(opts []DiffOptions) git.DiffOptions {
+	o := git.DiffOptions{
 		CommandOptions: git.CommandOptions{
 			Envs: []string{"GIT_CONFIG_GLOBAL=/dev/null"},
 		},
-	})
+	}
+	if len(opts) == 0 {
+		return o
+	}
+	o.Base = opts[0].Base
+	if opts[0].IgnoreWhitespace {
+		o.Args = append(o.Args, "-w")
+	}
+	return o
+}
+
+// Diff returns the diff for the given commit.
+func (r *Repository) Diff(commit *Commit, opts ...DiffOptions) (*Diff, error) {
+	diff, err := r.Repository.Diff(commit.ID.String(), DiffMaxFiles, DiffMaxFileLines, DiffMaxLineChars, diffOptions(opts))
 	if err != nil {
 		return nil, err
 	}
-	return toDiff(diff), nil
+	return toDiff(r, diff), nil
 }
 
 // Patch returns the patch for the given reference.
@@ -1,16 +1,10 @@
 package git
 
-import "github.com/aymanbagabas/git-module"
-
 // StashDiff returns the diff of the given stash index.
-func (r *Repository) StashDiff(index int) (*Diff, error) {
-	diff, err := r.Repository.StashDiff(index, DiffMaxFiles, DiffMaxFileLines, DiffMaxLineChars, git.DiffOptions{
-		CommandOptions: git.CommandOptions{
-			Envs: []string{"GIT_CONFIG_GLOBAL=/dev/null"},
-		},
-	})
+func (r *Repository) StashDiff(index int, opts ...DiffOptions) (*Diff, error) {
+	diff, err := r.Repository.StashDiff(index, DiffMaxFiles, DiffMaxFileLines, DiffMaxLineChars, diffOptions(opts))
 	if err != nil {
 		return nil, err
 	}
-	return toDiff(diff), nil
+	return toDiff(r, diff), nil
 }
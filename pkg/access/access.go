@@ -12,6 +12,10 @@ const (
 	// NoAccess does not allow access to the repo.
 	NoAccess AccessLevel = iota
 
+	// GuestAccess allows seeing the issues and merge requests of the repo,
+	// and opening issues, without access to its code.
+	GuestAccess
+
 	// ReadOnlyAccess allows read-only access to the repo.
 	ReadOnlyAccess
 
@@ -27,6 +31,8 @@ func (a AccessLevel) String() string {
 	switch a {
 	case NoAccess:
 		return "no-access"
+	case GuestAccess:
+		return "guest"
 	case ReadOnlyAccess:
 		return "read-only"
 	case ReadWriteAccess:
@@ -43,6 +49,8 @@ func ParseAccessLevel(s string) AccessLevel {
 	switch s {
 	case "no-access":
 		return NoAccess
+	case "guest":
+		return GuestAccess
 	case "read-only":
 		return ReadOnlyAccess
 	case "read-write":
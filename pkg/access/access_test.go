@@ -13,6 +13,7 @@ func TestParseAccessLevel(t *testing.T) {
 		{ReadOnlyAccess.String(), ReadOnlyAccess},
 		{ReadWriteAccess.String(), ReadWriteAccess},
 		{NoAccess.String(), NoAccess},
+		{GuestAccess.String(), GuestAccess},
 	}
 
 	for _, c := range cases {
@@ -3,25 +3,74 @@ package backend
 import (
 	"context"
 	"errors"
+	"net"
+	"slices"
 	"time"
 
+	"github.com/charmbracelet/soft-serve/pkg/access"
 	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
 	"github.com/charmbracelet/soft-serve/pkg/proto"
 	"github.com/charmbracelet/soft-serve/pkg/utils"
+	"golang.org/x/crypto/ssh"
 )
 
+// TokenExpiryWarning is how long before an access token expires its owner is
+// warned about it.
+const TokenExpiryWarning = 7 * 24 * time.Hour
+
+// ErrInvalidTokenScope is returned when the scope of an access token is not
+// an access level.
+var ErrInvalidTokenScope = errors.New("invalid token scope, must be one of: no-access, read-only, read-write, admin-access")
+
+// ErrInvalidTokenKey is returned when an access token is pinned to a key that
+// doesn't belong to its owner.
+var ErrInvalidTokenKey = errors.New("token can only be pinned to a key of its owner")
+
 // CreateAccessToken creates an access token for user.
-func (b *Backend) CreateAccessToken(ctx context.Context, user proto.User, name string, expiresAt time.Time) (string, error) {
+func (b *Backend) CreateAccessToken(ctx context.Context, user proto.User, name string, opts proto.AccessTokenOptions) (string, error) {
 	token := GenerateToken()
 	tokenHash := HashToken(token)
 	name = utils.Sanitize(name)
 
+	if opts.Scope != "" && access.ParseAccessLevel(opts.Scope) < 0 {
+		return "", ErrInvalidTokenScope
+	}
+	if opts.ScopeRepo != "" {
+		opts.ScopeRepo = utils.SanitizeRepo(opts.ScopeRepo)
+	}
+	cidrs, err := parseAllowedCIDRs(opts.AllowedCIDRs)
+	if err != nil {
+		return "", err
+	}
+	var pinnedKey string
+	if opts.PinnedKey != nil {
+		pinnedKey = ssh.FingerprintSHA256(opts.PinnedKey)
+		if !slices.ContainsFunc(user.PublicKeys(), func(pk ssh.PublicKey) bool {
+			return ssh.FingerprintSHA256(pk) == pinnedKey
+		}) {
+			return "", ErrInvalidTokenKey
+		}
+	}
+
 	if err := b.db.TransactionContext(ctx, func(tx *db.Tx) error {
-		_, err := b.store.CreateAccessToken(ctx, tx, name, user.ID(), tokenHash, expiresAt)
+		t, err := b.store.CreateAccessToken(ctx, tx, name, user.ID(), tokenHash, opts.ExpiresAt)
 		if err != nil {
 			return db.WrapError(err)
 		}
 
+		if opts.Scope != "" || opts.ScopeRepo != "" {
+			if err := b.store.SetAccessTokenScope(ctx, tx, t.ID, opts.Scope, opts.ScopeRepo); err != nil {
+				return db.WrapError(err)
+			}
+		}
+
+		if cidrs != "" || pinnedKey != "" {
+			if err := b.store.SetAccessTokenPins(ctx, tx, t.ID, cidrs, pinnedKey); err != nil {
+				return db.WrapError(err)
+			}
+		}
+
 		return nil
 	}); err != nil {
 		return "", err
@@ -62,19 +111,137 @@ func (b *Backend) ListAccessTokens(ctx context.Context, user proto.User) ([]prot
 
 	var tokens []proto.AccessToken
 	for _, t := range accessTokens {
-		token := proto.AccessToken{
-			ID:        t.ID,
-			Name:      t.Name,
-			TokenHash: t.Token,
-			UserID:    t.UserID,
-			CreatedAt: t.CreatedAt,
+		tokens = append(tokens, accessTokenFromModel(t))
+	}
+
+	return tokens, nil
+}
+
+// AccessTokenAudit is an access token that an admin might want to revoke.
+type AccessTokenAudit struct {
+	Token    proto.AccessToken
+	Username string
+	// Findings explain why the token was reported, e.g. "never used" or "no
+	// expiry".
+	Findings []string
+}
+
+// AuditAccessTokens returns the access tokens of all users that are expired,
+// haven't been used for staleAfter, never expire, or belong to an admin. With
+// all, every token is returned. Tokens are sorted by ID.
+func (b *Backend) AuditAccessTokens(ctx context.Context, staleAfter time.Duration, all bool, now time.Time) ([]AccessTokenAudit, error) {
+	accessTokens, err := b.store.GetAllAccessTokens(ctx, b.db)
+	if err != nil {
+		return nil, db.WrapError(err)
+	}
+
+	users := map[int64]proto.User{}
+	var audits []AccessTokenAudit
+	for _, t := range accessTokens {
+		user, ok := users[t.UserID]
+		if !ok {
+			user, err = b.UserByID(ctx, t.UserID)
+			if err != nil {
+				return nil, err
+			}
+			users[t.UserID] = user
 		}
-		if t.ExpiresAt.Valid {
-			token.ExpiresAt = t.ExpiresAt.Time
+
+		var findings []string
+		switch {
+		case !t.ExpiresAt.Valid:
+			findings = append(findings, "no expiry")
+		case t.ExpiresAt.Time.Before(now):
+			findings = append(findings, "expired")
+		}
+		lastUsed := t.CreatedAt
+		if t.LastUsedAt.Valid {
+			lastUsed = t.LastUsedAt.Time
+		}
+		if staleAfter > 0 && now.Sub(lastUsed) > staleAfter {
+			if t.LastUsedAt.Valid {
+				findings = append(findings, "stale")
+			} else {
+				findings = append(findings, "never used")
+			}
+		}
+		if user.IsAdmin() {
+			findings = append(findings, "admin")
 		}
 
-		tokens = append(tokens, token)
+		if len(findings) == 0 && !all {
+			continue
+		}
+
+		audits = append(audits, AccessTokenAudit{
+			Token:    accessTokenFromModel(t),
+			Username: user.Username(),
+			Findings: findings,
+		})
 	}
 
-	return tokens, nil
+	return audits, nil
+}
+
+// WarnExpiringAccessTokens notifies the owners of the access tokens that
+// expire within TokenExpiryWarning of now. Each token is only warned about
+// once.
+func (b *Backend) WarnExpiringAccessTokens(ctx context.Context, now time.Time) error {
+	accessTokens, err := b.store.GetAccessTokensToWarn(ctx, b.db, now, now.Add(TokenExpiryWarning))
+	if err != nil {
+		return db.WrapError(err)
+	}
+
+	for _, t := range accessTokens {
+		if err := b.db.TransactionContext(ctx, func(tx *db.Tx) error {
+			if err := b.store.CreateNotification(ctx, tx, models.Notification{
+				UserID:      t.UserID,
+				SubjectType: NotificationSubjectAccessToken,
+				SubjectID:   t.ID,
+				Title:       t.Name,
+				Action:      "expiring",
+			}); err != nil {
+				return err
+			}
+
+			return b.store.SetAccessTokenExpiryWarned(ctx, tx, t.ID)
+		}); err != nil {
+			return db.WrapError(err)
+		}
+	}
+
+	return nil
+}
+
+func accessTokenFromModel(t models.AccessToken) proto.AccessToken {
+	token := proto.AccessToken{
+		ID:         t.ID,
+		Name:       t.Name,
+		TokenHash:  t.Token,
+		UserID:     t.UserID,
+		CreatedAt:  t.CreatedAt,
+		LastUsedIP: t.LastUsedIP.String,
+		Scope:      t.Scope,
+		ScopeRepo:  t.ScopeRepo,
+		PinnedKey:  t.PinnedKey,
+	}
+	token.AllowedCIDRs = splitAllowedCIDRs(t.AllowedCIDRs)
+	if t.ExpiresAt.Valid {
+		token.ExpiresAt = t.ExpiresAt.Time
+	}
+	if t.LastUsedAt.Valid {
+		token.LastUsedAt = t.LastUsedAt.Time
+	}
+
+	return token
+}
+
+// remoteIP returns the IP address of the client from the context, without
+// the port.
+func remoteIP(ctx context.Context) string {
+	addr := proto.RemoteAddrFromContext(ctx)
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return host
+	}
+	return addr
 }
@@ -0,0 +1,486 @@
+package backend
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/sshutils"
+	"github.com/charmbracelet/soft-serve/pkg/storage"
+)
+
+// GhostUsername is the username of the bot the issues, merge requests, and
+// attachments of deleted users are reassigned to. Nobody can take it.
+const GhostUsername = "ghost"
+
+// ErrReservedUsername is returned when creating or renaming a user to a
+// reserved username, see GhostUsername.
+var ErrReservedUsername = errors.New("username is reserved")
+
+// checkUsernameNotReserved returns ErrReservedUsername if username is
+// reserved.
+func checkUsernameNotReserved(username string) error {
+	if strings.EqualFold(username, GhostUsername) {
+		return ErrReservedUsername
+	}
+	return nil
+}
+
+// ghostUser returns the ghost user, creating it if needed, and whether it
+// was created.
+func (d *Backend) ghostUser(ctx context.Context, tx *db.Tx) (models.User, bool, error) {
+	ghost, err := d.store.FindUserByUsername(ctx, tx, GhostUsername)
+	if err == nil {
+		if !ghost.Bot {
+			return ghost, false, fmt.Errorf("user %q must be renamed before deleting users: %w", GhostUsername, ErrReservedUsername)
+		}
+		return ghost, false, nil
+	}
+	if !errors.Is(db.WrapError(err), db.ErrRecordNotFound) {
+		return ghost, false, err
+	}
+
+	if err := d.store.CreateUser(ctx, tx, GhostUsername, false, nil); err != nil {
+		return ghost, false, err
+	}
+	if err := d.store.SetBotByUsername(ctx, tx, GhostUsername, true); err != nil {
+		return ghost, false, err
+	}
+	ghost, err = d.store.FindUserByUsername(ctx, tx, GhostUsername)
+	return ghost, true, err
+}
+
+// deleteUser deletes a user and their repositories. Their issues, merge
+// requests, and attachments are reassigned to the ghost user rather than
+// deleted, so the discussions they took part in stay whole. Everything else
+// of the user goes with the account.
+func (d *Backend) deleteUser(ctx context.Context, username string) error {
+	if err := checkUsernameNotReserved(username); err != nil {
+		return err
+	}
+
+	user, err := d.User(ctx, username)
+	if err != nil {
+		return err
+	}
+
+	if err := d.DeleteUserRepositories(ctx, username); err != nil {
+		return err
+	}
+
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		ghost, created, err := d.ghostUser(ctx, tx)
+		if err != nil {
+			return err
+		}
+		n, err := d.store.ReassignUserContent(ctx, tx, user.ID(), ghost.ID)
+		if err != nil {
+			return err
+		}
+		// Only keep the ghost around once it has something to show.
+		if created && n == 0 {
+			if err := d.store.DeleteUserByUsername(ctx, tx, GhostUsername); err != nil {
+				return err
+			}
+		}
+		return d.store.DeleteUserByUsername(ctx, tx, user.Username())
+	}); err != nil {
+		return db.WrapError(err)
+	}
+
+	for _, s := range d.Sessions(user) {
+		if s, ok := d.sessions.remove(s.ID); ok {
+			s.close()
+		}
+	}
+
+	// The deleted user can't be the actor of their own deletion.
+	if actor := proto.UserFromContext(ctx); actor != nil && actor.ID() == user.ID() {
+		ctx = proto.WithUserContext(ctx, nil)
+	}
+	d.audit(ctx, "user.delete", user.Username(), "content reassigned to "+GhostUsername)
+
+	return nil
+}
+
+// DeleteAccount deletes the account of the user of ctx, see DeleteUser.
+func (d *Backend) DeleteAccount(ctx context.Context) error {
+	user := proto.UserFromContext(ctx)
+	if user == nil {
+		return proto.ErrUserNotFound
+	}
+	return d.deleteUser(ctx, user.Username())
+}
+
+// AccountExport is the account of a user, as exported by ExportUserData.
+type AccountExport struct {
+	Username       string               `json:"username"`
+	Admin          bool                 `json:"admin"`
+	Bot            bool                 `json:"bot"`
+	CreatedAt      time.Time            `json:"created_at"`
+	PublicKeys     []string             `json:"public_keys"`
+	Preferences    PreferencesExport    `json:"preferences"`
+	AccessTokens   []AccessTokenExport  `json:"access_tokens"`
+	ChatIdentities []ChatIdentityExport `json:"chat_identities"`
+	Watches        []RepoWatchExport    `json:"watches"`
+	Issues         []IssueExport        `json:"issues"`
+	MergeRequests  []MergeRequestExport `json:"merge_requests"`
+	Comments       []CommentExport      `json:"comments"`
+	Attachments    []AttachmentExport   `json:"attachments"`
+}
+
+// PreferencesExport are the preferences of an exported account.
+type PreferencesExport struct {
+	DiffWordWrap         bool   `json:"diff_word_wrap"`
+	DiffIgnoreWhitespace bool   `json:"diff_ignore_whitespace"`
+	DiffTabWidth         int    `json:"diff_tab_width"`
+	HideNews             bool   `json:"hide_news"`
+	Timezone             string `json:"timezone"`
+	DateFormat           string `json:"date_format"`
+	DateStyle            string `json:"date_style"`
+}
+
+// AccessTokenExport is an access token of an exported account, without the
+// token itself.
+type AccessTokenExport struct {
+	Name       string     `json:"name"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	LastUsedIP string     `json:"last_used_ip,omitempty"`
+	Scope      string     `json:"scope,omitempty"`
+	ScopeRepo  string     `json:"scope_repo,omitempty"`
+}
+
+// ChatIdentityExport is a chat account linked to an exported account.
+type ChatIdentityExport struct {
+	Provider   string    `json:"provider"`
+	ExternalID string    `json:"external_id"`
+	LinkedAt   time.Time `json:"linked_at"`
+}
+
+// RepoWatchExport is a repository watched by an exported account.
+type RepoWatchExport struct {
+	Repository string `json:"repository"`
+	Level      string `json:"level"`
+}
+
+// IssueExport is an issue opened by an exported account.
+type IssueExport struct {
+	Repository  string     `json:"repository"`
+	Number      int64      `json:"number"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	State       string     `json:"state"`
+	Draft       bool       `json:"draft"`
+	CreatedAt   time.Time  `json:"created_at"`
+	ClosedAt    *time.Time `json:"closed_at,omitempty"`
+}
+
+// MergeRequestExport is a merge request opened by an exported account.
+type MergeRequestExport struct {
+	Repository   string     `json:"repository"`
+	ID           int64      `json:"id"`
+	Title        string     `json:"title"`
+	Description  string     `json:"description"`
+	State        string     `json:"state"`
+	SourceBranch string     `json:"source_branch"`
+	TargetBranch string     `json:"target_branch"`
+	CreatedAt    time.Time  `json:"created_at"`
+	MergedAt     *time.Time `json:"merged_at,omitempty"`
+	ClosedAt     *time.Time `json:"closed_at,omitempty"`
+}
+
+// CommentExport is a merge request comment made by an exported account.
+type CommentExport struct {
+	Repository     string    `json:"repository"`
+	MergeRequestID int64     `json:"merge_request_id"`
+	Body           string    `json:"body"`
+	Path           string    `json:"path,omitempty"`
+	Lines          string    `json:"lines,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// AttachmentExport is a file attached by an exported account. Its content
+// is in the archive at Path.
+type AttachmentExport struct {
+	Repository string    `json:"repository"`
+	Subject    string    `json:"subject"`
+	SubjectID  int64     `json:"subject_id"`
+	Name       string    `json:"name"`
+	Size       int64     `json:"size"`
+	Path       string    `json:"path,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// nullTime returns the time of t, or nil if it's null.
+func nullTime(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
+
+// ExportUserData writes a gzipped tar archive of everything associated with
+// user to w: their account, public keys, preferences, access tokens, linked
+// chat accounts, watches, and the issues, merge requests, comments, and
+// attachments they authored, in all repositories.
+func (d *Backend) ExportUserData(ctx context.Context, user proto.User, w io.Writer) error {
+	export, attachments, err := d.exportAccount(ctx, user)
+	if err != nil {
+		return err
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	dir := user.Username()
+	now := time.Now()
+
+	data, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := writeTarFile(tw, path.Join(dir, "account.json"), now, int64(len(data)+1), strings.NewReader(string(data)+"\n")); err != nil {
+		return err
+	}
+
+	keys := strings.Join(export.PublicKeys, "\n")
+	if keys != "" {
+		keys += "\n"
+	}
+	if err := writeTarFile(tw, path.Join(dir, "authorized_keys"), now, int64(len(keys)), strings.NewReader(keys)); err != nil {
+		return err
+	}
+
+	for i, a := range attachments {
+		p := export.Attachments[i].Path
+		if p == "" {
+			continue
+		}
+		obj, err := storage.NewLocalStorage(d.attachmentsPath(a.RepoID)).Open(attachmentObjectPath(a.Oid))
+		if err != nil {
+			d.logger.Error("error opening attachment content", "oid", a.Oid, "err", err)
+			continue
+		}
+		err = writeTarFile(tw, path.Join(dir, p), a.CreatedAt, a.Size, obj)
+		obj.Close() //nolint:errcheck
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+func writeTarFile(tw *tar.Writer, name string, modTime time.Time, size int64, r io.Reader) error {
+	if err := tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0o644,
+		Size:    size,
+		ModTime: modTime,
+	}); err != nil {
+		return err
+	}
+	_, err := io.CopyN(tw, r, size)
+	return err
+}
+
+// exportAccount gathers the data of user, and the attachments they
+// authored in the order of the export.
+func (d *Backend) exportAccount(ctx context.Context, user proto.User) (AccountExport, []models.Attachment, error) {
+	var export AccountExport
+	var attachments []models.Attachment
+
+	repos, err := d.Repositories(ctx)
+	if err != nil {
+		return export, nil, err
+	}
+	names := make(map[int64]string, len(repos))
+	for _, r := range repos {
+		names[r.ID()] = r.Name()
+	}
+
+	err = d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		u, err := d.store.FindUserByUsername(ctx, tx, user.Username())
+		if err != nil {
+			return err
+		}
+		export.Username = u.Username
+		export.Admin = u.Admin
+		export.Bot = u.Bot
+		export.CreatedAt = u.CreatedAt
+
+		pks, err := d.store.ListPublicKeysByUserID(ctx, tx, u.ID)
+		if err != nil {
+			return err
+		}
+		for _, pk := range pks {
+			export.PublicKeys = append(export.PublicKeys, sshutils.MarshalAuthorizedKey(pk))
+		}
+
+		prefs, err := d.store.GetUserPreferencesByUserID(ctx, tx, u.ID)
+		if err != nil && !errors.Is(db.WrapError(err), db.ErrRecordNotFound) {
+			return err
+		}
+		export.Preferences = PreferencesExport{
+			DiffWordWrap:         prefs.DiffWordWrap,
+			DiffIgnoreWhitespace: prefs.DiffIgnoreWhitespace,
+			DiffTabWidth:         prefs.DiffTabWidth,
+			HideNews:             prefs.HideNews,
+			Timezone:             prefs.Timezone,
+			DateFormat:           prefs.DateFormat,
+			DateStyle:            prefs.DateStyle,
+		}
+
+		tokens, err := d.store.GetAccessTokensByUserID(ctx, tx, u.ID)
+		if err != nil {
+			return err
+		}
+		for _, t := range tokens {
+			at := accessTokenFromModel(t)
+			e := AccessTokenExport{
+				Name:       at.Name,
+				CreatedAt:  at.CreatedAt,
+				LastUsedIP: at.LastUsedIP,
+				Scope:      at.Scope,
+				ScopeRepo:  at.ScopeRepo,
+			}
+			if !at.ExpiresAt.IsZero() {
+				e.ExpiresAt = &at.ExpiresAt
+			}
+			if !at.LastUsedAt.IsZero() {
+				e.LastUsedAt = &at.LastUsedAt
+			}
+			export.AccessTokens = append(export.AccessTokens, e)
+		}
+
+		ids, err := d.store.GetChatIdentitiesByUserID(ctx, tx, u.ID)
+		if err != nil {
+			return err
+		}
+		for _, id := range ids {
+			export.ChatIdentities = append(export.ChatIdentities, ChatIdentityExport{
+				Provider:   id.Provider,
+				ExternalID: id.ExternalID,
+				LinkedAt:   id.CreatedAt,
+			})
+		}
+
+		watches, err := d.store.GetRepoWatchesByUserID(ctx, tx, u.ID)
+		if err != nil {
+			return err
+		}
+		for _, w := range watches {
+			export.Watches = append(export.Watches, RepoWatchExport{
+				Repository: names[w.RepoID],
+				Level:      w.Level.String(),
+			})
+		}
+
+		issues, err := d.store.GetIssuesByAuthorID(ctx, tx, u.ID)
+		if err != nil {
+			return err
+		}
+		for _, issue := range issues {
+			if issue.DescriptionTruncated {
+				issue.Description, err = d.store.GetIssueLargeText(ctx, tx, issue.RepoID, issue.ID)
+				if err != nil {
+					return err
+				}
+			}
+			export.Issues = append(export.Issues, IssueExport{
+				Repository:  names[issue.RepoID],
+				Number:      issue.Number,
+				Title:       issue.Title,
+				Description: issue.Description,
+				State:       issue.State.String(),
+				Draft:       issue.Draft,
+				CreatedAt:   issue.CreatedAt,
+				ClosedAt:    nullTime(issue.ClosedAt),
+			})
+		}
+
+		mrs, err := d.store.GetMergeRequestsByAuthorID(ctx, tx, u.ID)
+		if err != nil {
+			return err
+		}
+		for _, mr := range mrs {
+			if mr.DescriptionTruncated {
+				mr.Description, err = d.store.GetMergeRequestLargeText(ctx, tx, mr.RepoID, mr.ID)
+				if err != nil {
+					return err
+				}
+			}
+			export.MergeRequests = append(export.MergeRequests, MergeRequestExport{
+				Repository:   names[mr.RepoID],
+				ID:           mr.ID,
+				Title:        mr.Title,
+				Description:  mr.Description,
+				State:        mr.State.String(),
+				SourceBranch: mr.SourceBranch,
+				TargetBranch: mr.TargetBranch,
+				CreatedAt:    mr.CreatedAt,
+				MergedAt:     nullTime(mr.MergedAt),
+				ClosedAt:     nullTime(mr.ClosedAt),
+			})
+		}
+
+		comments, err := d.store.GetMergeRequestCommentsByAuthorID(ctx, tx, u.ID)
+		if err != nil {
+			return err
+		}
+		for _, c := range comments {
+			e := CommentExport{
+				Repository:     names[c.RepoID],
+				MergeRequestID: c.MergeRequestID,
+				Body:           c.Body,
+				Path:           c.Path,
+				CreatedAt:      c.CreatedAt,
+			}
+			if !c.CommentAnchor.IsZero() {
+				e.Lines = c.Lines()
+			}
+			export.Comments = append(export.Comments, e)
+		}
+
+		attachments, err = d.store.GetAttachmentsByUserID(ctx, tx, u.ID)
+		if err != nil {
+			return err
+		}
+		for _, a := range attachments {
+			e := AttachmentExport{
+				Repository: names[a.RepoID],
+				Subject:    a.SubjectType,
+				SubjectID:  a.SubjectID,
+				Name:       a.Name,
+				Size:       a.Size,
+				CreatedAt:  a.CreatedAt,
+			}
+			if e.Repository != "" {
+				e.Path = path.Join("attachments", e.Repository, fmt.Sprintf("%d-%s", a.ID, path.Base(a.Name)))
+			}
+			export.Attachments = append(export.Attachments, e)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return export, nil, db.WrapError(err)
+	}
+
+	return export, attachments, nil
+}
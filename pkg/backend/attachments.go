@@ -0,0 +1,307 @@
+package backend
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/soft-serve/pkg/access"
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/storage"
+	"github.com/charmbracelet/soft-serve/pkg/utils"
+)
+
+// maxAttachmentNameLength is the maximum length of the file name of an
+// attachment.
+const maxAttachmentNameLength = 255
+
+var (
+	// ErrAttachmentsDisabled is returned when attaching a file while the
+	// maximum attachment size is 0.
+	ErrAttachmentsDisabled = errors.New("attachments are disabled")
+
+	// ErrAttachmentTooLarge is returned when attaching a file larger than the
+	// maximum attachment size.
+	ErrAttachmentTooLarge = errors.New("attachment is too large")
+
+	// ErrAttachmentNotFound is returned when an attachment doesn't exist.
+	ErrAttachmentNotFound = errors.New("attachment not found")
+
+	// ErrInvalidAttachmentName is returned when attaching a file without a
+	// usable name.
+	ErrInvalidAttachmentName = errors.New("invalid attachment name")
+)
+
+// AddAttachment attaches a file, read from r, to an issue or a merge request
+// on behalf of the user of ctx, who must be able to read the repository, or
+// be a guest of it for issues. Files larger than the maximum attachment size
+// are refused.
+func (d *Backend) AddAttachment(ctx context.Context, repoName string, subject Subject, name string, r io.Reader) (models.Attachment, error) {
+	repoName = utils.SanitizeRepo(repoName)
+	maxSize := int64(d.cfg.Limits.MaxAttachmentSize)
+	if maxSize <= 0 {
+		return models.Attachment{}, ErrAttachmentsDisabled
+	}
+
+	name = strings.TrimSpace(filepath.Base(strings.ReplaceAll(name, "\\", "/")))
+	if name == "" || name == "." || name == "/" || len(name) > maxAttachmentNameLength {
+		return models.Attachment{}, ErrInvalidAttachmentName
+	}
+
+	user := proto.UserFromContext(ctx)
+	if user == nil {
+		return models.Attachment{}, proto.ErrUserNotFound
+	}
+
+	repo, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return models.Attachment{}, err
+	}
+	// Guests open issues, so they attach files to them too.
+	minAccess := access.ReadOnlyAccess
+	if subject.Type == NotificationSubjectIssue {
+		minAccess = access.GuestAccess
+	}
+	if d.AccessLevelForUser(ctx, repo.Name(), user) < minAccess {
+		return models.Attachment{}, proto.ErrRepoNotFound
+	}
+
+	if err := d.checkNotBlocked(ctx, repo); err != nil {
+		return models.Attachment{}, err
+	}
+
+	subjectID, _, err := d.subscriptionSubject(ctx, repo, subject)
+	if err != nil {
+		return models.Attachment{}, err
+	}
+	if subject.Type == NotificationSubjectIssue {
+		if err := d.checkIssueUnlocked(ctx, repo, subject.ID); err != nil {
+			return models.Attachment{}, err
+		}
+	}
+
+	oid, size, contentType, err := d.storeAttachment(repo, name, io.LimitReader(r, maxSize+1), maxSize)
+	if err != nil {
+		return models.Attachment{}, err
+	}
+
+	a := models.Attachment{
+		RepoID:      repo.ID(),
+		SubjectType: subject.Type,
+		SubjectID:   subjectID,
+		UserID:      user.ID(),
+		Name:        name,
+		ContentType: contentType,
+		Size:        size,
+		Oid:         oid,
+	}
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		a.ID, err = d.store.CreateAttachment(ctx, tx, a)
+		if err != nil {
+			return err
+		}
+		a, err = d.store.GetAttachment(ctx, tx, repo.ID(), a.ID)
+		return err
+	}); err != nil {
+		return models.Attachment{}, db.WrapError(err)
+	}
+
+	d.audit(ctx, "attachment.create", repo.Name(), fmt.Sprintf("%s %d: %s", subject.Type, subjectID, name))
+
+	return a, nil
+}
+
+// storeAttachment writes the content of an attachment to the attachment
+// storage of repo, by OID, and returns its OID, size, and content type.
+func (d *Backend) storeAttachment(repo proto.Repository, name string, r io.Reader, maxSize int64) (string, int64, string, error) {
+	dir := d.attachmentsPath(repo.ID())
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return "", 0, "", err
+	}
+
+	tmp, err := os.CreateTemp(dir, "upload-*")
+	if err != nil {
+		return "", 0, "", err
+	}
+	defer os.Remove(tmp.Name()) // nolint: errcheck
+
+	h := sha256.New()
+	var head [512]byte
+	n, _ := io.ReadFull(r, head[:])
+	size, err := io.Copy(io.MultiWriter(tmp, h), io.MultiReader(bytes.NewReader(head[:n]), r))
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return "", 0, "", err
+	}
+	if size > maxSize {
+		return "", 0, "", ErrAttachmentTooLarge
+	}
+
+	contentType := mime.TypeByExtension(filepath.Ext(name))
+	if contentType == "" {
+		contentType = http.DetectContentType(head[:n])
+	}
+
+	oid := hex.EncodeToString(h.Sum(nil))
+	strg := storage.NewLocalStorage(dir)
+	if ok, err := strg.Exists(attachmentObjectPath(oid)); err != nil {
+		return "", 0, "", err
+	} else if !ok {
+		if err := strg.Rename(tmp.Name(), attachmentObjectPath(oid)); err != nil {
+			return "", 0, "", err
+		}
+	}
+
+	return oid, size, contentType, nil
+}
+
+// Attachments returns the files attached to an issue or a merge request,
+// oldest first. Guests of the repository can list them.
+func (d *Backend) Attachments(ctx context.Context, repoName string, subject Subject) ([]models.Attachment, error) {
+	repoName = utils.SanitizeRepo(repoName)
+
+	repo, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return nil, err
+	}
+	if d.AccessLevelForUser(ctx, repo.Name(), proto.UserFromContext(ctx)) < access.GuestAccess {
+		return nil, proto.ErrRepoNotFound
+	}
+
+	subjectID, _, err := d.subscriptionSubject(ctx, repo, subject)
+	if err != nil {
+		return nil, err
+	}
+
+	as, err := d.store.GetAttachments(ctx, d.db, repo.ID(), subject.Type, subjectID)
+	if err != nil {
+		return nil, db.WrapError(err)
+	}
+
+	return as, nil
+}
+
+// OpenAttachment returns an attachment of a repository and its content, for
+// the user of ctx to download. The caller must close the content.
+func (d *Backend) OpenAttachment(ctx context.Context, repoName string, id int64) (models.Attachment, storage.Object, error) {
+	repo, a, err := d.attachment(ctx, repoName, id)
+	if err != nil {
+		return models.Attachment{}, nil, err
+	}
+
+	obj, err := storage.NewLocalStorage(d.attachmentsPath(repo.ID())).Open(attachmentObjectPath(a.Oid))
+	if errors.Is(err, os.ErrNotExist) {
+		return models.Attachment{}, nil, ErrAttachmentNotFound
+	} else if err != nil {
+		return models.Attachment{}, nil, err
+	}
+
+	return a, obj, nil
+}
+
+// DeleteAttachment deletes an attachment of a repository. Only the user who
+// uploaded it and collaborators can. The content is deleted once no
+// attachment of the repository refers to it.
+func (d *Backend) DeleteAttachment(ctx context.Context, repoName string, id int64) error {
+	repo, a, err := d.attachment(ctx, repoName, id)
+	if err != nil {
+		return err
+	}
+
+	user := proto.UserFromContext(ctx)
+	if user == nil {
+		return proto.ErrUserNotFound
+	}
+	if a.UserID != user.ID() && d.AccessLevelForUser(ctx, repo.Name(), user) < access.ReadWriteAccess {
+		return proto.ErrUnauthorized
+	}
+
+	var remaining int64
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		if err := d.store.DeleteAttachment(ctx, tx, repo.ID(), id); err != nil {
+			return err
+		}
+		remaining, err = d.store.CountAttachmentsByOid(ctx, tx, repo.ID(), a.Oid)
+		return err
+	}); err != nil {
+		return db.WrapError(err)
+	}
+
+	if remaining == 0 {
+		strg := storage.NewLocalStorage(d.attachmentsPath(repo.ID()))
+		if err := strg.Delete(attachmentObjectPath(a.Oid)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			d.logger.Error("error deleting attachment content", "repo", repo.Name(), "oid", a.Oid, "err", err)
+		}
+	}
+
+	d.audit(ctx, "attachment.delete", repo.Name(), fmt.Sprintf("%s %d: %s", a.SubjectType, a.SubjectID, a.Name))
+
+	return nil
+}
+
+// AttachmentURL returns the URL an attachment is downloaded from.
+func (d *Backend) AttachmentURL(repoName string, a models.Attachment) string {
+	return fmt.Sprintf("%s/%s/-/attachments/%d/%s", strings.TrimSuffix(d.cfg.HTTP.PublicURL, "/"),
+		utils.SanitizeRepo(repoName), a.ID, url.PathEscape(a.Name))
+}
+
+// attachment returns an attachment of a repository the user of ctx can
+// read, like its guests. Attachments of issues, or merge requests, are hidden
+// when the repository turned them off.
+func (d *Backend) attachment(ctx context.Context, repoName string, id int64) (proto.Repository, models.Attachment, error) {
+	repoName = utils.SanitizeRepo(repoName)
+
+	repo, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return nil, models.Attachment{}, err
+	}
+	if d.AccessLevelForUser(ctx, repo.Name(), proto.UserFromContext(ctx)) < access.GuestAccess {
+		return nil, models.Attachment{}, proto.ErrRepoNotFound
+	}
+
+	a, err := d.store.GetAttachment(ctx, d.db, repo.ID(), id)
+	if errors.Is(db.WrapError(err), db.ErrRecordNotFound) {
+		return nil, models.Attachment{}, ErrAttachmentNotFound
+	} else if err != nil {
+		return nil, models.Attachment{}, db.WrapError(err)
+	}
+
+	switch a.SubjectType {
+	case NotificationSubjectIssue:
+		err = d.checkIssuesEnabled(ctx, repo.Name())
+	case NotificationSubjectMergeRequest:
+		err = d.checkMergeRequestsEnabled(ctx, repo.Name())
+	}
+	if err != nil {
+		return nil, models.Attachment{}, err
+	}
+
+	return repo, a, nil
+}
+
+// attachmentsPath returns the directory of the attachments of a repository.
+func (d *Backend) attachmentsPath(repoID int64) string {
+	return filepath.Join(d.cfg.DataPath, "attachments", strconv.FormatInt(repoID, 10))
+}
+
+// attachmentObjectPath returns the path of the content of an attachment in
+// the attachment storage of its repository.
+func attachmentObjectPath(oid string) string {
+	return path.Join("objects", oid[:2], oid)
+}
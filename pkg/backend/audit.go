@@ -0,0 +1,50 @@
+package backend
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+)
+
+// AuditEvents returns the latest limit events of the audit log, newest
+// first.
+func (d *Backend) AuditEvents(ctx context.Context, limit int) ([]models.AuditEvent, error) {
+	es, err := d.store.GetAuditEvents(ctx, d.db, limit)
+	if err != nil {
+		return nil, db.WrapError(err)
+	}
+
+	return es, nil
+}
+
+// audit adds an event to the audit log on behalf of the user in ctx. The
+// action has already happened, so errors are logged instead of returned.
+func (d *Backend) audit(ctx context.Context, action, target, detail string) {
+	e := models.AuditEvent{
+		Action: action,
+		Target: target,
+		Detail: detail,
+	}
+	if actor := proto.UserFromContext(ctx); actor != nil {
+		e.ActorID = sql.NullInt64{Int64: actor.ID(), Valid: true}
+	}
+	if admin := proto.ImpersonatorFromContext(ctx); admin != nil {
+		e.ImpersonatorID = sql.NullInt64{Int64: admin.ID(), Valid: true}
+	}
+
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		return d.store.CreateAuditEvent(ctx, tx, e)
+	}); err != nil {
+		d.logger.Error("error creating audit event", "action", action, "target", target, "err", err)
+	}
+}
+
+// AuditImpersonation records that the admin of ctx ran command as user.
+// Events of the actions of the command record the admin as the
+// impersonator.
+func (d *Backend) AuditImpersonation(ctx context.Context, user proto.User, command string) {
+	d.audit(ctx, "user.impersonate", user.Username(), command)
+}
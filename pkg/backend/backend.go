@@ -2,10 +2,12 @@ package backend
 
 import (
 	"context"
+	"sync"
 
 	"github.com/charmbracelet/log/v2"
 	"github.com/charmbracelet/soft-serve/pkg/config"
 	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/storage"
 	"github.com/charmbracelet/soft-serve/pkg/store"
 	"github.com/charmbracelet/soft-serve/pkg/task"
 )
@@ -20,6 +22,20 @@ type Backend struct {
 	logger  *log.Logger
 	cache   *cache
 	manager *task.Manager
+	repos   storage.RepoStorage
+
+	// mergeQueueMu serializes merge queue runs.
+	mergeQueueMu sync.Mutex
+	// poolsMu serializes object pool maintenance.
+	poolsMu sync.Mutex
+
+	sessions  sessions
+	policy    policy
+	diskUsage diskUsage
+	diffs     diffCache
+	compares  compareCache
+
+	keyLogins keyLogins
 }
 
 // New returns a new Soft Serve backend.
@@ -32,6 +48,7 @@ func New(ctx context.Context, cfg *config.Config, db *db.DB, st store.Store) *Ba
 		store:   st,
 		logger:  logger,
 		manager: task.NewManager(ctx),
+		repos:   storage.NewFSRepoStorage(cfg.DataPath, cfg.Storage.Volumes, cfg.Storage.ShardLevels),
 	}
 
 	// TODO: implement a proper caching interface
@@ -4,8 +4,9 @@ import lru "github.com/hashicorp/golang-lru/v2"
 
 // TODO: implement a caching interface.
 type cache struct {
-	b     *Backend
-	repos *lru.Cache[string, *repo]
+	b        *Backend
+	repos    *lru.Cache[string, *repo]
+	profiles *lru.Cache[string, profileReadme]
 }
 
 func newCache(b *Backend, size int) *cache {
@@ -15,6 +16,8 @@ func newCache(b *Backend, size int) *cache {
 	c := &cache{b: b}
 	cache, _ := lru.New[string, *repo](size)
 	c.repos = cache
+	profiles, _ := lru.New[string, profileReadme](size)
+	c.profiles = profiles
 	return c
 }
 
@@ -33,3 +36,13 @@ func (c *cache) Delete(repo string) {
 func (c *cache) Len() int {
 	return c.repos.Len()
 }
+
+// GetProfileReadme returns the cached README of a profile repository.
+func (c *cache) GetProfileReadme(repo string) (profileReadme, bool) {
+	return c.profiles.Get(repo)
+}
+
+// SetProfileReadme caches the README of a profile repository.
+func (c *cache) SetProfileReadme(repo string, p profileReadme) {
+	c.profiles.Add(repo, p)
+}
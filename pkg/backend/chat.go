@@ -0,0 +1,128 @@
+package backend
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+)
+
+// chatLinkCodeTTL is how long a chat link code can be redeemed.
+const chatLinkCodeTTL = 10 * time.Minute
+
+// ErrInvalidChatLinkCode is returned when a chat link code does not exist or
+// has expired.
+var ErrInvalidChatLinkCode = errors.New("invalid or expired link code")
+
+// CreateChatLinkCode creates a short lived code that links the chat account
+// externalID of provider to the user that redeems it with LinkChatIdentity.
+func (d *Backend) CreateChatLinkCode(ctx context.Context, provider string, externalID string) (string, error) {
+	buf := make([]byte, 10)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	code := base32.StdEncoding.EncodeToString(buf)
+
+	now := time.Now()
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		if err := d.store.DeleteExpiredChatLinkCodes(ctx, tx, now); err != nil {
+			return err
+		}
+
+		return d.store.CreateChatLinkCode(ctx, tx, code, provider, externalID, now.Add(chatLinkCodeTTL))
+	}); err != nil {
+		return "", db.WrapError(err)
+	}
+
+	return code, nil
+}
+
+// LinkChatIdentity redeems a chat link code and links its chat account to
+// user. A chat account already linked to another user is moved to user.
+func (d *Backend) LinkChatIdentity(ctx context.Context, user proto.User, code string) (models.ChatIdentity, error) {
+	var ci models.ChatIdentity
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		lc, err := d.store.GetChatLinkCode(ctx, tx, code)
+		if err != nil {
+			return db.WrapError(err)
+		}
+
+		if lc.ExpiresAt.Before(time.Now()) {
+			return ErrInvalidChatLinkCode
+		}
+
+		if err := d.store.DeleteChatLinkCode(ctx, tx, code); err != nil {
+			return err
+		}
+
+		existing, err := d.store.GetChatIdentity(ctx, tx, lc.Provider, lc.ExternalID)
+		if err == nil {
+			if err := d.store.DeleteChatIdentityForUser(ctx, tx, existing.UserID, lc.Provider, lc.ExternalID); err != nil {
+				return err
+			}
+		} else if !errors.Is(db.WrapError(err), db.ErrRecordNotFound) {
+			return err
+		}
+
+		if err := d.store.CreateChatIdentity(ctx, tx, user.ID(), lc.Provider, lc.ExternalID); err != nil {
+			return err
+		}
+
+		ci, err = d.store.GetChatIdentity(ctx, tx, lc.Provider, lc.ExternalID)
+		return err
+	}); err != nil {
+		err = db.WrapError(err)
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return models.ChatIdentity{}, ErrInvalidChatLinkCode
+		}
+		return models.ChatIdentity{}, err
+	}
+
+	return ci, nil
+}
+
+// UserByChatIdentity returns the user linked to the chat account externalID
+// of provider.
+func (d *Backend) UserByChatIdentity(ctx context.Context, provider string, externalID string) (proto.User, error) {
+	ci, err := d.store.GetChatIdentity(ctx, d.db, provider, externalID)
+	if err != nil {
+		err = db.WrapError(err)
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, proto.ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	return d.UserByID(ctx, ci.UserID)
+}
+
+// ChatIdentities returns the chat accounts linked to user.
+func (d *Backend) ChatIdentities(ctx context.Context, user proto.User) ([]models.ChatIdentity, error) {
+	cis, err := d.store.GetChatIdentitiesByUserID(ctx, d.db, user.ID())
+	if err != nil {
+		return nil, db.WrapError(err)
+	}
+
+	return cis, nil
+}
+
+// UnlinkChatIdentity unlinks the chat account externalID of provider from
+// user.
+func (d *Backend) UnlinkChatIdentity(ctx context.Context, user proto.User, provider string, externalID string) error {
+	return d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		ci, err := d.store.GetChatIdentity(ctx, tx, provider, externalID)
+		if err != nil {
+			return db.WrapError(err)
+		}
+		if ci.UserID != user.ID() {
+			return db.ErrRecordNotFound
+		}
+
+		return db.WrapError(d.store.DeleteChatIdentityForUser(ctx, tx, user.ID(), provider, externalID))
+	})
+}
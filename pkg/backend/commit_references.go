@@ -0,0 +1,297 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/soft-serve/git"
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/hooks"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/utils"
+	"github.com/charmbracelet/soft-serve/pkg/webhook"
+)
+
+// maxIndexedCommits is the maximum number of commits of a push, or of a merge
+// request, added to the reference index.
+const maxIndexedCommits = 1000
+
+// CommitReferences are the merge requests and issues the reference index
+// links to a commit.
+type CommitReferences struct {
+	// MergeRequests are the merge requests that introduced the commit.
+	MergeRequests []models.MergeRequest
+	// Closes are the issues the commit message closes, e.g. with "Fixes #12".
+	Closes []models.Issue
+	// Mentions are the other issues the commit message references.
+	Mentions []models.Issue
+}
+
+// issueReference is a reference to an issue in a commit message.
+type issueReference struct {
+	number int64
+	closes bool
+}
+
+// issueReferenceRe returns the regexp matching the references to issues in
+// commit messages, "#12" or, with the issue prefix of the repository,
+// "SRV-12", optionally following a closing keyword.
+func issueReferenceRe(prefix string) *regexp.Regexp {
+	ref := "#"
+	if prefix != "" {
+		ref = "(?:#|" + regexp.QuoteMeta(prefix) + "-)"
+	}
+	return regexp.MustCompile(`(?:^|\W)(?:(?i:(close[sd]?|fix(?:e[sd])?|resolve[sd]?|request)):?\s+)?` + ref + `(\d+)\b`)
+}
+
+// parseIssueReferences returns the references to issues in a commit message,
+// in order. References following a closing keyword, e.g. "Fixes #12" or
+// "closes SRV-12", close the issue. "Merge request #12" is left out since it
+// references a merge request.
+func parseIssueReferences(prefix string, msg string) []issueReference {
+	var refs []issueReference
+	seen := map[int64]int{}
+	for _, m := range issueReferenceRe(prefix).FindAllStringSubmatch(msg, -1) {
+		keyword := strings.ToLower(m[1])
+		if keyword == "request" {
+			continue
+		}
+		n, err := strconv.ParseInt(m[2], 10, 64)
+		if err != nil || n <= 0 {
+			continue
+		}
+		closes := keyword != ""
+		if i, ok := seen[n]; ok {
+			refs[i].closes = refs[i].closes || closes
+			continue
+		}
+		seen[n] = len(refs)
+		refs = append(refs, issueReference{number: n, closes: closes})
+	}
+	return refs
+}
+
+// CommitReferences returns the merge requests that introduced a commit of a
+// repository, and the issues its message closes or mentions. Issues, or merge
+// requests, are left out when the repository turned them off.
+func (d *Backend) CommitReferences(ctx context.Context, repoName string, sha string) (CommitReferences, error) {
+	repoName = utils.SanitizeRepo(repoName)
+
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return CommitReferences{}, err
+	}
+
+	var refs CommitReferences
+	if err := d.checkMergeRequestsEnabled(ctx, repoName); err == nil {
+		refs.MergeRequests, err = d.store.GetMergeRequestsByCommit(ctx, d.db, r.ID(), sha)
+		if err != nil {
+			return CommitReferences{}, db.WrapError(err)
+		}
+	} else if !errors.Is(err, ErrMergeRequestsDisabled) {
+		return CommitReferences{}, err
+	}
+
+	if err := d.checkIssuesEnabled(ctx, repoName); errors.Is(err, ErrIssuesDisabled) {
+		return refs, nil
+	} else if err != nil {
+		return CommitReferences{}, err
+	}
+
+	irefs, err := d.store.GetCommitIssueReferences(ctx, d.db, r.ID(), sha)
+	if err != nil {
+		return CommitReferences{}, db.WrapError(err)
+	}
+	for _, ref := range irefs {
+		issue, err := d.store.GetIssueByID(ctx, d.db, r.ID(), ref.IssueID)
+		if err != nil {
+			return CommitReferences{}, db.WrapError(err)
+		}
+		if ref.Closes {
+			refs.Closes = append(refs.Closes, issue)
+		} else {
+			refs.Mentions = append(refs.Mentions, issue)
+		}
+	}
+
+	return refs, nil
+}
+
+// issueClosure is an issue a commit pushed to the default branch closes.
+type issueClosure struct {
+	sha    string
+	number int64
+}
+
+// indexCommitReferences adds the references to issues in the messages of the
+// commits pushed to the branches of a repository to the reference index, and
+// returns the issues closed by the commits pushed to the default branch,
+// oldest commit first. The push already happened, so errors are logged
+// instead of returned.
+func (d *Backend) indexCommitReferences(ctx context.Context, name string, args []hooks.HookArg) []issueClosure {
+	r, err := d.Repository(ctx, name)
+	if err != nil {
+		d.logger.Error("error finding repository", "repo", name, "err", err)
+		return nil
+	}
+
+	prefix, err := d.IssuePrefix(ctx, name)
+	if err != nil {
+		d.logger.Error("error getting issue prefix", "repo", name, "err", err)
+		return nil
+	}
+
+	var defaultBranch string
+	if gr, err := r.Open(); err == nil {
+		if head, err := gr.HEAD(); err == nil {
+			defaultBranch = head.Name().String()
+		}
+	}
+
+	var closures []issueClosure
+	for _, arg := range args {
+		if !strings.HasPrefix(arg.RefName, git.RefsHeads) || git.IsZeroHash(arg.NewSha) {
+			continue
+		}
+
+		// Commits already on a branch were indexed when pushed to it. The
+		// patterns excluded from --branches leave out the refs/heads/ prefix.
+		revs := []string{"log", "--max-count=" + strconv.Itoa(maxIndexedCommits), "--format=%H%x1f%B%x1e", arg.NewSha}
+		if git.IsZeroHash(arg.OldSha) {
+			revs = append(revs, "--not", "--exclude="+strings.TrimPrefix(arg.RefName, git.RefsHeads), "--branches")
+		} else {
+			revs = append(revs, "^"+arg.OldSha)
+		}
+		out, err := d.command(ctx, revs...).RunInDir(r.(*repo).path)
+		if err != nil {
+			d.logger.Error("error listing pushed commits", "repo", name, "ref", arg.RefName, "err", err)
+			continue
+		}
+
+		// The log lists the newest commits first.
+		commits := strings.Split(string(out), "\x1e")
+		for i := len(commits) - 1; i >= 0; i-- {
+			sha, msg, ok := strings.Cut(strings.TrimSpace(commits[i]), "\x1f")
+			if !ok {
+				continue
+			}
+			refs := parseIssueReferences(prefix, msg)
+			d.indexIssueReferences(ctx, r, sha, refs)
+			if arg.RefName != defaultBranch {
+				continue
+			}
+			for _, ref := range refs {
+				if ref.closes {
+					closures = append(closures, issueClosure{sha: sha, number: ref.number})
+				}
+			}
+		}
+	}
+
+	return closures
+}
+
+// closeIssuesFromCommits closes the open issues closed by the commits pushed
+// to the default branch of a repository on behalf of the pusher, and tells
+// the pusher. The push already happened, so errors are logged instead of
+// returned.
+func (d *Backend) closeIssuesFromCommits(ctx context.Context, stdout io.Writer, name string, user proto.User, closures []issueClosure) {
+	if user == nil || len(closures) == 0 {
+		return
+	}
+
+	r, err := d.Repository(ctx, name)
+	if err != nil {
+		d.logger.Error("error finding repository", "repo", name, "err", err)
+		return
+	}
+	if enabled, err := d.IsIssuesEnabled(ctx, name); err != nil || !enabled {
+		return
+	}
+
+	prefix, err := d.IssuePrefix(ctx, name)
+	if err != nil {
+		d.logger.Error("error getting issue prefix", "repo", name, "err", err)
+		return
+	}
+
+	ctx = proto.WithUserContext(ctx, user)
+	for _, c := range closures {
+		issue, err := d.store.GetIssueByNumber(ctx, d.db, r.ID(), c.number)
+		if err != nil || issue.State != models.IssueStateOpen {
+			continue
+		}
+
+		if err := d.checkDependenciesClosed(ctx, name, issue.ID); err != nil {
+			fmt.Fprintf(stdout, "Not closing issue %s with commit %s: %v\n", FormatIssueRef(prefix, issue.Number), shortSHA(c.sha), err)
+			continue
+		}
+
+		if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+			if err := d.store.SetIssueResponded(ctx, tx, r.ID(), issue.ID, user.ID()); err != nil {
+				return err
+			}
+			return d.store.CloseIssueByCommit(ctx, tx, r.ID(), issue.ID, user.ID(), c.sha)
+		}); err != nil {
+			d.logger.Error("error closing issue", "repo", name, "issue", issue.Number, "commit", c.sha, "err", err)
+			continue
+		}
+
+		d.sendIssueEvent(ctx, r, issue.ID, webhook.IssueEventActionClosed)
+		fmt.Fprintf(stdout, "Closed issue %s with commit %s\n", FormatIssueRef(prefix, issue.Number), shortSHA(c.sha))
+	}
+}
+
+// indexIssueReferences adds the references to issues of a commit to the
+// reference index. References to missing issues are left out.
+func (d *Backend) indexIssueReferences(ctx context.Context, r proto.Repository, sha string, refs []issueReference) {
+	for _, ref := range refs {
+		issue, err := d.store.GetIssueByNumber(ctx, d.db, r.ID(), ref.number)
+		if err != nil {
+			continue
+		}
+		if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+			return d.store.AddCommitIssueReference(ctx, tx, r.ID(), sha, issue.ID, ref.closes)
+		}); err != nil {
+			d.logger.Error("error indexing issue reference", "repo", r.Name(), "commit", sha, "issue", issue.Number, "err", err)
+		}
+	}
+}
+
+// indexMergeRequestCommits adds the commits a merge request introduced with
+// its merge commit to the reference index: the merge commit and the commits
+// it brought into the target branch. The merge already happened, so errors
+// are logged instead of returned.
+func (d *Backend) indexMergeRequestCommits(ctx context.Context, r proto.Repository, mrID int64, commit string) {
+	out, err := d.command(ctx, "rev-list", "--max-count="+strconv.Itoa(maxIndexedCommits), commit, "^"+commit+"^1").
+		RunInDir(r.(*repo).path)
+	if err != nil {
+		d.logger.Error("error listing merged commits", "repo", r.Name(), "merge_request", mrID, "err", err)
+		return
+	}
+
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		for _, sha := range strings.Fields(string(out)) {
+			if err := d.store.AddMergeRequestCommit(ctx, tx, r.ID(), mrID, sha); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		d.logger.Error("error indexing merged commits", "repo", r.Name(), "merge_request", mrID, "err", err)
+	}
+}
+
+// shortSHA returns the abbreviated form of a commit SHA.
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
@@ -0,0 +1,28 @@
+package backend
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseIssueReferences(t *testing.T) {
+	cases := []struct {
+		prefix string
+		msg    string
+		want   []issueReference
+	}{
+		{"", "Add license", nil},
+		{"", "Fixes #12", []issueReference{{12, true}}},
+		{"", "See #3 and #4.\n\nCloses: #3", []issueReference{{3, true}, {4, false}}},
+		{"", "resolved #7, fix #8", []issueReference{{7, true}, {8, true}}},
+		{"SRV", "Refactor SRV-2 (closes SRV-5)", []issueReference{{2, false}, {5, true}}},
+		{"", "Refactor SRV-2", nil},
+		{"", "Merge request #1: Add license", nil},
+		{"", "foo#1 #2abc #0", nil},
+	}
+	for _, c := range cases {
+		if got := parseIssueReferences(c.prefix, c.msg); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("parseIssueReferences(%q, %q) = %v, want %v", c.prefix, c.msg, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,207 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/soft-serve/git"
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/hooks"
+	"github.com/charmbracelet/soft-serve/pkg/utils"
+)
+
+var (
+	// ErrNoCommitRules is returned when a repository has no commit message
+	// rules.
+	ErrNoCommitRules = errors.New("repository has no commit message rules")
+
+	// ErrCommitRules is returned when pushed commits break the commit message
+	// rules of a repository.
+	ErrCommitRules = errors.New("commit messages break the rules of the repository")
+
+	conventionalSubjectRe = regexp.MustCompile(`^[a-z]+(\([^()]+\))?!?: \S`)
+	trailerKeyRe          = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9-]*$`)
+	trailerRe             = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9-]*):\s*(\S.*)$`)
+)
+
+// CommitRules returns the commit message rules of a repository.
+func (d *Backend) CommitRules(ctx context.Context, repoName string) (models.CommitRules, error) {
+	repoName = utils.SanitizeRepo(repoName)
+
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return models.CommitRules{}, err
+	}
+
+	rules, err := d.store.GetCommitRulesByRepoID(ctx, d.db, r.ID())
+	if err != nil {
+		err = db.WrapError(err)
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return models.CommitRules{}, ErrNoCommitRules
+		}
+		return models.CommitRules{}, err
+	}
+
+	return rules, nil
+}
+
+// SetCommitRules sets the commit message rules of a repository, checked on
+// every push.
+func (d *Backend) SetCommitRules(ctx context.Context, repoName string, rules models.CommitRules) error {
+	repoName = utils.SanitizeRepo(repoName)
+	if rules.MaxSubjectLength < 0 {
+		return errors.New("max subject length must be positive")
+	}
+
+	var trailers []string
+	for _, t := range strings.Split(rules.RequiredTrailers, ",") {
+		t = strings.TrimSpace(t)
+		if t == "" {
+			continue
+		}
+		if !trailerKeyRe.MatchString(t) {
+			return fmt.Errorf("invalid trailer %q", t)
+		}
+		trailers = append(trailers, t)
+	}
+	rules.RequiredTrailers = strings.Join(trailers, ",")
+
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return err
+	}
+	rules.RepoID = r.ID()
+
+	return db.WrapError(d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		return d.store.SetCommitRules(ctx, tx, rules)
+	}))
+}
+
+// DeleteCommitRules deletes the commit message rules of a repository.
+func (d *Backend) DeleteCommitRules(ctx context.Context, repoName string) error {
+	repoName = utils.SanitizeRepo(repoName)
+
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return err
+	}
+
+	return db.WrapError(d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		return d.store.DeleteCommitRulesByRepoID(ctx, tx, r.ID())
+	}))
+}
+
+// LintCommitMessage returns how a commit message breaks rules, with hints on
+// how to fix it.
+func LintCommitMessage(rules models.CommitRules, msg string) []string {
+	msg = strings.TrimSpace(msg)
+	subject, _, _ := strings.Cut(msg, "\n")
+	subject = strings.TrimSpace(subject)
+
+	var problems []string
+	if rules.Conventional && !conventionalSubjectRe.MatchString(subject) {
+		problems = append(problems, `subject must follow Conventional Commits, e.g. "fix(ssh): handle timeouts"`)
+	}
+	if n := utf8.RuneCountInString(subject); rules.MaxSubjectLength > 0 && n > rules.MaxSubjectLength {
+		problems = append(problems, fmt.Sprintf("subject is %d characters long, at most %d allowed", n, rules.MaxSubjectLength))
+	}
+
+	if rules.RequiredTrailers == "" {
+		return problems
+	}
+
+	trailers := messageTrailers(msg)
+	for _, t := range strings.Split(rules.RequiredTrailers, ",") {
+		if len(trailers[strings.ToLower(t)]) > 0 {
+			continue
+		}
+		if strings.EqualFold(t, "Signed-off-by") {
+			problems = append(problems, "missing Signed-off-by trailer, add it with `git commit --amend --signoff`")
+		} else {
+			problems = append(problems, fmt.Sprintf("missing %s trailer, add it with `git commit --amend --trailer \"%s: ...\"`", t, t))
+		}
+	}
+
+	return problems
+}
+
+// messageTrailers returns the values of the trailers of a commit message, the
+// "Key: value" lines of its last paragraph, by lowercase key.
+func messageTrailers(msg string) map[string][]string {
+	trailers := map[string][]string{}
+	msg = strings.TrimSpace(msg)
+	i := strings.LastIndex(msg, "\n\n")
+	if i < 0 {
+		return trailers
+	}
+	for _, line := range strings.Split(msg[i+2:], "\n") {
+		if m := trailerRe.FindStringSubmatch(strings.TrimSpace(line)); m != nil {
+			key := strings.ToLower(m[1])
+			trailers[key] = append(trailers[key], strings.TrimSpace(m[2]))
+		}
+	}
+	return trailers
+}
+
+// checkCommitMessages checks the messages of the commits pushed to the
+// branches of a repository against its rules. Violations are written to
+// stderr, and reject the push unless the rules are in warn-only mode.
+func (d *Backend) checkCommitMessages(ctx context.Context, name string, args []hooks.HookArg, stderr io.Writer) error {
+	r, err := d.Repository(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	rules, err := d.store.GetCommitRulesByRepoID(ctx, d.db, r.ID())
+	if err != nil {
+		if errors.Is(db.WrapError(err), db.ErrRecordNotFound) {
+			return nil
+		}
+		return db.WrapError(err)
+	}
+
+	// The commits already reachable from a ref were checked when pushed.
+	revs := []string{"log", "--no-merges", "--format=%h%x1f%B%x1e"}
+	for _, arg := range args {
+		if strings.HasPrefix(arg.RefName, git.RefsHeads) && !git.IsZeroHash(arg.NewSha) {
+			revs = append(revs, arg.NewSha)
+		}
+	}
+	if len(revs) == 3 {
+		return nil
+	}
+	revs = append(revs, "--not", "--all")
+
+	out, err := d.command(ctx, revs...).RunInDir(r.(*repo).path)
+	if err != nil {
+		return err
+	}
+
+	var report strings.Builder
+	for _, c := range strings.Split(string(out), "\x1e") {
+		sha, msg, ok := strings.Cut(strings.TrimSpace(c), "\x1f")
+		if !ok {
+			continue
+		}
+		subject, _, _ := strings.Cut(strings.TrimSpace(msg), "\n")
+		for _, p := range LintCommitMessage(rules, msg) {
+			fmt.Fprintf(&report, "  %s %q: %s\n", sha, subject, p)
+		}
+	}
+	if report.Len() == 0 {
+		return nil
+	}
+
+	if rules.WarnOnly {
+		fmt.Fprintf(stderr, "warning: commit messages break the rules of %s:\n%s", r.Name(), report.String())
+		return nil
+	}
+
+	return fmt.Errorf("%w:\n%s  Reword the commits, e.g. with `git rebase -i`, and push again.", ErrCommitRules, report.String())
+}
@@ -0,0 +1,45 @@
+package backend
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+)
+
+func TestLintCommitMessage(t *testing.T) {
+	rules := models.CommitRules{
+		Conventional:     true,
+		MaxSubjectLength: 30,
+		RequiredTrailers: "Signed-off-by,Change-Id",
+	}
+
+	cases := []struct {
+		name     string
+		msg      string
+		problems []string
+	}{
+		{"valid", "fix(ssh): handle timeouts\n\nSome details.\n\nSigned-off-by: A <a@b.c>\nChange-Id: I123\n", nil},
+		{"breaking change", "feat!: drop v1\n\nsigned-off-by: A <a@b.c>\nChange-Id: I123", nil},
+		{"not conventional", "Handle timeouts\n\nSigned-off-by: A <a@b.c>\nChange-Id: I123", []string{"Conventional Commits"}},
+		{"too long", "fix: handle the timeouts of ssh sessions\n\nSigned-off-by: A <a@b.c>\nChange-Id: I123", []string{"40 characters long"}},
+		{"trailers in the subject", "fix: Signed-off-by: A", []string{"--signoff", "Change-Id trailer"}},
+		{"missing trailer", "fix: handle timeouts\n\nSigned-off-by: A <a@b.c>", []string{"missing Change-Id trailer"}},
+	}
+	for _, c := range cases {
+		problems := LintCommitMessage(rules, c.msg)
+		if len(problems) != len(c.problems) {
+			t.Errorf("%s: LintCommitMessage() = %q, want %d problems", c.name, problems, len(c.problems))
+			continue
+		}
+		for i, p := range c.problems {
+			if !strings.Contains(problems[i], p) {
+				t.Errorf("%s: problem %q doesn't mention %q", c.name, problems[i], p)
+			}
+		}
+	}
+
+	if problems := LintCommitMessage(models.CommitRules{}, "anything goes"); len(problems) != 0 {
+		t.Errorf("LintCommitMessage() without rules = %q", problems)
+	}
+}
@@ -0,0 +1,181 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/soft-serve/git"
+	"github.com/charmbracelet/soft-serve/pkg/utils"
+	lru "github.com/hashicorp/golang-lru/v2"
+)
+
+// compareCacheEntries is the number of merge bases and ahead/behind counts
+// kept in memory.
+const compareCacheEntries = 4096
+
+// ErrNothingToMerge is returned when merging a merge request whose source
+// branch has no commits its target branch doesn't have.
+var ErrNothingToMerge = errors.New("source branch has no commits to merge")
+
+// Comparison is how two revisions of a repository relate.
+type Comparison struct {
+	// Base and Head are the commit IDs of the compared revisions.
+	Base string
+	Head string
+	// MergeBase is the best common ancestor of Base and Head, empty when they
+	// don't share history.
+	MergeBase string
+	// Ahead is the number of commits of Head that Base doesn't have, and
+	// Behind the number of commits of Base that Head doesn't have.
+	Ahead  int
+	Behind int
+}
+
+// Compare compares two revisions of a repository.
+func (d *Backend) Compare(ctx context.Context, repoName string, base, head string) (Comparison, error) {
+	gr, base, head, err := d.resolveCompare(ctx, repoName, base, head)
+	if err != nil {
+		return Comparison{}, err
+	}
+
+	c := Comparison{Base: base, Head: head}
+	if c.MergeBase, err = d.mergeBase(ctx, gr, base, head); err != nil {
+		return Comparison{}, err
+	}
+	if c.Ahead, c.Behind, err = d.aheadBehind(ctx, gr, base, head); err != nil {
+		return Comparison{}, err
+	}
+	return c, nil
+}
+
+// MergeBase returns the best common ancestor of two revisions of a
+// repository, or an empty string when they don't share history.
+func (d *Backend) MergeBase(ctx context.Context, repoName string, a, b string) (string, error) {
+	gr, a, b, err := d.resolveCompare(ctx, repoName, a, b)
+	if err != nil {
+		return "", err
+	}
+	return d.mergeBase(ctx, gr, a, b)
+}
+
+// AheadBehind returns the number of commits of head that base doesn't have,
+// and the number of commits of base that head doesn't have.
+func (d *Backend) AheadBehind(ctx context.Context, repoName string, base, head string) (ahead int, behind int, err error) {
+	gr, base, head, err := d.resolveCompare(ctx, repoName, base, head)
+	if err != nil {
+		return 0, 0, err
+	}
+	return d.aheadBehind(ctx, gr, base, head)
+}
+
+// resolveCompare opens a repository and resolves two of its revisions to
+// commit IDs.
+func (d *Backend) resolveCompare(ctx context.Context, repoName string, a, b string) (*git.Repository, string, string, error) {
+	repoName = utils.SanitizeRepo(repoName)
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return nil, "", "", err
+	}
+	gr, err := r.Open()
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	ids := [2]string{a, b}
+	for i, rev := range ids {
+		id, err := d.revParse(ctx, gr, rev)
+		if err != nil {
+			return nil, "", "", fmt.Errorf("%w: %s", git.ErrRevisionNotExist, rev)
+		}
+		ids[i] = id
+	}
+	return gr, ids[0], ids[1], nil
+}
+
+// mergeBase is MergeBase for resolved commit IDs.
+func (d *Backend) mergeBase(ctx context.Context, gr *git.Repository, a, b string) (string, error) {
+	key := compareKey{path: gr.Path, a: a, b: b}
+	if base, ok := d.compares.mergeBase(key); ok {
+		return base, nil
+	}
+
+	// merge-base exits with 1 when the commits don't share history.
+	out, err := d.command(ctx, gitArgs("merge-base", nil, a, b)...).RunInDir(gr.Path)
+	if err != nil && !strings.Contains(err.Error(), "exit status 1") {
+		return "", err
+	}
+	base := strings.TrimSpace(string(out))
+	d.compares.setMergeBase(key, base)
+	return base, nil
+}
+
+// aheadBehind is AheadBehind for resolved commit IDs.
+func (d *Backend) aheadBehind(ctx context.Context, gr *git.Repository, base, head string) (int, int, error) {
+	key := compareKey{path: gr.Path, a: base, b: head}
+	if c, ok := d.compares.counts(key); ok {
+		return c[0], c[1], nil
+	}
+
+	out, err := d.command(ctx, gitArgs("rev-list", []string{"--left-right", "--count"}, base+"..."+head)...).RunInDir(gr.Path)
+	if err != nil {
+		return 0, 0, err
+	}
+	left, right, _ := strings.Cut(strings.TrimSpace(string(out)), "\t")
+	behind, err := strconv.Atoi(left)
+	if err != nil {
+		return 0, 0, fmt.Errorf("unexpected rev-list output %q", out)
+	}
+	ahead, err := strconv.Atoi(right)
+	if err != nil {
+		return 0, 0, fmt.Errorf("unexpected rev-list output %q", out)
+	}
+
+	d.compares.setCounts(key, [2]int{ahead, behind})
+	return ahead, behind, nil
+}
+
+// compareKey identifies a pair of commits of a repository. Commits never
+// change, so neither do their merge base and ahead/behind counts.
+type compareKey struct {
+	path string
+	a, b string
+}
+
+// compareCache holds the most recently used merge bases and ahead/behind
+// counts. The zero value is ready to use.
+type compareCache struct {
+	once       sync.Once
+	mergeBases *lru.Cache[compareKey, string]
+	aheads     *lru.Cache[compareKey, [2]int]
+}
+
+func (c *compareCache) init() {
+	c.once.Do(func() {
+		c.mergeBases, _ = lru.New[compareKey, string](compareCacheEntries)
+		c.aheads, _ = lru.New[compareKey, [2]int](compareCacheEntries)
+	})
+}
+
+func (c *compareCache) mergeBase(key compareKey) (string, bool) {
+	c.init()
+	return c.mergeBases.Get(key)
+}
+
+func (c *compareCache) setMergeBase(key compareKey, base string) {
+	c.init()
+	c.mergeBases.Add(key, base)
+}
+
+func (c *compareCache) counts(key compareKey) ([2]int, bool) {
+	c.init()
+	return c.aheads.Get(key)
+}
+
+func (c *compareCache) setCounts(key compareKey, counts [2]int) {
+	c.init()
+	c.aheads.Add(key, counts)
+}
@@ -0,0 +1,108 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/charmbracelet/soft-serve/pkg/access"
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/utils"
+)
+
+// ErrNoContributingGuide is returned when a repository has no contributor
+// guide.
+var ErrNoContributingGuide = errors.New("repository has no contributing guide")
+
+// contributingSummarySize is the maximum size of the summary of a
+// contributing guide.
+const contributingSummarySize = 280
+
+// contributingPatterns are where contributing guides are looked up, in order.
+var contributingPatterns = []string{
+	"[cC][oO][nN][tT][rR][iI][bB][uU][tT][iI][nN][gG]*",
+	".github/[cC][oO][nN][tT][rR][iI][bB][uU][tT][iI][nN][gG]*",
+	"docs/[cC][oO][nN][tT][rR][iI][bB][uU][tT][iI][nN][gG]*",
+}
+
+// ContributingGuide is the contributor guide of a repository, its
+// CONTRIBUTING.md.
+type ContributingGuide struct {
+	Path    string
+	Content string
+	// Summary is the first paragraph of the guide.
+	Summary string
+}
+
+// ContributingGuide returns the contributing guide on the default branch of a
+// repository.
+func (d *Backend) ContributingGuide(ctx context.Context, repoName string) (ContributingGuide, error) {
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return ContributingGuide{}, err
+	}
+
+	for _, pattern := range contributingPatterns {
+		content, path, err := LatestFile(r, nil, pattern)
+		if err != nil || path == "" {
+			continue
+		}
+		return ContributingGuide{
+			Path:    path,
+			Content: content,
+			Summary: contributingSummary(content),
+		}, nil
+	}
+
+	return ContributingGuide{}, ErrNoContributingGuide
+}
+
+// contributingSummary returns the first paragraph of a Markdown contributing
+// guide, skipping headings, badges, and HTML, on a single line.
+func contributingSummary(content string) string {
+	for _, p := range strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n\n") {
+		p = strings.TrimSpace(p)
+		if p == "" || strings.HasPrefix(p, "#") || strings.HasPrefix(p, "<") ||
+			strings.HasPrefix(p, "[![") || strings.HasPrefix(p, "---") {
+			continue
+		}
+
+		summary := strings.Join(strings.Fields(p), " ")
+		if s, truncated := utils.TruncateText(summary, contributingSummarySize); truncated {
+			summary = strings.TrimSpace(s) + "…"
+		}
+		return summary
+	}
+	return ""
+}
+
+// IsFirstContribution returns true if an issue or merge request opened by
+// user would be their first contribution to a repository: they haven't
+// opened any yet, and aren't a collaborator.
+func (d *Backend) IsFirstContribution(ctx context.Context, repoName string, user proto.User) (bool, error) {
+	repoName = utils.SanitizeRepo(repoName)
+	if user == nil {
+		return false, nil
+	}
+
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return false, err
+	}
+
+	if d.AccessLevelForUser(ctx, r.Name(), user) >= access.ReadWriteAccess {
+		return false, nil
+	}
+
+	issues, err := d.store.CountIssuesByAuthorID(ctx, d.db, r.ID(), user.ID())
+	if err != nil {
+		return false, db.WrapError(err)
+	}
+	mrs, err := d.store.CountMergeRequestsByAuthorID(ctx, d.db, r.ID(), user.ID())
+	if err != nil {
+		return false, db.WrapError(err)
+	}
+
+	return issues+mrs == 0, nil
+}
@@ -0,0 +1,29 @@
+package backend
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestContributingSummary(t *testing.T) {
+	cases := []struct {
+		name    string
+		content string
+		summary string
+	}{
+		{"empty", "", ""},
+		{"headings only", "# Contributing\n\n## Setup\n", ""},
+		{"first paragraph", "# Contributing\n\nThanks for helping!\nOpen an issue first.\n\n## Setup\n\nRun make.", "Thanks for helping! Open an issue first."},
+		{"badges and html", "[![CI](https://ci/badge.svg)](https://ci)\n\n<!-- toc -->\n\r\nPlease sign off.", "Please sign off."},
+	}
+	for _, c := range cases {
+		if summary := contributingSummary(c.content); summary != c.summary {
+			t.Errorf("%s: expected %q, got %q", c.name, c.summary, summary)
+		}
+	}
+
+	long := contributingSummary(strings.Repeat("word ", 100))
+	if !strings.HasSuffix(long, "…") || len(long) > contributingSummarySize+len("…") {
+		t.Errorf("expected a truncated summary, got %q", long)
+	}
+}
@@ -0,0 +1,272 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/charmbracelet/soft-serve/git"
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/utils"
+)
+
+var (
+	// ErrNoContributionAgreements is returned when a repository has no
+	// contribution agreements.
+	ErrNoContributionAgreements = errors.New("repository has no contribution agreements")
+
+	// ErrNoCLA is returned when accepting the contributor license agreement
+	// of a repository that doesn't have one.
+	ErrNoCLA = errors.New("repository has no contributor license agreement")
+
+	// ErrAgreementsUnsatisfied is returned when merging a merge request that
+	// doesn't satisfy the contribution agreements of its repository.
+	ErrAgreementsUnsatisfied = errors.New("merge request doesn't satisfy the contribution agreements")
+)
+
+// ContributionAgreements returns the contribution agreements of a repository.
+func (d *Backend) ContributionAgreements(ctx context.Context, repoName string) (models.ContributionAgreements, error) {
+	repoName = utils.SanitizeRepo(repoName)
+
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return models.ContributionAgreements{}, err
+	}
+
+	agreements, err := d.store.GetContributionAgreementsByRepoID(ctx, d.db, r.ID())
+	if err != nil {
+		err = db.WrapError(err)
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return models.ContributionAgreements{}, ErrNoContributionAgreements
+		}
+		return models.ContributionAgreements{}, err
+	}
+
+	return agreements, nil
+}
+
+// SetContributionAgreements sets the contribution agreements of a repository:
+// whether every commit of a merge request must be signed off by its author
+// (DCO), and the path of a contributor license agreement document on the
+// default branch the author of a merge request must accept (CLA).
+func (d *Backend) SetContributionAgreements(ctx context.Context, repoName string, requireDCO bool, claPath string) error {
+	repoName = utils.SanitizeRepo(repoName)
+
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return err
+	}
+
+	if claPath != "" {
+		claPath = strings.TrimPrefix(path.Clean("/"+claPath), "/")
+		if _, err := d.claDocumentSha(ctx, r, claPath); err != nil {
+			return err
+		}
+	}
+
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		return d.store.SetContributionAgreements(ctx, tx, models.ContributionAgreements{
+			RepoID:     r.ID(),
+			RequireDCO: requireDCO,
+			CLAPath:    claPath,
+		})
+	}); err != nil {
+		return db.WrapError(err)
+	}
+
+	d.audit(ctx, "repo.agreements", r.Name(), fmt.Sprintf("dco: %t, cla: %q", requireDCO, claPath))
+	return nil
+}
+
+// DeleteContributionAgreements deletes the contribution agreements of a
+// repository.
+func (d *Backend) DeleteContributionAgreements(ctx context.Context, repoName string) error {
+	repoName = utils.SanitizeRepo(repoName)
+
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return err
+	}
+
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		return d.store.DeleteContributionAgreementsByRepoID(ctx, tx, r.ID())
+	}); err != nil {
+		return db.WrapError(err)
+	}
+
+	d.audit(ctx, "repo.agreements", r.Name(), "deleted")
+	return nil
+}
+
+// claDocumentSha returns the blob of the contributor license agreement
+// document at path on the default branch of a repository. Changing the
+// document changes its blob, and requires authors to accept it again.
+func (d *Backend) claDocumentSha(ctx context.Context, r proto.Repository, path string) (string, error) {
+	out, err := d.command(ctx, gitArgs("rev-parse", []string{"--verify", "--quiet"}, "HEAD:"+path)...).RunInDir(r.(*repo).path)
+	if err != nil {
+		return "", fmt.Errorf("contributor license agreement %s not found on the default branch", path)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// AcceptCLA records that the current user accepts the current version of the
+// contributor license agreement of a repository.
+func (d *Backend) AcceptCLA(ctx context.Context, repoName string) error {
+	user := proto.UserFromContext(ctx)
+	if user == nil {
+		return proto.ErrUserNotFound
+	}
+
+	agreements, err := d.ContributionAgreements(ctx, repoName)
+	if errors.Is(err, ErrNoContributionAgreements) || (err == nil && agreements.CLAPath == "") {
+		return ErrNoCLA
+	} else if err != nil {
+		return err
+	}
+
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return err
+	}
+
+	sha, err := d.claDocumentSha(ctx, r, agreements.CLAPath)
+	if err != nil {
+		return err
+	}
+
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		return d.store.CreateCLASignature(ctx, tx, r.ID(), user.ID(), sha)
+	}); err != nil {
+		err = db.WrapError(err)
+		if errors.Is(err, db.ErrDuplicateKey) {
+			return nil
+		}
+		return err
+	}
+
+	d.audit(ctx, "repo.cla.accept", r.Name(), fmt.Sprintf("%s at %s", agreements.CLAPath, sha))
+	return nil
+}
+
+// HasAcceptedCLA returns true if a user accepted the current version of the
+// contributor license agreement of a repository.
+func (d *Backend) HasAcceptedCLA(ctx context.Context, repoName string, user proto.User) (bool, error) {
+	agreements, err := d.ContributionAgreements(ctx, repoName)
+	if errors.Is(err, ErrNoContributionAgreements) || (err == nil && agreements.CLAPath == "") {
+		return false, ErrNoCLA
+	} else if err != nil {
+		return false, err
+	}
+
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return false, err
+	}
+
+	return d.hasAcceptedCLA(ctx, r, agreements, user.ID())
+}
+
+// hasAcceptedCLA returns true if the user with the given id accepted the
+// current version of the contributor license agreement of a repository.
+func (d *Backend) hasAcceptedCLA(ctx context.Context, r proto.Repository, agreements models.ContributionAgreements, userID int64) (bool, error) {
+	sha, err := d.claDocumentSha(ctx, r, agreements.CLAPath)
+	if err != nil {
+		return false, err
+	}
+
+	if _, err := d.store.GetCLASignature(ctx, d.db, r.ID(), userID, sha); err != nil {
+		err = db.WrapError(err)
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+// signedOffBy returns true if a commit message has a Signed-off-by trailer
+// for author, formatted as "Name <email>".
+func signedOffBy(msg string, author string) bool {
+	for _, v := range messageTrailers(msg)["signed-off-by"] {
+		if strings.EqualFold(v, author) {
+			return true
+		}
+	}
+	return false
+}
+
+// MergeRequestAgreementFailures returns how a merge request fails the
+// contribution agreements of its repository, with hints on how to fix it. An
+// empty list means the merge request satisfies them.
+func (d *Backend) MergeRequestAgreementFailures(ctx context.Context, repoName string, mrID int64) ([]string, error) {
+	repoName = utils.SanitizeRepo(repoName)
+
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return nil, err
+	}
+
+	mr, err := d.GetMergeRequest(ctx, repoName, mrID)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.agreementFailures(ctx, r, mr)
+}
+
+// agreementFailures returns how a merge request fails the contribution
+// agreements of its repository.
+func (d *Backend) agreementFailures(ctx context.Context, r proto.Repository, mr models.MergeRequest) ([]string, error) {
+	agreements, err := d.store.GetContributionAgreementsByRepoID(ctx, d.db, r.ID())
+	if err != nil {
+		if errors.Is(db.WrapError(err), db.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, db.WrapError(err)
+	}
+
+	var failures []string
+	if agreements.RequireDCO {
+		out, err := d.command(ctx, gitArgs("log", []string{"--no-merges", "--format=%h%x1f%an <%ae>%x1f%B%x1e"},
+			git.RefsHeads+mr.TargetBranch+".."+git.RefsHeads+mr.SourceBranch)...).RunInDir(r.(*repo).path)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, c := range strings.Split(string(out), "\x1e") {
+			fields := strings.SplitN(strings.TrimSpace(c), "\x1f", 3)
+			if len(fields) != 3 {
+				continue
+			}
+			sha, author, msg := fields[0], fields[1], fields[2]
+			if signedOffBy(msg, author) {
+				continue
+			}
+			subject, _, _ := strings.Cut(strings.TrimSpace(msg), "\n")
+			failures = append(failures, fmt.Sprintf("%s %q isn't signed off by its author %s, sign off with `git rebase --signoff %s`",
+				sha, subject, author, mr.TargetBranch))
+		}
+	}
+
+	if agreements.CLAPath != "" {
+		accepted, err := d.hasAcceptedCLA(ctx, r, agreements, mr.AuthorID)
+		if err != nil {
+			return nil, err
+		}
+		if !accepted {
+			author := fmt.Sprintf("user #%d", mr.AuthorID)
+			if u, err := d.store.GetUserByID(ctx, d.db, mr.AuthorID); err == nil {
+				author = u.Username
+			}
+			failures = append(failures, fmt.Sprintf("%s hasn't accepted the contributor license agreement %s, accept it with `repo agreements accept %s`",
+				author, agreements.CLAPath, r.Name()))
+		}
+	}
+
+	return failures, nil
+}
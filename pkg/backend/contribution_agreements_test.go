@@ -0,0 +1,25 @@
+package backend
+
+import "testing"
+
+func TestSignedOffBy(t *testing.T) {
+	const author = "John Doe <john@example.com>"
+
+	cases := []struct {
+		name   string
+		msg    string
+		signed bool
+	}{
+		{"signed off", "Add readme\n\nSigned-off-by: John Doe <john@example.com>\n", true},
+		{"case insensitive", "Add readme\n\nsigned-off-by: john doe <JOHN@example.com>", true},
+		{"among other trailers", "Add readme\n\nBody.\n\nReviewed-by: Jane <jane@example.com>\nSigned-off-by: John Doe <john@example.com>", true},
+		{"not signed off", "Add readme", false},
+		{"signed off by someone else", "Add readme\n\nSigned-off-by: Jane <jane@example.com>", false},
+		{"not a trailer", "Add readme\n\nSigned-off-by: John Doe <john@example.com>\n\nMore text.", false},
+	}
+	for _, c := range cases {
+		if signed := signedOffBy(c.msg, author); signed != c.signed {
+			t.Errorf("%s: expected %t, got %t", c.name, c.signed, signed)
+		}
+	}
+}
@@ -0,0 +1,239 @@
+package backend
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+
+	"github.com/charmbracelet/soft-serve/pkg/access"
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/utils"
+)
+
+// ReferenceKindCommit is the kind of the references from commit messages.
+const ReferenceKindCommit = "commit"
+
+// crossReferenceRe matches the references to issues, "#12" or "repo#12", and
+// merge requests, "!3" or "repo!3", in titles and descriptions.
+var crossReferenceRe = regexp.MustCompile(`(?:^|[^\w./#!-])((?:[\w.-]+/)*[\w.-]+)?([#!])(\d+)\b`)
+
+// Reference is an issue, merge request, or commit that references an issue
+// or merge request.
+type Reference struct {
+	// Kind is NotificationSubjectIssue, NotificationSubjectMergeRequest, or
+	// ReferenceKindCommit.
+	Kind       string
+	Repository proto.Repository
+	// Number is the number of the issue, or the ID of the merge request.
+	Number int64
+	// Ref is how to reference the issue, merge request, or commit from the
+	// referenced repository, e.g. "#12", "other!3", or a short commit SHA.
+	Ref string
+	// Title is the title of the issue or merge request, empty for commits.
+	Title string
+}
+
+// crossReference is a reference to an issue or merge request in a title or
+// description.
+type crossReference struct {
+	// repo is the name of the referenced repository, empty for the
+	// repository of the title or description.
+	repo string
+	// kind is NotificationSubjectIssue or NotificationSubjectMergeRequest.
+	kind   string
+	number int64
+}
+
+// parseCrossReferences returns the references to issues and merge requests
+// in a text, in order and without duplicates.
+func parseCrossReferences(text string) []crossReference {
+	var refs []crossReference
+	seen := map[crossReference]bool{}
+	for _, m := range crossReferenceRe.FindAllStringSubmatch(text, -1) {
+		n, err := strconv.ParseInt(m[3], 10, 64)
+		if err != nil || n <= 0 {
+			continue
+		}
+		ref := crossReference{repo: utils.SanitizeRepo(m[1]), kind: NotificationSubjectIssue, number: n}
+		if m[2] == "!" {
+			ref.kind = NotificationSubjectMergeRequest
+		}
+		if seen[ref] {
+			continue
+		}
+		seen[ref] = true
+		refs = append(refs, ref)
+	}
+	return refs
+}
+
+// indexCrossReferences replaces the references of an issue or merge request
+// of r with the ones in its title and description. References to missing
+// issues and merge requests, or to repositories the current user can't read,
+// are left out. The issue or merge request is already saved, so errors are
+// logged instead of returned.
+func (d *Backend) indexCrossReferences(ctx context.Context, r proto.Repository, sourceType string, sourceID int64, title string, description string) {
+	user := proto.UserFromContext(ctx)
+
+	var xrefs []models.CrossReference
+	for _, ref := range parseCrossReferences(title + "\n" + description) {
+		target := r
+		if ref.repo != "" && ref.repo != r.Name() {
+			var err error
+			target, err = d.Repository(ctx, ref.repo)
+			if err != nil || d.AccessLevelForUser(ctx, target.Name(), user) < access.GuestAccess {
+				continue
+			}
+		}
+
+		var targetID int64
+		switch ref.kind {
+		case NotificationSubjectIssue:
+			issue, err := d.store.GetIssueByNumber(ctx, d.db, target.ID(), ref.number)
+			if err != nil {
+				continue
+			}
+			targetID = issue.ID
+		case NotificationSubjectMergeRequest:
+			mr, err := d.store.GetMergeRequestByID(ctx, d.db, target.ID(), ref.number)
+			if err != nil {
+				continue
+			}
+			targetID = mr.ID
+		}
+		if ref.kind == sourceType && targetID == sourceID {
+			continue
+		}
+
+		xrefs = append(xrefs, models.CrossReference{
+			RepoID:       r.ID(),
+			SourceType:   sourceType,
+			SourceID:     sourceID,
+			TargetRepoID: target.ID(),
+			TargetType:   ref.kind,
+			TargetID:     targetID,
+		})
+	}
+
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		if err := d.store.DeleteCrossReferencesBySource(ctx, tx, sourceType, sourceID); err != nil {
+			return err
+		}
+		for _, xref := range xrefs {
+			if err := d.store.AddCrossReference(ctx, tx, xref); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		d.logger.Error("error indexing cross references", "repo", r.Name(), sourceType, sourceID, "err", err)
+	}
+}
+
+// ReferencedBy returns the issues and merge requests whose title or
+// description references an issue or merge request of a repository, and the
+// commits whose message references the issue, oldest first. References from
+// repositories the current user can't see are left out, and so are commits
+// when the user can't read the code.
+func (d *Backend) ReferencedBy(ctx context.Context, repoName string, subject Subject) ([]Reference, error) {
+	repoName = utils.SanitizeRepo(repoName)
+
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return nil, err
+	}
+
+	xrefs, err := d.store.GetCrossReferencesByTarget(ctx, d.db, r.ID(), subject.Type, subject.ID)
+	if err != nil {
+		return nil, db.WrapError(err)
+	}
+
+	user := proto.UserFromContext(ctx)
+	prefix := ""
+	if len(xrefs) > 0 {
+		prefix, err = d.IssuePrefix(ctx, repoName)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var refs []Reference
+	repos := map[int64]proto.Repository{r.ID(): r}
+	for _, xref := range xrefs {
+		source, ok := repos[xref.RepoID]
+		if !ok {
+			source, err = d.repositoryByID(ctx, xref.RepoID)
+			if err != nil {
+				continue
+			}
+			repos[xref.RepoID] = source
+		}
+		if d.AccessLevelForUser(ctx, source.Name(), user) < access.GuestAccess {
+			continue
+		}
+
+		ref := Reference{Kind: xref.SourceType, Repository: source}
+		switch xref.SourceType {
+		case NotificationSubjectIssue:
+			if enabled, err := d.IsIssuesEnabled(ctx, source.Name()); err != nil || !enabled {
+				continue
+			}
+			issue, err := d.store.GetIssueByID(ctx, d.db, source.ID(), xref.SourceID)
+			if err != nil || !issueVisible(issue, user) {
+				continue
+			}
+			ref.Number, ref.Title = issue.Number, issue.Title
+			if source.ID() == r.ID() {
+				ref.Ref = FormatIssueRef(prefix, issue.Number)
+			} else {
+				ref.Ref = source.Name() + "#" + strconv.FormatInt(issue.Number, 10)
+			}
+		case NotificationSubjectMergeRequest:
+			if enabled, err := d.IsMergeRequestsEnabled(ctx, source.Name()); err != nil || !enabled {
+				continue
+			}
+			mr, err := d.store.GetMergeRequestByID(ctx, d.db, source.ID(), xref.SourceID)
+			if err != nil {
+				continue
+			}
+			ref.Number, ref.Title = mr.ID, mr.Title
+			ref.Ref = "!" + strconv.FormatInt(mr.ID, 10)
+			if source.ID() != r.ID() {
+				ref.Ref = source.Name() + ref.Ref
+			}
+		default:
+			continue
+		}
+		refs = append(refs, ref)
+	}
+
+	if subject.Type != NotificationSubjectIssue || d.AccessLevelForUser(ctx, repoName, user) < access.ReadOnlyAccess {
+		return refs, nil
+	}
+
+	crefs, err := d.store.GetIssueCommitReferences(ctx, d.db, r.ID(), subject.ID)
+	if err != nil {
+		return nil, db.WrapError(err)
+	}
+	for _, cref := range crefs {
+		refs = append(refs, Reference{Kind: ReferenceKindCommit, Repository: r, Ref: shortSHA(cref.CommitSHA)})
+	}
+
+	return refs, nil
+}
+
+// repositoryByID returns a repository by its ID.
+func (d *Backend) repositoryByID(ctx context.Context, id int64) (proto.Repository, error) {
+	repos, err := d.Repositories(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range repos {
+		if r.ID() == id {
+			return r, nil
+		}
+	}
+	return nil, proto.ErrRepoNotFound
+}
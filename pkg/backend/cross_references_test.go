@@ -0,0 +1,31 @@
+package backend
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseCrossReferences(t *testing.T) {
+	cases := []struct {
+		text string
+		want []crossReference
+	}{
+		{"No references", nil},
+		{"See #12", []crossReference{{"", NotificationSubjectIssue, 12}}},
+		{"Blocked by !3 and #4, see #4 again", []crossReference{
+			{"", NotificationSubjectMergeRequest, 3},
+			{"", NotificationSubjectIssue, 4},
+		}},
+		{"Duplicate of api#7 (myteam/web!2)", []crossReference{
+			{"api", NotificationSubjectIssue, 7},
+			{"myteam/web", NotificationSubjectMergeRequest, 2},
+		}},
+		{"# Heading\n#0 #12abc", nil},
+		{"https://example.com/page#12", nil},
+	}
+	for _, c := range cases {
+		if got := parseCrossReferences(c.text); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("parseCrossReferences(%q) = %v, want %v", c.text, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package backend
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/dustin/go-humanize"
+)
+
+// The styles dates are shown in.
+const (
+	// DateStyleAuto shows dates relative to now in lists and absolute in
+	// details.
+	DateStyleAuto = "auto"
+	// DateStyleRelative always shows dates relative to now.
+	DateStyleRelative = "relative"
+	// DateStyleAbsolute always shows dates absolute.
+	DateStyleAbsolute = "absolute"
+)
+
+// DefaultDateFormat is the date format of users who haven't picked one.
+const DefaultDateFormat = "iso"
+
+// DateFormats are the named date formats and their Go layouts.
+var DateFormats = map[string]string{
+	"iso":     "2006-01-02 15:04:05",
+	"rfc3339": time.RFC3339,
+	"rfc1123": time.RFC1123,
+	"us":      "01/02/2006 03:04 PM",
+	"eu":      "02/01/2006 15:04",
+}
+
+// StableDates formats dates the same for every user and every run: absolute,
+// in UTC, as RFC 3339. Scripts and tests parse them.
+var StableDates = Dates{Location: time.UTC, Layout: time.RFC3339, Style: DateStyleAbsolute}
+
+// DateStyles are the valid date styles.
+var DateStyles = []string{DateStyleAuto, DateStyleRelative, DateStyleAbsolute}
+
+// Dates formats dates according to the preferences of a user. The zero value
+// formats dates in the server's time zone with the default format.
+type Dates struct {
+	// Location is the time zone dates are shown in, nil for the server's.
+	Location *time.Location
+	// Layout is the Go layout of absolute dates.
+	Layout string
+	// Style tells whether dates are shown relative to now.
+	Style string
+}
+
+// UserDates returns the dates formatting of the given preferences. Invalid
+// preferences fall back to the defaults.
+func UserDates(prefs models.UserPreferences) Dates {
+	var d Dates
+	if prefs.Timezone != "" {
+		if loc, err := time.LoadLocation(prefs.Timezone); err == nil {
+			d.Location = loc
+		}
+	}
+	if layout, err := DateLayout(prefs.DateFormat); err == nil {
+		d.Layout = layout
+	}
+	if ValidateDateStyle(prefs.DateStyle) == nil {
+		d.Style = prefs.DateStyle
+	}
+	return d
+}
+
+// UserDates returns the dates formatting of a user.
+func (d *Backend) UserDates(ctx context.Context, user proto.User) Dates {
+	prefs, _ := d.UserPreferences(ctx, user)
+	return UserDates(prefs)
+}
+
+// Absolute formats a date where it is shown in full, e.g. in the details of
+// an issue.
+func (d Dates) Absolute(t time.Time) string {
+	if d.Style == DateStyleRelative {
+		return humanize.Time(t)
+	}
+	return d.format(t)
+}
+
+// Relative formats a date where it is shown in short, e.g. in lists.
+func (d Dates) Relative(t time.Time) string {
+	if d.Style == DateStyleAbsolute {
+		return d.format(t)
+	}
+	return humanize.Time(t)
+}
+
+func (d Dates) format(t time.Time) string {
+	if d.Location != nil {
+		t = t.In(d.Location)
+	}
+	layout := d.Layout
+	if layout == "" {
+		layout = DateFormats[DefaultDateFormat]
+	}
+	return t.Format(layout)
+}
+
+// DateLayout returns the Go layout of a date format: the name of one of
+// DateFormats or a Go layout. The empty format is the default one.
+func DateLayout(format string) (string, error) {
+	if format == "" {
+		format = DefaultDateFormat
+	}
+	if layout, ok := DateFormats[strings.ToLower(format)]; ok {
+		return layout, nil
+	}
+	// A layout formats the reference time differently from itself, anything
+	// else has no layout elements.
+	if time.Date(2001, 2, 3, 4, 5, 6, 0, time.UTC).Format(format) == format {
+		return "", fmt.Errorf("invalid date format %q", format)
+	}
+	return format, nil
+}
+
+// ValidateDateStyle returns an error if style isn't a date style. The empty
+// style is the default one.
+func ValidateDateStyle(style string) error {
+	if style == "" {
+		return nil
+	}
+	for _, s := range DateStyles {
+		if s == style {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid date style %q, must be one of %s", style, strings.Join(DateStyles, ", "))
+}
+
+// ValidateTimezone returns an error if tz isn't the name of a time zone. The
+// empty name is the server's time zone.
+func ValidateTimezone(tz string) error {
+	if tz == "" {
+		return nil
+	}
+	if _, err := time.LoadLocation(tz); err != nil {
+		return fmt.Errorf("invalid time zone %q", tz)
+	}
+	return nil
+}
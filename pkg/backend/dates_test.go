@@ -0,0 +1,73 @@
+package backend
+
+import (
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+)
+
+func TestDateLayout(t *testing.T) {
+	cases := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{"", "2006-01-02 15:04:05", false},
+		{"iso", "2006-01-02 15:04:05", false},
+		{"RFC3339", time.RFC3339, false},
+		{"Jan 2, 2006", "Jan 2, 2006", false},
+		{"yyyy-mm-dd", "", true},
+	}
+
+	for _, c := range cases {
+		got, err := DateLayout(c.in)
+		if (err != nil) != c.wantErr {
+			t.Errorf("DateLayout(%q) error = %v, want error %t", c.in, err, c.wantErr)
+			continue
+		}
+		if got != c.want {
+			t.Errorf("DateLayout(%q) = %q, want %q", c.in, got, c.want)
+		}
+	}
+}
+
+func TestUserDates(t *testing.T) {
+	at := time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)
+
+	d := UserDates(models.UserPreferences{
+		Timezone:   "Asia/Tokyo",
+		DateFormat: "eu",
+		DateStyle:  DateStyleAbsolute,
+	})
+	if got, want := d.Absolute(at), "01/05/2024 21:30"; got != want {
+		t.Errorf("Absolute() = %q, want %q", got, want)
+	}
+	if got, want := d.Relative(at), "01/05/2024 21:30"; got != want {
+		t.Errorf("Relative() = %q, want %q", got, want)
+	}
+
+	// Invalid preferences fall back to the defaults.
+	d = UserDates(models.UserPreferences{
+		Timezone:   "Nowhere/City",
+		DateFormat: "nope",
+		DateStyle:  "sometimes",
+	})
+	if got, want := d.Absolute(at.Local()), at.Local().Format("2006-01-02 15:04:05"); got != want {
+		t.Errorf("Absolute() = %q, want %q", got, want)
+	}
+
+	d = UserDates(models.UserPreferences{DateStyle: DateStyleRelative})
+	if got, want := d.Absolute(time.Now().Add(-2*time.Hour)), "2 hours ago"; got != want {
+		t.Errorf("Absolute() = %q, want %q", got, want)
+	}
+}
+
+func TestStableDates(t *testing.T) {
+	at := time.Date(2024, 5, 1, 21, 30, 0, 0, time.FixedZone("JST", 9*60*60))
+	for _, got := range []string{StableDates.Absolute(at), StableDates.Relative(at)} {
+		if want := "2024-05-01T12:30:00Z"; got != want {
+			t.Errorf("got %q, want %q", got, want)
+		}
+	}
+}
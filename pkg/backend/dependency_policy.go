@@ -0,0 +1,146 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/utils"
+)
+
+// DependencyPolicy is what happens when closing an issue that depends on open
+// issues.
+type DependencyPolicy string
+
+const (
+	// DependencyPolicyAllow closes the issue. It's the default.
+	DependencyPolicyAllow DependencyPolicy = "allow"
+	// DependencyPolicyWarn closes the issue, and commands warn about its open
+	// dependencies.
+	DependencyPolicyWarn DependencyPolicy = "warn"
+	// DependencyPolicyBlock refuses to close the issue, unless forced.
+	DependencyPolicyBlock DependencyPolicy = "block"
+)
+
+var (
+	// ErrInvalidDependencyPolicy is returned when setting an unknown
+	// dependency policy.
+	ErrInvalidDependencyPolicy = errors.New("invalid dependency policy, must be one of allow, warn, or block")
+
+	// ErrOpenDependencies is wrapped by OpenDependenciesError.
+	ErrOpenDependencies = errors.New("issue has open dependencies")
+)
+
+// OpenDependenciesError is returned when closing an issue that depends on
+// open issues, in a repository whose dependency policy blocks it.
+type OpenDependenciesError struct {
+	// Numbers are the numbers of the open issues the closed issue depends on.
+	Numbers []int64
+	// Prefix is the issue prefix of the repository.
+	Prefix string
+}
+
+// Error implements error.
+func (e *OpenDependenciesError) Error() string {
+	refs := make([]string, 0, len(e.Numbers))
+	for _, n := range e.Numbers {
+		refs = append(refs, FormatIssueRef(e.Prefix, n))
+	}
+	return fmt.Sprintf("%s: %s", ErrOpenDependencies, strings.Join(refs, ", "))
+}
+
+// Unwrap returns ErrOpenDependencies.
+func (e *OpenDependenciesError) Unwrap() error {
+	return ErrOpenDependencies
+}
+
+// ParseDependencyPolicy parses a dependency policy, case-insensitively.
+func ParseDependencyPolicy(s string) (DependencyPolicy, error) {
+	switch p := DependencyPolicy(strings.ToLower(strings.TrimSpace(s))); p {
+	case DependencyPolicyAllow, DependencyPolicyWarn, DependencyPolicyBlock:
+		return p, nil
+	default:
+		return "", ErrInvalidDependencyPolicy
+	}
+}
+
+// DependencyPolicy returns the dependency policy of a repository.
+func (d *Backend) DependencyPolicy(ctx context.Context, repoName string) (DependencyPolicy, error) {
+	repoName = utils.SanitizeRepo(repoName)
+	policy, err := d.store.GetRepoDependencyPolicyByName(ctx, d.db, repoName)
+	if err != nil {
+		err = db.WrapError(err)
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return "", proto.ErrRepoNotFound
+		}
+		return "", err
+	}
+
+	return DependencyPolicy(policy), nil
+}
+
+// SetDependencyPolicy sets the dependency policy of a repository.
+func (d *Backend) SetDependencyPolicy(ctx context.Context, repoName string, policy DependencyPolicy) error {
+	repoName = utils.SanitizeRepo(repoName)
+	policy, err := ParseDependencyPolicy(string(policy))
+	if err != nil {
+		return err
+	}
+
+	if err := db.WrapError(d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		return d.store.SetRepoDependencyPolicyByName(ctx, tx, repoName, string(policy))
+	})); err != nil {
+		return err
+	}
+
+	d.audit(ctx, "repo.dependency_policy", repoName, string(policy))
+	return nil
+}
+
+// OpenIssueDependencies returns the open issues an issue depends on.
+func (d *Backend) OpenIssueDependencies(ctx context.Context, repoName string, issueID int64) ([]models.Issue, error) {
+	deps, err := d.GetIssueDependencies(ctx, repoName, issueID)
+	if err != nil {
+		return nil, err
+	}
+
+	var open []models.Issue
+	for _, dep := range deps {
+		if dep.State == models.IssueStateOpen {
+			open = append(open, dep)
+		}
+	}
+
+	return open, nil
+}
+
+// checkDependenciesClosed returns an OpenDependenciesError if the dependency
+// policy of a repository blocks closing an issue that depends on open
+// issues.
+func (d *Backend) checkDependenciesClosed(ctx context.Context, repoName string, issueID int64) error {
+	policy, err := d.DependencyPolicy(ctx, repoName)
+	if err != nil || policy != DependencyPolicyBlock {
+		return err
+	}
+
+	open, err := d.OpenIssueDependencies(ctx, repoName, issueID)
+	if err != nil || len(open) == 0 {
+		return err
+	}
+
+	prefix, err := d.IssuePrefix(ctx, repoName)
+	if err != nil {
+		return err
+	}
+
+	e := &OpenDependenciesError{Prefix: prefix}
+	for _, dep := range open {
+		e.Numbers = append(e.Numbers, dep.Number)
+	}
+
+	return e
+}
@@ -0,0 +1,36 @@
+package backend
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestOpenDependenciesError(t *testing.T) {
+	err := error(&OpenDependenciesError{Numbers: []int64{2, 3}, Prefix: "SRV"})
+	if want := "issue has open dependencies: SRV-2, SRV-3"; err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+	if !errors.Is(err, ErrOpenDependencies) {
+		t.Errorf("errors.Is(%v, ErrOpenDependencies) = false, want true", err)
+	}
+
+	var e *OpenDependenciesError
+	if !errors.As(err, &e) || len(e.Numbers) != 2 {
+		t.Errorf("errors.As(%v) = %v, want the issue numbers", err, e)
+	}
+}
+
+func TestParseDependencyPolicy(t *testing.T) {
+	for in, want := range map[string]DependencyPolicy{
+		"allow":  DependencyPolicyAllow,
+		" Warn ": DependencyPolicyWarn,
+		"BLOCK":  DependencyPolicyBlock,
+	} {
+		if got, err := ParseDependencyPolicy(in); err != nil || got != want {
+			t.Errorf("ParseDependencyPolicy(%q) = %q, %v, want %q", in, got, err, want)
+		}
+	}
+	if _, err := ParseDependencyPolicy("never"); !errors.Is(err, ErrInvalidDependencyPolicy) {
+		t.Errorf("ParseDependencyPolicy(%q) error = %v, want ErrInvalidDependencyPolicy", "never", err)
+	}
+}
@@ -0,0 +1,117 @@
+package backend
+
+import (
+	"context"
+	"sync"
+
+	"github.com/charmbracelet/soft-serve/git"
+	lru "github.com/hashicorp/golang-lru/v2"
+)
+
+const (
+	// diffCacheSize is the number of bytes of diffs kept in memory.
+	diffCacheSize = 32 << 20
+	// diffCacheEntries is the number of diffs kept in memory.
+	diffCacheEntries = 1024
+)
+
+// Diff returns the diff of a revision of a repository against opts.Base, or
+// against its first parent when opts.Base is empty.
+//
+// Diffs are keyed by the commits they compare and the options they're
+// computed with, so they're cached until evicted: opening the same merge
+// request or commit again doesn't run git diff.
+func (d *Backend) Diff(ctx context.Context, repoName string, rev string, opts git.DiffOptions) (*git.Diff, error) {
+	rr, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return nil, err
+	}
+	r, err := rr.Open()
+	if err != nil {
+		return nil, err
+	}
+
+	commit, err := r.CatFileCommit(rev)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Base != "" {
+		base, err := r.CatFileCommit(opts.Base)
+		if err != nil {
+			return nil, err
+		}
+		opts.Base = base.ID.String()
+	}
+
+	key := diffKey{repo: rr.Name(), head: commit.ID.String(), opts: opts}
+	if diff, ok := d.diffs.get(key); ok {
+		return diff, nil
+	}
+
+	diff, err := r.Diff(commit, opts)
+	if err != nil {
+		return nil, err
+	}
+	d.diffs.add(key, diff)
+	return diff, nil
+}
+
+// diffKey identifies a diff: the commits it compares and the options it's
+// computed with.
+type diffKey struct {
+	repo string
+	head string
+	opts git.DiffOptions
+}
+
+type cachedDiff struct {
+	diff *git.Diff
+	size int
+}
+
+// diffCache holds the most recently used diffs, up to diffCacheSize bytes.
+// The zero value is ready to use.
+type diffCache struct {
+	mu    sync.Mutex
+	diffs *lru.Cache[diffKey, cachedDiff]
+	size  int
+	max   int
+}
+
+func (c *diffCache) init() {
+	if c.diffs != nil {
+		return
+	}
+	if c.max <= 0 {
+		c.max = diffCacheSize
+	}
+	c.diffs, _ = lru.NewWithEvict(diffCacheEntries, func(_ diffKey, e cachedDiff) {
+		c.size -= e.size
+	})
+}
+
+func (c *diffCache) get(key diffKey) (*git.Diff, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.init()
+	e, ok := c.diffs.Get(key)
+	return e.diff, ok
+}
+
+// add caches a diff, evicting the least recently used diffs to make room for
+// it. Diffs larger than the whole cache aren't cached.
+func (c *diffCache) add(key diffKey, diff *git.Diff) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.init()
+	size := diff.Size()
+	if size > c.max {
+		return
+	}
+	c.diffs.Remove(key)
+	for c.size+size > c.max {
+		c.diffs.RemoveOldest()
+	}
+	c.diffs.Add(key, cachedDiff{diff: diff, size: size})
+	c.size += size
+}
@@ -0,0 +1,65 @@
+package backend
+
+import (
+	"strings"
+	"testing"
+
+	gitm "github.com/aymanbagabas/git-module"
+	"github.com/charmbracelet/soft-serve/git"
+)
+
+// testDiff returns a diff of a single file with a line of size bytes.
+func testDiff(size int) *git.Diff {
+	line := &gitm.DiffLine{Type: gitm.DiffLineAdd, Content: strings.Repeat("x", size)}
+	return &git.Diff{
+		Diff: &gitm.Diff{},
+		Files: []*git.DiffFile{{
+			DiffFile: &gitm.DiffFile{},
+			Sections: []*git.DiffSection{{
+				DiffSection: &gitm.DiffSection{Lines: []*gitm.DiffLine{line}},
+			}},
+		}},
+	}
+}
+
+func TestDiffCache(t *testing.T) {
+	c := diffCache{max: 100}
+	key := func(head string) diffKey {
+		return diffKey{repo: "repo1", head: head}
+	}
+
+	c.add(key("a"), testDiff(40))
+	c.add(key("b"), testDiff(40))
+	if _, ok := c.get(key("a")); !ok {
+		t.Fatal("a isn't cached")
+	}
+
+	// b is the least recently used, and makes room for c.
+	c.add(key("c"), testDiff(40))
+	if _, ok := c.get(key("b")); ok {
+		t.Fatal("b wasn't evicted")
+	}
+	for _, head := range []string{"a", "c"} {
+		if _, ok := c.get(key(head)); !ok {
+			t.Fatalf("%s isn't cached", head)
+		}
+	}
+	if c.size != 80 {
+		t.Fatalf("size = %d, want 80", c.size)
+	}
+
+	// The options are part of the key.
+	ws := diffKey{repo: "repo1", head: "a", opts: git.DiffOptions{IgnoreWhitespace: true}}
+	if _, ok := c.get(ws); ok {
+		t.Fatal("diff ignoring whitespace is cached")
+	}
+
+	// Diffs larger than the cache aren't cached, and don't evict others.
+	c.add(key("d"), testDiff(200))
+	if _, ok := c.get(key("d")); ok {
+		t.Fatal("d is cached")
+	}
+	if c.size != 80 {
+		t.Fatalf("size = %d, want 80", c.size)
+	}
+}
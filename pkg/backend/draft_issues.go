@@ -0,0 +1,69 @@
+package backend
+
+import (
+	"context"
+	"errors"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/utils"
+	"github.com/charmbracelet/soft-serve/pkg/webhook"
+)
+
+// ErrIssueNotDraft is returned when publishing an issue that isn't a draft.
+var ErrIssueNotDraft = errors.New("issue is not a draft")
+
+// CreateDraftIssue creates a draft issue for a repository. Only its author
+// sees it until it's published with PublishIssue.
+func (d *Backend) CreateDraftIssue(ctx context.Context, repoName string, title string, description string) (int64, error) {
+	return d.createIssue(ctx, repoName, title, description, true)
+}
+
+// PublishIssue publishes a draft issue: everyone who can read the repository
+// sees it from now on, and watchers are notified it was opened.
+func (d *Backend) PublishIssue(ctx context.Context, repoName string, issueID int64) error {
+	repoName = utils.SanitizeRepo(repoName)
+
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return err
+	}
+
+	// Only the author gets draft issues.
+	issue, err := d.GetIssue(ctx, repoName, issueID)
+	if err != nil {
+		return err
+	}
+	if !issue.Draft {
+		return ErrIssueNotDraft
+	}
+
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		return d.store.SetIssueDraft(ctx, tx, r.ID(), issueID, false)
+	}); err != nil {
+		return db.WrapError(err)
+	}
+
+	d.indexCrossReferences(ctx, r, NotificationSubjectIssue, issueID, issue.Title, issue.Description)
+	d.sendIssueEvent(ctx, r, issueID, webhook.IssueEventActionOpened)
+
+	return nil
+}
+
+// issueVisible returns whether user can see issue: draft issues are only
+// visible to their author.
+func issueVisible(issue models.Issue, user proto.User) bool {
+	return !issue.Draft || (user != nil && user.ID() == issue.AuthorID)
+}
+
+// visibleIssues returns the issues user can see, see issueVisible.
+func visibleIssues(issues []models.Issue, user proto.User) []models.Issue {
+	visible := issues[:0]
+	for _, issue := range issues {
+		if issueVisible(issue, user) {
+			visible = append(visible, issue)
+		}
+	}
+	return visible
+}
@@ -0,0 +1,112 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/soft-serve/pkg/access"
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/utils"
+)
+
+// EditPolicy is who, besides their author, can edit and delete the issues and
+// merge requests of a repository.
+type EditPolicy string
+
+const (
+	// EditPolicyAuthor lets only the author edit and delete an issue or
+	// merge request. Moderators can still hide and delete it.
+	EditPolicyAuthor EditPolicy = "author"
+	// EditPolicyAdmins lets the author and the repository admins edit and
+	// delete an issue or merge request.
+	EditPolicyAdmins EditPolicy = "admins"
+	// EditPolicyCollaborators lets the author and the collaborators edit and
+	// delete an issue or merge request. It's the default.
+	EditPolicyCollaborators EditPolicy = "collaborators"
+)
+
+var (
+	// ErrInvalidEditPolicy is returned when setting an unknown edit policy.
+	ErrInvalidEditPolicy = errors.New("invalid edit policy, must be one of author, admins, or collaborators")
+
+	// ErrEditNotAllowed is returned when the edit policy of a repository
+	// doesn't let a user edit or delete an issue or merge request.
+	ErrEditNotAllowed = fmt.Errorf("%w: the edit policy of the repository doesn't let you change this", proto.ErrUnauthorized)
+)
+
+// ParseEditPolicy parses an edit policy, case-insensitively.
+func ParseEditPolicy(s string) (EditPolicy, error) {
+	switch p := EditPolicy(strings.ToLower(strings.TrimSpace(s))); p {
+	case EditPolicyAuthor, EditPolicyAdmins, EditPolicyCollaborators:
+		return p, nil
+	default:
+		return "", ErrInvalidEditPolicy
+	}
+}
+
+// EditPolicy returns the edit policy of a repository.
+func (d *Backend) EditPolicy(ctx context.Context, repoName string) (EditPolicy, error) {
+	repoName = utils.SanitizeRepo(repoName)
+	policy, err := d.store.GetRepoEditPolicyByName(ctx, d.db, repoName)
+	if err != nil {
+		err = db.WrapError(err)
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return "", proto.ErrRepoNotFound
+		}
+		return "", err
+	}
+
+	return EditPolicy(policy), nil
+}
+
+// SetEditPolicy sets the edit policy of a repository.
+func (d *Backend) SetEditPolicy(ctx context.Context, repoName string, policy EditPolicy) error {
+	repoName = utils.SanitizeRepo(repoName)
+	policy, err := ParseEditPolicy(string(policy))
+	if err != nil {
+		return err
+	}
+
+	if err := db.WrapError(d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		return d.store.SetRepoEditPolicyByName(ctx, tx, repoName, string(policy))
+	})); err != nil {
+		return err
+	}
+
+	d.audit(ctx, "repo.edit_policy", repoName, string(policy))
+	return nil
+}
+
+// checkCanEdit returns ErrEditNotAllowed unless the edit policy of r lets the
+// user of ctx edit and delete the issues and merge requests of authorID.
+func (d *Backend) checkCanEdit(ctx context.Context, r proto.Repository, authorID int64) error {
+	user := proto.UserFromContext(ctx)
+	if user == nil {
+		return proto.ErrUserNotFound
+	}
+	if user.ID() == authorID {
+		return nil
+	}
+
+	policy, err := d.EditPolicy(ctx, r.Name())
+	if err != nil {
+		return err
+	}
+
+	level := d.AccessLevelForUser(ctx, r.Name(), user)
+	switch policy {
+	case EditPolicyCollaborators:
+		if level >= access.ReadWriteAccess {
+			return nil
+		}
+	case EditPolicyAdmins:
+		if level >= access.AdminAccess {
+			return nil
+		}
+	}
+
+	return ErrEditNotAllowed
+}
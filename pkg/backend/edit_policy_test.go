@@ -0,0 +1,28 @@
+package backend
+
+import "testing"
+
+func TestParseEditPolicy(t *testing.T) {
+	cases := []struct {
+		in      string
+		want    EditPolicy
+		wantErr bool
+	}{
+		{"author", EditPolicyAuthor, false},
+		{" Admins ", EditPolicyAdmins, false},
+		{"COLLABORATORS", EditPolicyCollaborators, false},
+		{"", "", true},
+		{"everyone", "", true},
+	}
+
+	for _, c := range cases {
+		got, err := ParseEditPolicy(c.in)
+		if (err != nil) != c.wantErr {
+			t.Errorf("ParseEditPolicy(%q) error = %v, want error %t", c.in, err, c.wantErr)
+			continue
+		}
+		if got != c.want {
+			t.Errorf("ParseEditPolicy(%q) = %q, want %q", c.in, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	gitm "github.com/aymanbagabas/git-module"
+	"github.com/charmbracelet/soft-serve/git"
+	"github.com/charmbracelet/soft-serve/pkg/utils"
+)
+
+// ErrInvalidRef is returned when a git command is given an invalid ref name.
+var ErrInvalidRef = errors.New("invalid ref name")
+
+// command returns a git command that is killed when ctx is done, e.g. when
+// the client disconnects, or when it runs longer than the configured git
+// command timeout. Git runs directly with args, never through a shell.
+func (d *Backend) command(ctx context.Context, args ...string) *gitm.Command {
+	cmd := git.NewCommand(args...).WithContext(ctx)
+	if d.cfg != nil {
+		cmd.SetTimeout(d.cfg.GitCommandTimeout())
+	}
+	return cmd
+}
+
+// gitArgs returns the command line of a git subcommand: opts, then
+// --end-of-options, then operands. Git never takes an operand for an option,
+// even one starting with a hyphen. Operands naming refs that come from users,
+// e.g. the branches of a merge request, must pass validateRef first.
+func gitArgs(subcommand string, opts []string, operands ...string) []string {
+	args := make([]string, 0, len(opts)+len(operands)+2)
+	args = append(args, subcommand)
+	args = append(args, opts...)
+	args = append(args, "--end-of-options")
+	return append(args, operands...)
+}
+
+// validateRef returns ErrInvalidRef if ref isn't a valid short or full ref
+// name, e.g. "main" or "refs/heads/main", following git-check-ref-format(1).
+// Object IDs are valid ref names too.
+func validateRef(ref string) error {
+	if err := utils.ValidateBranch(strings.TrimPrefix(ref, "refs/")); err != nil {
+		return fmt.Errorf("%w %q: %w", ErrInvalidRef, ref, err)
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/soft-serve/git"
+	"github.com/matryer/is"
+)
+
+func TestGitArgs(t *testing.T) {
+	is := is.New(t)
+	is.Equal(gitArgs("update-ref", []string{"-d"}, "refs/heads/-x"),
+		[]string{"update-ref", "-d", "--end-of-options", "refs/heads/-x"})
+	is.Equal(gitArgs("rev-parse", nil), []string{"rev-parse", "--end-of-options"})
+}
+
+func TestValidateRef(t *testing.T) {
+	for _, ref := range []string{"main", "feature/x", "refs/heads/main", "refs/merge-queue/1", "0123abcd"} {
+		if err := validateRef(ref); err != nil {
+			t.Errorf("validateRef(%q) = %v, want nil", ref, err)
+		}
+	}
+	for _, ref := range []string{"", "-b", "--upload-pack=touch x", "a..b", "a b", "HEAD~1", "a@{1}", "x.lock"} {
+		if err := validateRef(ref); !errors.Is(err, ErrInvalidRef) {
+			t.Errorf("validateRef(%q) = %v, want %v", ref, err, ErrInvalidRef)
+		}
+	}
+}
+
+func TestPerformMerge(t *testing.T) {
+	is := is.New(t)
+	dir := t.TempDir()
+	run := func(args ...string) string {
+		t.Helper()
+		args = append([]string{"-C", dir, "-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)
+		out, err := exec.Command("git", args...).CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+	run("init", "-q", "-b", "main")
+	run("commit", "-q", "--allow-empty", "-m", "initial")
+	run("checkout", "-q", "-b", "feature")
+	run("commit", "-q", "--allow-empty", "-m", "feature")
+	feature := run("rev-parse", "HEAD")
+	run("checkout", "-q", "main")
+
+	gr, err := git.Open(dir)
+	is.NoErr(err)
+
+	ctx := context.Background()
+	d := &Backend{}
+	commit, err := d.performMerge(ctx, gr, "repo", "feature", "main", "admin")
+	is.NoErr(err)
+	is.Equal(run("rev-parse", "main"), commit)
+	is.Equal(run("rev-parse", "main^2"), feature)
+	is.Equal(run("log", "-1", "--format=%an %s", "main"), "admin Merge branch 'feature' into 'main'")
+
+	// Branch names are never taken for options.
+	_, err = d.revParse(ctx, gr, "--output=x")
+	is.True(errors.Is(err, ErrInvalidRef))
+	_, err = d.performMerge(ctx, gr, "repo", "-x", "main", "admin")
+	is.True(err != nil)
+}
@@ -4,9 +4,12 @@ import (
 	"context"
 	"io"
 	"os"
+	"path/filepath"
+	"strings"
 	"sync"
 
 	"github.com/charmbracelet/soft-serve/git"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
 	"github.com/charmbracelet/soft-serve/pkg/hooks"
 	"github.com/charmbracelet/soft-serve/pkg/proto"
 	"github.com/charmbracelet/soft-serve/pkg/sshutils"
@@ -18,15 +21,28 @@ var _ hooks.Hooks = (*Backend)(nil)
 // PostReceive is called by the git post-receive hook.
 //
 // It implements Hooks.
-func (d *Backend) PostReceive(_ context.Context, _ io.Writer, _ io.Writer, repo string, args []hooks.HookArg) {
+func (d *Backend) PostReceive(ctx context.Context, stdout io.Writer, _ io.Writer, repo string, args []hooks.HookArg) {
 	d.logger.Debug("post-receive hook called", "repo", repo, "args", args)
+
+	user := d.hookUser(ctx)
+	d.recordRefUpdates(ctx, repo, user, args)
+	d.outdateMergeRequestComments(ctx, repo, args)
+	d.markForOptimization(ctx, repo, args)
+	d.closeIssuesFromCommits(ctx, stdout, repo, user, d.indexCommitReferences(ctx, repo, args))
+	d.announcePushCreated(ctx, stdout, repo)
 }
 
 // PreReceive is called by the git pre-receive hook.
 //
 // It implements Hooks.
-func (d *Backend) PreReceive(_ context.Context, _ io.Writer, _ io.Writer, repo string, args []hooks.HookArg) {
+func (d *Backend) PreReceive(ctx context.Context, _ io.Writer, stderr io.Writer, repo string, args []hooks.HookArg) error {
 	d.logger.Debug("pre-receive hook called", "repo", repo, "args", args)
+
+	if err := d.checkLeases(ctx, repo, args, pushOptions()); err != nil {
+		return err
+	}
+
+	return d.checkCommitMessages(ctx, repo, args, stderr)
 }
 
 // Update is called by the git update hook.
@@ -35,29 +51,8 @@ func (d *Backend) PreReceive(_ context.Context, _ io.Writer, _ io.Writer, repo s
 func (d *Backend) Update(ctx context.Context, _ io.Writer, _ io.Writer, repo string, arg hooks.HookArg) {
 	d.logger.Debug("update hook called", "repo", repo, "arg", arg)
 
-	// Find user
-	var user proto.User
-	if pubkey := os.Getenv("SOFT_SERVE_PUBLIC_KEY"); pubkey != "" {
-		pk, _, err := sshutils.ParseAuthorizedKey(pubkey)
-		if err != nil {
-			d.logger.Error("error parsing public key", "err", err)
-			return
-		}
-
-		user, err = d.UserByPublicKey(ctx, pk)
-		if err != nil {
-			d.logger.Error("error finding user from public key", "key", pubkey, "err", err)
-			return
-		}
-	} else if username := os.Getenv("SOFT_SERVE_USERNAME"); username != "" {
-		var err error
-		user, err = d.User(ctx, username)
-		if err != nil {
-			d.logger.Error("error finding user from username", "username", username, "err", err)
-			return
-		}
-	} else {
-		d.logger.Error("error finding user")
+	user := d.hookUser(ctx)
+	if user == nil {
 		return
 	}
 
@@ -78,6 +73,13 @@ func (d *Backend) Update(ctx context.Context, _ io.Writer, _ io.Writer, repo str
 			d.logger.Error("error sending branch_tag webhook", "err", err)
 		}
 	}
+	if strings.HasPrefix(arg.RefName, git.RefsTags) && git.IsZeroHash(arg.OldSha) && !git.IsZeroHash(arg.NewSha) {
+		d.recordActivity(ctx, r, user, models.Activity{
+			Kind:   ActivityKindTag,
+			Action: "pushed",
+			Title:  strings.TrimPrefix(arg.RefName, git.RefsTags),
+		})
+	}
 	wh, err := webhook.NewPushEvent(ctx, user, r, arg.RefName, arg.OldSha, arg.NewSha)
 	if err != nil {
 		d.logger.Error("error creating push webhook", "err", err)
@@ -86,6 +88,57 @@ func (d *Backend) Update(ctx context.Context, _ io.Writer, _ io.Writer, repo str
 	}
 }
 
+// hookUser returns the user running a hook, from the environment set by the
+// server. It returns nil when the user can't be found.
+func (d *Backend) hookUser(ctx context.Context) proto.User {
+	if pubkey := os.Getenv("SOFT_SERVE_PUBLIC_KEY"); pubkey != "" {
+		pk, _, err := sshutils.ParseAuthorizedKey(pubkey)
+		if err != nil {
+			d.logger.Error("error parsing public key", "err", err)
+			return nil
+		}
+
+		user, err := d.UserByPublicKey(ctx, pk)
+		if err != nil {
+			d.logger.Error("error finding user from public key", "key", pubkey, "err", err)
+			return nil
+		}
+		return user
+	} else if username := os.Getenv("SOFT_SERVE_USERNAME"); username != "" {
+		user, err := d.User(ctx, username)
+		if err != nil {
+			d.logger.Error("error finding user from username", "username", username, "err", err)
+			return nil
+		}
+		return user
+	}
+
+	d.logger.Error("error finding user")
+	return nil
+}
+
+// updateRef moves ref of repository name from oldSha to newSha, a commit the
+// server created on behalf of username, e.g. a merge commit. With merge hooks
+// on, newSha is pushed to the repository itself so its hooks run, and may
+// reject it, as for any push. Either way, this fails if ref moved since
+// oldSha was resolved.
+func (d *Backend) updateRef(ctx context.Context, gr *git.Repository, name, username, ref, newSha, oldSha string) error {
+	if d.cfg == nil || !d.cfg.Git.MergeHooks {
+		_, err := d.command(ctx, gitArgs("update-ref", nil, ref, newSha, oldSha)...).RunInDir(gr.Path)
+		return err
+	}
+
+	envs := append(d.cfg.Environ(),
+		"SOFT_SERVE_REPO_NAME="+name,
+		"SOFT_SERVE_REPO_PATH="+gr.Path,
+		"SOFT_SERVE_USERNAME="+username,
+		"SOFT_SERVE_LOG_PATH="+filepath.Join(d.cfg.DataPath, "log", "hooks.log"),
+	)
+	opts := []string{"--quiet", "--force-with-lease=" + ref + ":" + oldSha}
+	_, err := d.command(ctx, gitArgs("push", opts, ".", newSha+":"+ref)...).AddEnvs(envs...).RunInDir(gr.Path)
+	return err
+}
+
 // PostUpdate is called by the git post-update hook.
 //
 // It implements Hooks.
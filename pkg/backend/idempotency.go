@@ -0,0 +1,104 @@
+package backend
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+)
+
+// IdempotencyKeyTTL is how long retrying a request with the same idempotency
+// key replays its response.
+const IdempotencyKeyTTL = 24 * time.Hour
+
+// maxIdempotencyKeyLength is the length of the longest idempotency key.
+const maxIdempotencyKeyLength = 255
+
+var (
+	// ErrInvalidIdempotencyKey is returned for empty or too long idempotency
+	// keys.
+	ErrInvalidIdempotencyKey = errors.New("idempotency key must be 1 to 255 characters")
+	// ErrIdempotencyKeyInUse is returned when retrying a request whose first
+	// run hasn't finished.
+	ErrIdempotencyKeyInUse = errors.New("a request with this idempotency key is still running")
+	// ErrIdempotencyKeyReused is returned when sending an idempotency key with
+	// another request than the one it was first sent with.
+	ErrIdempotencyKeyReused = errors.New("idempotency key already used for another request")
+)
+
+// IdempotentResponse is the response of a request run with an idempotency
+// key.
+type IdempotentResponse struct {
+	// Status is the HTTP status code of the response, zero for SSH commands.
+	Status int
+	// Body is the output of the request.
+	Body []byte
+}
+
+// Idempotent runs fn, the create request described by request, once per
+// idempotency key of the user. Retries with the same key within
+// IdempotencyKeyTTL don't run fn again, and get the response of the first run
+// with replayed set. A request that fails, or whose response can't be saved,
+// releases its key, so that it can be retried.
+func (d *Backend) Idempotent(ctx context.Context, key string, request string, fn func() (IdempotentResponse, error)) (resp IdempotentResponse, replayed bool, err error) {
+	user := proto.UserFromContext(ctx)
+	if user == nil {
+		return resp, false, proto.ErrUserNotFound
+	}
+	if key == "" || len(key) > maxIdempotencyKeyLength {
+		return resp, false, ErrInvalidIdempotencyKey
+	}
+
+	sum := sha256.Sum256([]byte(request))
+	hash := hex.EncodeToString(sum[:])
+
+	// Expired keys are purged as new ones come.
+	if _, err := d.store.DeleteIdempotencyKeysBefore(ctx, d.db, time.Now().Add(-IdempotencyKeyTTL)); err != nil {
+		return resp, false, db.WrapError(err)
+	}
+
+	if err := db.WrapError(d.store.CreateIdempotencyKey(ctx, d.db, user.ID(), key, hash)); err != nil {
+		if !errors.Is(err, db.ErrDuplicateKey) {
+			return resp, false, err
+		}
+
+		k, err := d.store.GetIdempotencyKey(ctx, d.db, user.ID(), key)
+		if err != nil {
+			return resp, false, db.WrapError(err)
+		}
+		switch {
+		case k.RequestHash != hash:
+			return resp, false, ErrIdempotencyKeyReused
+		case !k.Response.Valid:
+			return resp, false, ErrIdempotencyKeyInUse
+		}
+		return IdempotentResponse{Status: k.Status, Body: []byte(k.Response.String)}, true, nil
+	}
+
+	resp, err = fn()
+	if err != nil {
+		d.releaseIdempotencyKey(ctx, user.ID(), key)
+		return resp, false, err
+	}
+
+	if err := d.store.SetIdempotencyKeyResponse(ctx, d.db, user.ID(), key, resp.Status, string(resp.Body)); err != nil {
+		// Without a response to replay, retries would be refused until
+		// the key expires.
+		d.logger.Error("error saving idempotent response", "err", err)
+		d.releaseIdempotencyKey(ctx, user.ID(), key)
+	}
+
+	return resp, false, nil
+}
+
+// releaseIdempotencyKey deletes the idempotency key of a user, so that the
+// request can be retried.
+func (d *Backend) releaseIdempotencyKey(ctx context.Context, userID int64, key string) {
+	if err := d.store.DeleteIdempotencyKey(ctx, d.db, userID, key); err != nil {
+		d.logger.Error("error releasing idempotency key", "err", err)
+	}
+}
@@ -0,0 +1,77 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/charmbracelet/log/v2"
+	"github.com/charmbracelet/soft-serve/pkg/config"
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/migrate"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/store"
+	"github.com/charmbracelet/soft-serve/pkg/store/database"
+	_ "modernc.org/sqlite" // sqlite driver
+)
+
+// failingResponseStore fails to save idempotent responses.
+type failingResponseStore struct {
+	store.Store
+}
+
+func (failingResponseStore) SetIdempotencyKeyResponse(context.Context, db.Handler, int64, string, int, string) error {
+	return errors.New("disk full")
+}
+
+func TestIdempotentFailedSave(t *testing.T) {
+	t.Setenv("SOFT_SERVE_DATA_PATH", t.TempDir())
+	cfg := config.DefaultConfig()
+	if err := cfg.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := config.WithContext(context.Background(), cfg)
+	ctx = log.WithContext(ctx, log.New(io.Discard))
+	dbx, err := db.Open(ctx, cfg.DB.Driver, cfg.DB.DataSource)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { dbx.Close() }) // nolint: errcheck
+	if err := migrate.Migrate(ctx, dbx); err != nil {
+		t.Fatal(err)
+	}
+	datastore := database.New(ctx, dbx)
+	be := New(ctx, cfg, dbx, failingResponseStore{datastore})
+
+	user, err := be.CreateUser(ctx, "user1", proto.UserOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx = proto.WithUserContext(ctx, user)
+
+	var runs int
+	create := func() (IdempotentResponse, error) {
+		runs++
+		return IdempotentResponse{Body: []byte("created")}, nil
+	}
+
+	for i := 0; i < 2; i++ {
+		resp, replayed, err := be.Idempotent(ctx, "key1", "repo create repo1", create)
+		if err != nil {
+			t.Fatalf("run %d: %v", i, err)
+		}
+		if replayed || string(resp.Body) != "created" {
+			t.Fatalf("run %d: got %q, replayed %t", i, resp.Body, replayed)
+		}
+	}
+	if runs != 2 {
+		t.Fatalf("expected the retry to run again, ran %d times", runs)
+	}
+
+	// The key was released, not left reserved.
+	if _, err := datastore.GetIdempotencyKey(ctx, dbx, user.ID(), "key1"); !errors.Is(db.WrapError(err), db.ErrRecordNotFound) {
+		t.Fatalf("expected the key to be released, got %v", err)
+	}
+}
@@ -0,0 +1,163 @@
+package backend
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/store"
+	"github.com/charmbracelet/soft-serve/pkg/utils"
+	"github.com/charmbracelet/soft-serve/pkg/webhook"
+)
+
+// CreateIntegration creates a chat notification integration for a repository.
+func (b *Backend) CreateIntegration(ctx context.Context, repo proto.Repository, kind webhook.IntegrationKind, url string, secret string, template string, events []webhook.Event, active bool) error {
+	dbx := db.FromContext(ctx)
+	datastore := store.FromContext(ctx)
+	url = utils.Sanitize(url)
+
+	// Validate integration URL to prevent SSRF attacks
+	if err := webhook.ValidateWebhookURL(url); err != nil {
+		return err //nolint:wrapcheck
+	}
+	if template != "" {
+		if _, err := webhook.ParseTemplate(template); err != nil {
+			return err //nolint:wrapcheck
+		}
+	}
+
+	return dbx.TransactionContext(ctx, func(tx *db.Tx) error {
+		id, err := datastore.CreateIntegration(ctx, tx, repo.ID(), int(kind), url, secret, template, active)
+		if err != nil {
+			return db.WrapError(err)
+		}
+
+		if err := datastore.SetIntegrationEvents(ctx, tx, id, eventInts(events)); err != nil {
+			return db.WrapError(err)
+		}
+
+		return nil
+	})
+}
+
+// Integration returns a chat notification integration for a repository.
+func (b *Backend) Integration(ctx context.Context, repo proto.Repository, id int64) (webhook.Integration, error) {
+	dbx := db.FromContext(ctx)
+	datastore := store.FromContext(ctx)
+
+	var in webhook.Integration
+	if err := dbx.TransactionContext(ctx, func(tx *db.Tx) error {
+		i, err := datastore.GetIntegrationByID(ctx, tx, repo.ID(), id)
+		if err != nil {
+			return db.WrapError(err)
+		}
+		events, err := datastore.GetIntegrationEventsByIntegrationID(ctx, tx, id)
+		if err != nil {
+			return db.WrapError(err)
+		}
+
+		in = toIntegration(i, events)
+		return nil
+	}); err != nil {
+		return webhook.Integration{}, db.WrapError(err)
+	}
+
+	return in, nil
+}
+
+// ListIntegrations lists chat notification integrations for a repository.
+func (b *Backend) ListIntegrations(ctx context.Context, repo proto.Repository) ([]webhook.Integration, error) {
+	dbx := db.FromContext(ctx)
+	datastore := store.FromContext(ctx)
+
+	var integrations []webhook.Integration
+	if err := dbx.TransactionContext(ctx, func(tx *db.Tx) error {
+		is, err := datastore.GetIntegrationsByRepoID(ctx, tx, repo.ID())
+		if err != nil {
+			return err
+		}
+
+		for _, i := range is {
+			events, err := datastore.GetIntegrationEventsByIntegrationID(ctx, tx, i.ID)
+			if err != nil {
+				return err
+			}
+			integrations = append(integrations, toIntegration(i, events))
+		}
+
+		return nil
+	}); err != nil {
+		return nil, db.WrapError(err)
+	}
+
+	return integrations, nil
+}
+
+// UpdateIntegration updates a chat notification integration.
+func (b *Backend) UpdateIntegration(ctx context.Context, repo proto.Repository, id int64, url string, secret string, template string, events []webhook.Event, active bool) error {
+	dbx := db.FromContext(ctx)
+	datastore := store.FromContext(ctx)
+	url = utils.Sanitize(url)
+
+	// Validate integration URL to prevent SSRF attacks
+	if err := webhook.ValidateWebhookURL(url); err != nil {
+		return err //nolint:wrapcheck
+	}
+	if template != "" {
+		if _, err := webhook.ParseTemplate(template); err != nil {
+			return err //nolint:wrapcheck
+		}
+	}
+
+	return dbx.TransactionContext(ctx, func(tx *db.Tx) error {
+		if err := datastore.UpdateIntegrationByID(ctx, tx, repo.ID(), id, url, secret, template, active); err != nil {
+			return db.WrapError(err)
+		}
+
+		if err := datastore.SetIntegrationEvents(ctx, tx, id, eventInts(events)); err != nil {
+			return db.WrapError(err)
+		}
+
+		return nil
+	})
+}
+
+// DeleteIntegration deletes a chat notification integration for a repository.
+func (b *Backend) DeleteIntegration(ctx context.Context, repo proto.Repository, id int64) error {
+	dbx := db.FromContext(ctx)
+	datastore := store.FromContext(ctx)
+
+	return dbx.TransactionContext(ctx, func(tx *db.Tx) error {
+		if _, err := datastore.GetIntegrationByID(ctx, tx, repo.ID(), id); err != nil {
+			return db.WrapError(err)
+		}
+		if err := datastore.DeleteIntegrationForRepoByID(ctx, tx, repo.ID(), id); err != nil {
+			return db.WrapError(err)
+		}
+
+		return nil
+	})
+}
+
+func toIntegration(i models.Integration, events []models.IntegrationEvent) webhook.Integration {
+	in := webhook.Integration{
+		Integration: i,
+		Kind:        webhook.IntegrationKind(i.Kind), //nolint:gosec
+		Events:      make([]webhook.Event, len(events)),
+	}
+	for j, e := range events {
+		in.Events[j] = webhook.Event(e.Event)
+	}
+
+	return in
+}
+
+func eventInts(events []webhook.Event) []int {
+	evs := make([]int, len(events))
+	for i, e := range events {
+		evs[i] = int(e)
+	}
+
+	return evs
+}
@@ -0,0 +1,81 @@
+package backend
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/charmbracelet/soft-serve/pkg/access"
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/utils"
+)
+
+// ErrIssueLocked is returned when a user who isn't a collaborator edits, or
+// votes on, a locked issue.
+var ErrIssueLocked = fmt.Errorf("%w: issue is locked to collaborators", proto.ErrUnauthorized)
+
+// LockIssue locks an issue so that only collaborators can edit it or vote on
+// it.
+func (d *Backend) LockIssue(ctx context.Context, repoName string, issueID int64) error {
+	return d.setIssueLocked(ctx, repoName, issueID, true)
+}
+
+// UnlockIssue unlocks a locked issue.
+func (d *Backend) UnlockIssue(ctx context.Context, repoName string, issueID int64) error {
+	return d.setIssueLocked(ctx, repoName, issueID, false)
+}
+
+// setIssueLocked locks or unlocks an issue. Only collaborators can.
+func (d *Backend) setIssueLocked(ctx context.Context, repoName string, issueID int64, locked bool) error {
+	repoName = utils.SanitizeRepo(repoName)
+
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return err
+	}
+
+	issue, err := d.GetIssue(ctx, repoName, issueID)
+	if err != nil {
+		return err
+	}
+
+	user := proto.UserFromContext(ctx)
+	if user == nil {
+		return proto.ErrUserNotFound
+	}
+	if d.AccessLevelForUser(ctx, r.Name(), user) < access.ReadWriteAccess {
+		return proto.ErrUnauthorized
+	}
+
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		return d.store.SetIssueLocked(ctx, tx, r.ID(), issueID, locked)
+	}); err != nil {
+		return db.WrapError(err)
+	}
+
+	action := "issue.unlock"
+	if locked {
+		action = "issue.lock"
+	}
+	d.audit(ctx, action, r.Name(), fmt.Sprintf("issue #%d", issue.Number))
+
+	return nil
+}
+
+// checkIssueUnlocked returns ErrIssueLocked if the issue is locked and the
+// user of ctx isn't a collaborator of the repository.
+func (d *Backend) checkIssueUnlocked(ctx context.Context, r proto.Repository, issueID int64) error {
+	issue, err := d.store.GetIssueByID(ctx, d.db, r.ID(), issueID)
+	if err != nil {
+		return db.WrapError(err)
+	}
+	if !issue.Locked {
+		return nil
+	}
+
+	if d.AccessLevelForUser(ctx, r.Name(), proto.UserFromContext(ctx)) < access.ReadWriteAccess {
+		return ErrIssueLocked
+	}
+
+	return nil
+}
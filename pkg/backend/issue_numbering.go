@@ -0,0 +1,284 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/utils"
+)
+
+var (
+	// ErrIssueNotFound is returned when an issue reference matches no issue
+	// of a repository.
+	ErrIssueNotFound = errors.New("issue not found")
+
+	// ErrInvalidIssue is returned when importing an issue with an invalid
+	// external ID, title, or description.
+	ErrInvalidIssue = errors.New("invalid issue")
+
+	// ErrInvalidIssuePrefix is returned when setting an invalid issue prefix.
+	ErrInvalidIssuePrefix = errors.New("invalid issue prefix, must be up to 10 uppercase letters and digits, starting with a letter")
+
+	issuePrefixRe = regexp.MustCompile(`^[A-Z][A-Z0-9]{0,9}$`)
+)
+
+// maxExternalIDLength is the maximum length of the external IDs of imported
+// issues.
+const maxExternalIDLength = 100
+
+// FormatIssueRef returns the display reference of an issue, e.g. "SRV-12"
+// with the "SRV" prefix, or "#12" without a prefix.
+func FormatIssueRef(prefix string, id int64) string {
+	if prefix == "" {
+		return fmt.Sprintf("#%d", id)
+	}
+	return fmt.Sprintf("%s-%d", prefix, id)
+}
+
+// ValidateIssuePrefix returns ErrInvalidIssuePrefix if prefix isn't a valid
+// issue prefix. Prefixes are case-insensitive, and empty means no prefix.
+func ValidateIssuePrefix(prefix string) error {
+	prefix = strings.ToUpper(strings.TrimSpace(prefix))
+	if prefix != "" && !issuePrefixRe.MatchString(prefix) {
+		return ErrInvalidIssuePrefix
+	}
+	return nil
+}
+
+// IssuePrefix returns the issue display prefix of a repository, empty if it
+// has none.
+func (d *Backend) IssuePrefix(ctx context.Context, repoName string) (string, error) {
+	repoName = utils.SanitizeRepo(repoName)
+	prefix, err := d.store.GetRepoIssuePrefixByName(ctx, d.db, repoName)
+	if err != nil {
+		err = db.WrapError(err)
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return "", proto.ErrRepoNotFound
+		}
+		return "", err
+	}
+
+	return prefix, nil
+}
+
+// IssueRef returns the display reference of the issue of a repository with
+// the given ID. It falls back to "#ID" when the issue can't be read.
+func (d *Backend) IssueRef(ctx context.Context, repoName string, id int64) string {
+	prefix, _ := d.IssuePrefix(ctx, repoName)
+	if issue, err := d.GetIssue(ctx, repoName, id); err == nil {
+		id = issue.Number
+	}
+	return FormatIssueRef(prefix, id)
+}
+
+// SetIssuePrefix sets the issue display prefix of a repository, e.g. "SRV"
+// to show issue 12 as SRV-12. An empty prefix shows issues as #12 again.
+func (d *Backend) SetIssuePrefix(ctx context.Context, repoName string, prefix string) error {
+	repoName = utils.SanitizeRepo(repoName)
+	if err := ValidateIssuePrefix(prefix); err != nil {
+		return err
+	}
+	prefix = strings.ToUpper(strings.TrimSpace(prefix))
+
+	// Keys of the linked tracker projects are already links to the tracker.
+	if t, err := d.IssueTracker(ctx, repoName); err == nil && slices.Contains(t.Projects, prefix) {
+		return fmt.Errorf("issue prefix %s is a project of the repository's issue tracker", prefix)
+	} else if err != nil && !errors.Is(err, ErrNoIssueTracker) {
+		return err
+	}
+
+	// Delete cache
+	d.cache.Delete(repoName)
+
+	if err := db.WrapError(d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		return d.store.SetRepoIssuePrefixByName(ctx, tx, repoName, prefix)
+	})); err != nil {
+		return err
+	}
+
+	d.audit(ctx, "repo.issue_prefix", repoName, prefix)
+	return nil
+}
+
+// ResolveIssueID returns the ID of the issue of a repository a reference
+// points to. References are issue numbers, optionally written "#12" or with
+// the issue prefix of the repository, e.g. "SRV-12", or the external ID of an
+// imported issue, e.g. "GH-45". Numbers of issues of the repository win over
+// external IDs.
+func (d *Backend) ResolveIssueID(ctx context.Context, repoName string, ref string) (int64, error) {
+	repoName = utils.SanitizeRepo(repoName)
+	ref = strings.TrimSpace(ref)
+
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return 0, err
+	}
+
+	prefix, err := d.IssuePrefix(ctx, repoName)
+	if err != nil {
+		return 0, err
+	}
+
+	num := strings.TrimPrefix(ref, "#")
+	if p, n, ok := strings.Cut(ref, "-"); ok && prefix != "" && strings.EqualFold(p, prefix) {
+		num = n
+	}
+
+	number, parseErr := strconv.ParseInt(num, 10, 64)
+	if parseErr == nil {
+		if issue, err := d.store.GetIssueByNumber(ctx, d.db, r.ID(), number); err == nil {
+			return issue.ID, nil
+		}
+	}
+
+	issue, err := d.store.GetIssueByExternalID(ctx, d.db, r.ID(), ref)
+	if err == nil {
+		return issue.ID, nil
+	}
+	if err = db.WrapError(err); !errors.Is(err, db.ErrRecordNotFound) {
+		return 0, err
+	}
+
+	// Let numbers of missing issues fail like they always did.
+	if parseErr == nil {
+		return 0, db.ErrRecordNotFound
+	}
+
+	return 0, fmt.Errorf("%w: %s", ErrIssueNotFound, ref)
+}
+
+// IssueByNumber returns the issue of a repository with the given number.
+func (d *Backend) IssueByNumber(ctx context.Context, repoName string, number int64) (models.Issue, error) {
+	repoName = utils.SanitizeRepo(repoName)
+
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return models.Issue{}, err
+	}
+
+	issue, err := d.store.GetIssueByNumber(ctx, d.db, r.ID(), number)
+	if err != nil {
+		err = db.WrapError(err)
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return models.Issue{}, fmt.Errorf("%w: #%d", ErrIssueNotFound, number)
+		}
+		return models.Issue{}, err
+	}
+
+	return d.GetIssue(ctx, repoName, issue.ID)
+}
+
+// ImportedIssue returns the issue of a repository imported with the given
+// external ID.
+func (d *Backend) ImportedIssue(ctx context.Context, repoName string, externalID string) (models.Issue, error) {
+	repoName = utils.SanitizeRepo(repoName)
+
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return models.Issue{}, err
+	}
+
+	issue, err := d.store.GetIssueByExternalID(ctx, d.db, r.ID(), strings.TrimSpace(externalID))
+	if err != nil {
+		err = db.WrapError(err)
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return models.Issue{}, fmt.Errorf("%w: %s", ErrIssueNotFound, externalID)
+		}
+		return models.Issue{}, err
+	}
+
+	return d.GetIssue(ctx, repoName, issue.ID)
+}
+
+// ImportIssue creates or updates the issue of a repository imported from
+// another tracker, identified by its ID in that tracker. The current user
+// becomes the author of created issues. Imports don't send webhook events
+// nor notify watchers. It returns the issue and whether it was created.
+func (d *Backend) ImportIssue(ctx context.Context, repoName string, externalID string, title string, description string, state models.IssueState) (models.Issue, bool, error) {
+	repoName = utils.SanitizeRepo(repoName)
+	externalID = strings.TrimSpace(externalID)
+	switch {
+	case externalID == "":
+		return models.Issue{}, false, fmt.Errorf("%w: external ID cannot be empty", ErrInvalidIssue)
+	case len(externalID) > maxExternalIDLength:
+		return models.Issue{}, false, fmt.Errorf("%w: external ID cannot be longer than %d characters", ErrInvalidIssue, maxExternalIDLength)
+	}
+
+	title, description, err := d.sanitizeTitleAndDescription(title, description)
+	if err != nil {
+		return models.Issue{}, false, fmt.Errorf("%w: %w", ErrInvalidIssue, err)
+	}
+
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return models.Issue{}, false, err
+	}
+
+	if err := d.checkIssuesEnabled(ctx, repoName); err != nil {
+		return models.Issue{}, false, err
+	}
+
+	user := proto.UserFromContext(ctx)
+	if user == nil {
+		return models.Issue{}, false, proto.ErrUserNotFound
+	}
+
+	preview, large := d.descriptionPreview(description)
+
+	var issueID int64
+	var created bool
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		issue, err := d.store.GetIssueByExternalID(ctx, tx, r.ID(), externalID)
+		switch {
+		case err == nil:
+			issueID = issue.ID
+			if err := d.store.UpdateIssue(ctx, tx, r.ID(), issueID, title, preview); err != nil {
+				return err
+			}
+		case errors.Is(db.WrapError(err), db.ErrRecordNotFound):
+			created = true
+			issue.State = models.IssueStateOpen
+			issueID, err = d.store.CreateIssue(ctx, tx, r.ID(), user.ID(), title, preview)
+			if err != nil {
+				return err
+			}
+			if err := d.store.SetIssueExternalID(ctx, tx, r.ID(), issueID, externalID); err != nil {
+				return err
+			}
+		default:
+			return err
+		}
+
+		if issue.State != state {
+			if state == models.IssueStateClosed {
+				err = d.store.CloseIssue(ctx, tx, r.ID(), issueID, user.ID(), models.IssueCloseReasonCompleted)
+			} else {
+				err = d.store.ReopenIssue(ctx, tx, r.ID(), issueID)
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		if large {
+			return d.store.SetIssueLargeText(ctx, tx, r.ID(), issueID, description)
+		}
+
+		return d.store.DeleteIssueLargeText(ctx, tx, r.ID(), issueID)
+	}); err != nil {
+		return models.Issue{}, false, db.WrapError(err)
+	}
+
+	d.audit(ctx, "issue.import", r.Name(), externalID)
+
+	issue, err := d.GetIssue(ctx, repoName, issueID)
+	return issue, created, err
+}
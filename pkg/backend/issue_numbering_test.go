@@ -0,0 +1,28 @@
+package backend
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFormatIssueRef(t *testing.T) {
+	if got := FormatIssueRef("", 12); got != "#12" {
+		t.Errorf("FormatIssueRef(\"\", 12) = %q, want %q", got, "#12")
+	}
+	if got := FormatIssueRef("SRV", 12); got != "SRV-12" {
+		t.Errorf("FormatIssueRef(\"SRV\", 12) = %q, want %q", got, "SRV-12")
+	}
+}
+
+func TestValidateIssuePrefix(t *testing.T) {
+	for _, prefix := range []string{"", "SRV", "srv", "A1", " OPS "} {
+		if err := ValidateIssuePrefix(prefix); err != nil {
+			t.Errorf("ValidateIssuePrefix(%q) = %v, want nil", prefix, err)
+		}
+	}
+	for _, prefix := range []string{"1SRV", "SRV-", "S_RV", "ABCDEFGHIJK"} {
+		if err := ValidateIssuePrefix(prefix); !errors.Is(err, ErrInvalidIssuePrefix) {
+			t.Errorf("ValidateIssuePrefix(%q) = %v, want %v", prefix, err, ErrInvalidIssuePrefix)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/charmbracelet/soft-serve/pkg/access"
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/utils"
+)
+
+var (
+	// ErrIssuePinningDisabled is returned when pinning an issue on a server
+	// that doesn't allow pinned issues.
+	ErrIssuePinningDisabled = errors.New("issue pinning is disabled")
+
+	// ErrTooManyPinnedIssues is returned when pinning an issue in a
+	// repository that already has the maximum number of pinned issues.
+	ErrTooManyPinnedIssues = errors.New("too many pinned issues, unpin an issue first")
+)
+
+// PinIssue pins an issue to the top of the issue list of its repository.
+func (d *Backend) PinIssue(ctx context.Context, repoName string, issueID int64) error {
+	return d.setIssuePinned(ctx, repoName, issueID, true)
+}
+
+// UnpinIssue unpins a pinned issue.
+func (d *Backend) UnpinIssue(ctx context.Context, repoName string, issueID int64) error {
+	return d.setIssuePinned(ctx, repoName, issueID, false)
+}
+
+// setIssuePinned pins or unpins an issue. Only collaborators can.
+func (d *Backend) setIssuePinned(ctx context.Context, repoName string, issueID int64, pinned bool) error {
+	repoName = utils.SanitizeRepo(repoName)
+
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return err
+	}
+
+	issue, err := d.GetIssue(ctx, repoName, issueID)
+	if err != nil {
+		return err
+	}
+
+	user := proto.UserFromContext(ctx)
+	if user == nil {
+		return proto.ErrUserNotFound
+	}
+	if d.AccessLevelForUser(ctx, r.Name(), user) < access.ReadWriteAccess {
+		return proto.ErrUnauthorized
+	}
+
+	// Pinning a pinned issue keeps its place.
+	if issue.Pinned.Valid == pinned {
+		return nil
+	}
+
+	max := d.cfg.Limits.MaxPinnedIssues
+	if pinned && max <= 0 {
+		return ErrIssuePinningDisabled
+	}
+
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		if pinned {
+			issues, err := d.store.GetPinnedIssuesByRepoID(ctx, tx, r.ID())
+			if err != nil {
+				return err
+			}
+			if len(issues) >= max {
+				return ErrTooManyPinnedIssues
+			}
+		}
+		return d.store.SetIssuePinned(ctx, tx, r.ID(), issueID, pinned)
+	}); err != nil {
+		return db.WrapError(err)
+	}
+
+	action := "issue.unpin"
+	if pinned {
+		action = "issue.pin"
+	}
+	d.audit(ctx, action, r.Name(), fmt.Sprintf("issue #%d", issue.Number))
+
+	return nil
+}
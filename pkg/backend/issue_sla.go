@@ -0,0 +1,192 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/utils"
+	"github.com/charmbracelet/soft-serve/pkg/webhook"
+)
+
+// ErrNoIssueSLA is returned when a repository has no issue SLA.
+var ErrNoIssueSLA = errors.New("repository has no issue SLA")
+
+// IssueSLAStatus is the response time SLA status of an issue.
+type IssueSLAStatus struct {
+	Issue models.Issue
+	// Due is when the issue must get a first response.
+	Due time.Time
+	// Breached is true if the issue got its first response late, or is past
+	// due without one.
+	Breached bool
+}
+
+// SetIssueSLA sets the number of hours within which new issues of a
+// repository must get a first response from someone other than their author.
+func (d *Backend) SetIssueSLA(ctx context.Context, repoName string, responseHours int) error {
+	repoName = utils.SanitizeRepo(repoName)
+	if responseHours <= 0 {
+		return errors.New("response time must be at least one hour")
+	}
+
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return err
+	}
+
+	return db.WrapError(d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		return d.store.SetIssueSLA(ctx, tx, r.ID(), responseHours)
+	}))
+}
+
+// IssueSLA returns the issue SLA of a repository.
+func (d *Backend) IssueSLA(ctx context.Context, repoName string) (models.IssueSLA, error) {
+	repoName = utils.SanitizeRepo(repoName)
+
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return models.IssueSLA{}, err
+	}
+
+	sla, err := d.store.GetIssueSLAByRepoID(ctx, d.db, r.ID())
+	if err != nil {
+		err = db.WrapError(err)
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return models.IssueSLA{}, ErrNoIssueSLA
+		}
+		return models.IssueSLA{}, err
+	}
+
+	return sla, nil
+}
+
+// DeleteIssueSLA deletes the issue SLA of a repository.
+func (d *Backend) DeleteIssueSLA(ctx context.Context, repoName string) error {
+	repoName = utils.SanitizeRepo(repoName)
+
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return err
+	}
+
+	err = db.WrapError(d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		if _, err := d.store.GetIssueSLAByRepoID(ctx, tx, r.ID()); err != nil {
+			return err
+		}
+		return d.store.DeleteIssueSLAByRepoID(ctx, tx, r.ID())
+	}))
+	if errors.Is(err, db.ErrRecordNotFound) {
+		return ErrNoIssueSLA
+	}
+
+	return err
+}
+
+// IssueSLAReport returns the SLA status of the issues opened since the
+// repository SLA was set, oldest first.
+func (d *Backend) IssueSLAReport(ctx context.Context, repoName string, now time.Time) ([]IssueSLAStatus, error) {
+	sla, err := d.IssueSLA(ctx, repoName)
+	if err != nil {
+		return nil, err
+	}
+
+	issues, err := d.store.GetIssuesByRepoID(ctx, d.db, sla.RepoID, models.ListOrder{}, 0, 0)
+	if err != nil {
+		return nil, db.WrapError(err)
+	}
+
+	var report []IssueSLAStatus
+	for i := len(issues) - 1; i >= 0; i-- {
+		issue := issues[i]
+		// Draft issues wait for their author, not for a response.
+		if issue.Draft || issue.CreatedAt.Before(sla.CreatedAt) {
+			continue
+		}
+
+		due := issueSLADue(sla, issue)
+		breached := issue.SLABreachedAt.Valid
+		if issue.RespondedAt.Valid {
+			breached = breached || issue.RespondedAt.Time.After(due)
+		} else if issue.State == models.IssueStateOpen {
+			breached = breached || !now.Before(due)
+		}
+
+		report = append(report, IssueSLAStatus{
+			Issue:    issue,
+			Due:      due,
+			Breached: breached,
+		})
+	}
+
+	return report, nil
+}
+
+// CheckIssueSLAs marks the open issues that went past their response time SLA
+// without a response as breached, and sends an issue webhook event with the
+// sla_breached action for each of them.
+func (d *Backend) CheckIssueSLAs(ctx context.Context, now time.Time) error {
+	slas, err := d.store.GetIssueSLAs(ctx, d.db)
+	if err != nil {
+		return db.WrapError(err)
+	}
+	if len(slas) == 0 {
+		return nil
+	}
+
+	repos, err := d.Repositories(ctx)
+	if err != nil {
+		return err
+	}
+	byID := make(map[int64]proto.Repository, len(repos))
+	for _, r := range repos {
+		byID[r.ID()] = r
+	}
+
+	for _, sla := range slas {
+		r, ok := byID[sla.RepoID]
+		if !ok {
+			continue
+		}
+		if enabled, _ := d.IsIssuesEnabled(ctx, r.Name()); !enabled {
+			continue
+		}
+
+		if err := d.checkIssueSLA(ctx, r, sla, now); err != nil {
+			d.logger.Error("error checking issue SLA", "repo", r.Name(), "err", err)
+		}
+	}
+
+	return nil
+}
+
+func (d *Backend) checkIssueSLA(ctx context.Context, r proto.Repository, sla models.IssueSLA, now time.Time) error {
+	issues, err := d.store.GetIssuesByRepoIDAndState(ctx, d.db, r.ID(), models.IssueStateOpen, models.ListOrder{}, 0, 0)
+	if err != nil {
+		return db.WrapError(err)
+	}
+
+	for _, issue := range issues {
+		if issue.Draft || issue.RespondedAt.Valid || issue.SLABreachedAt.Valid ||
+			issue.CreatedAt.Before(sla.CreatedAt) || now.Before(issueSLADue(sla, issue)) {
+			continue
+		}
+
+		if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+			return d.store.SetIssueSLABreached(ctx, tx, r.ID(), issue.ID)
+		}); err != nil {
+			return db.WrapError(err)
+		}
+
+		d.sendIssueEvent(ctx, r, issue.ID, webhook.IssueEventActionSLABreached)
+	}
+
+	return nil
+}
+
+func issueSLADue(sla models.IssueSLA, issue models.Issue) time.Time {
+	return issue.CreatedAt.Add(time.Duration(sla.ResponseHours) * time.Hour)
+}
@@ -0,0 +1,130 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/tracker"
+	"github.com/charmbracelet/soft-serve/pkg/utils"
+	"github.com/charmbracelet/soft-serve/pkg/webhook"
+)
+
+// ErrNoIssueTracker is returned when a repository has no issue tracker.
+var ErrNoIssueTracker = errors.New("repository has no issue tracker")
+
+// SetIssueTracker links a repository to an external issue tracker, replacing
+// any existing one.
+func (d *Backend) SetIssueTracker(ctx context.Context, repoName string, t tracker.Tracker) error {
+	repoName = utils.SanitizeRepo(repoName)
+	t.URL = utils.Sanitize(t.URL)
+
+	// Validate tracker URL to prevent SSRF attacks
+	if err := webhook.ValidateWebhookURL(t.URL); err != nil {
+		return err //nolint:wrapcheck
+	}
+	if len(t.Projects) == 0 {
+		return errors.New("at least one project key is required")
+	}
+
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return err
+	}
+
+	return db.WrapError(d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		return d.store.SetIssueTracker(ctx, tx, r.ID(), int(t.Kind), t.URL,
+			strings.Join(t.Projects, ","), t.Username, t.Token, t.DoneState)
+	}))
+}
+
+// IssueTracker returns the external issue tracker of a repository.
+func (d *Backend) IssueTracker(ctx context.Context, repoName string) (tracker.Tracker, error) {
+	repoName = utils.SanitizeRepo(repoName)
+
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return tracker.Tracker{}, err
+	}
+
+	m, err := d.store.GetIssueTrackerByRepoID(ctx, d.db, r.ID())
+	if err != nil {
+		err = db.WrapError(err)
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return tracker.Tracker{}, ErrNoIssueTracker
+		}
+		return tracker.Tracker{}, err
+	}
+
+	return toTracker(m), nil
+}
+
+// DeleteIssueTracker unlinks a repository from its external issue tracker.
+func (d *Backend) DeleteIssueTracker(ctx context.Context, repoName string) error {
+	repoName = utils.SanitizeRepo(repoName)
+
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return err
+	}
+
+	err = db.WrapError(d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		if _, err := d.store.GetIssueTrackerByRepoID(ctx, tx, r.ID()); err != nil {
+			return err
+		}
+		return d.store.DeleteIssueTrackerByRepoID(ctx, tx, r.ID())
+	}))
+	if errors.Is(err, db.ErrRecordNotFound) {
+		return ErrNoIssueTracker
+	}
+
+	return err
+}
+
+// IssueTrackerLinks returns the external tracker issues mentioned in texts.
+// It returns nil when the repository has no issue tracker.
+func (d *Backend) IssueTrackerLinks(ctx context.Context, repoName string, texts ...string) []tracker.Link {
+	t, err := d.IssueTracker(ctx, repoName)
+	if err != nil {
+		return nil
+	}
+
+	return t.Links(texts...)
+}
+
+// transitionLinkedIssues moves the external tracker issues mentioned by a
+// merged merge request to the done state. The merge has already happened, so
+// errors are logged instead of returned.
+func (d *Backend) transitionLinkedIssues(ctx context.Context, r proto.Repository, mr models.MergeRequest) {
+	t, err := d.IssueTracker(ctx, r.Name())
+	if err != nil {
+		if !errors.Is(err, ErrNoIssueTracker) {
+			d.logger.Error("error getting issue tracker", "repo", r.Name(), "err", err)
+		}
+		return
+	}
+	if t.DoneState == "" {
+		return
+	}
+
+	for _, key := range t.Keys(mr.Title, mr.Description, mr.SourceBranch) {
+		if err := t.Transition(ctx, key, t.DoneState); err != nil {
+			d.logger.Error("error transitioning tracker issue", "repo", r.Name(), "issue", key, "err", err)
+		}
+	}
+}
+
+func toTracker(m models.IssueTracker) tracker.Tracker {
+	projects, _ := tracker.ParseProjects(m.Projects)
+	return tracker.Tracker{
+		Kind:      tracker.Kind(m.Kind), //nolint:gosec
+		URL:       m.URL,
+		Projects:  projects,
+		Username:  m.Username,
+		Token:     m.Token,
+		DoneState: m.DoneState,
+	}
+}
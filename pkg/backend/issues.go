@@ -2,16 +2,28 @@ package backend
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/charmbracelet/soft-serve/pkg/db"
 	"github.com/charmbracelet/soft-serve/pkg/db/models"
 	"github.com/charmbracelet/soft-serve/pkg/proto"
 	"github.com/charmbracelet/soft-serve/pkg/utils"
+	"github.com/charmbracelet/soft-serve/pkg/webhook"
 )
 
 // CreateIssue creates a new issue for a repository.
 func (d *Backend) CreateIssue(ctx context.Context, repoName string, title string, description string) (int64, error) {
+	return d.createIssue(ctx, repoName, title, description, false)
+}
+
+func (d *Backend) createIssue(ctx context.Context, repoName string, title string, description string, draft bool) (int64, error) {
 	repoName = utils.SanitizeRepo(repoName)
+	title, description, err := d.sanitizeTitleAndDescription(title, description)
+	if err != nil {
+		return 0, err
+	}
 
 	// Get repository
 	r, err := d.Repository(ctx, repoName)
@@ -19,22 +31,71 @@ func (d *Backend) CreateIssue(ctx context.Context, repoName string, title string
 		return 0, err
 	}
 
+	if err := d.checkIssuesEnabled(ctx, repoName); err != nil {
+		return 0, err
+	}
+
+	if err := d.checkNotBlocked(ctx, r); err != nil {
+		return 0, err
+	}
+
 	// Get current user
 	user := proto.UserFromContext(ctx)
 	if user == nil {
 		return 0, proto.ErrUserNotFound
 	}
 
+	first, err := d.IsFirstContribution(ctx, repoName, user)
+	if err != nil {
+		return 0, err
+	}
+
 	// Create issue in database
+	preview, large := d.descriptionPreview(description)
+
 	var issueID int64
 	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
 		var err error
-		issueID, err = d.store.CreateIssue(ctx, tx, r.ID(), user.ID(), title, description)
-		return err
+		issueID, err = d.store.CreateIssue(ctx, tx, r.ID(), user.ID(), title, preview)
+		if err != nil {
+			return err
+		}
+
+		issue, err := d.store.GetIssueByID(ctx, tx, r.ID(), issueID)
+		if err != nil {
+			return err
+		}
+		if err := d.store.AddSubscription(ctx, tx, r.ID(), NotificationSubjectIssue, issue.Number, user.ID()); err != nil {
+			return err
+		}
+
+		if first {
+			if err := d.store.SetIssueFirstContribution(ctx, tx, r.ID(), issueID); err != nil {
+				return err
+			}
+		}
+
+		if draft {
+			if err := d.store.SetIssueDraft(ctx, tx, r.ID(), issueID, true); err != nil {
+				return err
+			}
+		}
+
+		if large {
+			return d.store.SetIssueLargeText(ctx, tx, r.ID(), issueID, description)
+		}
+
+		return nil
 	}); err != nil {
 		return 0, db.WrapError(err)
 	}
 
+	// Draft issues are announced when published.
+	if !draft {
+		d.indexCrossReferences(ctx, r, NotificationSubjectIssue, issueID, title, description)
+		d.sendIssueEvent(ctx, r, issueID, webhook.IssueEventActionOpened)
+	}
+
 	return issueID, nil
 }
 
@@ -47,20 +108,52 @@ func (d *Backend) GetIssue(ctx context.Context, repoName string, issueID int64)
 		return models.Issue{}, err
 	}
 
+	if err := d.checkIssuesEnabled(ctx, repoName); err != nil {
+		return models.Issue{}, err
+	}
+
 	var issue models.Issue
 	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
 		var err error
 		issue, err = d.store.GetIssueByID(ctx, tx, r.ID(), issueID)
+		if err != nil {
+			return err
+		}
+		if !issueVisible(issue, proto.UserFromContext(ctx)) {
+			return db.ErrRecordNotFound
+		}
+
+		// Lazily load the full description when only a preview is stored
+		// inline.
+		if issue.DescriptionTruncated {
+			issue.Description, err = d.store.GetIssueLargeText(ctx, tx, r.ID(), issueID)
+		}
+
 		return err
 	}); err != nil {
 		return models.Issue{}, db.WrapError(err)
 	}
+	d.redactIssue(ctx, r, &issue)
 
 	return issue, nil
 }
 
-// ListIssues returns all issues for a repository.
-func (d *Backend) ListIssues(ctx context.Context, repoName string, state *models.IssueState) ([]models.Issue, error) {
+// IssueListOptions are options for listing the issues of a repository.
+type IssueListOptions struct {
+	// State limits the list to the issues in a state. Nil lists every issue.
+	State *models.IssueState
+	// Order is the order of the list. The zero value lists the newest first.
+	Order models.ListOrder
+	// Limit is the maximum number of issues listed. 0 lists every issue.
+	Limit int
+	// After is the number of the last issue of the previous page. Only the
+	// issues following it in order are listed.
+	After int64
+}
+
+// ListIssues returns a page of the issues for a repository in order. The
+// pinned issues top the first page, in the order they were pinned.
+func (d *Backend) ListIssues(ctx context.Context, repoName string, opts IssueListOptions) ([]models.Issue, error) {
 	repoName = utils.SanitizeRepo(repoName)
 
 	r, err := d.Repository(ctx, repoName)
@@ -68,43 +161,245 @@ func (d *Backend) ListIssues(ctx context.Context, repoName string, state *models
 		return nil, err
 	}
 
+	if err := d.checkIssuesEnabled(ctx, repoName); err != nil {
+		return nil, err
+	}
+
+	user := proto.UserFromContext(ctx)
 	var issues []models.Issue
 	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
-		var err error
-		if state == nil {
-			issues, err = d.store.GetIssuesByRepoID(ctx, tx, r.ID())
-		} else {
-			issues, err = d.store.GetIssuesByRepoIDAndState(ctx, tx, r.ID(), *state)
+		all, err := d.store.GetPinnedIssuesByRepoID(ctx, tx, r.ID())
+		if err != nil {
+			return err
+		}
+
+		// Pinned issues top the first page, so they are left out of the
+		// pages. Fetch as many more issues to keep the pages full.
+		pinned := make(map[int64]bool, len(all))
+		for _, issue := range all {
+			pinned[issue.ID] = true
+			if opts.After == 0 && (opts.State == nil || issue.State == *opts.State) && issueVisible(issue, user) {
+				issues = append(issues, issue)
+			}
+		}
+		limit := opts.Limit
+		if limit > 0 {
+			limit += len(all)
+		}
+
+		var n int
+		after := opts.After
+		for {
+			var page []models.Issue
+			if opts.State == nil {
+				page, err = d.store.GetIssuesByRepoID(ctx, tx, r.ID(), opts.Order, limit, after)
+			} else {
+				page, err = d.store.GetIssuesByRepoIDAndState(ctx, tx, r.ID(), *opts.State, opts.Order, limit, after)
+			}
+			if err != nil {
+				return err
+			}
+
+			for _, issue := range page {
+				if pinned[issue.ID] || !issueVisible(issue, user) {
+					continue
+				}
+				if opts.Limit > 0 && n == opts.Limit {
+					break
+				}
+				issues = append(issues, issue)
+				n++
+			}
+
+			// The draft issues of other users leave the page short. Fetch
+			// the issues following it until it's full.
+			if limit == 0 || len(page) < limit || n == opts.Limit {
+				return nil
+			}
+			after = page[len(page)-1].Number
 		}
-		return err
 	}); err != nil {
 		return nil, db.WrapError(err)
 	}
+	for i := range issues {
+		d.redactIssue(ctx, r, &issues[i])
+	}
 
 	return issues, nil
 }
 
+// CountIssues returns the number of issues of a repository. A nil state
+// counts the issues in any state.
+func (d *Backend) CountIssues(ctx context.Context, repoName string, state *models.IssueState) (int64, error) {
+	repoName = utils.SanitizeRepo(repoName)
+
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := d.checkIssuesEnabled(ctx, repoName); err != nil {
+		return 0, err
+	}
+
+	count, err := d.store.CountIssuesByRepoID(ctx, d.db, r.ID(), state)
+	if err != nil {
+		return 0, db.WrapError(err)
+	}
+
+	var userID int64
+	if user := proto.UserFromContext(ctx); user != nil {
+		userID = user.ID()
+	}
+	drafts, err := d.store.CountOthersDraftIssuesByRepoID(ctx, d.db, r.ID(), state, userID)
+	if err != nil {
+		return 0, db.WrapError(err)
+	}
+	count -= drafts
+
+	return count, nil
+}
+
 // UpdateIssue updates an issue.
 func (d *Backend) UpdateIssue(ctx context.Context, repoName string, issueID int64, title string, description string) error {
 	repoName = utils.SanitizeRepo(repoName)
+	title, description, err := d.sanitizeTitleAndDescription(title, description)
+	if err != nil {
+		return err
+	}
 
 	r, err := d.Repository(ctx, repoName)
 	if err != nil {
 		return err
 	}
 
+	if err := d.checkIssueUnlocked(ctx, r, issueID); err != nil {
+		return err
+	}
+
+	if err := d.checkNotBlocked(ctx, r); err != nil {
+		return err
+	}
+
+	issue, err := d.store.GetIssueByID(ctx, d.db, r.ID(), issueID)
+	if err != nil {
+		return db.WrapError(err)
+	}
+	if err := d.checkCanEdit(ctx, r, issue.AuthorID); err != nil {
+		return err
+	}
+
+	user := proto.UserFromContext(ctx)
+	preview, large := d.descriptionPreview(description)
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		if err := d.store.UpdateIssue(ctx, tx, r.ID(), issueID, title, preview); err != nil {
+			return err
+		}
+
+		if err := d.store.SetIssueEditedBy(ctx, tx, r.ID(), issueID, user.ID()); err != nil {
+			return err
+		}
+
+		if err := d.store.SetIssueResponded(ctx, tx, r.ID(), issueID, user.ID()); err != nil {
+			return err
+		}
+
+		if large {
+			return d.store.SetIssueLargeText(ctx, tx, r.ID(), issueID, description)
+		}
+
+		return d.store.DeleteIssueLargeText(ctx, tx, r.ID(), issueID)
+	}); err != nil {
+		return db.WrapError(err)
+	}
+
+	if !issue.Draft {
+		d.indexCrossReferences(ctx, r, NotificationSubjectIssue, issueID, title, description)
+	}
+	d.sendIssueEvent(ctx, r, issueID, webhook.IssueEventActionEdited)
+	d.audit(ctx, "issue.edit", r.Name(), fmt.Sprintf("issue #%d", issue.Number))
+
+	return nil
+}
+
+// DeleteIssue deletes an issue for good, if the edit policy of the
+// repository lets the current user.
+func (d *Backend) DeleteIssue(ctx context.Context, repoName string, issueID int64) error {
+	repoName = utils.SanitizeRepo(repoName)
+
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return err
+	}
+
+	if err := d.checkIssueUnlocked(ctx, r, issueID); err != nil {
+		return err
+	}
+
+	if err := d.checkNotBlocked(ctx, r); err != nil {
+		return err
+	}
+
+	issue, err := d.store.GetIssueByID(ctx, d.db, r.ID(), issueID)
+	if err != nil {
+		return db.WrapError(err)
+	}
+	if err := d.checkCanEdit(ctx, r, issue.AuthorID); err != nil {
+		return err
+	}
+
 	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
-		return d.store.UpdateIssue(ctx, tx, r.ID(), issueID, title, description)
+		if err := d.store.DeleteIssue(ctx, tx, r.ID(), issueID); err != nil {
+			return err
+		}
+		return d.store.DeleteCrossReferencesBySource(ctx, tx, NotificationSubjectIssue, issueID)
 	}); err != nil {
 		return db.WrapError(err)
 	}
 
+	d.audit(ctx, "issue.delete", r.Name(), fmt.Sprintf("issue #%d", issue.Number))
+
 	return nil
 }
 
-// CloseIssue closes an issue.
-func (d *Backend) CloseIssue(ctx context.Context, repoName string, issueID int64) error {
+// ErrInvalidIssueCloseReason is returned when closing an issue for an unknown
+// reason.
+var ErrInvalidIssueCloseReason = errors.New("invalid close reason, must be one of completed, not-planned, or duplicate")
+
+// ParseIssueCloseReason parses an issue close reason, case-insensitively. The
+// empty reason is completed.
+func ParseIssueCloseReason(s string) (models.IssueCloseReason, error) {
+	switch r := models.IssueCloseReason(strings.ToLower(strings.TrimSpace(s))); r {
+	case "":
+		return models.IssueCloseReasonCompleted, nil
+	case models.IssueCloseReasonCompleted, models.IssueCloseReasonNotPlanned, models.IssueCloseReasonDuplicate:
+		return r, nil
+	default:
+		return "", ErrInvalidIssueCloseReason
+	}
+}
+
+// CloseIssue closes an issue for the given reason, completed if empty. It
+// returns an OpenDependenciesError if the issue depends on open issues and
+// the dependency policy of the repository blocks closing it.
+func (d *Backend) CloseIssue(ctx context.Context, repoName string, issueID int64, reason models.IssueCloseReason) error {
+	return d.closeIssue(ctx, repoName, issueID, reason, false)
+}
+
+// ForceCloseIssue closes an issue for the given reason regardless of the
+// dependency policy of the repository.
+func (d *Backend) ForceCloseIssue(ctx context.Context, repoName string, issueID int64, reason models.IssueCloseReason) error {
+	return d.closeIssue(ctx, repoName, issueID, reason, true)
+}
+
+// closeIssue closes an issue, checking the dependency policy of the
+// repository unless forced.
+func (d *Backend) closeIssue(ctx context.Context, repoName string, issueID int64, reason models.IssueCloseReason, force bool) error {
 	repoName = utils.SanitizeRepo(repoName)
+	reason, err := ParseIssueCloseReason(string(reason))
+	if err != nil {
+		return err
+	}
 
 	r, err := d.Repository(ctx, repoName)
 	if err != nil {
@@ -117,12 +412,31 @@ func (d *Backend) CloseIssue(ctx context.Context, repoName string, issueID int64
 		return proto.ErrUserNotFound
 	}
 
+	if err := d.checkIssueUnlocked(ctx, r, issueID); err != nil {
+		return err
+	}
+
+	if err := d.checkNotBlocked(ctx, r); err != nil {
+		return err
+	}
+
+	if !force {
+		if err := d.checkDependenciesClosed(ctx, r.Name(), issueID); err != nil {
+			return err
+		}
+	}
+
 	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
-		return d.store.CloseIssue(ctx, tx, r.ID(), issueID, user.ID())
+		if err := d.store.SetIssueResponded(ctx, tx, r.ID(), issueID, user.ID()); err != nil {
+			return err
+		}
+		return d.store.CloseIssue(ctx, tx, r.ID(), issueID, user.ID(), reason)
 	}); err != nil {
 		return db.WrapError(err)
 	}
 
+	d.sendIssueEvent(ctx, r, issueID, webhook.IssueEventActionClosed)
+
 	return nil
 }
 
@@ -135,12 +449,22 @@ func (d *Backend) ReopenIssue(ctx context.Context, repoName string, issueID int6
 		return err
 	}
 
+	if err := d.checkIssueUnlocked(ctx, r, issueID); err != nil {
+		return err
+	}
+
+	if err := d.checkNotBlocked(ctx, r); err != nil {
+		return err
+	}
+
 	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
 		return d.store.ReopenIssue(ctx, tx, r.ID(), issueID)
 	}); err != nil {
 		return db.WrapError(err)
 	}
 
+	d.sendIssueEvent(ctx, r, issueID, webhook.IssueEventActionReopened)
+
 	return nil
 }
 
@@ -198,7 +522,7 @@ func (d *Backend) GetIssueDependencies(ctx context.Context, repoName string, iss
 		return nil, db.WrapError(err)
 	}
 
-	return dependencies, nil
+	return visibleIssues(dependencies, proto.UserFromContext(ctx)), nil
 }
 
 // GetIssueDependents returns all issues that depend on the given issue.
@@ -219,5 +543,127 @@ func (d *Backend) GetIssueDependents(ctx context.Context, repoName string, issue
 		return nil, db.WrapError(err)
 	}
 
-	return dependents, nil
+	return visibleIssues(dependents, proto.UserFromContext(ctx)), nil
+}
+
+// VoteIssue adds the current user's vote to an issue. Voting twice has no
+// effect. Only collaborators can vote on locked issues.
+func (d *Backend) VoteIssue(ctx context.Context, repoName string, issueID int64) error {
+	repoName = utils.SanitizeRepo(repoName)
+
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return err
+	}
+
+	if _, err := d.GetIssue(ctx, repoName, issueID); err != nil {
+		return err
+	}
+
+	user := proto.UserFromContext(ctx)
+	if user == nil {
+		return proto.ErrUserNotFound
+	}
+
+	if err := d.checkIssueUnlocked(ctx, r, issueID); err != nil {
+		return err
+	}
+
+	if err := d.checkNotBlocked(ctx, r); err != nil {
+		return err
+	}
+
+	err = db.WrapError(d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		return d.store.AddIssueVote(ctx, tx, r.ID(), issueID, user.ID())
+	}))
+	if errors.Is(err, db.ErrDuplicateKey) {
+		return nil
+	}
+
+	return err
+}
+
+// UnvoteIssue removes the current user's vote from an issue.
+func (d *Backend) UnvoteIssue(ctx context.Context, repoName string, issueID int64) error {
+	repoName = utils.SanitizeRepo(repoName)
+
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return err
+	}
+
+	user := proto.UserFromContext(ctx)
+	if user == nil {
+		return proto.ErrUserNotFound
+	}
+
+	return db.WrapError(d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		return d.store.RemoveIssueVote(ctx, tx, r.ID(), issueID, user.ID())
+	}))
+}
+
+// IssueVoteCounts returns the number of votes of the issues of a repository by
+// issue ID. Issues without votes are left out.
+func (d *Backend) IssueVoteCounts(ctx context.Context, repoName string) (map[int64]int, error) {
+	repoName = utils.SanitizeRepo(repoName)
+
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := d.store.GetIssueVoteCounts(ctx, d.db, r.ID())
+	if err != nil {
+		return nil, db.WrapError(err)
+	}
+
+	return counts, nil
+}
+
+// HasVotedIssue returns true if user voted for an issue.
+func (d *Backend) HasVotedIssue(ctx context.Context, repoName string, issueID int64, user proto.User) (bool, error) {
+	repoName = utils.SanitizeRepo(repoName)
+	if user == nil {
+		return false, nil
+	}
+
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return false, err
+	}
+
+	voted, err := d.store.HasIssueVote(ctx, d.db, r.ID(), issueID, user.ID())
+	if err != nil {
+		return false, db.WrapError(err)
+	}
+
+	return voted, nil
+}
+
+// sendIssueEvent sends an issue webhook event and notifies the watchers of
+// the repository. The issue change has already been committed, so errors are logged instead of returned.
+// Draft issues send no events until PublishIssue opens them.
+func (d *Backend) sendIssueEvent(ctx context.Context, r proto.Repository, issueID int64, action webhook.IssueEventAction) {
+	issue, err := d.GetIssue(ctx, r.Name(), issueID)
+	if err != nil {
+		d.logger.Error("error finding issue", "repo", r.Name(), "issue", issueID, "err", err)
+		return
+	}
+	if issue.Draft {
+		return
+	}
+
+	wh, err := webhook.NewIssueEvent(ctx, proto.UserFromContext(ctx), r, issue, action)
+	if err != nil {
+		d.logger.Error("error creating issue webhook", "err", err)
+	} else if err := webhook.SendEvent(ctx, wh); err != nil {
+		d.logger.Error("error sending issue webhook", "err", err)
+	}
+
+	d.notifyWatchers(ctx, r, models.Notification{
+		SubjectType: NotificationSubjectIssue,
+		SubjectID:   issue.Number,
+		Title:       issue.Title,
+		Action:      string(action),
+	}, issueParticipants(issue)...)
 }
@@ -0,0 +1,24 @@
+package backend
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+)
+
+func TestParseIssueCloseReason(t *testing.T) {
+	for in, want := range map[string]models.IssueCloseReason{
+		"":              models.IssueCloseReasonCompleted,
+		"completed":     models.IssueCloseReasonCompleted,
+		" Not-Planned ": models.IssueCloseReasonNotPlanned,
+		"DUPLICATE":     models.IssueCloseReasonDuplicate,
+	} {
+		if got, err := ParseIssueCloseReason(in); err != nil || got != want {
+			t.Errorf("ParseIssueCloseReason(%q) = %q, %v, want %q", in, got, err, want)
+		}
+	}
+	if _, err := ParseIssueCloseReason("wontfix"); !errors.Is(err, ErrInvalidIssueCloseReason) {
+		t.Errorf("ParseIssueCloseReason(%q) error = %v, want ErrInvalidIssueCloseReason", "wontfix", err)
+	}
+}
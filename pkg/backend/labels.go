@@ -0,0 +1,306 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/utils"
+	"github.com/charmbracelet/soft-serve/pkg/webhook"
+)
+
+var (
+	// ErrLabelNotFound is returned when a repository has no label with a
+	// name.
+	ErrLabelNotFound = errors.New("label not found")
+
+	// ErrLabelExists is returned when creating or renaming a label to the
+	// name of another label of the repository.
+	ErrLabelExists = errors.New("label already exists")
+
+	labelColorRe = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+)
+
+// DefaultLabelColor is the color of the labels created without one.
+const DefaultLabelColor = "#8b949e"
+
+// maxLabelNameLength is the maximum length of label names, in characters.
+const maxLabelNameLength = 50
+
+// validateLabel returns the label name and color normalized, or an error if
+// they're invalid.
+func validateLabel(name, color string) (string, string, error) {
+	name = strings.TrimSpace(name)
+	switch {
+	case name == "":
+		return "", "", errors.New("label name cannot be empty")
+	case utf8.RuneCountInString(name) > maxLabelNameLength:
+		return "", "", fmt.Errorf("label name cannot be longer than %d characters", maxLabelNameLength)
+	case strings.ContainsAny(name, ","):
+		return "", "", errors.New("label name cannot contain commas")
+	}
+
+	if color == "" {
+		color = DefaultLabelColor
+	}
+	if !labelColorRe.MatchString(color) {
+		return "", "", fmt.Errorf("invalid label color %q, use a hex color like #d73a4a", color)
+	}
+
+	return name, strings.ToLower(color), nil
+}
+
+// Labels returns the labels of a repository by name.
+func (d *Backend) Labels(ctx context.Context, repoName string) ([]models.Label, error) {
+	repoName = utils.SanitizeRepo(repoName)
+
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return nil, err
+	}
+
+	labels, err := d.store.GetLabelsByRepoID(ctx, d.db, r.ID())
+	if err != nil {
+		return nil, db.WrapError(err)
+	}
+
+	return labels, nil
+}
+
+// Label returns a label of a repository by its name.
+func (d *Backend) Label(ctx context.Context, repoName string, name string) (models.Label, error) {
+	repoName = utils.SanitizeRepo(repoName)
+
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return models.Label{}, err
+	}
+
+	return d.label(ctx, d.db, r, name)
+}
+
+// label returns a label of a repository by its name.
+func (d *Backend) label(ctx context.Context, h db.Handler, r proto.Repository, name string) (models.Label, error) {
+	label, err := d.store.GetLabelByName(ctx, h, r.ID(), strings.TrimSpace(name))
+	if err != nil {
+		err = db.WrapError(err)
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return models.Label{}, fmt.Errorf("%w: %s", ErrLabelNotFound, name)
+		}
+		return models.Label{}, err
+	}
+
+	return label, nil
+}
+
+// CreateLabel creates a label in a repository. An empty color uses
+// DefaultLabelColor.
+func (d *Backend) CreateLabel(ctx context.Context, repoName string, name string, color string, description string) error {
+	repoName = utils.SanitizeRepo(repoName)
+	name, color, err := validateLabel(name, color)
+	if err != nil {
+		return err
+	}
+
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return err
+	}
+
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		return d.store.CreateLabel(ctx, tx, r.ID(), name, color, strings.TrimSpace(description))
+	}); err != nil {
+		err = db.WrapError(err)
+		if errors.Is(err, db.ErrDuplicateKey) {
+			return ErrLabelExists
+		}
+		return err
+	}
+
+	d.audit(ctx, "label.create", r.Name(), name)
+	return nil
+}
+
+// UpdateLabel renames the label name of a repository, and changes its color
+// and description, to the ones of label.
+func (d *Backend) UpdateLabel(ctx context.Context, repoName string, name string, label models.Label) error {
+	repoName = utils.SanitizeRepo(repoName)
+	newName, color, err := validateLabel(label.Name, label.Color)
+	if err != nil {
+		return err
+	}
+
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return err
+	}
+
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		l, err := d.label(ctx, tx, r, name)
+		if err != nil {
+			return err
+		}
+		return d.store.UpdateLabel(ctx, tx, r.ID(), l.ID, newName, color, strings.TrimSpace(label.Description))
+	}); err != nil {
+		if errors.Is(err, ErrLabelNotFound) {
+			return err
+		}
+		err = db.WrapError(err)
+		if errors.Is(err, db.ErrDuplicateKey) {
+			return ErrLabelExists
+		}
+		return err
+	}
+
+	d.audit(ctx, "label.update", r.Name(), fmt.Sprintf("%s to %s %s", name, newName, color))
+	return nil
+}
+
+// DeleteLabel deletes a label of a repository, and detaches it from the
+// issues.
+func (d *Backend) DeleteLabel(ctx context.Context, repoName string, name string) error {
+	repoName = utils.SanitizeRepo(repoName)
+
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return err
+	}
+
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		l, err := d.label(ctx, tx, r, name)
+		if err != nil {
+			return err
+		}
+		return d.store.DeleteLabel(ctx, tx, r.ID(), l.ID)
+	}); err != nil {
+		if errors.Is(err, ErrLabelNotFound) {
+			return err
+		}
+		return db.WrapError(err)
+	}
+
+	d.audit(ctx, "label.delete", r.Name(), name)
+	return nil
+}
+
+// AddIssueLabels attaches labels of a repository to one of its issues.
+// Labels already attached are ignored.
+func (d *Backend) AddIssueLabels(ctx context.Context, repoName string, issueID int64, names ...string) error {
+	return d.setIssueLabels(ctx, repoName, issueID, names, true)
+}
+
+// RemoveIssueLabels detaches labels from an issue.
+func (d *Backend) RemoveIssueLabels(ctx context.Context, repoName string, issueID int64, names ...string) error {
+	return d.setIssueLabels(ctx, repoName, issueID, names, false)
+}
+
+// setIssueLabels attaches labels to, or detaches them from, an issue, and
+// sends an issue webhook event with the labeled or unlabeled action.
+func (d *Backend) setIssueLabels(ctx context.Context, repoName string, issueID int64, names []string, attach bool) error {
+	repoName = utils.SanitizeRepo(repoName)
+
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return err
+	}
+
+	if _, err := d.GetIssue(ctx, repoName, issueID); err != nil {
+		return err
+	}
+
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		for _, name := range names {
+			l, err := d.label(ctx, tx, r, name)
+			if err != nil {
+				return err
+			}
+
+			if !attach {
+				if err := d.store.RemoveIssueLabel(ctx, tx, r.ID(), issueID, l.ID); err != nil {
+					return err
+				}
+				continue
+			}
+
+			attached, err := d.store.GetIssueLabels(ctx, tx, r.ID(), issueID)
+			if err != nil {
+				return err
+			}
+			if !hasLabel(attached, l.ID) {
+				if err := d.store.AddIssueLabel(ctx, tx, r.ID(), issueID, l.ID); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}); err != nil {
+		if errors.Is(err, ErrLabelNotFound) {
+			return err
+		}
+		return db.WrapError(err)
+	}
+
+	action := webhook.IssueEventActionLabeled
+	if !attach {
+		action = webhook.IssueEventActionUnlabeled
+	}
+	d.sendIssueEvent(ctx, r, issueID, action)
+
+	return nil
+}
+
+// hasLabel returns true if labels has the label with the given ID.
+func hasLabel(labels []models.Label, id int64) bool {
+	for _, l := range labels {
+		if l.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// IssueLabels returns the labels attached to an issue by name.
+func (d *Backend) IssueLabels(ctx context.Context, repoName string, issueID int64) ([]models.Label, error) {
+	repoName = utils.SanitizeRepo(repoName)
+
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return nil, err
+	}
+
+	labels, err := d.store.GetIssueLabels(ctx, d.db, r.ID(), issueID)
+	if err != nil {
+		return nil, db.WrapError(err)
+	}
+
+	return labels, nil
+}
+
+// IssueLabelsByIssue returns the labels attached to the issues of a
+// repository by issue ID. Issues without labels are left out.
+func (d *Backend) IssueLabelsByIssue(ctx context.Context, repoName string) (map[int64][]models.Label, error) {
+	repoName = utils.SanitizeRepo(repoName)
+
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return nil, err
+	}
+
+	ils, err := d.store.GetIssueLabelsByRepoID(ctx, d.db, r.ID())
+	if err != nil {
+		return nil, db.WrapError(err)
+	}
+
+	labels := make(map[int64][]models.Label)
+	for _, il := range ils {
+		labels[il.IssueID] = append(labels[il.IssueID], il.Label)
+	}
+
+	return labels, nil
+}
@@ -0,0 +1,30 @@
+package backend
+
+import "testing"
+
+func TestValidateLabel(t *testing.T) {
+	cases := []struct {
+		name, color       string
+		wantName, wantCol string
+		wantErr           bool
+	}{
+		{"bug", "#D73A4A", "bug", "#d73a4a", false},
+		{" good first issue ", "#fff", "good first issue", "#fff", false},
+		{"docs", "", "docs", DefaultLabelColor, false},
+		{"", "#fff", "", "", true},
+		{"a,b", "#fff", "", "", true},
+		{"bug", "red", "", "", true},
+		{"bug", "#12345", "", "", true},
+	}
+
+	for _, c := range cases {
+		name, color, err := validateLabel(c.name, c.color)
+		if (err != nil) != c.wantErr {
+			t.Errorf("validateLabel(%q, %q) error = %v, want error %t", c.name, c.color, err, c.wantErr)
+			continue
+		}
+		if name != c.wantName || color != c.wantCol {
+			t.Errorf("validateLabel(%q, %q) = %q, %q, want %q, %q", c.name, c.color, name, color, c.wantName, c.wantCol)
+		}
+	}
+}
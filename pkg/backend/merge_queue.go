@@ -0,0 +1,411 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/soft-serve/git"
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/utils"
+	"github.com/charmbracelet/soft-serve/pkg/webhook"
+)
+
+var (
+	// ErrMergeQueueDisabled is returned when adding a merge request to the
+	// merge queue of a repository that doesn't use one.
+	ErrMergeQueueDisabled = errors.New("repository doesn't use a merge queue")
+
+	// ErrMergeQueueEnabled is returned when merging a merge request directly
+	// in a repository that uses a merge queue.
+	ErrMergeQueueEnabled = errors.New("repository uses a merge queue, add the merge request to the queue instead")
+
+	errMergeConflict = errors.New("merge conflict")
+)
+
+// mergeQueueRefPrefix is the prefix of the refs pointing to the speculative
+// merge commits of queued merge requests.
+const mergeQueueRefPrefix = "refs/merge-queue/"
+
+// queuedMergeRequest is a merge request in a merge train.
+type queuedMergeRequest struct {
+	entry models.MergeQueueEntry
+	mr    models.MergeRequest
+	// commit is the speculative merge commit of the merge request.
+	commit string
+	// username is the name of the user who queued the merge request.
+	username string
+}
+
+// IsMergeQueueEnabled returns true if the repository uses a merge queue.
+func (d *Backend) IsMergeQueueEnabled(ctx context.Context, name string) (bool, error) {
+	name = utils.SanitizeRepo(name)
+	var enabled bool
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		enabled, err = d.store.GetRepoIsMergeQueueByName(ctx, tx, name)
+		return err
+	}); err != nil {
+		return false, db.WrapError(err)
+	}
+
+	return enabled, nil
+}
+
+// SetMergeQueueEnabled opts a repository in or out of the merge queue.
+func (d *Backend) SetMergeQueueEnabled(ctx context.Context, name string, enabled bool) error {
+	name = utils.SanitizeRepo(name)
+
+	// Delete cache
+	d.cache.Delete(name)
+
+	return db.WrapError(d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		return d.store.SetRepoIsMergeQueueByName(ctx, tx, name, enabled)
+	}))
+}
+
+// MergeQueue returns the merge requests in the merge queue of a repository,
+// in the order they are merged.
+func (d *Backend) MergeQueue(ctx context.Context, repoName string) ([]models.MergeRequest, error) {
+	repoName = utils.SanitizeRepo(repoName)
+
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return nil, err
+	}
+
+	var mrs []models.MergeRequest
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		entries, err := d.store.GetMergeQueueEntriesByRepoID(ctx, tx, r.ID())
+		if err != nil {
+			return err
+		}
+
+		for _, e := range entries {
+			mr, err := d.store.GetMergeRequestByID(ctx, tx, r.ID(), e.MergeRequestID)
+			if err != nil {
+				return err
+			}
+			mrs = append(mrs, mr)
+		}
+		return nil
+	}); err != nil {
+		return nil, db.WrapError(err)
+	}
+
+	return mrs, nil
+}
+
+// EnqueueMergeRequest adds an open merge request to the end of the merge
+// queue of its repository. It's merged the next time the queue runs.
+func (d *Backend) EnqueueMergeRequest(ctx context.Context, repoName string, mrID int64) error {
+	repoName = utils.SanitizeRepo(repoName)
+
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return err
+	}
+
+	user := proto.UserFromContext(ctx)
+	if user == nil {
+		return proto.ErrUserNotFound
+	}
+
+	enabled, err := d.IsMergeQueueEnabled(ctx, repoName)
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		return ErrMergeQueueDisabled
+	}
+
+	mr, err := d.GetMergeRequest(ctx, repoName, mrID)
+	if err != nil {
+		return err
+	}
+	if mr.State != models.MergeRequestStateOpen {
+		return errors.New("merge request is not open")
+	}
+
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		if err := d.store.SetMergeRequestReviewed(ctx, tx, r.ID(), mrID, user.ID()); err != nil {
+			return err
+		}
+		return d.store.AddMergeQueueEntry(ctx, tx, r.ID(), mrID, user.ID())
+	}); err != nil {
+		err = db.WrapError(err)
+		if errors.Is(err, db.ErrDuplicateKey) {
+			return errors.New("merge request is already in the merge queue")
+		}
+		return err
+	}
+
+	return nil
+}
+
+// DequeueMergeRequest removes a merge request from the merge queue of its
+// repository.
+func (d *Backend) DequeueMergeRequest(ctx context.Context, repoName string, mrID int64) error {
+	repoName = utils.SanitizeRepo(repoName)
+
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return err
+	}
+
+	d.dropFromMergeQueue(ctx, r, mrID)
+	return nil
+}
+
+// dropFromMergeQueue removes a merge request and its speculative merge commit
+// from the merge queue. Errors are logged since the queue is retried anyway.
+func (d *Backend) dropFromMergeQueue(ctx context.Context, r proto.Repository, mrID int64) {
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		return d.store.DeleteMergeQueueEntry(ctx, tx, r.ID(), mrID)
+	}); err != nil {
+		d.logger.Error("error removing merge request from merge queue", "repo", r.Name(), "merge_request", mrID, "err", err)
+	}
+
+	d.deleteMergeQueueRef(ctx, r, mrID)
+}
+
+// deleteMergeQueueRef deletes the ref of the speculative merge commit of a
+// merge request, if any.
+func (d *Backend) deleteMergeQueueRef(ctx context.Context, r proto.Repository, mrID int64) {
+	gr, err := r.Open()
+	if err != nil {
+		return
+	}
+	ref := fmt.Sprintf("%s%d", mergeQueueRefPrefix, mrID)
+	if _, err := d.command(ctx, gitArgs("update-ref", []string{"-d"}, ref)...).RunInDir(gr.Path); err != nil {
+		d.logger.Debug("error deleting merge queue ref", "repo", r.Name(), "ref", ref, "err", err)
+	}
+}
+
+// ProcessMergeQueues runs the merge queue of every repository that uses one.
+func (d *Backend) ProcessMergeQueues(ctx context.Context) error {
+	repos, err := d.Repositories(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range repos {
+		enabled, err := d.IsMergeQueueEnabled(ctx, r.Name())
+		if err != nil {
+			return err
+		}
+		if !enabled {
+			continue
+		}
+
+		if err := d.processMergeQueue(ctx, r); err != nil {
+			d.logger.Error("error processing merge queue", "repo", r.Name(), "err", err)
+		}
+	}
+
+	return nil
+}
+
+// processMergeQueue runs one merge train per target branch of the merge
+// queue of a repository. Merge requests that are no longer open leave the
+// queue.
+func (d *Backend) processMergeQueue(ctx context.Context, r proto.Repository) error {
+	d.mergeQueueMu.Lock()
+	defer d.mergeQueueMu.Unlock()
+
+	entries, err := d.store.GetMergeQueueEntriesByRepoID(ctx, d.db, r.ID())
+	if err != nil {
+		return db.WrapError(err)
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+
+	var targets []string
+	trains := make(map[string][]*queuedMergeRequest)
+	for _, e := range entries {
+		mr, err := d.store.GetMergeRequestByID(ctx, d.db, r.ID(), e.MergeRequestID)
+		if err != nil || mr.State != models.MergeRequestStateOpen {
+			d.dropFromMergeQueue(ctx, r, e.MergeRequestID)
+			continue
+		}
+		// Drafts wait in the queue until they're marked ready.
+		if mr.Draft {
+			continue
+		}
+		// Stacked merge requests wait in the queue for their dependencies.
+		if blocked, err := d.hasUnmergedDependencies(ctx, d.db, r, mr.ID); err != nil || blocked {
+			continue
+		}
+		// So do merge requests still waiting for approvals.
+		if approvals, err := d.approvalStatus(ctx, d.db, r, mr.ID); err != nil || !approvals.Satisfied() {
+			continue
+		}
+		// And those failing the contribution agreements, until their
+		// authors fix them.
+		if failures, err := d.agreementFailures(ctx, r, mr); err != nil || len(failures) > 0 {
+			continue
+		}
+		if _, ok := trains[mr.TargetBranch]; !ok {
+			targets = append(targets, mr.TargetBranch)
+		}
+		trains[mr.TargetBranch] = append(trains[mr.TargetBranch], &queuedMergeRequest{entry: e, mr: mr})
+	}
+
+	gr, err := r.Open()
+	if err != nil {
+		return fmt.Errorf("failed to open repository: %w", err)
+	}
+
+	for _, target := range targets {
+		if err := d.runMergeTrain(ctx, r, gr, target, trains[target]); err != nil {
+			d.logger.Error("error running merge train", "repo", r.Name(), "branch", target, "err", err)
+		}
+	}
+
+	return nil
+}
+
+// runMergeTrain merges every merge request of the train on top of the target
+// branch and the merge requests ahead of it. Merge requests failing their
+// checks leave the queue without holding up the ones behind them. The others
+// land in order.
+func (d *Backend) runMergeTrain(ctx context.Context, r proto.Repository, gr *git.Repository, target string, train []*queuedMergeRequest) error {
+	base, err := d.revParse(ctx, gr, git.RefsHeads+target)
+	if err != nil {
+		return fmt.Errorf("failed to resolve target branch: %w", err)
+	}
+
+	head := base
+	landing := make([]*queuedMergeRequest, 0, len(train))
+	for _, q := range train {
+		commit, err := d.speculativeMerge(ctx, gr, head, q)
+		if err != nil {
+			d.logger.Warn("merge request failed merge queue checks", "repo", r.Name(), "merge_request", q.mr.ID, "err", err)
+			d.dropFromMergeQueue(ctx, r, q.mr.ID)
+			continue
+		}
+
+		ref := fmt.Sprintf("%s%d", mergeQueueRefPrefix, q.mr.ID)
+		if _, err := d.command(ctx, gitArgs("update-ref", nil, ref, commit)...).RunInDir(gr.Path); err != nil {
+			return fmt.Errorf("failed to update merge queue ref: %w", err)
+		}
+
+		q.commit = commit
+		head = commit
+		landing = append(landing, q)
+	}
+
+	prev := base
+	for _, q := range landing {
+		// This fails if the target branch moved while the train was built, or
+		// if the repository hooks reject the merge commit. The next run
+		// rebuilds the train on top of the new target, without the rejected
+		// merge request.
+		if err := d.updateRef(ctx, gr, r.Name(), q.username, git.RefsHeads+target, q.commit, prev); err != nil {
+			if head, rerr := d.revParse(ctx, gr, git.RefsHeads+target); rerr == nil && head == prev {
+				d.logger.Warn("merge request rejected by repository hooks", "repo", r.Name(), "merge_request", q.mr.ID, "err", err)
+				d.dropFromMergeQueue(ctx, r, q.mr.ID)
+				return nil
+			}
+			return fmt.Errorf("failed to land merge request #%d: %w", q.mr.ID, err)
+		}
+		prev = q.commit
+
+		d.landMergeRequest(ctx, r, q)
+	}
+
+	return nil
+}
+
+// speculativeMerge creates the merge commit of a queued merge request on top
+// of head without updating any branch. It fails if the source branch is gone
+// or doesn't merge cleanly.
+func (d *Backend) speculativeMerge(ctx context.Context, gr *git.Repository, head string, q *queuedMergeRequest) (string, error) {
+	source, err := d.revParse(ctx, gr, git.RefsHeads+q.mr.SourceBranch)
+	if err != nil {
+		return "", fmt.Errorf("source branch %q not found", q.mr.SourceBranch)
+	}
+
+	if user, err := d.UserByID(ctx, q.entry.UserID); err == nil {
+		q.username = user.Username()
+	}
+
+	msg := fmt.Sprintf("Merge branch '%s' into '%s'\n\nMerge request #%d: %s",
+		q.mr.SourceBranch, q.mr.TargetBranch, q.mr.ID, q.mr.Title)
+	commit, err := d.mergeCommit(ctx, gr, head, source, q.username, msg)
+	if errors.Is(err, errMergeConflict) {
+		return "", fmt.Errorf("merge conflict with %q", q.mr.TargetBranch)
+	}
+	return commit, err
+}
+
+// mergeCommit creates a merge commit of source on top of head, authored and
+// committed by name, without a work tree nor updating any branch. It returns
+// errMergeConflict if source doesn't merge cleanly. The merge commit is
+// signed with the server signing key, if one is configured.
+func (d *Backend) mergeCommit(ctx context.Context, gr *git.Repository, head string, source string, name string, msg string) (string, error) {
+	out, err := d.command(ctx, gitArgs("merge-tree", []string{"--write-tree"}, head, source)...).RunInDir(gr.Path)
+	if err != nil {
+		return "", errMergeConflict
+	}
+	tree, _, _ := strings.Cut(strings.TrimSpace(string(out)), "\n")
+
+	out, err = d.gitCommand(ctx, gitArgs("commit-tree", []string{"-p", head, "-p", source, "-m", msg}, tree)...).
+		AddEnvs(
+			"GIT_AUTHOR_NAME="+name,
+			"GIT_AUTHOR_EMAIL=",
+			"GIT_COMMITTER_NAME="+name,
+			"GIT_COMMITTER_EMAIL=",
+		).
+		RunInDir(gr.Path)
+	if err != nil {
+		return "", fmt.Errorf("failed to create merge commit: %w", err)
+	}
+
+	return strings.TrimSpace(string(out)), nil
+}
+
+// landMergeRequest marks a merge request whose merge commit landed as merged
+// by the user who queued it. The branch already moved, so errors are logged
+// instead of returned.
+func (d *Backend) landMergeRequest(ctx context.Context, r proto.Repository, q *queuedMergeRequest) {
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		if err := d.store.SetMergeRequestReviewed(ctx, tx, r.ID(), q.mr.ID, q.entry.UserID); err != nil {
+			return err
+		}
+		if err := d.store.MergeMergeRequest(ctx, tx, r.ID(), q.mr.ID, q.entry.UserID); err != nil {
+			return err
+		}
+		return d.store.DeleteMergeQueueEntry(ctx, tx, r.ID(), q.mr.ID)
+	}); err != nil {
+		d.logger.Error("error marking queued merge request merged", "repo", r.Name(), "merge_request", q.mr.ID, "err", err)
+		return
+	}
+
+	d.deleteMergeQueueRef(ctx, r, q.mr.ID)
+	d.indexMergeRequestCommits(ctx, r, q.mr.ID, q.commit)
+
+	if user, err := d.UserByID(ctx, q.entry.UserID); err == nil {
+		ctx = proto.WithUserContext(ctx, user)
+	}
+	d.sendMergeRequestEvent(ctx, r, q.mr.ID, webhook.MergeRequestEventActionMerged)
+	d.transitionLinkedIssues(ctx, r, q.mr)
+	d.retargetDependents(ctx, r, q.mr)
+}
+
+// revParse returns the commit ID of a ref.
+func (d *Backend) revParse(ctx context.Context, gr *git.Repository, ref string) (string, error) {
+	if err := validateRef(ref); err != nil {
+		return "", err
+	}
+
+	out, err := d.command(ctx, gitArgs("rev-parse", []string{"--verify"}, ref+"^{commit}")...).RunInDir(gr.Path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
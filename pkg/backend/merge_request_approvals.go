@@ -0,0 +1,216 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/charmbracelet/soft-serve/pkg/access"
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/utils"
+	"github.com/charmbracelet/soft-serve/pkg/webhook"
+)
+
+var (
+	// ErrSelfApproval is returned when the author of a merge request approves
+	// it.
+	ErrSelfApproval = errors.New("can't approve your own merge request")
+	// ErrApproverNoAccess is returned when a user who can't write to the
+	// repository approves a merge request.
+	ErrApproverNoAccess = errors.New("approver does not have write access to the repository")
+	// ErrInvalidRequiredApprovals is returned when setting a negative number
+	// of required approvals.
+	ErrInvalidRequiredApprovals = errors.New("invalid number of required approvals, must be 0 or more")
+	// ErrNotEnoughApprovals is returned when merging a merge request that
+	// doesn't have the approvals its repository requires.
+	ErrNotEnoughApprovals = errors.New("merge request doesn't have enough approvals")
+)
+
+// ApprovalStatus is the number of approvals of a merge request and the
+// number its repository requires.
+type ApprovalStatus struct {
+	Approvals int
+	Required  int
+}
+
+// Satisfied returns whether the merge request has the approvals it requires.
+func (s ApprovalStatus) Satisfied() bool {
+	return s.Approvals >= s.Required
+}
+
+// String returns the status as "approvals/required", or just the number of
+// approvals when none are required.
+func (s ApprovalStatus) String() string {
+	if s.Required == 0 {
+		return fmt.Sprintf("%d", s.Approvals)
+	}
+	return fmt.Sprintf("%d/%d", s.Approvals, s.Required)
+}
+
+// ApproveMergeRequest approves an open merge request as the current user.
+// Approving it again is a no-op.
+func (d *Backend) ApproveMergeRequest(ctx context.Context, repoName string, mrID int64) error {
+	repoName = utils.SanitizeRepo(repoName)
+	user := proto.UserFromContext(ctx)
+	if user == nil {
+		return proto.ErrUserNotFound
+	}
+
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return err
+	}
+	mr, err := d.GetMergeRequest(ctx, repoName, mrID)
+	if err != nil {
+		return err
+	}
+	if mr.State != models.MergeRequestStateOpen {
+		return errors.New("merge request is not open")
+	}
+	if mr.AuthorID == user.ID() {
+		return ErrSelfApproval
+	}
+	if d.AccessLevelForUser(ctx, r.Name(), user) < access.ReadWriteAccess {
+		return ErrApproverNoAccess
+	}
+
+	err = db.WrapError(d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		if err := d.store.SetMergeRequestReviewed(ctx, tx, r.ID(), mrID, user.ID()); err != nil {
+			return err
+		}
+		return d.store.AddMergeRequestApproval(ctx, tx, r.ID(), mrID, user.ID())
+	}))
+	if errors.Is(err, db.ErrDuplicateKey) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	d.audit(ctx, "merge_request.approve", r.Name(), fmt.Sprintf("merge request !%d", mrID))
+	d.sendMergeRequestEvent(ctx, r, mrID, webhook.MergeRequestEventActionApproved)
+	return nil
+}
+
+// RevokeApproval withdraws the current user's approval of a merge request.
+func (d *Backend) RevokeApproval(ctx context.Context, repoName string, mrID int64) error {
+	repoName = utils.SanitizeRepo(repoName)
+	user := proto.UserFromContext(ctx)
+	if user == nil {
+		return proto.ErrUserNotFound
+	}
+
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return err
+	}
+	if _, err := d.GetMergeRequest(ctx, repoName, mrID); err != nil {
+		return err
+	}
+
+	if err := db.WrapError(d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		return d.store.RemoveMergeRequestApproval(ctx, tx, r.ID(), mrID, user.ID())
+	})); err != nil {
+		return err
+	}
+
+	d.audit(ctx, "merge_request.revoke_approval", r.Name(), fmt.Sprintf("merge request !%d", mrID))
+	d.sendMergeRequestEvent(ctx, r, mrID, webhook.MergeRequestEventActionUnapproved)
+	return nil
+}
+
+// MergeRequestApprovers returns the usernames of the users who approved a
+// merge request, in the order they approved it.
+func (d *Backend) MergeRequestApprovers(ctx context.Context, repoName string, mrID int64) ([]string, error) {
+	repoName = utils.SanitizeRepo(repoName)
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return nil, err
+	}
+
+	users, err := d.store.GetMergeRequestApprovers(ctx, d.db, r.ID(), mrID)
+	if err != nil {
+		return nil, db.WrapError(err)
+	}
+
+	names := make([]string, 0, len(users))
+	for _, u := range users {
+		names = append(names, u.Username)
+	}
+	return names, nil
+}
+
+// MergeRequestApprovalStatus returns the number of approvals of a merge
+// request and the number its repository requires.
+func (d *Backend) MergeRequestApprovalStatus(ctx context.Context, repoName string, mrID int64) (ApprovalStatus, error) {
+	repoName = utils.SanitizeRepo(repoName)
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return ApprovalStatus{}, err
+	}
+	return d.approvalStatus(ctx, d.db, r, mrID)
+}
+
+// MergeRequestApprovalCounts returns the number of approvals of each merge
+// request of a repository, keyed by merge request ID.
+func (d *Backend) MergeRequestApprovalCounts(ctx context.Context, repoName string) (map[int64]int, error) {
+	repoName = utils.SanitizeRepo(repoName)
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := d.store.GetMergeRequestApprovalCountsByRepoID(ctx, d.db, r.ID())
+	if err != nil {
+		return nil, db.WrapError(err)
+	}
+	return counts, nil
+}
+
+func (d *Backend) approvalStatus(ctx context.Context, h db.Handler, r proto.Repository, mrID int64) (ApprovalStatus, error) {
+	required, err := d.store.GetRepoRequiredApprovalsByName(ctx, h, r.Name())
+	if err != nil {
+		return ApprovalStatus{}, err
+	}
+	n, err := d.store.CountMergeRequestApprovals(ctx, h, r.ID(), mrID)
+	if err != nil {
+		return ApprovalStatus{}, db.WrapError(err)
+	}
+	return ApprovalStatus{Approvals: n, Required: required}, nil
+}
+
+// RequiredApprovals returns the number of approvals merge requests of a
+// repository need to merge.
+func (d *Backend) RequiredApprovals(ctx context.Context, repoName string) (int, error) {
+	repoName = utils.SanitizeRepo(repoName)
+	n, err := d.store.GetRepoRequiredApprovalsByName(ctx, d.db, repoName)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return 0, proto.ErrRepoNotFound
+		}
+		return 0, err
+	}
+	return n, nil
+}
+
+// SetRequiredApprovals sets the number of approvals merge requests of a
+// repository need to merge. 0 doesn't require any.
+func (d *Backend) SetRequiredApprovals(ctx context.Context, repoName string, n int) error {
+	repoName = utils.SanitizeRepo(repoName)
+	if n < 0 {
+		return ErrInvalidRequiredApprovals
+	}
+	if _, err := d.Repository(ctx, repoName); err != nil {
+		return err
+	}
+
+	if err := db.WrapError(d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		return d.store.SetRepoRequiredApprovalsByName(ctx, tx, repoName, n)
+	})); err != nil {
+		return err
+	}
+
+	d.audit(ctx, "repo.required_approvals", repoName, fmt.Sprintf("%d", n))
+	return nil
+}
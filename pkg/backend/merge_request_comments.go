@@ -0,0 +1,261 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/soft-serve/git"
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/hooks"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/utils"
+	"github.com/charmbracelet/soft-serve/pkg/webhook"
+)
+
+var (
+	// ErrEmptyComment is returned when commenting without a body.
+	ErrEmptyComment = errors.New("comment can't be empty")
+	// ErrCommentNotFound is returned when replying to a comment that isn't
+	// on the merge request.
+	ErrCommentNotFound = errors.New("comment not found")
+	// ErrInvalidLineRange is returned when a comment is anchored to lines
+	// that aren't in the file.
+	ErrInvalidLineRange = errors.New("invalid line range")
+)
+
+// MergeRequestThread is a top-level comment of a merge request and its
+// replies, oldest first.
+type MergeRequestThread struct {
+	models.MergeRequestComment
+	Replies []models.MergeRequestComment
+}
+
+// CommentOnMergeRequest starts a thread on a merge request and returns the
+// ID of its comment.
+func (d *Backend) CommentOnMergeRequest(ctx context.Context, repoName string, mrID int64, body string) (int64, error) {
+	return d.addMergeRequestComment(ctx, repoName, mrID, 0, models.CommentAnchor{}, body)
+}
+
+// CommentOnMergeRequestLines starts a thread on lines start to end of a file
+// at a commit of a merge request, and returns the ID of its comment. An
+// empty commit is the head of the source branch.
+func (d *Backend) CommentOnMergeRequestLines(ctx context.Context, repoName string, mrID int64, path string, commit string, start int, end int, body string) (int64, error) {
+	repoName = utils.SanitizeRepo(repoName)
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return 0, err
+	}
+	mr, err := d.GetMergeRequest(ctx, repoName, mrID)
+	if err != nil {
+		return 0, err
+	}
+	if commit == "" {
+		commit = git.RefsHeads + mr.SourceBranch
+	}
+
+	anchor, err := anchorLines(r, commit, path, start, end)
+	if err != nil {
+		return 0, err
+	}
+
+	return d.addMergeRequestComment(ctx, repoName, mrID, 0, anchor, body)
+}
+
+// ReplyToMergeRequestComment replies to a comment of a merge request and
+// returns the ID of the reply. Replying to a reply adds to the same thread.
+func (d *Backend) ReplyToMergeRequestComment(ctx context.Context, repoName string, mrID int64, commentID int64, body string) (int64, error) {
+	return d.addMergeRequestComment(ctx, repoName, mrID, commentID, models.CommentAnchor{}, body)
+}
+
+func (d *Backend) addMergeRequestComment(ctx context.Context, repoName string, mrID int64, parentID int64, anchor models.CommentAnchor, body string) (int64, error) {
+	repoName = utils.SanitizeRepo(repoName)
+	body = strings.TrimSpace(utils.SanitizeText(body))
+	if body == "" {
+		return 0, ErrEmptyComment
+	}
+	if err := utils.ValidateDescription(body, d.cfg.Limits.MaxDescriptionSize); err != nil {
+		return 0, err
+	}
+
+	user := proto.UserFromContext(ctx)
+	if user == nil {
+		return 0, proto.ErrUserNotFound
+	}
+
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return 0, err
+	}
+	if err := d.checkNotBlocked(ctx, r); err != nil {
+		return 0, err
+	}
+	if _, err := d.GetMergeRequest(ctx, repoName, mrID); err != nil {
+		return 0, err
+	}
+
+	var id int64
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		if parentID != 0 {
+			parent, err := d.store.GetMergeRequestCommentByID(ctx, tx, r.ID(), mrID, parentID)
+			if errors.Is(db.WrapError(err), db.ErrRecordNotFound) {
+				return ErrCommentNotFound
+			} else if err != nil {
+				return err
+			}
+			// Threads are one level deep.
+			if parent.ParentID.Valid {
+				parentID = parent.ParentID.Int64
+			}
+		}
+
+		var err error
+		id, err = d.store.CreateMergeRequestComment(ctx, tx, r.ID(), mrID, user.ID(), parentID, anchor, body)
+		if err != nil {
+			return err
+		}
+
+		return d.store.SetMergeRequestReviewed(ctx, tx, r.ID(), mrID, user.ID())
+	}); err != nil {
+		if errors.Is(err, ErrCommentNotFound) {
+			return 0, err
+		}
+		return 0, db.WrapError(err)
+	}
+
+	d.sendMergeRequestEvent(ctx, r, mrID, webhook.MergeRequestEventActionCommented)
+
+	return id, nil
+}
+
+// MergeRequestThreads returns the comment threads of a merge request, oldest
+// first.
+func (d *Backend) MergeRequestThreads(ctx context.Context, repoName string, mrID int64) ([]MergeRequestThread, error) {
+	repoName = utils.SanitizeRepo(repoName)
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return nil, err
+	}
+
+	cs, err := d.store.GetMergeRequestComments(ctx, d.db, r.ID(), mrID)
+	if err != nil {
+		return nil, db.WrapError(err)
+	}
+
+	var threads []MergeRequestThread
+	index := make(map[int64]int)
+	for _, c := range cs {
+		d.redactMergeRequestComment(ctx, r, &c)
+		if !c.ParentID.Valid {
+			index[c.ID] = len(threads)
+			threads = append(threads, MergeRequestThread{MergeRequestComment: c})
+			continue
+		}
+		if i, ok := index[c.ParentID.Int64]; ok {
+			threads[i].Replies = append(threads[i].Replies, c)
+		}
+	}
+
+	return threads, nil
+}
+
+// ParseLineRange parses a line number or an inclusive range of line numbers,
+// e.g. "12" or "12-15".
+func ParseLineRange(s string) (int, int, error) {
+	from, to, isRange := strings.Cut(strings.TrimSpace(s), "-")
+	start, err := strconv.Atoi(from)
+	if err != nil {
+		return 0, 0, ErrInvalidLineRange
+	}
+	end := start
+	if isRange {
+		end, err = strconv.Atoi(to)
+		if err != nil {
+			return 0, 0, ErrInvalidLineRange
+		}
+	}
+	if start < 1 || end < start {
+		return 0, 0, ErrInvalidLineRange
+	}
+	return start, end, nil
+}
+
+// anchorLines returns the anchor of lines start to end of a file at a
+// revision of a repository.
+func anchorLines(r proto.Repository, rev string, path string, start int, end int) (models.CommentAnchor, error) {
+	if start < 1 || end < start {
+		return models.CommentAnchor{}, ErrInvalidLineRange
+	}
+
+	repo, err := r.Open()
+	if err != nil {
+		return models.CommentAnchor{}, err
+	}
+	commit, err := repo.CatFileCommit(rev)
+	if err != nil {
+		return models.CommentAnchor{}, git.ErrRevisionNotExist
+	}
+	tree, err := repo.LsTree(commit.ID.String())
+	if err != nil {
+		return models.CommentAnchor{}, err
+	}
+	path = strings.Trim(path, "/")
+	entry, err := tree.TreeEntry(path)
+	if err != nil || entry.IsTree() {
+		return models.CommentAnchor{}, git.ErrFileNotFound
+	}
+	content, err := entry.Contents()
+	if err != nil {
+		return models.CommentAnchor{}, err
+	}
+
+	lines := strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+	if end > len(lines) {
+		return models.CommentAnchor{}, ErrInvalidLineRange
+	}
+
+	return models.CommentAnchor{
+		Path:      path,
+		CommitSHA: commit.ID.String(),
+		StartLine: start,
+		EndLine:   end,
+		Snippet:   strings.Join(lines[start-1:end], "\n"),
+	}, nil
+}
+
+// outdateMergeRequestComments marks the anchored comments of the open merge
+// requests from the pushed branches as outdated when the push changed their
+// lines. Comments are kept, so their threads survive rebases and force
+// pushes.
+func (d *Backend) outdateMergeRequestComments(ctx context.Context, name string, args []hooks.HookArg) {
+	r, err := d.Repository(ctx, name)
+	if err != nil {
+		d.logger.Error("error finding repository", "repo", name, "err", err)
+		return
+	}
+
+	for _, arg := range args {
+		branch, ok := strings.CutPrefix(arg.RefName, git.RefsHeads)
+		if !ok {
+			continue
+		}
+		cs, err := d.store.GetCurrentAnchoredMergeRequestComments(ctx, d.db, r.ID(), branch)
+		if err != nil {
+			d.logger.Error("error getting merge request comments", "repo", name, "branch", branch, "err", err)
+			continue
+		}
+		for _, c := range cs {
+			if !git.IsZeroHash(arg.NewSha) {
+				anchor, err := anchorLines(r, arg.NewSha, c.Path, c.StartLine, c.EndLine)
+				if err == nil && anchor.Snippet == c.Snippet {
+					continue
+				}
+			}
+			if err := d.store.SetMergeRequestCommentOutdated(ctx, d.db, r.ID(), c.ID); err != nil {
+				d.logger.Error("error marking merge request comment outdated", "repo", name, "comment", c.ID, "err", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,24 @@
+package backend
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseLineRange(t *testing.T) {
+	for in, want := range map[string][2]int{
+		"12":     {12, 12},
+		" 3-7 ":  {3, 7},
+		"1-1":    {1, 1},
+		"10-100": {10, 100},
+	} {
+		if start, end, err := ParseLineRange(in); err != nil || start != want[0] || end != want[1] {
+			t.Errorf("ParseLineRange(%q) = %d, %d, %v, want %d, %d", in, start, end, err, want[0], want[1])
+		}
+	}
+	for _, in := range []string{"", "0", "-3", "7-3", "a", "3-", "3-b"} {
+		if _, _, err := ParseLineRange(in); !errors.Is(err, ErrInvalidLineRange) {
+			t.Errorf("ParseLineRange(%q) error = %v, want ErrInvalidLineRange", in, err)
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package backend
+
+import (
+	"context"
+	"errors"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/utils"
+	"github.com/charmbracelet/soft-serve/pkg/webhook"
+)
+
+var (
+	// ErrMergeRequestDependencyCycle is returned when adding a dependency
+	// would make a merge request depend on itself.
+	ErrMergeRequestDependencyCycle = errors.New("merge request dependency would create a cycle")
+
+	// ErrUnmergedDependencies is returned when merging a merge request whose
+	// dependencies haven't merged yet.
+	ErrUnmergedDependencies = errors.New("merge request depends on unmerged merge requests")
+)
+
+// AddMergeRequestDependency creates a dependency relationship where mrID
+// depends on dependsOnID, i.e. mrID is stacked on top of dependsOnID.
+func (d *Backend) AddMergeRequestDependency(ctx context.Context, repoName string, mrID int64, dependsOnID int64) error {
+	repoName = utils.SanitizeRepo(repoName)
+
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return err
+	}
+
+	if mrID == dependsOnID {
+		return ErrMergeRequestDependencyCycle
+	}
+
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		// Walk the dependencies of dependsOnID to make sure none of them is
+		// mrID.
+		seen := map[int64]bool{dependsOnID: true}
+		queue := []int64{dependsOnID}
+		for len(queue) > 0 {
+			id := queue[0]
+			queue = queue[1:]
+			deps, err := d.store.GetMergeRequestDependencies(ctx, tx, r.ID(), id)
+			if err != nil {
+				return err
+			}
+			for _, dep := range deps {
+				if dep.ID == mrID {
+					return ErrMergeRequestDependencyCycle
+				}
+				if !seen[dep.ID] {
+					seen[dep.ID] = true
+					queue = append(queue, dep.ID)
+				}
+			}
+		}
+
+		return d.store.AddMergeRequestDependency(ctx, tx, r.ID(), mrID, dependsOnID)
+	}); err != nil {
+		if errors.Is(err, ErrMergeRequestDependencyCycle) {
+			return err
+		}
+		return db.WrapError(err)
+	}
+
+	return nil
+}
+
+// RemoveMergeRequestDependency removes a dependency relationship.
+func (d *Backend) RemoveMergeRequestDependency(ctx context.Context, repoName string, mrID int64, dependsOnID int64) error {
+	repoName = utils.SanitizeRepo(repoName)
+
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return err
+	}
+
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		return d.store.RemoveMergeRequestDependency(ctx, tx, r.ID(), mrID, dependsOnID)
+	}); err != nil {
+		return db.WrapError(err)
+	}
+
+	return nil
+}
+
+// GetMergeRequestDependencies returns all merge requests that the given merge
+// request depends on.
+func (d *Backend) GetMergeRequestDependencies(ctx context.Context, repoName string, mrID int64) ([]models.MergeRequest, error) {
+	repoName = utils.SanitizeRepo(repoName)
+
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return nil, err
+	}
+
+	var dependencies []models.MergeRequest
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		dependencies, err = d.store.GetMergeRequestDependencies(ctx, tx, r.ID(), mrID)
+		return err
+	}); err != nil {
+		return nil, db.WrapError(err)
+	}
+
+	return dependencies, nil
+}
+
+// GetMergeRequestDependents returns all merge requests that depend on the
+// given merge request.
+func (d *Backend) GetMergeRequestDependents(ctx context.Context, repoName string, mrID int64) ([]models.MergeRequest, error) {
+	repoName = utils.SanitizeRepo(repoName)
+
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return nil, err
+	}
+
+	var dependents []models.MergeRequest
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		dependents, err = d.store.GetMergeRequestDependents(ctx, tx, r.ID(), mrID)
+		return err
+	}); err != nil {
+		return nil, db.WrapError(err)
+	}
+
+	return dependents, nil
+}
+
+// MergeRequestDependencyIDs returns the IDs of the merge requests each merge
+// request of a repository depends on, keyed by merge request ID.
+func (d *Backend) MergeRequestDependencyIDs(ctx context.Context, repoName string) (map[int64][]int64, error) {
+	repoName = utils.SanitizeRepo(repoName)
+
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return nil, err
+	}
+
+	var deps []models.MergeRequestDependency
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		deps, err = d.store.GetMergeRequestDependenciesByRepoID(ctx, tx, r.ID())
+		return err
+	}); err != nil {
+		return nil, db.WrapError(err)
+	}
+
+	ids := make(map[int64][]int64)
+	for _, dep := range deps {
+		ids[dep.MergeRequestID] = append(ids[dep.MergeRequestID], dep.DependsOnID)
+	}
+
+	return ids, nil
+}
+
+// hasUnmergedDependencies returns true if any of the merge requests the given
+// merge request depends on hasn't merged yet.
+func (d *Backend) hasUnmergedDependencies(ctx context.Context, h db.Handler, r proto.Repository, mrID int64) (bool, error) {
+	deps, err := d.store.GetMergeRequestDependencies(ctx, h, r.ID(), mrID)
+	if err != nil {
+		return false, db.WrapError(err)
+	}
+	for _, dep := range deps {
+		if dep.State != models.MergeRequestStateMerged {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// retargetDependents points the open merge requests stacked on a merge
+// request that just merged at the branch it merged into.
+func (d *Backend) retargetDependents(ctx context.Context, r proto.Repository, mr models.MergeRequest) {
+	dependents, err := d.store.GetMergeRequestDependents(ctx, d.db, r.ID(), mr.ID)
+	if err != nil {
+		d.logger.Error("error getting merge request dependents", "repo", r.Name(), "merge_request", mr.ID, "err", err)
+		return
+	}
+
+	for _, dep := range dependents {
+		if dep.State != models.MergeRequestStateOpen || dep.TargetBranch != mr.SourceBranch {
+			continue
+		}
+		if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+			return d.store.SetMergeRequestTargetBranch(ctx, tx, r.ID(), dep.ID, mr.TargetBranch)
+		}); err != nil {
+			d.logger.Error("error retargeting merge request", "repo", r.Name(), "merge_request", dep.ID, "err", err)
+			continue
+		}
+		d.sendMergeRequestEvent(ctx, r, dep.ID, webhook.MergeRequestEventActionEdited)
+	}
+}
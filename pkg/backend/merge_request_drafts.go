@@ -0,0 +1,75 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/utils"
+	"github.com/charmbracelet/soft-serve/pkg/webhook"
+)
+
+// ErrDraftMergeRequest is returned when merging a draft merge request.
+var ErrDraftMergeRequest = errors.New("merge request is a draft, mark it as ready first")
+
+// draftPrefixes are the title prefixes marking a merge request as a draft.
+var draftPrefixes = []string{"wip:", "draft:"}
+
+// IsDraftTitle returns true if a merge request title starts with a draft
+// prefix, "WIP:" or "Draft:" in any case.
+func IsDraftTitle(title string) bool {
+	title = strings.ToLower(strings.TrimSpace(title))
+	for _, p := range draftPrefixes {
+		if strings.HasPrefix(title, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// SetMergeRequestDraft marks an open merge request as a draft, or as ready to
+// merge. Whoever can edit the merge request can change it.
+func (d *Backend) SetMergeRequestDraft(ctx context.Context, repoName string, mrID int64, draft bool) error {
+	repoName = utils.SanitizeRepo(repoName)
+
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return err
+	}
+
+	if err := d.checkNotBlocked(ctx, r); err != nil {
+		return err
+	}
+
+	mr, err := d.GetMergeRequest(ctx, repoName, mrID)
+	if err != nil {
+		return err
+	}
+	if mr.State != models.MergeRequestStateOpen {
+		return errors.New("merge request is not open")
+	}
+	if err := d.checkCanEdit(ctx, r, mr.AuthorID); err != nil {
+		return err
+	}
+	if mr.Draft == draft {
+		return nil
+	}
+
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		return d.store.SetMergeRequestDraft(ctx, tx, r.ID(), mrID, draft)
+	}); err != nil {
+		return db.WrapError(err)
+	}
+
+	action, event := webhook.MergeRequestEventActionReady, "merge_request.ready"
+	if draft {
+		action, event = webhook.MergeRequestEventActionDraft, "merge_request.draft"
+	}
+	d.sendMergeRequestEvent(ctx, r, mrID, action)
+	d.audit(ctx, event, r.Name(), fmt.Sprintf("merge request !%d", mrID))
+
+	return nil
+}
@@ -0,0 +1,20 @@
+package backend
+
+import "testing"
+
+func TestIsDraftTitle(t *testing.T) {
+	for title, want := range map[string]bool{
+		"WIP: Add a flavor":    true,
+		"Draft: Add a flavor":  true,
+		"wip:Add a flavor":     true,
+		"  DRAFT: Add flavors": true,
+		"Add a flavor":         false,
+		"Add a WIP: flavor":    false,
+		"WIP Add a flavor":     false,
+		"Drafting the menu":    false,
+	} {
+		if got := IsDraftTitle(title); got != want {
+			t.Errorf("IsDraftTitle(%q) = %v, want %v", title, got, want)
+		}
+	}
+}
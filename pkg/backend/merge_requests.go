@@ -4,17 +4,36 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/charmbracelet/soft-serve/git"
+	"github.com/charmbracelet/soft-serve/pkg/access"
 	"github.com/charmbracelet/soft-serve/pkg/db"
 	"github.com/charmbracelet/soft-serve/pkg/db/models"
 	"github.com/charmbracelet/soft-serve/pkg/proto"
 	"github.com/charmbracelet/soft-serve/pkg/utils"
+	"github.com/charmbracelet/soft-serve/pkg/webhook"
 )
 
 // CreateMergeRequest creates a new merge request for a repository.
 func (d *Backend) CreateMergeRequest(ctx context.Context, repoName string, title string, description string, sourceBranch string, targetBranch string) (int64, error) {
 	repoName = utils.SanitizeRepo(repoName)
+	title, description, err := d.sanitizeTitleAndDescription(title, description)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := utils.ValidateBranch(sourceBranch); err != nil {
+		return 0, fmt.Errorf("invalid source branch: %w", err)
+	}
+
+	if err := utils.ValidateBranch(targetBranch); err != nil {
+		return 0, fmt.Errorf("invalid target branch: %w", err)
+	}
+
+	if sourceBranch == targetBranch {
+		return 0, errors.New("source and target branches must be different")
+	}
 
 	// Get repository
 	r, err := d.Repository(ctx, repoName)
@@ -22,6 +41,14 @@ func (d *Backend) CreateMergeRequest(ctx context.Context, repoName string, title
 		return 0, err
 	}
 
+	if err := d.checkMergeRequestsEnabled(ctx, repoName); err != nil {
+		return 0, err
+	}
+
+	if err := d.checkNotBlocked(ctx, r); err != nil {
+		return 0, err
+	}
+
 	// Get current user
 	user := proto.UserFromContext(ctx)
 	if user == nil {
@@ -44,16 +71,50 @@ func (d *Backend) CreateMergeRequest(ctx context.Context, repoName string, title
 		return 0, fmt.Errorf("target branch %q does not exist", targetBranch)
 	}
 
+	first, err := d.IsFirstContribution(ctx, repoName, user)
+	if err != nil {
+		return 0, err
+	}
+
 	// Create merge request in database
+	preview, large := d.descriptionPreview(description)
+
 	var mrID int64
 	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
 		var err error
-		mrID, err = d.store.CreateMergeRequest(ctx, tx, r.ID(), user.ID(), title, description, sourceBranch, targetBranch)
-		return err
+		mrID, err = d.store.CreateMergeRequest(ctx, tx, r.ID(), user.ID(), title, preview, sourceBranch, targetBranch)
+		if err != nil {
+			return err
+		}
+
+		if err := d.store.AddSubscription(ctx, tx, r.ID(), NotificationSubjectMergeRequest, mrID, user.ID()); err != nil {
+			return err
+		}
+
+		if first {
+			if err := d.store.SetMergeRequestFirstContribution(ctx, tx, r.ID(), mrID); err != nil {
+				return err
+			}
+		}
+
+		if IsDraftTitle(title) {
+			if err := d.store.SetMergeRequestDraft(ctx, tx, r.ID(), mrID, true); err != nil {
+				return err
+			}
+		}
+
+		if large {
+			return d.store.SetMergeRequestLargeText(ctx, tx, r.ID(), mrID, description)
+		}
+
+		return nil
 	}); err != nil {
 		return 0, db.WrapError(err)
 	}
 
+	d.indexCrossReferences(ctx, r, NotificationSubjectMergeRequest, mrID, title, description)
+	d.sendMergeRequestEvent(ctx, r, mrID, webhook.MergeRequestEventActionOpened)
+
 	return mrID, nil
 }
 
@@ -66,20 +127,52 @@ func (d *Backend) GetMergeRequest(ctx context.Context, repoName string, mrID int
 		return models.MergeRequest{}, err
 	}
 
+	if err := d.checkMergeRequestsEnabled(ctx, repoName); err != nil {
+		return models.MergeRequest{}, err
+	}
+
 	var mr models.MergeRequest
 	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
 		var err error
 		mr, err = d.store.GetMergeRequestByID(ctx, tx, r.ID(), mrID)
+		if err != nil {
+			return err
+		}
+
+		// Lazily load the full description when only a preview is stored
+		// inline.
+		if mr.DescriptionTruncated {
+			mr.Description, err = d.store.GetMergeRequestLargeText(ctx, tx, r.ID(), mrID)
+		}
+
 		return err
 	}); err != nil {
 		return models.MergeRequest{}, db.WrapError(err)
 	}
+	d.redactMergeRequest(ctx, r, &mr)
 
 	return mr, nil
 }
 
-// ListMergeRequests returns all merge requests for a repository.
-func (d *Backend) ListMergeRequests(ctx context.Context, repoName string, state *models.MergeRequestState) ([]models.MergeRequest, error) {
+// MergeRequestListOptions are options for listing the merge requests of a
+// repository.
+type MergeRequestListOptions struct {
+	// State limits the list to the merge requests in a state. Nil lists every
+	// merge request.
+	State *models.MergeRequestState
+	// Order is the order of the list. The zero value lists the newest first.
+	Order models.ListOrder
+	// Limit is the maximum number of merge requests listed. 0 lists every
+	// merge request.
+	Limit int
+	// After is the ID of the last merge request of the previous page. Only the
+	// merge requests following it in order are listed.
+	After int64
+}
+
+// ListMergeRequests returns a page of the merge requests for a repository in
+// order.
+func (d *Backend) ListMergeRequests(ctx context.Context, repoName string, opts MergeRequestListOptions) ([]models.MergeRequest, error) {
 	repoName = utils.SanitizeRepo(repoName)
 
 	r, err := d.Repository(ctx, repoName)
@@ -87,37 +180,243 @@ func (d *Backend) ListMergeRequests(ctx context.Context, repoName string, state
 		return nil, err
 	}
 
+	if err := d.checkMergeRequestsEnabled(ctx, repoName); err != nil {
+		return nil, err
+	}
+
 	var mrs []models.MergeRequest
 	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
 		var err error
-		if state == nil {
-			mrs, err = d.store.GetMergeRequestsByRepoID(ctx, tx, r.ID())
+		if opts.State == nil {
+			mrs, err = d.store.GetMergeRequestsByRepoID(ctx, tx, r.ID(), opts.Order, opts.Limit, opts.After)
 		} else {
-			mrs, err = d.store.GetMergeRequestsByRepoIDAndState(ctx, tx, r.ID(), *state)
+			mrs, err = d.store.GetMergeRequestsByRepoIDAndState(ctx, tx, r.ID(), *opts.State, opts.Order, opts.Limit, opts.After)
 		}
 		return err
 	}); err != nil {
 		return nil, db.WrapError(err)
 	}
+	for i := range mrs {
+		d.redactMergeRequest(ctx, r, &mrs[i])
+	}
 
 	return mrs, nil
 }
 
+// CountMergeRequests returns the number of merge requests of a repository. A
+// nil state counts the merge requests in any state.
+func (d *Backend) CountMergeRequests(ctx context.Context, repoName string, state *models.MergeRequestState) (int64, error) {
+	repoName = utils.SanitizeRepo(repoName)
+
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := d.checkMergeRequestsEnabled(ctx, repoName); err != nil {
+		return 0, err
+	}
+
+	count, err := d.store.CountMergeRequestsByRepoID(ctx, d.db, r.ID(), state)
+	if err != nil {
+		return 0, db.WrapError(err)
+	}
+
+	return count, nil
+}
+
+// ErrReviewerNoAccess is returned when a review is requested from a user who
+// cannot read the repository.
+var ErrReviewerNoAccess = errors.New("reviewer does not have access to the repository")
+
+// ReviewQueueItem is an open merge request awaiting review.
+type ReviewQueueItem struct {
+	Repository   proto.Repository
+	MergeRequest models.MergeRequest
+}
+
+// ReviewQueue returns the open merge requests user is requested to review,
+// oldest first. Merge requests of repositories user can no longer read, or
+// that turned merge requests off, are left out.
+func (d *Backend) ReviewQueue(ctx context.Context, user proto.User) ([]ReviewQueueItem, error) {
+	if user == nil {
+		return nil, nil
+	}
+
+	var mrs []models.MergeRequest
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		mrs, err = d.store.GetOpenMergeRequestsByReviewerID(ctx, tx, user.ID())
+		return err
+	}); err != nil {
+		return nil, db.WrapError(err)
+	}
+	if len(mrs) == 0 {
+		return nil, nil
+	}
+
+	repos, err := d.Repositories(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[int64]proto.Repository, len(repos))
+	for _, r := range repos {
+		byID[r.ID()] = r
+	}
+
+	var items []ReviewQueueItem
+	for _, mr := range mrs {
+		r, ok := byID[mr.RepoID]
+		if !ok || d.AccessLevelForUser(ctx, r.Name(), user) < access.ReadOnlyAccess {
+			continue
+		}
+		if enabled, err := d.IsMergeRequestsEnabled(ctx, r.Name()); err != nil || !enabled {
+			continue
+		}
+		items = append(items, ReviewQueueItem{Repository: r, MergeRequest: mr})
+	}
+
+	return items, nil
+}
+
+// AddMergeRequestReviewer requests a review of a merge request from a user.
+func (d *Backend) AddMergeRequestReviewer(ctx context.Context, repoName string, mrID int64, username string) error {
+	repoName = utils.SanitizeRepo(repoName)
+
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return err
+	}
+
+	if _, err := d.GetMergeRequest(ctx, repoName, mrID); err != nil {
+		return err
+	}
+
+	reviewer, err := d.User(ctx, username)
+	if err != nil {
+		return err
+	}
+	if d.AccessLevelForUser(ctx, r.Name(), reviewer) < access.ReadOnlyAccess {
+		return ErrReviewerNoAccess
+	}
+
+	err = db.WrapError(d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		return d.store.AddMergeRequestReviewer(ctx, tx, r.ID(), mrID, reviewer.ID())
+	}))
+	if errors.Is(err, db.ErrDuplicateKey) {
+		return nil
+	}
+
+	return err
+}
+
+// RemoveMergeRequestReviewer removes a review request from a merge request.
+func (d *Backend) RemoveMergeRequestReviewer(ctx context.Context, repoName string, mrID int64, username string) error {
+	repoName = utils.SanitizeRepo(repoName)
+
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return err
+	}
+
+	reviewer, err := d.User(ctx, username)
+	if err != nil {
+		return err
+	}
+
+	return db.WrapError(d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		return d.store.RemoveMergeRequestReviewer(ctx, tx, r.ID(), mrID, reviewer.ID())
+	}))
+}
+
+// MergeRequestReviewers returns the usernames of the users requested to review
+// a merge request.
+func (d *Backend) MergeRequestReviewers(ctx context.Context, repoName string, mrID int64) ([]string, error) {
+	repoName = utils.SanitizeRepo(repoName)
+
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return nil, err
+	}
+
+	var users []models.User
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		users, err = d.store.GetMergeRequestReviewers(ctx, tx, r.ID(), mrID)
+		return err
+	}); err != nil {
+		return nil, db.WrapError(err)
+	}
+
+	usernames := make([]string, len(users))
+	for i, u := range users {
+		usernames[i] = u.Username
+	}
+
+	return usernames, nil
+}
+
 // UpdateMergeRequest updates a merge request.
 func (d *Backend) UpdateMergeRequest(ctx context.Context, repoName string, mrID int64, title string, description string) error {
 	repoName = utils.SanitizeRepo(repoName)
+	title, description, err := d.sanitizeTitleAndDescription(title, description)
+	if err != nil {
+		return err
+	}
 
 	r, err := d.Repository(ctx, repoName)
 	if err != nil {
 		return err
 	}
 
+	if err := d.checkNotBlocked(ctx, r); err != nil {
+		return err
+	}
+
+	mr, err := d.store.GetMergeRequestByID(ctx, d.db, r.ID(), mrID)
+	if err != nil {
+		return db.WrapError(err)
+	}
+	if err := d.checkCanEdit(ctx, r, mr.AuthorID); err != nil {
+		return err
+	}
+
+	user := proto.UserFromContext(ctx)
+	preview, large := d.descriptionPreview(description)
 	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
-		return d.store.UpdateMergeRequest(ctx, tx, r.ID(), mrID, title, description)
+		if err := d.store.UpdateMergeRequest(ctx, tx, r.ID(), mrID, title, preview); err != nil {
+			return err
+		}
+
+		if err := d.store.SetMergeRequestEditedBy(ctx, tx, r.ID(), mrID, user.ID()); err != nil {
+			return err
+		}
+
+		// Adding or removing a draft prefix from the title marks the merge
+		// request as a draft or as ready.
+		if draft := IsDraftTitle(title); draft != IsDraftTitle(mr.Title) {
+			if err := d.store.SetMergeRequestDraft(ctx, tx, r.ID(), mrID, draft); err != nil {
+				return err
+			}
+		}
+
+		if err := d.store.SetMergeRequestReviewed(ctx, tx, r.ID(), mrID, user.ID()); err != nil {
+			return err
+		}
+
+		if large {
+			return d.store.SetMergeRequestLargeText(ctx, tx, r.ID(), mrID, description)
+		}
+
+		return d.store.DeleteMergeRequestLargeText(ctx, tx, r.ID(), mrID)
 	}); err != nil {
 		return db.WrapError(err)
 	}
 
+	d.indexCrossReferences(ctx, r, NotificationSubjectMergeRequest, mrID, title, description)
+	d.sendMergeRequestEvent(ctx, r, mrID, webhook.MergeRequestEventActionEdited)
+	d.audit(ctx, "merge_request.edit", r.Name(), fmt.Sprintf("merge request !%d", mrID))
+
 	return nil
 }
 
@@ -145,6 +444,49 @@ func (d *Backend) MergeMergeRequest(ctx context.Context, repoName string, mrID i
 	if mr.State != models.MergeRequestStateOpen {
 		return errors.New("merge request is not open")
 	}
+	if mr.Draft {
+		return ErrDraftMergeRequest
+	}
+
+	queued, err := d.IsMergeQueueEnabled(ctx, repoName)
+	if err != nil {
+		return err
+	}
+	if queued {
+		return ErrMergeQueueEnabled
+	}
+
+	blocked, err := d.hasUnmergedDependencies(ctx, d.db, r, mrID)
+	if err != nil {
+		return err
+	}
+	if blocked {
+		return ErrUnmergedDependencies
+	}
+
+	ahead, _, err := d.AheadBehind(ctx, repoName, git.RefsHeads+mr.TargetBranch, git.RefsHeads+mr.SourceBranch)
+	if err != nil {
+		return err
+	}
+	if ahead == 0 {
+		return ErrNothingToMerge
+	}
+
+	approvals, err := d.approvalStatus(ctx, d.db, r, mrID)
+	if err != nil {
+		return err
+	}
+	if !approvals.Satisfied() {
+		return fmt.Errorf("%w: %s", ErrNotEnoughApprovals, approvals)
+	}
+
+	failures, err := d.agreementFailures(ctx, r, mr)
+	if err != nil {
+		return err
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("%w:\n  %s", ErrAgreementsUnsatisfied, strings.Join(failures, "\n  "))
+	}
 
 	// Open git repository
 	gr, err := r.Open()
@@ -153,17 +495,26 @@ func (d *Backend) MergeMergeRequest(ctx context.Context, repoName string, mrID i
 	}
 
 	// Perform the merge
-	if err := performMerge(gr, mr.SourceBranch, mr.TargetBranch, user.Username()); err != nil {
+	commit, err := d.performMerge(ctx, gr, r.Name(), mr.SourceBranch, mr.TargetBranch, user.Username())
+	if err != nil {
 		return fmt.Errorf("failed to merge: %w", err)
 	}
 
 	// Update merge request state
 	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		if err := d.store.SetMergeRequestReviewed(ctx, tx, r.ID(), mrID, user.ID()); err != nil {
+			return err
+		}
 		return d.store.MergeMergeRequest(ctx, tx, r.ID(), mrID, user.ID())
 	}); err != nil {
 		return db.WrapError(err)
 	}
 
+	d.indexMergeRequestCommits(ctx, r, mrID, commit)
+	d.sendMergeRequestEvent(ctx, r, mrID, webhook.MergeRequestEventActionMerged)
+	d.transitionLinkedIssues(ctx, r, mr)
+	d.retargetDependents(ctx, r, mr)
+
 	return nil
 }
 
@@ -176,6 +527,10 @@ func (d *Backend) CloseMergeRequest(ctx context.Context, repoName string, mrID i
 		return err
 	}
 
+	if err := d.checkNotBlocked(ctx, r); err != nil {
+		return err
+	}
+
 	// Get current user
 	user := proto.UserFromContext(ctx)
 	if user == nil {
@@ -183,11 +538,16 @@ func (d *Backend) CloseMergeRequest(ctx context.Context, repoName string, mrID i
 	}
 
 	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		if err := d.store.SetMergeRequestReviewed(ctx, tx, r.ID(), mrID, user.ID()); err != nil {
+			return err
+		}
 		return d.store.CloseMergeRequest(ctx, tx, r.ID(), mrID, user.ID())
 	}); err != nil {
 		return db.WrapError(err)
 	}
 
+	d.sendMergeRequestEvent(ctx, r, mrID, webhook.MergeRequestEventActionClosed)
+
 	return nil
 }
 
@@ -200,29 +560,78 @@ func (d *Backend) ReopenMergeRequest(ctx context.Context, repoName string, mrID
 		return err
 	}
 
+	if err := d.checkNotBlocked(ctx, r); err != nil {
+		return err
+	}
+
 	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
 		return d.store.ReopenMergeRequest(ctx, tx, r.ID(), mrID)
 	}); err != nil {
 		return db.WrapError(err)
 	}
 
+	d.sendMergeRequestEvent(ctx, r, mrID, webhook.MergeRequestEventActionReopened)
+
 	return nil
 }
 
-// performMerge performs a git merge operation.
-func performMerge(repo *git.Repository, sourceBranch, targetBranch, author string) error {
-	// Checkout target branch
-	_, err := git.NewCommand("checkout", targetBranch).RunInDir(repo.Path)
+// sendMergeRequestEvent sends a merge request webhook event, adds it to the
+// activity log, and notifies the watchers of the repository. The merge request
+// change has already been committed, so errors are logged instead of returned.
+func (d *Backend) sendMergeRequestEvent(ctx context.Context, r proto.Repository, mrID int64, action webhook.MergeRequestEventAction) {
+	mr, err := d.GetMergeRequest(ctx, r.Name(), mrID)
 	if err != nil {
-		return fmt.Errorf("failed to checkout target branch: %w", err)
+		d.logger.Error("error finding merge request", "repo", r.Name(), "merge_request", mrID, "err", err)
+		return
 	}
 
-	// Merge source branch
-	commitMsg := fmt.Sprintf("Merge branch '%s' into '%s'", sourceBranch, targetBranch)
-	_, err = git.NewCommand("merge", "--no-ff", "-m", commitMsg, sourceBranch).RunInDir(repo.Path)
+	wh, err := webhook.NewMergeRequestEvent(ctx, proto.UserFromContext(ctx), r, mr, action)
 	if err != nil {
-		return fmt.Errorf("failed to merge branches: %w", err)
+		d.logger.Error("error creating merge_request webhook", "err", err)
+	} else if err := webhook.SendEvent(ctx, wh); err != nil {
+		d.logger.Error("error sending merge_request webhook", "err", err)
 	}
 
-	return nil
+	d.recordActivity(ctx, r, proto.UserFromContext(ctx), models.Activity{
+		Kind:      ActivityKindMergeRequest,
+		Action:    string(action),
+		SubjectID: mr.ID,
+		Title:     mr.Title,
+	})
+
+	d.notifyWatchers(ctx, r, models.Notification{
+		SubjectType: NotificationSubjectMergeRequest,
+		SubjectID:   mr.ID,
+		Title:       mr.Title,
+		Action:      string(action),
+	}, d.mergeRequestParticipants(ctx, r, mr)...)
+}
+
+// performMerge merges the source branch into the target branch of repository
+// name with a merge commit, see mergeCommit, and updateRef. It returns the
+// merge commit.
+func (d *Backend) performMerge(ctx context.Context, repo *git.Repository, name, sourceBranch, targetBranch, author string) (string, error) {
+	target, err := d.revParse(ctx, repo, git.RefsHeads+targetBranch)
+	if err != nil {
+		return "", fmt.Errorf("target branch %q not found", targetBranch)
+	}
+	source, err := d.revParse(ctx, repo, git.RefsHeads+sourceBranch)
+	if err != nil {
+		return "", fmt.Errorf("source branch %q not found", sourceBranch)
+	}
+
+	commitMsg := fmt.Sprintf("Merge branch '%s' into '%s'", sourceBranch, targetBranch)
+	commit, err := d.mergeCommit(ctx, repo, target, source, author, commitMsg)
+	if errors.Is(err, errMergeConflict) {
+		return "", fmt.Errorf("merge conflict with %q", targetBranch)
+	} else if err != nil {
+		return "", err
+	}
+
+	// This fails if the target branch moved since it was resolved.
+	if err := d.updateRef(ctx, repo, name, author, git.RefsHeads+targetBranch, commit, target); err != nil {
+		return "", fmt.Errorf("failed to update target branch: %w", err)
+	}
+
+	return commit, nil
 }
@@ -0,0 +1,118 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/utils"
+	"github.com/charmbracelet/soft-serve/pkg/webhook"
+)
+
+// MergeRequestFilter selects the merge requests of a repository a bulk
+// operation applies to. Empty fields match every merge request.
+type MergeRequestFilter struct {
+	// State limits the filter to merge requests in this state.
+	State *models.MergeRequestState
+	// SourceBranch matches the source branch of merge requests.
+	SourceBranch string
+	// TargetBranch matches the target branch of merge requests.
+	TargetBranch string
+	// Author matches the username of the author of merge requests.
+	Author string
+	// Query is matched case-insensitively against titles and descriptions.
+	Query string
+	// Stale limits the filter to merge requests marked stale.
+	Stale bool
+}
+
+// IsEmpty returns true if the filter matches every merge request regardless
+// of its state.
+func (f MergeRequestFilter) IsEmpty() bool {
+	return f.SourceBranch == "" && f.TargetBranch == "" && f.Author == "" &&
+		strings.TrimSpace(f.Query) == "" && !f.Stale
+}
+
+// FilterMergeRequests returns the merge requests of a repository matching a
+// filter.
+func (d *Backend) FilterMergeRequests(ctx context.Context, repoName string, filter MergeRequestFilter) ([]models.MergeRequest, error) {
+	var authorID int64
+	if filter.Author != "" {
+		author, err := d.User(ctx, filter.Author)
+		if err != nil {
+			return nil, err
+		}
+		authorID = author.ID()
+	}
+
+	mrs, err := d.ListMergeRequests(ctx, repoName, MergeRequestListOptions{State: filter.State})
+	if err != nil {
+		return nil, err
+	}
+
+	query := strings.ToLower(strings.TrimSpace(filter.Query))
+	matches := make([]models.MergeRequest, 0, len(mrs))
+	for _, mr := range mrs {
+		switch {
+		case filter.SourceBranch != "" && mr.SourceBranch != filter.SourceBranch,
+			filter.TargetBranch != "" && mr.TargetBranch != filter.TargetBranch,
+			authorID != 0 && mr.AuthorID != authorID,
+			filter.Stale && !mr.StaleAt.Valid,
+			query != "" && !strings.Contains(strings.ToLower(mr.Title), query) &&
+				!strings.Contains(strings.ToLower(mr.Description), query):
+			continue
+		}
+		matches = append(matches, mr)
+	}
+
+	return matches, nil
+}
+
+// RetargetMergeRequest changes the branch an open merge request merges into.
+func (d *Backend) RetargetMergeRequest(ctx context.Context, repoName string, mrID int64, targetBranch string) error {
+	repoName = utils.SanitizeRepo(repoName)
+
+	if err := utils.ValidateBranch(targetBranch); err != nil {
+		return fmt.Errorf("invalid target branch: %w", err)
+	}
+
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return err
+	}
+
+	mr, err := d.GetMergeRequest(ctx, repoName, mrID)
+	if err != nil {
+		return err
+	}
+	if mr.State != models.MergeRequestStateOpen {
+		return errors.New("merge request is not open")
+	}
+	if mr.SourceBranch == targetBranch {
+		return errors.New("source and target branches must be different")
+	}
+	if mr.TargetBranch == targetBranch {
+		return nil
+	}
+
+	gr, err := r.Open()
+	if err != nil {
+		return fmt.Errorf("failed to open repository: %w", err)
+	}
+	if _, err := gr.ShowRefVerify(fmt.Sprintf("refs/heads/%s", targetBranch)); err != nil {
+		return fmt.Errorf("target branch %q does not exist", targetBranch)
+	}
+
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		return d.store.SetMergeRequestTargetBranch(ctx, tx, r.ID(), mrID, targetBranch)
+	}); err != nil {
+		return db.WrapError(err)
+	}
+
+	d.sendMergeRequestEvent(ctx, r, mrID, webhook.MergeRequestEventActionEdited)
+
+	return nil
+}
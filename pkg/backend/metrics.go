@@ -0,0 +1,154 @@
+package backend
+
+import (
+	"context"
+	"io/fs"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// diskUsageTTL is how long the disk usage of the data directory is cached,
+// walking it can be slow on large instances.
+const diskUsageTTL = time.Minute
+
+// cloneCounters are the metrics counting git-upload-pack requests, i.e.
+// clones and fetches, per transport.
+var cloneCounters = map[string]func(*dto.Metric) bool{
+	"soft_serve_git_upload_pack_total":     nil,
+	"soft_serve_git_git_upload_pack_total": nil,
+	"soft_serve_http_git_upload_pack_total": func(m *dto.Metric) bool {
+		// Only count the pack requests, not the ref advertisements.
+		for _, l := range m.GetLabel() {
+			if l.GetName() == "file" {
+				return l.GetValue() == "git-upload-pack"
+			}
+		}
+		return false
+	},
+}
+
+// InstanceMetrics is a snapshot of the operational metrics of the instance.
+type InstanceMetrics struct {
+	// Time is when the snapshot was taken.
+	Time time.Time
+	// ActiveSessions is the number of open SSH sessions.
+	ActiveSessions int
+	// Clones is the total number of clones and fetches since the server
+	// started.
+	Clones float64
+	// QueuedJobs is the number of background tasks, such as imports, plus
+	// the merge requests waiting in merge queues.
+	QueuedJobs int64
+	// DBQueries and DBQuerySeconds are the total number of database queries
+	// and the time they took since the server started.
+	DBQueries      uint64
+	DBQuerySeconds float64
+	// DiskUsage is the size of the data directory in bytes, or -1 if it
+	// couldn't be computed.
+	DiskUsage int64
+}
+
+// ClonesPerMinute returns the rate of clones and fetches since prev.
+func (m InstanceMetrics) ClonesPerMinute(prev InstanceMetrics) float64 {
+	elapsed := m.Time.Sub(prev.Time).Minutes()
+	if prev.Time.IsZero() || elapsed <= 0 {
+		return 0
+	}
+	return (m.Clones - prev.Clones) / elapsed
+}
+
+// DBLatency returns the average duration of the database queries run since
+// prev.
+func (m InstanceMetrics) DBLatency(prev InstanceMetrics) time.Duration {
+	n := m.DBQueries - prev.DBQueries
+	if prev.Time.IsZero() || m.DBQueries <= prev.DBQueries {
+		return 0
+	}
+	return time.Duration((m.DBQuerySeconds - prev.DBQuerySeconds) / float64(n) * float64(time.Second))
+}
+
+// diskUsage caches the size of the data directory.
+type diskUsage struct {
+	mu      sync.Mutex
+	size    int64
+	updated time.Time
+}
+
+// get returns the size of the files under path, walking it at most once per
+// diskUsageTTL.
+func (d *diskUsage) get(path string) int64 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if time.Since(d.updated) < diskUsageTTL {
+		return d.size
+	}
+
+	size := dirSize(path)
+	d.size, d.updated = size, time.Now()
+	return size
+}
+
+// dirSize returns the size of the files under path, or -1 when it can't be
+// walked.
+func dirSize(path string) int64 {
+	var size int64
+	err := filepath.WalkDir(path, func(_ string, e fs.DirEntry, err error) error {
+		if err != nil || e.IsDir() {
+			return nil //nolint:nilerr
+		}
+		if info, err := e.Info(); err == nil {
+			size += info.Size()
+		}
+		return nil
+	})
+	if err != nil {
+		return -1
+	}
+	return size
+}
+
+// InstanceMetrics returns a snapshot of the operational metrics of the
+// instance, gathered from the metrics registry and the backend.
+func (d *Backend) InstanceMetrics(ctx context.Context) (InstanceMetrics, error) {
+	m := InstanceMetrics{
+		Time:           time.Now(),
+		ActiveSessions: len(d.Sessions(nil)),
+		QueuedJobs:     int64(d.manager.Len()),
+		DiskUsage:      d.diskUsage.get(d.cfg.DataPath),
+	}
+
+	queued, err := d.store.CountMergeQueueEntries(ctx, d.db)
+	if err != nil {
+		return m, err
+	}
+	m.QueuedJobs += queued
+
+	families, err := prometheus.DefaultGatherer.Gather()
+	if err != nil {
+		return m, err
+	}
+	for _, f := range families {
+		if f.GetName() == "soft_serve_db_query_duration_seconds" {
+			for _, metric := range f.GetMetric() {
+				m.DBQueries += metric.GetHistogram().GetSampleCount()
+				m.DBQuerySeconds += metric.GetHistogram().GetSampleSum()
+			}
+			continue
+		}
+		filter, ok := cloneCounters[f.GetName()]
+		if !ok {
+			continue
+		}
+		for _, metric := range f.GetMetric() {
+			if filter == nil || filter(metric) {
+				m.Clones += metric.GetCounter().GetValue()
+			}
+		}
+	}
+
+	return m, nil
+}
@@ -0,0 +1,49 @@
+package backend
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestInstanceMetricsRates(t *testing.T) {
+	start := time.Now()
+	prev := InstanceMetrics{Time: start, Clones: 10, DBQueries: 100, DBQuerySeconds: 1}
+	cur := InstanceMetrics{Time: start.Add(30 * time.Second), Clones: 13, DBQueries: 150, DBQuerySeconds: 1.5}
+
+	if got := cur.ClonesPerMinute(prev); got != 6 {
+		t.Fatalf("ClonesPerMinute() = %v, want 6", got)
+	}
+	if got := cur.DBLatency(prev); got != 10*time.Millisecond {
+		t.Fatalf("DBLatency() = %v, want 10ms", got)
+	}
+
+	// The first snapshot has nothing to compare to.
+	if got := cur.ClonesPerMinute(InstanceMetrics{}); got != 0 {
+		t.Fatalf("ClonesPerMinute() = %v, want 0", got)
+	}
+	if got := cur.DBLatency(cur); got != 0 {
+		t.Fatalf("DBLatency() = %v, want 0", got)
+	}
+}
+
+func TestDiskUsage(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "a"), make([]byte, 100), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var d diskUsage
+	if got := d.get(dir); got != 100 {
+		t.Fatalf("get() = %d, want 100", got)
+	}
+
+	// The size is cached.
+	if err := os.WriteFile(filepath.Join(dir, "b"), make([]byte, 50), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if got := d.get(dir); got != 100 {
+		t.Fatalf("get() = %d, want cached 100", got)
+	}
+}
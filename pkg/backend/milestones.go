@@ -0,0 +1,369 @@
+package backend
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/utils"
+)
+
+var (
+	// ErrMilestoneNotFound is returned when a repository has no milestone with
+	// a title, or an issue or merge request has no milestone.
+	ErrMilestoneNotFound = errors.New("milestone not found")
+
+	// ErrMilestoneExists is returned when creating or renaming a milestone to
+	// the title of another milestone of the repository.
+	ErrMilestoneExists = errors.New("milestone already exists")
+)
+
+// maxMilestoneTitleLength is the maximum length of milestone titles, in
+// characters.
+const maxMilestoneTitleLength = 100
+
+// validateMilestoneTitle returns the milestone title normalized, or an error
+// if it's invalid.
+func validateMilestoneTitle(title string) (string, error) {
+	title = strings.TrimSpace(title)
+	switch {
+	case title == "":
+		return "", errors.New("milestone title cannot be empty")
+	case utf8.RuneCountInString(title) > maxMilestoneTitleLength:
+		return "", fmt.Errorf("milestone title cannot be longer than %d characters", maxMilestoneTitleLength)
+	}
+	return title, nil
+}
+
+// dueDate returns t as a nullable due date, null when t is zero.
+func dueDate(t time.Time) sql.NullTime {
+	if t.IsZero() {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: t.UTC(), Valid: true}
+}
+
+// Milestones returns the milestones of a repository with their progress, the
+// ones due first first.
+func (d *Backend) Milestones(ctx context.Context, repoName string) ([]models.MilestoneProgress, error) {
+	repoName = utils.SanitizeRepo(repoName)
+
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return nil, err
+	}
+
+	milestones, err := d.store.GetMilestonesByRepoID(ctx, d.db, r.ID())
+	if err != nil {
+		return nil, db.WrapError(err)
+	}
+
+	return milestones, nil
+}
+
+// Milestone returns a milestone of a repository with its progress by its
+// title.
+func (d *Backend) Milestone(ctx context.Context, repoName string, title string) (models.MilestoneProgress, error) {
+	repoName = utils.SanitizeRepo(repoName)
+
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return models.MilestoneProgress{}, err
+	}
+
+	return d.milestone(ctx, d.db, r, title)
+}
+
+// milestone returns a milestone of a repository with its progress by its
+// title.
+func (d *Backend) milestone(ctx context.Context, h db.Handler, r proto.Repository, title string) (models.MilestoneProgress, error) {
+	milestone, err := d.store.GetMilestoneByTitle(ctx, h, r.ID(), strings.TrimSpace(title))
+	if err != nil {
+		err = db.WrapError(err)
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return models.MilestoneProgress{}, fmt.Errorf("%w: %s", ErrMilestoneNotFound, title)
+		}
+		return models.MilestoneProgress{}, err
+	}
+
+	return milestone, nil
+}
+
+// CreateMilestone creates a milestone in a repository. A zero due date means
+// the milestone has none.
+func (d *Backend) CreateMilestone(ctx context.Context, repoName string, title string, description string, due time.Time) error {
+	repoName = utils.SanitizeRepo(repoName)
+	title, err := validateMilestoneTitle(title)
+	if err != nil {
+		return err
+	}
+
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return err
+	}
+
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		return d.store.CreateMilestone(ctx, tx, r.ID(), title, strings.TrimSpace(description), dueDate(due))
+	}); err != nil {
+		err = db.WrapError(err)
+		if errors.Is(err, db.ErrDuplicateKey) {
+			return ErrMilestoneExists
+		}
+		return err
+	}
+
+	d.audit(ctx, "milestone.create", r.Name(), title)
+	return nil
+}
+
+// UpdateMilestone renames the milestone title of a repository, and changes its
+// description and due date, to the ones of milestone.
+func (d *Backend) UpdateMilestone(ctx context.Context, repoName string, title string, milestone models.Milestone) error {
+	repoName = utils.SanitizeRepo(repoName)
+	newTitle, err := validateMilestoneTitle(milestone.Title)
+	if err != nil {
+		return err
+	}
+
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return err
+	}
+
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		m, err := d.milestone(ctx, tx, r, title)
+		if err != nil {
+			return err
+		}
+		return d.store.UpdateMilestone(ctx, tx, r.ID(), m.ID, newTitle, strings.TrimSpace(milestone.Description), milestone.DueDate)
+	}); err != nil {
+		if errors.Is(err, ErrMilestoneNotFound) {
+			return err
+		}
+		err = db.WrapError(err)
+		if errors.Is(err, db.ErrDuplicateKey) {
+			return ErrMilestoneExists
+		}
+		return err
+	}
+
+	d.audit(ctx, "milestone.update", r.Name(), fmt.Sprintf("%s to %s", title, newTitle))
+	return nil
+}
+
+// CloseMilestone closes a milestone of a repository.
+func (d *Backend) CloseMilestone(ctx context.Context, repoName string, title string) error {
+	return d.setMilestoneState(ctx, repoName, title, models.MilestoneStateClosed)
+}
+
+// ReopenMilestone reopens a closed milestone of a repository.
+func (d *Backend) ReopenMilestone(ctx context.Context, repoName string, title string) error {
+	return d.setMilestoneState(ctx, repoName, title, models.MilestoneStateOpen)
+}
+
+// setMilestoneState opens or closes a milestone of a repository.
+func (d *Backend) setMilestoneState(ctx context.Context, repoName string, title string, state models.MilestoneState) error {
+	repoName = utils.SanitizeRepo(repoName)
+
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return err
+	}
+
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		m, err := d.milestone(ctx, tx, r, title)
+		if err != nil {
+			return err
+		}
+		if m.State == state {
+			return fmt.Errorf("milestone %s is already %s", m.Title, state)
+		}
+		return d.store.SetMilestoneState(ctx, tx, r.ID(), m.ID, state)
+	}); err != nil {
+		return db.WrapError(err)
+	}
+
+	action := "milestone.close"
+	if state == models.MilestoneStateOpen {
+		action = "milestone.reopen"
+	}
+	d.audit(ctx, action, r.Name(), title)
+	return nil
+}
+
+// DeleteMilestone deletes a milestone of a repository, and detaches it from
+// the issues and merge requests.
+func (d *Backend) DeleteMilestone(ctx context.Context, repoName string, title string) error {
+	repoName = utils.SanitizeRepo(repoName)
+
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return err
+	}
+
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		m, err := d.milestone(ctx, tx, r, title)
+		if err != nil {
+			return err
+		}
+		return d.store.DeleteMilestone(ctx, tx, r.ID(), m.ID)
+	}); err != nil {
+		if errors.Is(err, ErrMilestoneNotFound) {
+			return err
+		}
+		return db.WrapError(err)
+	}
+
+	d.audit(ctx, "milestone.delete", r.Name(), title)
+	return nil
+}
+
+// SetIssueMilestone attaches an issue to a milestone of its repository,
+// replacing its previous milestone. An empty title detaches the issue from
+// its milestone.
+func (d *Backend) SetIssueMilestone(ctx context.Context, repoName string, issueID int64, title string) error {
+	repoName = utils.SanitizeRepo(repoName)
+
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return err
+	}
+
+	if _, err := d.GetIssue(ctx, repoName, issueID); err != nil {
+		return err
+	}
+
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		if title == "" {
+			return d.store.RemoveIssueMilestone(ctx, tx, r.ID(), issueID)
+		}
+		m, err := d.milestone(ctx, tx, r, title)
+		if err != nil {
+			return err
+		}
+		return d.store.SetIssueMilestone(ctx, tx, r.ID(), issueID, m.ID)
+	}); err != nil {
+		if errors.Is(err, ErrMilestoneNotFound) {
+			return err
+		}
+		return db.WrapError(err)
+	}
+
+	d.audit(ctx, "issue.milestone", r.Name(), fmt.Sprintf("%d %s", issueID, title))
+	return nil
+}
+
+// SetMergeRequestMilestone attaches a merge request to a milestone of its
+// repository, replacing its previous milestone. An empty title detaches the
+// merge request from its milestone.
+func (d *Backend) SetMergeRequestMilestone(ctx context.Context, repoName string, mrID int64, title string) error {
+	repoName = utils.SanitizeRepo(repoName)
+
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return err
+	}
+
+	if _, err := d.GetMergeRequest(ctx, repoName, mrID); err != nil {
+		return err
+	}
+
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		if title == "" {
+			return d.store.RemoveMergeRequestMilestone(ctx, tx, r.ID(), mrID)
+		}
+		m, err := d.milestone(ctx, tx, r, title)
+		if err != nil {
+			return err
+		}
+		return d.store.SetMergeRequestMilestone(ctx, tx, r.ID(), mrID, m.ID)
+	}); err != nil {
+		if errors.Is(err, ErrMilestoneNotFound) {
+			return err
+		}
+		return db.WrapError(err)
+	}
+
+	d.audit(ctx, "merge_request.milestone", r.Name(), fmt.Sprintf("%d %s", mrID, title))
+	return nil
+}
+
+// IssueMilestone returns the milestone of an issue, or ErrMilestoneNotFound
+// if it has none.
+func (d *Backend) IssueMilestone(ctx context.Context, repoName string, issueID int64) (models.Milestone, error) {
+	repoName = utils.SanitizeRepo(repoName)
+
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return models.Milestone{}, err
+	}
+
+	m, err := d.store.GetIssueMilestone(ctx, d.db, r.ID(), issueID)
+	if err != nil {
+		err = db.WrapError(err)
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return models.Milestone{}, ErrMilestoneNotFound
+		}
+		return models.Milestone{}, err
+	}
+
+	return m, nil
+}
+
+// MergeRequestMilestone returns the milestone of a merge request, or
+// ErrMilestoneNotFound if it has none.
+func (d *Backend) MergeRequestMilestone(ctx context.Context, repoName string, mrID int64) (models.Milestone, error) {
+	repoName = utils.SanitizeRepo(repoName)
+
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return models.Milestone{}, err
+	}
+
+	m, err := d.store.GetMergeRequestMilestone(ctx, d.db, r.ID(), mrID)
+	if err != nil {
+		err = db.WrapError(err)
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return models.Milestone{}, ErrMilestoneNotFound
+		}
+		return models.Milestone{}, err
+	}
+
+	return m, nil
+}
+
+// MilestoneItems returns the issues and merge requests of a milestone of a
+// repository by its title.
+func (d *Backend) MilestoneItems(ctx context.Context, repoName string, title string) ([]models.Issue, []models.MergeRequest, error) {
+	repoName = utils.SanitizeRepo(repoName)
+
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	m, err := d.milestone(ctx, d.db, r, title)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	issues, err := d.store.GetMilestoneIssues(ctx, d.db, r.ID(), m.ID)
+	if err != nil {
+		return nil, nil, db.WrapError(err)
+	}
+
+	mrs, err := d.store.GetMilestoneMergeRequests(ctx, d.db, r.ID(), m.ID)
+	if err != nil {
+		return nil, nil, db.WrapError(err)
+	}
+
+	return issues, mrs, nil
+}
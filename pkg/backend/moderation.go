@@ -0,0 +1,453 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/soft-serve/pkg/access"
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/utils"
+)
+
+// HiddenContent replaces the description of the issues and merge requests,
+// and the body of the merge request comments, a moderator hid, for everyone
+// but the admins of their repository.
+const HiddenContent = "This content was hidden by a moderator."
+
+// moderationActionPrefix is the prefix of the audit log actions of the
+// moderation log.
+const moderationActionPrefix = "moderation."
+
+var (
+	// ErrUserBlocked is returned when a user blocked from a repository opens,
+	// edits, or votes on its issues and merge requests.
+	ErrUserBlocked = fmt.Errorf("%w: you are blocked from this repository", proto.ErrUnauthorized)
+
+	// ErrBlockCollaborator is returned when blocking a collaborator of a
+	// repository.
+	ErrBlockCollaborator = errors.New("collaborators can't be blocked")
+
+	// ErrReportNotFound is returned when a report doesn't exist.
+	ErrReportNotFound = errors.New("report not found")
+
+	// ErrEmptyReportReason is returned when reporting content without a
+	// reason.
+	ErrEmptyReportReason = errors.New("the reason of a report can't be empty")
+)
+
+// BlockUser blocks a user from opening, editing, and voting on the issues and
+// merge requests of a repository. Only the admins of the repository can, and
+// collaborators can't be blocked.
+func (d *Backend) BlockUser(ctx context.Context, repoName string, username string) error {
+	r, u, err := d.repoBlock(ctx, repoName, username)
+	if err != nil {
+		return err
+	}
+	if d.AccessLevelForUser(ctx, r.Name(), u) >= access.ReadWriteAccess {
+		return ErrBlockCollaborator
+	}
+
+	err = db.WrapError(d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		return d.store.AddRepoBlock(ctx, tx, r.ID(), u.ID())
+	}))
+	if errors.Is(err, db.ErrDuplicateKey) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	d.audit(ctx, "repo.block", r.Name(), u.Username())
+
+	return nil
+}
+
+// UnblockUser unblocks a user blocked from a repository.
+func (d *Backend) UnblockUser(ctx context.Context, repoName string, username string) error {
+	r, u, err := d.repoBlock(ctx, repoName, username)
+	if err != nil {
+		return err
+	}
+
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		return d.store.RemoveRepoBlock(ctx, tx, r.ID(), u.ID())
+	}); err != nil {
+		return db.WrapError(err)
+	}
+
+	d.audit(ctx, "repo.unblock", r.Name(), u.Username())
+
+	return nil
+}
+
+// BlockedUsers returns the usernames of the users blocked from a repository,
+// sorted.
+func (d *Backend) BlockedUsers(ctx context.Context, repoName string) ([]string, error) {
+	repoName = utils.SanitizeRepo(repoName)
+
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return nil, err
+	}
+	if d.AccessLevelForUser(ctx, r.Name(), proto.UserFromContext(ctx)) < access.AdminAccess {
+		return nil, proto.ErrUnauthorized
+	}
+
+	users, err := d.store.GetRepoBlockedUsers(ctx, d.db, r.ID())
+	if err != nil {
+		return nil, db.WrapError(err)
+	}
+
+	names := make([]string, 0, len(users))
+	for _, u := range users {
+		names = append(names, u.Username)
+	}
+
+	return names, nil
+}
+
+// repoBlock returns the repository and the user to block or unblock, once
+// the user of ctx is checked to be an admin of the repository.
+func (d *Backend) repoBlock(ctx context.Context, repoName string, username string) (proto.Repository, proto.User, error) {
+	repoName = utils.SanitizeRepo(repoName)
+
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return nil, nil, err
+	}
+	if d.AccessLevelForUser(ctx, r.Name(), proto.UserFromContext(ctx)) < access.AdminAccess {
+		return nil, nil, proto.ErrUnauthorized
+	}
+
+	u, err := d.User(ctx, username)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return r, u, nil
+}
+
+// checkNotBlocked returns ErrUserBlocked if the user of ctx is blocked from
+// the repository.
+func (d *Backend) checkNotBlocked(ctx context.Context, r proto.Repository) error {
+	user := proto.UserFromContext(ctx)
+	if user == nil {
+		return nil
+	}
+
+	blocked, err := d.store.IsUserBlockedFromRepo(ctx, d.db, r.ID(), user.ID())
+	if err != nil {
+		return db.WrapError(err)
+	}
+	if blocked {
+		return ErrUserBlocked
+	}
+
+	return nil
+}
+
+// ReportContent reports an issue, merge request, or merge request comment to
+// the server admins for abusive content, and returns the ID of the report.
+func (d *Backend) ReportContent(ctx context.Context, repoName string, subject Subject, reason string) (int64, error) {
+	repoName = utils.SanitizeRepo(repoName)
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return 0, ErrEmptyReportReason
+	}
+
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return 0, err
+	}
+
+	user := proto.UserFromContext(ctx)
+	if user == nil {
+		return 0, proto.ErrUserNotFound
+	}
+	if d.AccessLevelForUser(ctx, r.Name(), user) < access.GuestAccess {
+		return 0, proto.ErrRepoNotFound
+	}
+
+	if _, err := d.moderationSubject(ctx, r, subject); err != nil {
+		return 0, err
+	}
+
+	var id int64
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		id, err = d.store.CreateContentReport(ctx, tx, models.ContentReport{
+			RepoID:      r.ID(),
+			SubjectType: subject.Type,
+			SubjectID:   subject.ID,
+			ReporterID:  user.ID(),
+			Reason:      reason,
+		})
+		return err
+	}); err != nil {
+		return 0, db.WrapError(err)
+	}
+
+	return id, nil
+}
+
+// ContentReport is a report in the moderation queue.
+type ContentReport struct {
+	models.ContentReport
+	// Repository is the name of the repository of the reported content.
+	Repository string
+	// Subject is how the moderation log refers to the reported issue, merge
+	// request, or comment, e.g. "issue #12".
+	Subject string
+	// Reporter is the username of the user who reported the content.
+	Reporter string
+}
+
+// ContentReports returns the moderation queue, the unresolved reports,
+// oldest first.
+func (d *Backend) ContentReports(ctx context.Context) ([]ContentReport, error) {
+	rs, err := d.store.GetOpenContentReports(ctx, d.db)
+	if err != nil {
+		return nil, db.WrapError(err)
+	}
+
+	reports := make([]ContentReport, 0, len(rs))
+	for _, cr := range rs {
+		report := ContentReport{ContentReport: cr}
+		if r, err := d.repositoryByID(ctx, cr.RepoID); err == nil {
+			report.Repository = r.Name()
+			report.Subject, _ = d.moderationSubject(ctx, r, Subject{Type: cr.SubjectType, ID: cr.SubjectID})
+		}
+		if u, err := d.UserByID(ctx, cr.ReporterID); err == nil {
+			report.Reporter = u.Username()
+		}
+		reports = append(reports, report)
+	}
+
+	return reports, nil
+}
+
+// ResolveReport resolves a report without acting on the reported content,
+// taking it out of the moderation queue. Only server admins can.
+func (d *Backend) ResolveReport(ctx context.Context, id int64) error {
+	user := proto.UserFromContext(ctx)
+	if user == nil {
+		return proto.ErrUserNotFound
+	}
+	if !user.IsAdmin() {
+		return proto.ErrUnauthorized
+	}
+
+	report, err := d.store.GetContentReport(ctx, d.db, id)
+	if errors.Is(db.WrapError(err), db.ErrRecordNotFound) {
+		return ErrReportNotFound
+	} else if err != nil {
+		return db.WrapError(err)
+	}
+
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		return d.store.ResolveContentReport(ctx, tx, id, user.ID())
+	}); err != nil {
+		return db.WrapError(err)
+	}
+
+	target := ""
+	if r, err := d.repositoryByID(ctx, report.RepoID); err == nil {
+		target = r.Name()
+	}
+	d.audit(ctx, moderationActionPrefix+"resolve", target, fmt.Sprintf("report %d", id))
+
+	return nil
+}
+
+// HideContent hides the description of an issue or merge request, or the
+// body of a merge request comment, from everyone but the admins of its
+// repository, and resolves its reports. Only the admins of the repository
+// can.
+func (d *Backend) HideContent(ctx context.Context, repoName string, subject Subject) error {
+	return d.setContentHidden(ctx, repoName, subject, true)
+}
+
+// UnhideContent shows the description of an issue or merge request, or the
+// body of a merge request comment, again.
+func (d *Backend) UnhideContent(ctx context.Context, repoName string, subject Subject) error {
+	return d.setContentHidden(ctx, repoName, subject, false)
+}
+
+// setContentHidden hides or shows the description of an issue or merge
+// request, or the body of a merge request comment.
+func (d *Backend) setContentHidden(ctx context.Context, repoName string, subject Subject, hidden bool) error {
+	r, user, err := d.moderator(ctx, repoName)
+	if err != nil {
+		return err
+	}
+
+	name, err := d.moderationSubject(ctx, r, subject)
+	if err != nil {
+		return err
+	}
+
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		switch subject.Type {
+		case NotificationSubjectIssue:
+			err = d.store.SetIssueHidden(ctx, tx, r.ID(), subject.ID, hidden)
+		case NotificationSubjectMergeRequest:
+			err = d.store.SetMergeRequestHidden(ctx, tx, r.ID(), subject.ID, hidden)
+		case NotificationSubjectMergeRequestComment:
+			err = d.store.SetMergeRequestCommentHidden(ctx, tx, r.ID(), subject.ID, hidden)
+		}
+		if err != nil || !hidden {
+			return err
+		}
+		return d.store.ResolveContentReportsBySubject(ctx, tx, r.ID(), subject.Type, subject.ID, user.ID())
+	}); err != nil {
+		return db.WrapError(err)
+	}
+
+	action := "unhide"
+	if hidden {
+		action = "hide"
+	}
+	d.audit(ctx, moderationActionPrefix+action, r.Name(), name)
+
+	return nil
+}
+
+// DeleteContent deletes an issue, merge request, or merge request comment
+// and its replies for good, and resolves its reports. Only the admins of its
+// repository can.
+func (d *Backend) DeleteContent(ctx context.Context, repoName string, subject Subject) error {
+	r, user, err := d.moderator(ctx, repoName)
+	if err != nil {
+		return err
+	}
+
+	name, err := d.moderationSubject(ctx, r, subject)
+	if err != nil {
+		return err
+	}
+
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		switch subject.Type {
+		case NotificationSubjectIssue:
+			err = d.store.DeleteIssue(ctx, tx, r.ID(), subject.ID)
+		case NotificationSubjectMergeRequest:
+			err = d.store.DeleteMergeRequest(ctx, tx, r.ID(), subject.ID)
+		case NotificationSubjectMergeRequestComment:
+			err = d.store.DeleteMergeRequestComment(ctx, tx, r.ID(), subject.ID)
+		}
+		if err != nil {
+			return err
+		}
+		if err := d.store.DeleteCrossReferencesBySource(ctx, tx, subject.Type, subject.ID); err != nil {
+			return err
+		}
+		return d.store.ResolveContentReportsBySubject(ctx, tx, r.ID(), subject.Type, subject.ID, user.ID())
+	}); err != nil {
+		return db.WrapError(err)
+	}
+
+	d.audit(ctx, moderationActionPrefix+"delete", r.Name(), name)
+
+	return nil
+}
+
+// ModerationLog returns the latest limit moderation actions, newest first.
+func (d *Backend) ModerationLog(ctx context.Context, limit int) ([]models.AuditEvent, error) {
+	es, err := d.store.GetAuditEventsByActionPrefix(ctx, d.db, moderationActionPrefix, limit)
+	if err != nil {
+		return nil, db.WrapError(err)
+	}
+
+	return es, nil
+}
+
+// moderator returns a repository and the user of ctx, once the user is
+// checked to be an admin of the repository.
+func (d *Backend) moderator(ctx context.Context, repoName string) (proto.Repository, proto.User, error) {
+	repoName = utils.SanitizeRepo(repoName)
+
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	user := proto.UserFromContext(ctx)
+	if user == nil {
+		return nil, nil, proto.ErrUserNotFound
+	}
+	if d.AccessLevelForUser(ctx, r.Name(), user) < access.AdminAccess {
+		return nil, nil, proto.ErrUnauthorized
+	}
+
+	return r, user, nil
+}
+
+// moderationSubject checks that an issue, merge request, or merge request
+// comment exists, and returns how the moderation log refers to it, e.g.
+// "issue #12".
+func (d *Backend) moderationSubject(ctx context.Context, r proto.Repository, subject Subject) (string, error) {
+	switch subject.Type {
+	case NotificationSubjectIssue:
+		issue, err := d.GetIssue(ctx, r.Name(), subject.ID)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("issue #%d", issue.Number), nil
+	case NotificationSubjectMergeRequest:
+		mr, err := d.GetMergeRequest(ctx, r.Name(), subject.ID)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("merge request !%d", mr.ID), nil
+	case NotificationSubjectMergeRequestComment:
+		c, err := d.store.GetRepoMergeRequestComment(ctx, d.db, r.ID(), subject.ID)
+		if errors.Is(db.WrapError(err), db.ErrRecordNotFound) {
+			return "", ErrCommentNotFound
+		} else if err != nil {
+			return "", db.WrapError(err)
+		}
+		if _, err := d.GetMergeRequest(ctx, r.Name(), c.MergeRequestID); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("comment %d on merge request !%d", c.ID, c.MergeRequestID), nil
+	default:
+		return "", ErrInvalidSubject
+	}
+}
+
+// canSeeHidden returns whether the user of ctx can read the hidden
+// descriptions of a repository, like its admins.
+func (d *Backend) canSeeHidden(ctx context.Context, r proto.Repository) bool {
+	return d.AccessLevelForUser(ctx, r.Name(), proto.UserFromContext(ctx)) >= access.AdminAccess
+}
+
+// redactIssue replaces the description of a hidden issue with
+// HiddenContent, unless the user of ctx can see it.
+func (d *Backend) redactIssue(ctx context.Context, r proto.Repository, issue *models.Issue) {
+	if issue.Hidden && !d.canSeeHidden(ctx, r) {
+		issue.Description = HiddenContent
+		issue.DescriptionTruncated = false
+	}
+}
+
+// redactMergeRequest replaces the description of a hidden merge request with
+// HiddenContent, unless the user of ctx can see it.
+func (d *Backend) redactMergeRequest(ctx context.Context, r proto.Repository, mr *models.MergeRequest) {
+	if mr.Hidden && !d.canSeeHidden(ctx, r) {
+		mr.Description = HiddenContent
+		mr.DescriptionTruncated = false
+	}
+}
+
+// redactMergeRequestComment replaces the body of a hidden merge request
+// comment with HiddenContent, unless the user of ctx can see it.
+func (d *Backend) redactMergeRequestComment(ctx context.Context, r proto.Repository, c *models.MergeRequestComment) {
+	if c.Hidden && !d.canSeeHidden(ctx, r) {
+		c.Body = HiddenContent
+	}
+}
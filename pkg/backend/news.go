@@ -0,0 +1,179 @@
+package backend
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/access"
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+)
+
+const (
+	// ActivityKindRepository is the kind of repository activities.
+	ActivityKindRepository = "repository"
+	// ActivityKindTag is the kind of tag activities.
+	ActivityKindTag = "tag"
+	// ActivityKindMergeRequest is the kind of merge request activities.
+	ActivityKindMergeRequest = "merge_request"
+)
+
+const (
+	// newsLimit is the number of activities in the news.
+	newsLimit = 30
+	// trendingLimit is the number of trending merge requests in the news.
+	trendingLimit = 5
+	// trendingPeriod is how far back merge request activity counts towards
+	// trending.
+	trendingPeriod = 7 * 24 * time.Hour
+)
+
+// newsActions are the actions of the activities shown in the news: created
+// repositories, pushed tags, and opened and merged merge requests.
+var newsActions = []string{"created", "pushed", "opened", "merged"}
+
+// News is the recent activity of the instance.
+type News struct {
+	// Trending are the open merge requests with the most activity lately,
+	// busiest first.
+	Trending []TrendingMergeRequest
+	// Activities are the latest repository creations, tag pushes, and opened
+	// and merged merge requests, newest first.
+	Activities []NewsActivity
+}
+
+// TrendingMergeRequest is a merge request with a lot of activity lately.
+type TrendingMergeRequest struct {
+	Repository   proto.Repository
+	MergeRequest models.MergeRequest
+	// Activity is the number of events on the merge request during the
+	// trending period.
+	Activity int
+}
+
+// NewsActivity is an activity of the news.
+type NewsActivity struct {
+	Repository proto.Repository
+	Activity   models.Activity
+	// Actor is the username of the user who did it, if any.
+	Actor string
+}
+
+// Summary describes what happened, e.g. "pushed tag v1.0 to icecream".
+func (a NewsActivity) Summary() string {
+	name := a.Repository.Name()
+	switch a.Activity.Kind {
+	case ActivityKindRepository:
+		return "created " + name
+	case ActivityKindTag:
+		return fmt.Sprintf("pushed tag %s to %s", a.Activity.Title, name)
+	case ActivityKindMergeRequest:
+		return fmt.Sprintf("%s merge request %s!%d: %s", a.Activity.Action, name, a.Activity.SubjectID, a.Activity.Title)
+	default:
+		return a.Activity.Action + " " + name
+	}
+}
+
+// News returns the recent activity of the repositories user can read. Hidden
+// repositories, and those that turned the news off, are left out.
+func (d *Backend) News(ctx context.Context, user proto.User) (News, error) {
+	var news News
+	repos, err := d.Repositories(ctx)
+	if err != nil {
+		return news, err
+	}
+
+	byID := make(map[int64]proto.Repository, len(repos))
+	ids := make([]int64, 0, len(repos))
+	for _, r := range repos {
+		if r.IsHidden() || d.AccessLevelForUser(ctx, r.Name(), user) < access.ReadOnlyAccess {
+			continue
+		}
+		if enabled, err := d.IsNewsEnabled(ctx, r.Name()); err != nil || !enabled {
+			continue
+		}
+		byID[r.ID()] = r
+		ids = append(ids, r.ID())
+	}
+
+	var activities []models.Activity
+	var counts []models.ActivityCount
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		activities, err = d.store.GetActivitiesByRepoIDs(ctx, tx, ids, newsActions, newsLimit)
+		if err != nil {
+			return err
+		}
+
+		counts, err = d.store.GetActivityCounts(ctx, tx, ids, ActivityKindMergeRequest,
+			time.Now().Add(-trendingPeriod), trendingLimit*2)
+		return err
+	}); err != nil {
+		return news, db.WrapError(err)
+	}
+
+	for _, c := range counts {
+		if len(news.Trending) == trendingLimit {
+			break
+		}
+		r := byID[c.RepoID]
+		mr, err := d.GetMergeRequest(ctx, r.Name(), c.SubjectID)
+		if err != nil || mr.State != models.MergeRequestStateOpen {
+			continue
+		}
+		news.Trending = append(news.Trending, TrendingMergeRequest{
+			Repository:   r,
+			MergeRequest: mr,
+			Activity:     c.Count,
+		})
+	}
+
+	actors := map[int64]string{}
+	mrsEnabled := map[int64]bool{}
+	for _, a := range activities {
+		r := byID[a.RepoID]
+		if a.Kind == ActivityKindMergeRequest {
+			enabled, ok := mrsEnabled[r.ID()]
+			if !ok {
+				enabled, _ = d.IsMergeRequestsEnabled(ctx, r.Name())
+				mrsEnabled[r.ID()] = enabled
+			}
+			if !enabled {
+				continue
+			}
+		}
+		if id := a.ActorID.Int64; a.ActorID.Valid {
+			if _, ok := actors[id]; !ok {
+				if u, err := d.UserByID(ctx, id); err == nil {
+					actors[id] = u.Username()
+				}
+			}
+		}
+		news.Activities = append(news.Activities, NewsActivity{
+			Repository: r,
+			Activity:   a,
+			Actor:      actors[a.ActorID.Int64],
+		})
+	}
+
+	return news, nil
+}
+
+// recordActivity adds activity a on r by user to the activity log. The
+// activity has already been committed, so errors are logged instead of
+// returned.
+func (d *Backend) recordActivity(ctx context.Context, r proto.Repository, user proto.User, a models.Activity) {
+	a.RepoID = r.ID()
+	if user != nil {
+		a.ActorID = sql.NullInt64{Int64: user.ID(), Valid: true}
+	}
+
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		return d.store.CreateActivity(ctx, tx, a)
+	}); err != nil {
+		d.logger.Error("error recording activity", "repo", r.Name(), "kind", a.Kind, "err", err)
+	}
+}
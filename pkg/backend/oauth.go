@@ -0,0 +1,294 @@
+package backend
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/access"
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+)
+
+// OAuthCodeExpiry is how long an OAuth application has to exchange an
+// authorization code for an access token.
+const OAuthCodeExpiry = 10 * time.Minute
+
+// OAuth scopes besides access levels. An access token issued to an
+// application gets the access level of its scope, or no access to
+// repositories when the scope has none.
+const (
+	OAuthScopeOpenID  = "openid"
+	OAuthScopeProfile = "profile"
+)
+
+var (
+	// ErrInvalidOAuthScope is returned when an OAuth scope is unknown.
+	ErrInvalidOAuthScope = errors.New("invalid scope, must be a list of: openid, profile, read-only, read-write, admin-access")
+	// ErrInvalidRedirectURI is returned when an OAuth redirect URI is not an
+	// absolute http or https URL, or doesn't match the registered one.
+	ErrInvalidRedirectURI = errors.New("invalid redirect URI")
+	// ErrInvalidOAuthClient is returned when an OAuth client ID or secret is
+	// wrong.
+	ErrInvalidOAuthClient = errors.New("invalid client")
+	// ErrInvalidOAuthGrant is returned when an authorization code is wrong,
+	// expired, already used, or was issued to another application.
+	ErrInvalidOAuthGrant = errors.New("invalid authorization code")
+)
+
+// OAuthCodeOptions are the parameters of an authorization request a user
+// approved.
+type OAuthCodeOptions struct {
+	RedirectURI string
+	Scope       string
+	// CodeChallenge is the S256 PKCE code challenge, or empty.
+	CodeChallenge string
+	// Nonce is copied to the ID token.
+	Nonce string
+}
+
+// OAuthGrant is what an OAuth application gets for an authorization code.
+type OAuthGrant struct {
+	AccessToken string
+	Scope       string
+	User        proto.User
+	Nonce       string
+}
+
+// ParseOAuthScope validates a space separated OAuth scope and returns it
+// without duplicates.
+func ParseOAuthScope(scope string) (string, error) {
+	var scopes []string
+	var level bool
+	for _, s := range strings.Fields(scope) {
+		if slices.Contains(scopes, s) {
+			continue
+		}
+		switch s {
+		case OAuthScopeOpenID, OAuthScopeProfile:
+		default:
+			if l := access.ParseAccessLevel(s); l < access.ReadOnlyAccess || level {
+				return "", ErrInvalidOAuthScope
+			}
+			level = true
+		}
+		scopes = append(scopes, s)
+	}
+
+	return strings.Join(scopes, " "), nil
+}
+
+// HasOAuthScope returns true if the space separated scope contains s.
+func HasOAuthScope(scope string, s string) bool {
+	return slices.Contains(strings.Fields(scope), s)
+}
+
+// oauthScopeLevel returns the access level a scope grants.
+func oauthScopeLevel(scope string) access.AccessLevel {
+	for _, s := range strings.Fields(scope) {
+		if l := access.ParseAccessLevel(s); l > access.NoAccess {
+			return l
+		}
+	}
+	return access.NoAccess
+}
+
+// randomString returns n random bytes encoded as hex.
+func randomString(n int) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// CreateOAuthApp registers an OAuth application. It returns the application
+// and its client secret, which isn't stored and can't be shown again.
+func (d *Backend) CreateOAuthApp(ctx context.Context, name string, redirectURI string) (models.OAuthApp, string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return models.OAuthApp{}, "", errors.New("application name cannot be empty")
+	}
+	u, err := url.Parse(redirectURI)
+	if err != nil || !u.IsAbs() || (u.Scheme != "http" && u.Scheme != "https") || u.Fragment != "" {
+		return models.OAuthApp{}, "", ErrInvalidRedirectURI
+	}
+
+	clientID, err := randomString(16)
+	if err != nil {
+		return models.OAuthApp{}, "", err
+	}
+	secret, err := randomString(32)
+	if err != nil {
+		return models.OAuthApp{}, "", err
+	}
+
+	var app models.OAuthApp
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		app, err = d.store.CreateOAuthApp(ctx, tx, name, clientID, HashToken(secret), redirectURI)
+		return err
+	}); err != nil {
+		return models.OAuthApp{}, "", db.WrapError(err)
+	}
+
+	d.audit(ctx, "oauth_app.create", name, redirectURI)
+	return app, secret, nil
+}
+
+// OAuthApps returns the registered OAuth applications.
+func (d *Backend) OAuthApps(ctx context.Context) ([]models.OAuthApp, error) {
+	apps, err := d.store.GetOAuthApps(ctx, d.db)
+	return apps, db.WrapError(err)
+}
+
+// OAuthApp returns the OAuth application with the given client ID.
+func (d *Backend) OAuthApp(ctx context.Context, clientID string) (models.OAuthApp, error) {
+	app, err := d.store.GetOAuthAppByClientID(ctx, d.db, clientID)
+	if err != nil {
+		if errors.Is(db.WrapError(err), db.ErrRecordNotFound) {
+			return models.OAuthApp{}, ErrInvalidOAuthClient
+		}
+		return models.OAuthApp{}, db.WrapError(err)
+	}
+	return app, nil
+}
+
+// DeleteOAuthApp deletes an OAuth application and revokes the access tokens
+// it was issued.
+func (d *Backend) DeleteOAuthApp(ctx context.Context, name string) error {
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		app, err := d.store.GetOAuthAppByName(ctx, tx, name)
+		if err != nil {
+			return db.WrapError(err)
+		}
+		return d.store.DeleteOAuthApp(ctx, tx, app.ID)
+	}); err != nil {
+		return db.WrapError(err)
+	}
+
+	d.audit(ctx, "oauth_app.delete", name, "")
+	return nil
+}
+
+// VerifyOAuthClient returns the OAuth application with the given client ID
+// and secret.
+func (d *Backend) VerifyOAuthClient(ctx context.Context, clientID string, secret string) (models.OAuthApp, error) {
+	app, err := d.OAuthApp(ctx, clientID)
+	if err != nil {
+		return models.OAuthApp{}, err
+	}
+	if subtle.ConstantTimeCompare([]byte(app.ClientSecret), []byte(HashToken(secret))) != 1 {
+		return models.OAuthApp{}, ErrInvalidOAuthClient
+	}
+	return app, nil
+}
+
+// CreateOAuthCode returns an authorization code for app to act as user.
+func (d *Backend) CreateOAuthCode(ctx context.Context, app models.OAuthApp, user proto.User, opts OAuthCodeOptions) (string, error) {
+	if opts.RedirectURI != app.RedirectURI {
+		return "", ErrInvalidRedirectURI
+	}
+	scope, err := ParseOAuthScope(opts.Scope)
+	if err != nil {
+		return "", err
+	}
+
+	code, err := randomString(20)
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		if err := d.store.DeleteExpiredOAuthCodes(ctx, tx, now); err != nil {
+			return err
+		}
+		return d.store.CreateOAuthCode(ctx, tx, models.OAuthCode{
+			AppID:         app.ID,
+			UserID:        user.ID(),
+			Code:          HashToken(code),
+			RedirectURI:   opts.RedirectURI,
+			Scope:         scope,
+			CodeChallenge: opts.CodeChallenge,
+			Nonce:         opts.Nonce,
+			ExpiresAt:     now.Add(OAuthCodeExpiry),
+		})
+	}); err != nil {
+		return "", db.WrapError(err)
+	}
+
+	return code, nil
+}
+
+// ExchangeOAuthCode exchanges an authorization code issued to app for an
+// access token. Codes can only be used once. verifier is the PKCE code
+// verifier, required when the authorization request had a code challenge.
+func (d *Backend) ExchangeOAuthCode(ctx context.Context, app models.OAuthApp, code string, redirectURI string, verifier string) (OAuthGrant, error) {
+	var grant OAuthGrant
+	var userID int64
+	token := GenerateToken()
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		c, err := d.store.GetOAuthCode(ctx, tx, HashToken(code))
+		if err != nil {
+			return db.WrapError(err)
+		}
+		if c.AppID != app.ID || c.RedirectURI != redirectURI || time.Now().After(c.ExpiresAt) {
+			return ErrInvalidOAuthGrant
+		}
+		if c.CodeChallenge != "" {
+			sum := sha256.Sum256([]byte(verifier))
+			if base64.RawURLEncoding.EncodeToString(sum[:]) != c.CodeChallenge {
+				return ErrInvalidOAuthGrant
+			}
+		}
+		// Deleting the code is what makes it single use: of concurrent
+		// exchanges of the same code, only the one deleting it goes on.
+		if n, err := d.store.DeleteOAuthCode(ctx, tx, c.ID); err != nil {
+			return err
+		} else if n == 0 {
+			return ErrInvalidOAuthGrant
+		}
+
+		t, err := d.store.CreateAccessToken(ctx, tx, fmt.Sprintf("oauth: %s", app.Name), c.UserID, HashToken(token), time.Time{})
+		if err != nil {
+			return err
+		}
+		if err := d.store.SetAccessTokenScope(ctx, tx, t.ID, oauthScopeLevel(c.Scope).String(), ""); err != nil {
+			return err
+		}
+		if err := d.store.SetAccessTokenOAuthApp(ctx, tx, t.ID, app.ID); err != nil {
+			return err
+		}
+
+		userID = c.UserID
+		grant.Scope = c.Scope
+		grant.Nonce = c.Nonce
+		return nil
+	}); err != nil {
+		err = db.WrapError(err)
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return OAuthGrant{}, ErrInvalidOAuthGrant
+		}
+		return OAuthGrant{}, err
+	}
+
+	user, err := d.UserByID(ctx, userID)
+	if err != nil {
+		return OAuthGrant{}, err
+	}
+
+	grant.AccessToken = token
+	grant.User = user
+	return grant, nil
+}
@@ -0,0 +1,42 @@
+package backend
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseOAuthScope(t *testing.T) {
+	cases := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{"", "", false},
+		{"openid", "openid", false},
+		{"openid profile openid", "openid profile", false},
+		{"openid read-only", "openid read-only", false},
+		{"read-write", "read-write", false},
+		{"admin-access profile", "admin-access profile", false},
+		{"read-only read-write", "", true},
+		{"guest", "", true},
+		{"openid no-access", "", true},
+		{"email", "", true},
+	}
+
+	for _, c := range cases {
+		got, err := ParseOAuthScope(c.in)
+		if c.wantErr {
+			if !errors.Is(err, ErrInvalidOAuthScope) {
+				t.Errorf("ParseOAuthScope(%q) error = %v, want %v", c.in, err, ErrInvalidOAuthScope)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseOAuthScope(%q) error = %v", c.in, err)
+			continue
+		}
+		if got != c.want {
+			t.Errorf("ParseOAuthScope(%q) = %q, want %q", c.in, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/soft-serve/git"
+	"github.com/charmbracelet/soft-serve/pkg/hooks"
+)
+
+// optimizeMarker marks a repository for OptimizeRepositories, relative to
+// the repository.
+const optimizeMarker = "info/optimize"
+
+// markForOptimization marks a repository for OptimizeRepositories when a push
+// added at least the configured number of commits. Optimizing can take a
+// while on big histories, so it's left to the optimize job rather than
+// holding up the push.
+func (d *Backend) markForOptimization(ctx context.Context, name string, args []hooks.HookArg) {
+	if d.cfg == nil || d.cfg.Git.OptimizeThreshold <= 0 {
+		return
+	}
+
+	r, err := d.Repository(ctx, name)
+	if err != nil {
+		d.logger.Error("error finding repository", "repo", name, "err", err)
+		return
+	}
+	path := r.(*repo).path
+
+	n, err := d.pushedCommits(ctx, path, args)
+	if err != nil {
+		d.logger.Error("error counting pushed commits", "repo", name, "err", err)
+		return
+	}
+	if n < d.cfg.Git.OptimizeThreshold {
+		return
+	}
+
+	fp := filepath.Join(path, optimizeMarker)
+	if err := os.MkdirAll(filepath.Dir(fp), os.ModePerm); err != nil {
+		d.logger.Error("error marking repository for optimization", "repo", name, "err", err)
+		return
+	}
+	if err := os.WriteFile(fp, []byte(strconv.Itoa(n)), 0o644); err != nil { // nolint: gosec
+		d.logger.Error("error marking repository for optimization", "repo", name, "err", err)
+		return
+	}
+	d.logger.Debug("repository marked for optimization", "repo", name, "commits", n)
+}
+
+// pushedCommits returns the number of commits a push added, those reachable
+// from the new values of the pushed refs but not from their old values or
+// from the other refs.
+func (d *Backend) pushedCommits(ctx context.Context, path string, args []hooks.HookArg) (int, error) {
+	var tips, bases, excludes []string
+	for _, arg := range args {
+		if !git.IsZeroHash(arg.NewSha) {
+			tips = append(tips, arg.NewSha)
+		}
+		if !git.IsZeroHash(arg.OldSha) {
+			bases = append(bases, arg.OldSha)
+		}
+		excludes = append(excludes, "--exclude="+arg.RefName)
+	}
+	if len(tips) == 0 {
+		return 0, nil
+	}
+
+	// The values come from git itself, the object IDs can't be mistaken for
+	// options.
+	cmdArgs := append([]string{"rev-list", "--count"}, tips...)
+	cmdArgs = append(cmdArgs, "--not")
+	cmdArgs = append(cmdArgs, bases...)
+	cmdArgs = append(cmdArgs, excludes...)
+	// Unlike --all, the glob leaves out HEAD, which may point to a pushed
+	// branch.
+	cmdArgs = append(cmdArgs, "--glob=refs/*")
+	out, err := d.command(ctx, cmdArgs...).RunInDir(path)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(strings.TrimSpace(string(out)))
+}
+
+// OptimizeRepositories writes the commit-graph and reachability bitmaps of
+// the repositories large pushes marked for optimization.
+func (d *Backend) OptimizeRepositories(ctx context.Context) error {
+	repos, err := d.Repositories(ctx)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, r := range repos {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		path := r.(*repo).path
+		fp := filepath.Join(path, optimizeMarker)
+		if _, err := os.Stat(fp); err != nil {
+			continue
+		}
+		// Remove the marker first, pushes while optimizing mark the
+		// repository again.
+		if err := os.Remove(fp); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if err := d.optimizeRepository(ctx, path); err != nil {
+			errs = append(errs, fmt.Errorf("optimizing %s: %w", r.Name(), err))
+			continue
+		}
+		d.logger.Info("optimized repository", "repo", r.Name())
+	}
+
+	return errors.Join(errs...)
+}
+
+// OptimizeRepository writes the commit-graph and reachability bitmaps of a
+// repository now, whether a push marked it or not.
+func (d *Backend) OptimizeRepository(ctx context.Context, name string) error {
+	r, err := d.Repository(ctx, name)
+	if err != nil {
+		return err
+	}
+	path := r.(*repo).path
+	if err := os.Remove(filepath.Join(path, optimizeMarker)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return d.optimizeRepository(ctx, path)
+}
+
+// optimizeRepository writes the commit-graph of the repository at path, with
+// changed-path filters for path-limited logs, and repacks it with a
+// reachability bitmap.
+func (d *Backend) optimizeRepository(ctx context.Context, path string) error {
+	if _, err := d.command(ctx, "commit-graph", "write", "--reachable", "--changed-paths").
+		RunInDirWithTimeout(-1, path); err != nil {
+		return fmt.Errorf("writing commit-graph: %w", err)
+	}
+
+	// Repacking a repository that borrows objects would either copy them
+	// back or leave the bitmap incomplete, the pool is repacked when it's
+	// deduplicated instead.
+	if pool, foreign := d.repoPool(path); pool != "" || foreign {
+		return nil
+	}
+
+	// Unreachable objects are loosened rather than dropped so they stay
+	// recoverable until they expire.
+	if _, err := d.command(ctx, "repack", "-A", "-d", "-q", "--write-bitmap-index").
+		RunInDirWithTimeout(-1, path); err != nil {
+		return fmt.Errorf("repacking: %w", err)
+	}
+
+	return nil
+}
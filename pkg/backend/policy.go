@@ -0,0 +1,184 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// PolicyRule is a rule of the authorization policy file. A rule applies to
+// the operations matching its action, repository, and arguments patterns.
+// Operations a rule applies to are denied to the users matching Deny, and,
+// when Allow is set, to the users not matching Allow. An operation must pass
+// every rule that applies to it.
+//
+// Users are matched by username, "*" for everyone, "@admins", "@bots", or
+// "@anonymous".
+type PolicyRule struct {
+	// Action is a pattern of the operation, e.g. "repo delete",
+	// "repo merge-request *", or "git-receive-pack". Commands are named by
+	// their full names, not their aliases.
+	Action string `yaml:"action"`
+	// Repo is a pattern of the repository name, e.g. "infra/*". Empty matches
+	// any repository.
+	Repo string `yaml:"repo"`
+	// Args is a pattern of the space separated arguments of the operation.
+	// Empty matches any arguments.
+	Args    string   `yaml:"args"`
+	Allow   []string `yaml:"allow"`
+	Deny    []string `yaml:"deny"`
+	Message string   `yaml:"message"`
+}
+
+// PolicyError is returned when a policy rule denies an operation.
+type PolicyError struct {
+	Rule PolicyRule
+}
+
+// Error implements error.
+func (e *PolicyError) Error() string {
+	if e.Rule.Message != "" {
+		return "denied by policy: " + e.Rule.Message
+	}
+	return "denied by policy"
+}
+
+// Unwrap returns proto.ErrUnauthorized.
+func (e *PolicyError) Unwrap() error {
+	return proto.ErrUnauthorized
+}
+
+// policy caches the rules of the policy file.
+type policy struct {
+	mu      sync.Mutex
+	modTime time.Time
+	rules   []PolicyRule
+}
+
+// errInvalidPolicy is returned for every operation while the policy file
+// can't be read, so that a broken policy never lets everything through.
+var errInvalidPolicy = fmt.Errorf("%w: invalid policy file", proto.ErrUnauthorized)
+
+// policyRules returns the rules of the policy file, reloading it when it
+// changed.
+func (d *Backend) policyRules() ([]PolicyRule, error) {
+	fp := d.cfg.PolicyFile
+	if fp == "" {
+		return nil, nil
+	}
+
+	d.policy.mu.Lock()
+	defer d.policy.mu.Unlock()
+
+	fi, err := os.Stat(fp)
+	if err != nil {
+		return nil, err
+	}
+	if fi.ModTime().Equal(d.policy.modTime) {
+		return d.policy.rules, nil
+	}
+
+	bts, err := os.ReadFile(fp)
+	if err != nil {
+		return nil, err
+	}
+
+	var f struct {
+		Rules []PolicyRule `yaml:"rules"`
+	}
+	if err := yaml.Unmarshal(bts, &f); err != nil {
+		return nil, err
+	}
+	for _, r := range f.Rules {
+		if r.Action == "" {
+			return nil, errors.New("policy rule without action")
+		}
+		for _, p := range []string{r.Action, r.Repo, r.Args} {
+			if _, err := path.Match(p, ""); err != nil {
+				return nil, fmt.Errorf("policy rule pattern %q: %w", p, err)
+			}
+		}
+	}
+
+	d.policy.modTime = fi.ModTime()
+	d.policy.rules = f.Rules
+	return f.Rules, nil
+}
+
+// Authorize checks the policy file rules for user doing action on repo with
+// args. user is nil for anonymous users and repo is empty for operations that
+// aren't about a repository. It returns a *PolicyError when a rule denies the
+// operation.
+func (d *Backend) Authorize(ctx context.Context, user proto.User, action string, repo string, args []string) error {
+	rules, err := d.policyRules()
+	if err != nil {
+		d.logger.Error("error loading policy file", "path", d.cfg.PolicyFile, "err", err)
+		return errInvalidPolicy
+	}
+
+	if repo != "" {
+		repo = utils.SanitizeRepo(repo)
+	}
+	for _, r := range rules {
+		if !r.applies(action, repo, strings.Join(args, " ")) {
+			continue
+		}
+		if r.denies(user) {
+			d.logger.Debug("operation denied by policy", "action", action, "repo", repo, "rule", r.Action)
+			return &PolicyError{Rule: r}
+		}
+	}
+
+	return nil
+}
+
+// applies returns true if the rule applies to action on repo with args.
+func (r PolicyRule) applies(action, repo, args string) bool {
+	if ok, _ := path.Match(r.Action, action); !ok {
+		return false
+	}
+	if r.Repo != "" {
+		if ok, _ := path.Match(r.Repo, repo); !ok {
+			return false
+		}
+	}
+	if r.Args != "" {
+		if ok, _ := path.Match(r.Args, args); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// denies returns true if the rule denies the operation to user.
+func (r PolicyRule) denies(user proto.User) bool {
+	matches := func(subject string) bool {
+		switch subject {
+		case "*":
+			return true
+		case "@anonymous":
+			return user == nil
+		case "@admins":
+			return user != nil && user.IsAdmin()
+		case "@bots":
+			return user != nil && user.IsBot()
+		default:
+			return user != nil && strings.EqualFold(subject, user.Username())
+		}
+	}
+
+	if slices.ContainsFunc(r.Deny, matches) {
+		return true
+	}
+	return len(r.Allow) > 0 && !slices.ContainsFunc(r.Allow, matches)
+}
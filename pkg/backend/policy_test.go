@@ -0,0 +1,96 @@
+package backend
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/log/v2"
+	"github.com/charmbracelet/soft-serve/pkg/config"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+)
+
+func TestAuthorize(t *testing.T) {
+	fp := filepath.Join(t.TempDir(), "policy.yaml")
+	writePolicy := func(s string) {
+		if err := os.WriteFile(fp, []byte(s), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		// Make sure the reload notices the change.
+		mt := time.Now().Add(time.Duration(len(s)) * time.Second)
+		if err := os.Chtimes(fp, mt, mt); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writePolicy(`rules:
+  - action: repo merge-request merge
+    allow: [lead, "@admins"]
+    message: only team leads can merge merge requests
+  - action: git-receive-pack
+    repo: infra/*
+    deny: ["@bots"]
+  - action: repo branch delete
+    args: main
+    deny: ["*"]
+`)
+
+	d := &Backend{
+		cfg:    &config.Config{PolicyFile: fp},
+		logger: log.New(io.Discard),
+	}
+	admin := &user{user: models.User{Username: "admin", Admin: true}}
+	lead := &user{user: models.User{Username: "lead"}}
+	dev := &user{user: models.User{Username: "dev"}}
+	bot := &user{user: models.User{Username: "ci", Bot: true}}
+
+	cases := []struct {
+		name    string
+		user    proto.User
+		action  string
+		repo    string
+		args    []string
+		allowed bool
+	}{
+		{"admin merges mr", admin, "repo merge-request merge", "repo1", []string{"1"}, true},
+		{"lead merges mr", lead, "repo merge-request merge", "repo1", []string{"1"}, true},
+		{"dev merges mr", dev, "repo merge-request merge", "repo1", []string{"1"}, false},
+		{"anonymous merges mr", nil, "repo merge-request merge", "repo1", []string{"1"}, false},
+		{"dev closes mr", dev, "repo merge-request close", "repo1", []string{"1"}, true},
+		{"bot pushes infra", bot, "git-receive-pack", "infra/dns.git", nil, false},
+		{"bot pushes app", bot, "git-receive-pack", "app", nil, true},
+		{"dev pushes infra", dev, "git-receive-pack", "infra/dns", nil, true},
+		{"admin deletes main", admin, "repo branch delete", "repo1", []string{"main"}, false},
+		{"admin deletes topic", admin, "repo branch delete", "repo1", []string{"topic"}, true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := d.Authorize(t.Context(), c.user, c.action, c.repo, c.args)
+			if c.allowed && err != nil {
+				t.Fatalf("expected allowed, got %v", err)
+			}
+			if !c.allowed && !errors.Is(err, proto.ErrUnauthorized) {
+				t.Fatalf("expected unauthorized, got %v", err)
+			}
+		})
+	}
+
+	var perr *PolicyError
+	if err := d.Authorize(t.Context(), dev, "repo merge-request merge", "repo1", nil); !errors.As(err, &perr) || perr.Rule.Message == "" {
+		t.Fatalf("expected policy error with message, got %v", err)
+	}
+
+	writePolicy("rules: [")
+	if err := d.Authorize(t.Context(), admin, "repo merge-request close", "repo1", nil); !errors.Is(err, proto.ErrUnauthorized) {
+		t.Fatalf("expected invalid policy to deny, got %v", err)
+	}
+
+	writePolicy("rules: []\n")
+	if err := d.Authorize(t.Context(), dev, "repo merge-request merge", "repo1", nil); err != nil {
+		t.Fatalf("expected reloaded policy to allow, got %v", err)
+	}
+}
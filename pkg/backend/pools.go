@@ -0,0 +1,352 @@
+package backend
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/soft-serve/git"
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+)
+
+// poolMembersRefs is the namespace of the refs fetched from the members of an
+// object pool, as refs/members/<repo id>/<ref>.
+const poolMembersRefs = "refs/members/"
+
+// ObjectPool is a repository holding the objects shared by repositories with
+// the same history. Members borrow the objects of the pool through Git
+// alternates.
+type ObjectPool struct {
+	// Name is the root commit of the history shared by the members.
+	Name string
+	Path string
+	// Repos are the names of the member repositories.
+	Repos []string
+	// Size is the size of the objects of the pool.
+	Size int64
+	// Saved is the space the members would take on top of their current size
+	// without the pool.
+	Saved int64
+}
+
+// poolMember is a repository to share through an object pool.
+type poolMember struct {
+	id     int64
+	name   string
+	path   string
+	pooled bool
+}
+
+// poolsPath returns the directory of the object pools.
+func (d *Backend) poolsPath() string {
+	return filepath.Join(d.cfg.DataPath, "pools")
+}
+
+// repoPool returns the object pool the repository at path borrows objects
+// from, if any. foreign is true when the repository borrows objects from
+// somewhere else, and shouldn't be pooled.
+func (d *Backend) repoPool(path string) (pool string, foreign bool) {
+	f, err := os.Open(filepath.Join(path, "objects", "info", "alternates"))
+	if err != nil {
+		return "", false
+	}
+	defer f.Close() // nolint: errcheck
+
+	pools := d.poolsPath()
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if filepath.Dir(filepath.Dir(line)) == pools && pool == "" {
+			pool = filepath.Dir(line)
+			continue
+		}
+		foreign = true
+	}
+
+	return pool, foreign
+}
+
+// rootCommit returns the oldest root commit of the default branch of the
+// repository at path.
+func (d *Backend) rootCommit(ctx context.Context, path string) (string, error) {
+	out, err := d.command(ctx, "rev-list", "--max-parents=0", "HEAD").RunInDir(path)
+	if err != nil {
+		return "", err
+	}
+	lines := strings.Fields(string(out))
+	if len(lines) == 0 {
+		return "", nil
+	}
+	return lines[len(lines)-1], nil
+}
+
+// DedupRepositories shares the objects of repositories with the same history
+// through object pools. Repositories are grouped by the root commit of their
+// default branch, and each group of two or more repositories gets a pool
+// holding the objects of all its members. Members then borrow the objects of
+// the pool through Git alternates and drop their own copies.
+//
+// Pools only ever grow so that members never lose objects they borrow, and
+// are removed once their last member is deleted. This is a no-op unless
+// object pools are enabled.
+func (d *Backend) DedupRepositories(ctx context.Context) error {
+	if !d.cfg.Storage.ObjectPools {
+		return nil
+	}
+
+	d.poolsMu.Lock()
+	defer d.poolsMu.Unlock()
+
+	var ms []models.Repo
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		ms, err = d.store.GetAllRepos(ctx, tx)
+		return err
+	}); err != nil {
+		return db.WrapError(err)
+	}
+
+	var names []string
+	groups := map[string][]poolMember{}
+	for _, m := range ms {
+		path, err := d.repos.Dir(repoLocation(m))
+		if err != nil {
+			continue
+		}
+
+		member := poolMember{id: m.ID, name: m.Name, path: path}
+		pool, foreign := d.repoPool(path)
+		switch {
+		case foreign:
+			continue
+		case pool != "":
+			member.pooled = true
+			pool = strings.TrimSuffix(filepath.Base(pool), ".git")
+		default:
+			// Empty repositories have nothing to share.
+			pool, err = d.rootCommit(ctx, path)
+			if err != nil || pool == "" {
+				continue
+			}
+		}
+
+		if _, ok := groups[pool]; !ok {
+			names = append(names, pool)
+		}
+		groups[pool] = append(groups[pool], member)
+	}
+
+	var errs []error
+	for _, name := range names {
+		path := filepath.Join(d.poolsPath(), name+".git")
+		members := groups[name]
+		if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+			if len(members) < 2 {
+				continue
+			}
+			if err := d.initPool(ctx, path); err != nil {
+				errs = append(errs, fmt.Errorf("creating object pool %s: %w", name, err))
+				continue
+			}
+		}
+
+		if err := d.dedupPool(ctx, path, members); err != nil {
+			errs = append(errs, fmt.Errorf("object pool %s: %w", name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// initPool creates an empty object pool at path. Pools are never garbage
+// collected, members may borrow any of their objects.
+func (d *Backend) initPool(ctx context.Context, path string) error {
+	if _, err := git.Init(path, true); err != nil {
+		return err
+	}
+	for _, kv := range [][]string{
+		{"gc.auto", "0"},
+		{"gc.pruneExpire", "never"},
+		{"core.logAllRefUpdates", "false"},
+	} {
+		if _, err := d.command(ctx, "config", kv[0], kv[1]).RunInDir(path); err != nil {
+			os.RemoveAll(path) // nolint: errcheck
+			return err
+		}
+	}
+	return nil
+}
+
+// dedupPool fetches the objects of the members into the pool at path, and
+// makes the members borrow them.
+func (d *Backend) dedupPool(ctx context.Context, path string, members []poolMember) error {
+	var errs []error
+	for _, m := range members {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		refs := fmt.Sprintf("+refs/*:%s%d/*", poolMembersRefs, m.id)
+		if _, err := d.command(ctx, "fetch", "--quiet", "--no-tags", "--no-write-fetch-head", "--prune", m.path, refs).
+			RunInDirWithTimeout(-1, path); err != nil {
+			errs = append(errs, fmt.Errorf("fetching %s: %w", m.name, err))
+			continue
+		}
+
+		if !m.pooled {
+			info := filepath.Join(m.path, "objects", "info")
+			if err := os.MkdirAll(info, os.ModePerm); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			alternates := filepath.Join(path, "objects") + "\n"
+			if err := os.WriteFile(filepath.Join(info, "alternates"), []byte(alternates), 0o644); err != nil { // nolint: gosec
+				errs = append(errs, err)
+				continue
+			}
+			d.logger.Info("repository joined object pool", "repo", m.name, "pool", path)
+		}
+
+		// Drop the objects the pool now holds, pushes since the fetch stay
+		// in the repository. Unreachable objects are loosened rather than
+		// dropped so they stay recoverable until they expire.
+		if _, err := d.command(ctx, "repack", "-A", "-d", "-l", "-q").RunInDirWithTimeout(-1, m.path); err != nil {
+			errs = append(errs, fmt.Errorf("repacking %s: %w", m.name, err))
+		}
+	}
+
+	// Keep unreachable objects, a member may still borrow them.
+	if _, err := d.command(ctx, "repack", "-a", "-d", "-k", "-q").RunInDirWithTimeout(-1, path); err != nil {
+		errs = append(errs, fmt.Errorf("repacking pool: %w", err))
+	}
+
+	return errors.Join(errs...)
+}
+
+// poolMemberIDs returns the ids of the members of the object pool at path.
+func (d *Backend) poolMemberIDs(ctx context.Context, path string) ([]int64, error) {
+	out, err := d.command(ctx, "for-each-ref", "--format=%(refname)", poolMembersRefs).RunInDir(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []int64
+	seen := map[int64]bool{}
+	for _, ref := range strings.Fields(string(out)) {
+		id, _, _ := strings.Cut(strings.TrimPrefix(ref, poolMembersRefs), "/")
+		n, err := strconv.ParseInt(id, 10, 64)
+		if err != nil || seen[n] {
+			continue
+		}
+		seen[n] = true
+		ids = append(ids, n)
+	}
+
+	return ids, nil
+}
+
+// ObjectPools returns the object pools and the space they save.
+func (d *Backend) ObjectPools(ctx context.Context) ([]ObjectPool, error) {
+	entries, err := os.ReadDir(d.poolsPath())
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var ms []models.Repo
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		ms, err = d.store.GetAllRepos(ctx, tx)
+		return err
+	}); err != nil {
+		return nil, db.WrapError(err)
+	}
+	repos := make(map[int64]models.Repo, len(ms))
+	for _, m := range ms {
+		repos[m.ID] = m
+	}
+
+	var pools []ObjectPool
+	for _, e := range entries {
+		if !e.IsDir() || !strings.HasSuffix(e.Name(), ".git") {
+			continue
+		}
+
+		pool := ObjectPool{
+			Name: strings.TrimSuffix(e.Name(), ".git"),
+			Path: filepath.Join(d.poolsPath(), e.Name()),
+		}
+		ids, err := d.poolMemberIDs(ctx, pool.Path)
+		if err != nil {
+			return nil, fmt.Errorf("object pool %s: %w", pool.Name, err)
+		}
+
+		pool.Size = dirSize(filepath.Join(pool.Path, "objects"))
+		var size, unpooled int64
+		for _, id := range ids {
+			m, ok := repos[id]
+			if !ok {
+				continue
+			}
+			path, err := d.repos.Dir(repoLocation(m))
+			if err != nil {
+				continue
+			}
+			pool.Repos = append(pool.Repos, m.Name)
+			size += dirSize(filepath.Join(path, "objects"))
+			// The size of the objects of the repository, wherever they are.
+			out, err := d.command(ctx, "rev-list", "--all", "--objects", "--disk-usage").RunInDirWithTimeout(-1, path)
+			if err != nil {
+				continue
+			}
+			if n, err := strconv.ParseInt(string(bytes.TrimSpace(out)), 10, 64); err == nil {
+				unpooled += n
+			}
+		}
+		pool.Saved = max(unpooled-pool.Size-size, 0)
+
+		pools = append(pools, pool)
+	}
+
+	return pools, nil
+}
+
+// leavePool removes the repository id from the object pool at path, and
+// removes the pool once it has no members left.
+func (d *Backend) leavePool(ctx context.Context, path string, id int64) error {
+	d.poolsMu.Lock()
+	defer d.poolsMu.Unlock()
+
+	prefix := fmt.Sprintf("%s%d/", poolMembersRefs, id)
+	out, err := d.command(ctx, "for-each-ref", "--format=%(refname)", prefix).RunInDir(path)
+	if err != nil {
+		return err
+	}
+	for _, ref := range strings.Fields(string(out)) {
+		if _, err := d.command(ctx, "update-ref", "-d", ref).RunInDir(path); err != nil {
+			return err
+		}
+	}
+
+	ids, err := d.poolMemberIDs(ctx, path)
+	if err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		d.logger.Info("removing empty object pool", "pool", path)
+		return os.RemoveAll(path)
+	}
+
+	return nil
+}
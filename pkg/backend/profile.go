@@ -0,0 +1,136 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/soft-serve/pkg/access"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/utils"
+)
+
+// ProfileRepo is the name of the repository holding the README of a user or
+// organization profile. The profile README of "frankie" is the README of the
+// "frankie/.profile" repository.
+const ProfileRepo = ".profile"
+
+// ErrProfileNotFound is returned when there is no user, repository, or
+// profile README for a profile name.
+var ErrProfileNotFound = errors.New("profile not found")
+
+// Profile is the profile of a user or an organization. Organizations are the
+// paths repositories are nested under, e.g. "myteam" for "myteam/api".
+type Profile struct {
+	Name string
+	// User is the user named Name, if any.
+	User proto.User
+	// Readme is the README of the profile repository, if any.
+	Readme     string
+	ReadmePath string
+	// ReadmeTruncated is true when the README is larger than the profile
+	// README size limit and only its beginning is kept.
+	ReadmeTruncated bool
+	// Repositories are the repositories nested under Name or owned by User,
+	// sorted by name.
+	Repositories []proto.Repository
+}
+
+// profileReadme is a cached profile README. It's valid as long as the profile
+// repository HEAD points to commit.
+type profileReadme struct {
+	commit    string
+	readme    string
+	path      string
+	truncated bool
+}
+
+// Profile returns the profile of a user or an organization as seen by viewer.
+// Repositories viewer can't read, and hidden repositories other than the
+// profile repository, are left out.
+func (d *Backend) Profile(ctx context.Context, name string, viewer proto.User) (Profile, error) {
+	name = utils.SanitizeRepo(name)
+	p := Profile{Name: name}
+	if name == "" {
+		return p, ErrProfileNotFound
+	}
+
+	if !strings.Contains(name, "/") {
+		if u, err := d.User(ctx, name); err == nil {
+			p.User = u
+		}
+	}
+
+	repos, err := d.Repositories(ctx)
+	if err != nil {
+		return p, err
+	}
+
+	profileRepo := path.Join(name, ProfileRepo)
+	for _, r := range repos {
+		if d.AccessLevelForUser(ctx, r.Name(), viewer) < access.ReadOnlyAccess {
+			continue
+		}
+
+		// The profile repository can be hidden to keep it out of listings.
+		if r.Name() == profileRepo {
+			p.Readme, p.ReadmePath, p.ReadmeTruncated = d.profileReadme(r)
+			continue
+		}
+		if r.IsHidden() {
+			continue
+		}
+
+		owned := p.User != nil && r.UserID() == p.User.ID()
+		if owned || strings.HasPrefix(r.Name(), name+"/") {
+			p.Repositories = append(p.Repositories, r)
+		}
+	}
+
+	if p.User == nil && p.Readme == "" && len(p.Repositories) == 0 {
+		return p, ErrProfileNotFound
+	}
+
+	sort.Slice(p.Repositories, func(i, j int) bool {
+		return p.Repositories[i].Name() < p.Repositories[j].Name()
+	})
+
+	return p, nil
+}
+
+// profileReadme returns the README of profile repository r, truncated to the
+// profile README size limit. READMEs are cached until r's HEAD moves.
+func (d *Backend) profileReadme(r proto.Repository) (string, string, bool) {
+	gr, err := r.Open()
+	if err != nil {
+		return "", "", false
+	}
+
+	head, err := gr.HEAD()
+	if err != nil {
+		// Empty repository.
+		return "", "", false
+	}
+
+	if cached, ok := d.cache.GetProfileReadme(r.Name()); ok && cached.commit == head.ID {
+		return cached.readme, cached.path, cached.truncated
+	}
+
+	readme, rpath, err := Readme(r, head)
+	if err != nil {
+		d.logger.Debug("error reading profile readme", "repo", r.Name(), "err", err)
+		return "", "", false
+	}
+
+	readme, truncated := utils.TruncateText(readme, d.cfg.Limits.MaxProfileReadmeSize)
+	d.cache.SetProfileReadme(r.Name(), profileReadme{
+		commit:    head.ID,
+		readme:    readme,
+		path:      rpath,
+		truncated: truncated,
+	})
+
+	return readme, rpath, truncated
+}
@@ -0,0 +1,219 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/soft-serve/git"
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/hooks"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+)
+
+var (
+	// ErrProtectedBranchNotFound is returned when a branch pattern isn't
+	// protected.
+	ErrProtectedBranchNotFound = errors.New("protected branch not found")
+
+	// ErrLeaseRequired is returned when force pushing to, or deleting, a
+	// protected branch requiring a lease without one.
+	ErrLeaseRequired = errors.New("protected branch requires a lease")
+
+	// ErrStaleLease is returned when the lease of a push doesn't match the tip
+	// of the branch it overwrites.
+	ErrStaleLease = errors.New("stale lease")
+)
+
+// ProtectedBranches returns the protected branches of a repository.
+func (d *Backend) ProtectedBranches(ctx context.Context, repo string) ([]models.ProtectedBranch, error) {
+	r, err := d.Repository(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	bs, err := d.store.GetProtectedBranchesByRepoID(ctx, d.db, r.ID())
+	if err != nil {
+		return nil, db.WrapError(err)
+	}
+
+	return bs, nil
+}
+
+// ProtectBranch protects the branches of a repository matching pattern, or
+// updates the protection of an existing pattern.
+func (d *Backend) ProtectBranch(ctx context.Context, name string, pattern string, requireLease bool) error {
+	if _, err := path.Match(pattern, ""); err != nil || pattern == "" {
+		return fmt.Errorf("invalid branch pattern %q", pattern)
+	}
+
+	r, err := d.Repository(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	// Leases are push options, make sure repositories created before
+	// accept them.
+	if err := d.configureGit(ctx, r.(*repo).path); err != nil {
+		return err
+	}
+
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		return d.store.SetProtectedBranch(ctx, tx, r.ID(), pattern, requireLease)
+	}); err != nil {
+		return db.WrapError(err)
+	}
+
+	d.audit(ctx, "branch.protect", r.Name(), fmt.Sprintf("%s, require lease: %t", pattern, requireLease))
+	return nil
+}
+
+// UnprotectBranch removes the protection of pattern from a repository.
+func (d *Backend) UnprotectBranch(ctx context.Context, repo string, pattern string) error {
+	r, err := d.Repository(ctx, repo)
+	if err != nil {
+		return err
+	}
+
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		bs, err := d.store.GetProtectedBranchesByRepoID(ctx, tx, r.ID())
+		if err != nil {
+			return err
+		}
+		for _, b := range bs {
+			if b.Pattern == pattern {
+				return d.store.DeleteProtectedBranch(ctx, tx, r.ID(), pattern)
+			}
+		}
+		return ErrProtectedBranchNotFound
+	}); err != nil {
+		if errors.Is(err, ErrProtectedBranchNotFound) {
+			return err
+		}
+		return db.WrapError(err)
+	}
+
+	d.audit(ctx, "branch.unprotect", r.Name(), pattern)
+	return nil
+}
+
+// BranchProtection returns the protection of a branch of a repository, and
+// whether the branch is protected.
+func (d *Backend) BranchProtection(ctx context.Context, repo string, branch string) (models.ProtectedBranch, bool, error) {
+	bs, err := d.ProtectedBranches(ctx, repo)
+	if err != nil {
+		return models.ProtectedBranch{}, false, err
+	}
+
+	b, ok := matchProtectedBranch(bs, git.RefsHeads+branch)
+	return b, ok, nil
+}
+
+// matchProtectedBranch returns the first protected branch matching ref.
+func matchProtectedBranch(bs []models.ProtectedBranch, ref string) (models.ProtectedBranch, bool) {
+	name, ok := strings.CutPrefix(ref, git.RefsHeads)
+	if !ok {
+		return models.ProtectedBranch{}, false
+	}
+	for _, b := range bs {
+		if ok, _ := path.Match(b.Pattern, name); ok {
+			return b, true
+		}
+	}
+	return models.ProtectedBranch{}, false
+}
+
+// pushOptions returns the push options of the push running a hook.
+func pushOptions() []string {
+	n, _ := strconv.Atoi(os.Getenv("GIT_PUSH_OPTION_COUNT"))
+	opts := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		opts = append(opts, os.Getenv(fmt.Sprintf("GIT_PUSH_OPTION_%d", i)))
+	}
+	return opts
+}
+
+// pushLease returns the tip a push expects ref to be at before overwriting
+// it, from "lease=<sha>" or "lease=<branch>:<sha>" push options.
+func pushLease(opts []string, ref string) (string, bool) {
+	var lease string
+	var found bool
+	for _, opt := range opts {
+		v, ok := strings.CutPrefix(opt, "lease=")
+		if !ok {
+			continue
+		}
+		if name, sha, ok := strings.Cut(v, ":"); ok {
+			if fullRefName(name) == ref {
+				return sha, true
+			}
+			continue
+		}
+		lease, found = v, true
+	}
+	return lease, found
+}
+
+// checkLeases rejects the force pushes and deletions of protected branches
+// requiring a lease that don't state the tip they overwrite, which is what
+// `git push --force-with-lease` checks on the client.
+func (d *Backend) checkLeases(ctx context.Context, name string, args []hooks.HookArg, opts []string) error {
+	r, err := d.Repository(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	bs, err := d.store.GetProtectedBranchesByRepoID(ctx, d.db, r.ID())
+	if err != nil {
+		return db.WrapError(err)
+	}
+
+	path := r.(*repo).path
+	for _, arg := range args {
+		b, ok := matchProtectedBranch(bs, arg.RefName)
+		if !ok || !b.RequireLease {
+			continue
+		}
+		if action := d.refUpdateAction(ctx, path, arg.OldSha, arg.NewSha); action != RefUpdateForced && action != RefUpdateDeleted {
+			continue
+		}
+
+		lease, ok := pushLease(opts, arg.RefName)
+		if !ok {
+			return fmt.Errorf("%w: push %s with -o lease=<sha of the tip to overwrite>", ErrLeaseRequired, arg.RefName)
+		}
+		if lease == "" || !strings.HasPrefix(arg.OldSha, lease) {
+			return fmt.Errorf("%w: %s is at %s, not %s", ErrStaleLease, arg.RefName, arg.OldSha, lease)
+		}
+	}
+
+	return nil
+}
+
+// notifyRewrite notifies the watchers of a repository when ref update u
+// rewrote the history of a shared branch: the default branch or a protected
+// one.
+func (d *Backend) notifyRewrite(ctx context.Context, r proto.Repository, u models.RefUpdate) {
+	path := r.(*repo).path
+	shared := false
+	if out, err := d.command(ctx, "symbolic-ref", "HEAD").RunInDir(path); err == nil && strings.TrimSpace(string(out)) == u.Ref {
+		shared = true
+	} else if bs, err := d.store.GetProtectedBranchesByRepoID(ctx, d.db, r.ID()); err == nil {
+		_, shared = matchProtectedBranch(bs, u.Ref)
+	}
+	if !shared {
+		return
+	}
+
+	d.notifyWatchers(ctx, r, models.Notification{
+		SubjectType: NotificationSubjectRef,
+		SubjectID:   u.ID,
+		Title:       fmt.Sprintf("%s %s...%s", strings.TrimPrefix(u.Ref, git.RefsHeads), u.OldSha[:7], u.NewSha[:7]),
+		Action:      "force_pushed",
+	})
+}
@@ -0,0 +1,50 @@
+package backend
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+)
+
+func TestMatchProtectedBranch(t *testing.T) {
+	bs := []models.ProtectedBranch{{Pattern: "main"}, {Pattern: "release/*", RequireLease: true}}
+
+	cases := []struct {
+		ref     string
+		pattern string
+	}{
+		{"refs/heads/main", "main"},
+		{"refs/heads/release/1.0", "release/*"},
+		{"refs/heads/release/1.0/fix", ""},
+		{"refs/heads/feature", ""},
+		{"refs/tags/main", ""},
+	}
+	for _, c := range cases {
+		b, ok := matchProtectedBranch(bs, c.ref)
+		if ok != (c.pattern != "") || b.Pattern != c.pattern {
+			t.Errorf("matchProtectedBranch(%q) = %q, %t, want %q", c.ref, b.Pattern, ok, c.pattern)
+		}
+	}
+}
+
+func TestPushLease(t *testing.T) {
+	cases := []struct {
+		name  string
+		opts  []string
+		lease string
+		ok    bool
+	}{
+		{"none", []string{"ci.skip"}, "", false},
+		{"any ref", []string{"lease=abc123"}, "abc123", true},
+		{"this ref", []string{"lease=main:abc123", "lease=def456"}, "abc123", true},
+		{"full ref name", []string{"lease=refs/heads/main:abc123"}, "abc123", true},
+		{"other ref", []string{"lease=dev:abc123"}, "", false},
+		{"other ref and any ref", []string{"lease=dev:abc123", "lease=def456"}, "def456", true},
+	}
+	for _, c := range cases {
+		lease, ok := pushLease(c.opts, "refs/heads/main")
+		if lease != c.lease || ok != c.ok {
+			t.Errorf("%s: pushLease() = %q, %t, want %q, %t", c.name, lease, ok, c.lease, c.ok)
+		}
+	}
+}
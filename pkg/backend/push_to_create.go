@@ -0,0 +1,117 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/charmbracelet/soft-serve/pkg/access"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/utils"
+)
+
+// pushCreatedConfig is the Git config key marking the repositories created by
+// pushing to them until the push completes.
+const pushCreatedConfig = "soft-serve.pushcreated"
+
+var (
+	// ErrPushToCreateDisabled is returned when pushing to a repository that
+	// doesn't exist while push-to-create is disabled.
+	ErrPushToCreateDisabled = errors.New("repository does not exist, create it with \"repo create\" first")
+
+	// ErrPushToCreateNamespace is returned when pushing to a repository that
+	// doesn't exist outside of the namespaces users can create repositories
+	// in.
+	ErrPushToCreateNamespace = errors.New("you can't create repositories in this namespace")
+)
+
+// Namespace kinds of push-to-create, see config.PushToCreateConfig.
+const (
+	pushNamespaceUser = "user"
+	pushNamespaceOrg  = "org"
+	pushNamespaceAny  = "any"
+)
+
+// CreateRepositoryOnPush creates the repository user pushes to, if the
+// push-to-create rules let them. The visibility of the repository depends on
+// its namespace, see config.PushToCreateConfig.
+func (d *Backend) CreateRepositoryOnPush(ctx context.Context, name string, user proto.User) (proto.Repository, error) {
+	name = utils.SanitizeRepo(name)
+	cfg := d.cfg.PushToCreate
+	if !cfg.Enabled {
+		return nil, ErrPushToCreateDisabled
+	}
+
+	ns := d.pushNamespace(ctx, name, user)
+	if !cfg.Allows(ns) && (user == nil || !user.IsAdmin()) {
+		return nil, ErrPushToCreateNamespace
+	}
+
+	visibility := cfg.Visibility
+	switch ns {
+	case pushNamespaceUser:
+		visibility = cfg.UserVisibility
+	case pushNamespaceOrg:
+		visibility = cfg.OrgVisibility
+	}
+
+	r, err := d.CreateRepository(ctx, name, user, proto.RepositoryOptions{
+		Private:  visibility == string(proto.VisibilityPrivate),
+		Internal: visibility == string(proto.VisibilityInternal),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := d.command(ctx, "config", pushCreatedConfig, "true").RunInDir(r.(*repo).path); err != nil {
+		d.logger.Error("error marking pushed repository", "repo", name, "err", err)
+	}
+
+	return r, nil
+}
+
+// pushNamespace returns the namespace kind of repository name for user:
+// "user" under their username, "org" under an organization they can write to
+// a repository of, and "any" otherwise.
+func (d *Backend) pushNamespace(ctx context.Context, name string, user proto.User) string {
+	org, _, nested := strings.Cut(name, "/")
+	if !nested || user == nil {
+		return pushNamespaceAny
+	}
+	if strings.EqualFold(org, user.Username()) {
+		return pushNamespaceUser
+	}
+
+	repos, err := d.Repositories(ctx)
+	if err != nil {
+		return pushNamespaceAny
+	}
+	for _, r := range repos {
+		if strings.HasPrefix(r.Name(), org+"/") && d.AccessLevelForUser(ctx, r.Name(), user) >= access.ReadWriteAccess {
+			return pushNamespaceOrg
+		}
+	}
+
+	return pushNamespaceAny
+}
+
+// announcePushCreated prints how to change the settings of a repository
+// created by the push running a hook, once.
+func (d *Backend) announcePushCreated(ctx context.Context, stdout io.Writer, name string) {
+	r, err := d.Repository(ctx, name)
+	if err != nil {
+		return
+	}
+	path := r.(*repo).path
+	if out, err := d.command(ctx, "config", "--get", pushCreatedConfig).RunInDir(path); err != nil || strings.TrimSpace(string(out)) != "true" {
+		return
+	}
+	if _, err := d.command(ctx, "config", "--unset", pushCreatedConfig).RunInDir(path); err != nil {
+		d.logger.Error("error unmarking pushed repository", "repo", name, "err", err)
+	}
+
+	fmt.Fprintf(stdout, "Created %s repository %s\n", proto.RepositoryVisibility(r), r.Name())
+	fmt.Fprintf(stdout, "Settings: %s repo info %s\n", d.cfg.SSHCommand(), r.Name())
+}
@@ -0,0 +1,241 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/hooks"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/storage"
+	"github.com/charmbracelet/soft-serve/pkg/utils"
+)
+
+// ErrCantReconcile is returned when an inconsistency can't be reconciled with
+// an action, e.g. adopting a dangling reference.
+var ErrCantReconcile = errors.New("inconsistency can't be reconciled this way")
+
+// InconsistencyKind is a kind of inconsistency between the database and the
+// repositories on disk.
+type InconsistencyKind string
+
+// Inconsistency kinds.
+const (
+	// UntrackedRepository is a repository on disk missing from the database.
+	UntrackedRepository InconsistencyKind = "untracked"
+	// MissingRepository is a repository of the database missing on disk.
+	MissingRepository InconsistencyKind = "missing"
+	// DanglingReference is an issue, a merge request, or a collaborator of a
+	// missing repository, or referencing a missing user.
+	DanglingReference InconsistencyKind = "dangling"
+)
+
+// ReconcileAction is a way of reconciling an inconsistency.
+type ReconcileAction string
+
+// Reconcile actions.
+const (
+	// ReconcileAdopt adds an untracked repository to the database, as a
+	// private repository.
+	ReconcileAdopt ReconcileAction = "adopt"
+	// ReconcileArchive moves an untracked repository to the archive
+	// directory of the server.
+	ReconcileArchive ReconcileAction = "archive"
+	// ReconcilePurge deletes an untracked repository, the database record of
+	// a missing repository, or the row of a dangling reference.
+	ReconcilePurge ReconcileAction = "purge"
+)
+
+// Inconsistency is an inconsistency between the database and the
+// repositories on disk.
+type Inconsistency struct {
+	Kind InconsistencyKind
+	// Repo is the name of an untracked or missing repository.
+	Repo string
+	// Location is the location of an untracked or missing repository.
+	Location storage.Location
+	// Reference is a dangling reference.
+	Reference models.DanglingReference
+}
+
+// String returns a description of the inconsistency.
+func (i Inconsistency) String() string {
+	switch i.Kind {
+	case UntrackedRepository:
+		return fmt.Sprintf("untracked repository %s at %s", i.Repo, i.Location)
+	case MissingRepository:
+		return fmt.Sprintf("missing repository %s at %s", i.Repo, i.Location)
+	default:
+		target := "user"
+		if i.Reference.Column == "repo_id" {
+			target = "repository"
+		}
+		return fmt.Sprintf("%s %d referencing missing %s %d (%s)",
+			i.Reference.Table, i.Reference.RowID, target, i.Reference.RefID, i.Reference.Column)
+	}
+}
+
+// archivePath returns the directory reconciled repositories are archived to.
+func (d *Backend) archivePath() string {
+	return filepath.Join(d.cfg.DataPath, "archive")
+}
+
+// CheckConsistency returns the inconsistencies between the database and the
+// repositories on disk. Repositories on volumes that are no longer
+// configured are left alone.
+func (d *Backend) CheckConsistency(ctx context.Context) ([]Inconsistency, error) {
+	var ms []models.Repo
+	var refs []models.DanglingReference
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		ms, err = d.store.GetAllRepos(ctx, tx)
+		if err != nil {
+			return err
+		}
+		refs, err = d.store.GetDanglingReferences(ctx, tx)
+		return err
+	}); err != nil {
+		return nil, db.WrapError(err)
+	}
+
+	var incs []Inconsistency
+	tracked := make(map[storage.Location]bool, len(ms))
+	for _, m := range ms {
+		loc := repoLocation(m)
+		tracked[loc] = true
+		dir, err := d.repos.Dir(loc)
+		if err != nil {
+			continue
+		}
+		if _, err := os.Stat(dir); errors.Is(err, os.ErrNotExist) {
+			incs = append(incs, Inconsistency{Kind: MissingRepository, Repo: m.Name, Location: loc})
+		}
+	}
+
+	for _, volume := range d.repos.Volumes() {
+		locs, err := d.repos.List(volume)
+		if err != nil {
+			return nil, err
+		}
+		for _, loc := range locs {
+			if tracked[loc] || d.isServerDir(loc) {
+				continue
+			}
+			incs = append(incs, Inconsistency{Kind: UntrackedRepository, Repo: d.repos.Name(loc), Location: loc})
+		}
+	}
+
+	for _, ref := range refs {
+		incs = append(incs, Inconsistency{Kind: DanglingReference, Reference: ref})
+	}
+
+	return incs, nil
+}
+
+// isServerDir returns whether loc is in a directory the server keeps
+// repositories of its own in, object pools and archived repositories, when
+// it's on a volume.
+func (d *Backend) isServerDir(loc storage.Location) bool {
+	dir, err := d.repos.Dir(loc)
+	if err != nil {
+		return false
+	}
+	for _, root := range []string{d.poolsPath(), d.archivePath()} {
+		if strings.HasPrefix(dir, root+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Reconcile reconciles an inconsistency with an action. It returns
+// ErrCantReconcile when the action doesn't apply to the inconsistency.
+func (d *Backend) Reconcile(ctx context.Context, inc Inconsistency, action ReconcileAction) error {
+	switch {
+	case inc.Kind == UntrackedRepository && action == ReconcileAdopt:
+		if err := d.adoptRepository(ctx, inc); err != nil {
+			return err
+		}
+	case inc.Kind == UntrackedRepository && action == ReconcileArchive:
+		src, err := d.repos.Dir(inc.Location)
+		if err != nil {
+			return err
+		}
+		dst := filepath.Join(d.archivePath(), inc.Location.Volume, filepath.FromSlash(inc.Location.Path))
+		if _, err := os.Stat(dst); err == nil {
+			return fmt.Errorf("archiving %s: %s already exists", inc.Repo, dst)
+		}
+		if err := moveDir(src, dst); err != nil {
+			return fmt.Errorf("archiving %s: %w", inc.Repo, err)
+		}
+	case inc.Kind == UntrackedRepository && action == ReconcilePurge:
+		dir, err := d.repos.Dir(inc.Location)
+		if err != nil {
+			return err
+		}
+		if err := os.RemoveAll(dir); err != nil {
+			return err
+		}
+	case inc.Kind == MissingRepository && action == ReconcilePurge:
+		defer d.cache.Delete(inc.Repo)
+		if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+			return d.store.DeleteRepoByName(ctx, tx, inc.Repo)
+		}); err != nil {
+			return db.WrapError(err)
+		}
+	case inc.Kind == DanglingReference && action == ReconcilePurge:
+		if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+			return d.store.PurgeDanglingReference(ctx, tx, inc.Reference)
+		}); err != nil {
+			return db.WrapError(err)
+		}
+	default:
+		return fmt.Errorf("%w: %s %s", ErrCantReconcile, action, inc.Kind)
+	}
+
+	d.audit(ctx, "reconcile."+string(action), inc.Repo, inc.String())
+	return nil
+}
+
+// adoptRepository adds an untracked repository to the database, in place.
+// It's private until an admin looks into it.
+func (d *Backend) adoptRepository(ctx context.Context, inc Inconsistency) error {
+	if err := utils.ValidateRepo(inc.Repo); err != nil {
+		return err
+	}
+	dir, err := d.repos.Dir(inc.Location)
+	if err != nil {
+		return err
+	}
+
+	var userID int64
+	if user := proto.UserFromContext(ctx); user != nil {
+		userID = user.ID()
+	}
+
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		if err := d.store.CreateRepo(ctx, tx, inc.Repo, userID, "", "", true, false, false); err != nil {
+			return err
+		}
+		if err := d.store.SetRepoStorageByName(ctx, tx, inc.Repo, inc.Location.Volume, inc.Location.Path); err != nil {
+			return err
+		}
+		if err := d.configureGit(ctx, dir); err != nil {
+			return err
+		}
+		return hooks.GenerateHooks(ctx, d.cfg, dir)
+	}); err != nil {
+		err = db.WrapError(err)
+		if errors.Is(err, db.ErrDuplicateKey) {
+			return proto.ErrRepoExist
+		}
+		return err
+	}
+
+	return nil
+}
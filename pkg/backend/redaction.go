@@ -0,0 +1,134 @@
+package backend
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/soft-serve/pkg/access"
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/utils"
+)
+
+// DefaultRedaction replaces the redacted secrets when no replacement is
+// given.
+const DefaultRedaction = "[REDACTED]"
+
+// minRedactionLength is the length under which a secret is too likely to
+// match unrelated text to be redacted.
+const minRedactionLength = 6
+
+var (
+	// ErrRedactionTooShort is returned when redacting a secret shorter than
+	// minRedactionLength.
+	ErrRedactionTooShort = fmt.Errorf("secrets to redact must be at least %d characters long", minRedactionLength)
+
+	// ErrRedactionReplacement is returned when the replacement of a secret
+	// contains the secret.
+	ErrRedactionReplacement = errors.New("the replacement can't contain the secret")
+)
+
+// Redaction is the outcome of redacting a secret from a repository.
+type Redaction struct {
+	// Records is the number of database records the secret was removed from.
+	Records int64
+	// Commits are the commits whose changes or message contain the secret.
+	// Redacting doesn't rewrite the git history; these are left to rewrite
+	// and force-push.
+	Commits []string
+}
+
+// RedactSecret replaces a leaked secret with replacement in the issues and
+// merge requests of a repository, full descriptions and comments included,
+// and in the notifications, activities, events, and webhook deliveries
+// derived from them. It also lists the commits still containing the secret. Only the
+// admins of the repository can, and the audit log records the redaction but
+// never the secret.
+func (d *Backend) RedactSecret(ctx context.Context, repoName string, secret string, replacement string) (Redaction, error) {
+	repoName = utils.SanitizeRepo(repoName)
+	if len(secret) < minRedactionLength {
+		return Redaction{}, ErrRedactionTooShort
+	}
+	if replacement == "" {
+		replacement = DefaultRedaction
+	}
+	if strings.Contains(replacement, secret) {
+		return Redaction{}, ErrRedactionReplacement
+	}
+
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return Redaction{}, err
+	}
+	if d.AccessLevelForUser(ctx, r.Name(), proto.UserFromContext(ctx)) < access.AdminAccess {
+		return Redaction{}, proto.ErrUnauthorized
+	}
+
+	// Event payloads and webhook deliveries hold the secret JSON encoded.
+	replacements := [][2]string{{secret, replacement}}
+	if s, rs := jsonString(secret), jsonString(replacement); s != secret {
+		replacements = append(replacements, [2]string{s, rs})
+	}
+
+	var red Redaction
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		for _, rep := range replacements {
+			n, err := d.store.RedactRepoText(ctx, tx, r.ID(), rep[0], rep[1])
+			if err != nil {
+				return err
+			}
+			red.Records += n
+		}
+		return nil
+	}); err != nil {
+		return Redaction{}, db.WrapError(err)
+	}
+
+	red.Commits = d.commitsContaining(ctx, r, secret)
+
+	d.audit(ctx, moderationActionPrefix+"redact", r.Name(),
+		fmt.Sprintf("%d records, %d commits", red.Records, len(red.Commits)))
+
+	return red, nil
+}
+
+// commitsContaining returns the commits of r whose changes add or remove s,
+// or whose message contains s, sorted. The database is already redacted, so
+// errors are logged instead of returned.
+func (d *Backend) commitsContaining(ctx context.Context, r proto.Repository, s string) []string {
+	seen := map[string]bool{}
+	for _, args := range [][]string{
+		gitArgs("log", []string{"--all", "--format=%H", "-S" + s}),
+		gitArgs("log", []string{"--all", "--format=%H", "--fixed-strings", "--grep=" + s}),
+	} {
+		out, err := d.command(ctx, args...).RunInDir(r.(*repo).path)
+		if err != nil {
+			d.logger.Error("error searching commits for redacted secret", "repo", r.Name(), "err", err)
+			continue
+		}
+		for _, sha := range strings.Fields(string(out)) {
+			seen[sha] = true
+		}
+	}
+
+	commits := make([]string, 0, len(seen))
+	for sha := range seen {
+		commits = append(commits, sha)
+	}
+	sort.Strings(commits)
+
+	return commits
+}
+
+// jsonString returns s encoded as a JSON string, without the quotes.
+func jsonString(s string) string {
+	b, err := json.Marshal(s)
+	if err != nil {
+		return s
+	}
+	return string(b[1 : len(b)-1])
+}
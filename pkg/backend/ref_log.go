@@ -0,0 +1,235 @@
+package backend
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/git"
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/hooks"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/utils"
+)
+
+// Ref update actions of the server-side reflogs.
+const (
+	RefUpdateCreated   = "created"
+	RefUpdateUpdated   = "updated"
+	RefUpdateForced    = "forced"
+	RefUpdateDeleted   = "deleted"
+	RefUpdateRecovered = "recovered"
+)
+
+// configureGit configures Git on the repository at path to log every ref
+// update, to keep the objects of old ref values for the reflog retention, and
+// to accept push options like leases.
+func (d *Backend) configureGit(ctx context.Context, path string) error {
+	expire, prune := "never", "never"
+	if days := d.cfg.Git.ReflogRetention; days > 0 {
+		expire = fmt.Sprintf("%d.days", days)
+		prune = expire + ".ago"
+	}
+
+	for _, kv := range [][]string{
+		{"core.logAllRefUpdates", "always"},
+		{"gc.reflogExpire", expire},
+		{"gc.reflogExpireUnreachable", expire},
+		{"gc.pruneExpire", prune},
+		{"receive.advertisePushOptions", "true"},
+	} {
+		if _, err := d.command(ctx, "config", kv[0], kv[1]).RunInDir(path); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// fullRefName returns the full name of ref, assuming branch names.
+func fullRefName(ref string) string {
+	if strings.HasPrefix(ref, "refs/") {
+		return ref
+	}
+	return git.RefsHeads + ref
+}
+
+// refUpdateAction returns the action of a ref update in the repository at
+// path.
+func (d *Backend) refUpdateAction(ctx context.Context, path, oldSha, newSha string) string {
+	switch {
+	case git.IsZeroHash(oldSha):
+		return RefUpdateCreated
+	case git.IsZeroHash(newSha):
+		return RefUpdateDeleted
+	}
+
+	// merge-base exits with 1 when the old value isn't an ancestor of the
+	// new one, and fails for other reasons on non-commits like tags.
+	_, err := d.command(ctx, gitArgs("merge-base", []string{"--is-ancestor"}, oldSha, newSha)...).RunInDir(path)
+	if err != nil && strings.Contains(err.Error(), "exit status 1") {
+		return RefUpdateForced
+	}
+	return RefUpdateUpdated
+}
+
+// recordRefUpdates adds pushed ref updates to the reflog of a repository.
+// The refs are already updated, so errors are logged instead of returned.
+func (d *Backend) recordRefUpdates(ctx context.Context, name string, user proto.User, args []hooks.HookArg) {
+	r, err := d.Repository(ctx, name)
+	if err != nil {
+		d.logger.Error("error finding repository", "repo", name, "err", err)
+		return
+	}
+
+	var userID sql.NullInt64
+	if user != nil {
+		userID = sql.NullInt64{Int64: user.ID(), Valid: true}
+	}
+
+	path := r.(*repo).path
+	us := make([]models.RefUpdate, 0, len(args))
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		for _, arg := range args {
+			u := models.RefUpdate{
+				RepoID: r.ID(),
+				Ref:    arg.RefName,
+				OldSha: arg.OldSha,
+				NewSha: arg.NewSha,
+				Action: d.refUpdateAction(ctx, path, arg.OldSha, arg.NewSha),
+				UserID: userID,
+			}
+			id, err := d.store.CreateRefUpdate(ctx, tx, u)
+			if err != nil {
+				return err
+			}
+			u.ID = id
+			us = append(us, u)
+		}
+		return nil
+	}); err != nil {
+		d.logger.Error("error recording ref updates", "repo", name, "err", err)
+		return
+	}
+
+	if user != nil {
+		ctx = proto.WithUserContext(ctx, user)
+	}
+	for _, u := range us {
+		if u.Action == RefUpdateForced {
+			d.notifyRewrite(ctx, r, u)
+		}
+	}
+}
+
+// RefLog returns the latest limit entries of the reflog of a repository,
+// newest first. An empty ref returns the entries of all refs, and branches
+// can be given by their short name.
+func (d *Backend) RefLog(ctx context.Context, repo string, ref string, limit int) ([]models.RefUpdate, error) {
+	r, err := d.Repository(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+	if ref != "" {
+		ref = fullRefName(ref)
+	}
+
+	us, err := d.store.GetRefUpdatesByRepoID(ctx, d.db, r.ID(), ref, limit)
+	if err != nil {
+		return nil, db.WrapError(err)
+	}
+
+	return us, nil
+}
+
+// RecoverRef points ref of a repository back to sha, e.g. to restore a
+// force-pushed or deleted branch from its reflog. Branches can be given by
+// their short name.
+func (d *Backend) RecoverRef(ctx context.Context, name string, ref string, sha string) error {
+	name = utils.SanitizeRepo(name)
+	r, err := d.Repository(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	ref = fullRefName(ref)
+	path := r.(*repo).path
+	if err := validateRef(ref); err != nil {
+		return err
+	}
+
+	out, err := d.command(ctx, gitArgs("rev-parse", []string{"--verify", "--quiet"}, sha+"^{object}")...).RunInDir(path)
+	if err != nil {
+		return fmt.Errorf("object %s not found, it may have been garbage collected", sha)
+	}
+	newSha := strings.TrimSpace(string(out))
+
+	oldSha := git.ZeroID
+	if out, err := d.command(ctx, gitArgs("rev-parse", []string{"--verify", "--quiet"}, ref)...).RunInDir(path); err == nil {
+		oldSha = strings.TrimSpace(string(out))
+	}
+	if oldSha == newSha {
+		return nil
+	}
+
+	user := proto.UserFromContext(ctx)
+	msg := "recover"
+	if user != nil {
+		msg += " by " + user.Username()
+	}
+	if _, err := d.command(ctx, gitArgs("update-ref", []string{"-m", msg}, ref, newSha)...).RunInDir(path); err != nil {
+		return err
+	}
+
+	defer d.cache.Delete(name)
+	u := models.RefUpdate{
+		RepoID: r.ID(),
+		Ref:    ref,
+		OldSha: oldSha,
+		NewSha: newSha,
+		Action: RefUpdateRecovered,
+	}
+	if user != nil {
+		u.UserID = sql.NullInt64{Int64: user.ID(), Valid: true}
+	}
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		_, err := d.store.CreateRefUpdate(ctx, tx, u)
+		return err
+	}); err != nil {
+		d.logger.Error("error recording ref update", "repo", name, "ref", ref, "err", err)
+	}
+
+	d.audit(ctx, "repo.recover", name, fmt.Sprintf("%s to %s", ref, newSha))
+	return nil
+}
+
+// ExpireRefLogs deletes the reflog entries older than the reflog retention,
+// and applies the retention to the Git configuration of all the repositories.
+func (d *Backend) ExpireRefLogs(ctx context.Context) error {
+	var errs []error
+	if days := d.cfg.Git.ReflogRetention; days > 0 {
+		before := time.Now().AddDate(0, 0, -days)
+		n, err := d.store.DeleteRefUpdatesBefore(ctx, d.db, before)
+		if err != nil {
+			errs = append(errs, db.WrapError(err))
+		} else if n > 0 {
+			d.logger.Info("expired reflog entries", "count", n)
+		}
+	}
+
+	repos, err := d.Repositories(ctx)
+	if err != nil {
+		return errors.Join(append(errs, err)...)
+	}
+	for _, r := range repos {
+		if err := d.configureGit(ctx, r.(*repo).path); err != nil {
+			errs = append(errs, fmt.Errorf("configuring git of %s: %w", r.Name(), err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -6,11 +6,11 @@ import (
 	"errors"
 	"fmt"
 	"io/fs"
+	"net/http"
 	"os"
 	"path"
 	"path/filepath"
 	"strconv"
-	"strings"
 	"time"
 
 	"github.com/charmbracelet/soft-serve/git"
@@ -34,7 +34,35 @@ func (d *Backend) CreateRepository(ctx context.Context, name string, user proto.
 		return nil, err
 	}
 
-	rp := filepath.Join(d.repoPath(name))
+	if opts.DefaultBranch == "" && d.cfg != nil {
+		opts.DefaultBranch = d.cfg.Git.DefaultBranch
+	}
+	if opts.DefaultBranch != "" {
+		if err := utils.ValidateBranch(opts.DefaultBranch); err != nil {
+			return nil, fmt.Errorf("invalid default branch: %w", err)
+		}
+	}
+
+	files, err := d.initialFiles(name, user, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	loc, err := d.placeRepository(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.createRepository(ctx, name, user, opts, loc, files)
+}
+
+// createRepository creates a new repository at loc, with an initial commit
+// of files, if any.
+func (d *Backend) createRepository(ctx context.Context, name string, user proto.User, opts proto.RepositoryOptions, loc storage.Location, files []repoFile) (proto.Repository, error) {
+	rp, err := d.repos.Dir(loc)
+	if err != nil {
+		return nil, err
+	}
 
 	var userID int64
 	if user != nil {
@@ -56,25 +84,45 @@ func (d *Backend) CreateRepository(ctx context.Context, name string, user proto.
 			return err
 		}
 
+		if err := d.store.SetRepoStorageByName(ctx, tx, name, loc.Volume, loc.Path); err != nil {
+			return err
+		}
+
+		if opts.Internal && !opts.Private {
+			if err := d.store.SetRepoIsInternalByName(ctx, tx, name, true); err != nil {
+				return err
+			}
+		}
+
 		_, err := git.Init(rp, true)
 		if err != nil {
 			d.logger.Debug("failed to create repository", "err", err)
 			return err
 		}
 
+		if err := d.configureGit(ctx, rp); err != nil {
+			d.logger.Error("failed to configure git", "repo", name, "err", err)
+			return err
+		}
+
+		if err := d.initRepository(ctx, rp, opts.DefaultBranch, user, files); err != nil {
+			d.logger.Error("failed to initialize repository", "repo", name, "err", err)
+			return err
+		}
+
 		if err := os.WriteFile(filepath.Join(rp, "description"), []byte(opts.Description), fs.ModePerm); err != nil {
 			d.logger.Error("failed to write description", "repo", name, "err", err)
 			return err
 		}
 
-		if !opts.Private {
+		if !opts.Private && !opts.Internal {
 			if err := os.WriteFile(filepath.Join(rp, "git-daemon-export-ok"), []byte{}, fs.ModePerm); err != nil {
 				d.logger.Error("failed to write git-daemon-export-ok", "repo", name, "err", err)
 				return err
 			}
 		}
 
-		return hooks.GenerateHooks(ctx, d.cfg, name)
+		return hooks.GenerateHooks(ctx, d.cfg, rp)
 	}); err != nil {
 		d.logger.Debug("failed to create repository in database", "err", err)
 		err = db.WrapError(err)
@@ -85,24 +133,47 @@ func (d *Backend) CreateRepository(ctx context.Context, name string, user proto.
 		return nil, err
 	}
 
-	return d.Repository(ctx, name)
+	r, err := d.Repository(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	d.recordActivity(ctx, r, user, models.Activity{
+		Kind:   ActivityKindRepository,
+		Action: "created",
+		Title:  name,
+	})
+
+	return r, nil
 }
 
 // ImportRepository imports a repository from remote.
 // XXX: This a expensive operation and should be run in a goroutine.
-func (d *Backend) ImportRepository(_ context.Context, name string, user proto.User, remote string, opts proto.RepositoryOptions) (proto.Repository, error) {
+func (d *Backend) ImportRepository(ctx context.Context, name string, user proto.User, remote string, opts proto.RepositoryOptions) (proto.Repository, error) {
 	name = utils.SanitizeRepo(name)
 	if err := utils.ValidateRepo(name); err != nil {
 		return nil, err
 	}
 
-	rp := filepath.Join(d.repoPath(name))
-
 	tid := "import:" + name
 	if d.manager.Exists(tid) {
 		return nil, task.ErrAlreadyStarted
 	}
 
+	if _, err := d.Repository(ctx, name); err == nil {
+		return nil, proto.ErrRepoExist
+	}
+
+	loc, err := d.placeRepository(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	rp, err := d.repos.Dir(loc)
+	if err != nil {
+		return nil, err
+	}
+
 	if _, err := os.Stat(rp); err == nil || os.IsExist(err) {
 		return nil, proto.ErrRepoExist
 	}
@@ -120,12 +191,12 @@ func (d *Backend) ImportRepository(_ context.Context, name string, user proto.Us
 			CommandOptions: git.CommandOptions{
 				Timeout: -1,
 				Context: ctx,
-				Envs: []string{
+				Envs: append([]string{
 					fmt.Sprintf(`GIT_SSH_COMMAND=ssh -o UserKnownHostsFile="%s" -o StrictHostKeyChecking=no -i "%s"`,
 						filepath.Join(d.cfg.DataPath, "ssh", "known_hosts"),
 						d.cfg.SSH.ClientKeyPath,
 					),
-				},
+				}, d.cfg.Outbound.GitEnv()...),
 			},
 		}
 
@@ -139,7 +210,7 @@ func (d *Backend) ImportRepository(_ context.Context, name string, user proto.Us
 			return err
 		}
 
-		r, err := d.CreateRepository(ctx, name, user, opts)
+		r, err := d.createRepository(ctx, name, user, opts, loc, nil)
 		if err != nil {
 			d.logger.Error("failed to create repository", "err", err, "name", name)
 			return err
@@ -185,7 +256,13 @@ func (d *Backend) ImportRepository(_ context.Context, name string, user proto.Us
 			return err
 		}
 
-		client := lfs.NewClient(ep)
+		transport, err := d.cfg.Outbound.Transport()
+		if err != nil {
+			d.logger.Error("failed to create lfs transport", "err", err, "path", rp)
+			return err
+		}
+
+		client := lfs.NewClient(ep, lfs.WithHTTPClient(&http.Client{Transport: transport}))
 		if client == nil {
 			d.logger.Warn("failed to create lfs client: unsupported endpoint", "endpoint", endpoint)
 			return nil
@@ -212,13 +289,13 @@ func (d *Backend) ImportRepository(_ context.Context, name string, user proto.Us
 // It implements backend.Backend.
 func (d *Backend) DeleteRepository(ctx context.Context, name string) error {
 	name = utils.SanitizeRepo(name)
-	rp := filepath.Join(d.repoPath(name))
-
 	user := proto.UserFromContext(ctx)
 	r, err := d.Repository(ctx, name)
 	if err != nil {
 		return err
 	}
+	rp := r.(*repo).path
+	pool, _ := d.repoPool(rp)
 
 	// We create the webhook event before deleting the repository so we can
 	// send the event after deleting the repository.
@@ -263,6 +340,10 @@ func (d *Backend) DeleteRepository(ctx context.Context, name string) error {
 			}
 		}
 
+		if err := os.RemoveAll(d.attachmentsPath(repom.ID)); err != nil {
+			d.logger.Error("failed to delete attachments", "repo", name, "err", err)
+		}
+
 		if err := d.store.DeleteRepoByName(ctx, tx, name); err != nil {
 			return db.WrapError(err)
 		}
@@ -276,6 +357,12 @@ func (d *Backend) DeleteRepository(ctx context.Context, name string) error {
 		return db.WrapError(err)
 	}
 
+	if pool != "" {
+		if err := d.leavePool(ctx, pool, r.ID()); err != nil {
+			d.logger.Error("failed to leave object pool", "repo", name, "pool", pool, "err", err)
+		}
+	}
+
 	return webhook.SendEvent(ctx, wh)
 }
 
@@ -324,12 +411,24 @@ func (d *Backend) RenameRepository(ctx context.Context, oldName string, newName
 		return nil
 	}
 
-	op := filepath.Join(d.repoPath(oldName))
-	np := filepath.Join(d.repoPath(newName))
-	if _, err := os.Stat(op); err != nil {
+	r, err := d.Repository(ctx, oldName)
+	if err != nil {
 		return proto.ErrRepoNotFound
 	}
 
+	if _, err := d.Repository(ctx, newName); err == nil {
+		return proto.ErrRepoExist
+	}
+
+	// The repository stays on its volume, under the path of its new name.
+	from := repoLocation(r.(*repo).repo)
+	to := d.repos.Place(from.Volume, newName)
+	op := r.(*repo).path
+	np, err := d.repos.Dir(to)
+	if err != nil {
+		return err
+	}
+
 	if _, err := os.Stat(np); err == nil {
 		return proto.ErrRepoExist
 	}
@@ -342,6 +441,10 @@ func (d *Backend) RenameRepository(ctx context.Context, oldName string, newName
 			return err
 		}
 
+		if err := d.store.SetRepoStorageByName(ctx, tx, newName, to.Volume, to.Path); err != nil {
+			return err
+		}
+
 		// Make sure the new repository parent directory exists.
 		if err := os.MkdirAll(filepath.Dir(np), os.ModePerm); err != nil {
 			return err
@@ -379,9 +482,14 @@ func (d *Backend) Repositories(ctx context.Context) ([]proto.Repository, error)
 		}
 
 		for _, m := range ms {
+			rp, err := d.repos.Dir(repoLocation(m))
+			if err != nil {
+				d.logger.Error("failed to locate repository", "repo", m.Name, "err", err)
+			}
+
 			r := &repo{
 				name: m.Name,
-				path: filepath.Join(d.repoPath(m.Name)),
+				path: rp,
 				repo: m,
 			}
 
@@ -410,14 +518,6 @@ func (d *Backend) Repository(ctx context.Context, name string) (proto.Repository
 		return r, nil
 	}
 
-	rp := filepath.Join(d.repoPath(name))
-	if _, err := os.Stat(rp); err != nil {
-		if !errors.Is(err, fs.ErrNotExist) {
-			d.logger.Errorf("failed to stat repository path: %v", err)
-		}
-		return nil, proto.ErrRepoNotFound
-	}
-
 	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
 		var err error
 		m, err = d.store.GetRepoByName(ctx, tx, name)
@@ -429,6 +529,18 @@ func (d *Backend) Repository(ctx context.Context, name string) (proto.Repository
 		return nil, db.WrapError(err)
 	}
 
+	rp, err := d.repos.Dir(repoLocation(m))
+	if err != nil {
+		d.logger.Errorf("failed to locate repository: %v", err)
+		return nil, proto.ErrRepoNotFound
+	}
+	if _, err := os.Stat(rp); err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			d.logger.Errorf("failed to stat repository path: %v", err)
+		}
+		return nil, proto.ErrRepoNotFound
+	}
+
 	r := &repo{
 		name: name,
 		path: rp,
@@ -545,7 +657,10 @@ func (d *Backend) SetHidden(ctx context.Context, name string, hidden bool) error
 func (d *Backend) SetDescription(ctx context.Context, name string, desc string) error {
 	name = utils.SanitizeRepo(name)
 	desc = utils.Sanitize(desc)
-	rp := filepath.Join(d.repoPath(name))
+	rp, err := d.RepositoryPath(ctx, name)
+	if err != nil {
+		return err
+	}
 
 	// Delete cache
 	d.cache.Delete(name)
@@ -560,12 +675,32 @@ func (d *Backend) SetDescription(ctx context.Context, name string, desc string)
 	})
 }
 
-// SetPrivate sets the private flag of a repository.
+// SetPrivate sets the private flag of a repository. Making a repository not
+// private makes it public.
 //
 // It implements backend.Backend.
 func (d *Backend) SetPrivate(ctx context.Context, name string, private bool) error {
+	visibility := proto.VisibilityPublic
+	if private {
+		visibility = proto.VisibilityPrivate
+	}
+	return d.SetVisibility(ctx, name, visibility)
+}
+
+// SetVisibility sets the visibility of a repository. Only public repositories
+// are exported to the Git daemon, which serves anonymous users.
+func (d *Backend) SetVisibility(ctx context.Context, name string, visibility proto.Visibility) error {
 	name = utils.SanitizeRepo(name)
-	rp := filepath.Join(d.repoPath(name))
+	rp, err := d.RepositoryPath(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	repo, err := d.Repository(ctx, name)
+	if err != nil {
+		return err
+	}
+	old := proto.RepositoryVisibility(repo)
 
 	// Delete cache
 	d.cache.Delete(name)
@@ -573,7 +708,7 @@ func (d *Backend) SetPrivate(ctx context.Context, name string, private bool) err
 	if err := db.WrapError(
 		d.db.TransactionContext(ctx, func(tx *db.Tx) error {
 			fp := filepath.Join(rp, "git-daemon-export-ok")
-			if !private {
+			if visibility == proto.VisibilityPublic {
 				if err := os.WriteFile(fp, []byte{}, fs.ModePerm); err != nil {
 					d.logger.Error("failed to write git-daemon-export-ok", "repo", name, "err", err)
 					return err
@@ -587,30 +722,31 @@ func (d *Backend) SetPrivate(ctx context.Context, name string, private bool) err
 				}
 			}
 
-			return d.store.SetRepoIsPrivateByName(ctx, tx, name, private)
+			if err := d.store.SetRepoIsInternalByName(ctx, tx, name, visibility == proto.VisibilityInternal); err != nil {
+				return err
+			}
+			return d.store.SetRepoIsPrivateByName(ctx, tx, name, visibility == proto.VisibilityPrivate)
 		}),
 	); err != nil {
 		return err
 	}
 
+	if old == visibility {
+		return nil
+	}
+
 	user := proto.UserFromContext(ctx)
-	repo, err := d.Repository(ctx, name)
+	repo, err = d.Repository(ctx, name)
 	if err != nil {
 		return err
 	}
 
-	if repo.IsPrivate() != !private {
-		wh, err := webhook.NewRepositoryEvent(ctx, user, repo, webhook.RepositoryEventActionVisibilityChange)
-		if err != nil {
-			return err
-		}
-
-		if err := webhook.SendEvent(ctx, wh); err != nil {
-			return err
-		}
+	wh, err := webhook.NewRepositoryEvent(ctx, user, repo, webhook.RepositoryEventActionVisibilityChange)
+	if err != nil {
+		return err
 	}
 
-	return nil
+	return webhook.SendEvent(ctx, wh)
 }
 
 // SetProjectName sets the project name of a repository.
@@ -630,13 +766,6 @@ func (d *Backend) SetProjectName(ctx context.Context, repo string, name string)
 	)
 }
 
-// repoPath returns the path to a repository.
-func (d *Backend) repoPath(name string) string {
-	name = utils.SanitizeRepo(name)
-	rn := strings.ReplaceAll(name, "/", string(os.PathSeparator))
-	return filepath.Join(filepath.Join(d.cfg.DataPath, "repos"), rn+".git")
-}
-
 var _ proto.Repository = (*repo)(nil)
 
 // repo is a Git repository with metadata stored in a SQLite database.
@@ -685,6 +814,13 @@ func (r *repo) IsPrivate() bool {
 	return r.repo.Private
 }
 
+// IsInternal returns whether the repository is internal.
+//
+// It implements backend.Repository.
+func (r *repo) IsInternal() bool {
+	return r.repo.Internal && !r.repo.Private
+}
+
 // Name returns the repository's name.
 //
 // It implements backend.Repository.
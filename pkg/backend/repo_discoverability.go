@@ -0,0 +1,66 @@
+package backend
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/utils"
+)
+
+// IsSearchEnabled returns true if the issues and merge requests of the
+// repository show up in searches across repositories.
+func (d *Backend) IsSearchEnabled(ctx context.Context, name string) (bool, error) {
+	name = utils.SanitizeRepo(name)
+	var disabled bool
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		disabled, err = d.store.GetRepoIsSearchDisabledByName(ctx, tx, name)
+		return err
+	}); err != nil {
+		return false, db.WrapError(err)
+	}
+
+	return !disabled, nil
+}
+
+// SetSearchEnabled adds the issues and merge requests of a repository to, or
+// removes them from, searches across repositories.
+func (d *Backend) SetSearchEnabled(ctx context.Context, name string, enabled bool) error {
+	name = utils.SanitizeRepo(name)
+
+	// Delete cache
+	d.cache.Delete(name)
+
+	return db.WrapError(d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		return d.store.SetRepoIsSearchDisabledByName(ctx, tx, name, !enabled)
+	}))
+}
+
+// IsNewsEnabled returns true if the activity of the repository shows up in
+// the news.
+func (d *Backend) IsNewsEnabled(ctx context.Context, name string) (bool, error) {
+	name = utils.SanitizeRepo(name)
+	var disabled bool
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		disabled, err = d.store.GetRepoIsNewsDisabledByName(ctx, tx, name)
+		return err
+	}); err != nil {
+		return false, db.WrapError(err)
+	}
+
+	return !disabled, nil
+}
+
+// SetNewsEnabled adds the activity of a repository to, or removes it from,
+// the news.
+func (d *Backend) SetNewsEnabled(ctx context.Context, name string, enabled bool) error {
+	name = utils.SanitizeRepo(name)
+
+	// Delete cache
+	d.cache.Delete(name)
+
+	return db.WrapError(d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		return d.store.SetRepoIsNewsDisabledByName(ctx, tx, name, !enabled)
+	}))
+}
@@ -0,0 +1,31 @@
+package backend
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+)
+
+// RepoEvents returns up to limit events recorded for the event stream of a
+// repository after the event with ID afterID, oldest first.
+func (d *Backend) RepoEvents(ctx context.Context, repo proto.Repository, afterID int64, limit int) ([]models.RepoEvent, error) {
+	events, err := d.store.GetRepoEventsAfterID(ctx, d.db, repo.ID(), afterID, limit)
+	if err != nil {
+		return nil, db.WrapError(err)
+	}
+
+	return events, nil
+}
+
+// LatestRepoEventID returns the ID of the latest event recorded for the event
+// stream of a repository, zero if there's none.
+func (d *Backend) LatestRepoEventID(ctx context.Context, repo proto.Repository) (int64, error) {
+	id, err := d.store.GetLatestRepoEventID(ctx, d.db, repo.ID())
+	if err != nil {
+		return 0, db.WrapError(err)
+	}
+
+	return id, nil
+}
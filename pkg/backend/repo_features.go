@@ -0,0 +1,114 @@
+package backend
+
+import (
+	"context"
+	"errors"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/utils"
+)
+
+var (
+	// ErrIssuesDisabled is returned when using the issues of a repository that
+	// turned them off.
+	ErrIssuesDisabled = errors.New("issues are disabled for this repository")
+
+	// ErrMergeRequestsDisabled is returned when using the merge requests of a
+	// repository that turned them off.
+	ErrMergeRequestsDisabled = errors.New("merge requests are disabled for this repository")
+)
+
+// IsIssuesEnabled returns true if the repository uses issues.
+func (d *Backend) IsIssuesEnabled(ctx context.Context, name string) (bool, error) {
+	name = utils.SanitizeRepo(name)
+	var disabled bool
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		disabled, err = d.store.GetRepoIsIssuesDisabledByName(ctx, tx, name)
+		return err
+	}); err != nil {
+		return false, db.WrapError(err)
+	}
+
+	return !disabled, nil
+}
+
+// SetIssuesEnabled turns the issues of a repository on or off. Existing
+// issues are kept while they're off.
+func (d *Backend) SetIssuesEnabled(ctx context.Context, name string, enabled bool) error {
+	name = utils.SanitizeRepo(name)
+
+	// Delete cache
+	d.cache.Delete(name)
+
+	return db.WrapError(d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		return d.store.SetRepoIsIssuesDisabledByName(ctx, tx, name, !enabled)
+	}))
+}
+
+// IsMergeRequestsEnabled returns true if the repository uses merge requests.
+func (d *Backend) IsMergeRequestsEnabled(ctx context.Context, name string) (bool, error) {
+	name = utils.SanitizeRepo(name)
+	var disabled bool
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		disabled, err = d.store.GetRepoIsMergeRequestsDisabledByName(ctx, tx, name)
+		return err
+	}); err != nil {
+		return false, db.WrapError(err)
+	}
+
+	return !disabled, nil
+}
+
+// SetMergeRequestsEnabled turns the merge requests of a repository on or off.
+// Existing merge requests are kept while they're off.
+func (d *Backend) SetMergeRequestsEnabled(ctx context.Context, name string, enabled bool) error {
+	name = utils.SanitizeRepo(name)
+
+	// Delete cache
+	d.cache.Delete(name)
+
+	return db.WrapError(d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		return d.store.SetRepoIsMergeRequestsDisabledByName(ctx, tx, name, !enabled)
+	}))
+}
+
+// checkIssuesEnabled returns ErrIssuesDisabled if the repository turned its
+// issues off.
+func (d *Backend) checkIssuesEnabled(ctx context.Context, name string) error {
+	enabled, err := d.IsIssuesEnabled(ctx, name)
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		return ErrIssuesDisabled
+	}
+	return nil
+}
+
+// checkMergeRequestsEnabled returns ErrMergeRequestsDisabled if the
+// repository turned its merge requests off.
+func (d *Backend) checkMergeRequestsEnabled(ctx context.Context, name string) error {
+	enabled, err := d.IsMergeRequestsEnabled(ctx, name)
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		return ErrMergeRequestsDisabled
+	}
+	return nil
+}
+
+// enabledRepos returns the repositories of repos for which enabled, e.g.
+// d.IsIssuesEnabled, returns true.
+func (d *Backend) enabledRepos(ctx context.Context, repos map[int64]proto.Repository, enabled func(context.Context, string) (bool, error)) map[int64]proto.Repository {
+	filtered := make(map[int64]proto.Repository, len(repos))
+	for id, r := range repos {
+		if ok, err := enabled(ctx, r.Name()); err == nil && ok {
+			filtered[id] = r
+		}
+	}
+	return filtered
+}
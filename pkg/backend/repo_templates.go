@@ -0,0 +1,223 @@
+package backend
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	gitm "github.com/aymanbagabas/git-module"
+	"github.com/charmbracelet/soft-serve/git"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+)
+
+var (
+	// ErrUnknownLicense is returned when creating a repository with a license
+	// template that doesn't exist.
+	ErrUnknownLicense = errors.New("unknown license")
+
+	// ErrUnknownGitignore is returned when creating a repository with a
+	// .gitignore template that doesn't exist.
+	ErrUnknownGitignore = errors.New("unknown gitignore template")
+)
+
+// templates are the built-in license and .gitignore templates. Files in the
+// templates directory of the data path take precedence, and add to them.
+//
+//go:embed templates
+var templates embed.FS
+
+const (
+	licenseTemplates   = "licenses"
+	gitignoreTemplates = "gitignore"
+	gitignoreExt       = ".gitignore"
+)
+
+// repoFile is a file of the initial commit of a new repository.
+type repoFile struct {
+	name    string
+	content []byte
+}
+
+// Licenses returns the names of the available license templates, sorted.
+func (d *Backend) Licenses() []string {
+	return d.templateNames(licenseTemplates, "")
+}
+
+// Gitignores returns the names of the available .gitignore templates,
+// sorted.
+func (d *Backend) Gitignores() []string {
+	return d.templateNames(gitignoreTemplates, gitignoreExt)
+}
+
+// templateNames returns the names of the templates in dir, with ext
+// trimmed.
+func (d *Backend) templateNames(dir, ext string) []string {
+	seen := map[string]bool{}
+	var names []string
+	add := func(entries []fs.DirEntry) {
+		for _, e := range entries {
+			name, ok := strings.CutSuffix(e.Name(), ext)
+			if e.IsDir() || !ok || name == "" || seen[strings.ToLower(name)] {
+				continue
+			}
+			seen[strings.ToLower(name)] = true
+			names = append(names, name)
+		}
+	}
+
+	if entries, err := os.ReadDir(d.templatesPath(dir)); err == nil {
+		add(entries)
+	}
+	if entries, err := templates.ReadDir(path.Join("templates", dir)); err == nil {
+		add(entries)
+	}
+
+	sort.Slice(names, func(i, j int) bool {
+		return strings.ToLower(names[i]) < strings.ToLower(names[j])
+	})
+	return names
+}
+
+// templatesPath returns the directory of the custom templates in dir.
+func (d *Backend) templatesPath(dir string) string {
+	if d.cfg == nil {
+		return ""
+	}
+	return filepath.Join(d.cfg.DataPath, "templates", dir)
+}
+
+// readTemplate returns the content of the template name in dir. Names match
+// case-insensitively, e.g. "mit" is the MIT license.
+func (d *Backend) readTemplate(dir, ext, name string) ([]byte, bool) {
+	for _, n := range d.templateNames(dir, ext) {
+		if !strings.EqualFold(n, name) {
+			continue
+		}
+		if content, err := os.ReadFile(filepath.Join(d.templatesPath(dir), n+ext)); err == nil {
+			return content, true
+		}
+		if content, err := templates.ReadFile(path.Join("templates", dir, n+ext)); err == nil {
+			return content, true
+		}
+	}
+	return nil, false
+}
+
+// initialFiles returns the files of the initial commit of a new repository
+// created with opts, if any. The license holder is user, or the server name
+// when there is none.
+func (d *Backend) initialFiles(name string, user proto.User, opts proto.RepositoryOptions) ([]repoFile, error) {
+	var files []repoFile
+	if opts.Readme {
+		title := opts.ProjectName
+		if title == "" {
+			title = path.Base(name)
+		}
+		readme := "# " + title + "\n"
+		if opts.Description != "" {
+			readme += "\n" + opts.Description + "\n"
+		}
+		files = append(files, repoFile{name: "README.md", content: []byte(readme)})
+	}
+
+	if opts.License != "" {
+		content, ok := d.readTemplate(licenseTemplates, "", opts.License)
+		if !ok {
+			return nil, fmt.Errorf("%w %q, available licenses: %s", ErrUnknownLicense, opts.License, strings.Join(d.Licenses(), ", "))
+		}
+		content = bytes.ReplaceAll(content, []byte("[year]"), []byte(strconv.Itoa(time.Now().Year())))
+		content = bytes.ReplaceAll(content, []byte("[fullname]"), []byte(d.committerName(user)))
+		files = append(files, repoFile{name: "LICENSE", content: content})
+	}
+
+	if opts.Gitignore != "" {
+		content, ok := d.readTemplate(gitignoreTemplates, gitignoreExt, opts.Gitignore)
+		if !ok {
+			return nil, fmt.Errorf("%w %q, available templates: %s", ErrUnknownGitignore, opts.Gitignore, strings.Join(d.Gitignores(), ", "))
+		}
+		files = append(files, repoFile{name: ".gitignore", content: content})
+	}
+
+	return files, nil
+}
+
+// committerName returns the name the server commits as on behalf of user.
+func (d *Backend) committerName(user proto.User) string {
+	if user != nil {
+		return user.Username()
+	}
+	if d.cfg != nil && d.cfg.Name != "" {
+		return d.cfg.Name
+	}
+	return "Soft Serve"
+}
+
+// initRepository points HEAD of the new repository at rp to branch, and
+// commits files to it, if any. The commit isn't pushed, so no hooks run.
+func (d *Backend) initRepository(ctx context.Context, rp string, branch string, user proto.User, files []repoFile) error {
+	if branch != "" {
+		if err := validateRef(branch); err != nil {
+			return err
+		}
+		if _, err := d.command(ctx, "symbolic-ref", "HEAD", git.RefsHeads+branch).RunInDir(rp); err != nil {
+			return fmt.Errorf("failed to set default branch: %w", err)
+		}
+	}
+
+	if len(files) == 0 {
+		return nil
+	}
+
+	var tree bytes.Buffer
+	for _, f := range files {
+		var out bytes.Buffer
+		if err := d.command(ctx, "hash-object", "-w", "--stdin").RunInDirWithOptions(rp, gitm.RunInDirOptions{
+			Stdin:  bytes.NewReader(f.content),
+			Stdout: &out,
+		}); err != nil {
+			return fmt.Errorf("failed to write %s: %w", f.name, err)
+		}
+		fmt.Fprintf(&tree, "100644 blob %s\t%s\n", strings.TrimSpace(out.String()), f.name)
+	}
+
+	var out bytes.Buffer
+	if err := d.command(ctx, "mktree").RunInDirWithOptions(rp, gitm.RunInDirOptions{
+		Stdin:  &tree,
+		Stdout: &out,
+	}); err != nil {
+		return fmt.Errorf("failed to write initial tree: %w", err)
+	}
+
+	name := d.committerName(user)
+	commit, err := d.gitCommand(ctx, gitArgs("commit-tree", []string{"-m", "Initial commit"}, strings.TrimSpace(out.String()))...).
+		AddEnvs(
+			"GIT_AUTHOR_NAME="+name,
+			"GIT_AUTHOR_EMAIL=",
+			"GIT_COMMITTER_NAME="+name,
+			"GIT_COMMITTER_EMAIL=",
+		).
+		RunInDir(rp)
+	if err != nil {
+		return fmt.Errorf("failed to create initial commit: %w", err)
+	}
+
+	head, err := d.command(ctx, "symbolic-ref", "HEAD").RunInDir(rp)
+	if err != nil {
+		return fmt.Errorf("failed to read default branch: %w", err)
+	}
+	if _, err := d.command(ctx, "update-ref", strings.TrimSpace(string(head)), strings.TrimSpace(string(commit))).RunInDir(rp); err != nil {
+		return fmt.Errorf("failed to update default branch: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package backend
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/soft-serve/pkg/config"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+)
+
+func TestInitialFiles(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.DataPath = t.TempDir()
+	d := &Backend{cfg: cfg}
+
+	files, err := d.initialFiles("team/api", nil, proto.RepositoryOptions{})
+	if err != nil || len(files) != 0 {
+		t.Fatalf("expected no files, got %v, %v", files, err)
+	}
+
+	files, err = d.initialFiles("team/api", nil, proto.RepositoryOptions{
+		Description: "The API",
+		Readme:      true,
+		License:     "isc",
+		Gitignore:   "go",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 3 {
+		t.Fatalf("expected 3 files, got %d", len(files))
+	}
+	if got := string(files[0].content); got != "# api\n\nThe API\n" {
+		t.Errorf("unexpected README %q", got)
+	}
+	if got := string(files[1].content); !strings.HasPrefix(got, "ISC License") || strings.Contains(got, "[year]") || !strings.Contains(got, cfg.Name) {
+		t.Errorf("unexpected LICENSE %q", got)
+	}
+	if files[2].name != ".gitignore" {
+		t.Errorf("unexpected file %q", files[2].name)
+	}
+
+	if _, err := d.initialFiles("api", nil, proto.RepositoryOptions{License: "nope"}); !errors.Is(err, ErrUnknownLicense) {
+		t.Errorf("expected ErrUnknownLicense, got %v", err)
+	}
+	if _, err := d.initialFiles("api", nil, proto.RepositoryOptions{Gitignore: "nope"}); !errors.Is(err, ErrUnknownGitignore) {
+		t.Errorf("expected ErrUnknownGitignore, got %v", err)
+	}
+
+	// Custom templates take precedence over the built-in ones.
+	dir := filepath.Join(cfg.DataPath, "templates", "gitignore")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "Go.gitignore"), []byte("/bin\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	files, err = d.initialFiles("api", nil, proto.RepositoryOptions{Gitignore: "Go"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(files[0].content); got != "/bin\n" {
+		t.Errorf("expected the custom template, got %q", got)
+	}
+	if n := len(d.Gitignores()); n != 4 {
+		t.Errorf("expected 4 gitignore templates, got %d", n)
+	}
+}
@@ -0,0 +1,84 @@
+package backend
+
+import (
+	"context"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/access"
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/utils"
+)
+
+const (
+	// DefaultRecentRepos is the default number of recently visited
+	// repositories listed.
+	DefaultRecentRepos = 10
+
+	// maxRecentRepos is the maximum number of recently visited repositories
+	// listed.
+	maxRecentRepos = 100
+)
+
+// RecentRepository is a repository a user recently visited.
+type RecentRepository struct {
+	Repository proto.Repository
+	VisitedAt  time.Time
+}
+
+// RecordRepoVisit records that user visited a repository, e.g. opened it in
+// the TUI. Anonymous visits aren't recorded.
+func (d *Backend) RecordRepoVisit(ctx context.Context, repoName string, user proto.User) error {
+	repoName = utils.SanitizeRepo(repoName)
+	if user == nil {
+		return nil
+	}
+
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return err
+	}
+
+	return db.WrapError(d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		return d.store.SetRepoVisit(ctx, tx, r.ID(), user.ID(), time.Now().UTC())
+	}))
+}
+
+// RecentRepositories returns the last limit repositories user visited that
+// they can still read, most recent first.
+func (d *Backend) RecentRepositories(ctx context.Context, user proto.User, limit int) ([]RecentRepository, error) {
+	if user == nil {
+		return nil, proto.ErrUserNotFound
+	}
+	if limit < 1 {
+		limit = DefaultRecentRepos
+	}
+
+	vs, err := d.store.GetRepoVisitsByUserID(ctx, d.db, user.ID(), min(limit, maxRecentRepos))
+	if err != nil {
+		return nil, db.WrapError(err)
+	}
+
+	repos, err := d.Repositories(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[int64]proto.Repository, len(repos))
+	for _, r := range repos {
+		byID[r.ID()] = r
+	}
+
+	recent := make([]RecentRepository, 0, len(vs))
+	for _, v := range vs {
+		r, ok := byID[v.RepoID]
+		if !ok {
+			continue
+		}
+		if d.AccessLevelForUser(ctx, r.Name(), user) < access.ReadOnlyAccess {
+			continue
+		}
+		recent = append(recent, RecentRepository{Repository: r, VisitedAt: v.VisitedAt})
+	}
+
+	return recent, nil
+}
@@ -0,0 +1,79 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+// RetentionReport is the data deleted by the retention policies, or that
+// would be deleted on a dry run.
+type RetentionReport struct {
+	DryRun            bool  `json:"dry_run"`
+	ClosedIssues      int64 `json:"closed_issues"`
+	WebhookDeliveries int64 `json:"webhook_deliveries"`
+	AuditEvents       int64 `json:"audit_events"`
+}
+
+// errRetentionDryRun rolls back the transaction of a dry run.
+var errRetentionDryRun = errors.New("retention dry run")
+
+// ApplyRetention deletes the closed issues, webhook deliveries, and audit
+// events older than the retention policies of the configuration. On a dry
+// run, nothing is deleted and the report counts what would be.
+func (d *Backend) ApplyRetention(ctx context.Context, now time.Time, dryRun bool) (RetentionReport, error) {
+	cfg := d.cfg.Retention
+	report := RetentionReport{DryRun: dryRun}
+
+	err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		if days := cfg.ClosedIssueDays; days > 0 {
+			issues, err := d.store.GetIssuesClosedBefore(ctx, tx, now.AddDate(0, 0, -days))
+			if err != nil {
+				return err
+			}
+			for _, issue := range issues {
+				if err := d.store.DeleteIssue(ctx, tx, issue.RepoID, issue.ID); err != nil {
+					return err
+				}
+				if err := d.store.DeleteCrossReferencesBySource(ctx, tx, NotificationSubjectIssue, issue.ID); err != nil {
+					return err
+				}
+			}
+			report.ClosedIssues = int64(len(issues))
+		}
+
+		if days := cfg.WebhookDeliveryDays; days > 0 {
+			n, err := d.store.DeleteWebhookDeliveriesBefore(ctx, tx, now.AddDate(0, 0, -days))
+			if err != nil {
+				return err
+			}
+			report.WebhookDeliveries = n
+		}
+
+		if days := cfg.AuditEventDays; days > 0 {
+			n, err := d.store.DeleteAuditEventsBefore(ctx, tx, now.AddDate(0, 0, -days))
+			if err != nil {
+				return err
+			}
+			report.AuditEvents = n
+		}
+
+		if dryRun {
+			return errRetentionDryRun
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errRetentionDryRun) {
+		return RetentionReport{}, db.WrapError(err)
+	}
+
+	if !dryRun && report.ClosedIssues+report.WebhookDeliveries+report.AuditEvents > 0 {
+		d.audit(ctx, "retention.purge", "", fmt.Sprintf("%d closed issues, %d webhook deliveries, %d audit events",
+			report.ClosedIssues, report.WebhookDeliveries, report.AuditEvents))
+	}
+
+	return report, nil
+}
@@ -0,0 +1,178 @@
+package backend
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/access"
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/utils"
+)
+
+// MergeRequestReviewTimes are the review latencies of a merge request.
+type MergeRequestReviewTimes struct {
+	Repository   proto.Repository
+	MergeRequest models.MergeRequest
+	// TimeToFirstReview is how long the merge request waited for its first
+	// review, zero if it wasn't reviewed yet.
+	TimeToFirstReview time.Duration
+	// TimeToMerge is how long the merge request took to merge, zero if it
+	// wasn't merged.
+	TimeToMerge time.Duration
+}
+
+// ReviewStats are the review latencies of the merge requests of a repository,
+// or of a set of repositories.
+type ReviewStats struct {
+	// Repository is the name of the repository, empty for totals.
+	Repository    string
+	MergeRequests int
+	Reviewed      int
+	// Awaiting is the number of open merge requests without a review.
+	Awaiting int
+	Merged   int
+
+	MedianTimeToFirstReview time.Duration
+	P90TimeToFirstReview    time.Duration
+	MedianTimeToMerge       time.Duration
+	P90TimeToMerge          time.Duration
+}
+
+// ReviewStatsReport is the review latency report of a set of repositories.
+type ReviewStatsReport struct {
+	// Repositories are the stats of each repository with merge requests, by
+	// name.
+	Repositories []ReviewStats
+	// Total are the stats of all the merge requests.
+	Total ReviewStats
+	// MergeRequests are the merge requests of the report, oldest first.
+	MergeRequests []MergeRequestReviewTimes
+}
+
+// ReviewStats returns the time to first review and time to merge of the merge
+// requests opened since a time. The first review of a merge request is the
+// first edit, merge, close, or merge queue entry by a user other than its
+// author. scope is the name of a repository, or a path the repositories are
+// nested under, e.g. "myteam" for "myteam/api" and "myteam/web". An empty
+// scope reports on all the repositories user can read.
+func (d *Backend) ReviewStats(ctx context.Context, user proto.User, scope string, since time.Time) (ReviewStatsReport, error) {
+	scope = utils.SanitizeRepo(scope)
+
+	all, err := d.Repositories(ctx)
+	if err != nil {
+		return ReviewStatsReport{}, err
+	}
+
+	var repos []proto.Repository
+	for _, r := range all {
+		if scope != "" && r.Name() != scope && !strings.HasPrefix(r.Name(), scope+"/") {
+			continue
+		}
+		if d.AccessLevelForUser(ctx, r.Name(), user) < access.ReadOnlyAccess {
+			continue
+		}
+		repos = append(repos, r)
+	}
+	if scope != "" && len(repos) == 0 {
+		return ReviewStatsReport{}, proto.ErrRepoNotFound
+	}
+
+	var times []MergeRequestReviewTimes
+	for _, r := range repos {
+		mrs, err := d.store.GetMergeRequestsByRepoID(ctx, d.db, r.ID(), models.ListOrder{}, 0, 0)
+		if err != nil {
+			return ReviewStatsReport{}, db.WrapError(err)
+		}
+		for _, mr := range mrs {
+			if mr.CreatedAt.Before(since) {
+				continue
+			}
+			times = append(times, mergeRequestReviewTimes(r, mr))
+		}
+	}
+
+	return reviewStatsReport(times), nil
+}
+
+// mergeRequestReviewTimes returns the review latencies of a merge request.
+func mergeRequestReviewTimes(r proto.Repository, mr models.MergeRequest) MergeRequestReviewTimes {
+	t := MergeRequestReviewTimes{Repository: r, MergeRequest: mr}
+	if mr.ReviewedAt.Valid {
+		t.TimeToFirstReview = max(mr.ReviewedAt.Time.Sub(mr.CreatedAt), 0)
+	}
+	if mr.State == models.MergeRequestStateMerged && mr.MergedAt.Valid {
+		t.TimeToMerge = max(mr.MergedAt.Time.Sub(mr.CreatedAt), 0)
+	}
+	return t
+}
+
+// reviewStatsReport aggregates the review latencies of merge requests per
+// repository.
+func reviewStatsReport(times []MergeRequestReviewTimes) ReviewStatsReport {
+	sort.Slice(times, func(i, j int) bool {
+		a, b := times[i].MergeRequest, times[j].MergeRequest
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.Before(b.CreatedAt)
+		}
+		return a.ID < b.ID
+	})
+
+	byRepo := map[string][]MergeRequestReviewTimes{}
+	for _, t := range times {
+		byRepo[t.Repository.Name()] = append(byRepo[t.Repository.Name()], t)
+	}
+
+	report := ReviewStatsReport{
+		Total:         reviewStats("", times),
+		MergeRequests: times,
+	}
+	for name, ts := range byRepo {
+		report.Repositories = append(report.Repositories, reviewStats(name, ts))
+	}
+	sort.Slice(report.Repositories, func(i, j int) bool {
+		return report.Repositories[i].Repository < report.Repositories[j].Repository
+	})
+
+	return report
+}
+
+// reviewStats aggregates the review latencies of merge requests.
+func reviewStats(name string, times []MergeRequestReviewTimes) ReviewStats {
+	stats := ReviewStats{Repository: name, MergeRequests: len(times)}
+	var reviews, merges []time.Duration
+	for _, t := range times {
+		if t.MergeRequest.ReviewedAt.Valid {
+			stats.Reviewed++
+			reviews = append(reviews, t.TimeToFirstReview)
+		} else if t.MergeRequest.State == models.MergeRequestStateOpen {
+			stats.Awaiting++
+		}
+		if t.MergeRequest.State == models.MergeRequestStateMerged && t.MergeRequest.MergedAt.Valid {
+			stats.Merged++
+			merges = append(merges, t.TimeToMerge)
+		}
+	}
+
+	stats.MedianTimeToFirstReview = percentile(reviews, 50)
+	stats.P90TimeToFirstReview = percentile(reviews, 90)
+	stats.MedianTimeToMerge = percentile(merges, 50)
+	stats.P90TimeToMerge = percentile(merges, 90)
+
+	return stats
+}
+
+// percentile returns the nearest-rank p-th percentile of durations, zero if
+// there are none.
+func percentile(ds []time.Duration, p int) time.Duration {
+	if len(ds) == 0 {
+		return 0
+	}
+	sorted := append([]time.Duration(nil), ds...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	rank := (p*len(sorted) + 99) / 100
+	return sorted[max(rank, 1)-1]
+}
@@ -0,0 +1,65 @@
+package backend
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+)
+
+func TestPercentile(t *testing.T) {
+	ds := []time.Duration{5, 1, 4, 2, 3, 10, 7, 6, 9, 8}
+	cases := []struct {
+		p    int
+		want time.Duration
+	}{
+		{0, 1},
+		{50, 5},
+		{90, 9},
+		{100, 10},
+	}
+	for _, c := range cases {
+		if got := percentile(ds, c.p); got != c.want {
+			t.Errorf("percentile(%d) = %d, want %d", c.p, got, c.want)
+		}
+	}
+	if got := percentile(nil, 50); got != 0 {
+		t.Errorf("percentile(nil) = %d, want 0", got)
+	}
+}
+
+func TestReviewStats(t *testing.T) {
+	created := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) sql.NullTime {
+		return sql.NullTime{Time: created.Add(d), Valid: true}
+	}
+	mrs := []models.MergeRequest{
+		{State: models.MergeRequestStateMerged, CreatedAt: created, ReviewedAt: at(time.Hour), MergedAt: at(4 * time.Hour)},
+		{State: models.MergeRequestStateMerged, CreatedAt: created, ReviewedAt: at(3 * time.Hour), MergedAt: at(8 * time.Hour)},
+		{State: models.MergeRequestStateClosed, CreatedAt: created, ReviewedAt: at(2 * time.Hour)},
+		{State: models.MergeRequestStateOpen, CreatedAt: created},
+		{State: models.MergeRequestStateClosed, CreatedAt: created},
+	}
+
+	var times []MergeRequestReviewTimes
+	for _, mr := range mrs {
+		times = append(times, mergeRequestReviewTimes(nil, mr))
+	}
+
+	stats := reviewStats("repo1", times)
+	want := ReviewStats{
+		Repository:              "repo1",
+		MergeRequests:           5,
+		Reviewed:                3,
+		Awaiting:                1,
+		Merged:                  2,
+		MedianTimeToFirstReview: 2 * time.Hour,
+		P90TimeToFirstReview:    3 * time.Hour,
+		MedianTimeToMerge:       4 * time.Hour,
+		P90TimeToMerge:          8 * time.Hour,
+	}
+	if stats != want {
+		t.Errorf("reviewStats() = %+v, want %+v", stats, want)
+	}
+}
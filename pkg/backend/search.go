@@ -0,0 +1,159 @@
+package backend
+
+import (
+	"context"
+	"strings"
+
+	"github.com/charmbracelet/soft-serve/pkg/access"
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/utils"
+)
+
+const (
+	// DefaultSearchPerPage is the default number of search results per page.
+	DefaultSearchPerPage = 20
+	// MaxSearchPerPage is the maximum number of search results per page.
+	MaxSearchPerPage = 100
+)
+
+// SearchOptions are options for searching issues and merge requests across
+// repositories.
+type SearchOptions struct {
+	// Query is matched case-insensitively against titles and descriptions.
+	Query string
+	// Org limits the search to the repositories nested under it, e.g. "myteam"
+	// matches "myteam/api" and "myteam/web".
+	Org string
+	// Author limits the search to the issues or merge requests of a user.
+	Author string
+	// Page is the 1-based page number.
+	Page int
+	// PerPage is the number of results per page.
+	PerPage int
+}
+
+// IssueSearchResult is an issue found by a search.
+type IssueSearchResult struct {
+	Repository proto.Repository
+	Issue      models.Issue
+}
+
+// MergeRequestSearchResult is a merge request found by a search.
+type MergeRequestSearchResult struct {
+	Repository   proto.Repository
+	MergeRequest models.MergeRequest
+}
+
+// SearchIssues searches the issues of the listed repositories user can read,
+// or is a guest of.
+func (d *Backend) SearchIssues(ctx context.Context, user proto.User, opts SearchOptions, state *models.IssueState) ([]IssueSearchResult, error) {
+	repos, authorID, err := d.searchScope(ctx, user, &opts)
+	if err != nil {
+		return nil, err
+	}
+	repos = d.enabledRepos(ctx, repos, d.IsIssuesEnabled)
+
+	var viewerID int64
+	if user != nil {
+		viewerID = user.ID()
+	}
+
+	var issues []models.Issue
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		issues, err = d.store.SearchIssues(ctx, tx, repoIDs(repos), opts.Query, state, authorID, viewerID,
+			opts.PerPage, (opts.Page-1)*opts.PerPage)
+		return err
+	}); err != nil {
+		return nil, db.WrapError(err)
+	}
+
+	results := make([]IssueSearchResult, len(issues))
+	for i, issue := range issues {
+		results[i] = IssueSearchResult{Repository: repos[issue.RepoID], Issue: issue}
+	}
+
+	return results, nil
+}
+
+// SearchMergeRequests searches the merge requests of the listed repositories
+// user can read, or is a guest of.
+func (d *Backend) SearchMergeRequests(ctx context.Context, user proto.User, opts SearchOptions, state *models.MergeRequestState) ([]MergeRequestSearchResult, error) {
+	repos, authorID, err := d.searchScope(ctx, user, &opts)
+	if err != nil {
+		return nil, err
+	}
+	repos = d.enabledRepos(ctx, repos, d.IsMergeRequestsEnabled)
+
+	var mrs []models.MergeRequest
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		mrs, err = d.store.SearchMergeRequests(ctx, tx, repoIDs(repos), opts.Query, state, authorID,
+			opts.PerPage, (opts.Page-1)*opts.PerPage)
+		return err
+	}); err != nil {
+		return nil, db.WrapError(err)
+	}
+
+	results := make([]MergeRequestSearchResult, len(mrs))
+	for i, mr := range mrs {
+		results[i] = MergeRequestSearchResult{Repository: repos[mr.RepoID], MergeRequest: mr}
+	}
+
+	return results, nil
+}
+
+// searchScope normalizes the search options and returns the repositories to
+// search by ID, and the ID of the author to filter by, if any. Hidden
+// repositories, and those that turned search off, are left out.
+func (d *Backend) searchScope(ctx context.Context, user proto.User, opts *SearchOptions) (map[int64]proto.Repository, int64, error) {
+	opts.Query = strings.TrimSpace(opts.Query)
+	opts.Org = utils.SanitizeRepo(opts.Org)
+	if opts.Page < 1 {
+		opts.Page = 1
+	}
+	if opts.PerPage < 1 {
+		opts.PerPage = DefaultSearchPerPage
+	}
+	if opts.PerPage > MaxSearchPerPage {
+		opts.PerPage = MaxSearchPerPage
+	}
+
+	var authorID int64
+	if opts.Author != "" {
+		author, err := d.User(ctx, opts.Author)
+		if err != nil {
+			return nil, 0, err
+		}
+		authorID = author.ID()
+	}
+
+	all, err := d.Repositories(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	repos := make(map[int64]proto.Repository)
+	for _, r := range all {
+		if opts.Org != "" && !strings.HasPrefix(r.Name(), opts.Org+"/") {
+			continue
+		}
+		if r.IsHidden() || d.AccessLevelForUser(ctx, r.Name(), user) < access.GuestAccess {
+			continue
+		}
+		repos[r.ID()] = r
+	}
+
+	return d.enabledRepos(ctx, repos, d.IsSearchEnabled), authorID, nil
+}
+
+func repoIDs(repos map[int64]proto.Repository) []int64 {
+	ids := make([]int64, 0, len(repos))
+	for id := range repos {
+		ids = append(ids, id)
+	}
+
+	return ids
+}
@@ -0,0 +1,143 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+)
+
+// ErrSessionNotFound is returned when a session is not found.
+var ErrSessionNotFound = errors.New("session not found")
+
+// Session is an active SSH TUI session.
+type Session struct {
+	ID int64
+	// UserID and Username are zero for anonymous sessions.
+	UserID     int64
+	Username   string
+	RemoteAddr string
+	StartedAt  time.Time
+
+	close func()
+}
+
+// sessions keeps track of the active sessions of the server. The zero value
+// is ready to use.
+type sessions struct {
+	mu   sync.Mutex
+	last int64
+	m    map[int64]*Session
+}
+
+// add starts tracking s and returns its ID.
+func (ss *sessions) add(s Session) int64 {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	if ss.m == nil {
+		ss.m = make(map[int64]*Session)
+	}
+	ss.last++
+	s.ID = ss.last
+	ss.m[s.ID] = &s
+	return s.ID
+}
+
+// remove stops tracking the session with the given ID and returns it.
+func (ss *sessions) remove(id int64) (*Session, bool) {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	s, ok := ss.m[id]
+	delete(ss.m, id)
+	return s, ok
+}
+
+// list returns the sessions of the user with the given ID, or all of them
+// when userID is negative, sorted by ID.
+func (ss *sessions) list(userID int64) []Session {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	var list []Session
+	for _, s := range ss.m {
+		if userID < 0 || s.UserID == userID {
+			list = append(list, *s)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].ID < list[j].ID
+	})
+	return list
+}
+
+// StartSession tracks a new session of user from addr. user is nil for
+// anonymous sessions. close terminates the session when it's revoked. The
+// returned function must be called when the session ends.
+func (d *Backend) StartSession(user proto.User, addr string, close func()) func() {
+	s := Session{
+		RemoteAddr: addr,
+		StartedAt:  time.Now(),
+		close:      close,
+	}
+	if user != nil {
+		s.UserID = user.ID()
+		s.Username = user.Username()
+	}
+
+	id := d.sessions.add(s)
+	return func() {
+		d.sessions.remove(id)
+	}
+}
+
+// Sessions returns the active sessions of user, or of every user when user is
+// nil.
+func (d *Backend) Sessions(user proto.User) []Session {
+	if user == nil {
+		return d.sessions.list(-1)
+	}
+	return d.sessions.list(user.ID())
+}
+
+// RevokeSession terminates the session with the given ID. Unless user is nil,
+// the session must belong to user.
+func (d *Backend) RevokeSession(ctx context.Context, user proto.User, id int64) error {
+	var s *Session
+	for _, ls := range d.Sessions(user) {
+		if ls.ID == id {
+			s, _ = d.sessions.remove(id)
+			break
+		}
+	}
+	if s == nil {
+		return ErrSessionNotFound
+	}
+
+	s.close()
+	d.audit(ctx, "session.revoke", s.Username, fmt.Sprintf("session %d from %s", s.ID, s.RemoteAddr))
+	return nil
+}
+
+// RevokeUserSessions terminates all the sessions of the user with the given
+// username, e.g. after their credentials leaked, and returns how many were
+// terminated.
+func (d *Backend) RevokeUserSessions(ctx context.Context, username string) (int, error) {
+	user, err := d.User(ctx, username)
+	if err != nil {
+		return 0, err
+	}
+
+	var n int
+	for _, ls := range d.Sessions(user) {
+		if s, ok := d.sessions.remove(ls.ID); ok {
+			s.close()
+			n++
+		}
+	}
+
+	d.audit(ctx, "session.revoke_all", user.Username(), fmt.Sprintf("%d sessions", n))
+	return n, nil
+}
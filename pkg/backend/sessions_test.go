@@ -0,0 +1,27 @@
+package backend
+
+import "testing"
+
+func TestSessions(t *testing.T) {
+	var ss sessions
+	a := ss.add(Session{UserID: 1, Username: "a"})
+	b := ss.add(Session{UserID: 2, Username: "b"})
+	c := ss.add(Session{UserID: 1, Username: "a"})
+
+	if got := ss.list(-1); len(got) != 3 || got[0].ID != a || got[2].ID != c {
+		t.Fatalf("list(-1) = %v, want sessions %d, %d, %d", got, a, b, c)
+	}
+	if got := ss.list(1); len(got) != 2 {
+		t.Fatalf("list(1) = %v, want 2 sessions", got)
+	}
+
+	if s, ok := ss.remove(a); !ok || s.Username != "a" {
+		t.Fatalf("remove(%d) = %v, %t", a, s, ok)
+	}
+	if _, ok := ss.remove(a); ok {
+		t.Fatalf("remove(%d) removed a session twice", a)
+	}
+	if got := ss.list(1); len(got) != 1 || got[0].ID != c {
+		t.Fatalf("list(1) = %v, want session %d", got, c)
+	}
+}
@@ -0,0 +1,35 @@
+package backend
+
+import (
+	"context"
+
+	gitm "github.com/aymanbagabas/git-module"
+)
+
+// gitCommand returns a git command that signs the commits it creates with the
+// server signing key, if one is configured. See command.
+func (d *Backend) gitCommand(ctx context.Context, args ...string) *gitm.Command {
+	return d.command(ctx, append(d.signingArgs(), args...)...)
+}
+
+// signingArgs returns the git options to sign commits with the server signing
+// key.
+func (d *Backend) signingArgs() []string {
+	if d.cfg == nil || d.cfg.Signing.Format == "" {
+		return nil
+	}
+
+	key := d.cfg.Signing.Key
+	if key == "" && d.cfg.Signing.Format == "ssh" {
+		key = d.cfg.SSH.KeyPath
+	}
+
+	args := []string{
+		"-c", "commit.gpgSign=true",
+		"-c", "gpg.format=" + d.cfg.Signing.Format,
+	}
+	if key != "" {
+		args = append(args, "-c", "user.signingKey="+key)
+	}
+	return args
+}
@@ -0,0 +1,43 @@
+package backend
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/soft-serve/pkg/config"
+	"github.com/matryer/is"
+)
+
+func TestSigningArgs(t *testing.T) {
+	cases := []struct {
+		name    string
+		signing config.SigningConfig
+		want    []string
+	}{
+		{"disabled", config.SigningConfig{}, nil},
+		{"ssh server key", config.SigningConfig{Format: "ssh"}, []string{
+			"-c", "commit.gpgSign=true",
+			"-c", "gpg.format=ssh",
+			"-c", "user.signingKey=/data/ssh/host_key",
+		}},
+		{"ssh key", config.SigningConfig{Format: "ssh", Key: "/data/signing_key"}, []string{
+			"-c", "commit.gpgSign=true",
+			"-c", "gpg.format=ssh",
+			"-c", "user.signingKey=/data/signing_key",
+		}},
+		{"default gpg key", config.SigningConfig{Format: "openpgp"}, []string{
+			"-c", "commit.gpgSign=true",
+			"-c", "gpg.format=openpgp",
+		}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			is := is.New(t)
+			cfg := config.DefaultConfig()
+			cfg.SSH.KeyPath = "/data/ssh/host_key"
+			cfg.Signing = c.signing
+			d := &Backend{cfg: cfg}
+			is.Equal(d.signingArgs(), c.want)
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package backend
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/utils"
+	"github.com/charmbracelet/soft-serve/pkg/webhook"
+)
+
+// StalePolicy is the policy for marking inactive issues and merge requests
+// stale and closing them.
+type StalePolicy struct {
+	// StaleAfter is how long an open issue or merge request can go without
+	// activity before it is marked stale.
+	StaleAfter time.Duration
+	// CloseAfter is how long a stale issue or merge request is kept open
+	// without activity before it is closed. Zero never closes it.
+	CloseAfter time.Duration
+}
+
+// staleAction is what the stale policy does with an open issue or merge
+// request.
+type staleAction int
+
+const (
+	staleActionNone staleAction = iota
+	staleActionMark
+	staleActionUnmark
+	staleActionClose
+)
+
+// action returns what to do with an open issue or merge request last updated
+// at updatedAt and marked stale at staleAt, if any.
+func (p StalePolicy) action(staleAt sql.NullTime, updatedAt time.Time, now time.Time) staleAction {
+	switch {
+	case staleAt.Valid && updatedAt.After(staleAt.Time):
+		// Activity resumed after it was marked stale.
+		return staleActionUnmark
+	case staleAt.Valid:
+		if p.CloseAfter > 0 && now.Sub(staleAt.Time) >= p.CloseAfter {
+			return staleActionClose
+		}
+	case p.StaleAfter > 0 && now.Sub(updatedAt) >= p.StaleAfter:
+		return staleActionMark
+	}
+
+	return staleActionNone
+}
+
+// IsStaleExempt returns true if the repository opted out of the stale policy.
+func (d *Backend) IsStaleExempt(ctx context.Context, name string) (bool, error) {
+	name = utils.SanitizeRepo(name)
+	var exempt bool
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		exempt, err = d.store.GetRepoIsStaleExemptByName(ctx, tx, name)
+		return err
+	}); err != nil {
+		return false, db.WrapError(err)
+	}
+
+	return exempt, nil
+}
+
+// SetStaleExempt opts a repository out of the stale policy, or back in.
+func (d *Backend) SetStaleExempt(ctx context.Context, name string, exempt bool) error {
+	name = utils.SanitizeRepo(name)
+
+	// Delete cache
+	d.cache.Delete(name)
+
+	return db.WrapError(d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		return d.store.SetRepoIsStaleExemptByName(ctx, tx, name, exempt)
+	}))
+}
+
+// ApplyStalePolicy marks the open issues and merge requests without recent
+// activity stale, and closes the ones that stayed stale for too long. Issues
+// and merge requests with new activity since they were marked stale are
+// unmarked. Repositories that opted out are skipped, and so are the issues or
+// merge requests of repositories that turned them off.
+func (d *Backend) ApplyStalePolicy(ctx context.Context, policy StalePolicy, now time.Time) error {
+	repos, err := d.Repositories(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range repos {
+		exempt, err := d.IsStaleExempt(ctx, r.Name())
+		if err != nil {
+			return err
+		}
+		if exempt {
+			continue
+		}
+
+		if enabled, _ := d.IsIssuesEnabled(ctx, r.Name()); enabled {
+			if err := d.applyStalePolicyToIssues(ctx, r, policy, now); err != nil {
+				d.logger.Error("error applying stale policy to issues", "repo", r.Name(), "err", err)
+			}
+		}
+		if enabled, _ := d.IsMergeRequestsEnabled(ctx, r.Name()); enabled {
+			if err := d.applyStalePolicyToMergeRequests(ctx, r, policy, now); err != nil {
+				d.logger.Error("error applying stale policy to merge requests", "repo", r.Name(), "err", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (d *Backend) applyStalePolicyToIssues(ctx context.Context, r proto.Repository, policy StalePolicy, now time.Time) error {
+	issues, err := d.store.GetIssuesByRepoIDAndState(ctx, d.db, r.ID(), models.IssueStateOpen, models.ListOrder{}, 0, 0)
+	if err != nil {
+		return db.WrapError(err)
+	}
+
+	for _, issue := range issues {
+		if issue.Draft {
+			continue
+		}
+		var event webhook.IssueEventAction
+		if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+			switch policy.action(issue.StaleAt, issue.UpdatedAt, now) {
+			case staleActionMark:
+				event = webhook.IssueEventActionStale
+				return d.store.SetIssueStale(ctx, tx, r.ID(), issue.ID, true)
+			case staleActionUnmark:
+				return d.store.SetIssueStale(ctx, tx, r.ID(), issue.ID, false)
+			case staleActionClose:
+				event = webhook.IssueEventActionClosed
+				return d.store.CloseStaleIssue(ctx, tx, r.ID(), issue.ID)
+			}
+			return nil
+		}); err != nil {
+			return db.WrapError(err)
+		}
+
+		if event != "" {
+			d.sendIssueEvent(ctx, r, issue.ID, event)
+		}
+	}
+
+	return nil
+}
+
+func (d *Backend) applyStalePolicyToMergeRequests(ctx context.Context, r proto.Repository, policy StalePolicy, now time.Time) error {
+	mrs, err := d.store.GetMergeRequestsByRepoIDAndState(ctx, d.db, r.ID(), models.MergeRequestStateOpen, models.ListOrder{}, 0, 0)
+	if err != nil {
+		return db.WrapError(err)
+	}
+
+	for _, mr := range mrs {
+		var event webhook.MergeRequestEventAction
+		if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+			switch policy.action(mr.StaleAt, mr.UpdatedAt, now) {
+			case staleActionMark:
+				event = webhook.MergeRequestEventActionStale
+				return d.store.SetMergeRequestStale(ctx, tx, r.ID(), mr.ID, true)
+			case staleActionUnmark:
+				return d.store.SetMergeRequestStale(ctx, tx, r.ID(), mr.ID, false)
+			case staleActionClose:
+				event = webhook.MergeRequestEventActionClosed
+				return d.store.CloseStaleMergeRequest(ctx, tx, r.ID(), mr.ID)
+			}
+			return nil
+		}); err != nil {
+			return db.WrapError(err)
+		}
+
+		if event != "" {
+			d.sendMergeRequestEvent(ctx, r, mr.ID, event)
+		}
+	}
+
+	return nil
+}
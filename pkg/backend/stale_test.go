@@ -0,0 +1,39 @@
+package backend
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+)
+
+func TestStalePolicyAction(t *testing.T) {
+	day := 24 * time.Hour
+	now := time.Date(2024, 6, 30, 12, 0, 0, 0, time.UTC)
+	policy := StalePolicy{StaleAfter: 30 * day, CloseAfter: 7 * day}
+	stale := func(d time.Duration) sql.NullTime {
+		return sql.NullTime{Time: now.Add(-d), Valid: true}
+	}
+
+	cases := []struct {
+		name      string
+		policy    StalePolicy
+		staleAt   sql.NullTime
+		updatedAt time.Time
+		want      staleAction
+	}{
+		{"active", policy, sql.NullTime{}, now.Add(-day), staleActionNone},
+		{"inactive", policy, sql.NullTime{}, now.Add(-30 * day), staleActionMark},
+		{"recently stale", policy, stale(day), now.Add(-31 * day), staleActionNone},
+		{"stale for too long", policy, stale(7 * day), now.Add(-37 * day), staleActionClose},
+		{"never closed", StalePolicy{StaleAfter: 30 * day}, stale(60 * day), now.Add(-90 * day), staleActionNone},
+		{"activity resumed", policy, stale(7 * day), now.Add(-day), staleActionUnmark},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := c.policy.action(c.staleAt, c.updatedAt, now); got != c.want {
+				t.Errorf("action() = %d, want %d", got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package backend
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+// DefaultStatsTop is the default number of repositories listed by the
+// rankings of the instance statistics.
+const DefaultStatsTop = 10
+
+// StatsOptions are options for gathering the statistics of the instance.
+type StatsOptions struct {
+	// Months is the number of months of growth reported, the current one
+	// included.
+	Months int
+	// Top is the number of repositories of the storage and activity
+	// rankings.
+	Top int
+}
+
+// InstanceStats are the statistics of the instance, for capacity planning.
+type InstanceStats struct {
+	Time          time.Time `json:"time"`
+	Repositories  int64     `json:"repositories"`
+	Users         int64     `json:"users"`
+	Issues        int64     `json:"issues"`
+	MergeRequests int64     `json:"merge_requests"`
+	// DiskUsage is the size of the repositories, in bytes.
+	DiskUsage int64 `json:"disk_usage"`
+	// Storage are the largest repositories, largest first.
+	Storage []RepoStorage `json:"storage"`
+	// Growth is what was created each month, oldest first.
+	Growth []StatsGrowth `json:"growth"`
+	// Busiest are the repositories with the most activity over the last 30
+	// days, busiest first.
+	Busiest []RepoActivity `json:"busiest"`
+}
+
+// RepoStorage is the size of a repository on disk.
+type RepoStorage struct {
+	Repository string `json:"repository"`
+	Size       int64  `json:"size"`
+}
+
+// StatsGrowth is the number of repositories, users, issues, and merge
+// requests created in a month.
+type StatsGrowth struct {
+	// Month is the month, e.g. "2024-05".
+	Month         string `json:"month"`
+	Repositories  int64  `json:"repositories"`
+	Users         int64  `json:"users"`
+	Issues        int64  `json:"issues"`
+	MergeRequests int64  `json:"merge_requests"`
+}
+
+// RepoActivity is the number of activities of a repository, see News.
+type RepoActivity struct {
+	Repository string `json:"repository"`
+	Activities int    `json:"activities"`
+}
+
+// busiestWindow is how far back the activity of the busiest repositories is
+// counted.
+const busiestWindow = 30 * 24 * time.Hour
+
+// InstanceStats returns the statistics of the instance. Walking every
+// repository to measure its size, it can be slow on large instances.
+func (d *Backend) InstanceStats(ctx context.Context, opts StatsOptions) (InstanceStats, error) {
+	if opts.Top <= 0 {
+		opts.Top = DefaultStatsTop
+	}
+	now := time.Now()
+	stats := InstanceStats{Time: now}
+
+	counts, err := d.store.GetInstanceCounts(ctx, d.db, time.Time{}, time.Time{})
+	if err != nil {
+		return stats, db.WrapError(err)
+	}
+	stats.Repositories = counts.Repos
+	stats.Users = counts.Users
+	stats.Issues = counts.Issues
+	stats.MergeRequests = counts.MergeRequests
+
+	month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	for i := opts.Months - 1; i >= 0; i-- {
+		since := month.AddDate(0, -i, 0)
+		counts, err := d.store.GetInstanceCounts(ctx, d.db, since, since.AddDate(0, 1, 0))
+		if err != nil {
+			return stats, db.WrapError(err)
+		}
+		stats.Growth = append(stats.Growth, StatsGrowth{
+			Month:         since.Format("2006-01"),
+			Repositories:  counts.Repos,
+			Users:         counts.Users,
+			Issues:        counts.Issues,
+			MergeRequests: counts.MergeRequests,
+		})
+	}
+
+	repos, err := d.Repositories(ctx)
+	if err != nil {
+		return stats, err
+	}
+	names := make(map[int64]string, len(repos))
+	for _, r := range repos {
+		names[r.ID()] = r.Name()
+		size := dirSize(r.(*repo).path)
+		if size < 0 {
+			continue
+		}
+		stats.DiskUsage += size
+		stats.Storage = append(stats.Storage, RepoStorage{Repository: r.Name(), Size: size})
+	}
+	sort.SliceStable(stats.Storage, func(i, j int) bool {
+		return stats.Storage[i].Size > stats.Storage[j].Size
+	})
+	if len(stats.Storage) > opts.Top {
+		stats.Storage = stats.Storage[:opts.Top]
+	}
+
+	busiest, err := d.store.GetRepoActivityCounts(ctx, d.db, now.Add(-busiestWindow), opts.Top)
+	if err != nil {
+		return stats, db.WrapError(err)
+	}
+	for _, c := range busiest {
+		if name, ok := names[c.RepoID]; ok {
+			stats.Busiest = append(stats.Busiest, RepoActivity{Repository: name, Activities: c.Count})
+		}
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,216 @@
+package backend
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/storage"
+	"github.com/charmbracelet/soft-serve/pkg/utils"
+)
+
+// Relocation is a move of a repository to another location.
+type Relocation struct {
+	Repo string
+	From storage.Location
+	To   storage.Location
+}
+
+// repoLocation returns the location of a repository.
+func repoLocation(m models.Repo) storage.Location {
+	return storage.Location{Volume: m.StorageVolume, Path: m.StoragePath}
+}
+
+// RepositoryPath returns the directory of a repository.
+func (d *Backend) RepositoryPath(ctx context.Context, name string) (string, error) {
+	r, err := d.Repository(ctx, name)
+	if err != nil {
+		return "", err
+	}
+
+	return r.(*repo).path, nil
+}
+
+// placeRepository returns the location of a new repository, on the volume
+// holding the fewest repositories.
+func (d *Backend) placeRepository(ctx context.Context, name string) (storage.Location, error) {
+	counts, err := d.store.CountReposByStorageVolume(ctx, d.db)
+	if err != nil {
+		return storage.Location{}, db.WrapError(err)
+	}
+
+	return d.repos.Place(leastUsedVolume(d.repos.Volumes(), counts), name), nil
+}
+
+// leastUsedVolume returns the volume holding the fewest repositories, the
+// first one listed on ties.
+func leastUsedVolume(volumes []string, counts map[string]int64) string {
+	var volume string
+	for _, v := range volumes {
+		if volume == "" || counts[v] < counts[volume] {
+			volume = v
+		}
+	}
+	return volume
+}
+
+// PlanRelocation returns the relocation of a repository to volume, or to the
+// volume holding the fewest repositories when volume is empty. The
+// repository stays in place when it's already there.
+func (d *Backend) PlanRelocation(ctx context.Context, name string, volume string) (Relocation, error) {
+	name = utils.SanitizeRepo(name)
+	var m models.Repo
+	var counts map[string]int64
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		m, err = d.store.GetRepoByName(ctx, tx, name)
+		if err != nil {
+			return err
+		}
+		counts, err = d.store.CountReposByStorageVolume(ctx, tx)
+		return err
+	}); err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return Relocation{}, proto.ErrRepoNotFound
+		}
+		return Relocation{}, db.WrapError(err)
+	}
+
+	volumes := d.repos.Volumes()
+	switch {
+	case volume == "":
+		// Don't count the repository against its own volume, and keep it
+		// there on ties.
+		counts[m.StorageVolume]--
+		volume = leastUsedVolume(volumes, counts)
+		if slices.Contains(volumes, m.StorageVolume) && counts[m.StorageVolume] <= counts[volume] {
+			volume = m.StorageVolume
+		}
+	case !slices.Contains(volumes, volume):
+		return Relocation{}, fmt.Errorf("%w: %q", storage.ErrVolumeNotFound, volume)
+	}
+
+	return Relocation{
+		Repo: m.Name,
+		From: repoLocation(m),
+		To:   d.repos.Place(volume, m.Name),
+	}, nil
+}
+
+// PlanRebalance returns the relocations spreading the repositories evenly
+// across the volumes, and moving them to the configured shard layout.
+// Repositories on volumes that are no longer configured are left alone.
+func (d *Backend) PlanRebalance(ctx context.Context) ([]Relocation, error) {
+	var ms []models.Repo
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		ms, err = d.store.GetAllRepos(ctx, tx)
+		return err
+	}); err != nil {
+		return nil, db.WrapError(err)
+	}
+
+	volumes := d.repos.Volumes()
+	if len(volumes) == 0 {
+		return nil, nil
+	}
+	counts := make(map[string]int64, len(volumes))
+	var total int64
+	for _, m := range ms {
+		if slices.Contains(volumes, m.StorageVolume) {
+			counts[m.StorageVolume]++
+			total++
+		}
+	}
+	limit := (total + int64(len(volumes)) - 1) / int64(len(volumes))
+
+	slices.SortFunc(ms, func(a, b models.Repo) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	var plan []Relocation
+	for _, m := range ms {
+		from := repoLocation(m)
+		if !slices.Contains(volumes, from.Volume) {
+			continue
+		}
+
+		volume := from.Volume
+		if counts[volume] > limit {
+			if v := leastUsedVolume(volumes, counts); counts[v] < limit {
+				counts[volume]--
+				counts[v]++
+				volume = v
+			}
+		}
+
+		if to := d.repos.Place(volume, m.Name); to != from {
+			plan = append(plan, Relocation{Repo: m.Name, From: from, To: to})
+		}
+	}
+
+	return plan, nil
+}
+
+// Relocate moves a repository to another location. Repositories are copied
+// when the locations are on different filesystems, and shouldn't be pushed to
+// while they move.
+func (d *Backend) Relocate(ctx context.Context, r Relocation) error {
+	if r.From == r.To {
+		return nil
+	}
+
+	src, err := d.repos.Dir(r.From)
+	if err != nil {
+		return err
+	}
+	dst, err := d.repos.Dir(r.To)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(dst); err == nil {
+		return fmt.Errorf("relocating %s: %s already exists", r.Repo, dst)
+	}
+
+	if err := moveDir(src, dst); err != nil {
+		return fmt.Errorf("relocating %s: %w", r.Repo, err)
+	}
+
+	defer d.cache.Delete(r.Repo)
+	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		return d.store.SetRepoStorageByName(ctx, tx, r.Repo, r.To.Volume, r.To.Path)
+	}); err != nil {
+		// Move the repository back so it matches the database.
+		if merr := moveDir(dst, src); merr != nil {
+			d.logger.Error("failed to move repository back", "repo", r.Repo, "path", dst, "err", merr)
+		}
+		return db.WrapError(err)
+	}
+
+	d.audit(ctx, "repo.relocate", r.Repo, fmt.Sprintf("from %s to %s", r.From, r.To))
+	return nil
+}
+
+// moveDir moves the directory src to dst, copying it when they are on
+// different filesystems.
+func moveDir(src, dst string) error {
+	if err := os.MkdirAll(filepath.Dir(dst), os.ModePerm); err != nil {
+		return err
+	}
+	if err := os.Rename(src, dst); err == nil {
+		return nil
+	}
+
+	if err := os.CopyFS(dst, os.DirFS(src)); err != nil {
+		os.RemoveAll(dst) // nolint: errcheck
+		return err
+	}
+	return os.RemoveAll(src)
+}
@@ -0,0 +1,207 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/soft-serve/pkg/access"
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/utils"
+)
+
+// ErrInvalidSubject is returned when subscribing to something other than an
+// issue, a merge request, or a repository.
+var ErrInvalidSubject = errors.New("invalid subscription subject")
+
+// Subject is what users subscribe to: an issue, a merge request, or a whole
+// repository.
+type Subject struct {
+	// Type is NotificationSubjectIssue, NotificationSubjectMergeRequest, or
+	// empty for the whole repository.
+	Type string
+	// ID is the ID of the issue or merge request.
+	ID int64
+}
+
+// Subscribe subscribes user to the notifications about subject in a
+// repository. Subscribing to a repository watches all its activity, see
+// SetRepoWatchLevel.
+func (d *Backend) Subscribe(ctx context.Context, repoName string, subject Subject, user proto.User) error {
+	return d.setSubscription(ctx, repoName, subject, user, true)
+}
+
+// Unsubscribe unsubscribes user from the notifications about subject in a
+// repository, even when they participate in it. Unsubscribing from a
+// repository goes back to the participating watch level.
+func (d *Backend) Unsubscribe(ctx context.Context, repoName string, subject Subject, user proto.User) error {
+	return d.setSubscription(ctx, repoName, subject, user, false)
+}
+
+// setSubscription subscribes or unsubscribes user.
+func (d *Backend) setSubscription(ctx context.Context, repoName string, subject Subject, user proto.User, subscribed bool) error {
+	repoName = utils.SanitizeRepo(repoName)
+	if user == nil {
+		return proto.ErrUserNotFound
+	}
+
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return err
+	}
+	if d.AccessLevelForUser(ctx, r.Name(), user) < access.ReadOnlyAccess {
+		return proto.ErrUnauthorized
+	}
+
+	if subject.Type == "" {
+		level := models.WatchLevelParticipating
+		if subscribed {
+			level = models.WatchLevelAll
+		}
+		return d.SetRepoWatchLevel(ctx, repoName, user, level)
+	}
+
+	subjectID, _, err := d.subscriptionSubject(ctx, r, subject)
+	if err != nil {
+		return err
+	}
+
+	return db.WrapError(d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		return d.store.SetSubscription(ctx, tx, r.ID(), subject.Type, subjectID, user.ID(), subscribed)
+	}))
+}
+
+// ListSubscribers returns the users notified about subject in a repository,
+// by username: the users subscribed to it or watching all the activity of the
+// repository, and the participants, less the users who unsubscribed.
+func (d *Backend) ListSubscribers(ctx context.Context, repoName string, subject Subject) ([]proto.User, error) {
+	repoName = utils.SanitizeRepo(repoName)
+
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids map[int64]bool
+	if subject.Type == "" {
+		ws, err := d.store.GetRepoWatchesByRepoID(ctx, d.db, r.ID())
+		if err != nil {
+			return nil, db.WrapError(err)
+		}
+		ids = make(map[int64]bool, len(ws))
+		for _, w := range ws {
+			if w.Level == models.WatchLevelAll {
+				ids[w.UserID] = true
+			}
+		}
+	} else {
+		subjectID, participants, err := d.subscriptionSubject(ctx, r, subject)
+		if err != nil {
+			return nil, err
+		}
+		ids, err = d.recipients(ctx, r, subject.Type, subjectID, participants...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var users []proto.User
+	for id := range ids {
+		user, err := d.UserByID(ctx, id)
+		if err != nil || d.AccessLevelForUser(ctx, r.Name(), user) < access.ReadOnlyAccess {
+			continue
+		}
+		users = append(users, user)
+	}
+	slices.SortFunc(users, func(a, b proto.User) int {
+		return strings.Compare(a.Username(), b.Username())
+	})
+
+	return users, nil
+}
+
+// subscriptionSubject returns the subject ID subscriptions to, and
+// attachments of, an issue or a merge request are recorded with, the number
+// of an issue and the ID of a merge request, and its participants.
+func (d *Backend) subscriptionSubject(ctx context.Context, r proto.Repository, subject Subject) (int64, []int64, error) {
+	switch subject.Type {
+	case NotificationSubjectIssue:
+		issue, err := d.GetIssue(ctx, r.Name(), subject.ID)
+		if err != nil {
+			return 0, nil, err
+		}
+		return issue.Number, issueParticipants(issue), nil
+	case NotificationSubjectMergeRequest:
+		mr, err := d.GetMergeRequest(ctx, r.Name(), subject.ID)
+		if err != nil {
+			return 0, nil, err
+		}
+		return mr.ID, d.mergeRequestParticipants(ctx, r, mr), nil
+	default:
+		return 0, nil, ErrInvalidSubject
+	}
+}
+
+// issueParticipants returns the IDs of the users who participate in an
+// issue.
+func issueParticipants(issue models.Issue) []int64 {
+	return []int64{issue.AuthorID, issue.ClosedBy.Int64}
+}
+
+// mergeRequestParticipants returns the IDs of the users who participate in
+// a merge request.
+func (d *Backend) mergeRequestParticipants(ctx context.Context, r proto.Repository, mr models.MergeRequest) []int64 {
+	participants := []int64{mr.AuthorID, mr.MergedBy.Int64, mr.ClosedBy.Int64}
+	if reviewers, err := d.store.GetMergeRequestReviewers(ctx, d.db, r.ID(), mr.ID); err == nil {
+		for _, u := range reviewers {
+			participants = append(participants, u.ID)
+		}
+	}
+	if cs, err := d.store.GetMergeRequestComments(ctx, d.db, r.ID(), mr.ID); err == nil {
+		for _, c := range cs {
+			participants = append(participants, c.AuthorID)
+		}
+	}
+	return participants
+}
+
+// recipients returns the IDs of the users notified about a subject of r: the
+// users watching all the activity of r, and the participants and users
+// subscribed to the subject that don't ignore r, less the users who
+// unsubscribed from it.
+func (d *Backend) recipients(ctx context.Context, r proto.Repository, subjectType string, subjectID int64, participants ...int64) (map[int64]bool, error) {
+	ws, err := d.store.GetRepoWatchesByRepoID(ctx, d.db, r.ID())
+	if err != nil {
+		return nil, err
+	}
+	subs, err := d.store.GetSubscriptions(ctx, d.db, r.ID(), subjectType, subjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	levels := make(map[int64]models.WatchLevel, len(ws))
+	recipients := make(map[int64]bool)
+	for _, w := range ws {
+		levels[w.UserID] = w.Level
+		if w.Level == models.WatchLevelAll {
+			recipients[w.UserID] = true
+		}
+	}
+	for _, id := range participants {
+		if id > 0 && levels[id] != models.WatchLevelIgnore {
+			recipients[id] = true
+		}
+	}
+	for _, s := range subs {
+		if s.Subscribed && levels[s.UserID] != models.WatchLevelIgnore {
+			recipients[s.UserID] = true
+		} else {
+			delete(recipients, s.UserID)
+		}
+	}
+
+	return recipients, nil
+}
@@ -0,0 +1,286 @@
+package backend
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/utils"
+	"github.com/sahilm/fuzzy"
+)
+
+const (
+	// DefaultSwitcherLimit is the default number of quick switcher results of
+	// each kind.
+	DefaultSwitcherLimit = 10
+
+	// maxSwitcherFiles is the maximum number of files of a repository the
+	// quick switcher looks at.
+	maxSwitcherFiles = 10000
+)
+
+// SwitcherKind is the kind of a quick switcher result.
+type SwitcherKind int
+
+const (
+	// SwitcherRepository is a repository.
+	SwitcherRepository SwitcherKind = iota
+	// SwitcherIssue is an issue.
+	SwitcherIssue
+	// SwitcherMergeRequest is a merge request.
+	SwitcherMergeRequest
+	// SwitcherBranch is a branch.
+	SwitcherBranch
+	// SwitcherFile is a file on the default branch.
+	SwitcherFile
+)
+
+// String returns the string representation of the kind.
+func (k SwitcherKind) String() string {
+	switch k {
+	case SwitcherRepository:
+		return "repo"
+	case SwitcherIssue:
+		return "issue"
+	case SwitcherMergeRequest:
+		return "mr"
+	case SwitcherBranch:
+		return "branch"
+	case SwitcherFile:
+		return "file"
+	}
+	return "unknown"
+}
+
+// SwitcherResult is a place the quick switcher can jump to.
+type SwitcherResult struct {
+	Kind       SwitcherKind
+	Repository proto.Repository
+	// ID is the ID of an issue or merge request.
+	ID int64
+	// Number is the number of an issue in its repository, and the ID of a
+	// merge request.
+	Number int64
+	// Name is the title of an issue or merge request, the name of a branch,
+	// or the path of a file.
+	Name string
+}
+
+// switcherQuery is a parsed quick switcher query.
+type switcherQuery struct {
+	// Text is the text to match.
+	Text string
+	// Repo limits the results to a repository.
+	Repo string
+	// Kind limits the results to issues or merge requests when ID is set.
+	Kind SwitcherKind
+	// ID is the issue or merge request to look up.
+	ID int64
+}
+
+// parseSwitcherQuery parses a quick switcher query. "#12" and "!12" look up
+// issue and merge request 12 of every repository, and "repo#12" and
+// "repo!12" the ones of a single repository. Anything else is matched
+// against names and titles.
+func parseSwitcherQuery(s string) switcherQuery {
+	s = strings.TrimSpace(s)
+	if i := strings.LastIndexAny(s, "#!"); i >= 0 {
+		if id, err := strconv.ParseInt(s[i+1:], 10, 64); err == nil && id > 0 {
+			q := switcherQuery{
+				Repo: utils.SanitizeRepo(s[:i]),
+				Kind: SwitcherIssue,
+				ID:   id,
+			}
+			if s[i] == '!' {
+				q.Kind = SwitcherMergeRequest
+			}
+			return q
+		}
+	}
+
+	return switcherQuery{Text: s}
+}
+
+// QuickSwitch finds the repositories, issues, merge requests, branches, and
+// files matching query across the repositories user can read. Names and paths
+// are matched fuzzily, titles by substring. At most limit results of each
+// kind are returned, best matches first.
+func (d *Backend) QuickSwitch(ctx context.Context, user proto.User, query string, limit int) ([]SwitcherResult, error) {
+	if limit < 1 {
+		limit = DefaultSwitcherLimit
+	}
+
+	repos, _, err := d.searchScope(ctx, user, &SearchOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	q := parseSwitcherQuery(query)
+	if q.Repo != "" {
+		for id, r := range repos {
+			if r.Name() != q.Repo {
+				delete(repos, id)
+			}
+		}
+	}
+
+	// Order repositories by name so results are stable.
+	sorted := make([]proto.Repository, 0, len(repos))
+	for _, r := range repos {
+		sorted = append(sorted, r)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Name() < sorted[j].Name()
+	})
+
+	results := make([]SwitcherResult, 0)
+	if q.ID == 0 {
+		names := make([]string, len(sorted))
+		for i, r := range sorted {
+			names[i] = r.Name()
+		}
+		for _, idx := range fuzzyFind(q.Text, names, limit) {
+			results = append(results, SwitcherResult{
+				Kind:       SwitcherRepository,
+				Repository: sorted[idx],
+				Name:       sorted[idx].Name(),
+			})
+		}
+	}
+
+	if q.ID > 0 || q.Text != "" {
+		var items []models.SwitcherItem
+		if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+			var err error
+			items, err = d.store.SearchSwitcherItems(ctx, tx, repoIDs(repos), q.Text, q.ID, 2*limit)
+			return err
+		}); err != nil {
+			return nil, db.WrapError(err)
+		}
+
+		// Leave out the issues and merge requests of repositories that
+		// turned them off.
+		withIssues := d.enabledRepos(ctx, repos, d.IsIssuesEnabled)
+		withMergeRequests := d.enabledRepos(ctx, repos, d.IsMergeRequestsEnabled)
+
+		var issues, mrs []SwitcherResult
+		for _, item := range items {
+			res := SwitcherResult{
+				Kind:       SwitcherIssue,
+				Repository: repos[item.RepoID],
+				ID:         item.ID,
+				Number:     item.Number,
+				Name:       item.Title,
+			}
+			switch item.Kind {
+			case models.SwitcherItemIssue:
+				if _, ok := withIssues[item.RepoID]; ok && (q.ID == 0 || q.Kind == SwitcherIssue) {
+					issues = append(issues, res)
+				}
+			case models.SwitcherItemMergeRequest:
+				if _, ok := withMergeRequests[item.RepoID]; ok && (q.ID == 0 || q.Kind == SwitcherMergeRequest) {
+					res.Kind = SwitcherMergeRequest
+					mrs = append(mrs, res)
+				}
+			}
+		}
+		results = append(results, issues[:min(len(issues), limit)]...)
+		results = append(results, mrs[:min(len(mrs), limit)]...)
+	}
+
+	// Branches and files are only looked up for real queries, listing them
+	// all is too expensive.
+	if q.ID == 0 && len(q.Text) >= 2 {
+		results = append(results, d.switcherGitResults(ctx, sorted, q.Text, limit)...)
+	}
+
+	return results, nil
+}
+
+// switcherGitResults returns the branches and the files of the default branch
+// of repos matching text.
+func (d *Backend) switcherGitResults(ctx context.Context, repos []proto.Repository, text string, limit int) []SwitcherResult {
+	type entry struct {
+		repo proto.Repository
+		name string
+	}
+
+	var branches, files []entry
+	for _, r := range repos {
+		rr, err := r.Open()
+		if err != nil {
+			d.logger.Debug("quick switcher: failed to open repository", "repo", r.Name(), "err", err)
+			continue
+		}
+
+		refs, err := rr.References()
+		if err == nil {
+			for _, ref := range refs {
+				if ref.IsBranch() {
+					branches = append(branches, entry{r, ref.Name().Short()})
+				}
+			}
+		}
+
+		// Empty repositories have no HEAD to list.
+		out, err := d.command(ctx, "ls-tree", "-r", "--name-only", "HEAD").RunInDir(rr.Path)
+		if err != nil {
+			continue
+		}
+		paths := strings.Split(strings.TrimSpace(string(out)), "\n")
+		for _, p := range paths[:min(len(paths), maxSwitcherFiles)] {
+			if p != "" {
+				files = append(files, entry{r, p})
+			}
+		}
+	}
+
+	results := make([]SwitcherResult, 0)
+	for _, group := range []struct {
+		kind    SwitcherKind
+		entries []entry
+	}{
+		{SwitcherBranch, branches},
+		{SwitcherFile, files},
+	} {
+		// Match against "repo/name" so the repository narrows the search.
+		names := make([]string, len(group.entries))
+		for i, e := range group.entries {
+			names[i] = e.repo.Name() + "/" + e.name
+		}
+		for _, idx := range fuzzyFind(text, names, limit) {
+			e := group.entries[idx]
+			results = append(results, SwitcherResult{
+				Kind:       group.kind,
+				Repository: e.repo,
+				Name:       e.name,
+			})
+		}
+	}
+
+	return results
+}
+
+// fuzzyFind returns the indexes of the best limit matches of pattern in data.
+// An empty pattern matches the first limit items.
+func fuzzyFind(pattern string, data []string, limit int) []int {
+	if pattern == "" {
+		idx := make([]int, min(len(data), limit))
+		for i := range idx {
+			idx[i] = i
+		}
+		return idx
+	}
+
+	matches := fuzzy.Find(pattern, data)
+	idx := make([]int, 0, min(len(matches), limit))
+	for _, m := range matches[:min(len(matches), limit)] {
+		idx = append(idx, m.Index)
+	}
+
+	return idx
+}
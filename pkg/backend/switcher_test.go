@@ -0,0 +1,28 @@
+package backend
+
+import "testing"
+
+func TestParseSwitcherQuery(t *testing.T) {
+	cases := []struct {
+		name  string
+		query string
+		want  switcherQuery
+	}{
+		{"empty", "", switcherQuery{}},
+		{"text", " readme ", switcherQuery{Text: "readme"}},
+		{"issue", "#12", switcherQuery{Kind: SwitcherIssue, ID: 12}},
+		{"merge request", "!3", switcherQuery{Kind: SwitcherMergeRequest, ID: 3}},
+		{"repo issue", "repo1#12", switcherQuery{Repo: "repo1", Kind: SwitcherIssue, ID: 12}},
+		{"nested repo merge request", "org/repo1!7", switcherQuery{Repo: "org/repo1", Kind: SwitcherMergeRequest, ID: 7}},
+		{"not a number", "#abc", switcherQuery{Text: "#abc"}},
+		{"zero", "#0", switcherQuery{Text: "#0"}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := parseSwitcherQuery(c.query); got != c.want {
+				t.Errorf("parseSwitcherQuery(%q) = %+v, want %+v", c.query, got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package backend
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"golang.org/x/crypto/ssh"
+)
+
+// TokenKeyPinWindow is how long after an SSH login a token pinned to the
+// login key can be used from the login address.
+const TokenKeyPinWindow = 24 * time.Hour
+
+// keyLogins remembers the addresses SSH keys recently logged in from.
+type keyLogins struct {
+	mu sync.Mutex
+	// logins maps key fingerprints to login addresses to login times.
+	logins map[string]map[string]time.Time
+}
+
+func (k *keyLogins) add(fp, ip string, now time.Time) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if k.logins == nil {
+		k.logins = make(map[string]map[string]time.Time)
+	}
+	addrs := k.logins[fp]
+	if addrs == nil {
+		addrs = make(map[string]time.Time)
+		k.logins[fp] = addrs
+	}
+	for ip, t := range addrs {
+		if now.Sub(t) > TokenKeyPinWindow {
+			delete(addrs, ip)
+		}
+	}
+	addrs[ip] = now
+}
+
+func (k *keyLogins) recent(fp, ip string, now time.Time) bool {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	t, ok := k.logins[fp][ip]
+	return ok && now.Sub(t) <= TokenKeyPinWindow
+}
+
+// RecordKeyLogin records that pk logged in over SSH from addr, so tokens
+// pinned to pk can be used from there.
+func (d *Backend) RecordKeyLogin(pk ssh.PublicKey, addr net.Addr) {
+	if pk == nil || addr == nil {
+		return
+	}
+	ip := addr.String()
+	if host, _, err := net.SplitHostPort(ip); err == nil {
+		ip = host
+	}
+	d.keyLogins.add(ssh.FingerprintSHA256(pk), normalizeIP(ip), time.Now())
+}
+
+// normalizeIP returns ip in canonical form, with IPv4-mapped IPv6 addresses
+// turned into IPv4 addresses.
+func normalizeIP(ip string) string {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return ip
+	}
+	return addr.Unmap().String()
+}
+
+// parseAllowedCIDRs validates the networks a token can be used from and
+// returns them as stored in the database.
+func parseAllowedCIDRs(cidrs []string) (string, error) {
+	prefixes := make([]string, 0, len(cidrs))
+	for _, c := range cidrs {
+		c = strings.TrimSpace(c)
+		if c == "" {
+			continue
+		}
+		if !strings.Contains(c, "/") {
+			addr, err := netip.ParseAddr(c)
+			if err != nil {
+				return "", fmt.Errorf("invalid address %q", c)
+			}
+			c = netip.PrefixFrom(addr, addr.BitLen()).String()
+		}
+		p, err := netip.ParsePrefix(c)
+		if err != nil {
+			return "", fmt.Errorf("invalid network %q", c)
+		}
+		prefixes = append(prefixes, p.Masked().String())
+	}
+	return strings.Join(prefixes, ","), nil
+}
+
+func splitAllowedCIDRs(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, ",")
+}
+
+// checkTokenPins returns proto.ErrTokenPinned if the token can't be used from
+// ip. pks are the public keys of the token owner.
+func (d *Backend) checkTokenPins(t models.AccessToken, pks []ssh.PublicKey, ip string, now time.Time) error {
+	ip = normalizeIP(ip)
+	if cidrs := splitAllowedCIDRs(t.AllowedCIDRs); len(cidrs) > 0 {
+		addr, err := netip.ParseAddr(ip)
+		if err != nil {
+			return proto.ErrTokenPinned
+		}
+
+		var allowed bool
+		for _, c := range cidrs {
+			if p, err := netip.ParsePrefix(c); err == nil && p.Contains(addr) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return proto.ErrTokenPinned
+		}
+	}
+
+	if t.PinnedKey != "" {
+		// The key must still belong to the token owner.
+		var owned bool
+		for _, pk := range pks {
+			if ssh.FingerprintSHA256(pk) == t.PinnedKey {
+				owned = true
+				break
+			}
+		}
+		if !owned || !d.keyLogins.recent(t.PinnedKey, ip, now) {
+			return proto.ErrTokenPinned
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package backend
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestParseAllowedCIDRs(t *testing.T) {
+	got, err := parseAllowedCIDRs([]string{"10.1.2.3/8", " 192.168.1.2", "::1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "10.0.0.0/8,192.168.1.2/32,::1/128"; got != want {
+		t.Fatalf("parseAllowedCIDRs() = %q, want %q", got, want)
+	}
+
+	if _, err := parseAllowedCIDRs([]string{"10.0.0.0/33"}); err == nil {
+		t.Fatal("expected an invalid network error")
+	}
+	if _, err := parseAllowedCIDRs([]string{"example.com"}); err == nil {
+		t.Fatal("expected an invalid address error")
+	}
+}
+
+func TestCheckTokenPins(t *testing.T) {
+	newKey := func() ssh.PublicKey {
+		pub, _, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pk, err := ssh.NewPublicKey(pub)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return pk
+	}
+	key, other := newKey(), newKey()
+	fp := ssh.FingerprintSHA256(key)
+	now := time.Now()
+
+	d := &Backend{}
+	d.RecordKeyLogin(key, &net.TCPAddr{IP: net.ParseIP("::ffff:192.168.1.2"), Port: 2222})
+
+	cases := []struct {
+		name    string
+		token   models.AccessToken
+		pks     []ssh.PublicKey
+		ip      string
+		now     time.Time
+		allowed bool
+	}{
+		{"no pins", models.AccessToken{}, nil, "1.2.3.4", now, true},
+		{"in network", models.AccessToken{AllowedCIDRs: "10.0.0.0/8,192.168.1.0/24"}, nil, "192.168.1.7", now, true},
+		{"off network", models.AccessToken{AllowedCIDRs: "10.0.0.0/8"}, nil, "192.168.1.7", now, false},
+		{"unknown address", models.AccessToken{AllowedCIDRs: "10.0.0.0/8"}, nil, "", now, false},
+		{"key login address", models.AccessToken{PinnedKey: fp}, []ssh.PublicKey{other, key}, "192.168.1.2", now, true},
+		{"other address", models.AccessToken{PinnedKey: fp}, []ssh.PublicKey{key}, "192.168.1.3", now, false},
+		{"key removed", models.AccessToken{PinnedKey: fp}, []ssh.PublicKey{other}, "192.168.1.2", now, false},
+		{"old login", models.AccessToken{PinnedKey: fp}, []ssh.PublicKey{key}, "192.168.1.2", now.Add(TokenKeyPinWindow + time.Minute), false},
+		{"both pins", models.AccessToken{AllowedCIDRs: "10.0.0.0/8", PinnedKey: fp}, []ssh.PublicKey{key}, "192.168.1.2", now, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := d.checkTokenPins(c.token, c.pks, c.ip, c.now)
+			if c.allowed && err != nil {
+				t.Fatalf("expected allowed, got %v", err)
+			}
+			if !c.allowed && !errors.Is(err, proto.ErrTokenPinned) {
+				t.Fatalf("expected pinned error, got %v", err)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package backend
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strings"
 	"time"
 
@@ -42,8 +43,30 @@ func (d *Backend) AccessLevelByPublicKey(ctx context.Context, repo string, pk ss
 }
 
 // AccessLevelForUser returns the access level of a user for a repository.
+// Users authenticated with a scoped access token get at most the access level
+// of the token, and only to the repository of the token if it has one.
+func (d *Backend) AccessLevelForUser(ctx context.Context, repo string, u proto.User) access.AccessLevel {
+	tu, ok := u.(*user)
+	if !ok || tu.token == nil || (tu.token.Scope == "" && tu.token.ScopeRepo == "") {
+		return d.accessLevelForUser(ctx, repo, u)
+	}
+
+	if tu.token.ScopeRepo != "" && utils.SanitizeRepo(repo) != tu.token.ScopeRepo {
+		return d.accessLevelForUser(ctx, repo, nil)
+	}
+
+	level := d.accessLevelForUser(ctx, repo, u)
+	if tu.token.Scope != "" {
+		level = min(level, access.ParseAccessLevel(tu.token.Scope))
+	}
+
+	return level
+}
+
+// accessLevelForUser returns the access level of a user for a repository,
+// regardless of access token scopes.
 // TODO: user repository ownership
-func (d *Backend) AccessLevelForUser(ctx context.Context, repo string, user proto.User) access.AccessLevel {
+func (d *Backend) accessLevelForUser(ctx context.Context, repo string, user proto.User) access.AccessLevel {
 	var username string
 	anon := d.AnonAccess(ctx)
 	if user != nil {
@@ -71,20 +94,28 @@ func (d *Backend) AccessLevelForUser(ctx context.Context, repo string, user prot
 
 		// If the user is a collaborator, they have return their access level.
 		collabAccess, isCollab, _ := d.IsCollaborator(ctx, repo, username)
-		if isCollab {
+		if isCollab && collabAccess != access.GuestAccess {
 			if anon > collabAccess {
 				return anon
 			}
 			return collabAccess
 		}
 
-		// If the repository is private, the user has no access.
+		// If the repository is private, the user has no access, unless
+		// they're a guest.
 		if r.IsPrivate() {
+			if isCollab {
+				return access.GuestAccess
+			}
 			return access.NoAccess
 		}
 
-		// Otherwise, the user has read-only access.
+		// Otherwise, the user has read-only access. Internal repositories
+		// are hidden from anonymous users.
 		if user == nil {
+			if r.IsInternal() {
+				return access.NoAccess
+			}
 			return anon
 		}
 
@@ -198,14 +229,19 @@ func (d *Backend) UserByPublicKey(ctx context.Context, pk ssh.PublicKey) (proto.
 }
 
 // UserByAccessToken finds a user by access token.
-// This also validates the token for expiration and returns proto.ErrTokenExpired.
+// This also validates the token for expiration and returns proto.ErrTokenExpired,
+// checks where the token can be used from and returns proto.ErrTokenPinned,
+// and records when and from where the token was used.
 func (d *Backend) UserByAccessToken(ctx context.Context, token string) (proto.User, error) {
 	var m models.User
+	var t models.AccessToken
 	var pks []ssh.PublicKey
 	token = HashToken(token)
+	ip := remoteIP(ctx)
 
 	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
-		t, err := d.store.GetAccessTokenByToken(ctx, tx, token)
+		var err error
+		t, err = d.store.GetAccessTokenByToken(ctx, tx, token)
 		if err != nil {
 			return db.WrapError(err)
 		}
@@ -220,12 +256,25 @@ func (d *Backend) UserByAccessToken(ctx context.Context, token string) (proto.Us
 		}
 
 		pks, err = d.store.ListPublicKeysByUserID(ctx, tx, m.ID)
-		return err
+		if err != nil {
+			return err
+		}
+
+		if err := d.checkTokenPins(t, pks, ip, time.Now()); err != nil {
+			return err
+		}
+
+		return d.store.TouchAccessToken(ctx, tx, t.ID, ip)
 	}); err != nil {
 		err = db.WrapError(err)
 		if errors.Is(err, db.ErrRecordNotFound) {
 			return nil, proto.ErrUserNotFound
 		}
+		if errors.Is(err, proto.ErrTokenPinned) {
+			d.logger.Warn("pinned access token used from a disallowed address", "username", m.Username, "token", t.ID, "ip", ip)
+			d.audit(ctx, "token.pin_violation", m.Username, fmt.Sprintf("token #%d used from %s", t.ID, ip))
+			return nil, err
+		}
 		d.logger.Error("failed to find user by access token", "err", err, "token", token)
 		return nil, err
 	}
@@ -233,6 +282,7 @@ func (d *Backend) UserByAccessToken(ctx context.Context, token string) (proto.Us
 	return &user{
 		user:       m,
 		publicKeys: pks,
+		token:      &t,
 	}, nil
 }
 
@@ -240,6 +290,20 @@ func (d *Backend) UserByAccessToken(ctx context.Context, token string) (proto.Us
 //
 // It implements backend.Backend.
 func (d *Backend) Users(ctx context.Context) ([]string, error) {
+	return d.usernames(ctx, func(models.User) bool { return true })
+}
+
+// People returns the usernames of all users but bots.
+func (d *Backend) People(ctx context.Context) ([]string, error) {
+	return d.usernames(ctx, func(m models.User) bool { return !m.Bot })
+}
+
+// Bots returns the usernames of all machine users.
+func (d *Backend) Bots(ctx context.Context) ([]string, error) {
+	return d.usernames(ctx, func(m models.User) bool { return m.Bot })
+}
+
+func (d *Backend) usernames(ctx context.Context, keep func(models.User) bool) ([]string, error) {
 	var users []string
 	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
 		ms, err := d.store.GetAllUsers(ctx, tx)
@@ -248,7 +312,9 @@ func (d *Backend) Users(ctx context.Context) ([]string, error) {
 		}
 
 		for _, m := range ms {
-			users = append(users, m.Username)
+			if keep(m) {
+				users = append(users, m.Username)
+			}
 		}
 
 		return nil
@@ -284,9 +350,18 @@ func (d *Backend) CreateUser(ctx context.Context, username string, opts proto.Us
 	if err := utils.ValidateUsername(username); err != nil {
 		return nil, err
 	}
+	if err := checkUsernameNotReserved(username); err != nil {
+		return nil, err
+	}
 
 	if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
-		return d.store.CreateUser(ctx, tx, username, opts.Admin, opts.PublicKeys)
+		if err := d.store.CreateUser(ctx, tx, username, opts.Admin, opts.PublicKeys); err != nil {
+			return err
+		}
+		if opts.Bot {
+			return d.store.SetBotByUsername(ctx, tx, username, true)
+		}
+		return nil
 	}); err != nil {
 		return nil, db.WrapError(err)
 	}
@@ -294,7 +369,9 @@ func (d *Backend) CreateUser(ctx context.Context, username string, opts proto.Us
 	return d.User(ctx, username)
 }
 
-// DeleteUser deletes a user.
+// DeleteUser deletes a user and their repositories. Their issues, merge
+// requests, and attachments are reassigned to the ghost user, see
+// GhostUsername.
 //
 // It implements backend.Backend.
 func (d *Backend) DeleteUser(ctx context.Context, username string) error {
@@ -303,13 +380,7 @@ func (d *Backend) DeleteUser(ctx context.Context, username string) error {
 		return err
 	}
 
-	return d.db.TransactionContext(ctx, func(tx *db.Tx) error {
-		if err := d.store.DeleteUserByUsername(ctx, tx, username); err != nil {
-			return db.WrapError(err)
-		}
-
-		return d.DeleteUserRepositories(ctx, username)
-	})
+	return d.deleteUser(ctx, username)
 }
 
 // RemovePublicKey removes a public key from a user.
@@ -351,6 +422,10 @@ func (d *Backend) SetUsername(ctx context.Context, username string, newUsername
 		return err
 	}
 
+	if err := checkUsernameNotReserved(newUsername); err != nil {
+		return err
+	}
+
 	return db.WrapError(
 		d.db.TransactionContext(ctx, func(tx *db.Tx) error {
 			return d.store.SetUsernameByUsername(ctx, tx, username, newUsername)
@@ -374,6 +449,20 @@ func (d *Backend) SetAdmin(ctx context.Context, username string, admin bool) err
 	)
 }
 
+// SetBot sets whether a user is a machine user.
+func (d *Backend) SetBot(ctx context.Context, username string, bot bool) error {
+	username = strings.ToLower(username)
+	if err := utils.ValidateUsername(username); err != nil {
+		return err
+	}
+
+	return db.WrapError(
+		d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+			return d.store.SetBotByUsername(ctx, tx, username, bot)
+		}),
+	)
+}
+
 // SetPassword sets the password of a user.
 func (d *Backend) SetPassword(ctx context.Context, username string, rawPassword string) error {
 	username = strings.ToLower(username)
@@ -396,15 +485,26 @@ func (d *Backend) SetPassword(ctx context.Context, username string, rawPassword
 type user struct {
 	user       models.User
 	publicKeys []ssh.PublicKey
+	// token is the access token the user authenticated with, if any.
+	token *models.AccessToken
 }
 
 var _ proto.User = (*user)(nil)
 
-// IsAdmin implements proto.User
+// IsAdmin implements proto.User. Users authenticated with an access token
+// scoped below admin access, or to a single repository, aren't admins.
 func (u *user) IsAdmin() bool {
+	if u.token != nil && (u.token.ScopeRepo != "" || (u.token.Scope != "" && u.token.Scope != access.AdminAccess.String())) {
+		return false
+	}
 	return u.user.Admin
 }
 
+// IsBot implements proto.User
+func (u *user) IsBot() bool {
+	return u.user.Bot
+}
+
 // PublicKeys implements proto.User
 func (u *user) PublicKeys() []ssh.PublicKey {
 	return u.publicKeys
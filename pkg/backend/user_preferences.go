@@ -0,0 +1,76 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+)
+
+// MaxDiffTabWidth is the largest tab width of diffs.
+const MaxDiffTabWidth = 16
+
+// DefaultUserPreferences returns the preferences of users who haven't changed
+// any.
+func DefaultUserPreferences() models.UserPreferences {
+	return models.UserPreferences{
+		DiffWordWrap: true,
+		DiffTabWidth: 4,
+		DateFormat:   DefaultDateFormat,
+		DateStyle:    DateStyleAuto,
+	}
+}
+
+// UserPreferences returns the preferences of a user. Anonymous users, and
+// users who haven't changed any, get the default preferences.
+func (d *Backend) UserPreferences(ctx context.Context, user proto.User) (models.UserPreferences, error) {
+	if user == nil {
+		return DefaultUserPreferences(), nil
+	}
+
+	prefs, err := d.store.GetUserPreferencesByUserID(ctx, d.db, user.ID())
+	if err != nil {
+		err = db.WrapError(err)
+		if errors.Is(err, db.ErrRecordNotFound) {
+			prefs = DefaultUserPreferences()
+			prefs.UserID = user.ID()
+			return prefs, nil
+		}
+		return DefaultUserPreferences(), err
+	}
+
+	return prefs, nil
+}
+
+// SetUserPreferences saves the preferences of a user.
+func (d *Backend) SetUserPreferences(ctx context.Context, user proto.User, prefs models.UserPreferences) error {
+	if user == nil {
+		return proto.ErrUserNotFound
+	}
+	if prefs.DiffTabWidth < 1 || prefs.DiffTabWidth > MaxDiffTabWidth {
+		return fmt.Errorf("tab width must be between 1 and %d", MaxDiffTabWidth)
+	}
+	if prefs.DateFormat == "" {
+		prefs.DateFormat = DefaultDateFormat
+	}
+	if prefs.DateStyle == "" {
+		prefs.DateStyle = DateStyleAuto
+	}
+	if err := ValidateTimezone(prefs.Timezone); err != nil {
+		return err
+	}
+	if _, err := DateLayout(prefs.DateFormat); err != nil {
+		return err
+	}
+	if err := ValidateDateStyle(prefs.DateStyle); err != nil {
+		return err
+	}
+
+	prefs.UserID = user.ID()
+	return db.WrapError(d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		return d.store.SetUserPreferences(ctx, tx, prefs)
+	}))
+}
@@ -1,8 +1,11 @@
 package backend
 
 import (
+	"strings"
+
 	"github.com/charmbracelet/soft-serve/git"
 	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/utils"
 )
 
 // LatestFile returns the contents of the latest file at the specified path in
@@ -21,3 +24,26 @@ func Readme(r proto.Repository, ref *git.Reference) (readme string, path string,
 	readme, path, err = LatestFile(r, ref, pattern)
 	return
 }
+
+// sanitizeTitleAndDescription sanitizes and validates an issue or merge
+// request title and description before they are written to the database.
+func (d *Backend) sanitizeTitleAndDescription(title, description string) (string, string, error) {
+	title = utils.SanitizeTitle(title)
+	if err := utils.ValidateTitle(title); err != nil {
+		return "", "", err
+	}
+
+	description = strings.TrimSpace(utils.SanitizeText(description))
+	if err := utils.ValidateDescription(description, d.cfg.Limits.MaxDescriptionSize); err != nil {
+		return "", "", err
+	}
+
+	return title, description, nil
+}
+
+// descriptionPreview returns the part of a description that is stored inline
+// with an issue or merge request. It reports whether the description is large
+// enough to be stored separately.
+func (d *Backend) descriptionPreview(description string) (string, bool) {
+	return utils.TruncateText(description, d.cfg.Limits.LargeTextThreshold)
+}
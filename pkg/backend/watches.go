@@ -0,0 +1,173 @@
+package backend
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/charmbracelet/soft-serve/pkg/access"
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/utils"
+)
+
+const (
+	// NotificationSubjectIssue is the subject type of issue notifications.
+	NotificationSubjectIssue = "issue"
+	// NotificationSubjectMergeRequest is the subject type of merge request
+	// notifications.
+	NotificationSubjectMergeRequest = "merge_request"
+	// NotificationSubjectMergeRequestComment is the subject type of reports
+	// and moderation of merge request comments.
+	NotificationSubjectMergeRequestComment = "merge_request_comment"
+	// NotificationSubjectAccessToken is the subject type of access token
+	// notifications.
+	NotificationSubjectAccessToken = "access_token"
+	// NotificationSubjectWebhook is the subject type of webhook
+	// notifications.
+	NotificationSubjectWebhook = "webhook"
+	// NotificationSubjectRef is the subject type of notifications about ref
+	// updates, whose subject is an entry of the reflog.
+	NotificationSubjectRef = "ref"
+)
+
+// ErrInvalidWatchLevel is returned when the watch level is invalid.
+var ErrInvalidWatchLevel = errors.New("invalid watch level, must be one of: all, participating, ignore")
+
+// ParseWatchLevel parses a watch level string and returns the watch level.
+func ParseWatchLevel(s string) (models.WatchLevel, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "all":
+		return models.WatchLevelAll, nil
+	case "participating":
+		return models.WatchLevelParticipating, nil
+	case "ignore":
+		return models.WatchLevelIgnore, nil
+	default:
+		return -1, ErrInvalidWatchLevel
+	}
+}
+
+// RepoWatchLevel returns the watch level of user on a repository. Users who
+// haven't picked one are participating.
+func (d *Backend) RepoWatchLevel(ctx context.Context, repoName string, user proto.User) (models.WatchLevel, error) {
+	repoName = utils.SanitizeRepo(repoName)
+	if user == nil {
+		return models.WatchLevelParticipating, proto.ErrUserNotFound
+	}
+
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return models.WatchLevelParticipating, err
+	}
+
+	w, err := d.store.GetRepoWatch(ctx, d.db, r.ID(), user.ID())
+	if err != nil {
+		err = db.WrapError(err)
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return models.WatchLevelParticipating, nil
+		}
+		return models.WatchLevelParticipating, err
+	}
+
+	return w.Level, nil
+}
+
+// SetRepoWatchLevel sets the watch level of user on a repository.
+func (d *Backend) SetRepoWatchLevel(ctx context.Context, repoName string, user proto.User, level models.WatchLevel) error {
+	repoName = utils.SanitizeRepo(repoName)
+	if user == nil {
+		return proto.ErrUserNotFound
+	}
+
+	r, err := d.Repository(ctx, repoName)
+	if err != nil {
+		return err
+	}
+
+	return db.WrapError(d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		return d.store.SetRepoWatch(ctx, tx, r.ID(), user.ID(), level)
+	}))
+}
+
+// RepoWatchLevels returns the watch levels user picked, by repository ID.
+// Repositories missing from the map are watched at the participating level.
+func (d *Backend) RepoWatchLevels(ctx context.Context, user proto.User) (map[int64]models.WatchLevel, error) {
+	levels := map[int64]models.WatchLevel{}
+	if user == nil {
+		return levels, nil
+	}
+
+	ws, err := d.store.GetRepoWatchesByUserID(ctx, d.db, user.ID())
+	if err != nil {
+		return levels, db.WrapError(err)
+	}
+	for _, w := range ws {
+		levels[w.RepoID] = w.Level
+	}
+
+	return levels, nil
+}
+
+// Notifications returns the notifications of user, newest first. If all is
+// false, only unread notifications are returned.
+func (d *Backend) Notifications(ctx context.Context, user proto.User, all bool) ([]models.Notification, error) {
+	if user == nil {
+		return nil, proto.ErrUserNotFound
+	}
+
+	ns, err := d.store.GetNotificationsByUserID(ctx, d.db, user.ID(), !all)
+	if err != nil {
+		return nil, db.WrapError(err)
+	}
+
+	return ns, nil
+}
+
+// MarkNotificationsRead marks the given notifications of user as read. With
+// no ids, all of them are marked.
+func (d *Backend) MarkNotificationsRead(ctx context.Context, user proto.User, ids ...int64) error {
+	if user == nil {
+		return proto.ErrUserNotFound
+	}
+
+	return db.WrapError(d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+		return d.store.MarkNotificationsRead(ctx, tx, user.ID(), ids)
+	}))
+}
+
+// notifyWatchers creates notification n for the users watching all the
+// activity of r, and for the participants and users subscribed to the subject
+// of n that don't ignore r, less the users who unsubscribed from it. The
+// user causing the activity and users who can no longer read r are skipped.
+// The activity has already been committed, so errors are logged instead of
+// returned.
+func (d *Backend) notifyWatchers(ctx context.Context, r proto.Repository, n models.Notification, participants ...int64) {
+	recipients, err := d.recipients(ctx, r, n.SubjectType, n.SubjectID, participants...)
+	if err != nil {
+		d.logger.Error("error getting notification recipients", "repo", r.Name(), "err", err)
+		return
+	}
+
+	if actor := proto.UserFromContext(ctx); actor != nil {
+		delete(recipients, actor.ID())
+		n.ActorID = sql.NullInt64{Int64: actor.ID(), Valid: true}
+	}
+
+	n.RepoID = sql.NullInt64{Int64: r.ID(), Valid: true}
+	for id := range recipients {
+		user, err := d.UserByID(ctx, id)
+		if err != nil || d.AccessLevelForUser(ctx, r.Name(), user) < access.ReadOnlyAccess {
+			continue
+		}
+
+		n.UserID = id
+		if err := d.db.TransactionContext(ctx, func(tx *db.Tx) error {
+			return d.store.CreateNotification(ctx, tx, n)
+		}); err != nil {
+			d.logger.Error("error creating notification", "repo", r.Name(), "user", id, "err", err)
+		}
+	}
+}
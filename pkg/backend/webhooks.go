@@ -2,9 +2,11 @@ package backend
 
 import (
 	"context"
-	"encoding/json"
+	"database/sql"
+	"errors"
 
 	"github.com/charmbracelet/log/v2"
+	"github.com/charmbracelet/soft-serve/pkg/access"
 	"github.com/charmbracelet/soft-serve/pkg/db"
 	"github.com/charmbracelet/soft-serve/pkg/db/models"
 	"github.com/charmbracelet/soft-serve/pkg/proto"
@@ -15,7 +17,7 @@ import (
 )
 
 // CreateWebhook creates a webhook for a repository.
-func (b *Backend) CreateWebhook(ctx context.Context, repo proto.Repository, url string, contentType webhook.ContentType, secret string, events []webhook.Event, active bool) error {
+func (b *Backend) CreateWebhook(ctx context.Context, repo proto.Repository, url string, contentType webhook.ContentType, format webhook.Format, secret string, events []webhook.Event, active bool) error {
 	dbx := db.FromContext(ctx)
 	datastore := store.FromContext(ctx)
 	url = utils.Sanitize(url)
@@ -26,7 +28,7 @@ func (b *Backend) CreateWebhook(ctx context.Context, repo proto.Repository, url
 	}
 
 	return dbx.TransactionContext(ctx, func(tx *db.Tx) error {
-		lastID, err := datastore.CreateWebhook(ctx, tx, repo.ID(), url, secret, int(contentType), active)
+		lastID, err := datastore.CreateWebhook(ctx, tx, repo.ID(), url, secret, int(contentType), int(format), active)
 		if err != nil {
 			return db.WrapError(err)
 		}
@@ -62,6 +64,7 @@ func (b *Backend) Webhook(ctx context.Context, repo proto.Repository, id int64)
 		wh = webhook.Hook{
 			Webhook:     h,
 			ContentType: webhook.ContentType(h.ContentType), //nolint:gosec
+			Format:      webhook.Format(h.Format),           //nolint:gosec
 			Events:      make([]webhook.Event, len(events)),
 		}
 		for i, e := range events {
@@ -113,6 +116,7 @@ func (b *Backend) ListWebhooks(ctx context.Context, repo proto.Repository) ([]we
 		hooks[i] = webhook.Hook{
 			Webhook:     h,
 			ContentType: webhook.ContentType(h.ContentType), //nolint:gosec
+			Format:      webhook.Format(h.Format),           //nolint:gosec
 			Events:      events,
 		}
 	}
@@ -121,7 +125,7 @@ func (b *Backend) ListWebhooks(ctx context.Context, repo proto.Repository) ([]we
 }
 
 // UpdateWebhook updates a webhook.
-func (b *Backend) UpdateWebhook(ctx context.Context, repo proto.Repository, id int64, url string, contentType webhook.ContentType, secret string, updatedEvents []webhook.Event, active bool) error {
+func (b *Backend) UpdateWebhook(ctx context.Context, repo proto.Repository, id int64, url string, contentType webhook.ContentType, format webhook.Format, secret string, updatedEvents []webhook.Event, active bool) error {
 	dbx := db.FromContext(ctx)
 	datastore := store.FromContext(ctx)
 
@@ -131,7 +135,7 @@ func (b *Backend) UpdateWebhook(ctx context.Context, repo proto.Repository, id i
 	}
 
 	return dbx.TransactionContext(ctx, func(tx *db.Tx) error {
-		if err := datastore.UpdateWebhookByID(ctx, tx, repo.ID(), id, url, secret, int(contentType), active); err != nil {
+		if err := datastore.UpdateWebhookByID(ctx, tx, repo.ID(), id, url, secret, int(contentType), int(format), active); err != nil {
 			return db.WrapError(err)
 		}
 
@@ -200,6 +204,64 @@ func (b *Backend) DeleteWebhook(ctx context.Context, repo proto.Repository, id i
 	})
 }
 
+// WebhookByExternalID returns a webhook for a repository by its external ID.
+func (b *Backend) WebhookByExternalID(ctx context.Context, repo proto.Repository, externalID string) (webhook.Hook, error) {
+	dbx := db.FromContext(ctx)
+	datastore := store.FromContext(ctx)
+
+	h, err := datastore.GetWebhookByExternalID(ctx, dbx, repo.ID(), externalID)
+	if err != nil {
+		return webhook.Hook{}, db.WrapError(err)
+	}
+
+	return b.Webhook(ctx, repo, h.ID)
+}
+
+// SetWebhook creates or updates the repository webhook identified by an
+// external ID. It reports whether the webhook was created.
+func (b *Backend) SetWebhook(ctx context.Context, repo proto.Repository, externalID string, url string, contentType webhook.ContentType, format webhook.Format, secret string, events []webhook.Event, active bool) (webhook.Hook, bool, error) {
+	dbx := db.FromContext(ctx)
+	datastore := store.FromContext(ctx)
+	url = utils.Sanitize(url)
+
+	h, err := datastore.GetWebhookByExternalID(ctx, dbx, repo.ID(), externalID)
+	if err == nil {
+		if err := b.UpdateWebhook(ctx, repo, h.ID, url, contentType, format, secret, events, active); err != nil {
+			return webhook.Hook{}, false, err
+		}
+
+		wh, err := b.Webhook(ctx, repo, h.ID)
+		return wh, false, err
+	}
+	if err := db.WrapError(err); !errors.Is(err, db.ErrRecordNotFound) {
+		return webhook.Hook{}, false, err
+	}
+
+	// Validate webhook URL to prevent SSRF attacks
+	if err := webhook.ValidateWebhookURL(url); err != nil {
+		return webhook.Hook{}, false, err //nolint:wrapcheck
+	}
+
+	var id int64
+	if err := dbx.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		id, err = datastore.CreateWebhook(ctx, tx, repo.ID(), url, secret, int(contentType), int(format), active)
+		if err != nil {
+			return err
+		}
+		if err := datastore.SetWebhookExternalID(ctx, tx, repo.ID(), id, externalID); err != nil {
+			return err
+		}
+
+		return datastore.CreateWebhookEvents(ctx, tx, id, eventInts(events))
+	}); err != nil {
+		return webhook.Hook{}, false, db.WrapError(err)
+	}
+
+	wh, err := b.Webhook(ctx, repo, id)
+	return wh, true, err
+}
+
 // ListWebhookDeliveries lists webhook deliveries for a webhook.
 func (b *Backend) ListWebhookDeliveries(ctx context.Context, id int64) ([]webhook.Delivery, error) {
 	dbx := db.FromContext(ctx)
@@ -254,15 +316,9 @@ func (b *Backend) RedeliverWebhookDelivery(ctx context.Context, repo proto.Repos
 		return db.WrapError(err)
 	}
 
-	log.Infof("redelivering webhook delivery %s for webhook %d\n\n%s\n\n", delID, id, delivery.RequestBody)
-
-	var payload json.RawMessage
-	if err := json.Unmarshal([]byte(delivery.RequestBody), &payload); err != nil {
-		log.Errorf("error unmarshaling webhook payload: %v", err)
-		return err
-	}
+	log.Infof("redelivering webhook delivery %s for webhook %d", delID, id)
 
-	return webhook.SendWebhook(ctx, wh, webhook.Event(delivery.Event), payload)
+	return webhook.Redeliver(ctx, wh, delivery)
 }
 
 // WebhookDelivery returns a webhook delivery.
@@ -289,3 +345,70 @@ func (b *Backend) WebhookDelivery(ctx context.Context, webhookID int64, id uuid.
 
 	return delivery, nil
 }
+
+// NotifyFailingWebhooks notifies the admins of the repositories with webhooks
+// that failed webhook.FailureThreshold times in a row. Admins are notified
+// once, until a delivery succeeds again.
+func (b *Backend) NotifyFailingWebhooks(ctx context.Context) error {
+	whs, err := b.store.GetFailingWebhooksToNotify(ctx, b.db, webhook.FailureThreshold)
+	if err != nil {
+		return db.WrapError(err)
+	}
+	if len(whs) == 0 {
+		return nil
+	}
+
+	repos, err := b.Repositories(ctx)
+	if err != nil {
+		return err
+	}
+	people, err := b.People(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, wh := range whs {
+		var repo proto.Repository
+		for _, r := range repos {
+			if r.ID() == wh.RepoID {
+				repo = r
+				break
+			}
+		}
+		if repo == nil {
+			continue
+		}
+
+		var admins []int64
+		for _, username := range people {
+			user, err := b.User(ctx, username)
+			if err != nil {
+				continue
+			}
+			if b.AccessLevelForUser(ctx, repo.Name(), user) >= access.AdminAccess {
+				admins = append(admins, user.ID())
+			}
+		}
+
+		if err := b.db.TransactionContext(ctx, func(tx *db.Tx) error {
+			for _, id := range admins {
+				if err := b.store.CreateNotification(ctx, tx, models.Notification{
+					UserID:      id,
+					RepoID:      sql.NullInt64{Int64: repo.ID(), Valid: true},
+					SubjectType: NotificationSubjectWebhook,
+					SubjectID:   wh.ID,
+					Title:       utils.Sanitize(wh.URL),
+					Action:      "failing",
+				}); err != nil {
+					return err
+				}
+			}
+
+			return b.store.SetWebhookFailureNotified(ctx, tx, wh.ID)
+		}); err != nil {
+			return db.WrapError(err)
+		}
+	}
+
+	return nil
+}
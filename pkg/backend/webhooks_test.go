@@ -0,0 +1,118 @@
+package backend
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/charmbracelet/log/v2"
+	"github.com/charmbracelet/soft-serve/pkg/access"
+	"github.com/charmbracelet/soft-serve/pkg/config"
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/migrate"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/store"
+	"github.com/charmbracelet/soft-serve/pkg/store/database"
+	"github.com/charmbracelet/soft-serve/pkg/webhook"
+	_ "modernc.org/sqlite" // sqlite driver
+)
+
+func TestNotifyFailingWebhooks(t *testing.T) {
+	t.Setenv("SOFT_SERVE_DATA_PATH", t.TempDir())
+	cfg := config.DefaultConfig()
+	if err := cfg.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := config.WithContext(context.Background(), cfg)
+	ctx = log.WithContext(ctx, log.New(io.Discard))
+	dbx, err := db.Open(ctx, cfg.DB.Driver, cfg.DB.DataSource)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { dbx.Close() }) // nolint: errcheck
+	if err := migrate.Migrate(ctx, dbx); err != nil {
+		t.Fatal(err)
+	}
+	datastore := database.New(ctx, dbx)
+	be := New(ctx, cfg, dbx, datastore)
+	ctx = db.WithContext(ctx, dbx)
+	ctx = store.WithContext(ctx, datastore)
+
+	owner, err := be.CreateUser(ctx, "owner", proto.UserOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	maintainer, err := be.CreateUser(ctx, "maintainer", proto.UserOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	reader, err := be.CreateUser(ctx, "reader", proto.UserOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo, err := be.CreateRepository(ctx, "repo1", owner, proto.RepositoryOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx = proto.WithUserContext(ctx, owner)
+	if err := be.AddCollaborator(ctx, "repo1", "maintainer", access.AdminAccess); err != nil {
+		t.Fatal(err)
+	}
+	if err := be.AddCollaborator(ctx, "repo1", "reader", access.ReadOnlyAccess); err != nil {
+		t.Fatal(err)
+	}
+	if err := be.CreateWebhook(ctx, repo, "https://1.1.1.1/hook", webhook.ContentTypeJSON, webhook.FormatSoftServe, "", []webhook.Event{webhook.EventPush}, true); err != nil {
+		t.Fatal(err)
+	}
+	hooks, err := be.ListWebhooks(ctx, repo)
+	if err != nil || len(hooks) != 1 {
+		t.Fatalf("ListWebhooks() = %v, %v", hooks, err)
+	}
+	id := hooks[0].ID
+
+	deliver := func(ok bool, n int) {
+		t.Helper()
+		for range n {
+			if err := datastore.SetWebhookDeliveryResult(ctx, dbx, id, ok); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := be.NotifyFailingWebhooks(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	notified := func(user proto.User, want int) {
+		t.Helper()
+		ns, err := be.Notifications(ctx, user, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(ns) != want {
+			t.Fatalf("%s has %d notifications, want %d", user.Username(), len(ns), want)
+		}
+		for _, n := range ns {
+			if n.SubjectType != NotificationSubjectWebhook || n.SubjectID != id || n.Action != "failing" {
+				t.Fatalf("unexpected notification: %+v", n)
+			}
+		}
+	}
+
+	deliver(false, webhook.FailureThreshold-1)
+	notified(maintainer, 0)
+
+	// Admins are notified once the webhook keeps failing.
+	deliver(false, 1)
+	notified(owner, 1)
+	notified(maintainer, 1)
+	notified(reader, 0)
+
+	// Only once per run of failures.
+	deliver(false, 3)
+	notified(maintainer, 1)
+
+	// A successful delivery starts over.
+	deliver(true, 1)
+	deliver(false, webhook.FailureThreshold)
+	notified(maintainer, 2)
+}
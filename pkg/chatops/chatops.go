@@ -0,0 +1,176 @@
+// Package chatops runs repository commands sent from chat services.
+package chatops
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/soft-serve/pkg/access"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/config"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/utils"
+)
+
+// Chat providers.
+const (
+	ProviderSlack  = "slack"
+	ProviderMatrix = "matrix"
+)
+
+// Providers returns all chat providers.
+func Providers() []string {
+	return []string{ProviderSlack, ProviderMatrix}
+}
+
+const help = "Available commands:\n" +
+	"  link                  link this chat account to your Soft Serve user\n" +
+	"  queue                 list open merge requests awaiting your review\n" +
+	"  approve REPO MR_ID    approve a merge request\n" +
+	"  close REPO ISSUE_ID   close an issue\n" +
+	"  help                  show this help"
+
+// Run runs the chat command text sent by the chat account externalID of
+// provider and returns the reply.
+func Run(ctx context.Context, provider string, externalID string, text string) string {
+	args := strings.Fields(text)
+	if len(args) == 0 || args[0] == "help" {
+		return help
+	}
+
+	be := backend.FromContext(ctx)
+	if args[0] == "link" {
+		code, err := be.CreateChatLinkCode(ctx, provider, externalID)
+		if err != nil {
+			return "Failed to create a link code."
+		}
+		return fmt.Sprintf("Run `%s chat link %s` within 10 minutes to link this account.", config.FromContext(ctx).SSHCommand(), code)
+	}
+
+	user, err := be.UserByChatIdentity(ctx, provider, externalID)
+	if err != nil {
+		if errors.Is(err, proto.ErrUserNotFound) {
+			return "This chat account is not linked to a Soft Serve user. Send `link` to link it."
+		}
+		return "Failed to find your Soft Serve user."
+	}
+	ctx = proto.WithUserContext(ctx, user)
+
+	switch args[0] {
+	case "queue":
+		return reviewQueue(ctx, be, user)
+	case "approve":
+		repo, id, err := repoAndID(ctx, be, user, args)
+		if err != nil {
+			return err.Error()
+		}
+		if err := be.Authorize(ctx, user, "repo merge-request approve", repo, []string{strconv.FormatInt(id, 10)}); err != nil {
+			return fmt.Sprintf("Failed to approve %s!%d: %v", repo, id, err)
+		}
+		if err := be.ApproveMergeRequest(ctx, repo, id); err != nil {
+			return fmt.Sprintf("Failed to approve %s!%d: %v", repo, id, err)
+		}
+		return withLink(fmt.Sprintf("Approved %s!%d.", repo, id), config.FromContext(ctx).MergeRequestURL(repo, id))
+	case "close":
+		repo, id, err := repoAndID(ctx, be, user, args)
+		if err != nil {
+			return err.Error()
+		}
+		issue, err := be.IssueByNumber(ctx, repo, id)
+		if err != nil {
+			return fmt.Sprintf("Issue %s#%d not found.", repo, id)
+		}
+		if err := be.Authorize(ctx, user, "repo issue close", repo, []string{strconv.FormatInt(id, 10)}); err != nil {
+			return fmt.Sprintf("Failed to close %s#%d: %v", repo, id, err)
+		}
+		if err := be.CloseIssue(ctx, repo, issue.ID, models.IssueCloseReasonCompleted); err != nil {
+			return fmt.Sprintf("Failed to close %s#%d: %v", repo, id, err)
+		}
+		return withLink(fmt.Sprintf("Closed %s#%d.", repo, id), config.FromContext(ctx).IssueURL(repo, id))
+	default:
+		return fmt.Sprintf("Unknown command %q.\n\n%s", args[0], help)
+	}
+}
+
+// RunOnce runs the chat command text like Run, once per message ID: chat
+// services retry deliveries they aren't sure went through, and retried
+// messages get the reply of the first run instead of running again. ok is
+// false while the first run of the message hasn't finished.
+func RunOnce(ctx context.Context, provider string, externalID string, messageID string, text string) (reply string, ok bool) {
+	be := backend.FromContext(ctx)
+	user, err := be.UserByChatIdentity(ctx, provider, externalID)
+	if err != nil {
+		// Commands of unlinked accounts only reply.
+		return Run(ctx, provider, externalID, text), true
+	}
+
+	resp, _, err := be.Idempotent(proto.WithUserContext(ctx, user), provider+":"+messageID, "chat "+text,
+		func() (backend.IdempotentResponse, error) {
+			return backend.IdempotentResponse{Body: []byte(Run(ctx, provider, externalID, text))}, nil
+		})
+	switch {
+	case errors.Is(err, backend.ErrIdempotencyKeyInUse):
+		return "", false
+	case err != nil:
+		return "Failed to run the command.", true
+	}
+	return string(resp.Body), true
+}
+
+func reviewQueue(ctx context.Context, be *backend.Backend, user proto.User) string {
+	items, err := be.ReviewQueue(ctx, user)
+	if err != nil {
+		return "Failed to list your review queue."
+	}
+	if len(items) == 0 {
+		return "Your review queue is empty."
+	}
+
+	cfg := config.FromContext(ctx)
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%d merge request(s) awaiting review:", len(items))
+	for _, i := range items {
+		mr := i.MergeRequest
+		line := fmt.Sprintf("\n• %s!%d %s (%s → %s)", i.Repository.Name(), mr.ID, mr.Title, mr.SourceBranch, mr.TargetBranch)
+		sb.WriteString(withLink(line, cfg.MergeRequestURL(i.Repository.Name(), mr.ID)))
+	}
+
+	return sb.String()
+}
+
+// repoAndID parses the REPO ID arguments of a command and checks that user
+// can write to the repository.
+func repoAndID(ctx context.Context, be *backend.Backend, user proto.User, args []string) (string, int64, error) {
+	if len(args) != 3 {
+		return "", 0, fmt.Errorf("Usage: %s REPO ID", args[0]) //nolint:stylecheck
+	}
+
+	repo := utils.SanitizeRepo(args[1])
+	id, err := strconv.ParseInt(strings.TrimLeft(args[2], "#!"), 10, 64)
+	if err != nil {
+		return "", 0, fmt.Errorf("Invalid ID %q.", args[2]) //nolint:stylecheck
+	}
+
+	switch auth := be.AccessLevelForUser(ctx, repo, user); {
+	case auth < access.ReadOnlyAccess:
+		return "", 0, fmt.Errorf("Repository %q not found.", repo) //nolint:stylecheck
+	case auth < access.ReadWriteAccess:
+		return "", 0, fmt.Errorf("You don't have write access to %q.", repo) //nolint:stylecheck
+	}
+
+	return repo, id, nil
+}
+
+// withLink appends the web URL of an issue or merge request to a reply, if
+// there is one.
+func withLink(reply string, url string) string {
+	if url == "" {
+		return reply
+	}
+
+	return reply + " " + url
+}
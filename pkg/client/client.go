@@ -0,0 +1,218 @@
+// Package client is a Go client for Soft Serve servers.
+//
+// It runs the commands of the SSH command interface, e.g. "repo issue create",
+// and decodes their output into typed values, so Go programs can integrate
+// with a server without shelling out to ssh. Repository event streams are
+// read from the HTTP server.
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// ErrNoHTTPURL is returned when using the HTTP server of a client configured
+// without an HTTP URL.
+var ErrNoHTTPURL = errors.New("client: no HTTP URL configured")
+
+// Config is the configuration of a Client.
+type Config struct {
+	// Addr is the address of the SSH server, e.g. "localhost:23231".
+	Addr string
+
+	// User is the SSH user name. Users are identified by their key, so it
+	// defaults to "git".
+	User string
+
+	// Signer is the private key the client authenticates with.
+	Signer ssh.Signer
+
+	// HostKeyCallback verifies the host key of the server, see
+	// golang.org/x/crypto/ssh/knownhosts.
+	HostKeyCallback ssh.HostKeyCallback
+
+	// HTTPURL is the URL of the HTTP server, e.g. "http://localhost:23232".
+	// It is only needed for event streams.
+	HTTPURL string
+
+	// Token is the access token the client authenticates to the HTTP server
+	// with, see "token create". Public repositories need none.
+	Token string
+
+	// HTTPClient is the HTTP client used for the HTTP server. It defaults to
+	// http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+// Client is a client of a Soft Serve server. It is safe for concurrent use.
+type Client struct {
+	cfg Config
+
+	mu   sync.Mutex
+	conn *ssh.Client
+}
+
+// New returns a new client of the server configured by cfg. The SSH connection
+// is made on first use.
+func New(cfg Config) (*Client, error) {
+	if cfg.Addr == "" {
+		return nil, errors.New("client: missing SSH address")
+	}
+	if cfg.Signer == nil {
+		return nil, errors.New("client: missing signer")
+	}
+	if cfg.HostKeyCallback == nil {
+		return nil, errors.New("client: missing host key callback")
+	}
+	if cfg.User == "" {
+		cfg.User = "git"
+	}
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = http.DefaultClient
+	}
+	cfg.HTTPURL = strings.TrimSuffix(cfg.HTTPURL, "/")
+
+	return &Client{cfg: cfg}, nil
+}
+
+// Close closes the SSH connection of the client.
+func (c *Client) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.conn == nil {
+		return nil
+	}
+	err := c.conn.Close()
+	c.conn = nil
+	return err
+}
+
+// CommandError is returned when a command fails on the server.
+type CommandError struct {
+	// Args are the arguments of the command.
+	Args []string
+	// Message is the error the command printed.
+	Message string
+}
+
+// Error implements error.
+func (e *CommandError) Error() string {
+	return fmt.Sprintf("%s: %s", strings.Join(e.Args, " "), e.Message)
+}
+
+// Run runs a command of the SSH command interface, e.g. "repo", "info",
+// "repo1", and returns its output. The server drops empty arguments.
+func (c *Client) Run(ctx context.Context, args ...string) ([]byte, error) {
+	sess, err := c.session(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer sess.Close() // nolint: errcheck
+
+	var stdout, stderr bytes.Buffer
+	sess.Stdout = &stdout
+	sess.Stderr = &stderr
+
+	done := make(chan error, 1)
+	go func() {
+		done <- sess.Run(command(args))
+	}()
+
+	select {
+	case <-ctx.Done():
+		sess.Close() // nolint: errcheck
+		return nil, ctx.Err()
+	case err := <-done:
+		var exitErr *ssh.ExitError
+		if errors.As(err, &exitErr) {
+			msg := strings.TrimSpace(stderr.String())
+			msg = strings.TrimPrefix(msg, "Error: ")
+			if msg == "" {
+				msg = exitErr.Error()
+			}
+			return nil, &CommandError{Args: args, Message: msg}
+		}
+		if err != nil {
+			return nil, err
+		}
+		return stdout.Bytes(), nil
+	}
+}
+
+// session opens an SSH session, connecting to the server unless connected
+// already. A broken connection is replaced.
+func (c *Client) session(ctx context.Context) (*ssh.Session, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.conn != nil {
+		if sess, err := c.conn.NewSession(); err == nil {
+			return sess, nil
+		}
+		c.conn.Close() // nolint: errcheck
+		c.conn = nil
+	}
+
+	conn, err := dial(ctx, c.cfg)
+	if err != nil {
+		return nil, err
+	}
+	sess, err := conn.NewSession()
+	if err != nil {
+		conn.Close() // nolint: errcheck
+		return nil, err
+	}
+
+	c.conn = conn
+	return sess, nil
+}
+
+// dial connects to the SSH server of cfg.
+func dial(ctx context.Context, cfg Config) (*ssh.Client, error) {
+	var d net.Dialer
+	nc, err := d.DialContext(ctx, "tcp", cfg.Addr)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, chans, reqs, err := ssh.NewClientConn(nc, cfg.Addr, &ssh.ClientConfig{
+		User:            cfg.User,
+		Auth:            []ssh.AuthMethod{ssh.PublicKeys(cfg.Signer)},
+		HostKeyCallback: cfg.HostKeyCallback,
+	})
+	if err != nil {
+		nc.Close() // nolint: errcheck
+		return nil, err
+	}
+
+	return ssh.NewClient(conn, chans, reqs), nil
+}
+
+// command returns the command line of args, quoted for the server to split it
+// back into args.
+func command(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		quoted[i] = quote(arg)
+	}
+	return strings.Join(quoted, " ")
+}
+
+// quote quotes s as a single POSIX shell word.
+func quote(s string) string {
+	if s != "" && strings.IndexFunc(s, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("-_./:@,=+", r))
+	}) < 0 {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
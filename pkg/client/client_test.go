@@ -0,0 +1,103 @@
+package client
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+
+	cssh "github.com/charmbracelet/ssh"
+	"github.com/matryer/is"
+	"golang.org/x/crypto/ssh"
+)
+
+// newTestClient returns a client of an SSH server running handler.
+func newTestClient(t *testing.T, handler cssh.Handler) *Client {
+	t.Helper()
+	is := is.New(t)
+
+	_, hostKey, err := ed25519.GenerateKey(rand.Reader)
+	is.NoErr(err)
+	hostSigner, err := ssh.NewSignerFromKey(hostKey)
+	is.NoErr(err)
+	_, userKey, err := ed25519.GenerateKey(rand.Reader)
+	is.NoErr(err)
+	userSigner, err := ssh.NewSignerFromKey(userKey)
+	is.NoErr(err)
+
+	l, err := net.Listen("tcp", "localhost:0")
+	is.NoErr(err)
+	srv := &cssh.Server{
+		Handler: handler,
+		PublicKeyHandler: func(_ cssh.Context, key cssh.PublicKey) bool {
+			return cssh.KeysEqual(key, userSigner.PublicKey())
+		},
+	}
+	srv.AddHostKey(hostSigner)
+	go srv.Serve(l)                   // nolint: errcheck
+	t.Cleanup(func() { srv.Close() }) // nolint: errcheck
+
+	c, err := New(Config{
+		Addr:            l.Addr().String(),
+		Signer:          userSigner,
+		HostKeyCallback: ssh.FixedHostKey(hostSigner.PublicKey()),
+	})
+	is.NoErr(err)
+	t.Cleanup(func() { c.Close() }) // nolint: errcheck
+
+	return c
+}
+
+func TestRun(t *testing.T) {
+	is := is.New(t)
+	c := newTestClient(t, func(s cssh.Session) {
+		args := s.Command()
+		if len(args) > 0 && args[0] == "fail" {
+			fmt.Fprintln(s.Stderr(), "Error: something failed")
+			s.Exit(1) // nolint: errcheck
+			return
+		}
+		json.NewEncoder(s).Encode(args) // nolint: errcheck
+	})
+
+	ctx := context.Background()
+	want := []string{"repo", "issue", "create", "repo1", "It's \"broken\"", "$HOME; rm -rf /", "#1", "a\\b\nc"}
+	for i := 0; i < 2; i++ {
+		out, err := c.Run(ctx, want...)
+		is.NoErr(err)
+		var got []string
+		is.NoErr(json.Unmarshal(out, &got))
+		is.Equal(got, want)
+	}
+
+	_, err := c.Run(ctx, "fail", "now")
+	var cmdErr *CommandError
+	is.True(errors.As(err, &cmdErr))
+	is.Equal(cmdErr.Message, "something failed")
+	is.Equal(err.Error(), "fail now: something failed")
+}
+
+func TestCreateIssue(t *testing.T) {
+	is := is.New(t)
+	for out, want := range map[string]int64{
+		"Created issue SRV-12\nSSH: ssh -p 23231 localhost repo issue show repo1 12\n": 12,
+		"Created issue #3\n": 3,
+	} {
+		c := newTestClient(t, func(s cssh.Session) {
+			fmt.Fprint(s, out)
+		})
+		n, err := c.CreateIssue(context.Background(), "repo1", "title", "")
+		is.NoErr(err)
+		is.Equal(n, want)
+	}
+}
+
+func TestNew(t *testing.T) {
+	is := is.New(t)
+	_, err := New(Config{Addr: "localhost:23231"})
+	is.True(err != nil)
+}
@@ -0,0 +1,125 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Event is an event of a repository event stream.
+type Event struct {
+	// ID is the ID of the event, to resume the stream from, see
+	// EventOptions.Since.
+	ID int64
+	// Type is the event type, e.g. "push" or "issue".
+	Type string
+	// Data is the JSON webhook payload of the event.
+	Data json.RawMessage
+}
+
+// EventOptions are the options of Events.
+type EventOptions struct {
+	// Types only streams the events of these types, e.g. "push".
+	Types []string
+	// Since streams the events following the event with this ID. By default
+	// only new events are streamed.
+	Since int64
+}
+
+// Events streams the events of a repository from the HTTP server and calls fn
+// with each of them until ctx is done, fn returns an error, or the server
+// closes the stream. It returns io.ErrUnexpectedEOF in the latter case; resume
+// with EventOptions.Since set to the ID of the last event.
+func (c *Client) Events(ctx context.Context, repo string, opts EventOptions, fn func(Event) error) error {
+	if c.cfg.HTTPURL == "" {
+		return ErrNoHTTPURL
+	}
+
+	q := url.Values{}
+	if len(opts.Types) > 0 {
+		q.Set("events", strings.Join(opts.Types, ","))
+	}
+	if opts.Since > 0 {
+		q.Set("since", strconv.FormatInt(opts.Since, 10))
+	}
+	u := c.cfg.HTTPURL + "/" + strings.Trim(repo, "/") + "/-/events"
+	if len(q) > 0 {
+		u += "?" + q.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	if c.cfg.Token != "" {
+		req.Header.Set("Authorization", "Token "+c.cfg.Token)
+	}
+
+	resp, err := c.cfg.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close() // nolint: errcheck
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("client: event stream: %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	if err := readEvents(resp.Body, fn); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return err
+	}
+
+	return nil
+}
+
+// readEvents reads server-sent events from r and calls fn with each of them.
+// Comments, e.g. keep-alives, are skipped.
+func readEvents(r io.Reader, fn func(Event) error) error {
+	var ev Event
+	var data []string
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for sc.Scan() {
+		line := sc.Text()
+		if line == "" {
+			if data != nil {
+				ev.Data = json.RawMessage(strings.Join(data, "\n"))
+				if err := fn(ev); err != nil {
+					return err
+				}
+			}
+			ev, data = Event{}, nil
+			continue
+		}
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "id":
+			ev.ID, _ = strconv.ParseInt(value, 10, 64)
+		case "event":
+			ev.Type = value
+		case "data":
+			data = append(data, value)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return err
+	}
+
+	return io.ErrUnexpectedEOF
+}
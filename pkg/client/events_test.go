@@ -0,0 +1,57 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestEvents(t *testing.T) {
+	is := is.New(t)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/org/repo1/-/events" || r.Header.Get("Authorization") != "Token tok" {
+			http.NotFound(w, r)
+			return
+		}
+		is.Equal(r.URL.Query().Get("events"), "push,issue")
+		is.Equal(r.URL.Query().Get("since"), "4")
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, ": keep-alive\n\n")
+		fmt.Fprint(w, "id: 5\nevent: push\ndata: {\"ref\":\"refs/heads/main\"}\n\n")
+		fmt.Fprint(w, "id: 6\nevent: issue\ndata: {\"action\":\"opened\"}\n\n")
+	}))
+	defer srv.Close()
+
+	c := &Client{cfg: Config{HTTPURL: srv.URL, Token: "tok", HTTPClient: srv.Client()}}
+	opts := EventOptions{Types: []string{"push", "issue"}, Since: 4}
+
+	var got []Event
+	err := c.Events(context.Background(), "org/repo1", opts, func(ev Event) error {
+		got = append(got, ev)
+		return nil
+	})
+	is.True(errors.Is(err, io.ErrUnexpectedEOF))
+	is.Equal(len(got), 2)
+	is.Equal(got[0].ID, int64(5))
+	is.Equal(got[0].Type, "push")
+	is.Equal(string(got[0].Data), `{"ref":"refs/heads/main"}`)
+	is.Equal(got[1].Type, "issue")
+
+	// Errors of fn stop the stream.
+	stop := errors.New("stop")
+	err = c.Events(context.Background(), "org/repo1", opts, func(Event) error { return stop })
+	is.True(errors.Is(err, stop))
+
+	// Missing repositories are reported.
+	err = c.Events(context.Background(), "nope", EventOptions{}, func(Event) error { return nil })
+	is.True(err != nil)
+
+	c.cfg.HTTPURL = ""
+	is.True(errors.Is(c.Events(context.Background(), "org/repo1", opts, nil), ErrNoHTTPURL))
+}
@@ -0,0 +1,114 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Issue is an issue of a repository.
+type Issue struct {
+	Number            int64     `json:"number"`
+	Title             string    `json:"title"`
+	State             string    `json:"state"`
+	CloseReason       string    `json:"close_reason,omitempty"`
+	Votes             int       `json:"votes"`
+	Labels            []string  `json:"labels"`
+	Pinned            bool      `json:"pinned"`
+	FirstContribution bool      `json:"first_contribution"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+// ListOptions are the options of the list methods.
+type ListOptions struct {
+	// State only lists the issues, or merge requests, in this state, e.g.
+	// "open".
+	State string
+	// Sort is the field to sort by, e.g. "created" (default) or "updated".
+	Sort string
+	// Order is the sort order, "asc" or "desc" (default).
+	Order string
+	// Limit is the maximum number of results, 0 lists all of them.
+	Limit int
+	// After lists the results following this number, or ID, in order, the
+	// last one of the previous page.
+	After int64
+}
+
+// args returns the command line flags of opts.
+func (opts ListOptions) args() []string {
+	var args []string
+	if opts.State != "" {
+		args = append(args, "--state", opts.State)
+	}
+	if opts.Sort != "" {
+		args = append(args, "--sort", opts.Sort)
+	}
+	if opts.Order != "" {
+		args = append(args, "--order", opts.Order)
+	}
+	if opts.Limit > 0 {
+		args = append(args, "--limit", strconv.Itoa(opts.Limit))
+	}
+	if opts.After > 0 {
+		args = append(args, "--after", strconv.FormatInt(opts.After, 10))
+	}
+	return args
+}
+
+// CreateIssue creates an issue and returns its number.
+func (c *Client) CreateIssue(ctx context.Context, repo, title, description string) (int64, error) {
+	args := []string{"repo", "issue", "create", repo, title}
+	if description != "" {
+		args = append(args, description)
+	}
+	out, err := c.Run(ctx, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	// The issue is printed as "#12", or "SRV-12" with an issue prefix.
+	line, _, _ := strings.Cut(string(out), "\n")
+	ref, ok := strings.CutPrefix(strings.TrimSpace(line), "Created issue ")
+	if !ok {
+		return 0, fmt.Errorf("client: unexpected output: %q", line)
+	}
+	n, err := strconv.ParseInt(ref[strings.LastIndexAny(ref, "#-")+1:], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("client: unexpected issue reference: %q", ref)
+	}
+
+	return n, nil
+}
+
+// ListIssues lists the issues of a repository, pinned issues first.
+func (c *Client) ListIssues(ctx context.Context, repo string, opts ListOptions) ([]Issue, error) {
+	args := append([]string{"repo", "issue", "list", repo, "--json"}, opts.args()...)
+	out, err := c.Run(ctx, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	var issues []Issue
+	if err := json.Unmarshal(out, &issues); err != nil {
+		return nil, err
+	}
+
+	return issues, nil
+}
+
+// CloseIssue closes an issue.
+func (c *Client) CloseIssue(ctx context.Context, repo string, number int64) error {
+	_, err := c.Run(ctx, "repo", "issue", "close", repo, strconv.FormatInt(number, 10))
+	return err
+}
+
+// ReopenIssue reopens a closed issue.
+func (c *Client) ReopenIssue(ctx context.Context, repo string, number int64) error {
+	_, err := c.Run(ctx, "repo", "issue", "reopen", repo, strconv.FormatInt(number, 10))
+	return err
+}
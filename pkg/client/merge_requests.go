@@ -0,0 +1,72 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MergeRequest is a merge request of a repository.
+type MergeRequest struct {
+	ID                int64     `json:"id"`
+	Title             string    `json:"title"`
+	SourceBranch      string    `json:"source_branch"`
+	TargetBranch      string    `json:"target_branch"`
+	State             string    `json:"state"`
+	DependsOn         []int64   `json:"depends_on"`
+	FirstContribution bool      `json:"first_contribution"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+// CreateMergeRequest creates a merge request of the source branch into the
+// target branch and returns its ID.
+func (c *Client) CreateMergeRequest(ctx context.Context, repo, source, target, title, description string) (int64, error) {
+	args := []string{"repo", "merge-request", "create", repo, source, target, title}
+	if description != "" {
+		args = append(args, description)
+	}
+	out, err := c.Run(ctx, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	line, _, _ := strings.Cut(string(out), "\n")
+	id, err := strconv.ParseInt(strings.TrimPrefix(strings.TrimSpace(line), "Created merge request #"), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("client: unexpected output: %q", line)
+	}
+
+	return id, nil
+}
+
+// ListMergeRequests lists the merge requests of a repository.
+func (c *Client) ListMergeRequests(ctx context.Context, repo string, opts ListOptions) ([]MergeRequest, error) {
+	args := append([]string{"repo", "merge-request", "list", repo, "--json"}, opts.args()...)
+	out, err := c.Run(ctx, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	var mrs []MergeRequest
+	if err := json.Unmarshal(out, &mrs); err != nil {
+		return nil, err
+	}
+
+	return mrs, nil
+}
+
+// Merge merges a merge request.
+func (c *Client) Merge(ctx context.Context, repo string, id int64) error {
+	_, err := c.Run(ctx, "repo", "merge-request", "merge", repo, strconv.FormatInt(id, 10))
+	return err
+}
+
+// CloseMergeRequest closes a merge request without merging it.
+func (c *Client) CloseMergeRequest(ctx context.Context, repo string, id int64) error {
+	_, err := c.Run(ctx, "repo", "merge-request", "close", repo, strconv.FormatInt(id, 10))
+	return err
+}
@@ -4,12 +4,14 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/caarlos0/env/v11"
 	"github.com/charmbracelet/soft-serve/pkg/sshutils"
+	"github.com/charmbracelet/soft-serve/pkg/utils"
 	"golang.org/x/crypto/ssh"
 	"gopkg.in/yaml.v3"
 )
@@ -38,6 +40,10 @@ type SSHConfig struct {
 
 	// IdleTimeout is the number of seconds a connection can be idle before it is closed.
 	IdleTimeout int `env:"IDLE_TIMEOUT" yaml:"idle_timeout"`
+
+	// Impersonation lets admins run commands as another user with
+	// "admin sudo", for troubleshooting. Impersonated actions are audited.
+	Impersonation bool `env:"IMPERSONATION" yaml:"impersonation"`
 }
 
 // GitConfig is the Git daemon configuration for the server.
@@ -59,6 +65,36 @@ type GitConfig struct {
 
 	// MaxConnections is the maximum number of concurrent connections.
 	MaxConnections int `env:"MAX_CONNECTIONS" yaml:"max_connections"`
+
+	// CommandTimeout is the maximum number of seconds a git command the
+	// server runs itself, e.g. to merge a merge request, can take.
+	CommandTimeout int `env:"COMMAND_TIMEOUT" yaml:"command_timeout"`
+
+	// ReflogRetention is the number of days ref updates are kept in the
+	// server-side reflogs, along with the objects they point to. 0 keeps them
+	// forever.
+	ReflogRetention int `env:"REFLOG_RETENTION" yaml:"reflog_retention"`
+
+	// MergeHooks runs the repository hooks, e.g. branch protections and commit
+	// rules, for the commits the server creates itself when merging, as if
+	// they were pushed. Rejected merges fail.
+	MergeHooks bool `env:"MERGE_HOOKS" yaml:"merge_hooks"`
+
+	// HiddenRefs are the ref prefixes, e.g. refs/merge-requests/, left out of
+	// the refs advertised to clones and fetches. They can still be fetched
+	// by object ID.
+	HiddenRefs []string `env:"HIDDEN_REFS" yaml:"hidden_refs"`
+
+	// OptimizeThreshold is the number of commits a push must add for the
+	// server to write the commit-graph and reachability bitmaps of the
+	// repository, keeping logs, merge bases and diffs fast on big histories.
+	// 0 disables this.
+	OptimizeThreshold int `env:"OPTIMIZE_THRESHOLD" yaml:"optimize_threshold"`
+
+	// DefaultBranch is the branch HEAD points to in newly created
+	// repositories, e.g. main, master or trunk. When empty, git's
+	// init.defaultBranch applies.
+	DefaultBranch string `env:"DEFAULT_BRANCH" yaml:"default_branch"`
 }
 
 // CORSConfig is the CORS configuration for the server.
@@ -122,6 +158,26 @@ type DBConfig struct {
 
 	// DataSource is the database data source name.
 	DataSource string `env:"DATA_SOURCE" yaml:"data_source"`
+
+	// MaxOpenConns is the maximum number of open connections to the database.
+	// A value of 0 means no limit.
+	MaxOpenConns int `env:"MAX_OPEN_CONNS" yaml:"max_open_conns"`
+
+	// MaxIdleConns is the maximum number of idle connections in the pool.
+	// A value of 0 means no idle connections are kept.
+	MaxIdleConns int `env:"MAX_IDLE_CONNS" yaml:"max_idle_conns"`
+
+	// ConnMaxLifetime is the maximum number of seconds a connection may be
+	// reused. A value of 0 means connections are reused forever.
+	ConnMaxLifetime int `env:"CONN_MAX_LIFETIME" yaml:"conn_max_lifetime"`
+
+	// ConnMaxIdleTime is the maximum number of seconds a connection may be
+	// idle. A value of 0 means connections are not closed due to idle time.
+	ConnMaxIdleTime int `env:"CONN_MAX_IDLE_TIME" yaml:"conn_max_idle_time"`
+
+	// SlowQueryThreshold is the number of milliseconds after which a query is
+	// logged as slow. A value of 0 disables slow query logging.
+	SlowQueryThreshold int `env:"SLOW_QUERY_THRESHOLD" yaml:"slow_query_threshold"`
 }
 
 // LFSConfig is the configuration for Git LFS.
@@ -134,9 +190,184 @@ type LFSConfig struct {
 	SSHEnabled bool `env:"SSH_ENABLED" yaml:"ssh_enabled"`
 }
 
+// LimitsConfig is the configuration for content size limits.
+type LimitsConfig struct {
+	// MaxDescriptionSize is the maximum size in bytes of an issue or merge
+	// request description. A value of 0 means no limit.
+	MaxDescriptionSize int `env:"MAX_DESCRIPTION_SIZE" yaml:"max_description_size"`
+
+	// LargeTextThreshold is the size in bytes above which descriptions are
+	// stored separately and only a truncated preview is kept inline.
+	// A value of 0 disables out of line storage.
+	LargeTextThreshold int `env:"LARGE_TEXT_THRESHOLD" yaml:"large_text_threshold"`
+
+	// MaxProfileReadmeSize is the size in bytes above which profile READMEs
+	// are truncated. A value of 0 means no limit.
+	MaxProfileReadmeSize int `env:"MAX_PROFILE_README_SIZE" yaml:"max_profile_readme_size"`
+
+	// MaxPinnedIssues is the maximum number of issues pinned to the top of the
+	// issue list of a repository. A value of 0 disables pinning.
+	MaxPinnedIssues int `env:"MAX_PINNED_ISSUES" yaml:"max_pinned_issues"`
+
+	// MaxAttachmentSize is the maximum size in bytes of a file attached to an
+	// issue or merge request. A value of 0 disables attachments.
+	MaxAttachmentSize int `env:"MAX_ATTACHMENT_SIZE" yaml:"max_attachment_size"`
+}
+
+// ChatOpsConfig is the configuration for inbound chat commands.
+type ChatOpsConfig struct {
+	// SlackSigningSecret is the signing secret of the Slack app that sends
+	// slash commands to /chatops/slack. Slack commands are disabled when empty.
+	SlackSigningSecret string `env:"SLACK_SIGNING_SECRET" yaml:"slack_signing_secret"`
+
+	// MatrixHomeserverURL is the URL of the Matrix homeserver the application
+	// service is registered with.
+	MatrixHomeserverURL string `env:"MATRIX_HOMESERVER_URL" yaml:"matrix_homeserver_url"`
+
+	// MatrixASToken is the token the application service uses to talk to the
+	// homeserver.
+	MatrixASToken string `env:"MATRIX_AS_TOKEN" yaml:"matrix_as_token"`
+
+	// MatrixHSToken is the token the homeserver uses to talk to the
+	// application service. Matrix commands are disabled when empty.
+	MatrixHSToken string `env:"MATRIX_HS_TOKEN" yaml:"matrix_hs_token"`
+}
+
+// StaleConfig is the configuration for closing inactive issues and merge
+// requests.
+type StaleConfig struct {
+	// DaysUntilStale is the number of days without activity after which an
+	// open issue or merge request is marked stale. A value of 0 disables the
+	// stale policy.
+	DaysUntilStale int `env:"DAYS_UNTIL_STALE" yaml:"days_until_stale"`
+
+	// DaysUntilClose is the number of days a stale issue or merge request is
+	// kept open without activity before it is closed. A value of 0 means stale
+	// issues and merge requests are never closed.
+	DaysUntilClose int `env:"DAYS_UNTIL_CLOSE" yaml:"days_until_close"`
+}
+
+// RetentionConfig is the configuration for purging old data.
+type RetentionConfig struct {
+	// ClosedIssueDays is the number of days closed issues are kept before
+	// they are deleted. A value of 0 keeps them forever.
+	ClosedIssueDays int `env:"CLOSED_ISSUE_DAYS" yaml:"closed_issue_days"`
+
+	// WebhookDeliveryDays is the number of days the logs of webhook
+	// deliveries are kept. A value of 0 keeps them forever.
+	WebhookDeliveryDays int `env:"WEBHOOK_DELIVERY_DAYS" yaml:"webhook_delivery_days"`
+
+	// AuditEventDays is the number of days audit events are kept. A value of
+	// 0 keeps them forever.
+	AuditEventDays int `env:"AUDIT_EVENT_DAYS" yaml:"audit_event_days"`
+
+	// DryRun only logs what the retention job would delete.
+	DryRun bool `env:"DRY_RUN" yaml:"dry_run"`
+}
+
+// UIConfig is the configuration for the SSH terminal UI.
+type UIConfig struct {
+	// SplitPaneWidth is the terminal width from which the issues and merge
+	// requests tabs show the list and the selected item side by side. A value
+	// of 0 disables the split pane layout.
+	SplitPaneWidth int `env:"SPLIT_PANE_WIDTH" yaml:"split_pane_width"`
+}
+
+// SigningConfig is the configuration for signing the commits the server
+// creates, like merge commits.
+type SigningConfig struct {
+	// Format is the signature format, either "ssh" or "openpgp". Commits are
+	// not signed when empty.
+	Format string `env:"FORMAT" yaml:"format"`
+
+	// Key is the path to the SSH private key for the "ssh" format, or the GPG
+	// key ID for the "openpgp" format. It defaults to the SSH server key for
+	// the "ssh" format and to the default GPG key for the "openpgp" format.
+	Key string `env:"KEY" yaml:"key"`
+}
+
 // JobsConfig is the configuration for cron jobs.
 type JobsConfig struct {
-	MirrorPull string `env:"MIRROR_PULL" yaml:"mirror_pull"`
+	MirrorPull      string `env:"MIRROR_PULL" yaml:"mirror_pull"`
+	Stale           string `env:"STALE" yaml:"stale"`
+	IssueSLA        string `env:"ISSUE_SLA" yaml:"issue_sla"`
+	MergeQueue      string `env:"MERGE_QUEUE" yaml:"merge_queue"`
+	TokenExpiry     string `env:"TOKEN_EXPIRY" yaml:"token_expiry"`
+	WebhookFailures string `env:"WEBHOOK_FAILURES" yaml:"webhook_failures"`
+	Dedup           string `env:"DEDUP" yaml:"dedup"`
+	Reflog          string `env:"REFLOG" yaml:"reflog"`
+	Reconcile       string `env:"RECONCILE" yaml:"reconcile"`
+	Retention       string `env:"RETENTION" yaml:"retention"`
+	Optimize        string `env:"OPTIMIZE" yaml:"optimize"`
+}
+
+// PushToCreateConfig is the configuration for creating the repositories users
+// push to that don't exist.
+type PushToCreateConfig struct {
+	// Enabled creates the repositories users push to that don't exist.
+	// Otherwise repositories are only created with "repo create".
+	Enabled bool `env:"ENABLED" yaml:"enabled"`
+
+	// Namespaces are where users can create repositories by pushing: "user"
+	// under their username, e.g. frankie/icecream, "org" under the
+	// organizations they can already write to a repository of, and "any"
+	// anywhere. Admins can create repositories anywhere.
+	Namespaces []string `env:"NAMESPACES" yaml:"namespaces"`
+
+	// UserVisibility is the visibility, "public", "internal" or "private", of the
+	// repositories created under the username of the user pushing.
+	UserVisibility string `env:"USER_VISIBILITY" yaml:"user_visibility"`
+
+	// OrgVisibility is the visibility of the repositories created under an
+	// organization.
+	OrgVisibility string `env:"ORG_VISIBILITY" yaml:"org_visibility"`
+
+	// Visibility is the visibility of the other repositories created by
+	// pushing.
+	Visibility string `env:"VISIBILITY" yaml:"visibility"`
+}
+
+// OutboundConfig is the configuration for the outbound connections of the
+// server, made by webhooks, chat and tracker integrations, mirrors, and
+// imports.
+type OutboundConfig struct {
+	// HTTPProxy is the proxy URL for http requests.
+	HTTPProxy string `env:"HTTP_PROXY" yaml:"http_proxy"`
+
+	// HTTPSProxy is the proxy URL for https requests.
+	HTTPSProxy string `env:"HTTPS_PROXY" yaml:"https_proxy"`
+
+	// NoProxy is a comma separated list of hosts, domains, and networks that
+	// are reached without the proxies.
+	NoProxy string `env:"NO_PROXY" yaml:"no_proxy"`
+
+	// CAFile is the path to a PEM bundle of certificate authorities trusted
+	// besides the system ones.
+	CAFile string `env:"CA_FILE" yaml:"ca_file"`
+
+	// TLSMinVersion is the minimum TLS version, one of "1.0", "1.1", "1.2",
+	// or "1.3". It defaults to "1.2".
+	TLSMinVersion string `env:"TLS_MIN_VERSION" yaml:"tls_min_version"`
+}
+
+// StorageConfig is the configuration for the repository storage.
+type StorageConfig struct {
+	// Volumes are the directories repositories are stored in, relative to
+	// the data path unless absolute, e.g. local disks or network
+	// filesystems mounted on the server. New repositories go to the volume
+	// holding the fewest. It defaults to "repos".
+	Volumes []string `env:"VOLUMES" yaml:"volumes"`
+
+	// ShardLevels is the number of directories, named after the hash of
+	// repository names, repositories are nested in on their volume. It keeps
+	// directories small on instances with many repositories. A value of 0
+	// disables sharding.
+	ShardLevels int `env:"SHARD_LEVELS" yaml:"shard_levels"`
+
+	// ObjectPools enables sharing the objects of repositories with the same
+	// history, like mirrors and imports of the same upstream, through object
+	// pools in the "pools" directory of the data path.
+	ObjectPools bool `env:"OBJECT_POOLS" yaml:"object_pools"`
 }
 
 // Config is the configuration for Soft Serve.
@@ -144,6 +375,10 @@ type Config struct {
 	// Name is the name of the server.
 	Name string `env:"NAME" yaml:"name"`
 
+	// ExternalURL is the URL users reach the web interface at, used to link to
+	// issues and merge requests. It defaults to the HTTP public URL.
+	ExternalURL string `env:"EXTERNAL_URL" yaml:"external_url"`
+
 	// SSH is the configuration for the SSH server.
 	SSH SSHConfig `envPrefix:"SSH_" yaml:"ssh"`
 
@@ -168,6 +403,40 @@ type Config struct {
 	// Jobs is the configuration for cron jobs
 	Jobs JobsConfig `envPrefix:"JOBS_" yaml:"jobs"`
 
+	// Limits is the configuration for content size limits.
+	Limits LimitsConfig `envPrefix:"LIMITS_" yaml:"limits"`
+
+	// ChatOps is the configuration for inbound chat commands.
+	ChatOps ChatOpsConfig `envPrefix:"CHATOPS_" yaml:"chatops"`
+
+	// Stale is the configuration for closing inactive issues and merge
+	// requests.
+	Stale StaleConfig `envPrefix:"STALE_" yaml:"stale"`
+
+	// Retention is the configuration for purging old data.
+	Retention RetentionConfig `envPrefix:"RETENTION_" yaml:"retention"`
+
+	// UI is the configuration for the SSH terminal UI.
+	UI UIConfig `envPrefix:"UI_" yaml:"ui"`
+
+	// Signing is the configuration for signing server-generated commits.
+	Signing SigningConfig `envPrefix:"SIGNING_" yaml:"signing"`
+
+	// Outbound is the configuration for outbound connections.
+	Outbound OutboundConfig `envPrefix:"OUTBOUND_" yaml:"outbound"`
+
+	// Storage is the configuration for the repository storage.
+	Storage StorageConfig `envPrefix:"STORAGE_" yaml:"storage"`
+
+	// PushToCreate is the configuration for creating repositories by
+	// pushing to them.
+	PushToCreate PushToCreateConfig `envPrefix:"PUSH_TO_CREATE_" yaml:"push_to_create"`
+
+	// PolicyFile is the path to a YAML file of authorization rules evaluated
+	// before SSH commands and Git operations. The file is reloaded when it
+	// changes. Policies are disabled when empty.
+	PolicyFile string `env:"POLICY_FILE" yaml:"policy_file"`
+
 	// InitialAdminKeys is a list of public keys that will be added to the list of admins.
 	InitialAdminKeys []string `env:"INITIAL_ADMIN_KEYS" envSeparator:"\n" yaml:"initial_admin_keys"`
 
@@ -189,6 +458,7 @@ func (c *Config) Environ() []string {
 		fmt.Sprintf("SOFT_SERVE_CONFIG_LOCATION=%s", c.ConfigPath()),
 		fmt.Sprintf("SOFT_SERVE_DATA_PATH=%s", c.DataPath),
 		fmt.Sprintf("SOFT_SERVE_NAME=%s", c.Name),
+		fmt.Sprintf("SOFT_SERVE_EXTERNAL_URL=%s", c.ExternalURL),
 		fmt.Sprintf("SOFT_SERVE_INITIAL_ADMIN_KEYS=%s", strings.Join(c.InitialAdminKeys, "\n")),
 		fmt.Sprintf("SOFT_SERVE_SSH_ENABLED=%t", c.SSH.Enabled),
 		fmt.Sprintf("SOFT_SERVE_SSH_LISTEN_ADDR=%s", c.SSH.ListenAddr),
@@ -197,12 +467,19 @@ func (c *Config) Environ() []string {
 		fmt.Sprintf("SOFT_SERVE_SSH_CLIENT_KEY_PATH=%s", c.SSH.ClientKeyPath),
 		fmt.Sprintf("SOFT_SERVE_SSH_MAX_TIMEOUT=%d", c.SSH.MaxTimeout),
 		fmt.Sprintf("SOFT_SERVE_SSH_IDLE_TIMEOUT=%d", c.SSH.IdleTimeout),
+		fmt.Sprintf("SOFT_SERVE_SSH_IMPERSONATION=%t", c.SSH.Impersonation),
 		fmt.Sprintf("SOFT_SERVE_GIT_ENABLED=%t", c.Git.Enabled),
 		fmt.Sprintf("SOFT_SERVE_GIT_LISTEN_ADDR=%s", c.Git.ListenAddr),
 		fmt.Sprintf("SOFT_SERVE_GIT_PUBLIC_URL=%s", c.Git.PublicURL),
 		fmt.Sprintf("SOFT_SERVE_GIT_MAX_TIMEOUT=%d", c.Git.MaxTimeout),
 		fmt.Sprintf("SOFT_SERVE_GIT_IDLE_TIMEOUT=%d", c.Git.IdleTimeout),
 		fmt.Sprintf("SOFT_SERVE_GIT_MAX_CONNECTIONS=%d", c.Git.MaxConnections),
+		fmt.Sprintf("SOFT_SERVE_GIT_COMMAND_TIMEOUT=%d", c.Git.CommandTimeout),
+		fmt.Sprintf("SOFT_SERVE_GIT_REFLOG_RETENTION=%d", c.Git.ReflogRetention),
+		fmt.Sprintf("SOFT_SERVE_GIT_MERGE_HOOKS=%t", c.Git.MergeHooks),
+		fmt.Sprintf("SOFT_SERVE_GIT_HIDDEN_REFS=%s", strings.Join(c.Git.HiddenRefs, ",")),
+		fmt.Sprintf("SOFT_SERVE_GIT_OPTIMIZE_THRESHOLD=%d", c.Git.OptimizeThreshold),
+		fmt.Sprintf("SOFT_SERVE_GIT_DEFAULT_BRANCH=%s", c.Git.DefaultBranch),
 		fmt.Sprintf("SOFT_SERVE_HTTP_ENABLED=%t", c.HTTP.Enabled),
 		fmt.Sprintf("SOFT_SERVE_HTTP_LISTEN_ADDR=%s", c.HTTP.ListenAddr),
 		fmt.Sprintf("SOFT_SERVE_HTTP_TLS_KEY_PATH=%s", c.HTTP.TLSKeyPath),
@@ -217,9 +494,56 @@ func (c *Config) Environ() []string {
 		fmt.Sprintf("SOFT_SERVE_LOG_TIME_FORMAT=%s", c.Log.TimeFormat),
 		fmt.Sprintf("SOFT_SERVE_DB_DRIVER=%s", c.DB.Driver),
 		fmt.Sprintf("SOFT_SERVE_DB_DATA_SOURCE=%s", c.DB.DataSource),
+		fmt.Sprintf("SOFT_SERVE_DB_MAX_OPEN_CONNS=%d", c.DB.MaxOpenConns),
+		fmt.Sprintf("SOFT_SERVE_DB_MAX_IDLE_CONNS=%d", c.DB.MaxIdleConns),
+		fmt.Sprintf("SOFT_SERVE_DB_CONN_MAX_LIFETIME=%d", c.DB.ConnMaxLifetime),
+		fmt.Sprintf("SOFT_SERVE_DB_CONN_MAX_IDLE_TIME=%d", c.DB.ConnMaxIdleTime),
+		fmt.Sprintf("SOFT_SERVE_DB_SLOW_QUERY_THRESHOLD=%d", c.DB.SlowQueryThreshold),
 		fmt.Sprintf("SOFT_SERVE_LFS_ENABLED=%t", c.LFS.Enabled),
 		fmt.Sprintf("SOFT_SERVE_LFS_SSH_ENABLED=%t", c.LFS.SSHEnabled),
 		fmt.Sprintf("SOFT_SERVE_JOBS_MIRROR_PULL=%s", c.Jobs.MirrorPull),
+		fmt.Sprintf("SOFT_SERVE_JOBS_STALE=%s", c.Jobs.Stale),
+		fmt.Sprintf("SOFT_SERVE_JOBS_ISSUE_SLA=%s", c.Jobs.IssueSLA),
+		fmt.Sprintf("SOFT_SERVE_JOBS_MERGE_QUEUE=%s", c.Jobs.MergeQueue),
+		fmt.Sprintf("SOFT_SERVE_JOBS_TOKEN_EXPIRY=%s", c.Jobs.TokenExpiry),
+		fmt.Sprintf("SOFT_SERVE_JOBS_WEBHOOK_FAILURES=%s", c.Jobs.WebhookFailures),
+		fmt.Sprintf("SOFT_SERVE_JOBS_DEDUP=%s", c.Jobs.Dedup),
+		fmt.Sprintf("SOFT_SERVE_JOBS_REFLOG=%s", c.Jobs.Reflog),
+		fmt.Sprintf("SOFT_SERVE_JOBS_RECONCILE=%s", c.Jobs.Reconcile),
+		fmt.Sprintf("SOFT_SERVE_JOBS_RETENTION=%s", c.Jobs.Retention),
+		fmt.Sprintf("SOFT_SERVE_JOBS_OPTIMIZE=%s", c.Jobs.Optimize),
+		fmt.Sprintf("SOFT_SERVE_LIMITS_MAX_DESCRIPTION_SIZE=%d", c.Limits.MaxDescriptionSize),
+		fmt.Sprintf("SOFT_SERVE_LIMITS_LARGE_TEXT_THRESHOLD=%d", c.Limits.LargeTextThreshold),
+		fmt.Sprintf("SOFT_SERVE_LIMITS_MAX_PROFILE_README_SIZE=%d", c.Limits.MaxProfileReadmeSize),
+		fmt.Sprintf("SOFT_SERVE_LIMITS_MAX_PINNED_ISSUES=%d", c.Limits.MaxPinnedIssues),
+		fmt.Sprintf("SOFT_SERVE_LIMITS_MAX_ATTACHMENT_SIZE=%d", c.Limits.MaxAttachmentSize),
+		fmt.Sprintf("SOFT_SERVE_CHATOPS_SLACK_SIGNING_SECRET=%s", c.ChatOps.SlackSigningSecret),
+		fmt.Sprintf("SOFT_SERVE_CHATOPS_MATRIX_HOMESERVER_URL=%s", c.ChatOps.MatrixHomeserverURL),
+		fmt.Sprintf("SOFT_SERVE_CHATOPS_MATRIX_AS_TOKEN=%s", c.ChatOps.MatrixASToken),
+		fmt.Sprintf("SOFT_SERVE_CHATOPS_MATRIX_HS_TOKEN=%s", c.ChatOps.MatrixHSToken),
+		fmt.Sprintf("SOFT_SERVE_STALE_DAYS_UNTIL_STALE=%d", c.Stale.DaysUntilStale),
+		fmt.Sprintf("SOFT_SERVE_STALE_DAYS_UNTIL_CLOSE=%d", c.Stale.DaysUntilClose),
+		fmt.Sprintf("SOFT_SERVE_RETENTION_CLOSED_ISSUE_DAYS=%d", c.Retention.ClosedIssueDays),
+		fmt.Sprintf("SOFT_SERVE_RETENTION_WEBHOOK_DELIVERY_DAYS=%d", c.Retention.WebhookDeliveryDays),
+		fmt.Sprintf("SOFT_SERVE_RETENTION_AUDIT_EVENT_DAYS=%d", c.Retention.AuditEventDays),
+		fmt.Sprintf("SOFT_SERVE_RETENTION_DRY_RUN=%t", c.Retention.DryRun),
+		fmt.Sprintf("SOFT_SERVE_UI_SPLIT_PANE_WIDTH=%d", c.UI.SplitPaneWidth),
+		fmt.Sprintf("SOFT_SERVE_SIGNING_FORMAT=%s", c.Signing.Format),
+		fmt.Sprintf("SOFT_SERVE_SIGNING_KEY=%s", c.Signing.Key),
+		fmt.Sprintf("SOFT_SERVE_OUTBOUND_HTTP_PROXY=%s", c.Outbound.HTTPProxy),
+		fmt.Sprintf("SOFT_SERVE_OUTBOUND_HTTPS_PROXY=%s", c.Outbound.HTTPSProxy),
+		fmt.Sprintf("SOFT_SERVE_OUTBOUND_NO_PROXY=%s", c.Outbound.NoProxy),
+		fmt.Sprintf("SOFT_SERVE_OUTBOUND_CA_FILE=%s", c.Outbound.CAFile),
+		fmt.Sprintf("SOFT_SERVE_OUTBOUND_TLS_MIN_VERSION=%s", c.Outbound.TLSMinVersion),
+		fmt.Sprintf("SOFT_SERVE_STORAGE_VOLUMES=%s", strings.Join(c.Storage.Volumes, ",")),
+		fmt.Sprintf("SOFT_SERVE_STORAGE_SHARD_LEVELS=%d", c.Storage.ShardLevels),
+		fmt.Sprintf("SOFT_SERVE_STORAGE_OBJECT_POOLS=%t", c.Storage.ObjectPools),
+		fmt.Sprintf("SOFT_SERVE_PUSH_TO_CREATE_ENABLED=%t", c.PushToCreate.Enabled),
+		fmt.Sprintf("SOFT_SERVE_PUSH_TO_CREATE_NAMESPACES=%s", strings.Join(c.PushToCreate.Namespaces, ",")),
+		fmt.Sprintf("SOFT_SERVE_PUSH_TO_CREATE_USER_VISIBILITY=%s", c.PushToCreate.UserVisibility),
+		fmt.Sprintf("SOFT_SERVE_PUSH_TO_CREATE_ORG_VISIBILITY=%s", c.PushToCreate.OrgVisibility),
+		fmt.Sprintf("SOFT_SERVE_PUSH_TO_CREATE_VISIBILITY=%s", c.PushToCreate.Visibility),
+		fmt.Sprintf("SOFT_SERVE_POLICY_FILE=%s", c.PolicyFile),
 	}...)
 
 	return envs
@@ -359,12 +683,16 @@ func DefaultConfig() *Config {
 			IdleTimeout:   10 * 60, // 10 minutes
 		},
 		Git: GitConfig{
-			Enabled:        true,
-			ListenAddr:     ":9418",
-			PublicURL:      "git://localhost",
-			MaxTimeout:     0,
-			IdleTimeout:    3,
-			MaxConnections: 32,
+			Enabled:           true,
+			ListenAddr:        ":9418",
+			PublicURL:         "git://localhost",
+			MaxTimeout:        0,
+			IdleTimeout:       3,
+			MaxConnections:    32,
+			CommandTimeout:    60,
+			ReflogRetention:   90,
+			MergeHooks:        true,
+			OptimizeThreshold: 1000,
 		},
 		HTTP: HTTPConfig{
 			Enabled:    true,
@@ -388,13 +716,57 @@ func DefaultConfig() *Config {
 			Driver: "sqlite",
 			DataSource: "soft-serve.db" +
 				"?_pragma=busy_timeout(5000)&_pragma=foreign_keys(1)",
+			MaxOpenConns:       0,
+			MaxIdleConns:       2,
+			ConnMaxLifetime:    0,
+			ConnMaxIdleTime:    0,
+			SlowQueryThreshold: 500,
 		},
 		LFS: LFSConfig{
 			Enabled:    true,
 			SSHEnabled: false,
 		},
 		Jobs: JobsConfig{
-			MirrorPull: "@every 10m",
+			MirrorPull:      "@every 10m",
+			Stale:           "@every 1h",
+			IssueSLA:        "@every 10m",
+			MergeQueue:      "@every 1m",
+			TokenExpiry:     "@every 1h",
+			WebhookFailures: "@every 5m",
+			Dedup:           "@daily",
+			Reflog:          "@daily",
+			Reconcile:       "@daily",
+			Retention:       "@daily",
+			Optimize:        "@every 1m",
+		},
+		Limits: LimitsConfig{
+			MaxDescriptionSize:   1 << 20,  // 1 MiB
+			LargeTextThreshold:   8 << 10,  // 8 KiB
+			MaxProfileReadmeSize: 64 << 10, // 64 KiB
+			MaxPinnedIssues:      3,
+			MaxAttachmentSize:    10 << 20, // 10 MiB
+		},
+		Stale: StaleConfig{
+			DaysUntilClose: 7,
+		},
+		Retention: RetentionConfig{
+			WebhookDeliveryDays: 90,
+		},
+		UI: UIConfig{
+			SplitPaneWidth: 160,
+		},
+		Outbound: OutboundConfig{
+			TLSMinVersion: "1.2",
+		},
+		Storage: StorageConfig{
+			Volumes: []string{"repos"},
+		},
+		PushToCreate: PushToCreateConfig{
+			Enabled:        true,
+			Namespaces:     []string{"any"},
+			UserVisibility: "public",
+			OrgVisibility:  "public",
+			Visibility:     "public",
 		},
 	}
 }
@@ -413,6 +785,7 @@ func (c *Config) Validate() error {
 
 	c.SSH.PublicURL = strings.TrimSuffix(c.SSH.PublicURL, "/")
 	c.HTTP.PublicURL = strings.TrimSuffix(c.HTTP.PublicURL, "/")
+	c.ExternalURL = strings.TrimSuffix(c.ExternalURL, "/")
 
 	if c.SSH.KeyPath != "" && !filepath.IsAbs(c.SSH.KeyPath) {
 		c.SSH.KeyPath = filepath.Join(c.DataPath, c.SSH.KeyPath)
@@ -430,6 +803,80 @@ func (c *Config) Validate() error {
 		c.HTTP.TLSCertPath = filepath.Join(c.DataPath, c.HTTP.TLSCertPath)
 	}
 
+	if c.Outbound.CAFile != "" && !filepath.IsAbs(c.Outbound.CAFile) {
+		c.Outbound.CAFile = filepath.Join(c.DataPath, c.Outbound.CAFile)
+	}
+
+	if err := c.Outbound.Validate(); err != nil {
+		return err
+	}
+
+	// Volumes identify where repositories are stored, they're kept as
+	// configured and resolved by the repository storage.
+	volumes := make([]string, 0, len(c.Storage.Volumes))
+	for _, v := range c.Storage.Volumes {
+		if v = strings.TrimSpace(v); v != "" && !slices.Contains(volumes, v) {
+			volumes = append(volumes, v)
+		}
+	}
+	if len(volumes) == 0 {
+		volumes = append(volumes, "repos")
+	}
+	c.Storage.Volumes = volumes
+
+	if c.Storage.ShardLevels < 0 || c.Storage.ShardLevels > 4 {
+		return fmt.Errorf("invalid storage shard levels %d, must be between 0 and 4", c.Storage.ShardLevels)
+	}
+
+	if err := c.PushToCreate.Validate(); err != nil {
+		return err
+	}
+
+	if c.Git.ReflogRetention < 0 {
+		return fmt.Errorf("invalid reflog retention %d, must be a number of days or 0 to keep reflogs forever", c.Git.ReflogRetention)
+	}
+
+	if c.Git.OptimizeThreshold < 0 {
+		return fmt.Errorf("invalid optimize threshold %d, must be a number of commits or 0 to disable", c.Git.OptimizeThreshold)
+	}
+
+	if c.Git.DefaultBranch = strings.TrimSpace(c.Git.DefaultBranch); c.Git.DefaultBranch != "" {
+		if err := utils.ValidateBranch(c.Git.DefaultBranch); err != nil {
+			return fmt.Errorf("invalid default branch: %w", err)
+		}
+	}
+
+	hiddenRefs := make([]string, 0, len(c.Git.HiddenRefs))
+	for _, ref := range c.Git.HiddenRefs {
+		if ref = strings.TrimSpace(ref); ref == "" || slices.Contains(hiddenRefs, ref) {
+			continue
+		}
+		if !strings.HasPrefix(ref, "refs/") {
+			return fmt.Errorf("invalid hidden ref %q, must start with refs/", ref)
+		}
+		hiddenRefs = append(hiddenRefs, ref)
+	}
+	c.Git.HiddenRefs = hiddenRefs
+
+	if c.Retention.ClosedIssueDays < 0 || c.Retention.WebhookDeliveryDays < 0 || c.Retention.AuditEventDays < 0 {
+		return fmt.Errorf("invalid retention, must be a number of days or 0 to keep data forever")
+	}
+
+	if c.PolicyFile != "" && !filepath.IsAbs(c.PolicyFile) {
+		c.PolicyFile = filepath.Join(c.DataPath, c.PolicyFile)
+	}
+
+	switch c.Signing.Format {
+	case "":
+	case "ssh":
+		if c.Signing.Key != "" && !filepath.IsAbs(c.Signing.Key) {
+			c.Signing.Key = filepath.Join(c.DataPath, c.Signing.Key)
+		}
+	case "openpgp":
+	default:
+		return fmt.Errorf("invalid signing format %q, must be ssh or openpgp", c.Signing.Format)
+	}
+
 	if strings.HasPrefix(c.DB.Driver, "sqlite") && !filepath.IsAbs(c.DB.DataSource) {
 		c.DB.DataSource = filepath.Join(c.DataPath, c.DB.DataSource)
 	}
@@ -473,8 +920,46 @@ func (c *Config) AdminKeys() []ssh.PublicKey {
 	return parseAuthKeys(c.InitialAdminKeys)
 }
 
+// GitCommandTimeout returns the timeout of the git commands the server runs
+// itself. It's negative when there's no timeout, as git commands expect.
+func (c *Config) GitCommandTimeout() time.Duration {
+	if c.Git.CommandTimeout <= 0 {
+		return -1
+	}
+	return time.Duration(c.Git.CommandTimeout) * time.Second
+}
+
 func init() {
 	if ex, err := os.Executable(); err == nil {
 		binPath = filepath.ToSlash(ex)
 	}
 }
+
+// Validate validates the push-to-create configuration.
+func (c *PushToCreateConfig) Validate() error {
+	for _, ns := range c.Namespaces {
+		switch ns {
+		case "user", "org", "any":
+		default:
+			return fmt.Errorf("invalid push-to-create namespace %q, must be one of: user, org, any", ns)
+		}
+	}
+
+	for _, v := range []*string{&c.UserVisibility, &c.OrgVisibility, &c.Visibility} {
+		switch *v {
+		case "":
+			*v = "public"
+		case "public", "internal", "private":
+		default:
+			return fmt.Errorf("invalid push-to-create visibility %q, must be public, internal or private", *v)
+		}
+	}
+
+	return nil
+}
+
+// Allows returns whether users can create repositories by pushing to a
+// namespace kind: "user", "org", or "any".
+func (c PushToCreateConfig) Allows(ns string) bool {
+	return slices.Contains(c.Namespaces, ns) || slices.Contains(c.Namespaces, "any")
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"testing"
+	"time"
 
 	"github.com/matryer/is"
 )
@@ -124,3 +125,76 @@ func TestParseMultipleMethods(t *testing.T) {
 		"PUT",
 	})
 }
+
+func TestGitCommandTimeout(t *testing.T) {
+	is := is.New(t)
+	cfg := DefaultConfig()
+	is.Equal(cfg.GitCommandTimeout(), time.Minute)
+
+	is.NoErr(os.Setenv("SOFT_SERVE_GIT_COMMAND_TIMEOUT", "0"))
+	t.Cleanup(func() { is.NoErr(os.Unsetenv("SOFT_SERVE_GIT_COMMAND_TIMEOUT")) })
+	is.NoErr(cfg.ParseEnv())
+	is.True(cfg.GitCommandTimeout() < 0)
+}
+
+func TestPushToCreate(t *testing.T) {
+	is := is.New(t)
+	cfg := DefaultConfig()
+	is.True(cfg.PushToCreate.Allows("user"))
+	is.True(cfg.PushToCreate.Allows("org"))
+
+	is.NoErr(os.Setenv("SOFT_SERVE_PUSH_TO_CREATE_NAMESPACES", "user,org"))
+	t.Cleanup(func() { is.NoErr(os.Unsetenv("SOFT_SERVE_PUSH_TO_CREATE_NAMESPACES")) })
+	is.NoErr(cfg.ParseEnv())
+	is.Equal(cfg.PushToCreate.Namespaces, []string{"user", "org"})
+	is.True(cfg.PushToCreate.Allows("org"))
+	is.True(!cfg.PushToCreate.Allows("any"))
+
+	cfg.PushToCreate.Namespaces = []string{"team"}
+	is.True(cfg.Validate() != nil)
+	cfg.PushToCreate.Namespaces = nil
+	cfg.PushToCreate.OrgVisibility = "internal"
+	is.NoErr(cfg.Validate())
+	cfg.PushToCreate.OrgVisibility = "secret"
+	is.True(cfg.Validate() != nil)
+}
+
+func TestHiddenRefs(t *testing.T) {
+	is := is.New(t)
+	cfg := DefaultConfig()
+	is.Equal(len(cfg.Git.HiddenRefs), 0)
+
+	is.NoErr(os.Setenv("SOFT_SERVE_GIT_HIDDEN_REFS", "refs/merge-requests/, refs/pipelines/,refs/merge-requests/"))
+	t.Cleanup(func() { is.NoErr(os.Unsetenv("SOFT_SERVE_GIT_HIDDEN_REFS")) })
+	is.NoErr(cfg.ParseEnv())
+	is.NoErr(cfg.Validate())
+	is.Equal(cfg.Git.HiddenRefs, []string{"refs/merge-requests/", "refs/pipelines/"})
+
+	cfg.Git.HiddenRefs = []string{"merge-requests/"}
+	is.True(cfg.Validate() != nil)
+}
+
+func TestOptimizeThreshold(t *testing.T) {
+	is := is.New(t)
+	cfg := DefaultConfig()
+	is.Equal(cfg.Git.OptimizeThreshold, 1000)
+	is.Equal(cfg.Jobs.Optimize, "@every 1m")
+
+	cfg.Git.OptimizeThreshold = -1
+	is.True(cfg.Validate() != nil)
+}
+
+func TestDefaultBranch(t *testing.T) {
+	is := is.New(t)
+	cfg := DefaultConfig()
+	is.Equal(cfg.Git.DefaultBranch, "")
+
+	is.NoErr(os.Setenv("SOFT_SERVE_GIT_DEFAULT_BRANCH", "trunk"))
+	t.Cleanup(func() { is.NoErr(os.Unsetenv("SOFT_SERVE_GIT_DEFAULT_BRANCH")) })
+	is.NoErr(cfg.ParseEnv())
+	is.NoErr(cfg.Validate())
+	is.Equal(cfg.Git.DefaultBranch, "trunk")
+
+	cfg.Git.DefaultBranch = "bad..branch"
+	is.True(cfg.Validate() != nil)
+}
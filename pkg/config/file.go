@@ -11,6 +11,10 @@ var configFileTmpl = template.Must(template.New("config").Parse(`# Soft Serve Se
 # This is the name that will be displayed in the UI.
 name: "{{ .Name }}"
 
+# The URL users reach the web interface at, e.g. behind a reverse proxy.
+# Links to issues and merge requests use it. Defaults to the HTTP public URL.
+#external_url: "{{ .ExternalURL }}"
+
 # Logging configuration.
 log:
   # Log format to use. Valid values are "json", "logfmt", and "text".
@@ -48,6 +52,11 @@ ssh:
   # A value of 0 means no timeout.
   idle_timeout: {{ .SSH.IdleTimeout }}
 
+  # Let admins run commands as another user with "admin sudo", for
+  # troubleshooting. Impersonated actions are recorded in the audit log.
+  # Off by default.
+  impersonation: {{ .SSH.Impersonation }}
+
 # The Git daemon configuration.
 git:
   # Enable the Git daemon.
@@ -70,6 +79,38 @@ git:
   # The maximum number of concurrent connections.
   max_connections: {{ .Git.MaxConnections }}
 
+  # The maximum number of seconds a git command the server runs itself, e.g.
+  # to merge a merge request, can take. Commands are also stopped when the
+  # client that started them disconnects. A value of 0 means no timeout.
+  command_timeout: {{ .Git.CommandTimeout }}
+
+  # The number of days ref updates are kept in the server-side reflogs, along
+  # with the objects they point to, so force-pushed and deleted branches can be
+  # recovered. A value of 0 keeps them forever.
+  reflog_retention: {{ .Git.ReflogRetention }}
+
+  # Run the repository hooks, e.g. branch protections, commit rules and push
+  # webhooks, for the commits the server creates itself when merging merge
+  # requests, as if they were pushed. Merges the hooks reject fail.
+  merge_hooks: {{ .Git.MergeHooks }}
+
+  # The ref prefixes, e.g. "refs/merge-requests/", left out of the refs
+  # advertised to clones and fetches, to keep them fast on repositories with
+  # many internal refs. Hidden refs are hidden from every protocol version,
+  # but can still be fetched by object ID.
+  hidden_refs:{{ range .Git.HiddenRefs }}
+    - "{{ . }}"{{ end }}
+
+  # The number of commits a push must add for the server to write the
+  # commit-graph and reachability bitmaps of the repository, keeping logs,
+  # merge bases and diffs fast on big histories. The "optimize" job does this
+  # in the background. A value of 0 disables this.
+  optimize_threshold: {{ .Git.OptimizeThreshold }}
+
+  # The branch HEAD points to in newly created repositories, e.g. "main",
+  # "master" or "trunk". Leave empty to use git's init.defaultBranch.
+  default_branch: "{{ .Git.DefaultBranch }}"
+
 # The HTTP server configuration.
 http:
   # Enable the HTTP server.
@@ -132,6 +173,20 @@ db:
   # This is driver specific and can be a file path or connection string.
   # Make sure foreign key support is enabled when using SQLite.
   data_source: "{{ .DB.DataSource }}"
+  # The maximum number of open connections to the database.
+  # A value of 0 means no limit.
+  max_open_conns: {{ .DB.MaxOpenConns }}
+  # The maximum number of idle connections kept in the pool.
+  max_idle_conns: {{ .DB.MaxIdleConns }}
+  # The maximum number of seconds a connection may be reused.
+  # A value of 0 means no limit.
+  conn_max_lifetime: {{ .DB.ConnMaxLifetime }}
+  # The maximum number of seconds a connection may be idle before it's closed.
+  # A value of 0 means no limit.
+  conn_max_idle_time: {{ .DB.ConnMaxIdleTime }}
+  # Queries that take longer than this many milliseconds are logged.
+  # A value of 0 disables slow query logging.
+  slow_query_threshold: {{ .DB.SlowQueryThreshold }}
 
 # Git LFS configuration.
 lfs:
@@ -143,6 +198,140 @@ lfs:
 # Cron job configuration
 jobs:
   mirror_pull: "{{ .Jobs.MirrorPull }}"
+  stale: "{{ .Jobs.Stale }}"
+  issue_sla: "{{ .Jobs.IssueSLA }}"
+  merge_queue: "{{ .Jobs.MergeQueue }}"
+  token_expiry: "{{ .Jobs.TokenExpiry }}"
+  webhook_failures: "{{ .Jobs.WebhookFailures }}"
+  dedup: "{{ .Jobs.Dedup }}"
+  reflog: "{{ .Jobs.Reflog }}"
+  reconcile: "{{ .Jobs.Reconcile }}"
+  retention: "{{ .Jobs.Retention }}"
+  optimize: "{{ .Jobs.Optimize }}"
+
+# Content size limits.
+limits:
+  # The maximum size in bytes of an issue or merge request description.
+  # A value of 0 means no limit.
+  max_description_size: {{ .Limits.MaxDescriptionSize }}
+  # Descriptions larger than this many bytes are stored separately and only a
+  # truncated preview is kept with the issue or merge request to keep listings
+  # fast. A value of 0 disables this.
+  large_text_threshold: {{ .Limits.LargeTextThreshold }}
+  # Profile READMEs larger than this many bytes are truncated. A value of 0
+  # means no limit.
+  max_profile_readme_size: {{ .Limits.MaxProfileReadmeSize }}
+  # The maximum number of issues pinned to the top of the issue list of a
+  # repository. A value of 0 disables pinning.
+  max_pinned_issues: {{ .Limits.MaxPinnedIssues }}
+  # The maximum size in bytes of a file attached to an issue or merge request.
+  # A value of 0 disables attachments.
+  max_attachment_size: {{ .Limits.MaxAttachmentSize }}
+
+# Inbound chat commands. Chat users link their account to a Soft Serve user
+# by sending "link" and running the returned SSH command.
+chatops:
+  # The signing secret of the Slack app sending slash commands to
+  # /chatops/slack. Leave empty to disable Slack commands.
+  slack_signing_secret: "{{ .ChatOps.SlackSigningSecret }}"
+  # The Matrix homeserver and the application service tokens from the
+  # registration file. The application service URL is the HTTP server URL.
+  # Leave matrix_hs_token empty to disable Matrix commands.
+  matrix_homeserver_url: "{{ .ChatOps.MatrixHomeserverURL }}"
+  matrix_as_token: "{{ .ChatOps.MatrixASToken }}"
+  matrix_hs_token: "{{ .ChatOps.MatrixHSToken }}"
+
+# Inactive issues and merge requests. Open issues and merge requests without
+# activity for days_until_stale days are marked stale, and closed after
+# days_until_close more days unless there is new activity. Repositories can
+# opt out with "repo stale-exempt REPOSITORY true".
+stale:
+  # A value of 0 disables the stale policy.
+  days_until_stale: {{ .Stale.DaysUntilStale }}
+  # A value of 0 never closes stale issues and merge requests.
+  days_until_close: {{ .Stale.DaysUntilClose }}
+
+# Purging of old data by the retention job. A value of 0 keeps the data
+# forever.
+retention:
+  # The number of days closed issues are kept before they are deleted.
+  closed_issue_days: {{ .Retention.ClosedIssueDays }}
+  # The number of days the logs of webhook deliveries are kept.
+  webhook_delivery_days: {{ .Retention.WebhookDeliveryDays }}
+  # The number of days audit events are kept.
+  audit_event_days: {{ .Retention.AuditEventDays }}
+  # Only log what would be deleted.
+  dry_run: {{ .Retention.DryRun }}
+
+# The SSH terminal UI.
+ui:
+  # The terminal width from which the issues and merge requests tabs show the
+  # list and the selected item side by side. A value of 0 disables it.
+  split_pane_width: {{ .UI.SplitPaneWidth }}
+
+# Signing of the commits the server creates, like merge commits.
+signing:
+  # The signature format, either "ssh" or "openpgp". Leave empty to not sign
+  # commits.
+  format: "{{ .Signing.Format }}"
+  # The path to the SSH private key, or the GPG key ID. Defaults to the SSH
+  # server key for "ssh" and to the default GPG key for "openpgp".
+  key: "{{ .Signing.Key }}"
+
+# Where repositories are stored.
+storage:
+  # The directories repositories are stored in, relative to the data path
+  # unless absolute. Network filesystems and object stores mounted on the
+  # server work too. New repositories go to the volume holding the fewest, and
+  # "repo relocate" under "admin" moves existing ones.
+  volumes:{{ range .Storage.Volumes }}
+    - "{{ . }}"{{ end }}
+  # The number of directories, named after the hash of repository names,
+  # repositories are nested in. A value of 0 disables sharding.
+  shard_levels: {{ .Storage.ShardLevels }}
+  # Share the objects of repositories with the same history, like mirrors and
+  # imports of the same upstream, through object pools.
+  object_pools: {{ .Storage.ObjectPools }}
+
+# Creating the repositories users push to that don't exist.
+push_to_create:
+  # Set to false to only create repositories with "repo create".
+  enabled: {{ .PushToCreate.Enabled }}
+  # Where users can create repositories by pushing: "user" under their
+  # username, e.g. frankie/icecream, "org" under the organizations they can
+  # already write to a repository of, and "any" anywhere. Admins can create
+  # repositories anywhere.
+  namespaces:{{ range .PushToCreate.Namespaces }}
+    - "{{ . }}"{{ end }}
+  # The visibility, "public", "internal" or "private", of the repositories
+  # created under the username of the user pushing, under an organization, and
+  # elsewhere.
+  user_visibility: "{{ .PushToCreate.UserVisibility }}"
+  org_visibility: "{{ .PushToCreate.OrgVisibility }}"
+  visibility: "{{ .PushToCreate.Visibility }}"
+
+# Outbound connections made by webhooks, integrations, mirrors, and imports.
+outbound:
+  # The proxies for http and https requests, and the comma separated hosts,
+  # domains, and networks reached without them.
+  http_proxy: "{{ .Outbound.HTTPProxy }}"
+  https_proxy: "{{ .Outbound.HTTPSProxy }}"
+  no_proxy: "{{ .Outbound.NoProxy }}"
+  # The path to a PEM bundle of certificate authorities to trust besides the
+  # system ones.
+  ca_file: "{{ .Outbound.CAFile }}"
+  # The minimum TLS version, one of "1.0", "1.1", "1.2", or "1.3".
+  tls_min_version: "{{ .Outbound.TLSMinVersion }}"
+
+# The path to a YAML file of authorization rules, evaluated before SSH
+# commands and Git operations. The file is reloaded when it changes. Leave
+# empty to disable policies. For example:
+#
+#   rules:
+#     - action: "repo mr close"
+#       allow: ["alice", "@admins"]
+#       message: "only team leads can close merge requests"
+policy_file: "{{ .PolicyFile }}"
 
 # Additional admin keys.
 #initial_admin_keys:
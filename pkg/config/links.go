@@ -0,0 +1,56 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// SSHCommand returns the ssh command that reaches the server, e.g.
+// "ssh -p 23231 localhost".
+func (c *Config) SSHCommand() string {
+	hostname, port := "localhost", "23231"
+	if u, err := url.Parse(c.SSH.PublicURL); err == nil {
+		hostname, port = u.Hostname(), u.Port()
+	}
+
+	cmd := "ssh"
+	if port != "" && port != "22" {
+		cmd += " -p " + port
+	}
+
+	return cmd + " " + hostname
+}
+
+// WebURL returns the external URL of the web interface. It returns an empty
+// string when the HTTP server is disabled.
+func (c *Config) WebURL() string {
+	if !c.HTTP.Enabled {
+		return ""
+	}
+	if c.ExternalURL != "" {
+		return c.ExternalURL
+	}
+
+	return c.HTTP.PublicURL
+}
+
+// IssueURL returns the web URL of an issue, or an empty string when the HTTP
+// server is disabled.
+func (c *Config) IssueURL(repo string, id int64) string {
+	return c.webLink(repo, "issues", id)
+}
+
+// MergeRequestURL returns the web URL of a merge request, or an empty string
+// when the HTTP server is disabled.
+func (c *Config) MergeRequestURL(repo string, id int64) string {
+	return c.webLink(repo, "merge_requests", id)
+}
+
+func (c *Config) webLink(repo string, kind string, id int64) string {
+	base := c.WebURL()
+	if base == "" {
+		return ""
+	}
+
+	return fmt.Sprintf("%s/%s/-/%s/%d", base, repo, kind, id)
+}
@@ -0,0 +1,24 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestLinks(t *testing.T) {
+	is := is.New(t)
+	cfg := DefaultConfig()
+	is.Equal(cfg.SSHCommand(), "ssh -p 23231 localhost")
+	is.Equal(cfg.IssueURL("repo1", 1), "http://localhost:23232/repo1/-/issues/1")
+	is.Equal(cfg.MergeRequestURL("a/b", 2), "http://localhost:23232/a/b/-/merge_requests/2")
+
+	cfg.SSH.PublicURL = "ssh://git.example.com"
+	cfg.ExternalURL = "https://git.example.com"
+	is.Equal(cfg.SSHCommand(), "ssh git.example.com")
+	is.Equal(cfg.IssueURL("repo1", 1), "https://git.example.com/repo1/-/issues/1")
+
+	cfg.HTTP.Enabled = false
+	is.Equal(cfg.WebURL(), "")
+	is.Equal(cfg.IssueURL("repo1", 1), "")
+}
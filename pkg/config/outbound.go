@@ -0,0 +1,140 @@
+package config
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+// tlsVersions are the versions OutboundConfig.TLSMinVersion accepts, and the
+// matching git http.sslVersion values.
+var tlsVersions = map[string]struct {
+	version uint16
+	git     string
+}{
+	"1.0": {tls.VersionTLS10, "tlsv1.0"},
+	"1.1": {tls.VersionTLS11, "tlsv1.1"},
+	"1.2": {tls.VersionTLS12, "tlsv1.2"},
+	"1.3": {tls.VersionTLS13, "tlsv1.3"},
+}
+
+// minTLSVersion returns the minimum TLS version, "1.2" when empty.
+func (c OutboundConfig) minTLSVersion() (string, error) {
+	v := c.TLSMinVersion
+	if v == "" {
+		v = "1.2"
+	}
+	if _, ok := tlsVersions[v]; !ok {
+		return "", fmt.Errorf("invalid outbound TLS minimum version %q, must be one of 1.0, 1.1, 1.2, 1.3", c.TLSMinVersion)
+	}
+	return v, nil
+}
+
+// Validate validates the outbound configuration.
+func (c OutboundConfig) Validate() error {
+	for _, p := range []string{c.HTTPProxy, c.HTTPSProxy} {
+		if p == "" {
+			continue
+		}
+		if u, err := url.Parse(p); err != nil || u.Host == "" {
+			return fmt.Errorf("invalid outbound proxy URL %q", p)
+		}
+	}
+
+	_, err := c.TLSConfig()
+	return err
+}
+
+// TLSConfig returns the TLS configuration of outbound connections.
+func (c OutboundConfig) TLSConfig() (*tls.Config, error) {
+	v, err := c.minTLSVersion()
+	if err != nil {
+		return nil, err
+	}
+
+	tc := &tls.Config{MinVersion: tlsVersions[v].version} //nolint:gosec
+	if c.CAFile != "" {
+		pem, err := os.ReadFile(c.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading outbound CA file: %w", err)
+		}
+
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in outbound CA file %s", c.CAFile)
+		}
+		tc.RootCAs = pool
+	}
+
+	return tc, nil
+}
+
+// Proxy returns the proxy function of outbound HTTP requests, or nil when no
+// proxy is configured.
+func (c OutboundConfig) Proxy() func(*http.Request) (*url.URL, error) {
+	if c.HTTPProxy == "" && c.HTTPSProxy == "" {
+		return nil
+	}
+
+	proxy := (&httpproxy.Config{
+		HTTPProxy:  c.HTTPProxy,
+		HTTPSProxy: c.HTTPSProxy,
+		NoProxy:    c.NoProxy,
+	}).ProxyFunc()
+	return func(r *http.Request) (*url.URL, error) {
+		return proxy(r.URL)
+	}
+}
+
+// Transport returns an HTTP transport for outbound requests, using the
+// proxies and the TLS configuration.
+func (c OutboundConfig) Transport() (*http.Transport, error) {
+	tc, err := c.TLSConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	t := http.DefaultTransport.(*http.Transport).Clone() //nolint:forcetypeassert
+	t.TLSClientConfig = tc
+	if proxy := c.Proxy(); proxy != nil {
+		t.Proxy = proxy
+	}
+
+	return t, nil
+}
+
+// GitEnv returns the environment variables making Git commands use the
+// proxies and the TLS configuration. Git uses the CA file instead of its
+// default bundle, so it must include the public authorities remotes need.
+func (c OutboundConfig) GitEnv() []string {
+	var envs []string
+	if c.HTTPProxy != "" {
+		envs = append(envs, "http_proxy="+c.HTTPProxy)
+	}
+	if c.HTTPSProxy != "" {
+		envs = append(envs, "https_proxy="+c.HTTPSProxy)
+	}
+	if c.NoProxy != "" {
+		envs = append(envs, "no_proxy="+c.NoProxy)
+	}
+	if c.CAFile != "" {
+		envs = append(envs, "GIT_SSL_CAINFO="+c.CAFile)
+	}
+	if v, err := c.minTLSVersion(); err == nil {
+		envs = append(envs,
+			"GIT_CONFIG_COUNT=1",
+			"GIT_CONFIG_KEY_0=http.sslVersion",
+			"GIT_CONFIG_VALUE_0="+tlsVersions[v].git,
+		)
+	}
+
+	return envs
+}
@@ -0,0 +1,71 @@
+package config
+
+import (
+	"crypto/tls"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestOutboundValidate(t *testing.T) {
+	is := is.New(t)
+	is.NoErr(OutboundConfig{}.Validate())
+	is.NoErr(OutboundConfig{HTTPSProxy: "http://proxy.corp:3128", TLSMinVersion: "1.3"}.Validate())
+	is.True(OutboundConfig{TLSMinVersion: "1.4"}.Validate() != nil)
+	is.True(OutboundConfig{HTTPProxy: "proxy"}.Validate() != nil)
+	is.True(OutboundConfig{CAFile: filepath.Join(t.TempDir(), "missing.pem")}.Validate() != nil)
+
+	empty := filepath.Join(t.TempDir(), "empty.pem")
+	is.NoErr(os.WriteFile(empty, []byte("nothing"), 0o600))
+	is.True(OutboundConfig{CAFile: empty}.Validate() != nil)
+
+	tc, err := OutboundConfig{}.TLSConfig()
+	is.NoErr(err)
+	is.Equal(tc.MinVersion, uint16(tls.VersionTLS12))
+}
+
+func TestOutboundCAFile(t *testing.T) {
+	is := is.New(t)
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	defer srv.Close()
+
+	// The test server certificate isn't trusted by default.
+	transport, err := OutboundConfig{}.Transport()
+	is.NoErr(err)
+	_, err = (&http.Client{Transport: transport}).Get(srv.URL)
+	is.True(err != nil)
+
+	ca := filepath.Join(t.TempDir(), "ca.pem")
+	is.NoErr(os.WriteFile(ca, pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: srv.Certificate().Raw,
+	}), 0o600))
+	transport, err = OutboundConfig{CAFile: ca}.Transport()
+	is.NoErr(err)
+	res, err := (&http.Client{Transport: transport}).Get(srv.URL)
+	is.NoErr(err)
+	res.Body.Close() // nolint: errcheck
+	is.Equal(res.StatusCode, http.StatusOK)
+}
+
+func TestOutboundGitEnv(t *testing.T) {
+	is := is.New(t)
+	is.Equal(OutboundConfig{
+		HTTPSProxy:    "http://proxy.corp:3128",
+		NoProxy:       ".corp",
+		CAFile:        "/etc/corp/ca.pem",
+		TLSMinVersion: "1.3",
+	}.GitEnv(), []string{
+		"https_proxy=http://proxy.corp:3128",
+		"no_proxy=.corp",
+		"GIT_SSL_CAINFO=/etc/corp/ca.pem",
+		"GIT_CONFIG_COUNT=1",
+		"GIT_CONFIG_KEY_0=http.sslVersion",
+		"GIT_CONFIG_VALUE_0=tlsv1.3",
+	})
+}
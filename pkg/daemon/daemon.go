@@ -253,17 +253,9 @@ func (d *GitDaemon) handleClient(conn net.Conn) {
 		d.logger.Debugf("git: connect %s %s %s", c.RemoteAddr(), service, name)
 		defer d.logger.Debugf("git: disconnect %s %s %s", c.RemoteAddr(), service, name)
 
-		// git bare repositories should end in ".git"
-		// https://git-scm.com/docs/gitrepository-layout
-		repo := name + ".git"
-		reposDir := filepath.Join(d.cfg.DataPath, "repos")
-		if err := git.EnsureWithin(reposDir, repo); err != nil {
-			d.logger.Debugf("git: error ensuring repo path: %v", err)
-			d.fatal(c, git.ErrInvalidRepo)
-			return
-		}
-
-		if _, err := d.be.Repository(ctx, repo); err != nil {
+		repoPath, err := d.be.RepositoryPath(ctx, name)
+		if err != nil {
+			d.logger.Debugf("git: error locating repo: %v", err)
 			d.fatal(c, git.ErrInvalidRepo)
 			return
 		}
@@ -277,7 +269,7 @@ func (d *GitDaemon) handleClient(conn net.Conn) {
 		// Environment variables to pass down to git hooks.
 		envs := []string{
 			"SOFT_SERVE_REPO_NAME=" + name,
-			"SOFT_SERVE_REPO_PATH=" + filepath.Join(reposDir, repo),
+			"SOFT_SERVE_REPO_PATH=" + repoPath,
 			"SOFT_SERVE_HOST=" + host,
 			"SOFT_SERVE_LOG_PATH=" + filepath.Join(d.cfg.DataPath, "log", "hooks.log"),
 		}
@@ -297,11 +289,12 @@ func (d *GitDaemon) handleClient(conn net.Conn) {
 		envs = append(envs, d.cfg.Environ()...)
 
 		cmd := git.ServiceCommand{
-			Stdin:  c,
-			Stdout: c,
-			Stderr: c,
-			Env:    envs,
-			Dir:    filepath.Join(reposDir, repo),
+			Stdin:      c,
+			Stdout:     c,
+			Stderr:     c,
+			Env:        envs,
+			Dir:        repoPath,
+			HiddenRefs: d.cfg.Git.HiddenRefs,
 		}
 
 		if err := service.Handler(ctx, cmd); err != nil {
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/charmbracelet/log/v2"
 	"github.com/charmbracelet/soft-serve/pkg/config"
@@ -16,23 +17,36 @@ import (
 // DB is the interface for a Soft Serve database.
 type DB struct {
 	*sqlx.DB
-	logger *log.Logger
+	logger *queryLogger
 }
 
 // Open opens a database connection.
+// If the context has a config.Config, its database connection pool and slow
+// query settings are applied.
 func Open(ctx context.Context, driverName string, dsn string) (*DB, error) {
 	db, err := sqlx.ConnectContext(ctx, driverName, dsn)
 	if err != nil {
 		return nil, err
 	}
 
+	logger := log.FromContext(ctx).WithPrefix("db")
 	d := &DB{
 		DB: db,
+		logger: &queryLogger{
+			logger: logger,
+		},
 	}
 
 	if config.IsVerbose() {
-		logger := log.FromContext(ctx).WithPrefix("db")
-		d.logger = logger
+		d.logger.tracer = logger
+	}
+
+	if cfg := config.FromContext(ctx); cfg != nil {
+		db.SetMaxOpenConns(cfg.DB.MaxOpenConns)
+		db.SetMaxIdleConns(cfg.DB.MaxIdleConns)
+		db.SetConnMaxLifetime(time.Duration(cfg.DB.ConnMaxLifetime) * time.Second)
+		db.SetConnMaxIdleTime(time.Duration(cfg.DB.ConnMaxIdleTime) * time.Second)
+		d.logger.threshold = time.Duration(cfg.DB.SlowQueryThreshold) * time.Millisecond
 	}
 
 	return d, nil
@@ -46,7 +60,7 @@ func (d *DB) Close() error {
 // Tx is a database transaction.
 type Tx struct {
 	*sqlx.Tx
-	logger *log.Logger
+	logger *queryLogger
 }
 
 // Transaction implements db.DB.
@@ -3,137 +3,214 @@ package db
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"runtime"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/log/v2"
 	"github.com/jmoiron/sqlx"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
 )
 
-func trace(l *log.Logger, query string, args ...interface{}) {
-	if l != nil {
-		// Remove newlines and tabs
-		query = strings.ReplaceAll(query, "\t", "")
-		query = strings.TrimSpace(query)
-		l.Debug("trace", "query", query, "args", args)
+var queryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: "soft_serve",
+	Subsystem: "db",
+	Name:      "query_duration_seconds",
+	Help:      "The time it takes to run database queries per store method",
+	Buckets:   []float64{.0005, .001, .005, .01, .05, .1, .25, .5, 1, 2.5, 5, 10},
+}, []string{"method"})
+
+// queryLogger traces queries in verbose mode, records query latency metrics,
+// and logs queries that take longer than the slow query threshold.
+type queryLogger struct {
+	// tracer logs every query and its arguments. It is only set in verbose
+	// mode.
+	tracer *log.Logger
+	// logger logs slow queries.
+	logger *log.Logger
+	// threshold is the duration after which a query is considered slow.
+	// A value of 0 disables slow query logging.
+	threshold time.Duration
+}
+
+// observe traces the query and returns a function that must be called when the
+// query is done.
+// It must be called directly from the database wrapper methods so that the
+// caller of the wrapper can be used as the metrics label.
+func (l *queryLogger) observe(query string, args []interface{}) func() {
+	if l == nil {
+		return func() {}
 	}
+
+	if l.tracer != nil {
+		l.tracer.Debug("trace", "query", cleanQuery(query), "args", args)
+	}
+
+	method := "unknown"
+	if pc, _, _, ok := runtime.Caller(2); ok {
+		if fn := runtime.FuncForPC(pc); fn != nil {
+			method = funcName(fn.Name())
+		}
+	}
+
+	start := time.Now()
+	return func() {
+		elapsed := time.Since(start)
+		queryDuration.WithLabelValues(method).Observe(elapsed.Seconds())
+		if l.threshold > 0 && elapsed >= l.threshold && l.logger != nil {
+			l.logger.Warn("slow query",
+				"method", method,
+				"duration", elapsed,
+				"query", cleanQuery(query),
+				"args", redactArgs(args),
+			)
+		}
+	}
+}
+
+// cleanQuery removes tabs and newlines from the given query.
+func cleanQuery(query string) string {
+	return strings.Join(strings.Fields(query), " ")
+}
+
+// redactArgs replaces query arguments with their types so that sensitive
+// values such as tokens and passwords don't end up in the logs.
+func redactArgs(args []interface{}) []string {
+	redacted := make([]string, len(args))
+	for i, arg := range args {
+		redacted[i] = fmt.Sprintf("<%T>", arg)
+	}
+	return redacted
+}
+
+// funcName returns a short name for the given fully qualified function name.
+// For example, "github.com/charmbracelet/soft-serve/pkg/store/database.(*issueStore).GetIssueByID"
+// becomes "database.issueStore.GetIssueByID".
+func funcName(name string) string {
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+	return strings.NewReplacer("(*", "", ")", "").Replace(name)
 }
 
 // Select is a wrapper around sqlx.Select that logs the query and arguments.
 func (d *DB) Select(dest interface{}, query string, args ...interface{}) error {
-	trace(d.logger, query, args...)
+	defer d.logger.observe(query, args)()
 	return d.DB.Select(dest, query, args...)
 }
 
 // Get is a wrapper around sqlx.Get that logs the query and arguments.
 func (d *DB) Get(dest interface{}, query string, args ...interface{}) error {
-	trace(d.logger, query, args...)
+	defer d.logger.observe(query, args)()
 	return d.DB.Get(dest, query, args...)
 }
 
 // Queryx is a wrapper around sqlx.Queryx that logs the query and arguments.
 func (d *DB) Queryx(query string, args ...interface{}) (*sqlx.Rows, error) {
-	trace(d.logger, query, args...)
+	defer d.logger.observe(query, args)()
 	return d.DB.Queryx(query, args...)
 }
 
 // QueryRowx is a wrapper around sqlx.QueryRowx that logs the query and arguments.
 func (d *DB) QueryRowx(query string, args ...interface{}) *sqlx.Row {
-	trace(d.logger, query, args...)
+	defer d.logger.observe(query, args)()
 	return d.DB.QueryRowx(query, args...)
 }
 
 // Exec is a wrapper around sqlx.Exec that logs the query and arguments.
 func (d *DB) Exec(query string, args ...interface{}) (sql.Result, error) {
-	trace(d.logger, query, args...)
+	defer d.logger.observe(query, args)()
 	return d.DB.Exec(query, args...)
 }
 
 // SelectContext is a wrapper around sqlx.SelectContext that logs the query and arguments.
 func (d *DB) SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
-	trace(d.logger, query, args...)
+	defer d.logger.observe(query, args)()
 	return d.DB.SelectContext(ctx, dest, query, args...)
 }
 
 // GetContext is a wrapper around sqlx.GetContext that logs the query and arguments.
 func (d *DB) GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
-	trace(d.logger, query, args...)
+	defer d.logger.observe(query, args)()
 	return d.DB.GetContext(ctx, dest, query, args...)
 }
 
 // QueryxContext is a wrapper around sqlx.QueryxContext that logs the query and arguments.
 func (d *DB) QueryxContext(ctx context.Context, query string, args ...interface{}) (*sqlx.Rows, error) {
-	trace(d.logger, query, args...)
+	defer d.logger.observe(query, args)()
 	return d.DB.QueryxContext(ctx, query, args...)
 }
 
 // QueryRowxContext is a wrapper around sqlx.QueryRowxContext that logs the query and arguments.
 func (d *DB) QueryRowxContext(ctx context.Context, query string, args ...interface{}) *sqlx.Row {
-	trace(d.logger, query, args...)
+	defer d.logger.observe(query, args)()
 	return d.DB.QueryRowxContext(ctx, query, args...)
 }
 
 // ExecContext is a wrapper around sqlx.ExecContext that logs the query and arguments.
 func (d *DB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
-	trace(d.logger, query, args...)
+	defer d.logger.observe(query, args)()
 	return d.DB.ExecContext(ctx, query, args...)
 }
 
 // Select is a wrapper around sqlx.Select that logs the query and arguments.
 func (t *Tx) Select(dest interface{}, query string, args ...interface{}) error {
-	trace(t.logger, query, args...)
+	defer t.logger.observe(query, args)()
 	return t.Tx.Select(dest, query, args...)
 }
 
 // Get is a wrapper around sqlx.Get that logs the query and arguments.
 func (t *Tx) Get(dest interface{}, query string, args ...interface{}) error {
-	trace(t.logger, query, args...)
+	defer t.logger.observe(query, args)()
 	return t.Tx.Get(dest, query, args...)
 }
 
 // Queryx is a wrapper around sqlx.Queryx that logs the query and arguments.
 func (t *Tx) Queryx(query string, args ...interface{}) (*sqlx.Rows, error) {
-	trace(t.logger, query, args...)
+	defer t.logger.observe(query, args)()
 	return t.Tx.Queryx(query, args...)
 }
 
 // QueryRowx is a wrapper around sqlx.QueryRowx that logs the query and arguments.
 func (t *Tx) QueryRowx(query string, args ...interface{}) *sqlx.Row {
-	trace(t.logger, query, args...)
+	defer t.logger.observe(query, args)()
 	return t.Tx.QueryRowx(query, args...)
 }
 
 // Exec is a wrapper around sqlx.Exec that logs the query and arguments.
 func (t *Tx) Exec(query string, args ...interface{}) (sql.Result, error) {
-	trace(t.logger, query, args...)
+	defer t.logger.observe(query, args)()
 	return t.Tx.Exec(query, args...)
 }
 
 // SelectContext is a wrapper around sqlx.SelectContext that logs the query and arguments.
 func (t *Tx) SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
-	trace(t.logger, query, args...)
+	defer t.logger.observe(query, args)()
 	return t.Tx.SelectContext(ctx, dest, query, args...)
 }
 
 // GetContext is a wrapper around sqlx.GetContext that logs the query and arguments.
 func (t *Tx) GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
-	trace(t.logger, query, args...)
+	defer t.logger.observe(query, args)()
 	return t.Tx.GetContext(ctx, dest, query, args...)
 }
 
 // QueryxContext is a wrapper around sqlx.QueryxContext that logs the query and arguments.
 func (t *Tx) QueryxContext(ctx context.Context, query string, args ...interface{}) (*sqlx.Rows, error) {
-	trace(t.logger, query, args...)
+	defer t.logger.observe(query, args)()
 	return t.Tx.QueryxContext(ctx, query, args...)
 }
 
 // QueryRowxContext is a wrapper around sqlx.QueryRowxContext that logs the query and arguments.
 func (t *Tx) QueryRowxContext(ctx context.Context, query string, args ...interface{}) *sqlx.Row {
-	trace(t.logger, query, args...)
+	defer t.logger.observe(query, args)()
 	return t.Tx.QueryRowxContext(ctx, query, args...)
 }
 
 // ExecContext is a wrapper around sqlx.ExecContext that logs the query and arguments.
 func (t *Tx) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
-	trace(t.logger, query, args...)
+	defer t.logger.observe(query, args)()
 	return t.Tx.ExecContext(ctx, query, args...)
 }
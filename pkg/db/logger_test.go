@@ -0,0 +1,33 @@
+package db
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFuncName(t *testing.T) {
+	for in, want := range map[string]string{
+		"github.com/charmbracelet/soft-serve/pkg/store/database.(*issueStore).GetIssueByID": "database.issueStore.GetIssueByID",
+		"github.com/charmbracelet/soft-serve/pkg/db/migrate.Migrate.func1":                  "migrate.Migrate.func1",
+		"main.main": "main.main",
+	} {
+		if got := funcName(in); got != want {
+			t.Errorf("funcName(%q) => %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestRedactArgs(t *testing.T) {
+	got := redactArgs([]interface{}{int64(1), "secret-token", true, nil})
+	want := []string{"<int64>", "<string>", "<bool>", "<<nil>>"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("redactArgs() => %v, want %v", got, want)
+	}
+}
+
+func TestCleanQuery(t *testing.T) {
+	got := cleanQuery("\n\t\tSELECT * FROM issues\n\t\tWHERE id = ?\n\t")
+	if want := "SELECT * FROM issues WHERE id = ?"; got != want {
+		t.Errorf("cleanQuery() => %q, want %q", got, want)
+	}
+}
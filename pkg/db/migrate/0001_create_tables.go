@@ -4,7 +4,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"strconv"
 
 	"github.com/charmbracelet/soft-serve/pkg/access"
 	"github.com/charmbracelet/soft-serve/pkg/config"
@@ -118,9 +117,11 @@ var createTables = Migration{
 			}
 
 			if hasTable(tx, "collab_old") {
+				// Read-write access was level 2 until the guest access
+				// level was added, and migrated to 3 then.
 				sqlm := `
 				INSERT INTO collabs (id, user_id, repo_id, access_level, created_at, updated_at)
-					SELECT id, user_id, repo_id, ` + strconv.Itoa(int(access.ReadWriteAccess)) + `, created_at, updated_at FROM collab_old;
+					SELECT id, user_id, repo_id, 2, created_at, updated_at FROM collab_old;
 				`
 				if _, err := tx.ExecContext(ctx, sqlm); err != nil {
 					return err
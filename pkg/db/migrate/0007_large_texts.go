@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	largeTextsName    = "large_texts"
+	largeTextsVersion = 7
+)
+
+var largeTexts = Migration{
+	Name:    largeTextsName,
+	Version: largeTextsVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, largeTextsVersion, largeTextsName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, largeTextsVersion, largeTextsName)
+	},
+}
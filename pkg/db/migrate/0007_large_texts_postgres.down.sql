@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS large_texts;
+ALTER TABLE merge_requests DROP COLUMN IF EXISTS description_truncated;
+ALTER TABLE issues DROP COLUMN IF EXISTS description_truncated;
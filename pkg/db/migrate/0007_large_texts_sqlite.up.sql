@@ -0,0 +1,21 @@
+ALTER TABLE issues ADD COLUMN description_truncated BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE merge_requests ADD COLUMN description_truncated BOOLEAN NOT NULL DEFAULT false;
+
+CREATE TABLE IF NOT EXISTS large_texts (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  issue_id INTEGER UNIQUE,
+  merge_request_id INTEGER UNIQUE,
+  content TEXT NOT NULL,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at DATETIME NOT NULL,
+  CONSTRAINT issue_id_fk
+  FOREIGN KEY(issue_id) REFERENCES issues(id)
+  ON DELETE CASCADE
+  ON UPDATE CASCADE,
+  CONSTRAINT merge_request_id_fk
+  FOREIGN KEY(merge_request_id) REFERENCES merge_requests(id)
+  ON DELETE CASCADE
+  ON UPDATE CASCADE,
+  CONSTRAINT single_subject
+  CHECK((issue_id IS NULL) != (merge_request_id IS NULL))
+);
@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	issueMergeRequestIndexesName    = "issue_merge_request_indexes"
+	issueMergeRequestIndexesVersion = 8
+)
+
+var issueMergeRequestIndexes = Migration{
+	Name:    issueMergeRequestIndexesName,
+	Version: issueMergeRequestIndexesVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, issueMergeRequestIndexesVersion, issueMergeRequestIndexesName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, issueMergeRequestIndexesVersion, issueMergeRequestIndexesName)
+	},
+}
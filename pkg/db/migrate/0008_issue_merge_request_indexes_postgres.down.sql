@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_issue_dependencies_depends_on_id_issue_id;
+DROP INDEX IF EXISTS idx_merge_requests_repo_id_created_at;
+DROP INDEX IF EXISTS idx_merge_requests_repo_id_state_updated_at;
+DROP INDEX IF EXISTS idx_issues_repo_id_created_at;
+DROP INDEX IF EXISTS idx_issues_repo_id_state_updated_at;
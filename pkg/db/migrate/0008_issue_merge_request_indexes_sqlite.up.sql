@@ -0,0 +1,5 @@
+CREATE INDEX IF NOT EXISTS idx_issues_repo_id_state_updated_at ON issues(repo_id, state, updated_at);
+CREATE INDEX IF NOT EXISTS idx_issues_repo_id_created_at ON issues(repo_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_merge_requests_repo_id_state_updated_at ON merge_requests(repo_id, state, updated_at);
+CREATE INDEX IF NOT EXISTS idx_merge_requests_repo_id_created_at ON merge_requests(repo_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_issue_dependencies_depends_on_id_issue_id ON issue_dependencies(depends_on_id, issue_id);
@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	webhookFormatName    = "webhook_format"
+	webhookFormatVersion = 9
+)
+
+var webhookFormat = Migration{
+	Name:    webhookFormatName,
+	Version: webhookFormatVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, webhookFormatVersion, webhookFormatName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, webhookFormatVersion, webhookFormatName)
+	},
+}
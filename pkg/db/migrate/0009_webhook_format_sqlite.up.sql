@@ -0,0 +1 @@
+ALTER TABLE webhooks ADD COLUMN format INTEGER NOT NULL DEFAULT 0;
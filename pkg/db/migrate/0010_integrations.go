@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	integrationsName    = "integrations"
+	integrationsVersion = 10
+)
+
+var integrations = Migration{
+	Name:    integrationsName,
+	Version: integrationsVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, integrationsVersion, integrationsName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, integrationsVersion, integrationsName)
+	},
+}
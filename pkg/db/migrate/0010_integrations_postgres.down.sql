@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS integration_events;
+DROP TABLE IF EXISTS integrations;
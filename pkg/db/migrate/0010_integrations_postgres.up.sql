@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS integrations (
+  id SERIAL PRIMARY KEY,
+  repo_id INTEGER NOT NULL,
+  kind INTEGER NOT NULL,
+  url TEXT NOT NULL,
+  secret TEXT NOT NULL,
+  template TEXT NOT NULL,
+  active BOOLEAN NOT NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP NOT NULL,
+  UNIQUE (repo_id, url),
+  CONSTRAINT repo_id_fk
+  FOREIGN KEY(repo_id) REFERENCES repos(id)
+  ON DELETE CASCADE
+  ON UPDATE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS integration_events (
+  id SERIAL PRIMARY KEY,
+  integration_id INTEGER NOT NULL,
+  event INTEGER NOT NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  UNIQUE (integration_id, event),
+  CONSTRAINT integration_id_fk
+  FOREIGN KEY(integration_id) REFERENCES integrations(id)
+  ON DELETE CASCADE
+  ON UPDATE CASCADE
+);
@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	chatIdentitiesName    = "chat_identities"
+	chatIdentitiesVersion = 11
+)
+
+var chatIdentities = Migration{
+	Name:    chatIdentitiesName,
+	Version: chatIdentitiesVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, chatIdentitiesVersion, chatIdentitiesName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, chatIdentitiesVersion, chatIdentitiesName)
+	},
+}
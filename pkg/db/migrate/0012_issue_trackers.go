@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	issueTrackersName    = "issue_trackers"
+	issueTrackersVersion = 12
+)
+
+var issueTrackers = Migration{
+	Name:    issueTrackersName,
+	Version: issueTrackersVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, issueTrackersVersion, issueTrackersName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, issueTrackersVersion, issueTrackersName)
+	},
+}
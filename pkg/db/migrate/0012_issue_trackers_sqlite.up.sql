@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS issue_trackers (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  repo_id INTEGER NOT NULL UNIQUE,
+  kind INTEGER NOT NULL,
+  url TEXT NOT NULL,
+  projects TEXT NOT NULL,
+  username TEXT NOT NULL,
+  token TEXT NOT NULL,
+  done_state TEXT NOT NULL,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at DATETIME NOT NULL,
+  CONSTRAINT repo_id_fk
+  FOREIGN KEY(repo_id) REFERENCES repos(id)
+  ON DELETE CASCADE
+  ON UPDATE CASCADE
+);
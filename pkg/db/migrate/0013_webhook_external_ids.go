@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	webhookExternalIDsName    = "webhook_external_ids"
+	webhookExternalIDsVersion = 13
+)
+
+var webhookExternalIDs = Migration{
+	Name:    webhookExternalIDsName,
+	Version: webhookExternalIDsVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, webhookExternalIDsVersion, webhookExternalIDsName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, webhookExternalIDsVersion, webhookExternalIDsName)
+	},
+}
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_webhooks_repo_id_external_id;
+
+ALTER TABLE webhooks DROP COLUMN IF EXISTS external_id;
@@ -0,0 +1,3 @@
+ALTER TABLE webhooks ADD COLUMN external_id TEXT;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_webhooks_repo_id_external_id ON webhooks(repo_id, external_id);
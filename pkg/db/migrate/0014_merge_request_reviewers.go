@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	mergeRequestReviewersName    = "merge_request_reviewers"
+	mergeRequestReviewersVersion = 14
+)
+
+var mergeRequestReviewers = Migration{
+	Name:    mergeRequestReviewersName,
+	Version: mergeRequestReviewersVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, mergeRequestReviewersVersion, mergeRequestReviewersName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, mergeRequestReviewersVersion, mergeRequestReviewersName)
+	},
+}
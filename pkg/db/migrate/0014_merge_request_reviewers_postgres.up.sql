@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS merge_request_reviewers (
+  id SERIAL PRIMARY KEY,
+  merge_request_id INTEGER NOT NULL,
+  user_id INTEGER NOT NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  UNIQUE (merge_request_id, user_id),
+  CONSTRAINT merge_request_id_fk
+  FOREIGN KEY(merge_request_id) REFERENCES merge_requests(id)
+  ON DELETE CASCADE
+  ON UPDATE CASCADE,
+  CONSTRAINT user_id_fk
+  FOREIGN KEY(user_id) REFERENCES users(id)
+  ON DELETE CASCADE
+  ON UPDATE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_merge_request_reviewers_user_id ON merge_request_reviewers(user_id);
@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	stalePolicyName    = "stale_policy"
+	stalePolicyVersion = 15
+)
+
+var stalePolicy = Migration{
+	Name:    stalePolicyName,
+	Version: stalePolicyVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, stalePolicyVersion, stalePolicyName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, stalePolicyVersion, stalePolicyName)
+	},
+}
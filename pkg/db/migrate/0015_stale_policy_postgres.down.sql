@@ -0,0 +1,3 @@
+ALTER TABLE merge_requests DROP COLUMN IF EXISTS stale_at;
+ALTER TABLE issues DROP COLUMN IF EXISTS stale_at;
+ALTER TABLE repos DROP COLUMN IF EXISTS stale_exempt;
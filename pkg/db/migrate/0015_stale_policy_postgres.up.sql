@@ -0,0 +1,3 @@
+ALTER TABLE repos ADD COLUMN stale_exempt BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE issues ADD COLUMN stale_at TIMESTAMP;
+ALTER TABLE merge_requests ADD COLUMN stale_at TIMESTAMP;
@@ -0,0 +1,3 @@
+ALTER TABLE merge_requests DROP COLUMN stale_at;
+ALTER TABLE issues DROP COLUMN stale_at;
+ALTER TABLE repos DROP COLUMN stale_exempt;
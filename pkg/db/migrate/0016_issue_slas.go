@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	issueSLAsName    = "issue_slas"
+	issueSLAsVersion = 16
+)
+
+var issueSLAs = Migration{
+	Name:    issueSLAsName,
+	Version: issueSLAsVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, issueSLAsVersion, issueSLAsName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, issueSLAsVersion, issueSLAsName)
+	},
+}
@@ -0,0 +1,4 @@
+ALTER TABLE issues DROP COLUMN IF EXISTS sla_breached_at;
+ALTER TABLE issues DROP COLUMN IF EXISTS responded_at;
+
+DROP TABLE IF EXISTS issue_slas;
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS issue_slas (
+  id SERIAL PRIMARY KEY,
+  repo_id INTEGER NOT NULL UNIQUE,
+  response_hours INTEGER NOT NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP NOT NULL,
+  CONSTRAINT repo_id_fk
+  FOREIGN KEY(repo_id) REFERENCES repos(id)
+  ON DELETE CASCADE
+  ON UPDATE CASCADE
+);
+
+ALTER TABLE issues ADD COLUMN responded_at TIMESTAMP;
+ALTER TABLE issues ADD COLUMN sla_breached_at TIMESTAMP;
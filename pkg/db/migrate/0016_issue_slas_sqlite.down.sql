@@ -0,0 +1,4 @@
+ALTER TABLE issues DROP COLUMN sla_breached_at;
+ALTER TABLE issues DROP COLUMN responded_at;
+
+DROP TABLE IF EXISTS issue_slas;
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS issue_slas (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  repo_id INTEGER NOT NULL UNIQUE,
+  response_hours INTEGER NOT NULL,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at DATETIME NOT NULL,
+  CONSTRAINT repo_id_fk
+  FOREIGN KEY(repo_id) REFERENCES repos(id)
+  ON DELETE CASCADE
+  ON UPDATE CASCADE
+);
+
+ALTER TABLE issues ADD COLUMN responded_at DATETIME;
+ALTER TABLE issues ADD COLUMN sla_breached_at DATETIME;
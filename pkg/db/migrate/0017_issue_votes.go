@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	issueVotesName    = "issue_votes"
+	issueVotesVersion = 17
+)
+
+var issueVotes = Migration{
+	Name:    issueVotesName,
+	Version: issueVotesVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, issueVotesVersion, issueVotesName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, issueVotesVersion, issueVotesName)
+	},
+}
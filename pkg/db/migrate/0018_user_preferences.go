@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	userPreferencesName    = "user_preferences"
+	userPreferencesVersion = 18
+)
+
+var userPreferences = Migration{
+	Name:    userPreferencesName,
+	Version: userPreferencesVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, userPreferencesVersion, userPreferencesName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, userPreferencesVersion, userPreferencesName)
+	},
+}
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS user_preferences (
+  id SERIAL PRIMARY KEY,
+  user_id INTEGER NOT NULL UNIQUE,
+  diff_word_wrap BOOLEAN NOT NULL DEFAULT true,
+  diff_ignore_whitespace BOOLEAN NOT NULL DEFAULT false,
+  diff_tab_width INTEGER NOT NULL DEFAULT 4,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP NOT NULL,
+  CONSTRAINT user_id_fk
+  FOREIGN KEY(user_id) REFERENCES users(id)
+  ON DELETE CASCADE
+  ON UPDATE CASCADE
+);
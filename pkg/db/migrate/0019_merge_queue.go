@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	mergeQueueName    = "merge_queue"
+	mergeQueueVersion = 19
+)
+
+var mergeQueue = Migration{
+	Name:    mergeQueueName,
+	Version: mergeQueueVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, mergeQueueVersion, mergeQueueName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, mergeQueueVersion, mergeQueueName)
+	},
+}
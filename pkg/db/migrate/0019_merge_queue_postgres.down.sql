@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS merge_queue_entries;
+ALTER TABLE repos DROP COLUMN IF EXISTS merge_queue;
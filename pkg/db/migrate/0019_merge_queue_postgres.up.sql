@@ -0,0 +1,23 @@
+ALTER TABLE repos ADD COLUMN merge_queue BOOLEAN NOT NULL DEFAULT false;
+
+CREATE TABLE IF NOT EXISTS merge_queue_entries (
+  id SERIAL PRIMARY KEY,
+  repo_id INTEGER NOT NULL,
+  merge_request_id INTEGER NOT NULL UNIQUE,
+  user_id INTEGER NOT NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  CONSTRAINT repo_id_fk
+  FOREIGN KEY(repo_id) REFERENCES repos(id)
+  ON DELETE CASCADE
+  ON UPDATE CASCADE,
+  CONSTRAINT merge_request_id_fk
+  FOREIGN KEY(merge_request_id) REFERENCES merge_requests(id)
+  ON DELETE CASCADE
+  ON UPDATE CASCADE,
+  CONSTRAINT user_id_fk
+  FOREIGN KEY(user_id) REFERENCES users(id)
+  ON DELETE CASCADE
+  ON UPDATE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_merge_queue_entries_repo_id ON merge_queue_entries(repo_id);
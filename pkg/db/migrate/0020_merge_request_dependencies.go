@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	mergeRequestDependenciesName    = "merge_request_dependencies"
+	mergeRequestDependenciesVersion = 20
+)
+
+var mergeRequestDependencies = Migration{
+	Name:    mergeRequestDependenciesName,
+	Version: mergeRequestDependenciesVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, mergeRequestDependenciesVersion, mergeRequestDependenciesName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, mergeRequestDependenciesVersion, mergeRequestDependenciesName)
+	},
+}
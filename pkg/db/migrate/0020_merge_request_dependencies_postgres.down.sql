@@ -0,0 +1 @@
+DROP TABLE IF EXISTS merge_request_dependencies;
@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS merge_request_dependencies (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  merge_request_id INTEGER NOT NULL,
+  depends_on_id INTEGER NOT NULL,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  CONSTRAINT merge_request_id_fk
+  FOREIGN KEY(merge_request_id) REFERENCES merge_requests(id)
+  ON DELETE CASCADE
+  ON UPDATE CASCADE,
+  CONSTRAINT depends_on_id_fk
+  FOREIGN KEY(depends_on_id) REFERENCES merge_requests(id)
+  ON DELETE CASCADE
+  ON UPDATE CASCADE,
+  CONSTRAINT unique_merge_request_dependency
+  UNIQUE(merge_request_id, depends_on_id),
+  CONSTRAINT no_self_merge_request_dependency
+  CHECK(merge_request_id != depends_on_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_merge_request_dependencies_merge_request_id ON merge_request_dependencies(merge_request_id);
+CREATE INDEX IF NOT EXISTS idx_merge_request_dependencies_depends_on_id ON merge_request_dependencies(depends_on_id);
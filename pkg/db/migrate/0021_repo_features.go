@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	repoFeaturesName    = "repo_features"
+	repoFeaturesVersion = 21
+)
+
+var repoFeatures = Migration{
+	Name:    repoFeaturesName,
+	Version: repoFeaturesVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, repoFeaturesVersion, repoFeaturesName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, repoFeaturesVersion, repoFeaturesName)
+	},
+}
@@ -0,0 +1,2 @@
+ALTER TABLE repos DROP COLUMN merge_requests_disabled;
+ALTER TABLE repos DROP COLUMN issues_disabled;
@@ -0,0 +1,2 @@
+ALTER TABLE repos ADD COLUMN issues_disabled BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE repos ADD COLUMN merge_requests_disabled BOOLEAN NOT NULL DEFAULT false;
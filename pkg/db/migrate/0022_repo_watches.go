@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	repoWatchesName    = "repo_watches"
+	repoWatchesVersion = 22
+)
+
+var repoWatches = Migration{
+	Name:    repoWatchesName,
+	Version: repoWatchesVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, repoWatchesVersion, repoWatchesName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, repoWatchesVersion, repoWatchesName)
+	},
+}
@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	activityLogName    = "activity_log"
+	activityLogVersion = 23
+)
+
+var activityLog = Migration{
+	Name:    activityLogName,
+	Version: activityLogVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, activityLogVersion, activityLogName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, activityLogVersion, activityLogName)
+	},
+}
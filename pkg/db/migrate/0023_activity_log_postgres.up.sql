@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS activities (
+  id SERIAL PRIMARY KEY,
+  repo_id INTEGER NOT NULL,
+  actor_id INTEGER,
+  kind TEXT NOT NULL,
+  action TEXT NOT NULL,
+  subject_id INTEGER NOT NULL DEFAULT 0,
+  title TEXT NOT NULL DEFAULT '',
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  CONSTRAINT repo_id_fk
+  FOREIGN KEY(repo_id) REFERENCES repos(id)
+  ON DELETE CASCADE
+  ON UPDATE CASCADE,
+  CONSTRAINT actor_id_fk
+  FOREIGN KEY(actor_id) REFERENCES users(id)
+  ON DELETE SET NULL
+  ON UPDATE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_activities_created_at ON activities(created_at);
+
+ALTER TABLE user_preferences ADD COLUMN hide_news BOOLEAN NOT NULL DEFAULT false;
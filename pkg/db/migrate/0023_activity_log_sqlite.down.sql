@@ -0,0 +1,2 @@
+ALTER TABLE user_preferences DROP COLUMN hide_news;
+DROP TABLE IF EXISTS activities;
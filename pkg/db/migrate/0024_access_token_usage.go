@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	accessTokenUsageName    = "access_token_usage"
+	accessTokenUsageVersion = 24
+)
+
+var accessTokenUsage = Migration{
+	Name:    accessTokenUsageName,
+	Version: accessTokenUsageVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, accessTokenUsageVersion, accessTokenUsageName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, accessTokenUsageVersion, accessTokenUsageName)
+	},
+}
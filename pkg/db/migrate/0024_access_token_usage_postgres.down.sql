@@ -0,0 +1,5 @@
+DELETE FROM notifications WHERE repo_id IS NULL;
+ALTER TABLE notifications ALTER COLUMN repo_id SET NOT NULL;
+ALTER TABLE access_tokens DROP COLUMN expiry_warned_at;
+ALTER TABLE access_tokens DROP COLUMN last_used_ip;
+ALTER TABLE access_tokens DROP COLUMN last_used_at;
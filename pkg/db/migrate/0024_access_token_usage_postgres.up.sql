@@ -0,0 +1,6 @@
+ALTER TABLE access_tokens ADD COLUMN last_used_at TIMESTAMP;
+ALTER TABLE access_tokens ADD COLUMN last_used_ip TEXT;
+ALTER TABLE access_tokens ADD COLUMN expiry_warned_at TIMESTAMP;
+
+-- Notifications about access tokens don't belong to a repository.
+ALTER TABLE notifications ALTER COLUMN repo_id DROP NOT NULL;
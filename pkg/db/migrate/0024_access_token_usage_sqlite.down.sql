@@ -0,0 +1,4 @@
+DELETE FROM notifications WHERE repo_id IS NULL;
+ALTER TABLE access_tokens DROP COLUMN expiry_warned_at;
+ALTER TABLE access_tokens DROP COLUMN last_used_ip;
+ALTER TABLE access_tokens DROP COLUMN last_used_at;
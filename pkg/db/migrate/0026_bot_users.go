@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	botUsersName    = "bot_users"
+	botUsersVersion = 26
+)
+
+var botUsers = Migration{
+	Name:    botUsersName,
+	Version: botUsersVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, botUsersVersion, botUsersName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, botUsersVersion, botUsersName)
+	},
+}
@@ -0,0 +1,3 @@
+ALTER TABLE access_tokens DROP COLUMN scope_repo;
+ALTER TABLE access_tokens DROP COLUMN scope;
+ALTER TABLE users DROP COLUMN bot;
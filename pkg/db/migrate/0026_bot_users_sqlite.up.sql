@@ -0,0 +1,3 @@
+ALTER TABLE users ADD COLUMN bot BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE access_tokens ADD COLUMN scope TEXT NOT NULL DEFAULT '';
+ALTER TABLE access_tokens ADD COLUMN scope_repo TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	accessTokenPinsName    = "access_token_pins"
+	accessTokenPinsVersion = 27
+)
+
+var accessTokenPins = Migration{
+	Name:    accessTokenPinsName,
+	Version: accessTokenPinsVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, accessTokenPinsVersion, accessTokenPinsName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, accessTokenPinsVersion, accessTokenPinsName)
+	},
+}
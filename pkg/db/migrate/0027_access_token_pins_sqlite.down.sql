@@ -0,0 +1,2 @@
+ALTER TABLE access_tokens DROP COLUMN pinned_key;
+ALTER TABLE access_tokens DROP COLUMN allowed_cidrs;
@@ -0,0 +1,2 @@
+ALTER TABLE access_tokens ADD COLUMN allowed_cidrs TEXT NOT NULL DEFAULT '';
+ALTER TABLE access_tokens ADD COLUMN pinned_key TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	oauthAppsName    = "oauth_apps"
+	oauthAppsVersion = 28
+)
+
+var oauthApps = Migration{
+	Name:    oauthAppsName,
+	Version: oauthAppsVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, oauthAppsVersion, oauthAppsName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, oauthAppsVersion, oauthAppsName)
+	},
+}
@@ -0,0 +1,32 @@
+CREATE TABLE IF NOT EXISTS oauth_apps (
+  id SERIAL PRIMARY KEY,
+  name TEXT NOT NULL UNIQUE,
+  client_id TEXT NOT NULL UNIQUE,
+  client_secret TEXT NOT NULL,
+  redirect_uri TEXT NOT NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS oauth_codes (
+  id SERIAL PRIMARY KEY,
+  app_id INTEGER NOT NULL,
+  user_id INTEGER NOT NULL,
+  code TEXT NOT NULL UNIQUE,
+  redirect_uri TEXT NOT NULL,
+  scope TEXT NOT NULL,
+  code_challenge TEXT NOT NULL DEFAULT '',
+  nonce TEXT NOT NULL DEFAULT '',
+  expires_at TIMESTAMP NOT NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  CONSTRAINT app_id_fk
+  FOREIGN KEY(app_id) REFERENCES oauth_apps(id)
+  ON DELETE CASCADE
+  ON UPDATE CASCADE,
+  CONSTRAINT user_id_fk
+  FOREIGN KEY(user_id) REFERENCES users(id)
+  ON DELETE CASCADE
+  ON UPDATE CASCADE
+);
+
+ALTER TABLE access_tokens ADD COLUMN oauth_app_id INTEGER;
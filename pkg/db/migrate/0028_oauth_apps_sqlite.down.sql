@@ -0,0 +1,3 @@
+ALTER TABLE access_tokens DROP COLUMN oauth_app_id;
+DROP TABLE IF EXISTS oauth_codes;
+DROP TABLE IF EXISTS oauth_apps;
@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	webhookFailuresName    = "webhook_failures"
+	webhookFailuresVersion = 29
+)
+
+var webhookFailures = Migration{
+	Name:    webhookFailuresName,
+	Version: webhookFailuresVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, webhookFailuresVersion, webhookFailuresName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, webhookFailuresVersion, webhookFailuresName)
+	},
+}
@@ -0,0 +1,2 @@
+ALTER TABLE webhooks DROP COLUMN failure_notified;
+ALTER TABLE webhooks DROP COLUMN failures;
@@ -0,0 +1,2 @@
+ALTER TABLE webhooks ADD COLUMN failures INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE webhooks ADD COLUMN failure_notified BOOLEAN NOT NULL DEFAULT false;
@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	repoStorageName    = "repo_storage"
+	repoStorageVersion = 30
+)
+
+var repoStorage = Migration{
+	Name:    repoStorageName,
+	Version: repoStorageVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, repoStorageVersion, repoStorageName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, repoStorageVersion, repoStorageName)
+	},
+}
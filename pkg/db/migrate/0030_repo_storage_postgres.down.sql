@@ -0,0 +1,2 @@
+ALTER TABLE repos DROP COLUMN storage_path;
+ALTER TABLE repos DROP COLUMN storage_volume;
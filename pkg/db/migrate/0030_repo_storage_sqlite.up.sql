@@ -0,0 +1,3 @@
+ALTER TABLE repos ADD COLUMN storage_volume TEXT NOT NULL DEFAULT 'repos';
+ALTER TABLE repos ADD COLUMN storage_path TEXT NOT NULL DEFAULT '';
+UPDATE repos SET storage_path = name || '.git';
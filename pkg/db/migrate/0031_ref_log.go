@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	refLogName    = "ref_log"
+	refLogVersion = 31
+)
+
+var refLog = Migration{
+	Name:    refLogName,
+	Version: refLogVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, refLogVersion, refLogName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, refLogVersion, refLogName)
+	},
+}
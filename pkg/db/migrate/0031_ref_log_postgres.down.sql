@@ -0,0 +1 @@
+DROP TABLE IF EXISTS ref_log;
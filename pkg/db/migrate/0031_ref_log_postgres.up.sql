@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS ref_log (
+  id SERIAL PRIMARY KEY,
+  repo_id INTEGER NOT NULL,
+  ref TEXT NOT NULL,
+  old_sha TEXT NOT NULL,
+  new_sha TEXT NOT NULL,
+  action TEXT NOT NULL,
+  user_id INTEGER,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  CONSTRAINT repo_id_fk
+  FOREIGN KEY(repo_id) REFERENCES repos(id)
+  ON DELETE CASCADE
+  ON UPDATE CASCADE,
+  CONSTRAINT user_id_fk
+  FOREIGN KEY(user_id) REFERENCES users(id)
+  ON DELETE SET NULL
+  ON UPDATE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_ref_log_repo_id_ref ON ref_log(repo_id, ref);
+CREATE INDEX IF NOT EXISTS idx_ref_log_created_at ON ref_log(created_at);
@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	protectedBranchesName    = "protected_branches"
+	protectedBranchesVersion = 32
+)
+
+var protectedBranches = Migration{
+	Name:    protectedBranchesName,
+	Version: protectedBranchesVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, protectedBranchesVersion, protectedBranchesName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, protectedBranchesVersion, protectedBranchesName)
+	},
+}
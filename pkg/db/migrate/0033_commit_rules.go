@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	commitRulesName    = "commit_rules"
+	commitRulesVersion = 33
+)
+
+var commitRules = Migration{
+	Name:    commitRulesName,
+	Version: commitRulesVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, commitRulesVersion, commitRulesName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, commitRulesVersion, commitRulesName)
+	},
+}
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS commit_rules (
+  id SERIAL PRIMARY KEY,
+  repo_id INTEGER NOT NULL UNIQUE,
+  conventional BOOLEAN NOT NULL DEFAULT false,
+  max_subject_length INTEGER NOT NULL DEFAULT 0,
+  required_trailers TEXT NOT NULL DEFAULT '',
+  warn_only BOOLEAN NOT NULL DEFAULT false,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP NOT NULL,
+  CONSTRAINT repo_id_fk
+  FOREIGN KEY(repo_id) REFERENCES repos(id)
+  ON DELETE CASCADE
+  ON UPDATE CASCADE
+);
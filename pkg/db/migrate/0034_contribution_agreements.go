@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	contributionAgreementsName    = "contribution_agreements"
+	contributionAgreementsVersion = 34
+)
+
+var contributionAgreements = Migration{
+	Name:    contributionAgreementsName,
+	Version: contributionAgreementsVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, contributionAgreementsVersion, contributionAgreementsName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, contributionAgreementsVersion, contributionAgreementsName)
+	},
+}
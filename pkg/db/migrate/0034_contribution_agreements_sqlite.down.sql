@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS cla_signatures;
+DROP TABLE IF EXISTS contribution_agreements;
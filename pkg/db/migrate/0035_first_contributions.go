@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	firstContributionsName    = "first_contributions"
+	firstContributionsVersion = 35
+)
+
+var firstContributions = Migration{
+	Name:    firstContributionsName,
+	Version: firstContributionsVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, firstContributionsVersion, firstContributionsName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, firstContributionsVersion, firstContributionsName)
+	},
+}
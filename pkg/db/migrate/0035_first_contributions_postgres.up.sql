@@ -0,0 +1,2 @@
+ALTER TABLE issues ADD COLUMN IF NOT EXISTS first_contribution BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE merge_requests ADD COLUMN IF NOT EXISTS first_contribution BOOLEAN NOT NULL DEFAULT false;
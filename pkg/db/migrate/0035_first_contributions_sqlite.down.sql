@@ -0,0 +1,2 @@
+ALTER TABLE merge_requests DROP COLUMN first_contribution;
+ALTER TABLE issues DROP COLUMN first_contribution;
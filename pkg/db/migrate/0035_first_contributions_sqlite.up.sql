@@ -0,0 +1,2 @@
+ALTER TABLE issues ADD COLUMN first_contribution BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE merge_requests ADD COLUMN first_contribution BOOLEAN NOT NULL DEFAULT false;
@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	mergeRequestReviewsName    = "merge_request_reviews"
+	mergeRequestReviewsVersion = 36
+)
+
+var mergeRequestReviews = Migration{
+	Name:    mergeRequestReviewsName,
+	Version: mergeRequestReviewsVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, mergeRequestReviewsVersion, mergeRequestReviewsName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, mergeRequestReviewsVersion, mergeRequestReviewsName)
+	},
+}
@@ -0,0 +1,2 @@
+ALTER TABLE merge_requests ADD COLUMN IF NOT EXISTS reviewed_by INTEGER;
+ALTER TABLE merge_requests ADD COLUMN IF NOT EXISTS reviewed_at TIMESTAMP;
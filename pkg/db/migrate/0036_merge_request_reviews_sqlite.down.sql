@@ -0,0 +1,2 @@
+ALTER TABLE merge_requests DROP COLUMN reviewed_at;
+ALTER TABLE merge_requests DROP COLUMN reviewed_by;
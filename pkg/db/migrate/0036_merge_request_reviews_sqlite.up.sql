@@ -0,0 +1,2 @@
+ALTER TABLE merge_requests ADD COLUMN reviewed_by INTEGER;
+ALTER TABLE merge_requests ADD COLUMN reviewed_at DATETIME;
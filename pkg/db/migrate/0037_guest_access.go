@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	guestAccessName    = "guest_access"
+	guestAccessVersion = 37
+)
+
+var guestAccess = Migration{
+	Name:    guestAccessName,
+	Version: guestAccessVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, guestAccessVersion, guestAccessName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, guestAccessVersion, guestAccessName)
+	},
+}
@@ -0,0 +1,2 @@
+-- Make room for the guest access level between no-access and read-only.
+UPDATE collabs SET access_level = access_level + 1 WHERE access_level >= 1;
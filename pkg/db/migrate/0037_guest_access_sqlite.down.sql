@@ -0,0 +1,2 @@
+DELETE FROM collabs WHERE access_level = 1;
+UPDATE collabs SET access_level = access_level - 1 WHERE access_level >= 2;
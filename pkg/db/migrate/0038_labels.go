@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	labelsName    = "labels"
+	labelsVersion = 38
+)
+
+var labels = Migration{
+	Name:    labelsName,
+	Version: labelsVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, labelsVersion, labelsName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, labelsVersion, labelsName)
+	},
+}
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS issue_labels;
+DROP TABLE IF EXISTS labels;
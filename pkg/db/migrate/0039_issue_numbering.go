@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	issueNumberingName    = "issue_numbering"
+	issueNumberingVersion = 39
+)
+
+var issueNumbering = Migration{
+	Name:    issueNumberingName,
+	Version: issueNumberingVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, issueNumberingVersion, issueNumberingName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, issueNumberingVersion, issueNumberingName)
+	},
+}
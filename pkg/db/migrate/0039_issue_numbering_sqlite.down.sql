@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_issues_repo_id_external_id;
+
+ALTER TABLE issues DROP COLUMN external_id;
+ALTER TABLE repos DROP COLUMN issue_prefix;
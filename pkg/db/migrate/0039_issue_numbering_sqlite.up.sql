@@ -0,0 +1,4 @@
+ALTER TABLE repos ADD COLUMN issue_prefix TEXT NOT NULL DEFAULT '';
+ALTER TABLE issues ADD COLUMN external_id TEXT;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_issues_repo_id_external_id ON issues(repo_id, external_id);
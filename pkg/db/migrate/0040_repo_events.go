@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	repoEventsName    = "repo_events"
+	repoEventsVersion = 40
+)
+
+var repoEvents = Migration{
+	Name:    repoEventsName,
+	Version: repoEventsVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, repoEventsVersion, repoEventsName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, repoEventsVersion, repoEventsName)
+	},
+}
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS repo_events (
+  id SERIAL PRIMARY KEY,
+  repo_id INTEGER NOT NULL,
+  event INTEGER NOT NULL,
+  payload TEXT NOT NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  CONSTRAINT repo_id_fk
+  FOREIGN KEY(repo_id) REFERENCES repos(id)
+  ON DELETE CASCADE
+  ON UPDATE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_repo_events_repo_id_id ON repo_events(repo_id, id);
@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	milestonesName    = "milestones"
+	milestonesVersion = 41
+)
+
+var milestones = Migration{
+	Name:    milestonesName,
+	Version: milestonesVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, milestonesVersion, milestonesName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, milestonesVersion, milestonesName)
+	},
+}
@@ -0,0 +1,46 @@
+CREATE TABLE IF NOT EXISTS milestones (
+  id SERIAL PRIMARY KEY,
+  repo_id INTEGER NOT NULL,
+  title TEXT NOT NULL,
+  description TEXT NOT NULL DEFAULT '',
+  due_date TIMESTAMP,
+  state INTEGER NOT NULL DEFAULT 0,
+  closed_at TIMESTAMP,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP NOT NULL,
+  UNIQUE (repo_id, title),
+  CONSTRAINT repo_id_fk
+  FOREIGN KEY(repo_id) REFERENCES repos(id)
+  ON DELETE CASCADE
+  ON UPDATE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS milestone_issues (
+  id SERIAL PRIMARY KEY,
+  milestone_id INTEGER NOT NULL,
+  issue_id INTEGER NOT NULL UNIQUE,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  CONSTRAINT milestone_id_fk
+  FOREIGN KEY(milestone_id) REFERENCES milestones(id)
+  ON DELETE CASCADE
+  ON UPDATE CASCADE,
+  CONSTRAINT issue_id_fk
+  FOREIGN KEY(issue_id) REFERENCES issues(id)
+  ON DELETE CASCADE
+  ON UPDATE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS milestone_merge_requests (
+  id SERIAL PRIMARY KEY,
+  milestone_id INTEGER NOT NULL,
+  merge_request_id INTEGER NOT NULL UNIQUE,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  CONSTRAINT milestone_id_fk
+  FOREIGN KEY(milestone_id) REFERENCES milestones(id)
+  ON DELETE CASCADE
+  ON UPDATE CASCADE,
+  CONSTRAINT merge_request_id_fk
+  FOREIGN KEY(merge_request_id) REFERENCES merge_requests(id)
+  ON DELETE CASCADE
+  ON UPDATE CASCADE
+);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS milestone_merge_requests;
+DROP TABLE IF EXISTS milestone_issues;
+DROP TABLE IF EXISTS milestones;
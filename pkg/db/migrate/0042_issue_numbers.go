@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	issueNumbersName    = "issue_numbers"
+	issueNumbersVersion = 42
+)
+
+var issueNumbers = Migration{
+	Name:    issueNumbersName,
+	Version: issueNumbersVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, issueNumbersVersion, issueNumbersName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, issueNumbersVersion, issueNumbersName)
+	},
+}
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS issue_sequences;
+
+DROP INDEX IF EXISTS idx_issues_repo_id_number;
+
+ALTER TABLE issues DROP COLUMN number;
@@ -0,0 +1,20 @@
+ALTER TABLE issues ADD COLUMN number INTEGER NOT NULL DEFAULT 0;
+
+UPDATE issues SET number = (
+  SELECT COUNT(*) FROM issues i
+  WHERE i.repo_id = issues.repo_id AND i.id <= issues.id
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_issues_repo_id_number ON issues(repo_id, number);
+
+CREATE TABLE IF NOT EXISTS issue_sequences (
+  repo_id INTEGER PRIMARY KEY,
+  last_number INTEGER NOT NULL DEFAULT 0,
+  CONSTRAINT repo_id_fk
+  FOREIGN KEY(repo_id) REFERENCES repos(id)
+  ON DELETE CASCADE
+  ON UPDATE CASCADE
+);
+
+INSERT INTO issue_sequences (repo_id, last_number)
+SELECT repo_id, MAX(number) FROM issues GROUP BY repo_id;
@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	commitReferencesName    = "commit_references"
+	commitReferencesVersion = 43
+)
+
+var commitReferences = Migration{
+	Name:    commitReferencesName,
+	Version: commitReferencesVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, commitReferencesVersion, commitReferencesName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, commitReferencesVersion, commitReferencesName)
+	},
+}
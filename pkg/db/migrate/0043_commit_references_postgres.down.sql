@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS merge_request_commits;
+DROP TABLE IF EXISTS commit_issue_references;
@@ -0,0 +1,36 @@
+CREATE TABLE IF NOT EXISTS commit_issue_references (
+  id SERIAL PRIMARY KEY,
+  repo_id INTEGER NOT NULL,
+  commit_sha TEXT NOT NULL,
+  issue_id INTEGER NOT NULL,
+  closes BOOLEAN NOT NULL DEFAULT false,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  UNIQUE (repo_id, commit_sha, issue_id),
+  CONSTRAINT repo_id_fk
+  FOREIGN KEY(repo_id) REFERENCES repos(id)
+  ON DELETE CASCADE
+  ON UPDATE CASCADE,
+  CONSTRAINT issue_id_fk
+  FOREIGN KEY(issue_id) REFERENCES issues(id)
+  ON DELETE CASCADE
+  ON UPDATE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS merge_request_commits (
+  id SERIAL PRIMARY KEY,
+  repo_id INTEGER NOT NULL,
+  merge_request_id INTEGER NOT NULL,
+  commit_sha TEXT NOT NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  UNIQUE (merge_request_id, commit_sha),
+  CONSTRAINT repo_id_fk
+  FOREIGN KEY(repo_id) REFERENCES repos(id)
+  ON DELETE CASCADE
+  ON UPDATE CASCADE,
+  CONSTRAINT merge_request_id_fk
+  FOREIGN KEY(merge_request_id) REFERENCES merge_requests(id)
+  ON DELETE CASCADE
+  ON UPDATE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_merge_request_commits_repo_id_commit_sha ON merge_request_commits(repo_id, commit_sha);
@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	issueLocksName    = "issue_locks"
+	issueLocksVersion = 44
+)
+
+var issueLocks = Migration{
+	Name:    issueLocksName,
+	Version: issueLocksVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, issueLocksVersion, issueLocksName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, issueLocksVersion, issueLocksName)
+	},
+}
@@ -0,0 +1 @@
+ALTER TABLE issues ADD COLUMN IF NOT EXISTS locked BOOLEAN NOT NULL DEFAULT false;
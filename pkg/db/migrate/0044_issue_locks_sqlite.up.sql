@@ -0,0 +1 @@
+ALTER TABLE issues ADD COLUMN locked BOOLEAN NOT NULL DEFAULT false;
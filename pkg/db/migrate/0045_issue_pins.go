@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	issuePinsName    = "issue_pins"
+	issuePinsVersion = 45
+)
+
+var issuePins = Migration{
+	Name:    issuePinsName,
+	Version: issuePinsVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, issuePinsVersion, issuePinsName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, issuePinsVersion, issuePinsName)
+	},
+}
@@ -0,0 +1 @@
+ALTER TABLE issues ADD COLUMN IF NOT EXISTS pinned INTEGER;
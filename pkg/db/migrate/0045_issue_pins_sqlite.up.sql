@@ -0,0 +1 @@
+ALTER TABLE issues ADD COLUMN pinned INTEGER;
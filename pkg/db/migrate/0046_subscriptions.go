@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	subscriptionsName    = "subscriptions"
+	subscriptionsVersion = 46
+)
+
+var subscriptions = Migration{
+	Name:    subscriptionsName,
+	Version: subscriptionsVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, subscriptionsVersion, subscriptionsName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, subscriptionsVersion, subscriptionsName)
+	},
+}
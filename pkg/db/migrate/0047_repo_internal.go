@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	repoInternalName    = "repo_internal"
+	repoInternalVersion = 47
+)
+
+var repoInternal = Migration{
+	Name:    repoInternalName,
+	Version: repoInternalVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, repoInternalVersion, repoInternalName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, repoInternalVersion, repoInternalName)
+	},
+}
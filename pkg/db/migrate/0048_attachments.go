@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	attachmentsName    = "attachments"
+	attachmentsVersion = 48
+)
+
+var attachments = Migration{
+	Name:    attachmentsName,
+	Version: attachmentsVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, attachmentsVersion, attachmentsName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, attachmentsVersion, attachmentsName)
+	},
+}
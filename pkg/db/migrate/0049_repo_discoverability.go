@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	repoDiscoverabilityName    = "repo_discoverability"
+	repoDiscoverabilityVersion = 49
+)
+
+var repoDiscoverability = Migration{
+	Name:    repoDiscoverabilityName,
+	Version: repoDiscoverabilityVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, repoDiscoverabilityVersion, repoDiscoverabilityName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, repoDiscoverabilityVersion, repoDiscoverabilityName)
+	},
+}
@@ -0,0 +1,2 @@
+ALTER TABLE repos DROP COLUMN IF EXISTS search_disabled;
+ALTER TABLE repos DROP COLUMN IF EXISTS news_disabled;
@@ -0,0 +1,2 @@
+ALTER TABLE repos DROP COLUMN search_disabled;
+ALTER TABLE repos DROP COLUMN news_disabled;
@@ -0,0 +1,2 @@
+ALTER TABLE repos ADD COLUMN search_disabled BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE repos ADD COLUMN news_disabled BOOLEAN NOT NULL DEFAULT false;
@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	crossReferencesName    = "cross_references"
+	crossReferencesVersion = 50
+)
+
+var crossReferences = Migration{
+	Name:    crossReferencesName,
+	Version: crossReferencesVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, crossReferencesVersion, crossReferencesName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, crossReferencesVersion, crossReferencesName)
+	},
+}
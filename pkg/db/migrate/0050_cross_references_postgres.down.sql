@@ -0,0 +1 @@
+DROP TABLE IF EXISTS cross_references;
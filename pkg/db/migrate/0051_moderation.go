@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	moderationName    = "moderation"
+	moderationVersion = 51
+)
+
+var moderation = Migration{
+	Name:    moderationName,
+	Version: moderationVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, moderationVersion, moderationName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, moderationVersion, moderationName)
+	},
+}
@@ -0,0 +1,4 @@
+ALTER TABLE merge_requests DROP COLUMN hidden;
+ALTER TABLE issues DROP COLUMN hidden;
+DROP TABLE IF EXISTS content_reports;
+DROP TABLE IF EXISTS repo_blocks;
@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	issueClosingCommitsName    = "issue_closing_commits"
+	issueClosingCommitsVersion = 52
+)
+
+var issueClosingCommits = Migration{
+	Name:    issueClosingCommitsName,
+	Version: issueClosingCommitsVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, issueClosingCommitsVersion, issueClosingCommitsName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, issueClosingCommitsVersion, issueClosingCommitsName)
+	},
+}
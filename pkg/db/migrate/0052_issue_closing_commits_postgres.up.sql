@@ -0,0 +1 @@
+ALTER TABLE issues ADD COLUMN IF NOT EXISTS closed_by_commit TEXT;
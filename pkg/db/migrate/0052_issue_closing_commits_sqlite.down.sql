@@ -0,0 +1 @@
+ALTER TABLE issues DROP COLUMN closed_by_commit;
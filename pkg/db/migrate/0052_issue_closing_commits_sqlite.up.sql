@@ -0,0 +1 @@
+ALTER TABLE issues ADD COLUMN closed_by_commit TEXT;
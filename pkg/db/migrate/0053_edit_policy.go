@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	editPolicyName    = "edit_policy"
+	editPolicyVersion = 53
+)
+
+var editPolicy = Migration{
+	Name:    editPolicyName,
+	Version: editPolicyVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, editPolicyVersion, editPolicyName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, editPolicyVersion, editPolicyName)
+	},
+}
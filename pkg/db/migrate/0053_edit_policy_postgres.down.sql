@@ -0,0 +1,5 @@
+ALTER TABLE merge_requests DROP COLUMN IF EXISTS edited_at;
+ALTER TABLE merge_requests DROP COLUMN IF EXISTS edited_by_id;
+ALTER TABLE issues DROP COLUMN IF EXISTS edited_at;
+ALTER TABLE issues DROP COLUMN IF EXISTS edited_by_id;
+ALTER TABLE repos DROP COLUMN IF EXISTS edit_policy;
@@ -0,0 +1,5 @@
+ALTER TABLE repos ADD COLUMN IF NOT EXISTS edit_policy TEXT NOT NULL DEFAULT 'collaborators';
+ALTER TABLE issues ADD COLUMN IF NOT EXISTS edited_by_id INTEGER;
+ALTER TABLE issues ADD COLUMN IF NOT EXISTS edited_at TIMESTAMP;
+ALTER TABLE merge_requests ADD COLUMN IF NOT EXISTS edited_by_id INTEGER;
+ALTER TABLE merge_requests ADD COLUMN IF NOT EXISTS edited_at TIMESTAMP;
@@ -0,0 +1,5 @@
+ALTER TABLE merge_requests DROP COLUMN edited_at;
+ALTER TABLE merge_requests DROP COLUMN edited_by_id;
+ALTER TABLE issues DROP COLUMN edited_at;
+ALTER TABLE issues DROP COLUMN edited_by_id;
+ALTER TABLE repos DROP COLUMN edit_policy;
@@ -0,0 +1,5 @@
+ALTER TABLE repos ADD COLUMN edit_policy TEXT NOT NULL DEFAULT 'collaborators';
+ALTER TABLE issues ADD COLUMN edited_by_id INTEGER;
+ALTER TABLE issues ADD COLUMN edited_at DATETIME;
+ALTER TABLE merge_requests ADD COLUMN edited_by_id INTEGER;
+ALTER TABLE merge_requests ADD COLUMN edited_at DATETIME;
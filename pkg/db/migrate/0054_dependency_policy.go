@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	dependencyPolicyName    = "dependency_policy"
+	dependencyPolicyVersion = 54
+)
+
+var dependencyPolicy = Migration{
+	Name:    dependencyPolicyName,
+	Version: dependencyPolicyVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, dependencyPolicyVersion, dependencyPolicyName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, dependencyPolicyVersion, dependencyPolicyName)
+	},
+}
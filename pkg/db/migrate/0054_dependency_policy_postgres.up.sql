@@ -0,0 +1 @@
+ALTER TABLE repos ADD COLUMN IF NOT EXISTS dependency_policy TEXT NOT NULL DEFAULT 'allow';
@@ -0,0 +1 @@
+ALTER TABLE repos ADD COLUMN dependency_policy TEXT NOT NULL DEFAULT 'allow';
@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	repoVisitsName    = "repo_visits"
+	repoVisitsVersion = 55
+)
+
+var repoVisits = Migration{
+	Name:    repoVisitsName,
+	Version: repoVisitsVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, repoVisitsVersion, repoVisitsName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, repoVisitsVersion, repoVisitsName)
+	},
+}
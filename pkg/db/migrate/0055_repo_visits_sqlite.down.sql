@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_repo_visits_user_id_visited_at;
+DROP TABLE IF EXISTS repo_visits;
@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	datePreferencesName    = "date_preferences"
+	datePreferencesVersion = 56
+)
+
+var datePreferences = Migration{
+	Name:    datePreferencesName,
+	Version: datePreferencesVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, datePreferencesVersion, datePreferencesName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, datePreferencesVersion, datePreferencesName)
+	},
+}
@@ -0,0 +1,3 @@
+ALTER TABLE user_preferences DROP COLUMN IF EXISTS date_style;
+ALTER TABLE user_preferences DROP COLUMN IF EXISTS date_format;
+ALTER TABLE user_preferences DROP COLUMN IF EXISTS timezone;
@@ -0,0 +1,3 @@
+ALTER TABLE user_preferences ADD COLUMN IF NOT EXISTS timezone TEXT NOT NULL DEFAULT '';
+ALTER TABLE user_preferences ADD COLUMN IF NOT EXISTS date_format TEXT NOT NULL DEFAULT 'iso';
+ALTER TABLE user_preferences ADD COLUMN IF NOT EXISTS date_style TEXT NOT NULL DEFAULT 'auto';
@@ -0,0 +1,3 @@
+ALTER TABLE user_preferences DROP COLUMN date_style;
+ALTER TABLE user_preferences DROP COLUMN date_format;
+ALTER TABLE user_preferences DROP COLUMN timezone;
@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	draftIssuesName    = "draft_issues"
+	draftIssuesVersion = 57
+)
+
+var draftIssues = Migration{
+	Name:    draftIssuesName,
+	Version: draftIssuesVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, draftIssuesVersion, draftIssuesName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, draftIssuesVersion, draftIssuesName)
+	},
+}
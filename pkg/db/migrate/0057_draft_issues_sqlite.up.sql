@@ -0,0 +1 @@
+ALTER TABLE issues ADD COLUMN draft BOOLEAN NOT NULL DEFAULT false;
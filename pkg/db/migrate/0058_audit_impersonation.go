@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	auditImpersonationName    = "audit_impersonation"
+	auditImpersonationVersion = 58
+)
+
+var auditImpersonation = Migration{
+	Name:    auditImpersonationName,
+	Version: auditImpersonationVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, auditImpersonationVersion, auditImpersonationName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, auditImpersonationVersion, auditImpersonationName)
+	},
+}
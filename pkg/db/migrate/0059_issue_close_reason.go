@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	issueCloseReasonName    = "issue_close_reason"
+	issueCloseReasonVersion = 59
+)
+
+var issueCloseReason = Migration{
+	Name:    issueCloseReasonName,
+	Version: issueCloseReasonVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, issueCloseReasonVersion, issueCloseReasonName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, issueCloseReasonVersion, issueCloseReasonName)
+	},
+}
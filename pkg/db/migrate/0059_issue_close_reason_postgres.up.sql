@@ -0,0 +1,2 @@
+ALTER TABLE issues ADD COLUMN IF NOT EXISTS close_reason TEXT NOT NULL DEFAULT '';
+UPDATE issues SET close_reason = 'completed' WHERE state = 1;
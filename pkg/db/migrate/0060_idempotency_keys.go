@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	idempotencyKeysName    = "idempotency_keys"
+	idempotencyKeysVersion = 60
+)
+
+var idempotencyKeys = Migration{
+	Name:    idempotencyKeysName,
+	Version: idempotencyKeysVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, idempotencyKeysVersion, idempotencyKeysName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, idempotencyKeysVersion, idempotencyKeysName)
+	},
+}
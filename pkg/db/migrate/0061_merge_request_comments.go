@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	mergeRequestCommentsName    = "merge_request_comments"
+	mergeRequestCommentsVersion = 61
+)
+
+var mergeRequestComments = Migration{
+	Name:    mergeRequestCommentsName,
+	Version: mergeRequestCommentsVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, mergeRequestCommentsVersion, mergeRequestCommentsName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, mergeRequestCommentsVersion, mergeRequestCommentsName)
+	},
+}
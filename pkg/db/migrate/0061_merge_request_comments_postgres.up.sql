@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS merge_request_comments (
+  id SERIAL PRIMARY KEY,
+  repo_id INTEGER NOT NULL,
+  merge_request_id INTEGER NOT NULL,
+  author_id INTEGER NOT NULL,
+  parent_id INTEGER,
+  body TEXT NOT NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  CONSTRAINT repo_id_fk
+  FOREIGN KEY(repo_id) REFERENCES repos(id)
+  ON DELETE CASCADE
+  ON UPDATE CASCADE,
+  CONSTRAINT merge_request_id_fk
+  FOREIGN KEY(merge_request_id) REFERENCES merge_requests(id)
+  ON DELETE CASCADE
+  ON UPDATE CASCADE,
+  CONSTRAINT author_id_fk
+  FOREIGN KEY(author_id) REFERENCES users(id)
+  ON DELETE CASCADE
+  ON UPDATE CASCADE,
+  CONSTRAINT parent_id_fk
+  FOREIGN KEY(parent_id) REFERENCES merge_request_comments(id)
+  ON DELETE CASCADE
+  ON UPDATE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_merge_request_comments_merge_request_id ON merge_request_comments(merge_request_id);
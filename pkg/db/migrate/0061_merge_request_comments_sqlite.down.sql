@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_merge_request_comments_merge_request_id;
+DROP TABLE IF EXISTS merge_request_comments;
@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	mergeRequestCommentAnchorsName    = "merge_request_comment_anchors"
+	mergeRequestCommentAnchorsVersion = 62
+)
+
+var mergeRequestCommentAnchors = Migration{
+	Name:    mergeRequestCommentAnchorsName,
+	Version: mergeRequestCommentAnchorsVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, mergeRequestCommentAnchorsVersion, mergeRequestCommentAnchorsName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, mergeRequestCommentAnchorsVersion, mergeRequestCommentAnchorsName)
+	},
+}
@@ -0,0 +1,6 @@
+ALTER TABLE merge_request_comments DROP COLUMN IF EXISTS outdated;
+ALTER TABLE merge_request_comments DROP COLUMN IF EXISTS snippet;
+ALTER TABLE merge_request_comments DROP COLUMN IF EXISTS end_line;
+ALTER TABLE merge_request_comments DROP COLUMN IF EXISTS start_line;
+ALTER TABLE merge_request_comments DROP COLUMN IF EXISTS commit_sha;
+ALTER TABLE merge_request_comments DROP COLUMN IF EXISTS path;
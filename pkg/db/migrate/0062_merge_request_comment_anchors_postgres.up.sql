@@ -0,0 +1,6 @@
+ALTER TABLE merge_request_comments ADD COLUMN IF NOT EXISTS path TEXT NOT NULL DEFAULT '';
+ALTER TABLE merge_request_comments ADD COLUMN IF NOT EXISTS commit_sha TEXT NOT NULL DEFAULT '';
+ALTER TABLE merge_request_comments ADD COLUMN IF NOT EXISTS start_line INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE merge_request_comments ADD COLUMN IF NOT EXISTS end_line INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE merge_request_comments ADD COLUMN IF NOT EXISTS snippet TEXT NOT NULL DEFAULT '';
+ALTER TABLE merge_request_comments ADD COLUMN IF NOT EXISTS outdated BOOLEAN NOT NULL DEFAULT false;
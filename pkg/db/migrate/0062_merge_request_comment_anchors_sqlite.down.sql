@@ -0,0 +1,6 @@
+ALTER TABLE merge_request_comments DROP COLUMN outdated;
+ALTER TABLE merge_request_comments DROP COLUMN snippet;
+ALTER TABLE merge_request_comments DROP COLUMN end_line;
+ALTER TABLE merge_request_comments DROP COLUMN start_line;
+ALTER TABLE merge_request_comments DROP COLUMN commit_sha;
+ALTER TABLE merge_request_comments DROP COLUMN path;
@@ -0,0 +1,6 @@
+ALTER TABLE merge_request_comments ADD COLUMN path TEXT NOT NULL DEFAULT '';
+ALTER TABLE merge_request_comments ADD COLUMN commit_sha TEXT NOT NULL DEFAULT '';
+ALTER TABLE merge_request_comments ADD COLUMN start_line INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE merge_request_comments ADD COLUMN end_line INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE merge_request_comments ADD COLUMN snippet TEXT NOT NULL DEFAULT '';
+ALTER TABLE merge_request_comments ADD COLUMN outdated BOOLEAN NOT NULL DEFAULT false;
@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	mergeRequestApprovalsName    = "merge_request_approvals"
+	mergeRequestApprovalsVersion = 63
+)
+
+var mergeRequestApprovals = Migration{
+	Name:    mergeRequestApprovalsName,
+	Version: mergeRequestApprovalsVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, mergeRequestApprovalsVersion, mergeRequestApprovalsName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, mergeRequestApprovalsVersion, mergeRequestApprovalsName)
+	},
+}
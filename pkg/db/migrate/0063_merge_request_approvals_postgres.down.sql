@@ -0,0 +1,3 @@
+ALTER TABLE repos DROP COLUMN IF EXISTS required_approvals;
+
+DROP TABLE IF EXISTS merge_request_approvals;
@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	mergeRequestDraftsName    = "merge_request_drafts"
+	mergeRequestDraftsVersion = 64
+)
+
+var mergeRequestDrafts = Migration{
+	Name:    mergeRequestDraftsName,
+	Version: mergeRequestDraftsVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, mergeRequestDraftsVersion, mergeRequestDraftsName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, mergeRequestDraftsVersion, mergeRequestDraftsName)
+	},
+}
@@ -0,0 +1 @@
+ALTER TABLE merge_requests DROP COLUMN IF EXISTS draft;
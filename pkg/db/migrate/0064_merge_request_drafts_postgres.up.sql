@@ -0,0 +1 @@
+ALTER TABLE merge_requests ADD COLUMN IF NOT EXISTS draft BOOLEAN NOT NULL DEFAULT false;
@@ -0,0 +1 @@
+ALTER TABLE merge_requests ADD COLUMN draft BOOLEAN NOT NULL DEFAULT false;
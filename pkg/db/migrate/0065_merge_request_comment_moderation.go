@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+const (
+	mergeRequestCommentModerationName    = "merge_request_comment_moderation"
+	mergeRequestCommentModerationVersion = 65
+)
+
+var mergeRequestCommentModeration = Migration{
+	Name:    mergeRequestCommentModerationName,
+	Version: mergeRequestCommentModerationVersion,
+	Migrate: func(ctx context.Context, tx *db.Tx) error {
+		return migrateUp(ctx, tx, mergeRequestCommentModerationVersion, mergeRequestCommentModerationName)
+	},
+	Rollback: func(ctx context.Context, tx *db.Tx) error {
+		return migrateDown(ctx, tx, mergeRequestCommentModerationVersion, mergeRequestCommentModerationName)
+	},
+}
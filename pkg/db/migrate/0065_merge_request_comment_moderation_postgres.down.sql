@@ -0,0 +1 @@
+ALTER TABLE merge_request_comments DROP COLUMN IF EXISTS hidden;
@@ -0,0 +1 @@
+ALTER TABLE merge_request_comments ADD COLUMN hidden BOOLEAN NOT NULL DEFAULT false;
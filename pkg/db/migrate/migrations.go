@@ -21,6 +21,65 @@ var migrations = []Migration{
 	mergeRequests,
 	issues,
 	issueDependencies,
+	largeTexts,
+	issueMergeRequestIndexes,
+	webhookFormat,
+	integrations,
+	chatIdentities,
+	issueTrackers,
+	webhookExternalIDs,
+	mergeRequestReviewers,
+	stalePolicy,
+	issueSLAs,
+	issueVotes,
+	userPreferences,
+	mergeQueue,
+	mergeRequestDependencies,
+	repoFeatures,
+	repoWatches,
+	activityLog,
+	accessTokenUsage,
+	auditLog,
+	botUsers,
+	accessTokenPins,
+	oauthApps,
+	webhookFailures,
+	repoStorage,
+	refLog,
+	protectedBranches,
+	commitRules,
+	contributionAgreements,
+	firstContributions,
+	mergeRequestReviews,
+	guestAccess,
+	labels,
+	issueNumbering,
+	repoEvents,
+	milestones,
+	issueNumbers,
+	commitReferences,
+	issueLocks,
+	issuePins,
+	subscriptions,
+	repoInternal,
+	attachments,
+	repoDiscoverability,
+	crossReferences,
+	moderation,
+	issueClosingCommits,
+	editPolicy,
+	dependencyPolicy,
+	repoVisits,
+	datePreferences,
+	draftIssues,
+	auditImpersonation,
+	issueCloseReason,
+	idempotencyKeys,
+	mergeRequestComments,
+	mergeRequestCommentAnchors,
+	mergeRequestApprovals,
+	mergeRequestDrafts,
+	mergeRequestCommentModeration,
 }
 
 func execMigration(ctx context.Context, tx *db.Tx, version int, name string, down bool) error {
@@ -14,4 +14,26 @@ type AccessToken struct {
 	ExpiresAt sql.NullTime `db:"expires_at"`
 	CreatedAt time.Time    `db:"created_at"`
 	UpdatedAt time.Time    `db:"updated_at"`
+
+	// LastUsedAt and LastUsedIP tell when and from where the token was last
+	// used to authenticate.
+	LastUsedAt sql.NullTime   `db:"last_used_at"`
+	LastUsedIP sql.NullString `db:"last_used_ip"`
+	// ExpiryWarnedAt is when the owner was warned the token is about to
+	// expire.
+	ExpiryWarnedAt sql.NullTime `db:"expiry_warned_at"`
+	// Scope is the highest access level the token grants, or empty for the
+	// access level of its owner.
+	Scope string `db:"scope"`
+	// ScopeRepo is the only repository the token grants access to, or empty
+	// for all of them.
+	ScopeRepo string `db:"scope_repo"`
+	// AllowedCIDRs is a comma separated list of the networks the token can be
+	// used from, or empty for any network.
+	AllowedCIDRs string `db:"allowed_cidrs"`
+	// PinnedKey is the SHA256 fingerprint of the SSH key the token is pinned
+	// to, or empty.
+	PinnedKey string `db:"pinned_key"`
+	// OAuthAppID is the OAuth application the token was issued to, if any.
+	OAuthAppID sql.NullInt64 `db:"oauth_app_id"`
 }
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+)
+
+// Activity is an entry of the instance activity log.
+type Activity struct {
+	ID      int64         `db:"id"`
+	RepoID  int64         `db:"repo_id"`
+	ActorID sql.NullInt64 `db:"actor_id"`
+	// Kind is what the activity happened to, e.g. "repository", "tag", or
+	// "merge_request".
+	Kind   string `db:"kind"`
+	Action string `db:"action"`
+	// SubjectID is the ID of the merge request the activity happened to, if
+	// any.
+	SubjectID int64 `db:"subject_id"`
+	// Title is the merge request title or the tag name.
+	Title     string    `db:"title"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// ActivityCount is the number of activities on a subject.
+type ActivityCount struct {
+	RepoID    int64 `db:"repo_id"`
+	SubjectID int64 `db:"subject_id"`
+	Count     int   `db:"count"`
+}
@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// Attachment is a file attached to an issue or a merge request. The content
+// of attachments is stored by OID, the SHA-256 of the content, so uploading
+// the same file twice stores it once.
+type Attachment struct {
+	ID     int64 `db:"id"`
+	RepoID int64 `db:"repo_id"`
+	// SubjectType is either "issue" or "merge_request".
+	SubjectType string `db:"subject_type"`
+	// SubjectID is the number of an issue, and the ID of a merge request.
+	SubjectID   int64     `db:"subject_id"`
+	UserID      int64     `db:"user_id"`
+	Name        string    `db:"name"`
+	ContentType string    `db:"content_type"`
+	Size        int64     `db:"size"`
+	Oid         string    `db:"oid"`
+	CreatedAt   time.Time `db:"created_at"`
+}
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+)
+
+// AuditEvent is an entry of the audit log. It records security sensitive
+// actions, like revoking the sessions of a user.
+type AuditEvent struct {
+	ID int64 `db:"id"`
+	// ActorID is the user who did the action. It's null for actions done by
+	// the server itself, or by an admin key without a user.
+	ActorID sql.NullInt64 `db:"actor_id"`
+	// ImpersonatorID is the admin who did the action as the actor, see the
+	// "admin sudo" command.
+	ImpersonatorID sql.NullInt64 `db:"impersonator_id"`
+	// Action is what happened, e.g. "session.revoke".
+	Action string `db:"action"`
+	// Target is what the action happened to, e.g. a username.
+	Target    string    `db:"target"`
+	Detail    string    `db:"detail"`
+	CreatedAt time.Time `db:"created_at"`
+}
@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// ChatIdentity links a chat account to a user.
+type ChatIdentity struct {
+	ID         int64     `db:"id"`
+	UserID     int64     `db:"user_id"`
+	Provider   string    `db:"provider"`
+	ExternalID string    `db:"external_id"`
+	CreatedAt  time.Time `db:"created_at"`
+}
+
+// ChatLinkCode is a pending link between a chat account and a user. The code
+// is redeemed over SSH by the user the chat account belongs to.
+type ChatLinkCode struct {
+	ID         int64     `db:"id"`
+	Code       string    `db:"code"`
+	Provider   string    `db:"provider"`
+	ExternalID string    `db:"external_id"`
+	ExpiresAt  time.Time `db:"expires_at"`
+	CreatedAt  time.Time `db:"created_at"`
+}
@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// CommitIssueReference is a reference to an issue of a repository in the
+// message of one of its commits, e.g. "Fixes #12".
+type CommitIssueReference struct {
+	ID        int64     `db:"id"`
+	RepoID    int64     `db:"repo_id"`
+	CommitSHA string    `db:"commit_sha"`
+	IssueID   int64     `db:"issue_id"`
+	Closes    bool      `db:"closes"`
+	CreatedAt time.Time `db:"created_at"`
+}
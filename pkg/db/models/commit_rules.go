@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// CommitRules are the rules the messages of the commits pushed to a
+// repository must follow.
+type CommitRules struct {
+	ID     int64 `db:"id"`
+	RepoID int64 `db:"repo_id"`
+	// Conventional requires Conventional Commits subjects, e.g.
+	// "fix(ssh): handle timeouts".
+	Conventional bool `db:"conventional"`
+	// MaxSubjectLength is the maximum length of the subjects, 0 for no
+	// limit.
+	MaxSubjectLength int `db:"max_subject_length"`
+	// RequiredTrailers is a comma separated list of the trailers every
+	// message must have, e.g. "Signed-off-by".
+	RequiredTrailers string `db:"required_trailers"`
+	// WarnOnly reports violations to the pusher without rejecting the push.
+	WarnOnly  bool      `db:"warn_only"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
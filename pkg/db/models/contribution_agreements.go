@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// ContributionAgreements are the agreements the authors of the merge requests
+// of a repository must satisfy before they can be merged.
+type ContributionAgreements struct {
+	ID     int64 `db:"id"`
+	RepoID int64 `db:"repo_id"`
+	// RequireDCO requires every commit to be signed off by its author, as in
+	// the Developer Certificate of Origin.
+	RequireDCO bool `db:"require_dco"`
+	// CLAPath is the path of the contributor license agreement document on
+	// the default branch, empty for none.
+	CLAPath   string    `db:"cla_path"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// CLASignature is the acceptance of a version of the contributor license
+// agreement of a repository by a user.
+type CLASignature struct {
+	ID     int64 `db:"id"`
+	RepoID int64 `db:"repo_id"`
+	UserID int64 `db:"user_id"`
+	// DocumentSha is the blob of the accepted version of the document.
+	DocumentSha string    `db:"document_sha"`
+	CreatedAt   time.Time `db:"created_at"`
+}
@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// CrossReference is a reference from the title or description of an issue or
+// merge request to another issue or merge request, e.g. "#12" or
+// "other-repo!3".
+type CrossReference struct {
+	ID int64 `db:"id"`
+	// RepoID is the ID of the repository of the referencing issue or merge
+	// request.
+	RepoID int64 `db:"repo_id"`
+	// SourceType is "issue" or "merge_request".
+	SourceType string `db:"source_type"`
+	// SourceID is the ID of the referencing issue or merge request.
+	SourceID     int64     `db:"source_id"`
+	TargetRepoID int64     `db:"target_repo_id"`
+	TargetType   string    `db:"target_type"`
+	TargetID     int64     `db:"target_id"`
+	CreatedAt    time.Time `db:"created_at"`
+}
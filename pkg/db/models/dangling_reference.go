@@ -0,0 +1,13 @@
+package models
+
+// DanglingReference is a row referencing a repository or a user that doesn't
+// exist, e.g. an issue of a deleted repository.
+type DanglingReference struct {
+	// Table is the table of the row, and Column its referencing column.
+	Table  string `db:"-"`
+	Column string `db:"-"`
+	// RowID is the ID of the row.
+	RowID int64 `db:"row_id"`
+	// RefID is the ID of the missing repository or user.
+	RefID int64 `db:"ref_id"`
+}
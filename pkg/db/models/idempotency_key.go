@@ -0,0 +1,23 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+)
+
+// IdempotencyKey is a key a user sent with a create request, so that retrying
+// the request replays its response instead of creating a duplicate.
+type IdempotencyKey struct {
+	ID     int64  `db:"id"`
+	UserID int64  `db:"user_id"`
+	Key    string `db:"key"`
+	// RequestHash is the SHA-256 of the request, to refuse reusing the key for
+	// another request.
+	RequestHash string `db:"request_hash"`
+	// Status is the HTTP status code of the response, zero for SSH commands.
+	Status int `db:"status"`
+	// Response is the response of the request. It's null while the request
+	// runs.
+	Response  sql.NullString `db:"response"`
+	CreatedAt time.Time      `db:"created_at"`
+}
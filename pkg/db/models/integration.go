@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// Integration is a repository chat notification integration.
+type Integration struct {
+	ID        int64     `db:"id"`
+	RepoID    int64     `db:"repo_id"`
+	Kind      int       `db:"kind"`
+	URL       string    `db:"url"`
+	Secret    string    `db:"secret"`
+	Template  string    `db:"template"`
+	Active    bool      `db:"active"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// IntegrationEvent is an event an integration is notified of.
+type IntegrationEvent struct {
+	ID            int64     `db:"id"`
+	IntegrationID int64     `db:"integration_id"`
+	Event         int       `db:"event"`
+	CreatedAt     time.Time `db:"created_at"`
+}
@@ -27,18 +27,82 @@ func (s IssueState) String() string {
 	}
 }
 
+// IssueCloseReason is why an issue was closed.
+type IssueCloseReason string
+
+const (
+	// IssueCloseReasonCompleted is an issue closed because it was done. It's
+	// the default.
+	IssueCloseReasonCompleted IssueCloseReason = "completed"
+	// IssueCloseReasonNotPlanned is an issue closed without being done, e.g.
+	// a declined feature request.
+	IssueCloseReasonNotPlanned IssueCloseReason = "not-planned"
+	// IssueCloseReasonDuplicate is an issue closed as a duplicate of another.
+	IssueCloseReasonDuplicate IssueCloseReason = "duplicate"
+)
+
 // Issue represents an issue.
 type Issue struct {
 	ID          int64         `db:"id"`
 	RepoID      int64         `db:"repo_id"`
+	Number      int64         `db:"number"`
 	Title       string        `db:"title"`
 	Description string        `db:"description"`
 	State       IssueState    `db:"state"`
 	AuthorID    int64         `db:"author_id"`
 	ClosedBy    sql.NullInt64 `db:"closed_by"`
 	ClosedAt    sql.NullTime  `db:"closed_at"`
+	StaleAt     sql.NullTime  `db:"stale_at"`
 	CreatedAt   time.Time     `db:"created_at"`
 	UpdatedAt   time.Time     `db:"updated_at"`
+
+	// DescriptionTruncated is true when Description only holds a preview and
+	// the full description is stored as a LargeText.
+	DescriptionTruncated bool `db:"description_truncated"`
+
+	// RespondedAt is when a user other than the author first acted on the
+	// issue.
+	RespondedAt sql.NullTime `db:"responded_at"`
+
+	// SLABreachedAt is when the issue missed the response time SLA of its
+	// repository.
+	SLABreachedAt sql.NullTime `db:"sla_breached_at"`
+
+	// FirstContribution is true when the issue is the first issue or merge
+	// request its author opened in the repository, and the author isn't a
+	// collaborator.
+	FirstContribution bool `db:"first_contribution"`
+
+	// ExternalID is the ID of the issue in the tracker it was imported from,
+	// e.g. "GH-123".
+	ExternalID sql.NullString `db:"external_id"`
+
+	// Locked is true when only collaborators can edit or vote on the issue.
+	Locked bool `db:"locked"`
+
+	// Pinned is the place of the issue among the issues pinned to the top of
+	// the issue list of its repository. It's null for unpinned issues.
+	Pinned sql.NullInt64 `db:"pinned"`
+
+	// Hidden is true when a moderator hid the description of the issue.
+	Hidden bool `db:"hidden"`
+
+	// ClosedByCommit is the SHA of the commit whose message closed the issue
+	// when pushed to the default branch, e.g. with "Fixes #12".
+	ClosedByCommit sql.NullString `db:"closed_by_commit"`
+
+	// EditedByID is the ID of the user who last edited the title or
+	// description of the issue, and EditedAt when. Both are null for issues
+	// never edited.
+	EditedByID sql.NullInt64 `db:"edited_by_id"`
+	EditedAt   sql.NullTime  `db:"edited_at"`
+
+	// Draft is true until the author publishes the issue. Only the author
+	// sees draft issues.
+	Draft bool `db:"draft"`
+
+	// CloseReason is why the issue was closed. It's empty for open issues.
+	CloseReason IssueCloseReason `db:"close_reason"`
 }
 
 // IssueDependency represents a dependency relationship between two issues.
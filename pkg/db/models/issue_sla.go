@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// IssueSLA is the response time service level agreement of a repository's
+// issues.
+type IssueSLA struct {
+	ID     int64 `db:"id"`
+	RepoID int64 `db:"repo_id"`
+	// ResponseHours is the number of hours within which new issues must get a
+	// first response.
+	ResponseHours int       `db:"response_hours"`
+	CreatedAt     time.Time `db:"created_at"`
+	UpdatedAt     time.Time `db:"updated_at"`
+}
@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// IssueTracker is the external issue tracker linked to a repository.
+type IssueTracker struct {
+	ID     int64  `db:"id"`
+	RepoID int64  `db:"repo_id"`
+	Kind   int    `db:"kind"`
+	URL    string `db:"url"`
+	// Projects is a comma separated list of the tracker project keys linked
+	// to the repository.
+	Projects  string    `db:"projects"`
+	Username  string    `db:"username"`
+	Token     string    `db:"token"`
+	DoneState string    `db:"done_state"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
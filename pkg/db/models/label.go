@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// Label is a label of a repository that can be attached to its issues.
+type Label struct {
+	ID     int64  `db:"id"`
+	RepoID int64  `db:"repo_id"`
+	Name   string `db:"name"`
+	// Color is the hex color the label is rendered with, e.g. "#d73a4a".
+	Color       string    `db:"color"`
+	Description string    `db:"description"`
+	CreatedAt   time.Time `db:"created_at"`
+	UpdatedAt   time.Time `db:"updated_at"`
+}
+
+// IssueLabel is a label attached to an issue.
+type IssueLabel struct {
+	IssueID int64 `db:"issue_id"`
+	Label
+}
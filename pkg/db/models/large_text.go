@@ -0,0 +1,17 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+)
+
+// LargeText is the full body of an issue or merge request description that is
+// too large to be stored inline.
+type LargeText struct {
+	ID             int64         `db:"id"`
+	IssueID        sql.NullInt64 `db:"issue_id"`
+	MergeRequestID sql.NullInt64 `db:"merge_request_id"`
+	Content        string        `db:"content"`
+	CreatedAt      time.Time     `db:"created_at"`
+	UpdatedAt      time.Time     `db:"updated_at"`
+}
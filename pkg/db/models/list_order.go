@@ -0,0 +1,68 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ListSort is the field a list of issues or merge requests is sorted by.
+type ListSort int
+
+const (
+	// ListSortCreated sorts by creation time.
+	ListSortCreated ListSort = iota
+	// ListSortUpdated sorts by last update time.
+	ListSortUpdated
+	// ListSortTitle sorts by title.
+	ListSortTitle
+	// ListSortState sorts by state.
+	ListSortState
+)
+
+// String returns the string representation of the list sort.
+func (s ListSort) String() string {
+	switch s {
+	case ListSortCreated:
+		return "created"
+	case ListSortUpdated:
+		return "updated"
+	case ListSortTitle:
+		return "title"
+	case ListSortState:
+		return "state"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseListSort parses a list sort, e.g. "updated".
+func ParseListSort(s string) (ListSort, error) {
+	switch strings.ToLower(s) {
+	case "created":
+		return ListSortCreated, nil
+	case "updated":
+		return ListSortUpdated, nil
+	case "title":
+		return ListSortTitle, nil
+	case "state":
+		return ListSortState, nil
+	default:
+		return 0, fmt.Errorf("invalid sort: %s (must be one of: created, updated, title, state)", s)
+	}
+}
+
+// ListOrder is the order of a list of issues or merge requests. The zero
+// value lists the newest first.
+type ListOrder struct {
+	Sort      ListSort
+	Ascending bool
+}
+
+// String returns the string representation of the list order, e.g.
+// "title asc".
+func (o ListOrder) String() string {
+	if o.Ascending {
+		return o.Sort.String() + " asc"
+	}
+	return o.Sort.String() + " desc"
+}
@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// MergeQueueEntry is a merge request waiting in the merge queue of a
+// repository.
+type MergeQueueEntry struct {
+	ID             int64     `db:"id"`
+	RepoID         int64     `db:"repo_id"`
+	MergeRequestID int64     `db:"merge_request_id"`
+	UserID         int64     `db:"user_id"`
+	CreatedAt      time.Time `db:"created_at"`
+}
@@ -45,6 +45,42 @@ type MergeRequest struct {
 	MergedAt     sql.NullTime       `db:"merged_at"`
 	ClosedBy     sql.NullInt64      `db:"closed_by"`
 	ClosedAt     sql.NullTime       `db:"closed_at"`
+	StaleAt      sql.NullTime       `db:"stale_at"`
+	ReviewedBy   sql.NullInt64      `db:"reviewed_by"`
+	ReviewedAt   sql.NullTime       `db:"reviewed_at"`
 	CreatedAt    time.Time          `db:"created_at"`
 	UpdatedAt    time.Time          `db:"updated_at"`
+
+	// DescriptionTruncated is true when Description only holds a preview and
+	// the full description is stored as a LargeText.
+	DescriptionTruncated bool `db:"description_truncated"`
+
+	// FirstContribution is true when the merge request is the first issue or merge
+	// request its author opened in the repository, and the author isn't a
+	// collaborator.
+	FirstContribution bool `db:"first_contribution"`
+
+	// Hidden is true when a moderator hid the description of the merge
+	// request.
+	Hidden bool `db:"hidden"`
+
+	// Draft is true when the merge request is a work in progress. Drafts
+	// can't be merged until they're marked ready.
+	Draft bool `db:"draft"`
+
+	// EditedByID is the ID of the user who last edited the title or
+	// description of the merge request, and EditedAt when. Both are null for
+	// merge requests never edited.
+	EditedByID sql.NullInt64 `db:"edited_by_id"`
+	EditedAt   sql.NullTime  `db:"edited_at"`
+}
+
+// MergeRequestDependency represents a dependency relationship between two
+// merge requests. The merge request with ID MergeRequestID is stacked on the
+// merge request with ID DependsOnID.
+type MergeRequestDependency struct {
+	ID             int64     `db:"id"`
+	MergeRequestID int64     `db:"merge_request_id"`
+	DependsOnID    int64     `db:"depends_on_id"`
+	CreatedAt      time.Time `db:"created_at"`
 }
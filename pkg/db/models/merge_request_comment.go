@@ -0,0 +1,54 @@
+package models
+
+import (
+	"database/sql"
+	"strconv"
+	"time"
+)
+
+// MergeRequestComment is a comment on a merge request. Top-level comments
+// start threads, and replies belong to the thread of their parent.
+type MergeRequestComment struct {
+	ID             int64  `db:"id"`
+	RepoID         int64  `db:"repo_id"`
+	MergeRequestID int64  `db:"merge_request_id"`
+	AuthorID       int64  `db:"author_id"`
+	Body           string `db:"body"`
+	// ParentID is the ID of the top-level comment a reply belongs to. It's
+	// null for top-level comments.
+	ParentID sql.NullInt64 `db:"parent_id"`
+	// CommentAnchor is the lines of the diff the comment is about. It's
+	// empty for comments on the merge request as a whole.
+	CommentAnchor
+	// Outdated is whether the anchored lines changed since the comment was
+	// made.
+	Outdated bool `db:"outdated"`
+	// Hidden is true when a moderator hid the body of the comment.
+	Hidden    bool      `db:"hidden"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// CommentAnchor anchors a comment to a range of lines of a file at a commit.
+type CommentAnchor struct {
+	Path      string `db:"path"`
+	CommitSHA string `db:"commit_sha"`
+	StartLine int    `db:"start_line"`
+	EndLine   int    `db:"end_line"`
+	// Snippet is the content of the anchored lines at the commit. It's kept
+	// to tell whether later pushes changed them.
+	Snippet string `db:"snippet"`
+}
+
+// IsZero returns whether the anchor is empty.
+func (a CommentAnchor) IsZero() bool {
+	return a.Path == ""
+}
+
+// Lines returns the anchored line or range of lines, e.g. "12" or "12-15".
+func (a CommentAnchor) Lines() string {
+	if a.EndLine == a.StartLine {
+		return strconv.Itoa(a.StartLine)
+	}
+	return strconv.Itoa(a.StartLine) + "-" + strconv.Itoa(a.EndLine)
+}
@@ -0,0 +1,72 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+)
+
+// MilestoneState represents the state of a milestone.
+type MilestoneState int
+
+const (
+	// MilestoneStateOpen is an open milestone.
+	MilestoneStateOpen MilestoneState = iota
+	// MilestoneStateClosed is a closed milestone.
+	MilestoneStateClosed
+)
+
+// String returns the string representation of the milestone state.
+func (s MilestoneState) String() string {
+	switch s {
+	case MilestoneStateOpen:
+		return "open"
+	case MilestoneStateClosed:
+		return "closed"
+	default:
+		return "unknown"
+	}
+}
+
+// Milestone is a milestone of a repository that groups its issues and merge
+// requests.
+type Milestone struct {
+	ID          int64          `db:"id"`
+	RepoID      int64          `db:"repo_id"`
+	Title       string         `db:"title"`
+	Description string         `db:"description"`
+	DueDate     sql.NullTime   `db:"due_date"`
+	State       MilestoneState `db:"state"`
+	ClosedAt    sql.NullTime   `db:"closed_at"`
+	CreatedAt   time.Time      `db:"created_at"`
+	UpdatedAt   time.Time      `db:"updated_at"`
+}
+
+// MilestoneProgress is a milestone with the number of its open and closed
+// issues and merge requests. Merged merge requests count as closed.
+type MilestoneProgress struct {
+	Milestone
+	OpenIssues          int `db:"open_issues"`
+	ClosedIssues        int `db:"closed_issues"`
+	OpenMergeRequests   int `db:"open_merge_requests"`
+	ClosedMergeRequests int `db:"closed_merge_requests"`
+}
+
+// Open returns the number of open issues and merge requests.
+func (p MilestoneProgress) Open() int {
+	return p.OpenIssues + p.OpenMergeRequests
+}
+
+// Closed returns the number of closed issues and merge requests.
+func (p MilestoneProgress) Closed() int {
+	return p.ClosedIssues + p.ClosedMergeRequests
+}
+
+// Percent returns the percentage of closed issues and merge requests, zero
+// for milestones without any.
+func (p MilestoneProgress) Percent() int {
+	total := p.Open() + p.Closed()
+	if total == 0 {
+		return 0
+	}
+	return p.Closed() * 100 / total
+}
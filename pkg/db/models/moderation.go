@@ -0,0 +1,23 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+)
+
+// ContentReport is a report of abusive content in an issue or merge request,
+// queued for the server admins to review.
+type ContentReport struct {
+	ID     int64 `db:"id"`
+	RepoID int64 `db:"repo_id"`
+	// SubjectType is either "issue", "merge_request", or
+	// "merge_request_comment".
+	SubjectType string `db:"subject_type"`
+	// SubjectID is the ID of the reported issue, merge request, or comment.
+	SubjectID  int64         `db:"subject_id"`
+	ReporterID int64         `db:"reporter_id"`
+	Reason     string        `db:"reason"`
+	ResolvedBy sql.NullInt64 `db:"resolved_by"`
+	ResolvedAt sql.NullTime  `db:"resolved_at"`
+	CreatedAt  time.Time     `db:"created_at"`
+}
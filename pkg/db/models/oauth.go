@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// OAuthApp is a third-party application that can log users in with Soft
+// Serve, acting as an OAuth2 and OpenID Connect provider.
+type OAuthApp struct {
+	ID       int64  `db:"id"`
+	Name     string `db:"name"`
+	ClientID string `db:"client_id"`
+	// ClientSecret is the hash of the client secret.
+	ClientSecret string    `db:"client_secret"`
+	RedirectURI  string    `db:"redirect_uri"`
+	CreatedAt    time.Time `db:"created_at"`
+	UpdatedAt    time.Time `db:"updated_at"`
+}
+
+// OAuthCode is an authorization code a user granted an OAuth application. The
+// application exchanges it for an access token.
+type OAuthCode struct {
+	ID     int64 `db:"id"`
+	AppID  int64 `db:"app_id"`
+	UserID int64 `db:"user_id"`
+	// Code is the hash of the authorization code.
+	Code        string `db:"code"`
+	RedirectURI string `db:"redirect_uri"`
+	Scope       string `db:"scope"`
+	// CodeChallenge is the PKCE code challenge, or empty.
+	CodeChallenge string    `db:"code_challenge"`
+	Nonce         string    `db:"nonce"`
+	ExpiresAt     time.Time `db:"expires_at"`
+	CreatedAt     time.Time `db:"created_at"`
+}
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// ProtectedBranch protects the branches of a repository matching a pattern.
+// Rewriting the history of protected branches notifies the repository
+// watchers.
+type ProtectedBranch struct {
+	ID     int64 `db:"id"`
+	RepoID int64 `db:"repo_id"`
+	// Pattern is a glob pattern of the branch names, e.g. "release/*".
+	Pattern string `db:"pattern"`
+	// RequireLease rejects force pushes and deletions that don't state the
+	// tip they overwrite with a lease push option.
+	RequireLease bool      `db:"require_lease"`
+	CreatedAt    time.Time `db:"created_at"`
+	UpdatedAt    time.Time `db:"updated_at"`
+}
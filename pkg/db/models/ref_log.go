@@ -0,0 +1,23 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+)
+
+// RefUpdate is an entry of the server-side reflog of a repository. Unlike Git
+// reflogs, entries outlive the refs they are about, so deleted branches can
+// be recovered.
+type RefUpdate struct {
+	ID     int64  `db:"id"`
+	RepoID int64  `db:"repo_id"`
+	Ref    string `db:"ref"`
+	OldSha string `db:"old_sha"`
+	NewSha string `db:"new_sha"`
+	// Action is the kind of update, e.g. "forced" or "deleted".
+	Action string `db:"action"`
+	// UserID is the user who updated the ref. It's null for updates done by
+	// the server itself, like mirror pulls.
+	UserID    sql.NullInt64 `db:"user_id"`
+	CreatedAt time.Time     `db:"created_at"`
+}
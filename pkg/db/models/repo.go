@@ -7,14 +7,27 @@ import (
 
 // Repo is a database model for a repository.
 type Repo struct {
-	ID          int64         `db:"id"`
-	Name        string        `db:"name"`
-	ProjectName string        `db:"project_name"`
-	Description string        `db:"description"`
-	Private     bool          `db:"private"`
-	Mirror      bool          `db:"mirror"`
-	Hidden      bool          `db:"hidden"`
-	UserID      sql.NullInt64 `db:"user_id"`
-	CreatedAt   time.Time     `db:"created_at"`
-	UpdatedAt   time.Time     `db:"updated_at"`
+	ID                    int64         `db:"id"`
+	Name                  string        `db:"name"`
+	ProjectName           string        `db:"project_name"`
+	Description           string        `db:"description"`
+	Private               bool          `db:"private"`
+	Internal              bool          `db:"internal"`
+	Mirror                bool          `db:"mirror"`
+	Hidden                bool          `db:"hidden"`
+	StaleExempt           bool          `db:"stale_exempt"`
+	MergeQueue            bool          `db:"merge_queue"`
+	IssuesDisabled        bool          `db:"issues_disabled"`
+	MergeRequestsDisabled bool          `db:"merge_requests_disabled"`
+	SearchDisabled        bool          `db:"search_disabled"`
+	NewsDisabled          bool          `db:"news_disabled"`
+	IssuePrefix           string        `db:"issue_prefix"`
+	EditPolicy            string        `db:"edit_policy"`
+	DependencyPolicy      string        `db:"dependency_policy"`
+	RequiredApprovals     int           `db:"required_approvals"`
+	StorageVolume         string        `db:"storage_volume"`
+	StoragePath           string        `db:"storage_path"`
+	UserID                sql.NullInt64 `db:"user_id"`
+	CreatedAt             time.Time     `db:"created_at"`
+	UpdatedAt             time.Time     `db:"updated_at"`
 }
@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// RepoEvent is an event of a repository recorded for its event stream.
+type RepoEvent struct {
+	ID     int64 `db:"id"`
+	RepoID int64 `db:"repo_id"`
+	// Event is the webhook event type.
+	Event int `db:"event"`
+	// Payload is the JSON webhook payload of the event.
+	Payload   string    `db:"payload"`
+	CreatedAt time.Time `db:"created_at"`
+}
@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+// RepoVisit is the last visit of a user to a repository.
+type RepoVisit struct {
+	ID        int64     `db:"id"`
+	RepoID    int64     `db:"repo_id"`
+	UserID    int64     `db:"user_id"`
+	VisitedAt time.Time `db:"visited_at"`
+}
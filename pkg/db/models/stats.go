@@ -0,0 +1,10 @@
+package models
+
+// InstanceCounts are the numbers of repositories, users, issues, and merge
+// requests of the instance.
+type InstanceCounts struct {
+	Repos         int64 `db:"repos"`
+	Users         int64 `db:"users"`
+	Issues        int64 `db:"issues"`
+	MergeRequests int64 `db:"merge_requests"`
+}
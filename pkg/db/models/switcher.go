@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// SwitcherItemKind is the kind of a quick switcher item stored in the
+// database.
+type SwitcherItemKind string
+
+const (
+	// SwitcherItemIssue is an issue.
+	SwitcherItemIssue SwitcherItemKind = "issue"
+	// SwitcherItemMergeRequest is a merge request.
+	SwitcherItemMergeRequest SwitcherItemKind = "merge_request"
+)
+
+// SwitcherItem is an issue or a merge request matched by the quick switcher.
+type SwitcherItem struct {
+	Kind   SwitcherItemKind `db:"kind"`
+	RepoID int64            `db:"repo_id"`
+	ID     int64            `db:"id"`
+	// Number is the number of an issue in its repository, and the ID of a
+	// merge request.
+	Number    int64     `db:"number"`
+	Title     string    `db:"title"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
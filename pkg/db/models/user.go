@@ -13,4 +13,7 @@ type User struct {
 	Password  sql.NullString `db:"password"`
 	CreatedAt time.Time      `db:"created_at"`
 	UpdatedAt time.Time      `db:"updated_at"`
+
+	// Bot is true for machine users, used for automation.
+	Bot bool `db:"bot"`
 }
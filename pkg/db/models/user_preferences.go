@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// UserPreferences are the settings a user picked for the terminal UI.
+type UserPreferences struct {
+	ID     int64 `db:"id"`
+	UserID int64 `db:"user_id"`
+	// DiffWordWrap wraps long diff lines instead of cutting them.
+	DiffWordWrap bool `db:"diff_word_wrap"`
+	// DiffIgnoreWhitespace hides whitespace changes from diffs.
+	DiffIgnoreWhitespace bool `db:"diff_ignore_whitespace"`
+	// DiffTabWidth is the number of spaces tabs are expanded to in diffs.
+	DiffTabWidth int `db:"diff_tab_width"`
+	// HideNews hides the instance news from the home page of the terminal UI.
+	HideNews bool `db:"hide_news"`
+	// Timezone is the IANA name of the time zone dates are shown in, empty
+	// for the server's.
+	Timezone string `db:"timezone"`
+	// DateFormat is the name or Go layout of the format of dates.
+	DateFormat string `db:"date_format"`
+	// DateStyle tells whether dates are shown relative to now.
+	DateStyle string    `db:"date_style"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
@@ -0,0 +1,79 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+)
+
+// WatchLevel is how much of the activity of a repository a user is notified
+// about.
+type WatchLevel int
+
+const (
+	// WatchLevelParticipating notifies about the issues and merge requests
+	// the user authored, reviews, or closed. It's the default level.
+	WatchLevelParticipating WatchLevel = iota
+	// WatchLevelAll notifies about all issue and merge request activity.
+	WatchLevelAll
+	// WatchLevelIgnore never notifies.
+	WatchLevelIgnore
+)
+
+// String returns the string representation of the watch level.
+func (l WatchLevel) String() string {
+	switch l {
+	case WatchLevelParticipating:
+		return "participating"
+	case WatchLevelAll:
+		return "all"
+	case WatchLevelIgnore:
+		return "ignore"
+	default:
+		return "unknown"
+	}
+}
+
+// RepoWatch is the watch level a user picked for a repository.
+type RepoWatch struct {
+	ID        int64      `db:"id"`
+	RepoID    int64      `db:"repo_id"`
+	UserID    int64      `db:"user_id"`
+	Level     WatchLevel `db:"level"`
+	CreatedAt time.Time  `db:"created_at"`
+	UpdatedAt time.Time  `db:"updated_at"`
+}
+
+// Notification tells a user about activity on an issue, a merge request, or a
+// ref, or about one of their access tokens.
+type Notification struct {
+	ID     int64 `db:"id"`
+	UserID int64 `db:"user_id"`
+	// RepoID is null for notifications about access tokens.
+	RepoID sql.NullInt64 `db:"repo_id"`
+	// SubjectType is either "issue", "merge_request", "access_token",
+	// "webhook", or "ref".
+	SubjectType string `db:"subject_type"`
+	// SubjectID is the number of an issue, and the ID of other subjects.
+	SubjectID int64 `db:"subject_id"`
+	// Title is the title of the subject when the notification was created.
+	Title     string        `db:"title"`
+	Action    string        `db:"action"`
+	ActorID   sql.NullInt64 `db:"actor_id"`
+	ReadAt    sql.NullTime  `db:"read_at"`
+	CreatedAt time.Time     `db:"created_at"`
+}
+
+// Subscription is the choice of a user to be notified about an issue or a
+// merge request, or not, whatever their participation.
+type Subscription struct {
+	ID     int64 `db:"id"`
+	RepoID int64 `db:"repo_id"`
+	// SubjectType is either "issue" or "merge_request".
+	SubjectType string `db:"subject_type"`
+	// SubjectID is the number of an issue, and the ID of a merge request.
+	SubjectID  int64     `db:"subject_id"`
+	UserID     int64     `db:"user_id"`
+	Subscribed bool      `db:"subscribed"`
+	CreatedAt  time.Time `db:"created_at"`
+	UpdatedAt  time.Time `db:"updated_at"`
+}
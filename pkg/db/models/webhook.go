@@ -9,14 +9,21 @@ import (
 
 // Webhook is a repository webhook.
 type Webhook struct {
-	ID          int64     `db:"id"`
-	RepoID      int64     `db:"repo_id"`
-	URL         string    `db:"url"`
-	Secret      string    `db:"secret"`
-	ContentType int       `db:"content_type"`
-	Active      bool      `db:"active"`
-	CreatedAt   time.Time `db:"created_at"`
-	UpdatedAt   time.Time `db:"updated_at"`
+	ID          int64          `db:"id"`
+	RepoID      int64          `db:"repo_id"`
+	URL         string         `db:"url"`
+	Secret      string         `db:"secret"`
+	ContentType int            `db:"content_type"`
+	Format      int            `db:"format"`
+	Active      bool           `db:"active"`
+	ExternalID  sql.NullString `db:"external_id"`
+	// Failures is the number of consecutive failed deliveries.
+	Failures int `db:"failures"`
+	// FailureNotified is true when the repository admins have been notified
+	// of the current failures.
+	FailureNotified bool      `db:"failure_notified"`
+	CreatedAt       time.Time `db:"created_at"`
+	UpdatedAt       time.Time `db:"updated_at"`
 }
 
 // WebhookEvent is a webhook event.
@@ -5,7 +5,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/charmbracelet/soft-serve/git"
 )
@@ -95,3 +100,92 @@ func TestEnsureDefaultBranchEmpty(t *testing.T) {
 		t.Errorf("EnsureDefaultBranch(%q) => %v, want ErrNoBranches", tmp, err)
 	}
 }
+
+func TestServiceHandlerCanceled(t *testing.T) {
+	r, err := git.Init(filepath.Join(t.TempDir(), "repo"), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The client never sends its wants, so upload-pack waits for them until
+	// the client is gone.
+	stdin, stdinw := io.Pipe()
+	defer stdinw.Close() // nolint: errcheck
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errc := make(chan error, 1)
+	go func() {
+		errc <- UploadPack(ctx, ServiceCommand{
+			Stdin:  stdin,
+			Stdout: io.Discard,
+			Stderr: io.Discard,
+			Dir:    r.Path,
+		})
+	}()
+
+	time.Sleep(100 * time.Millisecond)
+	cancel()
+
+	select {
+	case err := <-errc:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected context.Canceled, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("service kept running after the client was gone")
+	}
+}
+
+func TestUploadPackHiddenRefs(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "repo")
+	if _, err := git.Init(dir, false); err != nil {
+		t.Fatal(err)
+	}
+	for _, args := range [][]string{
+		{"-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "--allow-empty", "-m", "init"},
+		{"update-ref", "refs/heads/main", "HEAD"},
+		{"update-ref", "refs/merge-requests/1/head", "HEAD"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, out)
+		}
+	}
+
+	advertised := func(stdin string, env []string, args ...string) string {
+		var out bytes.Buffer
+		if err := UploadPack(context.Background(), ServiceCommand{
+			Stdin:      strings.NewReader(stdin),
+			Stdout:     &out,
+			Dir:        dir,
+			Env:        env,
+			Args:       args,
+			HiddenRefs: []string{"refs/merge-requests/"},
+		}); err != nil {
+			t.Fatal(err)
+		}
+		return out.String()
+	}
+
+	refs := advertised("", nil, "--advertise-refs")
+	if !strings.Contains(refs, "refs/heads/main") {
+		t.Errorf("expected refs/heads/main to be advertised, got %q", refs)
+	}
+	if strings.Contains(refs, "refs/merge-requests/") {
+		t.Errorf("expected refs/merge-requests/ to be hidden, got %q", refs)
+	}
+	if !strings.Contains(refs, "allow-tip-sha1-in-want") {
+		t.Errorf("expected hidden tips to be fetchable, got %q", refs)
+	}
+
+	// Protocol v2 clients list refs with the ls-refs command.
+	refs = advertised("0014command=ls-refs\n00010009peel\n0000",
+		[]string{"GIT_PROTOCOL=version=2"}, "--stateless-rpc")
+	if !strings.Contains(refs, "refs/heads/main") {
+		t.Errorf("expected refs/heads/main to be listed with protocol v2, got %q", refs)
+	}
+	if strings.Contains(refs, "refs/merge-requests/") {
+		t.Errorf("expected refs/merge-requests/ to be hidden with protocol v2, got %q", refs)
+	}
+}
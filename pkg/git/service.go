@@ -67,8 +67,11 @@ func gitServiceHandler(ctx context.Context, svc Service, scmd ServiceCommand) er
 		"-c", "receive.advertisePushOptions=true",
 		// Disable LFS filters
 		"-c", "filter.lfs.required=", "-c", "filter.lfs.smudge=", "-c", "filter.lfs.clean=",
-		svc.Name(),
 	}...)
+	if svc == UploadPackService {
+		cmd.Args = append(cmd.Args, hideRefsArgs(scmd.HiddenRefs)...)
+	}
+	cmd.Args = append(cmd.Args, svc.Name())
 	if len(scmd.Args) > 0 {
 		cmd.Args = append(cmd.Args, scmd.Args...)
 	}
@@ -147,19 +150,30 @@ func gitServiceHandler(ctx context.Context, svc Service, scmd ServiceCommand) er
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			if _, erro := io.Copy(scmd.Stderr, stderr); err != nil {
+			if _, erro := io.Copy(scmd.Stderr, stderr); erro != nil {
 				log.Errorf("gitServiceHandler: failed to copy stderr: %v", erro)
 			}
 		}()
 	}
 
 	// Ensure all the output is written before waiting for the command to
-	// finish.
+	// finish, unless the client is gone. The command is then killed, and
+	// waiting for it closes the pipes its children might still hold open.
 	// Stdin is handled by the client side.
-	wg.Wait()
+	copied := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(copied)
+	}()
+	select {
+	case <-copied:
+	case <-ctx.Done():
+	}
 
 	err = cmd.Wait()
-	if err != nil && errors.Is(err, os.ErrNotExist) {
+	if ctxErr := ctx.Err(); err != nil && ctxErr != nil {
+		return ctxErr
+	} else if err != nil && errors.Is(err, os.ErrNotExist) {
 		return ErrInvalidRepo
 	} else if err != nil {
 		var exitErr *exec.ExitError
@@ -182,10 +196,28 @@ type ServiceCommand struct {
 	Env    []string
 	Args   []string
 
+	// HiddenRefs are the ref prefixes upload-pack leaves out of its ref
+	// advertisement.
+	HiddenRefs []string
+
 	// Modifier functions
 	CmdFunc func(*exec.Cmd)
 }
 
+// hideRefsArgs returns the git options hiding refs from the ref
+// advertisement of upload-pack, and, with protocol v2, from ls-refs. Hidden
+// refs can still be fetched by object ID.
+func hideRefsArgs(hidden []string) []string {
+	if len(hidden) == 0 {
+		return nil
+	}
+	args := []string{"-c", "uploadpack.allowTipSHA1InWant=true"}
+	for _, ref := range hidden {
+		args = append(args, "-c", "uploadpack.hideRefs="+ref)
+	}
+	return args
+}
+
 // UploadPack runs the git upload-pack protocol against the provided repo.
 func UploadPack(ctx context.Context, cmd ServiceCommand) error {
 	return gitServiceHandler(ctx, UploadPackService, cmd)
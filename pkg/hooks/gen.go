@@ -9,7 +9,6 @@ import (
 
 	"github.com/charmbracelet/log/v2"
 	"github.com/charmbracelet/soft-serve/pkg/config"
-	"github.com/charmbracelet/soft-serve/pkg/utils"
 )
 
 // The names of git server-side hooks.
@@ -26,11 +25,11 @@ const (
 // - post-receive
 // - post-update
 //
-// This function should be called by the backend when a repository is created.
+// This function should be called by the backend when a repository is created,
+// with the directory of the repository.
 // TODO: support context.
-func GenerateHooks(_ context.Context, cfg *config.Config, repo string) error {
-	repo = utils.SanitizeRepo(repo) + ".git"
-	hooksPath := filepath.Join(cfg.DataPath, "repos", repo, "hooks")
+func GenerateHooks(_ context.Context, _ *config.Config, repoPath string) error {
+	hooksPath := filepath.Join(repoPath, "hooks")
 	if err := os.MkdirAll(hooksPath, os.ModePerm); err != nil {
 		return err
 	}
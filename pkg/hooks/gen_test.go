@@ -20,7 +20,7 @@ func TestGenerateHooks(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	if err := GenerateHooks(context.TODO(), cfg, "test.git"); err != nil {
+	if err := GenerateHooks(context.TODO(), cfg, repoPath); err != nil {
 		t.Fatal(err)
 	}
 
@@ -12,9 +12,10 @@ type HookArg struct {
 	RefName string
 }
 
-// Hooks provides an interface for git server-side hooks.
+// Hooks provides an interface for git server-side hooks. An error from
+// PreReceive rejects the push.
 type Hooks interface {
-	PreReceive(ctx context.Context, stdout io.Writer, stderr io.Writer, repo string, args []HookArg)
+	PreReceive(ctx context.Context, stdout io.Writer, stderr io.Writer, repo string, args []HookArg) error
 	Update(ctx context.Context, stdout io.Writer, stderr io.Writer, repo string, arg HookArg)
 	PostReceive(ctx context.Context, stdout io.Writer, stderr io.Writer, repo string, args []HookArg)
 	PostUpdate(ctx context.Context, stdout io.Writer, stderr io.Writer, repo string, args ...string)
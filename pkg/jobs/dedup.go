@@ -0,0 +1,37 @@
+package jobs
+
+import (
+	"context"
+
+	"github.com/charmbracelet/log/v2"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/config"
+)
+
+func init() {
+	Register("repo-dedup", dedup{})
+}
+
+type dedup struct{}
+
+// Spec derives the spec used for sharing repository objects through object
+// pools and implements Runner.
+func (s dedup) Spec(ctx context.Context) string {
+	cfg := config.FromContext(ctx)
+	if cfg.Jobs.Dedup != "" {
+		return cfg.Jobs.Dedup
+	}
+	return "@daily"
+}
+
+// Func runs the repository deduplication task and implements Runner.
+func (s dedup) Func(ctx context.Context) func() {
+	logger := log.FromContext(ctx).WithPrefix("jobs.repo-dedup")
+	b := backend.FromContext(ctx)
+	return func() {
+		logger.Debug("deduplicating repositories")
+		if err := b.DedupRepositories(ctx); err != nil {
+			logger.Error("error deduplicating repositories", "err", err)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/charmbracelet/log/v2"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/config"
+)
+
+func init() {
+	Register("issue-sla", issueSLA{})
+}
+
+type issueSLA struct{}
+
+// Spec derives the spec used for checking issue SLAs and implements Runner.
+func (s issueSLA) Spec(ctx context.Context) string {
+	cfg := config.FromContext(ctx)
+	if cfg.Jobs.IssueSLA != "" {
+		return cfg.Jobs.IssueSLA
+	}
+	return "@every 10m"
+}
+
+// Func runs the issue SLA check task and implements Runner.
+func (s issueSLA) Func(ctx context.Context) func() {
+	logger := log.FromContext(ctx).WithPrefix("jobs.issue-sla")
+	b := backend.FromContext(ctx)
+	return func() {
+		logger.Debug("checking issue SLAs")
+		if err := b.CheckIssueSLAs(ctx, time.Now()); err != nil {
+			logger.Error("error checking issue SLAs", "err", err)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package jobs
+
+import (
+	"context"
+
+	"github.com/charmbracelet/log/v2"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/config"
+)
+
+func init() {
+	Register("merge-queue", mergeQueue{})
+}
+
+type mergeQueue struct{}
+
+// Spec derives the spec used for running merge queues and implements Runner.
+func (m mergeQueue) Spec(ctx context.Context) string {
+	cfg := config.FromContext(ctx)
+	if cfg.Jobs.MergeQueue != "" {
+		return cfg.Jobs.MergeQueue
+	}
+	return "@every 1m"
+}
+
+// Func runs the merge queues and implements Runner.
+func (m mergeQueue) Func(ctx context.Context) func() {
+	logger := log.FromContext(ctx).WithPrefix("jobs.merge-queue")
+	b := backend.FromContext(ctx)
+	return func() {
+		logger.Debug("processing merge queues")
+		if err := b.ProcessMergeQueues(ctx); err != nil {
+			logger.Error("error processing merge queues", "err", err)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package jobs
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"path/filepath"
 	"runtime"
 	"strings"
@@ -46,6 +47,12 @@ func (m mirrorPull) Func(ctx context.Context) func() {
 			return
 		}
 
+		transport, err := cfg.Outbound.Transport()
+		if err != nil {
+			logger.Error("error creating outbound transport", "err", err)
+			return
+		}
+
 		// Divide the work up among the number of CPUs.
 		wq := sync.NewWorkPool(ctx, runtime.GOMAXPROCS(0),
 			sync.WithWorkPoolLogger(logger.Errorf),
@@ -71,13 +78,14 @@ func (m mirrorPull) Func(ctx context.Context) func() {
 
 					for _, c := range cmds {
 						args := strings.Split(c, " ")
-						cmd := git.NewCommand(args...).WithContext(ctx)
+						cmd := git.NewCommand(args...).WithContext(ctx).WithTimeout(cfg.GitCommandTimeout())
 						cmd.AddEnvs(
 							fmt.Sprintf(`GIT_SSH_COMMAND=ssh -o UserKnownHostsFile="%s" -o StrictHostKeyChecking=no -i "%s"`,
 								filepath.Join(cfg.DataPath, "ssh", "known_hosts"),
 								cfg.SSH.ClientKeyPath,
 							),
 						)
+						cmd.AddEnvs(cfg.Outbound.GitEnv()...)
 
 						if _, err := cmd.RunInDir(r.Path); err != nil {
 							logger.Error("error running git remote update", "repo", name, "err", err)
@@ -104,7 +112,7 @@ func (m mirrorPull) Func(ctx context.Context) func() {
 							return
 						}
 
-						client := lfs.NewClient(ep)
+						client := lfs.NewClient(ep, lfs.WithHTTPClient(&http.Client{Transport: transport}))
 						if client == nil {
 							logger.Errorf("failed to create lfs client: unsupported endpoint %s", lfsEndpoint)
 							return
@@ -0,0 +1,37 @@
+package jobs
+
+import (
+	"context"
+
+	"github.com/charmbracelet/log/v2"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/config"
+)
+
+func init() {
+	Register("repo-optimize", optimize{})
+}
+
+type optimize struct{}
+
+// Spec derives the spec used for optimizing the repositories large pushes
+// marked and implements Runner.
+func (s optimize) Spec(ctx context.Context) string {
+	cfg := config.FromContext(ctx)
+	if cfg.Jobs.Optimize != "" {
+		return cfg.Jobs.Optimize
+	}
+	return "@every 1m"
+}
+
+// Func runs the repository optimization task and implements Runner.
+func (s optimize) Func(ctx context.Context) func() {
+	logger := log.FromContext(ctx).WithPrefix("jobs.repo-optimize")
+	b := backend.FromContext(ctx)
+	return func() {
+		logger.Debug("optimizing repositories")
+		if err := b.OptimizeRepositories(ctx); err != nil {
+			logger.Error("error optimizing repositories", "err", err)
+		}
+	}
+}
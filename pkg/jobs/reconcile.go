@@ -0,0 +1,43 @@
+package jobs
+
+import (
+	"context"
+
+	"github.com/charmbracelet/log/v2"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/config"
+)
+
+func init() {
+	Register("reconcile", reconcile{})
+}
+
+type reconcile struct{}
+
+// Spec derives the spec used for checking the consistency of the database
+// with the repositories on disk and implements Runner.
+func (s reconcile) Spec(ctx context.Context) string {
+	cfg := config.FromContext(ctx)
+	if cfg.Jobs.Reconcile != "" {
+		return cfg.Jobs.Reconcile
+	}
+	return "@daily"
+}
+
+// Func runs the consistency check and implements Runner. Inconsistencies are
+// only logged, admins reconcile them with "admin reconcile".
+func (s reconcile) Func(ctx context.Context) func() {
+	logger := log.FromContext(ctx).WithPrefix("jobs.reconcile")
+	b := backend.FromContext(ctx)
+	return func() {
+		logger.Debug("checking consistency")
+		incs, err := b.CheckConsistency(ctx)
+		if err != nil {
+			logger.Error("error checking consistency", "err", err)
+			return
+		}
+		for _, inc := range incs {
+			logger.Warn("inconsistency found, run \"admin reconcile\"", "inconsistency", inc.String())
+		}
+	}
+}
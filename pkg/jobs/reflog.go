@@ -0,0 +1,36 @@
+package jobs
+
+import (
+	"context"
+
+	"github.com/charmbracelet/log/v2"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/config"
+)
+
+func init() {
+	Register("reflog", reflog{})
+}
+
+type reflog struct{}
+
+// Spec derives the spec used for expiring reflogs and implements Runner.
+func (s reflog) Spec(ctx context.Context) string {
+	cfg := config.FromContext(ctx)
+	if cfg.Jobs.Reflog != "" {
+		return cfg.Jobs.Reflog
+	}
+	return "@daily"
+}
+
+// Func runs the reflog expiration task and implements Runner.
+func (s reflog) Func(ctx context.Context) func() {
+	logger := log.FromContext(ctx).WithPrefix("jobs.reflog")
+	b := backend.FromContext(ctx)
+	return func() {
+		logger.Debug("expiring reflogs")
+		if err := b.ExpireRefLogs(ctx); err != nil {
+			logger.Error("error expiring reflogs", "err", err)
+		}
+	}
+}
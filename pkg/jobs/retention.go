@@ -0,0 +1,49 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/charmbracelet/log/v2"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/config"
+)
+
+func init() {
+	Register("retention", retention{})
+}
+
+type retention struct{}
+
+// Spec derives the spec used for applying the retention policies and
+// implements Runner.
+func (s retention) Spec(ctx context.Context) string {
+	cfg := config.FromContext(ctx)
+	if cfg.Jobs.Retention != "" {
+		return cfg.Jobs.Retention
+	}
+	return "@daily"
+}
+
+// Func runs the retention task and implements Runner.
+func (s retention) Func(ctx context.Context) func() {
+	cfg := config.FromContext(ctx)
+	logger := log.FromContext(ctx).WithPrefix("jobs.retention")
+	b := backend.FromContext(ctx)
+	return func() {
+		logger.Debug("applying retention policies")
+		report, err := b.ApplyRetention(ctx, time.Now(), cfg.Retention.DryRun)
+		if err != nil {
+			logger.Error("error applying retention policies", "err", err)
+			return
+		}
+		msg := "purged old data"
+		if report.DryRun {
+			msg = "retention dry run, would purge old data"
+		}
+		logger.Info(msg,
+			"closed_issues", report.ClosedIssues,
+			"webhook_deliveries", report.WebhookDeliveries,
+			"audit_events", report.AuditEvents)
+	}
+}
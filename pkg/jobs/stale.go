@@ -0,0 +1,48 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/charmbracelet/log/v2"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/config"
+)
+
+func init() {
+	Register("stale", stale{})
+}
+
+type stale struct{}
+
+// Spec derives the spec used for the stale policy and implements Runner.
+func (s stale) Spec(ctx context.Context) string {
+	cfg := config.FromContext(ctx)
+	if cfg.Jobs.Stale != "" {
+		return cfg.Jobs.Stale
+	}
+	return "@every 1h"
+}
+
+// Func runs the stale policy task and implements Runner.
+func (s stale) Func(ctx context.Context) func() {
+	cfg := config.FromContext(ctx)
+	logger := log.FromContext(ctx).WithPrefix("jobs.stale")
+	b := backend.FromContext(ctx)
+	return func() {
+		if cfg.Stale.DaysUntilStale <= 0 {
+			return
+		}
+
+		day := 24 * time.Hour
+		policy := backend.StalePolicy{
+			StaleAfter: time.Duration(cfg.Stale.DaysUntilStale) * day,
+			CloseAfter: time.Duration(max(cfg.Stale.DaysUntilClose, 0)) * day,
+		}
+
+		logger.Debug("applying stale policy")
+		if err := b.ApplyStalePolicy(ctx, policy, time.Now()); err != nil {
+			logger.Error("error applying stale policy", "err", err)
+		}
+	}
+}
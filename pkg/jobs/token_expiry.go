@@ -0,0 +1,38 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/charmbracelet/log/v2"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/config"
+)
+
+func init() {
+	Register("token-expiry", tokenExpiry{})
+}
+
+type tokenExpiry struct{}
+
+// Spec derives the spec used for warning about expiring access tokens and
+// implements Runner.
+func (s tokenExpiry) Spec(ctx context.Context) string {
+	cfg := config.FromContext(ctx)
+	if cfg.Jobs.TokenExpiry != "" {
+		return cfg.Jobs.TokenExpiry
+	}
+	return "@every 1h"
+}
+
+// Func runs the access token expiry warning task and implements Runner.
+func (s tokenExpiry) Func(ctx context.Context) func() {
+	logger := log.FromContext(ctx).WithPrefix("jobs.token-expiry")
+	b := backend.FromContext(ctx)
+	return func() {
+		logger.Debug("warning about expiring access tokens")
+		if err := b.WarnExpiringAccessTokens(ctx, time.Now()); err != nil {
+			logger.Error("error warning about expiring access tokens", "err", err)
+		}
+	}
+}
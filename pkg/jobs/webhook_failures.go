@@ -0,0 +1,37 @@
+package jobs
+
+import (
+	"context"
+
+	"github.com/charmbracelet/log/v2"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/config"
+)
+
+func init() {
+	Register("webhook-failures", webhookFailures{})
+}
+
+type webhookFailures struct{}
+
+// Spec derives the spec used for notifying about failing webhooks and
+// implements Runner.
+func (s webhookFailures) Spec(ctx context.Context) string {
+	cfg := config.FromContext(ctx)
+	if cfg.Jobs.WebhookFailures != "" {
+		return cfg.Jobs.WebhookFailures
+	}
+	return "@every 5m"
+}
+
+// Func runs the failing webhooks notification task and implements Runner.
+func (s webhookFailures) Func(ctx context.Context) func() {
+	logger := log.FromContext(ctx).WithPrefix("jobs.webhook-failures")
+	b := backend.FromContext(ctx)
+	return func() {
+		logger.Debug("notifying about failing webhooks")
+		if err := b.NotifyFailingWebhooks(ctx); err != nil {
+			logger.Error("error notifying about failing webhooks", "err", err)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package lfs
 import (
 	"context"
 	"io"
+	"net/http"
 )
 
 // DownloadCallback gets called for every requested LFS object to process its content
@@ -17,10 +18,26 @@ type Client interface {
 	Upload(ctx context.Context, objects []Pointer, callback UploadCallback) error
 }
 
+// ClientOption is an option of a Git LFS client.
+type ClientOption func(*httpClient)
+
+// WithHTTPClient sets the HTTP client of a Git LFS client. It defaults to
+// http.DefaultClient.
+func WithHTTPClient(c *http.Client) ClientOption {
+	return func(hc *httpClient) {
+		hc.client = c
+		hc.transfers[TransferBasic] = &BasicTransferAdapter{c}
+	}
+}
+
 // NewClient returns a new Git LFS client.
-func NewClient(e Endpoint) Client {
+func NewClient(e Endpoint, opts ...ClientOption) Client {
 	if e.Scheme == "http" || e.Scheme == "https" {
-		return newHTTPClient(e)
+		c := newHTTPClient(e)
+		for _, opt := range opts {
+			opt(c)
+		}
+		return c
 	}
 	// TODO: support ssh client
 	return nil
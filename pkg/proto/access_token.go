@@ -1,6 +1,10 @@
 package proto
 
-import "time"
+import (
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
 
 // AccessToken represents an access token.
 type AccessToken struct {
@@ -10,4 +14,37 @@ type AccessToken struct {
 	TokenHash string
 	ExpiresAt time.Time
 	CreatedAt time.Time
+	// LastUsedAt is zero if the token was never used.
+	LastUsedAt time.Time
+	LastUsedIP string
+	// Scope is the highest access level the token grants, or empty for the
+	// access level of its owner.
+	Scope string
+	// ScopeRepo is the only repository the token grants access to, or empty
+	// for all of them.
+	ScopeRepo string
+	// AllowedCIDRs are the networks the token can be used from, or empty for
+	// any network.
+	AllowedCIDRs []string
+	// PinnedKey is the fingerprint of the SSH key the token is pinned to, or
+	// empty.
+	PinnedKey string
+}
+
+// AccessTokenOptions are options for creating an access token.
+type AccessTokenOptions struct {
+	// ExpiresAt is when the token expires, or zero if it never does.
+	ExpiresAt time.Time
+	// Scope is the highest access level the token grants, or empty for the
+	// access level of its owner.
+	Scope string
+	// ScopeRepo is the only repository the token grants access to, or empty
+	// for all of them.
+	ScopeRepo string
+	// AllowedCIDRs are the networks the token can be used from. Single
+	// addresses are allowed too.
+	AllowedCIDRs []string
+	// PinnedKey is the SSH key the token is pinned to. A pinned token can only
+	// be used from an address the key recently logged in from over SSH.
+	PinnedKey ssh.PublicKey
 }
@@ -19,8 +19,14 @@ var (
 	ErrTokenNotFound = errors.New("token not found")
 	// ErrTokenExpired is returned when a token is expired.
 	ErrTokenExpired = errors.New("token expired")
+	// ErrTokenPinned is returned when a pinned token is used from somewhere
+	// it isn't allowed.
+	ErrTokenPinned = errors.New("token not allowed from this address")
 	// ErrCollaboratorNotFound is returned when a collaborator is not found.
 	ErrCollaboratorNotFound = errors.New("collaborator not found")
 	// ErrCollaboratorExist is returned when a collaborator already exists.
 	ErrCollaboratorExist = errors.New("collaborator already exists")
+	// ErrBotUser is returned when a machine user tries to do something only
+	// people can do.
+	ErrBotUser = errors.New("not allowed for bot users")
 )
@@ -1,6 +1,8 @@
 package proto
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/charmbracelet/soft-serve/git"
@@ -18,6 +20,9 @@ type Repository interface {
 	Description() string
 	// IsPrivate returns whether the repository is private.
 	IsPrivate() bool
+	// IsInternal returns whether the repository is internal, i.e. readable
+	// by any authenticated user.
+	IsInternal() bool
 	// IsMirror returns whether the repository is a mirror.
 	IsMirror() bool
 	// IsHidden returns whether the repository is hidden.
@@ -37,12 +42,22 @@ type Repository interface {
 // RepositoryOptions are options for creating a new repository.
 type RepositoryOptions struct {
 	Private     bool
+	Internal    bool
 	Description string
 	ProjectName string
 	Mirror      bool
 	Hidden      bool
 	LFS         bool
 	LFSEndpoint string
+	// DefaultBranch is the branch HEAD points to. When empty, the server
+	// default branch applies.
+	DefaultBranch string
+	// Readme, License and Gitignore create an initial commit on the default
+	// branch with a README, the named license, e.g. "MIT", and the named
+	// .gitignore template, e.g. "Go".
+	Readme    bool
+	License   string
+	Gitignore string
 }
 
 // RepositoryDefaultBranch returns the default branch of a repository.
@@ -59,3 +74,38 @@ func RepositoryDefaultBranch(repo Repository) (string, error) {
 
 	return ref.Name().Short(), nil
 }
+
+// Visibility is who can read a repository besides its collaborators.
+type Visibility string
+
+const (
+	// VisibilityPublic repositories are readable by anyone, including
+	// anonymous users.
+	VisibilityPublic Visibility = "public"
+	// VisibilityInternal repositories are readable by any authenticated user.
+	VisibilityInternal Visibility = "internal"
+	// VisibilityPrivate repositories are readable by collaborators only.
+	VisibilityPrivate Visibility = "private"
+)
+
+// ParseVisibility parses a repository visibility, "public", "internal" or
+// "private".
+func ParseVisibility(s string) (Visibility, error) {
+	switch v := Visibility(strings.ToLower(s)); v {
+	case VisibilityPublic, VisibilityInternal, VisibilityPrivate:
+		return v, nil
+	}
+	return "", fmt.Errorf("invalid visibility %q, must be public, internal or private", s)
+}
+
+// RepositoryVisibility returns the visibility of a repository.
+func RepositoryVisibility(repo Repository) Visibility {
+	switch {
+	case repo.IsPrivate():
+		return VisibilityPrivate
+	case repo.IsInternal():
+		return VisibilityInternal
+	default:
+		return VisibilityPublic
+	}
+}
@@ -10,16 +10,32 @@ type User interface {
 	Username() string
 	// IsAdmin returns whether the user is an admin.
 	IsAdmin() bool
+	// IsBot returns whether the user is a machine user.
+	IsBot() bool
 	// PublicKeys returns the user's public keys.
 	PublicKeys() []ssh.PublicKey
 	// Password returns the user's password hash.
 	Password() string
 }
 
+// BotBadge is appended to the names of machine users in issues and merge
+// requests they author.
+const BotBadge = " [bot]"
+
+// DisplayName returns the username of u, with BotBadge for machine users.
+func DisplayName(u User) string {
+	if u.IsBot() {
+		return u.Username() + BotBadge
+	}
+	return u.Username()
+}
+
 // UserOptions are options for creating a user.
 type UserOptions struct {
 	// Admin is whether the user is an admin.
 	Admin bool
+	// Bot is whether the user is a machine user.
+	Bot bool
 	// PublicKeys are the user's public keys.
 	PublicKeys []ssh.PublicKey
 }
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"errors"
+
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/spf13/cobra"
+)
+
+func userExportDataCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "export-data [USERNAME]",
+		Short: "Export everything associated with your account",
+		Long: `Write a gzipped tar archive of everything associated with your account to
+stdout: your account, public keys, preferences, access tokens, linked chat
+accounts, watches, and the issues, merge requests, and attachments you
+authored. Admins can export the data of another user.
+
+  ssh soft user export-data > my-data.tar.gz`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+
+			user := proto.UserFromContext(ctx)
+			if len(args) > 0 {
+				if err := checkIfAdmin(cmd, nil); err != nil {
+					return err
+				}
+				u, err := be.User(ctx, args[0])
+				if err != nil {
+					return err
+				}
+				user = u
+			} else if user == nil {
+				return proto.ErrUserNotFound
+			}
+
+			return be.ExportUserData(ctx, user, cmd.OutOrStdout())
+		},
+	}
+}
+
+func userDeleteAccountCommand() *cobra.Command {
+	var yes bool
+
+	cmd := &cobra.Command{
+		Use:   "delete-account",
+		Short: "Delete your account",
+		Long: `Delete your account and your repositories. The issues, merge requests, and
+attachments you authored stay, attributed to the "ghost" user. Export your
+data with export-data first to keep a copy.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if !yes {
+				return errors.New("deleting your account can't be undone, run again with --yes to confirm")
+			}
+
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			if err := be.DeleteAccount(ctx); err != nil {
+				return err
+			}
+
+			cmd.Println("Your account was deleted")
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&yes, "yes", false, "confirm the deletion of your account")
+
+	return cmd
+}
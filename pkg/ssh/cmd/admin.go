@@ -0,0 +1,278 @@
+package cmd
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss/v2/table"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/config"
+	"github.com/dustin/go-humanize"
+	"github.com/spf13/cobra"
+)
+
+// AdminCommand returns a command for administrating the server.
+func AdminCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "admin",
+		Short: "Administrate the server",
+		// Only server admins, whatever the arguments: repository admins
+		// of a repository named like a subcommand don't count.
+		PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
+			return checkIfAdmin(cmd, nil)
+		},
+	}
+
+	repoCmd := &cobra.Command{
+		Use:     "repo",
+		Aliases: []string{"repos", "repository", "repositories"},
+		Short:   "Administrate repositories",
+	}
+	repoCmd.AddCommand(
+		adminRepoRelocateCommand(),
+		adminRepoPoolsCommand(),
+		adminRepoDedupCommand(),
+		adminRepoOptimizeCommand(),
+		adminRepoRecoverCommand(),
+		adminRepoRedactCommand(),
+	)
+	cmd.AddCommand(repoCmd, adminModerationCommand(), adminReconcileCommand(), adminRetentionCommand(), adminStatsCommand(), adminSudoCommand())
+
+	return cmd
+}
+
+func adminRepoRelocateCommand() *cobra.Command {
+	var volume string
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "relocate [REPOSITORY...]",
+		Short: "Move repositories to other volumes",
+		Long: `Move repositories to other storage volumes.
+
+Without repositories, spread all the repositories evenly across the configured
+volumes and move them to the configured shard layout, or move all of them to
+--volume. Repositories shouldn't be pushed to while they move.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+
+			var plan []backend.Relocation
+			switch {
+			case len(args) == 0 && volume == "":
+				var err error
+				plan, err = be.PlanRebalance(ctx)
+				if err != nil {
+					return err
+				}
+			case len(args) == 0:
+				// Move every repository to the volume, e.g. to drain another.
+				repos, err := be.Repositories(ctx)
+				if err != nil {
+					return err
+				}
+				for _, r := range repos {
+					args = append(args, r.Name())
+				}
+			}
+			for _, name := range args {
+				r, err := be.PlanRelocation(ctx, name, volume)
+				if err != nil {
+					return err
+				}
+				if r.From != r.To {
+					plan = append(plan, r)
+				}
+			}
+
+			if len(plan) == 0 {
+				cmd.Println("Nothing to relocate")
+				return nil
+			}
+
+			table := table.New().Headers("Repository", "From", "To")
+			for _, r := range plan {
+				table = table.Row(r.Repo, r.From.String(), r.To.String())
+			}
+			cmd.Println(table)
+			if dryRun {
+				return nil
+			}
+
+			for _, r := range plan {
+				if err := be.Relocate(ctx, r); err != nil {
+					return err
+				}
+			}
+			cmd.Printf("Relocated %d repositories\n", len(plan))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&volume, "volume", "v", "", "the volume to move the repositories to, defaults to the one holding the fewest")
+	cmd.Flags().BoolVarP(&dryRun, "dry-run", "n", false, "only print the moves")
+
+	return cmd
+}
+
+func adminRepoPoolsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pools",
+		Short: "List object pools and the space they save",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+
+			pools, err := be.ObjectPools(ctx)
+			if err != nil {
+				return err
+			}
+			if len(pools) == 0 {
+				cmd.Println("No object pools")
+				return nil
+			}
+
+			var size, saved int64
+			table := table.New().Headers("Pool", "Repositories", "Size", "Saved")
+			for _, p := range pools {
+				table = table.Row(p.Name, strings.Join(p.Repos, ", "),
+					humanize.Bytes(uint64(p.Size)), humanize.Bytes(uint64(p.Saved))) //nolint:gosec
+				size += p.Size
+				saved += p.Saved
+			}
+			cmd.Println(table)
+			cmd.Printf("Total: %s in %d pools, %s saved\n",
+				humanize.Bytes(uint64(size)), len(pools), humanize.Bytes(uint64(saved))) //nolint:gosec
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func adminRepoOptimizeCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "optimize [REPOSITORY...]",
+		Short: "Write the commit-graph and reachability bitmaps of repositories",
+		Long: `Write the commit-graph and reachability bitmaps of repositories now.
+
+Without repositories, optimize the repositories large pushes marked, instead of
+waiting for the optimize job.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+
+			if len(args) == 0 {
+				if err := be.OptimizeRepositories(ctx); err != nil {
+					return err
+				}
+				cmd.Println("Optimized repositories")
+				return nil
+			}
+
+			for _, name := range args {
+				if err := be.OptimizeRepository(ctx, name); err != nil {
+					return err
+				}
+				cmd.Printf("Optimized %s\n", name)
+			}
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func adminRepoDedupCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "dedup",
+		Short: "Share the objects of related repositories through object pools",
+		Long: `Share the objects of repositories with the same history through object
+pools now, instead of waiting for the deduplication job.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			cfg := config.FromContext(ctx)
+
+			if !cfg.Storage.ObjectPools {
+				return errors.New("object pools are disabled")
+			}
+			if err := be.DedupRepositories(ctx); err != nil {
+				return err
+			}
+
+			cmd.Println("Deduplicated repositories")
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func adminRepoRecoverCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "recover REPOSITORY REF SHA",
+		Short: "Restore a ref to an old value",
+		Long: `Restore a ref of a repository to an old value, e.g. a force-pushed or deleted
+branch to a commit listed by "repo reflog". Branches can be given by their
+short name.`,
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+
+			if err := be.RecoverRef(ctx, args[0], args[1], args[2]); err != nil {
+				return err
+			}
+
+			cmd.Printf("Recovered %s of %s to %s\n", args[1], args[0], args[2])
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func adminRepoRedactCommand() *cobra.Command {
+	var replacement string
+
+	cmd := &cobra.Command{
+		Use:   "redact REPOSITORY SECRET",
+		Short: "Redact a leaked secret from a repository",
+		Long: `Replace a leaked secret in the issues and merge requests of a repository, full
+descriptions included, and in the notifications, activities, events, and
+webhook deliveries derived from them. The git history isn't rewritten: the
+commits still containing the secret are listed instead. The audit log records
+the redaction, never the secret.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+
+			red, err := be.RedactSecret(ctx, args[0], args[1], replacement)
+			if err != nil {
+				return err
+			}
+
+			cmd.Printf("Redacted %d records of %s\n", red.Records, args[0])
+			if len(red.Commits) == 0 {
+				return nil
+			}
+
+			cmd.Printf("\nThe secret is still in %d commits:\n", len(red.Commits))
+			for _, sha := range red.Commits {
+				cmd.Println(sha)
+			}
+			cmd.Println("\nRewrite them in a clone, e.g. with \"git filter-repo --replace-text\",")
+			cmd.Println("force-push every branch and tag, and rotate the secret: anyone who fetched")
+			cmd.Println("the repository may still have it.")
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&replacement, "replacement", "r", backend.DefaultRedaction, "text replacing the secret")
+
+	return cmd
+}
@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"errors"
+
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/spf13/cobra"
+)
+
+func agreementsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "agreements",
+		Short: "Manage the repository contribution agreements",
+		Long: `Manage the repository contribution agreements.
+
+Merge requests can't be merged until they satisfy the agreements of their
+repository: with DCO, every commit must carry a Signed-off-by trailer matching
+its author, and with a CLA, the author must have accepted the current version of
+the contributor license agreement document on the default branch.`,
+	}
+
+	cmd.AddCommand(
+		agreementsSetCommand(),
+		agreementsShowCommand(),
+		agreementsDeleteCommand(),
+		agreementsAcceptCommand(),
+	)
+
+	return cmd
+}
+
+func agreementsSetCommand() *cobra.Command {
+	var dco bool
+	var cla string
+
+	cmd := &cobra.Command{
+		Use:               "set REPOSITORY",
+		Short:             "Set the contribution agreements",
+		Args:              cobra.ExactArgs(1),
+		PersistentPreRunE: checkIfReadableAndCollab,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+
+			return be.SetContributionAgreements(ctx, args[0], dco, cla)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&dco, "dco", "d", false, "require every commit to be signed off by its author")
+	cmd.Flags().StringVarP(&cla, "cla", "c", "", "path of the contributor license agreement on the default branch")
+
+	return cmd
+}
+
+func agreementsShowCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "show REPOSITORY",
+		Short:             "Show the contribution agreements",
+		Args:              cobra.ExactArgs(1),
+		PersistentPreRunE: checkIfReadable,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+
+			agreements, err := be.ContributionAgreements(ctx, args[0])
+			if err != nil {
+				return err
+			}
+
+			cmd.Printf("DCO: %t\n", agreements.RequireDCO)
+			if agreements.CLAPath == "" {
+				cmd.Println("CLA: none")
+				return nil
+			}
+			cmd.Printf("CLA: %s\n", agreements.CLAPath)
+
+			if user := proto.UserFromContext(ctx); user != nil {
+				accepted, err := be.HasAcceptedCLA(ctx, args[0], user)
+				if err != nil && !errors.Is(err, backend.ErrNoCLA) {
+					return err
+				}
+				cmd.Printf("Accepted: %t\n", accepted)
+			}
+
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func agreementsDeleteCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "delete REPOSITORY",
+		Short:             "Delete the contribution agreements",
+		Args:              cobra.ExactArgs(1),
+		PersistentPreRunE: checkIfReadableAndCollab,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+
+			return be.DeleteContributionAgreements(ctx, args[0])
+		},
+	}
+
+	return cmd
+}
+
+func agreementsAcceptCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "accept REPOSITORY",
+		Short:             "Accept the contributor license agreement",
+		Long:              "Accept the current version of the contributor license agreement of a repository. Read it first with `repo blob`.",
+		Args:              cobra.ExactArgs(1),
+		PersistentPreRunE: checkIfReadable,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+
+			if err := be.AcceptCLA(ctx, args[0]); err != nil {
+				return err
+			}
+
+			cmd.Printf("Accepted the contributor license agreement of %s\n", args[0])
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,23 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/dustin/go-humanize"
+	"github.com/spf13/cobra"
+)
+
+// printAttachments prints the download links of the files attached to an
+// issue or a merge request.
+func printAttachments(ctx context.Context, cmd *cobra.Command, be *backend.Backend, repo string, subject backend.Subject) {
+	as, err := be.Attachments(ctx, repo, subject)
+	if err != nil || len(as) == 0 {
+		return
+	}
+
+	cmd.Printf("\nAttachments:\n")
+	for _, a := range as {
+		cmd.Printf("  %s (%s) - %s\n", a.Name, humanize.Bytes(uint64(a.Size)), be.AttachmentURL(repo, a)) //nolint:gosec
+	}
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"strconv"
+
+	"github.com/charmbracelet/lipgloss/v2/table"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/spf13/cobra"
+)
+
+// AuditCommand returns a command that lists the audit log.
+func AuditCommand() *cobra.Command {
+	var limit int
+
+	cmd := &cobra.Command{
+		Use:               "audit",
+		Short:             "List the audit log",
+		Long:              "List the latest security sensitive actions, newest first.",
+		Args:              cobra.NoArgs,
+		PersistentPreRunE: checkIfAdmin,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			dates := userDates(cmd)
+			be := backend.FromContext(ctx)
+
+			es, err := be.AuditEvents(ctx, limit)
+			if err != nil {
+				return err
+			}
+
+			if len(es) == 0 {
+				cmd.Println("No audit events")
+				return nil
+			}
+
+			actors := map[int64]string{}
+			username := func(id int64) string {
+				if _, ok := actors[id]; !ok {
+					if u, err := be.UserByID(ctx, id); err == nil {
+						actors[id] = u.Username()
+					}
+				}
+				return actors[id]
+			}
+			table := table.New().Headers("ID", "Action", "Target", "Detail", "By", "When")
+			for _, e := range es {
+				var actor string
+				if e.ActorID.Valid {
+					actor = username(e.ActorID.Int64)
+				}
+				if e.ImpersonatorID.Valid {
+					actor += " (sudo by " + username(e.ImpersonatorID.Int64) + ")"
+				}
+				table = table.Row(strconv.FormatInt(e.ID, 10),
+					e.Action,
+					e.Target,
+					e.Detail,
+					actor,
+					dates.Relative(e.CreatedAt),
+				)
+			}
+			cmd.Println(table)
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVarP(&limit, "limit", "n", 50, "number of events to list")
+
+	return cmd
+}
@@ -22,12 +22,17 @@ func branchCommand() *cobra.Command {
 		branchListCommand(),
 		branchDefaultCommand(),
 		branchDeleteCommand(),
+		branchProtectCommand(),
+		branchUnprotectCommand(),
+		branchProtectedCommand(),
 	)
 
 	return cmd
 }
 
 func branchListCommand() *cobra.Command {
+	var aheadBehind bool
+
 	cmd := &cobra.Command{
 		Use:               "list REPOSITORY",
 		Short:             "List repository branches",
@@ -48,14 +53,36 @@ func branchListCommand() *cobra.Command {
 			}
 
 			branches, _ := r.Branches()
+			if !aheadBehind {
+				for _, b := range branches {
+					cmd.Println(b)
+				}
+				return nil
+			}
+
+			head, err := r.HEAD()
+			if err != nil {
+				return err
+			}
+			def := head.Name().Short()
 			for _, b := range branches {
-				cmd.Println(b)
+				if b == def {
+					cmd.Println(b)
+					continue
+				}
+				ahead, behind, err := be.AheadBehind(ctx, rn, git.RefsHeads+def, git.RefsHeads+b)
+				if err != nil {
+					return err
+				}
+				cmd.Printf("%s (%d ahead, %d behind %s)\n", b, ahead, behind, def)
 			}
 
 			return nil
 		},
 	}
 
+	cmd.Flags().BoolVar(&aheadBehind, "ahead-behind", false, "Show how many commits each branch is ahead and behind the default branch")
+
 	return cmd
 }
 
@@ -185,6 +212,12 @@ func branchDeleteCommand() *cobra.Command {
 				return fmt.Errorf("cannot delete the default branch")
 			}
 
+			if b, ok, err := be.BranchProtection(ctx, rn, branch); err != nil {
+				return err
+			} else if ok && b.RequireLease {
+				return fmt.Errorf("cannot delete %s, it's protected by %s and requires a lease, push the deletion instead", branch, b.Pattern)
+			}
+
 			branchCommit, err := r.BranchCommit(branch)
 			if err != nil {
 				return err
@@ -205,3 +238,79 @@ func branchDeleteCommand() *cobra.Command {
 
 	return cmd
 }
+
+func branchProtectCommand() *cobra.Command {
+	var requireLease bool
+
+	cmd := &cobra.Command{
+		Use:   "protect REPOSITORY PATTERN",
+		Short: "Protect branches",
+		Long: `Protect the branches matching a glob pattern, e.g. "main" or "release/*".
+Watchers of the repository are notified when the history of protected branches
+and of the default branch is rewritten.
+
+With --require-lease, force pushes and deletions of the branches are rejected
+unless they state the tip they overwrite, like --force-with-lease does:
+
+  git push --force -o lease=<sha> origin main`,
+		Args:              cobra.ExactArgs(2),
+		PersistentPreRunE: checkIfReadableAndCollab,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+
+			return be.ProtectBranch(ctx, args[0], args[1], requireLease)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&requireLease, "require-lease", "l", false, "require a lease to force push or delete the branches")
+
+	return cmd
+}
+
+func branchUnprotectCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "unprotect REPOSITORY PATTERN",
+		Short:             "Remove the protection of branches",
+		Args:              cobra.ExactArgs(2),
+		PersistentPreRunE: checkIfReadableAndCollab,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+
+			return be.UnprotectBranch(ctx, args[0], args[1])
+		},
+	}
+
+	return cmd
+}
+
+func branchProtectedCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "protected REPOSITORY",
+		Short:             "List protected branches",
+		Args:              cobra.ExactArgs(1),
+		PersistentPreRunE: checkIfReadable,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+
+			bs, err := be.ProtectedBranches(ctx, args[0])
+			if err != nil {
+				return err
+			}
+
+			for _, b := range bs {
+				if b.RequireLease {
+					cmd.Println(b.Pattern, "(require lease)")
+				} else {
+					cmd.Println(b.Pattern)
+				}
+			}
+
+			return nil
+		},
+	}
+
+	return cmd
+}
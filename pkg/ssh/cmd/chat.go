@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"errors"
+
+	"github.com/charmbracelet/lipgloss/v2/table"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/spf13/cobra"
+)
+
+// ChatCommand returns a command that manages the chat accounts linked to the
+// user.
+func ChatCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "chat",
+		Short: "Manage linked chat accounts",
+		Long: `Manage linked chat accounts.
+
+Send "link" to Soft Serve from Slack or Matrix to get a link code, then run
+"chat link CODE" to link that chat account to your user.`,
+	}
+
+	linkCmd := &cobra.Command{
+		Use:   "link CODE",
+		Short: "Link a chat account using a link code",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+
+			user := proto.UserFromContext(ctx)
+			if user == nil {
+				return proto.ErrUserNotFound
+			}
+
+			ci, err := be.LinkChatIdentity(ctx, user, args[0])
+			if err != nil {
+				return err
+			}
+
+			cmd.Printf("Linked %s account %s\n", ci.Provider, ci.ExternalID)
+			return nil
+		},
+	}
+
+	listCmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List linked chat accounts",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			dates := userDates(cmd)
+			be := backend.FromContext(ctx)
+
+			user := proto.UserFromContext(ctx)
+			if user == nil {
+				return proto.ErrUserNotFound
+			}
+
+			cis, err := be.ChatIdentities(ctx, user)
+			if err != nil {
+				return err
+			}
+
+			if len(cis) == 0 {
+				cmd.Println("No linked chat accounts")
+				return nil
+			}
+
+			table := table.New().Headers("Provider", "Account", "Linked At")
+			for _, ci := range cis {
+				table = table.Row(ci.Provider, ci.ExternalID, dates.Relative(ci.CreatedAt))
+			}
+			cmd.Println(table)
+			return nil
+		},
+	}
+
+	unlinkCmd := &cobra.Command{
+		Use:   "unlink PROVIDER ACCOUNT",
+		Short: "Unlink a chat account",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+
+			user := proto.UserFromContext(ctx)
+			if user == nil {
+				return proto.ErrUserNotFound
+			}
+
+			if err := be.UnlinkChatIdentity(ctx, user, args[0], args[1]); err != nil {
+				if errors.Is(err, db.ErrRecordNotFound) {
+					return errors.New("chat account not found")
+				}
+				return err
+			}
+
+			cmd.Printf("Unlinked %s account %s\n", args[0], args[1])
+			return nil
+		},
+	}
+
+	cmd.AddCommand(
+		linkCmd,
+		listCmd,
+		unlinkCmd,
+	)
+
+	return cmd
+}
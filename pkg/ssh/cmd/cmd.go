@@ -2,7 +2,6 @@ package cmd
 
 import (
 	"fmt"
-	"net/url"
 	"strings"
 	"text/template"
 	"unicode"
@@ -10,6 +9,7 @@ import (
 	"github.com/charmbracelet/soft-serve/pkg/access"
 	"github.com/charmbracelet/soft-serve/pkg/backend"
 	"github.com/charmbracelet/soft-serve/pkg/config"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
 	"github.com/charmbracelet/soft-serve/pkg/proto"
 	"github.com/charmbracelet/soft-serve/pkg/sshutils"
 	"github.com/charmbracelet/soft-serve/pkg/utils"
@@ -65,20 +65,7 @@ Use "{{.SSHCommand}}{{.CommandPath}} [command] --help" for more information abou
 func UsageFunc(c *cobra.Command) error {
 	ctx := c.Context()
 	cfg := config.FromContext(ctx)
-	hostname := "localhost"
-	port := "23231"
-	url, err := url.Parse(cfg.SSH.PublicURL)
-	if err == nil {
-		hostname = url.Hostname()
-		port = url.Port()
-	}
-
-	sshCmd := "ssh"
-	if port != "" && port != "22" {
-		sshCmd += " -p " + port
-	}
-
-	sshCmd += " " + hostname
+	sshCmd := cfg.SSHCommand()
 	t := template.New("usage")
 	t.Funcs(templateFuncs)
 	template.Must(t.Parse(c.UsageTemplate()))
@@ -126,6 +113,26 @@ func checkIfReadable(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// checkIfGuest checks that the user can at least see the issues and merge
+// requests of the repository, like guests of private repositories, who can't
+// read its code.
+func checkIfGuest(cmd *cobra.Command, args []string) error {
+	var repo string
+	if len(args) > 0 {
+		repo = args[0]
+	}
+
+	ctx := cmd.Context()
+	be := backend.FromContext(ctx)
+	rn := utils.SanitizeRepo(repo)
+	user := proto.UserFromContext(ctx)
+	auth := be.AccessLevelForUser(cmd.Context(), rn, user)
+	if auth < access.GuestAccess {
+		return proto.ErrRepoNotFound
+	}
+	return nil
+}
+
 // IsPublicKeyAdmin returns true if the given public key is an admin key from
 // the initial_admin_keys config or environment field.
 func IsPublicKeyAdmin(cfg *config.Config, pk ssh.PublicKey) bool {
@@ -186,6 +193,19 @@ func checkIfCollab(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// warnIfTruncated prints a warning when the given description is large enough
+// to only be shown as a truncated preview in listings.
+func warnIfTruncated(cmd *cobra.Command, description string) {
+	cfg := config.FromContext(cmd.Context())
+	if cfg == nil || cfg.Limits.LargeTextThreshold <= 0 {
+		return
+	}
+
+	if len(description) > cfg.Limits.LargeTextThreshold {
+		cmd.PrintErrf("Warning: description is larger than %d bytes and will be truncated in listings\n", cfg.Limits.LargeTextThreshold)
+	}
+}
+
 func checkIfReadableAndCollab(cmd *cobra.Command, args []string) error {
 	if err := checkIfReadable(cmd, args); err != nil {
 		return err
@@ -195,3 +215,98 @@ func checkIfReadableAndCollab(cmd *cobra.Command, args []string) error {
 	}
 	return nil
 }
+
+// parseListOrder parses the --sort and --order flags of the issue and merge
+// request lists.
+func parseListOrder(sortBy string, order string) (models.ListOrder, error) {
+	s, err := models.ParseListSort(sortBy)
+	if err != nil {
+		return models.ListOrder{}, err
+	}
+	switch strings.ToLower(order) {
+	case "desc":
+		return models.ListOrder{Sort: s}, nil
+	case "asc":
+		return models.ListOrder{Sort: s, Ascending: true}, nil
+	default:
+		return models.ListOrder{}, fmt.Errorf("invalid order: %s (must be one of: asc, desc)", order)
+	}
+}
+
+// printIssueLinks prints the ssh command and, when the web interface is
+// enabled, the URL that show the issue with the given ID, so it can be
+// shared. Both address the issue by its number.
+func printIssueLinks(cmd *cobra.Command, repo string, id int64) {
+	ctx := cmd.Context()
+	cfg := config.FromContext(ctx)
+	repo = utils.SanitizeRepo(repo)
+	issue, err := backend.FromContext(ctx).GetIssue(ctx, repo, id)
+	if err != nil {
+		return
+	}
+	printLinks(cmd, fmt.Sprintf("%s repo issue show %s %d", cfg.SSHCommand(), repo, issue.Number), cfg.IssueURL(repo, issue.Number))
+}
+
+// printMergeRequestLinks prints the ssh command and, when the web interface
+// is enabled, the URL that show a merge request, so it can be shared.
+func printMergeRequestLinks(cmd *cobra.Command, repo string, id int64) {
+	cfg := config.FromContext(cmd.Context())
+	repo = utils.SanitizeRepo(repo)
+	printLinks(cmd, fmt.Sprintf("%s repo mr show %s %d", cfg.SSHCommand(), repo, id), cfg.MergeRequestURL(repo, id))
+}
+
+func printLinks(cmd *cobra.Command, sshCmd string, webURL string) {
+	cmd.Printf("SSH: %s\n", sshCmd)
+	if webURL != "" {
+		cmd.Printf("URL: %s\n", webURL)
+	}
+}
+
+// printContributingGuide welcomes a first-time contributor to a repository
+// with the summary of its contributing guide, and how to read all of it.
+func printContributingGuide(cmd *cobra.Command, repo string) {
+	ctx := cmd.Context()
+	guide, err := backend.FromContext(ctx).ContributingGuide(ctx, repo)
+	if err != nil {
+		return
+	}
+
+	cfg := config.FromContext(ctx)
+	repo = utils.SanitizeRepo(repo)
+	cmd.Printf("\nWelcome, this is your first contribution to %s! Please read the contributing guide:\n", repo)
+	if guide.Summary != "" {
+		cmd.Printf("\n  %s\n\n", guide.Summary)
+	}
+	printLinks(cmd, fmt.Sprintf("%s repo blob %s %s", cfg.SSHCommand(), repo, guide.Path), "")
+}
+
+// WithDateFlags adds the flags overriding the dates formatting of the user
+// to c and its subcommands.
+func WithDateFlags(c *cobra.Command) {
+	c.PersistentFlags().Bool("relative", false, "show dates relative to now, e.g. \"3 days ago\"")
+	c.PersistentFlags().Bool("stable", false, "show dates in UTC as RFC 3339, regardless of preferences, for scripts")
+	c.MarkFlagsMutuallyExclusive("relative", "stable")
+}
+
+// userDates returns the dates formatting of the user running cmd, as
+// overridden by the --relative and --stable flags.
+func userDates(cmd *cobra.Command) backend.Dates {
+	if stable, _ := cmd.Flags().GetBool("stable"); stable {
+		return backend.StableDates
+	}
+
+	ctx := cmd.Context()
+	dates := backend.FromContext(ctx).UserDates(ctx, proto.UserFromContext(ctx))
+	if relative, _ := cmd.Flags().GetBool("relative"); relative {
+		dates.Style = backend.DateStyleRelative
+	}
+	return dates
+}
+
+// datesRequested returns whether dates were asked for with --relative or
+// --stable, for listings that don't show them otherwise.
+func datesRequested(cmd *cobra.Command) bool {
+	relative, _ := cmd.Flags().GetBool("relative")
+	stable, _ := cmd.Flags().GetBool("stable")
+	return relative || stable
+}
@@ -26,7 +26,7 @@ func collabAddCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:               "add REPOSITORY USERNAME [LEVEL]",
 		Short:             "Add a collaborator to a repo",
-		Long:              "Add a collaborator to a repo. LEVEL can be one of: no-access, read-only, read-write, or admin-access. Defaults to read-write.",
+		Long:              "Add a collaborator to a repo. LEVEL can be one of: no-access, guest, read-only, read-write, or admin-access. Defaults to read-write. Guests of private repos can see and open issues and see merge requests, but not read the code.",
 		Args:              cobra.RangeArgs(2, 3),
 		PersistentPreRunE: checkIfReadableAndCollab,
 		RunE: func(cmd *cobra.Command, args []string) error {
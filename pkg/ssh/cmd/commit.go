@@ -45,15 +45,11 @@ func commitCommand() *cobra.Command {
 				return err
 			}
 
-			patch, err := r.Patch(commit)
-			if err != nil {
-				return err
-			}
-
-			diff, err := r.Diff(commit)
+			diff, err := be.Diff(ctx, repoName, commit.ID.String(), git.DiffOptions{})
 			if err != nil {
 				return err
 			}
+			patch := diff.Patch()
 
 			commonStyle := styles.DefaultStyles()
 			style := commonStyle.Log
@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/spf13/cobra"
+)
+
+func commitRulesCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "commit-rules",
+		Short: "Manage the repository commit message rules",
+		Long: `Manage the repository commit message rules.
+
+The messages of the commits pushed to the branches of the repository are
+checked against the rules, and pushes breaking them are rejected with the
+offending commits and how to fix them. In warn-only mode, pushes go through and
+the pusher gets a warning instead, e.g. to roll out new rules.`,
+	}
+
+	cmd.AddCommand(
+		commitRulesSetCommand(),
+		commitRulesShowCommand(),
+		commitRulesDeleteCommand(),
+	)
+
+	return cmd
+}
+
+func commitRulesSetCommand() *cobra.Command {
+	var rules models.CommitRules
+	var trailers []string
+
+	cmd := &cobra.Command{
+		Use:               "set REPOSITORY",
+		Short:             "Set the commit message rules",
+		Args:              cobra.ExactArgs(1),
+		PersistentPreRunE: checkIfReadableAndCollab,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+
+			rules.RequiredTrailers = strings.Join(trailers, ",")
+			return be.SetCommitRules(ctx, args[0], rules)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&rules.Conventional, "conventional", "c", false, "require Conventional Commits subjects")
+	cmd.Flags().IntVarP(&rules.MaxSubjectLength, "max-subject-length", "l", 0, "maximum length of the subjects, 0 for no limit")
+	cmd.Flags().StringSliceVarP(&trailers, "trailer", "t", nil, "required trailer, e.g. Signed-off-by")
+	cmd.Flags().BoolVarP(&rules.WarnOnly, "warn-only", "w", false, "warn about violations without rejecting pushes")
+
+	return cmd
+}
+
+func commitRulesShowCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "show REPOSITORY",
+		Short:             "Show the commit message rules",
+		Args:              cobra.ExactArgs(1),
+		PersistentPreRunE: checkIfReadable,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+
+			rules, err := be.CommitRules(ctx, args[0])
+			if err != nil {
+				return err
+			}
+
+			cmd.Printf("Conventional Commits: %t\n", rules.Conventional)
+			if rules.MaxSubjectLength > 0 {
+				cmd.Printf("Max Subject Length: %d\n", rules.MaxSubjectLength)
+			} else {
+				cmd.Println("Max Subject Length: none")
+			}
+			if rules.RequiredTrailers != "" {
+				cmd.Printf("Required Trailers: %s\n", strings.ReplaceAll(rules.RequiredTrailers, ",", ", "))
+			} else {
+				cmd.Println("Required Trailers: none")
+			}
+			cmd.Printf("Warn Only: %t\n", rules.WarnOnly)
+
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func commitRulesDeleteCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "delete REPOSITORY",
+		Short:             "Delete the commit message rules",
+		Args:              cobra.ExactArgs(1),
+		PersistentPreRunE: checkIfReadableAndCollab,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+
+			return be.DeleteCommitRules(ctx, args[0])
+		},
+	}
+
+	return cmd
+}
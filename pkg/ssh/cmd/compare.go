@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"strings"
+
+	gitm "github.com/aymanbagabas/git-module"
+	"github.com/charmbracelet/soft-serve/git"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/ui/styles"
+	"github.com/spf13/cobra"
+)
+
+// compareCommand returns a command that compares two revisions of a
+// repository.
+func compareCommand() *cobra.Command {
+	var stat bool
+	var color bool
+	var limit int
+
+	cmd := &cobra.Command{
+		Use:   "compare REPOSITORY BASE HEAD",
+		Short: "Compare two revisions of a repository",
+		Long: `Compare two revisions of a repository: how many commits HEAD is ahead and
+behind BASE, their merge base, and the commits of HEAD that BASE doesn't
+have, newest first. --stat adds the files changed since the merge base.`,
+		Args:              cobra.ExactArgs(3),
+		PersistentPreRunE: checkIfReadable,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			repo, base, head := args[0], args[1], args[2]
+
+			c, err := be.Compare(ctx, repo, base, head)
+			if err != nil {
+				return err
+			}
+
+			cmd.Printf("%s is %d ahead and %d behind %s\n", head, c.Ahead, c.Behind, base)
+			if c.MergeBase == "" {
+				cmd.Println("No common history")
+				return nil
+			}
+			cmd.Printf("Merge base: %s\n", c.MergeBase)
+
+			if c.Ahead > 0 {
+				rr, err := be.Repository(ctx, repo)
+				if err != nil {
+					return err
+				}
+				r, err := rr.Open()
+				if err != nil {
+					return err
+				}
+				commits, err := r.Log(c.Base+".."+c.Head, gitm.LogOptions{MaxCount: limit})
+				if err != nil {
+					return err
+				}
+
+				cmd.Println()
+				for _, commit := range commits {
+					subject, _, _ := strings.Cut(commit.Message, "\n")
+					cmd.Printf("%s %s\n", commit.ID.String()[:7], subject)
+				}
+				if c.Ahead > len(commits) {
+					cmd.Printf("... and %d more\n", c.Ahead-len(commits))
+				}
+			}
+
+			if stat {
+				diff, err := be.Diff(ctx, repo, c.Head, git.DiffOptions{Base: c.MergeBase})
+				if err != nil {
+					return err
+				}
+				if len(diff.Files) == 0 {
+					return nil
+				}
+				cmd.Println()
+				cmd.Println(renderStats(diff, styles.DefaultStyles(), color))
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&stat, "stat", false, "Show the files changed since the merge base")
+	cmd.Flags().BoolVarP(&color, "color", "c", false, "Colorize output")
+	cmd.Flags().IntVar(&limit, "limit", 50, "Maximum number of commits to list (0 lists all)")
+
+	return cmd
+}
@@ -12,9 +12,14 @@ import (
 // createCommand is the command for creating a new repository.
 func createCommand() *cobra.Command {
 	var private bool
+	var internal bool
 	var description string
 	var projectName string
 	var hidden bool
+	var defaultBranch string
+	var readme bool
+	var license string
+	var gitignore string
 
 	cmd := &cobra.Command{
 		Use:               "create REPOSITORY",
@@ -28,10 +33,15 @@ func createCommand() *cobra.Command {
 			user := proto.UserFromContext(ctx)
 			name := args[0]
 			r, err := be.CreateRepository(ctx, name, user, proto.RepositoryOptions{
-				Private:     private,
-				Description: description,
-				ProjectName: projectName,
-				Hidden:      hidden,
+				Private:       private,
+				Internal:      internal,
+				Description:   description,
+				ProjectName:   projectName,
+				Hidden:        hidden,
+				DefaultBranch: defaultBranch,
+				Readme:        readme,
+				License:       license,
+				Gitignore:     gitignore,
 			})
 			if err != nil {
 				return err
@@ -46,9 +56,14 @@ func createCommand() *cobra.Command {
 	}
 
 	cmd.Flags().BoolVarP(&private, "private", "p", false, "make the repository private")
+	cmd.Flags().BoolVar(&internal, "internal", false, "make the repository readable by authenticated users only")
 	cmd.Flags().StringVarP(&description, "description", "d", "", "set the repository description")
 	cmd.Flags().StringVarP(&projectName, "name", "n", "", "set the project name")
 	cmd.Flags().BoolVarP(&hidden, "hidden", "H", false, "hide the repository from the UI")
+	cmd.Flags().StringVar(&defaultBranch, "default-branch", "", "set the default branch, instead of the server default")
+	cmd.Flags().BoolVar(&readme, "readme", false, "create an initial commit with a README")
+	cmd.Flags().StringVar(&license, "license", "", "create an initial commit with a license, e.g. MIT")
+	cmd.Flags().StringVar(&gitignore, "gitignore", "", "create an initial commit with a .gitignore template, e.g. Go")
 
 	return cmd
 }
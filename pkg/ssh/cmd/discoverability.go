@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"strconv"
+
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/spf13/cobra"
+)
+
+func searchEnabledCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "search-enabled REPOSITORY [TRUE|FALSE]",
+		Short:             "Add a repository to, or remove it from, searches",
+		Args:              cobra.RangeArgs(1, 2),
+		PersistentPreRunE: checkIfReadable,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			repo := args[0]
+			switch len(args) {
+			case 1:
+				enabled, err := be.IsSearchEnabled(ctx, repo)
+				if err != nil {
+					return err
+				}
+
+				cmd.Println(enabled)
+			case 2:
+				enabled, err := strconv.ParseBool(args[1])
+				if err != nil {
+					return err
+				}
+				if err := checkIfCollab(cmd, args); err != nil {
+					return err
+				}
+				if err := be.SetSearchEnabled(ctx, repo, enabled); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func newsEnabledCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "news-enabled REPOSITORY [TRUE|FALSE]",
+		Short:             "Add a repository to, or remove it from, the news",
+		Args:              cobra.RangeArgs(1, 2),
+		PersistentPreRunE: checkIfReadable,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			repo := args[0]
+			switch len(args) {
+			case 1:
+				enabled, err := be.IsNewsEnabled(ctx, repo)
+				if err != nil {
+					return err
+				}
+
+				cmd.Println(enabled)
+			case 2:
+				enabled, err := strconv.ParseBool(args[1])
+				if err != nil {
+					return err
+				}
+				if err := checkIfCollab(cmd, args); err != nil {
+					return err
+				}
+				if err := be.SetNewsEnabled(ctx, repo, enabled); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	}
+
+	return cmd
+}
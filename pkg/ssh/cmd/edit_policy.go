@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"context"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/spf13/cobra"
+)
+
+func editPolicyCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "edit-policy REPOSITORY [author|admins|collaborators]",
+		Short: "Get or set who can edit the issues and merge requests of a repository",
+		Long: `Get or set who, besides their author, can edit and delete the issues and merge
+requests of a repository:
+
+  author         nobody else
+  admins         the repository admins
+  collaborators  the collaborators, the default`,
+		Args:              cobra.RangeArgs(1, 2),
+		PersistentPreRunE: checkIfReadable,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			repo := args[0]
+			switch len(args) {
+			case 1:
+				policy, err := be.EditPolicy(ctx, repo)
+				if err != nil {
+					return err
+				}
+
+				cmd.Println(policy)
+			case 2:
+				policy, err := backend.ParseEditPolicy(args[1])
+				if err != nil {
+					return err
+				}
+				if err := checkIfAdmin(cmd, args); err != nil {
+					return err
+				}
+				if err := be.SetEditPolicy(ctx, repo, policy); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+// printEditedBy prints who last edited an issue or merge request, and when.
+func printEditedBy(ctx context.Context, cmd *cobra.Command, be *backend.Backend, userID int64, at time.Time) {
+	editor := "a deleted user"
+	if u, err := be.UserByID(ctx, userID); err == nil {
+		editor = u.Username()
+	}
+	cmd.Printf("Edited By: %s at %s\n", editor, userDates(cmd).Absolute(at))
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"strconv"
+
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/spf13/cobra"
+)
+
+func issuesEnabledCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "issues-enabled REPOSITORY [TRUE|FALSE]",
+		Short:             "Turn the issues of a repository on or off",
+		Args:              cobra.RangeArgs(1, 2),
+		PersistentPreRunE: checkIfReadable,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			repo := args[0]
+			switch len(args) {
+			case 1:
+				enabled, err := be.IsIssuesEnabled(ctx, repo)
+				if err != nil {
+					return err
+				}
+
+				cmd.Println(enabled)
+			case 2:
+				enabled, err := strconv.ParseBool(args[1])
+				if err != nil {
+					return err
+				}
+				if err := checkIfCollab(cmd, args); err != nil {
+					return err
+				}
+				if err := be.SetIssuesEnabled(ctx, repo, enabled); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func mergeRequestsEnabledCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "merge-requests-enabled REPOSITORY [TRUE|FALSE]",
+		Short:             "Turn the merge requests of a repository on or off",
+		Args:              cobra.RangeArgs(1, 2),
+		PersistentPreRunE: checkIfReadable,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			repo := args[0]
+			switch len(args) {
+			case 1:
+				enabled, err := be.IsMergeRequestsEnabled(ctx, repo)
+				if err != nil {
+					return err
+				}
+
+				cmd.Println(enabled)
+			case 2:
+				enabled, err := strconv.ParseBool(args[1])
+				if err != nil {
+					return err
+				}
+				if err := checkIfCollab(cmd, args); err != nil {
+					return err
+				}
+				if err := be.SetMergeRequestsEnabled(ctx, repo, enabled); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -177,12 +177,8 @@ func gitRunE(cmd *cobra.Command, args []string) error {
 	ak := sshutils.MarshalAuthorizedKey(pk)
 	user := proto.UserFromContext(ctx)
 	accessLevel := be.AccessLevelForUser(ctx, name, user)
-	// git bare repositories should end in ".git"
-	// https://git-scm.com/docs/gitrepository-layout
-	repoDir := name + ".git"
-	reposDir := filepath.Join(cfg.DataPath, "repos")
-	if err := git.EnsureWithin(reposDir, repoDir); err != nil {
-		return err
+	if err := utils.ValidateRepo(name); err != nil {
+		return git.ErrInvalidRepo
 	}
 
 	// Set repo in context
@@ -192,7 +188,6 @@ func gitRunE(cmd *cobra.Command, args []string) error {
 	// Environment variables to pass down to git hooks.
 	envs := []string{
 		"SOFT_SERVE_REPO_NAME=" + name,
-		"SOFT_SERVE_REPO_PATH=" + filepath.Join(reposDir, repoDir),
 		"SOFT_SERVE_PUBLIC_KEY=" + ak,
 		"SOFT_SERVE_LOG_PATH=" + filepath.Join(cfg.DataPath, "log", "hooks.log"),
 	}
@@ -215,17 +210,28 @@ func gitRunE(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	repoPath := filepath.Join(reposDir, repoDir)
 	service := git.Service(cmd.Name())
 	stdin := cmd.InOrStdin()
 	stdout := cmd.OutOrStdout()
 	stderr := cmd.ErrOrStderr()
 	scmd := git.ServiceCommand{
-		Stdin:  stdin,
-		Stdout: stdout,
-		Stderr: stderr,
-		Env:    envs,
-		Dir:    repoPath,
+		Stdin:      stdin,
+		Stdout:     stdout,
+		Stderr:     stderr,
+		Env:        envs,
+		HiddenRefs: cfg.Git.HiddenRefs,
+	}
+
+	// setRepoPath runs the service in the repository directory, once the
+	// repository exists.
+	setRepoPath := func() error {
+		rp, err := be.RepositoryPath(ctx, name)
+		if err != nil {
+			return git.ErrInvalidRepo
+		}
+		scmd.Dir = rp
+		scmd.Env = append(scmd.Env, "SOFT_SERVE_REPO_PATH="+rp)
+		return nil
 	}
 
 	switch service {
@@ -238,13 +244,17 @@ func gitRunE(cmd *cobra.Command, args []string) error {
 			return git.ErrNotAuthed
 		}
 		if repo == nil {
-			if _, err := be.CreateRepository(ctx, name, user, proto.RepositoryOptions{Private: false}); err != nil {
+			if _, err := be.CreateRepositoryOnPush(ctx, name, user); err != nil {
 				log.Errorf("failed to create repo: %s", err)
 				return err
 			}
 			createRepoCounter.WithLabelValues(name).Inc()
 		}
 
+		if err := setRepoPath(); err != nil {
+			return err
+		}
+
 		if err := service.Handler(ctx, scmd); err != nil {
 			logger.Error("failed to handle git service", "service", service, "err", err, "repo", name)
 			defer func() {
@@ -274,6 +284,10 @@ func gitRunE(cmd *cobra.Command, args []string) error {
 			return git.ErrInvalidRepo
 		}
 
+		if err := setRepoPath(); err != nil {
+			return err
+		}
+
 		switch service {
 		case git.UploadArchiveService:
 			uploadArchiveCounter.WithLabelValues(name).Inc()
@@ -315,6 +329,10 @@ func gitRunE(cmd *cobra.Command, args []string) error {
 			return git.ErrInvalidRepo
 		}
 
+		if err := setRepoPath(); err != nil {
+			return err
+		}
+
 		scmd.Args = []string{
 			name,
 			args[1],
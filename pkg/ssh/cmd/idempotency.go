@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// withIdempotencyKey adds the --idempotency-key flag to the create command c.
+// Running c again with the same key prints the output of its first run
+// instead of creating a duplicate.
+func withIdempotencyKey(c *cobra.Command) *cobra.Command {
+	var key string
+	c.Flags().StringVar(&key, "idempotency-key", "", "a unique key making retries of this command print its first output instead of creating a duplicate")
+
+	runE := c.RunE
+	c.RunE = func(cmd *cobra.Command, args []string) error {
+		if key == "" {
+			return runE(cmd, args)
+		}
+
+		ctx := cmd.Context()
+		be := backend.FromContext(ctx)
+		out := cmd.OutOrStdout()
+		resp, replayed, err := be.Idempotent(ctx, key, idempotentRequest(cmd, args), func() (backend.IdempotentResponse, error) {
+			var buf bytes.Buffer
+			cmd.SetOut(io.MultiWriter(out, &buf))
+			defer cmd.SetOut(out)
+			err := runE(cmd, args)
+			return backend.IdempotentResponse{Body: buf.Bytes()}, err
+		})
+		if replayed {
+			_, err = out.Write(resp.Body)
+		}
+		return err
+	}
+
+	return c
+}
+
+// idempotentRequest describes a run of cmd, to tell whether an idempotency
+// key is reused for another command.
+func idempotentRequest(cmd *cobra.Command, args []string) string {
+	req := []string{cmd.CommandPath()}
+	req = append(req, args...)
+	cmd.Flags().Visit(func(f *pflag.Flag) {
+		if f.Name != "idempotency-key" {
+			req = append(req, fmt.Sprintf("--%s=%s", f.Name, f.Value))
+		}
+	})
+	return strings.Join(req, "\x00")
+}
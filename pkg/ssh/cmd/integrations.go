@@ -0,0 +1,270 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss/v2/table"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/utils"
+	"github.com/charmbracelet/soft-serve/pkg/webhook"
+	"github.com/spf13/cobra"
+)
+
+func integrationsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "integrations",
+		Aliases: []string{"integration"},
+		Short:   "Manage repository chat notification integrations",
+		Long: `Manage repository chat notification integrations.
+
+Integrations post formatted messages to Slack, Discord, or Matrix rooms when
+repository events happen. Slack and Discord integrations take the incoming
+webhook URL of the channel. Matrix integrations take the room message endpoint,
+e.g. https://matrix.org/_matrix/client/v3/rooms/!room:matrix.org/send/m.room.message,
+and an access token.
+
+Messages are rendered with Go text/template against the event payload. The
+template has access to the same fields as the webhook payload, e.g.
+{{.Repository.Name}}, {{.Sender.Username}}, and {{.Action}}.`,
+	}
+
+	cmd.AddCommand(
+		integrationListCommand(),
+		integrationCreateCommand(),
+		integrationDeleteCommand(),
+		integrationUpdateCommand(),
+	)
+
+	return cmd
+}
+
+func integrationKinds() string {
+	kinds := webhook.IntegrationKinds()
+	names := make([]string, len(kinds))
+	for i, k := range kinds {
+		names[i] = k.String()
+	}
+
+	return strings.Join(names, ", ")
+}
+
+func parseEvents(events []string) ([]webhook.Event, error) {
+	evs := make([]webhook.Event, 0, len(events))
+	for _, e := range events {
+		ev, err := webhook.ParseEvent(e)
+		if err != nil {
+			return nil, fmt.Errorf("invalid event: %w", err)
+		}
+
+		evs = append(evs, ev)
+	}
+
+	return evs, nil
+}
+
+func integrationListCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "list REPOSITORY",
+		Short:             "List repository integrations",
+		Args:              cobra.ExactArgs(1),
+		PersistentPreRunE: checkIfAdmin,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			dates := userDates(cmd)
+			be := backend.FromContext(ctx)
+			repo, err := be.Repository(ctx, args[0])
+			if err != nil {
+				return err
+			}
+
+			integrations, err := be.ListIntegrations(ctx, repo)
+			if err != nil {
+				return err
+			}
+
+			table := table.New().Headers("ID", "Kind", "URL", "Events", "Template", "Active", "Created At", "Updated At")
+			for _, i := range integrations {
+				events := make([]string, len(i.Events))
+				for j, e := range i.Events {
+					events[j] = e.String()
+				}
+
+				tmpl := "default"
+				if i.Template != "" {
+					tmpl = "custom"
+				}
+
+				table = table.Row(
+					strconv.FormatInt(i.ID, 10),
+					i.Kind.String(),
+					utils.Sanitize(i.URL),
+					strings.Join(events, ","),
+					tmpl,
+					strconv.FormatBool(i.Active),
+					dates.Relative(i.CreatedAt),
+					dates.Relative(i.UpdatedAt),
+				)
+			}
+			cmd.Println(table)
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func integrationCreateCommand() *cobra.Command {
+	var events []string
+	var secret string
+	var template string
+	var active bool
+	cmd := &cobra.Command{
+		Use:               "create REPOSITORY KIND URL",
+		Short:             "Create a repository integration",
+		Long:              fmt.Sprintf("Create a repository integration.\n\nKIND is one of (%s).", integrationKinds()),
+		Args:              cobra.ExactArgs(3),
+		PersistentPreRunE: checkIfAdmin,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			repo, err := be.Repository(ctx, args[0])
+			if err != nil {
+				return err
+			}
+
+			kind, err := webhook.ParseIntegrationKind(args[1])
+			if err != nil {
+				return err
+			}
+
+			evs, err := parseEvents(events)
+			if err != nil {
+				return err
+			}
+			if len(evs) == 0 {
+				return fmt.Errorf("at least one event is required")
+			}
+
+			if kind == webhook.IntegrationMatrix && secret == "" {
+				return fmt.Errorf("matrix integrations require an access token")
+			}
+
+			url := utils.Sanitize(args[2])
+			return be.CreateIntegration(ctx, repo, kind, strings.TrimSpace(url), secret, template, evs, active)
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(&events, "events", "e", nil, fmt.Sprintf("events to notify, available events are (%s)", strings.Join(webhookEvents, ", ")))
+	cmd.Flags().StringVarP(&secret, "token", "t", "", "access token, required for matrix")
+	cmd.Flags().StringVar(&template, "template", "", "message template, defaults to a built-in template for each event")
+	cmd.Flags().BoolVarP(&active, "active", "a", true, "whether the integration is active")
+
+	return cmd
+}
+
+func integrationDeleteCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "delete REPOSITORY INTEGRATION_ID",
+		Short:             "Delete a repository integration",
+		Args:              cobra.ExactArgs(2),
+		PersistentPreRunE: checkIfAdmin,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			repo, err := be.Repository(ctx, args[0])
+			if err != nil {
+				return err
+			}
+
+			id, err := strconv.ParseInt(args[1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid integration ID: %w", err)
+			}
+
+			return be.DeleteIntegration(ctx, repo, id)
+		},
+	}
+
+	return cmd
+}
+
+func integrationUpdateCommand() *cobra.Command {
+	var events []string
+	var secret string
+	var template string
+	var defaultTemplate bool
+	var active string
+	var url string
+	cmd := &cobra.Command{
+		Use:               "update REPOSITORY INTEGRATION_ID",
+		Short:             "Update a repository integration",
+		Args:              cobra.ExactArgs(2),
+		PersistentPreRunE: checkIfAdmin,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			repo, err := be.Repository(ctx, args[0])
+			if err != nil {
+				return err
+			}
+
+			id, err := strconv.ParseInt(args[1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid integration ID: %w", err)
+			}
+
+			in, err := be.Integration(ctx, repo, id)
+			if err != nil {
+				return err
+			}
+
+			newURL := in.URL
+			if url != "" {
+				newURL = utils.Sanitize(url)
+			}
+
+			newSecret := in.Secret
+			if secret != "" {
+				newSecret = secret
+			}
+
+			newTemplate := in.Template
+			if defaultTemplate {
+				newTemplate = ""
+			} else if template != "" {
+				newTemplate = template
+			}
+
+			newActive := in.Active
+			if active != "" {
+				active, err := strconv.ParseBool(active)
+				if err != nil {
+					return fmt.Errorf("invalid active value: %w", err)
+				}
+
+				newActive = active
+			}
+
+			newEvents := in.Events
+			if len(events) > 0 {
+				newEvents, err = parseEvents(events)
+				if err != nil {
+					return err
+				}
+			}
+
+			return be.UpdateIntegration(ctx, repo, id, strings.TrimSpace(newURL), newSecret, newTemplate, newEvents, newActive)
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(&events, "events", "e", nil, fmt.Sprintf("events to notify, available events are (%s)", strings.Join(webhookEvents, ", ")))
+	cmd.Flags().StringVarP(&secret, "token", "t", "", "access token, required for matrix")
+	cmd.Flags().StringVar(&template, "template", "", "message template")
+	cmd.Flags().BoolVar(&defaultTemplate, "default-template", false, "use the built-in message templates")
+	cmd.Flags().StringVarP(&active, "active", "a", "", "whether the integration is active")
+	cmd.Flags().StringVarP(&url, "url", "u", "", "integration URL")
+
+	return cmd
+}
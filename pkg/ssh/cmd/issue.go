@@ -1,12 +1,16 @@
 package cmd
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
-	"strconv"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/soft-serve/pkg/backend"
 	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
 	"github.com/spf13/cobra"
 )
 
@@ -19,24 +23,42 @@ func issueCommand() *cobra.Command {
 
 	cmd.AddCommand(
 		issueCreateCommand(),
+		issuePublishCommand(),
 		issueListCommand(),
 		issueShowCommand(),
 		issueUpdateCommand(),
+		issueDeleteCommand(),
 		issueCloseCommand(),
 		issueReopenCommand(),
+		issueLockCommand(),
+		issueUnlockCommand(),
+		issuePinCommand(),
+		issueUnpinCommand(),
+		issueWatchCommand(),
+		issueUnwatchCommand(),
 		issueAddDependencyCommand(),
 		issueRemoveDependencyCommand(),
+		issueVoteCommand(),
+		issueUnvoteCommand(),
+		issueReportCommand(),
+		issueLabelCommand(),
+		issueMilestoneCommand(),
+		issuePrefixCommand(),
+		issueDependencyPolicyCommand(),
+		issueRenderCommand(),
 	)
 
 	return cmd
 }
 
 func issueCreateCommand() *cobra.Command {
+	var draft bool
+
 	cmd := &cobra.Command{
 		Use:               "create REPOSITORY TITLE [DESCRIPTION]",
 		Short:             "Create an issue",
 		Args:              cobra.RangeArgs(2, 3),
-		PersistentPreRunE: checkIfReadable,
+		PersistentPreRunE: checkIfGuest,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
 			be := backend.FromContext(ctx)
@@ -47,12 +69,55 @@ func issueCreateCommand() *cobra.Command {
 				description = args[2]
 			}
 
-			issueID, err := be.CreateIssue(ctx, repo, title, description)
+			create := be.CreateIssue
+			if draft {
+				create = be.CreateDraftIssue
+			}
+			issueID, err := create(ctx, repo, title, description)
 			if err != nil {
 				return err
 			}
 
-			cmd.Printf("Created issue #%d\n", issueID)
+			if draft {
+				cmd.Printf("Created draft issue %s, publish it with \"repo issue publish\"\n", be.IssueRef(ctx, repo, issueID))
+			} else {
+				cmd.Printf("Created issue %s\n", be.IssueRef(ctx, repo, issueID))
+			}
+			printIssueLinks(cmd, args[0], issueID)
+			warnIfTruncated(cmd, description)
+			if issue, err := be.GetIssue(ctx, repo, issueID); err == nil && issue.FirstContribution {
+				printContributingGuide(cmd, repo)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&draft, "draft", false, "Create a draft issue, only visible to you until published")
+
+	return withIdempotencyKey(cmd)
+}
+
+func issuePublishCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "publish REPOSITORY ISSUE_ID",
+		Short:             "Publish a draft issue",
+		Args:              cobra.ExactArgs(2),
+		PersistentPreRunE: checkIfGuest,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			repo := args[0]
+
+			issueID, err := be.ResolveIssueID(ctx, repo, args[1])
+			if err != nil {
+				return err
+			}
+
+			if err := be.PublishIssue(ctx, repo, issueID); err != nil {
+				return err
+			}
+
+			cmd.Printf("Published issue %s\n", be.IssueRef(ctx, repo, issueID))
 			return nil
 		},
 	}
@@ -62,12 +127,18 @@ func issueCreateCommand() *cobra.Command {
 
 func issueListCommand() *cobra.Command {
 	var stateFilter string
+	var sortBy string
+	var order string
+	var labelFilter string
+	var limit int
+	var after int64
+	var asJSON bool
 
 	cmd := &cobra.Command{
 		Use:               "list REPOSITORY",
 		Short:             "List issues",
 		Args:              cobra.ExactArgs(1),
-		PersistentPreRunE: checkIfReadable,
+		PersistentPreRunE: checkIfGuest,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
 			be := backend.FromContext(ctx)
@@ -82,21 +153,121 @@ func issueListCommand() *cobra.Command {
 				state = &s
 			}
 
-			issues, err := be.ListIssues(ctx, repo, state)
+			// Votes sort each page, listed by creation.
+			byVotes := strings.EqualFold(sortBy, "votes")
+			listSort := sortBy
+			if byVotes {
+				listSort = "created"
+			}
+			listOrder, err := parseListOrder(listSort, order)
+			if err != nil {
+				return err
+			}
+
+			issues, err := be.ListIssues(ctx, repo, backend.IssueListOptions{
+				State: state,
+				Order: listOrder,
+				Limit: limit,
+				After: after,
+			})
+			if err != nil {
+				return err
+			}
+
+			votes, err := be.IssueVoteCounts(ctx, repo)
+			if err != nil {
+				return err
+			}
+
+			labels, err := be.IssueLabelsByIssue(ctx, repo)
 			if err != nil {
 				return err
 			}
 
+			prefix, err := be.IssuePrefix(ctx, repo)
+			if err != nil {
+				return err
+			}
+
+			if labelFilter != "" {
+				if _, err := be.Label(ctx, repo, labelFilter); err != nil {
+					return err
+				}
+				filtered := issues[:0]
+				for _, issue := range issues {
+					for _, l := range labels[issue.ID] {
+						if l.Name == labelFilter {
+							filtered = append(filtered, issue)
+							break
+						}
+					}
+				}
+				issues = filtered
+			}
+
+			if byVotes {
+				// Pinned issues stay on top.
+				sort.SliceStable(issues, func(i, j int) bool {
+					if pi, pj := issues[i].Pinned.Valid, issues[j].Pinned.Valid; pi != pj {
+						return pi
+					}
+					return votes[issues[i].ID] > votes[issues[j].ID]
+				})
+			}
+
+			if asJSON {
+				results := make([]issueResult, len(issues))
+				for i, issue := range issues {
+					results[i] = issueResult{
+						Number:            issue.Number,
+						Title:             issue.Title,
+						State:             issue.State.String(),
+						CloseReason:       string(issue.CloseReason),
+						Votes:             votes[issue.ID],
+						Labels:            []string{},
+						Pinned:            issue.Pinned.Valid,
+						Draft:             issue.Draft,
+						FirstContribution: issue.FirstContribution,
+						CreatedAt:         issue.CreatedAt,
+						UpdatedAt:         issue.UpdatedAt,
+					}
+					for _, l := range labels[issue.ID] {
+						results[i].Labels = append(results[i].Labels, l.Name)
+					}
+				}
+				enc := json.NewEncoder(cmd.OutOrStdout())
+				enc.SetIndent("", "  ")
+				return enc.Encode(results)
+			}
+
 			if len(issues) == 0 {
 				cmd.Println("No issues found")
 				return nil
 			}
 
 			for _, issue := range issues {
-				cmd.Printf("#%d: %s [%s]\n",
-					issue.ID,
+				var badge string
+				if ls := labels[issue.ID]; len(ls) > 0 {
+					badge = " · " + labelNames(ls)
+				}
+				if issue.FirstContribution {
+					badge += " (first contribution)"
+				}
+				if issue.Pinned.Valid {
+					badge += " (pinned)"
+				}
+				if issue.Draft {
+					badge += " (draft)"
+				}
+				if datesRequested(cmd) {
+					badge += " · updated " + userDates(cmd).Relative(issue.UpdatedAt)
+				}
+				cmd.Printf("%s: %s [%s] ▲ %d%s\n",
+					backend.FormatIssueRef(prefix, issue.Number),
 					issue.Title,
 					issue.State.String(),
+					votes[issue.ID],
+					badge,
 				)
 			}
 
@@ -105,24 +276,46 @@ func issueListCommand() *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&stateFilter, "state", "", "Filter by state (open, closed)")
+	cmd.Flags().StringVar(&sortBy, "sort", "created", "Sort by (created, updated, title, state, votes)")
+	cmd.Flags().StringVar(&order, "order", "desc", "Sort order (asc, desc)")
+	cmd.Flags().StringVar(&labelFilter, "label", "", "Filter by label")
+	cmd.Flags().IntVar(&limit, "limit", 0, "Maximum number of issues to list (0 lists all)")
+	cmd.Flags().Int64Var(&after, "after", 0, "List the issues following this issue number in order, the last one of the previous page")
+	cmd.Flags().BoolVar(&asJSON, "json", false, "Print issues as JSON")
 
 	return cmd
 }
 
+// issueResult is an issue as printed with --json.
+type issueResult struct {
+	Number            int64     `json:"number"`
+	Title             string    `json:"title"`
+	State             string    `json:"state"`
+	CloseReason       string    `json:"close_reason,omitempty"`
+	Votes             int       `json:"votes"`
+	Labels            []string  `json:"labels"`
+	Pinned            bool      `json:"pinned"`
+	Draft             bool      `json:"draft"`
+	FirstContribution bool      `json:"first_contribution"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
 func issueShowCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:               "show REPOSITORY ISSUE_ID",
 		Short:             "Show issue details",
 		Args:              cobra.ExactArgs(2),
-		PersistentPreRunE: checkIfReadable,
+		PersistentPreRunE: checkIfGuest,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
+			dates := userDates(cmd)
 			be := backend.FromContext(ctx)
 			repo := args[0]
 
-			issueID, err := strconv.ParseInt(args[1], 10, 64)
+			issueID, err := be.ResolveIssueID(ctx, repo, args[1])
 			if err != nil {
-				return fmt.Errorf("invalid issue ID: %w", err)
+				return err
 			}
 
 			issue, err := be.GetIssue(ctx, repo, issueID)
@@ -130,15 +323,76 @@ func issueShowCommand() *cobra.Command {
 				return err
 			}
 
-			cmd.Printf("Issue #%d\n", issue.ID)
+			prefix, err := be.IssuePrefix(ctx, repo)
+			if err != nil {
+				return err
+			}
+
+			cmd.Printf("Issue %s\n", backend.FormatIssueRef(prefix, issue.Number))
 			cmd.Printf("Title: %s\n", issue.Title)
 			cmd.Printf("Description: %s\n", issue.Description)
 			cmd.Printf("State: %s\n", issue.State.String())
-			cmd.Printf("Created At: %s\n", issue.CreatedAt.Format("2006-01-02 15:04:05"))
-			cmd.Printf("Updated At: %s\n", issue.UpdatedAt.Format("2006-01-02 15:04:05"))
+			if issue.CloseReason != "" {
+				cmd.Printf("Close Reason: %s\n", issue.CloseReason)
+			}
+			if issue.Locked {
+				cmd.Println("Locked: only collaborators can edit or vote")
+			}
+			if issue.Draft {
+				cmd.Println("Draft: only visible to you until published")
+			}
+			if issue.Pinned.Valid {
+				cmd.Println("Pinned: to the top of the issue list")
+			}
+			if issue.ExternalID.Valid {
+				cmd.Printf("External ID: %s\n", issue.ExternalID.String)
+			}
+			cmd.Printf("Created At: %s\n", dates.Absolute(issue.CreatedAt))
+			cmd.Printf("Updated At: %s\n", dates.Absolute(issue.UpdatedAt))
 
 			if issue.ClosedAt.Valid {
-				cmd.Printf("Closed At: %s\n", issue.ClosedAt.Time.Format("2006-01-02 15:04:05"))
+				cmd.Printf("Closed At: %s\n", dates.Absolute(issue.ClosedAt.Time))
+			}
+			if issue.ClosedByCommit.Valid {
+				cmd.Printf("Closed By Commit: %s\n", issue.ClosedByCommit.String)
+			}
+			if issue.EditedByID.Valid {
+				printEditedBy(ctx, cmd, be, issue.EditedByID.Int64, issue.EditedAt.Time)
+			}
+			printIssueLinks(cmd, repo, issue.ID)
+
+			votes, err := be.IssueVoteCounts(ctx, repo)
+			if err != nil {
+				return err
+			}
+			voted, err := be.HasVotedIssue(ctx, repo, issueID, proto.UserFromContext(ctx))
+			if err != nil {
+				return err
+			}
+			if voted {
+				cmd.Printf("Votes: %d (including yours)\n", votes[issueID])
+			} else {
+				cmd.Printf("Votes: %d\n", votes[issueID])
+			}
+
+			labels, err := be.IssueLabels(ctx, repo, issueID)
+			if err != nil {
+				return err
+			}
+			if len(labels) > 0 {
+				cmd.Printf("Labels: %s\n", labelNames(labels))
+			}
+
+			if m, err := be.IssueMilestone(ctx, repo, issueID); err == nil {
+				cmd.Printf("Milestone: %s\n", m.Title)
+			}
+
+			subscribers, err := be.ListSubscribers(ctx, repo, backend.Subject{Type: backend.NotificationSubjectIssue, ID: issueID})
+			if err != nil {
+				return err
+			}
+			if len(subscribers) > 0 {
+				cmd.Printf("Subscribers: %s\n", usernames(subscribers))
 			}
 
 			// Display dependencies
@@ -146,7 +400,7 @@ func issueShowCommand() *cobra.Command {
 			if err == nil && len(dependencies) > 0 {
 				cmd.Printf("\nDepends on:\n")
 				for _, dep := range dependencies {
-					cmd.Printf("  #%d - %s\n", dep.ID, dep.Title)
+					cmd.Printf("  %s - %s\n", backend.FormatIssueRef(prefix, dep.Number), dep.Title)
 				}
 			}
 
@@ -155,10 +409,14 @@ func issueShowCommand() *cobra.Command {
 			if err == nil && len(dependents) > 0 {
 				cmd.Printf("\nBlocked by:\n")
 				for _, dep := range dependents {
-					cmd.Printf("  #%d - %s\n", dep.ID, dep.Title)
+					cmd.Printf("  %s - %s\n", backend.FormatIssueRef(prefix, dep.Number), dep.Title)
 				}
 			}
 
+			printReferencedBy(ctx, cmd, be, repo, backend.Subject{Type: backend.NotificationSubjectIssue, ID: issueID})
+			printAttachments(ctx, cmd, be, repo, backend.Subject{Type: backend.NotificationSubjectIssue, ID: issueID})
+			printTrackerLinks(cmd, be.IssueTrackerLinks(ctx, repo, issue.Title, issue.Description))
+
 			return nil
 		},
 	}
@@ -170,16 +428,17 @@ func issueUpdateCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:               "update REPOSITORY ISSUE_ID TITLE [DESCRIPTION]",
 		Short:             "Update an issue",
+		Long:              "Update an issue. Who can update the issues of others depends on the edit policy of the repository.",
 		Args:              cobra.RangeArgs(3, 4),
-		PersistentPreRunE: checkIfReadableAndCollab,
+		PersistentPreRunE: checkIfGuest,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
 			be := backend.FromContext(ctx)
 			repo := args[0]
 
-			issueID, err := strconv.ParseInt(args[1], 10, 64)
+			issueID, err := be.ResolveIssueID(ctx, repo, args[1])
 			if err != nil {
-				return fmt.Errorf("invalid issue ID: %w", err)
+				return err
 			}
 
 			title := args[2]
@@ -192,7 +451,39 @@ func issueUpdateCommand() *cobra.Command {
 				return err
 			}
 
-			cmd.Printf("Updated issue #%d\n", issueID)
+			cmd.Printf("Updated issue %s\n", be.IssueRef(ctx, repo, issueID))
+			printIssueLinks(cmd, args[0], issueID)
+			warnIfTruncated(cmd, description)
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func issueDeleteCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "delete REPOSITORY ISSUE_ID",
+		Short:             "Delete an issue",
+		Long:              "Delete an issue for good. Who can delete the issues of others depends on the edit policy of the repository.",
+		Args:              cobra.ExactArgs(2),
+		PersistentPreRunE: checkIfGuest,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			repo := args[0]
+
+			issueID, err := be.ResolveIssueID(ctx, repo, args[1])
+			if err != nil {
+				return err
+			}
+
+			ref := be.IssueRef(ctx, repo, issueID)
+			if err := be.DeleteIssue(ctx, repo, issueID); err != nil {
+				return err
+			}
+
+			cmd.Printf("Deleted issue %s\n", ref)
 			return nil
 		},
 	}
@@ -201,9 +492,136 @@ func issueUpdateCommand() *cobra.Command {
 }
 
 func issueCloseCommand() *cobra.Command {
+	var force bool
+	var reasonFlag string
+
+	cmd := &cobra.Command{
+		Use:   "close REPOSITORY ISSUE_ID",
+		Short: "Close an issue",
+		Long: `Close an issue as completed, not-planned, or duplicate. Depending on the
+dependency policy of the repository, closing an issue that depends on open
+issues warns about them, or fails unless forced.`,
+		Args:              cobra.ExactArgs(2),
+		PersistentPreRunE: checkIfReadableAndCollab,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			repo := args[0]
+
+			reason, err := backend.ParseIssueCloseReason(reasonFlag)
+			if err != nil {
+				return err
+			}
+
+			issueID, err := be.ResolveIssueID(ctx, repo, args[1])
+			if err != nil {
+				return err
+			}
+
+			if force {
+				err = be.ForceCloseIssue(ctx, repo, issueID, reason)
+			} else {
+				err = be.CloseIssue(ctx, repo, issueID, reason)
+			}
+			if err != nil {
+				if errors.Is(err, backend.ErrOpenDependencies) {
+					return fmt.Errorf("%w, use --force to close it anyway", err)
+				}
+				return err
+			}
+
+			cmd.Printf("Closed issue %s as %s\n", be.IssueRef(ctx, repo, issueID), reason)
+			printIssueLinks(cmd, args[0], issueID)
+			if policy, err := be.DependencyPolicy(ctx, repo); err == nil && policy == backend.DependencyPolicyWarn {
+				warnIfOpenDependencies(cmd, be, repo, issueID)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "close the issue even if it depends on open issues")
+	cmd.Flags().StringVar(&reasonFlag, "reason", string(models.IssueCloseReasonCompleted), "why the issue is closed (completed, not-planned, duplicate)")
+
+	return cmd
+}
+
+// warnIfOpenDependencies warns about the open issues an issue depends on.
+func warnIfOpenDependencies(cmd *cobra.Command, be *backend.Backend, repo string, issueID int64) {
+	ctx := cmd.Context()
+	open, err := be.OpenIssueDependencies(ctx, repo, issueID)
+	if err != nil || len(open) == 0 {
+		return
+	}
+
+	prefix, _ := be.IssuePrefix(ctx, repo)
+	refs := make([]string, 0, len(open))
+	for _, dep := range open {
+		refs = append(refs, backend.FormatIssueRef(prefix, dep.Number))
+	}
+	cmd.PrintErrf("Warning: the issue depends on open issues %s\n", strings.Join(refs, ", "))
+}
+
+func issueLockCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "lock REPOSITORY ISSUE_ID",
+		Short:             "Lock an issue to collaborators",
+		Long:              "Lock an issue so that only collaborators can edit it or vote on it.",
+		Args:              cobra.ExactArgs(2),
+		PersistentPreRunE: checkIfReadableAndCollab,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			repo := args[0]
+
+			issueID, err := be.ResolveIssueID(ctx, repo, args[1])
+			if err != nil {
+				return err
+			}
+
+			if err := be.LockIssue(ctx, repo, issueID); err != nil {
+				return err
+			}
+
+			cmd.Printf("Locked issue %s\n", be.IssueRef(ctx, repo, issueID))
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func issueUnlockCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "unlock REPOSITORY ISSUE_ID",
+		Short:             "Unlock a locked issue",
+		Args:              cobra.ExactArgs(2),
+		PersistentPreRunE: checkIfReadableAndCollab,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			repo := args[0]
+
+			issueID, err := be.ResolveIssueID(ctx, repo, args[1])
+			if err != nil {
+				return err
+			}
+
+			if err := be.UnlockIssue(ctx, repo, issueID); err != nil {
+				return err
+			}
+
+			cmd.Printf("Unlocked issue %s\n", be.IssueRef(ctx, repo, issueID))
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func issuePinCommand() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:               "close REPOSITORY ISSUE_ID",
-		Short:             "Close an issue",
+		Use:               "pin REPOSITORY ISSUE_ID",
+		Short:             "Pin an issue to the top of the issue list",
 		Args:              cobra.ExactArgs(2),
 		PersistentPreRunE: checkIfReadableAndCollab,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -211,16 +629,44 @@ func issueCloseCommand() *cobra.Command {
 			be := backend.FromContext(ctx)
 			repo := args[0]
 
-			issueID, err := strconv.ParseInt(args[1], 10, 64)
+			issueID, err := be.ResolveIssueID(ctx, repo, args[1])
 			if err != nil {
-				return fmt.Errorf("invalid issue ID: %w", err)
+				return err
 			}
 
-			if err := be.CloseIssue(ctx, repo, issueID); err != nil {
+			if err := be.PinIssue(ctx, repo, issueID); err != nil {
 				return err
 			}
 
-			cmd.Printf("Closed issue #%d\n", issueID)
+			cmd.Printf("Pinned issue %s\n", be.IssueRef(ctx, repo, issueID))
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func issueUnpinCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "unpin REPOSITORY ISSUE_ID",
+		Short:             "Unpin a pinned issue",
+		Args:              cobra.ExactArgs(2),
+		PersistentPreRunE: checkIfReadableAndCollab,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			repo := args[0]
+
+			issueID, err := be.ResolveIssueID(ctx, repo, args[1])
+			if err != nil {
+				return err
+			}
+
+			if err := be.UnpinIssue(ctx, repo, issueID); err != nil {
+				return err
+			}
+
+			cmd.Printf("Unpinned issue %s\n", be.IssueRef(ctx, repo, issueID))
 			return nil
 		},
 	}
@@ -239,16 +685,17 @@ func issueReopenCommand() *cobra.Command {
 			be := backend.FromContext(ctx)
 			repo := args[0]
 
-			issueID, err := strconv.ParseInt(args[1], 10, 64)
+			issueID, err := be.ResolveIssueID(ctx, repo, args[1])
 			if err != nil {
-				return fmt.Errorf("invalid issue ID: %w", err)
+				return err
 			}
 
 			if err := be.ReopenIssue(ctx, repo, issueID); err != nil {
 				return err
 			}
 
-			cmd.Printf("Reopened issue #%d\n", issueID)
+			cmd.Printf("Reopened issue %s\n", be.IssueRef(ctx, repo, issueID))
+			printIssueLinks(cmd, args[0], issueID)
 			return nil
 		},
 	}
@@ -268,21 +715,21 @@ func issueAddDependencyCommand() *cobra.Command {
 			be := backend.FromContext(ctx)
 			repo := args[0]
 
-			issueID, err := strconv.ParseInt(args[1], 10, 64)
+			issueID, err := be.ResolveIssueID(ctx, repo, args[1])
 			if err != nil {
-				return fmt.Errorf("invalid issue ID: %w", err)
+				return err
 			}
 
-			dependsOnID, err := strconv.ParseInt(args[2], 10, 64)
+			dependsOnID, err := be.ResolveIssueID(ctx, repo, args[2])
 			if err != nil {
-				return fmt.Errorf("invalid depends on ID: %w", err)
+				return err
 			}
 
 			if err := be.AddIssueDependency(ctx, repo, issueID, dependsOnID); err != nil {
 				return err
 			}
 
-			cmd.Printf("Added dependency: issue #%d now depends on issue #%d\n", issueID, dependsOnID)
+			cmd.Printf("Added dependency: issue %s now depends on issue %s\n", be.IssueRef(ctx, repo, issueID), be.IssueRef(ctx, repo, dependsOnID))
 			return nil
 		},
 	}
@@ -302,21 +749,112 @@ func issueRemoveDependencyCommand() *cobra.Command {
 			be := backend.FromContext(ctx)
 			repo := args[0]
 
-			issueID, err := strconv.ParseInt(args[1], 10, 64)
+			issueID, err := be.ResolveIssueID(ctx, repo, args[1])
 			if err != nil {
-				return fmt.Errorf("invalid issue ID: %w", err)
+				return err
 			}
 
-			dependsOnID, err := strconv.ParseInt(args[2], 10, 64)
+			dependsOnID, err := be.ResolveIssueID(ctx, repo, args[2])
 			if err != nil {
-				return fmt.Errorf("invalid depends on ID: %w", err)
+				return err
 			}
 
 			if err := be.RemoveIssueDependency(ctx, repo, issueID, dependsOnID); err != nil {
 				return err
 			}
 
-			cmd.Printf("Removed dependency: issue #%d no longer depends on issue #%d\n", issueID, dependsOnID)
+			cmd.Printf("Removed dependency: issue %s no longer depends on issue %s\n", be.IssueRef(ctx, repo, issueID), be.IssueRef(ctx, repo, dependsOnID))
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func issuePrefixCommand() *cobra.Command {
+	var clearPrefix bool
+
+	cmd := &cobra.Command{
+		Use:   "prefix REPOSITORY [PREFIX]",
+		Short: "Get or set the issue prefix of a repository",
+		Long: `Get or set the issue prefix of a repository.
+
+With a prefix, e.g. SRV, issues are shown as SRV-12 instead of #12. Commands
+taking an issue ID accept either form, as well as the external ID of imported
+issues.`,
+		Args:              cobra.RangeArgs(1, 2),
+		PersistentPreRunE: checkIfGuest,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			repo := args[0]
+
+			if len(args) == 1 && !clearPrefix {
+				prefix, err := be.IssuePrefix(ctx, repo)
+				if err != nil {
+					return err
+				}
+
+				cmd.Println(prefix)
+				return nil
+			}
+
+			if err := checkIfReadableAndCollab(cmd, args); err != nil {
+				return err
+			}
+
+			var prefix string
+			if !clearPrefix {
+				prefix = args[1]
+			}
+
+			return be.SetIssuePrefix(ctx, repo, prefix)
+		},
+	}
+
+	cmd.Flags().BoolVar(&clearPrefix, "clear", false, "remove the issue prefix")
+
+	return cmd
+}
+
+func issueDependencyPolicyCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "dependency-policy REPOSITORY [allow|warn|block]",
+		Short: "Get or set what happens when closing issues with open dependencies",
+		Long: `Get or set what happens when closing an issue that depends on open issues:
+
+  allow  close it, the default
+  warn   close it, and warn about its open dependencies
+  block  refuse to close it, unless forced with "repo issue close --force"
+
+Commits pushed to the default branch don't close blocked issues either.`,
+		Args:              cobra.RangeArgs(1, 2),
+		PersistentPreRunE: checkIfReadable,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			repo := args[0]
+			switch len(args) {
+			case 1:
+				policy, err := be.DependencyPolicy(ctx, repo)
+				if err != nil {
+					return err
+				}
+
+				cmd.Println(policy)
+			case 2:
+				policy, err := backend.ParseDependencyPolicy(args[1])
+				if err != nil {
+					return err
+				}
+				if err := checkIfCollab(cmd, args); err != nil {
+					return err
+				}
+				if err := be.SetDependencyPolicy(ctx, repo, policy); err != nil {
+					return err
+				}
+			}
+
 			return nil
 		},
 	}
@@ -335,3 +873,120 @@ func parseIssueState(s string) models.IssueState {
 		return -1
 	}
 }
+
+func issueWatchCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "watch REPOSITORY ISSUE_ID",
+		Aliases:           []string{"subscribe"},
+		Short:             "Get notified about an issue",
+		Args:              cobra.ExactArgs(2),
+		PersistentPreRunE: checkIfReadable,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			repo := args[0]
+
+			issueID, err := be.ResolveIssueID(ctx, repo, args[1])
+			if err != nil {
+				return err
+			}
+
+			subject := backend.Subject{Type: backend.NotificationSubjectIssue, ID: issueID}
+			if err := be.Subscribe(ctx, repo, subject, proto.UserFromContext(ctx)); err != nil {
+				return err
+			}
+
+			cmd.Printf("Watching issue %s\n", be.IssueRef(ctx, repo, issueID))
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func issueUnwatchCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "unwatch REPOSITORY ISSUE_ID",
+		Aliases:           []string{"unsubscribe"},
+		Short:             "Stop getting notified about an issue",
+		Args:              cobra.ExactArgs(2),
+		PersistentPreRunE: checkIfReadable,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			repo := args[0]
+
+			issueID, err := be.ResolveIssueID(ctx, repo, args[1])
+			if err != nil {
+				return err
+			}
+
+			subject := backend.Subject{Type: backend.NotificationSubjectIssue, ID: issueID}
+			if err := be.Unsubscribe(ctx, repo, subject, proto.UserFromContext(ctx)); err != nil {
+				return err
+			}
+
+			cmd.Printf("Stopped watching issue %s\n", be.IssueRef(ctx, repo, issueID))
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func issueVoteCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "vote REPOSITORY ISSUE_ID",
+		Aliases:           []string{"upvote"},
+		Short:             "Vote for an issue",
+		Args:              cobra.ExactArgs(2),
+		PersistentPreRunE: checkIfGuest,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			repo := args[0]
+
+			issueID, err := be.ResolveIssueID(ctx, repo, args[1])
+			if err != nil {
+				return err
+			}
+
+			if err := be.VoteIssue(ctx, repo, issueID); err != nil {
+				return err
+			}
+
+			cmd.Printf("Voted for issue %s\n", be.IssueRef(ctx, repo, issueID))
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func issueUnvoteCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "unvote REPOSITORY ISSUE_ID",
+		Short:             "Remove your vote from an issue",
+		Args:              cobra.ExactArgs(2),
+		PersistentPreRunE: checkIfGuest,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			repo := args[0]
+
+			issueID, err := be.ResolveIssueID(ctx, repo, args[1])
+			if err != nil {
+				return err
+			}
+
+			if err := be.UnvoteIssue(ctx, repo, issueID); err != nil {
+				return err
+			}
+
+			cmd.Printf("Removed your vote from issue %s\n", be.IssueRef(ctx, repo, issueID))
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,202 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss/v2/table"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/spf13/cobra"
+)
+
+func issueLabelCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "label",
+		Aliases: []string{"labels"},
+		Short:   "Manage issue labels",
+		Long: `Manage issue labels.
+
+Labels belong to a repository, and collaborators can attach them to its issues.
+Colors are hex colors, e.g. #d73a4a, and are used to render the labels in the
+TUI.`,
+	}
+
+	cmd.AddCommand(
+		labelListCommand(),
+		labelCreateCommand(),
+		labelEditCommand(),
+		labelDeleteCommand(),
+		labelAddCommand(),
+		labelRemoveCommand(),
+	)
+
+	return cmd
+}
+
+func labelListCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "list REPOSITORY",
+		Short:             "List the labels of a repository",
+		Args:              cobra.ExactArgs(1),
+		PersistentPreRunE: checkIfGuest,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+
+			labels, err := be.Labels(ctx, args[0])
+			if err != nil {
+				return err
+			}
+
+			if len(labels) == 0 {
+				cmd.Println("No labels found")
+				return nil
+			}
+
+			table := table.New().Headers("Name", "Color", "Description")
+			for _, l := range labels {
+				table = table.Row(l.Name, l.Color, l.Description)
+			}
+			cmd.Println(table)
+
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func labelCreateCommand() *cobra.Command {
+	var color, description string
+
+	cmd := &cobra.Command{
+		Use:               "create REPOSITORY NAME",
+		Short:             "Create a label",
+		Args:              cobra.ExactArgs(2),
+		PersistentPreRunE: checkIfReadableAndCollab,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+
+			if err := be.CreateLabel(ctx, args[0], args[1], color, description); err != nil {
+				return err
+			}
+
+			cmd.Printf("Created label %s\n", args[1])
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&color, "color", "c", backend.DefaultLabelColor, "hex color of the label")
+	cmd.Flags().StringVarP(&description, "description", "d", "", "description of the label")
+
+	return cmd
+}
+
+func labelEditCommand() *cobra.Command {
+	var name, color, description string
+
+	cmd := &cobra.Command{
+		Use:               "edit REPOSITORY NAME",
+		Short:             "Rename a label, or change its color or description",
+		Args:              cobra.ExactArgs(2),
+		PersistentPreRunE: checkIfReadableAndCollab,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+
+			label, err := be.Label(ctx, args[0], args[1])
+			if err != nil {
+				return err
+			}
+
+			if cmd.Flags().Changed("name") {
+				label.Name = name
+			}
+			if cmd.Flags().Changed("color") {
+				label.Color = color
+			}
+			if cmd.Flags().Changed("description") {
+				label.Description = description
+			}
+
+			return be.UpdateLabel(ctx, args[0], args[1], label)
+		},
+	}
+
+	cmd.Flags().StringVarP(&name, "name", "n", "", "new name of the label")
+	cmd.Flags().StringVarP(&color, "color", "c", "", "new hex color of the label")
+	cmd.Flags().StringVarP(&description, "description", "d", "", "new description of the label")
+
+	return cmd
+}
+
+func labelDeleteCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "delete REPOSITORY NAME",
+		Short:             "Delete a label and detach it from the issues",
+		Args:              cobra.ExactArgs(2),
+		PersistentPreRunE: checkIfReadableAndCollab,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+
+			return be.DeleteLabel(ctx, args[0], args[1])
+		},
+	}
+
+	return cmd
+}
+
+func labelAddCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "add REPOSITORY ISSUE_ID LABEL...",
+		Short:             "Attach labels to an issue",
+		Args:              cobra.MinimumNArgs(3),
+		PersistentPreRunE: checkIfReadableAndCollab,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+
+			issueID, err := be.ResolveIssueID(ctx, args[0], args[1])
+			if err != nil {
+				return err
+			}
+
+			return be.AddIssueLabels(ctx, args[0], issueID, args[2:]...)
+		},
+	}
+
+	return cmd
+}
+
+func labelRemoveCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "remove REPOSITORY ISSUE_ID LABEL...",
+		Short:             "Detach labels from an issue",
+		Args:              cobra.MinimumNArgs(3),
+		PersistentPreRunE: checkIfReadableAndCollab,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+
+			issueID, err := be.ResolveIssueID(ctx, args[0], args[1])
+			if err != nil {
+				return err
+			}
+
+			return be.RemoveIssueLabels(ctx, args[0], issueID, args[2:]...)
+		},
+	}
+
+	return cmd
+}
+
+// labelNames returns the names of labels, comma separated.
+func labelNames(labels []models.Label) string {
+	names := make([]string, len(labels))
+	for i, l := range labels {
+		names[i] = l.Name
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/spf13/cobra"
+)
+
+func mergeQueueCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "merge-queue REPOSITORY [TRUE|FALSE]",
+		Short:             "Merge merge requests through a merge queue",
+		Args:              cobra.RangeArgs(1, 2),
+		PersistentPreRunE: checkIfReadable,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			repo := args[0]
+			switch len(args) {
+			case 1:
+				enabled, err := be.IsMergeQueueEnabled(ctx, repo)
+				if err != nil {
+					return err
+				}
+
+				cmd.Println(enabled)
+			case 2:
+				if err := checkIfCollab(cmd, args); err != nil {
+					return err
+				}
+
+				enabled := args[1] == "true"
+				if err := be.SetMergeQueueEnabled(ctx, repo, enabled); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func mergeRequestQueueCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "queue REPOSITORY",
+		Short:             "List the merge requests in the merge queue",
+		Args:              cobra.ExactArgs(1),
+		PersistentPreRunE: checkIfReadable,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+
+			mrs, err := be.MergeQueue(ctx, args[0])
+			if err != nil {
+				return err
+			}
+
+			if len(mrs) == 0 {
+				cmd.Println("No merge requests in the merge queue")
+				return nil
+			}
+
+			for i, mr := range mrs {
+				cmd.Printf("%d. #%d: %s (%s -> %s)\n",
+					i+1,
+					mr.ID,
+					mr.Title,
+					mr.SourceBranch,
+					mr.TargetBranch,
+				)
+			}
+
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func mergeRequestEnqueueCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "enqueue REPOSITORY MR_ID",
+		Short:             "Add a merge request to the merge queue",
+		Args:              cobra.ExactArgs(2),
+		PersistentPreRunE: checkIfReadableAndCollab,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			repo := args[0]
+
+			mrID, err := strconv.ParseInt(args[1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid merge request ID: %w", err)
+			}
+
+			if err := be.EnqueueMergeRequest(ctx, repo, mrID); err != nil {
+				return err
+			}
+
+			cmd.Printf("Added merge request #%d to the merge queue\n", mrID)
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func mergeRequestDequeueCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "dequeue REPOSITORY MR_ID",
+		Short:             "Remove a merge request from the merge queue",
+		Args:              cobra.ExactArgs(2),
+		PersistentPreRunE: checkIfReadableAndCollab,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			repo := args[0]
+
+			mrID, err := strconv.ParseInt(args[1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid merge request ID: %w", err)
+			}
+
+			if err := be.DequeueMergeRequest(ctx, repo, mrID); err != nil {
+				return err
+			}
+
+			cmd.Printf("Removed merge request #%d from the merge queue\n", mrID)
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -1,12 +1,16 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/charmbracelet/soft-serve/git"
 	"github.com/charmbracelet/soft-serve/pkg/backend"
 	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
 	"github.com/spf13/cobra"
 )
 
@@ -21,9 +25,27 @@ func mergeRequestCommand() *cobra.Command {
 		mergeRequestCreateCommand(),
 		mergeRequestListCommand(),
 		mergeRequestShowCommand(),
+		mergeRequestCommentCommand(),
+		mergeRequestApproveCommand(),
+		mergeRequestRevokeApprovalCommand(),
 		mergeRequestMergeCommand(),
 		mergeRequestCloseCommand(),
 		mergeRequestReopenCommand(),
+		mergeRequestDraftCommand(),
+		mergeRequestReadyCommand(),
+		mergeRequestAddReviewerCommand(),
+		mergeRequestRemoveReviewerCommand(),
+		mergeRequestWatchCommand(),
+		mergeRequestUnwatchCommand(),
+		mergeRequestReportCommand(),
+		mergeRequestQueueCommand(),
+		mergeRequestEnqueueCommand(),
+		mergeRequestDequeueCommand(),
+		mergeRequestAddDependencyCommand(),
+		mergeRequestRemoveDependencyCommand(),
+		mergeRequestMilestoneCommand(),
+		mergeRequestBulkCommand(),
+		mergeRequestRenderCommand(),
 	)
 
 	return cmd
@@ -53,21 +75,31 @@ func mergeRequestCreateCommand() *cobra.Command {
 			}
 
 			cmd.Printf("Created merge request #%d\n", mrID)
+			printMergeRequestLinks(cmd, args[0], mrID)
+			warnIfTruncated(cmd, description)
+			if mr, err := be.GetMergeRequest(ctx, repo, mrID); err == nil && mr.FirstContribution {
+				printContributingGuide(cmd, repo)
+			}
 			return nil
 		},
 	}
 
-	return cmd
+	return withIdempotencyKey(cmd)
 }
 
 func mergeRequestListCommand() *cobra.Command {
 	var stateFilter string
+	var sortBy string
+	var order string
+	var limit int
+	var after int64
+	var asJSON bool
 
 	cmd := &cobra.Command{
 		Use:               "list REPOSITORY",
 		Short:             "List merge requests",
 		Args:              cobra.ExactArgs(1),
-		PersistentPreRunE: checkIfReadable,
+		PersistentPreRunE: checkIfGuest,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
 			be := backend.FromContext(ctx)
@@ -82,23 +114,90 @@ func mergeRequestListCommand() *cobra.Command {
 				state = &s
 			}
 
-			mrs, err := be.ListMergeRequests(ctx, repo, state)
+			listOrder, err := parseListOrder(sortBy, order)
+			if err != nil {
+				return err
+			}
+
+			mrs, err := be.ListMergeRequests(ctx, repo, backend.MergeRequestListOptions{
+				State: state,
+				Order: listOrder,
+				Limit: limit,
+				After: after,
+			})
+			if err != nil {
+				return err
+			}
+
+			deps, err := be.MergeRequestDependencyIDs(ctx, repo)
+			if err != nil {
+				return err
+			}
+
+			approvals, err := be.MergeRequestApprovalCounts(ctx, repo)
+			if err != nil {
+				return err
+			}
+			required, err := be.RequiredApprovals(ctx, repo)
 			if err != nil {
 				return err
 			}
 
+			if asJSON {
+				results := make([]mergeRequestResult, len(mrs))
+				for i, mr := range mrs {
+					results[i] = mergeRequestResult{
+						ID:                mr.ID,
+						Title:             mr.Title,
+						SourceBranch:      mr.SourceBranch,
+						TargetBranch:      mr.TargetBranch,
+						State:             mr.State.String(),
+						Draft:             mr.Draft,
+						DependsOn:         append([]int64{}, deps[mr.ID]...),
+						Approvals:         approvals[mr.ID],
+						FirstContribution: mr.FirstContribution,
+						CreatedAt:         mr.CreatedAt,
+						UpdatedAt:         mr.UpdatedAt,
+					}
+				}
+				enc := json.NewEncoder(cmd.OutOrStdout())
+				enc.SetIndent("", "  ")
+				return enc.Encode(results)
+			}
+
 			if len(mrs) == 0 {
 				cmd.Println("No merge requests found")
 				return nil
 			}
 
 			for _, mr := range mrs {
-				cmd.Printf("#%d: %s (%s -> %s) [%s]\n",
+				var notes string
+				if mr.Draft {
+					notes += " (draft)"
+				}
+				if ids := deps[mr.ID]; len(ids) > 0 {
+					refs := make([]string, len(ids))
+					for i, id := range ids {
+						refs[i] = fmt.Sprintf("#%d", id)
+					}
+					notes += fmt.Sprintf(" (depends on %s)", strings.Join(refs, ", "))
+				}
+				if mr.FirstContribution {
+					notes += " (first contribution)"
+				}
+				if status := approvalStatus(approvals[mr.ID], required); status != "" {
+					notes += " (" + status + ")"
+				}
+				if datesRequested(cmd) {
+					notes += " · updated " + userDates(cmd).Relative(mr.UpdatedAt)
+				}
+				cmd.Printf("#%d: %s (%s -> %s) [%s]%s\n",
 					mr.ID,
 					mr.Title,
 					mr.SourceBranch,
 					mr.TargetBranch,
 					mr.State.String(),
+					notes,
 				)
 			}
 
@@ -107,18 +206,39 @@ func mergeRequestListCommand() *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&stateFilter, "state", "", "Filter by state (open, merged, closed)")
+	cmd.Flags().StringVar(&sortBy, "sort", "created", "Sort by (created, updated, title, state)")
+	cmd.Flags().StringVar(&order, "order", "desc", "Sort order (asc, desc)")
+	cmd.Flags().IntVar(&limit, "limit", 0, "Maximum number of merge requests to list (0 lists all)")
+	cmd.Flags().Int64Var(&after, "after", 0, "List the merge requests following this merge request in order, the last one of the previous page")
+	cmd.Flags().BoolVar(&asJSON, "json", false, "Print merge requests as JSON")
 
 	return cmd
 }
 
+// mergeRequestResult is a merge request as printed with --json.
+type mergeRequestResult struct {
+	ID                int64     `json:"id"`
+	Title             string    `json:"title"`
+	SourceBranch      string    `json:"source_branch"`
+	TargetBranch      string    `json:"target_branch"`
+	State             string    `json:"state"`
+	Draft             bool      `json:"draft"`
+	DependsOn         []int64   `json:"depends_on"`
+	Approvals         int       `json:"approvals"`
+	FirstContribution bool      `json:"first_contribution"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
 func mergeRequestShowCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:               "show REPOSITORY MR_ID",
 		Short:             "Show merge request details",
 		Args:              cobra.ExactArgs(2),
-		PersistentPreRunE: checkIfReadable,
+		PersistentPreRunE: checkIfGuest,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
+			dates := userDates(cmd)
 			be := backend.FromContext(ctx)
 			repo := args[0]
 
@@ -138,16 +258,98 @@ func mergeRequestShowCommand() *cobra.Command {
 			cmd.Printf("Source Branch: %s\n", mr.SourceBranch)
 			cmd.Printf("Target Branch: %s\n", mr.TargetBranch)
 			cmd.Printf("State: %s\n", mr.State.String())
-			cmd.Printf("Created At: %s\n", mr.CreatedAt.Format("2006-01-02 15:04:05"))
-			cmd.Printf("Updated At: %s\n", mr.UpdatedAt.Format("2006-01-02 15:04:05"))
+			if mr.State == models.MergeRequestStateOpen {
+				if ahead, behind, err := be.AheadBehind(ctx, repo, git.RefsHeads+mr.TargetBranch, git.RefsHeads+mr.SourceBranch); err == nil {
+					cmd.Printf("Commits: %d ahead, %d behind %s\n", ahead, behind, mr.TargetBranch)
+				}
+			}
+			if mr.Draft {
+				cmd.Println("Draft: yes")
+			}
+			cmd.Printf("Created At: %s\n", dates.Absolute(mr.CreatedAt))
+			cmd.Printf("Updated At: %s\n", dates.Absolute(mr.UpdatedAt))
 
 			if mr.MergedAt.Valid {
-				cmd.Printf("Merged At: %s\n", mr.MergedAt.Time.Format("2006-01-02 15:04:05"))
+				cmd.Printf("Merged At: %s\n", dates.Absolute(mr.MergedAt.Time))
 			}
 			if mr.ClosedAt.Valid {
-				cmd.Printf("Closed At: %s\n", mr.ClosedAt.Time.Format("2006-01-02 15:04:05"))
+				cmd.Printf("Closed At: %s\n", dates.Absolute(mr.ClosedAt.Time))
+			}
+			if mr.EditedByID.Valid {
+				printEditedBy(ctx, cmd, be, mr.EditedByID.Int64, mr.EditedAt.Time)
+			}
+			printMergeRequestLinks(cmd, repo, mr.ID)
+
+			reviewers, err := be.MergeRequestReviewers(ctx, repo, mrID)
+			if err != nil {
+				return err
+			}
+			if len(reviewers) > 0 {
+				cmd.Printf("Reviewers: %s\n", strings.Join(reviewers, ", "))
+			}
+
+			approvers, err := be.MergeRequestApprovers(ctx, repo, mrID)
+			if err != nil {
+				return err
+			}
+			required, err := be.RequiredApprovals(ctx, repo)
+			if err != nil {
+				return err
+			}
+			if required > 0 || len(approvers) > 0 {
+				cmd.Printf("Approvals: %s", backend.ApprovalStatus{Approvals: len(approvers), Required: required})
+				if len(approvers) > 0 {
+					cmd.Printf(" (%s)", strings.Join(approvers, ", "))
+				}
+				cmd.Println()
+			}
+
+			if m, err := be.MergeRequestMilestone(ctx, repo, mrID); err == nil {
+				cmd.Printf("Milestone: %s\n", m.Title)
+			}
+
+			subscribers, err := be.ListSubscribers(ctx, repo, backend.Subject{Type: backend.NotificationSubjectMergeRequest, ID: mrID})
+			if err != nil {
+				return err
+			}
+			if len(subscribers) > 0 {
+				cmd.Printf("Subscribers: %s\n", usernames(subscribers))
+			}
+
+			// Display the merge requests this one is stacked on
+			dependencies, err := be.GetMergeRequestDependencies(ctx, repo, mrID)
+			if err == nil && len(dependencies) > 0 {
+				cmd.Printf("\nDepends on:\n")
+				for _, dep := range dependencies {
+					cmd.Printf("  #%d - %s [%s]\n", dep.ID, dep.Title, dep.State.String())
+				}
+			}
+
+			// Display the merge requests stacked on this one
+			dependents, err := be.GetMergeRequestDependents(ctx, repo, mrID)
+			if err == nil && len(dependents) > 0 {
+				cmd.Printf("\nRequired by:\n")
+				for _, dep := range dependents {
+					cmd.Printf("  #%d - %s [%s]\n", dep.ID, dep.Title, dep.State.String())
+				}
+			}
+
+			// Display how the merge request fails the contribution agreements
+			if mr.State == models.MergeRequestStateOpen {
+				failures, err := be.MergeRequestAgreementFailures(ctx, repo, mrID)
+				if err == nil && len(failures) > 0 {
+					cmd.Printf("\nFailing agreements:\n")
+					for _, f := range failures {
+						cmd.Printf("  %s\n", f)
+					}
+				}
 			}
 
+			printReferencedBy(ctx, cmd, be, repo, backend.Subject{Type: backend.NotificationSubjectMergeRequest, ID: mrID})
+			printAttachments(ctx, cmd, be, repo, backend.Subject{Type: backend.NotificationSubjectMergeRequest, ID: mrID})
+			printTrackerLinks(cmd, be.IssueTrackerLinks(ctx, repo, mr.Title, mr.Description, mr.SourceBranch))
+			printMergeRequestComments(ctx, cmd, be, repo, mrID)
+
 			return nil
 		},
 	}
@@ -176,6 +378,7 @@ func mergeRequestMergeCommand() *cobra.Command {
 			}
 
 			cmd.Printf("Merged merge request #%d\n", mrID)
+			printMergeRequestLinks(cmd, args[0], mrID)
 			return nil
 		},
 	}
@@ -204,6 +407,7 @@ func mergeRequestCloseCommand() *cobra.Command {
 			}
 
 			cmd.Printf("Closed merge request #%d\n", mrID)
+			printMergeRequestLinks(cmd, args[0], mrID)
 			return nil
 		},
 	}
@@ -232,6 +436,248 @@ func mergeRequestReopenCommand() *cobra.Command {
 			}
 
 			cmd.Printf("Reopened merge request #%d\n", mrID)
+			printMergeRequestLinks(cmd, args[0], mrID)
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func mergeRequestDraftCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "draft REPOSITORY MR_ID",
+		Short:             "Mark a merge request as a draft",
+		Args:              cobra.ExactArgs(2),
+		PersistentPreRunE: checkIfReadable,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			repo := args[0]
+
+			mrID, err := strconv.ParseInt(args[1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid merge request ID: %w", err)
+			}
+
+			if err := be.SetMergeRequestDraft(ctx, repo, mrID, true); err != nil {
+				return err
+			}
+
+			cmd.Printf("Marked merge request #%d as a draft\n", mrID)
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func mergeRequestReadyCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "ready REPOSITORY MR_ID",
+		Short:             "Mark a draft merge request as ready to merge",
+		Args:              cobra.ExactArgs(2),
+		PersistentPreRunE: checkIfReadable,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			repo := args[0]
+
+			mrID, err := strconv.ParseInt(args[1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid merge request ID: %w", err)
+			}
+
+			if err := be.SetMergeRequestDraft(ctx, repo, mrID, false); err != nil {
+				return err
+			}
+
+			cmd.Printf("Marked merge request #%d as ready\n", mrID)
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func mergeRequestWatchCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "watch REPOSITORY MR_ID",
+		Aliases:           []string{"subscribe"},
+		Short:             "Get notified about a merge request",
+		Args:              cobra.ExactArgs(2),
+		PersistentPreRunE: checkIfReadable,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			repo := args[0]
+
+			mrID, err := strconv.ParseInt(args[1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid merge request ID: %w", err)
+			}
+
+			subject := backend.Subject{Type: backend.NotificationSubjectMergeRequest, ID: mrID}
+			if err := be.Subscribe(ctx, repo, subject, proto.UserFromContext(ctx)); err != nil {
+				return err
+			}
+
+			cmd.Printf("Watching merge request #%d\n", mrID)
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func mergeRequestUnwatchCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "unwatch REPOSITORY MR_ID",
+		Aliases:           []string{"unsubscribe"},
+		Short:             "Stop getting notified about a merge request",
+		Args:              cobra.ExactArgs(2),
+		PersistentPreRunE: checkIfReadable,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			repo := args[0]
+
+			mrID, err := strconv.ParseInt(args[1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid merge request ID: %w", err)
+			}
+
+			subject := backend.Subject{Type: backend.NotificationSubjectMergeRequest, ID: mrID}
+			if err := be.Unsubscribe(ctx, repo, subject, proto.UserFromContext(ctx)); err != nil {
+				return err
+			}
+
+			cmd.Printf("Stopped watching merge request #%d\n", mrID)
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func mergeRequestAddReviewerCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "add-reviewer REPOSITORY MR_ID USERNAME",
+		Short:             "Request a review of a merge request from a user",
+		Args:              cobra.ExactArgs(3),
+		PersistentPreRunE: checkIfReadableAndCollab,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			repo := args[0]
+
+			mrID, err := strconv.ParseInt(args[1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid merge request ID: %w", err)
+			}
+
+			if err := be.AddMergeRequestReviewer(ctx, repo, mrID, args[2]); err != nil {
+				return err
+			}
+
+			cmd.Printf("Requested review of merge request #%d from %s\n", mrID, args[2])
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func mergeRequestRemoveReviewerCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "remove-reviewer REPOSITORY MR_ID USERNAME",
+		Short:             "Remove a review request from a merge request",
+		Args:              cobra.ExactArgs(3),
+		PersistentPreRunE: checkIfReadableAndCollab,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			repo := args[0]
+
+			mrID, err := strconv.ParseInt(args[1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid merge request ID: %w", err)
+			}
+
+			if err := be.RemoveMergeRequestReviewer(ctx, repo, mrID, args[2]); err != nil {
+				return err
+			}
+
+			cmd.Printf("Removed %s from the reviewers of merge request #%d\n", args[2], mrID)
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func mergeRequestAddDependencyCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "add-dependency REPOSITORY MR_ID DEPENDS_ON_ID",
+		Aliases:           []string{"add-dep"},
+		Short:             "Stack a merge request on top of another one",
+		Long:              "Stack a merge request on top of another one. It can't be merged until the merge request it depends on is merged, and is retargeted to the branch that one merged into if it targets its source branch.",
+		Args:              cobra.ExactArgs(3),
+		PersistentPreRunE: checkIfReadableAndCollab,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			repo := args[0]
+
+			mrID, err := strconv.ParseInt(args[1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid merge request ID: %w", err)
+			}
+
+			dependsOnID, err := strconv.ParseInt(args[2], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid depends on ID: %w", err)
+			}
+
+			if err := be.AddMergeRequestDependency(ctx, repo, mrID, dependsOnID); err != nil {
+				return err
+			}
+
+			cmd.Printf("Added dependency: merge request #%d now depends on merge request #%d\n", mrID, dependsOnID)
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func mergeRequestRemoveDependencyCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "remove-dependency REPOSITORY MR_ID DEPENDS_ON_ID",
+		Aliases:           []string{"remove-dep", "rm-dep"},
+		Short:             "Remove a dependency from a merge request",
+		Args:              cobra.ExactArgs(3),
+		PersistentPreRunE: checkIfReadableAndCollab,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			repo := args[0]
+
+			mrID, err := strconv.ParseInt(args[1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid merge request ID: %w", err)
+			}
+
+			dependsOnID, err := strconv.ParseInt(args[2], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid depends on ID: %w", err)
+			}
+
+			if err := be.RemoveMergeRequestDependency(ctx, repo, mrID, dependsOnID); err != nil {
+				return err
+			}
+
+			cmd.Printf("Removed dependency: merge request #%d no longer depends on merge request #%d\n", mrID, dependsOnID)
 			return nil
 		},
 	}
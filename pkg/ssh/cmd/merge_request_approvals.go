@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/spf13/cobra"
+)
+
+func requiredApprovalsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "required-approvals REPOSITORY [COUNT]",
+		Short:             "Set the number of approvals merge requests need to merge",
+		Args:              cobra.RangeArgs(1, 2),
+		PersistentPreRunE: checkIfReadable,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			repo := args[0]
+			switch len(args) {
+			case 1:
+				n, err := be.RequiredApprovals(ctx, repo)
+				if err != nil {
+					return err
+				}
+
+				cmd.Println(n)
+			case 2:
+				if err := checkIfCollab(cmd, args); err != nil {
+					return err
+				}
+
+				n, err := strconv.Atoi(args[1])
+				if err != nil {
+					return fmt.Errorf("invalid number of approvals: %w", err)
+				}
+				if err := be.SetRequiredApprovals(ctx, repo, n); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func mergeRequestApproveCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "approve REPOSITORY MR_ID",
+		Short:             "Approve a merge request",
+		Args:              cobra.ExactArgs(2),
+		PersistentPreRunE: checkIfReadableAndCollab,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			repo := args[0]
+
+			mrID, err := strconv.ParseInt(args[1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid merge request ID: %w", err)
+			}
+
+			if err := be.ApproveMergeRequest(ctx, repo, mrID); err != nil {
+				return err
+			}
+
+			cmd.Printf("Approved merge request #%d\n", mrID)
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func mergeRequestRevokeApprovalCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "revoke-approval REPOSITORY MR_ID",
+		Aliases:           []string{"unapprove"},
+		Short:             "Revoke your approval of a merge request",
+		Args:              cobra.ExactArgs(2),
+		PersistentPreRunE: checkIfReadable,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			repo := args[0]
+
+			mrID, err := strconv.ParseInt(args[1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid merge request ID: %w", err)
+			}
+
+			if err := be.RevokeApproval(ctx, repo, mrID); err != nil {
+				return err
+			}
+
+			cmd.Printf("Revoked your approval of merge request #%d\n", mrID)
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+// approvalStatus formats the approvals of a merge request for listings, or
+// returns an empty string when it has none and the repository doesn't require
+// any.
+func approvalStatus(approvals, required int) string {
+	if approvals == 0 && required == 0 {
+		return ""
+	}
+	return backend.ApprovalStatus{Approvals: approvals, Required: required}.String() + " approvals"
+}
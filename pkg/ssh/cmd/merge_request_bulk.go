@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/spf13/cobra"
+)
+
+// errEmptyBulkFilter is returned when a bulk operation has no filter.
+var errEmptyBulkFilter = errors.New("at least one of --source, --target, --author, --query, or --stale is required")
+
+func mergeRequestBulkCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bulk",
+		Short: "Change many merge requests at once",
+		Long: `Change many merge requests at once.
+
+The merge requests are selected with filters, and at least one filter other
+than --state is required. Use --dry-run to list them without changing them.`,
+	}
+
+	cmd.AddCommand(
+		mergeRequestBulkCloseCommand(),
+		mergeRequestBulkRetargetCommand(),
+	)
+
+	return cmd
+}
+
+// bulkFlags are the flags shared by the bulk subcommands.
+type bulkFlags struct {
+	filter backend.MergeRequestFilter
+	state  string
+	dryRun bool
+}
+
+func (f *bulkFlags) register(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&f.state, "state", "open", "filter by state (open, merged, closed)")
+	cmd.Flags().StringVar(&f.filter.SourceBranch, "source", "", "filter by source branch")
+	cmd.Flags().StringVar(&f.filter.TargetBranch, "target", "", "filter by target branch")
+	cmd.Flags().StringVar(&f.filter.Author, "author", "", "filter by author")
+	cmd.Flags().StringVar(&f.filter.Query, "query", "", "filter by text in the title or description")
+	cmd.Flags().BoolVar(&f.filter.Stale, "stale", false, "only merge requests marked stale")
+	cmd.Flags().BoolVar(&f.dryRun, "dry-run", false, "list the matching merge requests without changing them")
+}
+
+// run applies fn to the merge requests of repo matching the flags. verb
+// describes fn in the output, e.g. "Closed".
+func (f *bulkFlags) run(cmd *cobra.Command, repo string, verb string, fn func(mr models.MergeRequest) error) error {
+	ctx := cmd.Context()
+	be := backend.FromContext(ctx)
+
+	if f.filter.IsEmpty() {
+		return errEmptyBulkFilter
+	}
+
+	if f.state != "" {
+		s := parseState(f.state)
+		if s < 0 {
+			return fmt.Errorf("invalid state: %s (must be one of: open, merged, closed)", f.state)
+		}
+		f.filter.State = &s
+	}
+
+	mrs, err := be.FilterMergeRequests(ctx, repo, f.filter)
+	if err != nil {
+		return err
+	}
+
+	if len(mrs) == 0 {
+		cmd.Println("No merge requests found")
+		return nil
+	}
+
+	var failed int
+	for _, mr := range mrs {
+		if f.dryRun {
+			cmd.Printf("#%d: %s (%s -> %s) [%s]\n",
+				mr.ID,
+				mr.Title,
+				mr.SourceBranch,
+				mr.TargetBranch,
+				mr.State.String(),
+			)
+			continue
+		}
+
+		if err := fn(mr); err != nil {
+			cmd.PrintErrf("Failed to update merge request #%d: %v\n", mr.ID, err)
+			failed++
+			continue
+		}
+		cmd.Printf("%s merge request #%d\n", verb, mr.ID)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to update %d of %d merge requests", failed, len(mrs))
+	}
+
+	return nil
+}
+
+func mergeRequestBulkCloseCommand() *cobra.Command {
+	var flags bulkFlags
+
+	cmd := &cobra.Command{
+		Use:               "close REPOSITORY",
+		Short:             "Close the merge requests matching the filters",
+		Args:              cobra.ExactArgs(1),
+		PersistentPreRunE: checkIfReadableAndCollab,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			repo := args[0]
+
+			return flags.run(cmd, repo, "Closed", func(mr models.MergeRequest) error {
+				return be.CloseMergeRequest(ctx, repo, mr.ID)
+			})
+		},
+	}
+
+	flags.register(cmd)
+
+	return cmd
+}
+
+func mergeRequestBulkRetargetCommand() *cobra.Command {
+	var flags bulkFlags
+
+	cmd := &cobra.Command{
+		Use:               "retarget REPOSITORY BRANCH",
+		Short:             "Change the target branch of the merge requests matching the filters",
+		Args:              cobra.ExactArgs(2),
+		PersistentPreRunE: checkIfReadableAndCollab,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			repo := args[0]
+			branch := args[1]
+
+			return flags.run(cmd, repo, "Retargeted", func(mr models.MergeRequest) error {
+				return be.RetargetMergeRequest(ctx, repo, mr.ID, branch)
+			})
+		},
+	}
+
+	flags.register(cmd)
+
+	return cmd
+}
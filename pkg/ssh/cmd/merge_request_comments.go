@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/spf13/cobra"
+)
+
+func mergeRequestCommentCommand() *cobra.Command {
+	var replyTo int64
+	var path, lines, commit string
+	cmd := &cobra.Command{
+		Use:   "comment REPOSITORY MR_ID BODY",
+		Short: "Comment on a merge request",
+		Long: `Comment on a merge request. Use --reply-to to reply to an existing comment thread.
+
+Use --path and --lines to comment on lines of a file in the diff, at the head
+of the source branch or at --commit.`,
+		Args:              cobra.ExactArgs(3),
+		PersistentPreRunE: checkIfReadable,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			repo := args[0]
+
+			mrID, err := strconv.ParseInt(args[1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid merge request ID: %w", err)
+			}
+
+			var id int64
+			if replyTo != 0 {
+				id, err = be.ReplyToMergeRequestComment(ctx, repo, mrID, replyTo, args[2])
+			} else if path != "" {
+				var start, end int
+				start, end, err = backend.ParseLineRange(lines)
+				if err != nil {
+					return err
+				}
+				id, err = be.CommentOnMergeRequestLines(ctx, repo, mrID, path, commit, start, end, args[2])
+			} else {
+				id, err = be.CommentOnMergeRequest(ctx, repo, mrID, args[2])
+			}
+			if err != nil {
+				return err
+			}
+
+			cmd.Printf("Added comment %d to merge request #%d\n", id, mrID)
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64Var(&replyTo, "reply-to", 0, "ID of the comment to reply to")
+	cmd.Flags().StringVar(&path, "path", "", "file to comment on")
+	cmd.Flags().StringVar(&lines, "lines", "", "line or range of lines to comment on, e.g. 12 or 12-15")
+	cmd.Flags().StringVar(&commit, "commit", "", "commit the lines are from (default: head of the source branch)")
+	cmd.MarkFlagsRequiredTogether("path", "lines")
+	cmd.MarkFlagsMutuallyExclusive("reply-to", "path")
+	cmd.MarkFlagsMutuallyExclusive("reply-to", "commit")
+
+	return withIdempotencyKey(cmd)
+}
+
+// printMergeRequestComments prints the comment threads of a merge request
+// with replies indented under the comment they answer.
+func printMergeRequestComments(ctx context.Context, cmd *cobra.Command, be *backend.Backend, repo string, mrID int64) {
+	threads, err := be.MergeRequestThreads(ctx, repo, mrID)
+	if err != nil || len(threads) == 0 {
+		return
+	}
+
+	dates := userDates(cmd)
+	authors := make(map[int64]string)
+	author := func(c models.MergeRequestComment) string {
+		if name, ok := authors[c.AuthorID]; ok {
+			return name
+		}
+		name := "a deleted user"
+		if u, err := be.UserByID(ctx, c.AuthorID); err == nil {
+			name = u.Username()
+		}
+		authors[c.AuthorID] = name
+		return name
+	}
+	printComment := func(indent string, c models.MergeRequestComment) {
+		cmd.Printf("%s[%d] %s, %s%s:\n", indent, c.ID, author(c), dates.Relative(c.CreatedAt), commentAnchor(c))
+		for _, line := range strings.Split(c.Body, "\n") {
+			cmd.Printf("%s  %s\n", indent, line)
+		}
+	}
+
+	cmd.Printf("\nComments:\n")
+	for _, t := range threads {
+		printComment("  ", t.MergeRequestComment)
+		for _, r := range t.Replies {
+			printComment("    ", r)
+		}
+	}
+}
+
+// commentAnchor describes the lines a merge request comment is anchored to.
+func commentAnchor(c models.MergeRequestComment) string {
+	if c.CommentAnchor.IsZero() {
+		return ""
+	}
+	s := fmt.Sprintf(" on %s:%s at %.7s", c.Path, c.Lines(), c.CommitSHA)
+	if c.Outdated {
+		s += " (outdated)"
+	}
+	return s
+}
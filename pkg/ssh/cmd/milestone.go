@@ -0,0 +1,383 @@
+package cmd
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/charmbracelet/lipgloss/v2/table"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/spf13/cobra"
+)
+
+// milestoneDateFormat is the format of milestone due dates.
+const milestoneDateFormat = "2006-01-02"
+
+func milestoneCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "milestone",
+		Aliases: []string{"milestones"},
+		Short:   "Manage milestones",
+		Long: `Manage milestones.
+
+Milestones belong to a repository, and group its issues and merge requests
+toward a goal, e.g. a release. Collaborators attach issues and merge requests
+to milestones with the issue milestone and merge-request milestone commands.
+Due dates are written as YYYY-MM-DD.`,
+	}
+
+	cmd.AddCommand(
+		milestoneListCommand(),
+		milestoneShowCommand(),
+		milestoneCreateCommand(),
+		milestoneEditCommand(),
+		milestoneCloseCommand(),
+		milestoneReopenCommand(),
+		milestoneDeleteCommand(),
+	)
+
+	return cmd
+}
+
+func milestoneListCommand() *cobra.Command {
+	var all bool
+
+	cmd := &cobra.Command{
+		Use:               "list REPOSITORY",
+		Short:             "List the milestones of a repository and their progress",
+		Args:              cobra.ExactArgs(1),
+		PersistentPreRunE: checkIfGuest,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+
+			milestones, err := be.Milestones(ctx, args[0])
+			if err != nil {
+				return err
+			}
+
+			table := table.New().Headers("Title", "State", "Due", "Open", "Closed", "Progress")
+			var rows int
+			for _, m := range milestones {
+				if !all && m.State != models.MilestoneStateOpen {
+					continue
+				}
+				table = table.Row(
+					m.Title,
+					m.State.String(),
+					formatDueDate(m.DueDate),
+					strconv.Itoa(m.Open()),
+					strconv.Itoa(m.Closed()),
+					fmt.Sprintf("%d%%", m.Percent()),
+				)
+				rows++
+			}
+
+			if rows == 0 {
+				cmd.Println("No milestones found")
+				return nil
+			}
+
+			cmd.Println(table)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&all, "all", "a", false, "include closed milestones")
+
+	return cmd
+}
+
+func milestoneShowCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "show REPOSITORY TITLE",
+		Short:             "Show a milestone, its progress, issues, and merge requests",
+		Args:              cobra.ExactArgs(2),
+		PersistentPreRunE: checkIfGuest,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			dates := userDates(cmd)
+			be := backend.FromContext(ctx)
+			repo := args[0]
+
+			m, err := be.Milestone(ctx, repo, args[1])
+			if err != nil {
+				return err
+			}
+
+			issues, mrs, err := be.MilestoneItems(ctx, repo, args[1])
+			if err != nil {
+				return err
+			}
+
+			prefix, err := be.IssuePrefix(ctx, repo)
+			if err != nil {
+				return err
+			}
+
+			cmd.Printf("Milestone %s\n", m.Title)
+			if m.Description != "" {
+				cmd.Printf("Description: %s\n", m.Description)
+			}
+			cmd.Printf("State: %s\n", m.State.String())
+			cmd.Printf("Due: %s\n", formatDueDate(m.DueDate))
+			if m.ClosedAt.Valid {
+				cmd.Printf("Closed At: %s\n", dates.Absolute(m.ClosedAt.Time))
+			}
+			cmd.Printf("Progress: %d%% (%d open, %d closed)\n", m.Percent(), m.Open(), m.Closed())
+
+			if len(issues) > 0 {
+				cmd.Printf("\nIssues (%d open, %d closed):\n", m.OpenIssues, m.ClosedIssues)
+				for _, issue := range issues {
+					cmd.Printf("  %s - %s [%s]\n", backend.FormatIssueRef(prefix, issue.Number), issue.Title, issue.State.String())
+				}
+			}
+
+			if len(mrs) > 0 {
+				cmd.Printf("\nMerge requests (%d open, %d closed):\n", m.OpenMergeRequests, m.ClosedMergeRequests)
+				for _, mr := range mrs {
+					cmd.Printf("  #%d - %s [%s]\n", mr.ID, mr.Title, mr.State.String())
+				}
+			}
+
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func milestoneCreateCommand() *cobra.Command {
+	var description, due string
+
+	cmd := &cobra.Command{
+		Use:               "create REPOSITORY TITLE",
+		Short:             "Create a milestone",
+		Args:              cobra.ExactArgs(2),
+		PersistentPreRunE: checkIfReadableAndCollab,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+
+			dueDate, err := parseDueDate(due)
+			if err != nil {
+				return err
+			}
+
+			if err := be.CreateMilestone(ctx, args[0], args[1], description, dueDate.Time); err != nil {
+				return err
+			}
+
+			cmd.Printf("Created milestone %s\n", args[1])
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&description, "description", "d", "", "description of the milestone")
+	cmd.Flags().StringVar(&due, "due", "", "due date of the milestone (YYYY-MM-DD)")
+
+	return cmd
+}
+
+func milestoneEditCommand() *cobra.Command {
+	var title, description, due string
+
+	cmd := &cobra.Command{
+		Use:               "edit REPOSITORY TITLE",
+		Short:             "Rename a milestone, or change its description or due date",
+		Args:              cobra.ExactArgs(2),
+		PersistentPreRunE: checkIfReadableAndCollab,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+
+			m, err := be.Milestone(ctx, args[0], args[1])
+			if err != nil {
+				return err
+			}
+
+			if cmd.Flags().Changed("title") {
+				m.Title = title
+			}
+			if cmd.Flags().Changed("description") {
+				m.Description = description
+			}
+			if cmd.Flags().Changed("due") {
+				m.DueDate, err = parseDueDate(due)
+				if err != nil {
+					return err
+				}
+			}
+
+			return be.UpdateMilestone(ctx, args[0], args[1], m.Milestone)
+		},
+	}
+
+	cmd.Flags().StringVarP(&title, "title", "t", "", "new title of the milestone")
+	cmd.Flags().StringVarP(&description, "description", "d", "", "new description of the milestone")
+	cmd.Flags().StringVar(&due, "due", "", "new due date of the milestone (YYYY-MM-DD), empty to remove it")
+
+	return cmd
+}
+
+func milestoneCloseCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "close REPOSITORY TITLE",
+		Short:             "Close a milestone",
+		Args:              cobra.ExactArgs(2),
+		PersistentPreRunE: checkIfReadableAndCollab,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+
+			if err := be.CloseMilestone(ctx, args[0], args[1]); err != nil {
+				return err
+			}
+
+			cmd.Printf("Closed milestone %s\n", args[1])
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func milestoneReopenCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "reopen REPOSITORY TITLE",
+		Short:             "Reopen a closed milestone",
+		Args:              cobra.ExactArgs(2),
+		PersistentPreRunE: checkIfReadableAndCollab,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+
+			if err := be.ReopenMilestone(ctx, args[0], args[1]); err != nil {
+				return err
+			}
+
+			cmd.Printf("Reopened milestone %s\n", args[1])
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func milestoneDeleteCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "delete REPOSITORY TITLE",
+		Short:             "Delete a milestone and detach it from the issues and merge requests",
+		Args:              cobra.ExactArgs(2),
+		PersistentPreRunE: checkIfReadableAndCollab,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+
+			return be.DeleteMilestone(ctx, args[0], args[1])
+		},
+	}
+
+	return cmd
+}
+
+func issueMilestoneCommand() *cobra.Command {
+	var clearMilestone bool
+
+	cmd := &cobra.Command{
+		Use:               "milestone REPOSITORY ISSUE_ID [MILESTONE]",
+		Short:             "Attach an issue to a milestone, or detach it with --clear",
+		Args:              cobra.RangeArgs(2, 3),
+		PersistentPreRunE: checkIfReadableAndCollab,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+
+			title, err := milestoneArg(args, clearMilestone)
+			if err != nil {
+				return err
+			}
+
+			issueID, err := be.ResolveIssueID(ctx, args[0], args[1])
+			if err != nil {
+				return err
+			}
+
+			return be.SetIssueMilestone(ctx, args[0], issueID, title)
+		},
+	}
+
+	cmd.Flags().BoolVar(&clearMilestone, "clear", false, "detach the issue from its milestone")
+
+	return cmd
+}
+
+func mergeRequestMilestoneCommand() *cobra.Command {
+	var clearMilestone bool
+
+	cmd := &cobra.Command{
+		Use:               "milestone REPOSITORY MR_ID [MILESTONE]",
+		Short:             "Attach a merge request to a milestone, or detach it with --clear",
+		Args:              cobra.RangeArgs(2, 3),
+		PersistentPreRunE: checkIfReadableAndCollab,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+
+			title, err := milestoneArg(args, clearMilestone)
+			if err != nil {
+				return err
+			}
+
+			mrID, err := strconv.ParseInt(args[1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid merge request ID: %w", err)
+			}
+
+			return be.SetMergeRequestMilestone(ctx, args[0], mrID, title)
+		},
+	}
+
+	cmd.Flags().BoolVar(&clearMilestone, "clear", false, "detach the merge request from its milestone")
+
+	return cmd
+}
+
+// milestoneArg returns the milestone title argument of the issue and merge
+// request milestone commands, empty when clearing the milestone.
+func milestoneArg(args []string, clearMilestone bool) (string, error) {
+	switch {
+	case clearMilestone && len(args) > 2:
+		return "", errors.New("cannot use --clear with a milestone")
+	case clearMilestone:
+		return "", nil
+	case len(args) < 3:
+		return "", errors.New("missing milestone, or --clear to detach it")
+	}
+	return args[2], nil
+}
+
+// parseDueDate parses a YYYY-MM-DD due date, empty meaning no due date.
+func parseDueDate(s string) (sql.NullTime, error) {
+	if s == "" {
+		return sql.NullTime{}, nil
+	}
+
+	t, err := time.Parse(milestoneDateFormat, s)
+	if err != nil {
+		return sql.NullTime{}, fmt.Errorf("invalid due date %q, use YYYY-MM-DD", s)
+	}
+
+	return sql.NullTime{Time: t, Valid: true}, nil
+}
+
+// formatDueDate returns a due date as YYYY-MM-DD, or "-" without one.
+func formatDueDate(t sql.NullTime) string {
+	if !t.Valid {
+		return "-"
+	}
+	return t.Time.Format(milestoneDateFormat)
+}
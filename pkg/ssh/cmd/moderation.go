@@ -0,0 +1,393 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss/v2/table"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/spf13/cobra"
+)
+
+func blockCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "block",
+		Aliases: []string{"blocks"},
+		Short:   "Manage the users blocked from a repository",
+		Long: `Manage the users blocked from a repository. Blocked users can't open, edit,
+close, reopen, or vote on its issues and merge requests, nor attach files to
+them. Collaborators can't be blocked.`,
+	}
+
+	cmd.AddCommand(
+		blockAddCommand(),
+		blockRemoveCommand(),
+		blockListCommand(),
+	)
+
+	return cmd
+}
+
+func blockAddCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "add REPOSITORY USERNAME",
+		Short:             "Block a user from a repository",
+		Args:              cobra.ExactArgs(2),
+		PersistentPreRunE: checkIfAdmin,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+
+			if err := be.BlockUser(ctx, args[0], args[1]); err != nil {
+				return err
+			}
+
+			cmd.Printf("Blocked %s from %s\n", args[1], args[0])
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func blockRemoveCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "remove REPOSITORY USERNAME",
+		Short:             "Unblock a user blocked from a repository",
+		Args:              cobra.ExactArgs(2),
+		PersistentPreRunE: checkIfAdmin,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+
+			if err := be.UnblockUser(ctx, args[0], args[1]); err != nil {
+				return err
+			}
+
+			cmd.Printf("Unblocked %s from %s\n", args[1], args[0])
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func blockListCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "list REPOSITORY",
+		Short:             "List the users blocked from a repository",
+		Args:              cobra.ExactArgs(1),
+		PersistentPreRunE: checkIfAdmin,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+
+			users, err := be.BlockedUsers(ctx, args[0])
+			if err != nil {
+				return err
+			}
+
+			for _, u := range users {
+				cmd.Println(u)
+			}
+
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func issueReportCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "report REPOSITORY ISSUE_ID REASON",
+		Short:             "Report an issue to the server admins for abusive content",
+		Args:              cobra.MinimumNArgs(3),
+		PersistentPreRunE: checkIfGuest,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			repo := args[0]
+
+			issueID, err := be.ResolveIssueID(ctx, repo, args[1])
+			if err != nil {
+				return err
+			}
+
+			subject := backend.Subject{Type: backend.NotificationSubjectIssue, ID: issueID}
+			if _, err := be.ReportContent(ctx, repo, subject, strings.Join(args[2:], " ")); err != nil {
+				return err
+			}
+
+			cmd.Printf("Reported issue %s\n", be.IssueRef(ctx, repo, issueID))
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func mergeRequestReportCommand() *cobra.Command {
+	var commentID int64
+	cmd := &cobra.Command{
+		Use:               "report REPOSITORY MR_ID REASON",
+		Short:             "Report a merge request to the server admins for abusive content",
+		Long:              "Report a merge request to the server admins for abusive content. Use --comment to report one of its comments instead.",
+		Args:              cobra.MinimumNArgs(3),
+		PersistentPreRunE: checkIfGuest,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			repo := args[0]
+
+			mrID, err := strconv.ParseInt(args[1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid merge request ID: %w", err)
+			}
+
+			subject := backend.Subject{Type: backend.NotificationSubjectMergeRequest, ID: mrID}
+			if commentID != 0 {
+				subject = backend.Subject{Type: backend.NotificationSubjectMergeRequestComment, ID: commentID}
+			}
+			if _, err := be.ReportContent(ctx, repo, subject, strings.Join(args[2:], " ")); err != nil {
+				return err
+			}
+
+			if commentID != 0 {
+				cmd.Printf("Reported comment %d of merge request #%d\n", commentID, mrID)
+			} else {
+				cmd.Printf("Reported merge request #%d\n", mrID)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64Var(&commentID, "comment", 0, "ID of the comment to report")
+
+	return cmd
+}
+
+func adminModerationCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "moderation",
+		Short: "Moderate the issues and merge requests",
+	}
+
+	cmd.AddCommand(
+		adminModerationQueueCommand(),
+		adminModerationResolveCommand(),
+		adminModerationHideCommand(true),
+		adminModerationHideCommand(false),
+		adminModerationDeleteCommand(),
+		adminModerationLogCommand(),
+	)
+
+	return cmd
+}
+
+func adminModerationQueueCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "queue",
+		Short: "List the reports awaiting moderation",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			dates := userDates(cmd)
+			be := backend.FromContext(ctx)
+
+			reports, err := be.ContentReports(ctx)
+			if err != nil {
+				return err
+			}
+
+			if len(reports) == 0 {
+				cmd.Println("No reports")
+				return nil
+			}
+
+			table := table.New().Headers("ID", "Repository", "Subject", "Reason", "By", "When")
+			for _, r := range reports {
+				table = table.Row(strconv.FormatInt(r.ID, 10),
+					r.Repository,
+					r.Subject,
+					r.Reason,
+					r.Reporter,
+					dates.Relative(r.CreatedAt),
+				)
+			}
+			cmd.Println(table)
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func adminModerationResolveCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "resolve REPORT_ID",
+		Short: "Take a report out of the moderation queue without acting on it",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+
+			id, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid report ID: %w", err)
+			}
+
+			if err := be.ResolveReport(ctx, id); err != nil {
+				return err
+			}
+
+			cmd.Printf("Resolved report %d\n", id)
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func adminModerationHideCommand(hide bool) *cobra.Command {
+	use, short, done := "unhide", "Show the hidden description of an issue, merge request, or comment", "Unhid"
+	if hide {
+		use, short, done = "hide", "Hide the description of an issue, merge request, or comment", "Hid"
+	}
+
+	cmd := &cobra.Command{
+		Use:   use + " REPOSITORY issue|mr|comment ID",
+		Short: short,
+		Args:  cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+
+			subject, err := moderationSubject(cmd, be, args)
+			if err != nil {
+				return err
+			}
+
+			if hide {
+				err = be.HideContent(ctx, args[0], subject)
+			} else {
+				err = be.UnhideContent(ctx, args[0], subject)
+			}
+			if err != nil {
+				return err
+			}
+
+			cmd.Printf("%s %s %s of %s\n", done, args[1], args[2], args[0])
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func adminModerationDeleteCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "delete REPOSITORY issue|mr|comment ID",
+		Short: "Delete an issue, merge request, or comment for good",
+		Args:  cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+
+			subject, err := moderationSubject(cmd, be, args)
+			if err != nil {
+				return err
+			}
+
+			if err := be.DeleteContent(ctx, args[0], subject); err != nil {
+				return err
+			}
+
+			cmd.Printf("Deleted %s %s of %s\n", args[1], args[2], args[0])
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func adminModerationLogCommand() *cobra.Command {
+	var limit int
+
+	cmd := &cobra.Command{
+		Use:   "log",
+		Short: "List the moderation log",
+		Long:  "List the latest moderation actions, newest first.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			dates := userDates(cmd)
+			be := backend.FromContext(ctx)
+
+			es, err := be.ModerationLog(ctx, limit)
+			if err != nil {
+				return err
+			}
+
+			if len(es) == 0 {
+				cmd.Println("No moderation actions")
+				return nil
+			}
+
+			actors := map[int64]string{}
+			table := table.New().Headers("ID", "Action", "Repository", "Detail", "By", "When")
+			for _, e := range es {
+				var actor string
+				if e.ActorID.Valid {
+					if _, ok := actors[e.ActorID.Int64]; !ok {
+						if u, err := be.UserByID(ctx, e.ActorID.Int64); err == nil {
+							actors[e.ActorID.Int64] = u.Username()
+						}
+					}
+					actor = actors[e.ActorID.Int64]
+				}
+				table = table.Row(strconv.FormatInt(e.ID, 10),
+					strings.TrimPrefix(e.Action, "moderation."),
+					e.Target,
+					e.Detail,
+					actor,
+					dates.Relative(e.CreatedAt),
+				)
+			}
+			cmd.Println(table)
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVarP(&limit, "limit", "n", 50, "number of actions to list")
+
+	return cmd
+}
+
+// moderationSubject parses the "REPOSITORY issue|mr|comment ID" arguments of
+// the moderation commands. Comments are merge request comments.
+func moderationSubject(cmd *cobra.Command, be *backend.Backend, args []string) (backend.Subject, error) {
+	switch args[1] {
+	case "issue":
+		id, err := be.ResolveIssueID(cmd.Context(), args[0], args[2])
+		if err != nil {
+			return backend.Subject{}, err
+		}
+		return backend.Subject{Type: backend.NotificationSubjectIssue, ID: id}, nil
+	case "mr", "merge-request":
+		id, err := strconv.ParseInt(args[2], 10, 64)
+		if err != nil {
+			return backend.Subject{}, fmt.Errorf("invalid merge request ID: %w", err)
+		}
+		return backend.Subject{Type: backend.NotificationSubjectMergeRequest, ID: id}, nil
+	case "comment":
+		id, err := strconv.ParseInt(args[2], 10, 64)
+		if err != nil {
+			return backend.Subject{}, fmt.Errorf("invalid comment ID: %w", err)
+		}
+		return backend.Subject{Type: backend.NotificationSubjectMergeRequestComment, ID: id}, nil
+	default:
+		return backend.Subject{}, fmt.Errorf("unknown subject %q, expected issue, mr, or comment", args[1])
+	}
+}
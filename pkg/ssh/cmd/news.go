@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"strconv"
+
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/spf13/cobra"
+)
+
+// NewsCommand returns a command that shows the recent activity of the
+// instance.
+func NewsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "news",
+		Short: "Show the recent activity of the server",
+		Long: `Show the recent activity of the repositories you can read: the trending
+merge requests, new repositories, pushed tags, and opened and merged merge
+requests.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			dates := userDates(cmd)
+			be := backend.FromContext(ctx)
+
+			news, err := be.News(ctx, proto.UserFromContext(ctx))
+			if err != nil {
+				return err
+			}
+
+			if len(news.Trending) == 0 && len(news.Activities) == 0 {
+				cmd.Println("No news")
+				return nil
+			}
+
+			if len(news.Trending) > 0 {
+				cmd.Println("Trending merge requests:")
+				for _, t := range news.Trending {
+					cmd.Printf("  %s!%d: %s (%d updates this week)\n",
+						t.Repository.Name(), t.MergeRequest.ID, t.MergeRequest.Title, t.Activity)
+				}
+			}
+
+			if len(news.Activities) > 0 {
+				if len(news.Trending) > 0 {
+					cmd.Println()
+				}
+				cmd.Println("Latest activity:")
+				for _, a := range news.Activities {
+					summary := a.Summary()
+					if a.Actor != "" {
+						summary = a.Actor + " " + summary
+					}
+					cmd.Printf("  %s (%s)\n", summary, dates.Relative(a.Activity.CreatedAt))
+				}
+			}
+
+			return nil
+		},
+	}
+
+	hiddenCmd := &cobra.Command{
+		Use:   "hidden [TRUE|FALSE]",
+		Short: "Hide or show the news on the home page of the TUI",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+
+			user := proto.UserFromContext(ctx)
+			if user == nil {
+				return proto.ErrUserNotFound
+			}
+
+			prefs, err := be.UserPreferences(ctx, user)
+			if err != nil {
+				return err
+			}
+
+			if len(args) == 0 {
+				cmd.Println(prefs.HideNews)
+				return nil
+			}
+
+			hidden, err := strconv.ParseBool(args[0])
+			if err != nil {
+				return err
+			}
+
+			prefs.HideNews = hidden
+			return be.SetUserPreferences(ctx, user, prefs)
+		},
+	}
+
+	cmd.AddCommand(hiddenCmd)
+
+	return cmd
+}
@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"github.com/charmbracelet/lipgloss/v2/table"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+// OAuthCommand returns a command that manages the OAuth applications that
+// can log users in with Soft Serve.
+func OAuthCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "oauth",
+		Aliases: []string{"oauth-apps"},
+		Short:   "Manage OAuth applications",
+		Long: `Manage OAuth applications.
+
+Registered applications can log users in with Soft Serve using OAuth2 or
+OpenID Connect, see the discovery document at
+/.well-known/openid-configuration. Users sign in with their username and an
+access token and approve the requested scope. Scopes are openid, profile, and
+one of the read-only, read-write, or admin-access access levels. Applications
+get an access token capped to that level, or with no access to repositories
+when the scope has none.`,
+		PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
+			return checkIfAdmin(cmd, nil)
+		},
+	}
+
+	createCmd := &cobra.Command{
+		Use:   "create NAME REDIRECT_URI",
+		Short: "Register an OAuth application",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			cfg := config.FromContext(ctx)
+
+			app, secret, err := be.CreateOAuthApp(ctx, args[0], args[1])
+			if err != nil {
+				return err
+			}
+
+			cmd.PrintErrln("OAuth application created, the client secret won't be shown again")
+			cmd.Printf("Client ID: %s\n", app.ClientID)
+			cmd.Printf("Client Secret: %s\n", secret)
+			cmd.Printf("Issuer: %s\n", cfg.HTTP.PublicURL)
+			return nil
+		},
+	}
+
+	listCmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List OAuth applications",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			dates := userDates(cmd)
+			be := backend.FromContext(ctx)
+
+			apps, err := be.OAuthApps(ctx)
+			if err != nil {
+				return err
+			}
+
+			if len(apps) == 0 {
+				cmd.Println("No OAuth applications")
+				return nil
+			}
+
+			table := table.New().Headers("Name", "Client ID", "Redirect URI", "Created At")
+			for _, app := range apps {
+				table = table.Row(app.Name, app.ClientID, app.RedirectURI, dates.Relative(app.CreatedAt))
+			}
+			cmd.Println(table)
+			return nil
+		},
+	}
+
+	deleteCmd := &cobra.Command{
+		Use:     "delete NAME",
+		Aliases: []string{"rm", "remove"},
+		Short:   "Delete an OAuth application and revoke its tokens",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+
+			if err := be.DeleteOAuthApp(ctx, args[0]); err != nil {
+				return err
+			}
+
+			cmd.PrintErrln("OAuth application deleted")
+			return nil
+		},
+	}
+
+	cmd.AddCommand(
+		createCmd,
+		listCmd,
+		deleteCmd,
+	)
+
+	return cmd
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/spf13/cobra"
+)
+
+// WithPolicy makes c and its sub commands check the authorization policy
+// before running. The policy action is the command path without the root,
+// e.g. "repo merge-request merge" for "repo mr merge", and the repository is the first argument of
+// commands whose usage starts with a repository. The remaining arguments are
+// matched against the rule arguments.
+func WithPolicy(c *cobra.Command) {
+	for _, sc := range c.Commands() {
+		WithPolicy(sc)
+	}
+
+	run, runE := c.Run, c.RunE
+	if run == nil && runE == nil {
+		return
+	}
+
+	c.Run = nil
+	c.RunE = func(cmd *cobra.Command, args []string) error {
+		if err := checkPolicy(cmd, args); err != nil {
+			return err
+		}
+		if runE != nil {
+			return runE(cmd, args)
+		}
+		run(cmd, args)
+		return nil
+	}
+}
+
+func checkPolicy(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	be := backend.FromContext(ctx)
+	user := proto.UserFromContext(ctx)
+
+	var repo string
+	if fields := strings.Fields(cmd.Use); len(args) > 0 && len(fields) > 1 {
+		switch fields[1] {
+		case "REPOSITORY", "REPO":
+			repo, args = args[0], args[1:]
+		}
+	}
+
+	action := strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name())
+	return be.Authorize(ctx, user, strings.TrimSpace(action), repo, args)
+}
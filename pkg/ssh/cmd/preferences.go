@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/spf13/cobra"
+)
+
+// PreferencesCommand returns a command that manages how dates are shown to
+// the user.
+func PreferencesCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "preferences",
+		Aliases: []string{"prefs"},
+		Short:   "Show your preferences",
+		Long: `Show your preferences. They apply to the dates shown by the TUI and the
+commands.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			prefs, err := userPreferences(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			tz := prefs.Timezone
+			if tz == "" {
+				tz = "server"
+			}
+			cmd.Printf("Timezone: %s\n", tz)
+			cmd.Printf("Date Format: %s\n", prefs.DateFormat)
+			cmd.Printf("Date Style: %s\n", prefs.DateStyle)
+			return nil
+		},
+	}
+
+	formats := make([]string, 0, len(backend.DateFormats))
+	for f := range backend.DateFormats {
+		formats = append(formats, f)
+	}
+	sort.Strings(formats)
+
+	cmd.AddCommand(
+		preferenceCommand("timezone [ZONE]", "Get or set the time zone dates are shown in",
+			`Get or set the time zone dates are shown in, an IANA name such as
+"Europe/Paris". An empty ZONE is the time zone of the server.`,
+			func(prefs *models.UserPreferences) *string { return &prefs.Timezone }),
+		preferenceCommand("date-format [FORMAT]", "Get or set the format of dates",
+			`Get or set the format of dates: one of `+strings.Join(formats, ", ")+`, or a
+Go time layout such as "Jan 2, 2006 15:04".`,
+			func(prefs *models.UserPreferences) *string { return &prefs.DateFormat }),
+		preferenceCommand("date-style [STYLE]", "Get or set whether dates are relative to now",
+			`Get or set whether dates are relative to now, e.g. "3 days ago": one of
+`+strings.Join(backend.DateStyles, ", ")+`. The auto style shows relative dates
+in lists and absolute dates in details.`,
+			func(prefs *models.UserPreferences) *string { return &prefs.DateStyle }),
+	)
+
+	return cmd
+}
+
+// preferenceCommand returns a command that gets or sets the preference field
+// returns.
+func preferenceCommand(use, short, long string, field func(*models.UserPreferences) *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   use,
+		Short: short,
+		Long:  long,
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			prefs, err := userPreferences(ctx)
+			if err != nil {
+				return err
+			}
+
+			if len(args) == 0 {
+				cmd.Println(*field(&prefs))
+				return nil
+			}
+
+			*field(&prefs) = strings.TrimSpace(args[0])
+			return backend.FromContext(ctx).SetUserPreferences(ctx, proto.UserFromContext(ctx), prefs)
+		},
+	}
+}
+
+// userPreferences returns the preferences of the user running the command.
+func userPreferences(ctx context.Context) (models.UserPreferences, error) {
+	user := proto.UserFromContext(ctx)
+	if user == nil {
+		return models.UserPreferences{}, proto.ErrUserNotFound
+	}
+	return backend.FromContext(ctx).UserPreferences(ctx, user)
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/spf13/cobra"
+)
+
+// ProfileCommand returns a command that shows the profile of a user or an
+// organization.
+func ProfileCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "profile [NAME]",
+		Short: "Show the profile of a user or an organization",
+		Long: `Show the profile README and repositories of a user or an organization,
+or your own when no name is given.
+
+The profile README of NAME is the README of the "NAME/.profile" repository.
+Organizations are the paths repositories are nested under.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			user := proto.UserFromContext(ctx)
+
+			var name string
+			if len(args) > 0 {
+				name = args[0]
+			} else if user != nil {
+				name = user.Username()
+			} else {
+				return proto.ErrUserNotFound
+			}
+
+			p, err := be.Profile(ctx, name, user)
+			if err != nil {
+				return err
+			}
+
+			if p.Readme != "" {
+				cmd.Println(p.Readme)
+				if p.ReadmeTruncated {
+					cmd.Println("(README truncated)")
+				}
+				cmd.Println()
+			}
+
+			if len(p.Repositories) == 0 {
+				cmd.Println("No repositories")
+				return nil
+			}
+
+			cmd.Println("Repositories:")
+			for _, r := range p.Repositories {
+				cmd.Println("  " + r.Name())
+			}
+
+			return nil
+		},
+	}
+
+	return cmd
+}
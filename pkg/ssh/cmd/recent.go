@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/spf13/cobra"
+)
+
+// recentCommand returns a command that lists the recently visited
+// repositories.
+func recentCommand() *cobra.Command {
+	var limit int
+
+	cmd := &cobra.Command{
+		Use:   "recent",
+		Short: "List the repositories you recently visited",
+		Long: `List the repositories you recently opened in the TUI, most recent first. Press
+"r" in the TUI repository list to show them first.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			dates := userDates(cmd)
+			be := backend.FromContext(ctx)
+
+			recent, err := be.RecentRepositories(ctx, proto.UserFromContext(ctx), limit)
+			if err != nil {
+				return err
+			}
+
+			for _, r := range recent {
+				cmd.Printf("%s\t%s\n", r.Repository.Name(), dates.Relative(r.VisitedAt))
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVarP(&limit, "limit", "n", backend.DefaultRecentRepos, "number of repositories to list")
+
+	return cmd
+}
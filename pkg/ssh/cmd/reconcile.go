@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/spf13/cobra"
+)
+
+func adminReconcileCommand() *cobra.Command {
+	var adopt, archive, purge bool
+
+	cmd := &cobra.Command{
+		Use:   "reconcile",
+		Short: "Reconcile the database with the repositories on disk",
+		Long: `Find the repositories on disk missing from the database and the other way
+around, and the issues, merge requests, and collaborators of missing
+repositories or referencing missing users.
+
+Without flags, only list them. --adopt adds the repositories missing from the
+database as private repositories, --archive moves them to the archive directory
+of the server, and --purge deletes them, the database records of the
+repositories missing on disk, and the rows of missing repositories and users.
+Each inconsistency is reconciled with the first of the given flags that
+applies to it, in this order.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+
+			var actions []backend.ReconcileAction
+			for _, a := range []struct {
+				set    bool
+				action backend.ReconcileAction
+			}{
+				{adopt, backend.ReconcileAdopt},
+				{archive, backend.ReconcileArchive},
+				{purge, backend.ReconcilePurge},
+			} {
+				if a.set {
+					actions = append(actions, a.action)
+				}
+			}
+
+			incs, err := be.CheckConsistency(ctx)
+			if err != nil {
+				return err
+			}
+			if len(incs) == 0 {
+				cmd.Println("No inconsistencies found")
+				return nil
+			}
+
+			var failed int
+			for _, inc := range incs {
+				if len(actions) == 0 {
+					cmd.Println(inc)
+					continue
+				}
+
+				err := backend.ErrCantReconcile
+				var action backend.ReconcileAction
+				for _, action = range actions {
+					err = be.Reconcile(ctx, inc, action)
+					if !errors.Is(err, backend.ErrCantReconcile) {
+						break
+					}
+				}
+				switch {
+				case errors.Is(err, backend.ErrCantReconcile):
+					cmd.Printf("Skipped %s\n", inc)
+				case err != nil:
+					cmd.PrintErrf("Failed to %s %s: %v\n", action, inc, err)
+					failed++
+				default:
+					cmd.Printf("%s %s\n", reconciledVerbs[action], inc)
+				}
+			}
+
+			if failed > 0 {
+				return fmt.Errorf("failed to reconcile %d of %d inconsistencies", failed, len(incs))
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&adopt, "adopt", false, "add the repositories missing from the database")
+	cmd.Flags().BoolVar(&archive, "archive", false, "archive the repositories missing from the database")
+	cmd.Flags().BoolVar(&purge, "purge", false, "delete the inconsistent repositories and rows")
+
+	return cmd
+}
+
+// reconciledVerbs are the past tense of the reconcile actions.
+var reconciledVerbs = map[backend.ReconcileAction]string{
+	backend.ReconcileAdopt:   "Adopted",
+	backend.ReconcileArchive: "Archived",
+	backend.ReconcilePurge:   "Purged",
+}
@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/spf13/cobra"
+)
+
+// printReferencedBy prints the issues, merge requests, and commits that
+// reference an issue or a merge request.
+func printReferencedBy(ctx context.Context, cmd *cobra.Command, be *backend.Backend, repo string, subject backend.Subject) {
+	refs, err := be.ReferencedBy(ctx, repo, subject)
+	if err != nil || len(refs) == 0 {
+		return
+	}
+
+	cmd.Printf("\nReferenced by:\n")
+	for _, ref := range refs {
+		if ref.Kind == backend.ReferenceKindCommit {
+			cmd.Printf("  commit %s\n", ref.Ref)
+			continue
+		}
+		cmd.Printf("  %s - %s\n", ref.Ref, ref.Title)
+	}
+}
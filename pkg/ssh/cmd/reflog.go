@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"github.com/charmbracelet/lipgloss/v2/table"
+	"github.com/charmbracelet/soft-serve/git"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/spf13/cobra"
+)
+
+// reflogCommand returns a command that lists the reflog of a repository.
+func reflogCommand() *cobra.Command {
+	var limit int
+
+	cmd := &cobra.Command{
+		Use:   "reflog REPOSITORY [REF]",
+		Short: "List the updates of the refs of a repository",
+		Long: `List the latest updates of the refs of a repository, newest first, including
+force-pushed and deleted branches. Admins can restore a ref to an old value
+with "admin repo recover".`,
+		Args:              cobra.RangeArgs(1, 2),
+		PersistentPreRunE: checkIfReadable,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			dates := userDates(cmd)
+			be := backend.FromContext(ctx)
+			rn := args[0]
+			var ref string
+			if len(args) > 1 {
+				ref = args[1]
+			}
+
+			us, err := be.RefLog(ctx, rn, ref, limit)
+			if err != nil {
+				return err
+			}
+
+			if len(us) == 0 {
+				cmd.Println("No ref updates")
+				return nil
+			}
+
+			users := map[int64]string{}
+			table := table.New().Headers("Ref", "Action", "Old", "New", "By", "When")
+			for _, u := range us {
+				var user string
+				if u.UserID.Valid {
+					if _, ok := users[u.UserID.Int64]; !ok {
+						if uu, err := be.UserByID(ctx, u.UserID.Int64); err == nil {
+							users[u.UserID.Int64] = uu.Username()
+						}
+					}
+					user = users[u.UserID.Int64]
+				}
+				table = table.Row(u.Ref,
+					u.Action,
+					shortSha(u.OldSha),
+					shortSha(u.NewSha),
+					user,
+					dates.Relative(u.CreatedAt),
+				)
+			}
+			cmd.Println(table)
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVarP(&limit, "limit", "n", 50, "number of updates to list")
+
+	return cmd
+}
+
+// shortSha abbreviates sha, and hides zero hashes of created and deleted
+// refs.
+func shortSha(sha string) string {
+	if git.IsZeroHash(sha) {
+		return ""
+	}
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
@@ -0,0 +1,324 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html/template"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/spf13/cobra"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+)
+
+// document is an issue or a merge request rendered for people outside the
+// instance.
+type document struct {
+	Title       string
+	Fields      [][2]string
+	Description string
+	Sections    []documentSection
+}
+
+type documentSection struct {
+	Title string
+	Items []string
+}
+
+func (d *document) field(name, value string) {
+	if value != "" {
+		d.Fields = append(d.Fields, [2]string{name, value})
+	}
+}
+
+func (d *document) section(title string, items []string) {
+	if len(items) > 0 {
+		d.Sections = append(d.Sections, documentSection{Title: title, Items: items})
+	}
+}
+
+// Markdown returns the document as Markdown.
+func (d document) Markdown() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# %s\n\n", d.Title)
+	for _, f := range d.Fields {
+		fmt.Fprintf(&sb, "- **%s:** %s\n", f[0], f[1])
+	}
+	if d.Description != "" {
+		fmt.Fprintf(&sb, "\n## Description\n\n%s\n", strings.TrimSpace(d.Description))
+	}
+	for _, s := range d.Sections {
+		fmt.Fprintf(&sb, "\n## %s\n\n", s.Title)
+		for _, item := range s.Items {
+			fmt.Fprintf(&sb, "- %s\n", item)
+		}
+	}
+	return sb.String()
+}
+
+var documentTpl = template.Must(template.New("document").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta http-equiv="Content-Type" content="text/html; charset=utf-8"/>
+    <title>{{ .Title }}</title>
+    <style>
+        body { max-width: 50em; margin: 2em auto; padding: 0 1em; font-family: sans-serif; line-height: 1.5; }
+        pre { overflow-x: auto; padding: 0.5em; background: #f6f8fa; }
+        code { background: #f6f8fa; }
+    </style>
+</head>
+<body>
+{{ .Body }}
+</body>
+</html>
+`))
+
+// HTML returns the document as a standalone HTML page. Raw HTML in the
+// description is left out.
+func (d document) HTML() (string, error) {
+	var body bytes.Buffer
+	md := goldmark.New(goldmark.WithExtensions(extension.GFM))
+	if err := md.Convert([]byte(d.Markdown()), &body); err != nil {
+		return "", err
+	}
+
+	var page bytes.Buffer
+	err := documentTpl.Execute(&page, struct {
+		Title string
+		Body  template.HTML
+	}{d.Title, template.HTML(body.String())}) //nolint:gosec
+	return page.String(), err
+}
+
+// PDF returns the document as a PDF file of Markdown-like plain text. It
+// uses the Courier fonts every PDF reader has, so the file embeds no font,
+// and characters out of the Windows-1252 charset are replaced.
+func (d document) PDF() []byte {
+	var p pdfWriter
+	p.text(pdfTitle, d.Title)
+	p.space()
+	for _, f := range d.Fields {
+		p.text(pdfBody, f[0]+": "+f[1])
+	}
+	if d.Description != "" {
+		p.space()
+		p.text(pdfHeading, "Description")
+		for _, line := range strings.Split(strings.TrimSpace(d.Description), "\n") {
+			p.text(pdfBody, line)
+		}
+	}
+	for _, s := range d.Sections {
+		p.space()
+		p.text(pdfHeading, s.Title)
+		for _, item := range s.Items {
+			p.text(pdfBody, "- "+item)
+		}
+	}
+	return p.bytes()
+}
+
+// renderCommand returns a command that renders an issue or a merge request
+// with build.
+func renderCommand(use, short string, build func(ctx context.Context, be *backend.Backend, dates backend.Dates, repo string, id string) (document, error)) *cobra.Command {
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   use,
+		Short: short,
+		Long: short + ` as a standalone document: the metadata, the description,
+and the linked items, for archiving or sharing with people outside the
+instance.`,
+		Args:              cobra.ExactArgs(2),
+		PersistentPreRunE: checkIfGuest,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if format != "md" && format != "html" && format != "pdf" {
+				return fmt.Errorf("invalid format %q, must be md, html, or pdf", format)
+			}
+
+			ctx := cmd.Context()
+			doc, err := build(ctx, backend.FromContext(ctx), documentDates(cmd), args[0], args[1])
+			if err != nil {
+				return err
+			}
+
+			switch format {
+			case "html":
+				out, err := doc.HTML()
+				if err != nil {
+					return err
+				}
+				cmd.Print(out)
+			case "pdf":
+				_, err = cmd.OutOrStdout().Write(doc.PDF())
+				return err
+			default:
+				cmd.Print(doc.Markdown())
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&format, "format", "f", "md", "format of the document, `md`, `html`, or `pdf`")
+
+	return cmd
+}
+
+// documentDates returns the dates formatting of documents. They are
+// archived, so their dates are never relative.
+func documentDates(cmd *cobra.Command) backend.Dates {
+	dates := userDates(cmd)
+	dates.Style = backend.DateStyleAbsolute
+	return dates
+}
+
+func issueRenderCommand() *cobra.Command {
+	return renderCommand("render REPOSITORY ISSUE_ID", "Render an issue",
+		func(ctx context.Context, be *backend.Backend, dates backend.Dates, repo string, id string) (document, error) {
+			issueID, err := be.ResolveIssueID(ctx, repo, id)
+			if err != nil {
+				return document{}, err
+			}
+			issue, err := be.GetIssue(ctx, repo, issueID)
+			if err != nil {
+				return document{}, err
+			}
+			prefix, err := be.IssuePrefix(ctx, repo)
+			if err != nil {
+				return document{}, err
+			}
+
+			doc := document{
+				Title:       fmt.Sprintf("%s %s: %s", repo, backend.FormatIssueRef(prefix, issue.Number), issue.Title),
+				Description: issue.Description,
+			}
+			doc.field("State", issue.State.String())
+			doc.field("Close reason", string(issue.CloseReason))
+			doc.field("Author", documentAuthor(ctx, be, issue.AuthorID))
+			doc.field("Created", dates.Absolute(issue.CreatedAt))
+			doc.field("Updated", dates.Absolute(issue.UpdatedAt))
+			if issue.ClosedAt.Valid {
+				doc.field("Closed", dates.Absolute(issue.ClosedAt.Time))
+			}
+			if issue.ClosedByCommit.Valid {
+				doc.field("Closed by commit", issue.ClosedByCommit.String)
+			}
+			if labels, err := be.IssueLabels(ctx, repo, issueID); err == nil {
+				doc.field("Labels", labelNames(labels))
+			}
+			if m, err := be.IssueMilestone(ctx, repo, issueID); err == nil {
+				doc.field("Milestone", m.Title)
+			}
+
+			var deps []string
+			if dependencies, err := be.GetIssueDependencies(ctx, repo, issueID); err == nil {
+				for _, dep := range dependencies {
+					deps = append(deps, backend.FormatIssueRef(prefix, dep.Number)+" "+dep.Title)
+				}
+			}
+			doc.section("Depends on", deps)
+
+			subject := backend.Subject{Type: backend.NotificationSubjectIssue, ID: issueID}
+			doc.section("Referenced by", documentReferences(ctx, be, repo, subject))
+			doc.section("Attachments", documentAttachments(ctx, be, repo, subject))
+			return doc, nil
+		})
+}
+
+func mergeRequestRenderCommand() *cobra.Command {
+	return renderCommand("render REPOSITORY MR_ID", "Render a merge request",
+		func(ctx context.Context, be *backend.Backend, dates backend.Dates, repo string, id string) (document, error) {
+			mrID, err := strconv.ParseInt(id, 10, 64)
+			if err != nil {
+				return document{}, fmt.Errorf("invalid merge request ID: %w", err)
+			}
+			mr, err := be.GetMergeRequest(ctx, repo, mrID)
+			if err != nil {
+				return document{}, err
+			}
+
+			doc := document{
+				Title:       fmt.Sprintf("%s!%d: %s", repo, mr.ID, mr.Title),
+				Description: mr.Description,
+			}
+			doc.field("State", mr.State.String())
+			doc.field("Author", documentAuthor(ctx, be, mr.AuthorID))
+			doc.field("Branches", fmt.Sprintf("`%s` into `%s`", mr.SourceBranch, mr.TargetBranch))
+			doc.field("Created", dates.Absolute(mr.CreatedAt))
+			doc.field("Updated", dates.Absolute(mr.UpdatedAt))
+			if mr.MergedAt.Valid {
+				doc.field("Merged", dates.Absolute(mr.MergedAt.Time))
+			}
+			if mr.ClosedAt.Valid {
+				doc.field("Closed", dates.Absolute(mr.ClosedAt.Time))
+			}
+			if reviewers, err := be.MergeRequestReviewers(ctx, repo, mrID); err == nil {
+				doc.field("Reviewers", strings.Join(reviewers, ", "))
+			}
+			if m, err := be.MergeRequestMilestone(ctx, repo, mrID); err == nil {
+				doc.field("Milestone", m.Title)
+			}
+
+			var deps []string
+			if dependencies, err := be.GetMergeRequestDependencies(ctx, repo, mrID); err == nil {
+				for _, dep := range dependencies {
+					deps = append(deps, fmt.Sprintf("!%d %s [%s]", dep.ID, dep.Title, dep.State.String()))
+				}
+			}
+			doc.section("Depends on", deps)
+
+			subject := backend.Subject{Type: backend.NotificationSubjectMergeRequest, ID: mrID}
+			doc.section("Referenced by", documentReferences(ctx, be, repo, subject))
+			doc.section("Attachments", documentAttachments(ctx, be, repo, subject))
+			doc.section("Comments", documentComments(ctx, be, dates, repo, mrID))
+			return doc, nil
+		})
+}
+
+func documentAuthor(ctx context.Context, be *backend.Backend, id int64) string {
+	if id <= 0 {
+		return ""
+	}
+	u, err := be.UserByID(ctx, id)
+	if err != nil || u == nil {
+		return "a deleted user"
+	}
+	return proto.DisplayName(u)
+}
+
+func documentReferences(ctx context.Context, be *backend.Backend, repo string, subject backend.Subject) []string {
+	refs, _ := be.ReferencedBy(ctx, repo, subject)
+	items := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		if ref.Kind == backend.ReferenceKindCommit {
+			items = append(items, "commit "+ref.Ref)
+			continue
+		}
+		items = append(items, ref.Ref+" "+ref.Title)
+	}
+	return items
+}
+
+func documentAttachments(ctx context.Context, be *backend.Backend, repo string, subject backend.Subject) []string {
+	as, _ := be.Attachments(ctx, repo, subject)
+	items := make([]string, 0, len(as))
+	for _, a := range as {
+		items = append(items, fmt.Sprintf("[%s](%s)", a.Name, be.AttachmentURL(repo, a)))
+	}
+	return items
+}
+
+func documentComments(ctx context.Context, be *backend.Backend, dates backend.Dates, repo string, mrID int64) []string {
+	threads, _ := be.MergeRequestThreads(ctx, repo, mrID)
+	var items []string
+	for _, t := range threads {
+		items = append(items, fmt.Sprintf("%s (%s%s): %s", documentAuthor(ctx, be, t.AuthorID), dates.Absolute(t.CreatedAt), commentAnchor(t.MergeRequestComment), t.Body))
+		for _, r := range t.Replies {
+			items = append(items, fmt.Sprintf("↳ %s (%s): %s", documentAuthor(ctx, be, r.AuthorID), dates.Absolute(r.CreatedAt), r.Body))
+		}
+	}
+	return items
+}
@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+// Page layout of rendered PDF documents, in points: A4 pages with 2cm
+// margins.
+const (
+	pdfPageWidth  = 595
+	pdfPageHeight = 842
+	pdfMargin     = 56
+
+	// pdfCharWidth is the width of the Courier characters, in thousandths
+	// of the font size.
+	pdfCharWidth = 600
+)
+
+// pdfStyle is a font and a size of rendered PDF text.
+type pdfStyle struct {
+	font string
+	size float64
+}
+
+var (
+	pdfTitle   = pdfStyle{"F2", 16}
+	pdfHeading = pdfStyle{"F2", 13}
+	pdfBody    = pdfStyle{"F1", 10}
+)
+
+// pdfWriter lays out lines of text on PDF pages.
+type pdfWriter struct {
+	pages []*bytes.Buffer
+	y     float64
+}
+
+// text writes s in style, wrapped to the page width.
+func (p *pdfWriter) text(style pdfStyle, s string) {
+	width := int((pdfPageWidth - 2*pdfMargin) * 1000 / (style.size * pdfCharWidth))
+	for _, line := range pdfWrap(s, width) {
+		lead := style.size * 1.4
+		if len(p.pages) == 0 || p.y-lead < pdfMargin {
+			p.pages = append(p.pages, &bytes.Buffer{})
+			p.y = pdfPageHeight - pdfMargin
+		}
+		p.y -= lead
+		fmt.Fprintf(p.pages[len(p.pages)-1], "BT /%s %g Tf %d %g Td (%s) Tj ET\n",
+			style.font, style.size, pdfMargin, p.y, pdfEscape(line))
+	}
+}
+
+// space leaves a blank line.
+func (p *pdfWriter) space() {
+	p.y -= pdfBody.size
+}
+
+// bytes returns the PDF file.
+func (p *pdfWriter) bytes() []byte {
+	if len(p.pages) == 0 {
+		p.pages = append(p.pages, &bytes.Buffer{})
+	}
+
+	// Objects 1 and 2 are the catalog and the page tree, 3 and 4 the
+	// fonts, then every page is followed by its content stream.
+	var objs []string
+	kids := make([]string, len(p.pages))
+	for i := range p.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 5+2*i)
+	}
+	objs = append(objs,
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(p.pages)),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Courier-Bold /Encoding /WinAnsiEncoding >>",
+	)
+	for i, page := range p.pages {
+		objs = append(objs,
+			fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+				pdfPageWidth, pdfPageHeight, 6+2*i),
+			fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", page.Len(), page.String()),
+		)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objs))
+	for i, obj := range objs {
+		offsets[i] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(objs)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objs)+1, xref)
+	return buf.Bytes()
+}
+
+// pdfWrap splits s into lines of at most width characters, breaking at
+// spaces when it can.
+func pdfWrap(s string, width int) []string {
+	s = strings.ReplaceAll(strings.TrimRight(s, " \r"), "\t", "    ")
+	if s == "" {
+		return []string{""}
+	}
+
+	var lines []string
+	for utf8.RuneCountInString(s) > width {
+		cut := len(string([]rune(s)[:width]))
+		if i := strings.LastIndexByte(s[:cut+1], ' '); i > 0 {
+			lines = append(lines, s[:i])
+			s = strings.TrimLeft(s[i:], " ")
+			continue
+		}
+		lines = append(lines, s[:cut])
+		s = s[cut:]
+	}
+	return append(lines, s)
+}
+
+// pdfEscape encodes s as the content of a PDF string in the Windows-1252
+// charset of the fonts.
+func pdfEscape(s string) string {
+	var sb strings.Builder
+	for _, r := range s {
+		b, ok := charmap.Windows1252.EncodeRune(r)
+		if !ok {
+			b = '?'
+		}
+		switch b {
+		case '(', ')', '\\':
+			sb.WriteByte('\\')
+			sb.WriteByte(b)
+		default:
+			if b < 0x20 || b >= 0x7f {
+				fmt.Fprintf(&sb, "\\%03o", b)
+			} else {
+				sb.WriteByte(b)
+			}
+		}
+	}
+	return sb.String()
+}
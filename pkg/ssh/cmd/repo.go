@@ -18,24 +18,45 @@ func RepoCommand() *cobra.Command {
 	}
 
 	cmd.AddCommand(
+		agreementsCommand(),
 		blobCommand(),
+		blockCommand(),
 		branchCommand(),
 		collabCommand(),
 		commitCommand(),
+		commitRulesCommand(),
+		compareCommand(),
 		createCommand(),
 		deleteCommand(),
 		descriptionCommand(),
+		editPolicyCommand(),
 		hiddenCommand(),
 		importCommand(),
+		integrationsCommand(),
 		issueCommand(),
+		issuesEnabledCommand(),
 		listCommand(),
+		mergeQueueCommand(),
 		mergeRequestCommand(),
+		mergeRequestsEnabledCommand(),
+		milestoneCommand(),
 		mirrorCommand(),
+		newsEnabledCommand(),
 		privateCommand(),
 		projectName(),
+		recentCommand(),
+		reflogCommand(),
 		renameCommand(),
+		requiredApprovalsCommand(),
+		reviewStatsCommand(),
+		searchEnabledCommand(),
+		slaCommand(),
+		staleExemptCommand(),
 		tagCommand(),
+		trackerCommand(),
 		treeCommand(),
+		visibilityCommand(),
+		watchCommand(),
 		webhookCommand(),
 	)
 
@@ -81,6 +102,7 @@ func RepoCommand() *cobra.Command {
 				cmd.Println("Repository:", rr.Name())
 				cmd.Println(strings.TrimSpace(fmt.Sprint("Description: ", rr.Description())))
 				cmd.Println("Private:", rr.IsPrivate())
+				cmd.Println("Visibility:", proto.RepositoryVisibility(rr))
 				cmd.Println("Hidden:", rr.IsHidden())
 				cmd.Println("Mirror:", rr.IsMirror())
 				if owner != nil {
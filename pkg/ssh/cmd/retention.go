@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/spf13/cobra"
+)
+
+func adminRetentionCommand() *cobra.Command {
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "retention",
+		Short: "Apply the data retention policies",
+		Long: `Delete the closed issues, webhook deliveries, and audit events older than the
+retention policies of the server configuration, like the retention job does.
+
+With --dry-run, only report what would be deleted.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+
+			report, err := be.ApplyRetention(ctx, time.Now(), dryRun)
+			if err != nil {
+				return err
+			}
+
+			verb := "Deleted"
+			if report.DryRun {
+				verb = "Would delete"
+			}
+			cmd.Printf("%s %d closed issues, %d webhook deliveries, %d audit events\n",
+				verb, report.ClosedIssues, report.WebhookDeliveries, report.AuditEvents)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "only report what would be deleted")
+
+	return cmd
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"strconv"
+
+	"github.com/charmbracelet/lipgloss/v2/table"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/spf13/cobra"
+)
+
+// ReviewCommand returns a command that shows the merge requests awaiting the
+// user's review.
+func ReviewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "review",
+		Aliases: []string{"reviews"},
+		Short:   "Manage your merge request reviews",
+	}
+
+	queueCmd := &cobra.Command{
+		Use:   "queue",
+		Short: "List the open merge requests you are requested to review",
+		Long: `List the open merge requests you are requested to review, oldest first.
+
+Reviews are requested with "repo mr add-reviewer REPOSITORY MR_ID USERNAME".`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			dates := userDates(cmd)
+			be := backend.FromContext(ctx)
+
+			user := proto.UserFromContext(ctx)
+			if user == nil {
+				return proto.ErrUserNotFound
+			}
+
+			items, err := be.ReviewQueue(ctx, user)
+			if err != nil {
+				return err
+			}
+
+			if len(items) == 0 {
+				cmd.Println("No merge requests awaiting your review")
+				return nil
+			}
+
+			authors := map[int64]string{}
+			table := table.New().Headers("Repository", "ID", "Title", "Branches", "Author", "Opened")
+			for _, it := range items {
+				mr := it.MergeRequest
+				if _, ok := authors[mr.AuthorID]; !ok {
+					if u, err := be.UserByID(ctx, mr.AuthorID); err == nil {
+						authors[mr.AuthorID] = proto.DisplayName(u)
+					}
+				}
+				table = table.Row(
+					it.Repository.Name(),
+					"#"+strconv.FormatInt(mr.ID, 10),
+					mr.Title,
+					mr.SourceBranch+" → "+mr.TargetBranch,
+					authors[mr.AuthorID],
+					dates.Relative(mr.CreatedAt),
+				)
+			}
+			cmd.Println(table)
+
+			return nil
+		},
+	}
+
+	cmd.AddCommand(queueCmd)
+
+	return cmd
+}
@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/charmbracelet/lipgloss/v2/table"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/spf13/cobra"
+)
+
+func reviewStatsCommand() *cobra.Command {
+	var days int
+	var asCSV bool
+
+	cmd := &cobra.Command{
+		Use:   "review-stats [REPOSITORY|PATH]",
+		Short: "Show code review latency metrics",
+		Long: `Show code review latency metrics.
+
+Reports the time to first review and the time to merge of the merge requests
+opened in the last days, per repository. The first review of a merge request
+is the first edit, merge, close, or merge queue entry by someone other than its
+author.
+
+Give a repository to report on it, or a path to report on the repositories
+nested under it, e.g. myteam for myteam/api and myteam/web. Without one, all
+the repositories you can read are reported on. Use --csv to export every merge
+request with its timings instead.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			user := proto.UserFromContext(ctx)
+
+			if days < 1 {
+				return errors.New("days must be positive")
+			}
+
+			var scope string
+			if len(args) > 0 {
+				scope = args[0]
+			}
+
+			report, err := be.ReviewStats(ctx, user, scope, time.Now().AddDate(0, 0, -days))
+			if err != nil {
+				return err
+			}
+
+			if asCSV {
+				return writeReviewStatsCSV(cmd, be, report)
+			}
+
+			if report.Total.MergeRequests == 0 {
+				cmd.Printf("No merge requests opened in the last %d days\n", days)
+				return nil
+			}
+
+			table := table.New().Headers("Repository", "MRs", "Reviewed", "Awaiting", "Merged",
+				"Review p50", "Review p90", "Merge p50", "Merge p90")
+			for _, s := range append(report.Repositories, report.Total) {
+				name := s.Repository
+				if name == "" {
+					name = "Total"
+				}
+				table = table.Row(
+					name,
+					strconv.Itoa(s.MergeRequests),
+					strconv.Itoa(s.Reviewed),
+					strconv.Itoa(s.Awaiting),
+					strconv.Itoa(s.Merged),
+					reviewDuration(s.MedianTimeToFirstReview, s.Reviewed),
+					reviewDuration(s.P90TimeToFirstReview, s.Reviewed),
+					reviewDuration(s.MedianTimeToMerge, s.Merged),
+					reviewDuration(s.P90TimeToMerge, s.Merged),
+				)
+			}
+			cmd.Println(table)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVarP(&days, "days", "d", 30, "report on the merge requests opened in the last days")
+	cmd.Flags().BoolVar(&asCSV, "csv", false, "export the merge requests and their timings as CSV")
+
+	return cmd
+}
+
+// reviewDuration formats an aggregated review latency, "-" when there is no
+// merge request to aggregate.
+func reviewDuration(d time.Duration, n int) string {
+	if n == 0 {
+		return "-"
+	}
+
+	d = d.Round(time.Minute)
+	switch {
+	case d >= 24*time.Hour:
+		return fmt.Sprintf("%dd %dh", d/(24*time.Hour), d%(24*time.Hour)/time.Hour)
+	case d >= time.Hour:
+		return fmt.Sprintf("%dh %dm", d/time.Hour, d%time.Hour/time.Minute)
+	case d >= time.Minute:
+		return fmt.Sprintf("%dm", d/time.Minute)
+	default:
+		return "<1m"
+	}
+}
+
+// writeReviewStatsCSV writes the merge requests of a review latency report and
+// their timings, in seconds, as CSV.
+func writeReviewStatsCSV(cmd *cobra.Command, be *backend.Backend, report backend.ReviewStatsReport) error {
+	usernames := map[int64]string{}
+	username := func(id int64) string {
+		if id == 0 {
+			return ""
+		}
+		if name, ok := usernames[id]; ok {
+			return name
+		}
+		name := ""
+		if u, err := be.UserByID(cmd.Context(), id); err == nil {
+			name = u.Username()
+		}
+		usernames[id] = name
+		return name
+	}
+	optional := func(valid bool, s string) string {
+		if !valid {
+			return ""
+		}
+		return s
+	}
+
+	w := csv.NewWriter(cmd.OutOrStdout())
+	if err := w.Write([]string{
+		"repository", "id", "title", "author", "state", "created_at",
+		"reviewed_by", "reviewed_at", "merged_at",
+		"time_to_first_review_seconds", "time_to_merge_seconds",
+	}); err != nil {
+		return err
+	}
+
+	for _, t := range report.MergeRequests {
+		mr := t.MergeRequest
+		if err := w.Write([]string{
+			t.Repository.Name(),
+			strconv.FormatInt(mr.ID, 10),
+			mr.Title,
+			username(mr.AuthorID),
+			mr.State.String(),
+			mr.CreatedAt.UTC().Format(time.RFC3339),
+			optional(mr.ReviewedBy.Valid, username(mr.ReviewedBy.Int64)),
+			optional(mr.ReviewedAt.Valid, mr.ReviewedAt.Time.UTC().Format(time.RFC3339)),
+			optional(mr.MergedAt.Valid, mr.MergedAt.Time.UTC().Format(time.RFC3339)),
+			optional(mr.ReviewedAt.Valid, strconv.FormatInt(int64(t.TimeToFirstReview.Seconds()), 10)),
+			optional(mr.State == models.MergeRequestStateMerged && mr.MergedAt.Valid, strconv.FormatInt(int64(t.TimeToMerge.Seconds()), 10)),
+		}); err != nil {
+			return err
+		}
+	}
+
+	w.Flush()
+	return w.Error()
+}
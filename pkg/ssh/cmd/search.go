@@ -0,0 +1,201 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss/v2/table"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/spf13/cobra"
+)
+
+// SearchCommand returns a command that searches issues and merge requests
+// across repositories.
+func SearchCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "search",
+		Short: "Search issues and merge requests across repositories",
+		Long: `Search issues and merge requests across repositories.
+
+Only repositories you can read, or are a guest of, are searched. Use --org to
+limit the search to the repositories nested under a path, e.g. --org myteam
+searches myteam/api and myteam/web.`,
+	}
+
+	cmd.AddCommand(
+		searchIssuesCommand(),
+		searchMergeRequestsCommand(),
+	)
+
+	return cmd
+}
+
+// searchFlags are the flags shared by the search subcommands.
+type searchFlags struct {
+	opts   backend.SearchOptions
+	state  string
+	asJSON bool
+}
+
+func (f *searchFlags) register(cmd *cobra.Command, states string) {
+	cmd.Flags().StringVar(&f.opts.Org, "org", "", "only search repositories nested under this path")
+	cmd.Flags().StringVar(&f.opts.Author, "author", "", "only show results authored by this user")
+	cmd.Flags().StringVar(&f.state, "state", "", fmt.Sprintf("filter by state (%s)", states))
+	cmd.Flags().IntVar(&f.opts.Page, "page", 1, "page number")
+	cmd.Flags().IntVar(&f.opts.PerPage, "per-page", backend.DefaultSearchPerPage, fmt.Sprintf("results per page, at most %d", backend.MaxSearchPerPage))
+	cmd.Flags().BoolVar(&f.asJSON, "json", false, "print results as JSON")
+}
+
+// searchResult is a search result as printed with --json.
+type searchResult struct {
+	Repository string    `json:"repository"`
+	ID         int64     `json:"id"`
+	Title      string    `json:"title"`
+	State      string    `json:"state"`
+	Author     string    `json:"author"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+func searchIssuesCommand() *cobra.Command {
+	var flags searchFlags
+
+	cmd := &cobra.Command{
+		Use:   "issues [QUERY...]",
+		Short: "Search issues",
+		Args:  cobra.ArbitraryArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+
+			var state *models.IssueState
+			if flags.state != "" {
+				s := parseIssueState(flags.state)
+				if s < 0 {
+					return fmt.Errorf("invalid state: %s (must be one of: open, closed)", flags.state)
+				}
+				state = &s
+			}
+
+			flags.opts.Query = strings.Join(args, " ")
+			issues, err := be.SearchIssues(ctx, proto.UserFromContext(ctx), flags.opts, state)
+			if err != nil {
+				return err
+			}
+
+			results := make([]searchResult, len(issues))
+			for i, r := range issues {
+				results[i] = searchResult{
+					Repository: r.Repository.Name(),
+					ID:         r.Issue.Number,
+					Title:      r.Issue.Title,
+					State:      r.Issue.State.String(),
+					CreatedAt:  r.Issue.CreatedAt,
+					UpdatedAt:  r.Issue.UpdatedAt,
+				}
+			}
+
+			return printSearchResults(cmd, flags.asJSON, results, func(i int) int64 {
+				return issues[i].Issue.AuthorID
+			})
+		},
+	}
+
+	flags.register(cmd, "open, closed")
+
+	return cmd
+}
+
+func searchMergeRequestsCommand() *cobra.Command {
+	var flags searchFlags
+
+	cmd := &cobra.Command{
+		Use:     "merge-requests [QUERY...]",
+		Aliases: []string{"mrs", "mr"},
+		Short:   "Search merge requests",
+		Args:    cobra.ArbitraryArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+
+			var state *models.MergeRequestState
+			if flags.state != "" {
+				s := parseState(flags.state)
+				if s < 0 {
+					return fmt.Errorf("invalid state: %s (must be one of: open, merged, closed)", flags.state)
+				}
+				state = &s
+			}
+
+			flags.opts.Query = strings.Join(args, " ")
+			mrs, err := be.SearchMergeRequests(ctx, proto.UserFromContext(ctx), flags.opts, state)
+			if err != nil {
+				return err
+			}
+
+			results := make([]searchResult, len(mrs))
+			for i, r := range mrs {
+				results[i] = searchResult{
+					Repository: r.Repository.Name(),
+					ID:         r.MergeRequest.ID,
+					Title:      r.MergeRequest.Title,
+					State:      r.MergeRequest.State.String(),
+					CreatedAt:  r.MergeRequest.CreatedAt,
+					UpdatedAt:  r.MergeRequest.UpdatedAt,
+				}
+			}
+
+			return printSearchResults(cmd, flags.asJSON, results, func(i int) int64 {
+				return mrs[i].MergeRequest.AuthorID
+			})
+		},
+	}
+
+	flags.register(cmd, "open, merged, closed")
+
+	return cmd
+}
+
+// printSearchResults fills in the result authors and prints the results as a
+// table or as JSON.
+func printSearchResults(cmd *cobra.Command, asJSON bool, results []searchResult, authorID func(int) int64) error {
+	ctx := cmd.Context()
+	dates := userDates(cmd)
+	be := backend.FromContext(ctx)
+
+	authors := map[int64]string{}
+	for i := range results {
+		id := authorID(i)
+		if _, ok := authors[id]; !ok {
+			authors[id] = ""
+			if u, err := be.UserByID(ctx, id); err == nil {
+				authors[id] = proto.DisplayName(u)
+			}
+		}
+		results[i].Author = authors[id]
+	}
+
+	if asJSON {
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		return enc.Encode(results)
+	}
+
+	if len(results) == 0 {
+		cmd.Println("No results found")
+		return nil
+	}
+
+	table := table.New().Headers("Repository", "ID", "Title", "State", "Author", "Updated")
+	for _, r := range results {
+		table = table.Row(r.Repository, "#"+strconv.FormatInt(r.ID, 10), r.Title, r.State, r.Author, dates.Relative(r.UpdatedAt))
+	}
+	cmd.Println(table)
+
+	return nil
+}
@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"strconv"
+
+	"github.com/charmbracelet/lipgloss/v2/table"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/spf13/cobra"
+)
+
+func userSessionsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "sessions",
+		Aliases: []string{"session"},
+		Short:   "Manage active TUI sessions",
+	}
+
+	var all bool
+	listCmd := &cobra.Command{
+		Use:     "list [USERNAME]",
+		Aliases: []string{"ls"},
+		Short:   "List active TUI sessions",
+		Long:    "List your active TUI sessions. Admins can list the sessions of another user, or of every user with --all.",
+		Args:    cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			dates := userDates(cmd)
+			be := backend.FromContext(ctx)
+
+			user := proto.UserFromContext(ctx)
+			if all || len(args) > 0 {
+				if err := checkIfAdmin(cmd, nil); err != nil {
+					return err
+				}
+			} else if user == nil {
+				return proto.ErrUserNotFound
+			}
+
+			if all {
+				user = nil
+			} else if len(args) > 0 {
+				u, err := be.User(ctx, args[0])
+				if err != nil {
+					return err
+				}
+				user = u
+			}
+
+			sessions := be.Sessions(user)
+			if len(sessions) == 0 {
+				cmd.Println("No sessions found")
+				return nil
+			}
+
+			table := table.New().Headers("ID", "User", "From", "Started")
+			for _, s := range sessions {
+				username := s.Username
+				if username == "" {
+					username = "anonymous"
+				}
+				table = table.Row(strconv.FormatInt(s.ID, 10),
+					username,
+					s.RemoteAddr,
+					dates.Relative(s.StartedAt),
+				)
+			}
+			cmd.Println(table)
+			return nil
+		},
+	}
+
+	listCmd.Flags().BoolVarP(&all, "all", "a", false, "list the sessions of every user")
+
+	revokeCmd := &cobra.Command{
+		Use:     "revoke ID",
+		Aliases: []string{"kill"},
+		Short:   "Terminate a TUI session",
+		Long:    "Terminate one of your TUI sessions. Admins can terminate any session.",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+
+			id, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			user := proto.UserFromContext(ctx)
+			if checkIfAdmin(cmd, nil) == nil {
+				user = nil
+			} else if user == nil {
+				return proto.ErrUserNotFound
+			}
+
+			if err := be.RevokeSession(ctx, user, id); err != nil {
+				return err
+			}
+
+			cmd.PrintErrln("Session revoked")
+			return nil
+		},
+	}
+
+	revokeAllCmd := &cobra.Command{
+		Use:               "revoke-all USERNAME",
+		Short:             "Terminate all the TUI sessions of a user",
+		Long:              "Terminate all the TUI sessions of a user, e.g. after their credentials leaked. Revoke their access tokens and keys too.",
+		Args:              cobra.ExactArgs(1),
+		PersistentPreRunE: checkIfAdmin,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+
+			n, err := be.RevokeUserSessions(ctx, args[0])
+			if err != nil {
+				return err
+			}
+
+			cmd.PrintErrf("%d sessions revoked\n", n)
+			return nil
+		},
+	}
+
+	cmd.AddCommand(
+		listCmd,
+		revokeCmd,
+		revokeAllCmd,
+	)
+
+	return cmd
+}
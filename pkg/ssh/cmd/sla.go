@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/charmbracelet/lipgloss/v2/table"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/spf13/cobra"
+)
+
+func slaCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sla",
+		Short: "Manage the repository issue response time SLA",
+		Long: `Manage the repository issue response time SLA.
+
+New issues must get a first response, an edit or a close by someone other than
+their author, within the SLA. Issues that miss it are marked as breached and an
+issue webhook event with the sla_breached action is sent.`,
+	}
+
+	cmd.AddCommand(
+		slaSetCommand(),
+		slaShowCommand(),
+		slaDeleteCommand(),
+		slaReportCommand(),
+	)
+
+	return cmd
+}
+
+func slaSetCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "set REPOSITORY HOURS",
+		Short:             "Set the number of hours within which new issues must get a response",
+		Args:              cobra.ExactArgs(2),
+		PersistentPreRunE: checkIfReadableAndCollab,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+
+			hours, err := strconv.Atoi(args[1])
+			if err != nil {
+				return err
+			}
+
+			return be.SetIssueSLA(ctx, args[0], hours)
+		},
+	}
+
+	return cmd
+}
+
+func slaShowCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "show REPOSITORY",
+		Short:             "Show the repository issue SLA",
+		Args:              cobra.ExactArgs(1),
+		PersistentPreRunE: checkIfReadable,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			dates := userDates(cmd)
+			be := backend.FromContext(ctx)
+
+			sla, err := be.IssueSLA(ctx, args[0])
+			if err != nil {
+				return err
+			}
+
+			cmd.Printf("Response Time: %dh\n", sla.ResponseHours)
+			cmd.Printf("Since: %s\n", dates.Absolute(sla.CreatedAt))
+
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func slaDeleteCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "delete REPOSITORY",
+		Short:             "Delete the repository issue SLA",
+		Args:              cobra.ExactArgs(1),
+		PersistentPreRunE: checkIfReadableAndCollab,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+
+			return be.DeleteIssueSLA(ctx, args[0])
+		},
+	}
+
+	return cmd
+}
+
+func slaReportCommand() *cobra.Command {
+	var breachedOnly bool
+
+	cmd := &cobra.Command{
+		Use:               "report REPOSITORY",
+		Short:             "Report the SLA status of the issues opened since the SLA was set",
+		Args:              cobra.ExactArgs(1),
+		PersistentPreRunE: checkIfReadable,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			dates := userDates(cmd)
+			be := backend.FromContext(ctx)
+
+			report, err := be.IssueSLAReport(ctx, args[0], time.Now())
+			if err != nil {
+				return err
+			}
+
+			table := table.New().Headers("ID", "Title", "State", "Due", "Responded", "SLA")
+			var rows, breaches int
+			for _, s := range report {
+				if s.Breached {
+					breaches++
+				} else if breachedOnly {
+					continue
+				}
+
+				responded := "-"
+				if s.Issue.RespondedAt.Valid {
+					responded = dates.Relative(s.Issue.RespondedAt.Time)
+				}
+				status := "ok"
+				switch {
+				case s.Breached:
+					status = "breached"
+				case !s.Issue.RespondedAt.Valid && s.Issue.ClosedAt.Valid:
+					status = "closed"
+				case !s.Issue.RespondedAt.Valid:
+					status = "pending"
+				}
+
+				table = table.Row(
+					"#"+strconv.FormatInt(s.Issue.Number, 10),
+					s.Issue.Title,
+					s.Issue.State.String(),
+					dates.Relative(s.Due),
+					responded,
+					status,
+				)
+				rows++
+			}
+
+			if rows > 0 {
+				cmd.Println(table)
+			}
+			cmd.Printf("%d of %d issues breached the SLA\n", breaches, len(report))
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&breachedOnly, "breached", "b", false, "only show issues that breached the SLA")
+
+	return cmd
+}
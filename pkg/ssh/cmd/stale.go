@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/spf13/cobra"
+)
+
+func staleExemptCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "stale-exempt REPOSITORY [TRUE|FALSE]",
+		Short:             "Opt a repository out of closing inactive issues and merge requests",
+		Args:              cobra.RangeArgs(1, 2),
+		PersistentPreRunE: checkIfReadable,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			repo := args[0]
+			switch len(args) {
+			case 1:
+				exempt, err := be.IsStaleExempt(ctx, repo)
+				if err != nil {
+					return err
+				}
+
+				cmd.Println(exempt)
+			case 2:
+				if err := checkIfCollab(cmd, args); err != nil {
+					return err
+				}
+
+				exempt := args[1] == "true"
+				if err := be.SetStaleExempt(ctx, repo, exempt); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	}
+
+	return cmd
+}
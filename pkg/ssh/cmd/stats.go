@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss/v2/table"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/dustin/go-humanize"
+	"github.com/spf13/cobra"
+)
+
+func adminStatsCommand() *cobra.Command {
+	var months, top int
+	var asJSON bool
+
+	cmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Show the statistics of the server",
+		Long: `Show the number of repositories, users, issues, and merge requests of the
+server, what was created each month, the largest repositories, and the
+repositories with the most activity over the last 30 days.
+
+Measuring the repositories walks them all, which can take a while on large
+servers. Use --json to feed capacity planning dashboards.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if months < 0 {
+				return fmt.Errorf("invalid number of months: %d", months)
+			}
+
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+
+			stats, err := be.InstanceStats(ctx, backend.StatsOptions{Months: months, Top: top})
+			if err != nil {
+				return err
+			}
+
+			if asJSON {
+				enc := json.NewEncoder(cmd.OutOrStdout())
+				enc.SetIndent("", "  ")
+				return enc.Encode(stats)
+			}
+
+			cmd.Printf("Repositories: %d\n", stats.Repositories)
+			cmd.Printf("Users: %d\n", stats.Users)
+			cmd.Printf("Issues: %d\n", stats.Issues)
+			cmd.Printf("Merge Requests: %d\n", stats.MergeRequests)
+			cmd.Printf("Disk Usage: %s\n", humanize.Bytes(uint64(stats.DiskUsage))) //nolint:gosec
+
+			if len(stats.Growth) > 0 {
+				t := table.New().Headers("Month", "Repositories", "Users", "Issues", "Merge Requests")
+				for _, g := range stats.Growth {
+					t = t.Row(g.Month, fmt.Sprint(g.Repositories), fmt.Sprint(g.Users),
+						fmt.Sprint(g.Issues), fmt.Sprint(g.MergeRequests))
+				}
+				cmd.Printf("\nGrowth:\n%s\n", t)
+			}
+
+			if len(stats.Storage) > 0 {
+				cmd.Println("\nLargest repositories:")
+				for _, s := range stats.Storage {
+					cmd.Printf("  %s (%s)\n", s.Repository, humanize.Bytes(uint64(s.Size))) //nolint:gosec
+				}
+			}
+
+			if len(stats.Busiest) > 0 {
+				cmd.Println("\nBusiest repositories (last 30 days):")
+				for _, b := range stats.Busiest {
+					cmd.Printf("  %s (%d activities)\n", b.Repository, b.Activities)
+				}
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVar(&months, "months", 6, "Number of months of growth to show")
+	cmd.Flags().IntVar(&top, "top", backend.DefaultStatsTop, "Number of repositories of the rankings")
+	cmd.Flags().BoolVar(&asJSON, "json", false, "Print the statistics as JSON")
+
+	return cmd
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/config"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/ssh"
+	"github.com/spf13/cobra"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+var errImpersonationDisabled = errors.New("impersonation is disabled on this server")
+
+func adminSudoCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sudo USERNAME -- COMMAND [ARGS...]",
+		Short: "Run a command as another user",
+		Long: `Run a command as another user, to troubleshoot what they see. The command
+runs with the user's access and preferences, and its actions are recorded in
+the audit log along with the admin who ran it. Git commands and nested sudo
+aren't allowed.
+
+  ssh soft admin sudo frankie -- repo issue list icecream`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if cmd.ArgsLenAtDash() != 1 {
+				return errors.New("separate the command from the username with --")
+			}
+
+			ctx := cmd.Context()
+			cfg := config.FromContext(ctx)
+			be := backend.FromContext(ctx)
+			if !cfg.SSH.Impersonation {
+				return errImpersonationDisabled
+			}
+			if proto.ImpersonatorFromContext(ctx) != nil {
+				return errors.New("already impersonating a user")
+			}
+
+			admin := proto.UserFromContext(ctx)
+			if admin == nil {
+				return proto.ErrUserNotFound
+			}
+			user, err := be.User(ctx, args[0])
+			if err != nil {
+				return err
+			}
+
+			command := args[1:]
+			if strings.HasPrefix(command[0], "git-") {
+				return errors.New("git commands can't be run as another user")
+			}
+
+			be.AuditImpersonation(ctx, user, strings.Join(command, " "))
+
+			// Commands find their user by key, too.
+			var pk gossh.PublicKey
+			if pks := user.PublicKeys(); len(pks) > 0 {
+				pk = pks[0]
+			}
+			sctx := proto.WithImpersonatorContext(ctx, admin)
+			sctx = proto.WithUserContext(sctx, user)
+			sctx = context.WithValue(sctx, ssh.ContextKeyPublicKey, pk)
+
+			// Cobra only gives the root context to commands without one, so
+			// this command, already run, must be given the impersonated
+			// context for a nested sudo.
+			cmd.SetContext(sctx)
+			root := cmd.Root()
+			root.SetArgs(command)
+			err = root.ExecuteContext(sctx)
+			// The command already reported its error.
+			cmd.SilenceErrors = err != nil
+			return err
+		},
+	}
+
+	return cmd
+}
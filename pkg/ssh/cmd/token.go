@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"fmt"
 	"strconv"
 	"strings"
 	"time"
@@ -9,6 +10,8 @@ import (
 	"github.com/charmbracelet/lipgloss/v2/table"
 	"github.com/charmbracelet/soft-serve/pkg/backend"
 	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/sshutils"
+	"github.com/charmbracelet/ssh"
 	"github.com/dustin/go-humanize"
 	"github.com/spf13/cobra"
 )
@@ -21,17 +24,41 @@ func TokenCommand() *cobra.Command {
 		Short:   "Manage access tokens",
 	}
 
-	var createExpiresIn string
+	var createExpiresIn, createScope, createRepo, createUser, createPinKey string
+	var createAllowFrom []string
 	createCmd := &cobra.Command{
 		Use:   "create NAME",
 		Short: "Create a new access token",
-		Args:  cobra.MinimumNArgs(1),
+		Long: `Create a new access token.
+
+Use --scope and --repo to narrow down what the token can do. Admins can create
+tokens for bot users with --user.
+
+Use --allow-from to only accept the token from some networks, and --pin-key to
+only accept it from addresses an SSH key of yours logged in from in the last
+24 hours. Without a value, --pin-key pins the key of this session, otherwise
+pass an authorized key with --pin-key="ssh-ed25519 AAAA...".`,
+		Args: cobra.MinimumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
 			be := backend.FromContext(ctx)
 			name := strings.Join(args, " ")
 
 			user := proto.UserFromContext(ctx)
+			if createUser != "" {
+				if err := checkIfAdmin(cmd, nil); err != nil {
+					return err
+				}
+
+				bot, err := be.User(ctx, createUser)
+				if err != nil {
+					return err
+				}
+				if !bot.IsBot() {
+					return fmt.Errorf("%s is not a bot user", bot.Username())
+				}
+				user = bot
+			}
 			if user == nil {
 				return proto.ErrUserNotFound
 			}
@@ -48,7 +75,29 @@ func TokenCommand() *cobra.Command {
 				expiresAt = time.Now().Add(d)
 			}
 
-			token, err := be.CreateAccessToken(ctx, user, name, expiresAt)
+			var pinnedKey ssh.PublicKey
+			switch createPinKey {
+			case "":
+			case "current":
+				pinnedKey = sshutils.PublicKeyFromContext(ctx)
+				if pinnedKey == nil {
+					return fmt.Errorf("no key to pin, pass one to --pin-key")
+				}
+			default:
+				pk, _, err := sshutils.ParseAuthorizedKey(createPinKey)
+				if err != nil {
+					return err
+				}
+				pinnedKey = pk
+			}
+
+			token, err := be.CreateAccessToken(ctx, user, name, proto.AccessTokenOptions{
+				ExpiresAt:    expiresAt,
+				Scope:        createScope,
+				ScopeRepo:    createRepo,
+				AllowedCIDRs: createAllowFrom,
+				PinnedKey:    pinnedKey,
+			})
 			if err != nil {
 				return err
 			}
@@ -66,6 +115,12 @@ func TokenCommand() *cobra.Command {
 	}
 
 	createCmd.Flags().StringVar(&createExpiresIn, "expires-in", "", "Token expiration time (e.g. 1y, 3mo, 2w, 5d4h, 1h30m)")
+	createCmd.Flags().StringVar(&createScope, "scope", "", "Highest access level the token grants (read-only, read-write, admin-access)")
+	createCmd.Flags().StringVar(&createRepo, "repo", "", "Only grant access to this repository")
+	createCmd.Flags().StringVar(&createUser, "user", "", "Create the token for this bot user")
+	createCmd.Flags().StringSliceVar(&createAllowFrom, "allow-from", nil, "Only accept the token from these networks (e.g. 10.0.0.0/8,192.168.1.2)")
+	createCmd.Flags().StringVar(&createPinKey, "pin-key", "", "Only accept the token from where this SSH key recently logged in")
+	createCmd.Flags().Lookup("pin-key").NoOptDefVal = "current"
 
 	listCmd := &cobra.Command{
 		Use:     "list",
@@ -74,6 +129,7 @@ func TokenCommand() *cobra.Command {
 		Args:    cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			ctx := cmd.Context()
+			dates := userDates(cmd)
 			be := backend.FromContext(ctx)
 
 			user := proto.UserFromContext(ctx)
@@ -92,7 +148,7 @@ func TokenCommand() *cobra.Command {
 			}
 
 			now := time.Now()
-			table := table.New().Headers("ID", "Name", "Created At", "Expires In")
+			table := table.New().Headers("ID", "Name", "Created At", "Expires In", "Last Used", "Scope", "Pins")
 			for _, token := range tokens {
 				expiresAt := "-"
 				if !token.ExpiresAt.IsZero() {
@@ -105,8 +161,11 @@ func TokenCommand() *cobra.Command {
 
 				table = table.Row(strconv.FormatInt(token.ID, 10),
 					token.Name,
-					humanize.Time(token.CreatedAt),
+					dates.Relative(token.CreatedAt),
 					expiresAt,
+					lastUsed(token),
+					tokenScope(token),
+					tokenPins(token),
 				)
 			}
 			cmd.Println(table)
@@ -142,11 +201,86 @@ func TokenCommand() *cobra.Command {
 		},
 	}
 
+	var auditStale string
+	var auditAll bool
+	auditCmd := &cobra.Command{
+		Use:               "audit",
+		Short:             "Find stale or over-privileged access tokens",
+		Long:              "List the access tokens of all users that are expired, unused for a while, never expire, or belong to an admin.",
+		Args:              cobra.NoArgs,
+		PersistentPreRunE: checkIfAdmin,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+
+			staleAfter, err := duration.Parse(auditStale)
+			if err != nil {
+				return err
+			}
+
+			audits, err := be.AuditAccessTokens(ctx, staleAfter, auditAll, time.Now())
+			if err != nil {
+				return err
+			}
+
+			if len(audits) == 0 {
+				cmd.Println("No tokens found")
+				return nil
+			}
+
+			table := table.New().Headers("ID", "User", "Name", "Last Used", "From", "Findings")
+			for _, a := range audits {
+				table = table.Row(strconv.FormatInt(a.Token.ID, 10),
+					a.Username,
+					a.Token.Name,
+					lastUsed(a.Token),
+					a.Token.LastUsedIP,
+					strings.Join(a.Findings, ", "),
+				)
+			}
+			cmd.Println(table)
+			return nil
+		},
+	}
+
+	auditCmd.Flags().StringVar(&auditStale, "stale", "90d", "Report tokens unused for this long (e.g. 1y, 3mo, 2w, 5d4h)")
+	auditCmd.Flags().BoolVarP(&auditAll, "all", "a", false, "List every token")
+
 	cmd.AddCommand(
 		createCmd,
 		listCmd,
 		deleteCmd,
+		auditCmd,
 	)
 
 	return cmd
 }
+
+func tokenScope(token proto.AccessToken) string {
+	scope := token.Scope
+	if scope == "" {
+		scope = "all"
+	}
+	if token.ScopeRepo != "" {
+		scope += " on " + token.ScopeRepo
+	}
+	return scope
+}
+
+func tokenPins(token proto.AccessToken) string {
+	pins := token.AllowedCIDRs
+	if token.PinnedKey != "" {
+		pins = append(pins, "key "+token.PinnedKey)
+	}
+	if len(pins) == 0 {
+		return "-"
+	}
+	return strings.Join(pins, ", ")
+}
+
+func lastUsed(token proto.AccessToken) string {
+	if token.LastUsedAt.IsZero() {
+		return "never"
+	}
+	return humanize.Time(token.LastUsedAt)
+}
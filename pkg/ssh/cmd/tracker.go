@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/tracker"
+	"github.com/spf13/cobra"
+)
+
+func trackerCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tracker",
+		Short: "Manage the repository external issue tracker",
+		Long: `Manage the repository external issue tracker.
+
+Issue keys of the linked tracker projects (e.g. PROJ-123) mentioned in issue
+and merge request titles, descriptions, and source branches are linked to the
+tracker. When a merge request merges, its linked tracker issues are moved to
+the done state.`,
+	}
+
+	cmd.AddCommand(
+		trackerSetCommand(),
+		trackerShowCommand(),
+		trackerDeleteCommand(),
+	)
+
+	return cmd
+}
+
+func trackerSetCommand() *cobra.Command {
+	var projects string
+	var username string
+	var token string
+	var doneState string
+	kinds := tracker.Kinds()
+	names := make([]string, len(kinds))
+	for i, k := range kinds {
+		names[i] = k.String()
+	}
+
+	cmd := &cobra.Command{
+		Use:               "set REPOSITORY KIND URL",
+		Short:             "Link the repository to an external issue tracker",
+		Long:              fmt.Sprintf("Link the repository to an external issue tracker.\n\nKIND is one of (%s).", strings.Join(names, ", ")),
+		Args:              cobra.ExactArgs(3),
+		PersistentPreRunE: checkIfAdmin,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+
+			kind, err := tracker.ParseKind(args[1])
+			if err != nil {
+				return err
+			}
+
+			ps, err := tracker.ParseProjects(projects)
+			if err != nil {
+				return err
+			}
+
+			return be.SetIssueTracker(ctx, args[0], tracker.Tracker{
+				Kind:      kind,
+				URL:       strings.TrimSpace(args[2]),
+				Projects:  ps,
+				Username:  username,
+				Token:     token,
+				DoneState: doneState,
+			})
+		},
+	}
+
+	cmd.Flags().StringVarP(&projects, "projects", "p", "", "comma separated tracker project keys, e.g. PROJ,OPS")
+	cmd.Flags().StringVarP(&username, "username", "u", "", "account name for basic authentication, e.g. the Jira Cloud account email")
+	cmd.Flags().StringVarP(&token, "token", "t", "", "API token, sent as a bearer token when no username is set")
+	cmd.Flags().StringVar(&doneState, "done-state", tracker.DefaultDoneState, "state to move linked issues to when a merge request merges, empty to disable")
+	cmd.MarkFlagRequired("projects") // nolint: errcheck
+
+	return cmd
+}
+
+func trackerShowCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "show REPOSITORY",
+		Short:             "Show the repository external issue tracker",
+		Args:              cobra.ExactArgs(1),
+		PersistentPreRunE: checkIfAdmin,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+
+			t, err := be.IssueTracker(ctx, args[0])
+			if err != nil {
+				return err
+			}
+
+			cmd.Printf("Kind: %s\n", t.Kind)
+			cmd.Printf("URL: %s\n", t.URL)
+			cmd.Printf("Projects: %s\n", strings.Join(t.Projects, ","))
+			if t.Username != "" {
+				cmd.Printf("Username: %s\n", t.Username)
+			}
+			cmd.Printf("Token: %t\n", t.Token != "")
+			cmd.Printf("Done State: %s\n", t.DoneState)
+
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func trackerDeleteCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "delete REPOSITORY",
+		Short:             "Unlink the repository from its external issue tracker",
+		Args:              cobra.ExactArgs(1),
+		PersistentPreRunE: checkIfAdmin,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+
+			return be.DeleteIssueTracker(ctx, args[0])
+		},
+	}
+
+	return cmd
+}
+
+// printTrackerLinks prints the external tracker issues linked from an issue or
+// merge request.
+func printTrackerLinks(cmd *cobra.Command, links []tracker.Link) {
+	if len(links) == 0 {
+		return
+	}
+
+	cmd.Printf("\nLinked tracker issues:\n")
+	for _, l := range links {
+		cmd.Printf("  %s - %s\n", l.Key, l.URL)
+	}
+}
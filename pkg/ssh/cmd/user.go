@@ -19,7 +19,7 @@ func UserCommand() *cobra.Command {
 		Short:   "Manage users",
 	}
 
-	var admin bool
+	var admin, bot bool
 	var key string
 	userCreateCommand := &cobra.Command{
 		Use:               "create USERNAME",
@@ -42,6 +42,7 @@ func UserCommand() *cobra.Command {
 
 			opts := proto.UserOptions{
 				Admin:      admin,
+				Bot:        bot,
 				PublicKeys: pubkeys,
 			}
 
@@ -52,6 +53,7 @@ func UserCommand() *cobra.Command {
 
 	userCreateCommand.Flags().BoolVarP(&admin, "admin", "a", false, "make the user an admin")
 	userCreateCommand.Flags().StringVarP(&key, "key", "k", "", "add a public key to the user")
+	userCreateCommand.Flags().BoolVar(&bot, "bot", false, "make the user a bot, for automation")
 
 	userDeleteCommand := &cobra.Command{
 		Use:               "delete USERNAME",
@@ -67,6 +69,7 @@ func UserCommand() *cobra.Command {
 		},
 	}
 
+	var bots bool
 	userListCommand := &cobra.Command{
 		Use:               "list",
 		Aliases:           []string{"ls"},
@@ -76,7 +79,11 @@ func UserCommand() *cobra.Command {
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			ctx := cmd.Context()
 			be := backend.FromContext(ctx)
-			users, err := be.Users(ctx)
+			list := be.People
+			if bots {
+				list = be.Bots
+			}
+			users, err := list(ctx)
 			if err != nil {
 				return err
 			}
@@ -90,6 +97,8 @@ func UserCommand() *cobra.Command {
 		},
 	}
 
+	userListCommand.Flags().BoolVar(&bots, "bots", false, "list bot users instead of people")
+
 	userAddPubkeyCommand := &cobra.Command{
 		Use:               "add-pubkey USERNAME AUTHORIZED_KEY",
 		Short:             "Add a public key to a user",
@@ -161,6 +170,7 @@ func UserCommand() *cobra.Command {
 
 			cmd.Printf("Username: %s\n", user.Username())
 			cmd.Printf("Admin: %t\n", isAdmin)
+			cmd.Printf("Bot: %t\n", user.IsBot())
 			cmd.Printf("Public keys:\n")
 			for _, pk := range user.PublicKeys() {
 				cmd.Printf("  %s\n", sshutils.MarshalAuthorizedKey(pk))
@@ -194,6 +204,9 @@ func UserCommand() *cobra.Command {
 		userRemovePubkeyCommand,
 		userSetAdminCommand,
 		userSetUsernameCommand,
+		userSessionsCommand(),
+		userExportDataCommand(),
+		userDeleteAccountCommand(),
 	)
 
 	return cmd
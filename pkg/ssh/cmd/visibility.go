@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/spf13/cobra"
+)
+
+func visibilityCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "visibility REPOSITORY [public|internal|private]",
+		Short: "Set or get a repository visibility",
+		Long: `Set or get a repository visibility.
+
+Public repositories are readable by anyone, internal repositories by any
+authenticated user, and private repositories by collaborators only.`,
+		Args:              cobra.RangeArgs(1, 2),
+		PersistentPreRunE: checkIfReadable,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			rn := strings.TrimSuffix(args[0], ".git")
+
+			switch len(args) {
+			case 1:
+				r, err := be.Repository(ctx, rn)
+				if err != nil {
+					return err
+				}
+
+				cmd.Println(proto.RepositoryVisibility(r))
+			case 2:
+				visibility, err := proto.ParseVisibility(args[1])
+				if err != nil {
+					return err
+				}
+				if err := checkIfCollab(cmd, args); err != nil {
+					return err
+				}
+				if err := be.SetVisibility(ctx, rn, visibility); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+
+	return cmd
+}
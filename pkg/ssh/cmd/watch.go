@@ -0,0 +1,189 @@
+package cmd
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss/v2/table"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/spf13/cobra"
+)
+
+func watchCommand() *cobra.Command {
+	var level string
+
+	cmd := &cobra.Command{
+		Use:   "watch REPOSITORY",
+		Short: "Get or set how much of a repository you are notified about",
+		Long: `Get or set how much of a repository you are notified about.
+
+Levels:
+  all            notify about all issue and merge request activity
+  participating  notify about the issues and merge requests you authored,
+                 review, or closed (default)
+  ignore         never notify
+
+Notifications are listed with "notifications".`,
+		Args:              cobra.ExactArgs(1),
+		PersistentPreRunE: checkIfReadable,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+			repo := args[0]
+
+			user := proto.UserFromContext(ctx)
+			if user == nil {
+				return proto.ErrUserNotFound
+			}
+
+			if !cmd.Flags().Changed("level") {
+				l, err := be.RepoWatchLevel(ctx, repo, user)
+				if err != nil {
+					return err
+				}
+
+				cmd.Println(l)
+				return nil
+			}
+
+			l, err := backend.ParseWatchLevel(level)
+			if err != nil {
+				return err
+			}
+
+			return be.SetRepoWatchLevel(ctx, repo, user, l)
+		},
+	}
+
+	cmd.Flags().StringVarP(&level, "level", "l", "", "watch level (all, participating, ignore)")
+
+	return cmd
+}
+
+// NotificationsCommand returns a command that lists the user's notifications.
+func NotificationsCommand() *cobra.Command {
+	var all bool
+
+	cmd := &cobra.Command{
+		Use:     "notifications",
+		Aliases: []string{"notification", "notif"},
+		Short:   "List your notifications",
+		Long: `List your unread notifications, newest first.
+
+You are notified about the repositories you watch, see "repo watch", and
+about your access tokens that are about to expire.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			dates := userDates(cmd)
+			be := backend.FromContext(ctx)
+
+			user := proto.UserFromContext(ctx)
+			if user == nil {
+				return proto.ErrUserNotFound
+			}
+
+			ns, err := be.Notifications(ctx, user, all)
+			if err != nil {
+				return err
+			}
+
+			if len(ns) == 0 {
+				cmd.Println("No notifications")
+				return nil
+			}
+
+			repos, err := be.Repositories(ctx)
+			if err != nil {
+				return err
+			}
+			repoNames := make(map[int64]string, len(repos))
+			for _, r := range repos {
+				repoNames[r.ID()] = r.Name()
+			}
+
+			actors := map[int64]string{}
+			table := table.New().Headers("ID", "Repository", "Subject", "Title", "Action", "By", "When")
+			for _, n := range ns {
+				subject := "Issue #"
+				switch n.SubjectType {
+				case backend.NotificationSubjectMergeRequest:
+					subject = "MR #"
+				case backend.NotificationSubjectAccessToken:
+					subject = "Token #"
+				case backend.NotificationSubjectWebhook:
+					subject = "Webhook #"
+				case backend.NotificationSubjectRef:
+					subject = "Reflog #"
+				}
+				var actor string
+				if n.ActorID.Valid {
+					if _, ok := actors[n.ActorID.Int64]; !ok {
+						if u, err := be.UserByID(ctx, n.ActorID.Int64); err == nil {
+							actors[n.ActorID.Int64] = u.Username()
+						}
+					}
+					actor = actors[n.ActorID.Int64]
+				}
+				id := strconv.FormatInt(n.ID, 10)
+				if n.ReadAt.Valid {
+					id += " (read)"
+				}
+				table = table.Row(
+					id,
+					repoNames[n.RepoID.Int64],
+					subject+strconv.FormatInt(n.SubjectID, 10),
+					n.Title,
+					strings.ReplaceAll(n.Action, "_", " "),
+					actor,
+					dates.Relative(n.CreatedAt),
+				)
+			}
+			cmd.Println(table)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&all, "all", "a", false, "include read notifications")
+
+	readCmd := &cobra.Command{
+		Use:   "read [ID...]",
+		Short: "Mark notifications as read",
+		Long:  "Mark the given notifications as read, or all of them when no ID is given.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			be := backend.FromContext(ctx)
+
+			user := proto.UserFromContext(ctx)
+			if user == nil {
+				return proto.ErrUserNotFound
+			}
+
+			ids := make([]int64, len(args))
+			for i, arg := range args {
+				id, err := strconv.ParseInt(strings.TrimPrefix(arg, "#"), 10, 64)
+				if err != nil {
+					return err
+				}
+				ids[i] = id
+			}
+
+			return be.MarkNotificationsRead(ctx, user, ids...)
+		},
+	}
+
+	cmd.AddCommand(readCmd)
+
+	return cmd
+}
+
+// usernames returns the comma separated usernames of users.
+func usernames(users []proto.User) string {
+	names := make([]string, len(users))
+	for i, u := range users {
+		names[i] = u.Username()
+	}
+	return strings.Join(names, ", ")
+}
@@ -9,7 +9,6 @@ import (
 	"github.com/charmbracelet/soft-serve/pkg/backend"
 	"github.com/charmbracelet/soft-serve/pkg/utils"
 	"github.com/charmbracelet/soft-serve/pkg/webhook"
-	"github.com/dustin/go-humanize"
 	"github.com/google/uuid"
 	"github.com/spf13/cobra"
 )
@@ -50,6 +49,7 @@ func webhookListCommand() *cobra.Command {
 		PersistentPreRunE: checkIfAdmin,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
+			dates := userDates(cmd)
 			be := backend.FromContext(ctx)
 			repo, err := be.Repository(ctx, args[0])
 			if err != nil {
@@ -61,7 +61,7 @@ func webhookListCommand() *cobra.Command {
 				return err
 			}
 
-			table := table.New().Headers("ID", "URL", "Events", "Active", "Created At", "Updated At")
+			table := table.New().Headers("ID", "URL", "Events", "Format", "Active", "Created At", "Updated At")
 			for _, h := range webhooks {
 				events := make([]string, len(h.Events))
 				for i, e := range h.Events {
@@ -72,9 +72,10 @@ func webhookListCommand() *cobra.Command {
 					strconv.FormatInt(h.ID, 10),
 					utils.Sanitize(h.URL),
 					strings.Join(events, ","),
+					h.Format.String(),
 					strconv.FormatBool(h.Active),
-					humanize.Time(h.CreatedAt),
-					humanize.Time(h.UpdatedAt),
+					dates.Relative(h.CreatedAt),
+					dates.Relative(h.UpdatedAt),
 				)
 			}
 			cmd.Println(table)
@@ -90,6 +91,7 @@ func webhookCreateCommand() *cobra.Command {
 	var secret string
 	var active bool
 	var contentType string
+	var format string
 	cmd := &cobra.Command{
 		Use:               "create REPOSITORY URL",
 		Short:             "Create a repository webhook",
@@ -123,8 +125,13 @@ func webhookCreateCommand() *cobra.Command {
 				return webhook.ErrInvalidContentType
 			}
 
+			ft, err := webhook.ParseFormat(format)
+			if err != nil {
+				return err
+			}
+
 			url := utils.Sanitize(args[1])
-			return be.CreateWebhook(ctx, repo, strings.TrimSpace(url), ct, secret, evs, active)
+			return be.CreateWebhook(ctx, repo, strings.TrimSpace(url), ct, ft, secret, evs, active)
 		},
 	}
 
@@ -132,8 +139,9 @@ func webhookCreateCommand() *cobra.Command {
 	cmd.Flags().StringVarP(&secret, "secret", "s", "", "secret to sign the webhook payload")
 	cmd.Flags().BoolVarP(&active, "active", "a", true, "whether the webhook is active")
 	cmd.Flags().StringVarP(&contentType, "content-type", "c", "json", "content type of the webhook payload, can be either `json` or `form`")
+	cmd.Flags().StringVarP(&format, "format", "f", "softserve", "payload format of the webhook, can be either `softserve` or `github`")
 
-	return cmd
+	return withIdempotencyKey(cmd)
 }
 
 func webhookDeleteCommand() *cobra.Command {
@@ -167,6 +175,7 @@ func webhookUpdateCommand() *cobra.Command {
 	var secret string
 	var active string
 	var contentType string
+	var format string
 	var url string
 	cmd := &cobra.Command{
 		Use:               "update REPOSITORY WEBHOOK_ID",
@@ -225,6 +234,15 @@ func webhookUpdateCommand() *cobra.Command {
 				newContentType = ct
 			}
 
+			newFormat := wh.Format
+			if format != "" {
+				ft, err := webhook.ParseFormat(format)
+				if err != nil {
+					return err
+				}
+				newFormat = ft
+			}
+
 			newEvents := wh.Events
 			if len(events) > 0 {
 				var evs []webhook.Event
@@ -240,7 +258,7 @@ func webhookUpdateCommand() *cobra.Command {
 				newEvents = evs
 			}
 
-			return be.UpdateWebhook(ctx, repo, id, newURL, newContentType, newSecret, newEvents, newActive)
+			return be.UpdateWebhook(ctx, repo, id, newURL, newContentType, newFormat, newSecret, newEvents, newActive)
 		},
 	}
 
@@ -248,18 +266,28 @@ func webhookUpdateCommand() *cobra.Command {
 	cmd.Flags().StringVarP(&secret, "secret", "s", "", "secret to sign the webhook payload")
 	cmd.Flags().StringVarP(&active, "active", "a", "", "whether the webhook is active")
 	cmd.Flags().StringVarP(&contentType, "content-type", "c", "", "content type of the webhook payload, can be either `json` or `form`")
+	cmd.Flags().StringVarP(&format, "format", "f", "", "payload format of the webhook, can be either `softserve` or `github`")
 	cmd.Flags().StringVarP(&url, "url", "u", "", "webhook URL")
 
 	return cmd
 }
 
 func webhookDeliveriesCommand() *cobra.Command {
+	var failed bool
 	cmd := &cobra.Command{
-		Use:     "deliveries",
-		Short:   "Manage webhook deliveries",
-		Aliases: []string{"delivery", "deliver"},
+		Use:               "deliveries REPOSITORY WEBHOOK_ID",
+		Short:             "Manage webhook deliveries",
+		Long:              "Manage webhook deliveries. With arguments, list the deliveries of a webhook.",
+		Aliases:           []string{"delivery", "deliver"},
+		Args:              cobra.ExactArgs(2),
+		PersistentPreRunE: checkIfAdmin,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return listWebhookDeliveries(cmd, args, failed)
+		},
 	}
 
+	cmd.Flags().BoolVar(&failed, "failed", false, "only list failed deliveries")
+
 	cmd.AddCommand(
 		webhookDeliveriesListCommand(),
 		webhookDeliveriesRedeliverCommand(),
@@ -270,45 +298,78 @@ func webhookDeliveriesCommand() *cobra.Command {
 }
 
 func webhookDeliveriesListCommand() *cobra.Command {
+	var failed bool
 	cmd := &cobra.Command{
 		Use:               "list REPOSITORY WEBHOOK_ID",
 		Short:             "List webhook deliveries",
 		Args:              cobra.ExactArgs(2),
 		PersistentPreRunE: checkIfAdmin,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			ctx := cmd.Context()
-			be := backend.FromContext(ctx)
-			id, err := strconv.ParseInt(args[1], 10, 64)
-			if err != nil {
-				return fmt.Errorf("invalid webhook ID: %w", err)
-			}
-
-			dels, err := be.ListWebhookDeliveries(ctx, id)
-			if err != nil {
-				return err
-			}
-
-			table := table.New().Headers("Status", "ID", "Event", "Created At")
-			for _, d := range dels {
-				status := "❌"
-				if d.ResponseStatus >= 200 && d.ResponseStatus < 300 {
-					status = "✅"
-				}
-				table = table.Row(
-					status,
-					d.ID.String(),
-					d.Event.String(),
-					humanize.Time(d.CreatedAt),
-				)
-			}
-			cmd.Println(table)
-			return nil
+			return listWebhookDeliveries(cmd, args, failed)
 		},
 	}
 
+	cmd.Flags().BoolVar(&failed, "failed", false, "only list failed deliveries")
+
 	return cmd
 }
 
+// listWebhookDeliveries lists the deliveries of the webhook args[1] of
+// repository args[0], newest first.
+func listWebhookDeliveries(cmd *cobra.Command, args []string, failed bool) error {
+	ctx := cmd.Context()
+	dates := userDates(cmd)
+	be := backend.FromContext(ctx)
+	repo, err := be.Repository(ctx, args[0])
+	if err != nil {
+		return err
+	}
+
+	id, err := strconv.ParseInt(args[1], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid webhook ID: %w", err)
+	}
+
+	wh, err := be.Webhook(ctx, repo, id)
+	if err != nil {
+		return err
+	}
+
+	dels, err := be.ListWebhookDeliveries(ctx, wh.ID)
+	if err != nil {
+		return err
+	}
+
+	if wh.Failures >= webhook.FailureThreshold {
+		cmd.PrintErrf("Webhook is failing, the last %d deliveries failed.\n", wh.Failures)
+	}
+
+	table := table.New().Headers("Status", "ID", "Event", "Response", "Created At")
+	for _, d := range dels {
+		ok := webhook.Succeeded(d.ResponseStatus, nil)
+		if failed && ok {
+			continue
+		}
+		status := "❌"
+		if ok {
+			status = "✅"
+		}
+		response := "-"
+		if d.ResponseStatus != 0 {
+			response = strconv.Itoa(d.ResponseStatus)
+		}
+		table = table.Row(
+			status,
+			d.ID.String(),
+			d.Event.String(),
+			response,
+			dates.Relative(d.CreatedAt),
+		)
+	}
+	cmd.Println(table)
+	return nil
+}
+
 func webhookDeliveriesRedeliverCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:               "redeliver REPOSITORY WEBHOOK_ID DELIVERY_ID",
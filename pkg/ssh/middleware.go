@@ -63,6 +63,8 @@ func ContextMiddleware(cfg *config.Config, dbx *db.DB, datastore store.Store, be
 			ctx.SetValue(store.ContextKey, datastore)
 			ctx.SetValue(backend.ContextKey, be)
 			ctx.SetValue(log.ContextKey, logger.WithPrefix("ssh"))
+			// Let tokens pinned to this key be used from this address.
+			be.RecordKeyLogin(s.PublicKey(), s.RemoteAddr())
 			sh(s)
 		}
 	}
@@ -104,12 +106,22 @@ func CommandMiddleware(sh ssh.Handler) ssh.Handler {
 			cmd.GitReceivePackCommand(),
 			cmd.RepoCommand(),
 			cmd.SettingsCommand(),
+			cmd.AuditCommand(),
+			cmd.AdminCommand(),
 			cmd.UserCommand(),
 			cmd.InfoCommand(),
+			cmd.ProfileCommand(),
 			cmd.PubkeyCommand(),
 			cmd.SetUsernameCommand(),
 			cmd.JWTCommand(),
 			cmd.TokenCommand(),
+			cmd.OAuthCommand(),
+			cmd.ChatCommand(),
+			cmd.ReviewCommand(),
+			cmd.SearchCommand(),
+			cmd.NewsCommand(),
+			cmd.PreferencesCommand(),
+			cmd.NotificationsCommand(),
 		)
 
 		if cfg.LFS.Enabled {
@@ -124,6 +136,9 @@ func CommandMiddleware(sh ssh.Handler) ssh.Handler {
 			}
 		}
 
+		cmd.WithPolicy(rootCmd)
+		cmd.WithDateFlags(rootCmd)
+
 		rootCmd.SetArgs(args)
 		if len(args) == 0 {
 			// otherwise it'll default to os.Args, which is not what we want.
@@ -1,6 +1,7 @@
 package ssh
 
 import (
+	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea/v2"
@@ -41,10 +42,20 @@ func SessionHandler(s ssh.Session) *tea.Program {
 	ctx := s.Context()
 	be := backend.FromContext(ctx)
 	cfg := config.FromContext(ctx)
+	// Bots are for automation, they can't use the TUI.
+	if user := proto.UserFromContext(ctx); user != nil && user.IsBot() {
+		wish.Fatalln(s, proto.ErrBotUser)
+		return nil
+	}
+
 	cmd := s.Command()
 	initialRepo := ""
 	if len(cmd) == 1 {
 		initialRepo = cmd[0]
+	}
+	// "@NAME" opens the profile of a user or an organization instead of a
+	// repository.
+	if initialRepo != "" && !strings.HasPrefix(initialRepo, "@") {
 		auth := be.AccessLevelByPublicKey(ctx, initialRepo, s.PublicKey())
 		if auth < access.ReadOnlyAccess {
 			wish.Fatalln(s, proto.ErrUnauthorized)
@@ -63,14 +74,22 @@ func SessionHandler(s ssh.Session) *tea.Program {
 
 	c := common.NewCommon(ctx, pty.Window.Width, pty.Window.Height)
 	c.SetValue(common.ConfigKey, cfg)
+	c.Dates = be.UserDates(ctx, proto.UserFromContext(ctx))
 	m := NewUI(c, initialRepo)
 	p := tea.NewProgram(m, opts...)
 
 	tuiSessionCounter.WithLabelValues(initialRepo, pty.Term).Inc()
 
+	// Revoking the session quits the TUI and closes the connection.
+	endSession := be.StartSession(proto.UserFromContext(ctx), s.RemoteAddr().String(), func() {
+		p.Kill()
+		s.Close() // nolint: errcheck
+	})
+
 	start := time.Now()
 	go func() {
 		<-ctx.Done()
+		endSession()
 		tuiSessionDuration.WithLabelValues(initialRepo, pty.Term).Add(time.Since(start).Seconds())
 	}()
 
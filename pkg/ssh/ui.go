@@ -2,12 +2,14 @@ package ssh
 
 import (
 	"errors"
+	"strings"
 
 	"github.com/charmbracelet/bubbles/v2/key"
 	"github.com/charmbracelet/bubbles/v2/list"
 	tea "github.com/charmbracelet/bubbletea/v2"
 	"github.com/charmbracelet/lipgloss/v2"
 	"github.com/charmbracelet/soft-serve/git"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
 	"github.com/charmbracelet/soft-serve/pkg/proto"
 	"github.com/charmbracelet/soft-serve/pkg/ui/common"
 	"github.com/charmbracelet/soft-serve/pkg/ui/components/footer"
@@ -15,6 +17,7 @@ import (
 	"github.com/charmbracelet/soft-serve/pkg/ui/components/selector"
 	"github.com/charmbracelet/soft-serve/pkg/ui/pages/repo"
 	"github.com/charmbracelet/soft-serve/pkg/ui/pages/selection"
+	"github.com/charmbracelet/soft-serve/pkg/ui/pages/switcher"
 )
 
 type page int
@@ -43,6 +46,7 @@ type UI struct {
 	header      *header.Header
 	footer      *footer.Footer
 	showFooter  bool
+	switcher    *switcher.Switcher
 	error       error
 }
 
@@ -59,6 +63,7 @@ func NewUI(c common.Common, initialRepo string) *UI {
 		header:      h,
 		initialRepo: initialRepo,
 		showFooter:  true,
+		switcher:    switcher.New(c),
 	}
 	ui.footer = footer.New(c, ui)
 	return ui
@@ -85,6 +90,9 @@ func (ui *UI) getMargins() (wm, hm int) {
 
 // ShortHelp implements help.KeyMap.
 func (ui *UI) ShortHelp() []key.Binding {
+	if ui.switcher.Active() {
+		return ui.switcher.ShortHelp()
+	}
 	b := make([]key.Binding, 0)
 	switch ui.state {
 	case errorState:
@@ -101,6 +109,9 @@ func (ui *UI) ShortHelp() []key.Binding {
 
 // FullHelp implements help.KeyMap.
 func (ui *UI) FullHelp() [][]key.Binding {
+	if ui.switcher.Active() {
+		return ui.switcher.FullHelp()
+	}
 	b := make([][]key.Binding, 0)
 	switch ui.state {
 	case errorState:
@@ -124,6 +135,7 @@ func (ui *UI) SetSize(width, height int) {
 	wm, hm := ui.getMargins()
 	ui.header.SetSize(width-wm, height-hm)
 	ui.footer.SetSize(width-wm, height-hm)
+	ui.switcher.SetSize(width-wm, height-hm)
 	for _, p := range ui.pages {
 		if p != nil {
 			p.SetSize(width-wm, height-hm)
@@ -133,7 +145,11 @@ func (ui *UI) SetSize(width, height int) {
 
 // Init implements tea.Model.
 func (ui *UI) Init() tea.Cmd {
-	ui.pages[selectionPage] = selection.New(ui.common)
+	sel := selection.New(ui.common)
+	if name, ok := strings.CutPrefix(ui.initialRepo, "@"); ok {
+		sel.SetProfileName(name)
+	}
+	ui.pages[selectionPage] = sel
 	ui.pages[repoPage] = repo.New(ui.common,
 		repo.NewReadme(ui.common),
 		repo.NewFiles(ui.common),
@@ -149,7 +165,7 @@ func (ui *UI) Init() tea.Cmd {
 		ui.pages[selectionPage].Init(),
 		ui.pages[repoPage].Init(),
 	)
-	if ui.initialRepo != "" {
+	if ui.initialRepo != "" && !strings.HasPrefix(ui.initialRepo, "@") {
 		cmds = append(cmds, ui.initialRepoCmd(ui.initialRepo))
 	}
 	ui.state = readyState
@@ -171,6 +187,17 @@ func (ui *UI) IsFiltering() bool {
 func (ui *UI) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	ui.common.Logger.Debugf("msg received: %T", msg)
 	cmds := make([]tea.Cmd, 0)
+
+	// The quick switcher takes over the keyboard while it's open.
+	if msg, ok := msg.(tea.KeyPressMsg); ok && ui.switcher.Active() {
+		if msg.String() == "ctrl+c" {
+			ui.common.Zone.Close()
+			return ui, tea.Quit
+		}
+		_, cmd := ui.switcher.Update(msg)
+		return ui, cmd
+	}
+
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		ui.SetSize(msg.Width, msg.Height)
@@ -188,6 +215,9 @@ func (ui *UI) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			ui.state = readyState
 			// Always show the footer on error.
 			ui.showFooter = ui.footer.ShowAll()
+		case key.Matches(msg, ui.common.KeyMap.QuickSwitch) &&
+			ui.state == readyState && !ui.IsFiltering():
+			return ui, ui.switcher.Open()
 		case key.Matches(msg, ui.common.KeyMap.Help):
 			cmds = append(cmds, footer.ToggleFooterCmd)
 		case key.Matches(msg, ui.common.KeyMap.Quit):
@@ -222,17 +252,30 @@ func (ui *UI) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		ui.activePage = repoPage
 		// Show the footer on repo page if show all is set.
 		ui.showFooter = ui.footer.ShowAll()
-		cmds = append(cmds, repo.UpdateRefCmd(msg))
+		cmds = append(cmds, repo.UpdateRefCmd(msg), ui.recordVisitCmd(msg.Name()))
+	case switcher.ResultsMsg:
+		_, cmd := ui.switcher.Update(msg)
+		return ui, cmd
+	case switcher.SelectMsg:
+		cmds = append(cmds, ui.switchCmd(backend.SwitcherResult(msg)))
 	case common.ErrorMsg:
 		ui.error = msg
 		ui.state = errorState
 		ui.showFooter = true
 	case selector.SelectMsg:
-		switch msg.IdentifiableItem.(type) {
+		switch item := msg.IdentifiableItem.(type) {
 		case selection.Item:
 			if ui.activePage == selectionPage {
 				cmds = append(cmds, ui.setRepoCmd(msg.ID()))
 			}
+		case selection.ReviewItem:
+			if ui.activePage == selectionPage {
+				cmds = append(cmds, ui.setRepoCmd(item.Repo))
+			}
+		case selection.NewsItem:
+			if ui.activePage == selectionPage {
+				cmds = append(cmds, ui.setRepoCmd(item.Repo))
+			}
 		}
 	}
 	h, cmd := ui.header.Update(msg)
@@ -276,7 +319,11 @@ func (ui *UI) View() string {
 				ui.common.Styles.Error.GetVerticalFrameSize()).
 			Render(err)
 	case readyState:
-		view = ui.pages[ui.activePage].View()
+		if ui.switcher.Active() {
+			view = ui.switcher.View()
+		} else {
+			view = ui.pages[ui.activePage].View()
+		}
 	default:
 		view = "Unknown state :/ this is a bug!"
 	}
@@ -322,6 +369,51 @@ func (ui *UI) setRepoCmd(rn string) tea.Cmd {
 	}
 }
 
+// recordVisitCmd records that the user visited a repository, so it shows up
+// in their recent repositories.
+func (ui *UI) recordVisitCmd(rn string) tea.Cmd {
+	return func() tea.Msg {
+		ctx := ui.common.Context()
+		be := ui.common.Backend()
+		pk := ui.common.PublicKey()
+		if pk == nil {
+			return nil
+		}
+		user, err := be.UserByPublicKey(ctx, pk)
+		if err != nil {
+			return nil
+		}
+		if err := be.RecordRepoVisit(ctx, rn, user); err != nil {
+			ui.common.Logger.Debugf("ui: failed to record visit of %s: %v", rn, err)
+		}
+		return nil
+	}
+}
+
+// switchCmd opens the repository of a quick switcher result and jumps to it.
+func (ui *UI) switchCmd(res backend.SwitcherResult) tea.Cmd {
+	if res.Repository == nil {
+		return nil
+	}
+	var msg repo.GotoMsg
+	switch res.Kind {
+	case backend.SwitcherIssue:
+		msg.IssueID = res.ID
+	case backend.SwitcherMergeRequest:
+		msg.MergeRequestID = res.ID
+	case backend.SwitcherBranch:
+		msg.Branch = res.Name
+	case backend.SwitcherFile:
+		msg.Path = res.Name
+	default:
+		return ui.setRepoCmd(res.Repository.Name())
+	}
+	return tea.Sequence(
+		ui.setRepoCmd(res.Repository.Name()),
+		func() tea.Msg { return msg },
+	)
+}
+
 func (ui *UI) initialRepoCmd(rn string) tea.Cmd {
 	return func() tea.Msg {
 		r, err := ui.openRepo(rn)
@@ -0,0 +1,173 @@
+package storage
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// ErrVolumeNotFound is returned when a repository is on a volume that isn't
+// configured.
+var ErrVolumeNotFound = errors.New("storage volume not found")
+
+// Location is where a repository is stored: a volume and the path of the
+// repository relative to the volume, using forward slashes.
+type Location struct {
+	Volume string
+	Path   string
+}
+
+// String returns the location as "volume:path".
+func (l Location) String() string {
+	return l.Volume + ":" + l.Path
+}
+
+// RepoStorage is an interface for storing Git repositories. Git works on
+// directories, so storages map repository locations to directories, on local
+// disks or on network and object stores mounted on the server.
+type RepoStorage interface {
+	// Volumes returns the volumes repositories can be stored on.
+	Volumes() []string
+	// Place returns the location of the repository name on volume.
+	Place(volume, name string) Location
+	// Dir returns the directory of the repository at loc.
+	Dir(loc Location) (string, error)
+	// List returns the locations of the repositories stored on volume.
+	List(volume string) ([]Location, error)
+	// Name returns the name of the repository at loc, the reverse of Place.
+	Name(loc Location) string
+}
+
+// FSRepoStorage is a repository storage on mounted filesystems. Each volume is
+// a directory, and repositories can be sharded into nested directories named
+// after the hash of their names to keep directories small.
+type FSRepoStorage struct {
+	root        string
+	volumes     []string
+	shardLevels int
+}
+
+var _ RepoStorage = (*FSRepoStorage)(nil)
+
+// NewFSRepoStorage creates a new FSRepoStorage. Volumes are directories,
+// relative to root unless absolute, and shardLevels is the number of shard
+// directories repositories are nested in.
+func NewFSRepoStorage(root string, volumes []string, shardLevels int) *FSRepoStorage {
+	return &FSRepoStorage{
+		root:        root,
+		volumes:     volumes,
+		shardLevels: shardLevels,
+	}
+}
+
+// Volumes implements RepoStorage.
+func (s *FSRepoStorage) Volumes() []string {
+	return s.volumes
+}
+
+// Place implements RepoStorage.
+func (s *FSRepoStorage) Place(volume, name string) Location {
+	return Location{Volume: volume, Path: path.Join(s.shardPrefix(name, s.shardLevels), name+".git")}
+}
+
+// volumeDir returns the directory of volume.
+func (s *FSRepoStorage) volumeDir(volume string) (string, error) {
+	for _, v := range s.volumes {
+		if v != volume {
+			continue
+		}
+		if !filepath.IsAbs(v) {
+			v = filepath.Join(s.root, v)
+		}
+		return v, nil
+	}
+	return "", fmt.Errorf("%w: %q", ErrVolumeNotFound, volume)
+}
+
+// Dir implements RepoStorage.
+func (s *FSRepoStorage) Dir(loc Location) (string, error) {
+	root, err := s.volumeDir(loc.Volume)
+	if err != nil {
+		return "", err
+	}
+
+	dir := filepath.Join(root, filepath.FromSlash(loc.Path))
+	if rel, err := filepath.Rel(root, dir); err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+		return "", fmt.Errorf("repository location %s is outside of its volume", loc)
+	}
+
+	return dir, nil
+}
+
+// List implements RepoStorage. Repositories are the directories named
+// "*.git" holding a HEAD file.
+func (s *FSRepoStorage) List(volume string) ([]Location, error) {
+	root, err := s.volumeDir(volume)
+	if err != nil {
+		return nil, err
+	}
+
+	var locs []Location
+	err = filepath.WalkDir(root, func(p string, e fs.DirEntry, err error) error {
+		if err != nil {
+			if p == root && errors.Is(err, fs.ErrNotExist) {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if !e.IsDir() || p == root || !strings.HasSuffix(e.Name(), ".git") {
+			return nil
+		}
+		if _, err := os.Stat(filepath.Join(p, "HEAD")); err != nil {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		locs = append(locs, Location{Volume: volume, Path: filepath.ToSlash(rel)})
+		return filepath.SkipDir
+	})
+	return locs, err
+}
+
+// Name implements RepoStorage. Shard directories are left out when they match
+// the name, so repositories placed with other shard levels keep their names
+// too.
+func (s *FSRepoStorage) Name(loc Location) string {
+	parts := strings.Split(strings.TrimSuffix(loc.Path, ".git"), "/")
+	sharded := func(levels int) bool {
+		return levels > 0 && levels < len(parts) &&
+			s.shardPrefix(path.Join(parts[levels:]...), levels) == path.Join(parts[:levels]...)
+	}
+	if sharded(s.shardLevels) {
+		return path.Join(parts[s.shardLevels:]...)
+	}
+	for levels := len(parts) - 1; levels > 0; levels-- {
+		if sharded(levels) {
+			return path.Join(parts[levels:]...)
+		}
+	}
+	return path.Join(parts...)
+}
+
+// shardPrefix returns the levels shard directories of the repository name.
+func (s *FSRepoStorage) shardPrefix(name string, levels int) string {
+	sum := sha256.Sum256([]byte(name))
+	hash := hex.EncodeToString(sum[:])
+	if levels > len(hash)/2 {
+		return ""
+	}
+	parts := make([]string, 0, levels)
+	for i := 0; i < levels; i++ {
+		parts = append(parts, hash[i*2:i*2+2])
+	}
+	return path.Join(parts...)
+}
@@ -0,0 +1,83 @@
+package storage
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFSRepoStorage(t *testing.T) {
+	root := t.TempDir()
+	abs := t.TempDir()
+	s := NewFSRepoStorage(root, []string{"repos", abs}, 2)
+
+	loc := s.Place("repos", "org/repo1")
+	if loc.Path != "53/b9/org/repo1.git" {
+		t.Fatalf("Place() = %s, want sharded path", loc)
+	}
+	dir, err := s.Dir(loc)
+	if err != nil || dir != filepath.Join(root, "repos", "53", "b9", "org", "repo1.git") {
+		t.Fatalf("Dir() = %q, %v", dir, err)
+	}
+
+	dir, err = s.Dir(Location{Volume: abs, Path: "repo1.git"})
+	if err != nil || dir != filepath.Join(abs, "repo1.git") {
+		t.Fatalf("Dir() = %q, %v", dir, err)
+	}
+
+	if _, err := s.Dir(Location{Volume: "other", Path: "repo1.git"}); !errors.Is(err, ErrVolumeNotFound) {
+		t.Fatalf("Dir() of an unknown volume = %v, want ErrVolumeNotFound", err)
+	}
+	for _, p := range []string{"", "../repo1.git", "a/../../repo1.git"} {
+		if _, err := s.Dir(Location{Volume: "repos", Path: p}); err == nil {
+			t.Fatalf("Dir() of %q is outside of the volume", p)
+		}
+	}
+}
+
+func TestFSRepoStorageList(t *testing.T) {
+	root := t.TempDir()
+	s := NewFSRepoStorage(root, []string{"repos", "empty"}, 2)
+
+	for _, loc := range []Location{
+		s.Place("repos", "org/repo1"),
+		{Volume: "repos", Path: "repo2.git"},
+	} {
+		dir, err := s.Dir(loc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.MkdirAll(filepath.Join(dir, "objects"), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "HEAD"), []byte("ref: refs/heads/main\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Not a repository.
+	if err := os.MkdirAll(filepath.Join(root, "repos", "junk.git"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	locs, err := s.List("repos")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(locs) != 2 || locs[0].Path != "53/b9/org/repo1.git" || locs[1].Path != "repo2.git" {
+		t.Fatalf("List() = %v", locs)
+	}
+	if name := s.Name(locs[0]); name != "org/repo1" {
+		t.Fatalf("Name() = %q, want org/repo1", name)
+	}
+	if name := s.Name(locs[1]); name != "repo2" {
+		t.Fatalf("Name() = %q, want repo2", name)
+	}
+	if name := NewFSRepoStorage(root, []string{"repos"}, 0).Name(locs[0]); name != "org/repo1" {
+		t.Fatalf("Name() with other shard levels = %q, want org/repo1", name)
+	}
+
+	if locs, err := s.List("empty"); err != nil || len(locs) != 0 {
+		t.Fatalf("List() of a missing volume = %v, %v", locs, err)
+	}
+}
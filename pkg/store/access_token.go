@@ -13,7 +13,13 @@ type AccessTokenStore interface {
 	GetAccessToken(ctx context.Context, h db.Handler, id int64) (models.AccessToken, error)
 	GetAccessTokenByToken(ctx context.Context, h db.Handler, token string) (models.AccessToken, error)
 	GetAccessTokensByUserID(ctx context.Context, h db.Handler, userID int64) ([]models.AccessToken, error)
+	GetAllAccessTokens(ctx context.Context, h db.Handler) ([]models.AccessToken, error)
+	GetAccessTokensToWarn(ctx context.Context, h db.Handler, from, to time.Time) ([]models.AccessToken, error)
 	CreateAccessToken(ctx context.Context, h db.Handler, name string, userID int64, token string, expiresAt time.Time) (models.AccessToken, error)
 	DeleteAccessToken(ctx context.Context, h db.Handler, id int64) error
 	DeleteAccessTokenForUser(ctx context.Context, h db.Handler, userID int64, id int64) error
+	TouchAccessToken(ctx context.Context, h db.Handler, id int64, ip string) error
+	SetAccessTokenExpiryWarned(ctx context.Context, h db.Handler, id int64) error
+	SetAccessTokenScope(ctx context.Context, h db.Handler, id int64, scope string, scopeRepo string) error
+	SetAccessTokenPins(ctx context.Context, h db.Handler, id int64, allowedCIDRs string, pinnedKey string) error
 }
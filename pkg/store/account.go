@@ -0,0 +1,30 @@
+package store
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+)
+
+// AccountStore is an interface for the content of user accounts across
+// repositories, for exporting and anonymizing it.
+type AccountStore interface {
+	// GetIssuesByAuthorID returns the issues a user opened in all the
+	// repositories, oldest first.
+	GetIssuesByAuthorID(ctx context.Context, h db.Handler, authorID int64) ([]models.Issue, error)
+	// GetMergeRequestsByAuthorID returns the merge requests a user opened in
+	// all the repositories, oldest first.
+	GetMergeRequestsByAuthorID(ctx context.Context, h db.Handler, authorID int64) ([]models.MergeRequest, error)
+	// GetMergeRequestCommentsByAuthorID returns the merge request comments a
+	// user made in all the repositories, oldest first.
+	GetMergeRequestCommentsByAuthorID(ctx context.Context, h db.Handler, authorID int64) ([]models.MergeRequestComment, error)
+	// GetAttachmentsByUserID returns the files a user attached in all the
+	// repositories, oldest first.
+	GetAttachmentsByUserID(ctx context.Context, h db.Handler, userID int64) ([]models.Attachment, error)
+	// ReassignUserContent makes another user the author of the issues,
+	// merge requests, comments, and attachments of a user, and the one who
+	// closed, merged, reviewed, or edited them, and returns the number of rows
+	// changed.
+	ReassignUserContent(ctx context.Context, h db.Handler, fromID int64, toID int64) (int64, error)
+}
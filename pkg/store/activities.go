@@ -0,0 +1,21 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+)
+
+// ActivityStore is an interface for managing the instance activity log.
+type ActivityStore interface {
+	// CreateActivity adds an activity to the log.
+	CreateActivity(ctx context.Context, h db.Handler, a models.Activity) error
+	// GetActivitiesByRepoIDs returns the latest activities of the given
+	// repositories with one of the given actions, newest first.
+	GetActivitiesByRepoIDs(ctx context.Context, h db.Handler, repoIDs []int64, actions []string, limit int) ([]models.Activity, error)
+	// GetActivityCounts returns the number of activities of a kind on each
+	// subject of the given repositories since a time, busiest first.
+	GetActivityCounts(ctx context.Context, h db.Handler, repoIDs []int64, kind string, since time.Time, limit int) ([]models.ActivityCount, error)
+}
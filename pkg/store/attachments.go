@@ -0,0 +1,26 @@
+package store
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+)
+
+// AttachmentStore is an interface for managing the files attached to issues
+// and merge requests.
+type AttachmentStore interface {
+	// CreateAttachment records a file attached to an issue or a merge
+	// request, and returns its ID.
+	CreateAttachment(ctx context.Context, h db.Handler, a models.Attachment) (int64, error)
+	// GetAttachment returns an attachment of a repository by its ID.
+	GetAttachment(ctx context.Context, h db.Handler, repoID int64, id int64) (models.Attachment, error)
+	// GetAttachments returns the files attached to an issue or a merge
+	// request, oldest first.
+	GetAttachments(ctx context.Context, h db.Handler, repoID int64, subjectType string, subjectID int64) ([]models.Attachment, error)
+	// CountAttachmentsByOid returns the number of attachments of a repository
+	// with the given content.
+	CountAttachmentsByOid(ctx context.Context, h db.Handler, repoID int64, oid string) (int64, error)
+	// DeleteAttachment deletes an attachment of a repository.
+	DeleteAttachment(ctx context.Context, h db.Handler, repoID int64, id int64) error
+}
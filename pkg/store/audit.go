@@ -0,0 +1,24 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+)
+
+// AuditStore is an interface for managing the audit log.
+type AuditStore interface {
+	// CreateAuditEvent adds an event to the audit log.
+	CreateAuditEvent(ctx context.Context, h db.Handler, e models.AuditEvent) error
+	// GetAuditEvents returns the latest events of the audit log, newest
+	// first.
+	GetAuditEvents(ctx context.Context, h db.Handler, limit int) ([]models.AuditEvent, error)
+	// GetAuditEventsByActionPrefix returns the latest events of the audit log
+	// whose action starts with prefix, newest first.
+	GetAuditEventsByActionPrefix(ctx context.Context, h db.Handler, prefix string, limit int) ([]models.AuditEvent, error)
+	// DeleteAuditEventsBefore deletes the audit events older than t, and
+	// returns how many were deleted.
+	DeleteAuditEventsBefore(ctx context.Context, h db.Handler, t time.Time) (int64, error)
+}
@@ -0,0 +1,31 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+)
+
+// ChatIdentityStore is an interface for managing chat identities linked to
+// users.
+type ChatIdentityStore interface {
+	// GetChatIdentity returns the chat identity of a chat account.
+	GetChatIdentity(ctx context.Context, h db.Handler, provider string, externalID string) (models.ChatIdentity, error)
+	// GetChatIdentitiesByUserID returns all chat identities of a user.
+	GetChatIdentitiesByUserID(ctx context.Context, h db.Handler, userID int64) ([]models.ChatIdentity, error)
+	// CreateChatIdentity links a chat account to a user.
+	CreateChatIdentity(ctx context.Context, h db.Handler, userID int64, provider string, externalID string) error
+	// DeleteChatIdentityForUser unlinks a chat account from a user.
+	DeleteChatIdentityForUser(ctx context.Context, h db.Handler, userID int64, provider string, externalID string) error
+
+	// CreateChatLinkCode creates a link code for a chat account.
+	CreateChatLinkCode(ctx context.Context, h db.Handler, code string, provider string, externalID string, expiresAt time.Time) error
+	// GetChatLinkCode returns a link code.
+	GetChatLinkCode(ctx context.Context, h db.Handler, code string) (models.ChatLinkCode, error)
+	// DeleteChatLinkCode deletes a link code.
+	DeleteChatLinkCode(ctx context.Context, h db.Handler, code string) error
+	// DeleteExpiredChatLinkCodes deletes link codes that expired before t.
+	DeleteExpiredChatLinkCodes(ctx context.Context, h db.Handler, t time.Time) error
+}
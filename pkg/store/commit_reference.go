@@ -0,0 +1,28 @@
+package store
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+)
+
+// CommitReferenceStore is an interface for managing the reference index of
+// repositories: the issues their commits reference, and the merge requests
+// that introduced them.
+type CommitReferenceStore interface {
+	// AddCommitIssueReference records a reference to an issue in the message
+	// of a commit. A commit closing the issue once keeps closing it.
+	AddCommitIssueReference(ctx context.Context, h db.Handler, repoID int64, sha string, issueID int64, closes bool) error
+	// GetCommitIssueReferences returns the references to issues in the
+	// message of a commit.
+	GetCommitIssueReferences(ctx context.Context, h db.Handler, repoID int64, sha string) ([]models.CommitIssueReference, error)
+	// GetIssueCommitReferences returns the references to an issue in the
+	// messages of commits, oldest first.
+	GetIssueCommitReferences(ctx context.Context, h db.Handler, repoID int64, issueID int64) ([]models.CommitIssueReference, error)
+	// AddMergeRequestCommit records a commit a merge request introduced.
+	AddMergeRequestCommit(ctx context.Context, h db.Handler, repoID int64, mrID int64, sha string) error
+	// GetMergeRequestsByCommit returns the merge requests that introduced a
+	// commit.
+	GetMergeRequestsByCommit(ctx context.Context, h db.Handler, repoID int64, sha string) ([]models.MergeRequest, error)
+}
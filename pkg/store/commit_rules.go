@@ -0,0 +1,22 @@
+package store
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+)
+
+// CommitRulesStore is an interface for managing the commit message rules of
+// repositories.
+type CommitRulesStore interface {
+	// GetCommitRulesByRepoID returns the commit message rules of a
+	// repository.
+	GetCommitRulesByRepoID(ctx context.Context, h db.Handler, repoID int64) (models.CommitRules, error)
+	// SetCommitRules creates or replaces the commit message rules of a
+	// repository.
+	SetCommitRules(ctx context.Context, h db.Handler, rules models.CommitRules) error
+	// DeleteCommitRulesByRepoID deletes the commit message rules of a
+	// repository.
+	DeleteCommitRulesByRepoID(ctx context.Context, h db.Handler, repoID int64) error
+}
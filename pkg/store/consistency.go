@@ -0,0 +1,20 @@
+package store
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+)
+
+// ConsistencyStore is an interface for finding and purging the rows that
+// reference missing repositories and users, which foreign keys don't catch
+// when they are turned off.
+type ConsistencyStore interface {
+	// GetDanglingReferences returns the issues, merge requests, and
+	// collaborators referencing missing repositories or users.
+	GetDanglingReferences(ctx context.Context, h db.Handler) ([]models.DanglingReference, error)
+	// PurgeDanglingReference deletes the row of a dangling reference, or
+	// clears the reference when it's optional, as the foreign key would have.
+	PurgeDanglingReference(ctx context.Context, h db.Handler, ref models.DanglingReference) error
+}
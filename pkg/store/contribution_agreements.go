@@ -0,0 +1,28 @@
+package store
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+)
+
+// ContributionAgreementStore is an interface for managing the contribution
+// agreements of repositories and their acceptance.
+type ContributionAgreementStore interface {
+	// GetContributionAgreementsByRepoID returns the contribution agreements of
+	// a repository.
+	GetContributionAgreementsByRepoID(ctx context.Context, h db.Handler, repoID int64) (models.ContributionAgreements, error)
+	// SetContributionAgreements creates or replaces the contribution
+	// agreements of a repository.
+	SetContributionAgreements(ctx context.Context, h db.Handler, agreements models.ContributionAgreements) error
+	// DeleteContributionAgreementsByRepoID deletes the contribution agreements
+	// of a repository.
+	DeleteContributionAgreementsByRepoID(ctx context.Context, h db.Handler, repoID int64) error
+	// CreateCLASignature records that a user accepted a version of the
+	// contributor license agreement of a repository.
+	CreateCLASignature(ctx context.Context, h db.Handler, repoID int64, userID int64, documentSha string) error
+	// GetCLASignature returns the acceptance of a version of the contributor
+	// license agreement of a repository by a user.
+	GetCLASignature(ctx context.Context, h db.Handler, repoID int64, userID int64, documentSha string) (models.CLASignature, error)
+}
@@ -0,0 +1,22 @@
+package store
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+)
+
+// CrossReferenceStore is an interface for managing the references between
+// issues and merge requests.
+type CrossReferenceStore interface {
+	// AddCrossReference records a reference from an issue or merge request to
+	// another one.
+	AddCrossReference(ctx context.Context, h db.Handler, ref models.CrossReference) error
+	// DeleteCrossReferencesBySource deletes the references of an issue or
+	// merge request.
+	DeleteCrossReferencesBySource(ctx context.Context, h db.Handler, sourceType string, sourceID int64) error
+	// GetCrossReferencesByTarget returns the references to an issue or merge
+	// request of a repository, oldest first.
+	GetCrossReferencesByTarget(ctx context.Context, h db.Handler, targetRepoID int64, targetType string, targetID int64) ([]models.CrossReference, error)
+}
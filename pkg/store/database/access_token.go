@@ -72,3 +72,50 @@ func (*accessTokenStore) GetAccessTokenByToken(ctx context.Context, h db.Handler
 	err := h.GetContext(ctx, &m, query, token)
 	return m, err
 }
+
+// GetAllAccessTokens implements store.AccessTokenStore.
+func (*accessTokenStore) GetAllAccessTokens(ctx context.Context, h db.Handler) ([]models.AccessToken, error) {
+	query := h.Rebind(`SELECT * FROM access_tokens ORDER BY id ASC`)
+	var m []models.AccessToken
+	err := h.SelectContext(ctx, &m, query)
+	return m, err
+}
+
+// GetAccessTokensToWarn implements store.AccessTokenStore.
+func (*accessTokenStore) GetAccessTokensToWarn(ctx context.Context, h db.Handler, from, to time.Time) ([]models.AccessToken, error) {
+	query := h.Rebind(`SELECT * FROM access_tokens
+			WHERE expires_at IS NOT NULL AND expires_at > ? AND expires_at <= ?
+			AND expiry_warned_at IS NULL
+			ORDER BY expires_at ASC`)
+	var m []models.AccessToken
+	err := h.SelectContext(ctx, &m, query, from.UTC(), to.UTC())
+	return m, err
+}
+
+// TouchAccessToken implements store.AccessTokenStore.
+func (*accessTokenStore) TouchAccessToken(ctx context.Context, h db.Handler, id int64, ip string) error {
+	query := h.Rebind(`UPDATE access_tokens SET last_used_at = CURRENT_TIMESTAMP, last_used_ip = ? WHERE id = ?`)
+	_, err := h.ExecContext(ctx, query, ip, id)
+	return err
+}
+
+// SetAccessTokenExpiryWarned implements store.AccessTokenStore.
+func (*accessTokenStore) SetAccessTokenExpiryWarned(ctx context.Context, h db.Handler, id int64) error {
+	query := h.Rebind(`UPDATE access_tokens SET expiry_warned_at = CURRENT_TIMESTAMP WHERE id = ?`)
+	_, err := h.ExecContext(ctx, query, id)
+	return err
+}
+
+// SetAccessTokenScope implements store.AccessTokenStore.
+func (*accessTokenStore) SetAccessTokenScope(ctx context.Context, h db.Handler, id int64, scope string, scopeRepo string) error {
+	query := h.Rebind(`UPDATE access_tokens SET scope = ?, scope_repo = ? WHERE id = ?`)
+	_, err := h.ExecContext(ctx, query, scope, scopeRepo, id)
+	return err
+}
+
+// SetAccessTokenPins implements store.AccessTokenStore.
+func (*accessTokenStore) SetAccessTokenPins(ctx context.Context, h db.Handler, id int64, allowedCIDRs string, pinnedKey string) error {
+	query := h.Rebind(`UPDATE access_tokens SET allowed_cidrs = ?, pinned_key = ? WHERE id = ?`)
+	_, err := h.ExecContext(ctx, query, allowedCIDRs, pinnedKey, id)
+	return err
+}
@@ -0,0 +1,54 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/store/database"
+	"github.com/matryer/is"
+)
+
+func TestAccessTokenUsage(t *testing.T) {
+	runWithDatabases(t, testAccessTokenUsage)
+}
+
+func testAccessTokenUsage(t *testing.T, ctx context.Context, dbx *db.DB) {
+	is := is.New(t)
+
+	store := database.New(ctx, dbx)
+	userID, _, err := createTestUserAndRepo(ctx, dbx)
+	is.NoErr(err)
+
+	now := time.Now()
+	soon, err := store.CreateAccessToken(ctx, dbx, "soon", userID, "hash1", now.Add(24*time.Hour))
+	is.NoErr(err)
+	_, err = store.CreateAccessToken(ctx, dbx, "later", userID, "hash2", now.Add(30*24*time.Hour))
+	is.NoErr(err)
+	_, err = store.CreateAccessToken(ctx, dbx, "never", userID, "hash3", time.Time{})
+	is.NoErr(err)
+
+	is.True(!soon.LastUsedAt.Valid)
+	is.NoErr(store.TouchAccessToken(ctx, dbx, soon.ID, "192.0.2.1"))
+	soon, err = store.GetAccessToken(ctx, dbx, soon.ID)
+	is.NoErr(err)
+	is.True(soon.LastUsedAt.Valid)
+	is.Equal(soon.LastUsedIP.String, "192.0.2.1")
+
+	ts, err := store.GetAccessTokensToWarn(ctx, dbx, now, now.Add(7*24*time.Hour))
+	is.NoErr(err)
+	is.Equal(len(ts), 1)
+	is.Equal(ts[0].Name, "soon")
+
+	is.NoErr(store.SetAccessTokenExpiryWarned(ctx, dbx, soon.ID))
+	ts, err = store.GetAccessTokensToWarn(ctx, dbx, now, now.Add(7*24*time.Hour))
+	is.NoErr(err)
+	is.Equal(len(ts), 0)
+
+	var all []models.AccessToken
+	all, err = store.GetAllAccessTokens(ctx, dbx)
+	is.NoErr(err)
+	is.Equal(len(all), 3)
+}
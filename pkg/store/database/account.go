@@ -0,0 +1,82 @@
+package database
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/store"
+)
+
+type accountStore struct{}
+
+var _ store.AccountStore = (*accountStore)(nil)
+
+// GetIssuesByAuthorID implements store.AccountStore.
+func (*accountStore) GetIssuesByAuthorID(ctx context.Context, h db.Handler, authorID int64) ([]models.Issue, error) {
+	var issues []models.Issue
+	query := h.Rebind(`
+		SELECT ` + selectColumns("", issueColumns...) + ` FROM issues
+		WHERE author_id = ?
+		ORDER BY id ASC
+	`)
+	err := h.SelectContext(ctx, &issues, query, authorID)
+	return issues, err
+}
+
+// GetMergeRequestsByAuthorID implements store.AccountStore.
+func (*accountStore) GetMergeRequestsByAuthorID(ctx context.Context, h db.Handler, authorID int64) ([]models.MergeRequest, error) {
+	var mrs []models.MergeRequest
+	query := h.Rebind(`
+		SELECT ` + selectColumns("", mergeRequestColumns...) + ` FROM merge_requests
+		WHERE author_id = ?
+		ORDER BY id ASC
+	`)
+	err := h.SelectContext(ctx, &mrs, query, authorID)
+	return mrs, err
+}
+
+// GetMergeRequestCommentsByAuthorID implements store.AccountStore.
+func (*accountStore) GetMergeRequestCommentsByAuthorID(ctx context.Context, h db.Handler, authorID int64) ([]models.MergeRequestComment, error) {
+	var cs []models.MergeRequestComment
+	query := h.Rebind(`SELECT ` + selectColumns("", mergeRequestCommentColumns...) + ` FROM merge_request_comments WHERE author_id = ? ORDER BY id ASC;`)
+	err := h.SelectContext(ctx, &cs, query, authorID)
+	return cs, err
+}
+
+// GetAttachmentsByUserID implements store.AccountStore.
+func (*accountStore) GetAttachmentsByUserID(ctx context.Context, h db.Handler, userID int64) ([]models.Attachment, error) {
+	var as []models.Attachment
+	query := h.Rebind(`SELECT ` + selectColumns("", attachmentColumns...) + ` FROM attachments WHERE user_id = ? ORDER BY id ASC;`)
+	err := h.SelectContext(ctx, &as, query, userID)
+	return as, err
+}
+
+// ReassignUserContent implements store.AccountStore.
+func (*accountStore) ReassignUserContent(ctx context.Context, h db.Handler, fromID int64, toID int64) (int64, error) {
+	var n int64
+	for _, c := range []struct{ table, column string }{
+		{"issues", "author_id"},
+		{"issues", "closed_by"},
+		{"issues", "edited_by_id"},
+		{"merge_requests", "author_id"},
+		{"merge_requests", "merged_by"},
+		{"merge_requests", "closed_by"},
+		{"merge_requests", "reviewed_by"},
+		{"merge_requests", "edited_by_id"},
+		{"attachments", "user_id"},
+		{"merge_request_comments", "author_id"},
+	} {
+		query := h.Rebind(`UPDATE ` + c.table + ` SET ` + c.column + ` = ? WHERE ` + c.column + ` = ?;`)
+		res, err := h.ExecContext(ctx, query, toID, fromID)
+		if err != nil {
+			return n, err
+		}
+		rows, err := res.RowsAffected()
+		if err != nil {
+			return n, err
+		}
+		n += rows
+	}
+	return n, nil
+}
@@ -0,0 +1,82 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/store/database"
+	"github.com/matryer/is"
+)
+
+func TestAccountStore(t *testing.T) {
+	runWithDatabases(t, testAccountStore)
+}
+
+func testAccountStore(t *testing.T, ctx context.Context, dbx *db.DB) {
+	is := is.New(t)
+
+	store := database.New(ctx, dbx)
+	userID, repoID, err := createTestUserAndRepo(ctx, dbx)
+	is.NoErr(err)
+
+	is.NoErr(store.CreateUser(ctx, dbx, "ghost", false, nil))
+	ghost, err := store.FindUserByUsername(ctx, dbx, "ghost")
+	is.NoErr(err)
+
+	issueID, err := store.CreateIssue(ctx, dbx, repoID, userID, "First issue", "")
+	is.NoErr(err)
+	is.NoErr(store.CloseIssue(ctx, dbx, repoID, issueID, userID, models.IssueCloseReasonCompleted))
+	mrID, err := store.CreateMergeRequest(ctx, dbx, repoID, userID, "First MR", "", "feature", "main")
+	is.NoErr(err)
+	commentID, err := store.CreateMergeRequestComment(ctx, dbx, repoID, mrID, userID, 0, models.CommentAnchor{}, "LGTM")
+	is.NoErr(err)
+	_, err = store.CreateAttachment(ctx, dbx, models.Attachment{
+		RepoID:      repoID,
+		SubjectType: "issue",
+		SubjectID:   1,
+		UserID:      userID,
+		Name:        "log.txt",
+		ContentType: "text/plain",
+		Size:        3,
+		Oid:         "oid",
+	})
+	is.NoErr(err)
+
+	issues, err := store.GetIssuesByAuthorID(ctx, dbx, userID)
+	is.NoErr(err)
+	is.Equal(len(issues), 1)
+	is.Equal(issues[0].Title, "First issue")
+	mrs, err := store.GetMergeRequestsByAuthorID(ctx, dbx, userID)
+	is.NoErr(err)
+	is.Equal(len(mrs), 1)
+	comments, err := store.GetMergeRequestCommentsByAuthorID(ctx, dbx, userID)
+	is.NoErr(err)
+	is.Equal(len(comments), 1)
+	is.Equal(comments[0].Body, "LGTM")
+	as, err := store.GetAttachmentsByUserID(ctx, dbx, userID)
+	is.NoErr(err)
+	is.Equal(len(as), 1)
+
+	n, err := store.ReassignUserContent(ctx, dbx, userID, ghost.ID)
+	is.NoErr(err)
+	is.Equal(n, int64(5)) // issue author and closer, merge request author, comment, attachment
+
+	issues, err = store.GetIssuesByAuthorID(ctx, dbx, userID)
+	is.NoErr(err)
+	is.Equal(len(issues), 0)
+	issue, err := store.GetIssueByID(ctx, dbx, repoID, issueID)
+	is.NoErr(err)
+	is.Equal(issue.AuthorID, ghost.ID)
+	is.Equal(issue.ClosedBy.Int64, ghost.ID)
+	mr, err := store.GetMergeRequestByID(ctx, dbx, repoID, mrID)
+	is.NoErr(err)
+	is.Equal(mr.AuthorID, ghost.ID)
+	comment, err := store.GetMergeRequestCommentByID(ctx, dbx, repoID, mrID, commentID)
+	is.NoErr(err)
+	is.Equal(comment.AuthorID, ghost.ID)
+	as, err = store.GetAttachmentsByUserID(ctx, dbx, ghost.ID)
+	is.NoErr(err)
+	is.Equal(len(as), 1)
+}
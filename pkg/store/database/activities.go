@@ -0,0 +1,62 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/store"
+	"github.com/jmoiron/sqlx"
+)
+
+type activityStore struct{}
+
+var _ store.ActivityStore = (*activityStore)(nil)
+
+// CreateActivity implements store.ActivityStore.
+func (*activityStore) CreateActivity(ctx context.Context, h db.Handler, a models.Activity) error {
+	query := h.Rebind(`INSERT INTO activities (repo_id, actor_id, kind, action, subject_id, title)
+			VALUES (?, ?, ?, ?, ?, ?);`)
+	_, err := h.ExecContext(ctx, query, a.RepoID, a.ActorID, a.Kind, a.Action, a.SubjectID, a.Title)
+	return err
+}
+
+// GetActivitiesByRepoIDs implements store.ActivityStore.
+func (*activityStore) GetActivitiesByRepoIDs(ctx context.Context, h db.Handler, repoIDs []int64, actions []string, limit int) ([]models.Activity, error) {
+	if len(repoIDs) == 0 || len(actions) == 0 {
+		return nil, nil
+	}
+
+	query, args, err := sqlx.In(`SELECT * FROM activities
+			WHERE repo_id IN (?) AND action IN (?)
+			ORDER BY created_at DESC, id DESC
+			LIMIT ?;`, repoIDs, actions, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	var as []models.Activity
+	err = h.SelectContext(ctx, &as, h.Rebind(query), args...)
+	return as, err
+}
+
+// GetActivityCounts implements store.ActivityStore.
+func (*activityStore) GetActivityCounts(ctx context.Context, h db.Handler, repoIDs []int64, kind string, since time.Time, limit int) ([]models.ActivityCount, error) {
+	if len(repoIDs) == 0 {
+		return nil, nil
+	}
+
+	query, args, err := sqlx.In(`SELECT repo_id, subject_id, COUNT(*) AS count FROM activities
+			WHERE repo_id IN (?) AND kind = ? AND created_at >= ?
+			GROUP BY repo_id, subject_id
+			ORDER BY count DESC, MAX(created_at) DESC
+			LIMIT ?;`, repoIDs, kind, since.UTC(), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	var cs []models.ActivityCount
+	err = h.SelectContext(ctx, &cs, h.Rebind(query), args...)
+	return cs, err
+}
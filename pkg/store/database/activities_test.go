@@ -0,0 +1,53 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/store/database"
+	"github.com/matryer/is"
+)
+
+func TestActivities(t *testing.T) {
+	runWithDatabases(t, testActivities)
+}
+
+func testActivities(t *testing.T, ctx context.Context, dbx *db.DB) {
+	is := is.New(t)
+
+	store := database.New(ctx, dbx)
+	_, repoID, err := createTestUserAndRepo(ctx, dbx)
+	is.NoErr(err)
+
+	for _, a := range []models.Activity{
+		{RepoID: repoID, Kind: "repository", Action: "created"},
+		{RepoID: repoID, Kind: "merge_request", Action: "opened", SubjectID: 1, Title: "First"},
+		{RepoID: repoID, Kind: "merge_request", Action: "edited", SubjectID: 1, Title: "First"},
+		{RepoID: repoID, Kind: "merge_request", Action: "opened", SubjectID: 2, Title: "Second"},
+	} {
+		is.NoErr(store.CreateActivity(ctx, dbx, a))
+	}
+
+	as, err := store.GetActivitiesByRepoIDs(ctx, dbx, []int64{repoID}, []string{"created", "opened"}, 10)
+	is.NoErr(err)
+	is.Equal(len(as), 3)
+	is.Equal(as[0].Title, "Second")
+	is.Equal(as[2].Kind, "repository")
+
+	as, err = store.GetActivitiesByRepoIDs(ctx, dbx, []int64{repoID + 1}, []string{"created"}, 10)
+	is.NoErr(err)
+	is.Equal(len(as), 0)
+
+	counts, err := store.GetActivityCounts(ctx, dbx, []int64{repoID}, "merge_request", time.Now().Add(-time.Hour), 10)
+	is.NoErr(err)
+	is.Equal(len(counts), 2)
+	is.Equal(counts[0].SubjectID, int64(1))
+	is.Equal(counts[0].Count, 2)
+
+	counts, err = store.GetActivityCounts(ctx, dbx, []int64{repoID}, "merge_request", time.Now().Add(time.Hour), 10)
+	is.NoErr(err)
+	is.Equal(len(counts), 0)
+}
@@ -0,0 +1,69 @@
+package database
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/store"
+)
+
+type attachmentStore struct{}
+
+var _ store.AttachmentStore = (*attachmentStore)(nil)
+
+// attachmentColumns are the columns selected when querying attachments.
+var attachmentColumns = []string{
+	"id",
+	"repo_id",
+	"subject_type",
+	"subject_id",
+	"user_id",
+	"name",
+	"content_type",
+	"size",
+	"oid",
+	"created_at",
+}
+
+// CreateAttachment implements store.AttachmentStore.
+func (*attachmentStore) CreateAttachment(ctx context.Context, h db.Handler, a models.Attachment) (int64, error) {
+	var id int64
+	query := h.Rebind(`INSERT INTO attachments (repo_id, subject_type, subject_id, user_id, name, content_type, size, oid)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?) RETURNING id;`)
+	err := h.GetContext(ctx, &id, query, a.RepoID, a.SubjectType, a.SubjectID, a.UserID, a.Name, a.ContentType, a.Size, a.Oid)
+	return id, err
+}
+
+// GetAttachment implements store.AttachmentStore.
+func (*attachmentStore) GetAttachment(ctx context.Context, h db.Handler, repoID int64, id int64) (models.Attachment, error) {
+	var a models.Attachment
+	query := h.Rebind(`SELECT ` + selectColumns("", attachmentColumns...) + ` FROM attachments WHERE repo_id = ? AND id = ?;`)
+	err := h.GetContext(ctx, &a, query, repoID, id)
+	return a, err
+}
+
+// GetAttachments implements store.AttachmentStore.
+func (*attachmentStore) GetAttachments(ctx context.Context, h db.Handler, repoID int64, subjectType string, subjectID int64) ([]models.Attachment, error) {
+	var as []models.Attachment
+	query := h.Rebind(`SELECT ` + selectColumns("", attachmentColumns...) + ` FROM attachments
+			WHERE repo_id = ? AND subject_type = ? AND subject_id = ?
+			ORDER BY id ASC;`)
+	err := h.SelectContext(ctx, &as, query, repoID, subjectType, subjectID)
+	return as, err
+}
+
+// CountAttachmentsByOid implements store.AttachmentStore.
+func (*attachmentStore) CountAttachmentsByOid(ctx context.Context, h db.Handler, repoID int64, oid string) (int64, error) {
+	var n int64
+	query := h.Rebind(`SELECT COUNT(*) FROM attachments WHERE repo_id = ? AND oid = ?;`)
+	err := h.GetContext(ctx, &n, query, repoID, oid)
+	return n, err
+}
+
+// DeleteAttachment implements store.AttachmentStore.
+func (*attachmentStore) DeleteAttachment(ctx context.Context, h db.Handler, repoID int64, id int64) error {
+	query := h.Rebind(`DELETE FROM attachments WHERE repo_id = ? AND id = ?;`)
+	_, err := h.ExecContext(ctx, query, repoID, id)
+	return err
+}
@@ -0,0 +1,67 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/store/database"
+	"github.com/matryer/is"
+)
+
+func TestAttachmentStore(t *testing.T) {
+	runWithDatabases(t, testAttachmentStore)
+}
+
+func testAttachmentStore(t *testing.T, ctx context.Context, dbx *db.DB) {
+	is := is.New(t)
+
+	store := database.New(ctx, dbx)
+	userID, repoID, err := createTestUserAndRepo(ctx, dbx)
+	is.NoErr(err)
+
+	a := models.Attachment{
+		RepoID:      repoID,
+		SubjectType: "issue",
+		SubjectID:   1,
+		UserID:      userID,
+		Name:        "crash.log",
+		ContentType: "text/plain",
+		Size:        12,
+		Oid:         "abc",
+	}
+	id1, err := store.CreateAttachment(ctx, dbx, a)
+	is.NoErr(err)
+	a.Name = "crash-again.log"
+	id2, err := store.CreateAttachment(ctx, dbx, a)
+	is.NoErr(err)
+	a.SubjectType = "merge_request"
+	a.Oid = "def"
+	_, err = store.CreateAttachment(ctx, dbx, a)
+	is.NoErr(err)
+
+	got, err := store.GetAttachment(ctx, dbx, repoID, id1)
+	is.NoErr(err)
+	is.Equal(got.Name, "crash.log")
+	is.Equal(got.Size, int64(12))
+
+	// Attachments are listed per subject, oldest first.
+	as, err := store.GetAttachments(ctx, dbx, repoID, "issue", 1)
+	is.NoErr(err)
+	is.Equal(len(as), 2)
+	is.Equal(as[0].ID, id1)
+	is.Equal(as[1].ID, id2)
+
+	// Identical content is counted across subjects.
+	n, err := store.CountAttachmentsByOid(ctx, dbx, repoID, "abc")
+	is.NoErr(err)
+	is.Equal(n, int64(2))
+
+	is.NoErr(store.DeleteAttachment(ctx, dbx, repoID, id1))
+	_, err = store.GetAttachment(ctx, dbx, repoID, id1)
+	is.True(err != nil)
+	n, err = store.CountAttachmentsByOid(ctx, dbx, repoID, "abc")
+	is.NoErr(err)
+	is.Equal(n, int64(1))
+}
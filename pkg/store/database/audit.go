@@ -0,0 +1,53 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/store"
+)
+
+type auditStore struct{}
+
+var _ store.AuditStore = (*auditStore)(nil)
+
+// CreateAuditEvent implements store.AuditStore.
+func (*auditStore) CreateAuditEvent(ctx context.Context, h db.Handler, e models.AuditEvent) error {
+	query := h.Rebind(`INSERT INTO audit_events (actor_id, impersonator_id, action, target, detail)
+			VALUES (?, ?, ?, ?, ?);`)
+	_, err := h.ExecContext(ctx, query, e.ActorID, e.ImpersonatorID, e.Action, e.Target, e.Detail)
+	return err
+}
+
+// GetAuditEvents implements store.AuditStore.
+func (*auditStore) GetAuditEvents(ctx context.Context, h db.Handler, limit int) ([]models.AuditEvent, error) {
+	query := h.Rebind(`SELECT * FROM audit_events
+			ORDER BY created_at DESC, id DESC
+			LIMIT ?;`)
+	var es []models.AuditEvent
+	err := h.SelectContext(ctx, &es, query, limit)
+	return es, err
+}
+
+// GetAuditEventsByActionPrefix implements store.AuditStore.
+func (*auditStore) GetAuditEventsByActionPrefix(ctx context.Context, h db.Handler, prefix string, limit int) ([]models.AuditEvent, error) {
+	query := h.Rebind(`SELECT * FROM audit_events
+			WHERE action LIKE ?
+			ORDER BY created_at DESC, id DESC
+			LIMIT ?;`)
+	var es []models.AuditEvent
+	err := h.SelectContext(ctx, &es, query, prefix+"%", limit)
+	return es, err
+}
+
+// DeleteAuditEventsBefore implements store.AuditStore.
+func (*auditStore) DeleteAuditEventsBefore(ctx context.Context, h db.Handler, t time.Time) (int64, error) {
+	query := h.Rebind(`DELETE FROM audit_events WHERE created_at < ?;`)
+	res, err := h.ExecContext(ctx, query, t.UTC())
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
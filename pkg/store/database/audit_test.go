@@ -0,0 +1,69 @@
+package database_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/store/database"
+	"github.com/matryer/is"
+)
+
+func TestAuditEvents(t *testing.T) {
+	runWithDatabases(t, testAuditEvents)
+}
+
+func testAuditEvents(t *testing.T, ctx context.Context, dbx *db.DB) {
+	is := is.New(t)
+
+	store := database.New(ctx, dbx)
+	userID, _, err := createTestUserAndRepo(ctx, dbx)
+	is.NoErr(err)
+
+	for _, e := range []models.AuditEvent{
+		{Action: "session.revoke", Target: "user1", Detail: "session 1"},
+		{ActorID: sql.NullInt64{Int64: userID, Valid: true}, Action: "session.revoke", Target: "user1", Detail: "session 2"},
+		{Action: "moderation.hide", Target: "repo1", Detail: "issue #1"},
+		{
+			ActorID:        sql.NullInt64{Int64: userID, Valid: true},
+			ImpersonatorID: sql.NullInt64{Int64: userID, Valid: true},
+			Action:         "issue.delete",
+			Target:         "repo1",
+			Detail:         "issue #2",
+		},
+	} {
+		is.NoErr(store.CreateAuditEvent(ctx, dbx, e))
+	}
+
+	es, err := store.GetAuditEventsByActionPrefix(ctx, dbx, "session.", 10)
+	is.NoErr(err)
+	is.Equal(len(es), 2)
+	is.Equal(es[0].Detail, "session 2")
+	is.Equal(es[0].ActorID.Int64, userID)
+	is.True(!es[1].ActorID.Valid)
+
+	es, err = store.GetAuditEvents(ctx, dbx, 10)
+	is.NoErr(err)
+	is.Equal(len(es), 4)
+	is.Equal(es[0].ImpersonatorID.Int64, userID)
+	is.True(!es[1].ImpersonatorID.Valid)
+
+	es, err = store.GetAuditEvents(ctx, dbx, 1)
+	is.NoErr(err)
+	is.Equal(len(es), 1)
+
+	n, err := store.DeleteAuditEventsBefore(ctx, dbx, time.Now().Add(-time.Hour))
+	is.NoErr(err)
+	is.Equal(n, int64(0))
+
+	n, err = store.DeleteAuditEventsBefore(ctx, dbx, time.Now().Add(time.Hour))
+	is.NoErr(err)
+	is.Equal(n, int64(4))
+
+	es, err = store.GetAuditEvents(ctx, dbx, 10)
+	is.NoErr(err)
+	is.Equal(len(es), 0)
+}
@@ -0,0 +1,173 @@
+package database_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/charmbracelet/soft-serve/pkg/config"
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/migrate"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/store"
+	"github.com/charmbracelet/soft-serve/pkg/store/database"
+)
+
+const (
+	benchRepos = 10
+	benchRows  = 100_000
+)
+
+// benchDB is a database seeded with benchRows issues and merge requests
+// spread across benchRepos repositories.
+type benchDB struct {
+	ctx     context.Context
+	dbx     *db.DB
+	store   store.Store
+	repoIDs []int64
+}
+
+// openBenchDB opens and seeds a database for benchmarks.
+func openBenchDB(b *testing.B) *benchDB {
+	b.Helper()
+
+	ctx := config.WithContext(context.TODO(), config.DefaultConfig())
+	dbx, err := openTestDB(ctx, b)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	if err := migrate.Migrate(ctx, dbx); err != nil {
+		b.Fatal(err)
+	}
+
+	bdb := &benchDB{
+		ctx:   ctx,
+		dbx:   dbx,
+		store: database.New(ctx, dbx),
+	}
+
+	if err := dbx.TransactionContext(ctx, func(tx *db.Tx) error {
+		var userID int64
+		if err := tx.GetContext(ctx, &userID, tx.Rebind(`INSERT INTO users (username, admin, updated_at)
+			VALUES (?, ?, CURRENT_TIMESTAMP) RETURNING id;`), "bench", false); err != nil {
+			return err
+		}
+
+		for i := 0; i < benchRepos; i++ {
+			var repoID int64
+			if err := tx.GetContext(ctx, &repoID, tx.Rebind(`INSERT INTO repos (name, project_name, description, private, mirror, hidden, user_id, updated_at)
+				VALUES (?, '', '', false, false, false, ?, CURRENT_TIMESTAMP) RETURNING id;`), fmt.Sprintf("repo%d", i), userID); err != nil {
+				return err
+			}
+			bdb.repoIDs = append(bdb.repoIDs, repoID)
+		}
+
+		for i := 0; i < benchRows; i++ {
+			repoID := bdb.repoIDs[i%benchRepos]
+			state := models.IssueStateOpen
+			if i%3 == 0 {
+				state = models.IssueStateClosed
+			}
+
+			if _, err := tx.ExecContext(ctx, tx.Rebind(`INSERT INTO issues (repo_id, title, description, state, author_id, updated_at)
+				VALUES (?, ?, '', ?, ?, CURRENT_TIMESTAMP);`), repoID, fmt.Sprintf("issue %d", i), state, userID); err != nil {
+				return err
+			}
+
+			if _, err := tx.ExecContext(ctx, tx.Rebind(`INSERT INTO merge_requests (repo_id, title, description, source_branch, target_branch, state, author_id, updated_at)
+				VALUES (?, ?, '', ?, 'main', ?, ?, CURRENT_TIMESTAMP);`), repoID, fmt.Sprintf("mr %d", i), fmt.Sprintf("branch%d", i), models.MergeRequestStateOpen, userID); err != nil {
+				return err
+			}
+		}
+
+		// Chain each issue in the first repository to the previous one.
+		if _, err := tx.ExecContext(ctx, tx.Rebind(`INSERT INTO issue_dependencies (issue_id, depends_on_id)
+			SELECT i.id, i.id - ? FROM issues i WHERE i.repo_id = ? AND i.id > ?;`), benchRepos, bdb.repoIDs[0], benchRepos); err != nil {
+			return err
+		}
+
+		return nil
+	}); err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+
+	return bdb
+}
+
+func BenchmarkIssueStore(b *testing.B) {
+	bdb := openBenchDB(b)
+	ctx, dbx, s, repoID := bdb.ctx, bdb.dbx, bdb.store, bdb.repoIDs[0]
+	issueID := int64(benchRows / 2)
+
+	b.Run("GetIssueByID", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := s.GetIssueByID(ctx, dbx, repoID, issueID+1); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("GetIssuesByRepoID", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := s.GetIssuesByRepoID(ctx, dbx, repoID, models.ListOrder{}, 0, 0); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("GetIssuesByRepoIDAndState", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := s.GetIssuesByRepoIDAndState(ctx, dbx, repoID, models.IssueStateClosed, models.ListOrder{}, 0, 0); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("GetIssueDependencies", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := s.GetIssueDependencies(ctx, dbx, repoID, issueID+1); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("GetIssueDependents", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := s.GetIssueDependents(ctx, dbx, repoID, issueID+1); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkMergeRequestStore(b *testing.B) {
+	bdb := openBenchDB(b)
+	ctx, dbx, s, repoID := bdb.ctx, bdb.dbx, bdb.store, bdb.repoIDs[0]
+
+	b.Run("GetMergeRequestByID", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := s.GetMergeRequestByID(ctx, dbx, repoID, benchRows/2+1); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("GetMergeRequestsByRepoID", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := s.GetMergeRequestsByRepoID(ctx, dbx, repoID, models.ListOrder{}, 0, 0); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("GetMergeRequestsByRepoIDAndState", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := s.GetMergeRequestsByRepoIDAndState(ctx, dbx, repoID, models.MergeRequestStateOpen, models.ListOrder{}, 0, 0); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
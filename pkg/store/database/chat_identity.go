@@ -0,0 +1,73 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/store"
+)
+
+type chatIdentityStore struct{}
+
+var _ store.ChatIdentityStore = (*chatIdentityStore)(nil)
+
+// GetChatIdentity implements store.ChatIdentityStore.
+func (*chatIdentityStore) GetChatIdentity(ctx context.Context, h db.Handler, provider string, externalID string) (models.ChatIdentity, error) {
+	query := h.Rebind(`SELECT * FROM chat_identities WHERE provider = ? AND external_id = ?;`)
+	var ci models.ChatIdentity
+	err := h.GetContext(ctx, &ci, query, provider, externalID)
+	return ci, err
+}
+
+// GetChatIdentitiesByUserID implements store.ChatIdentityStore.
+func (*chatIdentityStore) GetChatIdentitiesByUserID(ctx context.Context, h db.Handler, userID int64) ([]models.ChatIdentity, error) {
+	query := h.Rebind(`SELECT * FROM chat_identities WHERE user_id = ? ORDER BY provider, external_id;`)
+	var cis []models.ChatIdentity
+	err := h.SelectContext(ctx, &cis, query, userID)
+	return cis, err
+}
+
+// CreateChatIdentity implements store.ChatIdentityStore.
+func (*chatIdentityStore) CreateChatIdentity(ctx context.Context, h db.Handler, userID int64, provider string, externalID string) error {
+	query := h.Rebind(`INSERT INTO chat_identities (user_id, provider, external_id) VALUES (?, ?, ?);`)
+	_, err := h.ExecContext(ctx, query, userID, provider, externalID)
+	return err
+}
+
+// DeleteChatIdentityForUser implements store.ChatIdentityStore.
+func (*chatIdentityStore) DeleteChatIdentityForUser(ctx context.Context, h db.Handler, userID int64, provider string, externalID string) error {
+	query := h.Rebind(`DELETE FROM chat_identities WHERE user_id = ? AND provider = ? AND external_id = ?;`)
+	_, err := h.ExecContext(ctx, query, userID, provider, externalID)
+	return err
+}
+
+// CreateChatLinkCode implements store.ChatIdentityStore.
+func (*chatIdentityStore) CreateChatLinkCode(ctx context.Context, h db.Handler, code string, provider string, externalID string, expiresAt time.Time) error {
+	query := h.Rebind(`INSERT INTO chat_link_codes (code, provider, external_id, expires_at) VALUES (?, ?, ?, ?);`)
+	_, err := h.ExecContext(ctx, query, code, provider, externalID, expiresAt)
+	return err
+}
+
+// GetChatLinkCode implements store.ChatIdentityStore.
+func (*chatIdentityStore) GetChatLinkCode(ctx context.Context, h db.Handler, code string) (models.ChatLinkCode, error) {
+	query := h.Rebind(`SELECT * FROM chat_link_codes WHERE code = ?;`)
+	var lc models.ChatLinkCode
+	err := h.GetContext(ctx, &lc, query, code)
+	return lc, err
+}
+
+// DeleteChatLinkCode implements store.ChatIdentityStore.
+func (*chatIdentityStore) DeleteChatLinkCode(ctx context.Context, h db.Handler, code string) error {
+	query := h.Rebind(`DELETE FROM chat_link_codes WHERE code = ?;`)
+	_, err := h.ExecContext(ctx, query, code)
+	return err
+}
+
+// DeleteExpiredChatLinkCodes implements store.ChatIdentityStore.
+func (*chatIdentityStore) DeleteExpiredChatLinkCodes(ctx context.Context, h db.Handler, t time.Time) error {
+	query := h.Rebind(`DELETE FROM chat_link_codes WHERE expires_at < ?;`)
+	_, err := h.ExecContext(ctx, query, t)
+	return err
+}
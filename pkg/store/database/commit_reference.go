@@ -0,0 +1,73 @@
+package database
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/store"
+)
+
+type commitReferenceStore struct{}
+
+var _ store.CommitReferenceStore = (*commitReferenceStore)(nil)
+
+// AddCommitIssueReference implements store.CommitReferenceStore.
+func (*commitReferenceStore) AddCommitIssueReference(ctx context.Context, h db.Handler, repoID int64, sha string, issueID int64, closes bool) error {
+	query := h.Rebind(`
+		INSERT INTO commit_issue_references (repo_id, commit_sha, issue_id, closes)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT (repo_id, commit_sha, issue_id) DO UPDATE SET
+			closes = commit_issue_references.closes OR excluded.closes
+	`)
+	_, err := h.ExecContext(ctx, query, repoID, sha, issueID, closes)
+	return err
+}
+
+// GetCommitIssueReferences implements store.CommitReferenceStore.
+func (*commitReferenceStore) GetCommitIssueReferences(ctx context.Context, h db.Handler, repoID int64, sha string) ([]models.CommitIssueReference, error) {
+	var refs []models.CommitIssueReference
+	query := h.Rebind(`
+		SELECT * FROM commit_issue_references
+		WHERE repo_id = ? AND commit_sha = ?
+		ORDER BY id ASC
+	`)
+	err := h.SelectContext(ctx, &refs, query, repoID, sha)
+	return refs, err
+}
+
+// GetIssueCommitReferences implements store.CommitReferenceStore.
+func (*commitReferenceStore) GetIssueCommitReferences(ctx context.Context, h db.Handler, repoID int64, issueID int64) ([]models.CommitIssueReference, error) {
+	var refs []models.CommitIssueReference
+	query := h.Rebind(`
+		SELECT * FROM commit_issue_references
+		WHERE repo_id = ? AND issue_id = ?
+		ORDER BY id ASC
+	`)
+	err := h.SelectContext(ctx, &refs, query, repoID, issueID)
+	return refs, err
+}
+
+// AddMergeRequestCommit implements store.CommitReferenceStore.
+func (*commitReferenceStore) AddMergeRequestCommit(ctx context.Context, h db.Handler, repoID int64, mrID int64, sha string) error {
+	query := h.Rebind(`
+		INSERT INTO merge_request_commits (repo_id, merge_request_id, commit_sha)
+		VALUES (?, ?, ?)
+		ON CONFLICT (merge_request_id, commit_sha) DO NOTHING
+	`)
+	_, err := h.ExecContext(ctx, query, repoID, mrID, sha)
+	return err
+}
+
+// GetMergeRequestsByCommit implements store.CommitReferenceStore.
+func (*commitReferenceStore) GetMergeRequestsByCommit(ctx context.Context, h db.Handler, repoID int64, sha string) ([]models.MergeRequest, error) {
+	var mrs []models.MergeRequest
+	query := h.Rebind(`
+		SELECT ` + selectColumns("merge_requests", mergeRequestColumns...) + ` FROM merge_requests
+		INNER JOIN merge_request_commits ON merge_request_commits.merge_request_id = merge_requests.id
+		WHERE merge_request_commits.repo_id = ? AND merge_request_commits.commit_sha = ?
+		ORDER BY merge_requests.id ASC
+	`)
+	err := h.SelectContext(ctx, &mrs, query, repoID, sha)
+	return mrs, err
+}
@@ -0,0 +1,48 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/store/database"
+	"github.com/matryer/is"
+)
+
+func TestCommitReferenceStore(t *testing.T) {
+	runWithDatabases(t, testCommitReferenceStore)
+}
+
+func testCommitReferenceStore(t *testing.T, ctx context.Context, dbx *db.DB) {
+	is := is.New(t)
+
+	store := database.New(ctx, dbx)
+	userID, repoID, err := createTestUserAndRepo(ctx, dbx)
+	is.NoErr(err)
+
+	issueID, err := store.CreateIssue(ctx, dbx, repoID, userID, "Broken build", "")
+	is.NoErr(err)
+	mrID, err := store.CreateMergeRequest(ctx, dbx, repoID, userID, "Fix build", "", "fix", "main")
+	is.NoErr(err)
+
+	// A later closing reference wins over a mention.
+	is.NoErr(store.AddCommitIssueReference(ctx, dbx, repoID, "abc", issueID, false))
+	is.NoErr(store.AddCommitIssueReference(ctx, dbx, repoID, "abc", issueID, true))
+	is.NoErr(store.AddCommitIssueReference(ctx, dbx, repoID, "abc", issueID, false))
+	refs, err := store.GetCommitIssueReferences(ctx, dbx, repoID, "abc")
+	is.NoErr(err)
+	is.Equal(len(refs), 1)
+	is.Equal(refs[0].IssueID, issueID)
+	is.True(refs[0].Closes)
+
+	is.NoErr(store.AddMergeRequestCommit(ctx, dbx, repoID, mrID, "abc"))
+	is.NoErr(store.AddMergeRequestCommit(ctx, dbx, repoID, mrID, "abc"))
+	mrs, err := store.GetMergeRequestsByCommit(ctx, dbx, repoID, "abc")
+	is.NoErr(err)
+	is.Equal(len(mrs), 1)
+	is.Equal(mrs[0].ID, mrID)
+
+	refs, err = store.GetCommitIssueReferences(ctx, dbx, repoID, "def")
+	is.NoErr(err)
+	is.Equal(len(refs), 0)
+}
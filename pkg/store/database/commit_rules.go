@@ -0,0 +1,42 @@
+package database
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/store"
+)
+
+type commitRulesStore struct{}
+
+var _ store.CommitRulesStore = (*commitRulesStore)(nil)
+
+// GetCommitRulesByRepoID implements store.CommitRulesStore.
+func (*commitRulesStore) GetCommitRulesByRepoID(ctx context.Context, h db.Handler, repoID int64) (models.CommitRules, error) {
+	query := h.Rebind(`SELECT * FROM commit_rules WHERE repo_id = ?;`)
+	var rules models.CommitRules
+	err := h.GetContext(ctx, &rules, query, repoID)
+	return rules, err
+}
+
+// SetCommitRules implements store.CommitRulesStore.
+func (*commitRulesStore) SetCommitRules(ctx context.Context, h db.Handler, rules models.CommitRules) error {
+	query := h.Rebind(`INSERT INTO commit_rules (repo_id, conventional, max_subject_length, required_trailers, warn_only, updated_at)
+			VALUES (?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+			ON CONFLICT (repo_id) DO UPDATE SET
+			conventional = excluded.conventional,
+			max_subject_length = excluded.max_subject_length,
+			required_trailers = excluded.required_trailers,
+			warn_only = excluded.warn_only,
+			updated_at = CURRENT_TIMESTAMP;`)
+	_, err := h.ExecContext(ctx, query, rules.RepoID, rules.Conventional, rules.MaxSubjectLength, rules.RequiredTrailers, rules.WarnOnly)
+	return err
+}
+
+// DeleteCommitRulesByRepoID implements store.CommitRulesStore.
+func (*commitRulesStore) DeleteCommitRulesByRepoID(ctx context.Context, h db.Handler, repoID int64) error {
+	query := h.Rebind(`DELETE FROM commit_rules WHERE repo_id = ?;`)
+	_, err := h.ExecContext(ctx, query, repoID)
+	return err
+}
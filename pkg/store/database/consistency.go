@@ -0,0 +1,82 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/store"
+)
+
+type consistencyStore struct{}
+
+var _ store.ConsistencyStore = (*consistencyStore)(nil)
+
+// reference is a column referencing a repository or a user.
+type reference struct {
+	table  string
+	column string
+	target string
+	// optional references are cleared when the target is deleted, the others
+	// delete the row.
+	optional bool
+}
+
+// references are the references checked for consistency.
+var references = []reference{
+	{table: "issues", column: "repo_id", target: "repos"},
+	{table: "merge_requests", column: "repo_id", target: "repos"},
+	{table: "collabs", column: "repo_id", target: "repos"},
+	{table: "issues", column: "author_id", target: "users"},
+	{table: "issues", column: "closed_by", target: "users", optional: true},
+	{table: "merge_requests", column: "author_id", target: "users"},
+	{table: "merge_requests", column: "merged_by", target: "users", optional: true},
+	{table: "merge_requests", column: "closed_by", target: "users", optional: true},
+	{table: "collabs", column: "user_id", target: "users"},
+}
+
+// dangling returns the condition matching the rows of ref referencing a
+// missing row.
+func (ref reference) dangling() string {
+	return ref.column + " IS NOT NULL AND NOT EXISTS (SELECT 1 FROM " + ref.target +
+		" WHERE " + ref.target + ".id = " + ref.table + "." + ref.column + ")"
+}
+
+// GetDanglingReferences implements store.ConsistencyStore.
+func (*consistencyStore) GetDanglingReferences(ctx context.Context, h db.Handler) ([]models.DanglingReference, error) {
+	var refs []models.DanglingReference
+	for _, ref := range references {
+		var rows []models.DanglingReference
+		query := `
+			SELECT id AS row_id, ` + ref.column + ` AS ref_id FROM ` + ref.table + `
+			WHERE ` + ref.dangling() + `
+			ORDER BY id ASC`
+		if err := h.SelectContext(ctx, &rows, query); err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			row.Table, row.Column = ref.table, ref.column
+			refs = append(refs, row)
+		}
+	}
+	return refs, nil
+}
+
+// PurgeDanglingReference implements store.ConsistencyStore.
+func (*consistencyStore) PurgeDanglingReference(ctx context.Context, h db.Handler, dr models.DanglingReference) error {
+	for _, ref := range references {
+		if ref.table != dr.Table || ref.column != dr.Column {
+			continue
+		}
+
+		query := `DELETE FROM ` + ref.table
+		if ref.optional {
+			query = `UPDATE ` + ref.table + ` SET ` + ref.column + ` = NULL`
+		}
+		query = h.Rebind(query + ` WHERE id = ? AND ` + ref.dangling())
+		_, err := h.ExecContext(ctx, query, dr.RowID)
+		return err
+	}
+	return fmt.Errorf("unknown reference %s.%s", dr.Table, dr.Column)
+}
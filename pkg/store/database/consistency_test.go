@@ -0,0 +1,86 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/store/database"
+	"github.com/matryer/is"
+)
+
+func TestConsistencyStore(t *testing.T) {
+	runWithDatabases(t, testConsistencyStore)
+}
+
+func testConsistencyStore(t *testing.T, ctx context.Context, dbx *db.DB) {
+	is := is.New(t)
+
+	store := database.New(ctx, dbx)
+	userID, repoID, err := createTestUserAndRepo(ctx, dbx)
+	is.NoErr(err)
+
+	refs, err := store.GetDanglingReferences(ctx, dbx)
+	is.NoErr(err)
+	is.Equal(len(refs), 0)
+
+	var otherUserID, otherRepoID int64
+	is.NoErr(dbx.GetContext(ctx, &otherUserID, dbx.Rebind(`INSERT INTO users (username, admin, created_at, updated_at)
+		VALUES (?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP) RETURNING id;`), "gone", false))
+	is.NoErr(dbx.GetContext(ctx, &otherRepoID, dbx.Rebind(`INSERT INTO repos (name, project_name, description, private, mirror, hidden, user_id, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP) RETURNING id;`),
+		"gone", "", "", false, false, false, userID))
+
+	closedID, err := store.CreateIssue(ctx, dbx, repoID, userID, "Closed by a gone user", "")
+	is.NoErr(err)
+	is.NoErr(store.CloseIssue(ctx, dbx, repoID, closedID, otherUserID, models.IssueCloseReasonCompleted))
+	authoredID, err := store.CreateIssue(ctx, dbx, repoID, otherUserID, "Opened by a gone user", "")
+	is.NoErr(err)
+	orphanID, err := store.CreateIssue(ctx, dbx, otherRepoID, userID, "In a gone repository", "")
+	is.NoErr(err)
+
+	// Delete the user and the repository without cascading.
+	conn, err := dbx.Connx(ctx)
+	is.NoErr(err)
+	defer conn.Close() //nolint:errcheck
+	switch dbx.DriverName() {
+	case "postgres":
+		if _, err := conn.ExecContext(ctx, "SET session_replication_role = replica"); err != nil {
+			t.Skip("can't turn off foreign keys:", err)
+		}
+		defer conn.ExecContext(ctx, "SET session_replication_role = DEFAULT") //nolint:errcheck
+	default:
+		_, err = conn.ExecContext(ctx, "PRAGMA foreign_keys = OFF")
+		is.NoErr(err)
+		defer conn.ExecContext(ctx, "PRAGMA foreign_keys = ON") //nolint:errcheck
+	}
+	_, err = conn.ExecContext(ctx, dbx.Rebind("DELETE FROM users WHERE id = ?"), otherUserID)
+	is.NoErr(err)
+	_, err = conn.ExecContext(ctx, dbx.Rebind("DELETE FROM repos WHERE id = ?"), otherRepoID)
+	is.NoErr(err)
+
+	refs, err = store.GetDanglingReferences(ctx, dbx)
+	is.NoErr(err)
+	is.Equal(len(refs), 3)
+	is.Equal([]interface{}{refs[0].Table, refs[0].Column, refs[0].RowID, refs[0].RefID},
+		[]interface{}{"issues", "repo_id", orphanID, otherRepoID})
+	is.Equal([]interface{}{refs[1].Table, refs[1].Column, refs[1].RowID, refs[1].RefID},
+		[]interface{}{"issues", "author_id", authoredID, otherUserID})
+	is.Equal([]interface{}{refs[2].Table, refs[2].Column, refs[2].RowID, refs[2].RefID},
+		[]interface{}{"issues", "closed_by", closedID, otherUserID})
+
+	for _, ref := range refs {
+		is.NoErr(store.PurgeDanglingReference(ctx, dbx, ref))
+	}
+	refs, err = store.GetDanglingReferences(ctx, dbx)
+	is.NoErr(err)
+	is.Equal(len(refs), 0)
+
+	// Optional references are cleared, the other rows deleted.
+	issue, err := store.GetIssueByID(ctx, dbx, repoID, closedID)
+	is.NoErr(err)
+	is.True(!issue.ClosedBy.Valid)
+	_, err = store.GetIssueByID(ctx, dbx, repoID, authoredID)
+	is.True(err != nil)
+}
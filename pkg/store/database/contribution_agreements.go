@@ -0,0 +1,56 @@
+package database
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/store"
+)
+
+type contributionAgreementStore struct{}
+
+var _ store.ContributionAgreementStore = (*contributionAgreementStore)(nil)
+
+// GetContributionAgreementsByRepoID implements store.ContributionAgreementStore.
+func (*contributionAgreementStore) GetContributionAgreementsByRepoID(ctx context.Context, h db.Handler, repoID int64) (models.ContributionAgreements, error) {
+	query := h.Rebind(`SELECT * FROM contribution_agreements WHERE repo_id = ?;`)
+	var agreements models.ContributionAgreements
+	err := h.GetContext(ctx, &agreements, query, repoID)
+	return agreements, err
+}
+
+// SetContributionAgreements implements store.ContributionAgreementStore.
+func (*contributionAgreementStore) SetContributionAgreements(ctx context.Context, h db.Handler, agreements models.ContributionAgreements) error {
+	query := h.Rebind(`INSERT INTO contribution_agreements (repo_id, require_dco, cla_path, updated_at)
+			VALUES (?, ?, ?, CURRENT_TIMESTAMP)
+			ON CONFLICT (repo_id) DO UPDATE SET
+			require_dco = excluded.require_dco,
+			cla_path = excluded.cla_path,
+			updated_at = CURRENT_TIMESTAMP;`)
+	_, err := h.ExecContext(ctx, query, agreements.RepoID, agreements.RequireDCO, agreements.CLAPath)
+	return err
+}
+
+// DeleteContributionAgreementsByRepoID implements store.ContributionAgreementStore.
+func (*contributionAgreementStore) DeleteContributionAgreementsByRepoID(ctx context.Context, h db.Handler, repoID int64) error {
+	query := h.Rebind(`DELETE FROM contribution_agreements WHERE repo_id = ?;`)
+	_, err := h.ExecContext(ctx, query, repoID)
+	return err
+}
+
+// CreateCLASignature implements store.ContributionAgreementStore.
+func (*contributionAgreementStore) CreateCLASignature(ctx context.Context, h db.Handler, repoID int64, userID int64, documentSha string) error {
+	query := h.Rebind(`INSERT INTO cla_signatures (repo_id, user_id, document_sha)
+			VALUES (?, ?, ?);`)
+	_, err := h.ExecContext(ctx, query, repoID, userID, documentSha)
+	return err
+}
+
+// GetCLASignature implements store.ContributionAgreementStore.
+func (*contributionAgreementStore) GetCLASignature(ctx context.Context, h db.Handler, repoID int64, userID int64, documentSha string) (models.CLASignature, error) {
+	query := h.Rebind(`SELECT * FROM cla_signatures WHERE repo_id = ? AND user_id = ? AND document_sha = ?;`)
+	var sig models.CLASignature
+	err := h.GetContext(ctx, &sig, query, repoID, userID, documentSha)
+	return sig, err
+}
@@ -0,0 +1,43 @@
+package database
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/store"
+)
+
+type crossReferenceStore struct{}
+
+var _ store.CrossReferenceStore = (*crossReferenceStore)(nil)
+
+// AddCrossReference implements store.CrossReferenceStore.
+func (*crossReferenceStore) AddCrossReference(ctx context.Context, h db.Handler, ref models.CrossReference) error {
+	query := h.Rebind(`
+		INSERT INTO cross_references (repo_id, source_type, source_id, target_repo_id, target_type, target_id)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT (source_type, source_id, target_type, target_id) DO NOTHING
+	`)
+	_, err := h.ExecContext(ctx, query, ref.RepoID, ref.SourceType, ref.SourceID, ref.TargetRepoID, ref.TargetType, ref.TargetID)
+	return err
+}
+
+// DeleteCrossReferencesBySource implements store.CrossReferenceStore.
+func (*crossReferenceStore) DeleteCrossReferencesBySource(ctx context.Context, h db.Handler, sourceType string, sourceID int64) error {
+	query := h.Rebind("DELETE FROM cross_references WHERE source_type = ? AND source_id = ?;")
+	_, err := h.ExecContext(ctx, query, sourceType, sourceID)
+	return err
+}
+
+// GetCrossReferencesByTarget implements store.CrossReferenceStore.
+func (*crossReferenceStore) GetCrossReferencesByTarget(ctx context.Context, h db.Handler, targetRepoID int64, targetType string, targetID int64) ([]models.CrossReference, error) {
+	var refs []models.CrossReference
+	query := h.Rebind(`
+		SELECT * FROM cross_references
+		WHERE target_repo_id = ? AND target_type = ? AND target_id = ?
+		ORDER BY id ASC
+	`)
+	err := h.SelectContext(ctx, &refs, query, targetRepoID, targetType, targetID)
+	return refs, err
+}
@@ -2,6 +2,7 @@ package database
 
 import (
 	"context"
+	"strings"
 
 	"github.com/charmbracelet/log/v2"
 	"github.com/charmbracelet/soft-serve/pkg/config"
@@ -24,6 +25,37 @@ type datastore struct {
 	*webhookStore
 	*mergeRequestStore
 	*issueStore
+	*largeTextStore
+	*integrationStore
+	*chatIdentityStore
+	*issueTrackerStore
+	*issueSLAStore
+	*switcherStore
+	*userPreferenceStore
+	*mergeQueueStore
+	*watchStore
+	*activityStore
+	*auditStore
+	*refLogStore
+	*protectedBranchStore
+	*commitRulesStore
+	*contributionAgreementStore
+	*labelStore
+	*repoEventStore
+	*milestoneStore
+	*commitReferenceStore
+	*consistencyStore
+	*oauthStore
+	*attachmentStore
+	*crossReferenceStore
+	*moderationStore
+	*redactionStore
+	*repoVisitStore
+	*statsStore
+	*accountStore
+	*idempotencyKeyStore
+	*mergeRequestCommentStore
+	*mergeRequestApprovalStore
 }
 
 // New returns a new store.Store database.
@@ -37,16 +69,63 @@ func New(ctx context.Context, db *db.DB) store.Store {
 		db:     db,
 		logger: logger,
 
-		settingsStore:     &settingsStore{},
-		repoStore:         &repoStore{},
-		userStore:         &userStore{},
-		collabStore:       &collabStore{},
-		lfsStore:          &lfsStore{},
-		accessTokenStore:  &accessTokenStore{},
-		webhookStore:      &webhookStore{},
-		mergeRequestStore: &mergeRequestStore{},
-		issueStore:        &issueStore{},
+		settingsStore:              &settingsStore{},
+		repoStore:                  &repoStore{},
+		userStore:                  &userStore{},
+		collabStore:                &collabStore{},
+		lfsStore:                   &lfsStore{},
+		accessTokenStore:           &accessTokenStore{},
+		webhookStore:               &webhookStore{},
+		mergeRequestStore:          &mergeRequestStore{},
+		issueStore:                 &issueStore{},
+		largeTextStore:             &largeTextStore{},
+		integrationStore:           &integrationStore{},
+		chatIdentityStore:          &chatIdentityStore{},
+		issueTrackerStore:          &issueTrackerStore{},
+		issueSLAStore:              &issueSLAStore{},
+		switcherStore:              &switcherStore{},
+		userPreferenceStore:        &userPreferenceStore{},
+		mergeQueueStore:            &mergeQueueStore{},
+		watchStore:                 &watchStore{},
+		activityStore:              &activityStore{},
+		auditStore:                 &auditStore{},
+		refLogStore:                &refLogStore{},
+		protectedBranchStore:       &protectedBranchStore{},
+		commitRulesStore:           &commitRulesStore{},
+		contributionAgreementStore: &contributionAgreementStore{},
+		labelStore:                 &labelStore{},
+		repoEventStore:             &repoEventStore{},
+		milestoneStore:             &milestoneStore{},
+		commitReferenceStore:       &commitReferenceStore{},
+		consistencyStore:           &consistencyStore{},
+		oauthStore:                 &oauthStore{},
+		attachmentStore:            &attachmentStore{},
+		crossReferenceStore:        &crossReferenceStore{},
+		moderationStore:            &moderationStore{},
+		redactionStore:             &redactionStore{},
+		repoVisitStore:             &repoVisitStore{},
+		statsStore:                 &statsStore{},
+		accountStore:               &accountStore{},
+		idempotencyKeyStore:        &idempotencyKeyStore{},
+		mergeRequestCommentStore:   &mergeRequestCommentStore{},
+		mergeRequestApprovalStore:  &mergeRequestApprovalStore{},
 	}
 
 	return s
 }
+
+// selectColumns returns the given columns as a comma separated list suitable
+// for a SELECT statement. If alias is not empty, each column is qualified with
+// it.
+func selectColumns(alias string, cols ...string) string {
+	if alias == "" {
+		return strings.Join(cols, ", ")
+	}
+
+	qualified := make([]string, len(cols))
+	for i, c := range cols {
+		qualified[i] = alias + "." + c
+	}
+
+	return strings.Join(qualified, ", ")
+}
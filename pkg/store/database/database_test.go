@@ -0,0 +1,126 @@
+package database_test
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net/url"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/config"
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/migrate"
+)
+
+// openTestDB opens a temporary SQLite database for testing.
+func openTestDB(ctx context.Context, t testing.TB) (*db.DB, error) {
+	dbpath := filepath.Join(t.TempDir(), "test.db")
+	// Use the same pragmas as the server so foreign key cascades apply.
+	dbx, err := db.Open(ctx, "sqlite", dbpath+"?_pragma=busy_timeout(5000)&_pragma=foreign_keys(1)")
+	if err != nil {
+		return nil, err
+	}
+	t.Cleanup(func() {
+		if err := dbx.Close(); err != nil {
+			t.Error(err)
+		}
+	})
+	return dbx, nil
+}
+
+// openPostgresTestDB creates a disposable Postgres database using the given
+// data source and opens it. The database is dropped when the test is done.
+func openPostgresTestDB(ctx context.Context, t testing.TB, dataSource string) (*db.DB, error) {
+	dsn, err := url.Parse(dataSource)
+	if err != nil {
+		return nil, err
+	}
+
+	admin, err := db.Open(ctx, "postgres", dataSource)
+	if err != nil {
+		return nil, err
+	}
+
+	rnd := rand.New(rand.NewSource(time.Now().UnixNano())) //nolint:gosec
+	dbName := fmt.Sprintf("softserve_test_%d", rnd.Int63())
+	if _, err := admin.ExecContext(ctx, "CREATE DATABASE "+dbName); err != nil {
+		admin.Close() //nolint:errcheck
+		return nil, err
+	}
+
+	dsn.Path = "/" + dbName
+	dbx, err := db.Open(ctx, "postgres", dsn.String())
+	if err != nil {
+		admin.Close() //nolint:errcheck
+		return nil, err
+	}
+
+	t.Cleanup(func() {
+		if err := dbx.Close(); err != nil {
+			t.Error(err)
+		}
+		if _, err := admin.ExecContext(context.TODO(), "DROP DATABASE "+dbName); err != nil {
+			t.Error(err)
+		}
+		if err := admin.Close(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	return dbx, nil
+}
+
+// runWithDatabases runs fn against a migrated SQLite database and, when
+// SOFT_SERVE_DB_DRIVER is set to postgres, a migrated Postgres database using
+// SOFT_SERVE_DB_DATA_SOURCE. The Postgres user must be allowed to create and
+// drop databases.
+func runWithDatabases(t *testing.T, fn func(t *testing.T, ctx context.Context, dbx *db.DB)) {
+	ctx := config.WithContext(context.TODO(), config.DefaultConfig())
+
+	env := config.DefaultConfig()
+	if err := env.ParseEnv(); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("sqlite", func(t *testing.T) {
+		dbx, err := openTestDB(ctx, t)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := migrate.Migrate(ctx, dbx); err != nil {
+			t.Fatal(err)
+		}
+		fn(t, ctx, dbx)
+	})
+
+	t.Run("postgres", func(t *testing.T) {
+		if env.DB.Driver != "postgres" {
+			t.Skip("SOFT_SERVE_DB_DRIVER is not postgres")
+		}
+		dbx, err := openPostgresTestDB(ctx, t, env.DB.DataSource)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := migrate.Migrate(ctx, dbx); err != nil {
+			t.Fatal(err)
+		}
+		fn(t, ctx, dbx)
+	})
+}
+
+// createTestUserAndRepo creates a user and a repository owned by it.
+func createTestUserAndRepo(ctx context.Context, dbx *db.DB) (userID int64, repoID int64, err error) {
+	err = dbx.TransactionContext(ctx, func(tx *db.Tx) error {
+		if err := tx.GetContext(ctx, &userID, tx.Rebind(`INSERT INTO users (username, admin, created_at, updated_at)
+			VALUES (?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP) RETURNING id;`), "testuser", false); err != nil {
+			return err
+		}
+
+		return tx.GetContext(ctx, &repoID, tx.Rebind(`INSERT INTO repos (name, project_name, description, private, mirror, hidden, user_id, created_at, updated_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP) RETURNING id;`),
+			"testrepo", "Test Repo", "Test Description", false, false, false, userID)
+	})
+	return
+}
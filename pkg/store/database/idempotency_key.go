@@ -0,0 +1,54 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/store"
+)
+
+type idempotencyKeyStore struct{}
+
+var _ store.IdempotencyKeyStore = (*idempotencyKeyStore)(nil)
+
+// CreateIdempotencyKey implements store.IdempotencyKeyStore.
+func (*idempotencyKeyStore) CreateIdempotencyKey(ctx context.Context, h db.Handler, userID int64, key string, requestHash string) error {
+	query := h.Rebind(`INSERT INTO idempotency_keys (user_id, key, request_hash, created_at)
+			VALUES (?, ?, ?, CURRENT_TIMESTAMP);`)
+	_, err := h.ExecContext(ctx, query, userID, key, requestHash)
+	return err
+}
+
+// GetIdempotencyKey implements store.IdempotencyKeyStore.
+func (*idempotencyKeyStore) GetIdempotencyKey(ctx context.Context, h db.Handler, userID int64, key string) (models.IdempotencyKey, error) {
+	var k models.IdempotencyKey
+	query := h.Rebind(`SELECT * FROM idempotency_keys WHERE user_id = ? AND key = ?;`)
+	err := h.GetContext(ctx, &k, query, userID, key)
+	return k, err
+}
+
+// SetIdempotencyKeyResponse implements store.IdempotencyKeyStore.
+func (*idempotencyKeyStore) SetIdempotencyKeyResponse(ctx context.Context, h db.Handler, userID int64, key string, status int, response string) error {
+	query := h.Rebind(`UPDATE idempotency_keys SET status = ?, response = ? WHERE user_id = ? AND key = ?;`)
+	_, err := h.ExecContext(ctx, query, status, response, userID, key)
+	return err
+}
+
+// DeleteIdempotencyKey implements store.IdempotencyKeyStore.
+func (*idempotencyKeyStore) DeleteIdempotencyKey(ctx context.Context, h db.Handler, userID int64, key string) error {
+	query := h.Rebind(`DELETE FROM idempotency_keys WHERE user_id = ? AND key = ?;`)
+	_, err := h.ExecContext(ctx, query, userID, key)
+	return err
+}
+
+// DeleteIdempotencyKeysBefore implements store.IdempotencyKeyStore.
+func (*idempotencyKeyStore) DeleteIdempotencyKeysBefore(ctx context.Context, h db.Handler, t time.Time) (int64, error) {
+	query := h.Rebind(`DELETE FROM idempotency_keys WHERE created_at < ?;`)
+	res, err := h.ExecContext(ctx, query, t.UTC())
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
@@ -0,0 +1,54 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/store/database"
+	"github.com/matryer/is"
+)
+
+func TestIdempotencyKeyStore(t *testing.T) {
+	runWithDatabases(t, testIdempotencyKeyStore)
+}
+
+func testIdempotencyKeyStore(t *testing.T, ctx context.Context, dbx *db.DB) {
+	is := is.New(t)
+
+	store := database.New(ctx, dbx)
+	userID, _, err := createTestUserAndRepo(ctx, dbx)
+	is.NoErr(err)
+
+	// A key is reserved once per user, without a response until it's set.
+	is.NoErr(store.CreateIdempotencyKey(ctx, dbx, userID, "key1", "hash1"))
+	err = db.WrapError(store.CreateIdempotencyKey(ctx, dbx, userID, "key1", "hash1"))
+	is.True(errors.Is(err, db.ErrDuplicateKey))
+
+	k, err := store.GetIdempotencyKey(ctx, dbx, userID, "key1")
+	is.NoErr(err)
+	is.Equal(k.RequestHash, "hash1")
+	is.True(!k.Response.Valid)
+
+	is.NoErr(store.SetIdempotencyKeyResponse(ctx, dbx, userID, "key1", 201, `{"id":1}`))
+	k, err = store.GetIdempotencyKey(ctx, dbx, userID, "key1")
+	is.NoErr(err)
+	is.Equal(k.Status, 201)
+	is.Equal(k.Response.String, `{"id":1}`)
+
+	// Deleting a key releases it.
+	is.NoErr(store.DeleteIdempotencyKey(ctx, dbx, userID, "key1"))
+	_, err = store.GetIdempotencyKey(ctx, dbx, userID, "key1")
+	is.True(errors.Is(db.WrapError(err), db.ErrRecordNotFound))
+
+	// Expired keys are purged.
+	is.NoErr(store.CreateIdempotencyKey(ctx, dbx, userID, "key2", "hash2"))
+	n, err := store.DeleteIdempotencyKeysBefore(ctx, dbx, time.Now().Add(-time.Hour))
+	is.NoErr(err)
+	is.Equal(n, int64(0))
+	n, err = store.DeleteIdempotencyKeysBefore(ctx, dbx, time.Now().Add(time.Hour))
+	is.NoErr(err)
+	is.Equal(n, int64(1))
+}
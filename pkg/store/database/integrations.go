@@ -0,0 +1,94 @@
+package database
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/store"
+	"github.com/jmoiron/sqlx"
+)
+
+type integrationStore struct{}
+
+var _ store.IntegrationStore = (*integrationStore)(nil)
+
+// GetIntegrationByID implements store.IntegrationStore.
+func (*integrationStore) GetIntegrationByID(ctx context.Context, h db.Handler, repoID int64, id int64) (models.Integration, error) {
+	query := h.Rebind(`SELECT * FROM integrations WHERE repo_id = ? AND id = ?;`)
+	var i models.Integration
+	err := h.GetContext(ctx, &i, query, repoID, id)
+	return i, err
+}
+
+// GetIntegrationsByRepoID implements store.IntegrationStore.
+func (*integrationStore) GetIntegrationsByRepoID(ctx context.Context, h db.Handler, repoID int64) ([]models.Integration, error) {
+	query := h.Rebind(`SELECT * FROM integrations WHERE repo_id = ? ORDER BY id;`)
+	var is []models.Integration
+	err := h.SelectContext(ctx, &is, query, repoID)
+	return is, err
+}
+
+// GetActiveIntegrationsByRepoIDWhereEvent implements store.IntegrationStore.
+func (*integrationStore) GetActiveIntegrationsByRepoIDWhereEvent(ctx context.Context, h db.Handler, repoID int64, events []int) ([]models.Integration, error) {
+	query, args, err := sqlx.In(`SELECT integrations.*
+			FROM integrations
+			INNER JOIN integration_events ON integrations.id = integration_events.integration_id
+			WHERE integrations.repo_id = ? AND integrations.active = ? AND integration_events.event IN (?);`, repoID, true, events)
+	if err != nil {
+		return nil, err
+	}
+
+	query = h.Rebind(query)
+	var is []models.Integration
+	err = h.SelectContext(ctx, &is, query, args...)
+	return is, err
+}
+
+// CreateIntegration implements store.IntegrationStore.
+func (*integrationStore) CreateIntegration(ctx context.Context, h db.Handler, repoID int64, kind int, url string, secret string, template string, active bool) (int64, error) {
+	var id int64
+	query := h.Rebind(`INSERT INTO integrations (repo_id, kind, url, secret, template, active, updated_at)
+			VALUES (?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP) RETURNING id;`)
+	err := h.GetContext(ctx, &id, query, repoID, kind, url, secret, template, active)
+	return id, err
+}
+
+// UpdateIntegrationByID implements store.IntegrationStore.
+func (*integrationStore) UpdateIntegrationByID(ctx context.Context, h db.Handler, repoID int64, id int64, url string, secret string, template string, active bool) error {
+	query := h.Rebind(`UPDATE integrations SET url = ?, secret = ?, template = ?, active = ?, updated_at = CURRENT_TIMESTAMP WHERE repo_id = ? AND id = ?;`)
+	_, err := h.ExecContext(ctx, query, url, secret, template, active, repoID, id)
+	return err
+}
+
+// DeleteIntegrationForRepoByID implements store.IntegrationStore.
+func (*integrationStore) DeleteIntegrationForRepoByID(ctx context.Context, h db.Handler, repoID int64, id int64) error {
+	query := h.Rebind(`DELETE FROM integrations WHERE repo_id = ? AND id = ?;`)
+	_, err := h.ExecContext(ctx, query, repoID, id)
+	return err
+}
+
+// GetIntegrationEventsByIntegrationID implements store.IntegrationStore.
+func (*integrationStore) GetIntegrationEventsByIntegrationID(ctx context.Context, h db.Handler, integrationID int64) ([]models.IntegrationEvent, error) {
+	query := h.Rebind(`SELECT * FROM integration_events WHERE integration_id = ? ORDER BY event;`)
+	var ies []models.IntegrationEvent
+	err := h.SelectContext(ctx, &ies, query, integrationID)
+	return ies, err
+}
+
+// SetIntegrationEvents implements store.IntegrationStore.
+func (*integrationStore) SetIntegrationEvents(ctx context.Context, h db.Handler, integrationID int64, events []int) error {
+	query := h.Rebind(`DELETE FROM integration_events WHERE integration_id = ?;`)
+	if _, err := h.ExecContext(ctx, query, integrationID); err != nil {
+		return err
+	}
+
+	query = h.Rebind(`INSERT INTO integration_events (integration_id, event) VALUES (?, ?);`)
+	for _, event := range events {
+		if _, err := h.ExecContext(ctx, query, integrationID, event); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/store/database"
+	"github.com/matryer/is"
+)
+
+func TestIntegrationStore(t *testing.T) {
+	runWithDatabases(t, testIntegrationStore)
+}
+
+func testIntegrationStore(t *testing.T, ctx context.Context, dbx *db.DB) {
+	is := is.New(t)
+
+	store := database.New(ctx, dbx)
+	_, repoID, err := createTestUserAndRepo(ctx, dbx)
+	is.NoErr(err)
+
+	var id int64
+	err = dbx.TransactionContext(ctx, func(tx *db.Tx) error {
+		var err error
+		id, err = store.CreateIntegration(ctx, tx, repoID, 0, "https://hooks.example.com/a", "", "", true)
+		if err != nil {
+			return err
+		}
+		return store.SetIntegrationEvents(ctx, tx, id, []int{1, 2})
+	})
+	is.NoErr(err)
+	is.True(id > 0)
+
+	t.Run("GetActiveIntegrationsByRepoIDWhereEvent", func(t *testing.T) {
+		is := is.New(t)
+
+		is2, err := store.GetActiveIntegrationsByRepoIDWhereEvent(ctx, dbx, repoID, []int{2})
+		is.NoErr(err)
+		is.Equal(len(is2), 1)
+		is.Equal(is2[0].ID, id)
+
+		none, err := store.GetActiveIntegrationsByRepoIDWhereEvent(ctx, dbx, repoID, []int{3})
+		is.NoErr(err)
+		is.Equal(len(none), 0)
+	})
+
+	t.Run("SetIntegrationEvents", func(t *testing.T) {
+		is := is.New(t)
+
+		err := store.SetIntegrationEvents(ctx, dbx, id, []int{3})
+		is.NoErr(err)
+
+		events, err := store.GetIntegrationEventsByIntegrationID(ctx, dbx, id)
+		is.NoErr(err)
+		is.Equal(len(events), 1)
+		is.Equal(events[0].Event, 3)
+	})
+
+	t.Run("UpdateIntegrationByID", func(t *testing.T) {
+		is := is.New(t)
+
+		err := store.UpdateIntegrationByID(ctx, dbx, repoID, id, "https://hooks.example.com/b", "token", "{{.Action}}", false)
+		is.NoErr(err)
+
+		i, err := store.GetIntegrationByID(ctx, dbx, repoID, id)
+		is.NoErr(err)
+		is.Equal(i.URL, "https://hooks.example.com/b")
+		is.Equal(i.Secret, "token")
+		is.Equal(i.Template, "{{.Action}}")
+		is.True(!i.Active)
+
+		active, err := store.GetActiveIntegrationsByRepoIDWhereEvent(ctx, dbx, repoID, []int{3})
+		is.NoErr(err)
+		is.Equal(len(active), 0) // inactive integrations are not notified
+	})
+
+	t.Run("DeleteIntegrationForRepoByID", func(t *testing.T) {
+		is := is.New(t)
+
+		err := store.DeleteIntegrationForRepoByID(ctx, dbx, repoID, id)
+		is.NoErr(err)
+
+		all, err := store.GetIntegrationsByRepoID(ctx, dbx, repoID)
+		is.NoErr(err)
+		is.Equal(len(all), 0)
+
+		events, err := store.GetIntegrationEventsByIntegrationID(ctx, dbx, id)
+		is.NoErr(err)
+		is.Equal(len(events), 0)
+	})
+}
@@ -0,0 +1,46 @@
+package database
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/store"
+)
+
+type issueSLAStore struct{}
+
+var _ store.IssueSLAStore = (*issueSLAStore)(nil)
+
+// GetIssueSLAs implements store.IssueSLAStore.
+func (*issueSLAStore) GetIssueSLAs(ctx context.Context, h db.Handler) ([]models.IssueSLA, error) {
+	query := h.Rebind(`SELECT * FROM issue_slas ORDER BY repo_id;`)
+	var slas []models.IssueSLA
+	err := h.SelectContext(ctx, &slas, query)
+	return slas, err
+}
+
+// GetIssueSLAByRepoID implements store.IssueSLAStore.
+func (*issueSLAStore) GetIssueSLAByRepoID(ctx context.Context, h db.Handler, repoID int64) (models.IssueSLA, error) {
+	query := h.Rebind(`SELECT * FROM issue_slas WHERE repo_id = ?;`)
+	var sla models.IssueSLA
+	err := h.GetContext(ctx, &sla, query, repoID)
+	return sla, err
+}
+
+// SetIssueSLA implements store.IssueSLAStore.
+func (*issueSLAStore) SetIssueSLA(ctx context.Context, h db.Handler, repoID int64, responseHours int) error {
+	query := h.Rebind(`INSERT INTO issue_slas (repo_id, response_hours, updated_at)
+			VALUES (?, ?, CURRENT_TIMESTAMP)
+			ON CONFLICT (repo_id) DO UPDATE SET
+			response_hours = excluded.response_hours, updated_at = CURRENT_TIMESTAMP;`)
+	_, err := h.ExecContext(ctx, query, repoID, responseHours)
+	return err
+}
+
+// DeleteIssueSLAByRepoID implements store.IssueSLAStore.
+func (*issueSLAStore) DeleteIssueSLAByRepoID(ctx context.Context, h db.Handler, repoID int64) error {
+	query := h.Rebind(`DELETE FROM issue_slas WHERE repo_id = ?;`)
+	_, err := h.ExecContext(ctx, query, repoID)
+	return err
+}
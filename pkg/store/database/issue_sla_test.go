@@ -0,0 +1,59 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/store/database"
+	"github.com/matryer/is"
+)
+
+func TestIssueSLAStore(t *testing.T) {
+	runWithDatabases(t, testIssueSLAStore)
+}
+
+func testIssueSLAStore(t *testing.T, ctx context.Context, dbx *db.DB) {
+	is := is.New(t)
+
+	store := database.New(ctx, dbx)
+	userID, repoID, err := createTestUserAndRepo(ctx, dbx)
+	is.NoErr(err)
+
+	err = store.SetIssueSLA(ctx, dbx, repoID, 24)
+	is.NoErr(err)
+
+	sla, err := store.GetIssueSLAByRepoID(ctx, dbx, repoID)
+	is.NoErr(err)
+	is.Equal(sla.ResponseHours, 24)
+
+	// Setting the SLA again replaces it.
+	err = store.SetIssueSLA(ctx, dbx, repoID, 4)
+	is.NoErr(err)
+
+	slas, err := store.GetIssueSLAs(ctx, dbx)
+	is.NoErr(err)
+	is.Equal(len(slas), 1)
+	is.Equal(slas[0].ResponseHours, 4)
+
+	// Only the first response by someone other than the author counts.
+	issueID, err := store.CreateIssue(ctx, dbx, repoID, userID, "Help", "Description")
+	is.NoErr(err)
+	is.NoErr(store.SetIssueResponded(ctx, dbx, repoID, issueID, userID))
+	issue, err := store.GetIssueByID(ctx, dbx, repoID, issueID)
+	is.NoErr(err)
+	is.True(!issue.RespondedAt.Valid)
+
+	is.NoErr(store.SetIssueResponded(ctx, dbx, repoID, issueID, userID+1))
+	is.NoErr(store.SetIssueSLABreached(ctx, dbx, repoID, issueID))
+	issue, err = store.GetIssueByID(ctx, dbx, repoID, issueID)
+	is.NoErr(err)
+	is.True(issue.RespondedAt.Valid)
+	is.True(issue.SLABreachedAt.Valid)
+
+	err = store.DeleteIssueSLAByRepoID(ctx, dbx, repoID)
+	is.NoErr(err)
+
+	_, err = store.GetIssueSLAByRepoID(ctx, dbx, repoID)
+	is.True(err != nil) // SLA should be deleted
+}
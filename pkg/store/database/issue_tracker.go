@@ -0,0 +1,40 @@
+package database
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/store"
+)
+
+type issueTrackerStore struct{}
+
+var _ store.IssueTrackerStore = (*issueTrackerStore)(nil)
+
+// GetIssueTrackerByRepoID implements store.IssueTrackerStore.
+func (*issueTrackerStore) GetIssueTrackerByRepoID(ctx context.Context, h db.Handler, repoID int64) (models.IssueTracker, error) {
+	query := h.Rebind(`SELECT * FROM issue_trackers WHERE repo_id = ?;`)
+	var t models.IssueTracker
+	err := h.GetContext(ctx, &t, query, repoID)
+	return t, err
+}
+
+// SetIssueTracker implements store.IssueTrackerStore.
+func (*issueTrackerStore) SetIssueTracker(ctx context.Context, h db.Handler, repoID int64, kind int, url string, projects string, username string, token string, doneState string) error {
+	query := h.Rebind(`INSERT INTO issue_trackers (repo_id, kind, url, projects, username, token, done_state, updated_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+			ON CONFLICT (repo_id) DO UPDATE SET
+			kind = excluded.kind, url = excluded.url, projects = excluded.projects,
+			username = excluded.username, token = excluded.token,
+			done_state = excluded.done_state, updated_at = CURRENT_TIMESTAMP;`)
+	_, err := h.ExecContext(ctx, query, repoID, kind, url, projects, username, token, doneState)
+	return err
+}
+
+// DeleteIssueTrackerByRepoID implements store.IssueTrackerStore.
+func (*issueTrackerStore) DeleteIssueTrackerByRepoID(ctx context.Context, h db.Handler, repoID int64) error {
+	query := h.Rebind(`DELETE FROM issue_trackers WHERE repo_id = ?;`)
+	_, err := h.ExecContext(ctx, query, repoID)
+	return err
+}
@@ -0,0 +1,48 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/store/database"
+	"github.com/matryer/is"
+)
+
+func TestIssueTrackerStore(t *testing.T) {
+	runWithDatabases(t, testIssueTrackerStore)
+}
+
+func testIssueTrackerStore(t *testing.T, ctx context.Context, dbx *db.DB) {
+	is := is.New(t)
+
+	store := database.New(ctx, dbx)
+	_, repoID, err := createTestUserAndRepo(ctx, dbx)
+	is.NoErr(err)
+
+	err = store.SetIssueTracker(ctx, dbx, repoID, 0, "https://jira.example.com", "PROJ", "me", "token", "Done")
+	is.NoErr(err)
+
+	tr, err := store.GetIssueTrackerByRepoID(ctx, dbx, repoID)
+	is.NoErr(err)
+	is.Equal(tr.URL, "https://jira.example.com")
+	is.Equal(tr.Projects, "PROJ")
+
+	// Setting the tracker again replaces it.
+	err = store.SetIssueTracker(ctx, dbx, repoID, 1, "https://youtrack.example.com", "PROJ,OPS", "", "perm:token", "Fixed")
+	is.NoErr(err)
+
+	tr, err = store.GetIssueTrackerByRepoID(ctx, dbx, repoID)
+	is.NoErr(err)
+	is.Equal(tr.Kind, 1)
+	is.Equal(tr.URL, "https://youtrack.example.com")
+	is.Equal(tr.Projects, "PROJ,OPS")
+	is.Equal(tr.Username, "")
+	is.Equal(tr.DoneState, "Fixed")
+
+	err = store.DeleteIssueTrackerByRepoID(ctx, dbx, repoID)
+	is.NoErr(err)
+
+	_, err = store.GetIssueTrackerByRepoID(ctx, dbx, repoID)
+	is.True(err != nil) // tracker should be deleted
+}
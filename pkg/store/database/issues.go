@@ -3,6 +3,7 @@ package database
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/charmbracelet/soft-serve/pkg/db"
 	"github.com/charmbracelet/soft-serve/pkg/db/models"
@@ -13,52 +14,142 @@ type issueStore struct{}
 
 var _ store.IssueStore = (*issueStore)(nil)
 
+// issueColumns are the columns selected when querying issues.
+var issueColumns = []string{
+	"id",
+	"repo_id",
+	"number",
+	"title",
+	"description",
+	"state",
+	"author_id",
+	"closed_by",
+	"closed_at",
+	"stale_at",
+	"responded_at",
+	"sla_breached_at",
+	"created_at",
+	"updated_at",
+	"description_truncated",
+	"first_contribution",
+	"external_id",
+	"locked",
+	"pinned",
+	"hidden",
+	"closed_by_commit",
+	"edited_by_id",
+	"edited_at",
+	"draft",
+	"close_reason",
+}
+
 // GetIssueByID implements store.IssueStore.
 func (*issueStore) GetIssueByID(ctx context.Context, h db.Handler, repoID int64, id int64) (models.Issue, error) {
 	var issue models.Issue
 	query := h.Rebind(`
-		SELECT * FROM issues
+		SELECT ` + selectColumns("", issueColumns...) + ` FROM issues
 		WHERE repo_id = ? AND id = ?
 	`)
 	err := h.GetContext(ctx, &issue, query, repoID, id)
 	return issue, err
 }
 
-// GetIssuesByRepoID implements store.IssueStore.
-func (*issueStore) GetIssuesByRepoID(ctx context.Context, h db.Handler, repoID int64) ([]models.Issue, error) {
-	var issues []models.Issue
+// GetIssueByNumber implements store.IssueStore.
+func (*issueStore) GetIssueByNumber(ctx context.Context, h db.Handler, repoID int64, number int64) (models.Issue, error) {
+	var issue models.Issue
 	query := h.Rebind(`
-		SELECT * FROM issues
-		WHERE repo_id = ?
-		ORDER BY created_at DESC
+		SELECT ` + selectColumns("", issueColumns...) + ` FROM issues
+		WHERE repo_id = ? AND number = ?
 	`)
-	err := h.SelectContext(ctx, &issues, query, repoID)
+	err := h.GetContext(ctx, &issue, query, repoID, number)
+	return issue, err
+}
+
+// GetIssueByExternalID implements store.IssueStore.
+func (*issueStore) GetIssueByExternalID(ctx context.Context, h db.Handler, repoID int64, externalID string) (models.Issue, error) {
+	var issue models.Issue
+	query := h.Rebind(`
+		SELECT ` + selectColumns("", issueColumns...) + ` FROM issues
+		WHERE repo_id = ? AND external_id = ?
+	`)
+	err := h.GetContext(ctx, &issue, query, repoID, externalID)
+	return issue, err
+}
+
+// GetIssuesByRepoID implements store.IssueStore.
+func (*issueStore) GetIssuesByRepoID(ctx context.Context, h db.Handler, repoID int64, order models.ListOrder, limit int, after int64) ([]models.Issue, error) {
+	var issues []models.Issue
+	query, args := pageQuery("issues", issueColumns, "number", repoID, nil, order, limit, after)
+	err := h.SelectContext(ctx, &issues, h.Rebind(query), args...)
 	return issues, err
 }
 
 // GetIssuesByRepoIDAndState implements store.IssueStore.
-func (*issueStore) GetIssuesByRepoIDAndState(ctx context.Context, h db.Handler, repoID int64, state models.IssueState) ([]models.Issue, error) {
+func (*issueStore) GetIssuesByRepoIDAndState(ctx context.Context, h db.Handler, repoID int64, state models.IssueState, order models.ListOrder, limit int, after int64) ([]models.Issue, error) {
 	var issues []models.Issue
-	query := h.Rebind(`
-		SELECT * FROM issues
-		WHERE repo_id = ? AND state = ?
-		ORDER BY created_at DESC
-	`)
-	err := h.SelectContext(ctx, &issues, query, repoID, state)
+	st := int(state)
+	query, args := pageQuery("issues", issueColumns, "number", repoID, &st, order, limit, after)
+	err := h.SelectContext(ctx, &issues, h.Rebind(query), args...)
+	return issues, err
+}
+
+// SearchIssues implements store.IssueStore.
+func (*issueStore) SearchIssues(ctx context.Context, h db.Handler, repoIDs []int64, query string, state *models.IssueState, authorID int64, viewerID int64, limit int, offset int) ([]models.Issue, error) {
+	if len(repoIDs) == 0 {
+		return nil, nil
+	}
+
+	var st *int
+	if state != nil {
+		s := int(*state)
+		st = &s
+	}
+
+	q, args, err := searchQuery("issues", issueColumns, repoIDs, query, st, authorID, limit, offset,
+		"(draft = ? OR author_id = ?)", false, viewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	var issues []models.Issue
+	err = h.SelectContext(ctx, &issues, h.Rebind(q), args...)
 	return issues, err
 }
 
 // CreateIssue implements store.IssueStore.
 func (*issueStore) CreateIssue(ctx context.Context, h db.Handler, repoID int64, authorID int64, title string, description string) (int64, error) {
+	// Issues are numbered per repository. Numbers of deleted issues aren't
+	// reused.
+	var number int64
 	query := h.Rebind(`
-		INSERT INTO issues (repo_id, author_id, title, description, state, updated_at)
-		VALUES (?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+		INSERT INTO issue_sequences (repo_id, last_number)
+		VALUES (?, 1)
+		ON CONFLICT (repo_id) DO UPDATE SET last_number = issue_sequences.last_number + 1
+		RETURNING last_number
 	`)
-	res, err := h.ExecContext(ctx, query, repoID, authorID, title, description, models.IssueStateOpen)
-	if err != nil {
+	if err := h.GetContext(ctx, &number, query, repoID); err != nil {
 		return 0, err
 	}
-	return res.LastInsertId()
+
+	query = h.Rebind(`
+		INSERT INTO issues (repo_id, number, author_id, title, description, state, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+		RETURNING id
+	`)
+	var id int64
+	err := h.GetContext(ctx, &id, query, repoID, number, authorID, title, description, models.IssueStateOpen)
+	return id, err
+}
+
+// SetIssueExternalID implements store.IssueStore.
+func (*issueStore) SetIssueExternalID(ctx context.Context, h db.Handler, repoID int64, id int64, externalID string) error {
+	query := h.Rebind(`
+		UPDATE issues
+		SET external_id = ?
+		WHERE repo_id = ? AND id = ?
+	`)
+	_, err := h.ExecContext(ctx, query, externalID, repoID, id)
+	return err
 }
 
 // UpdateIssue implements store.IssueStore.
@@ -73,13 +164,24 @@ func (*issueStore) UpdateIssue(ctx context.Context, h db.Handler, repoID int64,
 }
 
 // CloseIssue implements store.IssueStore.
-func (*issueStore) CloseIssue(ctx context.Context, h db.Handler, repoID int64, id int64, closedBy int64) error {
+func (*issueStore) CloseIssue(ctx context.Context, h db.Handler, repoID int64, id int64, closedBy int64, reason models.IssueCloseReason) error {
+	query := h.Rebind(`
+		UPDATE issues
+		SET state = ?, closed_by = ?, close_reason = ?, closed_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+		WHERE repo_id = ? AND id = ? AND state = ?
+	`)
+	_, err := h.ExecContext(ctx, query, models.IssueStateClosed, closedBy, reason, repoID, id, models.IssueStateOpen)
+	return err
+}
+
+// CloseIssueByCommit implements store.IssueStore.
+func (*issueStore) CloseIssueByCommit(ctx context.Context, h db.Handler, repoID int64, id int64, closedBy int64, sha string) error {
 	query := h.Rebind(`
 		UPDATE issues
-		SET state = ?, closed_by = ?, closed_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+		SET state = ?, closed_by = ?, closed_by_commit = ?, close_reason = ?, closed_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
 		WHERE repo_id = ? AND id = ? AND state = ?
 	`)
-	_, err := h.ExecContext(ctx, query, models.IssueStateClosed, closedBy, repoID, id, models.IssueStateOpen)
+	_, err := h.ExecContext(ctx, query, models.IssueStateClosed, closedBy, sha, models.IssueCloseReasonCompleted, repoID, id, models.IssueStateOpen)
 	return err
 }
 
@@ -87,13 +189,198 @@ func (*issueStore) CloseIssue(ctx context.Context, h db.Handler, repoID int64, i
 func (*issueStore) ReopenIssue(ctx context.Context, h db.Handler, repoID int64, id int64) error {
 	query := h.Rebind(`
 		UPDATE issues
-		SET state = ?, closed_by = NULL, closed_at = NULL, updated_at = CURRENT_TIMESTAMP
+		SET state = ?, closed_by = NULL, closed_at = NULL, closed_by_commit = NULL, close_reason = '', stale_at = NULL, updated_at = CURRENT_TIMESTAMP
 		WHERE repo_id = ? AND id = ? AND state = ?
 	`)
 	_, err := h.ExecContext(ctx, query, models.IssueStateOpen, repoID, id, models.IssueStateClosed)
 	return err
 }
 
+// CountIssuesByRepoID implements store.IssueStore.
+func (*issueStore) CountIssuesByRepoID(ctx context.Context, h db.Handler, repoID int64, state *models.IssueState) (int64, error) {
+	query := `SELECT COUNT(*) FROM issues WHERE repo_id = ?`
+	args := []interface{}{repoID}
+	if state != nil {
+		query += ` AND state = ?`
+		args = append(args, int(*state))
+	}
+	var count int64
+	err := h.GetContext(ctx, &count, h.Rebind(query), args...)
+	return count, err
+}
+
+// CountOthersDraftIssuesByRepoID implements store.IssueStore.
+func (*issueStore) CountOthersDraftIssuesByRepoID(ctx context.Context, h db.Handler, repoID int64, state *models.IssueState, authorID int64) (int64, error) {
+	query := `SELECT COUNT(*) FROM issues WHERE repo_id = ? AND draft = ? AND author_id != ?`
+	args := []interface{}{repoID, true, authorID}
+	if state != nil {
+		query += ` AND state = ?`
+		args = append(args, int(*state))
+	}
+	var count int64
+	err := h.GetContext(ctx, &count, h.Rebind(query), args...)
+	return count, err
+}
+
+// CountIssuesByAuthorID implements store.IssueStore.
+func (*issueStore) CountIssuesByAuthorID(ctx context.Context, h db.Handler, repoID int64, authorID int64) (int64, error) {
+	query := h.Rebind(`SELECT COUNT(*) FROM issues WHERE repo_id = ? AND author_id = ?`)
+	var count int64
+	err := h.GetContext(ctx, &count, query, repoID, authorID)
+	return count, err
+}
+
+// SetIssueFirstContribution implements store.IssueStore.
+func (*issueStore) SetIssueFirstContribution(ctx context.Context, h db.Handler, repoID int64, id int64) error {
+	query := h.Rebind(`
+		UPDATE issues
+		SET first_contribution = ?
+		WHERE repo_id = ? AND id = ?
+	`)
+	_, err := h.ExecContext(ctx, query, true, repoID, id)
+	return err
+}
+
+// SetIssueLocked implements store.IssueStore.
+func (*issueStore) SetIssueLocked(ctx context.Context, h db.Handler, repoID int64, id int64, locked bool) error {
+	query := h.Rebind(`
+		UPDATE issues
+		SET locked = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE repo_id = ? AND id = ?
+	`)
+	_, err := h.ExecContext(ctx, query, locked, repoID, id)
+	return err
+}
+
+// SetIssueDraft implements store.IssueStore.
+func (*issueStore) SetIssueDraft(ctx context.Context, h db.Handler, repoID int64, id int64, draft bool) error {
+	set := "draft = ?"
+	if !draft {
+		set += ", created_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP"
+	}
+	query := h.Rebind(`
+		UPDATE issues
+		SET ` + set + `
+		WHERE repo_id = ? AND id = ?
+	`)
+	_, err := h.ExecContext(ctx, query, draft, repoID, id)
+	return err
+}
+
+// SetIssueHidden implements store.IssueStore.
+func (*issueStore) SetIssueHidden(ctx context.Context, h db.Handler, repoID int64, id int64, hidden bool) error {
+	query := h.Rebind(`
+		UPDATE issues
+		SET hidden = ?
+		WHERE repo_id = ? AND id = ?
+	`)
+	_, err := h.ExecContext(ctx, query, hidden, repoID, id)
+	return err
+}
+
+// SetIssueEditedBy implements store.IssueStore.
+func (*issueStore) SetIssueEditedBy(ctx context.Context, h db.Handler, repoID int64, id int64, userID int64) error {
+	query := h.Rebind(`
+		UPDATE issues
+		SET edited_by_id = ?, edited_at = CURRENT_TIMESTAMP
+		WHERE repo_id = ? AND id = ?
+	`)
+	_, err := h.ExecContext(ctx, query, userID, repoID, id)
+	return err
+}
+
+// SetIssuePinned implements store.IssueStore.
+func (*issueStore) SetIssuePinned(ctx context.Context, h db.Handler, repoID int64, id int64, pinned bool) error {
+	if !pinned {
+		query := h.Rebind(`
+			UPDATE issues
+			SET pinned = NULL
+			WHERE repo_id = ? AND id = ?
+		`)
+		_, err := h.ExecContext(ctx, query, repoID, id)
+		return err
+	}
+
+	query := h.Rebind(`
+		UPDATE issues
+		SET pinned = (SELECT COALESCE(MAX(pinned), 0) + 1 FROM issues WHERE repo_id = ?)
+		WHERE repo_id = ? AND id = ?
+	`)
+	_, err := h.ExecContext(ctx, query, repoID, repoID, id)
+	return err
+}
+
+// GetPinnedIssuesByRepoID implements store.IssueStore.
+func (*issueStore) GetPinnedIssuesByRepoID(ctx context.Context, h db.Handler, repoID int64) ([]models.Issue, error) {
+	var issues []models.Issue
+	query := h.Rebind(`
+		SELECT ` + selectColumns("", issueColumns...) + ` FROM issues
+		WHERE repo_id = ? AND pinned IS NOT NULL
+		ORDER BY pinned ASC
+	`)
+	err := h.SelectContext(ctx, &issues, query, repoID)
+	return issues, err
+}
+
+// SetIssueStale implements store.IssueStore.
+func (*issueStore) SetIssueStale(ctx context.Context, h db.Handler, repoID int64, id int64, stale bool) error {
+	set := "stale_at = NULL"
+	if stale {
+		set = "stale_at = CURRENT_TIMESTAMP"
+	}
+	query := h.Rebind(`
+		UPDATE issues
+		SET ` + set + `
+		WHERE repo_id = ? AND id = ? AND state = ?
+	`)
+	_, err := h.ExecContext(ctx, query, repoID, id, models.IssueStateOpen)
+	return err
+}
+
+// SetIssueResponded implements store.IssueStore.
+func (*issueStore) SetIssueResponded(ctx context.Context, h db.Handler, repoID int64, id int64, userID int64) error {
+	query := h.Rebind(`
+		UPDATE issues
+		SET responded_at = CURRENT_TIMESTAMP
+		WHERE repo_id = ? AND id = ? AND author_id <> ? AND responded_at IS NULL
+	`)
+	_, err := h.ExecContext(ctx, query, repoID, id, userID)
+	return err
+}
+
+// SetIssueSLABreached implements store.IssueStore.
+func (*issueStore) SetIssueSLABreached(ctx context.Context, h db.Handler, repoID int64, id int64) error {
+	query := h.Rebind(`
+		UPDATE issues
+		SET sla_breached_at = CURRENT_TIMESTAMP
+		WHERE repo_id = ? AND id = ? AND sla_breached_at IS NULL
+	`)
+	_, err := h.ExecContext(ctx, query, repoID, id)
+	return err
+}
+
+// CloseStaleIssue implements store.IssueStore.
+func (*issueStore) CloseStaleIssue(ctx context.Context, h db.Handler, repoID int64, id int64) error {
+	query := h.Rebind(`
+		UPDATE issues
+		SET state = ?, closed_by = NULL, close_reason = ?, closed_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+		WHERE repo_id = ? AND id = ? AND state = ? AND stale_at IS NOT NULL
+	`)
+	_, err := h.ExecContext(ctx, query, models.IssueStateClosed, models.IssueCloseReasonNotPlanned, repoID, id, models.IssueStateOpen)
+	return err
+}
+
+// GetIssuesClosedBefore implements store.IssueStore.
+func (*issueStore) GetIssuesClosedBefore(ctx context.Context, h db.Handler, t time.Time) ([]models.Issue, error) {
+	var issues []models.Issue
+	query := h.Rebind(`
+		SELECT ` + selectColumns("", issueColumns...) + ` FROM issues
+		WHERE state = ? AND closed_at < ?
+	`)
+	err := h.SelectContext(ctx, &issues, query, models.IssueStateClosed, t.UTC())
+	return issues, err
+}
+
 // DeleteIssue implements store.IssueStore.
 func (*issueStore) DeleteIssue(ctx context.Context, h db.Handler, repoID int64, id int64) error {
 	query := h.Rebind(`
@@ -155,10 +442,12 @@ func (*issueStore) RemoveIssueDependency(ctx context.Context, h db.Handler, repo
 // GetIssueDependencies implements store.IssueStore.
 func (*issueStore) GetIssueDependencies(ctx context.Context, h db.Handler, repoID int64, issueID int64) ([]models.Issue, error) {
 	var issues []models.Issue
+	// CROSS JOIN keeps SQLite from scanning every issue in the repository
+	// before probing the dependency index.
 	query := h.Rebind(`
-		SELECT i.* FROM issues i
-		INNER JOIN issue_dependencies d ON i.id = d.depends_on_id
-		WHERE d.issue_id = ? AND i.repo_id = ?
+		SELECT ` + selectColumns("i", issueColumns...) + `
+		FROM issue_dependencies d CROSS JOIN issues i
+		WHERE i.id = d.depends_on_id AND d.issue_id = ? AND i.repo_id = ?
 		ORDER BY i.created_at DESC
 	`)
 	err := h.SelectContext(ctx, &issues, query, issueID, repoID)
@@ -169,9 +458,9 @@ func (*issueStore) GetIssueDependencies(ctx context.Context, h db.Handler, repoI
 func (*issueStore) GetIssueDependents(ctx context.Context, h db.Handler, repoID int64, issueID int64) ([]models.Issue, error) {
 	var issues []models.Issue
 	query := h.Rebind(`
-		SELECT i.* FROM issues i
-		INNER JOIN issue_dependencies d ON i.id = d.issue_id
-		WHERE d.depends_on_id = ? AND i.repo_id = ?
+		SELECT ` + selectColumns("i", issueColumns...) + `
+		FROM issue_dependencies d CROSS JOIN issues i
+		WHERE i.id = d.issue_id AND d.depends_on_id = ? AND i.repo_id = ?
 		ORDER BY i.created_at DESC
 	`)
 	err := h.SelectContext(ctx, &issues, query, issueID, repoID)
@@ -203,3 +492,62 @@ func (*issueStore) HasIssueDependency(ctx context.Context, h db.Handler, repoID
 	}
 	return count > 0, nil
 }
+
+// AddIssueVote implements store.IssueStore.
+func (*issueStore) AddIssueVote(ctx context.Context, h db.Handler, repoID int64, issueID int64, userID int64) error {
+	query := h.Rebind(`
+		INSERT INTO issue_votes (issue_id, user_id)
+		SELECT id, ? FROM issues
+		WHERE repo_id = ? AND id = ?
+	`)
+	_, err := h.ExecContext(ctx, query, userID, repoID, issueID)
+	return err
+}
+
+// RemoveIssueVote implements store.IssueStore.
+func (*issueStore) RemoveIssueVote(ctx context.Context, h db.Handler, repoID int64, issueID int64, userID int64) error {
+	query := h.Rebind(`
+		DELETE FROM issue_votes
+		WHERE user_id = ? AND issue_id IN (
+			SELECT id FROM issues WHERE repo_id = ? AND id = ?
+		)
+	`)
+	_, err := h.ExecContext(ctx, query, userID, repoID, issueID)
+	return err
+}
+
+// GetIssueVoteCounts implements store.IssueStore.
+func (*issueStore) GetIssueVoteCounts(ctx context.Context, h db.Handler, repoID int64) (map[int64]int, error) {
+	var rows []struct {
+		IssueID int64 `db:"issue_id"`
+		Votes   int   `db:"votes"`
+	}
+	query := h.Rebind(`
+		SELECT issue_votes.issue_id, COUNT(*) AS votes FROM issue_votes
+		INNER JOIN issues ON issues.id = issue_votes.issue_id
+		WHERE issues.repo_id = ?
+		GROUP BY issue_votes.issue_id
+	`)
+	if err := h.SelectContext(ctx, &rows, query, repoID); err != nil {
+		return nil, err
+	}
+
+	counts := make(map[int64]int, len(rows))
+	for _, r := range rows {
+		counts[r.IssueID] = r.Votes
+	}
+
+	return counts, nil
+}
+
+// HasIssueVote implements store.IssueStore.
+func (*issueStore) HasIssueVote(ctx context.Context, h db.Handler, repoID int64, issueID int64, userID int64) (bool, error) {
+	var count int
+	query := h.Rebind(`
+		SELECT COUNT(*) FROM issue_votes
+		INNER JOIN issues ON issues.id = issue_votes.issue_id
+		WHERE issues.repo_id = ? AND issues.id = ? AND issue_votes.user_id = ?
+	`)
+	err := h.GetContext(ctx, &count, query, repoID, issueID, userID)
+	return count > 0, err
+}
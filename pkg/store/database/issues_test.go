@@ -2,68 +2,27 @@ package database_test
 
 import (
 	"context"
-	"path/filepath"
 	"testing"
+	"time"
 
-	"github.com/charmbracelet/soft-serve/pkg/config"
 	"github.com/charmbracelet/soft-serve/pkg/db"
-	"github.com/charmbracelet/soft-serve/pkg/db/migrate"
 	"github.com/charmbracelet/soft-serve/pkg/db/models"
 	"github.com/charmbracelet/soft-serve/pkg/store/database"
 	"github.com/matryer/is"
 )
 
-// openTestDB opens a temporary SQLite database for testing.
-func openTestDB(ctx context.Context, t *testing.T) (*db.DB, error) {
-	dbpath := filepath.Join(t.TempDir(), "test.db")
-	dbx, err := db.Open(ctx, "sqlite", dbpath)
-	if err != nil {
-		return nil, err
-	}
-	t.Cleanup(func() {
-		if err := dbx.Close(); err != nil {
-			t.Error(err)
-		}
-	})
-	return dbx, nil
+func TestIssueStore(t *testing.T) {
+	runWithDatabases(t, testIssueStore)
 }
 
-func TestIssueStore(t *testing.T) {
+func testIssueStore(t *testing.T, ctx context.Context, dbx *db.DB) {
 	is := is.New(t)
 
-	// Setup database
-	ctx := config.WithContext(context.TODO(), config.DefaultConfig())
-	dbx, err := openTestDB(ctx, t)
-	is.NoErr(err)
-
-	// Run migrations
-	is.NoErr(migrate.Migrate(ctx, dbx))
-
 	// Create store
 	store := database.New(ctx, dbx)
 
 	// Create test data: user and repo
-	var userID, repoID int64
-	err = dbx.TransactionContext(ctx, func(tx *db.Tx) error {
-		// Create user
-		result, err := tx.ExecContext(ctx, "INSERT INTO users (username, admin, created_at, updated_at) VALUES (?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)", "testuser", false)
-		if err != nil {
-			return err
-		}
-		userID, err = result.LastInsertId()
-		if err != nil {
-			return err
-		}
-
-		// Create repo
-		result, err = tx.ExecContext(ctx, "INSERT INTO repos (name, project_name, description, private, mirror, hidden, user_id, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)",
-			"testrepo", "Test Repo", "Test Description", false, false, false, userID)
-		if err != nil {
-			return err
-		}
-		repoID, err = result.LastInsertId()
-		return err
-	})
+	userID, repoID, err := createTestUserAndRepo(ctx, dbx)
 	is.NoErr(err)
 
 	// Test CreateIssue
@@ -106,6 +65,43 @@ func TestIssueStore(t *testing.T) {
 		is.Equal(issue.State, models.IssueStateOpen)
 	})
 
+	// Test GetIssueByNumber
+	t.Run("GetIssueByNumber", func(t *testing.T) {
+		is := is.New(t)
+
+		// Issues of other repositories don't take numbers.
+		var otherRepoID, otherID, issueID int64
+		err := dbx.TransactionContext(ctx, func(tx *db.Tx) error {
+			if err := tx.GetContext(ctx, &otherRepoID, tx.Rebind(`INSERT INTO repos (name, project_name, description, private, mirror, hidden, user_id, updated_at)
+				VALUES (?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP) RETURNING id;`),
+				"numbered", "", "", false, false, false, userID); err != nil {
+				return err
+			}
+			var err error
+			if otherID, err = store.CreateIssue(ctx, tx, otherRepoID, userID, "First", ""); err != nil {
+				return err
+			}
+			issueID, err = store.CreateIssue(ctx, tx, repoID, userID, "Numbered", "")
+			return err
+		})
+		is.NoErr(err)
+
+		other, err := store.GetIssueByNumber(ctx, dbx, otherRepoID, 1)
+		is.NoErr(err)
+		is.Equal(other.ID, otherID)
+
+		issue, err := store.GetIssueByID(ctx, dbx, repoID, issueID)
+		is.NoErr(err)
+		is.True(issue.Number > 1)
+
+		byNumber, err := store.GetIssueByNumber(ctx, dbx, repoID, issue.Number)
+		is.NoErr(err)
+		is.Equal(byNumber.ID, issueID)
+
+		_, err = store.GetIssueByNumber(ctx, dbx, otherRepoID, issue.Number)
+		is.True(err != nil)
+	})
+
 	// Test GetIssuesByRepoID
 	t.Run("GetIssuesByRepoID", func(t *testing.T) {
 		is := is.New(t)
@@ -125,11 +121,50 @@ func TestIssueStore(t *testing.T) {
 		var issues []models.Issue
 		err = dbx.TransactionContext(ctx, func(tx *db.Tx) error {
 			var err error
-			issues, err = store.GetIssuesByRepoID(ctx, tx, repoID)
+			issues, err = store.GetIssuesByRepoID(ctx, tx, repoID, models.ListOrder{}, 0, 0)
 			return err
 		})
 		is.NoErr(err)
 		is.True(len(issues) >= 2) // At least 2 issues
+
+		// Pages follow each other, newest first, without gaps.
+		first, err := store.GetIssuesByRepoID(ctx, dbx, repoID, models.ListOrder{}, 1, 0)
+		is.NoErr(err)
+		is.Equal(len(first), 1)
+		is.Equal(first[0].ID, issues[0].ID)
+		second, err := store.GetIssuesByRepoID(ctx, dbx, repoID, models.ListOrder{}, 1, first[0].Number)
+		is.NoErr(err)
+		is.Equal(len(second), 1)
+		is.Equal(second[0].ID, issues[1].ID)
+		is.True(second[0].Number < first[0].Number)
+		last, err := store.GetIssuesByRepoID(ctx, dbx, repoID, models.ListOrder{}, 0, issues[len(issues)-1].Number)
+		is.NoErr(err)
+		is.Equal(len(last), 0)
+
+		// Sorted pages follow each other too, ties broken by number.
+		order := models.ListOrder{Sort: models.ListSortTitle, Ascending: true}
+		sorted, err := store.GetIssuesByRepoID(ctx, dbx, repoID, order, 0, 0)
+		is.NoErr(err)
+		is.Equal(len(sorted), len(issues))
+		for i := 1; i < len(sorted); i++ {
+			prev, cur := sorted[i-1], sorted[i]
+			is.True(prev.Title < cur.Title || prev.Title == cur.Title && prev.Number < cur.Number)
+		}
+		var paged []models.Issue
+		var after int64
+		for {
+			page, err := store.GetIssuesByRepoID(ctx, dbx, repoID, order, 1, after)
+			is.NoErr(err)
+			if len(page) == 0 {
+				break
+			}
+			paged = append(paged, page...)
+			after = page[0].Number
+		}
+		is.Equal(len(paged), len(sorted))
+		for i := range paged {
+			is.Equal(paged[i].ID, sorted[i].ID)
+		}
 	})
 
 	// Test GetIssuesByRepoIDAndState
@@ -144,7 +179,7 @@ func TestIssueStore(t *testing.T) {
 			if err != nil {
 				return err
 			}
-			return store.CloseIssue(ctx, tx, repoID, issueID, userID)
+			return store.CloseIssue(ctx, tx, repoID, issueID, userID, models.IssueCloseReasonCompleted)
 		})
 		is.NoErr(err)
 
@@ -152,7 +187,7 @@ func TestIssueStore(t *testing.T) {
 		var openIssues []models.Issue
 		err = dbx.TransactionContext(ctx, func(tx *db.Tx) error {
 			var err error
-			openIssues, err = store.GetIssuesByRepoIDAndState(ctx, tx, repoID, models.IssueStateOpen)
+			openIssues, err = store.GetIssuesByRepoIDAndState(ctx, tx, repoID, models.IssueStateOpen, models.ListOrder{}, 0, 0)
 			return err
 		})
 		is.NoErr(err)
@@ -166,7 +201,7 @@ func TestIssueStore(t *testing.T) {
 		var closedIssues []models.Issue
 		err = dbx.TransactionContext(ctx, func(tx *db.Tx) error {
 			var err error
-			closedIssues, err = store.GetIssuesByRepoIDAndState(ctx, tx, repoID, models.IssueStateClosed)
+			closedIssues, err = store.GetIssuesByRepoIDAndState(ctx, tx, repoID, models.IssueStateClosed, models.ListOrder{}, 0, 0)
 			return err
 		})
 		is.NoErr(err)
@@ -219,7 +254,7 @@ func TestIssueStore(t *testing.T) {
 
 		// Close issue
 		err = dbx.TransactionContext(ctx, func(tx *db.Tx) error {
-			return store.CloseIssue(ctx, tx, repoID, issueID, userID)
+			return store.CloseIssue(ctx, tx, repoID, issueID, userID, models.IssueCloseReasonCompleted)
 		})
 		is.NoErr(err)
 
@@ -235,6 +270,21 @@ func TestIssueStore(t *testing.T) {
 		is.True(issue.ClosedBy.Valid)
 		is.Equal(issue.ClosedBy.Int64, userID)
 		is.True(issue.ClosedAt.Valid)
+		is.Equal(issue.CloseReason, models.IssueCloseReasonCompleted)
+
+		// Closed before a time
+		issues, err := store.GetIssuesClosedBefore(ctx, dbx, time.Now().Add(-time.Hour))
+		is.NoErr(err)
+		for _, i := range issues {
+			is.True(i.ID != issueID)
+		}
+		issues, err = store.GetIssuesClosedBefore(ctx, dbx, time.Now().Add(time.Hour))
+		is.NoErr(err)
+		var found bool
+		for _, i := range issues {
+			found = found || i.ID == issueID
+		}
+		is.True(found)
 	})
 
 	// Test ReopenIssue
@@ -249,7 +299,7 @@ func TestIssueStore(t *testing.T) {
 			if err != nil {
 				return err
 			}
-			return store.CloseIssue(ctx, tx, repoID, issueID, userID)
+			return store.CloseIssue(ctx, tx, repoID, issueID, userID, models.IssueCloseReasonDuplicate)
 		})
 		is.NoErr(err)
 
@@ -268,8 +318,101 @@ func TestIssueStore(t *testing.T) {
 		})
 		is.NoErr(err)
 		is.Equal(issue.State, models.IssueStateOpen)
-		is.True(!issue.ClosedBy.Valid)  // Should be NULL
-		is.True(!issue.ClosedAt.Valid)  // Should be NULL
+		is.True(!issue.ClosedBy.Valid) // Should be NULL
+		is.True(!issue.ClosedAt.Valid) // Should be NULL
+		is.Equal(issue.CloseReason, models.IssueCloseReason(""))
+	})
+
+	// Test stale issues
+	t.Run("StaleIssue", func(t *testing.T) {
+		is := is.New(t)
+
+		var issueID int64
+		err := dbx.TransactionContext(ctx, func(tx *db.Tx) error {
+			var err error
+			issueID, err = store.CreateIssue(ctx, tx, repoID, userID, "Inactive", "Description")
+			if err != nil {
+				return err
+			}
+			// Only stale issues are closed as stale
+			if err := store.CloseStaleIssue(ctx, tx, repoID, issueID); err != nil {
+				return err
+			}
+			return store.SetIssueStale(ctx, tx, repoID, issueID, true)
+		})
+		is.NoErr(err)
+
+		var issue models.Issue
+		err = dbx.TransactionContext(ctx, func(tx *db.Tx) error {
+			var err error
+			issue, err = store.GetIssueByID(ctx, tx, repoID, issueID)
+			return err
+		})
+		is.NoErr(err)
+		is.Equal(issue.State, models.IssueStateOpen)
+		is.True(issue.StaleAt.Valid)
+
+		err = dbx.TransactionContext(ctx, func(tx *db.Tx) error {
+			if err := store.CloseStaleIssue(ctx, tx, repoID, issueID); err != nil {
+				return err
+			}
+			var err error
+			issue, err = store.GetIssueByID(ctx, tx, repoID, issueID)
+			return err
+		})
+		is.NoErr(err)
+		is.Equal(issue.State, models.IssueStateClosed)
+		is.True(!issue.ClosedBy.Valid) // Closed by the stale policy
+		is.True(issue.ClosedAt.Valid)
+		is.Equal(issue.CloseReason, models.IssueCloseReasonNotPlanned)
+
+		// Reopening clears the stale mark
+		err = dbx.TransactionContext(ctx, func(tx *db.Tx) error {
+			if err := store.ReopenIssue(ctx, tx, repoID, issueID); err != nil {
+				return err
+			}
+			var err error
+			issue, err = store.GetIssueByID(ctx, tx, repoID, issueID)
+			return err
+		})
+		is.NoErr(err)
+		is.Equal(issue.State, models.IssueStateOpen)
+		is.True(!issue.StaleAt.Valid)
+	})
+
+	// Test issue votes
+	t.Run("IssueVotes", func(t *testing.T) {
+		is := is.New(t)
+
+		var issueID int64
+		err := dbx.TransactionContext(ctx, func(tx *db.Tx) error {
+			var err error
+			issueID, err = store.CreateIssue(ctx, tx, repoID, userID, "Vote for me", "Description")
+			if err != nil {
+				return err
+			}
+			return store.AddIssueVote(ctx, tx, repoID, issueID, userID)
+		})
+		is.NoErr(err)
+
+		// Users vote once per issue
+		err = dbx.TransactionContext(ctx, func(tx *db.Tx) error {
+			return store.AddIssueVote(ctx, tx, repoID, issueID, userID)
+		})
+		is.True(err != nil)
+
+		counts, err := store.GetIssueVoteCounts(ctx, dbx, repoID)
+		is.NoErr(err)
+		is.Equal(counts[issueID], 1)
+		voted, err := store.HasIssueVote(ctx, dbx, repoID, issueID, userID)
+		is.NoErr(err)
+		is.True(voted)
+
+		err = store.RemoveIssueVote(ctx, dbx, repoID, issueID, userID)
+		is.NoErr(err)
+		counts, err = store.GetIssueVoteCounts(ctx, dbx, repoID)
+		is.NoErr(err)
+		is.Equal(counts[issueID], 0)
 	})
 
 	// Test DeleteIssue
@@ -497,4 +640,274 @@ func TestIssueStore(t *testing.T) {
 		is.NoErr(err)
 		is.True(hasDep) // Should exist now
 	})
+
+	// Test large issue descriptions
+	t.Run("IssueLargeText", func(t *testing.T) {
+		is := is.New(t)
+
+		var issueID int64
+		err := dbx.TransactionContext(ctx, func(tx *db.Tx) error {
+			var err error
+			issueID, err = store.CreateIssue(ctx, tx, repoID, userID, "Large Issue", "Preview")
+			if err != nil {
+				return err
+			}
+			return store.SetIssueLargeText(ctx, tx, repoID, issueID, "Preview and the rest")
+		})
+		is.NoErr(err)
+
+		// Verify the issue is marked as truncated and the full text is stored
+		var issue models.Issue
+		var content string
+		err = dbx.TransactionContext(ctx, func(tx *db.Tx) error {
+			var err error
+			issue, err = store.GetIssueByID(ctx, tx, repoID, issueID)
+			if err != nil {
+				return err
+			}
+			content, err = store.GetIssueLargeText(ctx, tx, repoID, issueID)
+			return err
+		})
+		is.NoErr(err)
+		is.True(issue.DescriptionTruncated)
+		is.Equal(issue.Description, "Preview")
+		is.Equal(content, "Preview and the rest")
+
+		// Replace the full text
+		err = dbx.TransactionContext(ctx, func(tx *db.Tx) error {
+			if err := store.SetIssueLargeText(ctx, tx, repoID, issueID, "Replaced"); err != nil {
+				return err
+			}
+			var err error
+			content, err = store.GetIssueLargeText(ctx, tx, repoID, issueID)
+			return err
+		})
+		is.NoErr(err)
+		is.Equal(content, "Replaced")
+
+		// Large text is scoped to the repository
+		err = dbx.TransactionContext(ctx, func(tx *db.Tx) error {
+			_, err := store.GetIssueLargeText(ctx, tx, repoID+1, issueID)
+			return err
+		})
+		is.True(err != nil) // Should not find large text for another repo
+
+		// Delete the full text
+		err = dbx.TransactionContext(ctx, func(tx *db.Tx) error {
+			if err := store.DeleteIssueLargeText(ctx, tx, repoID, issueID); err != nil {
+				return err
+			}
+			var err error
+			issue, err = store.GetIssueByID(ctx, tx, repoID, issueID)
+			return err
+		})
+		is.NoErr(err)
+		is.True(!issue.DescriptionTruncated)
+	})
+
+	// Test SearchIssues
+	t.Run("SearchIssues", func(t *testing.T) {
+		is := is.New(t)
+
+		var closedID int64
+		err := dbx.TransactionContext(ctx, func(tx *db.Tx) error {
+			if _, err := store.CreateIssue(ctx, tx, repoID, userID, "Panic in parser", "Stack trace"); err != nil {
+				return err
+			}
+			if _, err := store.CreateIssue(ctx, tx, repoID, userID, "Slow build", "The PARSER is 100% slow"); err != nil {
+				return err
+			}
+			var err error
+			closedID, err = store.CreateIssue(ctx, tx, repoID, userID, "Old parser panic", "")
+			if err != nil {
+				return err
+			}
+			return store.CloseIssue(ctx, tx, repoID, closedID, userID, models.IssueCloseReasonCompleted)
+		})
+		is.NoErr(err)
+
+		search := func(query string, state *models.IssueState, limit, offset int) []models.Issue {
+			var issues []models.Issue
+			err := dbx.TransactionContext(ctx, func(tx *db.Tx) error {
+				var err error
+				issues, err = store.SearchIssues(ctx, tx, []int64{repoID}, query, state, 0, userID, limit, offset)
+				return err
+			})
+			is.NoErr(err)
+			return issues
+		}
+
+		// Title and description matches are case-insensitive
+		is.Equal(len(search("parser", nil, 10, 0)), 3)
+
+		// LIKE wildcards are matched literally
+		issues := search("100%", nil, 10, 0)
+		is.Equal(len(issues), 1)
+		is.Equal(issues[0].Title, "Slow build")
+		is.Equal(len(search("10_%", nil, 10, 0)), 0)
+
+		// Filter by state
+		closed := models.IssueStateClosed
+		issues = search("parser", &closed, 10, 0)
+		is.Equal(len(issues), 1)
+		is.Equal(issues[0].ID, closedID)
+
+		// Paginate
+		is.Equal(len(search("parser", nil, 2, 0)), 2)
+		is.Equal(len(search("parser", nil, 2, 2)), 1)
+
+		// Other repos and authors are not matched
+		err = dbx.TransactionContext(ctx, func(tx *db.Tx) error {
+			var err error
+			issues, err = store.SearchIssues(ctx, tx, []int64{repoID + 1}, "parser", nil, 0, userID, 10, 0)
+			if err != nil || len(issues) > 0 {
+				return err
+			}
+			issues, err = store.SearchIssues(ctx, tx, []int64{repoID}, "parser", nil, userID+1, userID, 10, 0)
+			return err
+		})
+		is.NoErr(err)
+		is.Equal(len(issues), 0)
+
+		// Drafts are only matched for their author
+		draftID, err := store.CreateIssue(ctx, dbx, repoID, userID, "Draft parser fix", "")
+		is.NoErr(err)
+		is.NoErr(store.SetIssueDraft(ctx, dbx, repoID, draftID, true))
+		is.Equal(len(search("parser", nil, 10, 0)), 4)
+		issues, err = store.SearchIssues(ctx, dbx, []int64{repoID}, "parser", nil, 0, userID+1, 10, 0)
+		is.NoErr(err)
+		is.Equal(len(issues), 3)
+		is.NoErr(store.DeleteIssue(ctx, dbx, repoID, draftID))
+	})
+
+	// Test CountIssuesByAuthorID and SetIssueFirstContribution
+	t.Run("FirstContribution", func(t *testing.T) {
+		is := is.New(t)
+
+		var before, after int64
+		var issueID int64
+		err := dbx.TransactionContext(ctx, func(tx *db.Tx) error {
+			var err error
+			before, err = store.CountIssuesByAuthorID(ctx, tx, repoID, userID)
+			if err != nil {
+				return err
+			}
+			issueID, err = store.CreateIssue(ctx, tx, repoID, userID, "First", "Description")
+			if err != nil {
+				return err
+			}
+			after, err = store.CountIssuesByAuthorID(ctx, tx, repoID, userID)
+			if err != nil {
+				return err
+			}
+			return store.SetIssueFirstContribution(ctx, tx, repoID, issueID)
+		})
+		is.NoErr(err)
+		is.Equal(after, before+1)
+
+		issue, err := store.GetIssueByID(ctx, dbx, repoID, issueID)
+		is.NoErr(err)
+		is.True(issue.FirstContribution)
+
+		count, err := store.CountIssuesByAuthorID(ctx, dbx, repoID, userID+1)
+		is.NoErr(err)
+		is.Equal(count, int64(0))
+	})
+
+	// Test SetIssueLocked
+	t.Run("SetIssueLocked", func(t *testing.T) {
+		is := is.New(t)
+
+		issueID, err := store.CreateIssue(ctx, dbx, repoID, userID, "Locked issue", "")
+		is.NoErr(err)
+		issue, err := store.GetIssueByID(ctx, dbx, repoID, issueID)
+		is.NoErr(err)
+		is.True(!issue.Locked)
+
+		is.NoErr(store.SetIssueLocked(ctx, dbx, repoID, issueID, true))
+		issue, err = store.GetIssueByID(ctx, dbx, repoID, issueID)
+		is.NoErr(err)
+		is.True(issue.Locked)
+
+		is.NoErr(store.SetIssueLocked(ctx, dbx, repoID, issueID, false))
+		issue, err = store.GetIssueByID(ctx, dbx, repoID, issueID)
+		is.NoErr(err)
+		is.True(!issue.Locked)
+	})
+
+	// Test SetIssuePinned
+	t.Run("SetIssuePinned", func(t *testing.T) {
+		is := is.New(t)
+
+		first, err := store.CreateIssue(ctx, dbx, repoID, userID, "First pinned issue", "")
+		is.NoErr(err)
+		second, err := store.CreateIssue(ctx, dbx, repoID, userID, "Second pinned issue", "")
+		is.NoErr(err)
+
+		pinned, err := store.GetPinnedIssuesByRepoID(ctx, dbx, repoID)
+		is.NoErr(err)
+		is.Equal(len(pinned), 0)
+
+		is.NoErr(store.SetIssuePinned(ctx, dbx, repoID, first, true))
+		is.NoErr(store.SetIssuePinned(ctx, dbx, repoID, second, true))
+		pinned, err = store.GetPinnedIssuesByRepoID(ctx, dbx, repoID)
+		is.NoErr(err)
+		is.Equal(len(pinned), 2)
+		is.Equal(pinned[0].ID, first)
+		is.Equal(pinned[1].ID, second)
+		is.True(pinned[0].Pinned.Valid)
+
+		is.NoErr(store.SetIssuePinned(ctx, dbx, repoID, first, false))
+		pinned, err = store.GetPinnedIssuesByRepoID(ctx, dbx, repoID)
+		is.NoErr(err)
+		is.Equal(len(pinned), 1)
+		is.Equal(pinned[0].ID, second)
+		issue, err := store.GetIssueByID(ctx, dbx, repoID, first)
+		is.NoErr(err)
+		is.True(!issue.Pinned.Valid)
+	})
+
+	// Test CountIssuesByRepoID
+	t.Run("CountIssuesByRepoID", func(t *testing.T) {
+		is := is.New(t)
+
+		all, err := store.GetIssuesByRepoID(ctx, dbx, repoID, models.ListOrder{}, 0, 0)
+		is.NoErr(err)
+		count, err := store.CountIssuesByRepoID(ctx, dbx, repoID, nil)
+		is.NoErr(err)
+		is.Equal(count, int64(len(all)))
+
+		state := models.IssueStateOpen
+		open, err := store.GetIssuesByRepoIDAndState(ctx, dbx, repoID, state, models.ListOrder{}, 0, 0)
+		is.NoErr(err)
+		count, err = store.CountIssuesByRepoID(ctx, dbx, repoID, &state)
+		is.NoErr(err)
+		is.Equal(count, int64(len(open)))
+		is.True(count < int64(len(all)))
+	})
+
+	// Test SetIssueDraft
+	t.Run("SetIssueDraft", func(t *testing.T) {
+		is := is.New(t)
+
+		issueID, err := store.CreateIssue(ctx, dbx, repoID, userID, "Draft issue", "")
+		is.NoErr(err)
+		is.NoErr(store.SetIssueDraft(ctx, dbx, repoID, issueID, true))
+		issue, err := store.GetIssueByID(ctx, dbx, repoID, issueID)
+		is.NoErr(err)
+		is.True(issue.Draft)
+
+		count, err := store.CountOthersDraftIssuesByRepoID(ctx, dbx, repoID, nil, userID+1)
+		is.NoErr(err)
+		is.Equal(count, int64(1))
+		count, err = store.CountOthersDraftIssuesByRepoID(ctx, dbx, repoID, nil, userID)
+		is.NoErr(err)
+		is.Equal(count, int64(0))
+
+		is.NoErr(store.SetIssueDraft(ctx, dbx, repoID, issueID, false))
+		issue, err = store.GetIssueByID(ctx, dbx, repoID, issueID)
+		is.NoErr(err)
+		is.True(!issue.Draft)
+	})
+
 }
@@ -0,0 +1,102 @@
+package database
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/store"
+)
+
+type labelStore struct{}
+
+var _ store.LabelStore = (*labelStore)(nil)
+
+// GetLabelsByRepoID implements store.LabelStore.
+func (*labelStore) GetLabelsByRepoID(ctx context.Context, h db.Handler, repoID int64) ([]models.Label, error) {
+	query := h.Rebind(`SELECT * FROM labels WHERE repo_id = ? ORDER BY name;`)
+	var labels []models.Label
+	err := h.SelectContext(ctx, &labels, query, repoID)
+	return labels, err
+}
+
+// GetLabelByName implements store.LabelStore.
+func (*labelStore) GetLabelByName(ctx context.Context, h db.Handler, repoID int64, name string) (models.Label, error) {
+	query := h.Rebind(`SELECT * FROM labels WHERE repo_id = ? AND name = ?;`)
+	var label models.Label
+	err := h.GetContext(ctx, &label, query, repoID, name)
+	return label, err
+}
+
+// CreateLabel implements store.LabelStore.
+func (*labelStore) CreateLabel(ctx context.Context, h db.Handler, repoID int64, name string, color string, description string) error {
+	query := h.Rebind(`INSERT INTO labels (repo_id, name, color, description, updated_at)
+			VALUES (?, ?, ?, ?, CURRENT_TIMESTAMP);`)
+	_, err := h.ExecContext(ctx, query, repoID, name, color, description)
+	return err
+}
+
+// UpdateLabel implements store.LabelStore.
+func (*labelStore) UpdateLabel(ctx context.Context, h db.Handler, repoID int64, id int64, name string, color string, description string) error {
+	query := h.Rebind(`UPDATE labels SET name = ?, color = ?, description = ?, updated_at = CURRENT_TIMESTAMP
+			WHERE repo_id = ? AND id = ?;`)
+	_, err := h.ExecContext(ctx, query, name, color, description, repoID, id)
+	return err
+}
+
+// DeleteLabel implements store.LabelStore.
+func (*labelStore) DeleteLabel(ctx context.Context, h db.Handler, repoID int64, id int64) error {
+	query := h.Rebind(`DELETE FROM labels WHERE repo_id = ? AND id = ?;`)
+	_, err := h.ExecContext(ctx, query, repoID, id)
+	return err
+}
+
+// AddIssueLabel implements store.LabelStore.
+func (*labelStore) AddIssueLabel(ctx context.Context, h db.Handler, repoID int64, issueID int64, labelID int64) error {
+	query := h.Rebind(`
+		INSERT INTO issue_labels (issue_id, label_id)
+		SELECT issues.id, labels.id FROM issues
+		INNER JOIN labels ON labels.repo_id = issues.repo_id
+		WHERE issues.repo_id = ? AND issues.id = ? AND labels.id = ?
+	`)
+	_, err := h.ExecContext(ctx, query, repoID, issueID, labelID)
+	return err
+}
+
+// RemoveIssueLabel implements store.LabelStore.
+func (*labelStore) RemoveIssueLabel(ctx context.Context, h db.Handler, repoID int64, issueID int64, labelID int64) error {
+	query := h.Rebind(`
+		DELETE FROM issue_labels
+		WHERE label_id = ? AND issue_id IN (
+			SELECT id FROM issues WHERE repo_id = ? AND id = ?
+		)
+	`)
+	_, err := h.ExecContext(ctx, query, labelID, repoID, issueID)
+	return err
+}
+
+// GetIssueLabels implements store.LabelStore.
+func (*labelStore) GetIssueLabels(ctx context.Context, h db.Handler, repoID int64, issueID int64) ([]models.Label, error) {
+	query := h.Rebind(`
+		SELECT labels.* FROM labels
+		INNER JOIN issue_labels ON issue_labels.label_id = labels.id
+		WHERE labels.repo_id = ? AND issue_labels.issue_id = ?
+		ORDER BY labels.name
+	`)
+	var labels []models.Label
+	err := h.SelectContext(ctx, &labels, query, repoID, issueID)
+	return labels, err
+}
+
+// GetIssueLabelsByRepoID implements store.LabelStore.
+func (*labelStore) GetIssueLabelsByRepoID(ctx context.Context, h db.Handler, repoID int64) ([]models.IssueLabel, error) {
+	query := h.Rebind(`
+		SELECT issue_labels.issue_id, labels.* FROM labels
+		INNER JOIN issue_labels ON issue_labels.label_id = labels.id
+		WHERE labels.repo_id = ?
+		ORDER BY issue_labels.issue_id, labels.name
+	`)
+	var labels []models.IssueLabel
+	err := h.SelectContext(ctx, &labels, query, repoID)
+	return labels, err
+}
@@ -0,0 +1,68 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/store/database"
+	"github.com/matryer/is"
+)
+
+func TestLabelStore(t *testing.T) {
+	runWithDatabases(t, testLabelStore)
+}
+
+func testLabelStore(t *testing.T, ctx context.Context, dbx *db.DB) {
+	is := is.New(t)
+
+	store := database.New(ctx, dbx)
+	userID, repoID, err := createTestUserAndRepo(ctx, dbx)
+	is.NoErr(err)
+
+	is.NoErr(store.CreateLabel(ctx, dbx, repoID, "bug", "#d73a4a", "Something isn't working"))
+	is.NoErr(store.CreateLabel(ctx, dbx, repoID, "docs", "#0075ca", ""))
+
+	// Label names are unique per repository.
+	err = store.CreateLabel(ctx, dbx, repoID, "bug", "#000000", "")
+	is.True(err != nil)
+
+	labels, err := store.GetLabelsByRepoID(ctx, dbx, repoID)
+	is.NoErr(err)
+	is.Equal(len(labels), 2)
+	is.Equal(labels[0].Name, "bug")
+	is.Equal(labels[1].Name, "docs")
+
+	bug, err := store.GetLabelByName(ctx, dbx, repoID, "bug")
+	is.NoErr(err)
+	is.Equal(bug.Color, "#d73a4a")
+
+	issueID, err := store.CreateIssue(ctx, dbx, repoID, userID, "Crash", "Description")
+	is.NoErr(err)
+	is.NoErr(store.AddIssueLabel(ctx, dbx, repoID, issueID, bug.ID))
+	is.NoErr(store.AddIssueLabel(ctx, dbx, repoID, issueID, labels[1].ID))
+
+	// Labels are attached once.
+	err = store.AddIssueLabel(ctx, dbx, repoID, issueID, bug.ID)
+	is.True(err != nil)
+
+	is.NoErr(store.UpdateLabel(ctx, dbx, repoID, bug.ID, "defect", "#ff0000", ""))
+	attached, err := store.GetIssueLabels(ctx, dbx, repoID, issueID)
+	is.NoErr(err)
+	is.Equal(len(attached), 2)
+	is.Equal(attached[0].Name, "defect")
+	is.Equal(attached[0].Color, "#ff0000")
+
+	is.NoErr(store.RemoveIssueLabel(ctx, dbx, repoID, issueID, labels[1].ID))
+	byIssue, err := store.GetIssueLabelsByRepoID(ctx, dbx, repoID)
+	is.NoErr(err)
+	is.Equal(len(byIssue), 1)
+	is.Equal(byIssue[0].IssueID, issueID)
+	is.Equal(byIssue[0].Name, "defect")
+
+	// Deleting a label detaches it.
+	is.NoErr(store.DeleteLabel(ctx, dbx, repoID, bug.ID))
+	attached, err = store.GetIssueLabels(ctx, dbx, repoID, issueID)
+	is.NoErr(err)
+	is.Equal(len(attached), 0)
+}
@@ -0,0 +1,118 @@
+package database
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/store"
+)
+
+type largeTextStore struct{}
+
+var _ store.LargeTextStore = (*largeTextStore)(nil)
+
+// GetIssueLargeText implements store.LargeTextStore.
+func (*largeTextStore) GetIssueLargeText(ctx context.Context, h db.Handler, repoID int64, issueID int64) (string, error) {
+	var content string
+	query := h.Rebind(`
+		SELECT t.content FROM large_texts t
+		INNER JOIN issues i ON i.id = t.issue_id
+		WHERE i.repo_id = ? AND i.id = ?
+	`)
+	err := h.GetContext(ctx, &content, query, repoID, issueID)
+	return content, err
+}
+
+// SetIssueLargeText implements store.LargeTextStore.
+func (*largeTextStore) SetIssueLargeText(ctx context.Context, h db.Handler, repoID int64, issueID int64, content string) error {
+	query := h.Rebind(`
+		INSERT INTO large_texts (issue_id, content, updated_at)
+		SELECT id, ?, CURRENT_TIMESTAMP FROM issues
+		WHERE repo_id = ? AND id = ?
+		ON CONFLICT (issue_id) DO UPDATE
+		SET content = excluded.content, updated_at = CURRENT_TIMESTAMP
+	`)
+	if _, err := h.ExecContext(ctx, query, content, repoID, issueID); err != nil {
+		return err
+	}
+
+	query = h.Rebind(`
+		UPDATE issues
+		SET description_truncated = ?
+		WHERE repo_id = ? AND id = ?
+	`)
+	_, err := h.ExecContext(ctx, query, true, repoID, issueID)
+	return err
+}
+
+// DeleteIssueLargeText implements store.LargeTextStore.
+func (*largeTextStore) DeleteIssueLargeText(ctx context.Context, h db.Handler, repoID int64, issueID int64) error {
+	query := h.Rebind(`
+		DELETE FROM large_texts
+		WHERE issue_id IN (SELECT id FROM issues WHERE repo_id = ? AND id = ?)
+	`)
+	if _, err := h.ExecContext(ctx, query, repoID, issueID); err != nil {
+		return err
+	}
+
+	query = h.Rebind(`
+		UPDATE issues
+		SET description_truncated = ?
+		WHERE repo_id = ? AND id = ?
+	`)
+	_, err := h.ExecContext(ctx, query, false, repoID, issueID)
+	return err
+}
+
+// GetMergeRequestLargeText implements store.LargeTextStore.
+func (*largeTextStore) GetMergeRequestLargeText(ctx context.Context, h db.Handler, repoID int64, mrID int64) (string, error) {
+	var content string
+	query := h.Rebind(`
+		SELECT t.content FROM large_texts t
+		INNER JOIN merge_requests m ON m.id = t.merge_request_id
+		WHERE m.repo_id = ? AND m.id = ?
+	`)
+	err := h.GetContext(ctx, &content, query, repoID, mrID)
+	return content, err
+}
+
+// SetMergeRequestLargeText implements store.LargeTextStore.
+func (*largeTextStore) SetMergeRequestLargeText(ctx context.Context, h db.Handler, repoID int64, mrID int64, content string) error {
+	query := h.Rebind(`
+		INSERT INTO large_texts (merge_request_id, content, updated_at)
+		SELECT id, ?, CURRENT_TIMESTAMP FROM merge_requests
+		WHERE repo_id = ? AND id = ?
+		ON CONFLICT (merge_request_id) DO UPDATE
+		SET content = excluded.content, updated_at = CURRENT_TIMESTAMP
+	`)
+	if _, err := h.ExecContext(ctx, query, content, repoID, mrID); err != nil {
+		return err
+	}
+
+	query = h.Rebind(`
+		UPDATE merge_requests
+		SET description_truncated = ?
+		WHERE repo_id = ? AND id = ?
+	`)
+	_, err := h.ExecContext(ctx, query, true, repoID, mrID)
+	return err
+}
+
+// DeleteMergeRequestLargeText implements store.LargeTextStore.
+func (*largeTextStore) DeleteMergeRequestLargeText(ctx context.Context, h db.Handler, repoID int64, mrID int64) error {
+	query := h.Rebind(`
+		DELETE FROM large_texts
+		WHERE merge_request_id IN (SELECT id FROM merge_requests WHERE repo_id = ? AND id = ?)
+	`)
+	if _, err := h.ExecContext(ctx, query, repoID, mrID); err != nil {
+		return err
+	}
+
+	query = h.Rebind(`
+		UPDATE merge_requests
+		SET description_truncated = ?
+		WHERE repo_id = ? AND id = ?
+	`)
+	_, err := h.ExecContext(ctx, query, false, repoID, mrID)
+	return err
+}
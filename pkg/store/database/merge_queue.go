@@ -0,0 +1,52 @@
+package database
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/store"
+)
+
+type mergeQueueStore struct{}
+
+var _ store.MergeQueueStore = (*mergeQueueStore)(nil)
+
+// mergeQueueEntryColumns are the columns selected when querying merge queue entries.
+var mergeQueueEntryColumns = []string{
+	"id",
+	"repo_id",
+	"merge_request_id",
+	"user_id",
+	"created_at",
+}
+
+// GetMergeQueueEntriesByRepoID implements store.MergeQueueStore.
+func (*mergeQueueStore) GetMergeQueueEntriesByRepoID(ctx context.Context, h db.Handler, repoID int64) ([]models.MergeQueueEntry, error) {
+	query := h.Rebind(`SELECT ` + selectColumns("", mergeQueueEntryColumns...) + ` FROM merge_queue_entries WHERE repo_id = ? ORDER BY id;`)
+	var entries []models.MergeQueueEntry
+	err := h.SelectContext(ctx, &entries, query, repoID)
+	return entries, err
+}
+
+// CountMergeQueueEntries implements store.MergeQueueStore.
+func (*mergeQueueStore) CountMergeQueueEntries(ctx context.Context, h db.Handler) (int64, error) {
+	var count int64
+	err := h.GetContext(ctx, &count, `SELECT COUNT(*) FROM merge_queue_entries;`)
+	return count, err
+}
+
+// AddMergeQueueEntry implements store.MergeQueueStore.
+func (*mergeQueueStore) AddMergeQueueEntry(ctx context.Context, h db.Handler, repoID int64, mrID int64, userID int64) error {
+	query := h.Rebind(`INSERT INTO merge_queue_entries (repo_id, merge_request_id, user_id)
+			VALUES (?, ?, ?);`)
+	_, err := h.ExecContext(ctx, query, repoID, mrID, userID)
+	return err
+}
+
+// DeleteMergeQueueEntry implements store.MergeQueueStore.
+func (*mergeQueueStore) DeleteMergeQueueEntry(ctx context.Context, h db.Handler, repoID int64, mrID int64) error {
+	query := h.Rebind(`DELETE FROM merge_queue_entries WHERE repo_id = ? AND merge_request_id = ?;`)
+	_, err := h.ExecContext(ctx, query, repoID, mrID)
+	return err
+}
@@ -0,0 +1,44 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/store/database"
+	"github.com/matryer/is"
+)
+
+func TestMergeQueueStore(t *testing.T) {
+	runWithDatabases(t, testMergeQueueStore)
+}
+
+func testMergeQueueStore(t *testing.T, ctx context.Context, dbx *db.DB) {
+	is := is.New(t)
+
+	store := database.New(ctx, dbx)
+	userID, repoID, err := createTestUserAndRepo(ctx, dbx)
+	is.NoErr(err)
+
+	first, err := store.CreateMergeRequest(ctx, dbx, repoID, userID, "First", "", "first", "main")
+	is.NoErr(err)
+	second, err := store.CreateMergeRequest(ctx, dbx, repoID, userID, "Second", "", "second", "main")
+	is.NoErr(err)
+
+	is.NoErr(store.AddMergeQueueEntry(ctx, dbx, repoID, second, userID))
+	is.NoErr(store.AddMergeQueueEntry(ctx, dbx, repoID, first, userID))
+	is.True(store.AddMergeQueueEntry(ctx, dbx, repoID, first, userID) != nil) // Already queued
+
+	// Merge requests are merged in the order they entered the queue.
+	entries, err := store.GetMergeQueueEntriesByRepoID(ctx, dbx, repoID)
+	is.NoErr(err)
+	is.Equal(len(entries), 2)
+	is.Equal(entries[0].MergeRequestID, second)
+	is.Equal(entries[1].MergeRequestID, first)
+
+	is.NoErr(store.DeleteMergeQueueEntry(ctx, dbx, repoID, second))
+	entries, err = store.GetMergeQueueEntriesByRepoID(ctx, dbx, repoID)
+	is.NoErr(err)
+	is.Equal(len(entries), 1)
+	is.Equal(entries[0].MergeRequestID, first)
+}
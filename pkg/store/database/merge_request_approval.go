@@ -0,0 +1,86 @@
+package database
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/store"
+)
+
+type mergeRequestApprovalStore struct{}
+
+var _ store.MergeRequestApprovalStore = (*mergeRequestApprovalStore)(nil)
+
+// AddMergeRequestApproval implements store.MergeRequestApprovalStore.
+func (*mergeRequestApprovalStore) AddMergeRequestApproval(ctx context.Context, h db.Handler, repoID int64, mrID int64, userID int64) error {
+	query := h.Rebind(`
+		INSERT INTO merge_request_approvals (merge_request_id, user_id)
+		SELECT id, ? FROM merge_requests
+		WHERE repo_id = ? AND id = ?
+	`)
+	_, err := h.ExecContext(ctx, query, userID, repoID, mrID)
+	return err
+}
+
+// RemoveMergeRequestApproval implements store.MergeRequestApprovalStore.
+func (*mergeRequestApprovalStore) RemoveMergeRequestApproval(ctx context.Context, h db.Handler, repoID int64, mrID int64, userID int64) error {
+	query := h.Rebind(`
+		DELETE FROM merge_request_approvals
+		WHERE user_id = ? AND merge_request_id IN (
+			SELECT id FROM merge_requests WHERE repo_id = ? AND id = ?
+		)
+	`)
+	_, err := h.ExecContext(ctx, query, userID, repoID, mrID)
+	return err
+}
+
+// GetMergeRequestApprovers implements store.MergeRequestApprovalStore.
+func (*mergeRequestApprovalStore) GetMergeRequestApprovers(ctx context.Context, h db.Handler, repoID int64, mrID int64) ([]models.User, error) {
+	var users []models.User
+	query := h.Rebind(`
+		SELECT ` + selectColumns("users", userColumns...) + ` FROM users
+		INNER JOIN merge_request_approvals ON merge_request_approvals.user_id = users.id
+		INNER JOIN merge_requests ON merge_requests.id = merge_request_approvals.merge_request_id
+		WHERE merge_requests.repo_id = ? AND merge_requests.id = ?
+		ORDER BY merge_request_approvals.created_at ASC, merge_request_approvals.id ASC
+	`)
+	err := h.SelectContext(ctx, &users, query, repoID, mrID)
+	return users, err
+}
+
+// CountMergeRequestApprovals implements store.MergeRequestApprovalStore.
+func (*mergeRequestApprovalStore) CountMergeRequestApprovals(ctx context.Context, h db.Handler, repoID int64, mrID int64) (int, error) {
+	var n int
+	query := h.Rebind(`
+		SELECT COUNT(*) FROM merge_request_approvals
+		INNER JOIN merge_requests ON merge_requests.id = merge_request_approvals.merge_request_id
+		WHERE merge_requests.repo_id = ? AND merge_requests.id = ?
+	`)
+	err := h.GetContext(ctx, &n, query, repoID, mrID)
+	return n, err
+}
+
+// GetMergeRequestApprovalCountsByRepoID implements store.MergeRequestApprovalStore.
+func (*mergeRequestApprovalStore) GetMergeRequestApprovalCountsByRepoID(ctx context.Context, h db.Handler, repoID int64) (map[int64]int, error) {
+	var rows []struct {
+		MergeRequestID int64 `db:"merge_request_id"`
+		Count          int   `db:"count"`
+	}
+	query := h.Rebind(`
+		SELECT merge_request_approvals.merge_request_id, COUNT(*) AS count
+		FROM merge_request_approvals
+		INNER JOIN merge_requests ON merge_requests.id = merge_request_approvals.merge_request_id
+		WHERE merge_requests.repo_id = ?
+		GROUP BY merge_request_approvals.merge_request_id
+	`)
+	if err := h.SelectContext(ctx, &rows, query, repoID); err != nil {
+		return nil, err
+	}
+
+	counts := make(map[int64]int, len(rows))
+	for _, r := range rows {
+		counts[r.MergeRequestID] = r.Count
+	}
+	return counts, nil
+}
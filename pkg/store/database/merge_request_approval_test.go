@@ -0,0 +1,54 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/store/database"
+	"github.com/matryer/is"
+)
+
+func TestMergeRequestApprovals(t *testing.T) {
+	runWithDatabases(t, testMergeRequestApprovals)
+}
+
+func testMergeRequestApprovals(t *testing.T, ctx context.Context, dbx *db.DB) {
+	is := is.New(t)
+
+	store := database.New(ctx, dbx)
+	userID, repoID, err := createTestUserAndRepo(ctx, dbx)
+	is.NoErr(err)
+
+	mrID, err := store.CreateMergeRequest(ctx, dbx, repoID, userID, "Approve me", "", "feature", "main")
+	is.NoErr(err)
+
+	n, err := store.GetRepoRequiredApprovalsByName(ctx, dbx, "testrepo")
+	is.NoErr(err)
+	is.Equal(n, 0)
+	is.NoErr(store.SetRepoRequiredApprovalsByName(ctx, dbx, "testrepo", 2))
+	n, err = store.GetRepoRequiredApprovalsByName(ctx, dbx, "testrepo")
+	is.NoErr(err)
+	is.Equal(n, 2)
+
+	is.NoErr(store.AddMergeRequestApproval(ctx, dbx, repoID, mrID, userID))
+	is.True(store.AddMergeRequestApproval(ctx, dbx, repoID, mrID, userID) != nil) // Duplicate
+
+	approvers, err := store.GetMergeRequestApprovers(ctx, dbx, repoID, mrID)
+	is.NoErr(err)
+	is.Equal(len(approvers), 1)
+	is.Equal(approvers[0].Username, "testuser")
+
+	count, err := store.CountMergeRequestApprovals(ctx, dbx, repoID, mrID)
+	is.NoErr(err)
+	is.Equal(count, 1)
+
+	counts, err := store.GetMergeRequestApprovalCountsByRepoID(ctx, dbx, repoID)
+	is.NoErr(err)
+	is.Equal(counts, map[int64]int{mrID: 1})
+
+	is.NoErr(store.RemoveMergeRequestApproval(ctx, dbx, repoID, mrID, userID))
+	count, err = store.CountMergeRequestApprovals(ctx, dbx, repoID, mrID)
+	is.NoErr(err)
+	is.Equal(count, 0)
+}
@@ -0,0 +1,108 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/store"
+)
+
+type mergeRequestCommentStore struct{}
+
+var _ store.MergeRequestCommentStore = (*mergeRequestCommentStore)(nil)
+
+// mergeRequestCommentColumns are the columns selected when querying merge request comments.
+var mergeRequestCommentColumns = []string{
+	"id",
+	"repo_id",
+	"merge_request_id",
+	"author_id",
+	"path",
+	"commit_sha",
+	"start_line",
+	"end_line",
+	"snippet",
+	"body",
+	"parent_id",
+	"outdated",
+	"hidden",
+	"created_at",
+	"updated_at",
+}
+
+// CreateMergeRequestComment implements store.MergeRequestCommentStore.
+func (*mergeRequestCommentStore) CreateMergeRequestComment(ctx context.Context, h db.Handler, repoID int64, mrID int64, authorID int64, parentID int64, anchor models.CommentAnchor, body string) (int64, error) {
+	parent := sql.NullInt64{Int64: parentID, Valid: parentID != 0}
+	var id int64
+	query := h.Rebind(`INSERT INTO merge_request_comments (repo_id, merge_request_id, author_id, parent_id, path, commit_sha, start_line, end_line, snippet, body, created_at, updated_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP) RETURNING id;`)
+	err := h.GetContext(ctx, &id, query, repoID, mrID, authorID, parent,
+		anchor.Path, anchor.CommitSHA, anchor.StartLine, anchor.EndLine, anchor.Snippet, body)
+	return id, err
+}
+
+// GetMergeRequestCommentByID implements store.MergeRequestCommentStore.
+func (*mergeRequestCommentStore) GetMergeRequestCommentByID(ctx context.Context, h db.Handler, repoID int64, mrID int64, id int64) (models.MergeRequestComment, error) {
+	var c models.MergeRequestComment
+	query := h.Rebind(`SELECT ` + selectColumns("", mergeRequestCommentColumns...) + ` FROM merge_request_comments
+			WHERE repo_id = ? AND merge_request_id = ? AND id = ?;`)
+	err := h.GetContext(ctx, &c, query, repoID, mrID, id)
+	return c, err
+}
+
+// GetRepoMergeRequestComment implements store.MergeRequestCommentStore.
+func (*mergeRequestCommentStore) GetRepoMergeRequestComment(ctx context.Context, h db.Handler, repoID int64, id int64) (models.MergeRequestComment, error) {
+	var c models.MergeRequestComment
+	query := h.Rebind(`SELECT ` + selectColumns("", mergeRequestCommentColumns...) + ` FROM merge_request_comments
+			WHERE repo_id = ? AND id = ?;`)
+	err := h.GetContext(ctx, &c, query, repoID, id)
+	return c, err
+}
+
+// GetMergeRequestComments implements store.MergeRequestCommentStore.
+func (*mergeRequestCommentStore) GetMergeRequestComments(ctx context.Context, h db.Handler, repoID int64, mrID int64) ([]models.MergeRequestComment, error) {
+	var cs []models.MergeRequestComment
+	query := h.Rebind(`SELECT ` + selectColumns("", mergeRequestCommentColumns...) + ` FROM merge_request_comments
+			WHERE repo_id = ? AND merge_request_id = ?
+			ORDER BY created_at ASC, id ASC;`)
+	err := h.SelectContext(ctx, &cs, query, repoID, mrID)
+	return cs, err
+}
+
+// GetCurrentAnchoredMergeRequestComments implements store.MergeRequestCommentStore.
+func (*mergeRequestCommentStore) GetCurrentAnchoredMergeRequestComments(ctx context.Context, h db.Handler, repoID int64, sourceBranch string) ([]models.MergeRequestComment, error) {
+	var cs []models.MergeRequestComment
+	query := h.Rebind(`SELECT ` + selectColumns("c", mergeRequestCommentColumns...) + ` FROM merge_request_comments c
+			INNER JOIN merge_requests mr ON mr.id = c.merge_request_id
+			WHERE c.repo_id = ? AND c.path <> '' AND c.outdated = ?
+			AND mr.source_branch = ? AND mr.state = ?
+			ORDER BY c.id ASC;`)
+	err := h.SelectContext(ctx, &cs, query, repoID, false, sourceBranch, models.MergeRequestStateOpen)
+	return cs, err
+}
+
+// SetMergeRequestCommentOutdated implements store.MergeRequestCommentStore.
+func (*mergeRequestCommentStore) SetMergeRequestCommentOutdated(ctx context.Context, h db.Handler, repoID int64, id int64) error {
+	query := h.Rebind(`UPDATE merge_request_comments SET outdated = ?, updated_at = CURRENT_TIMESTAMP
+			WHERE repo_id = ? AND id = ?;`)
+	_, err := h.ExecContext(ctx, query, true, repoID, id)
+	return err
+}
+
+// SetMergeRequestCommentHidden implements store.MergeRequestCommentStore.
+func (*mergeRequestCommentStore) SetMergeRequestCommentHidden(ctx context.Context, h db.Handler, repoID int64, id int64, hidden bool) error {
+	query := h.Rebind(`UPDATE merge_request_comments SET hidden = ?
+			WHERE repo_id = ? AND id = ?;`)
+	_, err := h.ExecContext(ctx, query, hidden, repoID, id)
+	return err
+}
+
+// DeleteMergeRequestComment implements store.MergeRequestCommentStore.
+func (*mergeRequestCommentStore) DeleteMergeRequestComment(ctx context.Context, h db.Handler, repoID int64, id int64) error {
+	query := h.Rebind(`DELETE FROM merge_request_comments
+			WHERE repo_id = ? AND (id = ? OR parent_id = ?);`)
+	_, err := h.ExecContext(ctx, query, repoID, id, id)
+	return err
+}
@@ -0,0 +1,86 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/store/database"
+	"github.com/matryer/is"
+)
+
+func TestMergeRequestCommentStore(t *testing.T) {
+	runWithDatabases(t, testMergeRequestCommentStore)
+}
+
+func testMergeRequestCommentStore(t *testing.T, ctx context.Context, dbx *db.DB) {
+	is := is.New(t)
+
+	store := database.New(ctx, dbx)
+	userID, repoID, err := createTestUserAndRepo(ctx, dbx)
+	is.NoErr(err)
+
+	mrID, err := store.CreateMergeRequest(ctx, dbx, repoID, userID, "Add feature", "", "feature", "main")
+	is.NoErr(err)
+	otherID, err := store.CreateMergeRequest(ctx, dbx, repoID, userID, "Other", "", "other", "main")
+	is.NoErr(err)
+
+	cs, err := store.GetMergeRequestComments(ctx, dbx, repoID, mrID)
+	is.NoErr(err)
+	is.Equal(len(cs), 0)
+
+	threadID, err := store.CreateMergeRequestComment(ctx, dbx, repoID, mrID, userID, 0, models.CommentAnchor{}, "Why this name?")
+	is.NoErr(err)
+	replyID, err := store.CreateMergeRequestComment(ctx, dbx, repoID, mrID, userID, threadID, models.CommentAnchor{}, "It matches the flag.")
+	is.NoErr(err)
+	_, err = store.CreateMergeRequestComment(ctx, dbx, repoID, otherID, userID, 0, models.CommentAnchor{}, "Elsewhere")
+	is.NoErr(err)
+
+	cs, err = store.GetMergeRequestComments(ctx, dbx, repoID, mrID)
+	is.NoErr(err)
+	is.Equal(len(cs), 2)
+	is.Equal(cs[0].ID, threadID)
+	is.True(!cs[0].ParentID.Valid)
+	is.Equal(cs[1].ID, replyID)
+	is.Equal(cs[1].ParentID.Int64, threadID)
+	is.Equal(cs[1].Body, "It matches the flag.")
+
+	c, err := store.GetMergeRequestCommentByID(ctx, dbx, repoID, mrID, replyID)
+	is.NoErr(err)
+	is.Equal(c.AuthorID, userID)
+
+	// Anchored comments of open merge requests are current until outdated.
+	anchor := models.CommentAnchor{Path: "main.go", CommitSHA: "abc123", StartLine: 3, EndLine: 4, Snippet: "a\nb"}
+	lineID, err := store.CreateMergeRequestComment(ctx, dbx, repoID, mrID, userID, 0, anchor, "Rename this")
+	is.NoErr(err)
+	c, err = store.GetMergeRequestCommentByID(ctx, dbx, repoID, mrID, lineID)
+	is.NoErr(err)
+	is.Equal(c.CommentAnchor, anchor)
+	is.True(!c.Outdated)
+	cs, err = store.GetCurrentAnchoredMergeRequestComments(ctx, dbx, repoID, "feature")
+	is.NoErr(err)
+	is.Equal(len(cs), 1)
+	is.Equal(cs[0].ID, lineID)
+	cs, err = store.GetCurrentAnchoredMergeRequestComments(ctx, dbx, repoID, "other")
+	is.NoErr(err)
+	is.Equal(len(cs), 0)
+	is.NoErr(store.SetMergeRequestCommentOutdated(ctx, dbx, repoID, lineID))
+	c, err = store.GetMergeRequestCommentByID(ctx, dbx, repoID, mrID, lineID)
+	is.NoErr(err)
+	is.True(c.Outdated)
+	cs, err = store.GetCurrentAnchoredMergeRequestComments(ctx, dbx, repoID, "feature")
+	is.NoErr(err)
+	is.Equal(len(cs), 0)
+
+	// Comments are only found on their merge request.
+	_, err = store.GetMergeRequestCommentByID(ctx, dbx, repoID, otherID, replyID)
+	is.True(errors.Is(db.WrapError(err), db.ErrRecordNotFound))
+
+	// Deleting a merge request deletes its comments.
+	is.NoErr(store.DeleteMergeRequest(ctx, dbx, repoID, mrID))
+	cs, err = store.GetMergeRequestComments(ctx, dbx, repoID, mrID)
+	is.NoErr(err)
+	is.Equal(len(cs), 0)
+}
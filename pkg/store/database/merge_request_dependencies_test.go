@@ -0,0 +1,58 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/store/database"
+	"github.com/matryer/is"
+)
+
+func TestMergeRequestDependencies(t *testing.T) {
+	runWithDatabases(t, testMergeRequestDependencies)
+}
+
+func testMergeRequestDependencies(t *testing.T, ctx context.Context, dbx *db.DB) {
+	is := is.New(t)
+
+	store := database.New(ctx, dbx)
+	userID, repoID, err := createTestUserAndRepo(ctx, dbx)
+	is.NoErr(err)
+
+	parent, err := store.CreateMergeRequest(ctx, dbx, repoID, userID, "Parent", "", "parent", "main")
+	is.NoErr(err)
+	child, err := store.CreateMergeRequest(ctx, dbx, repoID, userID, "Child", "", "child", "parent")
+	is.NoErr(err)
+
+	is.NoErr(store.AddMergeRequestDependency(ctx, dbx, repoID, child, parent))
+	is.True(store.AddMergeRequestDependency(ctx, dbx, repoID, child, parent) != nil) // Duplicate
+	is.True(store.AddMergeRequestDependency(ctx, dbx, repoID, child, child) != nil)  // Self
+	is.True(store.AddMergeRequestDependency(ctx, dbx, repoID, child, 9999) != nil)   // Missing
+
+	deps, err := store.GetMergeRequestDependencies(ctx, dbx, repoID, child)
+	is.NoErr(err)
+	is.Equal(len(deps), 1)
+	is.Equal(deps[0].ID, parent)
+
+	dependents, err := store.GetMergeRequestDependents(ctx, dbx, repoID, parent)
+	is.NoErr(err)
+	is.Equal(len(dependents), 1)
+	is.Equal(dependents[0].ID, child)
+
+	all, err := store.GetMergeRequestDependenciesByRepoID(ctx, dbx, repoID)
+	is.NoErr(err)
+	is.Equal(len(all), 1)
+	is.Equal(all[0].MergeRequestID, child)
+	is.Equal(all[0].DependsOnID, parent)
+
+	is.NoErr(store.SetMergeRequestTargetBranch(ctx, dbx, repoID, child, "main"))
+	mr, err := store.GetMergeRequestByID(ctx, dbx, repoID, child)
+	is.NoErr(err)
+	is.Equal(mr.TargetBranch, "main")
+
+	is.NoErr(store.RemoveMergeRequestDependency(ctx, dbx, repoID, child, parent))
+	deps, err = store.GetMergeRequestDependencies(ctx, dbx, repoID, child)
+	is.NoErr(err)
+	is.Equal(len(deps), 0)
+}
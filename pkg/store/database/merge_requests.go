@@ -2,6 +2,7 @@ package database
 
 import (
 	"context"
+	"database/sql"
 
 	"github.com/charmbracelet/soft-serve/pkg/db"
 	"github.com/charmbracelet/soft-serve/pkg/db/models"
@@ -12,11 +13,46 @@ type mergeRequestStore struct{}
 
 var _ store.MergeRequestStore = (*mergeRequestStore)(nil)
 
+// mergeRequestColumns are the columns selected when querying merge requests.
+var mergeRequestColumns = []string{
+	"id",
+	"repo_id",
+	"title",
+	"description",
+	"source_branch",
+	"target_branch",
+	"state",
+	"author_id",
+	"merged_by",
+	"merged_at",
+	"closed_by",
+	"closed_at",
+	"stale_at",
+	"reviewed_by",
+	"reviewed_at",
+	"created_at",
+	"updated_at",
+	"description_truncated",
+	"first_contribution",
+	"hidden",
+	"draft",
+	"edited_by_id",
+	"edited_at",
+}
+
+// mergeRequestDependencyColumns are the columns selected when querying merge request dependencies.
+var mergeRequestDependencyColumns = []string{
+	"id",
+	"merge_request_id",
+	"depends_on_id",
+	"created_at",
+}
+
 // GetMergeRequestByID implements store.MergeRequestStore.
 func (*mergeRequestStore) GetMergeRequestByID(ctx context.Context, h db.Handler, repoID int64, id int64) (models.MergeRequest, error) {
 	var mr models.MergeRequest
 	query := h.Rebind(`
-		SELECT * FROM merge_requests
+		SELECT ` + selectColumns("", mergeRequestColumns...) + ` FROM merge_requests
 		WHERE repo_id = ? AND id = ?
 	`)
 	err := h.GetContext(ctx, &mr, query, repoID, id)
@@ -24,26 +60,41 @@ func (*mergeRequestStore) GetMergeRequestByID(ctx context.Context, h db.Handler,
 }
 
 // GetMergeRequestsByRepoID implements store.MergeRequestStore.
-func (*mergeRequestStore) GetMergeRequestsByRepoID(ctx context.Context, h db.Handler, repoID int64) ([]models.MergeRequest, error) {
+func (*mergeRequestStore) GetMergeRequestsByRepoID(ctx context.Context, h db.Handler, repoID int64, order models.ListOrder, limit int, after int64) ([]models.MergeRequest, error) {
 	var mrs []models.MergeRequest
-	query := h.Rebind(`
-		SELECT * FROM merge_requests
-		WHERE repo_id = ?
-		ORDER BY created_at DESC
-	`)
-	err := h.SelectContext(ctx, &mrs, query, repoID)
+	query, args := pageQuery("merge_requests", mergeRequestColumns, "id", repoID, nil, order, limit, after)
+	err := h.SelectContext(ctx, &mrs, h.Rebind(query), args...)
 	return mrs, err
 }
 
 // GetMergeRequestsByRepoIDAndState implements store.MergeRequestStore.
-func (*mergeRequestStore) GetMergeRequestsByRepoIDAndState(ctx context.Context, h db.Handler, repoID int64, state models.MergeRequestState) ([]models.MergeRequest, error) {
+func (*mergeRequestStore) GetMergeRequestsByRepoIDAndState(ctx context.Context, h db.Handler, repoID int64, state models.MergeRequestState, order models.ListOrder, limit int, after int64) ([]models.MergeRequest, error) {
 	var mrs []models.MergeRequest
-	query := h.Rebind(`
-		SELECT * FROM merge_requests
-		WHERE repo_id = ? AND state = ?
-		ORDER BY created_at DESC
-	`)
-	err := h.SelectContext(ctx, &mrs, query, repoID, state)
+	st := int(state)
+	query, args := pageQuery("merge_requests", mergeRequestColumns, "id", repoID, &st, order, limit, after)
+	err := h.SelectContext(ctx, &mrs, h.Rebind(query), args...)
+	return mrs, err
+}
+
+// SearchMergeRequests implements store.MergeRequestStore.
+func (*mergeRequestStore) SearchMergeRequests(ctx context.Context, h db.Handler, repoIDs []int64, query string, state *models.MergeRequestState, authorID int64, limit int, offset int) ([]models.MergeRequest, error) {
+	if len(repoIDs) == 0 {
+		return nil, nil
+	}
+
+	var st *int
+	if state != nil {
+		s := int(*state)
+		st = &s
+	}
+
+	q, args, err := searchQuery("merge_requests", mergeRequestColumns, repoIDs, query, st, authorID, limit, offset, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var mrs []models.MergeRequest
+	err = h.SelectContext(ctx, &mrs, h.Rebind(q), args...)
 	return mrs, err
 }
 
@@ -52,12 +103,11 @@ func (*mergeRequestStore) CreateMergeRequest(ctx context.Context, h db.Handler,
 	query := h.Rebind(`
 		INSERT INTO merge_requests (repo_id, author_id, title, description, source_branch, target_branch, state, updated_at)
 		VALUES (?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+		RETURNING id
 	`)
-	res, err := h.ExecContext(ctx, query, repoID, authorID, title, description, sourceBranch, targetBranch, models.MergeRequestStateOpen)
-	if err != nil {
-		return 0, err
-	}
-	return res.LastInsertId()
+	var id int64
+	err := h.GetContext(ctx, &id, query, repoID, authorID, title, description, sourceBranch, targetBranch, models.MergeRequestStateOpen)
+	return id, err
 }
 
 // UpdateMergeRequest implements store.MergeRequestStore.
@@ -71,6 +121,17 @@ func (*mergeRequestStore) UpdateMergeRequest(ctx context.Context, h db.Handler,
 	return err
 }
 
+// SetMergeRequestTargetBranch implements store.MergeRequestStore.
+func (*mergeRequestStore) SetMergeRequestTargetBranch(ctx context.Context, h db.Handler, repoID int64, id int64, targetBranch string) error {
+	query := h.Rebind(`
+		UPDATE merge_requests
+		SET target_branch = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE repo_id = ? AND id = ?
+	`)
+	_, err := h.ExecContext(ctx, query, targetBranch, repoID, id)
+	return err
+}
+
 // MergeMergeRequest implements store.MergeRequestStore.
 func (*mergeRequestStore) MergeMergeRequest(ctx context.Context, h db.Handler, repoID int64, id int64, mergedBy int64) error {
 	query := h.Rebind(`
@@ -97,13 +158,115 @@ func (*mergeRequestStore) CloseMergeRequest(ctx context.Context, h db.Handler, r
 func (*mergeRequestStore) ReopenMergeRequest(ctx context.Context, h db.Handler, repoID int64, id int64) error {
 	query := h.Rebind(`
 		UPDATE merge_requests
-		SET state = ?, closed_by = NULL, closed_at = NULL, updated_at = CURRENT_TIMESTAMP
+		SET state = ?, closed_by = NULL, closed_at = NULL, stale_at = NULL, updated_at = CURRENT_TIMESTAMP
 		WHERE repo_id = ? AND id = ? AND state = ?
 	`)
 	_, err := h.ExecContext(ctx, query, models.MergeRequestStateOpen, repoID, id, models.MergeRequestStateClosed)
 	return err
 }
 
+// CountMergeRequestsByRepoID implements store.MergeRequestStore.
+func (*mergeRequestStore) CountMergeRequestsByRepoID(ctx context.Context, h db.Handler, repoID int64, state *models.MergeRequestState) (int64, error) {
+	query := `SELECT COUNT(*) FROM merge_requests WHERE repo_id = ?`
+	args := []interface{}{repoID}
+	if state != nil {
+		query += ` AND state = ?`
+		args = append(args, int(*state))
+	}
+	var count int64
+	err := h.GetContext(ctx, &count, h.Rebind(query), args...)
+	return count, err
+}
+
+// CountMergeRequestsByAuthorID implements store.MergeRequestStore.
+func (*mergeRequestStore) CountMergeRequestsByAuthorID(ctx context.Context, h db.Handler, repoID int64, authorID int64) (int64, error) {
+	query := h.Rebind(`SELECT COUNT(*) FROM merge_requests WHERE repo_id = ? AND author_id = ?`)
+	var count int64
+	err := h.GetContext(ctx, &count, query, repoID, authorID)
+	return count, err
+}
+
+// SetMergeRequestFirstContribution implements store.MergeRequestStore.
+func (*mergeRequestStore) SetMergeRequestFirstContribution(ctx context.Context, h db.Handler, repoID int64, id int64) error {
+	query := h.Rebind(`
+		UPDATE merge_requests
+		SET first_contribution = ?
+		WHERE repo_id = ? AND id = ?
+	`)
+	_, err := h.ExecContext(ctx, query, true, repoID, id)
+	return err
+}
+
+// SetMergeRequestDraft implements store.MergeRequestStore.
+func (*mergeRequestStore) SetMergeRequestDraft(ctx context.Context, h db.Handler, repoID int64, id int64, draft bool) error {
+	query := h.Rebind(`
+		UPDATE merge_requests
+		SET draft = ?
+		WHERE repo_id = ? AND id = ?
+	`)
+	_, err := h.ExecContext(ctx, query, draft, repoID, id)
+	return err
+}
+
+// SetMergeRequestReviewed implements store.MergeRequestStore.
+func (*mergeRequestStore) SetMergeRequestReviewed(ctx context.Context, h db.Handler, repoID int64, id int64, userID int64) error {
+	query := h.Rebind(`
+		UPDATE merge_requests
+		SET reviewed_by = ?, reviewed_at = CURRENT_TIMESTAMP
+		WHERE repo_id = ? AND id = ? AND author_id <> ? AND reviewed_at IS NULL
+	`)
+	_, err := h.ExecContext(ctx, query, userID, repoID, id, userID)
+	return err
+}
+
+// SetMergeRequestStale implements store.MergeRequestStore.
+func (*mergeRequestStore) SetMergeRequestStale(ctx context.Context, h db.Handler, repoID int64, id int64, stale bool) error {
+	set := "stale_at = NULL"
+	if stale {
+		set = "stale_at = CURRENT_TIMESTAMP"
+	}
+	query := h.Rebind(`
+		UPDATE merge_requests
+		SET ` + set + `
+		WHERE repo_id = ? AND id = ? AND state = ?
+	`)
+	_, err := h.ExecContext(ctx, query, repoID, id, models.MergeRequestStateOpen)
+	return err
+}
+
+// CloseStaleMergeRequest implements store.MergeRequestStore.
+func (*mergeRequestStore) CloseStaleMergeRequest(ctx context.Context, h db.Handler, repoID int64, id int64) error {
+	query := h.Rebind(`
+		UPDATE merge_requests
+		SET state = ?, closed_by = NULL, closed_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+		WHERE repo_id = ? AND id = ? AND state = ? AND stale_at IS NOT NULL
+	`)
+	_, err := h.ExecContext(ctx, query, models.MergeRequestStateClosed, repoID, id, models.MergeRequestStateOpen)
+	return err
+}
+
+// SetMergeRequestHidden implements store.MergeRequestStore.
+func (*mergeRequestStore) SetMergeRequestHidden(ctx context.Context, h db.Handler, repoID int64, id int64, hidden bool) error {
+	query := h.Rebind(`
+		UPDATE merge_requests
+		SET hidden = ?
+		WHERE repo_id = ? AND id = ?
+	`)
+	_, err := h.ExecContext(ctx, query, hidden, repoID, id)
+	return err
+}
+
+// SetMergeRequestEditedBy implements store.MergeRequestStore.
+func (*mergeRequestStore) SetMergeRequestEditedBy(ctx context.Context, h db.Handler, repoID int64, id int64, userID int64) error {
+	query := h.Rebind(`
+		UPDATE merge_requests
+		SET edited_by_id = ?, edited_at = CURRENT_TIMESTAMP
+		WHERE repo_id = ? AND id = ?
+	`)
+	_, err := h.ExecContext(ctx, query, userID, repoID, id)
+	return err
+}
+
 // DeleteMergeRequest implements store.MergeRequestStore.
 func (*mergeRequestStore) DeleteMergeRequest(ctx context.Context, h db.Handler, repoID int64, id int64) error {
 	query := h.Rebind(`
@@ -113,3 +276,127 @@ func (*mergeRequestStore) DeleteMergeRequest(ctx context.Context, h db.Handler,
 	_, err := h.ExecContext(ctx, query, repoID, id)
 	return err
 }
+
+// AddMergeRequestReviewer implements store.MergeRequestStore.
+func (*mergeRequestStore) AddMergeRequestReviewer(ctx context.Context, h db.Handler, repoID int64, mrID int64, userID int64) error {
+	query := h.Rebind(`
+		INSERT INTO merge_request_reviewers (merge_request_id, user_id)
+		SELECT id, ? FROM merge_requests
+		WHERE repo_id = ? AND id = ?
+	`)
+	_, err := h.ExecContext(ctx, query, userID, repoID, mrID)
+	return err
+}
+
+// RemoveMergeRequestReviewer implements store.MergeRequestStore.
+func (*mergeRequestStore) RemoveMergeRequestReviewer(ctx context.Context, h db.Handler, repoID int64, mrID int64, userID int64) error {
+	query := h.Rebind(`
+		DELETE FROM merge_request_reviewers
+		WHERE user_id = ? AND merge_request_id IN (
+			SELECT id FROM merge_requests WHERE repo_id = ? AND id = ?
+		)
+	`)
+	_, err := h.ExecContext(ctx, query, userID, repoID, mrID)
+	return err
+}
+
+// GetMergeRequestReviewers implements store.MergeRequestStore.
+func (*mergeRequestStore) GetMergeRequestReviewers(ctx context.Context, h db.Handler, repoID int64, mrID int64) ([]models.User, error) {
+	var users []models.User
+	query := h.Rebind(`
+		SELECT ` + selectColumns("users", userColumns...) + ` FROM users
+		INNER JOIN merge_request_reviewers ON merge_request_reviewers.user_id = users.id
+		INNER JOIN merge_requests ON merge_requests.id = merge_request_reviewers.merge_request_id
+		WHERE merge_requests.repo_id = ? AND merge_requests.id = ?
+		ORDER BY users.username ASC
+	`)
+	err := h.SelectContext(ctx, &users, query, repoID, mrID)
+	return users, err
+}
+
+// GetOpenMergeRequestsByReviewerID implements store.MergeRequestStore.
+func (*mergeRequestStore) GetOpenMergeRequestsByReviewerID(ctx context.Context, h db.Handler, userID int64) ([]models.MergeRequest, error) {
+	var mrs []models.MergeRequest
+	query := h.Rebind(`
+		SELECT ` + selectColumns("merge_requests", mergeRequestColumns...) + ` FROM merge_requests
+		INNER JOIN merge_request_reviewers ON merge_request_reviewers.merge_request_id = merge_requests.id
+		WHERE merge_request_reviewers.user_id = ? AND merge_requests.state = ?
+		ORDER BY merge_requests.created_at ASC, merge_requests.id ASC
+	`)
+	err := h.SelectContext(ctx, &mrs, query, userID, models.MergeRequestStateOpen)
+	return mrs, err
+}
+
+// AddMergeRequestDependency implements store.MergeRequestStore.
+func (*mergeRequestStore) AddMergeRequestDependency(ctx context.Context, h db.Handler, repoID int64, mrID int64, dependsOnID int64) error {
+	// Verify both merge requests exist and belong to the same repository
+	query := h.Rebind(`
+		SELECT COUNT(*) FROM merge_requests
+		WHERE repo_id = ? AND (id = ? OR id = ?)
+	`)
+	var count int
+	if err := h.GetContext(ctx, &count, query, repoID, mrID, dependsOnID); err != nil {
+		return err
+	}
+	if count != 2 {
+		return sql.ErrNoRows
+	}
+
+	query = h.Rebind(`
+		INSERT INTO merge_request_dependencies (merge_request_id, depends_on_id)
+		VALUES (?, ?)
+	`)
+	_, err := h.ExecContext(ctx, query, mrID, dependsOnID)
+	return err
+}
+
+// RemoveMergeRequestDependency implements store.MergeRequestStore.
+func (*mergeRequestStore) RemoveMergeRequestDependency(ctx context.Context, h db.Handler, repoID int64, mrID int64, dependsOnID int64) error {
+	query := h.Rebind(`
+		DELETE FROM merge_request_dependencies
+		WHERE merge_request_id IN (
+			SELECT id FROM merge_requests WHERE repo_id = ? AND id = ?
+		) AND depends_on_id = ?
+	`)
+	_, err := h.ExecContext(ctx, query, repoID, mrID, dependsOnID)
+	return err
+}
+
+// GetMergeRequestDependencies implements store.MergeRequestStore.
+func (*mergeRequestStore) GetMergeRequestDependencies(ctx context.Context, h db.Handler, repoID int64, mrID int64) ([]models.MergeRequest, error) {
+	var mrs []models.MergeRequest
+	query := h.Rebind(`
+		SELECT ` + selectColumns("m", mergeRequestColumns...) + `
+		FROM merge_request_dependencies d CROSS JOIN merge_requests m
+		WHERE m.id = d.depends_on_id AND d.merge_request_id = ? AND m.repo_id = ?
+		ORDER BY m.id
+	`)
+	err := h.SelectContext(ctx, &mrs, query, mrID, repoID)
+	return mrs, err
+}
+
+// GetMergeRequestDependents implements store.MergeRequestStore.
+func (*mergeRequestStore) GetMergeRequestDependents(ctx context.Context, h db.Handler, repoID int64, mrID int64) ([]models.MergeRequest, error) {
+	var mrs []models.MergeRequest
+	query := h.Rebind(`
+		SELECT ` + selectColumns("m", mergeRequestColumns...) + `
+		FROM merge_request_dependencies d CROSS JOIN merge_requests m
+		WHERE m.id = d.merge_request_id AND d.depends_on_id = ? AND m.repo_id = ?
+		ORDER BY m.id
+	`)
+	err := h.SelectContext(ctx, &mrs, query, mrID, repoID)
+	return mrs, err
+}
+
+// GetMergeRequestDependenciesByRepoID implements store.MergeRequestStore.
+func (*mergeRequestStore) GetMergeRequestDependenciesByRepoID(ctx context.Context, h db.Handler, repoID int64) ([]models.MergeRequestDependency, error) {
+	var deps []models.MergeRequestDependency
+	query := h.Rebind(`
+		SELECT ` + selectColumns("d", mergeRequestDependencyColumns...) + ` FROM merge_request_dependencies d
+		INNER JOIN merge_requests m ON m.id = d.merge_request_id
+		WHERE m.repo_id = ?
+		ORDER BY d.merge_request_id, d.depends_on_id
+	`)
+	err := h.SelectContext(ctx, &deps, query, repoID)
+	return deps, err
+}
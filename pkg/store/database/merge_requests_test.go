@@ -4,50 +4,24 @@ import (
 	"context"
 	"testing"
 
-	"github.com/charmbracelet/soft-serve/pkg/config"
 	"github.com/charmbracelet/soft-serve/pkg/db"
-	"github.com/charmbracelet/soft-serve/pkg/db/migrate"
 	"github.com/charmbracelet/soft-serve/pkg/db/models"
 	"github.com/charmbracelet/soft-serve/pkg/store/database"
 	"github.com/matryer/is"
 )
 
 func TestMergeRequestStore(t *testing.T) {
-	is := is.New(t)
-
-	// Setup database
-	ctx := config.WithContext(context.TODO(), config.DefaultConfig())
-	dbx, err := openTestDB(ctx, t)
-	is.NoErr(err)
+	runWithDatabases(t, testMergeRequestStore)
+}
 
-	// Run migrations
-	is.NoErr(migrate.Migrate(ctx, dbx))
+func testMergeRequestStore(t *testing.T, ctx context.Context, dbx *db.DB) {
+	is := is.New(t)
 
 	// Create store
 	store := database.New(ctx, dbx)
 
 	// Create test data: user and repo
-	var userID, repoID int64
-	err = dbx.TransactionContext(ctx, func(tx *db.Tx) error {
-		// Create user
-		result, err := tx.ExecContext(ctx, "INSERT INTO users (username, admin, created_at, updated_at) VALUES (?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)", "testuser", false)
-		if err != nil {
-			return err
-		}
-		userID, err = result.LastInsertId()
-		if err != nil {
-			return err
-		}
-
-		// Create repo
-		result, err = tx.ExecContext(ctx, "INSERT INTO repos (name, project_name, description, private, mirror, hidden, user_id, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)",
-			"testrepo", "Test Repo", "Test Description", false, false, false, userID)
-		if err != nil {
-			return err
-		}
-		repoID, err = result.LastInsertId()
-		return err
-	})
+	userID, repoID, err := createTestUserAndRepo(ctx, dbx)
 	is.NoErr(err)
 
 	// Test CreateMergeRequest
@@ -111,11 +85,22 @@ func TestMergeRequestStore(t *testing.T) {
 		var mrs []models.MergeRequest
 		err = dbx.TransactionContext(ctx, func(tx *db.Tx) error {
 			var err error
-			mrs, err = store.GetMergeRequestsByRepoID(ctx, tx, repoID)
+			mrs, err = store.GetMergeRequestsByRepoID(ctx, tx, repoID, models.ListOrder{}, 0, 0)
 			return err
 		})
 		is.NoErr(err)
 		is.True(len(mrs) >= 2) // At least 2 MRs
+
+		// Pages follow each other, newest first, without gaps.
+		first, err := store.GetMergeRequestsByRepoID(ctx, dbx, repoID, models.ListOrder{}, 1, 0)
+		is.NoErr(err)
+		is.Equal(len(first), 1)
+		is.Equal(first[0].ID, mrs[0].ID)
+		second, err := store.GetMergeRequestsByRepoID(ctx, dbx, repoID, models.ListOrder{}, 1, first[0].ID)
+		is.NoErr(err)
+		is.Equal(len(second), 1)
+		is.Equal(second[0].ID, mrs[1].ID)
+		is.True(second[0].ID < first[0].ID)
 	})
 
 	// Test GetMergeRequestsByRepoIDAndState
@@ -138,7 +123,7 @@ func TestMergeRequestStore(t *testing.T) {
 		var openMRs []models.MergeRequest
 		err = dbx.TransactionContext(ctx, func(tx *db.Tx) error {
 			var err error
-			openMRs, err = store.GetMergeRequestsByRepoIDAndState(ctx, tx, repoID, models.MergeRequestStateOpen)
+			openMRs, err = store.GetMergeRequestsByRepoIDAndState(ctx, tx, repoID, models.MergeRequestStateOpen, models.ListOrder{}, 0, 0)
 			return err
 		})
 		is.NoErr(err)
@@ -152,7 +137,7 @@ func TestMergeRequestStore(t *testing.T) {
 		var closedMRs []models.MergeRequest
 		err = dbx.TransactionContext(ctx, func(tx *db.Tx) error {
 			var err error
-			closedMRs, err = store.GetMergeRequestsByRepoIDAndState(ctx, tx, repoID, models.MergeRequestStateClosed)
+			closedMRs, err = store.GetMergeRequestsByRepoIDAndState(ctx, tx, repoID, models.MergeRequestStateClosed, models.ListOrder{}, 0, 0)
 			return err
 		})
 		is.NoErr(err)
@@ -287,8 +272,8 @@ func TestMergeRequestStore(t *testing.T) {
 		})
 		is.NoErr(err)
 		is.Equal(mr.State, models.MergeRequestStateOpen)
-		is.True(!mr.ClosedBy.Valid)  // Should be NULL
-		is.True(!mr.ClosedAt.Valid)  // Should be NULL
+		is.True(!mr.ClosedBy.Valid) // Should be NULL
+		is.True(!mr.ClosedAt.Valid) // Should be NULL
 	})
 
 	// Test DeleteMergeRequest
@@ -317,4 +302,115 @@ func TestMergeRequestStore(t *testing.T) {
 		})
 		is.True(err != nil) // Should error (not found)
 	})
+
+	// Test merge request reviewers
+	t.Run("Reviewers", func(t *testing.T) {
+		is := is.New(t)
+
+		var openID, closedID int64
+		err := dbx.TransactionContext(ctx, func(tx *db.Tx) error {
+			var err error
+			openID, err = store.CreateMergeRequest(ctx, tx, repoID, userID, "Review me", "Description", "feature", "main")
+			if err != nil {
+				return err
+			}
+			closedID, err = store.CreateMergeRequest(ctx, tx, repoID, userID, "Closed review", "Description", "feature", "main")
+			if err != nil {
+				return err
+			}
+			if err := store.AddMergeRequestReviewer(ctx, tx, repoID, openID, userID); err != nil {
+				return err
+			}
+			if err := store.AddMergeRequestReviewer(ctx, tx, repoID, closedID, userID); err != nil {
+				return err
+			}
+			return store.CloseMergeRequest(ctx, tx, repoID, closedID, userID)
+		})
+		is.NoErr(err)
+
+		// Reviewers are unique per merge request
+		err = dbx.TransactionContext(ctx, func(tx *db.Tx) error {
+			return store.AddMergeRequestReviewer(ctx, tx, repoID, openID, userID)
+		})
+		is.True(err != nil)
+
+		var reviewers []models.User
+		var queue []models.MergeRequest
+		err = dbx.TransactionContext(ctx, func(tx *db.Tx) error {
+			var err error
+			reviewers, err = store.GetMergeRequestReviewers(ctx, tx, repoID, openID)
+			if err != nil {
+				return err
+			}
+			queue, err = store.GetOpenMergeRequestsByReviewerID(ctx, tx, userID)
+			return err
+		})
+		is.NoErr(err)
+		is.Equal(len(reviewers), 1)
+		is.Equal(reviewers[0].ID, userID)
+		is.Equal(len(queue), 1) // Closed merge requests are left out
+		is.Equal(queue[0].ID, openID)
+
+		err = dbx.TransactionContext(ctx, func(tx *db.Tx) error {
+			if err := store.RemoveMergeRequestReviewer(ctx, tx, repoID, openID, userID); err != nil {
+				return err
+			}
+			var err error
+			queue, err = store.GetOpenMergeRequestsByReviewerID(ctx, tx, userID)
+			return err
+		})
+		is.NoErr(err)
+		is.Equal(len(queue), 0)
+	})
+
+	// Test SetMergeRequestReviewed
+	t.Run("Reviewed", func(t *testing.T) {
+		is := is.New(t)
+
+		var mrID int64
+		err := dbx.TransactionContext(ctx, func(tx *db.Tx) error {
+			var err error
+			mrID, err = store.CreateMergeRequest(ctx, tx, repoID, userID, "Reviewed", "Description", "feature", "main")
+			if err != nil {
+				return err
+			}
+			// The author acting on their merge request isn't a review.
+			return store.SetMergeRequestReviewed(ctx, tx, repoID, mrID, userID)
+		})
+		is.NoErr(err)
+
+		mr, err := store.GetMergeRequestByID(ctx, dbx, repoID, mrID)
+		is.NoErr(err)
+		is.True(!mr.ReviewedAt.Valid)
+
+		// Only the first review counts.
+		is.NoErr(store.SetMergeRequestReviewed(ctx, dbx, repoID, mrID, userID+1))
+		is.NoErr(store.SetMergeRequestReviewed(ctx, dbx, repoID, mrID, userID+2))
+		mr, err = store.GetMergeRequestByID(ctx, dbx, repoID, mrID)
+		is.NoErr(err)
+		is.True(mr.ReviewedAt.Valid)
+		is.Equal(mr.ReviewedBy.Int64, userID+1)
+	})
+
+	t.Run("CountMergeRequestsByRepoID", func(t *testing.T) {
+		is := is.New(t)
+
+		all, err := store.GetMergeRequestsByRepoID(ctx, dbx, repoID, models.ListOrder{}, 0, 0)
+		is.NoErr(err)
+		count, err := store.CountMergeRequestsByRepoID(ctx, dbx, repoID, nil)
+		is.NoErr(err)
+		is.Equal(count, int64(len(all)))
+
+		state := models.MergeRequestStateOpen
+		open, err := store.GetMergeRequestsByRepoIDAndState(ctx, dbx, repoID, state, models.ListOrder{}, 0, 0)
+		is.NoErr(err)
+		count, err = store.CountMergeRequestsByRepoID(ctx, dbx, repoID, &state)
+		is.NoErr(err)
+		is.Equal(count, int64(len(open)))
+		is.True(count < int64(len(all)))
+
+		count, err = store.CountMergeRequestsByRepoID(ctx, dbx, repoID+1, nil)
+		is.NoErr(err)
+		is.Equal(count, int64(0))
+	})
 }
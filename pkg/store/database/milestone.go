@@ -0,0 +1,206 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/store"
+)
+
+type milestoneStore struct{}
+
+var _ store.MilestoneStore = (*milestoneStore)(nil)
+
+// milestoneProgressQuery selects milestones with the number of their open and
+// closed issues and merge requests. It takes the open and closed issue
+// states, and the open merge request state, as arguments.
+const milestoneProgressQuery = `
+	SELECT milestones.*,
+		(SELECT COUNT(*) FROM milestone_issues
+			INNER JOIN issues ON issues.id = milestone_issues.issue_id
+			WHERE milestone_issues.milestone_id = milestones.id AND issues.state = ?) AS open_issues,
+		(SELECT COUNT(*) FROM milestone_issues
+			INNER JOIN issues ON issues.id = milestone_issues.issue_id
+			WHERE milestone_issues.milestone_id = milestones.id AND issues.state = ?) AS closed_issues,
+		(SELECT COUNT(*) FROM milestone_merge_requests
+			INNER JOIN merge_requests ON merge_requests.id = milestone_merge_requests.merge_request_id
+			WHERE milestone_merge_requests.milestone_id = milestones.id AND merge_requests.state = ?) AS open_merge_requests,
+		(SELECT COUNT(*) FROM milestone_merge_requests
+			INNER JOIN merge_requests ON merge_requests.id = milestone_merge_requests.merge_request_id
+			WHERE milestone_merge_requests.milestone_id = milestones.id AND merge_requests.state != ?) AS closed_merge_requests
+	FROM milestones
+`
+
+// milestoneProgressArgs are the arguments of milestoneProgressQuery.
+var milestoneProgressArgs = []interface{}{
+	models.IssueStateOpen,
+	models.IssueStateClosed,
+	models.MergeRequestStateOpen,
+	models.MergeRequestStateOpen,
+}
+
+// GetMilestonesByRepoID implements store.MilestoneStore.
+func (*milestoneStore) GetMilestonesByRepoID(ctx context.Context, h db.Handler, repoID int64) ([]models.MilestoneProgress, error) {
+	query := h.Rebind(milestoneProgressQuery + `
+		WHERE milestones.repo_id = ?
+		ORDER BY milestones.due_date IS NULL, milestones.due_date, milestones.title
+	`)
+	var milestones []models.MilestoneProgress
+	err := h.SelectContext(ctx, &milestones, query, append(milestoneProgressArgs, repoID)...)
+	return milestones, err
+}
+
+// GetMilestoneByTitle implements store.MilestoneStore.
+func (*milestoneStore) GetMilestoneByTitle(ctx context.Context, h db.Handler, repoID int64, title string) (models.MilestoneProgress, error) {
+	query := h.Rebind(milestoneProgressQuery + `
+		WHERE milestones.repo_id = ? AND milestones.title = ?
+	`)
+	var milestone models.MilestoneProgress
+	err := h.GetContext(ctx, &milestone, query, append(milestoneProgressArgs, repoID, title)...)
+	return milestone, err
+}
+
+// CreateMilestone implements store.MilestoneStore.
+func (*milestoneStore) CreateMilestone(ctx context.Context, h db.Handler, repoID int64, title string, description string, dueDate sql.NullTime) error {
+	query := h.Rebind(`INSERT INTO milestones (repo_id, title, description, due_date, updated_at)
+			VALUES (?, ?, ?, ?, CURRENT_TIMESTAMP);`)
+	_, err := h.ExecContext(ctx, query, repoID, title, description, dueDate)
+	return err
+}
+
+// UpdateMilestone implements store.MilestoneStore.
+func (*milestoneStore) UpdateMilestone(ctx context.Context, h db.Handler, repoID int64, id int64, title string, description string, dueDate sql.NullTime) error {
+	query := h.Rebind(`UPDATE milestones SET title = ?, description = ?, due_date = ?, updated_at = CURRENT_TIMESTAMP
+			WHERE repo_id = ? AND id = ?;`)
+	_, err := h.ExecContext(ctx, query, title, description, dueDate, repoID, id)
+	return err
+}
+
+// SetMilestoneState implements store.MilestoneStore.
+func (*milestoneStore) SetMilestoneState(ctx context.Context, h db.Handler, repoID int64, id int64, state models.MilestoneState) error {
+	var query string
+	if state == models.MilestoneStateClosed {
+		query = `UPDATE milestones SET state = ?, closed_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+			WHERE repo_id = ? AND id = ?;`
+	} else {
+		query = `UPDATE milestones SET state = ?, closed_at = NULL, updated_at = CURRENT_TIMESTAMP
+			WHERE repo_id = ? AND id = ?;`
+	}
+	_, err := h.ExecContext(ctx, h.Rebind(query), state, repoID, id)
+	return err
+}
+
+// DeleteMilestone implements store.MilestoneStore.
+func (*milestoneStore) DeleteMilestone(ctx context.Context, h db.Handler, repoID int64, id int64) error {
+	query := h.Rebind(`DELETE FROM milestones WHERE repo_id = ? AND id = ?;`)
+	_, err := h.ExecContext(ctx, query, repoID, id)
+	return err
+}
+
+// SetIssueMilestone implements store.MilestoneStore.
+func (s *milestoneStore) SetIssueMilestone(ctx context.Context, h db.Handler, repoID int64, issueID int64, milestoneID int64) error {
+	if err := s.RemoveIssueMilestone(ctx, h, repoID, issueID); err != nil {
+		return err
+	}
+
+	query := h.Rebind(`
+		INSERT INTO milestone_issues (milestone_id, issue_id)
+		SELECT milestones.id, issues.id FROM issues
+		INNER JOIN milestones ON milestones.repo_id = issues.repo_id
+		WHERE issues.repo_id = ? AND issues.id = ? AND milestones.id = ?
+	`)
+	_, err := h.ExecContext(ctx, query, repoID, issueID, milestoneID)
+	return err
+}
+
+// RemoveIssueMilestone implements store.MilestoneStore.
+func (*milestoneStore) RemoveIssueMilestone(ctx context.Context, h db.Handler, repoID int64, issueID int64) error {
+	query := h.Rebind(`
+		DELETE FROM milestone_issues
+		WHERE issue_id IN (
+			SELECT id FROM issues WHERE repo_id = ? AND id = ?
+		)
+	`)
+	_, err := h.ExecContext(ctx, query, repoID, issueID)
+	return err
+}
+
+// GetIssueMilestone implements store.MilestoneStore.
+func (*milestoneStore) GetIssueMilestone(ctx context.Context, h db.Handler, repoID int64, issueID int64) (models.Milestone, error) {
+	query := h.Rebind(`
+		SELECT milestones.* FROM milestones
+		INNER JOIN milestone_issues ON milestone_issues.milestone_id = milestones.id
+		WHERE milestones.repo_id = ? AND milestone_issues.issue_id = ?
+	`)
+	var milestone models.Milestone
+	err := h.GetContext(ctx, &milestone, query, repoID, issueID)
+	return milestone, err
+}
+
+// GetMilestoneIssues implements store.MilestoneStore.
+func (*milestoneStore) GetMilestoneIssues(ctx context.Context, h db.Handler, repoID int64, milestoneID int64) ([]models.Issue, error) {
+	query := h.Rebind(`
+		SELECT ` + selectColumns("issues", issueColumns...) + ` FROM issues
+		INNER JOIN milestone_issues ON milestone_issues.issue_id = issues.id
+		WHERE issues.repo_id = ? AND milestone_issues.milestone_id = ?
+		ORDER BY issues.id
+	`)
+	var issues []models.Issue
+	err := h.SelectContext(ctx, &issues, query, repoID, milestoneID)
+	return issues, err
+}
+
+// SetMergeRequestMilestone implements store.MilestoneStore.
+func (s *milestoneStore) SetMergeRequestMilestone(ctx context.Context, h db.Handler, repoID int64, mrID int64, milestoneID int64) error {
+	if err := s.RemoveMergeRequestMilestone(ctx, h, repoID, mrID); err != nil {
+		return err
+	}
+
+	query := h.Rebind(`
+		INSERT INTO milestone_merge_requests (milestone_id, merge_request_id)
+		SELECT milestones.id, merge_requests.id FROM merge_requests
+		INNER JOIN milestones ON milestones.repo_id = merge_requests.repo_id
+		WHERE merge_requests.repo_id = ? AND merge_requests.id = ? AND milestones.id = ?
+	`)
+	_, err := h.ExecContext(ctx, query, repoID, mrID, milestoneID)
+	return err
+}
+
+// RemoveMergeRequestMilestone implements store.MilestoneStore.
+func (*milestoneStore) RemoveMergeRequestMilestone(ctx context.Context, h db.Handler, repoID int64, mrID int64) error {
+	query := h.Rebind(`
+		DELETE FROM milestone_merge_requests
+		WHERE merge_request_id IN (
+			SELECT id FROM merge_requests WHERE repo_id = ? AND id = ?
+		)
+	`)
+	_, err := h.ExecContext(ctx, query, repoID, mrID)
+	return err
+}
+
+// GetMergeRequestMilestone implements store.MilestoneStore.
+func (*milestoneStore) GetMergeRequestMilestone(ctx context.Context, h db.Handler, repoID int64, mrID int64) (models.Milestone, error) {
+	query := h.Rebind(`
+		SELECT milestones.* FROM milestones
+		INNER JOIN milestone_merge_requests ON milestone_merge_requests.milestone_id = milestones.id
+		WHERE milestones.repo_id = ? AND milestone_merge_requests.merge_request_id = ?
+	`)
+	var milestone models.Milestone
+	err := h.GetContext(ctx, &milestone, query, repoID, mrID)
+	return milestone, err
+}
+
+// GetMilestoneMergeRequests implements store.MilestoneStore.
+func (*milestoneStore) GetMilestoneMergeRequests(ctx context.Context, h db.Handler, repoID int64, milestoneID int64) ([]models.MergeRequest, error) {
+	query := h.Rebind(`
+		SELECT ` + selectColumns("merge_requests", mergeRequestColumns...) + ` FROM merge_requests
+		INNER JOIN milestone_merge_requests ON milestone_merge_requests.merge_request_id = merge_requests.id
+		WHERE merge_requests.repo_id = ? AND milestone_merge_requests.milestone_id = ?
+		ORDER BY merge_requests.id
+	`)
+	var mrs []models.MergeRequest
+	err := h.SelectContext(ctx, &mrs, query, repoID, milestoneID)
+	return mrs, err
+}
@@ -0,0 +1,94 @@
+package database_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/store/database"
+	"github.com/matryer/is"
+)
+
+func TestMilestoneStore(t *testing.T) {
+	runWithDatabases(t, testMilestoneStore)
+}
+
+func testMilestoneStore(t *testing.T, ctx context.Context, dbx *db.DB) {
+	is := is.New(t)
+
+	store := database.New(ctx, dbx)
+	userID, repoID, err := createTestUserAndRepo(ctx, dbx)
+	is.NoErr(err)
+
+	due := sql.NullTime{Time: time.Date(2030, 1, 31, 0, 0, 0, 0, time.UTC), Valid: true}
+	is.NoErr(store.CreateMilestone(ctx, dbx, repoID, "v2.0", "", sql.NullTime{}))
+	is.NoErr(store.CreateMilestone(ctx, dbx, repoID, "v1.0", "First release", due))
+
+	// Milestone titles are unique per repository.
+	err = store.CreateMilestone(ctx, dbx, repoID, "v1.0", "", sql.NullTime{})
+	is.True(err != nil)
+
+	// Milestones with a due date come first.
+	milestones, err := store.GetMilestonesByRepoID(ctx, dbx, repoID)
+	is.NoErr(err)
+	is.Equal(len(milestones), 2)
+	is.Equal(milestones[0].Title, "v1.0")
+	is.True(milestones[0].DueDate.Valid)
+	is.Equal(milestones[1].Title, "v2.0")
+
+	v1 := milestones[0]
+	v2 := milestones[1]
+
+	issue1, err := store.CreateIssue(ctx, dbx, repoID, userID, "Crash", "")
+	is.NoErr(err)
+	issue2, err := store.CreateIssue(ctx, dbx, repoID, userID, "Typo", "")
+	is.NoErr(err)
+	mrID, err := store.CreateMergeRequest(ctx, dbx, repoID, userID, "Fix crash", "", "fix", "main")
+	is.NoErr(err)
+
+	is.NoErr(store.SetIssueMilestone(ctx, dbx, repoID, issue1, v2.ID))
+	is.NoErr(store.SetIssueMilestone(ctx, dbx, repoID, issue2, v1.ID))
+	is.NoErr(store.SetMergeRequestMilestone(ctx, dbx, repoID, mrID, v1.ID))
+
+	// Setting a milestone replaces the previous one.
+	is.NoErr(store.SetIssueMilestone(ctx, dbx, repoID, issue1, v1.ID))
+	m, err := store.GetIssueMilestone(ctx, dbx, repoID, issue1)
+	is.NoErr(err)
+	is.Equal(m.ID, v1.ID)
+
+	is.NoErr(store.CloseIssue(ctx, dbx, repoID, issue2, userID, models.IssueCloseReasonCompleted))
+	is.NoErr(store.CloseMergeRequest(ctx, dbx, repoID, mrID, userID))
+
+	progress, err := store.GetMilestoneByTitle(ctx, dbx, repoID, "v1.0")
+	is.NoErr(err)
+	is.Equal(progress.OpenIssues, 1)
+	is.Equal(progress.ClosedIssues, 1)
+	is.Equal(progress.OpenMergeRequests, 0)
+	is.Equal(progress.ClosedMergeRequests, 1)
+	is.Equal(progress.Percent(), 66)
+
+	issues, err := store.GetMilestoneIssues(ctx, dbx, repoID, v1.ID)
+	is.NoErr(err)
+	is.Equal(len(issues), 2)
+	mrs, err := store.GetMilestoneMergeRequests(ctx, dbx, repoID, v1.ID)
+	is.NoErr(err)
+	is.Equal(len(mrs), 1)
+
+	is.NoErr(store.SetMilestoneState(ctx, dbx, repoID, v1.ID, models.MilestoneStateClosed))
+	progress, err = store.GetMilestoneByTitle(ctx, dbx, repoID, "v1.0")
+	is.NoErr(err)
+	is.Equal(progress.State, models.MilestoneStateClosed)
+	is.True(progress.ClosedAt.Valid)
+
+	is.NoErr(store.RemoveMergeRequestMilestone(ctx, dbx, repoID, mrID))
+	_, err = store.GetMergeRequestMilestone(ctx, dbx, repoID, mrID)
+	is.True(err != nil)
+
+	// Deleting a milestone detaches it.
+	is.NoErr(store.DeleteMilestone(ctx, dbx, repoID, v1.ID))
+	_, err = store.GetIssueMilestone(ctx, dbx, repoID, issue1)
+	is.True(err != nil)
+}
@@ -0,0 +1,108 @@
+package database
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/store"
+)
+
+type moderationStore struct{}
+
+var _ store.ModerationStore = (*moderationStore)(nil)
+
+// contentReportColumns are the columns selected when querying content reports.
+var contentReportColumns = []string{
+	"id",
+	"repo_id",
+	"subject_type",
+	"subject_id",
+	"reporter_id",
+	"reason",
+	"resolved_by",
+	"resolved_at",
+	"created_at",
+}
+
+// AddRepoBlock implements store.ModerationStore.
+func (*moderationStore) AddRepoBlock(ctx context.Context, h db.Handler, repoID int64, userID int64) error {
+	query := h.Rebind(`INSERT INTO repo_blocks (repo_id, user_id) VALUES (?, ?);`)
+	_, err := h.ExecContext(ctx, query, repoID, userID)
+	return err
+}
+
+// RemoveRepoBlock implements store.ModerationStore.
+func (*moderationStore) RemoveRepoBlock(ctx context.Context, h db.Handler, repoID int64, userID int64) error {
+	query := h.Rebind(`DELETE FROM repo_blocks WHERE repo_id = ? AND user_id = ?;`)
+	_, err := h.ExecContext(ctx, query, repoID, userID)
+	return err
+}
+
+// GetRepoBlockedUsers implements store.ModerationStore.
+func (*moderationStore) GetRepoBlockedUsers(ctx context.Context, h db.Handler, repoID int64) ([]models.User, error) {
+	var users []models.User
+	query := h.Rebind(`
+		SELECT ` + selectColumns("users", userColumns...) + ` FROM users
+		INNER JOIN repo_blocks ON repo_blocks.user_id = users.id
+		WHERE repo_blocks.repo_id = ?
+		ORDER BY users.username ASC
+	`)
+	err := h.SelectContext(ctx, &users, query, repoID)
+	return users, err
+}
+
+// IsUserBlockedFromRepo implements store.ModerationStore.
+func (*moderationStore) IsUserBlockedFromRepo(ctx context.Context, h db.Handler, repoID int64, userID int64) (bool, error) {
+	var count int
+	query := h.Rebind(`SELECT COUNT(*) FROM repo_blocks WHERE repo_id = ? AND user_id = ?;`)
+	err := h.GetContext(ctx, &count, query, repoID, userID)
+	return count > 0, err
+}
+
+// CreateContentReport implements store.ModerationStore.
+func (*moderationStore) CreateContentReport(ctx context.Context, h db.Handler, r models.ContentReport) (int64, error) {
+	var id int64
+	query := h.Rebind(`INSERT INTO content_reports (repo_id, subject_type, subject_id, reporter_id, reason)
+			VALUES (?, ?, ?, ?, ?) RETURNING id;`)
+	err := h.GetContext(ctx, &id, query, r.RepoID, r.SubjectType, r.SubjectID, r.ReporterID, r.Reason)
+	return id, err
+}
+
+// GetContentReport implements store.ModerationStore.
+func (*moderationStore) GetContentReport(ctx context.Context, h db.Handler, id int64) (models.ContentReport, error) {
+	var r models.ContentReport
+	query := h.Rebind(`SELECT ` + selectColumns("", contentReportColumns...) + ` FROM content_reports WHERE id = ?;`)
+	err := h.GetContext(ctx, &r, query, id)
+	return r, err
+}
+
+// GetOpenContentReports implements store.ModerationStore.
+func (*moderationStore) GetOpenContentReports(ctx context.Context, h db.Handler) ([]models.ContentReport, error) {
+	var rs []models.ContentReport
+	query := h.Rebind(`SELECT ` + selectColumns("", contentReportColumns...) + ` FROM content_reports WHERE resolved_at IS NULL ORDER BY id ASC;`)
+	err := h.SelectContext(ctx, &rs, query)
+	return rs, err
+}
+
+// ResolveContentReport implements store.ModerationStore.
+func (*moderationStore) ResolveContentReport(ctx context.Context, h db.Handler, id int64, resolvedBy int64) error {
+	query := h.Rebind(`
+		UPDATE content_reports
+		SET resolved_by = ?, resolved_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND resolved_at IS NULL
+	`)
+	_, err := h.ExecContext(ctx, query, resolvedBy, id)
+	return err
+}
+
+// ResolveContentReportsBySubject implements store.ModerationStore.
+func (*moderationStore) ResolveContentReportsBySubject(ctx context.Context, h db.Handler, repoID int64, subjectType string, subjectID int64, resolvedBy int64) error {
+	query := h.Rebind(`
+		UPDATE content_reports
+		SET resolved_by = ?, resolved_at = CURRENT_TIMESTAMP
+		WHERE repo_id = ? AND subject_type = ? AND subject_id = ? AND resolved_at IS NULL
+	`)
+	_, err := h.ExecContext(ctx, query, resolvedBy, repoID, subjectType, subjectID)
+	return err
+}
@@ -0,0 +1,75 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/store/database"
+	"github.com/matryer/is"
+)
+
+func TestModerationStore(t *testing.T) {
+	runWithDatabases(t, testModerationStore)
+}
+
+func testModerationStore(t *testing.T, ctx context.Context, dbx *db.DB) {
+	is := is.New(t)
+
+	store := database.New(ctx, dbx)
+	userID, repoID, err := createTestUserAndRepo(ctx, dbx)
+	is.NoErr(err)
+
+	is.NoErr(store.AddRepoBlock(ctx, dbx, repoID, userID))
+	blocked, err := store.IsUserBlockedFromRepo(ctx, dbx, repoID, userID)
+	is.NoErr(err)
+	is.True(blocked)
+	users, err := store.GetRepoBlockedUsers(ctx, dbx, repoID)
+	is.NoErr(err)
+	is.Equal(len(users), 1)
+	is.Equal(users[0].ID, userID)
+
+	is.NoErr(store.RemoveRepoBlock(ctx, dbx, repoID, userID))
+	blocked, err = store.IsUserBlockedFromRepo(ctx, dbx, repoID, userID)
+	is.NoErr(err)
+	is.True(!blocked)
+
+	r := models.ContentReport{
+		RepoID:      repoID,
+		SubjectType: "issue",
+		SubjectID:   1,
+		ReporterID:  userID,
+		Reason:      "spam",
+	}
+	id1, err := store.CreateContentReport(ctx, dbx, r)
+	is.NoErr(err)
+	_, err = store.CreateContentReport(ctx, dbx, r)
+	is.NoErr(err)
+	r.SubjectType = "merge_request"
+	id3, err := store.CreateContentReport(ctx, dbx, r)
+	is.NoErr(err)
+
+	got, err := store.GetContentReport(ctx, dbx, id1)
+	is.NoErr(err)
+	is.Equal(got.Reason, "spam")
+	is.True(!got.ResolvedAt.Valid)
+
+	// Open reports are listed oldest first.
+	rs, err := store.GetOpenContentReports(ctx, dbx)
+	is.NoErr(err)
+	is.Equal(len(rs), 3)
+	is.Equal(rs[0].ID, id1)
+
+	is.NoErr(store.ResolveContentReport(ctx, dbx, id3, userID))
+	got, err = store.GetContentReport(ctx, dbx, id3)
+	is.NoErr(err)
+	is.True(got.ResolvedAt.Valid)
+	is.Equal(got.ResolvedBy.Int64, userID)
+
+	// Resolving by subject leaves the reports of other subjects open.
+	is.NoErr(store.ResolveContentReportsBySubject(ctx, dbx, repoID, "issue", 1, userID))
+	rs, err = store.GetOpenContentReports(ctx, dbx)
+	is.NoErr(err)
+	is.Equal(len(rs), 0)
+}
@@ -0,0 +1,131 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/store"
+)
+
+type oauthStore struct{}
+
+var _ store.OAuthStore = (*oauthStore)(nil)
+
+// oauthAppColumns are the columns selected when querying OAuth applications.
+var oauthAppColumns = []string{
+	"id",
+	"name",
+	"client_id",
+	"client_secret",
+	"redirect_uri",
+	"created_at",
+	"updated_at",
+}
+
+// oauthCodeColumns are the columns selected when querying OAuth authorization codes.
+var oauthCodeColumns = []string{
+	"id",
+	"app_id",
+	"user_id",
+	"code",
+	"redirect_uri",
+	"scope",
+	"code_challenge",
+	"nonce",
+	"expires_at",
+	"created_at",
+}
+
+// CreateOAuthApp implements store.OAuthStore.
+func (s *oauthStore) CreateOAuthApp(ctx context.Context, h db.Handler, name string, clientID string, clientSecret string, redirectURI string) (models.OAuthApp, error) {
+	query := h.Rebind(`INSERT INTO oauth_apps (name, client_id, client_secret, redirect_uri, updated_at)
+			VALUES (?, ?, ?, ?, CURRENT_TIMESTAMP) RETURNING id;`)
+	var id int64
+	if err := h.GetContext(ctx, &id, query, name, clientID, clientSecret, redirectURI); err != nil {
+		return models.OAuthApp{}, err
+	}
+
+	return s.GetOAuthAppByID(ctx, h, id)
+}
+
+// GetOAuthApps implements store.OAuthStore.
+func (*oauthStore) GetOAuthApps(ctx context.Context, h db.Handler) ([]models.OAuthApp, error) {
+	var apps []models.OAuthApp
+	err := h.SelectContext(ctx, &apps, `SELECT `+selectColumns("", oauthAppColumns...)+` FROM oauth_apps ORDER BY name ASC;`)
+	return apps, err
+}
+
+// GetOAuthAppByID implements store.OAuthStore.
+func (*oauthStore) GetOAuthAppByID(ctx context.Context, h db.Handler, id int64) (models.OAuthApp, error) {
+	var app models.OAuthApp
+	err := h.GetContext(ctx, &app, h.Rebind(`SELECT `+selectColumns("", oauthAppColumns...)+` FROM oauth_apps WHERE id = ?;`), id)
+	return app, err
+}
+
+// GetOAuthAppByName implements store.OAuthStore.
+func (*oauthStore) GetOAuthAppByName(ctx context.Context, h db.Handler, name string) (models.OAuthApp, error) {
+	var app models.OAuthApp
+	err := h.GetContext(ctx, &app, h.Rebind(`SELECT `+selectColumns("", oauthAppColumns...)+` FROM oauth_apps WHERE name = ?;`), name)
+	return app, err
+}
+
+// GetOAuthAppByClientID implements store.OAuthStore.
+func (*oauthStore) GetOAuthAppByClientID(ctx context.Context, h db.Handler, clientID string) (models.OAuthApp, error) {
+	var app models.OAuthApp
+	err := h.GetContext(ctx, &app, h.Rebind(`SELECT `+selectColumns("", oauthAppColumns...)+` FROM oauth_apps WHERE client_id = ?;`), clientID)
+	return app, err
+}
+
+// DeleteOAuthApp implements store.OAuthStore.
+func (*oauthStore) DeleteOAuthApp(ctx context.Context, h db.Handler, id int64) error {
+	for _, query := range []string{
+		`DELETE FROM access_tokens WHERE oauth_app_id = ?;`,
+		`DELETE FROM oauth_codes WHERE app_id = ?;`,
+		`DELETE FROM oauth_apps WHERE id = ?;`,
+	} {
+		if _, err := h.ExecContext(ctx, h.Rebind(query), id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CreateOAuthCode implements store.OAuthStore.
+func (*oauthStore) CreateOAuthCode(ctx context.Context, h db.Handler, code models.OAuthCode) error {
+	query := h.Rebind(`INSERT INTO oauth_codes (app_id, user_id, code, redirect_uri, scope, code_challenge, nonce, expires_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?);`)
+	_, err := h.ExecContext(ctx, query, code.AppID, code.UserID, code.Code, code.RedirectURI,
+		code.Scope, code.CodeChallenge, code.Nonce, code.ExpiresAt.UTC())
+	return err
+}
+
+// GetOAuthCode implements store.OAuthStore.
+func (*oauthStore) GetOAuthCode(ctx context.Context, h db.Handler, code string) (models.OAuthCode, error) {
+	var m models.OAuthCode
+	err := h.GetContext(ctx, &m, h.Rebind(`SELECT `+selectColumns("", oauthCodeColumns...)+` FROM oauth_codes WHERE code = ?;`), code)
+	return m, err
+}
+
+// DeleteOAuthCode implements store.OAuthStore.
+func (*oauthStore) DeleteOAuthCode(ctx context.Context, h db.Handler, id int64) (int64, error) {
+	res, err := h.ExecContext(ctx, h.Rebind(`DELETE FROM oauth_codes WHERE id = ?;`), id)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// DeleteExpiredOAuthCodes implements store.OAuthStore.
+func (*oauthStore) DeleteExpiredOAuthCodes(ctx context.Context, h db.Handler, before time.Time) error {
+	_, err := h.ExecContext(ctx, h.Rebind(`DELETE FROM oauth_codes WHERE expires_at < ?;`), before.UTC())
+	return err
+}
+
+// SetAccessTokenOAuthApp implements store.OAuthStore.
+func (*oauthStore) SetAccessTokenOAuthApp(ctx context.Context, h db.Handler, tokenID int64, appID int64) error {
+	query := h.Rebind(`UPDATE access_tokens SET oauth_app_id = ? WHERE id = ?;`)
+	_, err := h.ExecContext(ctx, query, appID, tokenID)
+	return err
+}
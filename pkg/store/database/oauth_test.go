@@ -0,0 +1,45 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/store/database"
+	"github.com/matryer/is"
+)
+
+func TestOAuthCodeSingleUse(t *testing.T) {
+	runWithDatabases(t, testOAuthCodeSingleUse)
+}
+
+func testOAuthCodeSingleUse(t *testing.T, ctx context.Context, dbx *db.DB) {
+	is := is.New(t)
+
+	store := database.New(ctx, dbx)
+	userID, _, err := createTestUserAndRepo(ctx, dbx)
+	is.NoErr(err)
+
+	app, err := store.CreateOAuthApp(ctx, dbx, "ci", "client", "secret", "http://localhost/callback")
+	is.NoErr(err)
+	is.NoErr(store.CreateOAuthCode(ctx, dbx, models.OAuthCode{
+		AppID:       app.ID,
+		UserID:      userID,
+		Code:        "hash",
+		RedirectURI: app.RedirectURI,
+		ExpiresAt:   time.Now().Add(time.Minute),
+	}))
+
+	c, err := store.GetOAuthCode(ctx, dbx, "hash")
+	is.NoErr(err)
+
+	// Only the first exchange deletes the code.
+	n, err := store.DeleteOAuthCode(ctx, dbx, c.ID)
+	is.NoErr(err)
+	is.Equal(n, int64(1))
+	n, err = store.DeleteOAuthCode(ctx, dbx, c.ID)
+	is.NoErr(err)
+	is.Equal(n, int64(0))
+}
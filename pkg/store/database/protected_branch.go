@@ -0,0 +1,48 @@
+package database
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/store"
+)
+
+type protectedBranchStore struct{}
+
+var _ store.ProtectedBranchStore = (*protectedBranchStore)(nil)
+
+// protectedBranchColumns are the columns selected when querying protected branches.
+var protectedBranchColumns = []string{
+	"id",
+	"repo_id",
+	"pattern",
+	"require_lease",
+	"created_at",
+	"updated_at",
+}
+
+// GetProtectedBranchesByRepoID implements store.ProtectedBranchStore.
+func (*protectedBranchStore) GetProtectedBranchesByRepoID(ctx context.Context, h db.Handler, repoID int64) ([]models.ProtectedBranch, error) {
+	query := h.Rebind(`SELECT ` + selectColumns("", protectedBranchColumns...) + ` FROM protected_branches WHERE repo_id = ? ORDER BY pattern;`)
+	var bs []models.ProtectedBranch
+	err := h.SelectContext(ctx, &bs, query, repoID)
+	return bs, err
+}
+
+// SetProtectedBranch implements store.ProtectedBranchStore.
+func (*protectedBranchStore) SetProtectedBranch(ctx context.Context, h db.Handler, repoID int64, pattern string, requireLease bool) error {
+	query := h.Rebind(`INSERT INTO protected_branches (repo_id, pattern, require_lease, updated_at)
+			VALUES (?, ?, ?, CURRENT_TIMESTAMP)
+			ON CONFLICT (repo_id, pattern) DO UPDATE SET
+			require_lease = excluded.require_lease, updated_at = CURRENT_TIMESTAMP;`)
+	_, err := h.ExecContext(ctx, query, repoID, pattern, requireLease)
+	return err
+}
+
+// DeleteProtectedBranch implements store.ProtectedBranchStore.
+func (*protectedBranchStore) DeleteProtectedBranch(ctx context.Context, h db.Handler, repoID int64, pattern string) error {
+	query := h.Rebind(`DELETE FROM protected_branches WHERE repo_id = ? AND pattern = ?;`)
+	_, err := h.ExecContext(ctx, query, repoID, pattern)
+	return err
+}
@@ -0,0 +1,75 @@
+package database
+
+import (
+	"context"
+	"strings"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/store"
+)
+
+type redactionStore struct{}
+
+var _ store.RedactionStore = (*redactionStore)(nil)
+
+// RedactRepoText implements store.RedactionStore.
+func (*redactionStore) RedactRepoText(ctx context.Context, h db.Handler, repoID int64, old string, new string) (int64, error) {
+	var total int64
+	for _, r := range []struct {
+		table   string
+		columns []string
+		where   string
+		args    []any
+	}{
+		{"issues", []string{"title", "description"}, "repo_id = ?", []any{repoID}},
+		{"merge_requests", []string{"title", "description"}, "repo_id = ?", []any{repoID}},
+		{"merge_request_comments", []string{"body", "snippet"}, "repo_id = ?", []any{repoID}},
+		{
+			"large_texts", []string{"content"},
+			"(issue_id IN (SELECT id FROM issues WHERE repo_id = ?) OR merge_request_id IN (SELECT id FROM merge_requests WHERE repo_id = ?))",
+			[]any{repoID, repoID},
+		},
+		{"notifications", []string{"title"}, "repo_id = ?", []any{repoID}},
+		{"activities", []string{"title"}, "repo_id = ?", []any{repoID}},
+		{"repo_events", []string{"payload"}, "repo_id = ?", []any{repoID}},
+		{
+			"webhook_deliveries", []string{"request_body", "response_body"},
+			"webhook_id IN (SELECT id FROM webhooks WHERE repo_id = ?)",
+			[]any{repoID},
+		},
+	} {
+		n, err := redactColumns(ctx, h, r.table, r.columns, r.where, old, new, r.args...)
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+
+	return total, nil
+}
+
+// redactColumns replaces old with new in columns of the rows of table
+// matching where, and returns the number of rows changed.
+func redactColumns(ctx context.Context, h db.Handler, table string, columns []string, where string, old string, new string, whereArgs ...any) (int64, error) {
+	sets := make([]string, 0, len(columns))
+	changed := make([]string, 0, len(columns))
+	var args []any
+	for _, c := range columns {
+		sets = append(sets, c+" = REPLACE("+c+", ?, ?)")
+		args = append(args, old, new)
+	}
+	args = append(args, whereArgs...)
+	for _, c := range columns {
+		changed = append(changed, c+" <> REPLACE("+c+", ?, ?)")
+		args = append(args, old, new)
+	}
+
+	query := h.Rebind(`UPDATE ` + table + ` SET ` + strings.Join(sets, ", ") +
+		` WHERE ` + where + ` AND (` + strings.Join(changed, " OR ") + `)`)
+	res, err := h.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
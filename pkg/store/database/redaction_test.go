@@ -0,0 +1,44 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/store/database"
+	"github.com/matryer/is"
+)
+
+func TestRedactionStore(t *testing.T) {
+	runWithDatabases(t, testRedactionStore)
+}
+
+func testRedactionStore(t *testing.T, ctx context.Context, dbx *db.DB) {
+	is := is.New(t)
+
+	store := database.New(ctx, dbx)
+	userID, repoID, err := createTestUserAndRepo(ctx, dbx)
+	is.NoErr(err)
+
+	leakedID, err := store.CreateIssue(ctx, dbx, repoID, userID, "Token hunter2hunter2 leaked", "use hunter2hunter2 twice: hunter2hunter2")
+	is.NoErr(err)
+	cleanID, err := store.CreateIssue(ctx, dbx, repoID, userID, "Unrelated", "nothing to see")
+	is.NoErr(err)
+
+	n, err := store.RedactRepoText(ctx, dbx, repoID, "hunter2hunter2", "[REDACTED]")
+	is.NoErr(err)
+	is.Equal(n, int64(1))
+
+	leaked, err := store.GetIssueByID(ctx, dbx, repoID, leakedID)
+	is.NoErr(err)
+	is.Equal(leaked.Title, "Token [REDACTED] leaked")
+	is.Equal(leaked.Description, "use [REDACTED] twice: [REDACTED]")
+	clean, err := store.GetIssueByID(ctx, dbx, repoID, cleanID)
+	is.NoErr(err)
+	is.Equal(clean.Description, "nothing to see")
+
+	// Redacting again changes nothing.
+	n, err = store.RedactRepoText(ctx, dbx, repoID, "hunter2hunter2", "[REDACTED]")
+	is.NoErr(err)
+	is.Equal(n, int64(0))
+}
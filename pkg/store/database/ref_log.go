@@ -0,0 +1,51 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/store"
+)
+
+type refLogStore struct{}
+
+var _ store.RefLogStore = (*refLogStore)(nil)
+
+// CreateRefUpdate implements store.RefLogStore.
+func (*refLogStore) CreateRefUpdate(ctx context.Context, h db.Handler, u models.RefUpdate) (int64, error) {
+	query := h.Rebind(`INSERT INTO ref_log (repo_id, ref, old_sha, new_sha, action, user_id)
+			VALUES (?, ?, ?, ?, ?, ?)
+			RETURNING id;`)
+	var id int64
+	err := h.GetContext(ctx, &id, query, u.RepoID, u.Ref, u.OldSha, u.NewSha, u.Action, u.UserID)
+	return id, err
+}
+
+// GetRefUpdatesByRepoID implements store.RefLogStore.
+func (*refLogStore) GetRefUpdatesByRepoID(ctx context.Context, h db.Handler, repoID int64, ref string, limit int) ([]models.RefUpdate, error) {
+	where := "repo_id = ?"
+	args := []interface{}{repoID}
+	if ref != "" {
+		where += " AND ref = ?"
+		args = append(args, ref)
+	}
+	query := h.Rebind(`SELECT * FROM ref_log
+			WHERE ` + where + `
+			ORDER BY created_at DESC, id DESC
+			LIMIT ?;`)
+	var us []models.RefUpdate
+	err := h.SelectContext(ctx, &us, query, append(args, limit)...)
+	return us, err
+}
+
+// DeleteRefUpdatesBefore implements store.RefLogStore.
+func (*refLogStore) DeleteRefUpdatesBefore(ctx context.Context, h db.Handler, t time.Time) (int64, error) {
+	query := h.Rebind(`DELETE FROM ref_log WHERE created_at < ?;`)
+	res, err := h.ExecContext(ctx, query, t.UTC())
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
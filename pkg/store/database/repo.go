@@ -83,6 +83,15 @@ func (*repoStore) GetRepoIsHiddenByName(ctx context.Context, tx db.Handler, name
 	return isHidden, db.WrapError(err)
 }
 
+// GetRepoIsInternalByName implements store.RepositoryStore.
+func (*repoStore) GetRepoIsInternalByName(ctx context.Context, tx db.Handler, name string) (bool, error) {
+	var isInternal bool
+	name = utils.SanitizeRepo(name)
+	query := tx.Rebind("SELECT internal FROM repos WHERE name = ?;")
+	err := tx.GetContext(ctx, &isInternal, query, name)
+	return isInternal, db.WrapError(err)
+}
+
 // GetRepoIsMirrorByName implements store.RepositoryStore.
 func (*repoStore) GetRepoIsMirrorByName(ctx context.Context, tx db.Handler, name string) (bool, error) {
 	var isMirror bool
@@ -126,6 +135,176 @@ func (*repoStore) SetRepoIsHiddenByName(ctx context.Context, tx db.Handler, name
 	return db.WrapError(err)
 }
 
+// GetRepoIsStaleExemptByName implements store.RepositoryStore.
+func (*repoStore) GetRepoIsStaleExemptByName(ctx context.Context, tx db.Handler, name string) (bool, error) {
+	var isStaleExempt bool
+	name = utils.SanitizeRepo(name)
+	query := tx.Rebind("SELECT stale_exempt FROM repos WHERE name = ?;")
+	err := tx.GetContext(ctx, &isStaleExempt, query, name)
+	return isStaleExempt, db.WrapError(err)
+}
+
+// SetRepoIsStaleExemptByName implements store.RepositoryStore.
+func (*repoStore) SetRepoIsStaleExemptByName(ctx context.Context, tx db.Handler, name string, isStaleExempt bool) error {
+	name = utils.SanitizeRepo(name)
+	query := tx.Rebind("UPDATE repos SET stale_exempt = ? WHERE name = ?;")
+	_, err := tx.ExecContext(ctx, query, isStaleExempt, name)
+	return db.WrapError(err)
+}
+
+// GetRepoIsMergeQueueByName implements store.RepositoryStore.
+func (*repoStore) GetRepoIsMergeQueueByName(ctx context.Context, tx db.Handler, name string) (bool, error) {
+	var isMergeQueue bool
+	name = utils.SanitizeRepo(name)
+	query := tx.Rebind("SELECT merge_queue FROM repos WHERE name = ?;")
+	err := tx.GetContext(ctx, &isMergeQueue, query, name)
+	return isMergeQueue, db.WrapError(err)
+}
+
+// SetRepoIsMergeQueueByName implements store.RepositoryStore.
+func (*repoStore) SetRepoIsMergeQueueByName(ctx context.Context, tx db.Handler, name string, isMergeQueue bool) error {
+	name = utils.SanitizeRepo(name)
+	query := tx.Rebind("UPDATE repos SET merge_queue = ? WHERE name = ?;")
+	_, err := tx.ExecContext(ctx, query, isMergeQueue, name)
+	return db.WrapError(err)
+}
+
+// GetRepoIsIssuesDisabledByName implements store.RepositoryStore.
+func (*repoStore) GetRepoIsIssuesDisabledByName(ctx context.Context, tx db.Handler, name string) (bool, error) {
+	var isIssuesDisabled bool
+	name = utils.SanitizeRepo(name)
+	query := tx.Rebind("SELECT issues_disabled FROM repos WHERE name = ?;")
+	err := tx.GetContext(ctx, &isIssuesDisabled, query, name)
+	return isIssuesDisabled, db.WrapError(err)
+}
+
+// SetRepoIsIssuesDisabledByName implements store.RepositoryStore.
+func (*repoStore) SetRepoIsIssuesDisabledByName(ctx context.Context, tx db.Handler, name string, isIssuesDisabled bool) error {
+	name = utils.SanitizeRepo(name)
+	query := tx.Rebind("UPDATE repos SET issues_disabled = ? WHERE name = ?;")
+	_, err := tx.ExecContext(ctx, query, isIssuesDisabled, name)
+	return db.WrapError(err)
+}
+
+// GetRepoIsMergeRequestsDisabledByName implements store.RepositoryStore.
+func (*repoStore) GetRepoIsMergeRequestsDisabledByName(ctx context.Context, tx db.Handler, name string) (bool, error) {
+	var isMergeRequestsDisabled bool
+	name = utils.SanitizeRepo(name)
+	query := tx.Rebind("SELECT merge_requests_disabled FROM repos WHERE name = ?;")
+	err := tx.GetContext(ctx, &isMergeRequestsDisabled, query, name)
+	return isMergeRequestsDisabled, db.WrapError(err)
+}
+
+// SetRepoIsMergeRequestsDisabledByName implements store.RepositoryStore.
+func (*repoStore) SetRepoIsMergeRequestsDisabledByName(ctx context.Context, tx db.Handler, name string, isMergeRequestsDisabled bool) error {
+	name = utils.SanitizeRepo(name)
+	query := tx.Rebind("UPDATE repos SET merge_requests_disabled = ? WHERE name = ?;")
+	_, err := tx.ExecContext(ctx, query, isMergeRequestsDisabled, name)
+	return db.WrapError(err)
+}
+
+// GetRepoIsSearchDisabledByName implements store.RepositoryStore.
+func (*repoStore) GetRepoIsSearchDisabledByName(ctx context.Context, tx db.Handler, name string) (bool, error) {
+	var isSearchDisabled bool
+	name = utils.SanitizeRepo(name)
+	query := tx.Rebind("SELECT search_disabled FROM repos WHERE name = ?;")
+	err := tx.GetContext(ctx, &isSearchDisabled, query, name)
+	return isSearchDisabled, db.WrapError(err)
+}
+
+// SetRepoIsSearchDisabledByName implements store.RepositoryStore.
+func (*repoStore) SetRepoIsSearchDisabledByName(ctx context.Context, tx db.Handler, name string, isSearchDisabled bool) error {
+	name = utils.SanitizeRepo(name)
+	query := tx.Rebind("UPDATE repos SET search_disabled = ? WHERE name = ?;")
+	_, err := tx.ExecContext(ctx, query, isSearchDisabled, name)
+	return db.WrapError(err)
+}
+
+// GetRepoIsNewsDisabledByName implements store.RepositoryStore.
+func (*repoStore) GetRepoIsNewsDisabledByName(ctx context.Context, tx db.Handler, name string) (bool, error) {
+	var isNewsDisabled bool
+	name = utils.SanitizeRepo(name)
+	query := tx.Rebind("SELECT news_disabled FROM repos WHERE name = ?;")
+	err := tx.GetContext(ctx, &isNewsDisabled, query, name)
+	return isNewsDisabled, db.WrapError(err)
+}
+
+// SetRepoIsNewsDisabledByName implements store.RepositoryStore.
+func (*repoStore) SetRepoIsNewsDisabledByName(ctx context.Context, tx db.Handler, name string, isNewsDisabled bool) error {
+	name = utils.SanitizeRepo(name)
+	query := tx.Rebind("UPDATE repos SET news_disabled = ? WHERE name = ?;")
+	_, err := tx.ExecContext(ctx, query, isNewsDisabled, name)
+	return db.WrapError(err)
+}
+
+// GetRepoIssuePrefixByName implements store.RepositoryStore.
+func (*repoStore) GetRepoIssuePrefixByName(ctx context.Context, tx db.Handler, name string) (string, error) {
+	var prefix string
+	name = utils.SanitizeRepo(name)
+	query := tx.Rebind("SELECT issue_prefix FROM repos WHERE name = ?;")
+	err := tx.GetContext(ctx, &prefix, query, name)
+	return prefix, db.WrapError(err)
+}
+
+// SetRepoIssuePrefixByName implements store.RepositoryStore.
+func (*repoStore) SetRepoIssuePrefixByName(ctx context.Context, tx db.Handler, name string, prefix string) error {
+	name = utils.SanitizeRepo(name)
+	query := tx.Rebind("UPDATE repos SET issue_prefix = ? WHERE name = ?;")
+	_, err := tx.ExecContext(ctx, query, prefix, name)
+	return db.WrapError(err)
+}
+
+// GetRepoEditPolicyByName implements store.RepositoryStore.
+func (*repoStore) GetRepoEditPolicyByName(ctx context.Context, tx db.Handler, name string) (string, error) {
+	var policy string
+	name = utils.SanitizeRepo(name)
+	query := tx.Rebind("SELECT edit_policy FROM repos WHERE name = ?;")
+	err := tx.GetContext(ctx, &policy, query, name)
+	return policy, db.WrapError(err)
+}
+
+// SetRepoEditPolicyByName implements store.RepositoryStore.
+func (*repoStore) SetRepoEditPolicyByName(ctx context.Context, tx db.Handler, name string, policy string) error {
+	name = utils.SanitizeRepo(name)
+	query := tx.Rebind("UPDATE repos SET edit_policy = ? WHERE name = ?;")
+	_, err := tx.ExecContext(ctx, query, policy, name)
+	return db.WrapError(err)
+}
+
+// GetRepoDependencyPolicyByName implements store.RepositoryStore.
+func (*repoStore) GetRepoDependencyPolicyByName(ctx context.Context, tx db.Handler, name string) (string, error) {
+	var policy string
+	name = utils.SanitizeRepo(name)
+	query := tx.Rebind("SELECT dependency_policy FROM repos WHERE name = ?;")
+	err := tx.GetContext(ctx, &policy, query, name)
+	return policy, db.WrapError(err)
+}
+
+// SetRepoDependencyPolicyByName implements store.RepositoryStore.
+func (*repoStore) SetRepoDependencyPolicyByName(ctx context.Context, tx db.Handler, name string, policy string) error {
+	name = utils.SanitizeRepo(name)
+	query := tx.Rebind("UPDATE repos SET dependency_policy = ? WHERE name = ?;")
+	_, err := tx.ExecContext(ctx, query, policy, name)
+	return db.WrapError(err)
+}
+
+// GetRepoRequiredApprovalsByName implements store.RepositoryStore.
+func (*repoStore) GetRepoRequiredApprovalsByName(ctx context.Context, tx db.Handler, name string) (int, error) {
+	var n int
+	name = utils.SanitizeRepo(name)
+	query := tx.Rebind("SELECT required_approvals FROM repos WHERE name = ?;")
+	err := tx.GetContext(ctx, &n, query, name)
+	return n, db.WrapError(err)
+}
+
+// SetRepoRequiredApprovalsByName implements store.RepositoryStore.
+func (*repoStore) SetRepoRequiredApprovalsByName(ctx context.Context, tx db.Handler, name string, n int) error {
+	name = utils.SanitizeRepo(name)
+	query := tx.Rebind("UPDATE repos SET required_approvals = ? WHERE name = ?;")
+	_, err := tx.ExecContext(ctx, query, n, name)
+	return db.WrapError(err)
+}
+
 // SetRepoIsPrivateByName implements store.RepositoryStore.
 func (*repoStore) SetRepoIsPrivateByName(ctx context.Context, tx db.Handler, name string, isPrivate bool) error {
 	name = utils.SanitizeRepo(name)
@@ -134,6 +313,14 @@ func (*repoStore) SetRepoIsPrivateByName(ctx context.Context, tx db.Handler, nam
 	return db.WrapError(err)
 }
 
+// SetRepoIsInternalByName implements store.RepositoryStore.
+func (*repoStore) SetRepoIsInternalByName(ctx context.Context, tx db.Handler, name string, isInternal bool) error {
+	name = utils.SanitizeRepo(name)
+	query := tx.Rebind("UPDATE repos SET internal = ? WHERE name = ?;")
+	_, err := tx.ExecContext(ctx, query, isInternal, name)
+	return db.WrapError(err)
+}
+
 // SetRepoNameByName implements store.RepositoryStore.
 func (*repoStore) SetRepoNameByName(ctx context.Context, tx db.Handler, name string, newName string) error {
 	name = utils.SanitizeRepo(name)
@@ -143,6 +330,32 @@ func (*repoStore) SetRepoNameByName(ctx context.Context, tx db.Handler, name str
 	return db.WrapError(err)
 }
 
+// SetRepoStorageByName implements store.RepositoryStore.
+func (*repoStore) SetRepoStorageByName(ctx context.Context, tx db.Handler, name string, volume string, path string) error {
+	name = utils.SanitizeRepo(name)
+	query := tx.Rebind("UPDATE repos SET storage_volume = ?, storage_path = ? WHERE name = ?;")
+	_, err := tx.ExecContext(ctx, query, volume, path, name)
+	return db.WrapError(err)
+}
+
+// CountReposByStorageVolume implements store.RepositoryStore.
+func (*repoStore) CountReposByStorageVolume(ctx context.Context, tx db.Handler) (map[string]int64, error) {
+	var rows []struct {
+		Volume string `db:"storage_volume"`
+		Count  int64  `db:"count"`
+	}
+	query := tx.Rebind("SELECT storage_volume, COUNT(*) AS count FROM repos GROUP BY storage_volume;")
+	if err := tx.SelectContext(ctx, &rows, query); err != nil {
+		return nil, db.WrapError(err)
+	}
+
+	counts := make(map[string]int64, len(rows))
+	for _, r := range rows {
+		counts[r.Volume] = r.Count
+	}
+	return counts, nil
+}
+
 // SetRepoProjectNameByName implements store.RepositoryStore.
 func (*repoStore) SetRepoProjectNameByName(ctx context.Context, tx db.Handler, name string, projectName string) error {
 	name = utils.SanitizeRepo(name)
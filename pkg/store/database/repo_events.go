@@ -0,0 +1,55 @@
+package database
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/store"
+)
+
+type repoEventStore struct{}
+
+var _ store.RepoEventStore = (*repoEventStore)(nil)
+
+// CreateRepoEvent implements store.RepoEventStore.
+func (*repoEventStore) CreateRepoEvent(ctx context.Context, h db.Handler, repoID int64, event int, payload string) (int64, error) {
+	query := h.Rebind(`INSERT INTO repo_events (repo_id, event, payload)
+			VALUES (?, ?, ?)
+			RETURNING id;`)
+	var id int64
+	err := h.GetContext(ctx, &id, query, repoID, event, payload)
+	return id, err
+}
+
+// GetRepoEventsAfterID implements store.RepoEventStore.
+func (*repoEventStore) GetRepoEventsAfterID(ctx context.Context, h db.Handler, repoID int64, afterID int64, limit int) ([]models.RepoEvent, error) {
+	query := h.Rebind(`SELECT * FROM repo_events
+			WHERE repo_id = ? AND id > ?
+			ORDER BY id
+			LIMIT ?;`)
+	var events []models.RepoEvent
+	err := h.SelectContext(ctx, &events, query, repoID, afterID, limit)
+	return events, err
+}
+
+// GetLatestRepoEventID implements store.RepoEventStore.
+func (*repoEventStore) GetLatestRepoEventID(ctx context.Context, h db.Handler, repoID int64) (int64, error) {
+	query := h.Rebind(`SELECT COALESCE(MAX(id), 0) FROM repo_events WHERE repo_id = ?;`)
+	var id int64
+	err := h.GetContext(ctx, &id, query, repoID)
+	return id, err
+}
+
+// PruneRepoEvents implements store.RepoEventStore.
+func (*repoEventStore) PruneRepoEvents(ctx context.Context, h db.Handler, repoID int64, keep int) error {
+	query := h.Rebind(`DELETE FROM repo_events
+			WHERE repo_id = ? AND id < (
+				SELECT id FROM repo_events
+				WHERE repo_id = ?
+				ORDER BY id DESC
+				LIMIT 1 OFFSET ?
+			);`)
+	_, err := h.ExecContext(ctx, query, repoID, repoID, keep-1)
+	return err
+}
@@ -0,0 +1,35 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/store"
+)
+
+type repoVisitStore struct{}
+
+var _ store.RepoVisitStore = (*repoVisitStore)(nil)
+
+// SetRepoVisit implements store.RepoVisitStore.
+func (*repoVisitStore) SetRepoVisit(ctx context.Context, h db.Handler, repoID int64, userID int64, at time.Time) error {
+	query := h.Rebind(`INSERT INTO repo_visits (repo_id, user_id, visited_at)
+			VALUES (?, ?, ?)
+			ON CONFLICT (repo_id, user_id) DO UPDATE SET
+			visited_at = excluded.visited_at;`)
+	_, err := h.ExecContext(ctx, query, repoID, userID, at)
+	return err
+}
+
+// GetRepoVisitsByUserID implements store.RepoVisitStore.
+func (*repoVisitStore) GetRepoVisitsByUserID(ctx context.Context, h db.Handler, userID int64, limit int) ([]models.RepoVisit, error) {
+	var vs []models.RepoVisit
+	query := h.Rebind(`SELECT * FROM repo_visits
+			WHERE user_id = ?
+			ORDER BY visited_at DESC, id DESC
+			LIMIT ?;`)
+	err := h.SelectContext(ctx, &vs, query, userID, limit)
+	return vs, err
+}
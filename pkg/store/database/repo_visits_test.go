@@ -0,0 +1,41 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/store/database"
+	"github.com/matryer/is"
+)
+
+func TestRepoVisitStore(t *testing.T) {
+	runWithDatabases(t, testRepoVisitStore)
+}
+
+func testRepoVisitStore(t *testing.T, ctx context.Context, dbx *db.DB) {
+	is := is.New(t)
+
+	store := database.New(ctx, dbx)
+	userID, repoID, err := createTestUserAndRepo(ctx, dbx)
+	is.NoErr(err)
+
+	vs, err := store.GetRepoVisitsByUserID(ctx, dbx, userID, 10)
+	is.NoErr(err)
+	is.Equal(len(vs), 0)
+
+	// Visiting again replaces the previous visit.
+	first := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	is.NoErr(store.SetRepoVisit(ctx, dbx, repoID, userID, first))
+	is.NoErr(store.SetRepoVisit(ctx, dbx, repoID, userID, first.Add(time.Hour)))
+	vs, err = store.GetRepoVisitsByUserID(ctx, dbx, userID, 10)
+	is.NoErr(err)
+	is.Equal(len(vs), 1)
+	is.Equal(vs[0].RepoID, repoID)
+	is.True(vs[0].VisitedAt.Equal(first.Add(time.Hour)))
+
+	vs, err = store.GetRepoVisitsByUserID(ctx, dbx, userID+1, 10)
+	is.NoErr(err)
+	is.Equal(len(vs), 0)
+}
@@ -0,0 +1,104 @@
+package database
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/jmoiron/sqlx"
+)
+
+// likeEscaper escapes the LIKE wildcards of a search query.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// searchQuery builds the query shared by issue and merge request searches.
+// Rows are matched against repoIDs and, when set, a case-insensitive text
+// query on the title and description, a state, an author, and an extra
+// filter condition with its arguments. The query is expanded with sqlx.In but
+// not rebound.
+func searchQuery(table string, cols []string, repoIDs []int64, text string, state *int, authorID int64, limit int, offset int, filter string, filterArgs ...interface{}) (string, []interface{}, error) {
+	where := []string{"repo_id IN (?)"}
+	args := []interface{}{repoIDs}
+	if filter != "" {
+		where = append(where, filter)
+		args = append(args, filterArgs...)
+	}
+	if text != "" {
+		pattern := "%" + likeEscaper.Replace(strings.ToLower(text)) + "%"
+		where = append(where, `(LOWER(title) LIKE ? ESCAPE '\' OR LOWER(description) LIKE ? ESCAPE '\')`)
+		args = append(args, pattern, pattern)
+	}
+	if state != nil {
+		where = append(where, "state = ?")
+		args = append(args, *state)
+	}
+	if authorID > 0 {
+		where = append(where, "author_id = ?")
+		args = append(args, authorID)
+	}
+	args = append(args, limit, offset)
+
+	return sqlx.In(`
+		SELECT `+selectColumns("", cols...)+` FROM `+table+`
+		WHERE `+strings.Join(where, " AND ")+`
+		ORDER BY updated_at DESC, id DESC
+		LIMIT ? OFFSET ?
+	`, args...)
+}
+
+// pageQuery builds the query shared by the issue and merge request lists of a
+// repository. Rows are matched against repoID and, when set, a state, in
+// order. key is a column increasing with every new row: it sorts by creation
+// and breaks the ties of the other sorts. Pages are keyed on the order rather
+// than offset, so rows created while paging don't shift the pages that
+// follow: a non-zero after is the key of the last row of the previous page,
+// and only the rows following it in order are matched. A zero limit matches
+// every row. The query isn't rebound.
+func pageQuery(table string, cols []string, key string, repoID int64, state *int, order models.ListOrder, limit int, after int64) (string, []interface{}) {
+	where := []string{"repo_id = ?"}
+	args := []interface{}{repoID}
+	if state != nil {
+		where = append(where, "state = ?")
+		args = append(args, *state)
+	}
+
+	dir, cmp := "DESC", "<"
+	if order.Ascending {
+		dir, cmp = "ASC", ">"
+	}
+	var col string
+	switch order.Sort {
+	case models.ListSortUpdated:
+		col = "updated_at"
+	case models.ListSortTitle:
+		col = "title"
+	case models.ListSortState:
+		col = "state"
+	}
+
+	orderBy := key + " " + dir
+	if col != "" {
+		orderBy = col + " " + dir + ", " + orderBy
+	}
+	if after > 0 {
+		if col == "" {
+			where = append(where, key+" "+cmp+" ?")
+		} else {
+			where = append(where, "("+col+", "+key+") "+cmp+
+				" (SELECT "+col+", "+key+" FROM "+table+" WHERE repo_id = ? AND "+key+" = ?)")
+			args = append(args, repoID)
+		}
+		args = append(args, after)
+	}
+
+	query := `
+		SELECT ` + selectColumns("", cols...) + ` FROM ` + table + `
+		WHERE ` + strings.Join(where, " AND ") + `
+		ORDER BY ` + orderBy
+	if limit > 0 {
+		query += `
+		LIMIT ?`
+		args = append(args, limit)
+	}
+
+	return query, args
+}
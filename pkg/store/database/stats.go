@@ -0,0 +1,57 @@
+package database
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/store"
+)
+
+type statsStore struct{}
+
+var _ store.StatsStore = (*statsStore)(nil)
+
+// GetInstanceCounts implements store.StatsStore.
+func (*statsStore) GetInstanceCounts(ctx context.Context, h db.Handler, since time.Time, until time.Time) (models.InstanceCounts, error) {
+	var where []string
+	var args []interface{}
+	if !since.IsZero() {
+		where = append(where, "created_at >= ?")
+		args = append(args, since.UTC())
+	}
+	if !until.IsZero() {
+		where = append(where, "created_at < ?")
+		args = append(args, until.UTC())
+	}
+	cond := ""
+	if len(where) > 0 {
+		cond = " WHERE " + strings.Join(where, " AND ")
+	}
+
+	tables := []string{"repos", "users", "issues", "merge_requests"}
+	cols := make([]string, len(tables))
+	var all []interface{}
+	for i, t := range tables {
+		cols[i] = "(SELECT COUNT(*) FROM " + t + cond + ") AS " + t
+		all = append(all, args...)
+	}
+
+	var counts models.InstanceCounts
+	err := h.GetContext(ctx, &counts, h.Rebind("SELECT "+strings.Join(cols, ", ")), all...)
+	return counts, err
+}
+
+// GetRepoActivityCounts implements store.StatsStore.
+func (*statsStore) GetRepoActivityCounts(ctx context.Context, h db.Handler, since time.Time, limit int) ([]models.ActivityCount, error) {
+	query := h.Rebind(`SELECT repo_id, COUNT(*) AS count FROM activities
+			WHERE created_at >= ?
+			GROUP BY repo_id
+			ORDER BY count DESC, MAX(created_at) DESC
+			LIMIT ?;`)
+	var cs []models.ActivityCount
+	err := h.SelectContext(ctx, &cs, query, since.UTC(), limit)
+	return cs, err
+}
@@ -0,0 +1,57 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/store/database"
+	"github.com/matryer/is"
+)
+
+func TestStatsStore(t *testing.T) {
+	runWithDatabases(t, testStatsStore)
+}
+
+func testStatsStore(t *testing.T, ctx context.Context, dbx *db.DB) {
+	is := is.New(t)
+
+	store := database.New(ctx, dbx)
+	userID, repoID, err := createTestUserAndRepo(ctx, dbx)
+	is.NoErr(err)
+
+	before, err := store.GetInstanceCounts(ctx, dbx, time.Time{}, time.Time{})
+	is.NoErr(err)
+	is.True(before.Repos >= 1)
+	is.True(before.Users >= 1)
+
+	_, err = store.CreateIssue(ctx, dbx, repoID, userID, "Counted issue", "")
+	is.NoErr(err)
+	after, err := store.GetInstanceCounts(ctx, dbx, time.Time{}, time.Time{})
+	is.NoErr(err)
+	is.Equal(after.Issues, before.Issues+1)
+	is.Equal(after.MergeRequests, before.MergeRequests)
+
+	// Nothing was created in the future, nor before the year 2000.
+	future, err := store.GetInstanceCounts(ctx, dbx, time.Now().Add(24*time.Hour), time.Time{})
+	is.NoErr(err)
+	is.Equal(future, models.InstanceCounts{})
+	past, err := store.GetInstanceCounts(ctx, dbx, time.Time{}, time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC))
+	is.NoErr(err)
+	is.Equal(past, models.InstanceCounts{})
+
+	for i := 0; i < 2; i++ {
+		is.NoErr(store.CreateActivity(ctx, dbx, models.Activity{
+			RepoID: repoID,
+			Kind:   "repository",
+			Action: "pushed",
+		}))
+	}
+	cs, err := store.GetRepoActivityCounts(ctx, dbx, time.Now().Add(-time.Hour), 10)
+	is.NoErr(err)
+	is.Equal(len(cs), 1)
+	is.Equal(cs[0].RepoID, repoID)
+	is.Equal(cs[0].Count, 2)
+}
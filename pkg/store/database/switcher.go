@@ -0,0 +1,49 @@
+package database
+
+import (
+	"context"
+	"strings"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/store"
+	"github.com/jmoiron/sqlx"
+)
+
+type switcherStore struct{}
+
+var _ store.SwitcherStore = (*switcherStore)(nil)
+
+// SearchSwitcherItems implements store.SwitcherStore.
+func (*switcherStore) SearchSwitcherItems(ctx context.Context, h db.Handler, repoIDs []int64, query string, id int64, limit int) ([]models.SwitcherItem, error) {
+	if len(repoIDs) == 0 {
+		return nil, nil
+	}
+
+	issueMatch, mrMatch := "number = ?", "id = ?"
+	arg := interface{}(id)
+	if id <= 0 {
+		issueMatch = `LOWER(title) LIKE ? ESCAPE '\'`
+		mrMatch = issueMatch
+		arg = "%" + likeEscaper.Replace(strings.ToLower(query)) + "%"
+	}
+
+	q, args, err := sqlx.In(`
+		SELECT kind, repo_id, id, number, title, updated_at FROM (
+			SELECT 'issue' AS kind, repo_id, id, number, title, updated_at FROM issues
+			WHERE repo_id IN (?) AND `+issueMatch+`
+			UNION ALL
+			SELECT 'merge_request' AS kind, repo_id, id, id AS number, title, updated_at FROM merge_requests
+			WHERE repo_id IN (?) AND `+mrMatch+`
+		) AS items
+		ORDER BY updated_at DESC, id DESC
+		LIMIT ?
+	`, repoIDs, arg, repoIDs, arg, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []models.SwitcherItem
+	err = h.SelectContext(ctx, &items, h.Rebind(q), args...)
+	return items, err
+}
@@ -16,6 +16,17 @@ type userStore struct{}
 
 var _ store.UserStore = (*userStore)(nil)
 
+// userColumns are the columns selected when querying users.
+var userColumns = []string{
+	"id",
+	"username",
+	"admin",
+	"password",
+	"created_at",
+	"updated_at",
+	"bot",
+}
+
 // AddPublicKeyByUsername implements store.UserStore.
 func (*userStore) AddPublicKeyByUsername(ctx context.Context, tx db.Handler, username string, pk ssh.PublicKey) error {
 	username = strings.ToLower(username)
@@ -205,6 +216,18 @@ func (*userStore) SetAdminByUsername(ctx context.Context, tx db.Handler, usernam
 	return err
 }
 
+// SetBotByUsername implements store.UserStore.
+func (*userStore) SetBotByUsername(ctx context.Context, tx db.Handler, username string, isBot bool) error {
+	username = strings.ToLower(username)
+	if err := utils.ValidateUsername(username); err != nil {
+		return err
+	}
+
+	query := tx.Rebind(`UPDATE users SET bot = ? WHERE username = ?;`)
+	_, err := tx.ExecContext(ctx, query, isBot, username)
+	return err
+}
+
 // SetUsernameByUsername implements store.UserStore.
 func (*userStore) SetUsernameByUsername(ctx context.Context, tx db.Handler, username string, newUsername string) error {
 	username = strings.ToLower(username)
@@ -0,0 +1,39 @@
+package database
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/store"
+)
+
+type userPreferenceStore struct{}
+
+var _ store.UserPreferenceStore = (*userPreferenceStore)(nil)
+
+// GetUserPreferencesByUserID implements store.UserPreferenceStore.
+func (*userPreferenceStore) GetUserPreferencesByUserID(ctx context.Context, h db.Handler, userID int64) (models.UserPreferences, error) {
+	query := h.Rebind(`SELECT * FROM user_preferences WHERE user_id = ?;`)
+	var prefs models.UserPreferences
+	err := h.GetContext(ctx, &prefs, query, userID)
+	return prefs, err
+}
+
+// SetUserPreferences implements store.UserPreferenceStore.
+func (*userPreferenceStore) SetUserPreferences(ctx context.Context, h db.Handler, prefs models.UserPreferences) error {
+	query := h.Rebind(`INSERT INTO user_preferences (user_id, diff_word_wrap, diff_ignore_whitespace, diff_tab_width, hide_news, timezone, date_format, date_style, updated_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+			ON CONFLICT (user_id) DO UPDATE SET
+			diff_word_wrap = excluded.diff_word_wrap,
+			diff_ignore_whitespace = excluded.diff_ignore_whitespace,
+			diff_tab_width = excluded.diff_tab_width,
+			hide_news = excluded.hide_news,
+			timezone = excluded.timezone,
+			date_format = excluded.date_format,
+			date_style = excluded.date_style,
+			updated_at = CURRENT_TIMESTAMP;`)
+	_, err := h.ExecContext(ctx, query, prefs.UserID, prefs.DiffWordWrap, prefs.DiffIgnoreWhitespace, prefs.DiffTabWidth, prefs.HideNews,
+		prefs.Timezone, prefs.DateFormat, prefs.DateStyle)
+	return err
+}
@@ -0,0 +1,55 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/store/database"
+	"github.com/matryer/is"
+)
+
+func TestUserPreferenceStore(t *testing.T) {
+	runWithDatabases(t, testUserPreferenceStore)
+}
+
+func testUserPreferenceStore(t *testing.T, ctx context.Context, dbx *db.DB) {
+	is := is.New(t)
+
+	store := database.New(ctx, dbx)
+	userID, _, err := createTestUserAndRepo(ctx, dbx)
+	is.NoErr(err)
+
+	_, err = store.GetUserPreferencesByUserID(ctx, dbx, userID)
+	is.True(err != nil) // No preferences yet
+
+	err = store.SetUserPreferences(ctx, dbx, models.UserPreferences{
+		UserID:       userID,
+		DiffWordWrap: true,
+		DiffTabWidth: 4,
+	})
+	is.NoErr(err)
+
+	// Setting the preferences again replaces them.
+	err = store.SetUserPreferences(ctx, dbx, models.UserPreferences{
+		UserID:               userID,
+		DiffIgnoreWhitespace: true,
+		DiffTabWidth:         8,
+		HideNews:             true,
+		Timezone:             "Europe/Paris",
+		DateFormat:           "rfc3339",
+		DateStyle:            "absolute",
+	})
+	is.NoErr(err)
+
+	prefs, err := store.GetUserPreferencesByUserID(ctx, dbx, userID)
+	is.NoErr(err)
+	is.Equal(prefs.DiffWordWrap, false)
+	is.Equal(prefs.DiffIgnoreWhitespace, true)
+	is.Equal(prefs.DiffTabWidth, 8)
+	is.Equal(prefs.HideNews, true)
+	is.Equal(prefs.Timezone, "Europe/Paris")
+	is.Equal(prefs.DateFormat, "rfc3339")
+	is.Equal(prefs.DateStyle, "absolute")
+}
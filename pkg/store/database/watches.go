@@ -0,0 +1,153 @@
+package database
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/store"
+	"github.com/jmoiron/sqlx"
+)
+
+type watchStore struct{}
+
+var _ store.WatchStore = (*watchStore)(nil)
+
+// repoWatchColumns are the columns selected when querying repository watches.
+var repoWatchColumns = []string{
+	"id",
+	"repo_id",
+	"user_id",
+	"level",
+	"created_at",
+	"updated_at",
+}
+
+// subscriptionColumns are the columns selected when querying subscriptions.
+var subscriptionColumns = []string{
+	"id",
+	"repo_id",
+	"subject_type",
+	"subject_id",
+	"user_id",
+	"subscribed",
+	"created_at",
+	"updated_at",
+}
+
+// notificationColumns are the columns selected when querying notifications.
+var notificationColumns = []string{
+	"id",
+	"user_id",
+	"repo_id",
+	"subject_type",
+	"subject_id",
+	"title",
+	"action",
+	"actor_id",
+	"read_at",
+	"created_at",
+}
+
+// GetRepoWatch implements store.WatchStore.
+func (*watchStore) GetRepoWatch(ctx context.Context, h db.Handler, repoID int64, userID int64) (models.RepoWatch, error) {
+	var w models.RepoWatch
+	query := h.Rebind(`SELECT ` + selectColumns("", repoWatchColumns...) + ` FROM repo_watches WHERE repo_id = ? AND user_id = ?;`)
+	err := h.GetContext(ctx, &w, query, repoID, userID)
+	return w, err
+}
+
+// GetRepoWatchesByRepoID implements store.WatchStore.
+func (*watchStore) GetRepoWatchesByRepoID(ctx context.Context, h db.Handler, repoID int64) ([]models.RepoWatch, error) {
+	var ws []models.RepoWatch
+	query := h.Rebind(`SELECT ` + selectColumns("", repoWatchColumns...) + ` FROM repo_watches WHERE repo_id = ?;`)
+	err := h.SelectContext(ctx, &ws, query, repoID)
+	return ws, err
+}
+
+// GetRepoWatchesByUserID implements store.WatchStore.
+func (*watchStore) GetRepoWatchesByUserID(ctx context.Context, h db.Handler, userID int64) ([]models.RepoWatch, error) {
+	var ws []models.RepoWatch
+	query := h.Rebind(`SELECT ` + selectColumns("", repoWatchColumns...) + ` FROM repo_watches WHERE user_id = ?;`)
+	err := h.SelectContext(ctx, &ws, query, userID)
+	return ws, err
+}
+
+// SetRepoWatch implements store.WatchStore.
+func (*watchStore) SetRepoWatch(ctx context.Context, h db.Handler, repoID int64, userID int64, level models.WatchLevel) error {
+	query := h.Rebind(`INSERT INTO repo_watches (repo_id, user_id, level, updated_at)
+			VALUES (?, ?, ?, CURRENT_TIMESTAMP)
+			ON CONFLICT (repo_id, user_id) DO UPDATE SET
+			level = excluded.level,
+			updated_at = CURRENT_TIMESTAMP;`)
+	_, err := h.ExecContext(ctx, query, repoID, userID, level)
+	return err
+}
+
+// GetSubscriptions implements store.WatchStore.
+func (*watchStore) GetSubscriptions(ctx context.Context, h db.Handler, repoID int64, subjectType string, subjectID int64) ([]models.Subscription, error) {
+	var subs []models.Subscription
+	query := h.Rebind(`SELECT ` + selectColumns("", subscriptionColumns...) + ` FROM subscriptions
+			WHERE repo_id = ? AND subject_type = ? AND subject_id = ?
+			ORDER BY id ASC;`)
+	err := h.SelectContext(ctx, &subs, query, repoID, subjectType, subjectID)
+	return subs, err
+}
+
+// SetSubscription implements store.WatchStore.
+func (*watchStore) SetSubscription(ctx context.Context, h db.Handler, repoID int64, subjectType string, subjectID int64, userID int64, subscribed bool) error {
+	query := h.Rebind(`INSERT INTO subscriptions (repo_id, subject_type, subject_id, user_id, subscribed, updated_at)
+			VALUES (?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+			ON CONFLICT (repo_id, subject_type, subject_id, user_id) DO UPDATE SET
+			subscribed = excluded.subscribed,
+			updated_at = CURRENT_TIMESTAMP;`)
+	_, err := h.ExecContext(ctx, query, repoID, subjectType, subjectID, userID, subscribed)
+	return err
+}
+
+// AddSubscription implements store.WatchStore.
+func (*watchStore) AddSubscription(ctx context.Context, h db.Handler, repoID int64, subjectType string, subjectID int64, userID int64) error {
+	query := h.Rebind(`INSERT INTO subscriptions (repo_id, subject_type, subject_id, user_id, subscribed, updated_at)
+			VALUES (?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+			ON CONFLICT (repo_id, subject_type, subject_id, user_id) DO NOTHING;`)
+	_, err := h.ExecContext(ctx, query, repoID, subjectType, subjectID, userID, true)
+	return err
+}
+
+// CreateNotification implements store.WatchStore.
+func (*watchStore) CreateNotification(ctx context.Context, h db.Handler, n models.Notification) error {
+	query := h.Rebind(`INSERT INTO notifications (user_id, repo_id, subject_type, subject_id, title, action, actor_id)
+			VALUES (?, ?, ?, ?, ?, ?, ?);`)
+	_, err := h.ExecContext(ctx, query, n.UserID, n.RepoID, n.SubjectType, n.SubjectID, n.Title, n.Action, n.ActorID)
+	return err
+}
+
+// GetNotificationsByUserID implements store.WatchStore.
+func (*watchStore) GetNotificationsByUserID(ctx context.Context, h db.Handler, userID int64, unreadOnly bool) ([]models.Notification, error) {
+	var ns []models.Notification
+	query := `SELECT ` + selectColumns("", notificationColumns...) + ` FROM notifications WHERE user_id = ?`
+	if unreadOnly {
+		query += ` AND read_at IS NULL`
+	}
+	query += ` ORDER BY created_at DESC, id DESC;`
+	err := h.SelectContext(ctx, &ns, h.Rebind(query), userID)
+	return ns, err
+}
+
+// MarkNotificationsRead implements store.WatchStore.
+func (*watchStore) MarkNotificationsRead(ctx context.Context, h db.Handler, userID int64, ids []int64) error {
+	if len(ids) == 0 {
+		query := h.Rebind(`UPDATE notifications SET read_at = CURRENT_TIMESTAMP
+				WHERE user_id = ? AND read_at IS NULL;`)
+		_, err := h.ExecContext(ctx, query, userID)
+		return err
+	}
+
+	query, args, err := sqlx.In(`UPDATE notifications SET read_at = CURRENT_TIMESTAMP
+			WHERE user_id = ? AND read_at IS NULL AND id IN (?);`, userID, ids)
+	if err != nil {
+		return err
+	}
+	_, err = h.ExecContext(ctx, h.Rebind(query), args...)
+	return err
+}
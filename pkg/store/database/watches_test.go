@@ -0,0 +1,90 @@
+package database_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/store/database"
+	"github.com/matryer/is"
+)
+
+func TestWatches(t *testing.T) {
+	runWithDatabases(t, testWatches)
+}
+
+func testWatches(t *testing.T, ctx context.Context, dbx *db.DB) {
+	is := is.New(t)
+
+	store := database.New(ctx, dbx)
+	userID, repoID, err := createTestUserAndRepo(ctx, dbx)
+	is.NoErr(err)
+
+	_, err = store.GetRepoWatch(ctx, dbx, repoID, userID)
+	is.True(err != nil) // Not watching yet
+
+	is.NoErr(store.SetRepoWatch(ctx, dbx, repoID, userID, models.WatchLevelAll))
+	is.NoErr(store.SetRepoWatch(ctx, dbx, repoID, userID, models.WatchLevelIgnore))
+	w, err := store.GetRepoWatch(ctx, dbx, repoID, userID)
+	is.NoErr(err)
+	is.Equal(w.Level, models.WatchLevelIgnore)
+
+	ws, err := store.GetRepoWatchesByRepoID(ctx, dbx, repoID)
+	is.NoErr(err)
+	is.Equal(len(ws), 1)
+	ws, err = store.GetRepoWatchesByUserID(ctx, dbx, userID)
+	is.NoErr(err)
+	is.Equal(len(ws), 1)
+	is.Equal(ws[0].RepoID, repoID)
+
+	for _, title := range []string{"First", "Second"} {
+		is.NoErr(store.CreateNotification(ctx, dbx, models.Notification{
+			UserID:      userID,
+			RepoID:      sql.NullInt64{Int64: repoID, Valid: true},
+			SubjectType: "issue",
+			SubjectID:   1,
+			Title:       title,
+			Action:      "opened",
+			ActorID:     sql.NullInt64{Int64: userID, Valid: true},
+		}))
+	}
+
+	ns, err := store.GetNotificationsByUserID(ctx, dbx, userID, true)
+	is.NoErr(err)
+	is.Equal(len(ns), 2)
+	is.Equal(ns[0].Title, "Second")
+
+	is.NoErr(store.MarkNotificationsRead(ctx, dbx, userID, []int64{ns[0].ID}))
+	ns, err = store.GetNotificationsByUserID(ctx, dbx, userID, true)
+	is.NoErr(err)
+	is.Equal(len(ns), 1)
+	is.Equal(ns[0].Title, "First")
+
+	is.NoErr(store.MarkNotificationsRead(ctx, dbx, userID, nil))
+	ns, err = store.GetNotificationsByUserID(ctx, dbx, userID, true)
+	is.NoErr(err)
+	is.Equal(len(ns), 0)
+	ns, err = store.GetNotificationsByUserID(ctx, dbx, userID, false)
+	is.NoErr(err)
+	is.Equal(len(ns), 2)
+
+	// Explicit choices win over automatic subscriptions.
+	is.NoErr(store.SetSubscription(ctx, dbx, repoID, "issue", 1, userID, false))
+	is.NoErr(store.AddSubscription(ctx, dbx, repoID, "issue", 1, userID))
+	is.NoErr(store.AddSubscription(ctx, dbx, repoID, "merge_request", 1, userID))
+	subs, err := store.GetSubscriptions(ctx, dbx, repoID, "issue", 1)
+	is.NoErr(err)
+	is.Equal(len(subs), 1)
+	is.True(!subs[0].Subscribed)
+	subs, err = store.GetSubscriptions(ctx, dbx, repoID, "merge_request", 1)
+	is.NoErr(err)
+	is.Equal(len(subs), 1)
+	is.True(subs[0].Subscribed)
+
+	is.NoErr(store.SetSubscription(ctx, dbx, repoID, "issue", 1, userID, true))
+	subs, err = store.GetSubscriptions(ctx, dbx, repoID, "issue", 1)
+	is.NoErr(err)
+	is.True(subs[0].Subscribed)
+}
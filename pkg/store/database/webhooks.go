@@ -2,6 +2,7 @@ package database
 
 import (
 	"context"
+	"time"
 
 	"github.com/charmbracelet/soft-serve/pkg/db"
 	"github.com/charmbracelet/soft-serve/pkg/db/models"
@@ -15,11 +16,11 @@ type webhookStore struct{}
 var _ store.WebhookStore = (*webhookStore)(nil)
 
 // CreateWebhook implements store.WebhookStore.
-func (*webhookStore) CreateWebhook(ctx context.Context, h db.Handler, repoID int64, url string, secret string, contentType int, active bool) (int64, error) {
+func (*webhookStore) CreateWebhook(ctx context.Context, h db.Handler, repoID int64, url string, secret string, contentType int, format int, active bool) (int64, error) {
 	var id int64
-	query := h.Rebind(`INSERT INTO webhooks (repo_id, url, secret, content_type, active, updated_at)
-			VALUES (?, ?, ?, ?, ?, CURRENT_TIMESTAMP) RETURNING id;`)
-	err := h.GetContext(ctx, &id, query, repoID, url, secret, contentType, active)
+	query := h.Rebind(`INSERT INTO webhooks (repo_id, url, secret, content_type, format, active, updated_at)
+			VALUES (?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP) RETURNING id;`)
+	err := h.GetContext(ctx, &id, query, repoID, url, secret, contentType, format, active)
 	if err != nil {
 		return 0, err
 	}
@@ -73,8 +74,22 @@ func (*webhookStore) DeleteWebhookDeliveryByID(ctx context.Context, h db.Handler
 	return err
 }
 
+// DeleteWebhookDeliveriesBefore implements store.WebhookStore.
+func (*webhookStore) DeleteWebhookDeliveriesBefore(ctx context.Context, h db.Handler, t time.Time) (int64, error) {
+	query := h.Rebind(`DELETE FROM webhook_deliveries WHERE created_at < ?;`)
+	res, err := h.ExecContext(ctx, query, t.UTC())
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
 // DeleteWebhookEventsByWebhookID implements store.WebhookStore.
 func (*webhookStore) DeleteWebhookEventsByID(ctx context.Context, h db.Handler, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
 	query, args, err := sqlx.In(`DELETE FROM webhook_events WHERE id IN (?);`, ids)
 	if err != nil {
 		return err
@@ -93,6 +108,14 @@ func (*webhookStore) GetWebhookByID(ctx context.Context, h db.Handler, repoID in
 	return wh, err
 }
 
+// GetWebhookByExternalID implements store.WebhookStore.
+func (*webhookStore) GetWebhookByExternalID(ctx context.Context, h db.Handler, repoID int64, externalID string) (models.Webhook, error) {
+	query := h.Rebind(`SELECT * FROM webhooks WHERE repo_id = ? AND external_id = ?;`)
+	var wh models.Webhook
+	err := h.GetContext(ctx, &wh, query, repoID, externalID)
+	return wh, err
+}
+
 // GetWebhookDeliveriesByWebhookID implements store.WebhookStore.
 func (*webhookStore) GetWebhookDeliveriesByWebhookID(ctx context.Context, h db.Handler, webhookID int64) ([]models.WebhookDelivery, error) {
 	query := h.Rebind(`SELECT * FROM webhook_deliveries WHERE webhook_id = ?;`)
@@ -151,15 +174,51 @@ func (*webhookStore) GetWebhooksByRepoIDWhereEvent(ctx context.Context, h db.Han
 
 // ListWebhookDeliveriesByWebhookID implements store.WebhookStore.
 func (*webhookStore) ListWebhookDeliveriesByWebhookID(ctx context.Context, h db.Handler, webhookID int64) ([]models.WebhookDelivery, error) {
-	query := h.Rebind(`SELECT id, response_status, event FROM webhook_deliveries WHERE webhook_id = ?;`)
+	query := h.Rebind(`SELECT id, response_status, event, created_at FROM webhook_deliveries WHERE webhook_id = ? ORDER BY created_at DESC;`)
 	var whds []models.WebhookDelivery
 	err := h.SelectContext(ctx, &whds, query, webhookID)
 	return whds, err
 }
 
+// GetFailingWebhooksToNotify implements store.WebhookStore.
+func (*webhookStore) GetFailingWebhooksToNotify(ctx context.Context, h db.Handler, threshold int) ([]models.Webhook, error) {
+	query := h.Rebind(`SELECT * FROM webhooks
+			WHERE active = ? AND failures >= ? AND failure_notified = ?
+			ORDER BY id ASC;`)
+	var whs []models.Webhook
+	err := h.SelectContext(ctx, &whs, query, true, threshold, false)
+	return whs, err
+}
+
+// SetWebhookDeliveryResult implements store.WebhookStore.
+func (*webhookStore) SetWebhookDeliveryResult(ctx context.Context, h db.Handler, id int64, ok bool) error {
+	query := h.Rebind(`UPDATE webhooks SET failures = failures + 1 WHERE id = ?;`)
+	args := []interface{}{id}
+	if ok {
+		query = h.Rebind(`UPDATE webhooks SET failures = 0, failure_notified = ? WHERE id = ?;`)
+		args = []interface{}{false, id}
+	}
+	_, err := h.ExecContext(ctx, query, args...)
+	return err
+}
+
+// SetWebhookFailureNotified implements store.WebhookStore.
+func (*webhookStore) SetWebhookFailureNotified(ctx context.Context, h db.Handler, id int64) error {
+	query := h.Rebind(`UPDATE webhooks SET failure_notified = ? WHERE id = ?;`)
+	_, err := h.ExecContext(ctx, query, true, id)
+	return err
+}
+
+// SetWebhookExternalID implements store.WebhookStore.
+func (*webhookStore) SetWebhookExternalID(ctx context.Context, h db.Handler, repoID int64, id int64, externalID string) error {
+	query := h.Rebind(`UPDATE webhooks SET external_id = ?, updated_at = CURRENT_TIMESTAMP WHERE repo_id = ? AND id = ?;`)
+	_, err := h.ExecContext(ctx, query, externalID, repoID, id)
+	return err
+}
+
 // UpdateWebhookByID implements store.WebhookStore.
-func (*webhookStore) UpdateWebhookByID(ctx context.Context, h db.Handler, repoID int64, id int64, url string, secret string, contentType int, active bool) error {
-	query := h.Rebind(`UPDATE webhooks SET url = ?, secret = ?, content_type = ?, active = ?, updated_at = CURRENT_TIMESTAMP WHERE repo_id = ? AND id = ?;`)
-	_, err := h.ExecContext(ctx, query, url, secret, contentType, active, repoID, id)
+func (*webhookStore) UpdateWebhookByID(ctx context.Context, h db.Handler, repoID int64, id int64, url string, secret string, contentType int, format int, active bool) error {
+	query := h.Rebind(`UPDATE webhooks SET url = ?, secret = ?, content_type = ?, format = ?, active = ?, updated_at = CURRENT_TIMESTAMP WHERE repo_id = ? AND id = ?;`)
+	_, err := h.ExecContext(ctx, query, url, secret, contentType, format, active, repoID, id)
 	return err
 }
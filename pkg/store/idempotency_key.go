@@ -0,0 +1,28 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+)
+
+// IdempotencyKeyStore is an interface for managing the idempotency keys of
+// create requests.
+type IdempotencyKeyStore interface {
+	// CreateIdempotencyKey reserves a key of a user for a request, before it
+	// runs. It fails with a duplicate key error if the user already used the
+	// key.
+	CreateIdempotencyKey(ctx context.Context, h db.Handler, userID int64, key string, requestHash string) error
+	// GetIdempotencyKey returns a key of a user.
+	GetIdempotencyKey(ctx context.Context, h db.Handler, userID int64, key string) (models.IdempotencyKey, error)
+	// SetIdempotencyKeyResponse records the response of the request of a key.
+	SetIdempotencyKeyResponse(ctx context.Context, h db.Handler, userID int64, key string, status int, response string) error
+	// DeleteIdempotencyKey deletes a key of a user, e.g. when its request
+	// failed and can be retried.
+	DeleteIdempotencyKey(ctx context.Context, h db.Handler, userID int64, key string) error
+	// DeleteIdempotencyKeysBefore deletes the keys created before t, and
+	// returns how many were deleted.
+	DeleteIdempotencyKeysBefore(ctx context.Context, h db.Handler, t time.Time) (int64, error)
+}
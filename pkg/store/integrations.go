@@ -0,0 +1,29 @@
+package store
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+)
+
+// IntegrationStore is an interface for managing chat notification integrations.
+type IntegrationStore interface {
+	// GetIntegrationByID returns an integration by its ID.
+	GetIntegrationByID(ctx context.Context, h db.Handler, repoID int64, id int64) (models.Integration, error)
+	// GetIntegrationsByRepoID returns all integrations for a repository.
+	GetIntegrationsByRepoID(ctx context.Context, h db.Handler, repoID int64) ([]models.Integration, error)
+	// GetActiveIntegrationsByRepoIDWhereEvent returns all active integrations for a repository where event is in the events.
+	GetActiveIntegrationsByRepoIDWhereEvent(ctx context.Context, h db.Handler, repoID int64, events []int) ([]models.Integration, error)
+	// CreateIntegration creates an integration.
+	CreateIntegration(ctx context.Context, h db.Handler, repoID int64, kind int, url string, secret string, template string, active bool) (int64, error)
+	// UpdateIntegrationByID updates an integration by its ID.
+	UpdateIntegrationByID(ctx context.Context, h db.Handler, repoID int64, id int64, url string, secret string, template string, active bool) error
+	// DeleteIntegrationForRepoByID deletes an integration for a repository by its ID.
+	DeleteIntegrationForRepoByID(ctx context.Context, h db.Handler, repoID int64, id int64) error
+
+	// GetIntegrationEventsByIntegrationID returns all events for an integration.
+	GetIntegrationEventsByIntegrationID(ctx context.Context, h db.Handler, integrationID int64) ([]models.IntegrationEvent, error)
+	// SetIntegrationEvents replaces the events of an integration.
+	SetIntegrationEvents(ctx context.Context, h db.Handler, integrationID int64, events []int) error
+}
@@ -0,0 +1,21 @@
+package store
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+)
+
+// IssueSLAStore is an interface for managing the issue response time SLAs of
+// repositories.
+type IssueSLAStore interface {
+	// GetIssueSLAs returns the issue SLAs of all repositories.
+	GetIssueSLAs(ctx context.Context, h db.Handler) ([]models.IssueSLA, error)
+	// GetIssueSLAByRepoID returns the issue SLA of a repository.
+	GetIssueSLAByRepoID(ctx context.Context, h db.Handler, repoID int64) (models.IssueSLA, error)
+	// SetIssueSLA creates or replaces the issue SLA of a repository.
+	SetIssueSLA(ctx context.Context, h db.Handler, repoID int64, responseHours int) error
+	// DeleteIssueSLAByRepoID deletes the issue SLA of a repository.
+	DeleteIssueSLAByRepoID(ctx context.Context, h db.Handler, repoID int64) error
+}
@@ -0,0 +1,19 @@
+package store
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+)
+
+// IssueTrackerStore is an interface for managing external issue trackers
+// linked to repositories.
+type IssueTrackerStore interface {
+	// GetIssueTrackerByRepoID returns the issue tracker of a repository.
+	GetIssueTrackerByRepoID(ctx context.Context, h db.Handler, repoID int64) (models.IssueTracker, error)
+	// SetIssueTracker creates or replaces the issue tracker of a repository.
+	SetIssueTracker(ctx context.Context, h db.Handler, repoID int64, kind int, url string, projects string, username string, token string, doneState string) error
+	// DeleteIssueTrackerByRepoID deletes the issue tracker of a repository.
+	DeleteIssueTrackerByRepoID(ctx context.Context, h db.Handler, repoID int64) error
+}
@@ -2,6 +2,7 @@ package store
 
 import (
 	"context"
+	"time"
 
 	"github.com/charmbracelet/soft-serve/pkg/db"
 	"github.com/charmbracelet/soft-serve/pkg/db/models"
@@ -11,18 +12,83 @@ import (
 type IssueStore interface {
 	// GetIssueByID returns an issue by its ID.
 	GetIssueByID(ctx context.Context, h db.Handler, repoID int64, id int64) (models.Issue, error)
-	// GetIssuesByRepoID returns all issues for a repository.
-	GetIssuesByRepoID(ctx context.Context, h db.Handler, repoID int64) ([]models.Issue, error)
-	// GetIssuesByRepoIDAndState returns all issues for a repository with a specific state.
-	GetIssuesByRepoIDAndState(ctx context.Context, h db.Handler, repoID int64, state models.IssueState) ([]models.Issue, error)
-	// CreateIssue creates an issue.
+	// GetIssueByNumber returns an issue by its number in its repository.
+	GetIssueByNumber(ctx context.Context, h db.Handler, repoID int64, number int64) (models.Issue, error)
+	// GetIssueByExternalID returns an issue by the ID it had in the tracker
+	// it was imported from.
+	GetIssueByExternalID(ctx context.Context, h db.Handler, repoID int64, externalID string) (models.Issue, error)
+	// GetIssuesByRepoID returns a page of the issues for a repository in
+	// order. A zero limit returns every issue. A non-zero after is the number
+	// of the last issue of the previous page: only the issues following it in
+	// order are returned.
+	GetIssuesByRepoID(ctx context.Context, h db.Handler, repoID int64, order models.ListOrder, limit int, after int64) ([]models.Issue, error)
+	// GetIssuesByRepoIDAndState returns a page of the issues for a repository
+	// with a specific state, see GetIssuesByRepoID.
+	GetIssuesByRepoIDAndState(ctx context.Context, h db.Handler, repoID int64, state models.IssueState, order models.ListOrder, limit int, after int64) ([]models.Issue, error)
+	// SearchIssues returns the issues of the given repositories matching a
+	// text query, most recently updated first. A nil state or a zero authorID
+	// match any state or author. Draft issues are only matched if viewerID
+	// is their author.
+	SearchIssues(ctx context.Context, h db.Handler, repoIDs []int64, query string, state *models.IssueState, authorID int64, viewerID int64, limit int, offset int) ([]models.Issue, error)
+	// CreateIssue creates an issue with the next number of the repository
+	// and returns its ID.
 	CreateIssue(ctx context.Context, h db.Handler, repoID int64, authorID int64, title string, description string) (int64, error)
+	// CountIssuesByRepoID returns the number of issues of a repository. A
+	// nil state counts the issues in any state.
+	CountIssuesByRepoID(ctx context.Context, h db.Handler, repoID int64, state *models.IssueState) (int64, error)
+	// CountOthersDraftIssuesByRepoID returns the number of draft issues of
+	// a repository opened by other authors than authorID. A nil state counts
+	// the issues in any state.
+	CountOthersDraftIssuesByRepoID(ctx context.Context, h db.Handler, repoID int64, state *models.IssueState, authorID int64) (int64, error)
+	// CountIssuesByAuthorID returns the number of issues an author opened in
+	// a repository.
+	CountIssuesByAuthorID(ctx context.Context, h db.Handler, repoID int64, authorID int64) (int64, error)
+	// SetIssueFirstContribution marks an issue as the first contribution of
+	// its author to the repository.
+	SetIssueFirstContribution(ctx context.Context, h db.Handler, repoID int64, id int64) error
+	// SetIssueLocked locks or unlocks an issue, see models.Issue.Locked.
+	SetIssueLocked(ctx context.Context, h db.Handler, repoID int64, id int64, locked bool) error
+	// SetIssueDraft marks an issue as a draft or publishes it, see
+	// models.Issue.Draft. Publishing an issue makes it created now.
+	SetIssueDraft(ctx context.Context, h db.Handler, repoID int64, id int64, draft bool) error
+	// SetIssueHidden hides or shows the description of an issue, see
+	// models.Issue.Hidden.
+	SetIssueHidden(ctx context.Context, h db.Handler, repoID int64, id int64, hidden bool) error
+	// SetIssueEditedBy records who last edited an issue, see
+	// models.Issue.EditedByID.
+	SetIssueEditedBy(ctx context.Context, h db.Handler, repoID int64, id int64, userID int64) error
+	// SetIssuePinned pins or unpins an issue, see models.Issue.Pinned.
+	SetIssuePinned(ctx context.Context, h db.Handler, repoID int64, id int64, pinned bool) error
+	// GetPinnedIssuesByRepoID returns the pinned issues of a repository in
+	// the order they were pinned.
+	GetPinnedIssuesByRepoID(ctx context.Context, h db.Handler, repoID int64) ([]models.Issue, error)
+	// SetIssueExternalID records the ID an issue had in the tracker it was
+	// imported from.
+	SetIssueExternalID(ctx context.Context, h db.Handler, repoID int64, id int64, externalID string) error
 	// UpdateIssue updates an issue.
 	UpdateIssue(ctx context.Context, h db.Handler, repoID int64, id int64, title string, description string) error
-	// CloseIssue marks an issue as closed.
-	CloseIssue(ctx context.Context, h db.Handler, repoID int64, id int64, closedBy int64) error
+	// CloseIssue marks an issue as closed for the given reason.
+	CloseIssue(ctx context.Context, h db.Handler, repoID int64, id int64, closedBy int64, reason models.IssueCloseReason) error
+	// CloseIssueByCommit marks an issue as closed by a pushed commit, see
+	// models.Issue.ClosedByCommit.
+	CloseIssueByCommit(ctx context.Context, h db.Handler, repoID int64, id int64, closedBy int64, sha string) error
 	// ReopenIssue reopens a closed issue.
 	ReopenIssue(ctx context.Context, h db.Handler, repoID int64, id int64) error
+	// SetIssueStale marks an open issue as stale, or clears the mark. It does
+	// not count as activity on the issue.
+	SetIssueStale(ctx context.Context, h db.Handler, repoID int64, id int64, stale bool) error
+	// CloseStaleIssue closes a stale issue as not planned, without a closing
+	// user.
+	CloseStaleIssue(ctx context.Context, h db.Handler, repoID int64, id int64) error
+	// SetIssueResponded records the first response to an issue by a user
+	// other than its author. Later responses are ignored.
+	SetIssueResponded(ctx context.Context, h db.Handler, repoID int64, id int64, userID int64) error
+	// SetIssueSLABreached marks an issue as having missed its response time
+	// SLA.
+	SetIssueSLABreached(ctx context.Context, h db.Handler, repoID int64, id int64) error
+	// GetIssuesClosedBefore returns the issues of every repository closed
+	// before t.
+	GetIssuesClosedBefore(ctx context.Context, h db.Handler, t time.Time) ([]models.Issue, error)
 	// DeleteIssue deletes an issue by its ID.
 	DeleteIssue(ctx context.Context, h db.Handler, repoID int64, id int64) error
 
@@ -36,4 +102,14 @@ type IssueStore interface {
 	GetIssueDependents(ctx context.Context, h db.Handler, repoID int64, issueID int64) ([]models.Issue, error)
 	// HasIssueDependency checks if a dependency exists.
 	HasIssueDependency(ctx context.Context, h db.Handler, repoID int64, issueID int64, dependsOnID int64) (bool, error)
+
+	// AddIssueVote adds a user's vote to an issue.
+	AddIssueVote(ctx context.Context, h db.Handler, repoID int64, issueID int64, userID int64) error
+	// RemoveIssueVote removes a user's vote from an issue.
+	RemoveIssueVote(ctx context.Context, h db.Handler, repoID int64, issueID int64, userID int64) error
+	// GetIssueVoteCounts returns the number of votes of the voted issues of a
+	// repository by issue ID.
+	GetIssueVoteCounts(ctx context.Context, h db.Handler, repoID int64) (map[int64]int, error)
+	// HasIssueVote checks if a user voted for an issue.
+	HasIssueVote(ctx context.Context, h db.Handler, repoID int64, issueID int64, userID int64) (bool, error)
 }
@@ -0,0 +1,32 @@
+package store
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+)
+
+// LabelStore is an interface for managing the issue labels of repositories.
+type LabelStore interface {
+	// GetLabelsByRepoID returns the labels of a repository by name.
+	GetLabelsByRepoID(ctx context.Context, h db.Handler, repoID int64) ([]models.Label, error)
+	// GetLabelByName returns a label of a repository by its name.
+	GetLabelByName(ctx context.Context, h db.Handler, repoID int64, name string) (models.Label, error)
+	// CreateLabel creates a label.
+	CreateLabel(ctx context.Context, h db.Handler, repoID int64, name string, color string, description string) error
+	// UpdateLabel renames a label, and changes its color and description.
+	UpdateLabel(ctx context.Context, h db.Handler, repoID int64, id int64, name string, color string, description string) error
+	// DeleteLabel deletes a label, and detaches it from the issues.
+	DeleteLabel(ctx context.Context, h db.Handler, repoID int64, id int64) error
+
+	// AddIssueLabel attaches a label to an issue.
+	AddIssueLabel(ctx context.Context, h db.Handler, repoID int64, issueID int64, labelID int64) error
+	// RemoveIssueLabel detaches a label from an issue.
+	RemoveIssueLabel(ctx context.Context, h db.Handler, repoID int64, issueID int64, labelID int64) error
+	// GetIssueLabels returns the labels attached to an issue by name.
+	GetIssueLabels(ctx context.Context, h db.Handler, repoID int64, issueID int64) ([]models.Label, error)
+	// GetIssueLabelsByRepoID returns the labels attached to the issues of a
+	// repository.
+	GetIssueLabelsByRepoID(ctx context.Context, h db.Handler, repoID int64) ([]models.IssueLabel, error)
+}
@@ -0,0 +1,29 @@
+package store
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+// LargeTextStore is an interface for managing descriptions that are stored
+// out of line.
+type LargeTextStore interface {
+	// GetIssueLargeText returns the full description of an issue.
+	GetIssueLargeText(ctx context.Context, h db.Handler, repoID int64, issueID int64) (string, error)
+	// SetIssueLargeText stores the full description of an issue and marks the
+	// inline description as truncated.
+	SetIssueLargeText(ctx context.Context, h db.Handler, repoID int64, issueID int64, content string) error
+	// DeleteIssueLargeText deletes the full description of an issue and marks
+	// the inline description as complete.
+	DeleteIssueLargeText(ctx context.Context, h db.Handler, repoID int64, issueID int64) error
+
+	// GetMergeRequestLargeText returns the full description of a merge request.
+	GetMergeRequestLargeText(ctx context.Context, h db.Handler, repoID int64, mrID int64) (string, error)
+	// SetMergeRequestLargeText stores the full description of a merge request
+	// and marks the inline description as truncated.
+	SetMergeRequestLargeText(ctx context.Context, h db.Handler, repoID int64, mrID int64, content string) error
+	// DeleteMergeRequestLargeText deletes the full description of a merge
+	// request and marks the inline description as complete.
+	DeleteMergeRequestLargeText(ctx context.Context, h db.Handler, repoID int64, mrID int64) error
+}
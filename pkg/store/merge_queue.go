@@ -0,0 +1,23 @@
+package store
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+)
+
+// MergeQueueStore is an interface for managing the merge queues of
+// repositories.
+type MergeQueueStore interface {
+	// GetMergeQueueEntriesByRepoID returns the merge queue of a repository,
+	// in the order merge requests entered it.
+	GetMergeQueueEntriesByRepoID(ctx context.Context, h db.Handler, repoID int64) ([]models.MergeQueueEntry, error)
+	// CountMergeQueueEntries returns the number of merge requests waiting in
+	// the merge queues of all repositories.
+	CountMergeQueueEntries(ctx context.Context, h db.Handler) (int64, error)
+	// AddMergeQueueEntry adds a merge request to the end of the merge queue.
+	AddMergeQueueEntry(ctx context.Context, h db.Handler, repoID int64, mrID int64, userID int64) error
+	// DeleteMergeQueueEntry removes a merge request from the merge queue.
+	DeleteMergeQueueEntry(ctx context.Context, h db.Handler, repoID int64, mrID int64) error
+}
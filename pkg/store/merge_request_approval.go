@@ -0,0 +1,26 @@
+package store
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+)
+
+// MergeRequestApprovalStore is an interface for managing the approvals of
+// merge requests.
+type MergeRequestApprovalStore interface {
+	// AddMergeRequestApproval records a user's approval of a merge request.
+	AddMergeRequestApproval(ctx context.Context, h db.Handler, repoID int64, mrID int64, userID int64) error
+	// RemoveMergeRequestApproval removes a user's approval of a merge request.
+	RemoveMergeRequestApproval(ctx context.Context, h db.Handler, repoID int64, mrID int64, userID int64) error
+	// GetMergeRequestApprovers returns the users who approved a merge request.
+	GetMergeRequestApprovers(ctx context.Context, h db.Handler, repoID int64, mrID int64) ([]models.User, error)
+	// CountMergeRequestApprovals returns the number of approvals of a merge
+	// request.
+	CountMergeRequestApprovals(ctx context.Context, h db.Handler, repoID int64, mrID int64) (int, error)
+	// GetMergeRequestApprovalCountsByRepoID returns the number of approvals
+	// of each approved merge request of a repository, keyed by merge request
+	// ID.
+	GetMergeRequestApprovalCountsByRepoID(ctx context.Context, h db.Handler, repoID int64) (map[int64]int, error)
+}
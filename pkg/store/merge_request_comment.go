@@ -0,0 +1,35 @@
+package store
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+)
+
+// MergeRequestCommentStore is an interface for managing the comments of merge
+// requests.
+type MergeRequestCommentStore interface {
+	// CreateMergeRequestComment creates a comment on a merge request, a reply
+	// to the comment parentID, or a top-level comment if parentID is zero.
+	// Top-level comments may be anchored to lines of the diff.
+	CreateMergeRequestComment(ctx context.Context, h db.Handler, repoID int64, mrID int64, authorID int64, parentID int64, anchor models.CommentAnchor, body string) (int64, error)
+	// GetMergeRequestCommentByID returns a comment of a merge request.
+	GetMergeRequestCommentByID(ctx context.Context, h db.Handler, repoID int64, mrID int64, id int64) (models.MergeRequestComment, error)
+	// GetRepoMergeRequestComment returns a comment of any merge request of
+	// a repository.
+	GetRepoMergeRequestComment(ctx context.Context, h db.Handler, repoID int64, id int64) (models.MergeRequestComment, error)
+	// GetMergeRequestComments returns the comments of a merge request, oldest
+	// first.
+	GetMergeRequestComments(ctx context.Context, h db.Handler, repoID int64, mrID int64) ([]models.MergeRequestComment, error)
+	// GetCurrentAnchoredMergeRequestComments returns the anchored comments
+	// that aren't outdated of the open merge requests from a source branch.
+	GetCurrentAnchoredMergeRequestComments(ctx context.Context, h db.Handler, repoID int64, sourceBranch string) ([]models.MergeRequestComment, error)
+	// SetMergeRequestCommentOutdated marks an anchored comment as outdated.
+	SetMergeRequestCommentOutdated(ctx context.Context, h db.Handler, repoID int64, id int64) error
+	// SetMergeRequestCommentHidden hides or shows the body of a comment, see
+	// models.MergeRequestComment.Hidden.
+	SetMergeRequestCommentHidden(ctx context.Context, h db.Handler, repoID int64, id int64, hidden bool) error
+	// DeleteMergeRequestComment deletes a comment and its replies.
+	DeleteMergeRequestComment(ctx context.Context, h db.Handler, repoID int64, id int64) error
+}
@@ -11,20 +11,81 @@ import (
 type MergeRequestStore interface {
 	// GetMergeRequestByID returns a merge request by its ID.
 	GetMergeRequestByID(ctx context.Context, h db.Handler, repoID int64, id int64) (models.MergeRequest, error)
-	// GetMergeRequestsByRepoID returns all merge requests for a repository.
-	GetMergeRequestsByRepoID(ctx context.Context, h db.Handler, repoID int64) ([]models.MergeRequest, error)
-	// GetMergeRequestsByRepoIDAndState returns all merge requests for a repository with a specific state.
-	GetMergeRequestsByRepoIDAndState(ctx context.Context, h db.Handler, repoID int64, state models.MergeRequestState) ([]models.MergeRequest, error)
+	// GetMergeRequestsByRepoID returns a page of the merge requests for a
+	// repository in order. A zero limit returns every merge request. A
+	// non-zero after is the ID of the last merge request of the previous page:
+	// only the merge requests following it in order are returned.
+	GetMergeRequestsByRepoID(ctx context.Context, h db.Handler, repoID int64, order models.ListOrder, limit int, after int64) ([]models.MergeRequest, error)
+	// GetMergeRequestsByRepoIDAndState returns a page of the merge requests for
+	// a repository with a specific state, see GetMergeRequestsByRepoID.
+	GetMergeRequestsByRepoIDAndState(ctx context.Context, h db.Handler, repoID int64, state models.MergeRequestState, order models.ListOrder, limit int, after int64) ([]models.MergeRequest, error)
+	// SearchMergeRequests returns the merge requests of the given repositories
+	// matching a text query, most recently updated first. A nil state or a zero
+	// authorID match any state or author.
+	SearchMergeRequests(ctx context.Context, h db.Handler, repoIDs []int64, query string, state *models.MergeRequestState, authorID int64, limit int, offset int) ([]models.MergeRequest, error)
 	// CreateMergeRequest creates a merge request.
 	CreateMergeRequest(ctx context.Context, h db.Handler, repoID int64, authorID int64, title string, description string, sourceBranch string, targetBranch string) (int64, error)
+	// CountMergeRequestsByRepoID returns the number of merge requests of a
+	// repository. A nil state counts the merge requests in any state.
+	CountMergeRequestsByRepoID(ctx context.Context, h db.Handler, repoID int64, state *models.MergeRequestState) (int64, error)
+	// CountMergeRequestsByAuthorID returns the number of merge requests an
+	// author opened in a repository.
+	CountMergeRequestsByAuthorID(ctx context.Context, h db.Handler, repoID int64, authorID int64) (int64, error)
+	// SetMergeRequestFirstContribution marks a merge request as the first
+	// contribution of its author to the repository.
+	SetMergeRequestFirstContribution(ctx context.Context, h db.Handler, repoID int64, id int64) error
+	// SetMergeRequestDraft marks a merge request as a draft, or as ready, see
+	// models.MergeRequest.Draft.
+	SetMergeRequestDraft(ctx context.Context, h db.Handler, repoID int64, id int64, draft bool) error
 	// UpdateMergeRequest updates a merge request.
 	UpdateMergeRequest(ctx context.Context, h db.Handler, repoID int64, id int64, title string, description string) error
+	// SetMergeRequestTargetBranch changes the branch a merge request merges
+	// into.
+	SetMergeRequestTargetBranch(ctx context.Context, h db.Handler, repoID int64, id int64, targetBranch string) error
 	// MergeMergeRequest marks a merge request as merged.
 	MergeMergeRequest(ctx context.Context, h db.Handler, repoID int64, id int64, mergedBy int64) error
 	// CloseMergeRequest marks a merge request as closed.
 	CloseMergeRequest(ctx context.Context, h db.Handler, repoID int64, id int64, closedBy int64) error
 	// ReopenMergeRequest reopens a closed merge request.
 	ReopenMergeRequest(ctx context.Context, h db.Handler, repoID int64, id int64) error
+	// SetMergeRequestReviewed records the first review of a merge request, the
+	// first action on it by a user other than its author. Later reviews are
+	// ignored.
+	SetMergeRequestReviewed(ctx context.Context, h db.Handler, repoID int64, id int64, userID int64) error
+	// SetMergeRequestStale marks an open merge request as stale, or clears the
+	// mark. It does not count as activity on the merge request.
+	SetMergeRequestStale(ctx context.Context, h db.Handler, repoID int64, id int64, stale bool) error
+	// CloseStaleMergeRequest closes a stale merge request without a closing
+	// user.
+	CloseStaleMergeRequest(ctx context.Context, h db.Handler, repoID int64, id int64) error
+	// SetMergeRequestHidden hides or shows the description of a merge
+	// request, see models.MergeRequest.Hidden.
+	SetMergeRequestHidden(ctx context.Context, h db.Handler, repoID int64, id int64, hidden bool) error
+	// SetMergeRequestEditedBy records who last edited a merge request, see
+	// models.MergeRequest.EditedByID.
+	SetMergeRequestEditedBy(ctx context.Context, h db.Handler, repoID int64, id int64, userID int64) error
 	// DeleteMergeRequest deletes a merge request by its ID.
 	DeleteMergeRequest(ctx context.Context, h db.Handler, repoID int64, id int64) error
+
+	// AddMergeRequestReviewer requests a review of a merge request from a user.
+	AddMergeRequestReviewer(ctx context.Context, h db.Handler, repoID int64, mrID int64, userID int64) error
+	// RemoveMergeRequestReviewer removes a review request from a merge request.
+	RemoveMergeRequestReviewer(ctx context.Context, h db.Handler, repoID int64, mrID int64, userID int64) error
+	// GetMergeRequestReviewers returns the users requested to review a merge request.
+	GetMergeRequestReviewers(ctx context.Context, h db.Handler, repoID int64, mrID int64) ([]models.User, error)
+	// GetOpenMergeRequestsByReviewerID returns the open merge requests a user
+	// is requested to review, oldest first.
+	GetOpenMergeRequestsByReviewerID(ctx context.Context, h db.Handler, userID int64) ([]models.MergeRequest, error)
+
+	// AddMergeRequestDependency creates a dependency relationship where mrID depends on dependsOnID.
+	AddMergeRequestDependency(ctx context.Context, h db.Handler, repoID int64, mrID int64, dependsOnID int64) error
+	// RemoveMergeRequestDependency removes a dependency relationship.
+	RemoveMergeRequestDependency(ctx context.Context, h db.Handler, repoID int64, mrID int64, dependsOnID int64) error
+	// GetMergeRequestDependencies returns all merge requests that the given merge request depends on.
+	GetMergeRequestDependencies(ctx context.Context, h db.Handler, repoID int64, mrID int64) ([]models.MergeRequest, error)
+	// GetMergeRequestDependents returns all merge requests that depend on the given merge request.
+	GetMergeRequestDependents(ctx context.Context, h db.Handler, repoID int64, mrID int64) ([]models.MergeRequest, error)
+	// GetMergeRequestDependenciesByRepoID returns the dependency relationships
+	// between the merge requests of a repository.
+	GetMergeRequestDependenciesByRepoID(ctx context.Context, h db.Handler, repoID int64) ([]models.MergeRequestDependency, error)
 }
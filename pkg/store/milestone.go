@@ -0,0 +1,49 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+)
+
+// MilestoneStore is an interface for managing the milestones of repositories.
+type MilestoneStore interface {
+	// GetMilestonesByRepoID returns the milestones of a repository with their
+	// progress, by due date.
+	GetMilestonesByRepoID(ctx context.Context, h db.Handler, repoID int64) ([]models.MilestoneProgress, error)
+	// GetMilestoneByTitle returns a milestone of a repository with its
+	// progress by its title.
+	GetMilestoneByTitle(ctx context.Context, h db.Handler, repoID int64, title string) (models.MilestoneProgress, error)
+	// CreateMilestone creates a milestone.
+	CreateMilestone(ctx context.Context, h db.Handler, repoID int64, title string, description string, dueDate sql.NullTime) error
+	// UpdateMilestone changes the title, description, and due date of a
+	// milestone.
+	UpdateMilestone(ctx context.Context, h db.Handler, repoID int64, id int64, title string, description string, dueDate sql.NullTime) error
+	// SetMilestoneState opens or closes a milestone.
+	SetMilestoneState(ctx context.Context, h db.Handler, repoID int64, id int64, state models.MilestoneState) error
+	// DeleteMilestone deletes a milestone, and detaches it from the issues and
+	// merge requests.
+	DeleteMilestone(ctx context.Context, h db.Handler, repoID int64, id int64) error
+
+	// SetIssueMilestone attaches an issue to a milestone, replacing its
+	// previous milestone.
+	SetIssueMilestone(ctx context.Context, h db.Handler, repoID int64, issueID int64, milestoneID int64) error
+	// RemoveIssueMilestone detaches an issue from its milestone.
+	RemoveIssueMilestone(ctx context.Context, h db.Handler, repoID int64, issueID int64) error
+	// GetIssueMilestone returns the milestone of an issue.
+	GetIssueMilestone(ctx context.Context, h db.Handler, repoID int64, issueID int64) (models.Milestone, error)
+	// GetMilestoneIssues returns the issues of a milestone.
+	GetMilestoneIssues(ctx context.Context, h db.Handler, repoID int64, milestoneID int64) ([]models.Issue, error)
+
+	// SetMergeRequestMilestone attaches a merge request to a milestone,
+	// replacing its previous milestone.
+	SetMergeRequestMilestone(ctx context.Context, h db.Handler, repoID int64, mrID int64, milestoneID int64) error
+	// RemoveMergeRequestMilestone detaches a merge request from its milestone.
+	RemoveMergeRequestMilestone(ctx context.Context, h db.Handler, repoID int64, mrID int64) error
+	// GetMergeRequestMilestone returns the milestone of a merge request.
+	GetMergeRequestMilestone(ctx context.Context, h db.Handler, repoID int64, mrID int64) (models.Milestone, error)
+	// GetMilestoneMergeRequests returns the merge requests of a milestone.
+	GetMilestoneMergeRequests(ctx context.Context, h db.Handler, repoID int64, milestoneID int64) ([]models.MergeRequest, error)
+}
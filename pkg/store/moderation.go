@@ -0,0 +1,36 @@
+package store
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+)
+
+// ModerationStore is an interface for managing the users blocked from
+// repositories and the reports of abusive content.
+type ModerationStore interface {
+	// AddRepoBlock blocks a user from interacting with a repository.
+	AddRepoBlock(ctx context.Context, h db.Handler, repoID int64, userID int64) error
+	// RemoveRepoBlock unblocks a user blocked from a repository.
+	RemoveRepoBlock(ctx context.Context, h db.Handler, repoID int64, userID int64) error
+	// GetRepoBlockedUsers returns the users blocked from a repository,
+	// sorted by username.
+	GetRepoBlockedUsers(ctx context.Context, h db.Handler, repoID int64) ([]models.User, error)
+	// IsUserBlockedFromRepo returns whether a user is blocked from a
+	// repository.
+	IsUserBlockedFromRepo(ctx context.Context, h db.Handler, repoID int64, userID int64) (bool, error)
+
+	// CreateContentReport adds a report to the moderation queue and returns
+	// its ID.
+	CreateContentReport(ctx context.Context, h db.Handler, r models.ContentReport) (int64, error)
+	// GetContentReport returns a report by its ID.
+	GetContentReport(ctx context.Context, h db.Handler, id int64) (models.ContentReport, error)
+	// GetOpenContentReports returns the unresolved reports, oldest first.
+	GetOpenContentReports(ctx context.Context, h db.Handler) ([]models.ContentReport, error)
+	// ResolveContentReport resolves a report.
+	ResolveContentReport(ctx context.Context, h db.Handler, id int64, resolvedBy int64) error
+	// ResolveContentReportsBySubject resolves the open reports of an issue or
+	// merge request.
+	ResolveContentReportsBySubject(ctx context.Context, h db.Handler, repoID int64, subjectType string, subjectID int64, resolvedBy int64) error
+}
@@ -0,0 +1,35 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+)
+
+// OAuthStore is an interface for managing OAuth applications and their
+// authorization codes.
+type OAuthStore interface {
+	CreateOAuthApp(ctx context.Context, h db.Handler, name string, clientID string, clientSecret string, redirectURI string) (models.OAuthApp, error)
+	GetOAuthApps(ctx context.Context, h db.Handler) ([]models.OAuthApp, error)
+	GetOAuthAppByID(ctx context.Context, h db.Handler, id int64) (models.OAuthApp, error)
+	GetOAuthAppByName(ctx context.Context, h db.Handler, name string) (models.OAuthApp, error)
+	GetOAuthAppByClientID(ctx context.Context, h db.Handler, clientID string) (models.OAuthApp, error)
+	// DeleteOAuthApp deletes an application along with its authorization
+	// codes and access tokens.
+	DeleteOAuthApp(ctx context.Context, h db.Handler, id int64) error
+
+	CreateOAuthCode(ctx context.Context, h db.Handler, code models.OAuthCode) error
+	GetOAuthCode(ctx context.Context, h db.Handler, code string) (models.OAuthCode, error)
+	// DeleteOAuthCode deletes an authorization code and returns the number
+	// of codes deleted, 0 when another exchange already used it.
+	DeleteOAuthCode(ctx context.Context, h db.Handler, id int64) (int64, error)
+	// DeleteExpiredOAuthCodes deletes the authorization codes that expired
+	// before the given time.
+	DeleteExpiredOAuthCodes(ctx context.Context, h db.Handler, before time.Time) error
+
+	// SetAccessTokenOAuthApp marks an access token as issued to an
+	// application.
+	SetAccessTokenOAuthApp(ctx context.Context, h db.Handler, tokenID int64, appID int64) error
+}
@@ -0,0 +1,21 @@
+package store
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+)
+
+// ProtectedBranchStore is an interface for managing the protected branches of
+// repositories.
+type ProtectedBranchStore interface {
+	// GetProtectedBranchesByRepoID returns the protected branches of a
+	// repository.
+	GetProtectedBranchesByRepoID(ctx context.Context, h db.Handler, repoID int64) ([]models.ProtectedBranch, error)
+	// SetProtectedBranch protects the branches matching pattern, or updates
+	// the protection of an existing pattern.
+	SetProtectedBranch(ctx context.Context, h db.Handler, repoID int64, pattern string, requireLease bool) error
+	// DeleteProtectedBranch removes the protection of pattern.
+	DeleteProtectedBranch(ctx context.Context, h db.Handler, repoID int64, pattern string) error
+}
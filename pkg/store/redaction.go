@@ -0,0 +1,18 @@
+package store
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+)
+
+// RedactionStore is an interface for rewriting leaked secrets out of the
+// records of a repository.
+type RedactionStore interface {
+	// RedactRepoText replaces old with new in the titles and descriptions of
+	// the issues and merge requests of a repository, in the merge request
+	// comments and the diff snippets they quote, and in the records
+	// copying them: notifications, activities, events, and webhook
+	// deliveries. It returns the number of records changed.
+	RedactRepoText(ctx context.Context, h db.Handler, repoID int64, old string, new string) (int64, error)
+}
@@ -0,0 +1,23 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+)
+
+// RefLogStore is an interface for managing the server-side reflogs of
+// repositories.
+type RefLogStore interface {
+	// CreateRefUpdate adds an entry to the reflog of a repository, and
+	// returns its id.
+	CreateRefUpdate(ctx context.Context, h db.Handler, u models.RefUpdate) (int64, error)
+	// GetRefUpdatesByRepoID returns the latest entries of the reflog of a
+	// repository, newest first. An empty ref returns the entries of all refs.
+	GetRefUpdatesByRepoID(ctx context.Context, h db.Handler, repoID int64, ref string, limit int) ([]models.RefUpdate, error)
+	// DeleteRefUpdatesBefore deletes the reflog entries older than t, and
+	// returns how many were deleted.
+	DeleteRefUpdatesBefore(ctx context.Context, h db.Handler, t time.Time) (int64, error)
+}
@@ -22,7 +22,31 @@ type RepositoryStore interface {
 	SetRepoDescriptionByName(ctx context.Context, h db.Handler, name string, description string) error
 	GetRepoIsPrivateByName(ctx context.Context, h db.Handler, name string) (bool, error)
 	SetRepoIsPrivateByName(ctx context.Context, h db.Handler, name string, isPrivate bool) error
+	GetRepoIsInternalByName(ctx context.Context, h db.Handler, name string) (bool, error)
+	SetRepoIsInternalByName(ctx context.Context, h db.Handler, name string, isInternal bool) error
 	GetRepoIsHiddenByName(ctx context.Context, h db.Handler, name string) (bool, error)
 	SetRepoIsHiddenByName(ctx context.Context, h db.Handler, name string, isHidden bool) error
+	GetRepoIsStaleExemptByName(ctx context.Context, h db.Handler, name string) (bool, error)
+	SetRepoIsStaleExemptByName(ctx context.Context, h db.Handler, name string, isStaleExempt bool) error
+	GetRepoIsMergeQueueByName(ctx context.Context, h db.Handler, name string) (bool, error)
+	SetRepoIsMergeQueueByName(ctx context.Context, h db.Handler, name string, isMergeQueue bool) error
+	GetRepoIsIssuesDisabledByName(ctx context.Context, h db.Handler, name string) (bool, error)
+	SetRepoIsIssuesDisabledByName(ctx context.Context, h db.Handler, name string, isIssuesDisabled bool) error
+	GetRepoIsMergeRequestsDisabledByName(ctx context.Context, h db.Handler, name string) (bool, error)
+	SetRepoIsMergeRequestsDisabledByName(ctx context.Context, h db.Handler, name string, isMergeRequestsDisabled bool) error
+	GetRepoIsSearchDisabledByName(ctx context.Context, h db.Handler, name string) (bool, error)
+	SetRepoIsSearchDisabledByName(ctx context.Context, h db.Handler, name string, isSearchDisabled bool) error
+	GetRepoIsNewsDisabledByName(ctx context.Context, h db.Handler, name string) (bool, error)
+	SetRepoIsNewsDisabledByName(ctx context.Context, h db.Handler, name string, isNewsDisabled bool) error
+	GetRepoIssuePrefixByName(ctx context.Context, h db.Handler, name string) (string, error)
+	SetRepoIssuePrefixByName(ctx context.Context, h db.Handler, name string, prefix string) error
+	GetRepoEditPolicyByName(ctx context.Context, h db.Handler, name string) (string, error)
+	SetRepoEditPolicyByName(ctx context.Context, h db.Handler, name string, policy string) error
+	GetRepoDependencyPolicyByName(ctx context.Context, h db.Handler, name string) (string, error)
+	SetRepoDependencyPolicyByName(ctx context.Context, h db.Handler, name string, policy string) error
+	GetRepoRequiredApprovalsByName(ctx context.Context, h db.Handler, name string) (int, error)
+	SetRepoRequiredApprovalsByName(ctx context.Context, h db.Handler, name string, n int) error
 	GetRepoIsMirrorByName(ctx context.Context, h db.Handler, name string) (bool, error)
+	SetRepoStorageByName(ctx context.Context, h db.Handler, name string, volume string, path string) error
+	CountReposByStorageVolume(ctx context.Context, h db.Handler) (map[string]int64, error)
 }
@@ -0,0 +1,24 @@
+package store
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+)
+
+// RepoEventStore is an interface for managing the recorded events of
+// repositories.
+type RepoEventStore interface {
+	// CreateRepoEvent records an event of a repository and returns its ID.
+	CreateRepoEvent(ctx context.Context, h db.Handler, repoID int64, event int, payload string) (int64, error)
+	// GetRepoEventsAfterID returns up to limit events of a repository with an
+	// ID greater than afterID, oldest first.
+	GetRepoEventsAfterID(ctx context.Context, h db.Handler, repoID int64, afterID int64, limit int) ([]models.RepoEvent, error)
+	// GetLatestRepoEventID returns the ID of the latest event of a repository,
+	// zero if it has none.
+	GetLatestRepoEventID(ctx context.Context, h db.Handler, repoID int64) (int64, error)
+	// PruneRepoEvents deletes the events of a repository but the latest keep
+	// ones.
+	PruneRepoEvents(ctx context.Context, h db.Handler, repoID int64, keep int) error
+}
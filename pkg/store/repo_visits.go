@@ -0,0 +1,20 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+)
+
+// RepoVisitStore is an interface for managing the repositories users
+// recently visited.
+type RepoVisitStore interface {
+	// SetRepoVisit records a visit of a user to a repository at a time,
+	// replacing their previous one.
+	SetRepoVisit(ctx context.Context, h db.Handler, repoID int64, userID int64, at time.Time) error
+	// GetRepoVisitsByUserID returns the last limit repositories a user
+	// visited, most recent first.
+	GetRepoVisitsByUserID(ctx context.Context, h db.Handler, userID int64, limit int) ([]models.RepoVisit, error)
+}
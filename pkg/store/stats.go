@@ -0,0 +1,20 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+)
+
+// StatsStore is an interface for gathering the statistics of the instance.
+type StatsStore interface {
+	// GetInstanceCounts returns the numbers of repositories, users, issues,
+	// and merge requests created since a time and before another. Zero times
+	// leave the range open.
+	GetInstanceCounts(ctx context.Context, h db.Handler, since time.Time, until time.Time) (models.InstanceCounts, error)
+	// GetRepoActivityCounts returns the number of activities of the busiest
+	// repositories since a time, busiest first.
+	GetRepoActivityCounts(ctx context.Context, h db.Handler, since time.Time, limit int) ([]models.ActivityCount, error)
+}
@@ -11,4 +11,35 @@ type Store interface {
 	WebhookStore
 	MergeRequestStore
 	IssueStore
+	LargeTextStore
+	IntegrationStore
+	ChatIdentityStore
+	IssueTrackerStore
+	IssueSLAStore
+	SwitcherStore
+	UserPreferenceStore
+	MergeQueueStore
+	WatchStore
+	ActivityStore
+	AuditStore
+	RefLogStore
+	ProtectedBranchStore
+	CommitRulesStore
+	ContributionAgreementStore
+	LabelStore
+	RepoEventStore
+	MilestoneStore
+	CommitReferenceStore
+	ConsistencyStore
+	OAuthStore
+	AttachmentStore
+	CrossReferenceStore
+	ModerationStore
+	RedactionStore
+	RepoVisitStore
+	StatsStore
+	AccountStore
+	IdempotencyKeyStore
+	MergeRequestCommentStore
+	MergeRequestApprovalStore
 }
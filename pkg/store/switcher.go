@@ -0,0 +1,16 @@
+package store
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+)
+
+// SwitcherStore is an interface for the quick switcher search.
+type SwitcherStore interface {
+	// SearchSwitcherItems returns the issues and merge requests of the given
+	// repositories whose title contains query, or whose number is id when
+	// id is greater than zero, most recently updated first.
+	SearchSwitcherItems(ctx context.Context, h db.Handler, repoIDs []int64, query string, id int64, limit int) ([]models.SwitcherItem, error)
+}
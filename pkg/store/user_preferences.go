@@ -0,0 +1,16 @@
+package store
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+)
+
+// UserPreferenceStore is an interface for managing the preferences of users.
+type UserPreferenceStore interface {
+	// GetUserPreferencesByUserID returns the preferences of a user.
+	GetUserPreferencesByUserID(ctx context.Context, h db.Handler, userID int64) (models.UserPreferences, error)
+	// SetUserPreferences creates or replaces the preferences of a user.
+	SetUserPreferences(ctx context.Context, h db.Handler, prefs models.UserPreferences) error
+}
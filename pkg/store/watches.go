@@ -0,0 +1,40 @@
+package store
+
+import (
+	"context"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+)
+
+// WatchStore is an interface for managing repository watches and the
+// notifications they produce.
+type WatchStore interface {
+	// GetRepoWatch returns the watch of a user on a repository.
+	GetRepoWatch(ctx context.Context, h db.Handler, repoID int64, userID int64) (models.RepoWatch, error)
+	// GetRepoWatchesByRepoID returns the watches on a repository.
+	GetRepoWatchesByRepoID(ctx context.Context, h db.Handler, repoID int64) ([]models.RepoWatch, error)
+	// GetRepoWatchesByUserID returns the watches of a user.
+	GetRepoWatchesByUserID(ctx context.Context, h db.Handler, userID int64) ([]models.RepoWatch, error)
+	// SetRepoWatch creates or updates the watch of a user on a repository.
+	SetRepoWatch(ctx context.Context, h db.Handler, repoID int64, userID int64, level models.WatchLevel) error
+
+	// GetSubscriptions returns the subscriptions to an issue or a merge
+	// request, see models.Subscription.
+	GetSubscriptions(ctx context.Context, h db.Handler, repoID int64, subjectType string, subjectID int64) ([]models.Subscription, error)
+	// SetSubscription subscribes a user to an issue or a merge request, or
+	// unsubscribes them.
+	SetSubscription(ctx context.Context, h db.Handler, repoID int64, subjectType string, subjectID int64, userID int64, subscribed bool) error
+	// AddSubscription subscribes a user to an issue or a merge request
+	// unless they already chose whether to be.
+	AddSubscription(ctx context.Context, h db.Handler, repoID int64, subjectType string, subjectID int64, userID int64) error
+
+	// CreateNotification creates a notification.
+	CreateNotification(ctx context.Context, h db.Handler, n models.Notification) error
+	// GetNotificationsByUserID returns the notifications of a user, newest
+	// first. If unreadOnly is true, read notifications are left out.
+	GetNotificationsByUserID(ctx context.Context, h db.Handler, userID int64, unreadOnly bool) ([]models.Notification, error)
+	// MarkNotificationsRead marks notifications of a user as read. If ids is
+	// empty, all the notifications of the user are marked.
+	MarkNotificationsRead(ctx context.Context, h db.Handler, userID int64, ids []int64) error
+}
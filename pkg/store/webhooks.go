@@ -2,6 +2,7 @@ package store
 
 import (
 	"context"
+	"time"
 
 	"github.com/charmbracelet/soft-serve/pkg/db"
 	"github.com/charmbracelet/soft-serve/pkg/db/models"
@@ -12,18 +13,30 @@ import (
 type WebhookStore interface {
 	// GetWebhookByID returns a webhook by its ID.
 	GetWebhookByID(ctx context.Context, h db.Handler, repoID int64, id int64) (models.Webhook, error)
+	// GetWebhookByExternalID returns a webhook by its external ID.
+	GetWebhookByExternalID(ctx context.Context, h db.Handler, repoID int64, externalID string) (models.Webhook, error)
+	// SetWebhookExternalID sets the external ID of a webhook.
+	SetWebhookExternalID(ctx context.Context, h db.Handler, repoID int64, id int64, externalID string) error
 	// GetWebhooksByRepoID returns all webhooks for a repository.
 	GetWebhooksByRepoID(ctx context.Context, h db.Handler, repoID int64) ([]models.Webhook, error)
 	// GetWebhooksByRepoIDWhereEvent returns all webhooks for a repository where event is in the events.
 	GetWebhooksByRepoIDWhereEvent(ctx context.Context, h db.Handler, repoID int64, events []int) ([]models.Webhook, error)
 	// CreateWebhook creates a webhook.
-	CreateWebhook(ctx context.Context, h db.Handler, repoID int64, url string, secret string, contentType int, active bool) (int64, error)
+	CreateWebhook(ctx context.Context, h db.Handler, repoID int64, url string, secret string, contentType int, format int, active bool) (int64, error)
 	// UpdateWebhookByID updates a webhook by its ID.
-	UpdateWebhookByID(ctx context.Context, h db.Handler, repoID int64, id int64, url string, secret string, contentType int, active bool) error
+	UpdateWebhookByID(ctx context.Context, h db.Handler, repoID int64, id int64, url string, secret string, contentType int, format int, active bool) error
 	// DeleteWebhookByID deletes a webhook by its ID.
 	DeleteWebhookByID(ctx context.Context, h db.Handler, id int64) error
 	// DeleteWebhookForRepoByID deletes a webhook for a repository by its ID.
 	DeleteWebhookForRepoByID(ctx context.Context, h db.Handler, repoID int64, id int64) error
+	// SetWebhookDeliveryResult counts a failed delivery of a webhook, or
+	// resets its failures when ok is true.
+	SetWebhookDeliveryResult(ctx context.Context, h db.Handler, id int64, ok bool) error
+	// GetFailingWebhooksToNotify returns the active webhooks with at least
+	// threshold consecutive failed deliveries that haven't been notified about.
+	GetFailingWebhooksToNotify(ctx context.Context, h db.Handler, threshold int) ([]models.Webhook, error)
+	// SetWebhookFailureNotified marks the failures of a webhook as notified.
+	SetWebhookFailureNotified(ctx context.Context, h db.Handler, id int64) error
 
 	// GetWebhookEventByID returns a webhook event by its ID.
 	GetWebhookEventByID(ctx context.Context, h db.Handler, id int64) (models.WebhookEvent, error)
@@ -39,10 +52,14 @@ type WebhookStore interface {
 	// GetWebhookDeliveriesByWebhookID returns all webhook deliveries for a webhook.
 	GetWebhookDeliveriesByWebhookID(ctx context.Context, h db.Handler, webhookID int64) ([]models.WebhookDelivery, error)
 	// ListWebhookDeliveriesByWebhookID returns all webhook deliveries for a webhook.
-	// This only returns the delivery ID, response status, and event.
+	// This only returns the delivery ID, response status, event, and creation
+	// time, newest first.
 	ListWebhookDeliveriesByWebhookID(ctx context.Context, h db.Handler, webhookID int64) ([]models.WebhookDelivery, error)
 	// CreateWebhookDelivery creates a webhook delivery.
 	CreateWebhookDelivery(ctx context.Context, h db.Handler, id uuid.UUID, webhookID int64, event int, url string, method string, requestError error, requestHeaders string, requestBody string, responseStatus int, responseHeaders string, responseBody string) error
 	// DeleteWebhookDeliveryByID deletes a webhook delivery by its ID.
 	DeleteWebhookDeliveryByID(ctx context.Context, h db.Handler, webhookID int64, id uuid.UUID) error
+	// DeleteWebhookDeliveriesBefore deletes the webhook deliveries older than
+	// t, and returns how many were deleted.
+	DeleteWebhookDeliveriesBefore(ctx context.Context, h db.Handler, t time.Time) (int64, error)
 }
@@ -75,6 +75,16 @@ func (m *Manager) Exists(id string) bool {
 	return ok
 }
 
+// Len returns the number of tasks the manager holds, pending or running.
+func (m *Manager) Len() int {
+	var n int
+	m.m.Range(func(any, any) bool {
+		n++
+		return true
+	})
+	return n
+}
+
 // Run starts the task if it exists.
 // Otherwise, it waits for the process to finish.
 func (m *Manager) Run(id string, done chan<- error) {
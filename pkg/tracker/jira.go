@@ -0,0 +1,59 @@
+package tracker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// jiraTransitions is the response of the Jira get transitions API.
+type jiraTransitions struct {
+	Transitions []struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+		To   struct {
+			Name string `json:"name"`
+		} `json:"to"`
+	} `json:"transitions"`
+}
+
+// jiraTransition moves a Jira issue to state using the first available
+// transition named state or leading to state.
+// See https://developer.atlassian.com/cloud/jira/platform/rest/v2/api-group-issues/#api-rest-api-2-issue-issueidorkey-transitions-post
+func (t Tracker) jiraTransition(ctx context.Context, key string, state string) error {
+	path := "/rest/api/2/issue/" + url.PathEscape(key) + "/transitions"
+	b, err := t.do(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return err
+	}
+
+	var ts jiraTransitions
+	if err := json.Unmarshal(b, &ts); err != nil {
+		return err
+	}
+
+	var id string
+	for _, tr := range ts.Transitions {
+		if strings.EqualFold(tr.To.Name, state) || strings.EqualFold(tr.Name, state) {
+			id = tr.ID
+			break
+		}
+	}
+	if id == "" {
+		return fmt.Errorf("%s: no transition to %q", key, state)
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"transition": map[string]string{"id": id},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = t.do(ctx, http.MethodPost, path, bytes.NewReader(body))
+	return err
+}
@@ -0,0 +1,236 @@
+// Package tracker links repositories to external issue trackers like Jira
+// and YouTrack.
+package tracker
+
+import (
+	"context"
+	"encoding"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/soft-serve/pkg/version"
+	"github.com/charmbracelet/soft-serve/pkg/webhook"
+)
+
+// Kind is the kind of an external issue tracker.
+type Kind int8
+
+const (
+	// KindJira is a Jira Cloud or Jira Server instance.
+	KindJira Kind = iota
+	// KindYouTrack is a YouTrack instance.
+	KindYouTrack
+)
+
+var kindStrings = map[Kind]string{
+	KindJira:     "jira",
+	KindYouTrack: "youtrack",
+}
+
+// String returns the string representation of the tracker kind.
+func (k Kind) String() string {
+	return kindStrings[k]
+}
+
+// Kinds returns all tracker kinds.
+func Kinds() []Kind {
+	return []Kind{
+		KindJira,
+		KindYouTrack,
+	}
+}
+
+// ErrInvalidKind is returned when the tracker kind is invalid.
+var ErrInvalidKind = errors.New("invalid issue tracker kind")
+
+// ParseKind parses a tracker kind string and returns the tracker kind.
+func ParseKind(s string) (Kind, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	for k, v := range kindStrings {
+		if v == s {
+			return k, nil
+		}
+	}
+
+	return -1, ErrInvalidKind
+}
+
+var (
+	_ encoding.TextMarshaler   = Kind(0)
+	_ encoding.TextUnmarshaler = (*Kind)(nil)
+)
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (k *Kind) UnmarshalText(text []byte) error {
+	kind, err := ParseKind(string(text))
+	if err != nil {
+		return err
+	}
+
+	*k = kind
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (k Kind) MarshalText() (text []byte, err error) {
+	kind := k.String()
+	if kind == "" {
+		return nil, ErrInvalidKind
+	}
+
+	return []byte(kind), nil
+}
+
+// DefaultDoneState is the state linked issues are moved to when a merge
+// request merges.
+const DefaultDoneState = "Done"
+
+// projectKeyRe matches valid tracker project keys.
+var projectKeyRe = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
+
+// issueKeyRe matches issue keys like PROJ-123.
+var issueKeyRe = regexp.MustCompile(`\b([A-Z][A-Z0-9_]*)-([1-9][0-9]*)\b`)
+
+// ErrInvalidProjectKey is returned when a project key is invalid.
+var ErrInvalidProjectKey = errors.New("invalid project key, must be uppercase letters, digits, and underscores")
+
+// ParseProjects parses a comma separated list of project keys.
+func ParseProjects(s string) ([]string, error) {
+	var projects []string
+	for _, p := range strings.Split(s, ",") {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		if !projectKeyRe.MatchString(p) {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidProjectKey, p)
+		}
+		projects = append(projects, p)
+	}
+
+	return projects, nil
+}
+
+// Tracker is an external issue tracker linked to a repository.
+type Tracker struct {
+	Kind Kind
+	// URL is the base URL of the tracker, e.g. https://example.atlassian.net.
+	URL string
+	// Projects are the tracker project keys linked to the repository. Only
+	// issue keys of these projects are recognized.
+	Projects []string
+	// Username is the account name used with Token for basic authentication.
+	// When empty, Token is sent as a bearer token.
+	Username string
+	Token    string
+	// DoneState is the state linked issues are moved to when a merge request
+	// merges. Empty disables transitions.
+	DoneState string
+}
+
+// Link is an issue key linked to an external tracker issue.
+type Link struct {
+	Key string
+	URL string
+}
+
+// Keys returns the distinct issue keys of the tracker projects mentioned in
+// texts, in order of appearance.
+func (t Tracker) Keys(texts ...string) []string {
+	projects := make(map[string]bool, len(t.Projects))
+	for _, p := range t.Projects {
+		projects[p] = true
+	}
+
+	var keys []string
+	seen := map[string]bool{}
+	for _, text := range texts {
+		for _, m := range issueKeyRe.FindAllStringSubmatch(text, -1) {
+			if !projects[m[1]] || seen[m[0]] {
+				continue
+			}
+			seen[m[0]] = true
+			keys = append(keys, m[0])
+		}
+	}
+
+	return keys
+}
+
+// IssueURL returns the web URL of an issue.
+func (t Tracker) IssueURL(key string) string {
+	base := strings.TrimSuffix(t.URL, "/")
+	switch t.Kind {
+	case KindYouTrack:
+		return base + "/issue/" + key
+	default:
+		return base + "/browse/" + key
+	}
+}
+
+// Links returns the links of the issue keys mentioned in texts.
+func (t Tracker) Links(texts ...string) []Link {
+	keys := t.Keys(texts...)
+	links := make([]Link, len(keys))
+	for i, k := range keys {
+		links[i] = Link{Key: k, URL: t.IssueURL(k)}
+	}
+
+	return links
+}
+
+// Transition moves an issue to the given state.
+func (t Tracker) Transition(ctx context.Context, key string, state string) error {
+	switch t.Kind {
+	case KindJira:
+		return t.jiraTransition(ctx, key, state)
+	case KindYouTrack:
+		return t.youTrackTransition(ctx, key, state)
+	default:
+		return ErrInvalidKind
+	}
+}
+
+// httpClient is the client used to talk to trackers.
+var httpClient = webhook.SecureHTTPClient()
+
+// do sends a request to the tracker and returns the response body. Non 2xx
+// responses are returned as errors.
+func (t Tracker) do(ctx context.Context, method string, path string, body io.Reader) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(t.URL, "/")+path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "SoftServe/"+version.Version)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if t.Username != "" {
+		req.SetBasicAuth(t.Username, t.Token)
+	} else if t.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+t.Token)
+	}
+
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close() // nolint: errcheck
+
+	b, err := io.ReadAll(io.LimitReader(res.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return nil, fmt.Errorf("%s %s: unexpected status %s", method, path, res.Status)
+	}
+
+	return b, nil
+}
@@ -0,0 +1,138 @@
+package tracker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestParseProjects(t *testing.T) {
+	got, err := ParseProjects(" PROJ, OPS_2 ,,")
+	if err != nil {
+		t.Fatalf("ParseProjects: %v", err)
+	}
+	if want := []string{"PROJ", "OPS_2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseProjects = %v, want %v", got, want)
+	}
+
+	if _, err := ParseProjects("proj"); !errors.Is(err, ErrInvalidProjectKey) {
+		t.Errorf("ParseProjects(proj) error = %v, want %v", err, ErrInvalidProjectKey)
+	}
+}
+
+func TestKeys(t *testing.T) {
+	tr := Tracker{Projects: []string{"PROJ", "OPS"}}
+	got := tr.Keys(
+		"Fix PROJ-12 and OPS-3, not UTF-8 or PROJ-0",
+		"Also PROJ-12 again and XPROJ-4",
+		"feature/PROJ-7-login",
+	)
+	want := []string{"PROJ-12", "OPS-3", "PROJ-7"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Keys = %v, want %v", got, want)
+	}
+}
+
+func TestIssueURL(t *testing.T) {
+	cases := map[Kind]string{
+		KindJira:     "https://tracker.example.com/browse/PROJ-1",
+		KindYouTrack: "https://tracker.example.com/issue/PROJ-1",
+	}
+	for kind, want := range cases {
+		tr := Tracker{Kind: kind, URL: "https://tracker.example.com/"}
+		if got := tr.IssueURL("PROJ-1"); got != want {
+			t.Errorf("%s IssueURL = %q, want %q", kind, got, want)
+		}
+	}
+}
+
+// withTestServer points the tracker HTTP client at a local test server.
+func withTestServer(t *testing.T, h http.HandlerFunc) string {
+	t.Helper()
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+
+	orig := httpClient
+	httpClient = srv.Client()
+	t.Cleanup(func() { httpClient = orig })
+
+	return srv.URL
+}
+
+func TestJiraTransition(t *testing.T) {
+	var posted map[string]map[string]string
+	url := withTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/2/issue/PROJ-1/transitions" {
+			http.NotFound(w, r)
+			return
+		}
+		if u, p, ok := r.BasicAuth(); !ok || u != "me@example.com" || p != "token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			io.WriteString(w, `{"transitions":[
+				{"id":"11","name":"Start","to":{"name":"In Progress"}},
+				{"id":"31","name":"Resolve","to":{"name":"Done"}}
+			]}`) // nolint: errcheck
+		case http.MethodPost:
+			if err := json.NewDecoder(r.Body).Decode(&posted); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+
+	tr := Tracker{Kind: KindJira, URL: url, Username: "me@example.com", Token: "token"}
+	if err := tr.Transition(context.Background(), "PROJ-1", "done"); err != nil {
+		t.Fatalf("Transition: %v", err)
+	}
+	if id := posted["transition"]["id"]; id != "31" {
+		t.Errorf("transition id = %q, want %q", id, "31")
+	}
+
+	if err := tr.Transition(context.Background(), "PROJ-1", "Closed"); err == nil {
+		t.Error("expected error for unavailable transition")
+	}
+}
+
+func TestYouTrackTransition(t *testing.T) {
+	var body struct {
+		Query  string              `json:"query"`
+		Issues []map[string]string `json:"issues"`
+	}
+	url := withTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/commands" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer perm:token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		io.WriteString(w, `{}`) // nolint: errcheck
+	})
+
+	tr := Tracker{Kind: KindYouTrack, URL: url, Token: "perm:token"}
+	if err := tr.Transition(context.Background(), "PROJ-2", "In Review"); err != nil {
+		t.Fatalf("Transition: %v", err)
+	}
+	if body.Query != "State {In Review}" {
+		t.Errorf("query = %q, want %q", body.Query, "State {In Review}")
+	}
+	if len(body.Issues) != 1 || body.Issues[0]["idReadable"] != "PROJ-2" {
+		t.Errorf("issues = %v, want PROJ-2", body.Issues)
+	}
+}
@@ -0,0 +1,25 @@
+package tracker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+// youTrackTransition moves a YouTrack issue to state by applying a State
+// command.
+// See https://www.jetbrains.com/help/youtrack/devportal/resource-api-commands.html
+func (t Tracker) youTrackTransition(ctx context.Context, key string, state string) error {
+	body, err := json.Marshal(map[string]interface{}{
+		// Braces let the value contain spaces, e.g. {In Progress}.
+		"query":  "State {" + state + "}",
+		"issues": []map[string]string{{"idReadable": key}},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = t.do(ctx, http.MethodPost, "/api/commands", bytes.NewReader(body))
+	return err
+}
@@ -34,6 +34,8 @@ type Common struct {
 	Zone          *zone.Manager
 	Logger        *log.Logger
 	HideCloneCmd  bool
+	// Dates formats the dates shown to the user.
+	Dates backend.Dates
 }
 
 // NewCommon returns a new Common struct.
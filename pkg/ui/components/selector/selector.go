@@ -111,6 +111,27 @@ func (s *Selector) Select(index int) {
 	s.Model.Select(index)
 }
 
+// SelectID selects the item with the given ID. When no item has that ID, e.g.
+// because it's gone after the items were refetched, the item at index is
+// selected instead, or the last one if there are fewer items now. It reports
+// whether the item was found.
+func (s *Selector) SelectID(id string, index int) bool {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	items := s.Model.VisibleItems()
+	for i, item := range items {
+		if item, ok := item.(IdentifiableItem); ok && item.ID() == id {
+			s.Model.Select(i)
+			return true
+		}
+	}
+	if index >= len(items) {
+		index = len(items) - 1
+	}
+	s.Model.Select(max(index, 0))
+	return false
+}
+
 // SetShowTitle sets the show title flag.
 func (s *Selector) SetShowTitle(show bool) {
 	s.mtx.Lock()
@@ -18,6 +18,7 @@ type ActiveTabMsg int
 type Tabs struct {
 	common       common.Common
 	tabs         []string
+	hidden       map[int]bool
 	activeTab    int
 	TabSeparator lipgloss.Style
 	TabInactive  lipgloss.Style
@@ -31,6 +32,7 @@ func New(c common.Common, tabs []string) *Tabs {
 	r := &Tabs{
 		common:       c,
 		tabs:         tabs,
+		hidden:       make(map[int]bool),
 		activeTab:    0,
 		TabSeparator: c.Styles.TabSeparator,
 		TabInactive:  c.Styles.TabInactive,
@@ -44,6 +46,33 @@ func (t *Tabs) SetSize(width, height int) {
 	t.common.SetSize(width, height)
 }
 
+// SetHidden hides or shows the tab at the given index. Hidden tabs are
+// skipped when switching tabs.
+func (t *Tabs) SetHidden(tab int, hidden bool) {
+	if hidden {
+		t.hidden[tab] = true
+	} else {
+		delete(t.hidden, tab)
+	}
+}
+
+// IsHidden returns true if the tab at the given index is hidden.
+func (t *Tabs) IsHidden(tab int) bool {
+	return t.hidden[tab]
+}
+
+// step returns the index of the next visible tab in the given direction.
+func (t *Tabs) step(dir int) int {
+	tab := t.activeTab
+	for range t.tabs {
+		tab = (tab + dir + len(t.tabs)) % len(t.tabs)
+		if !t.hidden[tab] {
+			return tab
+		}
+	}
+	return t.activeTab
+}
+
 // Init implements tea.Model.
 func (t *Tabs) Init() tea.Cmd {
 	t.activeTab = 0
@@ -57,17 +86,17 @@ func (t *Tabs) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.KeyPressMsg:
 		switch msg.String() {
 		case "tab":
-			t.activeTab = (t.activeTab + 1) % len(t.tabs)
+			t.activeTab = t.step(1)
 			cmds = append(cmds, t.activeTabCmd)
 		case "shift+tab":
-			t.activeTab = (t.activeTab - 1 + len(t.tabs)) % len(t.tabs)
+			t.activeTab = t.step(-1)
 			cmds = append(cmds, t.activeTabCmd)
 		}
 	case tea.MouseClickMsg:
 		switch msg.Button {
 		case tea.MouseLeft:
 			for i, tab := range t.tabs {
-				if t.common.Zone.Get(tab).InBounds(msg) {
+				if !t.hidden[i] && t.common.Zone.Get(tab).InBounds(msg) {
 					t.activeTab = i
 					cmds = append(cmds, t.activeTabCmd)
 				}
@@ -75,7 +104,7 @@ func (t *Tabs) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 	case SelectTabMsg:
 		tab := int(msg)
-		if tab >= 0 && tab < len(t.tabs) {
+		if tab >= 0 && tab < len(t.tabs) && !t.hidden[tab] {
 			t.activeTab = int(msg)
 		}
 	}
@@ -86,7 +115,15 @@ func (t *Tabs) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 func (t *Tabs) View() string {
 	s := strings.Builder{}
 	sep := t.TabSeparator
+	first := true
 	for i, tab := range t.tabs {
+		if t.hidden[i] {
+			continue
+		}
+		if !first {
+			s.WriteString(sep.String())
+		}
+		first = false
 		style := t.TabInactive
 		prefix := "  "
 		if i == t.activeTab {
@@ -102,9 +139,6 @@ func (t *Tabs) View() string {
 				style.Render(tab),
 			),
 		)
-		if i != len(t.tabs)-1 {
-			s.WriteString(sep.String())
-		}
 	}
 	return lipgloss.NewStyle().
 		MaxWidth(t.common.Width).
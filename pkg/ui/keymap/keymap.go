@@ -23,6 +23,23 @@ type KeyMap struct {
 	BackItem   key.Binding
 
 	Copy key.Binding
+
+	QuickSwitch key.Binding
+
+	DiffWrap       key.Binding
+	DiffWhitespace key.Binding
+	DiffTabWidth   key.Binding
+
+	GotoMergeRequest key.Binding
+	GotoIssue        key.Binding
+
+	ToggleMark  key.Binding
+	CloseMarked key.Binding
+
+	SortList    key.Binding
+	ReverseSort key.Binding
+
+	RecentFirst key.Binding
 }
 
 // DefaultKeyMap returns the default key map.
@@ -226,5 +243,115 @@ func DefaultKeyMap() *KeyMap {
 		),
 	)
 
+	km.QuickSwitch = key.NewBinding(
+		key.WithKeys(
+			"ctrl+k",
+		),
+		key.WithHelp(
+			"ctrl+k",
+			"quick switch",
+		),
+	)
+
+	km.DiffWrap = key.NewBinding(
+		key.WithKeys(
+			"w",
+		),
+		key.WithHelp(
+			"w",
+			"toggle wrap",
+		),
+	)
+
+	km.DiffWhitespace = key.NewBinding(
+		key.WithKeys(
+			"i",
+		),
+		key.WithHelp(
+			"i",
+			"ignore whitespace",
+		),
+	)
+
+	km.DiffTabWidth = key.NewBinding(
+		key.WithKeys(
+			"t",
+		),
+		key.WithHelp(
+			"t",
+			"tab width",
+		),
+	)
+
+	km.GotoMergeRequest = key.NewBinding(
+		key.WithKeys(
+			"m",
+		),
+		key.WithHelp(
+			"m",
+			"merge request",
+		),
+	)
+
+	km.GotoIssue = key.NewBinding(
+		key.WithKeys(
+			"o",
+		),
+		key.WithHelp(
+			"o",
+			"issue",
+		),
+	)
+
+	km.ToggleMark = key.NewBinding(
+		key.WithKeys(
+			"space",
+		),
+		key.WithHelp(
+			"space",
+			"mark",
+		),
+	)
+
+	km.CloseMarked = key.NewBinding(
+		key.WithKeys(
+			"x",
+		),
+		key.WithHelp(
+			"x",
+			"close marked",
+		),
+	)
+
+	km.SortList = key.NewBinding(
+		key.WithKeys(
+			"s",
+		),
+		key.WithHelp(
+			"s",
+			"sort",
+		),
+	)
+
+	km.ReverseSort = key.NewBinding(
+		key.WithKeys(
+			"S",
+		),
+		key.WithHelp(
+			"S",
+			"reverse sort",
+		),
+	)
+
+	km.RecentFirst = key.NewBinding(
+		key.WithKeys(
+			"r",
+		),
+		key.WithHelp(
+			"r",
+			"recent first",
+		),
+	)
+
 	return km
 }
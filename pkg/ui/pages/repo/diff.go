@@ -0,0 +1,96 @@
+package repo
+
+import (
+	"github.com/charmbracelet/bubbles/v2/key"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/soft-serve/git"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/ui/common"
+)
+
+// diffTabWidths are the tab widths the tab width key cycles through.
+var diffTabWidths = []int{2, 4, 8}
+
+// DiffPrefsMsg is a message that contains the diff preferences of the user.
+type DiffPrefsMsg models.UserPreferences
+
+// currentUser returns the user of the session, if any.
+func currentUser(c common.Common) proto.User {
+	be := c.Backend()
+	pk := c.PublicKey()
+	if be == nil || pk == nil {
+		return nil
+	}
+	user, _ := be.UserByPublicKey(c.Context(), pk)
+	return user
+}
+
+// loadDiffPrefsCmd loads the diff preferences of the user.
+func loadDiffPrefsCmd(c common.Common) tea.Cmd {
+	return func() tea.Msg {
+		be := c.Backend()
+		if be == nil {
+			return DiffPrefsMsg(backend.DefaultUserPreferences())
+		}
+		prefs, err := be.UserPreferences(c.Context(), currentUser(c))
+		if err != nil {
+			c.Logger.Debugf("ui: failed to load user preferences: %v", err)
+		}
+		return DiffPrefsMsg(prefs)
+	}
+}
+
+// saveDiffPrefsCmd applies the diff preferences to every diff view and saves
+// them for the user. Anonymous users only keep them for the session.
+func saveDiffPrefsCmd(c common.Common, prefs models.UserPreferences) tea.Cmd {
+	return func() tea.Msg {
+		if user := currentUser(c); user != nil {
+			if err := c.Backend().SetUserPreferences(c.Context(), user, prefs); err != nil {
+				c.Logger.Debugf("ui: failed to save user preferences: %v", err)
+			}
+		}
+		return DiffPrefsMsg(prefs)
+	}
+}
+
+// updateDiffPrefsCmd changes the diff preferences matching the pressed key. It
+// returns nil if the key doesn't change any.
+func updateDiffPrefsCmd(c common.Common, prefs models.UserPreferences, msg tea.KeyPressMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, c.KeyMap.DiffWrap):
+		prefs.DiffWordWrap = !prefs.DiffWordWrap
+	case key.Matches(msg, c.KeyMap.DiffWhitespace):
+		prefs.DiffIgnoreWhitespace = !prefs.DiffIgnoreWhitespace
+	case key.Matches(msg, c.KeyMap.DiffTabWidth):
+		prefs.DiffTabWidth = nextDiffTabWidth(prefs.DiffTabWidth)
+	default:
+		return nil
+	}
+	return saveDiffPrefsCmd(c, prefs)
+}
+
+// nextDiffTabWidth returns the tab width following width.
+func nextDiffTabWidth(width int) int {
+	for _, w := range diffTabWidths {
+		if w > width {
+			return w
+		}
+	}
+	return diffTabWidths[0]
+}
+
+// diffOptions returns the options to compute diffs with.
+func diffOptions(prefs models.UserPreferences) git.DiffOptions {
+	return git.DiffOptions{IgnoreWhitespace: prefs.DiffIgnoreWhitespace}
+}
+
+// diffHelp returns the help of the diff preference keys.
+func diffHelp(c common.Common) []key.Binding {
+	return []key.Binding{
+		c.KeyMap.DiffWrap,
+		c.KeyMap.DiffWhitespace,
+		c.KeyMap.DiffTabWidth,
+	}
+}
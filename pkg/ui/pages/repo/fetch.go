@@ -0,0 +1,76 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/v2/key"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/soft-serve/pkg/ui/common"
+)
+
+// fetch tracks the fetch a tab is waiting for so it can be cancelled. Every
+// fetch gets an ID its messages carry: the messages of a cancelled or
+// superseded fetch are dropped.
+type fetch struct {
+	id     int
+	cancel context.CancelFunc
+}
+
+// start cancels the fetch in flight, if any, and starts a new one. It returns
+// the context to fetch with and the ID of the fetch.
+func (f *fetch) start(ctx context.Context) (context.Context, int) {
+	f.stop()
+	f.id++
+	ctx, f.cancel = context.WithCancel(ctx)
+	return ctx, f.id
+}
+
+// stop cancels the fetch in flight. It reports whether there was one.
+func (f *fetch) stop() bool {
+	if f.cancel == nil {
+		return false
+	}
+	f.cancel()
+	f.cancel = nil
+	return true
+}
+
+// done ends the fetch with the given ID. It reports whether the fetch was
+// still in flight, i.e. whether its result is to be used.
+func (f *fetch) done(id int) bool {
+	if f.cancel == nil || f.id != id {
+		return false
+	}
+	f.cancel()
+	f.cancel = nil
+	return true
+}
+
+// fetchErrorMsg returns the message for a fetch that failed with err, none if
+// the fetch failed because it was cancelled.
+func fetchErrorMsg(ctx context.Context, err error) tea.Msg {
+	if ctx.Err() != nil {
+		return nil
+	}
+	return common.ErrorMsg(err)
+}
+
+// cancelKey returns the key binding cancelling a fetch.
+func cancelKey(c common.Common) key.Binding {
+	k := c.KeyMap.Back
+	k.SetHelp("esc", "cancel")
+	return k
+}
+
+// listStatus returns the status bar value of a list of which only listed
+// items of total are fetched so far.
+func listStatus(name string, listed int, total int64, cancelled bool) string {
+	switch {
+	case cancelled:
+		return name + " (cancelled)"
+	case total > int64(listed):
+		return fmt.Sprintf("%s (%d/%d)", name, listed, total)
+	}
+	return fmt.Sprintf("%s (%d)", name, listed)
+}
@@ -233,7 +233,10 @@ func (f *Files) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		cmds = append(cmds,
 			f.selector.SetItems(msg),
 		)
-		f.activeView = filesViewFiles
+		// Stay on a file opened while the tree was loading.
+		if f.activeView != filesViewContent {
+			f.activeView = filesViewFiles
+		}
 		if f.cursor >= 0 {
 			f.selector.Select(f.cursor)
 			f.cursor = -1
@@ -249,6 +252,8 @@ func (f *Files) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		f.activeView = filesViewContent
 		f.code.UseGlamour = false
 		f.code.SetSideNote(renderBlame(f.common, f.currentItem, msg))
+	case ShowFileMsg:
+		cmds = append(cmds, f.showFileCmd(string(msg)))
 	case selector.SelectMsg:
 		switch sel := msg.IdentifiableItem.(type) {
 		case FileItem:
@@ -481,6 +486,38 @@ func (f *Files) selectFileCmd() tea.Msg {
 	return common.ErrorMsg(errNoFileSelected)
 }
 
+// showFileCmd opens the file at path as if it was selected in the tree.
+func (f *Files) showFileCmd(path string) tea.Cmd {
+	return func() tea.Msg {
+		if f.ref == nil {
+			return common.ErrorMsg(errNoFileSelected)
+		}
+		r, err := f.repo.Open()
+		if err != nil {
+			return common.ErrorMsg(err)
+		}
+		t, err := r.TreePath(f.ref, filepath.Dir(path))
+		if err != nil {
+			return common.ErrorMsg(err)
+		}
+		ents, err := t.Entries()
+		if err != nil {
+			return common.ErrorMsg(err)
+		}
+		for _, e := range ents {
+			if e.Name() == filepath.Base(path) && !e.IsTree() {
+				f.currentItem = &FileItem{entry: e}
+				f.path = path
+				// One entry per parent directory so going back walks up
+				// the tree.
+				f.lastSelected = make([]int, strings.Count(path, "/"))
+				return f.selectFileCmd()
+			}
+		}
+		return common.ErrorMsg(errInvalidFile)
+	}
+}
+
 func (f *Files) fetchBlame() tea.Msg {
 	r, err := f.repo.Open()
 	if err != nil {
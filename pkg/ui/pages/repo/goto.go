@@ -0,0 +1,70 @@
+package repo
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/soft-serve/pkg/ui/common"
+)
+
+// GotoMsg is a message to jump to an issue, a merge request, a branch, or a
+// file of the selected repository once it's loaded.
+type GotoMsg struct {
+	IssueID        int64
+	MergeRequestID int64
+	Branch         string
+	Path           string
+}
+
+// ShowIssueMsg is a message to show the details of an issue.
+type ShowIssueMsg int64
+
+// ShowMergeRequestMsg is a message to show the details of a merge request.
+type ShowMergeRequestMsg int64
+
+// ShowFileMsg is a message to show a file of the current reference.
+type ShowFileMsg string
+
+// gotoCmd jumps to the target of msg.
+func (r *Repo) gotoCmd(msg GotoMsg) tea.Cmd {
+	switch {
+	case msg.IssueID > 0:
+		return tea.Batch(
+			switchTabCmd(&Issues{}),
+			r.updateTabComponent(&Issues{}, ShowIssueMsg(msg.IssueID)),
+		)
+	case msg.MergeRequestID > 0:
+		return tea.Batch(
+			switchTabCmd(&MergeRequests{}),
+			r.updateTabComponent(&MergeRequests{}, ShowMergeRequestMsg(msg.MergeRequestID)),
+		)
+	case msg.Branch != "":
+		repo := r.selectedRepo
+		return tea.Batch(
+			func() tea.Msg {
+				rr, err := repo.Open()
+				if err != nil {
+					return common.ErrorMsg(err)
+				}
+				refs, err := rr.References()
+				if err != nil {
+					return common.ErrorMsg(err)
+				}
+				for _, ref := range refs {
+					if ref.IsBranch() && ref.Name().Short() == msg.Branch {
+						return RefMsg(ref)
+					}
+				}
+				return common.ErrorMsg(fmt.Errorf("branch %q not found", msg.Branch))
+			},
+			switchTabCmd(&Files{}),
+		)
+	case msg.Path != "":
+		return tea.Batch(
+			switchTabCmd(&Files{}),
+			r.updateTabComponent(&Files{}, ShowFileMsg(msg.Path)),
+		)
+	}
+
+	return nil
+}
@@ -2,11 +2,12 @@ package repo
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"sort"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/v2/key"
+	"github.com/charmbracelet/bubbles/v2/list"
 	"github.com/charmbracelet/bubbles/v2/spinner"
 	tea "github.com/charmbracelet/bubbletea/v2"
 	"github.com/charmbracelet/soft-serve/pkg/backend"
@@ -37,15 +38,64 @@ type Issues struct {
 	selectedIssue *models.Issue
 	issueDetails  string
 	stateFilter   string
+	order         models.ListOrder
+	// prefix is the issue prefix of the repository.
+	prefix string
+	// split shows the list and the selected issue side by side.
+	split bool
+	// more is set when older issues may follow the listed ones.
+	more bool
+	// loadingMore is set while the next page of issues is fetched.
+	loadingMore bool
+	// reselect is the ID of the issue selected when the list was reloaded,
+	// and reselectIndex its position, to select again once the list is back.
+	reselect      string
+	reselectIndex int
+	// total is the number of issues in the list, of which only the
+	// fetched pages are listed.
+	total int64
+	// listFetch is the fetch of the list, cancelled with esc while loading.
+	listFetch fetch
+	// cancelled is set when loading the list was cancelled.
+	cancelled bool
 }
 
-// IssueItemsMsg is a message for issue items.
-type IssueItemsMsg []IssueItem
+// listPageSize is the number of issues or merge requests fetched at once. The
+// next page is fetched when the cursor gets close to the end of the list.
+const listPageSize = 50
+
+// nextListOrder returns the order following o when cycling through the sorts
+// of the issue and merge request lists. Titles and states sort ascending, the
+// others newest first.
+func nextListOrder(o models.ListOrder) models.ListOrder {
+	s := (o.Sort + 1) % (models.ListSortState + 1)
+	return models.ListOrder{Sort: s, Ascending: s == models.ListSortTitle || s == models.ListSortState}
+}
+
+// IssueItemsMsg is a message for a page of issue items.
+type IssueItemsMsg struct {
+	Items []IssueItem
+	// After is the number of the issue the page follows, 0 for the first
+	// page.
+	After int64
+	// Order is the order of the list the page belongs to.
+	Order models.ListOrder
+	// More is set when more issues may follow the page.
+	More bool
+	// Total is the number of issues in the list, set with the first page.
+	Total int64
+	// fetch is the ID of the fetch the page comes from.
+	fetch int
+}
 
 // IssueDetailMsg is a message for issue details.
 type IssueDetailMsg struct {
 	Issue   models.Issue
 	Details string
+	// Prefix is the issue prefix of the repository.
+	Prefix string
+	// Preview is set when the issue is only shown next to the list.
+	Preview bool
 }
 
 // NewIssues creates a new issues component.
@@ -80,6 +130,13 @@ func NewIssues(c common.Common) *Issues {
 // SetSize implements common.Component.
 func (i *Issues) SetSize(width, height int) {
 	i.common.SetSize(width, height)
+	i.split = isSplitPane(i.common, width)
+	if i.split {
+		lw, dw := splitPaneWidths(i.common, width)
+		i.selector.SetSize(lw, height)
+		i.code.SetSize(dw, height)
+		return
+	}
 	i.selector.SetSize(width, height)
 	i.code.SetSize(width, height)
 }
@@ -92,7 +149,10 @@ func (i *Issues) ShortHelp() []key.Binding {
 		return []key.Binding{
 			k.UpDown,
 			k.Select,
+			k.SortList,
 		}
+	case issueViewLoading:
+		return []key.Binding{cancelKey(i.common)}
 	case issueViewDetail:
 		return []key.Binding{
 			k.UpDown,
@@ -109,8 +169,11 @@ func (i *Issues) FullHelp() [][]key.Binding {
 	case issueViewList:
 		return [][]key.Binding{
 			{k.UpDown, k.Select},
+			{k.SortList, k.ReverseSort},
 			{k.Back},
 		}
+	case issueViewLoading:
+		return [][]key.Binding{{cancelKey(i.common)}}
 	case issueViewDetail:
 		return [][]key.Binding{
 			{k.UpDown, k.Back},
@@ -122,9 +185,16 @@ func (i *Issues) FullHelp() [][]key.Binding {
 // Init implements tea.Model.
 func (i *Issues) Init() tea.Cmd {
 	i.activeView = issueViewLoading
+	// Forget the issue shown for the previous repository.
+	i.code.SetContent("", "")
+	i.more = false
+	i.loadingMore = false
+	i.reselect = ""
+	i.total = 0
+	i.cancelled = false
 	return tea.Batch(
 		i.spinner.Tick,
-		i.fetchIssuesCmd,
+		i.fetchIssuesCmd(0),
 	)
 }
 
@@ -138,20 +208,72 @@ func (i *Issues) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return i, i.Init()
 
 	case IssueItemsMsg:
-		i.activeView = issueViewList
-		i.items = msg
-		items := make([]selector.IdentifiableItem, len(msg))
-		for idx, item := range msg {
-			items[idx] = item
+		// Drop pages of a fetch cancelled or superseded since.
+		if !i.listFetch.done(msg.fetch) {
+			break
+		}
+		// Drop pages of a list sorted differently since.
+		if msg.Order != i.order {
+			break
+		}
+		if msg.After > 0 {
+			// Drop pages following a list that was reloaded since.
+			if !i.loadingMore || msg.After != i.lastNumber() {
+				break
+			}
+			i.loadingMore = false
+			i.more = msg.More
+			i.items = append(i.items, msg.Items...)
+			cmds = append(cmds, i.selector.SetItems(i.selectorItems()))
+			break
+		}
+		// Stay on an issue opened while the list was loading.
+		if i.activeView != issueViewDetail {
+			i.activeView = issueViewList
+		}
+		i.more = msg.More
+		i.loadingMore = false
+		i.total = msg.Total
+		i.cancelled = false
+		i.items = msg.Items
+		cmds = append(cmds, i.selector.SetItems(i.selectorItems()))
+		// Keep the user's place in a reloaded list.
+		if i.reselect != "" {
+			i.selector.SelectID(i.reselect, i.reselectIndex)
+			i.reselect = ""
+		}
+		if item, ok := i.selector.SelectedItem().(IssueItem); ok && i.split {
+			cmds = append(cmds, i.fetchIssuePreviewCmd(item.Issue.ID))
 		}
-		cmds = append(cmds, i.selector.SetItems(items))
 
 	case IssueDetailMsg:
+		if msg.Preview {
+			// Drop previews of issues the cursor already left.
+			item, ok := i.selector.SelectedItem().(IssueItem)
+			if i.activeView == issueViewList && ok && item.Issue.ID == msg.Issue.ID {
+				i.issueDetails = msg.Details
+				cmds = append(cmds, i.code.SetContent(msg.Details, ""))
+			}
+			break
+		}
 		i.activeView = issueViewDetail
 		i.selectedIssue = &msg.Issue
+		i.prefix = msg.Prefix
 		i.issueDetails = msg.Details
 		cmds = append(cmds, i.code.SetContent(msg.Details, ""))
 
+	case selector.ActiveMsg:
+		if item, ok := msg.IdentifiableItem.(IssueItem); ok && i.split && i.activeView == issueViewList {
+			cmds = append(cmds, i.fetchIssuePreviewCmd(item.Issue.ID))
+		}
+		if i.more && !i.loadingMore && i.selector.Index() >= len(i.items)-i.selector.PerPage() {
+			i.loadingMore = true
+			cmds = append(cmds, i.fetchIssuesCmd(i.lastNumber()))
+		}
+
+	case ShowIssueMsg:
+		cmds = append(cmds, i.fetchIssueDetailCmd(int64(msg)))
+
 	case selector.SelectMsg:
 		switch item := msg.IdentifiableItem.(type) {
 		case IssueItem:
@@ -161,10 +283,26 @@ func (i *Issues) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case tea.KeyPressMsg:
 		switch i.activeView {
+		case issueViewLoading:
+			// Stop waiting for a list that takes long to load.
+			if key.Matches(msg, i.common.KeyMap.Back) && i.listFetch.stop() {
+				i.activeView = issueViewList
+				i.cancelled = true
+				return i, nil
+			}
 		case issueViewList:
+			if i.selector.FilterState() == list.Filtering {
+				break
+			}
 			switch {
 			case key.Matches(msg, i.common.KeyMap.SelectItem):
 				cmds = append(cmds, i.selector.SelectItemCmd)
+			case key.Matches(msg, i.common.KeyMap.SortList):
+				i.order = nextListOrder(i.order)
+				cmds = append(cmds, i.reloadCmd())
+			case key.Matches(msg, i.common.KeyMap.ReverseSort):
+				i.order.Ascending = !i.order.Ascending
+				cmds = append(cmds, i.reloadCmd())
 			}
 		case issueViewDetail:
 			switch {
@@ -208,6 +346,13 @@ func (i *Issues) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 // View implements tea.Model.
 func (i *Issues) View() string {
+	if i.split {
+		list := i.selector.View()
+		if i.activeView == issueViewLoading {
+			list = renderLoading(i.common, i.spinner)
+		}
+		return renderSplitPane(i.common, list, i.code.View(), i.activeView == issueViewDetail)
+	}
 	switch i.activeView {
 	case issueViewLoading:
 		return renderLoading(i.common, i.spinner)
@@ -223,10 +368,10 @@ func (i *Issues) View() string {
 func (i *Issues) StatusBarValue() string {
 	switch i.activeView {
 	case issueViewList:
-		return fmt.Sprintf("Issues (%d)", len(i.items))
+		return listStatus("Issues", len(i.items), i.total, i.cancelled)
 	case issueViewDetail:
 		if i.selectedIssue != nil {
-			return fmt.Sprintf("Issue #%d", i.selectedIssue.ID)
+			return "Issue " + backend.FormatIssueRef(i.prefix, i.selectedIssue.Number)
 		}
 		return "Issue"
 	}
@@ -237,7 +382,7 @@ func (i *Issues) StatusBarValue() string {
 func (i *Issues) StatusBarInfo() string {
 	switch i.activeView {
 	case issueViewList:
-		return fmt.Sprintf("Filter: %s", i.stateFilter)
+		return fmt.Sprintf("Filter: %s • Sort: %s", i.stateFilter, i.order)
 	case issueViewDetail:
 		if i.selectedIssue != nil {
 			return i.selectedIssue.State.String()
@@ -258,58 +403,133 @@ func (i *Issues) TabName() string {
 
 // Path implements common.TabComponent.
 func (i *Issues) Path() string {
+	// Keep esc for cancelling the fetch rather than leaving the repository.
+	if i.activeView == issueViewLoading {
+		return "loading"
+	}
 	if i.selectedIssue != nil {
-		return fmt.Sprintf("#%d", i.selectedIssue.ID)
+		return backend.FormatIssueRef(i.prefix, i.selectedIssue.Number)
 	}
 	return ""
 }
 
-// fetchIssuesCmd fetches issues for the repository.
-func (i *Issues) fetchIssuesCmd() tea.Msg {
-	if i.repo == nil {
-		return common.ErrorMsg(common.ErrMissingRepo)
+// selectorItems returns the listed issues as selector items.
+func (i *Issues) selectorItems() []selector.IdentifiableItem {
+	items := make([]selector.IdentifiableItem, len(i.items))
+	for idx, item := range i.items {
+		items[idx] = item
 	}
+	return items
+}
 
-	ctx := i.common.Context()
-	be := backend.FromContext(ctx)
-
-	// Parse state filter
-	var state *models.IssueState
-	switch i.stateFilter {
-	case "open":
-		s := models.IssueStateOpen
-		state = &s
-	case "closed":
-		s := models.IssueStateClosed
-		state = &s
+// reloadCmd fetches the list again from the first page, e.g. once it's
+// sorted differently. The selected item stays selected once the list is
+// back, or the cursor stays where it was if the item is no longer listed.
+func (i *Issues) reloadCmd() tea.Cmd {
+	i.more = false
+	i.loadingMore = false
+	i.reselect = ""
+	if item := i.selector.SelectedItem(); item != nil {
+		i.reselect = item.ID()
 	}
+	i.reselectIndex = i.selector.Index()
+	return i.fetchIssuesCmd(0)
+}
 
-	issues, err := be.ListIssues(ctx, i.repo.Name(), state)
-	if err != nil {
-		return common.ErrorMsg(err)
+// lastNumber returns the number of the last listed issue.
+func (i *Issues) lastNumber() int64 {
+	if len(i.items) == 0 {
+		return 0
 	}
+	return i.items[len(i.items)-1].Issue.Number
+}
 
-	items := make([]IssueItem, 0, len(issues))
-	for _, issue := range issues {
-		// Get author name
-		authorName := "unknown"
-		if issue.AuthorID > 0 {
-			author, err := be.UserByID(ctx, issue.AuthorID)
-			if err == nil && author != nil {
-				authorName = author.Username()
-			}
+// fetchIssuesCmd fetches the page of issues of the repository following the
+// issue numbered after, or the first page if after is 0.
+func (i *Issues) fetchIssuesCmd(after int64) tea.Cmd {
+	order := i.order
+	ctx, id := i.listFetch.start(i.common.Context())
+	return func() tea.Msg {
+		if i.repo == nil {
+			return common.ErrorMsg(common.ErrMissingRepo)
+		}
+
+		be := backend.FromContext(ctx)
+
+		// Parse state filter
+		var state *models.IssueState
+		switch i.stateFilter {
+		case "open":
+			s := models.IssueStateOpen
+			state = &s
+		case "closed":
+			s := models.IssueStateClosed
+			state = &s
 		}
 
-		items = append(items, IssueItem{
-			Issue:      issue,
-			AuthorName: authorName,
+		issues, err := be.ListIssues(ctx, i.repo.Name(), backend.IssueListOptions{
+			State: state,
+			Order: order,
+			Limit: listPageSize,
+			After: after,
 		})
-	}
+		if errors.Is(err, backend.ErrIssuesDisabled) {
+			// The tab is hidden.
+			return IssueItemsMsg{After: after, Order: order, fetch: id}
+		}
+		if err != nil {
+			return fetchErrorMsg(ctx, err)
+		}
+
+		labels, err := be.IssueLabelsByIssue(ctx, i.repo.Name())
+		if err != nil {
+			return fetchErrorMsg(ctx, err)
+		}
+
+		prefix, err := be.IssuePrefix(ctx, i.repo.Name())
+		if err != nil {
+			return fetchErrorMsg(ctx, err)
+		}
+
+		// The first page comes with the number of issues to tell how many
+		// are still to be fetched.
+		var total int64
+		if after == 0 {
+			total, err = be.CountIssues(ctx, i.repo.Name(), state)
+			if err != nil {
+				return fetchErrorMsg(ctx, err)
+			}
+		}
+
+		items := make([]IssueItem, 0, len(issues))
+		for _, issue := range issues {
+			// Get author name
+			authorName := "unknown"
+			if issue.AuthorID > 0 {
+				author, err := be.UserByID(ctx, issue.AuthorID)
+				if err == nil && author != nil {
+					authorName = proto.DisplayName(author)
+				}
+			}
 
-	// Sort by update time (most recent first)
-	sort.Sort(IssueItems(items))
+			items = append(items, IssueItem{
+				Issue:      issue,
+				AuthorName: authorName,
+				Labels:     labels[issue.ID],
+				Prefix:     prefix,
+			})
+		}
 
-	return IssueItemsMsg(items)
+		return IssueItemsMsg{
+			Items: items,
+			After: after,
+			Order: order,
+			Total: total,
+			fetch: id,
+			// Pinned issues top the first page on top of a full page.
+			More: len(issues) >= listPageSize,
+		}
+	}
 }
 
 // fetchIssueDetailCmd fetches details for a specific issue.
@@ -327,25 +547,55 @@ func (i *Issues) fetchIssueDetailCmd(issueID int64) tea.Cmd {
 			return common.ErrorMsg(err)
 		}
 
+		prefix, err := be.IssuePrefix(ctx, i.repo.Name())
+		if err != nil {
+			return common.ErrorMsg(err)
+		}
+
 		// Build detailed view
-		details := i.buildIssueDetails(ctx, issue)
+		details := i.buildIssueDetails(ctx, issue, prefix)
 
 		return IssueDetailMsg{
 			Issue:   issue,
 			Details: details,
+			Prefix:  prefix,
 		}
 	}
 }
 
+// fetchIssuePreviewCmd fetches the details of the issue under the cursor to
+// show them next to the list.
+func (i *Issues) fetchIssuePreviewCmd(issueID int64) tea.Cmd {
+	fetch := i.fetchIssueDetailCmd(issueID)
+	return func() tea.Msg {
+		msg := fetch()
+		if detail, ok := msg.(IssueDetailMsg); ok {
+			detail.Preview = true
+			return detail
+		}
+		return msg
+	}
+}
+
 // buildIssueDetails builds a detailed text view of the issue.
-func (i *Issues) buildIssueDetails(ctx context.Context, issue models.Issue) string {
+func (i *Issues) buildIssueDetails(ctx context.Context, issue models.Issue, prefix string) string {
 	var sb strings.Builder
 	be := backend.FromContext(ctx)
 
 	st := i.common.Styles.MR // Reuse MR styles for now
 
 	// Header
-	sb.WriteString(st.DetailTitle.Render(fmt.Sprintf("Issue #%d", issue.ID)))
+	header := "Issue " + backend.FormatIssueRef(prefix, issue.Number)
+	if issue.Locked {
+		header += " (locked)"
+	}
+	if issue.Pinned.Valid {
+		header += " (pinned)"
+	}
+	if issue.Draft {
+		header += " (draft)"
+	}
+	sb.WriteString(st.DetailTitle.Render(header))
 	sb.WriteString("\n\n")
 
 	// Title
@@ -364,36 +614,49 @@ func (i *Issues) buildIssueDetails(ctx context.Context, issue models.Issue) stri
 	// State
 	sb.WriteString(st.DetailLabel.Render("State: "))
 	sb.WriteString(issue.State.String())
+	if issue.CloseReason != "" {
+		sb.WriteString(" (" + string(issue.CloseReason) + ")")
+	}
 	sb.WriteString("\n\n")
 
+	// Labels
+	if labels, err := be.IssueLabels(ctx, i.repo.Name(), issue.ID); err == nil && len(labels) > 0 {
+		sb.WriteString(st.DetailLabel.Render("Labels: "))
+		sb.WriteString(renderLabels(st.ItemLabel, labels))
+		sb.WriteString("\n\n")
+	}
+
 	// Author
 	if issue.AuthorID > 0 {
 		author, err := be.UserByID(ctx, issue.AuthorID)
 		if err == nil && author != nil {
 			sb.WriteString(st.DetailLabel.Render("Author: "))
-			sb.WriteString(author.Username())
+			sb.WriteString(proto.DisplayName(author))
 			sb.WriteString("\n\n")
 		}
 	}
 
 	// Timestamps
 	sb.WriteString(st.DetailLabel.Render("Created: "))
-	sb.WriteString(issue.CreatedAt.Format("2006-01-02 15:04:05"))
+	sb.WriteString(i.common.Dates.Absolute(issue.CreatedAt))
 	sb.WriteString("\n")
 
 	sb.WriteString(st.DetailLabel.Render("Updated: "))
-	sb.WriteString(issue.UpdatedAt.Format("2006-01-02 15:04:05"))
+	sb.WriteString(i.common.Dates.Absolute(issue.UpdatedAt))
 	sb.WriteString("\n")
 
 	if issue.ClosedAt.Valid {
 		sb.WriteString(st.DetailLabel.Render("Closed: "))
-		sb.WriteString(issue.ClosedAt.Time.Format("2006-01-02 15:04:05"))
+		sb.WriteString(i.common.Dates.Absolute(issue.ClosedAt.Time))
 		if issue.ClosedBy.Valid {
 			closedBy, err := be.UserByID(ctx, issue.ClosedBy.Int64)
 			if err == nil && closedBy != nil {
 				sb.WriteString(fmt.Sprintf(" by %s", closedBy.Username()))
 			}
 		}
+		if issue.ClosedByCommit.Valid {
+			sb.WriteString(fmt.Sprintf(" in commit %s", issue.ClosedByCommit.String[:min(7, len(issue.ClosedByCommit.String))]))
+		}
 		sb.WriteString("\n")
 	}
 
@@ -404,7 +667,7 @@ func (i *Issues) buildIssueDetails(ctx context.Context, issue models.Issue) stri
 		sb.WriteString(st.DetailLabel.Render("Depends on:"))
 		sb.WriteString("\n")
 		for _, dep := range dependencies {
-			sb.WriteString(fmt.Sprintf("  #%d - %s\n", dep.ID, dep.Title))
+			sb.WriteString(fmt.Sprintf("  %s - %s\n", backend.FormatIssueRef(prefix, dep.Number), dep.Title))
 		}
 	}
 
@@ -415,7 +678,32 @@ func (i *Issues) buildIssueDetails(ctx context.Context, issue models.Issue) stri
 		sb.WriteString(st.DetailLabel.Render("Blocked by:"))
 		sb.WriteString("\n")
 		for _, dep := range dependents {
-			sb.WriteString(fmt.Sprintf("  #%d - %s\n", dep.ID, dep.Title))
+			sb.WriteString(fmt.Sprintf("  %s - %s\n", backend.FormatIssueRef(prefix, dep.Number), dep.Title))
+		}
+	}
+
+	// Issues, merge requests, and commits referencing this one
+	subject := backend.Subject{Type: backend.NotificationSubjectIssue, ID: issue.ID}
+	if refs, err := be.ReferencedBy(ctx, i.repo.Name(), subject); err == nil && len(refs) > 0 {
+		sb.WriteString("\n")
+		sb.WriteString(st.DetailLabel.Render("Referenced by:"))
+		sb.WriteString("\n")
+		for _, ref := range refs {
+			if ref.Kind == backend.ReferenceKindCommit {
+				sb.WriteString(fmt.Sprintf("  commit %s\n", ref.Ref))
+				continue
+			}
+			sb.WriteString(fmt.Sprintf("  %s - %s\n", ref.Ref, ref.Title))
+		}
+	}
+
+	// Attachments
+	if as, err := be.Attachments(ctx, i.repo.Name(), subject); err == nil && len(as) > 0 {
+		sb.WriteString("\n")
+		sb.WriteString(st.DetailLabel.Render("Attachments:"))
+		sb.WriteString("\n")
+		for _, a := range as {
+			sb.WriteString(fmt.Sprintf("  %s - %s\n", a.Name, be.AttachmentURL(i.repo.Name(), a)))
 		}
 	}
 
@@ -3,14 +3,15 @@ package repo
 import (
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/charmbracelet/bubbles/v2/key"
 	"github.com/charmbracelet/bubbles/v2/list"
 	tea "github.com/charmbracelet/bubbletea/v2"
 	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
 	"github.com/charmbracelet/soft-serve/pkg/db/models"
 	"github.com/charmbracelet/soft-serve/pkg/ui/common"
-	"github.com/dustin/go-humanize"
 	"github.com/muesli/reflow/truncate"
 )
 
@@ -18,6 +19,9 @@ import (
 type IssueItem struct {
 	Issue      models.Issue
 	AuthorName string
+	Labels     []models.Label
+	// Prefix is the issue prefix of the repository.
+	Prefix string
 }
 
 // ID implements selector.IdentifiableItem.
@@ -32,28 +36,14 @@ func (i IssueItem) Title() string {
 
 // Description implements list.DefaultItem.
 func (i IssueItem) Description() string {
-	return fmt.Sprintf("#%d • %s",
-		i.Issue.ID,
+	return fmt.Sprintf("%s • %s",
+		backend.FormatIssueRef(i.Prefix, i.Issue.Number),
 		i.Issue.State.String())
 }
 
 // FilterValue implements list.Item.
 func (i IssueItem) FilterValue() string {
-	return fmt.Sprintf("%d %s", i.Issue.ID, i.Issue.Title)
-}
-
-// IssueItems is a list of issues.
-type IssueItems []IssueItem
-
-// Len implements sort.Interface.
-func (items IssueItems) Len() int { return len(items) }
-
-// Swap implements sort.Interface.
-func (items IssueItems) Swap(i, j int) { items[i], items[j] = items[j], items[i] }
-
-// Less implements sort.Interface (most recent first).
-func (items IssueItems) Less(i, j int) bool {
-	return items[i].Issue.UpdatedAt.After(items[j].Issue.UpdatedAt)
+	return fmt.Sprintf("%d %s %s %s", i.Issue.Number, backend.FormatIssueRef(i.Prefix, i.Issue.Number), i.Issue.ExternalID.String, i.Issue.Title)
 }
 
 // IssueItemDelegate is the delegate for the issue item.
@@ -109,11 +99,23 @@ func (d IssueItemDelegate) Render(w io.Writer, m list.Model, index int, listItem
 		stateSt = st.ItemStateOpen
 		stateBadge = "●"
 	case models.IssueStateClosed:
-		stateSt = st.ItemStateClosed
-		stateBadge = "✕"
+		switch i.Issue.CloseReason {
+		case models.IssueCloseReasonCompleted:
+			stateSt = st.ItemStateMerged
+			stateBadge = "✓"
+		case models.IssueCloseReasonNotPlanned:
+			stateSt = st.ItemStateNotPlanned
+			stateBadge = "⊘"
+		case models.IssueCloseReasonDuplicate:
+			stateSt = st.ItemStateNotPlanned
+			stateBadge = "≡"
+		default:
+			stateSt = st.ItemStateClosed
+			stateBadge = "✕"
+		}
 	}
 
-	issueNum := st.ItemNumber.Render(fmt.Sprintf("#%d", i.Issue.ID))
+	issueNum := st.ItemNumber.Render(backend.FormatIssueRef(i.Prefix, i.Issue.Number))
 	badge := stateSt.Render(stateBadge)
 
 	// Title
@@ -146,10 +148,22 @@ func (d IssueItemDelegate) Render(w io.Writer, m list.Model, index int, listItem
 	}
 	authorRendered := st.ItemAuthor.Render(author)
 
-	timeAgo := humanize.Time(i.Issue.UpdatedAt)
+	timeAgo := d.common.Dates.Relative(i.Issue.UpdatedAt)
 	timeRendered := st.ItemTime.Render(" • " + timeAgo)
 
 	secondLineContent := authorRendered + timeRendered
+	if i.Issue.Pinned.Valid {
+		secondLineContent = s.ItemPinned.String() + st.ItemTime.Render(" • ") + secondLineContent
+	}
+	if i.Issue.Draft {
+		secondLineContent = s.ItemDraft.String() + st.ItemTime.Render(" • ") + secondLineContent
+	}
+	if len(i.Labels) > 0 {
+		secondLineContent += st.ItemTime.Render(" • ") + renderLabels(s.ItemLabel, i.Labels)
+	}
+	if i.Issue.FirstContribution {
+		secondLineContent += st.ItemTime.Render(" • ") + s.ItemFirstContribution.String()
+	}
 
 	// Calculate padding for second line to align with first line
 	secondLineMargin := m.Width() -
@@ -177,3 +191,12 @@ func (d IssueItemDelegate) Render(w io.Writer, m list.Model, index int, listItem
 		),
 	)
 }
+
+// renderLabels renders issue labels in their colors.
+func renderLabels(st lipgloss.Style, labels []models.Label) string {
+	rendered := make([]string, len(labels))
+	for i, l := range labels {
+		rendered[i] = st.Foreground(lipgloss.Color(l.Color)).Render(l.Name)
+	}
+	return strings.Join(rendered, " ")
+}
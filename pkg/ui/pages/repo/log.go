@@ -2,6 +2,7 @@ package repo
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -11,12 +12,15 @@ import (
 	gansi "github.com/charmbracelet/glamour/v2/ansi"
 	"github.com/charmbracelet/lipgloss/v2"
 	"github.com/charmbracelet/soft-serve/git"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
 	"github.com/charmbracelet/soft-serve/pkg/proto"
 	"github.com/charmbracelet/soft-serve/pkg/ui/common"
 	"github.com/charmbracelet/soft-serve/pkg/ui/components/footer"
 	"github.com/charmbracelet/soft-serve/pkg/ui/components/selector"
 	"github.com/charmbracelet/soft-serve/pkg/ui/components/viewport"
 	"github.com/charmbracelet/soft-serve/pkg/ui/styles"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/muesli/reflow/wrap"
 )
 
@@ -31,16 +35,36 @@ const (
 )
 
 // LogCountMsg is a message that contains the number of commits in a repo.
-type LogCountMsg int64
+type LogCountMsg struct {
+	Count int64
+	// fetch is the ID of the fetch the count comes from.
+	fetch int
+}
 
 // LogItemsMsg is a message that contains a slice of LogItem.
-type LogItemsMsg []selector.IdentifiableItem
+type LogItemsMsg struct {
+	Items []selector.IdentifiableItem
+	// fetch is the ID of the fetch the items come from.
+	fetch int
+}
 
 // LogCommitMsg is a message that contains a git commit.
 type LogCommitMsg *git.Commit
 
 // LogDiffMsg is a message that contains a git diff.
-type LogDiffMsg *git.Diff
+type LogDiffMsg struct {
+	Diff *git.Diff
+	// fetch is the ID of the fetch the diff comes from.
+	fetch int
+}
+
+// LogReferencesMsg is a message that contains the merge requests and issues
+// linked to a commit.
+type LogReferencesMsg struct {
+	Commit     *git.Commit
+	References backend.CommitReferences
+	Prefix     string
+}
 
 // Log is a model that displays a list of commits and their diffs.
 type Log struct {
@@ -55,8 +79,19 @@ type Log struct {
 	activeCommit   *git.Commit
 	selectedCommit *git.Commit
 	currentDiff    *git.Diff
+	references     backend.CommitReferences
+	issuePrefix    string
+	nextMR         int
+	nextIssue      int
 	loadingTime    time.Time
 	spinner        spinner.Model
+	prefs          models.UserPreferences
+	// countFetch, pageFetch and diffFetch are the fetches of the number of
+	// commits, of a page of commits and of a diff, cancelled with esc while
+	// loading.
+	countFetch fetch
+	pageFetch  fetch
+	diffFetch  fetch
 }
 
 // NewLog creates a new Log model.
@@ -65,6 +100,7 @@ func NewLog(common common.Common) *Log {
 		common:     common,
 		vp:         viewport.New(common),
 		activeView: logViewCommits,
+		prefs:      backend.DefaultUserPreferences(),
 	}
 	selector := selector.New(common, []selector.IdentifiableItem{}, LogItemDelegate{&common})
 	selector.SetShowFilter(false)
@@ -116,16 +152,19 @@ func (l *Log) ShortHelp() []key.Binding {
 			l.common.KeyMap.SelectItem,
 			copyKey,
 		}
+	case logViewLoading:
+		return []key.Binding{cancelKey(l.common)}
 	case logViewDiff:
 		copyKey := l.common.KeyMap.Copy
 		copyKey.SetHelp("c", "copy diff")
-		return []key.Binding{
+		return append([]key.Binding{
 			l.common.KeyMap.UpDown,
 			l.common.KeyMap.BackItem,
 			copyKey,
 			l.common.KeyMap.GotoTop,
 			l.common.KeyMap.GotoBottom,
-		}
+			l.common.KeyMap.DiffWrap,
+		}, l.referenceHelp()...)
 	default:
 		return []key.Binding{}
 	}
@@ -136,6 +175,8 @@ func (l *Log) FullHelp() [][]key.Binding {
 	k := l.selector.KeyMap
 	b := make([][]key.Binding, 0)
 	switch l.activeView {
+	case logViewLoading:
+		b = append(b, []key.Binding{cancelKey(l.common)})
 	case logViewCommits:
 		copyKey := l.common.KeyMap.Copy
 		copyKey.SetHelp("c", "copy hash")
@@ -177,7 +218,24 @@ func (l *Log) FullHelp() [][]key.Binding {
 				l.common.KeyMap.GotoTop,
 				l.common.KeyMap.GotoBottom,
 			},
+			diffHelp(l.common),
 		}...)
+		if help := l.referenceHelp(); len(help) > 0 {
+			b = append(b, help)
+		}
+	}
+	return b
+}
+
+// referenceHelp returns the key bindings to jump to the merge requests and
+// issues linked to the selected commit.
+func (l *Log) referenceHelp() []key.Binding {
+	var b []key.Binding
+	if len(l.references.MergeRequests) > 0 {
+		b = append(b, l.common.KeyMap.GotoMergeRequest)
+	}
+	if len(l.references.Closes)+len(l.references.Mentions) > 0 {
+		b = append(b, l.common.KeyMap.GotoIssue)
 	}
 	return b
 }
@@ -196,7 +254,7 @@ func (l *Log) Init() tea.Cmd {
 	l.activeCommit = nil
 	l.selectedCommit = nil
 	return tea.Batch(
-		l.countCommitsCmd,
+		l.countCommitsCmd(),
 		// start loading on init
 		l.startLoading(),
 	)
@@ -213,14 +271,20 @@ func (l *Log) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		l.selector.Select(0)
 		cmds = append(cmds, l.Init())
 	case LogCountMsg:
-		l.count = int64(msg)
-		l.selector.SetTotalPages(int(msg))
+		if !l.countFetch.done(msg.fetch) {
+			break
+		}
+		l.count = msg.Count
+		l.selector.SetTotalPages(int(msg.Count))
 		l.selector.SetItems(make([]selector.IdentifiableItem, l.count))
-		cmds = append(cmds, l.updateCommitsCmd)
+		cmds = append(cmds, l.updateCommitsCmd())
 	case LogItemsMsg:
+		if !l.pageFetch.done(msg.fetch) {
+			break
+		}
 		// stop loading after receiving items
 		l.activeView = logViewCommits
-		cmds = append(cmds, l.selector.SetItems(msg))
+		cmds = append(cmds, l.selector.SetItems(msg.Items))
 		l.selector.SetPage(l.nextPage)
 		l.SetSize(l.common.Width, l.common.Height)
 		i := l.selector.SelectedItem()
@@ -247,7 +311,7 @@ func (l *Log) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				l.nextPage = m.Page()
 				l.selector.SetPage(curPage)
 				cmds = append(cmds,
-					l.updateCommitsCmd,
+					l.updateCommitsCmd(),
 					l.startLoading(),
 				)
 			}
@@ -262,11 +326,37 @@ func (l *Log) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					if l.currentDiff != nil {
 						cmds = append(cmds, copyCmd(l.currentDiff.Patch(), "Commit diff copied to clipboard"))
 					}
+				case key.Matches(kmsg, l.common.KeyMap.GotoMergeRequest):
+					// Repeated presses go through the merge requests in turn.
+					if mrs := l.references.MergeRequests; len(mrs) > 0 {
+						mr := mrs[l.nextMR%len(mrs)]
+						l.nextMR++
+						cmds = append(cmds, func() tea.Msg {
+							return GotoMsg{MergeRequestID: mr.ID}
+						})
+					}
+				case key.Matches(kmsg, l.common.KeyMap.GotoIssue):
+					// Repeated presses go through the issues in turn.
+					if issues := slices.Concat(l.references.Closes, l.references.Mentions); len(issues) > 0 {
+						issue := issues[l.nextIssue%len(issues)]
+						l.nextIssue++
+						cmds = append(cmds, func() tea.Msg {
+							return GotoMsg{IssueID: issue.ID}
+						})
+					}
+				default:
+					if cmd := updateDiffPrefsCmd(l.common, l.prefs, kmsg); cmd != nil {
+						cmds = append(cmds, cmd)
+					}
 				}
 			}
 		}
 	case GoBackMsg:
-		l.goBack()
+		if l.activeView == logViewLoading {
+			l.cancelLoading()
+		} else {
+			l.goBack()
+		}
 	case selector.ActiveMsg:
 		switch sel := msg.IdentifiableItem.(type) {
 		case LogItem:
@@ -282,34 +372,45 @@ func (l *Log) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 	case LogCommitMsg:
 		l.selectedCommit = msg
-		cmds = append(cmds, l.loadDiffCmd)
+		l.references = backend.CommitReferences{}
+		l.nextMR, l.nextIssue = 0, 0
+		cmds = append(cmds, l.loadDiffCmd(), l.loadReferencesCmd(msg))
+	case LogReferencesMsg:
+		if l.selectedCommit == msg.Commit {
+			l.references = msg.References
+			l.issuePrefix = msg.Prefix
+			if l.activeView == logViewDiff && l.currentDiff != nil {
+				l.setDiffContent()
+			}
+		}
 	case LogDiffMsg:
-		l.currentDiff = msg
-		l.vp.SetContent(
-			lipgloss.JoinVertical(lipgloss.Left,
-				l.renderCommit(l.selectedCommit),
-				renderSummary(msg, l.common.Styles, l.common.Width),
-				renderDiff(msg, l.common.Width),
-			),
-		)
+		if !l.diffFetch.done(msg.fetch) {
+			break
+		}
+		l.currentDiff = msg.Diff
+		l.setDiffContent()
 		l.vp.GotoTop()
 		l.activeView = logViewDiff
+	case DiffPrefsMsg:
+		prev := l.prefs
+		l.prefs = models.UserPreferences(msg)
+		if l.activeView == logViewDiff && l.selectedCommit != nil && l.currentDiff != nil {
+			if prev.DiffIgnoreWhitespace != l.prefs.DiffIgnoreWhitespace {
+				cmds = append(cmds, l.loadDiffCmd())
+			} else {
+				l.setDiffContent()
+			}
+		}
 	case footer.ToggleFooterMsg:
-		cmds = append(cmds, l.updateCommitsCmd)
+		cmds = append(cmds, l.updateCommitsCmd())
 	case tea.WindowSizeMsg:
 		l.SetSize(msg.Width, msg.Height)
 		if l.selectedCommit != nil && l.currentDiff != nil {
-			l.vp.SetContent(
-				lipgloss.JoinVertical(lipgloss.Left,
-					l.renderCommit(l.selectedCommit),
-					renderSummary(l.currentDiff, l.common.Styles, l.common.Width),
-					renderDiff(l.currentDiff, l.common.Width),
-				),
-			)
+			l.setDiffContent()
 		}
 		if l.repo != nil && l.ref != nil {
 			cmds = append(cmds,
-				l.updateCommitsCmd,
+				l.updateCommitsCmd(),
 				// start loading on resize since the number of commits per page
 				// might change and we'd need to load more commits.
 				l.startLoading(),
@@ -408,12 +509,31 @@ func (l *Log) StatusBarInfo() string {
 		// of the paginator hack above.
 		return fmt.Sprintf("p. %d/%d", l.nextPage+1, l.selector.TotalPages())
 	case logViewDiff:
-		return fmt.Sprintf("☰ %.f%%", l.vp.ScrollPercent()*100)
+		info := fmt.Sprintf("☰ %.f%%", l.vp.ScrollPercent()*100)
+		if l.prefs.DiffIgnoreWhitespace {
+			info = "-w " + info
+		}
+		return info
 	default:
 		return ""
 	}
 }
 
+// cancelLoading stops waiting for the commits or the diff being loaded, e.g.
+// in a large repository.
+func (l *Log) cancelLoading() {
+	cancelled := l.countFetch.stop()
+	cancelled = l.pageFetch.stop() || cancelled
+	cancelled = l.diffFetch.stop() || cancelled
+	if !cancelled {
+		return
+	}
+	l.activeView = logViewCommits
+	l.selectedCommit = nil
+	// Stay on the page shown before loading another one.
+	l.nextPage = l.selector.Page()
+}
+
 func (l *Log) goBack() {
 	if l.activeView == logViewDiff {
 		l.activeView = logViewCommits
@@ -421,23 +541,33 @@ func (l *Log) goBack() {
 	}
 }
 
-func (l *Log) countCommitsCmd() tea.Msg {
-	if l.ref == nil {
-		return nil
-	}
-	r, err := l.repo.Open()
-	if err != nil {
-		return common.ErrorMsg(err)
+func (l *Log) countCommitsCmd() tea.Cmd {
+	_, id := l.countFetch.start(l.common.Context())
+	return func() tea.Msg {
+		if l.ref == nil {
+			return nil
+		}
+		r, err := l.repo.Open()
+		if err != nil {
+			return common.ErrorMsg(err)
+		}
+		count, err := r.CountCommits(l.ref)
+		if err != nil {
+			l.common.Logger.Debugf("ui: error counting commits: %v", err)
+			return common.ErrorMsg(err)
+		}
+		return LogCountMsg{Count: count, fetch: id}
 	}
-	count, err := r.CountCommits(l.ref)
-	if err != nil {
-		l.common.Logger.Debugf("ui: error counting commits: %v", err)
-		return common.ErrorMsg(err)
+}
+
+func (l *Log) updateCommitsCmd() tea.Cmd {
+	_, id := l.pageFetch.start(l.common.Context())
+	return func() tea.Msg {
+		return l.updateCommits(id)
 	}
-	return LogCountMsg(count)
 }
 
-func (l *Log) updateCommitsCmd() tea.Msg {
+func (l *Log) updateCommits(id int) tea.Msg {
 	if l.ref == nil {
 		return nil
 	}
@@ -448,7 +578,7 @@ func (l *Log) updateCommitsCmd() tea.Msg {
 
 	count := l.count
 	if count == 0 {
-		return LogItemsMsg([]selector.IdentifiableItem{})
+		return LogItemsMsg{Items: []selector.IdentifiableItem{}, fetch: id}
 	}
 
 	page := l.nextPage
@@ -469,7 +599,7 @@ func (l *Log) updateCommitsCmd() tea.Msg {
 		}
 		items[idx] = LogItem{Commit: c}
 	}
-	return LogItemsMsg(items)
+	return LogItemsMsg{Items: items, fetch: id}
 }
 
 func (l *Log) selectCommitCmd(commit *git.Commit) tea.Cmd {
@@ -478,21 +608,55 @@ func (l *Log) selectCommitCmd(commit *git.Commit) tea.Cmd {
 	}
 }
 
-func (l *Log) loadDiffCmd() tea.Msg {
-	if l.selectedCommit == nil {
-		return nil
-	}
-	r, err := l.repo.Open()
-	if err != nil {
-		l.common.Logger.Debugf("ui: error loading diff repository: %v", err)
-		return common.ErrorMsg(err)
+func (l *Log) loadDiffCmd() tea.Cmd {
+	_, id := l.diffFetch.start(l.common.Context())
+	return func() tea.Msg {
+		if l.selectedCommit == nil {
+			return nil
+		}
+		ctx := l.common.Context()
+		be := backend.FromContext(ctx)
+		diff, err := be.Diff(ctx, l.repo.Name(), l.selectedCommit.ID.String(), diffOptions(l.prefs))
+		if err != nil {
+			l.common.Logger.Debugf("ui: error loading diff: %v", err)
+			return common.ErrorMsg(err)
+		}
+		return LogDiffMsg{Diff: diff, fetch: id}
 	}
-	diff, err := r.Diff(l.selectedCommit)
-	if err != nil {
-		l.common.Logger.Debugf("ui: error loading diff: %v", err)
-		return common.ErrorMsg(err)
+}
+
+// loadReferencesCmd loads the merge requests and issues linked to a commit.
+// The diff shows without them when they fail to load.
+func (l *Log) loadReferencesCmd(commit *git.Commit) tea.Cmd {
+	return func() tea.Msg {
+		if l.repo == nil {
+			return nil
+		}
+		ctx := l.common.Context()
+		be := backend.FromContext(ctx)
+		refs, err := be.CommitReferences(ctx, l.repo.Name(), commit.ID.String())
+		if err != nil {
+			l.common.Logger.Debugf("ui: error loading commit references: %v", err)
+			return nil
+		}
+		prefix, err := be.IssuePrefix(ctx, l.repo.Name())
+		if err != nil {
+			l.common.Logger.Debugf("ui: error loading issue prefix: %v", err)
+			return nil
+		}
+		return LogReferencesMsg{Commit: commit, References: refs, Prefix: prefix}
 	}
-	return LogDiffMsg(diff)
+}
+
+// setDiffContent renders the selected commit and its diff.
+func (l *Log) setDiffContent() {
+	l.vp.SetContent(
+		lipgloss.JoinVertical(lipgloss.Left,
+			l.renderCommit(l.selectedCommit),
+			renderSummary(l.currentDiff, l.common.Styles, l.common.Width),
+			renderDiff(l.currentDiff, l.common.Width, l.prefs),
+		),
+	)
 }
 
 func (l *Log) renderCommit(c *git.Commit) string {
@@ -506,6 +670,24 @@ func (l *Log) renderCommit(c *git.Commit) string {
 		l.common.Styles.Log.CommitDate.Render("Date:   "+c.Committer.When.Format(time.UnixDate)),
 		l.common.Styles.Log.CommitBody.Render(msg),
 	))
+	refs := l.references
+	if len(refs.MergeRequests) > 0 {
+		s.WriteString("\n")
+		for _, mr := range refs.MergeRequests {
+			s.WriteString(l.common.Styles.Log.CommitAuthor.Render(fmt.Sprintf("Merge request: #%d %s", mr.ID, mr.Title)) + "\n")
+		}
+	}
+	for _, ref := range []struct {
+		label  string
+		issues []models.Issue
+	}{
+		{"Closes", refs.Closes},
+		{"References", refs.Mentions},
+	} {
+		for _, issue := range ref.issues {
+			s.WriteString(l.common.Styles.Log.CommitAuthor.Render(fmt.Sprintf("%s: %s %s", ref.label, backend.FormatIssueRef(l.issuePrefix, issue.Number), issue.Title)) + "\n")
+		}
+	}
 	return wrap.String(s.String(), l.common.Width-2)
 }
 
@@ -523,11 +705,12 @@ func renderSummary(diff *git.Diff, styles *styles.Styles, width int) string {
 	return wrap.String(strings.Join(stats, "\n"), width-2)
 }
 
-func renderDiff(diff *git.Diff, width int) string {
+func renderDiff(diff *git.Diff, width int, prefs models.UserPreferences) string {
 	var s strings.Builder
 	var pr strings.Builder
+	tabWidth := max(prefs.DiffTabWidth, 1)
 	diffChroma := &gansi.CodeBlockElement{
-		Code:     diff.Patch(),
+		Code:     strings.ReplaceAll(diff.Patch(), "\t", strings.Repeat(" ", tabWidth)),
 		Language: "diff",
 	}
 	err := diffChroma.Render(&pr, common.StyleRenderer())
@@ -536,11 +719,19 @@ func renderDiff(diff *git.Diff, width int) string {
 	} else {
 		s.WriteString(fmt.Sprintf("\n%s", pr.String()))
 	}
-	return wrap.String(s.String(), width)
+	if prefs.DiffWordWrap {
+		return wrap.String(s.String(), width)
+	}
+	lines := strings.Split(s.String(), "\n")
+	for i, line := range lines {
+		lines[i] = ansi.Truncate(line, width, "…")
+	}
+	return strings.Join(lines, "\n")
 }
 
 func (l *Log) setItems(items []selector.IdentifiableItem) tea.Cmd {
+	_, id := l.pageFetch.start(l.common.Context())
 	return func() tea.Msg {
-		return LogItemsMsg(items)
+		return LogItemsMsg{Items: items, fetch: id}
 	}
 }
@@ -2,14 +2,20 @@ package repo
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/v2/key"
+	"github.com/charmbracelet/bubbles/v2/list"
 	"github.com/charmbracelet/bubbles/v2/spinner"
 	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss/v2"
 	"github.com/charmbracelet/soft-serve/git"
+	"github.com/charmbracelet/soft-serve/pkg/access"
 	"github.com/charmbracelet/soft-serve/pkg/backend"
 	"github.com/charmbracelet/soft-serve/pkg/db/models"
 	"github.com/charmbracelet/soft-serve/pkg/proto"
@@ -39,15 +45,58 @@ type MergeRequests struct {
 	selectedMR  *models.MergeRequest
 	mrDetails   string
 	stateFilter string
+	order       models.ListOrder
+	// split shows the list and the selected merge request side by side.
+	split bool
+	prefs models.UserPreferences
+	// marked holds the IDs of the merge requests marked for a bulk action.
+	marked map[int64]bool
+	// more is set when older merge requests may follow the listed ones.
+	more bool
+	// loadingMore is set while the next page of merge requests is fetched.
+	loadingMore bool
+	// reselect is the ID of the merge request selected when the list was reloaded,
+	// and reselectIndex its position, to select again once the list is back.
+	reselect      string
+	reselectIndex int
+	// total is the number of merge requests in the list, of which only the
+	// fetched pages are listed.
+	total int64
+	// listFetch is the fetch of the list, cancelled with esc while loading.
+	listFetch fetch
+	// cancelled is set when loading the list was cancelled.
+	cancelled bool
 }
 
-// MRItemsMsg is a message for merge request items.
-type MRItemsMsg []MRItem
+// MRItemsMsg is a message for a page of merge request items.
+type MRItemsMsg struct {
+	Items []MRItem
+	// After is the ID of the merge request the page follows, 0 for the first
+	// page.
+	After int64
+	// Order is the order of the list the page belongs to.
+	Order models.ListOrder
+	// More is set when more merge requests may follow the page.
+	More bool
+	// Total is the number of merge requests in the list, set with the first
+	// page.
+	Total int64
+	// fetch is the ID of the fetch the page comes from.
+	fetch int
+}
 
 // MRDetailMsg is a message for merge request details.
 type MRDetailMsg struct {
 	MR      models.MergeRequest
 	Details string
+	// Preview is set when the merge request is only shown next to the list.
+	Preview bool
+}
+
+// MRBulkClosedMsg is a message sent after closing the marked merge requests.
+type MRBulkClosedMsg struct {
+	IDs []int64
+	Err error
 }
 
 // MRActionMsg is a message for MR actions.
@@ -62,9 +111,11 @@ func NewMergeRequests(c common.Common) *MergeRequests {
 		common:      c,
 		activeView:  mrViewLoading,
 		stateFilter: "open",
+		prefs:       backend.DefaultUserPreferences(),
+		marked:      make(map[int64]bool),
 	}
 
-	s := selector.New(c, []selector.IdentifiableItem{}, MRItemDelegate{&c})
+	s := selector.New(c, []selector.IdentifiableItem{}, MRItemDelegate{common: &c, marked: mr.marked})
 	s.SetShowFilter(true)
 	s.SetShowHelp(false)
 	s.SetShowPagination(true)
@@ -88,6 +139,13 @@ func NewMergeRequests(c common.Common) *MergeRequests {
 // SetSize implements common.Component.
 func (mr *MergeRequests) SetSize(width, height int) {
 	mr.common.SetSize(width, height)
+	mr.split = isSplitPane(mr.common, width)
+	if mr.split {
+		lw, dw := splitPaneWidths(mr.common, width)
+		mr.selector.SetSize(lw, height)
+		mr.code.SetSize(dw, height)
+		return
+	}
 	mr.selector.SetSize(width, height)
 	mr.code.SetSize(width, height)
 }
@@ -100,7 +158,11 @@ func (mr *MergeRequests) ShortHelp() []key.Binding {
 		return []key.Binding{
 			k.UpDown,
 			k.Select,
+			k.ToggleMark,
+			k.SortList,
 		}
+	case mrViewLoading:
+		return []key.Binding{cancelKey(mr.common)}
 	case mrViewDetail:
 		return []key.Binding{
 			k.UpDown,
@@ -117,11 +179,16 @@ func (mr *MergeRequests) FullHelp() [][]key.Binding {
 	case mrViewList:
 		return [][]key.Binding{
 			{k.UpDown, k.Select},
+			{k.ToggleMark, k.CloseMarked},
+			{k.SortList, k.ReverseSort},
 			{k.Back},
 		}
+	case mrViewLoading:
+		return [][]key.Binding{{cancelKey(mr.common)}}
 	case mrViewDetail:
 		return [][]key.Binding{
 			{k.UpDown, k.Back},
+			{k.DiffWhitespace, k.DiffTabWidth},
 		}
 	}
 	return [][]key.Binding{}
@@ -130,9 +197,17 @@ func (mr *MergeRequests) FullHelp() [][]key.Binding {
 // Init implements tea.Model.
 func (mr *MergeRequests) Init() tea.Cmd {
 	mr.activeView = mrViewLoading
+	// Forget the merge request shown for the previous repository.
+	mr.code.SetContent("", "")
+	clear(mr.marked)
+	mr.more = false
+	mr.loadingMore = false
+	mr.reselect = ""
+	mr.total = 0
+	mr.cancelled = false
 	return tea.Batch(
 		mr.spinner.Tick,
-		mr.fetchMRsCmd,
+		mr.fetchMRsCmd(0),
 	)
 }
 
@@ -150,20 +225,86 @@ func (mr *MergeRequests) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return mr, mr.Init()
 
 	case MRItemsMsg:
-		mr.activeView = mrViewList
-		mr.items = msg
-		items := make([]selector.IdentifiableItem, len(msg))
-		for i, item := range msg {
-			items[i] = item
+		// Drop pages of a fetch cancelled or superseded since.
+		if !mr.listFetch.done(msg.fetch) {
+			break
+		}
+		// Drop pages of a list sorted differently since.
+		if msg.Order != mr.order {
+			break
+		}
+		if msg.After > 0 {
+			// Drop pages following a list that was reloaded since.
+			if !mr.loadingMore || msg.After != mr.lastID() {
+				break
+			}
+			mr.loadingMore = false
+			mr.more = msg.More
+			mr.items = append(mr.items, msg.Items...)
+			cmds = append(cmds, mr.selector.SetItems(mr.selectorItems()))
+			break
+		}
+		// Stay on a merge request opened while the list was loading.
+		if mr.activeView != mrViewDetail {
+			mr.activeView = mrViewList
+		}
+		mr.more = msg.More
+		mr.loadingMore = false
+		mr.total = msg.Total
+		mr.cancelled = false
+		mr.items = msg.Items
+		cmds = append(cmds, mr.selector.SetItems(mr.selectorItems()))
+		// Keep the user's place in a reloaded list.
+		if mr.reselect != "" {
+			mr.selector.SelectID(mr.reselect, mr.reselectIndex)
+			mr.reselect = ""
+		}
+		if item, ok := mr.selector.SelectedItem().(MRItem); ok && mr.split {
+			cmds = append(cmds, mr.fetchMRPreviewCmd(item.MR.ID))
 		}
-		cmds = append(cmds, mr.selector.SetItems(items))
 
 	case MRDetailMsg:
+		if msg.Preview {
+			// Drop previews of merge requests the cursor already left.
+			item, ok := mr.selector.SelectedItem().(MRItem)
+			if mr.activeView == mrViewList && ok && item.MR.ID == msg.MR.ID {
+				mr.mrDetails = msg.Details
+				cmds = append(cmds, mr.code.SetContent(msg.Details, ""))
+			}
+			break
+		}
 		mr.activeView = mrViewDetail
 		mr.selectedMR = &msg.MR
 		mr.mrDetails = msg.Details
 		cmds = append(cmds, mr.code.SetContent(msg.Details, ""))
 
+	case MRBulkClosedMsg:
+		for _, id := range msg.IDs {
+			delete(mr.marked, id)
+		}
+		cmds = append(cmds, mr.reloadCmd())
+		if msg.Err != nil {
+			cmds = append(cmds, func() tea.Msg { return common.ErrorMsg(msg.Err) })
+		}
+
+	case ShowMergeRequestMsg:
+		cmds = append(cmds, mr.fetchMRDetailCmd(int64(msg)))
+
+	case DiffPrefsMsg:
+		mr.prefs = models.UserPreferences(msg)
+		if mr.activeView == mrViewDetail && mr.selectedMR != nil {
+			cmds = append(cmds, mr.fetchMRDetailCmd(mr.selectedMR.ID))
+		}
+
+	case selector.ActiveMsg:
+		if item, ok := msg.IdentifiableItem.(MRItem); ok && mr.split && mr.activeView == mrViewList {
+			cmds = append(cmds, mr.fetchMRPreviewCmd(item.MR.ID))
+		}
+		if mr.more && !mr.loadingMore && mr.selector.Index() >= len(mr.items)-mr.selector.PerPage() {
+			mr.loadingMore = true
+			cmds = append(cmds, mr.fetchMRsCmd(mr.lastID()))
+		}
+
 	case selector.SelectMsg:
 		switch item := msg.IdentifiableItem.(type) {
 		case MRItem:
@@ -173,10 +314,38 @@ func (mr *MergeRequests) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case tea.KeyPressMsg:
 		switch mr.activeView {
+		case mrViewLoading:
+			// Stop waiting for a list that takes long to load.
+			if key.Matches(msg, mr.common.KeyMap.Back) && mr.listFetch.stop() {
+				mr.activeView = mrViewList
+				mr.cancelled = true
+				return mr, nil
+			}
 		case mrViewList:
+			if mr.selector.FilterState() == list.Filtering {
+				break
+			}
 			switch {
 			case key.Matches(msg, mr.common.KeyMap.SelectItem):
 				cmds = append(cmds, mr.selector.SelectItemCmd)
+			case key.Matches(msg, mr.common.KeyMap.ToggleMark):
+				if item, ok := mr.selector.SelectedItem().(MRItem); ok {
+					if mr.marked[item.MR.ID] {
+						delete(mr.marked, item.MR.ID)
+					} else {
+						mr.marked[item.MR.ID] = true
+					}
+				}
+			case key.Matches(msg, mr.common.KeyMap.CloseMarked):
+				if len(mr.marked) > 0 {
+					cmds = append(cmds, mr.closeMarkedCmd())
+				}
+			case key.Matches(msg, mr.common.KeyMap.SortList):
+				mr.order = nextListOrder(mr.order)
+				cmds = append(cmds, mr.reloadCmd())
+			case key.Matches(msg, mr.common.KeyMap.ReverseSort):
+				mr.order.Ascending = !mr.order.Ascending
+				cmds = append(cmds, mr.reloadCmd())
 			}
 		case mrViewDetail:
 			switch {
@@ -184,6 +353,10 @@ func (mr *MergeRequests) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				mr.activeView = mrViewList
 				mr.selectedMR = nil
 				return mr, nil
+			default:
+				if cmd := updateDiffPrefsCmd(mr.common, mr.prefs, msg); cmd != nil {
+					cmds = append(cmds, cmd)
+				}
 			}
 		}
 
@@ -220,6 +393,13 @@ func (mr *MergeRequests) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 // View implements tea.Model.
 func (mr *MergeRequests) View() string {
+	if mr.split {
+		list := mr.selector.View()
+		if mr.activeView == mrViewLoading {
+			list = renderLoading(mr.common, mr.spinner)
+		}
+		return renderSplitPane(mr.common, list, mr.code.View(), mr.activeView == mrViewDetail)
+	}
 	switch mr.activeView {
 	case mrViewLoading:
 		return renderLoading(mr.common, mr.spinner)
@@ -235,7 +415,7 @@ func (mr *MergeRequests) View() string {
 func (mr *MergeRequests) StatusBarValue() string {
 	switch mr.activeView {
 	case mrViewList:
-		return fmt.Sprintf("Merge Requests (%d)", len(mr.items))
+		return listStatus("Merge Requests", len(mr.items), mr.total, mr.cancelled)
 	case mrViewDetail:
 		if mr.selectedMR != nil {
 			return fmt.Sprintf("MR #%d", mr.selectedMR.ID)
@@ -249,7 +429,10 @@ func (mr *MergeRequests) StatusBarValue() string {
 func (mr *MergeRequests) StatusBarInfo() string {
 	switch mr.activeView {
 	case mrViewList:
-		return fmt.Sprintf("Filter: %s", mr.stateFilter)
+		if len(mr.marked) > 0 {
+			return fmt.Sprintf("Filter: %s • Sort: %s • %d marked", mr.stateFilter, mr.order, len(mr.marked))
+		}
+		return fmt.Sprintf("Filter: %s • Sort: %s", mr.stateFilter, mr.order)
 	case mrViewDetail:
 		if mr.selectedMR != nil {
 			return fmt.Sprintf("%s → %s • %s",
@@ -273,61 +456,178 @@ func (mr *MergeRequests) TabName() string {
 
 // Path implements common.TabComponent.
 func (mr *MergeRequests) Path() string {
+	// Keep esc for cancelling the fetch rather than leaving the repository.
+	if mr.activeView == mrViewLoading {
+		return "loading"
+	}
 	if mr.selectedMR != nil {
 		return fmt.Sprintf("#%d", mr.selectedMR.ID)
 	}
 	return ""
 }
 
-// fetchMRsCmd fetches merge requests for the repository.
-func (mr *MergeRequests) fetchMRsCmd() tea.Msg {
-	if mr.repo == nil {
-		return common.ErrorMsg(common.ErrMissingRepo)
+// selectorItems returns the listed merge requests as selector items.
+func (mr *MergeRequests) selectorItems() []selector.IdentifiableItem {
+	items := make([]selector.IdentifiableItem, len(mr.items))
+	for i, item := range mr.items {
+		items[i] = item
 	}
+	return items
+}
 
-	ctx := mr.common.Context()
-	be := backend.FromContext(ctx)
+// reloadCmd fetches the list again from the first page, e.g. once it's
+// sorted differently. The selected item stays selected once the list is
+// back, or the cursor stays where it was if the item is no longer listed.
+func (mr *MergeRequests) reloadCmd() tea.Cmd {
+	mr.more = false
+	mr.loadingMore = false
+	mr.reselect = ""
+	if item := mr.selector.SelectedItem(); item != nil {
+		mr.reselect = item.ID()
+	}
+	mr.reselectIndex = mr.selector.Index()
+	return mr.fetchMRsCmd(0)
+}
 
-	// Parse state filter
-	var state *models.MergeRequestState
-	switch mr.stateFilter {
-	case "open":
-		s := models.MergeRequestStateOpen
-		state = &s
-	case "merged":
-		s := models.MergeRequestStateMerged
-		state = &s
-	case "closed":
-		s := models.MergeRequestStateClosed
-		state = &s
-	}
-
-	mrs, err := be.ListMergeRequests(ctx, mr.repo.Name(), state)
-	if err != nil {
-		return common.ErrorMsg(err)
+// lastID returns the ID of the last listed merge request.
+func (mr *MergeRequests) lastID() int64 {
+	if len(mr.items) == 0 {
+		return 0
 	}
+	return mr.items[len(mr.items)-1].MR.ID
+}
 
-	items := make([]MRItem, 0, len(mrs))
-	for _, m := range mrs {
-		// Get author name
-		authorName := "unknown"
-		if m.AuthorID > 0 {
-			author, err := be.UserByID(ctx, m.AuthorID)
-			if err == nil && author != nil {
-				authorName = author.Username()
-			}
+// fetchMRsCmd fetches the page of merge requests of the repository following
+// the merge request with ID after, or the first page if after is 0.
+func (mr *MergeRequests) fetchMRsCmd(after int64) tea.Cmd {
+	order := mr.order
+	ctx, id := mr.listFetch.start(mr.common.Context())
+	return func() tea.Msg {
+		if mr.repo == nil {
+			return common.ErrorMsg(common.ErrMissingRepo)
+		}
+
+		be := backend.FromContext(ctx)
+
+		// Parse state filter
+		var state *models.MergeRequestState
+		switch mr.stateFilter {
+		case "open":
+			s := models.MergeRequestStateOpen
+			state = &s
+		case "merged":
+			s := models.MergeRequestStateMerged
+			state = &s
+		case "closed":
+			s := models.MergeRequestStateClosed
+			state = &s
 		}
 
-		items = append(items, MRItem{
-			MR:         m,
-			AuthorName: authorName,
+		mrs, err := be.ListMergeRequests(ctx, mr.repo.Name(), backend.MergeRequestListOptions{
+			State: state,
+			Order: order,
+			Limit: listPageSize,
+			After: after,
 		})
+		if errors.Is(err, backend.ErrMergeRequestsDisabled) {
+			// The tab is hidden.
+			return MRItemsMsg{After: after, Order: order, fetch: id}
+		}
+		if err != nil {
+			return fetchErrorMsg(ctx, err)
+		}
+
+		deps, err := be.MergeRequestDependencyIDs(ctx, mr.repo.Name())
+		if err != nil {
+			return fetchErrorMsg(ctx, err)
+		}
+		approvals, err := be.MergeRequestApprovalCounts(ctx, mr.repo.Name())
+		if err != nil {
+			return fetchErrorMsg(ctx, err)
+		}
+		required, err := be.RequiredApprovals(ctx, mr.repo.Name())
+		if err != nil {
+			return fetchErrorMsg(ctx, err)
+		}
+
+		// The first page comes with the number of merge requests to tell how
+		// many are still to be fetched.
+		var total int64
+		if after == 0 {
+			total, err = be.CountMergeRequests(ctx, mr.repo.Name(), state)
+			if err != nil {
+				return fetchErrorMsg(ctx, err)
+			}
+		}
+
+		items := make([]MRItem, 0, len(mrs))
+		for _, m := range mrs {
+			// Get author name
+			authorName := "unknown"
+			if m.AuthorID > 0 {
+				author, err := be.UserByID(ctx, m.AuthorID)
+				if err == nil && author != nil {
+					authorName = proto.DisplayName(author)
+				}
+			}
+
+			items = append(items, MRItem{
+				MR:                m,
+				AuthorName:        authorName,
+				DependsOn:         deps[m.ID],
+				Approvals:         approvals[m.ID],
+				RequiredApprovals: required,
+			})
+		}
+
+		return MRItemsMsg{
+			Items: items,
+			After: after,
+			Order: order,
+			Total: total,
+			fetch: id,
+			More:  len(mrs) == listPageSize,
+		}
+	}
+}
+
+// closeMarkedCmd closes the marked merge requests.
+func (mr *MergeRequests) closeMarkedCmd() tea.Cmd {
+	ids := make([]int64, 0, len(mr.marked))
+	for id := range mr.marked {
+		ids = append(ids, id)
 	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	return func() tea.Msg {
+		if mr.repo == nil {
+			return common.ErrorMsg(common.ErrMissingRepo)
+		}
+
+		ctx := mr.common.Context()
+		be := backend.FromContext(ctx)
 
-	// Sort by update time (most recent first)
-	sort.Sort(MRItems(items))
+		user := currentUser(mr.common)
+		if user == nil {
+			return common.ErrorMsg(proto.ErrUserNotFound)
+		}
+		if be.AccessLevelForUser(ctx, mr.repo.Name(), user) < access.ReadWriteAccess {
+			return common.ErrorMsg(proto.ErrUnauthorized)
+		}
+
+		ctx = proto.WithUserContext(ctx, user)
+		closed := make([]int64, 0, len(ids))
+		var errs []error
+		for _, id := range ids {
+			if err := be.CloseMergeRequest(ctx, mr.repo.Name(), id); err != nil {
+				errs = append(errs, fmt.Errorf("merge request #%d: %w", id, err))
+				continue
+			}
+			closed = append(closed, id)
+		}
 
-	return MRItemsMsg(items)
+		return MRBulkClosedMsg{IDs: closed, Err: errors.Join(errs...)}
+	}
 }
 
 // fetchMRDetailCmd fetches details for a specific merge request.
@@ -355,6 +655,20 @@ func (mr *MergeRequests) fetchMRDetailCmd(mrID int64) tea.Cmd {
 	}
 }
 
+// fetchMRPreviewCmd fetches the details of the merge request under the cursor
+// to show them next to the list.
+func (mr *MergeRequests) fetchMRPreviewCmd(mrID int64) tea.Cmd {
+	fetch := mr.fetchMRDetailCmd(mrID)
+	return func() tea.Msg {
+		msg := fetch()
+		if detail, ok := msg.(MRDetailMsg); ok {
+			detail.Preview = true
+			return detail
+		}
+		return msg
+	}
+}
+
 // buildMRDetails builds a detailed text view of the merge request.
 func (mr *MergeRequests) buildMRDetails(ctx context.Context, m models.MergeRequest) string {
 	var sb strings.Builder
@@ -382,35 +696,107 @@ func (mr *MergeRequests) buildMRDetails(ctx context.Context, m models.MergeReque
 	// Branches
 	sb.WriteString(st.DetailLabel.Render("Branches:"))
 	sb.WriteString("\n")
-	sb.WriteString(fmt.Sprintf("  %s → %s\n\n", m.SourceBranch, m.TargetBranch))
+	sb.WriteString(fmt.Sprintf("  %s → %s", m.SourceBranch, m.TargetBranch))
+	if m.State == models.MergeRequestStateOpen {
+		if ahead, behind, err := be.AheadBehind(ctx, mr.repo.Name(), git.RefsHeads+m.TargetBranch, git.RefsHeads+m.SourceBranch); err == nil {
+			sb.WriteString(fmt.Sprintf(" (%d ahead, %d behind)", ahead, behind))
+		}
+	}
+	sb.WriteString("\n\n")
 
 	// State
 	sb.WriteString(st.DetailLabel.Render("State: "))
 	sb.WriteString(m.State.String())
+	if m.Draft {
+		sb.WriteString(" (draft)")
+	}
 	sb.WriteString("\n\n")
 
+	// Approvals
+	if approvers, err := be.MergeRequestApprovers(ctx, mr.repo.Name(), m.ID); err == nil {
+		required, _ := be.RequiredApprovals(ctx, mr.repo.Name())
+		if required > 0 || len(approvers) > 0 {
+			sb.WriteString(st.DetailLabel.Render("Approvals: "))
+			sb.WriteString(backend.ApprovalStatus{Approvals: len(approvers), Required: required}.String())
+			if len(approvers) > 0 {
+				sb.WriteString(" (" + strings.Join(approvers, ", ") + ")")
+			}
+			sb.WriteString("\n\n")
+		}
+	}
+
+	// Stack
+	if deps, err := be.GetMergeRequestDependencies(ctx, mr.repo.Name(), m.ID); err == nil && len(deps) > 0 {
+		sb.WriteString(st.DetailLabel.Render("Depends on:"))
+		sb.WriteString("\n")
+		for _, dep := range deps {
+			sb.WriteString(fmt.Sprintf("  #%d %s (%s)\n", dep.ID, dep.Title, dep.State.String()))
+		}
+		sb.WriteString("\n")
+	}
+	if deps, err := be.GetMergeRequestDependents(ctx, mr.repo.Name(), m.ID); err == nil && len(deps) > 0 {
+		sb.WriteString(st.DetailLabel.Render("Required by:"))
+		sb.WriteString("\n")
+		for _, dep := range deps {
+			sb.WriteString(fmt.Sprintf("  #%d %s (%s)\n", dep.ID, dep.Title, dep.State.String()))
+		}
+		sb.WriteString("\n")
+	}
+
+	// Issues and merge requests referencing this one
+	subject := backend.Subject{Type: backend.NotificationSubjectMergeRequest, ID: m.ID}
+	if refs, err := be.ReferencedBy(ctx, mr.repo.Name(), subject); err == nil && len(refs) > 0 {
+		sb.WriteString(st.DetailLabel.Render("Referenced by:"))
+		sb.WriteString("\n")
+		for _, ref := range refs {
+			sb.WriteString(fmt.Sprintf("  %s - %s\n", ref.Ref, ref.Title))
+		}
+		sb.WriteString("\n")
+	}
+
+	// Attachments
+	if as, err := be.Attachments(ctx, mr.repo.Name(), subject); err == nil && len(as) > 0 {
+		sb.WriteString(st.DetailLabel.Render("Attachments:"))
+		sb.WriteString("\n")
+		for _, a := range as {
+			sb.WriteString(fmt.Sprintf("  %s - %s\n", a.Name, be.AttachmentURL(mr.repo.Name(), a)))
+		}
+		sb.WriteString("\n")
+	}
+
+	// Comments
+	threads, _ := be.MergeRequestThreads(ctx, mr.repo.Name(), m.ID)
+	if len(threads) > 0 {
+		sb.WriteString(st.DetailLabel.Render("Comments:"))
+		sb.WriteString("\n")
+		for _, t := range threads {
+			sb.WriteString(mr.formatThread(ctx, "  ", t))
+		}
+		sb.WriteString("\n")
+	}
+
 	// Author
 	if m.AuthorID > 0 {
 		author, err := be.UserByID(ctx, m.AuthorID)
 		if err == nil && author != nil {
 			sb.WriteString(st.DetailLabel.Render("Author: "))
-			sb.WriteString(author.Username())
+			sb.WriteString(proto.DisplayName(author))
 			sb.WriteString("\n\n")
 		}
 	}
 
 	// Timestamps
 	sb.WriteString(st.DetailLabel.Render("Created: "))
-	sb.WriteString(m.CreatedAt.Format("2006-01-02 15:04:05"))
+	sb.WriteString(mr.common.Dates.Absolute(m.CreatedAt))
 	sb.WriteString("\n")
 
 	sb.WriteString(st.DetailLabel.Render("Updated: "))
-	sb.WriteString(m.UpdatedAt.Format("2006-01-02 15:04:05"))
+	sb.WriteString(mr.common.Dates.Absolute(m.UpdatedAt))
 	sb.WriteString("\n")
 
 	if m.MergedAt.Valid {
 		sb.WriteString(st.DetailLabel.Render("Merged: "))
-		sb.WriteString(m.MergedAt.Time.Format("2006-01-02 15:04:05"))
+		sb.WriteString(mr.common.Dates.Absolute(m.MergedAt.Time))
 		if m.MergedBy.Valid {
 			mergedBy, err := be.UserByID(ctx, m.MergedBy.Int64)
 			if err == nil && mergedBy != nil {
@@ -422,7 +808,7 @@ func (mr *MergeRequests) buildMRDetails(ctx context.Context, m models.MergeReque
 
 	if m.ClosedAt.Valid {
 		sb.WriteString(st.DetailLabel.Render("Closed: "))
-		sb.WriteString(m.ClosedAt.Time.Format("2006-01-02 15:04:05"))
+		sb.WriteString(mr.common.Dates.Absolute(m.ClosedAt.Time))
 		if m.ClosedBy.Valid {
 			closedBy, err := be.UserByID(ctx, m.ClosedBy.Int64)
 			if err == nil && closedBy != nil {
@@ -440,32 +826,100 @@ func (mr *MergeRequests) buildMRDetails(ctx context.Context, m models.MergeReque
 	sb.WriteString(st.DetailLabel.Render("Changes:"))
 	sb.WriteString("\n\n")
 
-	r, err := mr.repo.Open()
-	if err == nil {
-		diff, err := mr.getDiff(r, m.SourceBranch, m.TargetBranch)
-		if err == nil && diff != "" {
-			sb.WriteString(diff)
-		} else {
-			sb.WriteString("Unable to generate diff\n")
-		}
+	diff, err := mr.getDiff(ctx, m.SourceBranch)
+	if err == nil && diff != "" {
+		sb.WriteString(mr.annotateDiff(ctx, diff, threads))
+	} else {
+		sb.WriteString("Unable to generate diff\n")
 	}
 
 	return sb.String()
 }
 
-// getDiff gets the diff between two branches.
-func (mr *MergeRequests) getDiff(repo *git.Repository, source, target string) (string, error) {
-	// Get commit for source branch
-	commit, err := repo.CatFileCommit(fmt.Sprintf("refs/heads/%s", source))
-	if err != nil {
-		return "", fmt.Errorf("failed to get source commit: %w", err)
+// formatThread formats a merge request comment thread for the details view.
+func (mr *MergeRequests) formatThread(ctx context.Context, prefix string, t backend.MergeRequestThread) string {
+	s := mr.formatComment(ctx, prefix, t.MergeRequestComment)
+	for _, r := range t.Replies {
+		s += mr.formatComment(ctx, prefix+"  ↳ ", r)
 	}
+	return s
+}
 
-	// Get diff for the commit
-	diff, err := repo.Diff(commit)
+// formatComment formats a merge request comment for the details view.
+func (mr *MergeRequests) formatComment(ctx context.Context, prefix string, c models.MergeRequestComment) string {
+	author := "a deleted user"
+	if u, err := backend.FromContext(ctx).UserByID(ctx, c.AuthorID); err == nil && u != nil {
+		author = proto.DisplayName(u)
+	}
+	var anchor string
+	if !c.CommentAnchor.IsZero() {
+		anchor = fmt.Sprintf(" on %s:%s", c.Path, c.Lines())
+		if c.Outdated {
+			anchor += ", outdated"
+		}
+	}
+	indent := strings.Repeat(" ", lipgloss.Width(prefix))
+	body := strings.ReplaceAll(c.Body, "\n", "\n"+indent)
+	return fmt.Sprintf("%s%s (%s%s): %s\n", prefix, author, mr.common.Dates.Relative(c.CreatedAt), anchor, body)
+}
+
+// hunkHeader matches the header of a patch hunk, capturing the first line of
+// the new side.
+var hunkHeader = regexp.MustCompile(`^@@ -\d+(?:,\d+)? \+(\d+)(?:,\d+)? @@`)
+
+// annotateDiff inserts the threads anchored to lines of the diff below the
+// last line they're anchored to. Outdated threads are only listed with the
+// other comments.
+func (mr *MergeRequests) annotateDiff(ctx context.Context, diff string, threads []backend.MergeRequestThread) string {
+	inline := make(map[string]map[int][]backend.MergeRequestThread)
+	for _, t := range threads {
+		if t.CommentAnchor.IsZero() || t.Outdated {
+			continue
+		}
+		if inline[t.Path] == nil {
+			inline[t.Path] = make(map[int][]backend.MergeRequestThread)
+		}
+		inline[t.Path][t.EndLine] = append(inline[t.Path][t.EndLine], t)
+	}
+	if len(inline) == 0 {
+		return diff
+	}
+
+	var sb strings.Builder
+	var path string
+	var line int
+	inHunk := false
+	for _, l := range strings.SplitAfter(diff, "\n") {
+		sb.WriteString(l)
+		switch {
+		case strings.HasPrefix(l, "diff --git "):
+			path, inHunk = "", false
+		case !inHunk && strings.HasPrefix(l, "+++ b/"):
+			path = strings.TrimSuffix(strings.TrimPrefix(l, "+++ b/"), "\n")
+		case strings.HasPrefix(l, "@@"):
+			if m := hunkHeader.FindStringSubmatch(l); m != nil {
+				line, _ = strconv.Atoi(m[1])
+				line--
+				inHunk = true
+			}
+		case inHunk && (strings.HasPrefix(l, "+") || strings.HasPrefix(l, " ")):
+			line++
+			for _, t := range inline[path][line] {
+				sb.WriteString(mr.formatThread(ctx, "    ┃ ", t))
+			}
+		}
+	}
+	return sb.String()
+}
+
+// getDiff gets the diff of the head of the source branch.
+func (mr *MergeRequests) getDiff(ctx context.Context, source string) (string, error) {
+	be := backend.FromContext(ctx)
+	diff, err := be.Diff(ctx, mr.repo.Name(), "refs/heads/"+source, diffOptions(mr.prefs))
 	if err != nil {
 		return "", fmt.Errorf("failed to get diff: %w", err)
 	}
 
-	return diff.Patch(), nil
+	tabWidth := max(mr.prefs.DiffTabWidth, 1)
+	return strings.ReplaceAll(diff.Patch(), "\t", strings.Repeat(" ", tabWidth)), nil
 }
@@ -38,6 +38,12 @@ type MRCreatedMsg struct {
 	RepoName string
 }
 
+// contributingGuideMsg is sent with the contributing guide of the repository
+// when the user is a first-time contributor.
+type contributingGuideMsg struct {
+	guide backend.ContributingGuide
+}
+
 // MRForm is a component for creating merge requests.
 type MRForm struct {
 	common       common.Common
@@ -53,6 +59,9 @@ type MRForm struct {
 	descInput    textinput.Model
 	focusIndex   int
 
+	// guide is the contributing guide, shown to first-time contributors.
+	guide *backend.ContributingGuide
+
 	// Result
 	createdMRID  int64
 	err          error
@@ -87,7 +96,7 @@ func NewMRForm(c common.Common, sourceBranch string) *MRForm {
 
 // Init implements tea.Model.
 func (f *MRForm) Init() tea.Cmd {
-	return f.fetchBranchesCmd()
+	return tea.Batch(f.fetchBranchesCmd(), f.fetchContributingGuideCmd())
 }
 
 // Update implements tea.Model.
@@ -97,7 +106,10 @@ func (f *MRForm) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case RepoMsg:
 		f.repo = msg
-		return f, f.fetchBranchesCmd()
+		return f, tea.Batch(f.fetchBranchesCmd(), f.fetchContributingGuideCmd())
+
+	case contributingGuideMsg:
+		f.guide = &msg.guide
 
 	case RefItemsMsg:
 		// Build branch list from refs
@@ -281,6 +293,18 @@ func (f *MRForm) viewEnterDetails() string {
 	b.WriteString(s.MR.DetailLabel.Render(branches))
 	b.WriteString("\n\n")
 
+	// Contributing guide
+	if f.guide != nil {
+		b.WriteString(s.MR.ItemFirstContribution.Render("Welcome, this is your first contribution!"))
+		b.WriteString(" Please read the contributing guide, " + f.guide.Path + ":")
+		b.WriteString("\n")
+		if f.guide.Summary != "" {
+			b.WriteString(s.HelpValue.Width(70).Render(f.guide.Summary))
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+	}
+
 	// Title input
 	b.WriteString(s.MR.DetailLabel.Render("Title:"))
 	b.WriteString("\n")
@@ -359,6 +383,30 @@ func (f *MRForm) fetchBranchesCmd() tea.Cmd {
 	}
 }
 
+// fetchContributingGuideCmd fetches the contributing guide of the repository
+// if the user is a first-time contributor.
+func (f *MRForm) fetchContributingGuideCmd() tea.Cmd {
+	return func() tea.Msg {
+		if f.repo == nil {
+			return nil
+		}
+
+		ctx := f.common.Context()
+		be := backend.FromContext(ctx)
+		first, err := be.IsFirstContribution(ctx, f.repo.Name(), proto.UserFromContext(ctx))
+		if err != nil || !first {
+			return nil
+		}
+
+		guide, err := be.ContributingGuide(ctx, f.repo.Name())
+		if err != nil {
+			return nil
+		}
+
+		return contributingGuideMsg{guide: guide}
+	}
+}
+
 // createMRCmd creates the merge request via backend.
 func (f *MRForm) createMRCmd() tea.Cmd {
 	return func() tea.Msg {
@@ -3,6 +3,7 @@ package repo
 import (
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/charmbracelet/bubbles/v2/key"
 	"github.com/charmbracelet/bubbles/v2/list"
@@ -10,7 +11,6 @@ import (
 	"github.com/charmbracelet/lipgloss/v2"
 	"github.com/charmbracelet/soft-serve/pkg/db/models"
 	"github.com/charmbracelet/soft-serve/pkg/ui/common"
-	"github.com/dustin/go-humanize"
 	"github.com/muesli/reflow/truncate"
 )
 
@@ -18,6 +18,12 @@ import (
 type MRItem struct {
 	MR         models.MergeRequest
 	AuthorName string
+	// DependsOn holds the IDs of the merge requests this one is stacked on.
+	DependsOn []int64
+	// Approvals is the number of approvals of the merge request, and
+	// RequiredApprovals the number its repository requires to merge it.
+	Approvals         int
+	RequiredApprovals int
 }
 
 // ID implements selector.IdentifiableItem.
@@ -44,23 +50,11 @@ func (i MRItem) FilterValue() string {
 	return fmt.Sprintf("%d %s", i.MR.ID, i.MR.Title)
 }
 
-// MRItems is a list of merge requests.
-type MRItems []MRItem
-
-// Len implements sort.Interface.
-func (items MRItems) Len() int { return len(items) }
-
-// Swap implements sort.Interface.
-func (items MRItems) Swap(i, j int) { items[i], items[j] = items[j], items[i] }
-
-// Less implements sort.Interface (most recent first).
-func (items MRItems) Less(i, j int) bool {
-	return items[i].MR.UpdatedAt.After(items[j].MR.UpdatedAt)
-}
-
 // MRItemDelegate is the delegate for the merge request item.
 type MRItemDelegate struct {
 	common *common.Common
+	// marked holds the IDs of the merge requests marked for a bulk action.
+	marked map[int64]bool
 }
 
 // Height implements list.ItemDelegate.
@@ -110,6 +104,9 @@ func (d MRItemDelegate) Render(w io.Writer, m list.Model, index int, listItem li
 	case models.MergeRequestStateOpen:
 		stateSt = st.ItemStateOpen
 		stateBadge = "●"
+		if i.MR.Draft {
+			stateBadge = "◌"
+		}
 	case models.MergeRequestStateMerged:
 		stateSt = st.ItemStateMerged
 		stateBadge = "✓"
@@ -120,6 +117,9 @@ func (d MRItemDelegate) Render(w io.Writer, m list.Model, index int, listItem li
 
 	mrNum := st.ItemNumber.Render(fmt.Sprintf("#%d", i.MR.ID))
 	badge := stateSt.Render(stateBadge)
+	if d.marked[i.MR.ID] {
+		badge = s.ItemMarked.String()
+	}
 
 	// Title
 	title := i.MR.Title
@@ -144,7 +144,7 @@ func (d MRItemDelegate) Render(w io.Writer, m list.Model, index int, listItem li
 		title,
 	)
 
-	// Second line: branches + author + time
+	// Second line: branches + stack + author + time
 	branches := fmt.Sprintf("%s → %s", i.MR.SourceBranch, i.MR.TargetBranch)
 	branchesRendered := st.ItemBranches.Render(branches)
 
@@ -154,10 +154,34 @@ func (d MRItemDelegate) Render(w io.Writer, m list.Model, index int, listItem li
 	}
 	authorRendered := st.ItemAuthor.Render(author)
 
-	timeAgo := humanize.Time(i.MR.UpdatedAt)
+	timeAgo := d.common.Dates.Relative(i.MR.UpdatedAt)
 	timeRendered := st.ItemTime.Render(" • " + timeAgo)
 
-	secondLineContent := branchesRendered + authorRendered + timeRendered
+	stack := ""
+	if len(i.DependsOn) > 0 {
+		refs := make([]string, len(i.DependsOn))
+		for j, id := range i.DependsOn {
+			refs[j] = fmt.Sprintf("#%d", id)
+		}
+		stack = " • on " + strings.Join(refs, ", ")
+	}
+	stackRendered := st.ItemBranches.Render(stack)
+
+	approvals := ""
+	if i.RequiredApprovals > 0 {
+		approvals = fmt.Sprintf(" • %d/%d approvals", i.Approvals, i.RequiredApprovals)
+	} else if i.Approvals > 0 {
+		approvals = fmt.Sprintf(" • %d approvals", i.Approvals)
+	}
+	approvalsRendered := st.ItemAuthor.Render(approvals)
+
+	secondLineContent := branchesRendered + stackRendered + approvalsRendered + authorRendered + timeRendered
+	if i.MR.Draft {
+		secondLineContent = s.ItemDraft.String() + st.ItemTime.Render(" • ") + secondLineContent
+	}
+	if i.MR.FirstContribution {
+		secondLineContent += st.ItemTime.Render(" • ") + s.ItemFirstContribution.String()
+	}
 
 	// Calculate padding for second line to align with first line
 	secondLineMargin := m.Width() -
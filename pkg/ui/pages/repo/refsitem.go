@@ -12,7 +12,6 @@ import (
 	"github.com/charmbracelet/lipgloss/v2"
 	"github.com/charmbracelet/soft-serve/git"
 	"github.com/charmbracelet/soft-serve/pkg/ui/common"
-	"github.com/dustin/go-humanize"
 	"github.com/muesli/reflow/truncate"
 )
 
@@ -171,7 +170,7 @@ func (d RefItemDelegate) Render(w io.Writer, m list.Model, index int, listItem l
 			lipgloss.Width(sha) -
 			2 // 2 is for the padding and truncation symbol
 		if onMargin >= 0 {
-			on := common.TruncateString("updated "+humanize.Time(c.Committer.When), onMargin)
+			on := common.TruncateString("updated "+d.common.Dates.Relative(c.Committer.When), onMargin)
 			desc += " " + st.ItemDesc.Render(on)
 		}
 	}
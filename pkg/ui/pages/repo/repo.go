@@ -34,6 +34,13 @@ type CopyURLMsg struct{}
 // RepoMsg is a message that contains a git.Repository.
 type RepoMsg proto.Repository // nolint:revive
 
+// RepoFeaturesMsg is a message that contains the features a repository
+// turned on.
+type RepoFeaturesMsg struct {
+	Issues        bool
+	MergeRequests bool
+}
+
 // GoBackMsg is a message to go back to the previous view.
 type GoBackMsg struct{}
 
@@ -58,6 +65,8 @@ type Repo struct {
 	state        state
 	spinner      spinner.Model
 	panesReady   []bool
+	// pending is where to jump to once the repository is loaded.
+	pending *GotoMsg
 }
 
 // New returns a new Repo.
@@ -153,16 +162,44 @@ func (r *Repo) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case RepoMsg:
 		// Set the state to loading when we get a new repository.
 		r.selectedRepo = msg
+		r.pending = nil
 		cmds = append(cmds,
 			r.Init(),
 			// This will set the selected repo in each pane's model.
 			r.updateModels(msg),
+			loadDiffPrefsCmd(r.common),
+			loadRepoFeaturesCmd(r.common, msg),
 		)
 	case RefMsg:
 		r.ref = msg
 		cmds = append(cmds, r.updateModels(msg))
 		r.state = readyState
+		if r.pending != nil {
+			cmds = append(cmds, r.gotoCmd(*r.pending))
+			r.pending = nil
+		}
+	case GotoMsg:
+		if r.state == readyState {
+			cmds = append(cmds, r.gotoCmd(msg))
+		} else {
+			r.pending = &msg
+		}
+	case RepoFeaturesMsg:
+		for i, p := range r.panes {
+			switch p.TabName() {
+			case (&Issues{}).TabName():
+				r.tabs.SetHidden(i, !msg.Issues)
+			case (&MergeRequests{}).TabName():
+				r.tabs.SetHidden(i, !msg.MergeRequests)
+			}
+		}
+		if r.tabs.IsHidden(r.activeTab) {
+			cmds = append(cmds, tabs.SelectTabCmd(0))
+		}
 	case tabs.SelectTabMsg:
+		if r.tabs.IsHidden(int(msg)) {
+			break
+		}
 		r.activeTab = int(msg)
 		t, cmd := r.tabs.Update(msg)
 		r.tabs = t.(*tabs.Tabs)
@@ -216,12 +253,18 @@ func (r *Repo) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		cmds = append(cmds, r.updateTabComponent(&Readme{}, msg))
 	case FileItemsMsg, FileContentMsg:
 		cmds = append(cmds, r.updateTabComponent(&Files{}, msg))
-	case LogItemsMsg, LogDiffMsg, LogCountMsg:
+	case LogItemsMsg, LogDiffMsg, LogCountMsg, LogReferencesMsg:
 		cmds = append(cmds, r.updateTabComponent(&Log{}, msg))
 	case RefItemsMsg:
 		cmds = append(cmds, r.updateTabComponent(&Refs{refPrefix: msg.prefix}, msg))
 	case StashListMsg, StashPatchMsg:
 		cmds = append(cmds, r.updateTabComponent(&Stash{}, msg))
+	case IssueItemsMsg, IssueDetailMsg:
+		cmds = append(cmds, r.updateTabComponent(&Issues{}, msg))
+	case MRItemsMsg, MRDetailMsg:
+		cmds = append(cmds, r.updateTabComponent(&MergeRequests{}, msg))
+	case DiffPrefsMsg:
+		cmds = append(cmds, r.updateModels(msg))
 	// We have two spinners, one is used to when loading the repository and the
 	// other is used when loading the log.
 	// Check if the spinner ID matches the spinner model.
@@ -251,6 +294,10 @@ func (r *Repo) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		r.ref = nil
 		r.state = readyState
 		cmds = append(cmds, r.updateModels(msg))
+		if r.pending != nil {
+			cmds = append(cmds, r.gotoCmd(*r.pending))
+			r.pending = nil
+		}
 	case common.ErrorMsg:
 		r.state = readyState
 	case SwitchTabMsg:
@@ -274,7 +321,8 @@ func (r *Repo) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case RepoMsg, RefMsg, tabs.ActiveTabMsg, tea.KeyPressMsg,
 		tea.MouseClickMsg, tea.MouseWheelMsg, FileItemsMsg, FileContentMsg,
 		FileBlameMsg, selector.ActiveMsg, LogItemsMsg, GoBackMsg, LogDiffMsg,
-		EmptyRepoMsg, StashListMsg, StashPatchMsg:
+		EmptyRepoMsg, StashListMsg, StashPatchMsg, DiffPrefsMsg, IssueItemsMsg,
+		IssueDetailMsg, MRItemsMsg, MRDetailMsg:
 		r.setStatusBarInfo()
 	}
 
@@ -401,6 +449,25 @@ func (r *Repo) updateModels(msg tea.Msg) tea.Cmd {
 	return tea.Batch(cmds...)
 }
 
+// loadRepoFeaturesCmd loads the features the repository turned on, to hide the
+// tabs of the others.
+func loadRepoFeaturesCmd(c common.Common, repo proto.Repository) tea.Cmd {
+	return func() tea.Msg {
+		msg := RepoFeaturesMsg{Issues: true, MergeRequests: true}
+		be := c.Backend()
+		if be == nil || repo == nil {
+			return msg
+		}
+		if enabled, err := be.IsIssuesEnabled(c.Context(), repo.Name()); err == nil {
+			msg.Issues = enabled
+		}
+		if enabled, err := be.IsMergeRequestsEnabled(c.Context(), repo.Name()); err == nil {
+			msg.MergeRequests = enabled
+		}
+		return msg
+	}
+}
+
 func copyCmd(text, msg string) tea.Cmd {
 	return func() tea.Msg {
 		return CopyMsg{
@@ -0,0 +1,33 @@
+package repo
+
+import (
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/soft-serve/pkg/ui/common"
+)
+
+// isSplitPane returns whether a list and its selected item are shown side by
+// side at the given width.
+func isSplitPane(c common.Common, width int) bool {
+	cfg := c.Config()
+	return cfg != nil && cfg.UI.SplitPaneWidth > 0 && width >= cfg.UI.SplitPaneWidth
+}
+
+// splitPaneWidths returns the widths of the list and of the detail of a split
+// pane.
+func splitPaneWidths(c common.Common, width int) (int, int) {
+	list := width * 2 / 5
+	return list, width - list - c.Styles.SplitPane.Detail.GetHorizontalFrameSize()
+}
+
+// renderSplitPane renders a list next to the detail of its selected item.
+func renderSplitPane(c common.Common, list, detail string, focusDetail bool) string {
+	lw, _ := splitPaneWidths(c, c.Width)
+	st := c.Styles.SplitPane.Detail
+	if focusDetail {
+		st = c.Styles.SplitPane.ActiveDetail
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top,
+		lipgloss.NewStyle().Width(lw).MaxWidth(lw).Render(list),
+		st.Render(detail),
+	)
+}
@@ -9,6 +9,8 @@ import (
 	tea "github.com/charmbracelet/bubbletea/v2"
 	"github.com/charmbracelet/lipgloss/v2"
 	"github.com/charmbracelet/soft-serve/git"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
 	"github.com/charmbracelet/soft-serve/pkg/proto"
 	"github.com/charmbracelet/soft-serve/pkg/ui/common"
 	"github.com/charmbracelet/soft-serve/pkg/ui/components/code"
@@ -39,6 +41,7 @@ type Stash struct {
 	list         *selector.Selector
 	state        stashState
 	currentPatch StashPatchMsg
+	prefs        models.UserPreferences
 }
 
 // NewStash creates a new stash model.
@@ -61,6 +64,7 @@ func NewStash(common common.Common) *Stash {
 		common:  common,
 		spinner: s,
 		list:    selector,
+		prefs:   backend.DefaultUserPreferences(),
 	}
 }
 
@@ -105,6 +109,9 @@ func (s *Stash) FullHelp() [][]key.Binding {
 			s.common.KeyMap.GotoBottom,
 		},
 	}
+	if s.state == stashStatePatch {
+		b = append(b, diffHelp(s.common))
+	}
 	return b
 }
 
@@ -183,6 +190,10 @@ func (s *Stash) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					patch := s.currentPatch.Diff
 					cmds = append(cmds, copyCmd(patch.Patch(), "Stash patch copied to clipboard"))
 				}
+			default:
+				if cmd := updateDiffPrefsCmd(s.common, s.prefs, msg); cmd != nil {
+					cmds = append(cmds, cmd)
+				}
 			}
 		}
 	case StashListMsg:
@@ -196,16 +207,19 @@ func (s *Stash) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		s.state = stashStatePatch
 		s.currentPatch = msg
 		if msg.Diff != nil {
-			title := s.common.Styles.Stash.Title.Render(s.list.SelectedItem().(StashItem).Title())
-			content := lipgloss.JoinVertical(lipgloss.Left,
-				title,
-				"",
-				renderSummary(msg.Diff, s.common.Styles, s.common.Width),
-				renderDiff(msg.Diff, s.common.Width),
-			)
-			cmds = append(cmds, s.code.SetContent(content, ".diff"))
+			cmds = append(cmds, s.setPatchContent())
 			s.code.GotoTop()
 		}
+	case DiffPrefsMsg:
+		prev := s.prefs
+		s.prefs = models.UserPreferences(msg)
+		if s.state == stashStatePatch && s.currentPatch.Diff != nil {
+			if prev.DiffIgnoreWhitespace != s.prefs.DiffIgnoreWhitespace {
+				cmds = append(cmds, s.fetchStashPatch)
+			} else {
+				cmds = append(cmds, s.setPatchContent())
+			}
+		}
 	case selector.SelectMsg:
 		switch msg.IdentifiableItem.(type) {
 		case StashItem:
@@ -247,6 +261,18 @@ func (s *Stash) View() string {
 	return ""
 }
 
+// setPatchContent renders the selected stash and its patch.
+func (s *Stash) setPatchContent() tea.Cmd {
+	title := s.common.Styles.Stash.Title.Render(s.list.SelectedItem().(StashItem).Title())
+	content := lipgloss.JoinVertical(lipgloss.Left,
+		title,
+		"",
+		renderSummary(s.currentPatch.Diff, s.common.Styles, s.common.Width),
+		renderDiff(s.currentPatch.Diff, s.common.Width, s.prefs),
+	)
+	return s.code.SetContent(content, ".diff")
+}
+
 func (s *Stash) fetchStash() tea.Msg {
 	if s.repo == nil {
 		return StashListMsg(nil)
@@ -275,7 +301,7 @@ func (s *Stash) fetchStashPatch() tea.Msg {
 		return common.ErrorMsg(err)
 	}
 
-	diff, err := r.StashDiff(s.list.Index())
+	diff, err := r.StashDiff(s.list.Index(), diffOptions(s.prefs))
 	if err != nil {
 		return common.ErrorMsg(err)
 	}
@@ -0,0 +1,114 @@
+package selection
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/dustin/go-humanize"
+)
+
+// adminRefreshInterval is how often the admin dashboard refreshes while it's
+// shown.
+const adminRefreshInterval = 2 * time.Second
+
+// adminMetricsMsg carries a new snapshot of the instance metrics.
+type adminMetricsMsg struct {
+	metrics backend.InstanceMetrics
+	err     error
+}
+
+// adminMetricsCmd gathers the instance metrics after delay.
+func (s *Selection) adminMetricsCmd(delay time.Duration) tea.Cmd {
+	ctx := s.common.Context()
+	be := s.common.Backend()
+	fetch := func(time.Time) tea.Msg {
+		m, err := be.InstanceMetrics(ctx)
+		return adminMetricsMsg{metrics: m, err: err}
+	}
+	if delay == 0 {
+		return func() tea.Msg { return fetch(time.Now()) }
+	}
+	return tea.Tick(delay, fetch)
+}
+
+// startAdminRefresh starts refreshing the admin dashboard unless it's
+// already refreshing. Refreshing stops once the dashboard is hidden.
+func (s *Selection) startAdminRefresh() tea.Cmd {
+	if s.activePane != adminPane || s.adminRefreshing {
+		return nil
+	}
+	s.adminRefreshing = true
+	return s.adminMetricsCmd(0)
+}
+
+// updateAdminMetrics records a new snapshot and schedules the next one.
+func (s *Selection) updateAdminMetrics(msg adminMetricsMsg) tea.Cmd {
+	if msg.err != nil {
+		s.common.Logger.Debugf("ui: failed to get instance metrics: %v", msg.err)
+	}
+	s.prevMetrics, s.metrics = s.metrics, msg.metrics
+	if s.activePane != adminPane {
+		s.adminRefreshing = false
+		return nil
+	}
+	return s.adminMetricsCmd(adminRefreshInterval)
+}
+
+// adminView renders the instance metrics as panels, wrapping them to the
+// width of the page.
+func (s *Selection) adminView(width int) string {
+	if s.metrics.Time.IsZero() {
+		return s.common.Styles.NoContent.Render("Loading metrics…")
+	}
+
+	m, prev := s.metrics, s.prevMetrics
+	disk := "n/a"
+	if m.DiskUsage >= 0 {
+		disk = humanize.Bytes(uint64(m.DiskUsage)) //nolint:gosec
+	}
+	latency := "n/a"
+	if d := m.DBLatency(prev); d > 0 {
+		latency = d.Round(time.Microsecond).String()
+	}
+	panels := []string{
+		s.adminPanel("Active sessions", strconv.Itoa(m.ActiveSessions), "SSH"),
+		s.adminPanel("Clone rate", fmt.Sprintf("%.1f/min", m.ClonesPerMinute(prev)),
+			fmt.Sprintf("%.0f since start", m.Clones)),
+		s.adminPanel("Job queue", strconv.FormatInt(m.QueuedJobs, 10), "tasks and merge queues"),
+		s.adminPanel("DB latency", latency,
+			fmt.Sprintf("%d queries", m.DBQueries-prev.DBQueries)),
+		s.adminPanel("Disk usage", disk, "data directory"),
+	}
+
+	var rows []string
+	var row []string
+	rowWidth := 0
+	for _, p := range panels {
+		w := lipgloss.Width(p)
+		if len(row) > 0 && rowWidth+w > width {
+			rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, row...))
+			row, rowWidth = nil, 0
+		}
+		row = append(row, p)
+		rowWidth += w
+	}
+	rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, row...))
+
+	updated := s.common.Styles.Dashboard.Detail.
+		Render("Updated " + m.Time.Format(time.TimeOnly))
+	return lipgloss.JoinVertical(lipgloss.Left, append(rows, " "+updated)...)
+}
+
+// adminPanel renders a dashboard panel.
+func (s *Selection) adminPanel(title, value, detail string) string {
+	st := s.common.Styles.Dashboard
+	return st.Panel.Render(lipgloss.JoinVertical(lipgloss.Left,
+		st.Title.Render(title),
+		st.Value.Render(value),
+		st.Detail.Render(detail),
+	))
+}
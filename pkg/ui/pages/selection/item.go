@@ -11,9 +11,9 @@ import (
 	"github.com/charmbracelet/bubbles/v2/list"
 	tea "github.com/charmbracelet/bubbletea/v2"
 	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
 	"github.com/charmbracelet/soft-serve/pkg/proto"
 	"github.com/charmbracelet/soft-serve/pkg/ui/common"
-	"github.com/dustin/go-humanize"
 )
 
 var _ sort.Interface = Items{}
@@ -50,6 +50,11 @@ type Item struct {
 	repo       proto.Repository
 	lastUpdate *time.Time
 	cmd        string
+	watch      models.WatchLevel
+
+	// visited is when the user last visited the repository, set when the
+	// recently visited repositories come first.
+	visited *time.Time
 }
 
 // New creates a new Item.
@@ -169,13 +174,23 @@ func (d *ItemDelegate) Render(w io.Writer, m list.Model, index int, listItem lis
 	title = common.TruncateString(title, m.Width()-styles.Base.GetHorizontalFrameSize())
 	if i.repo.IsPrivate() {
 		title += " 🔒"
+	} else if i.repo.IsInternal() {
+		title += " 🏢"
+	}
+	switch i.watch {
+	case models.WatchLevelAll:
+		title += " 👁"
+	case models.WatchLevelIgnore:
+		title += " 🔕"
 	}
 	if isSelected {
 		title += " "
 	}
 	var updatedStr string
-	if i.lastUpdate != nil {
-		updatedStr = fmt.Sprintf(" Updated %s", humanize.Time(*i.lastUpdate))
+	if i.visited != nil {
+		updatedStr = fmt.Sprintf(" Visited %s", d.common.Dates.Relative(*i.visited))
+	} else if i.lastUpdate != nil {
+		updatedStr = fmt.Sprintf(" Updated %s", d.common.Dates.Relative(*i.lastUpdate))
 	}
 	if m.Width()-styles.Base.GetHorizontalFrameSize()-lipgloss.Width(updatedStr)-lipgloss.Width(title) <= 0 {
 		updatedStr = ""
@@ -0,0 +1,121 @@
+package selection
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/charmbracelet/bubbles/v2/list"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/soft-serve/pkg/ui/common"
+	"github.com/muesli/reflow/truncate"
+)
+
+// NewsItem is an entry of the instance news.
+type NewsItem struct {
+	Repo    string
+	Summary string
+	// Detail is shown next to the time, e.g. the user who did it or the
+	// activity of a trending merge request.
+	Detail string
+	Time   time.Time
+	// idx keeps the IDs of identical entries unique.
+	idx int
+}
+
+// ID implements selector.IdentifiableItem.
+func (i NewsItem) ID() string {
+	return fmt.Sprintf("news-%d", i.idx)
+}
+
+// Title implements list.DefaultItem.
+func (i NewsItem) Title() string {
+	return i.Summary
+}
+
+// Description implements list.DefaultItem.
+func (i NewsItem) Description() string {
+	return i.Detail
+}
+
+// FilterValue implements list.Item.
+func (i NewsItem) FilterValue() string {
+	return i.Summary
+}
+
+// NewsItemDelegate is the delegate for the news item.
+type NewsItemDelegate struct {
+	common *common.Common
+}
+
+// NewNewsItemDelegate creates a new NewsItemDelegate.
+func NewNewsItemDelegate(c *common.Common) *NewsItemDelegate {
+	return &NewsItemDelegate{common: c}
+}
+
+// Height implements list.ItemDelegate.
+func (d *NewsItemDelegate) Height() int { return 2 }
+
+// Spacing implements list.ItemDelegate.
+func (d *NewsItemDelegate) Spacing() int { return 1 }
+
+// Update implements list.ItemDelegate.
+func (d *NewsItemDelegate) Update(tea.Msg, *list.Model) tea.Cmd {
+	return nil
+}
+
+// Render implements list.ItemDelegate.
+func (d *NewsItemDelegate) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
+	i, ok := listItem.(NewsItem)
+	if !ok {
+		return
+	}
+
+	isActive := index == m.Index()
+	s := d.common.Styles.MR
+	st := s.Normal
+	selector := "  "
+	if isActive {
+		st = s.Active
+		selector = s.ItemSelector.String()
+	}
+
+	horizontalFrameSize := st.Base.GetHorizontalFrameSize()
+
+	title := i.Summary
+	titleMargin := m.Width() -
+		horizontalFrameSize -
+		lipgloss.Width(selector) -
+		2 // padding
+	if titleMargin > 0 {
+		title = common.TruncateString(title, titleMargin)
+	}
+	title = st.ItemTitle.Render(title)
+
+	firstLine := lipgloss.JoinHorizontal(lipgloss.Top,
+		selector,
+		title,
+	)
+
+	detail := ""
+	if i.Detail != "" {
+		detail = st.ItemAuthor.Render(i.Detail + " • ")
+	}
+	when := st.ItemTime.Render(d.common.Dates.Relative(i.Time))
+
+	secondLine := "  " + truncate.String(detail+when,
+		uint(max(m.Width()-horizontalFrameSize-2, 0))) //nolint:gosec
+
+	content := lipgloss.JoinVertical(lipgloss.Left,
+		firstLine,
+		secondLine,
+	)
+
+	fmt.Fprint(w, //nolint:errcheck
+		d.common.Zone.Mark(
+			i.ID(),
+			st.Base.Render(content),
+		),
+	)
+}
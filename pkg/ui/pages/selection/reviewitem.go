@@ -0,0 +1,126 @@
+package selection
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/charmbracelet/bubbles/v2/list"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/ui/common"
+	"github.com/muesli/reflow/truncate"
+)
+
+// ReviewItem is a merge request awaiting the user's review.
+type ReviewItem struct {
+	Repo       string
+	MR         models.MergeRequest
+	AuthorName string
+}
+
+// ID implements selector.IdentifiableItem.
+func (i ReviewItem) ID() string {
+	return fmt.Sprintf("%s#%d", i.Repo, i.MR.ID)
+}
+
+// Title implements list.DefaultItem.
+func (i ReviewItem) Title() string {
+	return i.MR.Title
+}
+
+// Description implements list.DefaultItem.
+func (i ReviewItem) Description() string {
+	return fmt.Sprintf("%s #%d • %s → %s",
+		i.Repo,
+		i.MR.ID,
+		i.MR.SourceBranch,
+		i.MR.TargetBranch)
+}
+
+// FilterValue implements list.Item.
+func (i ReviewItem) FilterValue() string {
+	return fmt.Sprintf("%s %d %s", i.Repo, i.MR.ID, i.MR.Title)
+}
+
+// ReviewItemDelegate is the delegate for the review item.
+type ReviewItemDelegate struct {
+	common *common.Common
+}
+
+// NewReviewItemDelegate creates a new ReviewItemDelegate.
+func NewReviewItemDelegate(c *common.Common) *ReviewItemDelegate {
+	return &ReviewItemDelegate{common: c}
+}
+
+// Height implements list.ItemDelegate.
+func (d *ReviewItemDelegate) Height() int { return 2 }
+
+// Spacing implements list.ItemDelegate.
+func (d *ReviewItemDelegate) Spacing() int { return 1 }
+
+// Update implements list.ItemDelegate.
+func (d *ReviewItemDelegate) Update(tea.Msg, *list.Model) tea.Cmd {
+	return nil
+}
+
+// Render implements list.ItemDelegate.
+func (d *ReviewItemDelegate) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
+	i, ok := listItem.(ReviewItem)
+	if !ok {
+		return
+	}
+
+	isActive := index == m.Index()
+	s := d.common.Styles.MR
+	st := s.Normal
+	selector := "  "
+	if isActive {
+		st = s.Active
+		selector = s.ItemSelector.String()
+	}
+
+	horizontalFrameSize := st.Base.GetHorizontalFrameSize()
+
+	repo := st.ItemNumber.Render(fmt.Sprintf("%s#%d", i.Repo, i.MR.ID))
+	title := i.MR.Title
+	titleMargin := m.Width() -
+		horizontalFrameSize -
+		lipgloss.Width(selector) -
+		lipgloss.Width(repo) -
+		2 // padding
+	if titleMargin > 0 {
+		title = common.TruncateString(title, titleMargin)
+	}
+	title = st.ItemTitle.Render(title)
+
+	firstLine := lipgloss.JoinHorizontal(lipgloss.Top,
+		selector,
+		repo,
+		" ",
+		title,
+	)
+
+	branches := st.ItemBranches.Render(fmt.Sprintf("%s → %s", i.MR.SourceBranch, i.MR.TargetBranch))
+	author := ""
+	if i.AuthorName != "" {
+		author = " • by " + i.AuthorName
+	}
+	author = st.ItemAuthor.Render(author)
+	opened := st.ItemTime.Render(" • opened " + d.common.Dates.Relative(i.MR.CreatedAt))
+
+	secondLine := "  " + truncate.String(branches+author+opened,
+		uint(max(m.Width()-horizontalFrameSize-2, 0))) //nolint:gosec
+
+	content := lipgloss.JoinVertical(lipgloss.Left,
+		firstLine,
+		secondLine,
+	)
+
+	fmt.Fprint(w, //nolint:errcheck
+		d.common.Zone.Mark(
+			i.ID(),
+			st.Base.Render(content),
+		),
+	)
+}
@@ -3,6 +3,8 @@ package selection
 import (
 	"fmt"
 	"sort"
+	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/v2/key"
 	"github.com/charmbracelet/bubbles/v2/list"
@@ -10,6 +12,7 @@ import (
 	"github.com/charmbracelet/lipgloss/v2"
 	"github.com/charmbracelet/soft-serve/pkg/access"
 	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
 	"github.com/charmbracelet/soft-serve/pkg/ui/common"
 	"github.com/charmbracelet/soft-serve/pkg/ui/components/code"
 	"github.com/charmbracelet/soft-serve/pkg/ui/components/selector"
@@ -25,6 +28,10 @@ type pane int
 const (
 	selectorPane pane = iota
 	readmePane
+	reviewsPane
+	newsPane
+	profilePane
+	adminPane
 	lastPane
 )
 
@@ -32,6 +39,10 @@ func (p pane) String() string {
 	return []string{
 		"Repositories",
 		"About",
+		"Reviews",
+		"News",
+		"Profile",
+		"Admin",
 	}[p]
 }
 
@@ -40,14 +51,32 @@ type Selection struct {
 	common     common.Common
 	readme     *code.Code
 	selector   *selector.Selector
+	reviews    *selector.Selector
+	news       *selector.Selector
+	profile    *code.Code
 	activePane pane
 	tabs       *tabs.Tabs
+
+	// repos are the items of the repository selector, most recently updated
+	// first. recentFirst moves the recently visited ones to the top.
+	repos       Items
+	recentFirst bool
+
+	// profileName is the user or organization of the profile tab. It
+	// defaults to the current user.
+	profileName string
+
+	// metrics and prevMetrics are the last two snapshots of the instance
+	// metrics shown in the admin tab.
+	metrics         backend.InstanceMetrics
+	prevMetrics     backend.InstanceMetrics
+	adminRefreshing bool
 }
 
 // New creates a new selection model.
 func New(c common.Common) *Selection {
 	ts := make([]string, lastPane)
-	for i, b := range []pane{selectorPane, readmePane} {
+	for i, b := range []pane{selectorPane, readmePane, reviewsPane, newsPane, profilePane, adminPane} {
 		ts[i] = b.String()
 	}
 	t := tabs.New(c, ts)
@@ -61,6 +90,26 @@ func New(c common.Common) *Selection {
 		activePane: selectorPane, // start with the selector focused
 		tabs:       t,
 	}
+	reviews := selector.New(c,
+		[]selector.IdentifiableItem{},
+		NewReviewItemDelegate(&c))
+	reviews.SetShowTitle(false)
+	reviews.SetShowHelp(false)
+	reviews.SetShowStatusBar(false)
+	reviews.DisableQuitKeybindings()
+	sel.reviews = reviews
+	news := selector.New(c,
+		[]selector.IdentifiableItem{},
+		NewNewsItemDelegate(&c))
+	news.SetShowTitle(false)
+	news.SetShowHelp(false)
+	news.SetShowStatusBar(false)
+	news.DisableQuitKeybindings()
+	sel.news = news
+	profile := code.New(c, "", "")
+	profile.UseGlamour = true
+	profile.NoContentStyle = c.Styles.NoContent
+	sel.profile = profile
 	readme := code.New(c, "", "")
 	readme.UseGlamour = true
 	readme.NoContentStyle = c.Styles.NoContent.
@@ -99,7 +148,10 @@ func (s *Selection) SetSize(width, height int) {
 	wm, hm := s.getMargins()
 	s.tabs.SetSize(width, height-hm)
 	s.selector.SetSize(width-wm, height-hm)
+	s.reviews.SetSize(width-wm, height-hm)
+	s.news.SetSize(width-wm, height-hm)
 	s.readme.SetSize(width-wm, height-hm-1) // -1 for readme status line
+	s.profile.SetSize(width-wm, height-hm-1)
 }
 
 // IsFiltering returns true if the selector is currently filtering.
@@ -125,6 +177,9 @@ func (s *Selection) ShortHelp() []key.Binding {
 			copyKey,
 		)
 	}
+	if s.activePane == reviewsPane || s.activePane == newsPane {
+		kb = append(kb, s.common.KeyMap.Select)
+	}
 	return kb
 }
 
@@ -136,7 +191,7 @@ func (s *Selection) FullHelp() [][]key.Binding {
 		},
 	}
 	switch s.activePane {
-	case readmePane:
+	case readmePane, profilePane:
 		k := s.readme.KeyMap
 		b = append(b, []key.Binding{
 			k.PageDown,
@@ -158,6 +213,7 @@ func (s *Selection) FullHelp() [][]key.Binding {
 			b[0] = append(b[0],
 				s.common.KeyMap.Select,
 				copyKey,
+				s.common.KeyMap.RecentFirst,
 			)
 		}
 		b = append(b, []key.Binding{
@@ -176,6 +232,19 @@ func (s *Selection) FullHelp() [][]key.Binding {
 			k.CancelWhileFiltering,
 			k.AcceptWhileFiltering,
 		})
+	case reviewsPane, newsPane:
+		k := s.reviews.KeyMap
+		b[0] = append(b[0], s.common.KeyMap.Select)
+		b = append(b, []key.Binding{
+			k.CursorUp,
+			k.CursorDown,
+		})
+		b = append(b, []key.Binding{
+			k.NextPage,
+			k.PrevPage,
+			k.GoToStart,
+			k.GoToEnd,
+		})
 	}
 	return b
 }
@@ -199,6 +268,14 @@ func (s *Selection) Init() tea.Cmd {
 	if err != nil {
 		return common.ErrorCmd(err)
 	}
+	var user proto.User
+	if pk != nil {
+		user, _ = be.UserByPublicKey(ctx, pk)
+	}
+	watches, _ := be.RepoWatchLevels(ctx, user)
+	prefs, _ := be.UserPreferences(ctx, user)
+	s.tabs.SetHidden(int(newsPane), prefs.HideNews)
+	s.tabs.SetHidden(int(adminPane), user == nil || !user.IsAdmin())
 	sortedItems := make(Items, 0)
 	for _, r := range repos {
 		if r.Name() == ".soft-serve" {
@@ -220,21 +297,196 @@ func (s *Selection) Init() tea.Cmd {
 				s.common.Logger.Debugf("ui: failed to create item for %s: %v", r.Name(), err)
 				continue
 			}
+			item.watch = watches[r.ID()]
 			sortedItems = append(sortedItems, item)
 		}
 	}
 	sort.Sort(sortedItems)
-	items := make([]selector.IdentifiableItem, len(sortedItems))
-	for i, it := range sortedItems {
-		items[i] = it
-	}
+	s.repos = sortedItems
 	return tea.Batch(
 		s.selector.Init(),
-		s.selector.SetItems(items),
+		s.selector.SetItems(s.repoItems()),
+		s.reviews.SetItems(s.reviewItems()),
+		s.news.SetItems(s.newsItems(user, prefs.HideNews)),
 		readmeCmd,
+		s.profileCmd(user),
+	)
+}
+
+// repoItems returns the items of the repository selector. With recentFirst,
+// the repositories the user recently visited come first, most recent first.
+func (s *Selection) repoItems() []selector.IdentifiableItem {
+	repos := s.repos
+	if s.recentFirst {
+		ctx := s.common.Context()
+		be := s.common.Backend()
+		var user proto.User
+		if pk := s.common.PublicKey(); pk != nil {
+			user, _ = be.UserByPublicKey(ctx, pk)
+		}
+		recent, _ := be.RecentRepositories(ctx, user, len(s.repos))
+		visited := make(map[string]time.Time, len(recent))
+		rank := make(map[string]int, len(recent))
+		for i, r := range recent {
+			visited[r.Repository.Name()] = r.VisitedAt
+			rank[r.Repository.Name()] = i
+		}
+
+		repos = make(Items, len(s.repos))
+		for i, it := range s.repos {
+			if at, ok := visited[it.ID()]; ok {
+				it.visited = &at
+			}
+			repos[i] = it
+		}
+		sort.SliceStable(repos, func(i, j int) bool {
+			ri, iok := rank[repos[i].ID()]
+			rj, jok := rank[repos[j].ID()]
+			if iok && jok {
+				return ri < rj
+			}
+			return iok && !jok
+		})
+	}
+
+	items := make([]selector.IdentifiableItem, len(repos))
+	for i, it := range repos {
+		items[i] = it
+	}
+	return items
+}
+
+// reviewItems returns the merge requests awaiting the user's review, oldest
+// first.
+func (s *Selection) reviewItems() []selector.IdentifiableItem {
+	ctx := s.common.Context()
+	be := s.common.Backend()
+	pk := s.common.PublicKey()
+	if pk == nil {
+		return nil
+	}
+	user, err := be.UserByPublicKey(ctx, pk)
+	if err != nil {
+		return nil
+	}
+	queue, err := be.ReviewQueue(ctx, user)
+	if err != nil {
+		s.common.Logger.Debugf("ui: failed to get review queue: %v", err)
+		return nil
+	}
+
+	authors := map[int64]string{}
+	items := make([]selector.IdentifiableItem, len(queue))
+	for i, it := range queue {
+		mr := it.MergeRequest
+		if _, ok := authors[mr.AuthorID]; !ok {
+			if u, err := be.UserByID(ctx, mr.AuthorID); err == nil {
+				authors[mr.AuthorID] = proto.DisplayName(u)
+			}
+		}
+		items[i] = ReviewItem{
+			Repo:       it.Repository.Name(),
+			MR:         mr,
+			AuthorName: authors[mr.AuthorID],
+		}
+	}
+	return items
+}
+
+// SetProfileName sets the user or organization whose profile the profile tab
+// shows, and opens the tab when the page starts.
+func (s *Selection) SetProfileName(name string) {
+	s.profileName = name
+}
+
+// profileCmd loads the profile of the profile tab. The tab is hidden when
+// there is no profile to show.
+func (s *Selection) profileCmd(user proto.User) tea.Cmd {
+	name := s.profileName
+	if name == "" && user != nil {
+		name = user.Username()
+	}
+	if name == "" {
+		s.tabs.SetHidden(int(profilePane), true)
+		return nil
+	}
+
+	p, err := s.common.Backend().Profile(s.common.Context(), name, user)
+	if err != nil {
+		s.common.Logger.Debugf("ui: failed to get profile of %s: %v", name, err)
+		s.profile.NoContentStyle = s.common.Styles.NoContent.
+			SetString(fmt.Sprintf("No profile found for %q.", name))
+		return s.selectProfileCmd()
+	}
+
+	var sb strings.Builder
+	if p.Readme != "" {
+		sb.WriteString(p.Readme)
+		if p.ReadmeTruncated {
+			sb.WriteString("\n\n_README truncated._")
+		}
+		sb.WriteString("\n\n")
+	} else {
+		fmt.Fprintf(&sb, "# %s\n\nCreate a `%s/%s` repository and add a `README.md` file to display a profile.\n\n",
+			p.Name, p.Name, backend.ProfileRepo)
+	}
+	sb.WriteString("## Repositories\n\n")
+	if len(p.Repositories) == 0 {
+		sb.WriteString("Nothing here yet.\n")
+	}
+	for _, r := range p.Repositories {
+		fmt.Fprintf(&sb, "- %s\n", r.Name())
+	}
+
+	return tea.Batch(
+		s.profile.SetContent(sb.String(), ".md"),
+		s.selectProfileCmd(),
 	)
 }
 
+// selectProfileCmd opens the profile tab if a profile name was set.
+func (s *Selection) selectProfileCmd() tea.Cmd {
+	if s.profileName == "" {
+		return nil
+	}
+	return tabs.SelectTabCmd(int(profilePane))
+}
+
+// newsItems returns the trending merge requests followed by the latest
+// activity of the repositories the user can read.
+func (s *Selection) newsItems(user proto.User, hidden bool) []selector.IdentifiableItem {
+	if hidden {
+		return nil
+	}
+	news, err := s.common.Backend().News(s.common.Context(), user)
+	if err != nil {
+		s.common.Logger.Debugf("ui: failed to get news: %v", err)
+		return nil
+	}
+
+	items := make([]selector.IdentifiableItem, 0, len(news.Trending)+len(news.Activities))
+	for _, t := range news.Trending {
+		mr := t.MergeRequest
+		items = append(items, NewsItem{
+			Repo:    t.Repository.Name(),
+			Summary: fmt.Sprintf("%s!%d: %s", t.Repository.Name(), mr.ID, mr.Title),
+			Detail:  fmt.Sprintf("trending, %d updates this week", t.Activity),
+			Time:    mr.UpdatedAt,
+			idx:     len(items),
+		})
+	}
+	for _, a := range news.Activities {
+		items = append(items, NewsItem{
+			Repo:    a.Repository.Name(),
+			Summary: a.Summary(),
+			Detail:  a.Actor,
+			Time:    a.Activity.CreatedAt,
+			idx:     len(items),
+		})
+	}
+	return items
+}
+
 // Update implements tea.Model.
 func (s *Selection) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	cmds := make([]tea.Cmd, 0)
@@ -245,17 +497,41 @@ func (s *Selection) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if cmd != nil {
 			cmds = append(cmds, cmd)
 		}
+		r, cmd = s.profile.Update(msg)
+		s.profile = r.(*code.Code)
+		if cmd != nil {
+			cmds = append(cmds, cmd)
+		}
 		m, cmd := s.selector.Update(msg)
 		s.selector = m.(*selector.Selector)
 		if cmd != nil {
 			cmds = append(cmds, cmd)
 		}
+		m, cmd = s.reviews.Update(msg)
+		s.reviews = m.(*selector.Selector)
+		if cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+		m, cmd = s.news.Update(msg)
+		s.news = m.(*selector.Selector)
+		if cmd != nil {
+			cmds = append(cmds, cmd)
+		}
 	case tea.KeyPressMsg, tea.MouseMsg:
 		switch msg := msg.(type) {
 		case tea.KeyPressMsg:
 			switch {
 			case key.Matches(msg, s.common.KeyMap.Back):
 				cmds = append(cmds, s.selector.Init())
+				if s.recentFirst {
+					// Move the repository the user just left to the top.
+					cmds = append(cmds, s.selector.SetItems(s.repoItems()))
+				}
+			case key.Matches(msg, s.common.KeyMap.RecentFirst) &&
+				s.activePane == selectorPane && !s.IsFiltering():
+				s.recentFirst = !s.recentFirst
+				cmds = append(cmds, s.selector.SetItems(s.repoItems()))
+				s.selector.Select(0)
 			}
 		}
 		t, cmd := s.tabs.Update(msg)
@@ -265,6 +541,19 @@ func (s *Selection) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 	case tabs.ActiveTabMsg:
 		s.activePane = pane(msg)
+		cmds = append(cmds, s.startAdminRefresh())
+	case tabs.SelectTabMsg:
+		if !s.tabs.IsHidden(int(msg)) {
+			t, cmd := s.tabs.Update(msg)
+			s.tabs = t.(*tabs.Tabs)
+			if cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+			s.activePane = pane(msg)
+			cmds = append(cmds, s.startAdminRefresh())
+		}
+	case adminMetricsMsg:
+		cmds = append(cmds, s.updateAdminMetrics(msg))
 	}
 	switch s.activePane {
 	case readmePane:
@@ -279,6 +568,24 @@ func (s *Selection) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if cmd != nil {
 			cmds = append(cmds, cmd)
 		}
+	case reviewsPane:
+		m, cmd := s.reviews.Update(msg)
+		s.reviews = m.(*selector.Selector)
+		if cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+	case profilePane:
+		r, cmd := s.profile.Update(msg)
+		s.profile = r.(*code.Code)
+		if cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+	case newsPane:
+		m, cmd := s.news.Update(msg)
+		s.news = m.(*selector.Selector)
+		if cmd != nil {
+			cmds = append(cmds, cmd)
+		}
 	}
 	return s, tea.Batch(cmds...)
 }
@@ -293,17 +600,46 @@ func (s *Selection) View() string {
 			Width(s.common.Width - wm).
 			Height(s.common.Height - hm)
 		view = ss.Render(s.selector.View())
-	case readmePane:
+	case reviewsPane:
+		ss := lipgloss.NewStyle().
+			Width(s.common.Width - wm).
+			Height(s.common.Height - hm)
+		if len(s.reviews.Items()) == 0 {
+			view = ss.Render(s.common.Styles.NoContent.
+				Render("No merge requests awaiting your review."))
+		} else {
+			view = ss.Render(s.reviews.View())
+		}
+	case newsPane:
+		ss := lipgloss.NewStyle().
+			Width(s.common.Width - wm).
+			Height(s.common.Height - hm)
+		if len(s.news.Items()) == 0 {
+			view = ss.Render(s.common.Styles.NoContent.
+				Render("No news yet."))
+		} else {
+			view = ss.Render(s.news.View())
+		}
+	case adminPane:
+		ss := lipgloss.NewStyle().
+			Width(s.common.Width - wm).
+			Height(s.common.Height - hm)
+		view = ss.Render(s.adminView(s.common.Width - wm))
+	case readmePane, profilePane:
+		doc := s.readme
+		if s.activePane == profilePane {
+			doc = s.profile
+		}
 		rs := lipgloss.NewStyle().
 			Height(s.common.Height - hm)
-		status := fmt.Sprintf("☰ %.f%%", s.readme.ScrollPercent()*100)
+		status := fmt.Sprintf("☰ %.f%%", doc.ScrollPercent()*100)
 		readmeStatus := lipgloss.NewStyle().
 			Align(lipgloss.Right).
 			Width(s.common.Width - wm).
 			Foreground(s.common.Styles.InactiveBorderColor).
 			Render(status)
 		view = rs.Render(lipgloss.JoinVertical(lipgloss.Left,
-			s.readme.View(),
+			doc.View(),
 			readmeStatus,
 		))
 	}
@@ -0,0 +1,233 @@
+package switcher
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/v2/key"
+	"github.com/charmbracelet/bubbles/v2/textinput"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/ui/common"
+)
+
+var (
+	prevResult = key.NewBinding(
+		key.WithKeys("up", "ctrl+p"),
+		key.WithHelp("↑", "previous"),
+	)
+	nextResult = key.NewBinding(
+		key.WithKeys("down", "ctrl+n", "tab"),
+		key.WithHelp("↓", "next"),
+	)
+	closeSwitcher = key.NewBinding(
+		key.WithKeys("esc", "ctrl+k"),
+		key.WithHelp("esc", "close"),
+	)
+)
+
+// ResultsMsg is a message that contains the results of a query.
+type ResultsMsg struct {
+	Query   string
+	Results []backend.SwitcherResult
+}
+
+// SelectMsg is a message sent when a result is picked.
+type SelectMsg backend.SwitcherResult
+
+// Switcher is a fuzzy quick switcher that jumps to any repository, issue,
+// merge request, branch, or file across the instance.
+type Switcher struct {
+	common  common.Common
+	input   textinput.Model
+	results []backend.SwitcherResult
+	index   int
+	active  bool
+}
+
+// New returns a new Switcher.
+func New(c common.Common) *Switcher {
+	input := textinput.New()
+	input.Placeholder = "Jump to a repo, #issue, !mr, branch, or file"
+	input.Prompt = "> "
+	input.CharLimit = 200
+	return &Switcher{
+		common: c,
+		input:  input,
+	}
+}
+
+// SetSize implements common.Component.
+func (s *Switcher) SetSize(width, height int) {
+	s.common.SetSize(width, height)
+	s.input.SetWidth(s.width() - 2)
+}
+
+// width returns the width of the switcher content.
+func (s *Switcher) width() int {
+	return max(min(s.common.Width, 80)-s.common.Styles.Switcher.Base.GetHorizontalFrameSize(), 10)
+}
+
+// Active returns whether the switcher is open.
+func (s *Switcher) Active() bool {
+	return s.active
+}
+
+// Open opens the switcher with an empty query.
+func (s *Switcher) Open() tea.Cmd {
+	s.active = true
+	s.index = 0
+	s.results = nil
+	s.input.Reset()
+	return tea.Batch(s.input.Focus(), s.searchCmd(""))
+}
+
+// Close closes the switcher.
+func (s *Switcher) Close() {
+	s.active = false
+	s.input.Blur()
+}
+
+// ShortHelp implements help.KeyMap.
+func (s *Switcher) ShortHelp() []key.Binding {
+	return []key.Binding{
+		prevResult,
+		nextResult,
+		s.common.KeyMap.Select,
+		closeSwitcher,
+	}
+}
+
+// FullHelp implements help.KeyMap.
+func (s *Switcher) FullHelp() [][]key.Binding {
+	return [][]key.Binding{s.ShortHelp()}
+}
+
+// Init implements tea.Model.
+func (s *Switcher) Init() tea.Cmd {
+	return nil
+}
+
+// Update implements tea.Model.
+func (s *Switcher) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	cmds := make([]tea.Cmd, 0)
+	switch msg := msg.(type) {
+	case ResultsMsg:
+		// Drop the results of outdated queries.
+		if msg.Query == s.input.Value() {
+			s.results = msg.Results
+			s.index = 0
+		}
+		return s, nil
+	case tea.KeyPressMsg:
+		if !s.active {
+			return s, nil
+		}
+		switch {
+		case key.Matches(msg, closeSwitcher):
+			s.Close()
+			return s, nil
+		case key.Matches(msg, prevResult):
+			if s.index > 0 {
+				s.index--
+			}
+			return s, nil
+		case key.Matches(msg, nextResult):
+			if s.index < len(s.results)-1 {
+				s.index++
+			}
+			return s, nil
+		case key.Matches(msg, s.common.KeyMap.Select):
+			if s.index < len(s.results) {
+				res := s.results[s.index]
+				s.Close()
+				return s, func() tea.Msg { return SelectMsg(res) }
+			}
+			return s, nil
+		}
+	}
+
+	if s.active {
+		query := s.input.Value()
+		input, cmd := s.input.Update(msg)
+		s.input = input
+		if cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+		if s.input.Value() != query {
+			cmds = append(cmds, s.searchCmd(s.input.Value()))
+		}
+	}
+
+	return s, tea.Batch(cmds...)
+}
+
+// View implements tea.Model.
+func (s *Switcher) View() string {
+	st := s.common.Styles.Switcher
+	width := s.width()
+
+	var sb strings.Builder
+	sb.WriteString(st.Input.Render(s.input.View()))
+	sb.WriteString("\n")
+	if len(s.results) == 0 {
+		sb.WriteString(st.NoResults.Render("No matches"))
+	}
+
+	// Keep the selected result visible on short terminals.
+	height := max(s.common.Height-st.Base.GetVerticalFrameSize()-3, 1)
+	start := max(s.index-height+1, 0)
+	for i := start; i < len(s.results) && i < start+height; i++ {
+		res := s.results[i]
+		line := st.Kind.Render(res.Kind.String()) + describe(st.Repo, res)
+		item := st.Item
+		if i == s.index {
+			item = st.ActiveItem
+			line = s.common.Styles.MR.ItemSelector.String() + line
+		}
+		line = common.TruncateString(line, width-item.GetHorizontalFrameSize())
+		if i > start {
+			sb.WriteString("\n")
+		}
+		sb.WriteString(item.Render(line))
+	}
+
+	return st.Base.Width(width + st.Base.GetHorizontalFrameSize()).Render(sb.String())
+}
+
+// describe returns the description of a result.
+func describe(repo lipgloss.Style, res backend.SwitcherResult) string {
+	name := repo.Render(res.Repository.Name())
+	switch res.Kind {
+	case backend.SwitcherIssue:
+		return fmt.Sprintf("%s#%d %s", name, res.Number, res.Name)
+	case backend.SwitcherMergeRequest:
+		return fmt.Sprintf("%s!%d %s", name, res.ID, res.Name)
+	case backend.SwitcherBranch:
+		return fmt.Sprintf("%s @ %s", name, res.Name)
+	case backend.SwitcherFile:
+		return fmt.Sprintf("%s: %s", name, res.Name)
+	}
+	return name
+}
+
+func (s *Switcher) searchCmd(query string) tea.Cmd {
+	return func() tea.Msg {
+		ctx := s.common.Context()
+		be := s.common.Backend()
+
+		var user proto.User
+		if pk := s.common.PublicKey(); pk != nil {
+			user, _ = be.UserByPublicKey(ctx, pk)
+		}
+
+		results, err := be.QuickSwitch(ctx, user, query, backend.DefaultSwitcherLimit)
+		if err != nil {
+			s.common.Logger.Debugf("ui: quick switcher search failed: %v", err)
+		}
+
+		return ResultsMsg{Query: query, Results: results}
+	}
+}
@@ -154,6 +154,9 @@ type Styles struct {
 			ItemStateOpen lipgloss.Style
 			ItemStateMerged lipgloss.Style
 			ItemStateClosed lipgloss.Style
+			// ItemStateNotPlanned is the state badge of the issues closed
+			// without being done.
+			ItemStateNotPlanned lipgloss.Style
 		}
 		Active struct {
 			Base          lipgloss.Style
@@ -165,13 +168,48 @@ type Styles struct {
 			ItemStateOpen lipgloss.Style
 			ItemStateMerged lipgloss.Style
 			ItemStateClosed lipgloss.Style
+			// ItemStateNotPlanned is the state badge of the issues closed
+			// without being done.
+			ItemStateNotPlanned lipgloss.Style
 		}
 		ItemSelector    lipgloss.Style
+		ItemMarked      lipgloss.Style
+		// ItemFirstContribution badges the items opened by first-time
+		// contributors.
+		ItemFirstContribution lipgloss.Style
+		// ItemPinned badges the issues pinned to the top of the list.
+		ItemPinned lipgloss.Style
+		// ItemDraft badges the draft issues and merge requests.
+		ItemDraft lipgloss.Style
+		// ItemLabel renders issue labels, in the color of each label.
+		ItemLabel lipgloss.Style
 		DetailTitle     lipgloss.Style
 		DetailLabel     lipgloss.Style
 		DetailSeparator lipgloss.Style
 	}
 
+	SplitPane struct {
+		Detail       lipgloss.Style
+		ActiveDetail lipgloss.Style
+	}
+
+	Dashboard struct {
+		Panel  lipgloss.Style
+		Title  lipgloss.Style
+		Value  lipgloss.Style
+		Detail lipgloss.Style
+	}
+
+	Switcher struct {
+		Base       lipgloss.Style
+		Input      lipgloss.Style
+		Kind       lipgloss.Style
+		Repo       lipgloss.Style
+		Item       lipgloss.Style
+		ActiveItem lipgloss.Style
+		NoResults  lipgloss.Style
+	}
+
 	Spinner          lipgloss.Style
 	SpinnerContainer lipgloss.Style
 
@@ -557,6 +595,27 @@ func DefaultStyles() *Styles {
 		Foreground(selectorColor).
 		SetString("> ")
 
+	s.MR.ItemMarked = lipgloss.NewStyle().
+		Foreground(selectorColor).
+		Bold(true).
+		SetString("◆")
+
+	s.MR.ItemFirstContribution = lipgloss.NewStyle().
+		Foreground(lipgloss.Color("214")).
+		SetString("first contribution")
+
+	s.MR.ItemPinned = lipgloss.NewStyle().
+		Foreground(selectorColor).
+		SetString("pinned")
+
+	s.MR.ItemDraft = lipgloss.NewStyle().
+		Foreground(lipgloss.Color("243")).
+		Italic(true).
+		SetString("draft")
+
+	s.MR.ItemLabel = lipgloss.NewStyle().
+		Bold(true)
+
 	s.MR.Normal.Base = lipgloss.NewStyle()
 
 	s.MR.Active.Base = lipgloss.NewStyle()
@@ -613,6 +672,13 @@ func DefaultStyles() *Styles {
 		Foreground(lipgloss.Color("210")).
 		Bold(true)
 
+	s.MR.Normal.ItemStateNotPlanned = lipgloss.NewStyle().
+		Foreground(lipgloss.Color("243")) // gray
+
+	s.MR.Active.ItemStateNotPlanned = lipgloss.NewStyle().
+		Foreground(lipgloss.Color("250")).
+		Bold(true)
+
 	// Detail view styles
 	s.MR.DetailTitle = lipgloss.NewStyle().
 		Foreground(highlightColor).
@@ -626,5 +692,55 @@ func DefaultStyles() *Styles {
 	s.MR.DetailSeparator = lipgloss.NewStyle().
 		Foreground(lipgloss.Color("237"))
 
+	s.SplitPane.Detail = lipgloss.NewStyle().
+		Border(lipgloss.NormalBorder(), false, false, false, true).
+		BorderForeground(s.InactiveBorderColor).
+		PaddingLeft(1)
+
+	s.SplitPane.ActiveDetail = s.SplitPane.Detail.
+		BorderForeground(s.ActiveBorderColor)
+
+	s.Dashboard.Panel = lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(s.InactiveBorderColor).
+		Padding(0, 1).
+		Width(24)
+
+	s.Dashboard.Title = lipgloss.NewStyle().
+		Foreground(lipgloss.Color("243"))
+
+	s.Dashboard.Value = lipgloss.NewStyle().
+		Foreground(highlightColor).
+		Bold(true)
+
+	s.Dashboard.Detail = lipgloss.NewStyle().
+		Foreground(lipgloss.Color("242"))
+
+	s.Switcher.Base = lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(s.ActiveBorderColor).
+		Padding(0, 1)
+
+	s.Switcher.Input = lipgloss.NewStyle().
+		MarginBottom(1)
+
+	s.Switcher.Kind = lipgloss.NewStyle().
+		Width(7).
+		Foreground(lipgloss.Color("243"))
+
+	s.Switcher.Repo = lipgloss.NewStyle().
+		Foreground(lipgloss.Color("39"))
+
+	s.Switcher.Item = lipgloss.NewStyle().
+		PaddingLeft(2)
+
+	s.Switcher.ActiveItem = s.Switcher.Item.
+		PaddingLeft(0).
+		Foreground(highlightColor).
+		Bold(true)
+
+	s.Switcher.NoResults = lipgloss.NewStyle().
+		Foreground(lipgloss.Color("242"))
+
 	return s
 }
@@ -5,8 +5,10 @@ import (
 	"path"
 	"strings"
 	"unicode"
+	"unicode/utf8"
 
 	"github.com/charmbracelet/x/ansi"
+	"golang.org/x/text/unicode/norm"
 )
 
 // SanitizeRepo returns a sanitized version of the given repository name.
@@ -61,3 +63,141 @@ func ValidateRepo(repo string) error {
 
 	return nil
 }
+
+const (
+	// MaxTitleLength is the maximum number of characters allowed in an issue
+	// or merge request title.
+	MaxTitleLength = 255
+
+	// MaxBranchLength is the maximum length of a branch name in bytes.
+	MaxBranchLength = 255
+)
+
+// SanitizeText strips ANSI escape codes and control characters from the given
+// string, replaces invalid UTF-8 sequences, and normalizes it to NFC. Newlines
+// and tabs are preserved, and CRLF line endings are converted to LF.
+func SanitizeText(s string) string {
+	s = strings.ToValidUTF8(s, string(utf8.RuneError))
+	s = Sanitize(s)
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	s = strings.Map(func(r rune) rune {
+		if r == '\n' || r == '\t' {
+			return r
+		}
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, s)
+	return norm.NFC.String(s)
+}
+
+// SanitizeTitle sanitizes a single line title. It behaves like SanitizeText
+// but also collapses line breaks and tabs into spaces and trims surrounding
+// whitespace.
+func SanitizeTitle(s string) string {
+	s = SanitizeText(s)
+	s = strings.Map(func(r rune) rune {
+		if r == '\n' || r == '\t' {
+			return ' '
+		}
+		return r
+	}, s)
+	return strings.TrimSpace(s)
+}
+
+// ValidateTitle returns an error if the given title is invalid.
+func ValidateTitle(title string) error {
+	if strings.TrimSpace(title) == "" {
+		return fmt.Errorf("title cannot be empty")
+	}
+
+	if n := utf8.RuneCountInString(title); n > MaxTitleLength {
+		return fmt.Errorf("title is too long (%d characters, maximum is %d)", n, MaxTitleLength)
+	}
+
+	if strings.ContainsAny(title, "\r\n") {
+		return fmt.Errorf("title cannot contain line breaks")
+	}
+
+	return nil
+}
+
+// ValidateDescription returns an error if the given description is invalid or
+// larger than maxSize bytes. A maxSize of 0 or less means no limit.
+func ValidateDescription(desc string, maxSize int) error {
+	if !utf8.ValidString(desc) {
+		return fmt.Errorf("description must be valid UTF-8")
+	}
+
+	if maxSize > 0 && len(desc) > maxSize {
+		return fmt.Errorf("description is too large (%d bytes, maximum is %d)", len(desc), maxSize)
+	}
+
+	return nil
+}
+
+// TruncateText truncates the given string to at most size bytes without
+// splitting a UTF-8 sequence. It reports whether the string was truncated.
+func TruncateText(s string, size int) (string, bool) {
+	if size <= 0 || len(s) <= size {
+		return s, false
+	}
+
+	for size > 0 && !utf8.RuneStart(s[size]) {
+		size--
+	}
+
+	return s[:size], true
+}
+
+// ValidateBranch returns an error if the given branch name is not a valid Git
+// branch name. It follows the rules of git-check-ref-format(1).
+func ValidateBranch(branch string) error {
+	if branch == "" {
+		return fmt.Errorf("branch name cannot be empty")
+	}
+
+	if len(branch) > MaxBranchLength {
+		return fmt.Errorf("branch name is too long (%d bytes, maximum is %d)", len(branch), MaxBranchLength)
+	}
+
+	if branch == "@" {
+		return fmt.Errorf("branch name cannot be %q", branch)
+	}
+
+	if strings.HasPrefix(branch, "-") {
+		return fmt.Errorf("branch name cannot start with a hyphen")
+	}
+
+	if strings.HasPrefix(branch, "/") || strings.HasSuffix(branch, "/") {
+		return fmt.Errorf("branch name cannot start or end with a slash")
+	}
+
+	if strings.HasSuffix(branch, ".") {
+		return fmt.Errorf("branch name cannot end with a period")
+	}
+
+	for _, seq := range []string{"..", "//", "@{"} {
+		if strings.Contains(branch, seq) {
+			return fmt.Errorf("branch name cannot contain %q", seq)
+		}
+	}
+
+	for _, r := range branch {
+		if r < 0x20 || r == 0x7f || strings.ContainsRune(" ~^:?*[\\", r) {
+			return fmt.Errorf("branch name cannot contain %q", r)
+		}
+	}
+
+	for _, c := range strings.Split(branch, "/") {
+		if strings.HasPrefix(c, ".") {
+			return fmt.Errorf("branch name components cannot start with a period")
+		}
+		if strings.HasSuffix(c, ".lock") {
+			return fmt.Errorf("branch name components cannot end with %q", ".lock")
+		}
+	}
+
+	return nil
+}
@@ -1,6 +1,9 @@
 package utils
 
-import "testing"
+import (
+	"strings"
+	"testing"
+)
 
 func TestValidateRepo(t *testing.T) {
 	t.Run("valid", func(t *testing.T) {
@@ -54,3 +57,145 @@ func TestSanitizeRepo(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateBranch(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		for _, branch := range []string{
+			"main",
+			"feature/foo",
+			"fix-123",
+			"v1.2.3",
+			"user/feature.x",
+		} {
+			t.Run(branch, func(t *testing.T) {
+				if err := ValidateBranch(branch); err != nil {
+					t.Errorf("expected no error, got %v", err)
+				}
+			})
+		}
+	})
+	t.Run("invalid", func(t *testing.T) {
+		for _, branch := range []string{
+			"",
+			"@",
+			"-foo",
+			"/foo",
+			"foo/",
+			"foo.",
+			"foo..bar",
+			"foo//bar",
+			"foo@{bar",
+			"foo bar",
+			"foo~1",
+			"foo^",
+			"foo:bar",
+			"foo?",
+			"foo*",
+			"foo[bar",
+			"foo\\bar",
+			"foo\x01",
+			".foo",
+			"foo/.bar",
+			"foo.lock",
+			"foo/bar.lock/baz",
+		} {
+			t.Run(branch, func(t *testing.T) {
+				if err := ValidateBranch(branch); err == nil {
+					t.Error("expected an error, got nil")
+				}
+			})
+		}
+	})
+}
+
+func TestValidateTitle(t *testing.T) {
+	cases := []struct {
+		title string
+		valid bool
+	}{
+		{"Fix the thing", true},
+		{"", false},
+		{"   ", false},
+		{"line\nbreak", false},
+		{strings.Repeat("a", MaxTitleLength), true},
+		{strings.Repeat("a", MaxTitleLength+1), false},
+		{strings.Repeat("é", MaxTitleLength), true},
+	}
+	for _, c := range cases {
+		t.Run(c.title, func(t *testing.T) {
+			err := ValidateTitle(c.title)
+			if c.valid && err != nil {
+				t.Errorf("expected no error, got %v", err)
+			}
+			if !c.valid && err == nil {
+				t.Error("expected an error, got nil")
+			}
+		})
+	}
+}
+
+func TestValidateDescription(t *testing.T) {
+	if err := ValidateDescription("small", 10); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	if err := ValidateDescription(strings.Repeat("a", 11), 10); err == nil {
+		t.Error("expected an error, got nil")
+	}
+	if err := ValidateDescription(strings.Repeat("a", 11), 0); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	if err := ValidateDescription("bad\xff", 0); err == nil {
+		t.Error("expected an error, got nil")
+	}
+}
+
+func TestTruncateText(t *testing.T) {
+	cases := []struct {
+		in        string
+		size      int
+		out       string
+		truncated bool
+	}{
+		{"hello", 10, "hello", false},
+		{"hello", 5, "hello", false},
+		{"hello", 3, "hel", true},
+		{"hello", 0, "hello", false},
+		{"héllo", 2, "h", true},
+		{"héllo", 3, "hé", true},
+	}
+	for _, c := range cases {
+		t.Run(c.in, func(t *testing.T) {
+			out, truncated := TruncateText(c.in, c.size)
+			if out != c.out || truncated != c.truncated {
+				t.Errorf("expected (%q, %t), got (%q, %t)", c.out, c.truncated, out, truncated)
+			}
+		})
+	}
+}
+
+func TestSanitizeText(t *testing.T) {
+	cases := []struct {
+		in, out string
+	}{
+		{"plain", "plain"},
+		{"keeps\nnewlines\tand tabs", "keeps\nnewlines\tand tabs"},
+		{"crlf\r\nline", "crlf\nline"},
+		{"bell\a and null\x00", "bell and null"},
+		{"\x1b[31mred\x1b[0m", "red"},
+		{"é", "é"},
+		{"bad\xffutf8", "bad�utf8"},
+	}
+	for _, c := range cases {
+		t.Run(c.in, func(t *testing.T) {
+			if got := SanitizeText(c.in); got != c.out {
+				t.Errorf("expected %q, got %q", c.out, got)
+			}
+		})
+	}
+}
+
+func TestSanitizeTitle(t *testing.T) {
+	if got := SanitizeTitle("  multi\nline\ttitle\r\n "); got != "multi line title" {
+		t.Errorf("expected %q, got %q", "multi line title", got)
+	}
+}
@@ -0,0 +1,777 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/log/v2"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/sshutils"
+	"github.com/charmbracelet/soft-serve/pkg/utils"
+	"github.com/charmbracelet/soft-serve/pkg/webhook"
+	"github.com/gorilla/mux"
+	"golang.org/x/crypto/ssh"
+)
+
+// adminAPIPrefix is the path prefix of the admin API.
+const adminAPIPrefix = "/api/v1/admin"
+
+// maxAPIBodySize is the maximum size of an admin API request body.
+const maxAPIBodySize = 1 << 20
+
+// AdminAPIController registers the admin API routes for the web server.
+//
+// The admin API manages repositories, users, and repository webhooks
+// declaratively, e.g. from a Terraform or Pulumi provider. Resources are
+// addressed by their natural keys (repository name, username, and a client
+// chosen webhook external ID) and PUT creates or replaces them, so applying
+// the same request twice is a no-op. Issues imported from other trackers are
+// addressed by their ID in that tracker the same way.
+func AdminAPIController(_ context.Context, r *mux.Router) {
+	s := r.PathPrefix(adminAPIPrefix).Subrouter()
+	s.Use(withAdmin)
+
+	s.HandleFunc("/repos", withPolicy("repo list", apiListRepositories)).Methods(http.MethodGet)
+	// Webhook routes must come first since repository names may contain
+	// slashes.
+	s.HandleFunc("/repos/{repo:.+}/webhooks", withPolicy("repo webhook list", apiListWebhooks)).Methods(http.MethodGet)
+	s.HandleFunc("/repos/{repo:.+}/webhooks/{external_id}", withPolicy("repo webhook list", apiGetWebhook)).Methods(http.MethodGet)
+	s.HandleFunc("/repos/{repo:.+}/webhooks/{external_id}", withPolicy("repo webhook create", apiPutWebhook)).Methods(http.MethodPut)
+	s.HandleFunc("/repos/{repo:.+}/webhooks/{external_id}", withPolicy("repo webhook delete", apiDeleteWebhook)).Methods(http.MethodDelete)
+	s.HandleFunc("/repos/{repo:.+}/issues/{external_id}", withPolicy("repo issue show", apiGetIssue)).Methods(http.MethodGet)
+	s.HandleFunc("/repos/{repo:.+}/issues/{external_id}", withPolicy("repo issue create", apiPutIssue)).Methods(http.MethodPut)
+	s.HandleFunc("/repos/{repo:.+}", withPolicy("repo info", apiGetRepository)).Methods(http.MethodGet)
+	s.HandleFunc("/repos/{repo:.+}", withPolicy("repo create", apiPutRepository)).Methods(http.MethodPut)
+	s.HandleFunc("/repos/{repo:.+}", withPolicy("repo delete", apiDeleteRepository)).Methods(http.MethodDelete)
+
+	s.HandleFunc("/users", withPolicy("user list", apiListUsers)).Methods(http.MethodGet)
+	s.HandleFunc("/users/{username}", withPolicy("user info", apiGetUser)).Methods(http.MethodGet)
+	s.HandleFunc("/users/{username}", withPolicy("user create", apiPutUser)).Methods(http.MethodPut)
+	s.HandleFunc("/users/{username}", withPolicy("user delete", apiDeleteUser)).Methods(http.MethodDelete)
+}
+
+// withAdmin only lets admin users authenticated with an access token or a
+// password through.
+func withAdmin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		user, err := authenticate(r)
+		if err != nil {
+			renderAPIError(w, http.StatusUnauthorized, "unauthorized")
+			return
+		}
+		if !user.IsAdmin() {
+			renderAPIError(w, http.StatusForbidden, "admin access required")
+			return
+		}
+
+		ctx = proto.WithUserContext(ctx, user)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// withPolicy makes h check the authorization policy for action first, see
+// authorizeRequest.
+func withPolicy(action string, h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if authorizeRequest(w, r, action) {
+			h(w, r)
+		}
+	}
+}
+
+// authorizeRequest checks the authorization policy for the user of the
+// request doing action, and renders a JSON error if it's denied. The
+// repository is the repo route variable, and the arguments the other
+// variables naming the resource, e.g. the username or the issue number.
+func authorizeRequest(w http.ResponseWriter, r *http.Request, action string) bool {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	var args []string
+	for _, k := range []string{"username", "external_id", "issue", "mr", "id"} {
+		if v, ok := vars[k]; ok {
+			args = append(args, v)
+		}
+	}
+
+	if err := backend.FromContext(ctx).Authorize(ctx, proto.UserFromContext(ctx), action, vars["repo"], args); err != nil {
+		renderAPIError(w, http.StatusForbidden, err.Error())
+		return false
+	}
+
+	return true
+}
+
+type apiError struct {
+	Message string `json:"message"`
+}
+
+type apiRepository struct {
+	ID          int64     `json:"id"`
+	Name        string    `json:"name"`
+	ProjectName string    `json:"project_name"`
+	Description string    `json:"description"`
+	Private     bool      `json:"private"`
+	Internal    bool      `json:"internal"`
+	Hidden      bool      `json:"hidden"`
+	Mirror      bool      `json:"mirror"`
+	IssuePrefix string    `json:"issue_prefix,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type apiRepositoryRequest struct {
+	ProjectName string `json:"project_name"`
+	Description string `json:"description"`
+	Private     bool   `json:"private"`
+	Internal    bool   `json:"internal"`
+	Hidden      bool   `json:"hidden"`
+	IssuePrefix string `json:"issue_prefix"`
+}
+
+// visibility returns the repository visibility of the request. Private wins
+// over internal.
+func (r apiRepositoryRequest) visibility() proto.Visibility {
+	switch {
+	case r.Private:
+		return proto.VisibilityPrivate
+	case r.Internal:
+		return proto.VisibilityInternal
+	default:
+		return proto.VisibilityPublic
+	}
+}
+
+type apiUser struct {
+	ID         int64    `json:"id"`
+	Username   string   `json:"username"`
+	Admin      bool     `json:"admin"`
+	Bot        bool     `json:"bot"`
+	PublicKeys []string `json:"public_keys"`
+}
+
+type apiUserRequest struct {
+	Admin      bool     `json:"admin"`
+	Bot        bool     `json:"bot"`
+	PublicKeys []string `json:"public_keys"`
+}
+
+type apiWebhook struct {
+	ID          int64               `json:"id"`
+	ExternalID  string              `json:"external_id,omitempty"`
+	URL         string              `json:"url"`
+	ContentType webhook.ContentType `json:"content_type"`
+	Format      webhook.Format      `json:"format"`
+	Events      []webhook.Event     `json:"events"`
+	Active      bool                `json:"active"`
+	CreatedAt   time.Time           `json:"created_at"`
+	UpdatedAt   time.Time           `json:"updated_at"`
+}
+
+type apiIssue struct {
+	ID          int64     `json:"id"`
+	Number      int64     `json:"number"`
+	Ref         string    `json:"ref"`
+	ExternalID  string    `json:"external_id,omitempty"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	State       string    `json:"state"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type apiIssueRequest struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	State       string `json:"state"`
+}
+
+type apiWebhookRequest struct {
+	URL         string              `json:"url"`
+	ContentType webhook.ContentType `json:"content_type"`
+	Format      webhook.Format      `json:"format"`
+	Secret      string              `json:"secret"`
+	Events      []webhook.Event     `json:"events"`
+	Active      *bool               `json:"active"`
+}
+
+func apiListRepositories(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	be := backend.FromContext(ctx)
+
+	repos, err := be.Repositories(ctx)
+	if err != nil {
+		renderAPIInternalError(w, r, err)
+		return
+	}
+
+	res := make([]apiRepository, len(repos))
+	for i, repo := range repos {
+		res[i] = toAPIRepository(ctx, repo)
+	}
+
+	renderAPIJSON(w, http.StatusOK, res)
+}
+
+func apiGetRepository(w http.ResponseWriter, r *http.Request) {
+	repo, ok := apiRepositoryFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	renderAPIJSON(w, http.StatusOK, toAPIRepository(r.Context(), repo))
+}
+
+// apiPutRepository creates a repository or updates its settings.
+func apiPutRepository(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	be := backend.FromContext(ctx)
+	name := utils.SanitizeRepo(mux.Vars(r)["repo"])
+	if err := utils.ValidateRepo(name); err != nil {
+		renderAPIError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var req apiRepositoryRequest
+	if !decodeAPIRequest(w, r, &req) {
+		return
+	}
+	if err := backend.ValidateIssuePrefix(req.IssuePrefix); err != nil {
+		renderAPIError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	repo, err := be.Repository(ctx, name)
+	if errors.Is(err, proto.ErrRepoNotFound) {
+		repo, err = be.CreateRepository(ctx, name, proto.UserFromContext(ctx), proto.RepositoryOptions{
+			ProjectName: req.ProjectName,
+			Description: req.Description,
+			Private:     req.Private,
+			Internal:    req.Internal,
+			Hidden:      req.Hidden,
+		})
+		if err == nil && req.IssuePrefix != "" {
+			err = be.SetIssuePrefix(ctx, name, req.IssuePrefix)
+		}
+		if err != nil {
+			renderAPIInternalError(w, r, err)
+			return
+		}
+
+		renderAPIJSON(w, http.StatusCreated, toAPIRepository(ctx, repo))
+		return
+	}
+	if err != nil {
+		renderAPIInternalError(w, r, err)
+		return
+	}
+
+	if repo.ProjectName() != req.ProjectName {
+		err = be.SetProjectName(ctx, name, req.ProjectName)
+	}
+	if err == nil && repo.Description() != req.Description {
+		err = be.SetDescription(ctx, name, req.Description)
+	}
+	if visibility := req.visibility(); err == nil && proto.RepositoryVisibility(repo) != visibility {
+		err = be.SetVisibility(ctx, name, visibility)
+	}
+	if err == nil && repo.IsHidden() != req.Hidden {
+		err = be.SetHidden(ctx, name, req.Hidden)
+	}
+	if err == nil {
+		var prefix string
+		prefix, err = be.IssuePrefix(ctx, name)
+		if err == nil && prefix != strings.ToUpper(req.IssuePrefix) {
+			err = be.SetIssuePrefix(ctx, name, req.IssuePrefix)
+		}
+	}
+	if err == nil {
+		repo, err = be.Repository(ctx, name)
+	}
+	if err != nil {
+		renderAPIInternalError(w, r, err)
+		return
+	}
+
+	renderAPIJSON(w, http.StatusOK, toAPIRepository(ctx, repo))
+}
+
+func apiDeleteRepository(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	be := backend.FromContext(ctx)
+	repo, ok := apiRepositoryFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	if err := be.DeleteRepository(ctx, repo.Name()); err != nil {
+		renderAPIInternalError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func apiListUsers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	be := backend.FromContext(ctx)
+
+	usernames, err := be.Users(ctx)
+	if err != nil {
+		renderAPIInternalError(w, r, err)
+		return
+	}
+
+	res := make([]apiUser, 0, len(usernames))
+	for _, username := range usernames {
+		user, err := be.User(ctx, username)
+		if err != nil {
+			renderAPIInternalError(w, r, err)
+			return
+		}
+		res = append(res, toAPIUser(user))
+	}
+
+	renderAPIJSON(w, http.StatusOK, res)
+}
+
+func apiGetUser(w http.ResponseWriter, r *http.Request) {
+	user, ok := apiUserFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	renderAPIJSON(w, http.StatusOK, toAPIUser(user))
+}
+
+// apiPutUser creates a user or updates its admin and bot flags and replaces
+// its public keys.
+func apiPutUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	be := backend.FromContext(ctx)
+	username := strings.ToLower(mux.Vars(r)["username"])
+	if err := utils.ValidateUsername(username); err != nil {
+		renderAPIError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var req apiUserRequest
+	if !decodeAPIRequest(w, r, &req) {
+		return
+	}
+
+	pks := make([]ssh.PublicKey, 0, len(req.PublicKeys))
+	for _, key := range req.PublicKeys {
+		pk, _, err := sshutils.ParseAuthorizedKey(key)
+		if err != nil {
+			renderAPIError(w, http.StatusBadRequest, "invalid public key: "+err.Error())
+			return
+		}
+		pks = append(pks, pk)
+	}
+
+	user, err := be.User(ctx, username)
+	if errors.Is(err, proto.ErrUserNotFound) {
+		user, err = be.CreateUser(ctx, username, proto.UserOptions{
+			Admin:      req.Admin,
+			Bot:        req.Bot,
+			PublicKeys: pks,
+		})
+		if errors.Is(err, db.ErrDuplicateKey) {
+			renderAPIError(w, http.StatusConflict, "public key already in use")
+			return
+		}
+		if err != nil {
+			renderAPIInternalError(w, r, err)
+			return
+		}
+
+		renderAPIJSON(w, http.StatusCreated, toAPIUser(user))
+		return
+	}
+	if err != nil {
+		renderAPIInternalError(w, r, err)
+		return
+	}
+
+	if user.IsAdmin() != req.Admin {
+		err = be.SetAdmin(ctx, username, req.Admin)
+	}
+	if err == nil && user.IsBot() != req.Bot {
+		err = be.SetBot(ctx, username, req.Bot)
+	}
+
+	// Replace the public keys, leaving the ones that didn't change alone.
+	current := map[string]ssh.PublicKey{}
+	for _, pk := range user.PublicKeys() {
+		current[sshutils.MarshalAuthorizedKey(pk)] = pk
+	}
+	for _, pk := range pks {
+		ak := sshutils.MarshalAuthorizedKey(pk)
+		if _, ok := current[ak]; ok {
+			delete(current, ak)
+			continue
+		}
+		if err == nil {
+			err = be.AddPublicKey(ctx, username, pk)
+		}
+	}
+	for _, pk := range current {
+		if err == nil {
+			err = be.RemovePublicKey(ctx, username, pk)
+		}
+	}
+
+	if err == nil {
+		user, err = be.User(ctx, username)
+	}
+	if errors.Is(err, db.ErrDuplicateKey) {
+		renderAPIError(w, http.StatusConflict, "public key already in use")
+		return
+	}
+	if err != nil {
+		renderAPIInternalError(w, r, err)
+		return
+	}
+
+	renderAPIJSON(w, http.StatusOK, toAPIUser(user))
+}
+
+func apiDeleteUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	be := backend.FromContext(ctx)
+	user, ok := apiUserFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	if err := be.DeleteUser(ctx, user.Username()); err != nil {
+		renderAPIInternalError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func apiListWebhooks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	be := backend.FromContext(ctx)
+	repo, ok := apiRepositoryFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	hooks, err := be.ListWebhooks(ctx, repo)
+	if err != nil {
+		renderAPIInternalError(w, r, err)
+		return
+	}
+
+	res := make([]apiWebhook, len(hooks))
+	for i, h := range hooks {
+		res[i] = toAPIWebhook(h)
+	}
+
+	renderAPIJSON(w, http.StatusOK, res)
+}
+
+func apiGetWebhook(w http.ResponseWriter, r *http.Request) {
+	hook, _, ok := apiWebhookFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	renderAPIJSON(w, http.StatusOK, toAPIWebhook(hook))
+}
+
+// apiPutWebhook creates or replaces the webhook with the given external ID.
+func apiPutWebhook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	be := backend.FromContext(ctx)
+	repo, ok := apiRepositoryFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	var req apiWebhookRequest
+	if !decodeAPIRequest(w, r, &req) {
+		return
+	}
+
+	url := strings.TrimSpace(req.URL)
+	if err := webhook.ValidateWebhookURL(url); err != nil {
+		renderAPIError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if len(req.Events) == 0 {
+		renderAPIError(w, http.StatusBadRequest, "at least one event is required")
+		return
+	}
+
+	active := true
+	if req.Active != nil {
+		active = *req.Active
+	}
+
+	hook, created, err := be.SetWebhook(ctx, repo, mux.Vars(r)["external_id"], url, req.ContentType, req.Format, req.Secret, req.Events, active)
+	if err != nil {
+		renderAPIInternalError(w, r, err)
+		return
+	}
+
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+
+	renderAPIJSON(w, status, toAPIWebhook(hook))
+}
+
+func apiDeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	be := backend.FromContext(ctx)
+	hook, repo, ok := apiWebhookFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	if err := be.DeleteWebhook(ctx, repo, hook.ID); err != nil {
+		renderAPIInternalError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func apiGetIssue(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	be := backend.FromContext(ctx)
+	repo, ok := apiRepositoryFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	issue, err := be.ImportedIssue(ctx, repo.Name(), mux.Vars(r)["external_id"])
+	if err != nil {
+		if errors.Is(err, backend.ErrIssueNotFound) {
+			renderAPIError(w, http.StatusNotFound, "issue not found")
+		} else {
+			renderAPIInternalError(w, r, err)
+		}
+		return
+	}
+
+	renderAPIJSON(w, http.StatusOK, toAPIIssue(ctx, repo, issue))
+}
+
+// apiPutIssue imports the issue with the given external ID, the ID it has in
+// the tracker it comes from, creating it or replacing its title, description,
+// and state.
+func apiPutIssue(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	be := backend.FromContext(ctx)
+	repo, ok := apiRepositoryFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	var req apiIssueRequest
+	if !decodeAPIRequest(w, r, &req) {
+		return
+	}
+
+	var state models.IssueState
+	switch strings.ToLower(req.State) {
+	case "", "open":
+		state = models.IssueStateOpen
+	case "closed":
+		state = models.IssueStateClosed
+	default:
+		renderAPIError(w, http.StatusBadRequest, "invalid state, must be one of: open, closed")
+		return
+	}
+
+	issue, created, err := be.ImportIssue(ctx, repo.Name(), mux.Vars(r)["external_id"], req.Title, req.Description, state)
+	if errors.Is(err, backend.ErrInvalidIssue) {
+		renderAPIError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if errors.Is(err, backend.ErrIssuesDisabled) {
+		renderAPIError(w, http.StatusConflict, err.Error())
+		return
+	}
+	if err != nil {
+		renderAPIInternalError(w, r, err)
+		return
+	}
+
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+
+	renderAPIJSON(w, status, toAPIIssue(ctx, repo, issue))
+}
+
+// apiRepositoryFromRequest returns the repository addressed by the request. It
+// renders an error response and returns false when there's none.
+func apiRepositoryFromRequest(w http.ResponseWriter, r *http.Request) (proto.Repository, bool) {
+	ctx := r.Context()
+	be := backend.FromContext(ctx)
+
+	repo, err := be.Repository(ctx, mux.Vars(r)["repo"])
+	if err != nil {
+		if errors.Is(err, proto.ErrRepoNotFound) {
+			renderAPIError(w, http.StatusNotFound, err.Error())
+		} else {
+			renderAPIInternalError(w, r, err)
+		}
+		return nil, false
+	}
+
+	return repo, true
+}
+
+// apiUserFromRequest returns the user addressed by the request. It renders an
+// error response and returns false when there's none.
+func apiUserFromRequest(w http.ResponseWriter, r *http.Request) (proto.User, bool) {
+	ctx := r.Context()
+	be := backend.FromContext(ctx)
+
+	user, err := be.User(ctx, mux.Vars(r)["username"])
+	if err != nil {
+		if errors.Is(err, proto.ErrUserNotFound) {
+			renderAPIError(w, http.StatusNotFound, err.Error())
+		} else {
+			renderAPIError(w, http.StatusBadRequest, err.Error())
+		}
+		return nil, false
+	}
+
+	return user, true
+}
+
+// apiWebhookFromRequest returns the webhook addressed by the request and its
+// repository. It renders an error response and returns false when there's
+// none.
+func apiWebhookFromRequest(w http.ResponseWriter, r *http.Request) (webhook.Hook, proto.Repository, bool) {
+	ctx := r.Context()
+	be := backend.FromContext(ctx)
+	repo, ok := apiRepositoryFromRequest(w, r)
+	if !ok {
+		return webhook.Hook{}, nil, false
+	}
+
+	hook, err := be.WebhookByExternalID(ctx, repo, mux.Vars(r)["external_id"])
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			renderAPIError(w, http.StatusNotFound, "webhook not found")
+		} else {
+			renderAPIInternalError(w, r, err)
+		}
+		return webhook.Hook{}, nil, false
+	}
+
+	return hook, repo, true
+}
+
+// decodeAPIRequest decodes a JSON request body into v. It renders an error
+// response and returns false when the body is invalid.
+func decodeAPIRequest(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	dec := json.NewDecoder(io.LimitReader(r.Body, maxAPIBodySize))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		renderAPIError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return false
+	}
+
+	return true
+}
+
+func toAPIRepository(ctx context.Context, r proto.Repository) apiRepository {
+	prefix, _ := backend.FromContext(ctx).IssuePrefix(ctx, r.Name())
+	return apiRepository{
+		ID:          r.ID(),
+		Name:        r.Name(),
+		ProjectName: r.ProjectName(),
+		Description: r.Description(),
+		Private:     r.IsPrivate(),
+		Internal:    r.IsInternal(),
+		Hidden:      r.IsHidden(),
+		Mirror:      r.IsMirror(),
+		IssuePrefix: prefix,
+		CreatedAt:   r.CreatedAt(),
+		UpdatedAt:   r.UpdatedAt(),
+	}
+}
+
+func toAPIUser(u proto.User) apiUser {
+	pks := u.PublicKeys()
+	keys := make([]string, len(pks))
+	for i, pk := range pks {
+		keys[i] = sshutils.MarshalAuthorizedKey(pk)
+	}
+
+	return apiUser{
+		ID:         u.ID(),
+		Username:   u.Username(),
+		Admin:      u.IsAdmin(),
+		Bot:        u.IsBot(),
+		PublicKeys: keys,
+	}
+}
+
+func toAPIIssue(ctx context.Context, repo proto.Repository, issue models.Issue) apiIssue {
+	return apiIssue{
+		ID:          issue.ID,
+		Number:      issue.Number,
+		Ref:         backend.FromContext(ctx).IssueRef(ctx, repo.Name(), issue.ID),
+		ExternalID:  issue.ExternalID.String,
+		Title:       issue.Title,
+		Description: issue.Description,
+		State:       issue.State.String(),
+		CreatedAt:   issue.CreatedAt,
+		UpdatedAt:   issue.UpdatedAt,
+	}
+}
+
+func toAPIWebhook(h webhook.Hook) apiWebhook {
+	return apiWebhook{
+		ID:          h.ID,
+		ExternalID:  h.ExternalID.String,
+		URL:         h.URL,
+		ContentType: h.ContentType,
+		Format:      h.Format,
+		Events:      h.Events,
+		Active:      h.Active,
+		CreatedAt:   h.CreatedAt,
+		UpdatedAt:   h.UpdatedAt,
+	}
+}
+
+// renderAPIJSON renders an admin API JSON response with the given status code.
+func renderAPIJSON(w http.ResponseWriter, statusCode int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Error("error encoding json", "err", err)
+	}
+}
+
+func renderAPIError(w http.ResponseWriter, statusCode int, msg string) {
+	renderAPIJSON(w, statusCode, apiError{Message: msg})
+}
+
+func renderAPIInternalError(w http.ResponseWriter, r *http.Request, err error) {
+	log.FromContext(r.Context()).Error("admin api error", "err", err)
+	renderAPIError(w, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+}
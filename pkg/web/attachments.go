@@ -0,0 +1,288 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/charmbracelet/log/v2"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/config"
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/utils"
+	"github.com/gorilla/mux"
+)
+
+// attachmentFormField is the multipart form field of uploaded attachments.
+const attachmentFormField = "file"
+
+// inlineAttachmentTypes are the content types of the attachments browsers
+// display instead of downloading. Other types, e.g. HTML or SVG, could run
+// scripts.
+var inlineAttachmentTypes = []string{"image/png", "image/jpeg", "image/gif", "image/webp", "text/plain"}
+
+// attachmentResult is the JSON representation of an attachment.
+type attachmentResult struct {
+	ID          int64     `json:"id"`
+	Name        string    `json:"name"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	URL         string    `json:"url"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// AttachmentsController registers the routes of the files attached to
+// issues and merge requests.
+//
+// Files are uploaded as the "file" field of a multipart form, authenticated
+// with an access token, and downloaded from the URL in the response. Uploads
+// with an Idempotency-Key header are only done once per key.
+func AttachmentsController(_ context.Context, r *mux.Router) {
+	r.HandleFunc("/{repo:.+}/-/issues/{issue:[0-9]+}/attachments", listAttachments).Methods(http.MethodGet)
+	r.HandleFunc("/{repo:.+}/-/issues/{issue:[0-9]+}/attachments", uploadAttachment).Methods(http.MethodPost)
+	r.HandleFunc("/{repo:.+}/-/merge_requests/{mr:[0-9]+}/attachments", listAttachments).Methods(http.MethodGet)
+	r.HandleFunc("/{repo:.+}/-/merge_requests/{mr:[0-9]+}/attachments", uploadAttachment).Methods(http.MethodPost)
+	r.HandleFunc("/{repo:.+}/-/attachments/{id:[0-9]+}/{name}", downloadAttachment).Methods(http.MethodGet)
+	r.HandleFunc("/{repo:.+}/-/attachments/{id:[0-9]+}/{name}", deleteAttachment).Methods(http.MethodDelete)
+}
+
+func listAttachments(w http.ResponseWriter, r *http.Request) {
+	r, ok := withAttachmentUser(w, r, false)
+	if !ok {
+		return
+	}
+
+	ctx := r.Context()
+	be := backend.FromContext(ctx)
+	name := utils.SanitizeRepo(mux.Vars(r)["repo"])
+
+	subject, err := attachmentSubject(r)
+	if err != nil {
+		renderAttachmentError(w, r, err)
+		return
+	}
+
+	as, err := be.Attachments(ctx, name, subject)
+	if err != nil {
+		renderAttachmentError(w, r, err)
+		return
+	}
+
+	res := make([]attachmentResult, len(as))
+	for i, a := range as {
+		res[i] = toAttachmentResult(be, name, a)
+	}
+	renderAPIJSON(w, http.StatusOK, res)
+}
+
+func uploadAttachment(w http.ResponseWriter, r *http.Request) {
+	r, ok := withAttachmentUser(w, r, true)
+	if !ok {
+		return
+	}
+
+	action := "repo issue attach"
+	if _, ok := mux.Vars(r)["mr"]; ok {
+		action = "repo merge-request attach"
+	}
+	if !authorizeRequest(w, r, action) {
+		return
+	}
+
+	serveIdempotent(w, r, addAttachment)
+}
+
+func addAttachment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	be := backend.FromContext(ctx)
+	cfg := config.FromContext(ctx)
+	name := utils.SanitizeRepo(mux.Vars(r)["repo"])
+
+	subject, err := attachmentSubject(r)
+	if err != nil {
+		renderAttachmentError(w, r, err)
+		return
+	}
+
+	// Leave room for the multipart headers; the backend enforces the limit
+	// on the file itself.
+	r.Body = http.MaxBytesReader(w, r.Body, int64(cfg.Limits.MaxAttachmentSize)+1<<20)
+	mr, err := r.MultipartReader()
+	if err != nil {
+		renderAPIError(w, http.StatusBadRequest, "expected a multipart form with a file field")
+		return
+	}
+
+	for {
+		part, err := mr.NextPart()
+		if errors.Is(err, io.EOF) {
+			renderAPIError(w, http.StatusBadRequest, "expected a multipart form with a file field")
+			return
+		}
+		if err != nil {
+			renderAttachmentError(w, r, err)
+			return
+		}
+		if part.FormName() != attachmentFormField {
+			continue
+		}
+
+		a, err := be.AddAttachment(ctx, name, subject, part.FileName(), part)
+		if err != nil {
+			renderAttachmentError(w, r, err)
+			return
+		}
+
+		renderAPIJSON(w, http.StatusCreated, toAttachmentResult(be, name, a))
+		return
+	}
+}
+
+func downloadAttachment(w http.ResponseWriter, r *http.Request) {
+	r, ok := withAttachmentUser(w, r, false)
+	if !ok {
+		return
+	}
+
+	ctx := r.Context()
+	be := backend.FromContext(ctx)
+	vars := mux.Vars(r)
+	id, _ := strconv.ParseInt(vars["id"], 10, 64)
+
+	a, obj, err := be.OpenAttachment(ctx, vars["repo"], id)
+	if err != nil {
+		renderAttachmentError(w, r, err)
+		return
+	}
+	defer obj.Close() // nolint: errcheck
+
+	if a.Name != vars["name"] {
+		renderAttachmentError(w, r, backend.ErrAttachmentNotFound)
+		return
+	}
+
+	disposition := "attachment"
+	contentType := "application/octet-stream"
+	mediaType, _, _ := mime.ParseMediaType(a.ContentType)
+	for _, t := range inlineAttachmentTypes {
+		if mediaType == t {
+			disposition = "inline"
+			contentType = a.ContentType
+			break
+		}
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType(disposition, map[string]string{"filename": a.Name}))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	http.ServeContent(w, r, a.Name, a.CreatedAt, obj)
+}
+
+func deleteAttachment(w http.ResponseWriter, r *http.Request) {
+	r, ok := withAttachmentUser(w, r, true)
+	if !ok {
+		return
+	}
+	if !authorizeRequest(w, r, "repo attachment delete") {
+		return
+	}
+
+	ctx := r.Context()
+	be := backend.FromContext(ctx)
+	vars := mux.Vars(r)
+	id, _ := strconv.ParseInt(vars["id"], 10, 64)
+
+	if err := be.DeleteAttachment(ctx, vars["repo"], id); err != nil {
+		renderAttachmentError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// withAttachmentUser returns the request with the user it authenticates in
+// its context. Anonymous requests are refused when required is true.
+func withAttachmentUser(w http.ResponseWriter, r *http.Request, required bool) (*http.Request, bool) {
+	user, err := authenticate(r)
+	if err != nil && !errors.Is(err, proto.ErrUserNotFound) || user == nil && required {
+		renderUnauthorized(w, r)
+		return r, false
+	}
+	if user != nil {
+		r = r.WithContext(proto.WithUserContext(r.Context(), user))
+	}
+
+	return r, true
+}
+
+// attachmentSubject returns the issue, by number, or merge request of an
+// attachments route.
+func attachmentSubject(r *http.Request) (backend.Subject, error) {
+	ctx := r.Context()
+	be := backend.FromContext(ctx)
+	vars := mux.Vars(r)
+
+	if s, ok := vars["issue"]; ok {
+		number, _ := strconv.ParseInt(s, 10, 64)
+		issue, err := be.IssueByNumber(ctx, vars["repo"], number)
+		if err != nil {
+			return backend.Subject{}, err
+		}
+		return backend.Subject{Type: backend.NotificationSubjectIssue, ID: issue.ID}, nil
+	}
+
+	mrID, _ := strconv.ParseInt(vars["mr"], 10, 64)
+	return backend.Subject{Type: backend.NotificationSubjectMergeRequest, ID: mrID}, nil
+}
+
+// renderAttachmentError renders the JSON error response of an attachments
+// route.
+func renderAttachmentError(w http.ResponseWriter, r *http.Request, err error) {
+	var maxBytesErr *http.MaxBytesError
+	switch {
+	case errors.Is(err, backend.ErrAttachmentTooLarge), errors.As(err, &maxBytesErr):
+		renderAPIError(w, http.StatusRequestEntityTooLarge, backend.ErrAttachmentTooLarge.Error())
+	case errors.Is(err, backend.ErrInvalidAttachmentName):
+		renderAPIError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, backend.ErrAttachmentsDisabled), errors.Is(err, proto.ErrUnauthorized):
+		renderAPIError(w, http.StatusForbidden, err.Error())
+	case errors.Is(err, proto.ErrRepoNotFound), errors.Is(err, db.ErrRecordNotFound),
+		errors.Is(err, backend.ErrIssueNotFound), errors.Is(err, backend.ErrAttachmentNotFound),
+		errors.Is(err, backend.ErrIssuesDisabled), errors.Is(err, backend.ErrMergeRequestsDisabled):
+		renderAPIError(w, http.StatusNotFound, http.StatusText(http.StatusNotFound))
+	default:
+		log.FromContext(r.Context()).Error("attachment error", "err", err)
+		renderAPIError(w, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+	}
+}
+
+// toAttachmentResults returns the JSON representation of the attachments of
+// an issue or a merge request, nil if listing them fails.
+func toAttachmentResults(ctx context.Context, be *backend.Backend, repo string, subject backend.Subject) []attachmentResult {
+	as, err := be.Attachments(ctx, repo, subject)
+	if err != nil {
+		return nil
+	}
+
+	res := make([]attachmentResult, len(as))
+	for i, a := range as {
+		res[i] = toAttachmentResult(be, repo, a)
+	}
+	return res
+}
+
+func toAttachmentResult(be *backend.Backend, repo string, a models.Attachment) attachmentResult {
+	return attachmentResult{
+		ID:          a.ID,
+		Name:        a.Name,
+		ContentType: a.ContentType,
+		Size:        a.Size,
+		URL:         be.AttachmentURL(repo, a),
+		CreatedAt:   a.CreatedAt,
+	}
+}
@@ -0,0 +1,502 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html/template"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/log/v2"
+	"github.com/charmbracelet/soft-serve/pkg/access"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/utils"
+	"github.com/gorilla/mux"
+)
+
+// BrowseController registers the read-only issue, merge request, and profile
+// routes for the web server.
+//
+// The pages are served without authentication, so only the repositories
+// anonymous users can read are available. Links shared in chat can then be
+// opened by people without an account. Requests accepting application/json
+// get JSON instead of HTML.
+func BrowseController(_ context.Context, r *mux.Router) {
+	r.HandleFunc("/{repo:.+}/-/issues", browseIssues).Methods(http.MethodGet)
+	r.HandleFunc("/{repo:.+}/-/issues/{id:[0-9]+}", browseIssue).Methods(http.MethodGet)
+	r.HandleFunc("/{repo:.+}/-/merge_requests", browseMergeRequests).Methods(http.MethodGet)
+	r.HandleFunc("/{repo:.+}/-/merge_requests/{id:[0-9]+}", browseMergeRequest).Methods(http.MethodGet)
+	r.HandleFunc("/{name:.+}/-/profile", browseProfile).Methods(http.MethodGet)
+}
+
+type browseItem struct {
+	ID           int64      `json:"id"`
+	Title        string     `json:"title"`
+	Description  string     `json:"description,omitempty"`
+	State        string     `json:"state"`
+	Author       string     `json:"author"`
+	SourceBranch string     `json:"source_branch,omitempty"`
+	TargetBranch string     `json:"target_branch,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+	ClosedAt     *time.Time `json:"closed_at,omitempty"`
+
+	ClosedByCommit    string `json:"closed_by_commit,omitempty"`
+	FirstContribution bool   `json:"first_contribution,omitempty"`
+	Draft             bool   `json:"draft,omitempty"`
+}
+
+type browseList struct {
+	Repository string       `json:"repository"`
+	Kind       string       `json:"kind"`
+	Path       string       `json:"-"`
+	Items      []browseItem `json:"items"`
+	// Next is the path of the next page, if any.
+	Next string `json:"next,omitempty"`
+}
+
+// browsePageSize is the default and maximum number of items of a list page.
+const browsePageSize = 50
+
+// browsePagination returns the page size and the key of the last item of the
+// previous page of a list request, from its limit and after query
+// parameters.
+func browsePagination(r *http.Request) (limit int, after int64, ok bool) {
+	q := r.URL.Query()
+	limit = browsePageSize
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return 0, 0, false
+		}
+		limit = min(n, browsePageSize)
+	}
+	if v := q.Get("after"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || n < 1 {
+			return 0, 0, false
+		}
+		after = n
+	}
+	return limit, after, true
+}
+
+// nextPage returns the path of the page following the one ending with the
+// item after.
+func (l browseList) nextPage(r *http.Request, after int64) string {
+	q := r.URL.Query()
+	q.Set("after", strconv.FormatInt(after, 10))
+	return l.Path + "?" + q.Encode()
+}
+
+type browseReference struct {
+	Kind  string `json:"kind"`
+	Ref   string `json:"ref"`
+	Title string `json:"title,omitempty"`
+	Path  string `json:"-"`
+}
+
+type browsePage struct {
+	Repository   string             `json:"repository"`
+	Kind         string             `json:"kind"`
+	Path         string             `json:"-"`
+	Item         browseItem         `json:"item"`
+	ReferencedBy []browseReference  `json:"referenced_by,omitempty"`
+	Attachments  []attachmentResult `json:"attachments,omitempty"`
+}
+
+var browseListTpl = template.Must(template.New("list").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta http-equiv="Content-Type" content="text/html; charset=utf-8"/>
+    <title>{{ .Repository }} · {{ .Kind }}</title>
+</head>
+<body>
+<h1>{{ .Repository }} · {{ .Kind }}</h1>
+{{- if not .Items }}
+<p>Nothing here yet.</p>
+{{- else }}
+<ul>
+{{- range .Items }}
+    <li><a href="{{ $.Path }}/{{ .ID }}">#{{ .ID }} {{ .Title }}</a> [{{ .State }}{{ if .Draft }}, draft{{ end }}] by {{ .Author }}{{ if .FirstContribution }} · first contribution{{ end }}</li>
+{{- end }}
+</ul>
+{{- end }}
+{{- if .Next }}
+<p><a href="{{ .Next }}">Next page</a></p>
+{{- end }}
+</body>
+</html>
+`))
+
+var browsePageTpl = template.Must(template.New("page").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta http-equiv="Content-Type" content="text/html; charset=utf-8"/>
+    <title>#{{ .Item.ID }} {{ .Item.Title }} · {{ .Repository }}</title>
+</head>
+<body>
+<p><a href="{{ .Path }}">{{ .Repository }} · {{ .Kind }}</a></p>
+<h1>#{{ .Item.ID }} {{ .Item.Title }}</h1>
+<p>[{{ .Item.State }}{{ if .Item.Draft }}, draft{{ end }}] opened by {{ .Item.Author }} on {{ .Item.CreatedAt.Format "2006-01-02 15:04" }}
+{{- if .Item.SourceBranch }} · {{ .Item.SourceBranch }} → {{ .Item.TargetBranch }}{{ end }}</p>
+<pre>{{ .Item.Description }}</pre>
+{{- if .ReferencedBy }}
+<h2>Referenced by</h2>
+<ul>
+{{- range .ReferencedBy }}
+    <li>{{ if .Path }}<a href="{{ .Path }}">{{ .Ref }}</a>{{ else }}{{ .Kind }} {{ .Ref }}{{ end }}{{ if .Title }} {{ .Title }}{{ end }}</li>
+{{- end }}
+</ul>
+{{- end }}
+{{- if .Attachments }}
+<h2>Attachments</h2>
+<ul>
+{{- range .Attachments }}
+    <li><a href="{{ .URL }}">{{ .Name }}</a> ({{ .Size }} bytes)</li>
+{{- end }}
+</ul>
+{{- end }}
+</body>
+</html>
+`))
+
+type browseProfilePage struct {
+	Name            string   `json:"name"`
+	Readme          string   `json:"readme,omitempty"`
+	ReadmeTruncated bool     `json:"readme_truncated,omitempty"`
+	Repositories    []string `json:"repositories"`
+}
+
+var browseProfileTpl = template.Must(template.New("profile").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta http-equiv="Content-Type" content="text/html; charset=utf-8"/>
+    <title>{{ .Name }}</title>
+</head>
+<body>
+<h1>{{ .Name }}</h1>
+{{- if .Readme }}
+<pre>{{ .Readme }}</pre>
+{{- if .ReadmeTruncated }}
+<p>README truncated.</p>
+{{- end }}
+{{- end }}
+<h2>Repositories</h2>
+{{- if not .Repositories }}
+<p>Nothing here yet.</p>
+{{- else }}
+<ul>
+{{- range .Repositories }}
+    <li>{{ . }}</li>
+{{- end }}
+</ul>
+{{- end }}
+</body>
+</html>
+`))
+
+// browseRepository returns the repository of a browse request if anonymous
+// users can read it. Other repositories are reported as not found so their
+// existence is not leaked.
+func browseRepository(w http.ResponseWriter, r *http.Request) (proto.Repository, bool) {
+	ctx := r.Context()
+	be := backend.FromContext(ctx)
+	name := utils.SanitizeRepo(mux.Vars(r)["repo"])
+
+	if be.AccessLevelForUser(ctx, name, nil) < access.ReadOnlyAccess {
+		renderNotFound(w, r)
+		return nil, false
+	}
+
+	repo, err := be.Repository(ctx, name)
+	if err != nil {
+		renderNotFound(w, r)
+		return nil, false
+	}
+
+	return repo, true
+}
+
+func browseIssues(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	be := backend.FromContext(ctx)
+	repo, ok := browseRepository(w, r)
+	if !ok {
+		return
+	}
+
+	limit, after, ok := browsePagination(r)
+	if !ok {
+		renderBadRequest(w, r)
+		return
+	}
+
+	// One more issue tells whether there is a next page. Pinned issues top
+	// the first page on top of the limit.
+	issues, err := be.ListIssues(ctx, repo.Name(), backend.IssueListOptions{Limit: limit + 1, After: after})
+	if err != nil {
+		renderBrowseError(w, r, err)
+		return
+	}
+
+	list := browseList{
+		Repository: repo.Name(),
+		Kind:       "issues",
+		Path:       "/" + repo.Name() + "/-/issues",
+	}
+	var n int
+	for i, issue := range issues {
+		if !issue.Pinned.Valid {
+			n++
+		}
+		if n > limit {
+			issues = issues[:i]
+			list.Next = list.nextPage(r, issues[i-1].Number)
+			break
+		}
+	}
+
+	authors := map[int64]string{}
+	list.Items = make([]browseItem, len(issues))
+	for i, issue := range issues {
+		list.Items[i] = browseIssueItem(ctx, be, authors, issue)
+		list.Items[i].Description = ""
+	}
+
+	renderBrowse(w, r, browseListTpl, list)
+}
+
+func browseIssue(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	be := backend.FromContext(ctx)
+	repo, ok := browseRepository(w, r)
+	if !ok {
+		return
+	}
+
+	number, _ := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	issue, err := be.IssueByNumber(ctx, repo.Name(), number)
+	if err != nil {
+		renderBrowseError(w, r, err)
+		return
+	}
+
+	renderBrowse(w, r, browsePageTpl, browsePage{
+		Repository: repo.Name(),
+		Kind:       "issues",
+		Path:       "/" + repo.Name() + "/-/issues",
+		Item:       browseIssueItem(ctx, be, map[int64]string{}, issue),
+		ReferencedBy: toBrowseReferences(ctx, be, repo.Name(),
+			backend.Subject{Type: backend.NotificationSubjectIssue, ID: issue.ID}),
+		Attachments: toAttachmentResults(ctx, be, repo.Name(),
+			backend.Subject{Type: backend.NotificationSubjectIssue, ID: issue.ID}),
+	})
+}
+
+func browseMergeRequests(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	be := backend.FromContext(ctx)
+	repo, ok := browseRepository(w, r)
+	if !ok {
+		return
+	}
+
+	limit, after, ok := browsePagination(r)
+	if !ok {
+		renderBadRequest(w, r)
+		return
+	}
+
+	// One more merge request tells whether there is a next page.
+	mrs, err := be.ListMergeRequests(ctx, repo.Name(), backend.MergeRequestListOptions{Limit: limit + 1, After: after})
+	if err != nil {
+		renderBrowseError(w, r, err)
+		return
+	}
+
+	list := browseList{
+		Repository: repo.Name(),
+		Kind:       "merge requests",
+		Path:       "/" + repo.Name() + "/-/merge_requests",
+	}
+	if len(mrs) > limit {
+		mrs = mrs[:limit]
+		list.Next = list.nextPage(r, mrs[limit-1].ID)
+	}
+
+	authors := map[int64]string{}
+	list.Items = make([]browseItem, len(mrs))
+	for i, mr := range mrs {
+		list.Items[i] = browseMergeRequestItem(ctx, be, authors, mr)
+		list.Items[i].Description = ""
+	}
+
+	renderBrowse(w, r, browseListTpl, list)
+}
+
+func browseMergeRequest(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	be := backend.FromContext(ctx)
+	repo, ok := browseRepository(w, r)
+	if !ok {
+		return
+	}
+
+	id, _ := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	mr, err := be.GetMergeRequest(ctx, repo.Name(), id)
+	if err != nil {
+		renderBrowseError(w, r, err)
+		return
+	}
+
+	renderBrowse(w, r, browsePageTpl, browsePage{
+		Repository: repo.Name(),
+		Kind:       "merge requests",
+		Path:       "/" + repo.Name() + "/-/merge_requests",
+		Item:       browseMergeRequestItem(ctx, be, map[int64]string{}, mr),
+		ReferencedBy: toBrowseReferences(ctx, be, repo.Name(),
+			backend.Subject{Type: backend.NotificationSubjectMergeRequest, ID: mr.ID}),
+		Attachments: toAttachmentResults(ctx, be, repo.Name(),
+			backend.Subject{Type: backend.NotificationSubjectMergeRequest, ID: mr.ID}),
+	})
+}
+
+func browseProfile(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	be := backend.FromContext(ctx)
+
+	p, err := be.Profile(ctx, mux.Vars(r)["name"], nil)
+	if err != nil {
+		renderBrowseError(w, r, err)
+		return
+	}
+
+	repos := make([]string, len(p.Repositories))
+	for i, repo := range p.Repositories {
+		repos[i] = repo.Name()
+	}
+
+	renderBrowse(w, r, browseProfileTpl, browseProfilePage{
+		Name:            p.Name,
+		Readme:          p.Readme,
+		ReadmeTruncated: p.ReadmeTruncated,
+		Repositories:    repos,
+	})
+}
+
+func browseIssueItem(ctx context.Context, be *backend.Backend, authors map[int64]string, issue models.Issue) browseItem {
+	item := browseItem{
+		ID:          issue.Number,
+		Title:       issue.Title,
+		Description: issue.Description,
+		State:       issue.State.String(),
+		Author:      browseAuthor(ctx, be, authors, issue.AuthorID),
+		CreatedAt:   issue.CreatedAt,
+		UpdatedAt:   issue.UpdatedAt,
+
+		FirstContribution: issue.FirstContribution,
+	}
+	if issue.ClosedAt.Valid {
+		item.ClosedAt = &issue.ClosedAt.Time
+	}
+	if issue.ClosedByCommit.Valid {
+		item.ClosedByCommit = issue.ClosedByCommit.String
+	}
+
+	return item
+}
+
+func browseMergeRequestItem(ctx context.Context, be *backend.Backend, authors map[int64]string, mr models.MergeRequest) browseItem {
+	item := browseItem{
+		ID:           mr.ID,
+		Title:        mr.Title,
+		Description:  mr.Description,
+		State:        mr.State.String(),
+		Author:       browseAuthor(ctx, be, authors, mr.AuthorID),
+		SourceBranch: mr.SourceBranch,
+		TargetBranch: mr.TargetBranch,
+		CreatedAt:    mr.CreatedAt,
+		UpdatedAt:    mr.UpdatedAt,
+
+		FirstContribution: mr.FirstContribution,
+		Draft:             mr.Draft,
+	}
+	if mr.ClosedAt.Valid {
+		item.ClosedAt = &mr.ClosedAt.Time
+	}
+
+	return item
+}
+
+func browseAuthor(ctx context.Context, be *backend.Backend, authors map[int64]string, id int64) string {
+	if name, ok := authors[id]; ok {
+		return name
+	}
+
+	var name string
+	if u, err := be.UserByID(ctx, id); err == nil {
+		name = proto.DisplayName(u)
+	}
+	authors[id] = name
+
+	return name
+}
+
+// wantsJSON returns true if the client prefers JSON over HTML.
+func wantsJSON(r *http.Request) bool {
+	return strings.Contains(r.Header.Get("Accept"), "application/json")
+}
+
+func renderBrowse(w http.ResponseWriter, r *http.Request, tpl *template.Template, v interface{}) {
+	if wantsJSON(r) {
+		renderAPIJSON(w, http.StatusOK, v)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := tpl.Execute(w, v); err != nil {
+		log.FromContext(r.Context()).Error("error rendering page", "err", err)
+	}
+}
+
+func renderBrowseError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, db.ErrRecordNotFound) ||
+		errors.Is(err, backend.ErrIssueNotFound) ||
+		errors.Is(err, backend.ErrIssuesDisabled) ||
+		errors.Is(err, backend.ErrMergeRequestsDisabled) ||
+		errors.Is(err, backend.ErrProfileNotFound) {
+		renderNotFound(w, r)
+		return
+	}
+
+	log.FromContext(r.Context()).Error("error browsing repository", "err", err)
+	renderInternalServerError(w, r)
+}
+
+// toBrowseReferences returns the issues, merge requests, and commits that
+// reference an issue or a merge request, nil if listing them fails.
+func toBrowseReferences(ctx context.Context, be *backend.Backend, repo string, subject backend.Subject) []browseReference {
+	refs, err := be.ReferencedBy(ctx, repo, subject)
+	if err != nil {
+		return nil
+	}
+
+	res := make([]browseReference, len(refs))
+	for i, ref := range refs {
+		res[i] = browseReference{Kind: ref.Kind, Ref: ref.Ref, Title: ref.Title}
+		switch ref.Kind {
+		case backend.NotificationSubjectIssue:
+			res[i].Path = fmt.Sprintf("/%s/-/issues/%d", ref.Repository.Name(), ref.Number)
+		case backend.NotificationSubjectMergeRequest:
+			res[i].Path = fmt.Sprintf("/%s/-/merge_requests/%d", ref.Repository.Name(), ref.Number)
+		}
+	}
+	return res
+}
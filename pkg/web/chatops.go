@@ -0,0 +1,199 @@
+package web
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/log/v2"
+	"github.com/charmbracelet/soft-serve/pkg/chatops"
+	"github.com/charmbracelet/soft-serve/pkg/config"
+	"github.com/gorilla/mux"
+)
+
+// maxChatOpsBodySize is the maximum size of an inbound chat request body.
+const maxChatOpsBodySize = 1 << 20
+
+// slackMaxClockSkew is how old a Slack request timestamp can be before the
+// request is rejected as a replay.
+const slackMaxClockSkew = 5 * time.Minute
+
+// matrixCommandPrefix prefixes Matrix messages meant for Soft Serve.
+const matrixCommandPrefix = "!soft"
+
+// ChatOpsController registers the inbound chat command routes for the web
+// server. Routes are only registered for configured chat services.
+func ChatOpsController(ctx context.Context, r *mux.Router) {
+	cfg := config.FromContext(ctx)
+	if cfg.ChatOps.SlackSigningSecret != "" {
+		r.HandleFunc("/chatops/slack", slackCommand).Methods(http.MethodPost)
+	}
+	if cfg.ChatOps.MatrixHSToken != "" {
+		r.HandleFunc("/_matrix/app/v1/transactions/{txn}", matrixTransaction).Methods(http.MethodPut)
+	}
+}
+
+// verifySlackSignature verifies the signature of a Slack request.
+// See https://api.slack.com/authentication/verifying-requests-from-slack
+func verifySlackSignature(secret string, header http.Header, body []byte, now time.Time) bool {
+	ts, err := strconv.ParseInt(header.Get("X-Slack-Request-Timestamp"), 10, 64)
+	if err != nil {
+		return false
+	}
+	if d := now.Sub(time.Unix(ts, 0)); d > slackMaxClockSkew || d < -slackMaxClockSkew {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "v0:%d:", ts)
+	mac.Write(body)
+	expected := "v0=" + hex.EncodeToString(mac.Sum(nil))
+
+	return hmac.Equal([]byte(expected), []byte(header.Get("X-Slack-Signature")))
+}
+
+// slackCommand handles Slack slash commands.
+func slackCommand(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	cfg := config.FromContext(ctx)
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxChatOpsBodySize))
+	if err != nil {
+		renderBadRequest(w, r)
+		return
+	}
+
+	if !verifySlackSignature(cfg.ChatOps.SlackSigningSecret, r.Header, body, time.Now()) {
+		renderUnauthorized(w, r)
+		return
+	}
+
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		renderBadRequest(w, r)
+		return
+	}
+
+	// Slack user IDs are only unique within a workspace.
+	externalID := form.Get("team_id") + "/" + form.Get("user_id")
+	reply := chatops.Run(ctx, chatops.ProviderSlack, externalID, form.Get("text"))
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{ // nolint: errcheck
+		"response_type": "ephemeral",
+		"text":          reply,
+	})
+}
+
+// matrixEvent is a Matrix room event.
+type matrixEvent struct {
+	Type    string `json:"type"`
+	EventID string `json:"event_id"`
+	RoomID  string `json:"room_id"`
+	Sender  string `json:"sender"`
+	Content struct {
+		MsgType string `json:"msgtype"`
+		Body    string `json:"body"`
+	} `json:"content"`
+}
+
+// matrixTransaction handles Matrix application service transactions.
+// See https://spec.matrix.org/latest/application-service-api/#pushing-events
+func matrixTransaction(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	cfg := config.FromContext(ctx)
+	logger := log.FromContext(ctx)
+
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if token == "" {
+		// Older homeservers send the token as a query parameter.
+		token = r.URL.Query().Get("access_token")
+	}
+	if subtle.ConstantTimeCompare([]byte(token), []byte(cfg.ChatOps.MatrixHSToken)) != 1 {
+		renderForbidden(w, r)
+		return
+	}
+
+	var txn struct {
+		Events []matrixEvent `json:"events"`
+	}
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxChatOpsBodySize)).Decode(&txn); err != nil {
+		renderBadRequest(w, r)
+		return
+	}
+
+	for _, ev := range txn.Events {
+		if ev.Type != "m.room.message" || ev.Content.MsgType != "m.text" {
+			continue
+		}
+
+		text, ok := strings.CutPrefix(strings.TrimSpace(ev.Content.Body), matrixCommandPrefix)
+		if !ok || (text != "" && text[0] != ' ') {
+			continue
+		}
+
+		// Homeservers retry transactions until they get a response, and
+		// event IDs are unique, so commands run once per event.
+		reply, ok := chatops.RunOnce(ctx, chatops.ProviderMatrix, ev.Sender, ev.EventID, text)
+		if !ok {
+			continue
+		}
+		if err := sendMatrixNotice(ctx, cfg, ev.RoomID, ev.EventID, reply); err != nil {
+			logger.Error("error sending matrix reply", "room", ev.RoomID, "err", err)
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	io.WriteString(w, "{}") // nolint: errcheck
+}
+
+// sendMatrixNotice sends a notice to a Matrix room as the application service
+// user.
+func sendMatrixNotice(ctx context.Context, cfg *config.Config, roomID, eventID, body string) error {
+	b, err := json.Marshal(map[string]string{
+		"msgtype": "m.notice",
+		"body":    body,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Use the event being replied to as the transaction ID so homeserver
+	// retries don't send duplicate replies.
+	u := fmt.Sprintf("%s/_matrix/client/v3/rooms/%s/send/m.room.message/%s",
+		strings.TrimSuffix(cfg.ChatOps.MatrixHomeserverURL, "/"),
+		url.PathEscape(roomID), url.PathEscape(eventID))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, u, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+cfg.ChatOps.MatrixASToken)
+
+	transport, err := cfg.Outbound.Transport()
+	if err != nil {
+		return err
+	}
+
+	res, err := (&http.Client{Transport: transport}).Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close() // nolint: errcheck
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s", res.Status)
+	}
+
+	return nil
+}
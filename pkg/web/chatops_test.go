@@ -0,0 +1,172 @@
+package web
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/log/v2"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/chatops"
+	"github.com/charmbracelet/soft-serve/pkg/config"
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/migrate"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/store"
+	"github.com/charmbracelet/soft-serve/pkg/store/database"
+	_ "modernc.org/sqlite" // sqlite driver
+)
+
+func TestVerifySlackSignature(t *testing.T) {
+	const secret = "8f742231b10e8888abcd99yyyzzz85a5"
+	body := []byte("token=x&team_id=T1&user_id=U1&command=%2Fsoft&text=queue")
+	now := time.Unix(1700000000, 0)
+
+	sign := func(ts int64, body []byte) http.Header {
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write([]byte("v0:" + strconv.FormatInt(ts, 10) + ":"))
+		mac.Write(body)
+		h := http.Header{}
+		h.Set("X-Slack-Request-Timestamp", strconv.FormatInt(ts, 10))
+		h.Set("X-Slack-Signature", "v0="+hex.EncodeToString(mac.Sum(nil)))
+		return h
+	}
+
+	cases := []struct {
+		name   string
+		header http.Header
+		body   []byte
+		want   bool
+	}{
+		{"valid", sign(now.Unix(), body), body, true},
+		{"tampered body", sign(now.Unix(), body), append(body, '!'), false},
+		{"stale timestamp", sign(now.Add(-10*time.Minute).Unix(), body), body, false},
+		{"missing headers", http.Header{}, body, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := verifySlackSignature(secret, c.header, c.body, now); got != c.want {
+				t.Errorf("verifySlackSignature = %v, want %v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestMatrixTransactionRetry(t *testing.T) {
+	var mu sync.Mutex
+	var notices []string
+	hs := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var msg struct {
+			Body string `json:"body"`
+		}
+		json.NewDecoder(r.Body).Decode(&msg) // nolint: errcheck
+		mu.Lock()
+		notices = append(notices, msg.Body)
+		mu.Unlock()
+		io.WriteString(w, "{}") // nolint: errcheck
+	}))
+	t.Cleanup(hs.Close)
+
+	t.Setenv("SOFT_SERVE_DATA_PATH", t.TempDir())
+	cfg := config.DefaultConfig()
+	cfg.ChatOps.MatrixHomeserverURL = hs.URL
+	cfg.ChatOps.MatrixASToken = "as-token"
+	cfg.ChatOps.MatrixHSToken = "hs-token"
+	if err := cfg.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := config.WithContext(context.Background(), cfg)
+	ctx = log.WithContext(ctx, log.New(io.Discard))
+	dbx, err := db.Open(ctx, cfg.DB.Driver, cfg.DB.DataSource)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { dbx.Close() }) // nolint: errcheck
+	if err := migrate.Migrate(ctx, dbx); err != nil {
+		t.Fatal(err)
+	}
+	datastore := database.New(ctx, dbx)
+	be := backend.New(ctx, cfg, dbx, datastore)
+	ctx = db.WithContext(ctx, dbx)
+	ctx = store.WithContext(ctx, datastore)
+	ctx = backend.WithContext(ctx, be)
+
+	user, err := be.CreateUser(ctx, "user1", proto.UserOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := be.CreateRepository(ctx, "repo1", user, proto.RepositoryOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	uctx := proto.WithUserContext(ctx, user)
+	issueID, err := be.CreateIssue(uctx, "repo1", "Crash", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	code, err := be.CreateChatLinkCode(ctx, chatops.ProviderMatrix, "@user1:example.org")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := be.LinkChatIdentity(ctx, user, code); err != nil {
+		t.Fatal(err)
+	}
+
+	srv := httptest.NewServer(NewRouter(ctx))
+	t.Cleanup(srv.Close)
+	send := func(txn string) {
+		t.Helper()
+		body := `{"events":[{"type":"m.room.message","event_id":"$ev1","room_id":"!room:example.org",
+			"sender":"@user1:example.org","content":{"msgtype":"m.text","body":"!soft close repo1 1"}}]}`
+		req, _ := http.NewRequest(http.MethodPut, srv.URL+"/_matrix/app/v1/transactions/"+txn, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer hs-token")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close() // nolint: errcheck
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("transaction %s: got status %d", txn, resp.StatusCode)
+		}
+	}
+
+	send("1")
+	issue, err := be.GetIssue(ctx, "repo1", issueID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if issue.State != models.IssueStateClosed {
+		t.Fatalf("expected the issue to be closed, got %s", issue.State)
+	}
+
+	// A retried transaction doesn't close the issue again.
+	if err := be.ReopenIssue(uctx, "repo1", issueID); err != nil {
+		t.Fatal(err)
+	}
+	send("1")
+	issue, err = be.GetIssue(ctx, "repo1", issueID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if issue.State != models.IssueStateOpen {
+		t.Fatalf("expected the retry not to run the command again, got %s", issue.State)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(notices) != 2 || notices[0] != notices[1] || !strings.HasPrefix(notices[0], "Closed repo1#1.") {
+		t.Fatalf("expected the retry to get the first reply, got %q", notices)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/charmbracelet/soft-serve/pkg/backend"
 	"github.com/charmbracelet/soft-serve/pkg/config"
 	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
 	"github.com/charmbracelet/soft-serve/pkg/store"
 )
 
@@ -31,6 +32,7 @@ func NewContextHandler(ctx context.Context) func(http.Handler) http.Handler {
 			))
 			ctx = db.WithContext(ctx, dbx)
 			ctx = store.WithContext(ctx, datastore)
+			ctx = proto.WithRemoteAddrContext(ctx, r.RemoteAddr)
 			r = r.WithContext(ctx)
 
 			next.ServeHTTP(w, r)
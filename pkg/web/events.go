@@ -0,0 +1,161 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/log/v2"
+	"github.com/charmbracelet/soft-serve/pkg/access"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/utils"
+	"github.com/charmbracelet/soft-serve/pkg/webhook"
+	"github.com/gorilla/mux"
+)
+
+const (
+	// eventStreamPollInterval is how often the event stream checks for new
+	// events. Events are recorded by other processes too, e.g. Git hooks, so
+	// the stream polls the database.
+	eventStreamPollInterval = time.Second
+
+	// eventStreamKeepAlive is how often the event stream sends a comment to
+	// keep idle connections open.
+	eventStreamKeepAlive = 30 * time.Second
+
+	// eventStreamBatchSize is the maximum number of events read at once.
+	eventStreamBatchSize = 100
+)
+
+// EventsController registers the repository event stream route.
+//
+// The stream is a server-sent events (text/event-stream) response with the
+// JSON webhook payload of each event of the repository, so dashboards and
+// bots can react to pushes, issues, and merge requests without polling.
+// Clients resume from the Last-Event-ID header or the since query parameter,
+// and filter events with a comma separated events query parameter.
+func EventsController(_ context.Context, r *mux.Router) {
+	r.HandleFunc("/{repo:.+}/-/events", streamEvents).Methods(http.MethodGet)
+}
+
+func streamEvents(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	be := backend.FromContext(ctx)
+	logger := log.FromContext(ctx)
+	name := utils.SanitizeRepo(mux.Vars(r)["repo"])
+
+	user, err := authenticate(r)
+	if err != nil && !errors.Is(err, proto.ErrUserNotFound) {
+		renderUnauthorized(w, r)
+		return
+	}
+
+	if be.AccessLevelForUser(ctx, name, user) < access.ReadOnlyAccess {
+		renderNotFound(w, r)
+		return
+	}
+
+	repo, err := be.Repository(ctx, name)
+	if err != nil {
+		renderNotFound(w, r)
+		return
+	}
+
+	var events map[webhook.Event]bool
+	if s := r.URL.Query().Get("events"); s != "" {
+		events = map[webhook.Event]bool{}
+		for _, e := range strings.Split(s, ",") {
+			ev, err := webhook.ParseEvent(strings.TrimSpace(e))
+			if err != nil {
+				http.Error(w, fmt.Sprintf("invalid event: %s", e), http.StatusBadRequest)
+				return
+			}
+			events[ev] = true
+		}
+	}
+
+	// Only stream new events unless the client resumes from an event.
+	since := r.Header.Get("Last-Event-ID")
+	if since == "" {
+		since = r.URL.Query().Get("since")
+	}
+	var lastID int64
+	if since != "" {
+		lastID, err = strconv.ParseInt(since, 10, 64)
+		if err != nil || lastID < 0 {
+			http.Error(w, "invalid event ID", http.StatusBadRequest)
+			return
+		}
+	} else {
+		lastID, err = be.LatestRepoEventID(ctx, repo)
+		if err != nil {
+			logger.Error("error getting latest event", "repo", name, "err", err)
+			renderInternalServerError(w, r)
+			return
+		}
+	}
+
+	rc := http.NewResponseController(w) // nolint: bodyclose
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	if err := rc.Flush(); err != nil {
+		logger.Error("error flushing event stream", "err", err)
+		return
+	}
+
+	poll := time.NewTicker(eventStreamPollInterval)
+	defer poll.Stop()
+	keepAlive := time.NewTicker(eventStreamKeepAlive)
+	defer keepAlive.Stop()
+
+	for {
+		evs, err := be.RepoEvents(ctx, repo, lastID, eventStreamBatchSize)
+		if err != nil {
+			if ctx.Err() == nil {
+				logger.Error("error reading events", "repo", name, "err", err)
+			}
+			return
+		}
+
+		for _, ev := range evs {
+			lastID = ev.ID
+			e := webhook.Event(ev.Event)
+			if events != nil && !events[e] {
+				continue
+			}
+			if _, err := fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", ev.ID, e, ev.Payload); err != nil {
+				return
+			}
+		}
+		if len(evs) > 0 {
+			if err := rc.Flush(); err != nil {
+				return
+			}
+		}
+
+		// Drain full batches right away.
+		if len(evs) == eventStreamBatchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+			if err := rc.Flush(); err != nil {
+				return
+			}
+		case <-poll.C:
+		}
+	}
+}
@@ -75,8 +75,6 @@ var (
 
 func withParams(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		ctx := r.Context()
-		cfg := config.FromContext(ctx)
 		vars := mux.Vars(r)
 		repo := vars["repo"]
 
@@ -93,7 +91,6 @@ func withParams(next http.Handler) http.Handler {
 
 		repo = utils.SanitizeRepo(repo)
 		vars["repo"] = repo
-		vars["dir"] = filepath.Join(cfg.DataPath, "repos", repo+".git")
 
 		// Add repo suffix (.git)
 		r.URL.Path = fmt.Sprintf("%s.git/%s", repo, vars["file"])
@@ -248,6 +245,18 @@ func withAccess(next http.Handler) http.HandlerFunc {
 
 		file := mux.Vars(r)["file"]
 
+		// Check the authorization policy for git and git-lfs operations.
+		action := service.String()
+		if strings.HasPrefix(file, "info/lfs") {
+			action = "git-lfs"
+		}
+		if action != "" {
+			if err := be.Authorize(ctx, user, action, repoName, nil); err != nil {
+				renderForbidden(w, r)
+				return
+			}
+		}
+
 		// We only allow these services to proceed any other services should return 403
 		// - git-upload-pack
 		// - git-receive-pack
@@ -262,8 +271,11 @@ func withAccess(next http.Handler) http.HandlerFunc {
 
 			// Create the repo if it doesn't exist.
 			if repo == nil {
-				repo, err = be.CreateRepository(ctx, repoName, user, proto.RepositoryOptions{})
-				if err != nil {
+				repo, err = be.CreateRepositoryOnPush(ctx, repoName, user)
+				if errors.Is(err, backend.ErrPushToCreateDisabled) || errors.Is(err, backend.ErrPushToCreateNamespace) {
+					renderForbidden(w, r)
+					return
+				} else if err != nil {
 					logger.Error("failed to create repository", "repo", repoName, "err", err)
 					renderInternalServerError(w, r)
 					return
@@ -362,6 +374,19 @@ func withAccess(next http.Handler) http.HandlerFunc {
 			return
 		}
 
+		if repo != nil {
+			dir, err := be.RepositoryPath(ctx, repo.Name())
+			if err != nil {
+				logger.Error("failed to locate repository", "repo", repo.Name(), "err", err)
+				renderInternalServerError(w, r)
+				return
+			}
+
+			vars := mux.Vars(r)
+			vars["dir"] = dir
+			r = mux.SetURLVars(r, vars)
+		}
+
 		next.ServeHTTP(w, r)
 	}
 }
@@ -392,8 +417,9 @@ func serviceRpc(w http.ResponseWriter, r *http.Request) {
 
 	var stdout bytes.Buffer
 	cmd := git.ServiceCommand{
-		Stdout: &stdout,
-		Dir:    dir,
+		Stdout:     &stdout,
+		Dir:        dir,
+		HiddenRefs: cfg.Git.HiddenRefs,
 	}
 
 	switch service {
@@ -498,9 +524,10 @@ func getInfoRefs(w http.ResponseWriter, r *http.Request) {
 		// Smart HTTP
 		var refs bytes.Buffer
 		cmd := git.ServiceCommand{
-			Stdout: &refs,
-			Dir:    dir,
-			Args:   []string{"--stateless-rpc", "--advertise-refs"},
+			Stdout:     &refs,
+			Dir:        dir,
+			Args:       []string{"--stateless-rpc", "--advertise-refs"},
+			HiddenRefs: cfg.Git.HiddenRefs,
 		}
 
 		user := proto.UserFromContext(ctx)
@@ -0,0 +1,86 @@
+package web
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/charmbracelet/log/v2"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+)
+
+// idempotencyKeyHeader is the header of the idempotency key of create
+// requests. Retrying a request with the same key replays its first response
+// instead of creating a duplicate.
+const idempotencyKeyHeader = "Idempotency-Key"
+
+// idempotentReplayedHeader is set on the responses replayed for a retried
+// request.
+const idempotentReplayedHeader = "Idempotent-Replayed"
+
+// idempotentWriter is a wrapper around http.ResponseWriter recording the
+// response of a create request.
+type idempotentWriter struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+// WriteHeader implements http.ResponseWriter.
+func (w *idempotentWriter) WriteHeader(code int) {
+	w.status = code
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// Write implements http.ResponseWriter.
+func (w *idempotentWriter) Write(p []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	w.body.Write(p)
+	return w.ResponseWriter.Write(p)
+}
+
+// serveIdempotent serves the create request r with h once per
+// Idempotency-Key of the user in its context, and replays the response of the
+// first request to the retries. Requests without the header are served as is.
+// h must render JSON.
+func serveIdempotent(w http.ResponseWriter, r *http.Request, h http.HandlerFunc) {
+	key := r.Header.Get(idempotencyKeyHeader)
+	if key == "" {
+		h(w, r)
+		return
+	}
+
+	ctx := r.Context()
+	be := backend.FromContext(ctx)
+	resp, replayed, err := be.Idempotent(ctx, key, r.Method+" "+r.URL.Path, func() (backend.IdempotentResponse, error) {
+		iw := &idempotentWriter{ResponseWriter: w}
+		h(iw, r)
+		resp := backend.IdempotentResponse{Status: iw.status, Body: iw.body.Bytes()}
+		if iw.status >= http.StatusBadRequest {
+			// Failed requests don't keep their key.
+			return resp, fmt.Errorf("request failed with status %d", iw.status)
+		}
+		return resp, nil
+	})
+	switch {
+	case replayed:
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set(idempotentReplayedHeader, "true")
+		w.WriteHeader(resp.Status)
+		if _, err := w.Write(resp.Body); err != nil {
+			log.FromContext(ctx).Error("error replaying response", "err", err)
+		}
+	case errors.Is(err, backend.ErrInvalidIdempotencyKey):
+		renderAPIError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, backend.ErrIdempotencyKeyInUse):
+		renderAPIError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, backend.ErrIdempotencyKeyReused):
+		renderAPIError(w, http.StatusUnprocessableEntity, err.Error())
+	case err != nil && resp.Status == 0:
+		// The handler didn't run.
+		renderAPIInternalError(w, r, err)
+	}
+}
@@ -0,0 +1,460 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"html/template"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/log/v2"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/config"
+	"github.com/charmbracelet/soft-serve/pkg/jwk"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/go-jose/go-jose/v3"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/gorilla/mux"
+)
+
+// OAuthController registers the OAuth2 and OpenID Connect provider routes for
+// the web server.
+//
+// Applications registered with the "oauth" command send users to the
+// authorize endpoint, where they sign in with their username and an access
+// token, and approve the requested scope. The application then exchanges the
+// authorization code for an access token limited to that scope. ID tokens are
+// signed with the server key using EdDSA.
+func OAuthController(_ context.Context, r *mux.Router) {
+	r.HandleFunc("/.well-known/openid-configuration", oauthDiscovery).Methods(http.MethodGet)
+	r.HandleFunc("/oauth/jwks", oauthJWKS).Methods(http.MethodGet)
+	r.HandleFunc("/oauth/authorize", oauthAuthorize).Methods(http.MethodGet)
+	r.HandleFunc("/oauth/authorize", oauthConsent).Methods(http.MethodPost)
+	r.HandleFunc("/oauth/token", oauthToken).Methods(http.MethodPost)
+	r.HandleFunc("/oauth/userinfo", oauthUserInfo).Methods(http.MethodGet, http.MethodPost)
+}
+
+// oauthTokenExpiry is how long ID tokens are valid.
+const oauthTokenExpiry = time.Hour
+
+type oauthError struct {
+	Error       string `json:"error"`
+	Description string `json:"error_description,omitempty"`
+}
+
+type oauthTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	Scope       string `json:"scope"`
+	IDToken     string `json:"id_token,omitempty"`
+}
+
+type oauthUserInfoResponse struct {
+	Subject           string `json:"sub"`
+	PreferredUsername string `json:"preferred_username"`
+	Name              string `json:"name"`
+}
+
+type oauthIDTokenClaims struct {
+	jwt.RegisteredClaims
+	Nonce             string `json:"nonce,omitempty"`
+	PreferredUsername string `json:"preferred_username,omitempty"`
+	Name              string `json:"name,omitempty"`
+}
+
+// oauthConsentClaims carry an authorization request from the consent page to
+// the consent form submission. They are signed so that other sites can't
+// approve requests on behalf of users.
+type oauthConsentClaims struct {
+	jwt.RegisteredClaims
+	RedirectURI   string `json:"redirect_uri"`
+	Scope         string `json:"scope"`
+	State         string `json:"state,omitempty"`
+	CodeChallenge string `json:"code_challenge,omitempty"`
+	Nonce         string `json:"nonce,omitempty"`
+}
+
+type oauthConsentPage struct {
+	App      string
+	Username string
+	Scopes   []string
+	Consent  string
+}
+
+var oauthConsentTpl = template.Must(template.New("consent").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta http-equiv="Content-Type" content="text/html; charset=utf-8"/>
+    <title>Authorize {{ .App }}</title>
+</head>
+<body>
+<h1>Authorize {{ .App }}</h1>
+<p>{{ .App }} wants to access your account <strong>{{ .Username }}</strong>:</p>
+<ul>
+{{- range .Scopes }}
+    <li>{{ . }}</li>
+{{- end }}
+</ul>
+<form method="post" action="/oauth/authorize">
+    <input type="hidden" name="consent" value="{{ .Consent }}"/>
+    <button type="submit" name="decision" value="approve">Approve</button>
+    <button type="submit" name="decision" value="deny">Deny</button>
+</form>
+</body>
+</html>
+`))
+
+// oauthScopeDescription describes a scope on the consent page.
+func oauthScopeDescription(scope string) string {
+	switch scope {
+	case backend.OAuthScopeOpenID:
+		return "Sign you in"
+	case backend.OAuthScopeProfile:
+		return "Read your username"
+	case "read-only":
+		return "Read your repositories"
+	case "read-write":
+		return "Read and write your repositories"
+	case "admin-access":
+		return "Administer your repositories"
+	default:
+		return scope
+	}
+}
+
+// oauthIssuer returns the issuer of the tokens signed by the server.
+func oauthIssuer(cfg *config.Config) string {
+	return strings.TrimSuffix(cfg.HTTP.PublicURL, "/")
+}
+
+func oauthDiscovery(w http.ResponseWriter, r *http.Request) {
+	issuer := oauthIssuer(config.FromContext(r.Context()))
+	renderAPIJSON(w, http.StatusOK, map[string]interface{}{
+		"issuer":                                issuer,
+		"authorization_endpoint":                issuer + "/oauth/authorize",
+		"token_endpoint":                        issuer + "/oauth/token",
+		"userinfo_endpoint":                     issuer + "/oauth/userinfo",
+		"jwks_uri":                              issuer + "/oauth/jwks",
+		"response_types_supported":              []string{"code"},
+		"grant_types_supported":                 []string{"authorization_code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{jwk.SigningMethod.Alg()},
+		"scopes_supported":                      []string{backend.OAuthScopeOpenID, backend.OAuthScopeProfile, "read-only", "read-write", "admin-access"},
+		"token_endpoint_auth_methods_supported": []string{"client_secret_basic", "client_secret_post"},
+		"code_challenge_methods_supported":      []string{"S256"},
+		"claims_supported":                      []string{"sub", "preferred_username", "name"},
+	})
+}
+
+func oauthJWKS(w http.ResponseWriter, r *http.Request) {
+	kp, err := jwk.NewPair(config.FromContext(r.Context()))
+	if err != nil {
+		log.FromContext(r.Context()).Error("error loading server key", "err", err)
+		renderInternalServerError(w, r)
+		return
+	}
+
+	renderAPIJSON(w, http.StatusOK, jose.JSONWebKeySet{Keys: []jose.JSONWebKey{kp.JWK()}})
+}
+
+// oauthRedirect sends the user back to the application with the given
+// parameters.
+func oauthRedirect(w http.ResponseWriter, r *http.Request, redirectURI string, params url.Values) {
+	u, err := url.Parse(redirectURI)
+	if err != nil {
+		renderBadRequest(w, r)
+		return
+	}
+
+	q := u.Query()
+	for k, v := range params {
+		if v[0] != "" {
+			q.Set(k, v[0])
+		}
+	}
+	u.RawQuery = q.Encode()
+	http.Redirect(w, r, u.String(), http.StatusFound)
+}
+
+func oauthAuthorize(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	be := backend.FromContext(ctx)
+	logger := log.FromContext(ctx)
+	q := r.URL.Query()
+
+	// Errors about the application itself can't be sent back to it.
+	app, err := be.OAuthApp(ctx, q.Get("client_id"))
+	if err != nil {
+		renderBadRequest(w, r)
+		return
+	}
+	redirectURI := q.Get("redirect_uri")
+	if redirectURI == "" {
+		redirectURI = app.RedirectURI
+	}
+	if redirectURI != app.RedirectURI {
+		renderBadRequest(w, r)
+		return
+	}
+
+	state := q.Get("state")
+	fail := func(code, desc string) {
+		oauthRedirect(w, r, redirectURI, url.Values{
+			"error":             {code},
+			"error_description": {desc},
+			"state":             {state},
+		})
+	}
+
+	if q.Get("response_type") != "code" {
+		fail("unsupported_response_type", "only the code response type is supported")
+		return
+	}
+	scope, err := backend.ParseOAuthScope(q.Get("scope"))
+	if err != nil {
+		fail("invalid_scope", err.Error())
+		return
+	}
+	challenge := q.Get("code_challenge")
+	if method := q.Get("code_challenge_method"); (challenge != "" || method != "") && method != "S256" {
+		fail("invalid_request", "only the S256 code challenge method is supported")
+		return
+	}
+
+	user, err := authenticate(r)
+	if err != nil || user == nil {
+		askCredentials(w, r)
+		renderUnauthorized(w, r)
+		return
+	}
+	if user.IsBot() {
+		fail("access_denied", proto.ErrBotUser.Error())
+		return
+	}
+
+	cfg := config.FromContext(ctx)
+	kp, err := jwk.NewPair(cfg)
+	if err != nil {
+		logger.Error("error loading server key", "err", err)
+		renderInternalServerError(w, r)
+		return
+	}
+
+	now := time.Now()
+	claims := oauthConsentClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   strconv.FormatInt(user.ID(), 10),
+			Audience:  jwt.ClaimStrings{app.ClientID},
+			Issuer:    oauthIssuer(cfg),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(backend.OAuthCodeExpiry)),
+		},
+		RedirectURI:   redirectURI,
+		Scope:         scope,
+		State:         state,
+		CodeChallenge: challenge,
+		Nonce:         q.Get("nonce"),
+	}
+	consent, err := jwt.NewWithClaims(jwk.SigningMethod, claims).SignedString(kp.PrivateKey())
+	if err != nil {
+		logger.Error("error signing consent", "err", err)
+		renderInternalServerError(w, r)
+		return
+	}
+
+	page := oauthConsentPage{
+		App:      app.Name,
+		Username: user.Username(),
+		Consent:  consent,
+	}
+	for _, s := range strings.Fields(scope) {
+		page.Scopes = append(page.Scopes, oauthScopeDescription(s))
+	}
+	if len(page.Scopes) == 0 {
+		page.Scopes = append(page.Scopes, oauthScopeDescription(backend.OAuthScopeOpenID))
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	// Don't let other sites frame the consent page.
+	w.Header().Set("X-Frame-Options", "DENY")
+	if err := oauthConsentTpl.Execute(w, page); err != nil {
+		logger.Error("error rendering page", "err", err)
+	}
+}
+
+func oauthConsent(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	be := backend.FromContext(ctx)
+	cfg := config.FromContext(ctx)
+	logger := log.FromContext(ctx)
+
+	user, err := authenticate(r)
+	if err != nil || user == nil {
+		askCredentials(w, r)
+		renderUnauthorized(w, r)
+		return
+	}
+
+	kp, err := jwk.NewPair(cfg)
+	if err != nil {
+		logger.Error("error loading server key", "err", err)
+		renderInternalServerError(w, r)
+		return
+	}
+
+	var claims oauthConsentClaims
+	if _, err := jwt.ParseWithClaims(r.PostFormValue("consent"), &claims, func(*jwt.Token) (interface{}, error) {
+		return kp.JWK().Key, nil
+	},
+		jwt.WithValidMethods([]string{jwk.SigningMethod.Alg()}),
+		jwt.WithIssuer(oauthIssuer(cfg)),
+		jwt.WithExpirationRequired(),
+	); err != nil || claims.Subject != strconv.FormatInt(user.ID(), 10) || len(claims.Audience) != 1 {
+		renderBadRequest(w, r)
+		return
+	}
+
+	app, err := be.OAuthApp(ctx, claims.Audience[0])
+	if err != nil {
+		renderBadRequest(w, r)
+		return
+	}
+
+	if r.PostFormValue("decision") != "approve" {
+		oauthRedirect(w, r, claims.RedirectURI, url.Values{
+			"error": {"access_denied"},
+			"state": {claims.State},
+		})
+		return
+	}
+
+	code, err := be.CreateOAuthCode(ctx, app, user, backend.OAuthCodeOptions{
+		RedirectURI:   claims.RedirectURI,
+		Scope:         claims.Scope,
+		CodeChallenge: claims.CodeChallenge,
+		Nonce:         claims.Nonce,
+	})
+	if err != nil {
+		logger.Error("error creating authorization code", "app", app.Name, "err", err)
+		renderInternalServerError(w, r)
+		return
+	}
+
+	oauthRedirect(w, r, claims.RedirectURI, url.Values{
+		"code":  {code},
+		"state": {claims.State},
+	})
+}
+
+func renderOAuthError(w http.ResponseWriter, code int, err string, desc string) {
+	w.Header().Set("Cache-Control", "no-store")
+	renderAPIJSON(w, code, oauthError{Error: err, Description: desc})
+}
+
+func oauthToken(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	be := backend.FromContext(ctx)
+	cfg := config.FromContext(ctx)
+	logger := log.FromContext(ctx)
+
+	clientID, secret, ok := r.BasicAuth()
+	if !ok {
+		clientID, secret = r.PostFormValue("client_id"), r.PostFormValue("client_secret")
+	}
+	app, err := be.VerifyOAuthClient(ctx, clientID, secret)
+	if err != nil {
+		renderOAuthError(w, http.StatusUnauthorized, "invalid_client", "")
+		return
+	}
+
+	if r.PostFormValue("grant_type") != "authorization_code" {
+		renderOAuthError(w, http.StatusBadRequest, "unsupported_grant_type", "")
+		return
+	}
+
+	redirectURI := r.PostFormValue("redirect_uri")
+	if redirectURI == "" {
+		redirectURI = app.RedirectURI
+	}
+	grant, err := be.ExchangeOAuthCode(ctx, app, r.PostFormValue("code"), redirectURI, r.PostFormValue("code_verifier"))
+	if err != nil {
+		if errors.Is(err, backend.ErrInvalidOAuthGrant) {
+			renderOAuthError(w, http.StatusBadRequest, "invalid_grant", err.Error())
+			return
+		}
+		logger.Error("error exchanging authorization code", "app", app.Name, "err", err)
+		renderOAuthError(w, http.StatusInternalServerError, "server_error", "")
+		return
+	}
+
+	resp := oauthTokenResponse{
+		AccessToken: grant.AccessToken,
+		TokenType:   "bearer",
+		Scope:       grant.Scope,
+	}
+	if backend.HasOAuthScope(grant.Scope, backend.OAuthScopeOpenID) {
+		kp, err := jwk.NewPair(cfg)
+		if err != nil {
+			logger.Error("error loading server key", "err", err)
+			renderOAuthError(w, http.StatusInternalServerError, "server_error", "")
+			return
+		}
+
+		now := time.Now()
+		claims := oauthIDTokenClaims{
+			RegisteredClaims: jwt.RegisteredClaims{
+				Subject:   strconv.FormatInt(grant.User.ID(), 10),
+				Audience:  jwt.ClaimStrings{app.ClientID},
+				Issuer:    oauthIssuer(cfg),
+				IssuedAt:  jwt.NewNumericDate(now),
+				ExpiresAt: jwt.NewNumericDate(now.Add(oauthTokenExpiry)),
+			},
+			Nonce: grant.Nonce,
+		}
+		if backend.HasOAuthScope(grant.Scope, backend.OAuthScopeProfile) {
+			claims.PreferredUsername = grant.User.Username()
+			claims.Name = grant.User.Username()
+		}
+
+		token := jwt.NewWithClaims(jwk.SigningMethod, claims)
+		token.Header["kid"] = kp.JWK().KeyID
+		resp.IDToken, err = token.SignedString(kp.PrivateKey())
+		if err != nil {
+			logger.Error("error signing id token", "err", err)
+			renderOAuthError(w, http.StatusInternalServerError, "server_error", "")
+			return
+		}
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	renderAPIJSON(w, http.StatusOK, resp)
+}
+
+func oauthUserInfo(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	be := backend.FromContext(ctx)
+
+	parts := strings.SplitN(r.Header.Get("Authorization"), " ", 2)
+	if len(parts) != 2 || (!strings.EqualFold(parts[0], "bearer") && !strings.EqualFold(parts[0], "token")) {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		renderOAuthError(w, http.StatusUnauthorized, "invalid_token", "")
+		return
+	}
+
+	user, err := be.UserByAccessToken(ctx, parts[1])
+	if err != nil {
+		w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+		renderOAuthError(w, http.StatusUnauthorized, "invalid_token", "")
+		return
+	}
+
+	renderAPIJSON(w, http.StatusOK, oauthUserInfoResponse{
+		Subject:           strconv.FormatInt(user.ID(), 10),
+		PreferredUsername: user.Username(),
+		Name:              user.Username(),
+	})
+}
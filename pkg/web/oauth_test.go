@@ -0,0 +1,208 @@
+package web
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/keygen"
+	"github.com/charmbracelet/log/v2"
+	"github.com/charmbracelet/soft-serve/pkg/backend"
+	"github.com/charmbracelet/soft-serve/pkg/config"
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/migrate"
+	"github.com/charmbracelet/soft-serve/pkg/jwk"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/store"
+	"github.com/charmbracelet/soft-serve/pkg/store/database"
+	"github.com/golang-jwt/jwt/v5"
+	_ "modernc.org/sqlite" // sqlite driver
+)
+
+func TestOAuthFlow(t *testing.T) {
+	t.Setenv("SOFT_SERVE_DATA_PATH", t.TempDir())
+	cfg := config.DefaultConfig()
+	if err := cfg.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := keygen.New(cfg.SSH.KeyPath, keygen.WithKeyType(keygen.Ed25519), keygen.WithWrite()); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := config.WithContext(context.Background(), cfg)
+	ctx = log.WithContext(ctx, log.New(io.Discard))
+	dbx, err := db.Open(ctx, cfg.DB.Driver, cfg.DB.DataSource)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { dbx.Close() }) // nolint: errcheck
+	if err := migrate.Migrate(ctx, dbx); err != nil {
+		t.Fatal(err)
+	}
+	datastore := database.New(ctx, dbx)
+	be := backend.New(ctx, cfg, dbx, datastore)
+	ctx = db.WithContext(ctx, dbx)
+	ctx = store.WithContext(ctx, datastore)
+	ctx = backend.WithContext(ctx, be)
+
+	user, err := be.CreateUser(ctx, "user1", proto.UserOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	password, err := be.CreateAccessToken(ctx, user, "login", proto.AccessTokenOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	app, secret, err := be.CreateOAuthApp(ctx, "wiki", "https://wiki.example.com/callback")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv := httptest.NewServer(NewRouter(ctx))
+	t.Cleanup(srv.Close)
+	client := &http.Client{
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	do := func(req *http.Request, status int) *http.Response {
+		t.Helper()
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { resp.Body.Close() }) // nolint: errcheck
+		if resp.StatusCode != status {
+			t.Fatalf("%s %s: got status %d, want %d", req.Method, req.URL.Path, resp.StatusCode, status)
+		}
+		return resp
+	}
+	form := func(path string, values url.Values) *http.Request {
+		req, _ := http.NewRequest(http.MethodPost, srv.URL+path, strings.NewReader(values.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		return req
+	}
+
+	verifier := "a-code-verifier-long-enough-to-be-valid-1234567890"
+	sum := sha256.Sum256([]byte(verifier))
+	authorizeURL := srv.URL + "/oauth/authorize?" + url.Values{
+		"response_type":         {"code"},
+		"client_id":             {app.ClientID},
+		"scope":                 {"openid profile read-only"},
+		"state":                 {"xyz"},
+		"nonce":                 {"n0nce"},
+		"code_challenge":        {base64.RawURLEncoding.EncodeToString(sum[:])},
+		"code_challenge_method": {"S256"},
+	}.Encode()
+
+	// Users must sign in.
+	req, _ := http.NewRequest(http.MethodGet, authorizeURL, nil)
+	do(req, http.StatusUnauthorized)
+
+	// The consent page carries the signed request.
+	req, _ = http.NewRequest(http.MethodGet, authorizeURL, nil)
+	req.SetBasicAuth("user1", password)
+	resp := do(req, http.StatusOK)
+	page, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := regexp.MustCompile(`name="consent" value="([^"]+)"`).FindSubmatch(page)
+	if m == nil || !strings.Contains(string(page), "Read your repositories") {
+		t.Fatalf("unexpected consent page: %s", page)
+	}
+	consent := string(m[1])
+
+	// Forged consents are rejected.
+	req = form("/oauth/authorize", url.Values{"consent": {"forged"}, "decision": {"approve"}})
+	req.SetBasicAuth("user1", password)
+	do(req, http.StatusBadRequest)
+
+	// Approving sends the code back to the application.
+	req = form("/oauth/authorize", url.Values{"consent": {consent}, "decision": {"approve"}})
+	req.SetBasicAuth("user1", password)
+	resp = do(req, http.StatusFound)
+	loc, err := url.Parse(resp.Header.Get("Location"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	code := loc.Query().Get("code")
+	if loc.Host != "wiki.example.com" || code == "" || loc.Query().Get("state") != "xyz" {
+		t.Fatalf("unexpected redirect: %s", loc)
+	}
+
+	// The code is only exchanged by the application with the code verifier.
+	exchange := url.Values{"grant_type": {"authorization_code"}, "code": {code}, "code_verifier": {verifier}}
+	req = form("/oauth/token", exchange)
+	req.SetBasicAuth(app.ClientID, "wrong")
+	do(req, http.StatusUnauthorized)
+	req = form("/oauth/token", url.Values{"grant_type": {"authorization_code"}, "code": {code}, "code_verifier": {"wrong"}})
+	req.SetBasicAuth(app.ClientID, secret)
+	do(req, http.StatusBadRequest)
+
+	req = form("/oauth/token", exchange)
+	req.SetBasicAuth(app.ClientID, secret)
+	resp = do(req, http.StatusOK)
+	var tr oauthTokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&tr); err != nil {
+		t.Fatal(err)
+	}
+	if tr.AccessToken == "" || tr.Scope != "openid profile read-only" {
+		t.Fatalf("unexpected token response: %+v", tr)
+	}
+
+	kp, err := jwk.NewPair(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var claims oauthIDTokenClaims
+	if _, err := jwt.ParseWithClaims(tr.IDToken, &claims, func(*jwt.Token) (interface{}, error) {
+		return kp.JWK().Key, nil
+	}, jwt.WithAudience(app.ClientID), jwt.WithIssuer(oauthIssuer(cfg))); err != nil {
+		t.Fatal(err)
+	}
+	if claims.Nonce != "n0nce" || claims.PreferredUsername != "user1" {
+		t.Fatalf("unexpected id token claims: %+v", claims)
+	}
+
+	// Codes can't be used twice.
+	req = form("/oauth/token", exchange)
+	req.SetBasicAuth(app.ClientID, secret)
+	do(req, http.StatusBadRequest)
+
+	// The access token is capped to the scope.
+	tokens, err := be.ListAccessTokens(ctx, user)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tokens) != 2 || tokens[1].Name != "oauth: wiki" || tokens[1].Scope != "read-only" {
+		t.Fatalf("unexpected tokens: %+v", tokens)
+	}
+
+	req, _ = http.NewRequest(http.MethodGet, srv.URL+"/oauth/userinfo", nil)
+	req.Header.Set("Authorization", "Bearer "+tr.AccessToken)
+	resp = do(req, http.StatusOK)
+	var info oauthUserInfoResponse
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		t.Fatal(err)
+	}
+	if info.PreferredUsername != "user1" || info.Subject != claims.Subject {
+		t.Fatalf("unexpected user info: %+v", info)
+	}
+
+	// Deleting the application revokes its tokens.
+	if err := be.DeleteOAuthApp(ctx, "wiki"); err != nil {
+		t.Fatal(err)
+	}
+	req, _ = http.NewRequest(http.MethodGet, srv.URL+"/oauth/userinfo", nil)
+	req.Header.Set("Authorization", "Bearer "+tr.AccessToken)
+	do(req, http.StatusUnauthorized)
+}
@@ -18,6 +18,24 @@ func NewRouter(ctx context.Context) http.Handler {
 	// Health routes
 	HealthController(ctx, router)
 
+	// Admin API routes
+	AdminAPIController(ctx, router)
+
+	// Chat routes
+	ChatOpsController(ctx, router)
+
+	// Issue and merge request pages
+	BrowseController(ctx, router)
+
+	// Repository event streams
+	EventsController(ctx, router)
+
+	// Issue and merge request attachment routes
+	AttachmentsController(ctx, router)
+
+	// OAuth and OpenID Connect provider routes
+	OAuthController(ctx, router)
+
 	// Git routes
 	GitController(ctx, router)
 
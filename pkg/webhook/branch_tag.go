@@ -52,6 +52,7 @@ func NewBranchTagEvent(ctx context.Context, user proto.User, repo proto.Reposito
 				Description: repo.Description(),
 				ProjectName: repo.ProjectName(),
 				Private:     repo.IsPrivate(),
+				Visibility:  string(proto.RepositoryVisibility(repo)),
 				CreatedAt:   repo.CreatedAt(),
 				UpdatedAt:   repo.UpdatedAt(),
 			},
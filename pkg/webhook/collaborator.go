@@ -45,6 +45,7 @@ func NewCollaboratorEvent(ctx context.Context, user proto.User, repo proto.Repos
 				Description: repo.Description(),
 				ProjectName: repo.ProjectName(),
 				Private:     repo.IsPrivate(),
+				Visibility:  string(proto.RepositoryVisibility(repo)),
 				CreatedAt:   repo.CreatedAt(),
 				UpdatedAt:   repo.UpdatedAt(),
 			},
@@ -1,6 +1,15 @@
 package webhook
 
-import "time"
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/config"
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+	"github.com/charmbracelet/soft-serve/pkg/store"
+)
 
 // EventPayload is a webhook event payload.
 type EventPayload interface {
@@ -32,6 +41,70 @@ func (c Common) RepositoryID() int64 {
 	return c.Repository.ID
 }
 
+// newCommon returns the common payload of an event sent by user on repo.
+func newCommon(ctx context.Context, event Event, user proto.User, repo proto.Repository) (Common, error) {
+	c := Common{
+		EventType: event,
+		Repository: Repository{
+			ID:          repo.ID(),
+			Name:        repo.Name(),
+			Description: repo.Description(),
+			ProjectName: repo.ProjectName(),
+			Private:     repo.IsPrivate(),
+			Visibility:  string(proto.RepositoryVisibility(repo)),
+			CreatedAt:   repo.CreatedAt(),
+			UpdatedAt:   repo.UpdatedAt(),
+		},
+	}
+
+	if user != nil {
+		c.Sender = User{
+			ID:       user.ID(),
+			Username: user.Username(),
+		}
+	}
+
+	cfg := config.FromContext(ctx)
+	c.Repository.HTTPURL = repoURL(cfg.HTTP.PublicURL, repo.Name())
+	c.Repository.SSHURL = repoURL(cfg.SSH.PublicURL, repo.Name())
+	c.Repository.GitURL = repoURL(cfg.Git.PublicURL, repo.Name())
+
+	// Find repo owner.
+	dbx := db.FromContext(ctx)
+	datastore := store.FromContext(ctx)
+	owner, err := datastore.GetUserByID(ctx, dbx, repo.UserID())
+	if err != nil {
+		return Common{}, db.WrapError(err)
+	}
+
+	c.Repository.Owner.ID = owner.ID
+	c.Repository.Owner.Username = owner.Username
+	c.Repository.DefaultBranch, _ = getDefaultBranch(repo)
+
+	return c, nil
+}
+
+// userByID returns the event user for the given user ID.
+func userByID(ctx context.Context, id int64) (User, error) {
+	dbx := db.FromContext(ctx)
+	datastore := store.FromContext(ctx)
+	u, err := datastore.GetUserByID(ctx, dbx, id)
+	if err != nil {
+		return User{}, db.WrapError(err)
+	}
+
+	return User{ID: u.ID, Username: u.Username}, nil
+}
+
+// nullTime returns a pointer to the time if it is valid.
+func nullTime(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+
+	return &t.Time
+}
+
 // User represents a user in an event.
 type User struct {
 	// ID is the owner ID.
@@ -54,6 +127,9 @@ type Repository struct {
 	DefaultBranch string `json:"default_branch" url:"default_branch"`
 	// Private is whether the repository is private.
 	Private bool `json:"private" url:"private"`
+	// Visibility is the repository visibility, "public", "internal" or
+	// "private".
+	Visibility string `json:"visibility" url:"visibility"`
 	// Owner is the repository owner.
 	Owner User `json:"owner" url:"owner"`
 	// HTTPURL is the repository HTTP URL.
@@ -26,6 +26,12 @@ const (
 
 	// EventRepositoryVisibilityChange is a repository visibility change event.
 	EventRepositoryVisibilityChange Event = 6
+
+	// EventIssue is an issue open, edit, close, reopen event.
+	EventIssue Event = 7
+
+	// EventMergeRequest is a merge request open, edit, close, reopen, merge event.
+	EventMergeRequest Event = 8
 )
 
 // Events return all events.
@@ -37,6 +43,8 @@ func Events() []Event {
 		EventPush,
 		EventRepository,
 		EventRepositoryVisibilityChange,
+		EventIssue,
+		EventMergeRequest,
 	}
 }
 
@@ -47,6 +55,8 @@ var eventStrings = map[Event]string{
 	EventPush:                       "push",
 	EventRepository:                 "repository",
 	EventRepositoryVisibilityChange: "repository_visibility_change",
+	EventIssue:                      "issue",
+	EventMergeRequest:               "merge_request",
 }
 
 // String returns the string representation of the event.
@@ -61,6 +71,8 @@ var stringEvent = map[string]Event{
 	"push":                         EventPush,
 	"repository":                   EventRepository,
 	"repository_visibility_change": EventRepositoryVisibilityChange,
+	"issue":                        EventIssue,
+	"merge_request":                EventMergeRequest,
 }
 
 // ErrInvalidEvent is returned when the event is invalid.
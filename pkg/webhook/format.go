@@ -0,0 +1,77 @@
+package webhook
+
+import (
+	"encoding"
+	"errors"
+	"strings"
+)
+
+// Format is the payload format of a webhook.
+type Format int8
+
+const (
+	// FormatSoftServe is the native Soft Serve payload format.
+	FormatSoftServe Format = iota
+	// FormatGitHub is a GitHub compatible payload format. Event names, payload
+	// shape, and signature headers follow GitHub webhooks.
+	FormatGitHub
+)
+
+var formatStrings = map[Format]string{
+	FormatSoftServe: "softserve",
+	FormatGitHub:    "github",
+}
+
+// String returns the string representation of the format.
+func (f Format) String() string {
+	return formatStrings[f]
+}
+
+// Formats returns all formats.
+func Formats() []Format {
+	return []Format{
+		FormatSoftServe,
+		FormatGitHub,
+	}
+}
+
+// ErrInvalidFormat is returned when the format is invalid.
+var ErrInvalidFormat = errors.New("invalid format")
+
+// ParseFormat parses a format string and returns the format.
+func ParseFormat(s string) (Format, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	for k, v := range formatStrings {
+		if v == s {
+			return k, nil
+		}
+	}
+
+	return -1, ErrInvalidFormat
+}
+
+var (
+	_ encoding.TextMarshaler   = Format(0)
+	_ encoding.TextUnmarshaler = (*Format)(nil)
+)
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (f *Format) UnmarshalText(text []byte) error {
+	ft, err := ParseFormat(string(text))
+	if err != nil {
+		return err
+	}
+
+	*f = ft
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (f Format) MarshalText() (text []byte, err error) {
+	ft := f.String()
+	if ft == "" {
+		return nil, ErrInvalidFormat
+	}
+
+	return []byte(ft), nil
+}
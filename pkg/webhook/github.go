@@ -0,0 +1,330 @@
+package webhook
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/git"
+)
+
+// githubEvents maps events to GitHub event names.
+var githubEvents = map[Event]string{
+	EventBranchTagCreate:            "create",
+	EventBranchTagDelete:            "delete",
+	EventCollaborator:               "member",
+	EventPush:                       "push",
+	EventRepository:                 "repository",
+	EventRepositoryVisibilityChange: "repository",
+	EventIssue:                      "issues",
+	EventMergeRequest:               "pull_request",
+}
+
+// githubUser is a GitHub user object.
+type githubUser struct {
+	ID    int64  `json:"id"`
+	Login string `json:"login"`
+}
+
+// githubRepository is a GitHub repository object.
+type githubRepository struct {
+	ID            int64      `json:"id"`
+	Name          string     `json:"name"`
+	FullName      string     `json:"full_name"`
+	Private       bool       `json:"private"`
+	Visibility    string     `json:"visibility"`
+	Owner         githubUser `json:"owner"`
+	HTMLURL       string     `json:"html_url"`
+	Description   string     `json:"description"`
+	CloneURL      string     `json:"clone_url"`
+	SSHURL        string     `json:"ssh_url"`
+	GitURL        string     `json:"git_url"`
+	DefaultBranch string     `json:"default_branch"`
+	MasterBranch  string     `json:"master_branch"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// githubCommitAuthor is a GitHub commit author object.
+type githubCommitAuthor struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+// githubCommit is a GitHub push event commit object.
+type githubCommit struct {
+	ID        string             `json:"id"`
+	Distinct  bool               `json:"distinct"`
+	Message   string             `json:"message"`
+	Timestamp time.Time          `json:"timestamp"`
+	Author    githubCommitAuthor `json:"author"`
+	Committer githubCommitAuthor `json:"committer"`
+}
+
+// githubPushEvent is a GitHub push event payload.
+type githubPushEvent struct {
+	Ref        string             `json:"ref"`
+	Before     string             `json:"before"`
+	After      string             `json:"after"`
+	Created    bool               `json:"created"`
+	Deleted    bool               `json:"deleted"`
+	Forced     bool               `json:"forced"`
+	Commits    []githubCommit     `json:"commits"`
+	HeadCommit *githubCommit      `json:"head_commit"`
+	Repository githubRepository   `json:"repository"`
+	Pusher     githubCommitAuthor `json:"pusher"`
+	Sender     githubUser         `json:"sender"`
+}
+
+// githubRefEvent is a GitHub create or delete event payload.
+type githubRefEvent struct {
+	Ref          string           `json:"ref"`
+	RefType      string           `json:"ref_type"`
+	MasterBranch string           `json:"master_branch"`
+	PusherType   string           `json:"pusher_type"`
+	Repository   githubRepository `json:"repository"`
+	Sender       githubUser       `json:"sender"`
+}
+
+// githubMemberEvent is a GitHub member event payload.
+type githubMemberEvent struct {
+	Action     string           `json:"action"`
+	Member     githubUser       `json:"member"`
+	Repository githubRepository `json:"repository"`
+	Sender     githubUser       `json:"sender"`
+}
+
+// githubRepositoryEvent is a GitHub repository event payload.
+type githubRepositoryEvent struct {
+	Action     string           `json:"action"`
+	Repository githubRepository `json:"repository"`
+	Sender     githubUser       `json:"sender"`
+}
+
+// githubIssue is a GitHub issue object.
+type githubIssue struct {
+	ID        int64      `json:"id"`
+	Number    int64      `json:"number"`
+	Title     string     `json:"title"`
+	Body      string     `json:"body"`
+	State     string     `json:"state"`
+	User      githubUser `json:"user"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	ClosedAt  *time.Time `json:"closed_at"`
+}
+
+// githubIssuesEvent is a GitHub issues event payload.
+type githubIssuesEvent struct {
+	Action     string           `json:"action"`
+	Issue      githubIssue      `json:"issue"`
+	Repository githubRepository `json:"repository"`
+	Sender     githubUser       `json:"sender"`
+}
+
+// githubBranch is a GitHub pull request head or base object.
+type githubBranch struct {
+	Label string           `json:"label"`
+	Ref   string           `json:"ref"`
+	Repo  githubRepository `json:"repo"`
+}
+
+// githubPullRequest is a GitHub pull request object.
+type githubPullRequest struct {
+	ID        int64        `json:"id"`
+	Number    int64        `json:"number"`
+	Title     string       `json:"title"`
+	Body      string       `json:"body"`
+	State     string       `json:"state"`
+	Merged    bool         `json:"merged"`
+	User      githubUser   `json:"user"`
+	Head      githubBranch `json:"head"`
+	Base      githubBranch `json:"base"`
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at"`
+	ClosedAt  *time.Time   `json:"closed_at"`
+	MergedAt  *time.Time   `json:"merged_at"`
+}
+
+// githubPullRequestEvent is a GitHub pull_request event payload.
+type githubPullRequestEvent struct {
+	Action      string            `json:"action"`
+	Number      int64             `json:"number"`
+	PullRequest githubPullRequest `json:"pull_request"`
+	Repository  githubRepository  `json:"repository"`
+	Sender      githubUser        `json:"sender"`
+}
+
+func toGitHubUser(u User) githubUser {
+	return githubUser{ID: u.ID, Login: u.Username}
+}
+
+func toGitHubRepository(r Repository) githubRepository {
+	return githubRepository{
+		ID:            r.ID,
+		Name:          r.Name,
+		FullName:      r.Name,
+		Private:       r.Private,
+		Visibility:    r.Visibility,
+		Owner:         toGitHubUser(r.Owner),
+		HTMLURL:       strings.TrimSuffix(r.HTTPURL, ".git"),
+		Description:   r.Description,
+		CloneURL:      r.HTTPURL,
+		SSHURL:        r.SSHURL,
+		GitURL:        r.GitURL,
+		DefaultBranch: r.DefaultBranch,
+		MasterBranch:  r.DefaultBranch,
+		CreatedAt:     r.CreatedAt,
+		UpdatedAt:     r.UpdatedAt,
+	}
+}
+
+// githubPayload converts a payload to its GitHub event name and payload.
+// Payloads that are not Soft Serve events, like raw redelivered bodies, are
+// returned as is.
+func githubPayload(event Event, payload interface{}) (string, interface{}) {
+	name := githubEvents[event]
+	switch p := payload.(type) {
+	case PushEvent:
+		ev := githubPushEvent{
+			Ref:        p.Ref,
+			Before:     p.Before,
+			After:      p.After,
+			Created:    git.IsZeroHash(p.Before),
+			Deleted:    git.IsZeroHash(p.After),
+			Commits:    make([]githubCommit, len(p.Commits)),
+			Repository: toGitHubRepository(p.Repository),
+			Pusher:     githubCommitAuthor{Name: p.Sender.Username},
+			Sender:     toGitHubUser(p.Sender),
+		}
+		for i, c := range p.Commits {
+			ev.Commits[i] = githubCommit{
+				ID:        c.ID,
+				Distinct:  true,
+				Message:   c.Message,
+				Timestamp: c.Timestamp,
+				Author:    githubCommitAuthor{Name: c.Author.Name, Email: c.Author.Email},
+				Committer: githubCommitAuthor{Name: c.Committer.Name, Email: c.Committer.Email},
+			}
+		}
+		if len(ev.Commits) > 0 {
+			// Soft Serve lists commits newest first, GitHub oldest first.
+			for i, j := 0, len(ev.Commits)-1; i < j; i, j = i+1, j-1 {
+				ev.Commits[i], ev.Commits[j] = ev.Commits[j], ev.Commits[i]
+			}
+			head := ev.Commits[len(ev.Commits)-1]
+			ev.HeadCommit = &head
+		}
+		return name, ev
+	case BranchTagEvent:
+		ref, refType := p.Ref, "branch"
+		if strings.HasPrefix(ref, git.RefsTags) {
+			ref, refType = strings.TrimPrefix(ref, git.RefsTags), "tag"
+		} else {
+			ref = strings.TrimPrefix(ref, git.RefsHeads)
+		}
+		return name, githubRefEvent{
+			Ref:          ref,
+			RefType:      refType,
+			MasterBranch: p.Repository.DefaultBranch,
+			PusherType:   "user",
+			Repository:   toGitHubRepository(p.Repository),
+			Sender:       toGitHubUser(p.Sender),
+		}
+	case CollaboratorEvent:
+		return name, githubMemberEvent{
+			Action:     string(p.Action),
+			Member:     toGitHubUser(p.Collaborator),
+			Repository: toGitHubRepository(p.Repository),
+			Sender:     toGitHubUser(p.Sender),
+		}
+	case RepositoryEvent:
+		action := "edited"
+		switch p.Action {
+		case RepositoryEventActionDelete:
+			action = "deleted"
+		case RepositoryEventActionRename:
+			action = "renamed"
+		case RepositoryEventActionVisibilityChange:
+			action = "publicized"
+			if p.Repository.Private {
+				action = "privatized"
+			}
+		case RepositoryEventActionDefaultBranchChange:
+		}
+		return name, githubRepositoryEvent{
+			Action:     action,
+			Repository: toGitHubRepository(p.Repository),
+			Sender:     toGitHubUser(p.Sender),
+		}
+	case IssueEvent:
+		return name, githubIssuesEvent{
+			Action: string(p.Action),
+			Issue: githubIssue{
+				ID:        p.Issue.ID,
+				Number:    p.Issue.Number,
+				Title:     p.Issue.Title,
+				Body:      p.Issue.Description,
+				State:     p.Issue.State,
+				User:      toGitHubUser(p.Issue.Author),
+				CreatedAt: p.Issue.CreatedAt,
+				UpdatedAt: p.Issue.UpdatedAt,
+				ClosedAt:  p.Issue.ClosedAt,
+			},
+			Repository: toGitHubRepository(p.Repository),
+			Sender:     toGitHubUser(p.Sender),
+		}
+	case MergeRequestEvent:
+		repo := toGitHubRepository(p.Repository)
+		mr := p.MergeRequest
+		// GitHub pull requests are either open or closed, merged ones are
+		// closed with merged set.
+		action, state := string(p.Action), "open"
+		if mr.State != "open" {
+			state = "closed"
+		}
+		closedAt := mr.ClosedAt
+		if p.Action == MergeRequestEventActionMerged {
+			action = "closed"
+		}
+		if closedAt == nil {
+			closedAt = mr.MergedAt
+		}
+		return name, githubPullRequestEvent{
+			Action: action,
+			Number: mr.ID,
+			PullRequest: githubPullRequest{
+				ID:        mr.ID,
+				Number:    mr.ID,
+				Title:     mr.Title,
+				Body:      mr.Description,
+				State:     state,
+				Merged:    mr.MergedAt != nil,
+				User:      toGitHubUser(mr.Author),
+				Head:      githubBranch{Label: mr.SourceBranch, Ref: mr.SourceBranch, Repo: repo},
+				Base:      githubBranch{Label: mr.TargetBranch, Ref: mr.TargetBranch, Repo: repo},
+				CreatedAt: mr.CreatedAt,
+				UpdatedAt: mr.UpdatedAt,
+				ClosedAt:  closedAt,
+				MergedAt:  mr.MergedAt,
+			},
+			Repository: repo,
+			Sender:     toGitHubUser(p.Sender),
+		}
+	}
+
+	return name, payload
+}
+
+// githubFormBody returns the form encoded body GitHub sends for the
+// application/x-www-form-urlencoded content type, a single payload field
+// holding the JSON payload.
+func githubFormBody(payload interface{}) (string, error) {
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+
+	return "payload=" + url.QueryEscape(string(b)), nil
+}
@@ -0,0 +1,136 @@
+package webhook
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/git"
+)
+
+func TestParseFormat(t *testing.T) {
+	for _, f := range Formats() {
+		got, err := ParseFormat(f.String())
+		if err != nil {
+			t.Fatalf("ParseFormat(%q): %v", f, err)
+		}
+		if got != f {
+			t.Errorf("ParseFormat(%q) = %v, want %v", f, got, f)
+		}
+	}
+
+	if _, err := ParseFormat("gitlab"); err != ErrInvalidFormat {
+		t.Errorf("ParseFormat(gitlab) error = %v, want %v", err, ErrInvalidFormat)
+	}
+}
+
+func TestGitHubPayload(t *testing.T) {
+	repo := Repository{
+		ID:            1,
+		Name:          "repo",
+		DefaultBranch: "main",
+		HTTPURL:       "https://example.com/repo.git",
+		Owner:         User{ID: 1, Username: "owner"},
+	}
+	sender := User{ID: 2, Username: "sender"}
+	common := func(e Event) Common {
+		return Common{EventType: e, Repository: repo, Sender: sender}
+	}
+
+	t.Run("push", func(t *testing.T) {
+		name, p := githubPayload(EventPush, PushEvent{
+			Common: common(EventPush),
+			Ref:    git.RefsHeads + "main",
+			Before: git.ZeroID,
+			After:  "b",
+			Commits: []Commit{
+				{ID: "b", Message: "second"},
+				{ID: "a", Message: "first"},
+			},
+		})
+		if name != "push" {
+			t.Errorf("name = %q, want push", name)
+		}
+		ev := p.(githubPushEvent)
+		if !ev.Created || ev.Deleted {
+			t.Errorf("created = %v, deleted = %v", ev.Created, ev.Deleted)
+		}
+		if ev.Commits[0].ID != "a" || ev.HeadCommit.ID != "b" {
+			t.Errorf("commits = %v, head = %v, want oldest first", ev.Commits, ev.HeadCommit)
+		}
+		if ev.Repository.HTMLURL != "https://example.com/repo" || ev.Sender.Login != "sender" {
+			t.Errorf("unexpected repository or sender: %+v %+v", ev.Repository, ev.Sender)
+		}
+	})
+
+	t.Run("tag delete", func(t *testing.T) {
+		name, p := githubPayload(EventBranchTagDelete, BranchTagEvent{
+			Common:  common(EventBranchTagDelete),
+			Ref:     git.RefsTags + "v1.0.0",
+			Deleted: true,
+		})
+		ev := p.(githubRefEvent)
+		if name != "delete" || ev.Ref != "v1.0.0" || ev.RefType != "tag" {
+			t.Errorf("got %q %+v", name, ev)
+		}
+	})
+
+	t.Run("visibility", func(t *testing.T) {
+		c := common(EventRepositoryVisibilityChange)
+		c.Repository.Private = true
+		name, p := githubPayload(EventRepositoryVisibilityChange, RepositoryEvent{
+			Common: c,
+			Action: RepositoryEventActionVisibilityChange,
+		})
+		if ev := p.(githubRepositoryEvent); name != "repository" || ev.Action != "privatized" {
+			t.Errorf("got %q %+v", name, ev)
+		}
+	})
+
+	t.Run("merged merge request", func(t *testing.T) {
+		now := time.Now()
+		name, p := githubPayload(EventMergeRequest, MergeRequestEvent{
+			Common: common(EventMergeRequest),
+			Action: MergeRequestEventActionMerged,
+			MergeRequest: MergeRequest{
+				ID:           3,
+				State:        "merged",
+				SourceBranch: "feature",
+				TargetBranch: "main",
+				MergedAt:     &now,
+			},
+		})
+		ev := p.(githubPullRequestEvent)
+		if name != "pull_request" || ev.Action != "closed" || ev.Number != 3 {
+			t.Errorf("got %q %+v", name, ev)
+		}
+		pr := ev.PullRequest
+		if pr.State != "closed" || !pr.Merged || pr.ClosedAt == nil || pr.Head.Ref != "feature" || pr.Base.Ref != "main" {
+			t.Errorf("unexpected pull request %+v", pr)
+		}
+	})
+
+	t.Run("raw", func(t *testing.T) {
+		raw := json.RawMessage(`{"action":"opened"}`)
+		name, p := githubPayload(EventIssue, raw)
+		if name != "issues" || string(p.(json.RawMessage)) != string(raw) {
+			t.Errorf("got %q %s", name, p)
+		}
+	})
+}
+
+func TestGitHubFormBody(t *testing.T) {
+	body, err := githubFormBody(map[string]string{"action": "opened"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v, err := url.ParseQuery(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := v.Get("payload"); got != `{"action":"opened"}` {
+		t.Errorf("payload = %q", got)
+	}
+}
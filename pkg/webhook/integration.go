@@ -0,0 +1,236 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"text/template"
+
+	"github.com/charmbracelet/soft-serve/git"
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/store"
+	"github.com/charmbracelet/soft-serve/pkg/version"
+	"github.com/google/uuid"
+)
+
+// IntegrationKind is the chat service of an integration.
+type IntegrationKind int8
+
+const (
+	// IntegrationSlack posts to a Slack incoming webhook URL.
+	IntegrationSlack IntegrationKind = iota
+	// IntegrationDiscord posts to a Discord webhook URL.
+	IntegrationDiscord
+	// IntegrationMatrix sends to a Matrix room. The URL is the room message
+	// endpoint, e.g.
+	// https://matrix.org/_matrix/client/v3/rooms/!room:matrix.org/send/m.room.message
+	// and the secret is the access token.
+	IntegrationMatrix
+)
+
+var integrationKindStrings = map[IntegrationKind]string{
+	IntegrationSlack:   "slack",
+	IntegrationDiscord: "discord",
+	IntegrationMatrix:  "matrix",
+}
+
+// String returns the string representation of the integration kind.
+func (k IntegrationKind) String() string {
+	return integrationKindStrings[k]
+}
+
+// IntegrationKinds returns all integration kinds.
+func IntegrationKinds() []IntegrationKind {
+	return []IntegrationKind{
+		IntegrationSlack,
+		IntegrationDiscord,
+		IntegrationMatrix,
+	}
+}
+
+// ErrInvalidIntegrationKind is returned when the integration kind is invalid.
+var ErrInvalidIntegrationKind = errors.New("invalid integration kind")
+
+// ParseIntegrationKind parses an integration kind string and returns the
+// integration kind.
+func ParseIntegrationKind(s string) (IntegrationKind, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	for k, v := range integrationKindStrings {
+		if v == s {
+			return k, nil
+		}
+	}
+
+	return -1, ErrInvalidIntegrationKind
+}
+
+var (
+	_ encoding.TextMarshaler   = IntegrationKind(0)
+	_ encoding.TextUnmarshaler = (*IntegrationKind)(nil)
+)
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (k *IntegrationKind) UnmarshalText(text []byte) error {
+	kind, err := ParseIntegrationKind(string(text))
+	if err != nil {
+		return err
+	}
+
+	*k = kind
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (k IntegrationKind) MarshalText() (text []byte, err error) {
+	kind := k.String()
+	if kind == "" {
+		return nil, ErrInvalidIntegrationKind
+	}
+
+	return []byte(kind), nil
+}
+
+// Integration is a repository chat notification integration.
+type Integration struct {
+	models.Integration
+	Kind   IntegrationKind
+	Events []Event
+}
+
+// defaultTemplates are the message templates used when an integration has no
+// template.
+var defaultTemplates = map[Event]string{
+	EventBranchTagCreate:            `[{{.Repository.Name}}] {{.Sender.Username}} created {{ref .Ref}}`,
+	EventBranchTagDelete:            `[{{.Repository.Name}}] {{.Sender.Username}} deleted {{ref .Ref}}`,
+	EventCollaborator:               `[{{.Repository.Name}}] {{.Sender.Username}} {{.Action}} collaborator {{.Collaborator.Username}}`,
+	EventPush:                       `[{{.Repository.Name}}] {{.Sender.Username}} pushed {{len .Commits}} commit(s) to {{ref .Ref}}{{range .Commits}}` + "\n" + `• {{short .ID}} {{.Title}}{{end}}`,
+	EventRepository:                 `[{{.Repository.Name}}] {{.Sender.Username}}: repository {{.Action}}`,
+	EventRepositoryVisibilityChange: `[{{.Repository.Name}}] {{.Sender.Username}} made the repository {{.Repository.Visibility}}`,
+	EventIssue:                      `[{{.Repository.Name}}] {{.Sender.Username}} {{.Action}} issue #{{.Issue.Number}}: {{.Issue.Title}}`,
+	EventMergeRequest:               `[{{.Repository.Name}}] {{.Sender.Username}} {{.Action}} merge request #{{.MergeRequest.ID}}: {{.MergeRequest.Title}} ({{.MergeRequest.SourceBranch}} → {{.MergeRequest.TargetBranch}})`,
+}
+
+var templateFuncs = template.FuncMap{
+	// ref returns the short name of a branch or tag reference.
+	"ref": func(ref string) string {
+		return strings.TrimPrefix(strings.TrimPrefix(ref, git.RefsHeads), git.RefsTags)
+	},
+	// short returns the abbreviated commit hash.
+	"short": func(id string) string {
+		if len(id) > 7 {
+			return id[:7]
+		}
+		return id
+	},
+}
+
+// ParseTemplate parses an integration message template.
+func ParseTemplate(text string) (*template.Template, error) {
+	return template.New("message").Funcs(templateFuncs).Parse(text) //nolint:wrapcheck
+}
+
+// RenderMessage renders the message for an event payload using the given
+// template, or the default template of the event if text is empty.
+func RenderMessage(text string, event Event, payload interface{}) (string, error) {
+	if text == "" {
+		text = defaultTemplates[event]
+	}
+
+	tmpl, err := ParseTemplate(text)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, payload); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+// integrationBody returns the request body posting message to kind.
+func integrationBody(kind IntegrationKind, message string) ([]byte, error) {
+	var body interface{}
+	switch kind {
+	case IntegrationSlack:
+		body = map[string]string{"text": message}
+	case IntegrationDiscord:
+		body = map[string]string{"content": message}
+	case IntegrationMatrix:
+		body = map[string]string{"msgtype": "m.text", "body": message}
+	default:
+		return nil, ErrInvalidIntegrationKind
+	}
+
+	return json.Marshal(body) //nolint:wrapcheck
+}
+
+// SendIntegration posts an event notification to an integration.
+func SendIntegration(ctx context.Context, i models.Integration, event Event, payload interface{}) error {
+	kind := IntegrationKind(i.Kind) //nolint:gosec
+	message, err := RenderMessage(i.Template, event, payload)
+	if err != nil {
+		return fmt.Errorf("render message: %w", err)
+	}
+
+	body, err := integrationBody(kind, message)
+	if err != nil {
+		return err
+	}
+
+	headers := http.Header{}
+	headers.Add("Content-Type", "application/json")
+	headers.Add("User-Agent", "SoftServe/"+version.Version)
+
+	method, url := http.MethodPost, i.URL
+	if kind == IntegrationMatrix {
+		// Matrix requires a unique transaction ID for each message.
+		txnID, err := uuid.NewUUID()
+		if err != nil {
+			return err
+		}
+		method, url = http.MethodPut, strings.TrimSuffix(url, "/")+"/"+txnID.String()
+		headers.Add("Authorization", "Bearer "+i.Secret)
+	}
+
+	res, err := do(ctx, url, method, headers, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close() // nolint: errcheck
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		b, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+		return fmt.Errorf("%s integration %d: unexpected status %d: %s", kind, i.ID, res.StatusCode, strings.TrimSpace(string(b)))
+	}
+
+	return nil
+}
+
+// sendIntegrations notifies the active integrations of the payload
+// repository that subscribe to the payload event.
+func sendIntegrations(ctx context.Context, payload EventPayload) error {
+	dbx := db.FromContext(ctx)
+	datastore := store.FromContext(ctx)
+	integrations, err := datastore.GetActiveIntegrationsByRepoIDWhereEvent(ctx, dbx, payload.RepositoryID(), []int{int(payload.Event())})
+	if err != nil {
+		return db.WrapError(err)
+	}
+
+	var errs []error
+	for _, i := range integrations {
+		if err := SendIntegration(ctx, i, payload.Event(), payload); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
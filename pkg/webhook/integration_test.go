@@ -0,0 +1,134 @@
+package webhook
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseIntegrationKind(t *testing.T) {
+	for _, k := range IntegrationKinds() {
+		got, err := ParseIntegrationKind(k.String())
+		if err != nil {
+			t.Fatalf("ParseIntegrationKind(%q): %v", k, err)
+		}
+		if got != k {
+			t.Errorf("ParseIntegrationKind(%q) = %v, want %v", k, got, k)
+		}
+	}
+
+	if _, err := ParseIntegrationKind("irc"); err != ErrInvalidIntegrationKind {
+		t.Errorf("ParseIntegrationKind(irc) error = %v, want %v", err, ErrInvalidIntegrationKind)
+	}
+}
+
+func TestDefaultTemplates(t *testing.T) {
+	for _, e := range Events() {
+		if _, ok := defaultTemplates[e]; !ok {
+			t.Errorf("missing default template for event %q", e)
+		}
+	}
+}
+
+func TestRenderMessage(t *testing.T) {
+	common := func(e Event) Common {
+		return Common{
+			EventType:  e,
+			Repository: Repository{ID: 1, Name: "repo"},
+			Sender:     User{ID: 2, Username: "sender"},
+		}
+	}
+
+	cases := []struct {
+		name     string
+		template string
+		event    Event
+		payload  interface{}
+		want     string
+	}{
+		{
+			name:  "push",
+			event: EventPush,
+			payload: PushEvent{
+				Common: common(EventPush),
+				Ref:    "refs/heads/main",
+				Commits: []Commit{
+					{ID: "0123456789abcdef", Title: "Second"},
+					{ID: "fedcba9876543210", Title: "First"},
+				},
+			},
+			want: "[repo] sender pushed 2 commit(s) to main\n• 0123456 Second\n• fedcba9 First",
+		},
+		{
+			name:  "tag create",
+			event: EventBranchTagCreate,
+			payload: BranchTagEvent{
+				Common: common(EventBranchTagCreate),
+				Ref:    "refs/tags/v1.0.0",
+			},
+			want: "[repo] sender created v1.0.0",
+		},
+		{
+			name:  "merge request",
+			event: EventMergeRequest,
+			payload: MergeRequestEvent{
+				Common: common(EventMergeRequest),
+				Action: MergeRequestEventActionOpened,
+				MergeRequest: MergeRequest{
+					ID:           3,
+					Title:        "Add feature",
+					SourceBranch: "feature",
+					TargetBranch: "main",
+				},
+			},
+			want: "[repo] sender opened merge request #3: Add feature (feature → main)",
+		},
+		{
+			name:     "custom template",
+			template: `{{.Sender.Username}} {{.Action}} #{{.Issue.ID}} in {{.Repository.Name}}`,
+			event:    EventIssue,
+			payload: IssueEvent{
+				Common: common(EventIssue),
+				Action: IssueEventActionClosed,
+				Issue:  Issue{ID: 7, Title: "Bug"},
+			},
+			want: "sender closed #7 in repo",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := RenderMessage(c.template, c.event, c.payload)
+			if err != nil {
+				t.Fatalf("RenderMessage: %v", err)
+			}
+			if got != c.want {
+				t.Errorf("RenderMessage = %q, want %q", got, c.want)
+			}
+		})
+	}
+
+	if _, err := RenderMessage("{{.Missing", EventPush, PushEvent{}); err == nil {
+		t.Error("expected error for invalid template")
+	}
+}
+
+func TestIntegrationBody(t *testing.T) {
+	cases := map[IntegrationKind]string{
+		IntegrationSlack:   `{"text":"hello"}`,
+		IntegrationDiscord: `{"content":"hello"}`,
+		IntegrationMatrix:  `{"body":"hello","msgtype":"m.text"}`,
+	}
+	for kind, want := range cases {
+		b, err := integrationBody(kind, "hello")
+		if err != nil {
+			t.Fatalf("integrationBody(%s): %v", kind, err)
+		}
+		if !json.Valid(b) || string(b) != want {
+			t.Errorf("integrationBody(%s) = %s, want %s", kind, b, want)
+		}
+	}
+
+	if _, err := integrationBody(IntegrationKind(-1), "hello"); err != ErrInvalidIntegrationKind {
+		t.Errorf("integrationBody(-1) error = %v, want %v", err, ErrInvalidIntegrationKind)
+	}
+}
@@ -0,0 +1,105 @@
+package webhook
+
+import (
+	"context"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/config"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+)
+
+// IssueEvent is an issue event.
+type IssueEvent struct {
+	Common
+
+	// Action is the issue event action.
+	Action IssueEventAction `json:"action" url:"action"`
+	// Issue is the issue.
+	Issue Issue `json:"issue" url:"issue"`
+}
+
+// IssueEventAction is an issue event action.
+type IssueEventAction string
+
+const (
+	// IssueEventActionOpened is an issue opened event.
+	IssueEventActionOpened IssueEventAction = "opened"
+	// IssueEventActionEdited is an issue edited event.
+	IssueEventActionEdited IssueEventAction = "edited"
+	// IssueEventActionClosed is an issue closed event.
+	IssueEventActionClosed IssueEventAction = "closed"
+	// IssueEventActionReopened is an issue reopened event.
+	IssueEventActionReopened IssueEventAction = "reopened"
+	// IssueEventActionStale is an issue marked stale event.
+	IssueEventActionStale IssueEventAction = "stale"
+	// IssueEventActionSLABreached is an issue missed its response time SLA
+	// event.
+	IssueEventActionSLABreached IssueEventAction = "sla_breached"
+	// IssueEventActionLabeled is an issue labels attached event.
+	IssueEventActionLabeled IssueEventAction = "labeled"
+	// IssueEventActionUnlabeled is an issue labels detached event.
+	IssueEventActionUnlabeled IssueEventAction = "unlabeled"
+)
+
+// Issue represents an issue in an event.
+type Issue struct {
+	// ID is the issue ID.
+	ID int64 `json:"id" url:"id"`
+	// Number is the issue number in its repository.
+	Number int64 `json:"number" url:"number"`
+	// Title is the issue title.
+	Title string `json:"title" url:"title"`
+	// Description is the issue description.
+	Description string `json:"description" url:"description"`
+	// State is the issue state.
+	State string `json:"state" url:"state"`
+	// Author is the issue author.
+	Author User `json:"author" url:"author"`
+	// CreatedAt is the issue creation time.
+	CreatedAt time.Time `json:"created_at" url:"created_at"`
+	// UpdatedAt is the issue last update time.
+	UpdatedAt time.Time `json:"updated_at" url:"updated_at"`
+	// ClosedAt is the issue close time.
+	ClosedAt *time.Time `json:"closed_at,omitempty" url:"closed_at,omitempty"`
+	// ClosedByCommit is the SHA of the commit that closed the issue, if a
+	// pushed commit message closed it.
+	ClosedByCommit string `json:"closed_by_commit,omitempty" url:"closed_by_commit,omitempty"`
+	// CloseReason is why the issue was closed: completed, not-planned, or
+	// duplicate.
+	CloseReason string `json:"close_reason,omitempty" url:"close_reason,omitempty"`
+	// URL is the web URL of the issue, if the web interface is enabled.
+	URL string `json:"url,omitempty" url:"url,omitempty"`
+}
+
+// NewIssueEvent sends an issue event.
+func NewIssueEvent(ctx context.Context, user proto.User, repo proto.Repository, issue models.Issue, action IssueEventAction) (IssueEvent, error) {
+	common, err := newCommon(ctx, EventIssue, user, repo)
+	if err != nil {
+		return IssueEvent{}, err
+	}
+
+	author, err := userByID(ctx, issue.AuthorID)
+	if err != nil {
+		return IssueEvent{}, err
+	}
+
+	return IssueEvent{
+		Common: common,
+		Action: action,
+		Issue: Issue{
+			ID:             issue.ID,
+			Number:         issue.Number,
+			Title:          issue.Title,
+			Description:    issue.Description,
+			State:          issue.State.String(),
+			Author:         author,
+			CreatedAt:      issue.CreatedAt,
+			UpdatedAt:      issue.UpdatedAt,
+			ClosedAt:       nullTime(issue.ClosedAt),
+			ClosedByCommit: issue.ClosedByCommit.String,
+			CloseReason:    string(issue.CloseReason),
+			URL:            config.FromContext(ctx).IssueURL(repo.Name(), issue.Number),
+		},
+	}, nil
+}
@@ -0,0 +1,113 @@
+package webhook
+
+import (
+	"context"
+	"time"
+
+	"github.com/charmbracelet/soft-serve/pkg/config"
+	"github.com/charmbracelet/soft-serve/pkg/db/models"
+	"github.com/charmbracelet/soft-serve/pkg/proto"
+)
+
+// MergeRequestEvent is a merge request event.
+type MergeRequestEvent struct {
+	Common
+
+	// Action is the merge request event action.
+	Action MergeRequestEventAction `json:"action" url:"action"`
+	// MergeRequest is the merge request.
+	MergeRequest MergeRequest `json:"merge_request" url:"merge_request"`
+}
+
+// MergeRequestEventAction is a merge request event action.
+type MergeRequestEventAction string
+
+const (
+	// MergeRequestEventActionOpened is a merge request opened event.
+	MergeRequestEventActionOpened MergeRequestEventAction = "opened"
+	// MergeRequestEventActionEdited is a merge request edited event.
+	MergeRequestEventActionEdited MergeRequestEventAction = "edited"
+	// MergeRequestEventActionClosed is a merge request closed event.
+	MergeRequestEventActionClosed MergeRequestEventAction = "closed"
+	// MergeRequestEventActionReopened is a merge request reopened event.
+	MergeRequestEventActionReopened MergeRequestEventAction = "reopened"
+	// MergeRequestEventActionMerged is a merge request merged event.
+	MergeRequestEventActionMerged MergeRequestEventAction = "merged"
+	// MergeRequestEventActionStale is a merge request marked stale event.
+	MergeRequestEventActionStale MergeRequestEventAction = "stale"
+	// MergeRequestEventActionCommented is a merge request comment event.
+	MergeRequestEventActionCommented MergeRequestEventAction = "commented"
+	// MergeRequestEventActionApproved is a merge request approved event.
+	MergeRequestEventActionApproved MergeRequestEventAction = "approved"
+	// MergeRequestEventActionUnapproved is a merge request approval revoked
+	// event.
+	MergeRequestEventActionUnapproved MergeRequestEventAction = "unapproved"
+	// MergeRequestEventActionDraft is a merge request marked as draft event.
+	MergeRequestEventActionDraft MergeRequestEventAction = "draft"
+	// MergeRequestEventActionReady is a merge request marked as ready event.
+	MergeRequestEventActionReady MergeRequestEventAction = "ready"
+)
+
+// MergeRequest represents a merge request in an event.
+type MergeRequest struct {
+	// ID is the merge request ID.
+	ID int64 `json:"id" url:"id"`
+	// Title is the merge request title.
+	Title string `json:"title" url:"title"`
+	// Description is the merge request description.
+	Description string `json:"description" url:"description"`
+	// SourceBranch is the branch to merge from.
+	SourceBranch string `json:"source_branch" url:"source_branch"`
+	// TargetBranch is the branch to merge into.
+	TargetBranch string `json:"target_branch" url:"target_branch"`
+	// State is the merge request state.
+	State string `json:"state" url:"state"`
+	// Draft is true when the merge request is a work in progress.
+	Draft bool `json:"draft" url:"draft"`
+	// Author is the merge request author.
+	Author User `json:"author" url:"author"`
+	// CreatedAt is the merge request creation time.
+	CreatedAt time.Time `json:"created_at" url:"created_at"`
+	// UpdatedAt is the merge request last update time.
+	UpdatedAt time.Time `json:"updated_at" url:"updated_at"`
+	// MergedAt is the merge request merge time.
+	MergedAt *time.Time `json:"merged_at,omitempty" url:"merged_at,omitempty"`
+	// ClosedAt is the merge request close time.
+	ClosedAt *time.Time `json:"closed_at,omitempty" url:"closed_at,omitempty"`
+	// URL is the web URL of the merge request, if the web interface is
+	// enabled.
+	URL string `json:"url,omitempty" url:"url,omitempty"`
+}
+
+// NewMergeRequestEvent sends a merge request event.
+func NewMergeRequestEvent(ctx context.Context, user proto.User, repo proto.Repository, mr models.MergeRequest, action MergeRequestEventAction) (MergeRequestEvent, error) {
+	common, err := newCommon(ctx, EventMergeRequest, user, repo)
+	if err != nil {
+		return MergeRequestEvent{}, err
+	}
+
+	author, err := userByID(ctx, mr.AuthorID)
+	if err != nil {
+		return MergeRequestEvent{}, err
+	}
+
+	return MergeRequestEvent{
+		Common: common,
+		Action: action,
+		MergeRequest: MergeRequest{
+			ID:           mr.ID,
+			Title:        mr.Title,
+			Description:  mr.Description,
+			SourceBranch: mr.SourceBranch,
+			TargetBranch: mr.TargetBranch,
+			State:        mr.State.String(),
+			Draft:        mr.Draft,
+			Author:       author,
+			CreatedAt:    mr.CreatedAt,
+			UpdatedAt:    mr.UpdatedAt,
+			MergedAt:     nullTime(mr.MergedAt),
+			ClosedAt:     nullTime(mr.ClosedAt),
+			URL:          config.FromContext(ctx).MergeRequestURL(repo.Name(), mr.ID),
+		},
+	}, nil
+}
@@ -42,6 +42,7 @@ func NewPushEvent(ctx context.Context, user proto.User, repo proto.Repository, r
 				Description: repo.Description(),
 				ProjectName: repo.ProjectName(),
 				Private:     repo.IsPrivate(),
+				Visibility:  string(proto.RepositoryVisibility(repo)),
 				CreatedAt:   repo.CreatedAt(),
 				UpdatedAt:   repo.UpdatedAt(),
 			},
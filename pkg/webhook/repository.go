@@ -51,6 +51,7 @@ func NewRepositoryEvent(ctx context.Context, user proto.User, repo proto.Reposit
 				Description: repo.Description(),
 				ProjectName: repo.ProjectName(),
 				Private:     repo.IsPrivate(),
+				Visibility:  string(proto.RepositoryVisibility(repo)),
 				CreatedAt:   repo.CreatedAt(),
 				UpdatedAt:   repo.UpdatedAt(),
 			},
@@ -2,11 +2,13 @@ package webhook
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
 
+	"github.com/charmbracelet/soft-serve/pkg/config"
 	"github.com/charmbracelet/soft-serve/pkg/db/models"
 )
 
@@ -117,7 +119,7 @@ func TestSecureHTTPClientBlocksRedirects(t *testing.T) {
 
 // TestDialContextBlocksPrivateIPs tests the DialContext function directly.
 func TestDialContextBlocksPrivateIPs(t *testing.T) {
-	transport := secureHTTPClient.Transport.(*http.Transport)
+	transport := secureHTTPClient.Transport.(*secureTransport).base
 
 	tests := []struct {
 		name    string
@@ -155,6 +157,34 @@ func TestDialContextBlocksPrivateIPs(t *testing.T) {
 	}
 }
 
+// TestProxyIsTrusted tests that requests go through a proxy on a private
+// address, and that their URLs are validated instead.
+func TestProxyIsTrusted(t *testing.T) {
+	var proxied []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = append(proxied, r.URL.String())
+	}))
+	defer proxy.Close()
+
+	client := &http.Client{Transport: newSecureTransport(config.OutboundConfig{
+		HTTPProxy: proxy.URL,
+	}.Proxy(), nil)}
+
+	resp, err := client.Get("http://8.8.8.8/hook")
+	if err != nil {
+		t.Fatalf("Request through proxy failed: %v", err)
+	}
+	resp.Body.Close()
+
+	if _, err := client.Get("http://10.0.0.1/hook"); !errors.Is(err, ErrPrivateIP) {
+		t.Errorf("Expected private IP error through proxy, got: %v", err)
+	}
+
+	if len(proxied) != 1 || proxied[0] != "http://8.8.8.8/hook" {
+		t.Errorf("Unexpected proxied requests: %v", proxied)
+	}
+}
+
 // sendWebhookWithContext is a test helper that doesn't require database.
 func sendWebhookWithContext(ctx context.Context, w models.Webhook, _ Event, _ any) error {
 	// This is a simplified version for testing that just attempts the HTTP connection
@@ -0,0 +1,36 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/charmbracelet/soft-serve/pkg/db"
+	"github.com/charmbracelet/soft-serve/pkg/store"
+)
+
+// MaxRecordedEvents is the number of latest events kept per repository for
+// its event stream.
+const MaxRecordedEvents = 1000
+
+// recordEvent records payload for the event stream of its repository, and
+// drops the events past MaxRecordedEvents. Deleted repositories have no
+// stream left to record to.
+func recordEvent(ctx context.Context, payload EventPayload) error {
+	if e, ok := payload.(RepositoryEvent); ok && e.Action == RepositoryEventActionDelete {
+		return nil
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	dbx := db.FromContext(ctx)
+	datastore := store.FromContext(ctx)
+	return db.WrapError(dbx.TransactionContext(ctx, func(tx *db.Tx) error {
+		if _, err := datastore.CreateRepoEvent(ctx, tx, payload.RepositoryID(), int(payload.Event()), string(data)); err != nil {
+			return err
+		}
+		return datastore.PruneRepoEvents(ctx, tx, payload.RepositoryID(), MaxRecordedEvents)
+	}))
+}
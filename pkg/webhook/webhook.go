@@ -4,7 +4,9 @@ import (
 	"bytes"
 	"context"
 	"crypto/hmac"
+	"crypto/sha1" //nolint:gosec
 	"crypto/sha256"
+	"crypto/tls"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
@@ -12,9 +14,13 @@ import (
 	"io"
 	"net"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/charmbracelet/soft-serve/git"
+	"github.com/charmbracelet/soft-serve/pkg/config"
 	"github.com/charmbracelet/soft-serve/pkg/db"
 	"github.com/charmbracelet/soft-serve/pkg/db/models"
 	"github.com/charmbracelet/soft-serve/pkg/proto"
@@ -29,9 +35,14 @@ import (
 type Hook struct {
 	models.Webhook
 	ContentType ContentType
+	Format      Format
 	Events      []Event
 }
 
+// FailureThreshold is the number of consecutive failed deliveries after which
+// a webhook is failing and the repository admins are notified.
+const FailureThreshold = 5
+
 // Delivery is a webhook delivery.
 type Delivery struct {
 	models.WebhookDelivery
@@ -40,39 +51,109 @@ type Delivery struct {
 
 // secureHTTPClient creates an HTTP client with SSRF protection.
 var secureHTTPClient = &http.Client{
-	Timeout: 30 * time.Second,
-	Transport: &http.Transport{
-		DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
-			// Parse the address to get the IP
-			host, _, err := net.SplitHostPort(addr)
-			if err != nil {
-				return nil, err //nolint:wrapcheck
-			}
+	Timeout:   30 * time.Second,
+	Transport: newSecureTransport(nil, nil),
+	// Don't follow redirects to prevent bypassing IP validation
+	CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	},
+}
+
+// secureTransport refuses to connect to private and internal addresses.
+// Requests through a proxy are validated before they are sent instead, since
+// the proxy resolves and connects to the host.
+type secureTransport struct {
+	proxy func(*http.Request) (*url.URL, error)
+	base  *http.Transport
+}
+
+// newSecureTransport returns a secure transport using proxy, which may be nil,
+// and tlsConfig.
+func newSecureTransport(proxy func(*http.Request) (*url.URL, error), tlsConfig *tls.Config) *secureTransport {
+	return &secureTransport{
+		proxy: proxy,
+		base: &http.Transport{
+			Proxy: proxy,
+			DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+				// Parse the address to get the IP
+				host, _, err := net.SplitHostPort(addr)
+				if err != nil {
+					return nil, err //nolint:wrapcheck
+				}
+
+				// Validate the resolved IP before connecting, unless
+				// connecting to the proxy.
+				ip := net.ParseIP(host)
+				if proxy, _ := ctx.Value(proxyAddrKey{}).(string); ip != nil && proxy != addr {
+					if err := ValidateIPBeforeDial(ip); err != nil {
+						return nil, fmt.Errorf("blocked connection to private IP: %w", err)
+					}
+				}
 
-			// Validate the resolved IP before connecting
-			ip := net.ParseIP(host)
-			if ip != nil {
-				if err := ValidateIPBeforeDial(ip); err != nil {
-					return nil, fmt.Errorf("blocked connection to private IP: %w", err)
+				// Use standard dialer with timeout
+				dialer := &net.Dialer{
+					Timeout:   10 * time.Second,
+					KeepAlive: 30 * time.Second,
 				}
+				return dialer.DialContext(ctx, network, addr)
+			},
+			TLSClientConfig:       tlsConfig,
+			MaxIdleConns:          100,
+			IdleConnTimeout:       90 * time.Second,
+			TLSHandshakeTimeout:   10 * time.Second,
+			ExpectContinueTimeout: 1 * time.Second,
+		},
+	}
+}
+
+// proxyAddrKey is the context key of the address of the proxy a request is
+// sent through.
+type proxyAddrKey struct{}
+
+// RoundTrip implements http.RoundTripper.
+func (t *secureTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.proxy != nil {
+		proxy, err := t.proxy(req)
+		if err != nil {
+			return nil, err
+		}
+		if proxy != nil {
+			if err := ValidateWebhookURL(req.URL.String()); err != nil {
+				return nil, err
 			}
 
-			// Use standard dialer with timeout
-			dialer := &net.Dialer{
-				Timeout:   10 * time.Second,
-				KeepAlive: 30 * time.Second,
+			addr := proxy.Host
+			if proxy.Port() == "" {
+				port := "80"
+				if proxy.Scheme == "https" {
+					port = "443"
+				}
+				addr = net.JoinHostPort(proxy.Hostname(), port)
 			}
-			return dialer.DialContext(ctx, network, addr)
-		},
-		MaxIdleConns:          100,
-		IdleConnTimeout:       90 * time.Second,
-		TLSHandshakeTimeout:   10 * time.Second,
-		ExpectContinueTimeout: 1 * time.Second,
-	},
-	// Don't follow redirects to prevent bypassing IP validation
-	CheckRedirect: func(*http.Request, []*http.Request) error {
-		return http.ErrUseLastResponse
-	},
+			req = req.WithContext(context.WithValue(req.Context(), proxyAddrKey{}, addr))
+		}
+	}
+
+	return t.base.RoundTrip(req)
+}
+
+// Configure makes webhooks, and the other clients of SecureHTTPClient, use
+// the outbound proxies and TLS configuration. It must be called before
+// sending requests.
+func Configure(cfg config.OutboundConfig) error {
+	tlsConfig, err := cfg.TLSConfig()
+	if err != nil {
+		return err
+	}
+
+	secureHTTPClient.Transport = newSecureTransport(cfg.Proxy(), tlsConfig)
+	return nil
+}
+
+// SecureHTTPClient returns an HTTP client with SSRF protection. It refuses to
+// connect to private and internal addresses and doesn't follow redirects.
+func SecureHTTPClient() *http.Client {
+	return secureHTTPClient
 }
 
 // do sends a webhook.
@@ -95,8 +176,11 @@ func do(ctx context.Context, url string, method string, headers http.Header, bod
 // SendWebhook sends a webhook event.
 func SendWebhook(ctx context.Context, w models.Webhook, event Event, payload interface{}) error {
 	var buf bytes.Buffer
-	dbx := db.FromContext(ctx)
-	datastore := store.FromContext(ctx)
+	format := Format(w.Format) //nolint:gosec
+	var githubEvent string
+	if format == FormatGitHub {
+		githubEvent, payload = githubPayload(event, payload)
+	}
 
 	contentType := ContentType(w.ContentType) //nolint:gosec
 	switch contentType {
@@ -105,6 +189,14 @@ func SendWebhook(ctx context.Context, w models.Webhook, event Event, payload int
 			return err
 		}
 	case ContentTypeForm:
+		if format == FormatGitHub {
+			body, err := githubFormBody(payload)
+			if err != nil {
+				return err
+			}
+			buf.WriteString(body) // nolint: errcheck
+			break
+		}
 		v, err := query.Values(payload)
 		if err != nil {
 			return err
@@ -114,26 +206,55 @@ func SendWebhook(ctx context.Context, w models.Webhook, event Event, payload int
 		return ErrInvalidContentType
 	}
 
-	headers := http.Header{}
-	headers.Add("Content-Type", contentType.String())
-	headers.Add("User-Agent", "SoftServe/"+version.Version)
-	headers.Add("X-SoftServe-Event", event.String())
+	return deliver(ctx, w, event, githubEvent, buf.String())
+}
+
+// Redeliver sends the request body of a previous delivery of w again, as a
+// new delivery. The body is signed with the current secret of w.
+func Redeliver(ctx context.Context, w models.Webhook, d models.WebhookDelivery) error {
+	event := Event(d.Event)
+	return deliver(ctx, w, event, githubEvents[event], d.RequestBody)
+}
+
+// deliver sends an encoded webhook request body and records the delivery.
+func deliver(ctx context.Context, w models.Webhook, event Event, githubEvent string, reqBody string) error {
+	dbx := db.FromContext(ctx)
+	datastore := store.FromContext(ctx)
+	format := Format(w.Format)                //nolint:gosec
+	contentType := ContentType(w.ContentType) //nolint:gosec
 
 	id, err := uuid.NewUUID()
 	if err != nil {
 		return err
 	}
 
+	headers := http.Header{}
+	headers.Add("Content-Type", contentType.String())
+	headers.Add("X-SoftServe-Event", event.String())
 	headers.Add("X-SoftServe-Delivery", id.String())
+	if format == FormatGitHub {
+		headers.Add("User-Agent", "GitHub-Hookshot/SoftServe-"+version.Version)
+		headers.Add("X-GitHub-Event", githubEvent)
+		headers.Add("X-GitHub-Delivery", id.String())
+		headers.Add("X-GitHub-Hook-ID", strconv.FormatInt(w.ID, 10))
+	} else {
+		headers.Add("User-Agent", "SoftServe/"+version.Version)
+	}
 
-	reqBody := buf.String()
 	if w.Secret != "" {
 		sig := hmac.New(sha256.New, []byte(w.Secret))
 		sig.Write([]byte(reqBody)) // nolint: errcheck
-		headers.Add("X-SoftServe-Signature", "sha256="+hex.EncodeToString(sig.Sum(nil)))
+		sig256 := "sha256=" + hex.EncodeToString(sig.Sum(nil))
+		headers.Add("X-SoftServe-Signature", sig256)
+		if format == FormatGitHub {
+			sig1 := hmac.New(sha1.New, []byte(w.Secret))
+			sig1.Write([]byte(reqBody)) // nolint: errcheck
+			headers.Add("X-Hub-Signature-256", sig256)
+			headers.Add("X-Hub-Signature", "sha1="+hex.EncodeToString(sig1.Sum(nil)))
+		}
 	}
 
-	res, reqErr := do(ctx, w.URL, http.MethodPost, headers, &buf)
+	res, reqErr := do(ctx, w.URL, http.MethodPost, headers, strings.NewReader(reqBody))
 	var reqHeaders string
 	for k, v := range headers {
 		reqHeaders += k + ": " + v[0] + "\n"
@@ -160,25 +281,42 @@ func SendWebhook(ctx context.Context, w models.Webhook, event Event, payload int
 		}
 	}
 
-	return db.WrapError(datastore.CreateWebhookDelivery(ctx, dbx, id, w.ID, int(event), w.URL, http.MethodPost, reqErr, reqHeaders, reqBody, resStatus, resHeaders, resBody))
+	return db.WrapError(dbx.TransactionContext(ctx, func(tx *db.Tx) error {
+		if err := datastore.CreateWebhookDelivery(ctx, tx, id, w.ID, int(event), w.URL, http.MethodPost, reqErr, reqHeaders, reqBody, resStatus, resHeaders, resBody); err != nil {
+			return err
+		}
+
+		return datastore.SetWebhookDeliveryResult(ctx, tx, w.ID, Succeeded(resStatus, reqErr))
+	}))
+}
+
+// Succeeded returns true if a delivery with the given response status and
+// request error succeeded.
+func Succeeded(status int, reqErr error) bool {
+	return reqErr == nil && status >= 200 && status < 300
 }
 
-// SendEvent sends a webhook event.
+// SendEvent sends a webhook event, and records it for the event stream of
+// the repository.
 func SendEvent(ctx context.Context, payload EventPayload) error {
+	// Record the event first so failing webhooks don't hide it from the event
+	// stream.
+	recordErr := recordEvent(ctx, payload)
+
 	dbx := db.FromContext(ctx)
 	datastore := store.FromContext(ctx)
 	webhooks, err := datastore.GetWebhooksByRepoIDWhereEvent(ctx, dbx, payload.RepositoryID(), []int{int(payload.Event())})
 	if err != nil {
-		return db.WrapError(err)
+		return errors.Join(recordErr, db.WrapError(err))
 	}
 
 	for _, w := range webhooks {
 		if err := SendWebhook(ctx, w, payload.Event(), payload); err != nil {
-			return err
+			return errors.Join(recordErr, err)
 		}
 	}
 
-	return nil
+	return errors.Join(recordErr, sendIntegrations(ctx, payload))
 }
 
 func repoURL(publicURL string, repo string) string {
@@ -8,15 +8,18 @@ import (
 	"fmt"
 	"io"
 	"math/rand"
+	"mime/multipart"
 	"net"
 	"net/http"
 	"net/url"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
 	"runtime"
 	"strconv"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -24,6 +27,7 @@ import (
 	"github.com/charmbracelet/soft-serve/pkg/config"
 	"github.com/charmbracelet/soft-serve/pkg/db"
 	"github.com/charmbracelet/soft-serve/pkg/test"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/rogpeppe/go-internal/testscript"
 	"github.com/spf13/cobra"
 	"golang.org/x/crypto/ssh"
@@ -97,6 +101,8 @@ func TestScript(t *testing.T) {
 			"envfile":                cmdEnvfile,
 			"readfile":               cmdReadfile,
 			"dos2unix":               cmdDos2Unix,
+			"sleep":                  cmdSleep,
+			"waitfor":                cmdWaitFor("admin", admin1.Signer()),
 			"new-webhook":            cmdNewWebhook,
 			"ensureserverrunning":    cmdEnsureServerRunning,
 			"ensureservernotrunning": cmdEnsureServerNotRunning,
@@ -191,36 +197,61 @@ func TestScript(t *testing.T) {
 
 func cmdSoft(user string, key ssh.Signer) func(ts *testscript.TestScript, neg bool, args []string) {
 	return func(ts *testscript.TestScript, neg bool, args []string) {
-		cli, err := ssh.Dial(
-			"tcp",
-			net.JoinHostPort("localhost", ts.Getenv("SSH_PORT")),
-			&ssh.ClientConfig{
-				User:            user,
-				Auth:            []ssh.AuthMethod{ssh.PublicKeys(key)},
-				HostKeyCallback: ssh.InsecureIgnoreHostKey(),
-			},
-		)
-		ts.Check(err)
-		defer cli.Close()
+		check(ts, runSoft(ts, user, key, args, ts.Stdout(), ts.Stderr()), neg)
+	}
+}
 
-		sess, err := cli.NewSession()
-		ts.Check(err)
-		defer sess.Close()
+// runSoft runs a soft command over SSH as user.
+func runSoft(ts *testscript.TestScript, user string, key ssh.Signer, args []string, stdout, stderr io.Writer) error {
+	cli, err := ssh.Dial(
+		"tcp",
+		net.JoinHostPort("localhost", ts.Getenv("SSH_PORT")),
+		&ssh.ClientConfig{
+			User:            user,
+			Auth:            []ssh.AuthMethod{ssh.PublicKeys(key)},
+			HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+		},
+	)
+	ts.Check(err)
+	defer cli.Close()
 
-		sess.Stdout = ts.Stdout()
-		sess.Stderr = ts.Stderr()
+	sess, err := cli.NewSession()
+	ts.Check(err)
+	defer sess.Close()
 
-		check(ts, sess.Run(strings.Join(args, " ")), neg)
-	}
+	sess.Stdout = stdout
+	sess.Stderr = stderr
+
+	return sess.Run(strings.Join(args, " "))
 }
 
+// cmdUI runs the UI and types the quoted inputs into it. The other arguments
+// are patterns to wait for on the screen before typing the next input, so
+// keys aren't sent before the frame they act on is rendered.
 func cmdUI(key ssh.Signer) func(ts *testscript.TestScript, neg bool, args []string) {
 	return func(ts *testscript.TestScript, neg bool, args []string) {
 		if len(args) < 1 {
-			ts.Fatalf("usage: ui <quoted string input>")
+			ts.Fatalf("usage: ui <quoted string input | pattern>...")
 			return
 		}
 
+		type step struct {
+			input string
+			wait  *regexp.Regexp
+		}
+		steps := make([]step, 0, len(args))
+		for _, arg := range args {
+			if !strings.HasPrefix(arg, `"`) {
+				re, err := regexp.Compile(arg)
+				check(ts, err, false)
+				steps = append(steps, step{wait: re})
+				continue
+			}
+			in, err := strconv.Unquote(arg)
+			check(ts, err, false)
+			steps = append(steps, step{input: in})
+		}
+
 		cli, err := ssh.Dial(
 			"tcp",
 			net.JoinHostPort("localhost", ts.Getenv("SSH_PORT")),
@@ -230,48 +261,271 @@ func cmdUI(key ssh.Signer) func(ts *testscript.TestScript, neg bool, args []stri
 				HostKeyCallback: ssh.InsecureIgnoreHostKey(),
 			},
 		)
-		check(ts, err, neg)
+		check(ts, err, false)
 		defer cli.Close()
 
 		sess, err := cli.NewSession()
-		check(ts, err, neg)
+		check(ts, err, false)
 		defer sess.Close()
 
-		// XXX: this is a hack to make the UI tests work
-		// cmp command always complains about an extra newline
-		// in the output
-		defer ts.Stdout().Write([]byte("\n"))
-
-		sess.Stdout = ts.Stdout()
+		scr := &screen{}
+		sess.Stdout = scr
 		sess.Stderr = ts.Stderr()
 
 		stdin, err := sess.StdinPipe()
-		check(ts, err, neg)
+		check(ts, err, false)
 
 		err = sess.RequestPty("dumb", 40, 80, ssh.TerminalModes{})
-		check(ts, err, neg)
-		check(ts, sess.Start(""), neg)
+		check(ts, err, false)
+		check(ts, sess.Start(""), false)
 
-		in, err := strconv.Unquote(args[0])
-		check(ts, err, neg)
-		reader := strings.NewReader(in)
+		waitErr := make(chan error, 1)
 		go func() {
 			defer stdin.Close()
-			for {
-				r, _, err := reader.ReadRune()
-				if err == io.EOF {
-					break
+			for _, st := range steps {
+				if st.wait != nil {
+					if err := scr.waitFor(st.wait, 10*time.Second); err != nil {
+						waitErr <- err
+						sess.Close() // nolint: errcheck
+						return
+					}
+					continue
 				}
-				check(ts, err, neg)
-				stdin.Write([]byte(string(r))) // nolint: errcheck
+				for _, r := range st.input {
+					stdin.Write([]byte(string(r))) // nolint: errcheck
 
-				// Wait for the UI to process the input
-				time.Sleep(100 * time.Millisecond)
+					// Wait for the UI to process the input
+					time.Sleep(100 * time.Millisecond)
+				}
 			}
+			waitErr <- nil
 		}()
 
-		check(ts, sess.Wait(), neg)
+		err = sess.Wait()
+
+		// The text of the screen, without escape sequences. XXX: this is a
+		// hack to make the UI tests work, cmp command always complains about
+		// an extra newline in the output
+		ts.Stdout().Write([]byte(scr.String() + "\n")) // nolint: errcheck
+
+		check(ts, <-waitErr, false)
+		// Only the UI itself is expected to fail with "! ui".
+		check(ts, err, neg)
+	}
+}
+
+// screen records the output of the UI, and the frames waited for.
+type screen struct {
+	mu     sync.Mutex
+	buf    bytes.Buffer
+	frames []string
+}
+
+func (s *screen) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.buf.Write(p)
+}
+
+// String returns the output of the UI stripped of escape sequences, followed
+// by the frames waited for.
+func (s *screen) String() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return ansi.Strip(s.buf.String()) + "\n" + strings.Join(s.frames, "\n")
+}
+
+// waitFor waits until the screen of the UI matches re.
+func (s *screen) waitFor(re *regexp.Regexp, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		s.mu.Lock()
+		frame := renderScreen(s.buf.String(), 80, 40)
+		if re.MatchString(frame) {
+			s.frames = append(s.frames, frame)
+			s.mu.Unlock()
+			return nil
+		}
+		s.mu.Unlock()
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out waiting for %q in the UI:\n%s", re, frame)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+// renderScreen replays the output of the UI on a width by height terminal,
+// and returns the text on the screen. The renderer only redraws the cells
+// that changed between frames, so a frame can't be read from the output
+// itself.
+func renderScreen(out string, width, height int) string {
+	cells := make([][]string, height)
+	for y := range cells {
+		cells[y] = make([]string, width)
+	}
+	erase := func(y, from, to int) {
+		for x := max(from, 0); x < min(to, width); x++ {
+			cells[y][x] = " "
+		}
+	}
+	for y := range cells {
+		erase(y, 0, width)
+	}
+
+	var x, y int
+	top, bottom := 0, height-1
+	// scroll moves the lines of the scrolling region up n lines, or down if
+	// n is negative.
+	scroll := func(from, n int) {
+		for ; n > 0; n-- {
+			copy(cells[from:bottom+1], cells[from+1:bottom+1])
+			cells[bottom] = make([]string, width)
+			erase(bottom, 0, width)
+		}
+		for ; n < 0; n++ {
+			copy(cells[from+1:bottom+1], cells[from:bottom])
+			cells[from] = make([]string, width)
+			erase(from, 0, width)
+		}
 	}
+	lineFeed := func() {
+		if y == bottom {
+			scroll(top, 1)
+		} else if y < height-1 {
+			y++
+		}
+	}
+
+	p := ansi.NewParser()
+	var state byte
+	var last string
+	for len(out) > 0 {
+		seq, w, n, newState := ansi.DecodeSequence(out, state, p)
+		state = newState
+		out = out[n:]
+
+		param := func(i, def int) int {
+			v, _ := p.Param(i, def)
+			if v == 0 {
+				return def
+			}
+			return v
+		}
+
+		switch {
+		case w > 0:
+			if x+w > width {
+				x = 0
+				lineFeed()
+			}
+			cells[y][x] = seq
+			for i := 1; i < w; i++ {
+				cells[y][x+i] = ""
+			}
+			x += w
+			last = seq
+		case seq == "\r":
+			x = 0
+		case seq == "\n":
+			lineFeed()
+		case seq == "\b":
+			x--
+		case seq == "\t":
+			x = (x/8 + 1) * 8
+		case seq == "\x1bM":
+			if y == top {
+				scroll(top, -1)
+			} else {
+				y--
+			}
+		case ansi.HasCsiPrefix(seq):
+			cmd := ansi.Cmd(p.Command())
+			if cmd.Prefix() != 0 || cmd.Intermediate() != 0 {
+				break
+			}
+			switch cmd.Final() {
+			case 'A':
+				y -= param(0, 1)
+			case 'B':
+				y += param(0, 1)
+			case 'C':
+				x += param(0, 1)
+			case 'D':
+				x -= param(0, 1)
+			case 'E':
+				x, y = 0, y+param(0, 1)
+			case 'F':
+				x, y = 0, y-param(0, 1)
+			case 'G', '`':
+				x = param(0, 1) - 1
+			case 'd':
+				y = param(0, 1) - 1
+			case 'H', 'f':
+				y, x = param(0, 1)-1, param(1, 1)-1
+			case 'J':
+				from, to := 0, height
+				switch param(0, 0) {
+				case 0:
+					erase(y, x, width)
+					from = y + 1
+				case 1:
+					erase(y, 0, x+1)
+					to = y
+				}
+				for i := from; i < to; i++ {
+					erase(i, 0, width)
+				}
+			case 'K':
+				switch param(0, 0) {
+				case 0:
+					erase(y, x, width)
+				case 1:
+					erase(y, 0, x+1)
+				default:
+					erase(y, 0, width)
+				}
+			case 'X':
+				erase(y, x, x+param(0, 1))
+			case 'b':
+				for i := 0; i < param(0, 1) && x < width; i++ {
+					cells[y][x] = last
+					x++
+				}
+			case '@':
+				n := min(param(0, 1), width-x)
+				copy(cells[y][x+n:], cells[y][x:width-n])
+				erase(y, x, x+n)
+			case 'P':
+				n := min(param(0, 1), width-x)
+				copy(cells[y][x:], cells[y][x+n:])
+				erase(y, width-n, width)
+			case 'L':
+				if y >= top && y <= bottom {
+					scroll(y, -param(0, 1))
+				}
+			case 'M':
+				if y >= top && y <= bottom {
+					scroll(y, param(0, 1))
+				}
+			case 'S':
+				scroll(top, param(0, 1))
+			case 'T':
+				scroll(top, -param(0, 1))
+			case 'r':
+				top, bottom = param(0, 1)-1, param(1, height)-1
+				x, y = 0, 0
+			}
+		}
+
+		x = max(0, min(x, width))
+		y = max(0, min(y, height-1))
+	}
+
+	lines := make([]string, height)
+	for i, row := range cells {
+		lines[i] = strings.TrimRight(strings.Join(row, ""), " ")
+	}
+	return strings.Join(lines, "\n")
 }
 
 func cmdDos2Unix(ts *testscript.TestScript, neg bool, args []string) {
@@ -297,6 +551,50 @@ func cmdDos2Unix(ts *testscript.TestScript, neg bool, args []string) {
 	}
 }
 
+// cmdSleep waits for background jobs of the server to run.
+func cmdSleep(ts *testscript.TestScript, neg bool, args []string) {
+	if neg {
+		ts.Fatalf("unsupported: ! sleep")
+	}
+	if len(args) != 1 {
+		ts.Fatalf("usage: sleep duration")
+	}
+	d, err := time.ParseDuration(args[0])
+	ts.Check(err)
+	time.Sleep(d)
+}
+
+// cmdWaitFor runs a soft command until its output matches a pattern, to wait
+// for background jobs of the server without guessing how long they take. The
+// last output is written to stdout.
+func cmdWaitFor(user string, key ssh.Signer) func(ts *testscript.TestScript, neg bool, args []string) {
+	return func(ts *testscript.TestScript, neg bool, args []string) {
+		if neg {
+			ts.Fatalf("unsupported: ! waitfor")
+		}
+		if len(args) < 2 {
+			ts.Fatalf("usage: waitfor pattern command...")
+		}
+		re, err := regexp.Compile(args[0])
+		ts.Check(err)
+
+		var out bytes.Buffer
+		deadline := time.Now().Add(time.Minute)
+		for {
+			out.Reset()
+			err := runSoft(ts, user, key, args[1:], &out, &out)
+			if err == nil && re.Match(out.Bytes()) {
+				break
+			}
+			if time.Now().After(deadline) {
+				ts.Fatalf("timed out waiting for %q in:\n%s", args[0], out.String())
+			}
+			time.Sleep(500 * time.Millisecond)
+		}
+		ts.Stdout().Write(out.Bytes()) // nolint: errcheck
+	}
+}
+
 var sshConfig = `
 Host *
   UserKnownHostsFile %q
@@ -398,6 +696,8 @@ func cmdCurl(ts *testscript.TestScript, neg bool, args []string) {
 	var verbose bool
 	var headers []string
 	var data string
+	var forms []string
+	var maxTime int
 	method := http.MethodGet
 
 	cmd := &cobra.Command{
@@ -409,7 +709,14 @@ func cmdCurl(ts *testscript.TestScript, neg bool, args []string) {
 				return err
 			}
 
-			req, err := http.NewRequest(method, url.String(), nil)
+			ctx := context.Background()
+			if maxTime > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, time.Duration(maxTime)*time.Second)
+				defer cancel()
+			}
+
+			req, err := http.NewRequestWithContext(ctx, method, url.String(), nil)
 			if err != nil {
 				return err
 			}
@@ -418,6 +725,38 @@ func cmdCurl(ts *testscript.TestScript, neg bool, args []string) {
 				req.Body = io.NopCloser(strings.NewReader(data))
 			}
 
+			// Like curl, form fields are sent as a multipart POST request, and
+			// "name=@path" fields upload the file at path.
+			if len(forms) > 0 {
+				var body bytes.Buffer
+				mw := multipart.NewWriter(&body)
+				for _, f := range forms {
+					name, value, ok := strings.Cut(f, "=")
+					if !ok {
+						return fmt.Errorf("invalid form field: %s", f)
+					}
+					if path, ok := strings.CutPrefix(value, "@"); ok {
+						fw, err := mw.CreateFormFile(name, filepath.Base(path))
+						if err != nil {
+							return err
+						}
+						if _, err := io.WriteString(fw, ts.ReadFile(path)); err != nil {
+							return err
+						}
+					} else if err := mw.WriteField(name, value); err != nil {
+						return err
+					}
+				}
+				if err := mw.Close(); err != nil {
+					return err
+				}
+				if !cmd.Flags().Changed("request") {
+					req.Method = http.MethodPost
+				}
+				req.Body = io.NopCloser(&body)
+				req.Header.Set("Content-Type", mw.FormDataContentType())
+			}
+
 			if verbose {
 				fmt.Fprintf(cmd.ErrOrStderr(), "< %s %s\n", req.Method, url.String())
 			}
@@ -459,7 +798,8 @@ func cmdCurl(ts *testscript.TestScript, neg bool, args []string) {
 
 			defer resp.Body.Close()
 			buf, err := io.ReadAll(resp.Body)
-			if err != nil {
+			// Streamed responses end when they reach the max time.
+			if err != nil && (maxTime == 0 || ctx.Err() == nil) {
 				return err
 			}
 
@@ -477,6 +817,8 @@ func cmdCurl(ts *testscript.TestScript, neg bool, args []string) {
 	cmd.Flags().StringArrayVarP(&headers, "header", "H", nil, "HTTP header")
 	cmd.Flags().StringVarP(&method, "request", "X", method, "HTTP method")
 	cmd.Flags().StringVarP(&data, "data", "d", data, "HTTP data")
+	cmd.Flags().StringArrayVarP(&forms, "form", "F", nil, "multipart form field, name=value or name=@file")
+	cmd.Flags().IntVarP(&maxTime, "max-time", "m", maxTime, "maximum time in seconds")
 
 	check(ts, cmd.Execute(), neg)
 }